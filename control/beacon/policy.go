@@ -15,13 +15,19 @@
 package beacon
 
 import (
+	"math"
 	"os"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/ptr"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/path/pathpol"
 )
 
 // PolicyType is the policy type.
@@ -188,6 +194,8 @@ type Policy struct {
 	Filter Filter `yaml:"Filter"`
 	// Type is the policy type.
 	Type PolicyType `yaml:"Type"`
+	// Algorithm is the name of the algorithm that selects the segments among the candidates.
+	Algorithm string `yaml:"Algorithm"`
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -202,6 +210,9 @@ func (p *Policy) InitDefaults() {
 		m := DefaultMaxExpTime
 		p.MaxExpTime = &m
 	}
+	if p.Algorithm == "" {
+		p.Algorithm = DefaultSelection
+	}
 	p.Filter.InitDefaults()
 }
 
@@ -246,6 +257,22 @@ type Filter struct {
 	IsdBlackList []addr.ISD `yaml:"IsdBlackList"`
 	// AllowIsdLoop indicates whether ISD loops should not be filtered.
 	AllowIsdLoop *bool `yaml:"AllowIsdLoop"`
+	// MinHopsLength is the minimum number of hops a segment must have.
+	MinHopsLength int `yaml:"MinHopsLength"`
+	// Sequence is the sequence of hops, from the originating AS to the local AS, that a segment
+	// must match. It has the syntax of the path policy sequences.
+	Sequence *pathpol.Sequence `yaml:"Sequence"`
+	// ACL is the access control list that every hop of a segment must be allowed by. It has the
+	// syntax of the path policy ACLs.
+	ACL *pathpol.ACL `yaml:"ACL"`
+	// MaxLatency is the maximum latency of a segment, as announced in the static info
+	// extensions of its AS entries. Latencies that are not announced count as zero. If zero,
+	// the latency is not limited.
+	MaxLatency time.Duration `yaml:"MaxLatency"`
+	// MinBandwidth is the minimum bandwidth in Kbit/s of every link and every AS of a segment,
+	// as announced in the static info extensions of its AS entries. Bandwidths that are not
+	// announced are not checked.
+	MinBandwidth uint64 `yaml:"MinBandwidth"`
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -280,9 +307,89 @@ func (f Filter) Apply(beacon Beacon) error {
 			}
 		}
 	}
+	if len(beacon.Segment.ASEntries) < f.MinHopsLength {
+		return serrors.New("MinHopsLength not reached", "min", f.MinHopsLength,
+			"actual", len(beacon.Segment.ASEntries))
+	}
+	if f.Sequence != nil || f.ACL != nil {
+		path := []snet.Path{beaconPath(beacon)}
+		if len(f.Sequence.Eval(path)) == 0 {
+			return serrors.New("does not match sequence", "sequence", f.Sequence)
+		}
+		if len(f.ACL.Eval(path)) == 0 {
+			return serrors.New("denied by ACL")
+		}
+	}
+	if f.MaxLatency != 0 {
+		if latency, _ := beaconLatency(beacon); latency > f.MaxLatency {
+			return serrors.New("MaxLatency exceeded", "max", f.MaxLatency, "actual", latency)
+		}
+	}
+	if f.MinBandwidth != 0 {
+		if bw, ok := beaconBandwidth(beacon); ok && bw < f.MinBandwidth {
+			return serrors.New("MinBandwidth not reached", "min", f.MinBandwidth,
+				"actual", bw)
+		}
+	}
 	return nil
 }
 
+// beaconPath returns the beacon as a path from the originating AS to the AS that received it,
+// so that path policies can be evaluated on it.
+func beaconPath(beacon Beacon) snet.Path {
+	entries := beacon.Segment.ASEntries
+	intfs := make([]snet.PathInterface, 0, 2*len(entries))
+	for i, entry := range entries {
+		hf := entry.HopEntry.HopField
+		if i > 0 {
+			intfs = append(intfs, snet.PathInterface{
+				IA: entry.Local,
+				ID: iface.ID(hf.ConsIngress),
+			})
+		}
+		if i < len(entries)-1 || !entry.Next.IsZero() {
+			intfs = append(intfs, snet.PathInterface{
+				IA: entry.Local,
+				ID: iface.ID(hf.ConsEgress),
+			})
+		}
+	}
+	if len(entries) > 0 {
+		if next := entries[len(entries)-1].Next; !next.IsZero() {
+			intfs = append(intfs, snet.PathInterface{IA: next, ID: iface.ID(beacon.InIfID)})
+		}
+	}
+	return path.Path{Meta: snet.PathMetadata{Interfaces: intfs}}
+}
+
+// beaconBandwidth returns the smallest bandwidth of the links and the ASes of the beacon, as
+// announced in the static info extensions of its AS entries. It returns false if no bandwidth is
+// announced.
+func beaconBandwidth(beacon Beacon) (uint64, bool) {
+	minBW := uint64(math.MaxUint64)
+	var found bool
+	update := func(m map[iface.ID]uint64, ifID uint16) {
+		if bw, ok := m[iface.ID(ifID)]; ok {
+			minBW = min(minBW, bw)
+			found = true
+		}
+	}
+	for _, entry := range beacon.Segment.ASEntries {
+		ext := entry.Extensions.StaticInfo
+		if ext == nil {
+			continue
+		}
+		hf := entry.HopEntry.HopField
+		if hf.ConsIngress != 0 && hf.ConsEgress != 0 {
+			update(ext.Bandwidth.Intra, hf.ConsIngress)
+		}
+		if hf.ConsEgress != 0 {
+			update(ext.Bandwidth.Inter, hf.ConsEgress)
+		}
+	}
+	return minBW, found
+}
+
 // FilterLoop returns an error if the beacon contains an AS or ISD loop. If ISD
 // loops are allowed, an error is returned only on AS loops.
 func FilterLoop(beacon Beacon, next addr.IA, allowIsdLoop bool) error {
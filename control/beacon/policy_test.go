@@ -16,12 +16,16 @@ package beacon_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/control/beacon"
 	"github.com/scionproto/scion/pkg/addr"
 	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/pkg/segment/extensions/staticinfo"
+	"github.com/scionproto/scion/pkg/segment/iface"
 )
 
 var (
@@ -41,11 +45,13 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 	tests := map[string]struct {
 		File         string
 		Type         beacon.PolicyType
+		Algorithm    string
 		ErrAssertion assert.ErrorAssertionFunc
 	}{
 		"policy with matching type": {
 			File:         "testdata/typedPolicy.yml",
 			Type:         beacon.PropPolicy,
+			Algorithm:    beacon.MostDiverseSelection,
 			ErrAssertion: assert.NoError,
 		},
 		"policy with wrong type": {
@@ -56,6 +62,7 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 		"policy without type": {
 			File:         "testdata/policy.yml",
 			Type:         beacon.PropPolicy,
+			Algorithm:    beacon.DefaultSelection,
 			ErrAssertion: assert.NoError,
 		},
 	}
@@ -69,6 +76,7 @@ func TestLoadPolicyFromYaml(t *testing.T) {
 			assert.Equal(t, 6, p.BestSetSize)
 			assert.Equal(t, 20, p.CandidateSetSize)
 			assert.Equal(t, test.Type, p.Type)
+			assert.Equal(t, test.Algorithm, p.Algorithm)
 			assert.Equal(t, uint8(42), *p.MaxExpTime)
 			assert.Equal(t, 8, p.Filter.MaxHopsLength)
 			assert.Equal(t, []addr.AS{ia110.AS(), ia111.AS()}, p.Filter.AsBlackList)
@@ -153,6 +161,88 @@ func TestFilterApply(t *testing.T) {
 	}
 }
 
+func TestFilterApplyExpressions(t *testing.T) {
+	// 1-ff00:0:110#1 -> 1#1-ff00:0:111#2 -> 2#1-ff00:0:113#2 -> 3#1-ff00:0:112 (local)
+	b := newTestBeacon(ia110, ia111, ia113)
+	b.InIfID = 3
+	for i := range b.Segment.ASEntries {
+		entry := &b.Segment.ASEntries[i]
+		if i > 0 {
+			entry.HopEntry.HopField.ConsIngress = uint16(i)
+		}
+		entry.HopEntry.HopField.ConsEgress = uint16(i + 1)
+		entry.Extensions.StaticInfo = &staticinfo.Extension{
+			Latency: staticinfo.LatencyInfo{
+				Intra: map[iface.ID]time.Duration{iface.ID(i): 5 * time.Millisecond},
+				Inter: map[iface.ID]time.Duration{iface.ID(i + 1): 10 * time.Millisecond},
+			},
+			Bandwidth: staticinfo.BandwidthInfo{
+				Inter: map[iface.ID]uint64{iface.ID(i + 1): uint64(1000 * (i + 1))},
+			},
+		}
+	}
+	b.Segment.ASEntries[2].Next = ia112
+
+	testCases := map[string]struct {
+		Filter       string
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"matching sequence": {
+			Filter:       `Sequence: "1-ff00:0:110 1-ff00:0:111 0* 1-ff00:0:112#3"`,
+			ErrAssertion: assert.NoError,
+		},
+		"sequence not matched": {
+			Filter:       `Sequence: "1-ff00:0:110 1-ff00:0:113 0*"`,
+			ErrAssertion: assert.Error,
+		},
+		"allowed by ACL": {
+			Filter:       "ACL: [\"- 2\", \"+\"]",
+			ErrAssertion: assert.NoError,
+		},
+		"denied by ACL": {
+			Filter:       "ACL: [\"- 1-ff00:0:113\", \"+\"]",
+			ErrAssertion: assert.Error,
+		},
+		"ISD membership": {
+			Filter:       "ACL: [\"+ 1\", \"-\"]",
+			ErrAssertion: assert.NoError,
+		},
+		"min hops reached": {
+			Filter:       "MinHopsLength: 3",
+			ErrAssertion: assert.NoError,
+		},
+		"min hops not reached": {
+			Filter:       "MinHopsLength: 4",
+			ErrAssertion: assert.Error,
+		},
+		// 3 links of 10ms and 2 ASes of 5ms.
+		"latency within limit": {
+			Filter:       "MaxLatency: 40ms",
+			ErrAssertion: assert.NoError,
+		},
+		"latency exceeded": {
+			Filter:       "MaxLatency: 39ms",
+			ErrAssertion: assert.Error,
+		},
+		"bandwidth reached": {
+			Filter:       "MinBandwidth: 1000",
+			ErrAssertion: assert.NoError,
+		},
+		"bandwidth not reached": {
+			Filter:       "MinBandwidth: 2000",
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			raw := "Filter:\n  " + test.Filter
+			p, err := beacon.ParsePolicyYaml([]byte(raw), beacon.PropPolicy)
+			require.NoError(t, err)
+			test.ErrAssertion(t, p.Filter.Apply(b))
+		})
+	}
+}
+
 func TestFilterLoop(t *testing.T) {
 	testCases := []struct {
 		Name         string
@@ -15,20 +15,122 @@
 package beacon
 
 import (
+	"cmp"
 	"context"
 	"math"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/patrickmn/go-cache"
 
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/segment/extensions/staticinfo"
+	"github.com/scionproto/scion/pkg/segment/iface"
 	"github.com/scionproto/scion/private/segment/segverifier"
 )
 
-type selectionAlgorithm interface {
-	// SelectBeacons selects the `n` best beacons from the provided slice of beacons.
+// Names of the built-in selection algorithms.
+const (
+	// DefaultSelection selects the shortest beacons, and replaces the last one with a more
+	// diverse beacon if there is one.
+	DefaultSelection = "Default"
+	// ShortestPathSelection selects the shortest beacons.
+	ShortestPathSelection = "ShortestPath"
+	// MostDiverseSelection selects the shortest beacon, then, one by one, the beacons that share
+	// the fewest links with those already selected.
+	MostDiverseSelection = "MostDiverse"
+	// LatencyOptimizedSelection selects the beacons with the lowest latency, as announced in
+	// their static info extensions.
+	LatencyOptimizedSelection = "LatencyOptimized"
+)
+
+// SelectionAlgorithm selects the beacons to propagate or register among the candidate beacons.
+// Additional algorithms can be made available to the policies with
+// RegisterSelectionAlgorithm.
+type SelectionAlgorithm interface {
+	// SelectBeacons selects the `n` best beacons from the provided slice of beacons. The
+	// beacons are sorted by increasing number of AS entries. It must not modify the slice.
 	SelectBeacons(ctx context.Context, beacons []Beacon, resultSize int) []Beacon
 }
 
+// IntraLatency returns the latency of the path through the local AS from the ingress to the
+// egress interface, and whether it is known.
+type IntraLatency func(ingress, egress uint16) (time.Duration, bool)
+
+// egressSelector is implemented by the selection algorithms whose selection depends on the egress
+// interface that the beacons are propagated on.
+type egressSelector interface {
+	// SelectBeaconsPerEgress selects the `n` best beacons from the provided slice of beacons for
+	// each of the egress interfaces. intra may be nil. It must not modify the slice.
+	SelectBeaconsPerEgress(ctx context.Context, beacons []Beacon, resultSize int,
+		egresses []uint16, intra IntraLatency) map[uint16][]Beacon
+}
+
+// selectPerEgress selects the beacons for each of the egress interfaces. If the algorithm does not
+// depend on the egress interface, the same beacons are selected for all of them.
+func selectPerEgress(
+	ctx context.Context,
+	algo SelectionAlgorithm,
+	beacons []Beacon,
+	resultSize int,
+	egresses []uint16,
+	intra IntraLatency,
+) map[uint16][]Beacon {
+
+	if e, ok := algo.(egressSelector); ok {
+		return e.SelectBeaconsPerEgress(ctx, beacons, resultSize, egresses, intra)
+	}
+	return sameForAll(algo.SelectBeacons(ctx, beacons, resultSize), egresses)
+}
+
+// sameForAll returns the selected beacons for each of the egress interfaces.
+func sameForAll(selected []Beacon, egresses []uint16) map[uint16][]Beacon {
+	r := make(map[uint16][]Beacon, len(egresses))
+	for _, egress := range egresses {
+		r[egress] = selected
+	}
+	return r
+}
+
+var (
+	selectionAlgorithmsMtx sync.Mutex
+	selectionAlgorithms    = map[string]SelectionAlgorithm{
+		DefaultSelection:          baseAlgo{},
+		ShortestPathSelection:     shortestPathAlgo{},
+		MostDiverseSelection:      mostDiverseAlgo{},
+		LatencyOptimizedSelection: latencyAlgo{},
+	}
+)
+
+// RegisterSelectionAlgorithm makes a selection algorithm available to the policies under the
+// given name. It must be called before the beacon stores are created, and panics if the name is
+// already taken.
+func RegisterSelectionAlgorithm(name string, algo SelectionAlgorithm) {
+	selectionAlgorithmsMtx.Lock()
+	defer selectionAlgorithmsMtx.Unlock()
+	if _, ok := selectionAlgorithms[name]; ok {
+		panic("selection algorithm registered twice: " + name)
+	}
+	selectionAlgorithms[name] = algo
+}
+
+// selectionAlgorithm returns the selection algorithm with the given name. If the name is empty,
+// the default algorithm is returned.
+func selectionAlgorithm(name string) (SelectionAlgorithm, error) {
+	if name == "" {
+		name = DefaultSelection
+	}
+	selectionAlgorithmsMtx.Lock()
+	defer selectionAlgorithmsMtx.Unlock()
+	algo, ok := selectionAlgorithms[name]
+	if !ok {
+		return nil, serrors.New("unknown selection algorithm", "algorithm", name)
+	}
+	return algo, nil
+}
+
 // baseAlgo implements a very simple selection algorithm that optimizes for
 // short paths, but also tries to achieve some path diversity.
 type baseAlgo struct{}
@@ -78,9 +180,161 @@ func (baseAlgo) selectMostDiverse(beacons []Beacon, best Beacon) (Beacon, int) {
 	return diverse, maxDiversity
 }
 
+// shortestPathAlgo selects the shortest beacons.
+type shortestPathAlgo struct{}
+
+func (shortestPathAlgo) SelectBeacons(
+	_ context.Context,
+	beacons []Beacon,
+	resultSize int,
+) []Beacon {
+	return beacons[:min(len(beacons), resultSize)]
+}
+
+// mostDiverseAlgo selects the shortest beacon, then, one by one, the beacon whose smallest
+// diversity to the beacons already selected is the largest. Among equally diverse beacons, the
+// shortest is selected.
+type mostDiverseAlgo struct{}
+
+func (mostDiverseAlgo) SelectBeacons(_ context.Context, beacons []Beacon, resultSize int) []Beacon {
+	if len(beacons) <= resultSize {
+		return beacons
+	}
+	result := make([]Beacon, 1, resultSize)
+	result[0] = beacons[0]
+	// diversity holds, for each remaining beacon, its smallest diversity to the selected ones.
+	remaining := slices.Clone(beacons[1:])
+	diversity := make([]int, len(remaining))
+	for i, b := range remaining {
+		diversity[i] = b.Diversity(result[0])
+	}
+	for len(result) < resultSize {
+		best := 0
+		for i := range remaining {
+			if diversity[i] > diversity[best] {
+				best = i
+			}
+		}
+		selected := remaining[best]
+		result = append(result, selected)
+		remaining = slices.Delete(remaining, best, best+1)
+		diversity = slices.Delete(diversity, best, best+1)
+		for i, b := range remaining {
+			diversity[i] = min(diversity[i], b.Diversity(selected))
+		}
+	}
+	return result
+}
+
+// latencyAlgo selects the beacons with the lowest latency. The latency of a beacon is the sum of
+// the latencies of its links and of the paths through its ASes, as announced in the static info
+// extensions of its AS entries. The beacons that announce fewer latencies come after the others,
+// since their latency is likely underestimated. Beacons with the same latency are selected in
+// order of length.
+//
+// When the beacons are selected per egress interface, the latency of the path through the local
+// AS, from the ingress interface of the beacon to the egress interface, is added.
+type latencyAlgo struct{}
+
+// latencyEntry is a beacon with its latency and the number of latencies it does not announce.
+type latencyEntry struct {
+	beacon  Beacon
+	latency time.Duration
+	unknown int
+}
+
+func (latencyAlgo) SelectBeacons(_ context.Context, beacons []Beacon, resultSize int) []Beacon {
+	if len(beacons) <= resultSize {
+		return beacons
+	}
+	entries := make([]latencyEntry, len(beacons))
+	for i, b := range beacons {
+		entries[i].beacon = b
+		entries[i].latency, entries[i].unknown = beaconLatency(b)
+	}
+	return selectLowestLatency(entries, resultSize)
+}
+
+func (a latencyAlgo) SelectBeaconsPerEgress(
+	ctx context.Context,
+	beacons []Beacon,
+	resultSize int,
+	egresses []uint16,
+	intra IntraLatency,
+) map[uint16][]Beacon {
+
+	if len(beacons) <= resultSize || intra == nil {
+		return sameForAll(a.SelectBeacons(ctx, beacons, resultSize), egresses)
+	}
+	base := make([]latencyEntry, len(beacons))
+	for i, b := range beacons {
+		base[i].beacon = b
+		base[i].latency, base[i].unknown = beaconLatency(b)
+	}
+	r := make(map[uint16][]Beacon, len(egresses))
+	entries := make([]latencyEntry, len(base))
+	for _, egress := range egresses {
+		copy(entries, base)
+		for i := range entries {
+			l, ok := intra(entries[i].beacon.InIfID, egress)
+			entries[i].latency += l
+			if !ok {
+				entries[i].unknown++
+			}
+		}
+		r[egress] = selectLowestLatency(entries, resultSize)
+	}
+	return r
+}
+
+// selectLowestLatency sorts the entries and returns the beacons of the first resultSize ones.
+func selectLowestLatency(entries []latencyEntry, resultSize int) []Beacon {
+	slices.SortStableFunc(entries, func(a, b latencyEntry) int {
+		return cmp.Or(cmp.Compare(a.unknown, b.unknown), cmp.Compare(a.latency, b.latency))
+	})
+	result := make([]Beacon, resultSize)
+	for i := range result {
+		result[i] = entries[i].beacon
+	}
+	return result
+}
+
+// beaconLatency returns the sum of the latencies announced by the AS entries of the beacon, and
+// the number of latencies that are not announced.
+func beaconLatency(b Beacon) (time.Duration, int) {
+	var total time.Duration
+	var unknown int
+	for _, entry := range b.Segment.ASEntries {
+		hf := entry.HopEntry.HopField
+		var latency staticinfo.LatencyInfo
+		if entry.Extensions.StaticInfo != nil {
+			latency = entry.Extensions.StaticInfo.Latency
+		}
+		// The path through the AS, from the ingress to the egress interface.
+		if hf.ConsIngress != 0 && hf.ConsEgress != 0 {
+			l, ok := latency.Intra[iface.ID(hf.ConsIngress)]
+			total += l
+			if !ok {
+				unknown++
+			}
+		}
+		// The link to the next AS.
+		if hf.ConsEgress != 0 {
+			l, ok := latency.Inter[iface.ID(hf.ConsEgress)]
+			total += l
+			if !ok {
+				unknown++
+			}
+		}
+	}
+	return total, unknown
+}
+
 type chainsAvailableAlgo struct {
 	verifier     chainChecker
 	logThrottled *cache.Cache
+	// algo selects among the beacons whose chains are available.
+	algo SelectionAlgorithm
 }
 
 func newChainsAvailableAlgo(engine ChainProvider) chainsAvailableAlgo {
@@ -93,11 +347,34 @@ func newChainsAvailableAlgo(engine ChainProvider) chainsAvailableAlgo {
 	}
 }
 
+// withAlgorithm returns a copy that selects with the given algorithm. The copy shares the caches
+// of the original.
+func (a chainsAvailableAlgo) withAlgorithm(algo SelectionAlgorithm) chainsAvailableAlgo {
+	a.algo = algo
+	return a
+}
+
 func (a chainsAvailableAlgo) SelectBeacons(
 	ctx context.Context,
 	beacons []Beacon,
 	resultSize int,
 ) []Beacon {
+	return a.algo.SelectBeacons(ctx, a.withChain(ctx, beacons), resultSize)
+}
+
+func (a chainsAvailableAlgo) SelectBeaconsPerEgress(
+	ctx context.Context,
+	beacons []Beacon,
+	resultSize int,
+	egresses []uint16,
+	intra IntraLatency,
+) map[uint16][]Beacon {
+
+	return selectPerEgress(ctx, a.algo, a.withChain(ctx, beacons), resultSize, egresses, intra)
+}
+
+// withChain returns the beacons for which all the required certificate chains are available.
+func (a chainsAvailableAlgo) withChain(ctx context.Context, beacons []Beacon) []Beacon {
 	withChain := make([]Beacon, 0, len(beacons))
 	for _, b := range beacons {
 		err := segverifier.VerifySegment(ctx, a.verifier, nil, b.Segment)
@@ -115,5 +392,5 @@ func (a chainsAvailableAlgo) SelectBeacons(
 			a.logThrottled.Set(id, struct{}{}, cache.DefaultExpiration)
 		}
 	}
-	return baseAlgo{}.SelectBeacons(ctx, withChain, resultSize)
+	return withChain
 }
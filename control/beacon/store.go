@@ -73,11 +73,15 @@ func NewBeaconStore(policies Policies, db DB, opts ...StoreOption) (*Store, erro
 	if err := policies.Validate(); err != nil {
 		return nil, err
 	}
-	o := applyStoreOptions(opts)
+	algos, err := selectAlgos(applyStoreOptions(opts),
+		&policies.Prop, &policies.UpReg, &policies.DownReg)
+	if err != nil {
+		return nil, err
+	}
 	s := &Store{
 		baseStore: baseStore{
-			db:   db,
-			algo: selectAlgo(o),
+			db:    db,
+			algos: algos,
 		},
 		policies: policies,
 	}
@@ -91,6 +95,26 @@ func (s *Store) BeaconsToPropagate(ctx context.Context) ([]Beacon, error) {
 	return s.getBeacons(ctx, &s.policies.Prop)
 }
 
+// BeaconsToPropagatePerEgress returns the beacons to propagate on each of the egress
+// interfaces at the time of the call. The selection is based on the configured propagation
+// policy; selection algorithms that take the intra-AS latency into account select the beacons
+// for each egress interface separately.
+func (s *Store) BeaconsToPropagatePerEgress(
+	ctx context.Context,
+	egresses []uint16,
+	intra IntraLatency,
+) (map[uint16][]Beacon, error) {
+
+	policy := &s.policies.Prop
+	beacons, err := s.db.CandidateBeacons(ctx, policy.CandidateSetSize,
+		UsageFromPolicyType(policy.Type), 0)
+	if err != nil {
+		return nil, err
+	}
+	return selectPerEgress(ctx, s.algos[policy.Type], beacons, policy.BestSetSize,
+		egresses, intra), nil
+}
+
 // SegmentsToRegister returns a channel that provides all beacons to register at
 // the time of the call. The selections is based on the configured policy for
 // the requested segment type.
@@ -113,7 +137,7 @@ func (s *Store) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, error
 	if err != nil {
 		return nil, err
 	}
-	return s.algo.SelectBeacons(ctx, beacons, policy.BestSetSize), nil
+	return s.algos[policy.Type].SelectBeacons(ctx, beacons, policy.BestSetSize), nil
 }
 
 // MaxExpTime returns the segment maximum expiration time for the given policy.
@@ -144,11 +168,14 @@ func NewCoreBeaconStore(policies CorePolicies, db DB, opts ...StoreOption) (*Cor
 	if err := policies.Validate(); err != nil {
 		return nil, err
 	}
-	o := applyStoreOptions(opts)
+	algos, err := selectAlgos(applyStoreOptions(opts), &policies.Prop, &policies.CoreReg)
+	if err != nil {
+		return nil, err
+	}
 	s := &CoreStore{
 		baseStore: baseStore{
-			db:   db,
-			algo: selectAlgo(o),
+			db:    db,
+			algos: algos,
 		},
 		policies: policies,
 	}
@@ -162,6 +189,39 @@ func (s *CoreStore) BeaconsToPropagate(ctx context.Context) ([]Beacon, error) {
 	return s.getBeacons(ctx, &s.policies.Prop)
 }
 
+// BeaconsToPropagatePerEgress returns the beacons to propagate on each of the egress
+// interfaces at the time of the call. The beacons are selected per origin AS, as in
+// BeaconsToPropagate, and per egress interface if the selection algorithm takes the intra-AS
+// latency into account.
+func (s *CoreStore) BeaconsToPropagatePerEgress(
+	ctx context.Context,
+	egresses []uint16,
+	intra IntraLatency,
+) (map[uint16][]Beacon, error) {
+
+	policy := &s.policies.Prop
+	srcs, err := s.db.BeaconSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r := make(map[uint16][]Beacon, len(egresses))
+	for _, src := range srcs {
+		candidateBeacons, err := s.db.CandidateBeacons(ctx, policy.CandidateSetSize,
+			UsageFromPolicyType(policy.Type), src)
+		// Must not return as a partial result is better than no result at all.
+		if err != nil {
+			log.FromCtx(ctx).Error("Error getting candidate beacons", "src", src, "err", err)
+			continue
+		}
+		perEgress := selectPerEgress(ctx, s.algos[policy.Type], candidateBeacons,
+			policy.BestSetSize, egresses, intra)
+		for egress, selBeacons := range perEgress {
+			r[egress] = append(r[egress], selBeacons...)
+		}
+	}
+	return r, nil
+}
+
 // SegmentsToRegister returns a slice of all beacons to register at the time of the call.
 // The selections is based on the configured policy for the requested segment type.
 func (s *CoreStore) SegmentsToRegister(ctx context.Context, segType seg.Type) ([]Beacon, error) {
@@ -188,7 +248,7 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) ([]Beacon, e
 			log.FromCtx(ctx).Error("Error getting candidate beacons", "src", src, "err", err)
 			continue
 		}
-		selBeacons := s.algo.SelectBeacons(ctx, candidateBeacons, policy.BestSetSize)
+		selBeacons := s.algos[policy.Type].SelectBeacons(ctx, candidateBeacons, policy.BestSetSize)
 		beacons = append(beacons, selBeacons...)
 	}
 	return beacons, nil
@@ -209,7 +269,8 @@ func (s *CoreStore) MaxExpTime(policyType PolicyType) uint8 {
 type baseStore struct {
 	db     DB
 	usager usager
-	algo   selectionAlgorithm
+	// algos holds the selection algorithm of each policy.
+	algos map[PolicyType]SelectionAlgorithm
 }
 
 // PreFilter indicates whether the beacon will be filtered on insert by
@@ -236,9 +297,26 @@ func (s *baseStore) UpdatePolicy(ctx context.Context, policy Policy) error {
 	return serrors.New("policy update not supported")
 }
 
-func selectAlgo(o storeOptions) selectionAlgorithm {
+// selectAlgos returns the selection algorithms of the given policies, by policy type.
+func selectAlgos(
+	o storeOptions,
+	policies ...*Policy,
+) (map[PolicyType]SelectionAlgorithm, error) {
+	var chains chainsAvailableAlgo
 	if o.chainChecker != nil {
-		return newChainsAvailableAlgo(o.chainChecker)
+		chains = newChainsAvailableAlgo(o.chainChecker)
+	}
+	algos := make(map[PolicyType]SelectionAlgorithm, len(policies))
+	for _, p := range policies {
+		algo, err := selectionAlgorithm(p.Algorithm)
+		if err != nil {
+			return nil, serrors.Wrap("selecting beacon selection algorithm", err,
+				"policy", p.Type)
+		}
+		if o.chainChecker != nil {
+			algo = chains.withAlgorithm(algo)
+		}
+		algos[p.Type] = algo
 	}
-	return baseAlgo{}
+	return algos, nil
 }
@@ -18,8 +18,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/control/beacon"
@@ -27,6 +29,8 @@ import (
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/xtest/graph"
 	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/pkg/segment/extensions/staticinfo"
+	"github.com/scionproto/scion/pkg/segment/iface"
 )
 
 func TestStoreSegmentsToRegister(t *testing.T) {
@@ -279,3 +283,145 @@ func testSegment(g *graph.Graph, ifIDs []uint16) *seg.PathSegment {
 	pseg.ASEntries = pseg.ASEntries[:len(pseg.ASEntries)-1]
 	return pseg
 }
+
+func TestStoreSelectionAlgorithms(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+
+	stub := graph.If_210_X_220_X
+	diverseBeacons := []beacon.Beacon{
+		testBeacon(g, graph.If_130_A_110_X, graph.If_110_X_210_X, stub),
+		// Same beacon as the first beacon.
+		testBeacon(g, graph.If_130_A_110_X, graph.If_110_X_210_X, stub),
+		// Share the last link between 110 and 210.
+		testBeacon(g, graph.If_130_B_120_A, graph.If_120_A_110_X, graph.If_110_X_210_X, stub),
+		// Share no link.
+		testBeacon(g, graph.If_130_B_120_A, graph.If_120_B_220_X, graph.If_220_X_210_X, stub),
+	}
+	stub = graph.If_111_A_112_X
+	latencyBeacons := []beacon.Beacon{
+		// No latency is announced.
+		testBeacon(g, graph.If_120_X_111_B, stub),
+		testBeacon(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub),
+		testBeacon(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub),
+	}
+	withLatency(latencyBeacons[1], 10*time.Millisecond)
+	withLatency(latencyBeacons[2], time.Millisecond)
+
+	var tests = []struct {
+		name      string
+		algorithm string
+		results   []beacon.Beacon
+		bestSize  int
+		expected  []beacon.Beacon
+	}{
+		{
+			name:      "shortest path",
+			algorithm: beacon.ShortestPathSelection,
+			results:   diverseBeacons,
+			bestSize:  2,
+			expected:  diverseBeacons[:2],
+		},
+		{
+			name:      "most diverse",
+			algorithm: beacon.MostDiverseSelection,
+			results:   diverseBeacons,
+			bestSize:  3,
+			expected:  []beacon.Beacon{diverseBeacons[0], diverseBeacons[3], diverseBeacons[2]},
+		},
+		{
+			name:      "latency optimized",
+			algorithm: beacon.LatencyOptimizedSelection,
+			results:   latencyBeacons,
+			bestSize:  2,
+			expected:  []beacon.Beacon{latencyBeacons[2], latencyBeacons[1]},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			db := mock_beacon.NewMockDB(mctrl)
+			policies := beacon.Policies{
+				Prop: beacon.Policy{BestSetSize: test.bestSize, Algorithm: test.algorithm},
+			}
+			store, err := beacon.NewBeaconStore(policies, db)
+			require.NoError(t, err)
+
+			db.EXPECT().CandidateBeacons(
+				gomock.Any(), gomock.Any(), gomock.Any(), addr.IA(0),
+			).Return(test.results, nil)
+			res, err := store.BeaconsToPropagate(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+	t.Run("unknown algorithm", func(t *testing.T) {
+		policies := beacon.Policies{
+			UpReg: beacon.Policy{Algorithm: "Random"},
+		}
+		_, err := beacon.NewBeaconStore(policies, mock_beacon.NewMockDB(mctrl))
+		assert.Error(t, err)
+	})
+}
+
+func TestStoreLatencyPerEgress(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+
+	stub := graph.If_111_A_112_X
+	beacons := []beacon.Beacon{
+		testBeacon(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub),
+		testBeacon(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub),
+	}
+	withLatency(beacons[0], 10*time.Millisecond)
+	withLatency(beacons[1], time.Millisecond)
+	beacons[0].InIfID, beacons[1].InIfID = 1, 2
+	intra := map[[2]uint16]time.Duration{
+		{1, 10}: time.Millisecond,
+		{2, 10}: 100 * time.Millisecond,
+		{1, 20}: 5 * time.Millisecond,
+		{2, 20}: time.Millisecond,
+	}
+
+	db := mock_beacon.NewMockDB(mctrl)
+	policies := beacon.Policies{
+		Prop: beacon.Policy{BestSetSize: 1, Algorithm: beacon.LatencyOptimizedSelection},
+	}
+	store, err := beacon.NewBeaconStore(policies, db)
+	require.NoError(t, err)
+	db.EXPECT().CandidateBeacons(
+		gomock.Any(), gomock.Any(), gomock.Any(), addr.IA(0),
+	).Return(beacons, nil)
+
+	res, err := store.BeaconsToPropagatePerEgress(context.Background(), []uint16{10, 20, 30},
+		func(ingress, egress uint16) (time.Duration, bool) {
+			l, ok := intra[[2]uint16{ingress, egress}]
+			return l, ok
+		},
+	)
+	require.NoError(t, err)
+	expected := map[uint16][]beacon.Beacon{
+		10: {beacons[0]},
+		20: {beacons[1]},
+		// Without intra-AS latency, the beacon with the lowest announced latency wins.
+		30: {beacons[1]},
+	}
+	assert.Equal(t, expected, res)
+}
+
+// withLatency announces the given latency for every link and every AS of the beacon.
+func withLatency(b beacon.Beacon, latency time.Duration) {
+	for i := range b.Segment.ASEntries {
+		entry := &b.Segment.ASEntries[i]
+		hf := entry.HopEntry.HopField
+		entry.Extensions.StaticInfo = &staticinfo.Extension{
+			Latency: staticinfo.LatencyInfo{
+				Intra: map[iface.ID]time.Duration{iface.ID(hf.ConsIngress): latency},
+				Inter: map[iface.ID]time.Duration{iface.ID(hf.ConsEgress): latency},
+			},
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/segment/iface"
+)
+
+// AnomalyAction is the action taken on the beacons flagged by an anomaly detector.
+type AnomalyAction string
+
+const (
+	// AnomalyTag inserts the flagged beacons, but logs and counts them.
+	AnomalyTag AnomalyAction = "tag"
+	// AnomalyReject rejects the flagged beacons.
+	AnomalyReject AnomalyAction = "reject"
+)
+
+// AnomalyDetector flags suspicious beacons.
+type AnomalyDetector interface {
+	// Name identifies the detector in the logs and metrics.
+	Name() string
+	// Detect returns an error that describes the anomaly if the beacon received at the given
+	// time is suspicious. It must be safe for concurrent use.
+	Detect(b beacon.Beacon, now time.Time) error
+}
+
+// AnomalyHook applies the action to the beacons flagged by the detector.
+type AnomalyHook struct {
+	Detector AnomalyDetector
+	Action   AnomalyAction
+}
+
+// detectAnomalies runs the anomaly hooks on the beacon in order. It returns an error if a
+// hook with the reject action flags the beacon; the remaining hooks are not run in that case.
+func (h Handler) detectAnomalies(ctx context.Context, b beacon.Beacon, upstream addr.IA) error {
+	now := time.Now()
+	for _, hook := range h.Anomalies {
+		err := hook.Detector.Detect(b, now)
+		if err == nil {
+			continue
+		}
+		name := hook.Detector.Name()
+		if h.AnomaliesDetected != nil {
+			metrics.CounterInc(h.AnomaliesDetected.With(
+				"detector", name,
+				"action", string(hook.Action),
+				prom.LabelNeighIA, upstream.String(),
+			))
+		}
+		log.FromCtx(ctx).Info("Beacon anomaly detected", "detector", name,
+			"action", hook.Action, "err", err)
+		if hook.Action == AnomalyReject {
+			return serrors.Wrap("beacon rejected by anomaly detector", err, "detector", name)
+		}
+	}
+	return nil
+}
+
+// ASLoopDetector flags the beacons that contain an ISD-AS more than once, or that contain
+// the local ISD-AS.
+type ASLoopDetector struct {
+	// LocalIAs are the ISD-ASes of the local AS.
+	LocalIAs []addr.IA
+}
+
+// Name returns "as_loop".
+func (d ASLoopDetector) Name() string {
+	return "as_loop"
+}
+
+// Detect flags the beacon if it contains an AS loop.
+func (d ASLoopDetector) Detect(b beacon.Beacon, _ time.Time) error {
+	seen := make(map[addr.IA]struct{}, len(b.Segment.ASEntries))
+	for _, ia := range d.LocalIAs {
+		seen[ia] = struct{}{}
+	}
+	for i, entry := range b.Segment.ASEntries {
+		if _, ok := seen[entry.Local]; ok {
+			return serrors.New("AS loop", "isd_as", entry.Local, "as_entry", i)
+		}
+		seen[entry.Local] = struct{}{}
+	}
+	return nil
+}
+
+// LatencyDetector flags the beacons that announce link latencies above a maximum in the static
+// info extension.
+type LatencyDetector struct {
+	// MaxLatency is the maximum plausible latency of an intra-AS or inter-AS link.
+	MaxLatency time.Duration
+}
+
+// Name returns "latency".
+func (d LatencyDetector) Name() string {
+	return "latency"
+}
+
+// Detect flags the beacon if an AS entry announces an implausible latency.
+func (d LatencyDetector) Detect(b beacon.Beacon, _ time.Time) error {
+	for i, entry := range b.Segment.ASEntries {
+		info := entry.Extensions.StaticInfo
+		if info == nil {
+			continue
+		}
+		for _, latencies := range []map[iface.ID]time.Duration{
+			info.Latency.Intra,
+			info.Latency.Inter,
+		} {
+			for ifID, latency := range latencies {
+				if latency > d.MaxLatency {
+					return serrors.New("implausible latency", "isd_as", entry.Local,
+						"as_entry", i, "interface", ifID, "latency", latency,
+						"max", d.MaxLatency)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// OriginRateDetector flags the beacons of origin ASes that originate more beacons than
+// expected. The originations of an AS are identified by the distinct timestamps of their info
+// fields.
+type OriginRateDetector struct {
+	// Window is the duration over which the originations are counted.
+	Window time.Duration
+	// MaxOriginations is the maximum number of originations of an AS within the window.
+	MaxOriginations int
+
+	mu sync.Mutex
+	// originations contains the timestamps of the originations within the window, by origin
+	// AS.
+	originations map[addr.IA]map[time.Time]struct{}
+	lastSweep    time.Time
+}
+
+// Name returns "origin_rate".
+func (d *OriginRateDetector) Name() string {
+	return "origin_rate"
+}
+
+// Detect records the origination of the beacon and flags it if its origin AS exceeds the
+// maximum number of originations within the window.
+func (d *OriginRateDetector) Detect(b beacon.Beacon, now time.Time) error {
+	if len(b.Segment.ASEntries) == 0 {
+		return nil
+	}
+	origin := b.Segment.ASEntries[0].Local
+	ts := b.Segment.Info.Timestamp
+	start := now.Add(-d.Window)
+	if ts.Before(start) {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.originations == nil {
+		d.originations = make(map[addr.IA]map[time.Time]struct{})
+	}
+	// Remove the originations that left the window, for all origin ASes at most once per
+	// window.
+	if now.Sub(d.lastSweep) >= d.Window {
+		for ia, timestamps := range d.originations {
+			prune(timestamps, start)
+			if len(timestamps) == 0 {
+				delete(d.originations, ia)
+			}
+		}
+		d.lastSweep = now
+	}
+	timestamps, ok := d.originations[origin]
+	if !ok {
+		timestamps = make(map[time.Time]struct{})
+		d.originations[origin] = timestamps
+	}
+	prune(timestamps, start)
+	timestamps[ts] = struct{}{}
+	if len(timestamps) > d.MaxOriginations {
+		return serrors.New("unusual origination rate", "origin", origin,
+			"originations", len(timestamps), "window", d.Window, "max", d.MaxOriginations)
+	}
+	return nil
+}
+
+func prune(timestamps map[time.Time]struct{}, start time.Time) {
+	for ts := range timestamps {
+		if ts.Before(start) {
+			delete(timestamps, ts)
+		}
+	}
+}
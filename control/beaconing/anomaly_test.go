@@ -0,0 +1,164 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/control/beaconing/mock_beaconing"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+	"github.com/scionproto/scion/pkg/segment/extensions/staticinfo"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/path"
+	mock_infra "github.com/scionproto/scion/private/segment/verifier/mock_verifier"
+	"github.com/scionproto/scion/private/topology"
+)
+
+func TestASLoopDetector(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	g := graph.NewDefaultGraph(mctrl)
+	b := beacon.Beacon{
+		Segment: testSegment(g, []uint16{graph.If_220_X_120_B, graph.If_120_A_110_X}),
+		InIfID:  localIF,
+	}
+	d := beaconing.ASLoopDetector{LocalIAs: []addr.IA{localIA}}
+	assert.NoError(t, d.Detect(b, time.Now()))
+
+	// The beacon already traversed one of the ISD-ASes of the local AS.
+	d.LocalIAs = append(d.LocalIAs, addr.MustParseIA("1-ff00:0:120"))
+	assert.ErrorContains(t, d.Detect(b, time.Now()), "AS loop")
+
+	// The beacon traverses an ISD-AS twice.
+	looping := *b.Segment
+	looping.ASEntries = append(looping.ASEntries, looping.ASEntries[0])
+	d.LocalIAs = nil
+	assert.ErrorContains(t, d.Detect(beacon.Beacon{Segment: &looping}, time.Now()), "AS loop")
+}
+
+func TestLatencyDetector(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	g := graph.NewDefaultGraph(mctrl)
+	b := beacon.Beacon{
+		Segment: testSegment(g, []uint16{graph.If_220_X_120_B, graph.If_120_A_110_X}),
+		InIfID:  localIF,
+	}
+	d := beaconing.LatencyDetector{MaxLatency: time.Second}
+	b.Segment.ASEntries[0].Extensions.StaticInfo = &staticinfo.Extension{
+		Latency: staticinfo.LatencyInfo{
+			Inter: map[iface.ID]time.Duration{1: 200 * time.Millisecond},
+		},
+	}
+	assert.NoError(t, d.Detect(b, time.Now()))
+
+	b.Segment.ASEntries[0].Extensions.StaticInfo.Latency.Intra = map[iface.ID]time.Duration{
+		2: 5 * time.Second,
+	}
+	assert.ErrorContains(t, d.Detect(b, time.Now()), "implausible latency")
+}
+
+func TestOriginRateDetector(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	g := graph.NewDefaultGraph(mctrl)
+	segment := testSegment(g, []uint16{graph.If_220_X_120_B, graph.If_120_A_110_X})
+	now := time.Now().Truncate(time.Second)
+	originated := func(ts time.Time) beacon.Beacon {
+		s := *segment
+		s.Info.Timestamp = ts
+		return beacon.Beacon{Segment: &s, InIfID: localIF}
+	}
+
+	d := &beaconing.OriginRateDetector{Window: time.Minute, MaxOriginations: 3}
+	for i := 0; i < 3; i++ {
+		ts := now.Add(time.Duration(i-2) * time.Second)
+		require.NoError(t, d.Detect(originated(ts), now))
+		// Beacons of the same origination are only counted once.
+		require.NoError(t, d.Detect(originated(ts), now))
+	}
+	assert.ErrorContains(t, d.Detect(originated(now.Add(time.Second)), now), "origination rate")
+
+	// Beacons originated before the window are ignored, and the originations leave the
+	// window.
+	assert.NoError(t, d.Detect(originated(now.Add(-2*time.Minute)), now))
+	later := now.Add(2 * time.Minute)
+	assert.NoError(t, d.Detect(originated(later), later))
+}
+
+func TestHandlerHandleBeaconAnomalies(t *testing.T) {
+	topo, err := topology.FromJSONFile("testdata/topology-core.json")
+	require.NoError(t, err)
+	mctrl := gomock.NewController(t)
+	g := graph.NewDefaultGraph(mctrl)
+	b := beacon.Beacon{
+		Segment: testSegment(g, []uint16{graph.If_220_X_120_B, graph.If_120_A_110_X}),
+		InIfID:  localIF,
+	}
+	detector := beaconing.ASLoopDetector{
+		LocalIAs: []addr.IA{addr.MustParseIA("1-ff00:0:120")},
+	}
+
+	testCases := map[string]struct {
+		Action    beaconing.AnomalyAction
+		Inserted  bool
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"tag": {
+			Action:    beaconing.AnomalyTag,
+			Inserted:  true,
+			Assertion: assert.NoError,
+		},
+		"reject": {
+			Action:    beaconing.AnomalyReject,
+			Assertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			inserter := mock_beaconing.NewMockBeaconInserter(mctrl)
+			inserter.EXPECT().PreFilter(gomock.Any()).Return(nil)
+			if tc.Inserted {
+				inserter.EXPECT().InsertBeacon(gomock.Any(), b).Return(beacon.InsertStats{}, nil)
+			}
+			verifier := mock_infra.NewMockVerifier(mctrl)
+			verifier.EXPECT().WithServer(gomock.Any()).AnyTimes().Return(verifier)
+			verifier.EXPECT().WithIA(gomock.Any()).AnyTimes().Return(verifier)
+			verifier.EXPECT().WithValidity(gomock.Any()).AnyTimes().Return(verifier)
+			verifier.EXPECT().Verify(gomock.Any(), gomock.Any(),
+				gomock.Any()).AnyTimes().Return(nil, nil)
+			handler := beaconing.Handler{
+				LocalIA:    localIA,
+				Inserter:   inserter,
+				Interfaces: testInterfaces(topo),
+				Verifier:   verifier,
+				Anomalies: []beaconing.AnomalyHook{
+					{Detector: detector, Action: tc.Action},
+				},
+			}
+			err := handler.HandleBeacon(context.Background(), b,
+				&snet.UDPAddr{Path: path.SCION{}})
+			tc.Assertion(t, err)
+		})
+	}
+}
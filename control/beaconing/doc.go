@@ -18,8 +18,10 @@
 // # Handler
 //
 // Call NewHandler to create a beacon handler that implements infra.Handler. The
-// handler validates the received beacon and verifies all signatures. If
-// successful, the beacon is added to the beacon store.
+// handler validates the received beacon and verifies all signatures. The
+// anomaly hooks then inspect the beacon, e.g., for AS loops or implausible
+// latencies, and either tag or reject it. If successful, the beacon is added to
+// the beacon store.
 //
 // # Originator
 //
@@ -67,6 +67,12 @@ type DefaultExtender struct {
 	IA addr.IA
 	// SignerGen is used to sign path segments.
 	SignerGen SignerGen
+	// AdditionalSignerGens are used to sign the path segments of the additional
+	// ISD-ASes of a core AS that is a member of several ISDs. The AS entries
+	// are created for the local ISD-AS of the interfaces, see
+	// ifstate.InterfaceInfo.LocalIA, and the ones for IA are signed with
+	// SignerGen.
+	AdditionalSignerGens map[addr.IA]SignerGen
 	// MAC is used to calculate the hop field MAC.
 	MAC func() hash.Hash
 	// Intfs holds all interfaces in the AS.
@@ -111,7 +117,17 @@ func (s *DefaultExtender) Extend(
 	}
 	ts := pseg.Info.Timestamp
 
-	signers, err := s.SignerGen.Generate(ctx)
+	local, err := s.localIA(ingress, egress)
+	if err != nil {
+		return err
+	}
+	signerGen := s.SignerGen
+	if local != s.IA {
+		if signerGen = s.AdditionalSignerGens[local]; signerGen == nil {
+			return serrors.New("no signer for local ISD-AS", "isd_as", local)
+		}
+	}
+	signers, err := signerGen.Generate(ctx)
 	if err != nil {
 		return serrors.Wrap("getting signer", err)
 	}
@@ -158,7 +174,9 @@ func (s *DefaultExtender) Extend(
 	// is traversed.
 
 	peerBeta := hopBeta ^ binary.BigEndian.Uint16(hopEntry.HopField.MAC[:2])
-	peerEntries, epicPeerMacs, err := s.createPeerEntries(egress, peers, expTime, ts, peerBeta)
+	peerEntries, epicPeerMacs, err := s.createPeerEntries(
+		local, egress, peers, expTime, ts, peerBeta,
+	)
 	if err != nil {
 		return err
 	}
@@ -168,7 +186,7 @@ func (s *DefaultExtender) Extend(
 	}
 	asEntry := seg.ASEntry{
 		HopEntry:    hopEntry,
-		Local:       s.IA,
+		Local:       local,
 		Next:        next,
 		PeerEntries: peerEntries,
 		MTU:         int(s.MTU),
@@ -206,12 +224,17 @@ func (s *DefaultExtender) Extend(
 	return pseg.Validate(seg.ValidateBeacon)
 }
 
-func (s *DefaultExtender) createPeerEntries(egress uint16, peers []uint16,
+func (s *DefaultExtender) createPeerEntries(local addr.IA, egress uint16, peers []uint16,
 	expTime uint8, ts time.Time, beta uint16) ([]seg.PeerEntry, [][]byte, error) {
 
 	peerEntries := make([]seg.PeerEntry, 0, len(peers))
 	peerEpicMacs := make([][]byte, 0, len(peers))
 	for _, peer := range peers {
+		// Peering links attached to another ISD-AS of the AS cannot be used
+		// in the segments of this ISD-AS.
+		if intf := s.Intfs.Get(peer); intf != nil && intf.TopoInfo().LocalIAOr(s.IA) != local {
+			continue
+		}
 		peerEntry, epicMac, err := s.createPeerEntry(peer, egress, expTime, ts, beta)
 		if err != nil {
 			log.Debug("Ignoring peer link upon error",
@@ -271,6 +294,30 @@ func (s *DefaultExtender) createPeerEntry(ingress, egress uint16, expTime uint8,
 	}, epicMac, nil
 }
 
+// localIA returns the local ISD-AS the segment is extended for, i.e., the one
+// the ingress and egress interfaces are attached to.
+func (s *DefaultExtender) localIA(ingress, egress uint16) (addr.IA, error) {
+	local := addr.IA(0)
+	for _, ifID := range []uint16{ingress, egress} {
+		if ifID == 0 {
+			continue
+		}
+		intf := s.Intfs.Get(ifID)
+		if intf == nil {
+			return 0, serrors.New("interface not found", "interface", ifID)
+		}
+		ia := intf.TopoInfo().LocalIAOr(s.IA)
+		if !local.IsZero() && ia != local {
+			return 0, serrors.New(
+				"ingress and egress interfaces are attached to different ISD-ASes",
+				"ingress_interface", ingress, "ingress_isd_as", local,
+				"egress_interface", egress, "egress_isd_as", ia)
+		}
+		local = ia
+	}
+	return local, nil
+}
+
 func (s *DefaultExtender) remoteIA(ifID uint16) (addr.IA, error) {
 	if ifID == 0 {
 		return 0, nil
@@ -32,6 +32,7 @@ import (
 	"github.com/scionproto/scion/control/beacon"
 	"github.com/scionproto/scion/control/beaconing"
 	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/xtest/graph"
 	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
 	"github.com/scionproto/scion/pkg/scrypto"
@@ -360,6 +361,78 @@ func TestDefaultExtenderExtend(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("AS entries are created for the local ISD-AS of the interfaces", func(t *testing.T) {
+		additionalIA := addr.MustParseIA("2-ff00:0:111")
+		additionalPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		newExtender := func(t *testing.T, signers map[addr.IA]beaconing.SignerGen,
+			attached ...uint16) *beaconing.DefaultExtender {
+
+			infos := interfaceInfos(topo)
+			for _, ifID := range attached {
+				info := infos[ifID]
+				info.LocalIA = additionalIA
+				infos[ifID] = info
+			}
+			intfs := ifstate.NewInterfaces(infos, ifstate.Config{})
+			intfs.Get(graph.If_111_C_121_X).Activate(peerRemoteIfs[graph.If_111_C_121_X])
+			return &beaconing.DefaultExtender{
+				IA: topo.IA(),
+				SignerGen: testSignerGen{
+					Signers: []trust.Signer{testSigner(t, priv, topo.IA())},
+				},
+				AdditionalSignerGens: signers,
+				MAC: func() hash.Hash {
+					mac, err := scrypto.InitMac(make([]byte, 16))
+					require.NoError(t, err)
+					return mac
+				},
+				Intfs:      intfs,
+				MTU:        1337,
+				MaxExpTime: func() uint8 { return beacon.DefaultMaxExpTime },
+				StaticInfo: func() *beaconing.StaticInfoCfg { return nil },
+			}
+		}
+		signers := map[addr.IA]beaconing.SignerGen{
+			additionalIA: testSignerGen{
+				Signers: []trust.Signer{testSigner(t, additionalPriv, additionalIA)},
+			},
+		}
+
+		t.Run("additional ISD-AS", func(t *testing.T) {
+			ext := newExtender(t, signers, graph.If_111_A_112_X)
+			pseg, err := seg.CreateSegment(time.Now(), uint16(mrand.Int()))
+			require.NoError(t, err)
+			err = ext.Extend(context.Background(), pseg, 0, graph.If_111_A_112_X,
+				[]uint16{graph.If_111_C_121_X})
+			require.NoError(t, err)
+			entry := pseg.ASEntries[0]
+			assert.Equal(t, additionalIA, entry.Local)
+			// The peering link is attached to the ISD-AS of the topology.
+			assert.Empty(t, entry.PeerEntries)
+			err = pseg.VerifyASEntry(context.Background(),
+				segVerifier{pubKey: additionalPriv.Public()}, 0)
+			assert.NoError(t, err)
+		})
+		t.Run("missing signer", func(t *testing.T) {
+			ext := newExtender(t, nil, graph.If_111_A_112_X)
+			pseg, err := seg.CreateSegment(time.Now(), uint16(mrand.Int()))
+			require.NoError(t, err)
+			err = ext.Extend(context.Background(), pseg, 0, graph.If_111_A_112_X, nil)
+			assert.Error(t, err)
+		})
+		t.Run("ingress and egress attached to different ISD-ASes", func(t *testing.T) {
+			ext := newExtender(t, signers, graph.If_111_A_112_X)
+			pseg, err := seg.CreateSegment(time.Now(), uint16(mrand.Int()))
+			require.NoError(t, err)
+			require.NoError(t, ext.Extend(context.Background(), pseg, 0, graph.If_111_B_120_X, nil))
+			err = ext.Extend(context.Background(), pseg, graph.If_111_B_120_X,
+				graph.If_111_A_112_X, nil)
+			assert.ErrorContains(t, err, "different ISD-ASes")
+			assert.Len(t, pseg.ASEntries, 1)
+		})
+	})
 }
 
 type failSigner struct{}
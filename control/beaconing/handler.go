@@ -16,6 +16,7 @@ package beaconing
 
 import (
 	"context"
+	"net"
 	"strconv"
 
 	"github.com/opentracing/opentracing-go"
@@ -41,14 +42,26 @@ type BeaconInserter interface {
 	InsertBeacon(ctx context.Context, beacon beacon.Beacon) (beacon.InsertStats, error)
 }
 
+// SegmentVerifier verifies the path segments of beacons.
+type SegmentVerifier interface {
+	VerifySegment(ctx context.Context, server net.Addr, segment *seg.PathSegment) error
+}
+
 // Handler handles beacons.
 type Handler struct {
 	LocalIA    addr.IA
 	Inserter   BeaconInserter
 	Verifier   infra.Verifier
 	Interfaces *ifstate.Interfaces
+	// SegmentVerifier, if set, verifies the segments of the beacons instead of Verifier, e.g.,
+	// a segverifier.CachingVerifier.
+	SegmentVerifier SegmentVerifier
+	// Anomalies are run in order on the verified beacons before they are inserted.
+	Anomalies []AnomalyHook
 
 	BeaconsHandled metrics.Counter
+	// AnomaliesDetected counts the beacons flagged by the anomaly detectors.
+	AnomaliesDetected metrics.Counter
 }
 
 // HandleBeacon handles a baeacon received from peer.
@@ -89,6 +102,10 @@ func (h Handler) HandleBeacon(ctx context.Context, b beacon.Beacon, peer *snet.U
 		h.updateMetric(span, labels.WithResult(prom.ErrVerify), err)
 		return serrors.Wrap("verifying beacon", err)
 	}
+	if err := h.detectAnomalies(ctx, b, upstream); err != nil {
+		h.updateMetric(span, labels.WithResult("err_anomaly"), err)
+		return err
+	}
 	stat, err := h.Inserter.InsertBeacon(ctx, b)
 	if err != nil {
 		logger.Debug("Failed to insert beacon", "err", err)
@@ -113,9 +130,9 @@ func (h Handler) validateASEntry(b beacon.Beacon, intf *ifstate.Interface) error
 		return serrors.New("invalid upstream ISD-AS",
 			"expected", topoInfo.IA, "actual", asEntry.Local)
 	}
-	if !asEntry.Next.Equal(h.LocalIA) {
+	if local := topoInfo.LocalIAOr(h.LocalIA); !asEntry.Next.Equal(local) {
 		return serrors.New("next ISD-AS of upstream AS entry does not match local ISD-AS",
-			"expected", h.LocalIA, "actual", asEntry.Next)
+			"expected", local, "actual", asEntry.Next)
 	}
 	return nil
 }
@@ -133,6 +150,9 @@ func (h Handler) verifySegment(ctx context.Context, segment *seg.PathSegment,
 		NextHop: peerPath.UnderlayNextHop(),
 		SVC:     addr.SvcCS,
 	}
+	if h.SegmentVerifier != nil {
+		return h.SegmentVerifier.VerifySegment(ctx, svcToQuery, segment)
+	}
 	return segverifier.VerifySegment(ctx, h.Verifier, svcToQuery, segment)
 }
 
@@ -17,8 +17,10 @@ package beaconing_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -32,6 +34,7 @@ import (
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/segment/segverifier"
 	mock_infra "github.com/scionproto/scion/private/segment/verifier/mock_verifier"
 	"github.com/scionproto/scion/private/topology"
 )
@@ -276,6 +279,70 @@ func TestHandlerHandleBeacon(t *testing.T) {
 	}
 }
 
+func TestHandlerHandleBeaconAttachedInterface(t *testing.T) {
+	topo, err := topology.FromJSONFile("testdata/topology-core.json")
+	require.NoError(t, err)
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+	b := beacon.Beacon{
+		Segment: testSegment(g, []uint16{graph.If_220_X_120_B, graph.If_120_A_110_X}),
+		InIfID:  localIF,
+	}
+
+	// The ingress interface is attached to another ISD-AS of the core AS
+	// than the one the upstream AS sent the beacon to.
+	infos := interfaceInfos(topo)
+	info := infos[localIF]
+	info.LocalIA = addr.MustParseIA("2-ff00:0:110")
+	infos[localIF] = info
+	inserter := mock_beaconing.NewMockBeaconInserter(mctrl)
+	inserter.EXPECT().PreFilter(gomock.Any()).Return(nil)
+	handler := beaconing.Handler{
+		LocalIA:    localIA,
+		Inserter:   inserter,
+		Interfaces: ifstate.NewInterfaces(infos, ifstate.Config{}),
+		Verifier:   mock_infra.NewMockVerifier(mctrl),
+	}
+	err = handler.HandleBeacon(context.Background(), b, &snet.UDPAddr{Path: path.SCION{}})
+	assert.ErrorContains(t, err, "does not match local ISD-AS")
+}
+
+func TestHandlerHandleBeaconCachedVerification(t *testing.T) {
+	topo, err := topology.FromJSONFile("testdata/topology-core.json")
+	require.NoError(t, err)
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+	b := beacon.Beacon{
+		Segment: testSegment(g, []uint16{graph.If_220_X_120_B, graph.If_120_A_110_X}),
+		InIfID:  localIF,
+	}
+
+	inserter := mock_beaconing.NewMockBeaconInserter(mctrl)
+	inserter.EXPECT().PreFilter(gomock.Any()).Return(nil).Times(2)
+	inserter.EXPECT().InsertBeacon(gomock.Any(), b).Return(beacon.InsertStats{}, nil).Times(2)
+	// The AS entries are only verified when the beacon is received for the first time.
+	verifier := mock_infra.NewMockVerifier(mctrl)
+	verifier.EXPECT().WithServer(gomock.Any()).Times(2).Return(verifier)
+	verifier.EXPECT().WithIA(gomock.Any()).Times(2).Return(verifier)
+	verifier.EXPECT().WithValidity(gomock.Any()).Times(2).Return(verifier)
+	verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil, nil)
+	handler := beaconing.Handler{
+		LocalIA:    localIA,
+		Inserter:   inserter,
+		Interfaces: testInterfaces(topo),
+		SegmentVerifier: &segverifier.CachingVerifier{
+			Verifier: verifier,
+			Cache:    cache.New(time.Minute, time.Minute),
+		},
+	}
+	for i := 0; i < 2; i++ {
+		err := handler.HandleBeacon(context.Background(), b, &snet.UDPAddr{Path: path.SCION{}})
+		require.NoError(t, err)
+	}
+}
+
 func testSegment(g *graph.Graph, ifIDs []uint16) *seg.PathSegment {
 	pseg := g.Beacon(ifIDs)
 	pseg.ASEntries = pseg.ASEntries[:len(pseg.ASEntries)-1]
@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/scionproto/scion/control/ifstate"
@@ -72,6 +73,8 @@ type Originator struct {
 
 	// Tick is mutable.
 	Tick Tick
+	// force indicates that the next run should originate on all interfaces.
+	force atomic.Bool
 }
 
 // Name returns the tasks name.
@@ -82,10 +85,20 @@ func (o *Originator) Name() string {
 // Run originates core and downstream beacons.
 func (o *Originator) Run(ctx context.Context) {
 	o.Tick.SetNow(time.Now())
+	if o.force.Swap(false) {
+		o.Tick.reset()
+	}
 	o.originateBeacons(ctx)
 	o.Tick.UpdateLast()
 }
 
+// Force makes the next run originate beacons on all interfaces, even if the
+// origination interval has not passed yet. It can be called concurrently with
+// Run.
+func (o *Originator) Force() {
+	o.force.Store(true)
+}
+
 // originateBeacons creates and sends a beacon for each active interface.
 func (o *Originator) originateBeacons(ctx context.Context) {
 	intfs := o.needBeacon(o.OriginationInterfaces())
@@ -177,6 +177,47 @@ func TestOriginatorRun(t *testing.T) {
 		// Fourth run. Since period has passed, two writes are expected.
 		o.Run(context.Background())
 	})
+	t.Run("Force originates on all interfaces", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		intfs := ifstate.NewInterfaces(interfaceInfos(topo), ifstate.Config{})
+		senderFactory := mock_beaconing.NewMockSenderFactory(mctrl)
+		sender := mock_beaconing.NewMockSender(mctrl)
+		o := beaconing.Originator{
+			Extender: &beaconing.DefaultExtender{
+				IA:         topo.IA(),
+				MTU:        topo.MTU(),
+				SignerGen:  testSignerGen{Signers: []trust.Signer{signer}},
+				Intfs:      intfs,
+				MAC:        macFactory,
+				MaxExpTime: func() uint8 { return beacon.DefaultMaxExpTime },
+				StaticInfo: func() *beaconing.StaticInfoCfg { return nil },
+			},
+			SenderFactory: senderFactory,
+			IA:            topo.IA(),
+			Signer:        signer,
+			AllInterfaces: intfs,
+			OriginationInterfaces: func() []*ifstate.Interface {
+				return intfs.Filtered(originationFilter)
+			},
+			Tick: beaconing.NewTick(time.Hour),
+		}
+
+		senderFactory.EXPECT().NewSender(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any()).Times(8).Return(sender, nil)
+		sender.EXPECT().Send(gomock.Any(), gomock.Any()).Times(8).Return(nil)
+		sender.EXPECT().Close().Times(8)
+
+		// Initial run. Writes on all interfaces expected.
+		o.Run(context.Background())
+		// Second run. No write expected, since the period has not passed.
+		o.Run(context.Background())
+		// Forced run. Writes on all interfaces expected.
+		o.Force()
+		o.Run(context.Background())
+		// The force only applies to one run.
+		o.Run(context.Background())
+	})
 }
 
 type segVerifier struct {
@@ -20,6 +20,7 @@ import (
 	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/scionproto/scion/control/beacon"
@@ -30,6 +31,7 @@ import (
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/pkg/segment/iface"
 	"github.com/scionproto/scion/private/periodic"
 	"github.com/scionproto/scion/private/topology"
 )
@@ -45,6 +47,17 @@ type BeaconProvider interface {
 	BeaconsToPropagate(ctx context.Context) ([]beacon.Beacon, error)
 }
 
+// EgressBeaconProvider provides the beacons to send to neighboring ASes per egress interface.
+// A beacon provider that implements it selects the beacons for each egress interface
+// separately, taking the intra-AS latency into account if the selection algorithm does.
+type EgressBeaconProvider interface {
+	BeaconsToPropagatePerEgress(
+		ctx context.Context,
+		egresses []uint16,
+		intra beacon.IntraLatency,
+	) (map[uint16][]beacon.Beacon, error)
+}
+
 var _ periodic.Task = (*Propagator)(nil)
 
 // Propagator forwards beacons to neighboring ASes. In a core AS, the beacons
@@ -60,12 +73,16 @@ type Propagator struct {
 	AllInterfaces         *ifstate.Interfaces
 	PropagationInterfaces func() []*ifstate.Interface
 	AllowIsdLoop          bool
+	// StaticInfo optionally provides the intra-AS latencies for the per-egress selection.
+	StaticInfo func() *StaticInfoCfg
 
 	Propagated     metrics.Counter
 	InternalErrors metrics.Counter
 
 	// Tick is mutable.
 	Tick Tick
+	// force indicates that the next run should propagate on all interfaces.
+	force atomic.Bool
 }
 
 // Name returns the tasks name.
@@ -79,12 +96,22 @@ func (p *Propagator) Name() string {
 // interfaces.
 func (p *Propagator) Run(ctx context.Context) {
 	p.Tick.SetNow(time.Now())
+	if p.force.Swap(false) {
+		p.Tick.reset()
+	}
 	if err := p.run(ctx); err != nil {
 		withSilent(ctx, p.Tick.Passed()).Error("Unable to propagate beacons", "err", err)
 	}
 	p.Tick.UpdateLast()
 }
 
+// Force makes the next run propagate beacons on all interfaces, even if the
+// propagation interval has not passed yet. It can be called concurrently with
+// Run.
+func (p *Propagator) Force() {
+	p.force.Store(true)
+}
+
 func (p *Propagator) run(ctx context.Context) error {
 	intfs := p.needsBeacons()
 	if len(intfs) == 0 {
@@ -163,22 +190,16 @@ func (p *Propagator) beaconsPerInterface(
 	intfs []*ifstate.Interface,
 ) (map[*ifstate.Interface][]beacon.Beacon, error) {
 
-	allBeacons, err := p.Provider.BeaconsToPropagate(ctx)
+	perEgress, err := p.beaconsToPropagate(ctx, intfs)
 	if err != nil {
 		return nil, serrors.Wrap("fetching beacons to propagate", err)
 	}
-	var beacons []beacon.Beacon
-	for _, b := range allBeacons {
-		if p.AllInterfaces.Get(b.InIfID) == nil {
-			continue
-		}
-		beacons = append(beacons, b)
-	}
 	r := make(map[*ifstate.Interface][]beacon.Beacon)
 	for _, intf := range intfs {
+		beacons := perEgress(intf.TopoInfo().ID)
 		toPropagate := make([]beacon.Beacon, 0, len(beacons))
 		for _, b := range beacons {
-			if p.shouldIgnore(b, intf) {
+			if p.AllInterfaces.Get(b.InIfID) == nil || p.shouldIgnore(b, intf) {
 				continue
 			}
 			ps, err := seg.BeaconFromPB(seg.PathSegmentToPB(b.Segment))
@@ -192,10 +213,58 @@ func (p *Propagator) beaconsPerInterface(
 	return r, nil
 }
 
+// beaconsToPropagate fetches the beacons to propagate and returns a function that provides the
+// beacons for each egress interface. If the provider does not select the beacons per egress
+// interface, the same beacons are provided for all of them.
+func (p *Propagator) beaconsToPropagate(
+	ctx context.Context,
+	intfs []*ifstate.Interface,
+) (func(egress uint16) []beacon.Beacon, error) {
+
+	if provider, ok := p.Provider.(EgressBeaconProvider); ok {
+		egresses := make([]uint16, 0, len(intfs))
+		for _, intf := range intfs {
+			egresses = append(egresses, intf.TopoInfo().ID)
+		}
+		beacons, err := provider.BeaconsToPropagatePerEgress(ctx, egresses, p.intraLatency())
+		if err != nil {
+			return nil, err
+		}
+		return func(egress uint16) []beacon.Beacon { return beacons[egress] }, nil
+	}
+	beacons, err := p.Provider.BeaconsToPropagate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return func(uint16) []beacon.Beacon { return beacons }, nil
+}
+
+// intraLatency returns the intra-AS latencies of the static info configuration, or nil if no
+// configuration is available.
+func (p *Propagator) intraLatency() beacon.IntraLatency {
+	if p.StaticInfo == nil {
+		return nil
+	}
+	cfg := p.StaticInfo()
+	if cfg == nil {
+		return nil
+	}
+	return func(ingress, egress uint16) (time.Duration, bool) {
+		l, ok := cfg.Latency[iface.ID(ingress)].Intra[iface.ID(egress)]
+		return l.Duration, ok
+	}
+}
+
 // shouldIgnore indicates whether a beacon should not be sent on the egress
-// interface because it creates a loop.
+// interface because it creates a loop, or because the ingress interface is
+// attached to another ISD-AS of the local AS than the egress interface.
 func (p *Propagator) shouldIgnore(bseg beacon.Beacon, intf *ifstate.Interface) bool {
-	if err := beacon.FilterLoop(bseg, intf.TopoInfo().IA, p.AllowIsdLoop); err != nil {
+	topoInfo := intf.TopoInfo()
+	ingress := p.AllInterfaces.Get(bseg.InIfID)
+	if ingress.TopoInfo().LocalIAOr(p.IA) != topoInfo.LocalIAOr(p.IA) {
+		return true
+	}
+	if err := beacon.FilterLoop(bseg, topoInfo.IA, p.AllowIsdLoop); err != nil {
 		return true
 	}
 	return false
@@ -0,0 +1,70 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stability adapts the beaconing intervals to the stability of the network.
+//
+// Right after a topology change, e.g., an added interface or a revocation, the
+// intervals are shortened to MinScale times the configured intervals, such that
+// beacons reflecting the change spread quickly. While no further change is
+// detected, the intervals double after every beaconing round, up to MaxScale
+// times the configured intervals.
+type Stability struct {
+	// MinScale is the factor applied to the configured intervals right after a
+	// change. It must be in (0, 1].
+	MinScale float64
+	// MaxScale is the factor applied to the configured intervals in steady
+	// state. It must be at least 1.
+	MaxScale float64
+
+	// lastChange is the time, in Unix nanoseconds, of the last change.
+	lastChange atomic.Int64
+}
+
+// NewStability creates a new stability tracker. The start of the service counts
+// as a change.
+func NewStability(minScale, maxScale float64) *Stability {
+	s := &Stability{
+		MinScale: minScale,
+		MaxScale: maxScale,
+	}
+	s.Change(time.Now())
+	return s
+}
+
+// Change records a topology change at the given time. It can be called
+// concurrently with the beaconing tasks.
+func (s *Stability) Change(now time.Time) {
+	s.lastChange.Store(now.UnixNano())
+}
+
+// Interval returns the interval to use instead of the configured interval,
+// given the last time the task ran. The interval is the time between the last
+// change and the last run, bounded by MinScale and MaxScale times the
+// configured interval. Thus, it doubles with every run after a change.
+func (s *Stability) Interval(interval time.Duration, last time.Time) time.Duration {
+	minInterval := time.Duration(float64(interval) * s.MinScale)
+	maxInterval := time.Duration(float64(interval) * s.MaxScale)
+	stable := time.Duration(last.UnixNano() - s.lastChange.Load())
+	if last.IsZero() || stable < minInterval {
+		return minInterval
+	}
+	return min(stable, maxInterval)
+}
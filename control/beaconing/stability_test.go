@@ -0,0 +1,86 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/control/beaconing"
+)
+
+func TestStabilityInterval(t *testing.T) {
+	s := beaconing.Stability{MinScale: 0.2, MaxScale: 4}
+	change := time.Now()
+	s.Change(change)
+
+	testCases := map[string]struct {
+		last     time.Time
+		expected time.Duration
+	}{
+		"never run": {
+			expected: time.Second,
+		},
+		"run before the change": {
+			last:     change.Add(-time.Minute),
+			expected: time.Second,
+		},
+		"run right after the change": {
+			last:     change.Add(100 * time.Millisecond),
+			expected: time.Second,
+		},
+		"run while stable": {
+			last:     change.Add(3 * time.Second),
+			expected: 3 * time.Second,
+		},
+		"run in steady state": {
+			last:     change.Add(time.Hour),
+			expected: 20 * time.Second,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, s.Interval(5*time.Second, tc.last))
+		})
+	}
+}
+
+func TestAdaptiveTick(t *testing.T) {
+	s := beaconing.NewStability(0.2, 4)
+	tick := beaconing.NewAdaptiveTick(5*time.Second, s)
+	now := time.Now()
+	tick.SetNow(now)
+	assert.True(t, tick.Passed())
+	tick.UpdateLast()
+
+	// Steady state: the period is stretched to the maximum.
+	s.Change(now.Add(-time.Hour))
+	tick.SetNow(now.Add(19 * time.Second))
+	assert.False(t, tick.Passed())
+	tick.SetNow(now.Add(20 * time.Second))
+	assert.True(t, tick.Passed())
+
+	// A change shortens the period to the minimum.
+	s.Change(now.Add(10 * time.Second))
+	tick.SetNow(now.Add(11 * time.Second))
+	assert.True(t, tick.Passed())
+	tick.UpdateLast()
+	tick.SetNow(now.Add(11*time.Second + 900*time.Millisecond))
+	assert.False(t, tick.Passed())
+	tick.SetNow(now.Add(12 * time.Second))
+	assert.True(t, tick.Passed())
+}
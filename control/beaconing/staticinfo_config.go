@@ -16,7 +16,9 @@ package beaconing
 
 import (
 	"encoding/json"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -101,10 +103,77 @@ func ParseStaticInfoCfg(file string) (*StaticInfoCfg, error) {
 			err, "file ", file)
 
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, serrors.Wrap("invalid static info config", err, "file", file)
+	}
 	cfg.clean()
 	return &cfg, nil
 }
 
+// validate checks that the values of the static info configuration can be announced.
+func (cfg *StaticInfoCfg) validate() error {
+	for ifID, l := range cfg.Latency {
+		if l.Inter.Duration < 0 {
+			return serrors.New("negative inter-AS latency", "interface", ifID)
+		}
+		for other, v := range l.Intra {
+			if v.Duration < 0 {
+				return serrors.New("negative intra-AS latency",
+					"interface", ifID, "other", other)
+			}
+		}
+	}
+	for ifID, g := range cfg.Geo {
+		if g.Latitude < -90 || g.Latitude > 90 {
+			return serrors.New("latitude out of range", "interface", ifID,
+				"latitude", g.Latitude)
+		}
+		if g.Longitude < -180 || g.Longitude > 180 {
+			return serrors.New("longitude out of range", "interface", ifID,
+				"longitude", g.Longitude)
+		}
+	}
+	return nil
+}
+
+// UnknownInterfaces returns the interfaces for which the static info configuration has entries,
+// but which are not interfaces of the AS. The metadata of these interfaces is never announced,
+// which usually indicates a mistake in the configuration.
+func (cfg StaticInfoCfg) UnknownInterfaces(intfs *ifstate.Interfaces) []iface.ID {
+	known := intfs.All()
+	unknown := map[iface.ID]struct{}{}
+	check := func(ifID iface.ID) {
+		if _, ok := known[uint16(ifID)]; !ok {
+			unknown[ifID] = struct{}{}
+		}
+	}
+	for ifID, l := range cfg.Latency {
+		check(ifID)
+		for other := range l.Intra {
+			check(other)
+		}
+	}
+	for ifID, bw := range cfg.Bandwidth {
+		check(ifID)
+		for other := range bw.Intra {
+			check(other)
+		}
+	}
+	for ifID := range cfg.LinkType {
+		check(ifID)
+	}
+	for ifID := range cfg.Geo {
+		check(ifID)
+	}
+	for ifID, h := range cfg.Hops {
+		check(ifID)
+		for other := range h.Intra {
+			check(other)
+		}
+	}
+	return slices.Sorted(maps.Keys(unknown))
+}
+
 // clean checks or corrects the entries in the static info configuration.
 // In particular, it will
 //   - ensure there are no entries for the 0 interface ID (as this is invalid
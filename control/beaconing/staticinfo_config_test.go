@@ -15,12 +15,17 @@
 package beaconing_test
 
 import (
+	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/control/ifstate"
 	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/pkg/segment/extensions/staticinfo"
 	"github.com/scionproto/scion/pkg/segment/iface"
@@ -217,6 +222,41 @@ func TestParsing(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestParsingInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"negative latency": `{"Latency": {"1": {"Inter": "-1ms"}}}`,
+		"latitude":         `{"Geo": {"1": {"Latitude": 91, "Longitude": 8.5}}}`,
+		"longitude":        `{"Geo": {"1": {"Latitude": 47.3, "Longitude": -181}}}`,
+		"link type":        `{"LinkType": {"1": "satellite"}}`,
+	}
+	for name, raw := range testCases {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "staticInfoConfig.json")
+			require.NoError(t, os.WriteFile(file, []byte(raw), 0644))
+			_, err := beaconing.ParseStaticInfoCfg(file)
+			assert.Error(t, err)
+		})
+	}
+	t.Run("missing file", func(t *testing.T) {
+		_, err := beaconing.ParseStaticInfoCfg(filepath.Join(t.TempDir(), "missing.json"))
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
+
+func TestUnknownInterfaces(t *testing.T) {
+	cfg := getTestConfigData()
+	intfs := ifstate.NewInterfaces(map[uint16]ifstate.InterfaceInfo{
+		1: {ID: 1},
+		2: {ID: 2},
+		3: {ID: 3},
+	}, ifstate.Config{})
+	assert.Equal(t, []iface.ID{5}, cfg.UnknownInterfaces(intfs))
+
+	cfg.Geo[7] = beaconing.InterfaceGeodata{}
+	cfg.Hops[1].Intra[6] = 1
+	assert.Equal(t, []iface.ID{5, 6, 7}, cfg.UnknownInterfaces(intfs))
+}
+
 func TestGenerateStaticInfo(t *testing.T) {
 	cfg := getTestConfigData()
 
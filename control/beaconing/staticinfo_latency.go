@@ -0,0 +1,158 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/segment/iface"
+)
+
+// LinkLatencyFetcher periodically queries the management APIs of the border routers for the
+// latencies they measure on their inter-AS links, and provides the static info configuration
+// with the configured inter-AS latencies replaced by the measured ones.
+//
+// The routers measure the round trip time of the BFD sessions to their neighbors; half of it is
+// used as the latency of the link. The links for which no router reports a measurement keep
+// their configured latency.
+type LinkLatencyFetcher struct {
+	// Static is the configured static info. It may be nil.
+	Static *StaticInfoCfg
+	// RouterAPIs contains the addresses of the management APIs of the border routers.
+	RouterAPIs []string
+	// Client is used to query the routers. If it is nil, http.DefaultClient is used.
+	Client *http.Client
+
+	// measured contains the last successfully fetched latencies of each router. It is only
+	// accessed by Run.
+	measured map[string]map[iface.ID]time.Duration
+	current  atomic.Pointer[StaticInfoCfg]
+}
+
+// Name returns the tasks name.
+func (f *LinkLatencyFetcher) Name() string {
+	return "control_beaconing_link_latency_fetcher"
+}
+
+// Run queries all routers and updates the static info configuration. If a router cannot be
+// queried, its previous measurements are used.
+func (f *LinkLatencyFetcher) Run(ctx context.Context) {
+	if f.measured == nil {
+		f.measured = make(map[string]map[iface.ID]time.Duration)
+	}
+	for _, api := range f.RouterAPIs {
+		latencies, err := f.fetch(ctx, api)
+		if err != nil {
+			log.FromCtx(ctx).Info("Failed to fetch link latencies", "router_api", api,
+				"err", err)
+			continue
+		}
+		f.measured[api] = latencies
+	}
+	f.current.Store(f.merge())
+}
+
+// StaticInfo returns the static info configuration with the measured inter-AS latencies.
+func (f *LinkLatencyFetcher) StaticInfo() *StaticInfoCfg {
+	if cfg := f.current.Load(); cfg != nil {
+		return cfg
+	}
+	return f.Static
+}
+
+func (f *LinkLatencyFetcher) fetch(ctx context.Context,
+	api string) (map[iface.ID]time.Duration, error) {
+
+	url := api
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	url = strings.TrimSuffix(url, "/") + "/api/v1/interfaces"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	rep, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rep.Body.Close()
+	if rep.StatusCode != http.StatusOK {
+		return nil, serrors.New("unexpected status", "status", rep.Status)
+	}
+	// Only the fields that are needed here are decoded, so that the control service does not
+	// depend on the router's API package.
+	var body struct {
+		Interfaces []struct {
+			InterfaceID iface.ID `json:"interface_id"`
+			RTT         string   `json:"rtt"`
+		} `json:"interfaces"`
+	}
+	if err := json.NewDecoder(rep.Body).Decode(&body); err != nil {
+		return nil, serrors.Wrap("decoding interfaces", err)
+	}
+	latencies := make(map[iface.ID]time.Duration)
+	for _, intf := range body.Interfaces {
+		if intf.RTT == "" {
+			continue
+		}
+		rtt, err := time.ParseDuration(intf.RTT)
+		if err != nil {
+			return nil, serrors.Wrap("parsing round trip time", err,
+				"interface_id", intf.InterfaceID)
+		}
+		if rtt > 0 {
+			latencies[intf.InterfaceID] = rtt / 2
+		}
+	}
+	return latencies, nil
+}
+
+// merge returns a copy of the static info configuration with the measured latencies. The
+// configuration itself is not modified, it may still be in use.
+func (f *LinkLatencyFetcher) merge() *StaticInfoCfg {
+	var cfg StaticInfoCfg
+	if f.Static != nil {
+		cfg = *f.Static
+	}
+	cfg.Latency = maps.Clone(cfg.Latency)
+	if cfg.Latency == nil {
+		cfg.Latency = make(map[iface.ID]InterfaceLatencies)
+	}
+	for _, latencies := range f.measured {
+		for ifID, latency := range latencies {
+			if ifID == 0 {
+				continue
+			}
+			l := cfg.Latency[ifID]
+			l.Inter = util.DurWrap{Duration: latency}
+			cfg.Latency[ifID] = l
+		}
+	}
+	return &cfg
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/segment/iface"
+)
+
+func TestLinkLatencyFetcher(t *testing.T) {
+	reply := `{"interfaces": [
+		{"interface_id": 1, "rtt": "10ms"},
+		{"interface_id": 2},
+		{"interface_id": 3, "rtt": "1.5ms"}
+	]}`
+	router := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/interfaces" || reply == "" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(reply))
+	}))
+	defer router.Close()
+
+	static := &beaconing.StaticInfoCfg{
+		Latency: map[iface.ID]beaconing.InterfaceLatencies{
+			1: {
+				Inter: util.DurWrap{Duration: 30 * time.Millisecond},
+				Intra: map[iface.ID]util.DurWrap{2: {Duration: time.Millisecond}},
+			},
+			2: {Inter: util.DurWrap{Duration: 40 * time.Millisecond}},
+		},
+		Note: "note",
+	}
+	f := &beaconing.LinkLatencyFetcher{
+		Static:     static,
+		RouterAPIs: []string{router.URL},
+	}
+	assert.Same(t, static, f.StaticInfo())
+
+	check := func(t *testing.T) {
+		cfg := f.StaticInfo()
+		require.NotNil(t, cfg)
+		assert.Equal(t, map[iface.ID]beaconing.InterfaceLatencies{
+			1: {
+				Inter: util.DurWrap{Duration: 5 * time.Millisecond},
+				Intra: map[iface.ID]util.DurWrap{2: {Duration: time.Millisecond}},
+			},
+			2: {Inter: util.DurWrap{Duration: 40 * time.Millisecond}},
+			3: {Inter: util.DurWrap{Duration: 750 * time.Microsecond}},
+		}, cfg.Latency)
+		assert.Equal(t, "note", cfg.Note)
+		// The configured static info is not modified.
+		assert.Equal(t, 30*time.Millisecond, static.Latency[1].Inter.Duration)
+		assert.Len(t, static.Latency, 2)
+	}
+	t.Run("measured latencies replace the configured ones", func(t *testing.T) {
+		f.Run(context.Background())
+		check(t)
+	})
+	t.Run("previous measurements are kept on failure", func(t *testing.T) {
+		reply = ""
+		f.Run(context.Background())
+		check(t)
+	})
+	t.Run("no static info", func(t *testing.T) {
+		reply = `{"interfaces": [{"interface_id": 1, "rtt": "10ms"}]}`
+		f := &beaconing.LinkLatencyFetcher{RouterAPIs: []string{router.Listener.Addr().String()}}
+		assert.Nil(t, f.StaticInfo())
+		f.Run(context.Background())
+		assert.Equal(t, map[iface.ID]beaconing.InterfaceLatencies{
+			1: {Inter: util.DurWrap{Duration: 5 * time.Millisecond}},
+		}, f.StaticInfo().Latency)
+	})
+}
@@ -15,6 +15,7 @@
 package beaconing
 
 import (
+	"math/rand/v2"
 	"time"
 )
 
@@ -25,12 +26,33 @@ type Tick struct {
 	now    time.Time
 	last   time.Time
 	period time.Duration
+	// stability adapts the period, if set.
+	stability *Stability
+	// jitter is the maximum deviation of the period, as a fraction of the period.
+	jitter float64
+	// deviation is the deviation of the current period, as a fraction of the period. It is
+	// drawn again whenever the last time is updated.
+	deviation float64
 }
 
 func NewTick(period time.Duration) Tick {
 	return Tick{period: period}
 }
 
+// NewAdaptiveTick creates a Tick whose period is adapted to the stability of the
+// network. If stability is nil, the period is fixed.
+func NewAdaptiveTick(period time.Duration, stability *Stability) Tick {
+	return Tick{period: period, stability: stability}
+}
+
+// SetJitter sets the maximum random deviation of the period, as a fraction of
+// the period. The period deviates by a different amount every time the last time
+// is updated, such that the Ticks of different services do not synchronize.
+func (t *Tick) SetJitter(jitter float64) {
+	t.jitter = jitter
+	t.drawDeviation()
+}
+
 func (t *Tick) SetNow(now time.Time) {
 	t.now = now
 }
@@ -42,11 +64,17 @@ func (t *Tick) Now() time.Time {
 // Overdue returns true if the Tick's period has elapsed since timestamp in the
 // past up to the Tick's Now time.
 func (t *Tick) Overdue(timestamp time.Time) bool {
-	return t.now.Sub(timestamp) > t.period
+	return t.now.Sub(timestamp) > t.Period()
 }
 
+// Period returns the current period. For adaptive Ticks, it depends on the
+// last time. It includes the jitter.
 func (t *Tick) Period() time.Duration {
-	return t.period
+	period := t.period
+	if t.stability != nil {
+		period = t.stability.Interval(t.period, t.last)
+	}
+	return period + time.Duration(float64(period)*t.deviation)
 }
 
 // UpdateLast updates the last time to the current time, if the period has
@@ -54,11 +82,25 @@ func (t *Tick) Period() time.Duration {
 func (t *Tick) UpdateLast() {
 	if t.Passed() {
 		t.last = t.now
+		t.drawDeviation()
 	}
 }
 
+// reset forgets the last time, such that the period is considered passed.
+func (t *Tick) reset() {
+	t.last = time.Time{}
+}
+
 // Passed returns true if the Tick's period has elapsed since the last UpdateLast call up to
 // the Tick's Now time.
 func (t *Tick) Passed() bool {
-	return t.now.Sub(t.last) >= t.period
+	return t.now.Sub(t.last) >= t.Period()
+}
+
+func (t *Tick) drawDeviation() {
+	if t.jitter == 0 {
+		t.deviation = 0
+		return
+	}
+	t.deviation = t.jitter * (2*rand.Float64() - 1)
 }
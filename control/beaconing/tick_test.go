@@ -0,0 +1,48 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/control/beaconing"
+)
+
+func TestTickJitter(t *testing.T) {
+	tick := beaconing.NewTick(10 * time.Second)
+	tick.SetJitter(0.1)
+	tick.SetNow(time.Now())
+	tick.UpdateLast()
+	now := tick.Now()
+	periods := make(map[time.Duration]struct{})
+	for i := 0; i < 20; i++ {
+		period := tick.Period()
+		assert.GreaterOrEqual(t, period, 9*time.Second)
+		assert.LessOrEqual(t, period, 11*time.Second)
+		periods[period] = struct{}{}
+
+		tick.SetNow(now.Add(period - time.Millisecond))
+		assert.False(t, tick.Passed())
+		tick.SetNow(now.Add(period))
+		assert.True(t, tick.Passed())
+		tick.UpdateLast()
+		now = tick.Now()
+	}
+	// The period is drawn again for every round.
+	assert.Greater(t, len(periods), 1)
+}
@@ -17,8 +17,10 @@ package beaconing
 import (
 	"context"
 	"net"
+	"slices"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/scionproto/scion/control/beacon"
@@ -94,6 +96,8 @@ type WriteScheduler struct {
 	Tick Tick
 	// lastWrite indicates the time of the last successful write.
 	lastWrite time.Time
+	// force indicates that the next run should write regardless of the tick.
+	force atomic.Bool
 }
 
 // Name returns the tasks name.
@@ -112,8 +116,14 @@ func (r *WriteScheduler) Run(ctx context.Context) {
 	r.Tick.UpdateLast()
 }
 
+// Force makes the next run write the path segments, even if the registration
+// interval has not passed yet. It can be called concurrently with Run.
+func (r *WriteScheduler) Force() {
+	r.force.Store(true)
+}
+
 func (r *WriteScheduler) run(ctx context.Context) error {
-	if !(r.Tick.Overdue(r.lastWrite) || r.Tick.Passed()) {
+	if !r.force.Swap(false) && !(r.Tick.Overdue(r.lastWrite) || r.Tick.Passed()) {
 		return nil
 	}
 	segments, err := r.Provider.SegmentsToRegister(ctx, r.Type)
@@ -151,9 +161,14 @@ type RemoteWriter struct {
 	RPC RPC
 	// Pather is used to find paths to a remote.
 	Pather Pather
+	// AdditionalCores are the core ASes at which the segments are registered in addition to
+	// the source AS of each segment. This keeps the segments available if the source AS cannot
+	// be reached. The registrations are sent over a segment that starts at the respective core
+	// AS, and do not count towards the returned statistics.
+	AdditionalCores []addr.IA
 }
 
-// Write writes the segment at the source AS of the segment.
+// Write writes the segment at the source AS of the segment, and at the additional core ASes.
 func (r *RemoteWriter) Write(
 	ctx context.Context,
 	segments []beacon.Beacon,
@@ -164,6 +179,7 @@ func (r *RemoteWriter) Write(
 	s := newSummary()
 	var expected int
 	var wg sync.WaitGroup
+	var terminated []beacon.Beacon
 	for _, b := range segments {
 		if r.Intfs.Get(b.InIfID) == nil {
 			continue
@@ -175,6 +191,7 @@ func (r *RemoteWriter) Write(
 			continue
 		}
 		expected++
+		terminated = append(terminated, b)
 		s := remoteWriter{
 			writer:  r,
 			rpc:     r.RPC,
@@ -186,6 +203,9 @@ func (r *RemoteWriter) Write(
 		// Avoid head-of-line blocking when sending message to slow servers.
 		s.start(ctx, b)
 	}
+	for _, core := range r.AdditionalCores {
+		r.writeAtCore(ctx, core, terminated, &wg)
+	}
 	wg.Wait()
 	if expected > 0 && s.count <= 0 {
 		return WriteStats{}, serrors.New("no beacons registered", "candidates", expected)
@@ -193,6 +213,43 @@ func (r *RemoteWriter) Write(
 	return WriteStats{Count: s.count, StartIAs: s.srcs}, nil
 }
 
+// writeAtCore registers the segments that do not start at the core AS with that core AS.
+func (r *RemoteWriter) writeAtCore(
+	ctx context.Context,
+	core addr.IA,
+	segments []beacon.Beacon,
+	wg *sync.WaitGroup,
+) {
+
+	logger := log.FromCtx(ctx)
+	i := slices.IndexFunc(segments, func(b beacon.Beacon) bool {
+		return b.Segment.FirstIA() == core
+	})
+	if i < 0 {
+		logger.Debug("No segment to registration core", "core", core)
+		return
+	}
+	addr, err := r.Pather.GetPath(addr.SvcCS, segments[i].Segment)
+	if err != nil {
+		logger.Error("Unable to choose server", "core", core, "err", err)
+		metrics.CounterInc(r.InternalErrors)
+		return
+	}
+	w := remoteWriter{
+		writer:  r,
+		rpc:     r.RPC,
+		pather:  r.Pather,
+		summary: newSummary(),
+		wg:      wg,
+	}
+	for _, b := range segments {
+		if b.Segment.FirstIA() == core {
+			continue
+		}
+		w.startSendSegReg(ctx, b, seg.Meta{Type: r.Type, Segment: b.Segment}, addr)
+	}
+}
+
 // LocalWriter can be used to write segments in the SegmentStore.
 type LocalWriter struct {
 	// InternalErrors counts errors that happened before being able to send a
@@ -323,6 +323,109 @@ func TestRegistrarRun(t *testing.T) {
 			})
 		r.Run(context.Background())
 	})
+
+	t.Run("Segments are registered at the additional cores", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+
+		topo, err := topology.FromJSONFile(topoNonCore)
+		require.NoError(t, err)
+		intfs := ifstate.NewInterfaces(interfaceInfos(topo), ifstate.Config{})
+		segProvider := mock_beaconing.NewMockSegmentProvider(mctrl)
+		rpc := mock_beaconing.NewMockRPC(mctrl)
+		core120, core130 := addr.MustParseIA("1-ff00:0:120"), addr.MustParseIA("1-ff00:0:130")
+
+		r := beaconing.WriteScheduler{
+			Writer: &beaconing.RemoteWriter{
+				Extender: &beaconing.DefaultExtender{
+					IA:  topo.IA(),
+					MTU: topo.MTU(),
+					SignerGen: testSignerGen{
+						Signers: []trust.Signer{testSigner(t, priv, topo.IA())},
+					},
+					Intfs:      intfs,
+					MAC:        macFactory,
+					MaxExpTime: func() uint8 { return beacon.DefaultMaxExpTime },
+					StaticInfo: func() *beaconing.StaticInfoCfg { return nil },
+				},
+				Pather: addrutil.Pather{
+					NextHopper: topoWrap{Topo: topo},
+				},
+				RPC:             rpc,
+				Intfs:           intfs,
+				Type:            seg.TypeDown,
+				AdditionalCores: []addr.IA{core120, addr.MustParseIA("1-ff00:0:110")},
+			},
+			Intfs:    intfs,
+			Tick:     beaconing.NewTick(time.Hour),
+			Provider: segProvider,
+			Type:     seg.TypeDown,
+		}
+
+		g := graph.NewDefaultGraph(mctrl)
+		segProvider.EXPECT().SegmentsToRegister(gomock.Any(), seg.TypeDown).Return(
+			[]beacon.Beacon{
+				testBeacon(g, []uint16{graph.If_120_X_111_B}),
+				testBeacon(g, []uint16{graph.If_130_B_120_A, graph.If_120_X_111_B}),
+			}, nil,
+		)
+		var segMu sync.Mutex
+		sent := make(map[addr.IA][]addr.IA)
+		rpc.EXPECT().RegisterSegment(gomock.Any(), gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+			func(_ context.Context, meta seg.Meta, remote net.Addr) error {
+				segMu.Lock()
+				defer segMu.Unlock()
+				dst := remote.(*snet.SVCAddr).IA
+				sent[dst] = append(sent[dst], meta.Segment.FirstIA())
+				return nil
+			},
+		)
+		r.Run(context.Background())
+
+		// No segment starts at the core AS 110, so nothing is registered there.
+		assert.Len(t, sent, 2)
+		assert.ElementsMatch(t, []addr.IA{core120, core130}, sent[core120])
+		assert.Equal(t, []addr.IA{core130}, sent[core130])
+	})
+}
+
+func TestWriteSchedulerForce(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	segProvider := mock_beaconing.NewMockSegmentProvider(mctrl)
+	segProvider.EXPECT().SegmentsToRegister(gomock.Any(), seg.TypeDown).Return(
+		[]beacon.Beacon{}, nil).Times(2)
+	w := &countingWriter{}
+	r := beaconing.WriteScheduler{
+		Provider: segProvider,
+		Intfs:    ifstate.NewInterfaces(nil, ifstate.Config{}),
+		Type:     seg.TypeDown,
+		Writer:   w,
+		Tick:     beaconing.NewTick(time.Hour),
+	}
+	r.Run(context.Background())
+	assert.Equal(t, 1, w.writes)
+	// The second run does nothing, since the period has not passed.
+	r.Run(context.Background())
+	assert.Equal(t, 1, w.writes)
+	// A forced run writes anyway, but only once.
+	r.Force()
+	r.Run(context.Background())
+	assert.Equal(t, 2, w.writes)
+	r.Run(context.Background())
+	assert.Equal(t, 2, w.writes)
+}
+
+type countingWriter struct {
+	writes int
+}
+
+func (w *countingWriter) Write(context.Context, []beacon.Beacon, []uint16) (
+	beaconing.WriteStats, error) {
+
+	w.writes++
+	return beaconing.WriteStats{Count: 1}, nil
 }
 
 func testBeacon(g *graph.Graph, desc []uint16) beacon.Beacon {
@@ -0,0 +1,89 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	api "github.com/scionproto/scion/private/mgmtapi"
+	cppkiapi "github.com/scionproto/scion/private/mgmtapi/cppki/api"
+	"github.com/scionproto/scion/private/storage"
+)
+
+// NewBootstrapHandler returns the handler of the bootstrap endpoint, which serves the topology
+// and the trust material to the end hosts and tools of the AS. The topology is served at
+// /topology, the TRCs and certificate chains at /trcs and /certificates, in the same
+// representation as in the management API. Every request is authorized with auth first.
+func NewBootstrapHandler(
+	trustDB storage.TrustDB,
+	topology http.HandlerFunc,
+	auth func(http.Handler) http.Handler,
+) http.Handler {
+
+	r := chi.NewRouter()
+	r.Use(auth)
+	r.Get("/topology", topology)
+	s := &bootstrapServer{Server: cppkiapi.Server{TrustDB: trustDB}}
+	return cppkiapi.HandlerFromMux(s, r)
+}
+
+// bootstrapServer serves the trust material. Unlike the management API, it serves the signed
+// TRCs, so that the end hosts can verify them.
+type bootstrapServer struct {
+	cppkiapi.Server
+}
+
+// GetTrcBlob serves the PEM-encoded signed TRC.
+func (s *bootstrapServer) GetTrcBlob(
+	w http.ResponseWriter,
+	r *http.Request,
+	isd int,
+	base int,
+	serial int,
+) {
+
+	trc, err := s.TrustDB.SignedTRC(r.Context(), cppki.TRCID{
+		ISD:    addr.ISD(isd),
+		Base:   scrypto.Version(base),
+		Serial: scrypto.Version(serial),
+	})
+	if err != nil {
+		cppkiapi.Error(w, cppkiapi.Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error getting trc",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	if trc.IsZero() {
+		cppkiapi.Error(w, cppkiapi.Problem{
+			Status: http.StatusNotFound,
+			Title: fmt.Sprintf("trc with isd %d, base %d, serial %d does not exist",
+				isd, base, serial),
+			Type: api.StringRef(api.NotFound),
+		})
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	_ = pem.Encode(w, &pem.Block{Type: "TRC", Bytes: trc.Raw})
+}
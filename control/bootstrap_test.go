@@ -0,0 +1,93 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control_test
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cs "github.com/scionproto/scion/control"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/storage/mock_storage"
+)
+
+func TestBootstrapHandler(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	id := cppki.TRCID{ISD: 1, Base: 1, Serial: 1}
+	trc := cppki.SignedTRC{
+		Raw: []byte("signed trc"),
+		TRC: cppki.TRC{ID: id, Raw: []byte("trc payload")},
+	}
+	db := mock_storage.NewMockTrustDB(mctrl)
+	db.EXPECT().SignedTRC(gomock.Any(), id).Return(trc, nil).AnyTimes()
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Not(id)).Return(cppki.SignedTRC{}, nil).AnyTimes()
+	topology := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("topology"))
+	}
+	auth := func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+	handler := cs.NewBootstrapHandler(db, topology, auth)
+	get := func(t *testing.T, path string, authorized bool) (int, []byte) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if authorized {
+			req.Header.Set("Authorization", "Bearer token")
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		body, err := io.ReadAll(rr.Result().Body)
+		require.NoError(t, err)
+		return rr.Code, body
+	}
+
+	t.Run("topology", func(t *testing.T) {
+		status, body := get(t, "/topology", true)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "topology", string(body))
+	})
+	t.Run("signed TRC", func(t *testing.T) {
+		status, body := get(t, "/trcs/isd1-b1-s1/blob", true)
+		assert.Equal(t, http.StatusOK, status)
+		block, _ := pem.Decode(body)
+		require.NotNil(t, block)
+		assert.Equal(t, "TRC", block.Type)
+		assert.Equal(t, trc.Raw, block.Bytes)
+	})
+	t.Run("unknown TRC", func(t *testing.T) {
+		status, _ := get(t, "/trcs/isd1-b1-s2/blob", true)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		status, _ := get(t, "/trcs/isd1-b1-s1/blob", false)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		status, _ = get(t, "/topology", false)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+}
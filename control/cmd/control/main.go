@@ -19,9 +19,14 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"hash"
+	"io/fs"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"net/netip"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -30,6 +35,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	promgrpc "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/patrickmn/go-cache"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
@@ -44,8 +50,10 @@ import (
 	"github.com/scionproto/scion/control/drkey"
 	drkeygrpc "github.com/scionproto/scion/control/drkey/grpc"
 	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/control/leader"
 	api "github.com/scionproto/scion/control/mgmtapi"
 	"github.com/scionproto/scion/control/onehop"
+	"github.com/scionproto/scion/control/revocation"
 	segreggrpc "github.com/scionproto/scion/control/segreg/grpc"
 	"github.com/scionproto/scion/control/segreq"
 	segreqgrpc "github.com/scionproto/scion/control/segreq/grpc"
@@ -53,6 +61,7 @@ import (
 	cstrustgrpc "github.com/scionproto/scion/control/trust/grpc"
 	cstrustmetrics "github.com/scionproto/scion/control/trust/metrics"
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
 	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
 	libmetrics "github.com/scionproto/scion/pkg/metrics"
@@ -81,6 +90,7 @@ import (
 	"github.com/scionproto/scion/private/periodic"
 	segfetchergrpc "github.com/scionproto/scion/private/segment/segfetcher/grpc"
 	"github.com/scionproto/scion/private/segment/seghandler"
+	"github.com/scionproto/scion/private/segment/segverifier"
 	"github.com/scionproto/scion/private/service"
 	"github.com/scionproto/scion/private/storage"
 	beaconstoragemetrics "github.com/scionproto/scion/private/storage/beacon/metrics"
@@ -128,7 +138,18 @@ func realMain(ctx context.Context) error {
 		defer log.HandlePanic()
 		return topo.Run(errCtx)
 	})
-	intfs := ifstate.NewInterfaces(adaptInterfaceMap(topo.InterfaceInfoMap()), ifstate.Config{})
+	additionalIAs, err := additionalLocalIAs(topo, globalCfg.MultiISD)
+	if err != nil {
+		return err
+	}
+	intfs := ifstate.NewInterfaces(
+		adaptInterfaceMap(topo.InterfaceInfoMap(), topo.IA(), additionalIAs),
+		ifstate.Config{},
+	)
+	var stability *beaconing.Stability
+	if cfg := globalCfg.BS.AdaptiveIntervals; cfg.Enabled {
+		stability = beaconing.NewStability(cfg.MinScale, cfg.MaxScale)
+	}
 	g.Go(func() error {
 		defer log.HandlePanic()
 		sub := topo.Subscribe()
@@ -136,7 +157,12 @@ func realMain(ctx context.Context) error {
 		for {
 			select {
 			case <-sub.Updates:
-				intfs.Update(adaptInterfaceMap(topo.InterfaceInfoMap()))
+				changed := intfs.Update(
+					adaptInterfaceMap(topo.InterfaceInfoMap(), topo.IA(), additionalIAs),
+				)
+				if changed && stability != nil {
+					stability.Change(time.Now())
+				}
 			case <-errCtx.Done():
 				return nil
 			}
@@ -165,6 +191,34 @@ func realMain(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// Authenticate the inter-AS control-plane messages with DRKey. The DRKey engine is set
+	// once it is initialized below.
+	var drkeyAuth *drkey.Authenticator
+	if globalCfg.DRKey.ControlAuth != config.ControlAuthDisabled {
+		drkeyAuth = &drkey.Authenticator{LocalIA: topo.IA()}
+	}
+	revPusher := &revocation.Pusher{
+		IA:            topo.IA(),
+		AllInterfaces: intfs,
+		Notifications: libmetrics.NewPromCounter(metrics.RevocationNotificationsTotal),
+	}
+	for _, a := range globalCfg.PS.Revocations.Daemons {
+		conn, err := daemon.Service{Address: a}.Connect(ctx)
+		if err != nil {
+			return serrors.Wrap("connecting to daemon", err, "address", a)
+		}
+		defer conn.Close()
+		revPusher.Daemons = append(revPusher.Daemons, conn)
+	}
+	if !globalCfg.PS.Revocations.DisableNeighbors {
+		notifier, err := newNeighborNotifier(ctx, topo, macGen())
+		if err != nil {
+			return err
+		}
+		defer notifier.Conn.Close()
+		notifier.Auth = drkeyAuth
+		revPusher.Neighbors = notifier
+	}
 
 	trustDB, err := storage.NewTrustStorage(globalCfg.TrustDB)
 	if err != nil {
@@ -199,6 +253,39 @@ func realMain(ctx context.Context) error {
 	if err := cs.LoadTrustMaterial(ctx, globalCfg.General.ConfigDir, trustDB); err != nil {
 		return err
 	}
+	keyRings, err := cs.NewKeyRings(globalCfg.Keys, globalCfg.General.ConfigDir)
+	if err != nil {
+		return serrors.Wrap("initializing key rings", err)
+	}
+
+	var scmpHandler snet.SCMPHandler = snet.DefaultSCMPHandler{
+		RevocationHandler: cs.RevocationHandler{
+			RevCache:  revCache,
+			Stability: stability,
+			Pusher:    revPusher,
+		},
+		SCMPErrors: metrics.SCMPErrors,
+	}
+	scmpHandler = revocation.AuthHandler{
+		Handler: scmpHandler,
+		LocalIA: topo.IA(),
+		Routers: func() []netip.Addr {
+			var routers []netip.Addr
+			for _, info := range topo.InterfaceInfoMap() {
+				routers = append(routers, info.InternalAddr.Addr())
+			}
+			return routers
+		},
+		Auth:     drkeyAuth,
+		Required: globalCfg.DRKey.ControlAuth == config.ControlAuthRequired,
+	}
+
+	gracePeriod, err := trust.NewGracePeriod(globalCfg.TrustEngine.GracePeriodRefusals)
+	if err != nil {
+		return serrors.Wrap("initializing TRC grace period", err)
+	}
+	tlsVerifier := trust.NewTLSCryptoVerifier(trustDB)
+	tlsVerifier.GracePeriod = gracePeriod
 
 	// FIXME: readability would be improved if we could be consistent with address
 	// representations in NetworkConfig (string or cooked, chose one).
@@ -206,19 +293,18 @@ func realMain(ctx context.Context) error {
 		IA:     topo.IA(),
 		Public: topo.ControlServiceAddress(globalCfg.General.ID),
 		QUIC: infraenv.QUIC{
-			TLSVerifier: trust.NewTLSCryptoVerifier(trustDB),
+			TLSVerifier: tlsVerifier,
 			GetCertificate: cs.NewTLSCertificateLoader(
 				topo.IA(), x509.ExtKeyUsageServerAuth, trustDB, globalCfg.General.ConfigDir,
+				keyRings.AS,
 			).GetCertificate,
 			GetClientCertificate: cs.NewTLSCertificateLoader(
 				topo.IA(), x509.ExtKeyUsageClientAuth, trustDB, globalCfg.General.ConfigDir,
+				keyRings.AS,
 			).GetClientCertificate,
 		},
-		SVCResolver: topo,
-		SCMPHandler: snet.DefaultSCMPHandler{
-			RevocationHandler: cs.RevocationHandler{RevCache: revCache},
-			SCMPErrors:        metrics.SCMPErrors,
-		},
+		SVCResolver:            topo,
+		SCMPHandler:            scmpHandler,
 		SCIONNetworkMetrics:    metrics.SCIONNetworkMetrics,
 		SCIONPacketConnMetrics: metrics.SCIONPacketConnMetrics,
 		MTU:                    topo.MTU(),
@@ -239,6 +325,11 @@ func realMain(ctx context.Context) error {
 		},
 		Dialer: quicStack.InsecureDialer,
 	}
+	if drkeyAuth != nil {
+		dialer.Interceptors = []grpc.UnaryClientInterceptor{
+			drkeygrpc.ClientAuthInterceptor(drkeyAuth, drkeygrpc.AuthenticatedMethods),
+		}
+	}
 
 	beaconDB, err := storage.NewBeaconStorage(globalCfg.BeaconDB, topo.IA())
 	if err != nil {
@@ -283,7 +374,8 @@ func realMain(ctx context.Context) error {
 			Dialer:   dialer,
 			Requests: libmetrics.NewPromCounter(trustmetrics.RPC.Fetches),
 		},
-		Recurser: trust.ASLocalRecurser{IA: topo.IA()},
+		Recurser:    trust.ASLocalRecurser{IA: topo.IA()},
+		GracePeriod: gracePeriod,
 		// XXX(roosd): cyclic dependency on router. It is set below.
 	}
 	verifier := compat.Verifier{
@@ -314,11 +406,18 @@ func realMain(ctx context.Context) error {
 		Router: segreq.NewRouter(fetcherCfg),
 	}
 
-	quicServer := grpc.NewServer(
+	quicServerOpts := []grpc.ServerOption{
 		grpc.Creds(libgrpc.PassThroughCredentials{}),
 		libgrpc.UnaryServerInterceptor(),
 		libgrpc.DefaultMaxConcurrentStreams(),
-	)
+	}
+	if drkeyAuth != nil {
+		quicServerOpts = append(quicServerOpts, grpc.ChainUnaryInterceptor(
+			drkeygrpc.ServerAuthInterceptor(drkeyAuth, drkeygrpc.AuthenticatedMethods,
+				globalCfg.DRKey.ControlAuth == config.ControlAuthRequired),
+		))
+	}
+	quicServer := grpc.NewServer(quicServerOpts...)
 	tcpServer := grpc.NewServer(
 		libgrpc.UnaryServerInterceptor(),
 		libgrpc.DefaultMaxConcurrentStreams(),
@@ -333,41 +432,107 @@ func realMain(ctx context.Context) error {
 	cppb.RegisterTrustMaterialServiceServer(quicServer, trustServer)
 	cppb.RegisterTrustMaterialServiceServer(tcpServer, trustServer)
 
+	// Push new TRCs to the child ASes, and accept the TRCs pushed by the parent ASes. Accepted
+	// TRCs are pushed further down right away.
+	trcPushRunner := periodic.Start(
+		&cstrust.TRCPusher{
+			IA:     topo.IA(),
+			DB:     trustDB,
+			Sender: cstrustgrpc.TRCPushSender{Dialer: dialer},
+			Children: func() []*ifstate.Interface {
+				return intfs.Filtered(func(intf *ifstate.Interface) bool {
+					return intf.TopoInfo().LinkType == topology.Child
+				})
+			},
+		},
+		30*time.Second,
+		30*time.Second,
+	)
+	defer trcPushRunner.Kill()
+	cppb.RegisterTRCPushServiceServer(quicServer, cstrustgrpc.TRCPushServer{
+		DB:       trustDB,
+		Provider: provider,
+		IA:       topo.IA(),
+		Accepted: func(cppki.TRCID) { trcPushRunner.TriggerRun() },
+		Requests: libmetrics.NewPromCounter(cstrustmetrics.Handler.Requests),
+	})
+
 	// Handle beaconing.
+	var beaconVerificationCache *cache.Cache
+	if !globalCfg.BS.Verification.DisableCache {
+		beaconVerificationCache = cache.New(globalCfg.BS.Verification.CacheExpiration.Duration,
+			time.Minute)
+	}
 	cppb.RegisterSegmentCreationServiceServer(quicServer, &beaconinggrpc.SegmentCreationServer{
 		Handler: &beaconing.Handler{
-			LocalIA:        topo.IA(),
-			Inserter:       beaconStore,
-			Interfaces:     intfs,
-			Verifier:       verifier,
-			BeaconsHandled: libmetrics.NewPromCounter(metrics.BeaconingReceivedTotal),
+			LocalIA:    topo.IA(),
+			Inserter:   beaconStore,
+			Interfaces: intfs,
+			Verifier:   verifier,
+			SegmentVerifier: &segverifier.CachingVerifier{
+				Verifier:           verifier,
+				Cache:              beaconVerificationCache,
+				MaxCacheExpiration: globalCfg.BS.Verification.CacheExpiration.Duration,
+				Workers:            globalCfg.BS.Verification.Workers,
+				CacheHits: libmetrics.NewPromCounter(
+					metrics.BeaconingVerificationCacheTotal),
+			},
+			Anomalies: beaconAnomalyHooks(globalCfg.BS.Anomalies,
+				append([]addr.IA{topo.IA()}, additionalIAs...)),
+			BeaconsHandled:    libmetrics.NewPromCounter(metrics.BeaconingReceivedTotal),
+			AnomaliesDetected: libmetrics.NewPromCounter(metrics.BeaconingAnomaliesTotal),
 		},
 	})
 
 	// Handle segment lookup
+	lookupLimiter := segreqgrpc.NewLimiter(segreqgrpc.LimiterConfig{
+		ClientRate:    globalCfg.PS.LookupLimits.ClientRate,
+		ClientBurst:   globalCfg.PS.LookupLimits.ClientBurst,
+		GlobalRate:    globalCfg.PS.LookupLimits.GlobalRate,
+		GlobalBurst:   globalCfg.PS.LookupLimits.GlobalBurst,
+		MaxConcurrent: globalCfg.PS.LookupLimits.MaxConcurrent,
+	})
 	authLookupServer := &segreqgrpc.LookupServer{
 		Lookuper: segreq.AuthoritativeLookup{
-			LocalIA:     topo.IA(),
-			CoreChecker: segreq.CoreChecker{Inspector: inspector},
-			PathDB:      pathDB,
+			LocalIA:       topo.IA(),
+			AdditionalIAs: additionalIAs,
+			CoreChecker:   segreq.CoreChecker{Inspector: inspector},
+			PathDB:        pathDB,
 		},
 		RevCache:     revCache,
+		Limiter:      lookupLimiter,
 		Requests:     libmetrics.NewPromCounter(metrics.SegmentLookupRequestsTotal),
 		SegmentsSent: libmetrics.NewPromCounter(metrics.SegmentLookupSegmentsSentTotal),
 	}
-	forwardingLookupServer := &segreqgrpc.LookupServer{
-		Lookuper: segreq.ForwardingLookup{
-			LocalIA:     topo.IA(),
-			CoreChecker: segreq.CoreChecker{Inspector: inspector},
-			Fetcher:     segreq.NewFetcher(fetcherCfg),
-			Expander: segreq.WildcardExpander{
-				LocalIA:   topo.IA(),
-				Core:      topo.Core(),
-				Inspector: inspector,
-				PathDB:    pathDB,
-			},
+	var forwardingLookup segreq.Lookuper = segreq.ForwardingLookup{
+		LocalIA:       topo.IA(),
+		AdditionalIAs: additionalIAs,
+		CoreChecker:   segreq.CoreChecker{Inspector: inspector},
+		Fetcher:       segreq.NewFetcher(fetcherCfg),
+		Expander: segreq.WildcardExpander{
+			LocalIA:       topo.IA(),
+			AdditionalIAs: additionalIAs,
+			Core:          topo.Core(),
+			Inspector:     inspector,
+			PathDB:        pathDB,
 		},
+	}
+	var lookupCache *segreq.CachingLookup
+	if ttl := globalCfg.PS.LookupCache.TTL.Duration; ttl > 0 {
+		lookupCache = &segreq.CachingLookup{
+			Lookuper:   forwardingLookup,
+			TTL:        ttl,
+			EmptyTTL:   globalCfg.PS.LookupCache.EmptyTTL.Duration,
+			MaxEntries: globalCfg.PS.LookupCache.MaxEntries,
+			RevCache:   revCache,
+			Lookups:    libmetrics.NewPromCounter(metrics.SegmentLookupCacheTotal),
+		}
+		forwardingLookup = lookupCache
+	}
+	forwardingLookupServer := &segreqgrpc.LookupServer{
+		Lookuper:     forwardingLookup,
 		RevCache:     revCache,
+		Limiter:      lookupLimiter,
 		Requests:     libmetrics.NewPromCounter(metrics.SegmentLookupRequestsTotal),
 		SegmentsSent: libmetrics.NewPromCounter(metrics.SegmentLookupSegmentsSentTotal),
 	}
@@ -396,7 +561,15 @@ func realMain(ctx context.Context) error {
 
 	}
 
-	signer := cs.NewSigner(topo.IA(), trustDB, globalCfg.General.ConfigDir)
+	signer := cs.NewSigner(topo.IA(), trustDB, globalCfg.General.ConfigDir, keyRings.AS)
+	// The beacons of the additional ISD-ASes are signed with the AS certificates
+	// of these ISD-ASes.
+	additionalSignerGens := make(map[addr.IA]beaconing.SignerGen, len(additionalIAs))
+	for _, ia := range additionalIAs {
+		additionalSignerGens[ia] = beaconingSignerGen(
+			cs.NewSigner(ia, trustDB, globalCfg.General.ConfigDir, keyRings.AS),
+		)
+	}
 
 	var chainBuilder renewal.ChainBuilder
 	var caClient *caapi.Client
@@ -423,13 +596,23 @@ func realMain(ctx context.Context) error {
 				libmetrics.NewPromCounter(metrics.RenewalHandledRequestsTotal),
 				"type", "in-process",
 			)
+			var issuancePolicy *renewal.IssuancePolicy
+			if globalCfg.CA.IssuancePolicy != "" {
+				issuancePolicy, err = renewal.LoadIssuancePolicyFromYaml(
+					globalCfg.CA.IssuancePolicy)
+				if err != nil {
+					return serrors.Wrap("loading issuance policy", err)
+				}
+			}
 			chainBuilder = cs.NewChainBuilder(
 				cs.ChainBuilderConfig{
 					IA:                   topo.IA(),
 					DB:                   trustDB,
 					MaxValidity:          globalCfg.CA.MaxASValidity.Duration,
 					ConfigDir:            globalCfg.General.ConfigDir,
+					KeyRing:              keyRings.CA,
 					Metrics:              metrics.RenewalMetrics,
+					IssuancePolicy:       issuancePolicy,
 					ForceECDSAWithSHA512: !globalCfg.Features.AppropriateDigest,
 				},
 			)
@@ -447,6 +630,7 @@ func realMain(ctx context.Context) error {
 					NotFoundError: cmsCtr.With(prom.LabelResult, prom.ErrNotFound),
 					ParseError:    cmsCtr.With(prom.LabelResult, prom.ErrParse),
 					VerifyError:   cmsCtr.With(prom.LabelResult, prom.ErrVerify),
+					PolicyError:   cmsCtr.With(prom.LabelResult, prom.ErrValidate),
 				},
 			}
 		case config.Delegating:
@@ -563,6 +747,44 @@ func realMain(ctx context.Context) error {
 	)
 	trcRunner.TriggerRun()
 
+	if cas := globalCfg.Renewal.CAs; len(cas) > 0 {
+		renewer := &cstrust.Renewer{
+			IA:        topo.IA(),
+			SignerGen: signer.SignerGen,
+			Requester: renewalgrpc.Requester{Dialer: dialer},
+			CAs:       cas,
+			TRCs:      trustDB,
+			Dir:       filepath.Join(globalCfg.General.ConfigDir, "crypto/as"),
+			LeadTime:  globalCfg.Renewal.LeadTime.Duration,
+		}
+		renewalRunner := periodic.Start(renewer, time.Minute, time.Minute)
+		defer renewalRunner.Kill()
+		renewalRunner.TriggerRun()
+	}
+
+	expiryChecker := &cstrust.ExpiryChecker{
+		IA:        topo.IA(),
+		TRCs:      trustDB,
+		SignerGen: signer.SignerGen,
+		CA:        chainBuilder.PolicyGen,
+		Thresholds: map[string]time.Duration{
+			cstrust.ArtifactTRC: globalCfg.ExpiryAlert.TRC.Duration,
+			cstrust.ArtifactCA:  globalCfg.ExpiryAlert.CA.Duration,
+			cstrust.ArtifactAS:  globalCfg.ExpiryAlert.AS.Duration,
+		},
+		Expiration: libmetrics.NewPromGauge(metrics.TrustExpirationTime),
+		Alerting:   libmetrics.NewPromGauge(metrics.TrustExpiryAlerting),
+	}
+	if webhook := globalCfg.ExpiryAlert.Webhook; webhook != "" {
+		expiryChecker.Alerter = cstrust.WebhookAlerter{
+			URL:    webhook,
+			Client: &http.Client{Timeout: 5 * time.Second},
+		}
+	}
+	expiryRunner := periodic.Start(expiryChecker, time.Minute, time.Minute)
+	defer expiryRunner.Kill()
+	expiryRunner.TriggerRun()
+
 	ds := discovery.Topology{
 		Information: topo,
 		Requests:    libmetrics.NewPromCounter(metrics.DiscoveryRequestsTotal),
@@ -582,14 +804,21 @@ func realMain(ctx context.Context) error {
 		FetcherConfig:     fetcherCfg,
 		IntraASTCPServer:  tcpServer,
 		InterASQUICServer: quicServer,
+		ACLPath:           globalCfg.PS.HiddenPathsACL,
 	}
-	hpWriterCfg, err := hpCfg.Setup(globalCfg.PS.HiddenPathsCfg)
+	hpWriterCfg, hpConfig, err := hpCfg.Setup(globalCfg.PS.HiddenPathsCfg)
 	if err != nil {
 		return err
 	}
+	if hpConfig != nil {
+		interval := globalCfg.PS.HiddenPathsReloadInterval.Duration
+		hpReloadRunner := periodic.Start(hpConfig, interval, interval)
+		defer hpReloadRunner.Kill()
+	}
 
 	// DRKey feature
 	var drkeyEngine *drkey.ServiceEngine
+	var svRotator *drkey.SecretValueRotator
 	var epochDuration time.Duration
 	if globalCfg.DRKey.Enabled() {
 		epochDuration = drkeyutil.LoadEpochDuration()
@@ -660,6 +889,17 @@ func realMain(ctx context.Context) error {
 		}
 		cppb.RegisterDRKeyInterServiceServer(quicServer, drkeyService)
 		cppb.RegisterDRKeyIntraServiceServer(tcpServer, drkeyService)
+		if drkeyAuth != nil {
+			drkeyAuth.SetEngine(drkeyEngine)
+		}
+		svRotator = &drkey.SecretValueRotator{
+			Engine:    drkeyEngine,
+			Protocols: globalCfg.DRKey.SecretValueProtocolIDs(),
+			Prefetch:  globalCfg.DRKey.SecretValuePrefetch.Duration,
+		}
+		svRotatorRunner := periodic.Start(svRotator, time.Minute, time.Minute)
+		defer svRotatorRunner.Kill()
+		svRotatorRunner.TriggerRun()
 		log.Info("DRKey is enabled")
 	} else {
 		log.Info("DRKey is DISABLED by configuration")
@@ -686,48 +926,6 @@ func realMain(ctx context.Context) error {
 	})
 	cleanup.Add(func() error { tcpServer.GracefulStop(); return nil })
 
-	if globalCfg.API.Addr != "" {
-		r := chi.NewRouter()
-		r.Use(cors.Handler(cors.Options{
-			AllowedOrigins: []string{"*"},
-		}))
-		r.Get("/", api.ServeSpecInteractive)
-		r.Get("/openapi.json", api.ServeSpecJSON)
-		server := api.Server{
-			SegmentsServer: segapi.Server{
-				Segments: pathDB,
-			},
-			CPPKIServer: cppkiapi.Server{
-				TrustDB: trustDB,
-			},
-			Beacons:  beaconDB,
-			CA:       chainBuilder,
-			Config:   service.NewConfigStatusPage(globalCfg).Handler,
-			Info:     service.NewInfoStatusPage().Handler,
-			LogLevel: service.NewLogLevelStatusPage().Handler,
-			Signer:   signer,
-			Topology: topo.HandleHTTP,
-			Healther: &healther{
-				Signer:   signer,
-				TrustDB:  trustDB,
-				ISD:      topo.IA().ISD(),
-				CAHealth: caHealthCached,
-			},
-		}
-		log.Info("Exposing API", "addr", globalCfg.API.Addr)
-		s := http.Server{
-			Addr:    globalCfg.API.Addr,
-			Handler: api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1"),
-		}
-		g.Go(func() error {
-			defer log.HandlePanic()
-			if err := s.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-				return serrors.Wrap("serving service management API", err)
-			}
-			return nil
-		})
-		cleanup.Add(s.Close)
-	}
 	err = cs.RegisterHTTPEndpoints(
 		globalCfg.General.ID,
 		&globalCfg,
@@ -740,8 +938,29 @@ func realMain(ctx context.Context) error {
 	}
 
 	staticInfo, err := beaconing.ParseStaticInfoCfg(globalCfg.General.StaticInfoConfig())
-	if err != nil {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
 		log.Info("No static info file found. Static info settings disabled.", "err", err)
+	case err != nil:
+		return serrors.Wrap("loading static info config", err)
+	default:
+		if unknown := staticInfo.UnknownInterfaces(intfs); len(unknown) > 0 {
+			log.Info("Static info config has entries for unknown interfaces. "+
+				"They are not announced.", "interfaces", unknown)
+		}
+	}
+	staticInfoFn := func() *beaconing.StaticInfoCfg { return staticInfo }
+	if apis := globalCfg.BS.LinkLatency.RouterAPIs; len(apis) > 0 {
+		fetcher := &beaconing.LinkLatencyFetcher{
+			Static:     staticInfo,
+			RouterAPIs: apis,
+			Client:     &http.Client{Timeout: 5 * time.Second},
+		}
+		interval := globalCfg.BS.LinkLatency.QueryInterval.Duration
+		latencyRunner := periodic.Start(fetcher, interval, interval)
+		defer latencyRunner.Kill()
+		latencyRunner.TriggerRun()
+		staticInfoFn = fetcher.StaticInfo
 	}
 
 	var propagationFilter func(intf *ifstate.Interface) bool
@@ -762,6 +981,40 @@ func realMain(ctx context.Context) error {
 		return topoInfo.LinkType == topology.Core || topoInfo.LinkType == topology.Child
 	}
 
+	// With leader election, only the leader among the instances that share the databases
+	// originates, propagates and registers beacons.
+	var beaconingLeader interface{ IsLeader() bool }
+	if globalCfg.BS.LeaderElection.Enabled {
+		leaseDB, err := storage.NewLeaseStorage(globalCfg.BeaconDB)
+		if err != nil {
+			return serrors.Wrap("initializing lease storage", err)
+		}
+		defer leaseDB.Close()
+		hostname, err := os.Hostname()
+		if err != nil {
+			return serrors.Wrap("determining hostname", err)
+		}
+		duration := globalCfg.BS.LeaderElection.LeaseDuration.Duration
+		elector := &leader.Elector{
+			DB:       leaseDB,
+			Lease:    "beaconing",
+			Holder:   fmt.Sprintf("%s@%s:%d", globalCfg.General.ID, hostname, os.Getpid()),
+			Duration: duration,
+			Leader:   libmetrics.NewPromGauge(metrics.BeaconingLeader),
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if err := elector.Release(ctx); err != nil {
+				log.Info("Failed to release lease", "err", err)
+			}
+		}()
+		electionRunner := periodic.Start(elector, duration/3, duration/3)
+		defer electionRunner.Kill()
+		electionRunner.TriggerRun()
+		beaconingLeader = elector
+	}
+
 	tasks, err := cs.StartTasks(cs.TasksConfig{
 		IA:            topo.IA(),
 		Core:          topo.Core(),
@@ -780,36 +1033,28 @@ func realMain(ctx context.Context) error {
 		BeaconSenderFactory: &beaconinggrpc.BeaconSenderFactory{
 			Dialer: dialer,
 		},
-		SegmentRegister: beaconinggrpc.Registrar{Dialer: dialer},
-		BeaconStore:     beaconStore,
-		SignerGen: beaconing.SignerGenFunc(func(ctx context.Context) ([]beaconing.Signer, error) {
-			signers, err := signer.SignerGen.Generate(ctx)
-			if err != nil {
-				return nil, err
-			}
-			if len(signers) == 0 {
-				return nil, nil
-			}
-			r := make([]beaconing.Signer, 0, len(signers))
-			for _, s := range signers {
-				r = append(r, s)
-			}
-			return r, nil
-		}),
-		Inspector:   inspector,
-		Metrics:     metrics,
-		DRKeyEngine: drkeyEngine,
-		MACGen:      macGen,
-		NextHopper:  topo,
-		StaticInfo:  func() *beaconing.StaticInfoCfg { return staticInfo },
+		SegmentRegister:      beaconinggrpc.Registrar{Dialer: dialer},
+		BeaconStore:          beaconStore,
+		SignerGen:            beaconingSignerGen(signer),
+		AdditionalSignerGens: additionalSignerGens,
+		Inspector:            inspector,
+		Metrics:              metrics,
+		DRKeyEngine:          drkeyEngine,
+		MACGen:               macGen,
+		NextHopper:           topo,
+		StaticInfo:           staticInfoFn,
 
 		OriginationInterval:       globalCfg.BS.OriginationInterval.Duration,
 		PropagationInterval:       globalCfg.BS.PropagationInterval.Duration,
 		RegistrationInterval:      globalCfg.BS.RegistrationInterval.Duration,
 		DRKeyEpochInterval:        epochDuration,
+		Stability:                 stability,
+		Jitter:                    globalCfg.BS.Jitter,
 		HiddenPathRegistrationCfg: hpWriterCfg,
 		AllowIsdLoop:              isdLoopAllowed,
 		EPIC:                      globalCfg.BS.EPIC,
+		Leader:                    beaconingLeader,
+		RegistrationCores:         globalCfg.BS.RegistrationCores,
 	})
 	if err != nil {
 		return serrors.Wrap("starting periodic tasks", err)
@@ -817,6 +1062,105 @@ func realMain(ctx context.Context) error {
 	defer tasks.Kill()
 	log.Info("Started periodic tasks")
 
+	if globalCfg.API.Addr != "" {
+		r := chi.NewRouter()
+		r.Use(cors.Handler(cors.Options{
+			AllowedOrigins: []string{"*"},
+		}))
+		r.Get("/", api.ServeSpecInteractive)
+		r.Get("/openapi.json", api.ServeSpecJSON)
+		server := api.Server{
+			SegmentsServer: segapi.Server{
+				Segments: pathDB,
+			},
+			CPPKIServer: cppkiapi.Server{
+				TrustDB: trustDB,
+			},
+			Beacons:  beaconDB,
+			CA:       chainBuilder,
+			Config:   service.NewConfigStatusPage(globalCfg).Handler,
+			Info:     service.NewInfoStatusPage().Handler,
+			LogLevel: service.NewLogLevelStatusPage().Handler,
+			Signer:   signer,
+			Topology: topo.HandleHTTP,
+			Healther: &healther{
+				Signer:   signer,
+				TrustDB:  trustDB,
+				ISD:      topo.IA().ISD(),
+				CAHealth: caHealthCached,
+			},
+			Registrar:    tasks,
+			Originator:   tasks,
+			TrustDB:      trustDB,
+			GracePeriods: gracePeriod,
+		}
+		if svRotator != nil {
+			server.SecretValues = svRotator
+		}
+		if hpConfig != nil {
+			server.HiddenPathACLs = hpConfig.ACL
+		}
+		if lookupCache != nil {
+			server.LookupCache = lookupCache
+		}
+		if path := globalCfg.BS.AdminSharedSecret; path != "" {
+			verifier := &jwtauth.HTTPVerifier{
+				Generator: caconfig.NewPEMSymmetricKey(path).Get,
+				Logger:    log.Root(),
+			}
+			server.AdminAuth = verifier.AddAuthorization
+		}
+		log.Info("Exposing API", "addr", globalCfg.API.Addr)
+		s := http.Server{
+			Addr:    globalCfg.API.Addr,
+			Handler: api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1"),
+		}
+		g.Go(func() error {
+			defer log.HandlePanic()
+			if err := s.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return serrors.Wrap("serving service management API", err)
+			}
+			return nil
+		})
+		cleanup.Add(s.Close)
+	}
+
+	if globalCfg.Bootstrap.Addr != "" {
+		verifier := &jwtauth.HTTPVerifier{
+			Generator: caconfig.NewPEMSymmetricKey(globalCfg.Bootstrap.SharedSecret).Get,
+			Logger:    log.Root(),
+		}
+		log.Info("Exposing bootstrap endpoint", "addr", globalCfg.Bootstrap.Addr)
+		s := http.Server{
+			Addr:    globalCfg.Bootstrap.Addr,
+			Handler: cs.NewBootstrapHandler(trustDB, topo.HandleHTTP, verifier.AddAuthorization),
+		}
+		g.Go(func() error {
+			defer log.HandlePanic()
+			if err := s.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return serrors.Wrap("serving bootstrap endpoint", err)
+			}
+			return nil
+		})
+		cleanup.Add(s.Close)
+	}
+
+	if len(originationSignals) > 0 {
+		originateC := app.SignalChannel(errCtx, originationSignals...)
+		g.Go(func() error {
+			defer log.HandlePanic()
+			for {
+				select {
+				case <-originateC:
+					log.Info("Received signal, originating beacons")
+					tasks.Originate()
+				case <-errCtx.Done():
+					return nil
+				}
+			}
+		})
+	}
+
 	g.Go(func() error {
 		defer log.HandlePanic()
 		return globalCfg.Metrics.ServePrometheus(errCtx)
@@ -854,12 +1198,89 @@ func createBeaconStore(
 	return store, *policies.Prop.Filter.AllowIsdLoop, err
 }
 
-func adaptInterfaceMap(in map[iface.ID]topology.IFInfo) map[uint16]ifstate.InterfaceInfo {
+// beaconingSignerGen adapts the signer generator of signer for beaconing.
+func beaconingSignerGen(signer cstrust.RenewingSigner) beaconing.SignerGen {
+	return beaconing.SignerGenFunc(func(ctx context.Context) ([]beaconing.Signer, error) {
+		signers, err := signer.SignerGen.Generate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(signers) == 0 {
+			return nil, nil
+		}
+		r := make([]beaconing.Signer, 0, len(signers))
+		for _, s := range signers {
+			r = append(r, s)
+		}
+		return r, nil
+	})
+}
+
+// beaconAnomalyHooks creates the hooks of the enabled beacon anomaly detectors.
+func beaconAnomalyHooks(cfg config.BeaconAnomalies, localIAs []addr.IA) []beaconing.AnomalyHook {
+	var hooks []beaconing.AnomalyHook
+	add := func(action string, detector beaconing.AnomalyDetector) {
+		if action == config.AnomalyOff {
+			return
+		}
+		hooks = append(hooks, beaconing.AnomalyHook{
+			Detector: detector,
+			Action:   beaconing.AnomalyAction(action),
+		})
+	}
+	add(cfg.ASLoop, beaconing.ASLoopDetector{LocalIAs: localIAs})
+	add(cfg.Latency, beaconing.LatencyDetector{MaxLatency: cfg.MaxLatency.Duration})
+	add(cfg.OriginRate, &beaconing.OriginRateDetector{
+		Window:          cfg.OriginRateWindow.Duration,
+		MaxOriginations: cfg.MaxOriginations,
+	})
+	return hooks
+}
+
+// additionalLocalIAs returns the ISD-ASes of the local AS in the additional
+// ISDs it is a member of.
+func additionalLocalIAs(topo *topology.Loader, cfg config.MultiISD) ([]addr.IA, error) {
+	if len(cfg.AdditionalISDs) == 0 {
+		return nil, nil
+	}
+	if !topo.Core() {
+		return nil, serrors.New("only core ASes can be members of several ISDs")
+	}
+	ias := make([]addr.IA, 0, len(cfg.AdditionalISDs))
+	for _, isd := range cfg.AdditionalISDs {
+		if isd == topo.IA().ISD() {
+			return nil, serrors.New("additional ISD is the ISD of the topology", "isd", isd)
+		}
+		ia, err := addr.IAFrom(isd, topo.IA().AS())
+		if err != nil {
+			return nil, err
+		}
+		ias = append(ias, ia)
+	}
+	return ias, nil
+}
+
+// adaptInterfaceMap converts the interfaces of the topology. Each interface is
+// attached to the ISD-AS of the local AS in the ISD of its neighbor, if the AS
+// is a member of it, and to the ISD-AS of the topology otherwise.
+func adaptInterfaceMap(
+	in map[iface.ID]topology.IFInfo,
+	local addr.IA,
+	additional []addr.IA,
+) map[uint16]ifstate.InterfaceInfo {
+
 	converted := make(map[uint16]ifstate.InterfaceInfo, len(in))
 	for id, info := range in {
+		localIA := local
+		for _, ia := range additional {
+			if ia.ISD() == info.IA.ISD() {
+				localIA = ia
+			}
+		}
 		converted[uint16(id)] = ifstate.InterfaceInfo{
 			ID:           uint16(info.ID),
 			IA:           info.IA,
+			LocalIA:      localIA,
 			LinkType:     info.LinkType,
 			InternalAddr: info.InternalAddr,
 			RemoteID:     uint16(info.RemoteIfID),
@@ -945,6 +1366,31 @@ func (h *healther) GetCAHealth(ctx context.Context) (api.CAHealthStatus, bool) {
 	return api.Unavailable, false
 }
 
+// newNeighborNotifier returns the notifier of the neighbor ASes about revocations. It sends from
+// the IP address of the control service, on a port of the endhost port range.
+func newNeighborNotifier(
+	ctx context.Context,
+	topo *topology.Loader,
+	mac hash.Hash,
+) (*revocation.SCMPNotifier, error) {
+
+	public := topo.ControlServiceAddress(globalCfg.General.ID)
+	network := &snet.SCIONNetwork{Topology: adaptTopology(topo)}
+	conn, err := network.OpenRaw(ctx, &net.UDPAddr{IP: public.IP, Zone: public.Zone})
+	if err != nil {
+		return nil, serrors.Wrap("opening connection for revocation notifications", err)
+	}
+	return &revocation.SCMPNotifier{
+		Conn: conn,
+		Source: snet.SCIONAddress{
+			IA:   topo.IA(),
+			Host: addr.HostIP(public.AddrPort().Addr()),
+		},
+		NextHopper: topo,
+		MAC:        mac,
+	}, nil
+}
+
 func adaptTopology(topo *topology.Loader) snet.Topology {
 	start, end := topo.PortRange()
 	return snet.Topology{
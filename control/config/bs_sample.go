@@ -24,8 +24,28 @@ propagation_interval = "5s"
 # The interval between registering beacons. (default 5s)
 registration_interval = "5s"
 
+# The maximum random deviation of the origination and propagation intervals, as
+# a fraction of the intervals. The deviation is drawn again for every round, such
+# that the control services of different ASes do not synchronize their
+# beaconing. It must be in [0, 1). (default 0)
+jitter = 0.0
+
 # Add EPIC authenticators to the beacons. (default false)
 epic = false
+
+# The core ASes at which a non-core AS registers its down segments, in addition
+# to the core AS at which each segment starts. Remote ASes can then look up the
+# segments even if the registration service of the core AS at which they start
+# is unreachable. The segments are sent over the segments that start at the
+# respective core AS. In a core AS, this field is ignored. (default [])
+registration_cores = []
+
+# The path to the PEM-encoded shared secret that the JWT bearer tokens
+# authorizing the beacon administration requests of the management API, i.e.,
+# deleting beacons, marking them unusable and triggering the origination and
+# registration, are signed with. If not set, beacons can be deleted without
+# authorization and the other requests are not available. (default "")
+admin_shared_secret = ""
 `
 
 const policiesSample = `
@@ -50,3 +70,87 @@ up_registration = ""
 # (default "")
 down_registration = ""
 `
+
+const linkLatencySample = `
+# The addresses of the management APIs of the border routers of the AS, which
+# report the latencies they measure on their inter-AS links. The measured
+# latencies replace the configured inter-AS latencies of the StaticInfo
+# extension. If empty, the configured latencies are used. (default [])
+router_apis = []
+
+# The interval between querying the border routers. (default 1m)
+query_interval = "1m"
+`
+
+const leaderElectionSample = `
+# Elect the instance that originates, propagates and registers the beacons among
+# the control service instances of the AS that share their databases. The lease
+# of the leader is stored in the beacon_db, which must use the "postgres"
+# backend. If disabled, the instance runs these tasks on its own.
+# (default false)
+enabled = false
+
+# The duration of the lease of the leader. Another instance takes over at most
+# this long after the leader failed. (default 15s)
+lease_duration = "15s"
+`
+
+const adaptiveIntervalsSample = `
+# Adapt the origination and propagation intervals to the stability of the
+# network. Right after a topology change, i.e., an interface is added, removed
+# or modified, or a revocation is received, the intervals are shortened to
+# min_scale times the configured intervals. While the network is stable, they
+# double after every round, up to max_scale times the configured intervals.
+# (default false)
+enabled = false
+
+# The factor applied to the intervals right after a topology change. It must be
+# in (0, 1]. (default 0.2)
+min_scale = 0.2
+
+# The factor applied to the intervals in steady state. It must be at least 1.
+# (default 4.0)
+max_scale = 4.0
+`
+
+const beaconVerificationSample = `
+# The maximum number of AS entries of the received beacons that are verified
+# concurrently. If zero, the number of CPUs is used. (default 0)
+workers = 0
+
+# Disable the cache of the verified prefixes of the received beacons. If the
+# cache is enabled, the AS entries of beacons that extend an already verified
+# beacon are not verified again. (default false)
+disable_cache = false
+
+# The maximum duration a verified prefix is cached. It is further bounded by
+# the expiration of the hop fields of the prefix. (default 1m)
+cache_expiration = "1m"
+`
+
+const beaconAnomaliesSample = `
+# The action taken on received beacons that traverse an ISD-AS twice, or the
+# local AS. Tagged beacons are inserted, but logged and counted; rejected
+# beacons are dropped. One of "off", "tag" or "reject". (default "tag")
+as_loop = "tag"
+
+# The action taken on received beacons that announce a link latency above
+# max_latency. One of "off", "tag" or "reject". (default "tag")
+latency = "tag"
+
+# The maximum plausible latency of an intra-AS or inter-AS link announced in the
+# static info extension of a beacon. (default 1s)
+max_latency = "1s"
+
+# The action taken on received beacons of origin ASes that originate more than
+# max_originations beacons within origin_rate_window. The originations are
+# identified by the timestamps of the beacons. One of "off", "tag" or
+# "reject". (default "tag")
+origin_rate = "tag"
+
+# The duration over which the originations of an AS are counted. (default 1m)
+origin_rate_window = "1m"
+
+# The maximum number of originations of an AS within the window. (default 60)
+max_originations = 60
+`
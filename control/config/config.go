@@ -17,9 +17,12 @@ package config
 
 import (
 	"io"
+	"net"
+	"net/url"
 	"strings"
 	"time"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
@@ -42,8 +45,52 @@ const (
 	// DefaultQueryInterval is the default interval after which the segment
 	// cache expires.
 	DefaultQueryInterval = 5 * time.Minute
+	// DefaultHiddenPathsReloadInterval is the default interval between reloads of the hidden
+	// path configuration.
+	DefaultHiddenPathsReloadInterval = 30 * time.Second
+	// DefaultLookupCacheMaxEntries is the default maximum number of cached segment lookups.
+	DefaultLookupCacheMaxEntries = 10000
 	// DefaultMaxASValidity is the default validity period for renewed AS certificates.
 	DefaultMaxASValidity = 3 * 24 * time.Hour
+	// DefaultLinkLatencyQueryInterval is the default interval between querying the border
+	// routers for the measured link latencies.
+	DefaultLinkLatencyQueryInterval = time.Minute
+	// DefaultLeaderLeaseDuration is the default duration of the lease of the instance that runs
+	// the beaconing tasks. It bounds the time it takes for another instance to take over after
+	// the leader failed.
+	DefaultLeaderLeaseDuration = 15 * time.Second
+	// DefaultBeaconVerificationCacheExpiration is the default maximum duration a verified
+	// prefix of a beacon is cached.
+	DefaultBeaconVerificationCacheExpiration = time.Minute
+	// DefaultAnomalyMaxLatency is the default maximum plausible latency of a link announced in
+	// the received beacons.
+	DefaultAnomalyMaxLatency = time.Second
+	// DefaultAnomalyOriginRateWindow is the default duration over which the originations of
+	// the origin ASes of the received beacons are counted.
+	DefaultAnomalyOriginRateWindow = time.Minute
+	// DefaultAnomalyMaxOriginations is the default maximum number of originations of an AS
+	// within the window.
+	DefaultAnomalyMaxOriginations = 60
+	// DefaultAdaptiveMinScale is the default factor applied to the origination and propagation
+	// intervals right after a topology change, if the intervals are adaptive.
+	DefaultAdaptiveMinScale = 0.2
+	// DefaultAdaptiveMaxScale is the default factor applied to the origination and propagation
+	// intervals in steady state, if the intervals are adaptive.
+	DefaultAdaptiveMaxScale = 4.0
+	// DefaultVaultMount is the default path the transit secrets engine of Vault is mounted at.
+	DefaultVaultMount = "transit"
+	// DefaultRenewalLeadTime is the default remaining validity of the AS certificate at which it
+	// is renewed.
+	DefaultRenewalLeadTime = 24 * time.Hour
+	// DefaultExpiryAlertTRC is the default remaining validity of the TRC at which an alert is
+	// raised.
+	DefaultExpiryAlertTRC = 30 * 24 * time.Hour
+	// DefaultExpiryAlertCA is the default remaining validity of the CA certificate at which an
+	// alert is raised.
+	DefaultExpiryAlertCA = 48 * time.Hour
+	// DefaultExpiryAlertAS is the default remaining validity of the AS certificate chain at which
+	// an alert is raised.
+	DefaultExpiryAlertAS = 12 * time.Hour
 )
 
 var _ config.Config = (*Config)(nil)
@@ -64,6 +111,11 @@ type Config struct {
 	CA          CA                 `toml:"ca,omitempty"`
 	TrustEngine trustengine.Config `toml:"trustengine,omitempty"`
 	DRKey       DRKeyConfig        `toml:"drkey,omitempty"`
+	MultiISD    MultiISD           `toml:"multi_isd,omitempty"`
+	Keys        Keys               `toml:"keys,omitempty"`
+	Renewal     Renewal            `toml:"renewal,omitempty"`
+	ExpiryAlert ExpiryAlert        `toml:"expiry_alert,omitempty"`
+	Bootstrap   Bootstrap          `toml:"bootstrap,omitempty"`
 }
 
 // InitDefaults initializes the default values for all parts of the config.
@@ -83,11 +135,24 @@ func (cfg *Config) InitDefaults() {
 		&cfg.CA,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
+		&cfg.MultiISD,
+		&cfg.Keys,
+		&cfg.Renewal,
+		&cfg.ExpiryAlert,
+		&cfg.Bootstrap,
 	)
 }
 
 // Validate validates all parts of the config.
 func (cfg *Config) Validate() error {
+	if cfg.BS.LeaderElection.Enabled && cfg.BeaconDB.Backend != storage.BackendPostgres {
+		return serrors.New("leader election requires the postgres backend for the beacon_db",
+			"backend", cfg.BeaconDB.Backend)
+	}
+	if len(cfg.Renewal.CAs) > 0 && cfg.Keys.Backend == KeyBackendVault {
+		return serrors.New("certificate renewal requires the file key backend",
+			"backend", cfg.Keys.Backend)
+	}
 	return config.ValidateAll(
 		&cfg.General,
 		&cfg.Features,
@@ -102,6 +167,11 @@ func (cfg *Config) Validate() error {
 		&cfg.CA,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
+		&cfg.MultiISD,
+		&cfg.Keys,
+		&cfg.Renewal,
+		&cfg.ExpiryAlert,
+		&cfg.Bootstrap,
 	)
 }
 
@@ -140,6 +210,11 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.CA,
 		&cfg.TrustEngine,
 		&cfg.DRKey,
+		&cfg.MultiISD,
+		&cfg.Keys,
+		&cfg.Renewal,
+		&cfg.ExpiryAlert,
+		&cfg.Bootstrap,
 	)
 }
 
@@ -155,8 +230,28 @@ type BSConfig struct {
 	RegistrationInterval util.DurWrap `toml:"registration_interval,omitempty"`
 	// Policies contains the policy files.
 	Policies Policies `toml:"policies,omitempty"`
+	// LinkLatency configures the link latencies measured by the border routers.
+	LinkLatency LinkLatency `toml:"link_latency,omitempty"`
+	// LeaderElection configures the election of the instance that runs the beaconing tasks.
+	LeaderElection LeaderElection `toml:"leader_election,omitempty"`
+	// AdaptiveIntervals configures the adaptation of the origination and propagation intervals
+	// to the stability of the network.
+	AdaptiveIntervals AdaptiveIntervals `toml:"adaptive_intervals,omitempty"`
+	// Verification configures the verification of the received beacons.
+	Verification BeaconVerification `toml:"verification,omitempty"`
+	// Anomalies configures the detection of anomalies in the received beacons.
+	Anomalies BeaconAnomalies `toml:"anomalies,omitempty"`
+	// Jitter is the maximum random deviation of the origination and propagation intervals, as a
+	// fraction of the intervals.
+	Jitter float64 `toml:"jitter,omitempty"`
 	// EPIC specifies whether the EPIC authenticators should be added to the beacons.
 	EPIC bool `toml:"epic,omitempty"`
+	// RegistrationCores are the core ASes at which a non-core AS registers its down segments,
+	// in addition to the core AS at which each segment starts.
+	RegistrationCores []addr.IA `toml:"registration_cores,omitempty"`
+	// AdminSharedSecret is the path to the PEM-encoded shared secret that the tokens
+	// authorizing the beacon administration requests of the management API are signed with.
+	AdminSharedSecret string `toml:"admin_shared_secret,omitempty"`
 }
 
 // InitDefaults the default values for the durations that are equal to zero.
@@ -174,13 +269,32 @@ func (cfg *BSConfig) Validate() error {
 	if cfg.RegistrationInterval.Duration == 0 {
 		initDurWrap(&cfg.RegistrationInterval, DefaultRegistrationInterval)
 	}
-	return nil
+	initDurWrap(&cfg.LinkLatency.QueryInterval, DefaultLinkLatencyQueryInterval)
+	initDurWrap(&cfg.LeaderElection.LeaseDuration, DefaultLeaderLeaseDuration)
+	initDurWrap(&cfg.Verification.CacheExpiration, DefaultBeaconVerificationCacheExpiration)
+	if cfg.Verification.Workers < 0 {
+		return serrors.New("verification workers must not be negative",
+			"workers", cfg.Verification.Workers)
+	}
+	if cfg.Jitter < 0 || cfg.Jitter >= 1 {
+		return serrors.New("jitter must be in [0, 1)", "jitter", cfg.Jitter)
+	}
+	for _, ia := range cfg.RegistrationCores {
+		if ia.IsWildcard() {
+			return serrors.New("registration core must not be a wildcard", "ia", ia)
+		}
+	}
+	if err := cfg.AdaptiveIntervals.Validate(); err != nil {
+		return err
+	}
+	return cfg.Anomalies.Validate()
 }
 
 // Sample generates a sample for the beacon server specific configuration.
 func (cfg *BSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, bsSample)
-	config.WriteSample(dst, path, ctx, &cfg.Policies)
+	config.WriteSample(dst, path, ctx, &cfg.Policies, &cfg.LinkLatency, &cfg.LeaderElection,
+		&cfg.AdaptiveIntervals, &cfg.Verification, &cfg.Anomalies)
 }
 
 // ConfigName is the toml key for the beacon server specific configuration.
@@ -204,29 +318,160 @@ type PSConfig struct {
 	// If HiddenPathsCfg begins with http:// or https://, it will be fetched
 	// over the network from the specified URL instead.
 	HiddenPathsCfg string `toml:"hidden_paths_cfg,omitempty"`
+	// HiddenPathsReloadInterval specifies the interval between reloads of the hidden path
+	// configuration, such that groups and their members can be changed without a restart.
+	HiddenPathsReloadInterval util.DurWrap `toml:"hidden_paths_reload_interval,omitempty"`
+	// HiddenPathsACL specifies the file name the ACLs of the hidden path groups that are set
+	// through the management API are persisted in. If empty, they are lost on restart.
+	HiddenPathsACL string `toml:"hidden_paths_acl,omitempty"`
+	// LookupLimits limits the segment lookups served.
+	LookupLimits LookupLimits `toml:"lookup_limits,omitempty"`
+	// LookupCache configures the cache of the segment lookups served.
+	LookupCache LookupCache `toml:"lookup_cache,omitempty"`
+	// Revocations configures the push of the revocations of interfaces.
+	Revocations Revocations `toml:"revocations,omitempty"`
 }
 
 func (cfg *PSConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	initDurWrap(&cfg.HiddenPathsReloadInterval, DefaultHiddenPathsReloadInterval)
+	config.InitAll(&cfg.LookupCache)
 }
 
 func (cfg *PSConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("query_interval must not be zero")
 	}
-	return nil
+	return config.ValidateAll(&cfg.LookupLimits, &cfg.LookupCache, &cfg.Revocations)
 }
 
 func (cfg *PSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, psSample)
+	config.WriteSample(dst, path, ctx, &cfg.LookupLimits, &cfg.LookupCache, &cfg.Revocations)
 }
 
 func (cfg *PSConfig) ConfigName() string {
 	return "path"
 }
 
+var _ config.Config = (*LookupLimits)(nil)
+
+// LookupLimits limits the segment lookups served by the control service, such that a
+// misbehaving client cannot starve the lookups of the other clients. The zero value of each
+// limit disables it.
+type LookupLimits struct {
+	config.NoDefaulter
+	// ClientRate is the number of lookups per second allowed per client on average.
+	ClientRate float64 `toml:"client_rate,omitempty"`
+	// ClientBurst is the number of lookups a client can send at once.
+	ClientBurst int `toml:"client_burst,omitempty"`
+	// GlobalRate is the number of lookups per second allowed from all clients on average.
+	GlobalRate float64 `toml:"global_rate,omitempty"`
+	// GlobalBurst is the number of lookups all clients can send at once.
+	GlobalBurst int `toml:"global_burst,omitempty"`
+	// MaxConcurrent is the number of lookups that are processed concurrently.
+	MaxConcurrent int `toml:"max_concurrent,omitempty"`
+}
+
+// Validate validates that the limits are not negative.
+func (cfg *LookupLimits) Validate() error {
+	if cfg.ClientRate < 0 || cfg.ClientBurst < 0 || cfg.GlobalRate < 0 || cfg.GlobalBurst < 0 ||
+		cfg.MaxConcurrent < 0 {
+		return serrors.New("lookup limits must not be negative",
+			"client_rate", cfg.ClientRate, "client_burst", cfg.ClientBurst,
+			"global_rate", cfg.GlobalRate, "global_burst", cfg.GlobalBurst,
+			"max_concurrent", cfg.MaxConcurrent)
+	}
+	return nil
+}
+
+// Sample generates a sample for the lookup limits configuration.
+func (cfg *LookupLimits) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, lookupLimitsSample)
+}
+
+// ConfigName is the toml key for the lookup limits configuration.
+func (cfg *LookupLimits) ConfigName() string {
+	return "lookup_limits"
+}
+
+var _ config.Config = (*LookupCache)(nil)
+
+// LookupCache configures the cache of the segment lookups that the control service forwards to
+// the core ASes. Cached lookups are answered without asking the core ASes again.
+type LookupCache struct {
+	// TTL is the time lookups that returned segments are cached for. If it is zero, the cache
+	// is disabled.
+	TTL util.DurWrap `toml:"ttl,omitempty"`
+	// EmptyTTL is the time lookups that returned no segments are cached for. If it is zero,
+	// they are not cached.
+	EmptyTTL util.DurWrap `toml:"empty_ttl,omitempty"`
+	// MaxEntries is the maximum number of cached lookups.
+	MaxEntries int `toml:"max_entries,omitempty"`
+}
+
+// InitDefaults initializes the maximum number of cached lookups.
+func (cfg *LookupCache) InitDefaults() {
+	if cfg.MaxEntries == 0 {
+		cfg.MaxEntries = DefaultLookupCacheMaxEntries
+	}
+}
+
+// Validate validates that the TTLs and the maximum number of entries are not negative.
+func (cfg *LookupCache) Validate() error {
+	if cfg.TTL.Duration < 0 || cfg.EmptyTTL.Duration < 0 || cfg.MaxEntries < 0 {
+		return serrors.New("lookup cache settings must not be negative",
+			"ttl", cfg.TTL, "empty_ttl", cfg.EmptyTTL, "max_entries", cfg.MaxEntries)
+	}
+	return nil
+}
+
+// Sample generates a sample for the lookup cache configuration.
+func (cfg *LookupCache) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, lookupCacheSample)
+}
+
+// ConfigName is the toml key for the lookup cache configuration.
+func (cfg *LookupCache) ConfigName() string {
+	return "lookup_cache"
+}
+
+var _ config.Config = (*Revocations)(nil)
+
+// Revocations configures the push of the revocations of interfaces. The revocations are derived
+// from the notifications of the routers when an interface goes down.
+type Revocations struct {
+	config.NoDefaulter
+	// Daemons are the addresses of the daemons of the local AS that are notified about all
+	// revocations.
+	Daemons []string `toml:"daemons,omitempty"`
+	// DisableNeighbors disables the notification of the control services of the neighbor ASes
+	// about the revocations of the interfaces of the local AS.
+	DisableNeighbors bool `toml:"disable_neighbors,omitempty"`
+}
+
+// Validate validates that the daemon addresses are host:port pairs.
+func (cfg *Revocations) Validate() error {
+	for _, d := range cfg.Daemons {
+		if _, _, err := net.SplitHostPort(d); err != nil {
+			return serrors.Wrap("invalid daemon address", err, "address", d)
+		}
+	}
+	return nil
+}
+
+// Sample generates a sample for the revocations configuration.
+func (cfg *Revocations) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, revocationsSample)
+}
+
+// ConfigName is the toml key for the revocations configuration.
+func (cfg *Revocations) ConfigName() string {
+	return "revocations"
+}
+
 var _ config.Config = (*Policies)(nil)
 
 // Policies contains the file paths of the policies.
@@ -260,6 +505,322 @@ func (cfg *Policies) ConfigName() string {
 	return "policies"
 }
 
+// LinkLatency configures the link latencies that are measured by the border routers. The
+// measured latencies replace the configured inter-AS latencies of the StaticInfo extension.
+type LinkLatency struct {
+	config.NoDefaulter
+	config.NoValidator
+	// RouterAPIs contains the addresses of the management APIs of the border routers of the
+	// AS. If it is empty, the configured latencies are used.
+	RouterAPIs []string `toml:"router_apis,omitempty"`
+	// QueryInterval is the interval between querying the border routers.
+	QueryInterval util.DurWrap `toml:"query_interval,omitempty"`
+}
+
+// Sample generates a sample for the link latency configuration.
+func (cfg *LinkLatency) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, linkLatencySample)
+}
+
+// ConfigName is the toml key for the link latency configuration.
+func (cfg *LinkLatency) ConfigName() string {
+	return "link_latency"
+}
+
+// LeaderElection configures the election of the control service instance that originates,
+// propagates and registers the beacons, among the instances of the AS that share their
+// databases. The lease of the leader is stored in the beacon database.
+type LeaderElection struct {
+	config.NoDefaulter
+	config.NoValidator
+	// Enabled enables the leader election. If it is disabled, the instance runs the beaconing
+	// tasks on its own.
+	Enabled bool `toml:"enabled,omitempty"`
+	// LeaseDuration is the duration of the lease of the leader.
+	LeaseDuration util.DurWrap `toml:"lease_duration,omitempty"`
+}
+
+// Sample generates a sample for the leader election configuration.
+func (cfg *LeaderElection) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, leaderElectionSample)
+}
+
+// ConfigName is the toml key for the leader election configuration.
+func (cfg *LeaderElection) ConfigName() string {
+	return "leader_election"
+}
+
+// AdaptiveIntervals configures the adaptation of the origination and propagation intervals to
+// the stability of the network. Right after a topology change, the intervals are shortened, and
+// they grow again while the network is stable.
+type AdaptiveIntervals struct {
+	config.NoDefaulter
+	// Enabled enables the adaptive intervals. If it is disabled, the configured intervals are
+	// used.
+	Enabled bool `toml:"enabled,omitempty"`
+	// MinScale is the factor applied to the intervals right after a topology change.
+	MinScale float64 `toml:"min_scale,omitempty"`
+	// MaxScale is the factor applied to the intervals in steady state.
+	MaxScale float64 `toml:"max_scale,omitempty"`
+}
+
+// Validate initializes the unset factors to the defaults and validates their range.
+func (cfg *AdaptiveIntervals) Validate() error {
+	if cfg.MinScale == 0 {
+		cfg.MinScale = DefaultAdaptiveMinScale
+	}
+	if cfg.MaxScale == 0 {
+		cfg.MaxScale = DefaultAdaptiveMaxScale
+	}
+	if cfg.MinScale < 0 || cfg.MinScale > 1 {
+		return serrors.New("min_scale must be in (0, 1]", "min_scale", cfg.MinScale)
+	}
+	if cfg.MaxScale < 1 {
+		return serrors.New("max_scale must be at least 1", "max_scale", cfg.MaxScale)
+	}
+	return nil
+}
+
+// Sample generates a sample for the adaptive intervals configuration.
+func (cfg *AdaptiveIntervals) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, adaptiveIntervalsSample)
+}
+
+// ConfigName is the toml key for the adaptive intervals configuration.
+func (cfg *AdaptiveIntervals) ConfigName() string {
+	return "adaptive_intervals"
+}
+
+// BeaconVerification configures the verification of the received beacons. The AS entries of a
+// beacon are verified concurrently, and the verified prefixes of the beacons are cached, such
+// that the AS entries of beacons that are received again are not verified again.
+type BeaconVerification struct {
+	config.NoDefaulter
+	config.NoValidator
+	// Workers is the maximum number of AS entries that are verified concurrently. If zero, the
+	// number of CPUs is used.
+	Workers int `toml:"workers,omitempty"`
+	// DisableCache disables the cache of the verified prefixes.
+	DisableCache bool `toml:"disable_cache,omitempty"`
+	// CacheExpiration is the maximum duration a verified prefix is cached.
+	CacheExpiration util.DurWrap `toml:"cache_expiration,omitempty"`
+}
+
+// Sample generates a sample for the beacon verification configuration.
+func (cfg *BeaconVerification) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, beaconVerificationSample)
+}
+
+// ConfigName is the toml key for the beacon verification configuration.
+func (cfg *BeaconVerification) ConfigName() string {
+	return "verification"
+}
+
+// Actions of the beacon anomaly detectors.
+const (
+	// AnomalyOff disables the detector.
+	AnomalyOff = "off"
+	// AnomalyTag inserts the flagged beacons, but logs and counts them.
+	AnomalyTag = "tag"
+	// AnomalyReject rejects the flagged beacons.
+	AnomalyReject = "reject"
+)
+
+// BeaconAnomalies configures the detection of anomalies in the received beacons. For each
+// detector, the action taken on the flagged beacons is AnomalyOff, AnomalyTag or AnomalyReject.
+type BeaconAnomalies struct {
+	config.NoDefaulter
+	// ASLoop is the action for beacons that traverse an ISD-AS twice, or the local AS.
+	ASLoop string `toml:"as_loop,omitempty"`
+	// Latency is the action for beacons that announce link latencies above MaxLatency.
+	Latency string `toml:"latency,omitempty"`
+	// MaxLatency is the maximum plausible latency of a link.
+	MaxLatency util.DurWrap `toml:"max_latency,omitempty"`
+	// OriginRate is the action for beacons of origin ASes that originate more than
+	// MaxOriginations beacons within OriginRateWindow.
+	OriginRate string `toml:"origin_rate,omitempty"`
+	// OriginRateWindow is the duration over which the originations are counted.
+	OriginRateWindow util.DurWrap `toml:"origin_rate_window,omitempty"`
+	// MaxOriginations is the maximum number of originations of an AS within the window.
+	MaxOriginations int `toml:"max_originations,omitempty"`
+}
+
+// Validate initializes the unset values to the defaults and validates the actions.
+func (cfg *BeaconAnomalies) Validate() error {
+	for _, action := range []*string{&cfg.ASLoop, &cfg.Latency, &cfg.OriginRate} {
+		switch *action {
+		case "":
+			*action = AnomalyTag
+		case AnomalyOff, AnomalyTag, AnomalyReject:
+		default:
+			return serrors.New("invalid anomaly action", "action", *action,
+				"expected", []string{AnomalyOff, AnomalyTag, AnomalyReject})
+		}
+	}
+	initDurWrap(&cfg.MaxLatency, DefaultAnomalyMaxLatency)
+	initDurWrap(&cfg.OriginRateWindow, DefaultAnomalyOriginRateWindow)
+	if cfg.MaxOriginations == 0 {
+		cfg.MaxOriginations = DefaultAnomalyMaxOriginations
+	}
+	if cfg.MaxOriginations < 0 {
+		return serrors.New("max_originations must not be negative",
+			"max_originations", cfg.MaxOriginations)
+	}
+	return nil
+}
+
+// Sample generates a sample for the beacon anomaly detection configuration.
+func (cfg *BeaconAnomalies) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, beaconAnomaliesSample)
+}
+
+// ConfigName is the toml key for the beacon anomaly detection configuration.
+func (cfg *BeaconAnomalies) ConfigName() string {
+	return "anomalies"
+}
+
+var _ config.Config = (*MultiISD)(nil)
+
+// MultiISD configures the membership of a core AS in several ISDs.
+//
+// In each additional ISD, the AS has the ISD-AS with the AS number of the topology. Each
+// interface is attached to the ISD of its neighbor if the AS is a member of it, and to the ISD
+// of the topology otherwise. The control service originates, propagates and registers the
+// beacons of each interface as the ISD-AS of the interface, signed with the AS certificate of
+// that ISD-AS.
+type MultiISD struct {
+	config.NoDefaulter
+	// AdditionalISDs are the ISDs the AS is a member of, in addition to the ISD of the topology.
+	AdditionalISDs []addr.ISD `toml:"additional_isds,omitempty"`
+}
+
+// Validate validates that the additional ISDs are valid and distinct.
+func (cfg *MultiISD) Validate() error {
+	seen := make(map[addr.ISD]struct{}, len(cfg.AdditionalISDs))
+	for _, isd := range cfg.AdditionalISDs {
+		if isd == 0 {
+			return serrors.New("additional ISD must not be the wildcard ISD")
+		}
+		if _, ok := seen[isd]; ok {
+			return serrors.New("duplicate additional ISD", "isd", isd)
+		}
+		seen[isd] = struct{}{}
+	}
+	return nil
+}
+
+// Sample generates a sample for the multi-ISD configuration.
+func (cfg *MultiISD) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, multiISDSample)
+}
+
+// ConfigName is the toml key for the multi-ISD configuration.
+func (cfg *MultiISD) ConfigName() string {
+	return "multi_isd"
+}
+
+var _ config.Config = (*Keys)(nil)
+
+// KeyBackend is the backend that keeps the private keys.
+type KeyBackend string
+
+const (
+	// KeyBackendFile keeps the private keys in PEM files in the configuration directory.
+	KeyBackendFile KeyBackend = "file"
+	// KeyBackendVault keeps the private keys in the transit secrets engine of HashiCorp Vault.
+	KeyBackendVault KeyBackend = "vault"
+)
+
+// Keys configures where the private keys of the AS certificates and, in a CA AS, of the CA
+// certificates are kept.
+type Keys struct {
+	// Backend is the backend that keeps the private keys.
+	Backend KeyBackend `toml:"backend,omitempty"`
+	// Vault configures the HashiCorp Vault backend.
+	Vault VaultKeys `toml:"vault,omitempty"`
+}
+
+// InitDefaults selects the file backend if no backend is set.
+func (cfg *Keys) InitDefaults() {
+	if cfg.Backend == "" {
+		cfg.Backend = KeyBackendFile
+	}
+	config.InitAll(&cfg.Vault)
+}
+
+// Validate validates that the backend is known and configured.
+func (cfg *Keys) Validate() error {
+	switch cfg.Backend {
+	case KeyBackendFile:
+		return nil
+	case KeyBackendVault:
+		return cfg.Vault.Validate()
+	default:
+		return serrors.New("unknown key backend", "backend", cfg.Backend)
+	}
+}
+
+// Sample generates a sample for the key configuration.
+func (cfg *Keys) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, keysSample)
+	config.WriteSample(dst, path, ctx, &cfg.Vault)
+}
+
+// ConfigName is the toml key for the key configuration.
+func (cfg *Keys) ConfigName() string {
+	return "keys"
+}
+
+var _ config.Config = (*VaultKeys)(nil)
+
+// VaultKeys configures the keys in the transit secrets engine of HashiCorp Vault.
+type VaultKeys struct {
+	// Address is the address of Vault.
+	Address string `toml:"addr,omitempty"`
+	// TokenFile is the file with the token that authenticates the control service.
+	TokenFile string `toml:"token_file,omitempty"`
+	// CAFile is the PEM file with the CA certificates that the certificate of Vault is verified
+	// with. If empty, the system roots are used.
+	CAFile string `toml:"ca_file,omitempty"`
+	// Mount is the path the transit secrets engine is mounted at.
+	Mount string `toml:"mount,omitempty"`
+	// ASKeys are the names of the keys of the AS certificates.
+	ASKeys []string `toml:"as_keys,omitempty"`
+	// CAKeys are the names of the keys of the CA certificates.
+	CAKeys []string `toml:"ca_keys,omitempty"`
+}
+
+// InitDefaults sets the default mount path.
+func (cfg *VaultKeys) InitDefaults() {
+	if cfg.Mount == "" {
+		cfg.Mount = DefaultVaultMount
+	}
+}
+
+// Validate validates that Vault and the keys of the AS certificates are configured.
+func (cfg *VaultKeys) Validate() error {
+	if cfg.Address == "" {
+		return serrors.New("vault address must be set")
+	}
+	if cfg.TokenFile == "" {
+		return serrors.New("vault token file must be set")
+	}
+	if len(cfg.ASKeys) == 0 {
+		return serrors.New("vault AS keys must be set")
+	}
+	return nil
+}
+
+// Sample generates a sample for the Vault configuration.
+func (cfg *VaultKeys) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, vaultKeysSample)
+}
+
+// ConfigName is the toml key for the Vault configuration.
+func (cfg *VaultKeys) ConfigName() string {
+	return "vault"
+}
+
 // CA is the CA configuration.
 type CA struct {
 	// MaxASValidity is the maximum AS certificate lifetime.
@@ -271,6 +832,10 @@ type CA struct {
 	Mode CAMode `toml:"mode,omitempty"`
 	// Service contains details about CA functionality delegation.
 	Service CAService `toml:"service,omitempty"`
+	// IssuancePolicy is the path to the yaml file with the issuance policy that constrains the
+	// AS certificates issued in the in-process mode. If it is the empty string, certificates
+	// are issued for all ASes of the ISD.
+	IssuancePolicy string `toml:"issuance_policy,omitempty"`
 }
 
 func (cfg *CA) InitDefaults() {
@@ -342,3 +907,143 @@ func (cfg *CAService) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
 func (cfg *CAService) ConfigName() string {
 	return "service"
 }
+
+var _ config.Config = (*Renewal)(nil)
+
+// Renewal configures the automatic renewal of the AS certificate.
+type Renewal struct {
+	// CAs are the ISD-ASes of the CAs the AS certificate is renewed with, in order of
+	// preference. If it is empty, the AS certificate is not renewed automatically.
+	CAs []addr.IA `toml:"cas,omitempty"`
+	// LeadTime is the remaining validity of the AS certificate at which it is renewed.
+	LeadTime util.DurWrap `toml:"lead_time,omitempty"`
+}
+
+// InitDefaults sets the default lead time.
+func (cfg *Renewal) InitDefaults() {
+	if cfg.LeadTime.Duration == 0 {
+		cfg.LeadTime.Duration = DefaultRenewalLeadTime
+	}
+}
+
+// Validate validates that the CAs are not wildcards and the lead time is positive.
+func (cfg *Renewal) Validate() error {
+	for _, ia := range cfg.CAs {
+		if ia.IsWildcard() {
+			return serrors.New("renewal CA must not be a wildcard", "ia", ia)
+		}
+	}
+	if cfg.LeadTime.Duration <= 0 {
+		return serrors.New("lead_time must be positive", "lead_time", cfg.LeadTime)
+	}
+	return nil
+}
+
+// Sample generates a sample for the renewal configuration.
+func (cfg *Renewal) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, renewalSample)
+}
+
+// ConfigName is the toml key for the renewal configuration.
+func (cfg *Renewal) ConfigName() string {
+	return "renewal"
+}
+
+var _ config.Config = (*ExpiryAlert)(nil)
+
+// ExpiryAlert configures the alerts for the trust material that is about to expire.
+type ExpiryAlert struct {
+	// TRC is the remaining validity of the latest TRC of the local ISD at which an alert is
+	// raised.
+	TRC util.DurWrap `toml:"trc,omitempty"`
+	// CA is the remaining validity of the CA certificate at which an alert is raised.
+	CA util.DurWrap `toml:"ca,omitempty"`
+	// AS is the remaining validity of the AS certificate chain at which an alert is raised.
+	AS util.DurWrap `toml:"as,omitempty"`
+	// Webhook is the URL the alerts are posted to. If it is empty, the alerts are only logged.
+	Webhook string `toml:"webhook,omitempty"`
+}
+
+// InitDefaults sets the default thresholds.
+func (cfg *ExpiryAlert) InitDefaults() {
+	if cfg.TRC.Duration == 0 {
+		cfg.TRC.Duration = DefaultExpiryAlertTRC
+	}
+	if cfg.CA.Duration == 0 {
+		cfg.CA.Duration = DefaultExpiryAlertCA
+	}
+	if cfg.AS.Duration == 0 {
+		cfg.AS.Duration = DefaultExpiryAlertAS
+	}
+}
+
+// Validate validates that the thresholds are positive and the webhook is an HTTP(S) URL.
+func (cfg *ExpiryAlert) Validate() error {
+	thresholds := []struct {
+		name      string
+		threshold util.DurWrap
+	}{
+		{name: "trc", threshold: cfg.TRC},
+		{name: "ca", threshold: cfg.CA},
+		{name: "as", threshold: cfg.AS},
+	}
+	for _, t := range thresholds {
+		if t.threshold.Duration <= 0 {
+			return serrors.New("expiry alert threshold must be positive",
+				"artifact", t.name, "threshold", t.threshold)
+		}
+	}
+	if cfg.Webhook == "" {
+		return nil
+	}
+	u, err := url.Parse(cfg.Webhook)
+	if err != nil {
+		return serrors.Wrap("parsing webhook", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return serrors.New("webhook must be an HTTP(S) URL", "webhook", cfg.Webhook)
+	}
+	return nil
+}
+
+// Sample generates a sample for the expiry alert configuration.
+func (cfg *ExpiryAlert) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, expiryAlertSample)
+}
+
+// ConfigName is the toml key for the expiry alert configuration.
+func (cfg *ExpiryAlert) ConfigName() string {
+	return "expiry_alert"
+}
+
+var _ config.Config = (*Bootstrap)(nil)
+
+// Bootstrap configures the endpoint that serves the topology and the trust material to the end
+// hosts and tools of the AS.
+type Bootstrap struct {
+	config.NoDefaulter
+	// Addr is the address the endpoint is exposed on. If it is empty, the endpoint is not
+	// exposed.
+	Addr string `toml:"addr,omitempty"`
+	// SharedSecret is the path to the PEM-encoded shared secret that the tokens authorizing the
+	// requests are signed with.
+	SharedSecret string `toml:"shared_secret,omitempty"`
+}
+
+// Validate validates that the shared secret is set if the endpoint is exposed.
+func (cfg *Bootstrap) Validate() error {
+	if cfg.Addr != "" && cfg.SharedSecret == "" {
+		return serrors.New("bootstrap endpoint requires a shared_secret")
+	}
+	return nil
+}
+
+// Sample generates a sample for the bootstrap configuration.
+func (cfg *Bootstrap) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, bootstrapSample)
+}
+
+// ConfigName is the toml key for the bootstrap configuration.
+func (cfg *Bootstrap) ConfigName() string {
+	return "bootstrap"
+}
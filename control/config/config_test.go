@@ -17,11 +17,14 @@ package config
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/pelletier/go-toml/v2"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log/logtest"
+	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/private/env/envtest"
 	"github.com/scionproto/scion/private/mgmtapi/jwtauth"
 	apitest "github.com/scionproto/scion/private/mgmtapi/mgmtapitest"
@@ -46,10 +49,14 @@ func InitTestConfig(cfg *Config) {
 	InitTestBSConfig(&cfg.BS)
 	InitTestPSConfig(&cfg.PS)
 	InitTestCA(&cfg.CA)
+	cfg.MultiISD.AdditionalISDs = []addr.ISD{42}
 }
 
 func InitTestBSConfig(cfg *BSConfig) {
 	InitTestPolicies(&cfg.Policies)
+	cfg.LinkLatency.RouterAPIs = []string{"garbage"}
+	cfg.LeaderElection.Enabled = true
+	cfg.AdaptiveIntervals.Enabled = true
 }
 
 func InitTestPolicies(cfg *Policies) {
@@ -69,6 +76,17 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	CheckTestBSConfig(t, &cfg.BS)
 	CheckTestPSConfig(t, &cfg.PS, id)
 	CheckTestCA(t, &cfg.CA)
+	assert.Empty(t, cfg.MultiISD.AdditionalISDs)
+	assert.Equal(t, KeyBackendFile, cfg.Keys.Backend)
+	assert.Equal(t, DefaultVaultMount, cfg.Keys.Vault.Mount)
+	assert.Empty(t, cfg.Renewal.CAs)
+	assert.Equal(t, DefaultRenewalLeadTime, cfg.Renewal.LeadTime.Duration)
+	assert.Equal(t, DefaultExpiryAlertTRC, cfg.ExpiryAlert.TRC.Duration)
+	assert.Equal(t, DefaultExpiryAlertCA, cfg.ExpiryAlert.CA.Duration)
+	assert.Equal(t, DefaultExpiryAlertAS, cfg.ExpiryAlert.AS.Duration)
+	assert.Empty(t, cfg.ExpiryAlert.Webhook)
+	assert.Empty(t, cfg.Bootstrap.Addr)
+	assert.Empty(t, cfg.Bootstrap.SharedSecret)
 }
 
 func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
@@ -76,7 +94,27 @@ func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
 	assert.Equal(t, DefaultPropagationInterval, cfg.PropagationInterval.Duration)
 	assert.Equal(t, DefaultRegistrationInterval, cfg.RegistrationInterval.Duration)
 	assert.False(t, cfg.EPIC)
+	assert.Zero(t, cfg.Jitter)
+	assert.Empty(t, cfg.RegistrationCores)
 	CheckTestPolicies(t, &cfg.Policies)
+	assert.Empty(t, cfg.LinkLatency.RouterAPIs)
+	assert.Equal(t, DefaultLinkLatencyQueryInterval, cfg.LinkLatency.QueryInterval.Duration)
+	assert.False(t, cfg.LeaderElection.Enabled)
+	assert.Empty(t, cfg.AdminSharedSecret)
+	assert.Equal(t, DefaultLeaderLeaseDuration, cfg.LeaderElection.LeaseDuration.Duration)
+	assert.False(t, cfg.AdaptiveIntervals.Enabled)
+	assert.Equal(t, DefaultAdaptiveMinScale, cfg.AdaptiveIntervals.MinScale)
+	assert.Equal(t, DefaultAdaptiveMaxScale, cfg.AdaptiveIntervals.MaxScale)
+	assert.Zero(t, cfg.Verification.Workers)
+	assert.False(t, cfg.Verification.DisableCache)
+	assert.Equal(t, DefaultBeaconVerificationCacheExpiration,
+		cfg.Verification.CacheExpiration.Duration)
+	assert.Equal(t, AnomalyTag, cfg.Anomalies.ASLoop)
+	assert.Equal(t, AnomalyTag, cfg.Anomalies.Latency)
+	assert.Equal(t, DefaultAnomalyMaxLatency, cfg.Anomalies.MaxLatency.Duration)
+	assert.Equal(t, AnomalyTag, cfg.Anomalies.OriginRate)
+	assert.Equal(t, DefaultAnomalyOriginRateWindow, cfg.Anomalies.OriginRateWindow.Duration)
+	assert.Equal(t, DefaultAnomalyMaxOriginations, cfg.Anomalies.MaxOriginations)
 }
 
 func CheckTestPolicies(t *testing.T, cfg *Policies) {
@@ -88,11 +126,24 @@ func CheckTestPolicies(t *testing.T, cfg *Policies) {
 
 func InitTestPSConfig(cfg *PSConfig) {
 	cfg.HiddenPathsCfg = "garbage"
+	cfg.HiddenPathsACL = "garbage"
+	cfg.LookupLimits.MaxConcurrent = 42
+	cfg.LookupCache.TTL.Duration = time.Hour
+	cfg.Revocations.Daemons = []string{"garbage"}
+	cfg.Revocations.DisableNeighbors = true
 }
 
 func CheckTestPSConfig(t *testing.T, cfg *PSConfig, id string) {
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
 	assert.Empty(t, cfg.HiddenPathsCfg)
+	assert.Equal(t, DefaultHiddenPathsReloadInterval, cfg.HiddenPathsReloadInterval.Duration)
+	assert.Empty(t, cfg.HiddenPathsACL)
+	assert.Equal(t, LookupLimits{}, cfg.LookupLimits)
+	assert.Zero(t, cfg.LookupCache.TTL.Duration)
+	assert.Zero(t, cfg.LookupCache.EmptyTTL.Duration)
+	assert.Equal(t, DefaultLookupCacheMaxEntries, cfg.LookupCache.MaxEntries)
+	assert.Empty(t, cfg.Revocations.Daemons)
+	assert.False(t, cfg.Revocations.DisableNeighbors)
 }
 
 func InitTestCA(cfg *CA) {
@@ -101,6 +152,7 @@ func InitTestCA(cfg *CA) {
 func CheckTestCA(t *testing.T, cfg *CA) {
 	assert.Equal(t, DefaultMaxASValidity, cfg.MaxASValidity.Duration)
 	assert.Equal(t, cfg.Mode, InProcess)
+	assert.Empty(t, cfg.IssuancePolicy)
 	CheckTestService(t, &cfg.Service)
 }
 
@@ -110,3 +162,182 @@ func CheckTestService(t *testing.T, cfg *CAService) {
 	assert.Equal(t, jwtauth.DefaultTokenLifetime, cfg.Lifetime.Duration)
 	assert.Empty(t, cfg.ClientID)
 }
+
+func TestRevocationsValidate(t *testing.T) {
+	testCases := map[string]struct {
+		daemons   []string
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"none":    {assertErr: assert.NoError},
+		"valid":   {daemons: []string{"127.0.0.1:30255", "[::1]:30255"}, assertErr: assert.NoError},
+		"no port": {daemons: []string{"127.0.0.1"}, assertErr: assert.Error},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := Revocations{Daemons: tc.daemons}
+			tc.assertErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestBeaconAnomaliesValidate(t *testing.T) {
+	testCases := map[string]struct {
+		cfg       BeaconAnomalies
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"defaults": {assertErr: assert.NoError},
+		"valid actions": {
+			cfg:       BeaconAnomalies{ASLoop: AnomalyReject, Latency: AnomalyOff},
+			assertErr: assert.NoError,
+		},
+		"invalid action": {
+			cfg:       BeaconAnomalies{OriginRate: "drop"},
+			assertErr: assert.Error,
+		},
+		"negative max originations": {
+			cfg:       BeaconAnomalies{MaxOriginations: -1},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tc.assertErr(t, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestMultiISDValidate(t *testing.T) {
+	testCases := map[string]struct {
+		isds      []addr.ISD
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"none":      {assertErr: assert.NoError},
+		"distinct":  {isds: []addr.ISD{2, 3}, assertErr: assert.NoError},
+		"wildcard":  {isds: []addr.ISD{0}, assertErr: assert.Error},
+		"duplicate": {isds: []addr.ISD{2, 3, 2}, assertErr: assert.Error},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := MultiISD{AdditionalISDs: tc.isds}
+			tc.assertErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestKeysValidate(t *testing.T) {
+	vault := VaultKeys{
+		Address:   "https://vault.local:8200",
+		TokenFile: "token",
+		ASKeys:    []string{"cp-as"},
+	}
+	testCases := map[string]struct {
+		cfg       Keys
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"file": {cfg: Keys{Backend: KeyBackendFile}, assertErr: assert.NoError},
+		"vault": {
+			cfg:       Keys{Backend: KeyBackendVault, Vault: vault},
+			assertErr: assert.NoError,
+		},
+		"vault no keys": {cfg: Keys{Backend: KeyBackendVault}, assertErr: assert.Error},
+		"unknown":       {cfg: Keys{Backend: "pkcs11"}, assertErr: assert.Error},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tc.assertErr(t, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestRenewalValidate(t *testing.T) {
+	leadTime := util.DurWrap{Duration: time.Hour}
+	testCases := map[string]struct {
+		cfg       Renewal
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"none": {cfg: Renewal{LeadTime: leadTime}, assertErr: assert.NoError},
+		"valid": {
+			cfg: Renewal{
+				CAs:      []addr.IA{addr.MustParseIA("1-ff00:0:110")},
+				LeadTime: leadTime,
+			},
+			assertErr: assert.NoError,
+		},
+		"wildcard": {
+			cfg:       Renewal{CAs: []addr.IA{addr.MustParseIA("1-0")}, LeadTime: leadTime},
+			assertErr: assert.Error,
+		},
+		"no lead time": {cfg: Renewal{}, assertErr: assert.Error},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tc.assertErr(t, tc.cfg.Validate())
+		})
+	}
+}
+
+func TestExpiryAlertValidate(t *testing.T) {
+	valid := func() ExpiryAlert {
+		var cfg ExpiryAlert
+		cfg.InitDefaults()
+		return cfg
+	}
+	testCases := map[string]struct {
+		cfg       func() ExpiryAlert
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"defaults": {cfg: valid, assertErr: assert.NoError},
+		"webhook": {
+			cfg: func() ExpiryAlert {
+				cfg := valid()
+				cfg.Webhook = "https://alerts.example.com/hook"
+				return cfg
+			},
+			assertErr: assert.NoError,
+		},
+		"invalid webhook": {
+			cfg: func() ExpiryAlert {
+				cfg := valid()
+				cfg.Webhook = "alerts.example.com"
+				return cfg
+			},
+			assertErr: assert.Error,
+		},
+		"negative threshold": {
+			cfg: func() ExpiryAlert {
+				cfg := valid()
+				cfg.CA.Duration = -time.Hour
+				return cfg
+			},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := tc.cfg()
+			tc.assertErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestBootstrapValidate(t *testing.T) {
+	testCases := map[string]struct {
+		cfg       Bootstrap
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"disabled": {cfg: Bootstrap{}, assertErr: assert.NoError},
+		"valid": {
+			cfg:       Bootstrap{Addr: "127.0.0.1:8041", SharedSecret: "bootstrap.key"},
+			assertErr: assert.NoError,
+		},
+		"no shared secret": {
+			cfg:       Bootstrap{Addr: "127.0.0.1:8041"},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tc.assertErr(t, tc.cfg.Validate())
+		})
+	}
+}
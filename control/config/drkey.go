@@ -17,15 +17,23 @@ package config
 import (
 	"io"
 	"net/netip"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/scionproto/scion/pkg/drkey"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/storage"
 )
 
-const DefaultPrefetchEntries = 10000
+const (
+	DefaultPrefetchEntries = 10000
+	// DefaultSecretValuePrefetch is the default time before the end of an epoch at which the
+	// secret values of the next epoch are derived.
+	DefaultSecretValuePrefetch = time.Hour
+)
 
 var _ (config.Config) = (*DRKeyConfig)(nil)
 
@@ -35,13 +43,44 @@ type DRKeyConfig struct {
 	SecretValueDB   storage.DBConfig    `toml:"secret_value_db,omitempty"`
 	Delegation      SecretValueHostList `toml:"delegation,omitempty"`
 	PrefetchEntries int                 `toml:"prefetch_entries,omitempty"`
+	// SecretValueProtocols are the protocols whose secret values are derived ahead of time, in
+	// addition to the protocols in Delegation.
+	SecretValueProtocols []string `toml:"secret_value_protocols,omitempty"`
+	// SecretValuePrefetch is the time before the end of an epoch at which the secret values of
+	// the next epoch are derived.
+	SecretValuePrefetch util.DurWrap `toml:"secret_value_prefetch,omitempty"`
+	// ControlAuth defines whether the inter-AS control-plane messages are authenticated with
+	// DRKey.
+	ControlAuth ControlAuthMode `toml:"control_auth,omitempty"`
 }
 
+// ControlAuthMode defines whether the inter-AS control-plane messages are authenticated with
+// DRKey.
+type ControlAuthMode string
+
+const (
+	// ControlAuthDisabled disables the authentication.
+	ControlAuthDisabled ControlAuthMode = "disabled"
+	// ControlAuthEnabled authenticates the outgoing messages, and rejects the incoming messages
+	// with an invalid MAC. Incoming messages without a MAC are accepted, so this mode does not
+	// protect against forged messages; it is only meant for the migration to ControlAuthRequired.
+	ControlAuthEnabled ControlAuthMode = "enabled"
+	// ControlAuthRequired authenticates the outgoing messages, and rejects the incoming
+	// messages without a valid MAC.
+	ControlAuthRequired ControlAuthMode = "required"
+)
+
 // InitDefaults initializes values of unset keys and determines if the configuration enables DRKey.
 func (cfg *DRKeyConfig) InitDefaults() {
 	if cfg.PrefetchEntries == 0 {
 		cfg.PrefetchEntries = DefaultPrefetchEntries
 	}
+	if cfg.SecretValuePrefetch.Duration == 0 {
+		cfg.SecretValuePrefetch.Duration = DefaultSecretValuePrefetch
+	}
+	if cfg.ControlAuth == "" {
+		cfg.ControlAuth = ControlAuthDisabled
+	}
 	config.InitAll(
 		cfg.Level1DB.WithDefault(""),
 		cfg.SecretValueDB.WithDefault(""),
@@ -56,9 +95,63 @@ func (cfg *DRKeyConfig) Enabled() bool {
 
 // Validate validates that all values are parsable.
 func (cfg *DRKeyConfig) Validate() error {
+	for _, proto := range cfg.SecretValueProtocols {
+		if _, err := parseProtocol(proto); err != nil {
+			return err
+		}
+	}
+	if cfg.SecretValuePrefetch.Duration < 0 {
+		return serrors.New("secret_value_prefetch must not be negative",
+			"secret_value_prefetch", cfg.SecretValuePrefetch)
+	}
+	switch mode := ControlAuthMode(strings.ToLower(string(cfg.ControlAuth))); mode {
+	case ControlAuthDisabled:
+		cfg.ControlAuth = mode
+	case ControlAuthEnabled, ControlAuthRequired:
+		if !cfg.Enabled() {
+			return serrors.New("control_auth requires DRKey to be enabled",
+				"control_auth", cfg.ControlAuth)
+		}
+		cfg.ControlAuth = mode
+	default:
+		return serrors.New("unknown control_auth mode", "control_auth", cfg.ControlAuth)
+	}
 	return config.ValidateAll(&cfg.Level1DB, &cfg.SecretValueDB, &cfg.Delegation)
 }
 
+// SecretValueProtocolIDs returns the protocols whose secret values are derived ahead of time,
+// i.e., the protocols in SecretValueProtocols and Delegation, in ascending order. Protocols
+// that cannot be parsed are skipped.
+func (cfg *DRKeyConfig) SecretValueProtocolIDs() []drkey.Protocol {
+	var protos []drkey.Protocol
+	add := func(proto string) {
+		if id, err := parseProtocol(proto); err == nil && !slices.Contains(protos, id) {
+			protos = append(protos, id)
+		}
+	}
+	for _, proto := range cfg.SecretValueProtocols {
+		add(proto)
+	}
+	for proto := range cfg.Delegation {
+		add(proto)
+	}
+	slices.Sort(protos)
+	return protos
+}
+
+// parseProtocol parses the lower case protocol name, as used in the configuration. The generic
+// protocol is not allowed.
+func parseProtocol(proto string) (drkey.Protocol, error) {
+	protoID, ok := drkey.ProtocolStringToId("PROTOCOL_" + strings.ToUpper(proto))
+	if !ok {
+		return 0, serrors.New("Configured protocol not found", "protocol", proto)
+	}
+	if protoID == drkey.Generic {
+		return 0, serrors.New("GENERIC protocol is not allowed")
+	}
+	return protoID, nil
+}
+
 // Sample writes a config sample to the writer.
 func (cfg *DRKeyConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, drkeySample)
@@ -102,13 +195,8 @@ func (cfg *SecretValueHostList) InitDefaults() {
 // Validate validates that the protocols exist, and their addresses are parsable.
 func (cfg *SecretValueHostList) Validate() error {
 	for proto, list := range *cfg {
-		protoString := "PROTOCOL_" + strings.ToUpper(proto)
-		protoID, ok := drkey.ProtocolStringToId(protoString)
-		if !ok {
-			return serrors.New("Configured protocol not found", "protocol", proto)
-		}
-		if protoID == drkey.Generic {
-			return serrors.New("GENERIC protocol is not allowed")
+		if _, err := parseProtocol(proto); err != nil {
+			return err
 		}
 		for _, ip := range list {
 			if _, err := netip.ParseAddr(ip); err != nil {
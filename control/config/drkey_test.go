@@ -33,6 +33,9 @@ func TestInitDefaults(t *testing.T) {
 	cfg.InitDefaults()
 	assert.EqualValues(t, DefaultPrefetchEntries, cfg.PrefetchEntries)
 	assert.NotNil(t, cfg.Delegation)
+	assert.Empty(t, cfg.SecretValueProtocols)
+	assert.Equal(t, DefaultSecretValuePrefetch, cfg.SecretValuePrefetch.Duration)
+	assert.Equal(t, ControlAuthDisabled, cfg.ControlAuth)
 }
 
 func TestSample(t *testing.T) {
@@ -162,3 +165,71 @@ func tempFile(t *testing.T) string {
 	require.NoError(t, err)
 	return name
 }
+
+func TestSecretValueProtocolIDs(t *testing.T) {
+	var cfg DRKeyConfig
+	sample := `
+secret_value_protocols = ["scmp"]
+
+[delegation]
+scmp = ["1.1.1.1"]
+`
+	err := toml.NewDecoder(bytes.NewReader([]byte(sample))).DisallowUnknownFields().Decode(&cfg)
+	require.NoError(t, err)
+	cfg.InitDefaults()
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, []drkey.Protocol{drkey.SCMP}, cfg.SecretValueProtocolIDs())
+
+	cfg.SecretValueProtocols = []string{"generic"}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestControlAuth(t *testing.T) {
+	cases := map[string]struct {
+		mode      ControlAuthMode
+		enabled   bool
+		expected  ControlAuthMode
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"disabled": {
+			mode:      "disabled",
+			expected:  ControlAuthDisabled,
+			assertErr: assert.NoError,
+		},
+		"enabled": {
+			mode:      "Enabled",
+			enabled:   true,
+			expected:  ControlAuthEnabled,
+			assertErr: assert.NoError,
+		},
+		"required": {
+			mode:      "required",
+			enabled:   true,
+			expected:  ControlAuthRequired,
+			assertErr: assert.NoError,
+		},
+		"without DRKey": {
+			mode:      "required",
+			assertErr: assert.Error,
+		},
+		"unknown": {
+			mode:      "always",
+			enabled:   true,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &DRKeyConfig{ControlAuth: tc.mode}
+			cfg.InitDefaults()
+			if tc.enabled {
+				cfg.Level1DB.Connection = "test"
+			}
+			err := cfg.Validate()
+			tc.assertErr(t, err)
+			if err == nil {
+				assert.Equal(t, tc.expected, cfg.ControlAuth)
+			}
+		})
+	}
+}
@@ -23,6 +23,15 @@ query_interval = "5m"
 # paths functionality is not enabled. If the path starts with http:// or
 # https:// the configuration is fetched from the given URL. (default: "")
 hidden_paths_cfg = ""
+# The interval between reloads of the hidden paths configuration. Changes of the
+# groups and their members are applied without a restart, except for changes of
+# whether this AS is a writer. (default 30s)
+hidden_paths_reload_interval = "30s"
+# The path to the file the ACLs of the hidden path groups that are set through
+# the management API are persisted in. The ACLs override the writers and readers
+# of the groups for which this AS is a registry. If the path is empty, the ACLs
+# are lost on restart. (default: "")
+hidden_paths_acl = ""
 `
 
 const caSample = `
@@ -49,6 +58,12 @@ max_as_validity = "3d"
 #
 # (default disabled)
 mode = "in-process"
+
+# The path to the yaml file with the issuance policy, which constrains the
+# subjects, the validity, the key types and the renewal frequency of the AS
+# certificates issued in the in-process mode. If empty, certificates are issued
+# for all ASes of the ISD. (default "")
+issuance_policy = ""
 `
 
 const serviceSample = `
@@ -67,8 +82,171 @@ client_id = ""
 const drkeySample = `
 # Number of distinct Level1Keys to be prefetched.
 prefetch_entries = 10000
+
+# The protocols whose secret values are derived ahead of time, in addition to
+# the protocols in the delegation list. (default [])
+secret_value_protocols = []
+
+# The time before the end of an epoch at which the secret values of the next
+# epoch are derived. (default 1h)
+secret_value_prefetch = "1h"
+
+# Whether the inter-AS control-plane messages, i.e., the segment lookups, the
+# authoritative hidden segment lookups and the revocation notifications, are
+# authenticated with DRKey. In the enabled mode, the outgoing messages are
+# authenticated, and incoming messages with an invalid MAC are rejected, but the
+# ones without a MAC are accepted, so that forged messages are not stopped. In
+# the required mode, which is the advised one, incoming messages without a MAC
+# are rejected too. Either mode requires DRKey to be enabled.
+# (disabled|enabled|required) (default disabled)
+control_auth = "disabled"
 `
 const drkeySecretValueHostListSample = `
 # The list of hosts authorized to get a SV per protocol.
 scmp = [ "127.0.0.1", "127.0.0.2"]
 `
+
+const lookupCacheSample = `
+# The time segment lookups that returned segments are cached for. Cached lookups
+# are answered without asking the core ASes again. The entries never outlive
+# their segments. In case of 0, the cache is disabled. (default 0s)
+ttl = "0s"
+
+# The time segment lookups that returned no segments are cached for. In case of
+# 0, they are not cached. (default 0s)
+empty_ttl = "0s"
+
+# The maximum number of cached lookups. (default 10000)
+max_entries = 10000
+`
+
+const lookupLimitsSample = `
+# The number of segment lookups per second allowed per client on average.
+# Clients are identified by their ISD-AS, if known, and their IP address.
+# Lookups beyond the limit are rejected with the gRPC status
+# RESOURCE_EXHAUSTED. In case of 0, the lookups are not limited. (default 0)
+client_rate = 0.0
+
+# The number of lookups a client can send at once. In case of 0, the client
+# rate rounded up is used. (default 0)
+client_burst = 0
+
+# The number of segment lookups per second allowed from all clients on average.
+# In case of 0, the lookups are not limited. (default 0)
+global_rate = 0.0
+
+# The number of lookups all clients can send at once. In case of 0, the global
+# rate rounded up is used. (default 0)
+global_burst = 0
+
+# The number of lookups that are processed concurrently. Further lookups are
+# rejected with the gRPC status UNAVAILABLE, such that an overloaded control
+# service sheds the load instead of queuing it. In case of 0, the number is not
+# limited. (default 0)
+max_concurrent = 0
+`
+
+const revocationsSample = `
+# The addresses of the daemons of the local AS that are notified about the
+# revocations of interfaces, so that they stop using the paths over them. The
+# revocations are derived from the notifications of the routers when an
+# interface goes down. (default [])
+daemons = []
+
+# Disable the notification of the control services of the neighbor ASes about
+# the revocations of the interfaces of the local AS. (default false)
+disable_neighbors = false
+`
+
+const multiISDSample = `
+# The ISDs a core AS is a member of, in addition to the ISD of the topology.
+# In each of them, the AS has the ISD-AS with the AS number of the topology,
+# and needs an AS certificate for it in the configuration directory. Each
+# interface is attached to the ISD of its neighbor if the AS is a member of it,
+# and to the ISD of the topology otherwise. (default [])
+additional_isds = []
+`
+
+const keysSample = `
+# The backend that keeps the private keys of the AS certificates and, in a CA
+# AS, of the CA certificates.
+#
+# - file:  The keys are PEM files in the crypto/as and crypto/ca directories of
+#          the configuration directory.
+# - vault: The keys are kept in the transit secrets engine of HashiCorp Vault,
+#          which creates the signatures. The keys never leave Vault.
+#
+# PKCS#11 modules are not supported directly.
+#
+# (default file)
+backend = "file"
+`
+
+const vaultKeysSample = `
+# The address of Vault. (default "")
+addr = "https://vault.local:8200"
+
+# The file with the Vault token that authenticates the control service. It is
+# read for every request, such that it can be renewed by an agent. (default "")
+token_file = "/etc/scion/vault-token"
+
+# The PEM file with the CA certificates that the TLS certificate of Vault is
+# verified with. If empty, the system roots are used. (default "")
+ca_file = ""
+
+# The path the transit secrets engine is mounted at. (default transit)
+mount = "transit"
+
+# The names of the keys of the AS certificates. Every version of the keys can be
+# used, such that a key can be rotated before its certificate is issued.
+# (default [])
+as_keys = ["cp-as"]
+
+# The names of the keys of the CA certificates, in a CA AS. (default [])
+ca_keys = []
+`
+
+const renewalSample = `
+# The ISD-ASes of the CAs the AS certificate is renewed with, in order of
+# preference. The control service renews the AS certificate before it expires,
+# with a fresh private key, and proves the possession of the current key by
+# signing the request with it. The renewed certificate chain and private key
+# are written to the crypto/as directory. If empty, the AS certificate is not
+# renewed automatically. (default [])
+cas = []
+
+# The remaining validity of the AS certificate at which it is renewed. The
+# certificate is not renewed before half of its validity has passed. (default 1d)
+lead_time = "1d"
+`
+
+const expiryAlertSample = `
+# The remaining validity of the latest TRC of the local ISD at which an alert is
+# raised. (default 30d)
+trc = "30d"
+
+# The remaining validity of the CA certificate at which an alert is raised. Only
+# applies if the in-process CA is used. (default 2d)
+ca = "2d"
+
+# The remaining validity of the AS certificate chain at which an alert is
+# raised. (default 12h)
+as = "12h"
+
+# The URL the alerts are posted to as JSON. If empty, the alerts are only
+# logged. (default "")
+webhook = ""
+`
+
+const bootstrapSample = `
+# The address to expose the bootstrap endpoint on (host:port or ip:port). The
+# endpoint serves the topology, the TRCs and the certificate chains to the end
+# hosts and tools of the AS. If not set, the endpoint is not exposed.
+# (default "")
+addr = ""
+
+# The path to the PEM-encoded shared secret that the tokens authorizing the
+# requests to the bootstrap endpoint are signed with. Required if the endpoint
+# is exposed. (default "")
+shared_secret = ""
+`
@@ -0,0 +1,200 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/subtle"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dchest/cmac"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// DefaultMaxClockSkew is the default maximum difference between the timestamp of an
+	// authenticated message and the time it is verified at.
+	DefaultMaxClockSkew = 10 * time.Second
+	// DefaultFetchBackoff is the default time during which no level 1 keys are fetched from an
+	// AS after fetching one failed.
+	DefaultFetchBackoff = time.Minute
+)
+
+var (
+	// ErrNotAuthenticated indicates that the MAC of a message is invalid.
+	ErrNotAuthenticated = serrors.New("message not authenticated")
+	// ErrNotReady indicates that the authenticator has no engine yet.
+	ErrNotReady = serrors.New("authenticator not ready")
+)
+
+// AuthEngine provides the level 1 keys that authenticate the messages.
+type AuthEngine interface {
+	GetLevel1Key(ctx context.Context, meta drkey.Level1Meta) (drkey.Level1Key, error)
+	DeriveLevel1(meta drkey.Level1Meta) (drkey.Level1Key, error)
+}
+
+// Authenticator computes and verifies the MACs of the control-plane messages exchanged with
+// other ASes. A message from AS A to AS B is authenticated with the level 1 key K_{B->A} of the
+// generic protocol. B derives this key from its secret value, so it can reject spoofed messages
+// without any lookup in a remote AS, while A fetches the key from B once per epoch.
+//
+// The MAC covers a label that identifies the kind of message, a timestamp and the message. The
+// timestamp must be within MaxClockSkew of the time the message is verified at. Messages can be
+// replayed within this window, so only idempotent messages should be authenticated this way.
+//
+// The engine is set with SetEngine, which allows creating the authenticator before the DRKey
+// engine is ready. Until then, no MACs are computed and no messages are verified.
+type Authenticator struct {
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+	// MaxClockSkew is the maximum difference between the timestamp of a message and the time
+	// it is verified at. If it is zero, DefaultMaxClockSkew is used.
+	MaxClockSkew time.Duration
+	// FetchBackoff is the time during which no level 1 keys are fetched from an AS after
+	// fetching one failed, e.g., because the AS does not support DRKey. If it is zero,
+	// DefaultFetchBackoff is used.
+	FetchBackoff time.Duration
+
+	engine atomic.Pointer[AuthEngine]
+
+	mu     sync.Mutex
+	failed map[addr.IA]time.Time
+}
+
+// SetEngine sets the engine that provides the level 1 keys.
+func (a *Authenticator) SetEngine(engine AuthEngine) {
+	a.engine.Store(&engine)
+}
+
+// MAC computes the MAC of the message with the label and the timestamp ts, for the AS remote.
+// It uses the level 1 key K_{remote->local}, which is fetched from the remote AS.
+func (a *Authenticator) MAC(
+	ctx context.Context,
+	remote addr.IA,
+	label string,
+	ts time.Time,
+	msg []byte,
+) ([]byte, error) {
+
+	engine := a.engine.Load()
+	if engine == nil {
+		return nil, ErrNotReady
+	}
+	if a.backingOff(remote, time.Now()) {
+		return nil, serrors.New("fetching level 1 keys is backing off", "isd_as", remote)
+	}
+	key, err := (*engine).GetLevel1Key(ctx, drkey.Level1Meta{
+		Validity: ts,
+		ProtoId:  drkey.Generic,
+		SrcIA:    remote,
+		DstIA:    a.LocalIA,
+	})
+	if err != nil {
+		a.mu.Lock()
+		if a.failed == nil {
+			a.failed = make(map[addr.IA]time.Time)
+		}
+		a.failed[remote] = time.Now()
+		a.mu.Unlock()
+		return nil, serrors.Wrap("getting level 1 key", err, "isd_as", remote)
+	}
+	return computeMAC(key.Key, label, ts, msg)
+}
+
+// Verify verifies the MAC of the message with the label and the timestamp ts, from the AS
+// remote. It uses the level 1 key K_{local->remote}, which is derived locally. It returns an
+// error wrapping ErrNotAuthenticated if the MAC or the timestamp is invalid.
+func (a *Authenticator) Verify(
+	remote addr.IA,
+	label string,
+	ts time.Time,
+	msg []byte,
+	mac []byte,
+) error {
+
+	engine := a.engine.Load()
+	if engine == nil {
+		return ErrNotReady
+	}
+	maxSkew := a.MaxClockSkew
+	if maxSkew == 0 {
+		maxSkew = DefaultMaxClockSkew
+	}
+	if skew := time.Since(ts).Abs(); skew > maxSkew {
+		return serrors.JoinNoStack(ErrNotAuthenticated, nil, "reason", "timestamp out of range",
+			"timestamp", ts, "max_skew", maxSkew)
+	}
+	key, err := (*engine).DeriveLevel1(drkey.Level1Meta{
+		Validity: ts,
+		ProtoId:  drkey.Generic,
+		SrcIA:    a.LocalIA,
+		DstIA:    remote,
+	})
+	if err != nil {
+		return serrors.Wrap("deriving level 1 key", err, "isd_as", remote)
+	}
+	expected, err := computeMAC(key.Key, label, ts, msg)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(mac, expected) != 1 {
+		return serrors.JoinNoStack(ErrNotAuthenticated, nil, "reason", "invalid MAC")
+	}
+	return nil
+}
+
+func (a *Authenticator) backingOff(remote addr.IA, now time.Time) bool {
+	backoff := a.FetchBackoff
+	if backoff == 0 {
+		backoff = DefaultFetchBackoff
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	failed, ok := a.failed[remote]
+	if !ok {
+		return false
+	}
+	if now.Sub(failed) >= backoff {
+		delete(a.failed, remote)
+		return false
+	}
+	return true
+}
+
+func computeMAC(key drkey.Key, label string, ts time.Time, msg []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, serrors.Wrap("initializing AES cipher", err)
+	}
+	mac, err := cmac.New(block)
+	if err != nil {
+		return nil, serrors.Wrap("initializing CMAC", err)
+	}
+	var hdr [10]byte
+	binary.BigEndian.PutUint16(hdr[:2], uint16(len(label)))
+	binary.BigEndian.PutUint64(hdr[2:], uint64(ts.UnixMilli()))
+	mac.Write(hdr[:2])
+	mac.Write([]byte(label))
+	mac.Write(hdr[2:])
+	mac.Write(msg)
+	return mac.Sum(nil), nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cs_drkey "github.com/scionproto/scion/control/drkey"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+var _ cs_drkey.AuthEngine = (*cs_drkey.ServiceEngine)(nil)
+
+func TestAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	ia110 := addr.MustParseIA("1-ff00:0:110")
+	ia111 := addr.MustParseIA("1-ff00:0:111")
+	ia112 := addr.MustParseIA("1-ff00:0:112")
+	engine := &fakeAuthEngine{}
+	sender := &cs_drkey.Authenticator{LocalIA: ia111}
+	receiver := &cs_drkey.Authenticator{LocalIA: ia110}
+	msg := []byte("segments request")
+	now := time.Now()
+
+	_, err := sender.MAC(ctx, ia110, "label", now, msg)
+	assert.ErrorIs(t, err, cs_drkey.ErrNotReady)
+	assert.ErrorIs(t, receiver.Verify(ia111, "label", now, msg, nil), cs_drkey.ErrNotReady)
+
+	sender.SetEngine(engine)
+	receiver.SetEngine(engine)
+	mac, err := sender.MAC(ctx, ia110, "label", now, msg)
+	require.NoError(t, err)
+	assert.NoError(t, receiver.Verify(ia111, "label", now, msg, mac))
+
+	t.Run("spoofed source", func(t *testing.T) {
+		err := receiver.Verify(ia112, "label", now, msg, mac)
+		assert.ErrorIs(t, err, cs_drkey.ErrNotAuthenticated)
+	})
+	t.Run("other label", func(t *testing.T) {
+		err := receiver.Verify(ia111, "other", now, msg, mac)
+		assert.ErrorIs(t, err, cs_drkey.ErrNotAuthenticated)
+	})
+	t.Run("modified message", func(t *testing.T) {
+		err := receiver.Verify(ia111, "label", now, []byte("segments reply"), mac)
+		assert.ErrorIs(t, err, cs_drkey.ErrNotAuthenticated)
+	})
+	t.Run("stale timestamp", func(t *testing.T) {
+		old := now.Add(-time.Minute)
+		mac, err := sender.MAC(ctx, ia110, "label", old, msg)
+		require.NoError(t, err)
+		err = receiver.Verify(ia111, "label", old, msg, mac)
+		assert.ErrorIs(t, err, cs_drkey.ErrNotAuthenticated)
+	})
+	t.Run("fetch backoff", func(t *testing.T) {
+		engine.err = serrors.New("not reachable")
+		_, err := sender.MAC(ctx, ia112, "label", now, msg)
+		assert.Error(t, err)
+		engine.err = nil
+		_, err = sender.MAC(ctx, ia112, "label", now, msg)
+		assert.Error(t, err)
+		assert.Equal(t, 1, engine.fetches[ia112])
+	})
+}
+
+// fakeAuthEngine returns level 1 keys that only depend on the source and the destination.
+type fakeAuthEngine struct {
+	err     error
+	fetches map[addr.IA]int
+}
+
+func (e *fakeAuthEngine) GetLevel1Key(
+	_ context.Context,
+	meta drkey.Level1Meta,
+) (drkey.Level1Key, error) {
+
+	if e.fetches == nil {
+		e.fetches = make(map[addr.IA]int)
+	}
+	e.fetches[meta.SrcIA]++
+	if e.err != nil {
+		return drkey.Level1Key{}, e.err
+	}
+	return e.DeriveLevel1(meta)
+}
+
+func (e *fakeAuthEngine) DeriveLevel1(meta drkey.Level1Meta) (drkey.Level1Key, error) {
+	key := drkey.Level1Key{SrcIA: meta.SrcIA, DstIA: meta.DstIA, ProtoId: meta.ProtoId}
+	binary.BigEndian.PutUint64(key.Key[:8], uint64(meta.SrcIA))
+	binary.BigEndian.PutUint64(key.Key[8:], uint64(meta.DstIA))
+	return key, nil
+}
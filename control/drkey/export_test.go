@@ -14,6 +14,15 @@
 
 package drkey
 
+import (
+	"context"
+	"time"
+)
+
 func FromPrefetcher() fromPrefetcher {
 	return fromPrefetcher{}
 }
+
+func (r *SecretValueRotator) RotateAt(ctx context.Context, now time.Time, force bool) error {
+	return r.rotate(ctx, now, force)
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	csdrkey "github.com/scionproto/scion/control/drkey"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+const (
+	// timestampKey is the metadata key of the timestamp of an authenticated request, in
+	// milliseconds since the Unix epoch.
+	timestampKey = "scion-drkey-timestamp"
+	// macKey is the metadata key of the MAC of an authenticated request.
+	macKey = "scion-drkey-mac-bin"
+)
+
+// AuthenticatedMethods are the inter-AS methods whose requests are authenticated with DRKey,
+// i.e., the segment lookups and the authoritative hidden segment lookups.
+var AuthenticatedMethods = []string{
+	"/proto.control_plane.v1.SegmentLookupService/Segments",
+	"/proto.hidden_segment.v1.AuthoritativeHiddenSegmentLookupService/AuthoritativeHiddenSegments",
+}
+
+// ClientAuthInterceptor adds the DRKey MAC of the request to the outgoing requests of the
+// methods to other ASes. The MAC covers the method, a timestamp and the request. If the MAC
+// cannot be computed, e.g., because the remote AS does not support DRKey, the request is sent
+// without it.
+func ClientAuthInterceptor(
+	auth *csdrkey.Authenticator,
+	methods []string,
+) grpc.UnaryClientInterceptor {
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, resp any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+
+		remote, err := snet.ParseUDPAddr(cc.Target())
+		if err != nil || remote.IA == auth.LocalIA || !slices.Contains(methods, method) {
+			return invoker(ctx, method, req, resp, cc, opts...)
+		}
+		msg, err := marshalRequest(req)
+		if err != nil {
+			return invoker(ctx, method, req, resp, cc, opts...)
+		}
+		now := time.Now()
+		mac, err := auth.MAC(ctx, remote.IA, method, now, msg)
+		if err != nil {
+			log.FromCtx(ctx).Debug("Sending request without DRKey MAC", "method", method,
+				"isd_as", remote.IA, "err", err)
+			return invoker(ctx, method, req, resp, cc, opts...)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx,
+			timestampKey, strconv.FormatInt(now.UnixMilli(), 10),
+			macKey, string(mac),
+		)
+		return invoker(ctx, method, req, resp, cc, opts...)
+	}
+}
+
+// ServerAuthInterceptor verifies the DRKey MACs of the incoming requests of the methods from
+// other ASes. Requests with an invalid MAC are rejected with the status UNAUTHENTICATED. If
+// required is set, requests without a MAC are rejected too; otherwise they are served as
+// before.
+func ServerAuthInterceptor(
+	auth *csdrkey.Authenticator,
+	methods []string,
+	required bool,
+) grpc.UnaryServerInterceptor {
+
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+
+		if !slices.Contains(methods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+		remote, ok := p.Addr.(*snet.UDPAddr)
+		if !ok || remote.IA == auth.LocalIA {
+			return handler(ctx, req)
+		}
+		err := verifyRequest(ctx, auth, remote.IA, info.FullMethod, req)
+		switch {
+		case err == nil:
+			return handler(ctx, req)
+		case errors.Is(err, errNoMAC) && !required:
+			return handler(ctx, req)
+		default:
+			log.FromCtx(ctx).Debug("Rejecting unauthenticated request", "method", info.FullMethod,
+				"isd_as", remote.IA, "err", err)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+	}
+}
+
+var errNoMAC = serrors.New("request has no DRKey MAC")
+
+func verifyRequest(
+	ctx context.Context,
+	auth *csdrkey.Authenticator,
+	remote addr.IA,
+	method string,
+	req any,
+) error {
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	macs, timestamps := md.Get(macKey), md.Get(timestampKey)
+	if len(macs) == 0 {
+		return errNoMAC
+	}
+	if len(macs) != 1 || len(timestamps) != 1 {
+		return csdrkey.ErrNotAuthenticated
+	}
+	millis, err := strconv.ParseInt(timestamps[0], 10, 64)
+	if err != nil {
+		return csdrkey.ErrNotAuthenticated
+	}
+	msg, err := marshalRequest(req)
+	if err != nil {
+		return err
+	}
+	return auth.Verify(remote, method, time.UnixMilli(millis), msg, []byte(macs[0]))
+}
+
+// marshalRequest deterministically marshals the request, such that the client and the server
+// compute the MAC over the same bytes.
+func marshalRequest(req any) ([]byte, error) {
+	m, ok := req.(proto.Message)
+	if !ok {
+		return nil, serrors.New("request is not a protobuf message")
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(m)
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	csdrkey "github.com/scionproto/scion/control/drkey"
+	dk_grpc "github.com/scionproto/scion/control/drkey/grpc"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+func TestAuthInterceptors(t *testing.T) {
+	ia110 := addr.MustParseIA("1-ff00:0:110")
+	ia111 := addr.MustParseIA("1-ff00:0:111")
+	method := dk_grpc.AuthenticatedMethods[0]
+	newAuth := func(ia addr.IA) *csdrkey.Authenticator {
+		auth := &csdrkey.Authenticator{LocalIA: ia}
+		auth.SetEngine(authEngine{})
+		return auth
+	}
+	client := dk_grpc.ClientAuthInterceptor(newAuth(ia111), dk_grpc.AuthenticatedMethods)
+
+	// send returns the incoming context of the request, as seen by the server.
+	send := func(t *testing.T, req *cppb.SegmentsRequest, target string) context.Context {
+		cc, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer cc.Close()
+		var md metadata.MD
+		invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn,
+			_ ...grpc.CallOption) error {
+
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		}
+		err = client(context.Background(), method, req, nil, cc, invoker)
+		require.NoError(t, err)
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		return peer.NewContext(ctx, &peer.Peer{Addr: &snet.UDPAddr{IA: ia111}})
+	}
+	serve := func(ctx context.Context, req *cppb.SegmentsRequest, required bool) codes.Code {
+		server := dk_grpc.ServerAuthInterceptor(newAuth(ia110), dk_grpc.AuthenticatedMethods,
+			required)
+		handler := func(context.Context, any) (any, error) { return nil, nil }
+		_, err := server(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return status.Code(err)
+	}
+	req := &cppb.SegmentsRequest{SrcIsdAs: uint64(ia111), DstIsdAs: uint64(ia110)}
+	remote := "1-ff00:0:110,127.0.0.1:30252"
+
+	t.Run("authenticated", func(t *testing.T) {
+		ctx := send(t, req, remote)
+		assert.Equal(t, codes.OK, serve(ctx, req, true))
+	})
+	t.Run("modified", func(t *testing.T) {
+		ctx := send(t, req, remote)
+		modified := &cppb.SegmentsRequest{SrcIsdAs: uint64(ia111), DstIsdAs: uint64(ia111)}
+		assert.Equal(t, codes.Unauthenticated, serve(ctx, modified, false))
+	})
+	t.Run("without MAC", func(t *testing.T) {
+		// Requests to the local AS are not authenticated.
+		ctx := send(t, req, "1-ff00:0:111,127.0.0.1:30252")
+		assert.Equal(t, codes.OK, serve(ctx, req, false))
+		assert.Equal(t, codes.Unauthenticated, serve(ctx, req, true))
+	})
+	t.Run("other method", func(t *testing.T) {
+		server := dk_grpc.ServerAuthInterceptor(newAuth(ia110), dk_grpc.AuthenticatedMethods,
+			true)
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &snet.UDPAddr{IA: ia111}})
+		handler := func(context.Context, any) (any, error) { return nil, nil }
+		_, err := server(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/other"}, handler)
+		assert.NoError(t, err)
+	})
+}
+
+type authEngine struct{}
+
+func (e authEngine) GetLevel1Key(
+	_ context.Context,
+	meta drkey.Level1Meta,
+) (drkey.Level1Key, error) {
+
+	return e.DeriveLevel1(meta)
+}
+
+func (authEngine) DeriveLevel1(meta drkey.Level1Meta) (drkey.Level1Key, error) {
+	key := drkey.Level1Key{
+		Epoch:   drkey.Epoch{NotBefore: meta.Validity, NotAfter: meta.Validity.Add(time.Hour)},
+		SrcIA:   meta.SrcIA,
+		DstIA:   meta.DstIA,
+		ProtoId: meta.ProtoId,
+	}
+	binary.BigEndian.PutUint64(key.Key[:8], uint64(meta.SrcIA))
+	binary.BigEndian.PutUint64(key.Key[8:], uint64(meta.DstIA))
+	return key, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// SecretValueEngine provides the secret values.
+type SecretValueEngine interface {
+	GetSecretValue(ctx context.Context, meta drkey.SecretValueMeta) (drkey.SecretValue, error)
+}
+
+// SecretValueStatus is the status of the secret values of a protocol.
+type SecretValueStatus struct {
+	// Protocol is the protocol of the secret values.
+	Protocol drkey.Protocol
+	// Current is the epoch of the current secret value.
+	Current drkey.Epoch
+	// Next is the epoch of the secret value of the next epoch. It is nil if that secret value
+	// has not been derived yet.
+	Next *drkey.Epoch
+}
+
+// SecretValueRotator is a periodic task that manages the secret values of the protocols. It
+// derives and stores the secret value of the current epoch, and the one of the next epoch once
+// the current epoch ends within the prefetch window, such that the level 1 keys of the next
+// epoch can be served before it starts.
+//
+// The epochs are aligned to the epoch duration, so rotating only makes the secret values of
+// the next epoch available early; it does not end the current epoch.
+type SecretValueRotator struct {
+	// Engine derives and stores the secret values.
+	Engine SecretValueEngine
+	// Protocols are the protocols whose secret values are managed.
+	Protocols []drkey.Protocol
+	// Prefetch is the time before the end of the current epoch at which the secret values of
+	// the next epoch are derived.
+	Prefetch time.Duration
+
+	mu     sync.Mutex
+	status map[drkey.Protocol]SecretValueStatus
+}
+
+// Name returns the task name.
+func (r *SecretValueRotator) Name() string {
+	return "drkey_secret_value_rotator"
+}
+
+// Run derives the secret values of the current epoch, and those of the next epoch if the current
+// epoch ends within the prefetch window.
+func (r *SecretValueRotator) Run(ctx context.Context) {
+	if err := r.rotate(ctx, time.Now(), false); err != nil {
+		log.FromCtx(ctx).Info("Failed to rotate DRKey secret values", "err", err)
+	}
+}
+
+// Rotate derives the secret values of the next epoch immediately, regardless of the prefetch
+// window.
+func (r *SecretValueRotator) Rotate(ctx context.Context) error {
+	return r.rotate(ctx, time.Now(), true)
+}
+
+// Status returns the status of the secret values, in the order of the protocols.
+func (r *SecretValueRotator) Status() []SecretValueStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := make([]SecretValueStatus, 0, len(r.status))
+	for _, proto := range r.Protocols {
+		if s, ok := r.status[proto]; ok {
+			status = append(status, s)
+		}
+	}
+	return status
+}
+
+func (r *SecretValueRotator) rotate(ctx context.Context, now time.Time, force bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.status == nil {
+		r.status = make(map[drkey.Protocol]SecretValueStatus)
+	}
+	var errs serrors.List
+	for _, proto := range r.Protocols {
+		if err := r.rotateProtocol(ctx, now, proto, force); err != nil {
+			errs = append(errs, serrors.Wrap("rotating", err, "protocol", proto))
+		}
+	}
+	return errs.ToError()
+}
+
+func (r *SecretValueRotator) rotateProtocol(
+	ctx context.Context,
+	now time.Time,
+	proto drkey.Protocol,
+	force bool,
+) error {
+
+	current, err := r.Engine.GetSecretValue(ctx, drkey.SecretValueMeta{
+		ProtoId:  proto,
+		Validity: now,
+	})
+	if err != nil {
+		return serrors.Wrap("getting current secret value", err)
+	}
+	status := SecretValueStatus{Protocol: proto, Current: current.Epoch}
+	prev, ok := r.status[proto]
+	derived := ok && prev.Next != nil && prev.Next.NotBefore.Equal(current.Epoch.NotAfter)
+	if force || derived || !now.Add(r.Prefetch).Before(current.Epoch.NotAfter) {
+		next, err := r.Engine.GetSecretValue(ctx, drkey.SecretValueMeta{
+			ProtoId:  proto,
+			Validity: current.Epoch.NotAfter,
+		})
+		if err != nil {
+			r.status[proto] = status
+			return serrors.Wrap("getting next secret value", err)
+		}
+		status.Next = &next.Epoch
+	}
+	r.status[proto] = status
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cs_drkey "github.com/scionproto/scion/control/drkey"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/private/periodic"
+)
+
+var _ periodic.Task = (*cs_drkey.SecretValueRotator)(nil)
+var _ cs_drkey.SecretValueEngine = (*cs_drkey.ServiceEngine)(nil)
+
+func TestSecretValueRotator(t *testing.T) {
+	ctx := context.Background()
+	svdb := newSVDatabase(t)
+	defer svdb.Close()
+	masterKey := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	engine := &cs_drkey.ServiceEngine{
+		SecretBackend: cs_drkey.NewSecretValueBackend(svdb, masterKey, time.Hour),
+	}
+	rotator := &cs_drkey.SecretValueRotator{
+		Engine:    engine,
+		Protocols: []drkey.Protocol{drkey.SCMP},
+		Prefetch:  10 * time.Minute,
+	}
+	start := time.Unix(1000*3600, 0)
+	epoch := func(i int) drkey.Epoch {
+		begin := start.Add(time.Duration(i) * time.Hour)
+		return drkey.Epoch{NotBefore: begin, NotAfter: begin.Add(time.Hour)}
+	}
+	assertStatus := func(t *testing.T, current drkey.Epoch, next *drkey.Epoch) {
+		t.Helper()
+		status := rotator.Status()
+		require.Len(t, status, 1)
+		assert.Equal(t, drkey.SCMP, status[0].Protocol)
+		assert.Equal(t, current, status[0].Current)
+		assert.Equal(t, next, status[0].Next)
+	}
+	next := func(i int) *drkey.Epoch {
+		e := epoch(i)
+		return &e
+	}
+
+	// The next epoch is derived within the prefetch window only.
+	require.NoError(t, rotator.RotateAt(ctx, start.Add(30*time.Minute), false))
+	assertStatus(t, epoch(0), nil)
+	require.NoError(t, rotator.RotateAt(ctx, start.Add(55*time.Minute), false))
+	assertStatus(t, epoch(0), next(1))
+
+	// Rotating derives the next epoch immediately, and it stays derived.
+	require.NoError(t, rotator.RotateAt(ctx, start.Add(65*time.Minute), true))
+	assertStatus(t, epoch(1), next(2))
+	require.NoError(t, rotator.RotateAt(ctx, start.Add(70*time.Minute), false))
+	assertStatus(t, epoch(1), next(2))
+
+	// The derived secret value is the one served for the next epoch.
+	sv, err := engine.GetSecretValue(ctx, drkey.SecretValueMeta{
+		ProtoId:  drkey.SCMP,
+		Validity: start.Add(2*time.Hour + time.Minute),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, epoch(2), sv.Epoch)
+}
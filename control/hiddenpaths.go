@@ -39,29 +39,46 @@ type HiddenPathConfigurator struct {
 	FetcherConfig     segreq.FetcherConfig
 	IntraASTCPServer  *grpc.Server
 	InterASQUICServer *grpc.Server
+	// ACLPath is the file the ACLs of the groups for which this AS is a registry are persisted
+	// in. If empty, the ACLs set at runtime are lost on restart.
+	ACLPath string
 }
 
 // Setup sets up the hidden paths servers using the configuration at the given
 // location. An empty location will not enable any hidden path behavior. It
-// returns the configuration for the hidden segment writer. The return value can
-// be nil if this AS isn't a writer.
-func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationCfg, error) {
+// returns the configuration for the hidden segment writer, and the hidden path
+// configuration. The hidden path configuration must be reloaded periodically to
+// apply changes of the groups, and it contains the ACL of the groups for which
+// this AS is a registry. The servers are set up independently of the roles of
+// this AS, such that it can become a registry at runtime. The writer
+// configuration is nil if this AS isn't a writer.
+func (c HiddenPathConfigurator) Setup(
+	location string,
+) (*HiddenPathRegistrationCfg, *hiddenpath.Configuration, error) {
+
 	if location == "" {
-		return nil, nil
+		return nil, nil, nil
 	}
-	groups, regPolicy, err := hiddenpath.LoadConfiguration(location)
+	hpConfig, err := hiddenpath.NewConfiguration(location, c.LocalIA)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if hpConfig.ACL, err = hiddenpath.NewACL(c.LocalIA, hpConfig.Groups(), c.ACLPath); err != nil {
+		return nil, nil, err
 	}
-	roles := groups.Roles(c.LocalIA)
-	if roles.None() {
-		return nil, nil
+	localAuth := hiddenpath.AuthoritativeServer{
+		Groups: hpConfig,
+		DB: &hiddenpath.Storer{
+			DB: c.PathDB,
+		},
+		LocalIA: c.LocalIA,
+		ACL:     hpConfig.ACL,
 	}
 	log.Info("Starting hidden path forward server")
 	hspb.RegisterHiddenSegmentLookupServiceServer(c.IntraASTCPServer, &hpgrpc.SegmentServer{
 		Lookup: hiddenpath.ForwardServer{
-			Groups:    groups,
-			LocalAuth: c.localAuthServer(groups),
+			Groups:    hpConfig,
+			LocalAuth: localAuth,
 			LocalIA:   c.LocalIA,
 			RPC: &hpgrpc.AuthoritativeRequester{
 				Dialer: c.Dialer,
@@ -78,36 +95,35 @@ func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationC
 			},
 		},
 	})
-	if roles.Registry {
-		log.Info("Starting hidden path authoritative and registration server")
-		hspb.RegisterAuthoritativeHiddenSegmentLookupServiceServer(c.InterASQUICServer,
-			&hpgrpc.AuthoritativeSegmentServer{
-				Lookup:   c.localAuthServer(groups),
-				Verifier: c.Verifier,
-			},
-		)
-		hspb.RegisterHiddenSegmentRegistrationServiceServer(c.InterASQUICServer,
-			&hpgrpc.RegistrationServer{
-				Registry: hiddenpath.RegistryServer{
-					Groups: groups,
-					DB: &hiddenpath.Storer{
-						DB: c.PathDB,
-					},
-					Verifier: hiddenpath.VerifierAdapter{
-						Verifier: c.Verifier,
-					},
-					LocalIA: c.LocalIA,
+	log.Info("Starting hidden path authoritative and registration server")
+	hspb.RegisterAuthoritativeHiddenSegmentLookupServiceServer(c.InterASQUICServer,
+		&hpgrpc.AuthoritativeSegmentServer{
+			Lookup:   localAuth,
+			Verifier: c.Verifier,
+		},
+	)
+	hspb.RegisterHiddenSegmentRegistrationServiceServer(c.InterASQUICServer,
+		&hpgrpc.RegistrationServer{
+			Registry: hiddenpath.RegistryServer{
+				Groups: hpConfig,
+				DB: &hiddenpath.Storer{
+					DB: c.PathDB,
 				},
-				Verifier: c.Verifier,
+				Verifier: hiddenpath.VerifierAdapter{
+					Verifier: c.Verifier,
+				},
+				LocalIA: c.LocalIA,
+				ACL:     hpConfig.ACL,
 			},
-		)
-	}
-	if !roles.Writer {
-		return nil, nil
+			Verifier: c.Verifier,
+		},
+	)
+	if !hpConfig.Groups().Roles(c.LocalIA).Writer {
+		return nil, hpConfig, nil
 	}
 	log.Info("Using hidden path beacon writer")
 	return &HiddenPathRegistrationCfg{
-		Policy: regPolicy,
+		Policy: hpConfig,
 		Router: segreq.NewRouter(c.FetcherConfig),
 		Discoverer: &hpgrpc.Discoverer{
 			Dialer: c.Dialer,
@@ -117,19 +133,5 @@ func (c HiddenPathConfigurator) Setup(location string) (*HiddenPathRegistrationC
 			RegularRegistration: beaconinggrpc.Registrar{Dialer: c.Dialer},
 			Signer:              c.Signer,
 		},
-	}, nil
-}
-
-func (c HiddenPathConfigurator) localAuthServer(groups hiddenpath.Groups) hiddenpath.Lookuper {
-	roles := groups.Roles(c.LocalIA)
-	if !roles.Registry {
-		return nil
-	}
-	return hiddenpath.AuthoritativeServer{
-		Groups: groups,
-		DB: &hiddenpath.Storer{
-			DB: c.PathDB,
-		},
-		LocalIA: c.LocalIA,
-	}
+	}, hpConfig, nil
 }
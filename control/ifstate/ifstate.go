@@ -27,7 +27,12 @@ type InterfaceInfo struct {
 	// ID is the interface ID.
 	ID uint16
 	// IA is the remote ISD-AS.
-	IA       addr.IA
+	IA addr.IA
+	// LocalIA is the local ISD-AS the interface is attached to. It only
+	// differs from the ISD-AS of the topology in core ASes that are members of
+	// several ISDs. If it is zero, the interface is attached to the ISD-AS of
+	// the topology.
+	LocalIA  addr.IA
 	LinkType topology.LinkType
 	// InternalAddr is the AS-internal address of the router that owns this
 	// interface.
@@ -38,6 +43,15 @@ type InterfaceInfo struct {
 	MTU uint16
 }
 
+// LocalIAOr returns the local ISD-AS the interface is attached to, or the
+// given ISD-AS of the topology if LocalIA is not set.
+func (i InterfaceInfo) LocalIAOr(topoIA addr.IA) addr.IA {
+	if i.LocalIA.IsZero() {
+		return topoIA
+	}
+	return i.LocalIA
+}
+
 const (
 	// DefaultKeepaliveInterval is the default time between sending IFID
 	// keepalive packets to the neighbor.
@@ -81,16 +95,19 @@ func NewInterfaces(ifInfomap map[uint16]InterfaceInfo, cfg Config) *Interfaces {
 
 // Update updates the interface mapping. Interfaces no longer present in
 // the topology are removed. The state of existing interfaces is preserved.
-// New interfaces are added as inactive.
-func (intfs *Interfaces) Update(ifInfomap map[uint16]InterfaceInfo) {
+// New interfaces are added as inactive. Update returns whether interfaces
+// were added or removed, or their topology information changed.
+func (intfs *Interfaces) Update(ifInfomap map[uint16]InterfaceInfo) bool {
 	intfs.mu.Lock()
 	defer intfs.mu.Unlock()
+	changed := len(ifInfomap) != len(intfs.intfs)
 	m := make(map[uint16]*Interface, len(intfs.intfs))
 	for ifID, info := range ifInfomap {
 		if intf, ok := intfs.intfs[ifID]; ok {
-			intf.updateTopoInfo(info)
+			changed = intf.updateTopoInfo(info) || changed
 			m[ifID] = intf
 		} else {
+			changed = true
 			m[ifID] = &Interface{
 				topoInfo: info,
 				cfg:      intfs.cfg,
@@ -98,6 +115,7 @@ func (intfs *Interfaces) Update(ifInfomap map[uint16]InterfaceInfo) {
 		}
 	}
 	intfs.intfs = m
+	return changed
 }
 
 // Filtered returns the subset of interfaces which pass the given filter function.
@@ -202,10 +220,12 @@ func (intf *Interface) reset() {
 	intf.lastPropagate = time.Time{}
 }
 
-func (intf *Interface) updateTopoInfo(topoInfo InterfaceInfo) {
+func (intf *Interface) updateTopoInfo(topoInfo InterfaceInfo) bool {
 	intf.mu.Lock()
 	defer intf.mu.Unlock()
 	// Keep remote topo info.
 	topoInfo.RemoteID = intf.topoInfo.RemoteID
+	changed := topoInfo != intf.topoInfo
 	intf.topoInfo = topoInfo
+	return changed
 }
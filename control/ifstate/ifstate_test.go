@@ -29,7 +29,7 @@ func TestInterfacesUpdate(t *testing.T) {
 			1: {ID: 1, MTU: 1401},
 			2: {ID: 2, MTU: 1402},
 		}
-		intfs.Update(topoMap)
+		assert.True(t, intfs.Update(topoMap))
 		// The topo info should come from the updated map.
 		assert.Equal(t, uint16(1401), intfs.Get(1).TopoInfo().MTU)
 		assert.Equal(t, uint16(1402), intfs.Get(2).TopoInfo().MTU)
@@ -42,11 +42,19 @@ func TestInterfacesUpdate(t *testing.T) {
 		topoMap := map[uint16]ifstate.InterfaceInfo{
 			3: {ID: 3, MTU: 1403},
 		}
-		intfs.Update(topoMap)
+		assert.True(t, intfs.Update(topoMap))
 		assert.Nil(t, intfs.Get(1))
 		assert.Nil(t, intfs.Get(2))
 		assert.Equal(t, uint16(1403), intfs.Get(3).TopoInfo().MTU)
 	})
+	t.Run("The update reports no change for the same interfaces", func(t *testing.T) {
+		intfs := testInterfaces(t)
+		topoMap := map[uint16]ifstate.InterfaceInfo{
+			1: {ID: 1, MTU: 1301},
+			2: {ID: 2, MTU: 1302},
+		}
+		assert.False(t, intfs.Update(topoMap))
+	})
 }
 
 func TestInterfacesReset(t *testing.T) {
@@ -0,0 +1,111 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package leader elects the control service instance of an AS that runs the beaconing tasks,
+// when several instances share their databases.
+//
+// The instances compete for a lease in the shared database. The instance that holds the lease
+// originates, propagates and registers the beacons; the others only answer requests from the
+// shared databases. If the leader fails, its lease expires and another instance takes over.
+package leader
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+)
+
+// DB stores the leases.
+type DB interface {
+	// AcquireLease acquires or renews the named lease for the holder for the given duration.
+	// It returns false if another holder has the lease.
+	AcquireLease(ctx context.Context, name, holder string, duration time.Duration) (bool, error)
+	// ReleaseLease releases the named lease if the holder has it.
+	ReleaseLease(ctx context.Context, name, holder string) error
+}
+
+// Elector is a periodic task that acquires and renews the lease of an instance. It must run
+// more than once per lease duration, e.g. every third of it.
+type Elector struct {
+	// DB stores the leases.
+	DB DB
+	// Lease is the name of the lease.
+	Lease string
+	// Holder identifies this instance. It must be unique among the instances.
+	Holder string
+	// Duration is the duration of the lease.
+	Duration time.Duration
+	// Leader is set to 1 while the instance is the leader, and to 0 otherwise.
+	Leader metrics.Gauge
+
+	// until is the time, in Unix nanoseconds, until which the instance holds the lease.
+	until atomic.Int64
+}
+
+// Name returns the task name.
+func (e *Elector) Name() string {
+	return "control_leader_election"
+}
+
+// Run acquires or renews the lease. If this fails, the instance keeps the lease until it
+// expires.
+func (e *Elector) Run(ctx context.Context) {
+	leader := e.IsLeader()
+	defer func() {
+		if now := e.IsLeader(); now != leader {
+			log.FromCtx(ctx).Info("Leadership changed", "lease", e.Lease, "holder", e.Holder,
+				"leader", now)
+		}
+		metrics.GaugeSet(e.Leader, boolToFloat(e.IsLeader()))
+	}()
+	start := time.Now()
+	ok, err := e.DB.AcquireLease(ctx, e.Lease, e.Holder, e.Duration)
+	if err != nil {
+		log.FromCtx(ctx).Info("Failed to renew lease", "lease", e.Lease, "err", err)
+		return
+	}
+	if !ok {
+		e.until.Store(0)
+		return
+	}
+	// The lease was granted at some point after start, so it is held until at least
+	// start+duration.
+	e.until.Store(start.Add(e.Duration).UnixNano())
+}
+
+// IsLeader returns whether the instance currently holds the lease.
+func (e *Elector) IsLeader() bool {
+	return time.Now().UnixNano() < e.until.Load()
+}
+
+// Release gives up the lease, so that another instance can take over without waiting for it to
+// expire.
+func (e *Elector) Release(ctx context.Context) error {
+	if !e.IsLeader() {
+		return nil
+	}
+	e.until.Store(0)
+	metrics.GaugeSet(e.Leader, 0)
+	return e.DB.ReleaseLease(ctx, e.Lease, e.Holder)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
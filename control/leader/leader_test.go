@@ -0,0 +1,93 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leader_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/leader"
+)
+
+// memDB keeps the leases in memory.
+type memDB struct {
+	holder string
+	expiry time.Time
+	err    error
+}
+
+func (db *memDB) AcquireLease(_ context.Context, _, holder string, d time.Duration) (bool, error) {
+	if db.err != nil {
+		return false, db.err
+	}
+	now := time.Now()
+	if db.holder != holder && now.Before(db.expiry) {
+		return false, nil
+	}
+	db.holder, db.expiry = holder, now.Add(d)
+	return true, nil
+}
+
+func (db *memDB) ReleaseLease(_ context.Context, _, holder string) error {
+	if db.holder == holder {
+		db.holder, db.expiry = "", time.Time{}
+	}
+	return nil
+}
+
+func TestElector(t *testing.T) {
+	ctx := context.Background()
+	db := &memDB{}
+	newElector := func(holder string) *leader.Elector {
+		return &leader.Elector{
+			DB:       db,
+			Lease:    "beaconing",
+			Holder:   holder,
+			Duration: 100 * time.Millisecond,
+		}
+	}
+	a, b := newElector("a"), newElector("b")
+	assert.False(t, a.IsLeader())
+
+	a.Run(ctx)
+	b.Run(ctx)
+	assert.True(t, a.IsLeader())
+	assert.False(t, b.IsLeader())
+
+	// The leader keeps the lease until it expires when the database fails.
+	db.err = errors.New("unreachable")
+	a.Run(ctx)
+	assert.True(t, a.IsLeader())
+	time.Sleep(150 * time.Millisecond)
+	assert.False(t, a.IsLeader())
+
+	// Another instance takes over the expired lease.
+	db.err = nil
+	b.Run(ctx)
+	a.Run(ctx)
+	assert.True(t, b.IsLeader())
+	assert.False(t, a.IsLeader())
+
+	// Released leases are taken over immediately.
+	require.NoError(t, b.Release(ctx))
+	assert.False(t, b.IsLeader())
+	a.Run(ctx)
+	assert.True(t, a.IsLeader())
+}
@@ -20,16 +20,21 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
+	"strings"
 	"time"
 
 	"google.golang.org/protobuf/proto"
 
 	"github.com/scionproto/scion/control/beacon"
+	csdrkey "github.com/scionproto/scion/control/drkey"
+	"github.com/scionproto/scion/control/segreq"
 	cstrust "github.com/scionproto/scion/control/trust"
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/scrypto/cppki"
 	seg "github.com/scionproto/scion/pkg/segment"
@@ -40,12 +45,51 @@ import (
 	segapi "github.com/scionproto/scion/private/mgmtapi/segments/api"
 	"github.com/scionproto/scion/private/storage"
 	beaconstorage "github.com/scionproto/scion/private/storage/beacon"
+	truststorage "github.com/scionproto/scion/private/storage/trust"
 	"github.com/scionproto/scion/private/trust"
 )
 
 type BeaconStore interface {
 	GetBeacons(context.Context, *beaconstorage.QueryParams) ([]beaconstorage.Beacon, error)
 	DeleteBeacon(ctx context.Context, idPrefix string) error
+	MarkBeaconUnusable(ctx context.Context, idPrefix string) error
+}
+
+// Registrar triggers the registration of the path segments.
+type Registrar interface {
+	Reregister()
+}
+
+// Originator triggers the origination and propagation of the beacons.
+type Originator interface {
+	Originate()
+}
+
+// SecretValues manages the DRKey secret values.
+type SecretValues interface {
+	Status() []csdrkey.SecretValueStatus
+	Rotate(ctx context.Context) error
+}
+
+// HiddenPathACLs manages the ACLs of the hidden path groups for which the AS is a registry.
+type HiddenPathACLs interface {
+	ACLs() map[hiddenpath.GroupID]hiddenpath.GroupACL
+	Set(id hiddenpath.GroupID, acl hiddenpath.GroupACL) error
+	Reset(id hiddenpath.GroupID) error
+}
+
+// LookupCache caches the segment lookups.
+type LookupCache interface {
+	Entries() []segreq.CacheEntry
+	Flush(src, dst addr.IA) int
+}
+
+// GracePeriods manages the refusals of the predecessor TRCs during the grace period of their
+// successors.
+type GracePeriods interface {
+	Refused(id cppki.TRCID) bool
+	Refuse(id cppki.TRCID) error
+	Accept(id cppki.TRCID) error
 }
 
 type Healther interface {
@@ -91,6 +135,20 @@ type Server struct {
 	Topology       http.HandlerFunc
 	TrustDB        storage.TrustDB
 	Healther       Healther
+	Registrar      Registrar
+	Originator     Originator
+	// SecretValues is nil if DRKey is not enabled.
+	SecretValues SecretValues
+	// HiddenPathACLs is nil if the AS is not a registry of any hidden path group.
+	HiddenPathACLs HiddenPathACLs
+	// LookupCache is nil if the lookup cache is disabled.
+	LookupCache LookupCache
+	// GracePeriods is nil if the predecessor TRCs cannot be refused.
+	GracePeriods GracePeriods
+	// AdminAuth authorizes the requests that modify the beacons or trigger the
+	// beaconing tasks. If it is nil, beacons can be deleted
+	// without authorization, and the other resources are not available.
+	AdminAuth func(http.Handler) http.Handler
 
 	// nowProvider can be set during tests to control the current time.
 	nowProvider func() time.Time
@@ -158,6 +216,16 @@ func (s *Server) GetBeacons(w http.ResponseWriter, r *http.Request, params GetBe
 		}
 		q.IngressInterfaces = []uint16{uint16(*params.IngressInterface)}
 	}
+	if params.MaxAge != nil {
+		if *params.MaxAge < 0 {
+			errs = append(errs, serrors.New(
+				"value for parameter out of range",
+				"max_age",
+				*params.MaxAge,
+			))
+		}
+		q.CreatedAfter = s.now().Add(-time.Duration(*params.MaxAge) * time.Second)
+	}
 	switch {
 	case (params.All != nil) && *params.All:
 		q.ValidAt = time.Time{}
@@ -383,25 +451,404 @@ func (s *Server) GetBeacon(w http.ResponseWriter, r *http.Request, segmentId Seg
 	}
 }
 
+// DeleteBeacon deletes the beacons with the given segment ID prefix.
 func (s *Server) DeleteBeacon(w http.ResponseWriter, r *http.Request, segmentId SegmentID) {
-	if segmentId == "" {
+	s.adminOnly(w, r, func(w http.ResponseWriter, r *http.Request) {
+		if segmentId == "" {
+			ErrorResponse(w, Problem{
+				Status: http.StatusBadRequest,
+				Title:  "segment ID is required",
+				Type:   api.StringRef(api.BadRequest),
+			})
+			return
+		}
+		if err := s.Beacons.DeleteBeacon(r.Context(), segmentId); err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusInternalServerError,
+				Title:  "unable to delete beacon",
+				Type:   api.StringRef(api.InternalError),
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// DeleteBeaconUsages marks the beacons with the given segment ID prefix unusable.
+func (s *Server) DeleteBeaconUsages(w http.ResponseWriter, r *http.Request, segmentId SegmentID) {
+	s.adminOnly(w, r, func(w http.ResponseWriter, r *http.Request) {
+		if segmentId == "" {
+			ErrorResponse(w, Problem{
+				Status: http.StatusBadRequest,
+				Title:  "segment ID is required",
+				Type:   api.StringRef(api.BadRequest),
+			})
+			return
+		}
+		if err := s.Beacons.MarkBeaconUnusable(r.Context(), segmentId); err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusInternalServerError,
+				Title:  "unable to mark beacon unusable",
+				Type:   api.StringRef(api.InternalError),
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// PostOrigination triggers the origination and propagation of the beacons.
+func (s *Server) PostOrigination(w http.ResponseWriter, r *http.Request) {
+	s.adminOnly(w, r, func(w http.ResponseWriter, r *http.Request) {
+		s.Originator.Originate()
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// PostRegistration triggers the registration of the path segments.
+func (s *Server) PostRegistration(w http.ResponseWriter, r *http.Request) {
+	s.adminOnly(w, r, func(w http.ResponseWriter, r *http.Request) {
+		s.Registrar.Reregister()
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// GetDrkeySecretValues lists the epochs of the DRKey secret values.
+func (s *Server) GetDrkeySecretValues(w http.ResponseWriter, r *http.Request) {
+	if s.SecretValues == nil {
+		drkeyNotAvailable(w)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	rep := []SecretValueStatus{}
+	for _, sv := range s.SecretValues.Status() {
+		status := SecretValueStatus{
+			Protocol: strings.ToLower(strings.TrimPrefix(sv.Protocol.String(), "PROTOCOL_")),
+			Current: Validity{
+				NotAfter:  sv.Current.NotAfter,
+				NotBefore: sv.Current.NotBefore,
+			},
+		}
+		if sv.Next != nil {
+			status.Next = &Validity{
+				NotAfter:  sv.Next.NotAfter,
+				NotBefore: sv.Next.NotBefore,
+			}
+		}
+		rep = append(rep, status)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
 		ErrorResponse(w, Problem{
-			Status: http.StatusBadRequest,
-			Title:  "segment ID is required",
-			Type:   api.StringRef(api.BadRequest),
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// PostDrkeySecretValuesRotation derives the DRKey secret values of the next epoch.
+func (s *Server) PostDrkeySecretValuesRotation(w http.ResponseWriter, r *http.Request) {
+	s.adminOnly(w, r, func(w http.ResponseWriter, r *http.Request) {
+		if s.SecretValues == nil {
+			drkeyNotAvailable(w)
+			return
+		}
+		if err := s.SecretValues.Rotate(r.Context()); err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusInternalServerError,
+				Title:  "unable to rotate secret values",
+				Type:   api.StringRef(api.InternalError),
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func drkeyNotAvailable(w http.ResponseWriter) {
+	ErrorResponse(w, Problem{
+		Detail: api.StringRef("DRKey is not enabled"),
+		Status: http.StatusNotFound,
+		Title:  "DRKey not available",
+		Type:   api.StringRef(api.NotFound),
+	})
+}
+
+// GetHiddenPathAcls lists the ACLs of the hidden path groups.
+func (s *Server) GetHiddenPathAcls(w http.ResponseWriter, r *http.Request) {
+	if s.HiddenPathACLs == nil {
+		hiddenPathsNotAvailable(w)
+		return
+	}
+	acls := s.HiddenPathACLs.ACLs()
+	ids := make([]hiddenpath.GroupID, 0, len(acls))
+	for id := range acls {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].ToUint64() < ids[j].ToUint64()
+	})
+	rep := make([]HiddenPathACL, 0, len(ids))
+	for _, id := range ids {
+		rep = append(rep, hiddenPathACLToAPI(id, acls[id]))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
 		})
 		return
 	}
-	if err := s.Beacons.DeleteBeacon(r.Context(), segmentId); err != nil {
+}
+
+// SetHiddenPathAcl overrides the ACL of a hidden path group.
+func (s *Server) SetHiddenPathAcl(w http.ResponseWriter, r *http.Request, groupId string) {
+	s.adminOnly(w, r, func(w http.ResponseWriter, r *http.Request) {
+		if s.HiddenPathACLs == nil {
+			hiddenPathsNotAvailable(w)
+			return
+		}
+		id, err := hiddenpath.ParseGroupID(groupId)
+		if err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusBadRequest,
+				Title:  "invalid group ID",
+				Type:   api.StringRef(api.BadRequest),
+			})
+			return
+		}
+		var req HiddenPathACLConfig
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusBadRequest,
+				Title:  "error decoding body",
+				Type:   api.StringRef(api.BadRequest),
+			})
+			return
+		}
+		acl, err := hiddenPathACLFromAPI(req)
+		if err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusBadRequest,
+				Title:  "invalid ACL",
+				Type:   api.StringRef(api.BadRequest),
+			})
+			return
+		}
+		if err := s.HiddenPathACLs.Set(id, acl); err != nil {
+			hiddenPathACLError(w, err, "unable to set ACL")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		acl.Overridden = true
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "    ")
+		if err := enc.Encode(hiddenPathACLToAPI(id, acl)); err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusInternalServerError,
+				Title:  "unable to marshal response",
+				Type:   api.StringRef(api.InternalError),
+			})
+			return
+		}
+	})
+}
+
+// ResetHiddenPathAcl removes the override of the ACL of a hidden path group.
+func (s *Server) ResetHiddenPathAcl(w http.ResponseWriter, r *http.Request, groupId string) {
+	s.adminOnly(w, r, func(w http.ResponseWriter, r *http.Request) {
+		if s.HiddenPathACLs == nil {
+			hiddenPathsNotAvailable(w)
+			return
+		}
+		id, err := hiddenpath.ParseGroupID(groupId)
+		if err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusBadRequest,
+				Title:  "invalid group ID",
+				Type:   api.StringRef(api.BadRequest),
+			})
+			return
+		}
+		if err := s.HiddenPathACLs.Reset(id); err != nil {
+			hiddenPathACLError(w, err, "unable to reset ACL")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func hiddenPathACLToAPI(id hiddenpath.GroupID, acl hiddenpath.GroupACL) HiddenPathACL {
+	toAPI := func(set map[addr.IA]struct{}) []IsdAs {
+		ias := make([]IsdAs, 0, len(set))
+		for ia := range set {
+			ias = append(ias, ia.String())
+		}
+		sort.Strings(ias)
+		return ias
+	}
+	return HiddenPathACL{
+		GroupId:    id.String(),
+		Writers:    toAPI(acl.Writers),
+		Readers:    toAPI(acl.Readers),
+		Overridden: acl.Overridden,
+	}
+}
+
+func hiddenPathACLFromAPI(req HiddenPathACLConfig) (hiddenpath.GroupACL, error) {
+	fromAPI := func(raw *[]IsdAs) (map[addr.IA]struct{}, error) {
+		set := make(map[addr.IA]struct{})
+		if raw == nil {
+			return set, nil
+		}
+		for _, s := range *raw {
+			ia, err := addr.ParseIA(s)
+			if err != nil {
+				return nil, err
+			}
+			set[ia] = struct{}{}
+		}
+		return set, nil
+	}
+	writers, err := fromAPI(req.Writers)
+	if err != nil {
+		return hiddenpath.GroupACL{}, serrors.Wrap("parsing writers", err)
+	}
+	readers, err := fromAPI(req.Readers)
+	if err != nil {
+		return hiddenpath.GroupACL{}, serrors.Wrap("parsing readers", err)
+	}
+	return hiddenpath.GroupACL{Writers: writers, Readers: readers}, nil
+}
+
+func hiddenPathACLError(w http.ResponseWriter, err error, title string) {
+	status, typ := http.StatusInternalServerError, api.InternalError
+	if errors.Is(err, hiddenpath.ErrUnknownGroup) {
+		status, typ = http.StatusNotFound, api.NotFound
+	}
+	ErrorResponse(w, Problem{
+		Detail: api.StringRef(err.Error()),
+		Status: status,
+		Title:  title,
+		Type:   api.StringRef(typ),
+	})
+}
+
+func hiddenPathsNotAvailable(w http.ResponseWriter) {
+	ErrorResponse(w, Problem{
+		Detail: api.StringRef("this AS is not a registry of any hidden path group"),
+		Status: http.StatusNotFound,
+		Title:  "hidden paths not available",
+		Type:   api.StringRef(api.NotFound),
+	})
+}
+
+// GetLookupCache lists the cached segment lookups.
+func (s *Server) GetLookupCache(w http.ResponseWriter, r *http.Request) {
+	if s.LookupCache == nil {
+		lookupCacheNotAvailable(w)
+		return
+	}
+	entries := s.LookupCache.Entries()
+	rep := make([]LookupCacheEntry, 0, len(entries))
+	for _, e := range entries {
+		rep = append(rep, LookupCacheEntry{
+			Src:        e.Src.String(),
+			Dst:        e.Dst.String(),
+			Segments:   e.Segments,
+			Expiration: e.Expiration,
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
 		ErrorResponse(w, Problem{
 			Detail: api.StringRef(err.Error()),
 			Status: http.StatusInternalServerError,
-			Title:  "unable to delete beacon",
+			Title:  "unable to marshal response",
 			Type:   api.StringRef(api.InternalError),
 		})
 		return
 	}
-	w.WriteHeader(http.StatusNoContent)
+}
+
+// FlushLookupCache removes the cached segment lookups.
+func (s *Server) FlushLookupCache(w http.ResponseWriter, r *http.Request,
+	params FlushLookupCacheParams) {
+
+	s.adminOnly(w, r, func(w http.ResponseWriter, r *http.Request) {
+		if s.LookupCache == nil {
+			lookupCacheNotAvailable(w)
+			return
+		}
+		var src, dst addr.IA
+		for _, p := range []struct {
+			param *IsdAs
+			ia    *addr.IA
+			name  string
+		}{
+			{param: params.Src, ia: &src, name: "src"},
+			{param: params.Dst, ia: &dst, name: "dst"},
+		} {
+			if p.param == nil {
+				continue
+			}
+			ia, err := addr.ParseIA(*p.param)
+			if err != nil {
+				ErrorResponse(w, Problem{
+					Detail: api.StringRef(err.Error()),
+					Status: http.StatusBadRequest,
+					Title:  "invalid " + p.name,
+					Type:   api.StringRef(api.BadRequest),
+				})
+				return
+			}
+			*p.ia = ia
+		}
+		s.LookupCache.Flush(src, dst)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func lookupCacheNotAvailable(w http.ResponseWriter) {
+	ErrorResponse(w, Problem{
+		Detail: api.StringRef("the lookup cache is disabled"),
+		Status: http.StatusNotFound,
+		Title:  "lookup cache not available",
+		Type:   api.StringRef(api.NotFound),
+	})
+}
+
+// adminOnly serves the request with the handler, if it is authorized.
+func (s *Server) adminOnly(w http.ResponseWriter, r *http.Request, handler http.HandlerFunc) {
+	if s.AdminAuth == nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef("no administration shared secret is configured"),
+			Status: http.StatusNotFound,
+			Title:  "administration not available",
+			Type:   api.StringRef(api.NotFound),
+		})
+		return
+	}
+	s.AdminAuth(handler).ServeHTTP(w, r)
 }
 
 func (s *Server) GetBeaconBlob(w http.ResponseWriter, r *http.Request, segmentId SegmentID) {
@@ -608,6 +1055,163 @@ func (s *Server) GetTrcBlob(w http.ResponseWriter, r *http.Request, isd int, bas
 	s.CPPKIServer.GetTrcBlob(w, r, isd, base, serial) // nolint - name from published API
 }
 
+// GetGracePeriods lists the ISDs whose latest TRC is in its grace period.
+func (s *Server) GetGracePeriods(w http.ResponseWriter, r *http.Request) {
+	if s.GracePeriods == nil {
+		gracePeriodsNotAvailable(w)
+		return
+	}
+	trcs, err := s.TrustDB.SignedTRCs(r.Context(), truststorage.TRCsQuery{Latest: true})
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error getting trcs",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	sort.Sort(trcs)
+	now := s.now()
+	rep := make([]GracePeriod, 0, len(trcs))
+	for _, trc := range trcs {
+		if !trc.TRC.InGracePeriod(now) {
+			continue
+		}
+		rep = append(rep, s.gracePeriodToAPI(&trc.TRC))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// RefuseGracePeriod refuses the predecessor of the latest TRC of the ISD.
+func (s *Server) RefuseGracePeriod(w http.ResponseWriter, r *http.Request, isd int) {
+	s.adminOnly(w, r, func(w http.ResponseWriter, r *http.Request) {
+		trc, ok := s.latestTRCInGracePeriod(w, r, isd)
+		if !ok {
+			return
+		}
+		if err := s.GracePeriods.Refuse(predecessorID(trc)); err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusInternalServerError,
+				Title:  "unable to refuse predecessor TRC",
+				Type:   api.StringRef(api.InternalError),
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "    ")
+		if err := enc.Encode(s.gracePeriodToAPI(trc)); err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusInternalServerError,
+				Title:  "unable to marshal response",
+				Type:   api.StringRef(api.InternalError),
+			})
+			return
+		}
+	})
+}
+
+// AcceptGracePeriod withdraws the refusal of the predecessor of the latest TRC of the ISD.
+func (s *Server) AcceptGracePeriod(w http.ResponseWriter, r *http.Request, isd int) {
+	s.adminOnly(w, r, func(w http.ResponseWriter, r *http.Request) {
+		trc, ok := s.latestTRCInGracePeriod(w, r, isd)
+		if !ok {
+			return
+		}
+		if err := s.GracePeriods.Accept(predecessorID(trc)); err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusInternalServerError,
+				Title:  "unable to accept predecessor TRC",
+				Type:   api.StringRef(api.InternalError),
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// latestTRCInGracePeriod returns the latest TRC of the ISD, if it is in its grace period.
+// Otherwise, it writes the error response.
+func (s *Server) latestTRCInGracePeriod(w http.ResponseWriter, r *http.Request,
+	isd int) (*cppki.TRC, bool) {
+
+	if s.GracePeriods == nil {
+		gracePeriodsNotAvailable(w)
+		return nil, false
+	}
+	trc, err := s.TrustDB.SignedTRC(r.Context(), cppki.TRCID{ISD: addr.ISD(isd)})
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "error getting trc",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return nil, false
+	}
+	if trc.IsZero() || !trc.TRC.InGracePeriod(s.now()) {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(fmt.Sprintf("the latest TRC of ISD %d is not in its grace period",
+				isd)),
+			Status: http.StatusNotFound,
+			Title:  "no grace period",
+			Type:   api.StringRef(api.NotFound),
+		})
+		return nil, false
+	}
+	return &trc.TRC, true
+}
+
+func (s *Server) gracePeriodToAPI(trc *cppki.TRC) GracePeriod {
+	toAPI := func(id cppki.TRCID) TRCID {
+		return TRCID{
+			Isd:          int(id.ISD),
+			BaseNumber:   int(id.Base),
+			SerialNumber: int(id.Serial),
+		}
+	}
+	pred := predecessorID(trc)
+	return GracePeriod{
+		Isd:            int(trc.ID.ISD),
+		Trc:            toAPI(trc.ID),
+		Predecessor:    toAPI(pred),
+		GracePeriodEnd: trc.GracePeriodEnd(),
+		Refused:        s.GracePeriods.Refused(pred),
+	}
+}
+
+func predecessorID(trc *cppki.TRC) cppki.TRCID {
+	return cppki.TRCID{
+		ISD:    trc.ID.ISD,
+		Base:   trc.ID.Base,
+		Serial: trc.ID.Serial - 1,
+	}
+}
+
+func gracePeriodsNotAvailable(w http.ResponseWriter) {
+	ErrorResponse(w, Problem{
+		Detail: api.StringRef("refusing predecessor TRCs is not supported"),
+		Status: http.StatusNotFound,
+		Title:  "grace periods not available",
+		Type:   api.StringRef(api.NotFound),
+	})
+}
+
 // GetConfig is an indirection to the http handler.
 func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 	s.Config(w, r)
@@ -15,6 +15,7 @@
 package mgmtapi_test
 
 import (
+	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/hex"
@@ -32,11 +33,15 @@ import (
 	"github.com/stretchr/testify/require"
 
 	beaconlib "github.com/scionproto/scion/control/beacon"
+	csdrkey "github.com/scionproto/scion/control/drkey"
 	api "github.com/scionproto/scion/control/mgmtapi"
 	"github.com/scionproto/scion/control/mgmtapi/mock_mgmtapi"
+	"github.com/scionproto/scion/control/segreq"
 	cstrust "github.com/scionproto/scion/control/trust"
 	"github.com/scionproto/scion/control/trust/mock_trust"
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
 	"github.com/scionproto/scion/pkg/scrypto/cppki"
@@ -45,6 +50,8 @@ import (
 	"github.com/scionproto/scion/private/ca/renewal"
 	"github.com/scionproto/scion/private/ca/renewal/mock_renewal"
 	"github.com/scionproto/scion/private/storage/beacon"
+	"github.com/scionproto/scion/private/storage/mock_storage"
+	truststorage "github.com/scionproto/scion/private/storage/trust"
 	"github.com/scionproto/scion/private/trust"
 )
 
@@ -912,6 +919,323 @@ func TestAPI(t *testing.T) {
 	}
 }
 
+func TestBeaconsMaxAge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2021, 3, 1, 8, 0, 0, 0, time.UTC)
+	bs := mock_mgmtapi.NewMockBeaconStore(ctrl)
+	s := &api.Server{Beacons: bs}
+	s.SetNowProvider(func() time.Time { return now })
+	bs.EXPECT().GetBeacons(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, q *beacon.QueryParams) ([]beacon.Beacon, error) {
+			assert.Equal(t, now.Add(-time.Hour), q.CreatedAfter)
+			return nil, nil
+		},
+	)
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/beacons?max_age=3600", nil)
+	api.Handler(s).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	rr = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/beacons?max_age=-1", nil)
+	api.Handler(s).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestBeaconsAdmin(t *testing.T) {
+	// auth authorizes the requests with the header "Authorization: ok".
+	auth := func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "ok" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+	testCases := map[string]struct {
+		Method     string
+		RequestURL string
+		Authorized bool
+		NoAuth     bool
+		Prepare    func(bs *mock_mgmtapi.MockBeaconStore, r *fakeTrigger)
+		Check      func(t *testing.T, r *fakeTrigger)
+		Status     int
+	}{
+		"mark unusable": {
+			Method:     http.MethodDelete,
+			RequestURL: "/beacons/abcd/usages",
+			Authorized: true,
+			Prepare: func(bs *mock_mgmtapi.MockBeaconStore, _ *fakeTrigger) {
+				bs.EXPECT().MarkBeaconUnusable(gomock.Any(), "abcd").Return(nil)
+			},
+			Status: http.StatusNoContent,
+		},
+		"mark unusable error": {
+			Method:     http.MethodDelete,
+			RequestURL: "/beacons/abcd/usages",
+			Authorized: true,
+			Prepare: func(bs *mock_mgmtapi.MockBeaconStore, _ *fakeTrigger) {
+				bs.EXPECT().MarkBeaconUnusable(gomock.Any(), "abcd").Return(
+					serrors.New("internal"))
+			},
+			Status: http.StatusInternalServerError,
+		},
+		"mark unusable unauthorized": {
+			Method:     http.MethodDelete,
+			RequestURL: "/beacons/abcd/usages",
+			Status:     http.StatusUnauthorized,
+		},
+		"mark unusable without secret": {
+			Method:     http.MethodDelete,
+			RequestURL: "/beacons/abcd/usages",
+			NoAuth:     true,
+			Status:     http.StatusNotFound,
+		},
+		"delete": {
+			Method:     http.MethodDelete,
+			RequestURL: "/beacons/abcd",
+			Authorized: true,
+			Prepare: func(bs *mock_mgmtapi.MockBeaconStore, _ *fakeTrigger) {
+				bs.EXPECT().DeleteBeacon(gomock.Any(), "abcd").Return(nil)
+			},
+			Status: http.StatusNoContent,
+		},
+		"delete unauthorized": {
+			Method:     http.MethodDelete,
+			RequestURL: "/beacons/abcd",
+			Status:     http.StatusUnauthorized,
+		},
+		"delete without secret": {
+			Method:     http.MethodDelete,
+			RequestURL: "/beacons/abcd",
+			NoAuth:     true,
+			Status:     http.StatusNotFound,
+		},
+		"registration": {
+			Method:     http.MethodPost,
+			RequestURL: "/registration",
+			Authorized: true,
+			Check: func(t *testing.T, r *fakeTrigger) {
+				assert.Equal(t, 1, r.registrations)
+			},
+			Status: http.StatusNoContent,
+		},
+		"registration unauthorized": {
+			Method:     http.MethodPost,
+			RequestURL: "/registration",
+			Check: func(t *testing.T, r *fakeTrigger) {
+				assert.Equal(t, 0, r.registrations)
+			},
+			Status: http.StatusUnauthorized,
+		},
+		"origination": {
+			Method:     http.MethodPost,
+			RequestURL: "/origination",
+			Authorized: true,
+			Check: func(t *testing.T, r *fakeTrigger) {
+				assert.Equal(t, 1, r.originations)
+				assert.Equal(t, 0, r.registrations)
+			},
+			Status: http.StatusNoContent,
+		},
+		"origination unauthorized": {
+			Method:     http.MethodPost,
+			RequestURL: "/origination",
+			Check: func(t *testing.T, r *fakeTrigger) {
+				assert.Equal(t, 0, r.originations)
+			},
+			Status: http.StatusUnauthorized,
+		},
+		"origination without secret": {
+			Method:     http.MethodPost,
+			RequestURL: "/origination",
+			NoAuth:     true,
+			Status:     http.StatusNotFound,
+		},
+		"registration without secret": {
+			Method:     http.MethodPost,
+			RequestURL: "/registration",
+			NoAuth:     true,
+			Status:     http.StatusNotFound,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bs := mock_mgmtapi.NewMockBeaconStore(ctrl)
+			trigger := &fakeTrigger{}
+			if tc.Prepare != nil {
+				tc.Prepare(bs, trigger)
+			}
+			s := &api.Server{
+				Beacons:    bs,
+				Registrar:  trigger,
+				Originator: trigger,
+				AdminAuth:  auth,
+			}
+			if tc.NoAuth {
+				s.AdminAuth = nil
+			}
+			req := httptest.NewRequest(tc.Method, tc.RequestURL, nil)
+			if tc.Authorized {
+				req.Header.Set("Authorization", "ok")
+			}
+			rr := httptest.NewRecorder()
+			api.Handler(s).ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+			if tc.Check != nil {
+				tc.Check(t, trigger)
+			}
+		})
+	}
+}
+
+type fakeTrigger struct {
+	registrations int
+	originations  int
+}
+
+func (r *fakeTrigger) Reregister() {
+	r.registrations++
+}
+
+func (r *fakeTrigger) Originate() {
+	r.originations++
+}
+
+func TestDRKeySecretValues(t *testing.T) {
+	auth := func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "ok" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+	begin := time.Date(2021, 1, 1, 8, 0, 0, 0, time.UTC)
+	current := drkey.Epoch{NotBefore: begin, NotAfter: begin.Add(24 * time.Hour)}
+	next := drkey.Epoch{NotBefore: current.NotAfter, NotAfter: current.NotAfter.Add(24 * time.Hour)}
+	testCases := map[string]struct {
+		Method     string
+		Authorized bool
+		NoAuth     bool
+		NoDRKey    bool
+		RotateErr  error
+		Status     int
+		Body       string
+		Rotations  int
+	}{
+		"status": {
+			Method: http.MethodGet,
+			Status: http.StatusOK,
+			Body: `[
+    {
+        "current": {
+            "not_after": "2021-01-02T08:00:00Z",
+            "not_before": "2021-01-01T08:00:00Z"
+        },
+        "next": {
+            "not_after": "2021-01-03T08:00:00Z",
+            "not_before": "2021-01-02T08:00:00Z"
+        },
+        "protocol": "scmp"
+    }
+]
+`,
+		},
+		"status without drkey": {
+			Method:  http.MethodGet,
+			NoDRKey: true,
+			Status:  http.StatusNotFound,
+		},
+		"rotation": {
+			Method:     http.MethodPost,
+			Authorized: true,
+			Status:     http.StatusNoContent,
+			Rotations:  1,
+		},
+		"rotation error": {
+			Method:     http.MethodPost,
+			Authorized: true,
+			RotateErr:  serrors.New("internal"),
+			Status:     http.StatusInternalServerError,
+			Rotations:  1,
+		},
+		"rotation unauthorized": {
+			Method: http.MethodPost,
+			Status: http.StatusUnauthorized,
+		},
+		"rotation without secret": {
+			Method:     http.MethodPost,
+			Authorized: true,
+			NoAuth:     true,
+			Status:     http.StatusNotFound,
+		},
+		"rotation without drkey": {
+			Method:     http.MethodPost,
+			Authorized: true,
+			NoDRKey:    true,
+			Status:     http.StatusNotFound,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			svs := &fakeSecretValues{
+				status: []csdrkey.SecretValueStatus{
+					{Protocol: drkey.SCMP, Current: current, Next: &next},
+				},
+				err: tc.RotateErr,
+			}
+			s := &api.Server{
+				SecretValues: svs,
+				AdminAuth:    auth,
+			}
+			if tc.NoAuth {
+				s.AdminAuth = nil
+			}
+			if tc.NoDRKey {
+				s.SecretValues = nil
+			}
+			url := "/drkey/secret_values"
+			if tc.Method == http.MethodPost {
+				url += "/rotation"
+			}
+			req := httptest.NewRequest(tc.Method, url, nil)
+			if tc.Authorized {
+				req.Header.Set("Authorization", "ok")
+			}
+			rr := httptest.NewRecorder()
+			api.Handler(s).ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+			if tc.Body != "" {
+				assert.Equal(t, tc.Body, rr.Body.String())
+			}
+			assert.Equal(t, tc.Rotations, svs.rotations)
+		})
+	}
+}
+
+type fakeSecretValues struct {
+	status    []csdrkey.SecretValueStatus
+	err       error
+	rotations int
+}
+
+func (f *fakeSecretValues) Status() []csdrkey.SecretValueStatus {
+	return f.status
+}
+
+func (f *fakeSecretValues) Rotate(context.Context) error {
+	f.rotations++
+	return f.err
+}
+
 func createBeacons(t *testing.T) []beacon.Beacon {
 	return []beacon.Beacon{
 		{
@@ -1020,3 +1344,426 @@ func (m queryMatcher) Matches(x any) bool {
 func (m queryMatcher) String() string {
 	return fmt.Sprintf("%v with ValidAt around %s", m.query, m.creationTime)
 }
+
+func TestHiddenPathACLs(t *testing.T) {
+	auth := func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "ok" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+	local := addr.MustParseIA("1-ff00:0:110")
+	id := hiddenpath.GroupID{OwnerAS: local.AS(), Suffix: 0x69b5}
+	groups := hiddenpath.Groups{
+		id: {
+			ID:         id,
+			Owner:      local,
+			Writers:    map[addr.IA]struct{}{addr.MustParseIA("1-ff00:0:111"): {}},
+			Readers:    map[addr.IA]struct{}{addr.MustParseIA("1-ff00:0:112"): {}},
+			Registries: map[addr.IA]struct{}{local: {}},
+		},
+	}
+	testCases := map[string]struct {
+		Method       string
+		URL          string
+		Body         string
+		Authorized   bool
+		NoAuth       bool
+		NoHiddenPath bool
+		Status       int
+		Response     string
+	}{
+		"list": {
+			Method: http.MethodGet,
+			URL:    "/hidden_paths/acls",
+			Status: http.StatusOK,
+			Response: `[
+    {
+        "group_id": "ff00:0:110-69b5",
+        "overridden": false,
+        "readers": [
+            "1-ff00:0:112"
+        ],
+        "writers": [
+            "1-ff00:0:111"
+        ]
+    }
+]
+`,
+		},
+		"list without hidden paths": {
+			Method:       http.MethodGet,
+			URL:          "/hidden_paths/acls",
+			NoHiddenPath: true,
+			Status:       http.StatusNotFound,
+		},
+		"set": {
+			Method:     http.MethodPut,
+			URL:        "/hidden_paths/acls/ff00:0:110-69b5",
+			Body:       `{"writers": ["1-ff00:0:113", "1-ff00:0:111"]}`,
+			Authorized: true,
+			Status:     http.StatusOK,
+			Response: `{
+    "group_id": "ff00:0:110-69b5",
+    "overridden": true,
+    "readers": [],
+    "writers": [
+        "1-ff00:0:111",
+        "1-ff00:0:113"
+    ]
+}
+`,
+		},
+		"set unknown group": {
+			Method:     http.MethodPut,
+			URL:        "/hidden_paths/acls/ff00:0:110-1",
+			Body:       `{"writers": ["1-ff00:0:111"]}`,
+			Authorized: true,
+			Status:     http.StatusNotFound,
+		},
+		"set invalid group": {
+			Method:     http.MethodPut,
+			URL:        "/hidden_paths/acls/garbage",
+			Body:       `{"writers": ["1-ff00:0:111"]}`,
+			Authorized: true,
+			Status:     http.StatusBadRequest,
+		},
+		"set invalid ISD-AS": {
+			Method:     http.MethodPut,
+			URL:        "/hidden_paths/acls/ff00:0:110-69b5",
+			Body:       `{"readers": ["garbage"]}`,
+			Authorized: true,
+			Status:     http.StatusBadRequest,
+		},
+		"set unauthorized": {
+			Method: http.MethodPut,
+			URL:    "/hidden_paths/acls/ff00:0:110-69b5",
+			Body:   `{"writers": ["1-ff00:0:111"]}`,
+			Status: http.StatusUnauthorized,
+		},
+		"set without secret": {
+			Method:     http.MethodPut,
+			URL:        "/hidden_paths/acls/ff00:0:110-69b5",
+			Body:       `{"writers": ["1-ff00:0:111"]}`,
+			Authorized: true,
+			NoAuth:     true,
+			Status:     http.StatusNotFound,
+		},
+		"reset": {
+			Method:     http.MethodDelete,
+			URL:        "/hidden_paths/acls/ff00:0:110-69b5",
+			Authorized: true,
+			Status:     http.StatusNoContent,
+		},
+		"reset unknown group": {
+			Method:     http.MethodDelete,
+			URL:        "/hidden_paths/acls/ff00:0:110-1",
+			Authorized: true,
+			Status:     http.StatusNotFound,
+		},
+		"reset without hidden paths": {
+			Method:       http.MethodDelete,
+			URL:          "/hidden_paths/acls/ff00:0:110-69b5",
+			Authorized:   true,
+			NoHiddenPath: true,
+			Status:       http.StatusNotFound,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			acl, err := hiddenpath.NewACL(local, groups, "")
+			require.NoError(t, err)
+			s := &api.Server{
+				HiddenPathACLs: acl,
+				AdminAuth:      auth,
+			}
+			if tc.NoAuth {
+				s.AdminAuth = nil
+			}
+			if tc.NoHiddenPath {
+				s.HiddenPathACLs = nil
+			}
+			req := httptest.NewRequest(tc.Method, tc.URL, strings.NewReader(tc.Body))
+			if tc.Authorized {
+				req.Header.Set("Authorization", "ok")
+			}
+			rr := httptest.NewRecorder()
+			api.Handler(s).ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+			if tc.Response != "" {
+				assert.Equal(t, tc.Response, rr.Body.String())
+			}
+		})
+	}
+}
+
+// fakeLookupCache records the flushed lookups.
+type fakeLookupCache struct {
+	entries  []segreq.CacheEntry
+	src, dst addr.IA
+	flushed  bool
+}
+
+func (c *fakeLookupCache) Entries() []segreq.CacheEntry {
+	return c.entries
+}
+
+func (c *fakeLookupCache) Flush(src, dst addr.IA) int {
+	c.src, c.dst, c.flushed = src, dst, true
+	return len(c.entries)
+}
+
+func TestLookupCache(t *testing.T) {
+	auth := func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "ok" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+	src := addr.MustParseIA("1-ff00:0:110")
+	dst := addr.MustParseIA("1-ff00:0:111")
+	testCases := map[string]struct {
+		Method     string
+		URL        string
+		Authorized bool
+		Disabled   bool
+		Status     int
+		Response   string
+		Flushed    bool
+		Src, Dst   addr.IA
+	}{
+		"list": {
+			Method: http.MethodGet,
+			URL:    "/lookup_cache",
+			Status: http.StatusOK,
+			Response: `[
+    {
+        "dst": "1-ff00:0:111",
+        "expiration": "2021-11-25T12:20:50Z",
+        "segments": 3,
+        "src": "1-ff00:0:110"
+    }
+]
+`,
+		},
+		"list disabled": {
+			Method:   http.MethodGet,
+			URL:      "/lookup_cache",
+			Disabled: true,
+			Status:   http.StatusNotFound,
+		},
+		"flush all": {
+			Method:     http.MethodDelete,
+			URL:        "/lookup_cache",
+			Authorized: true,
+			Status:     http.StatusNoContent,
+			Flushed:    true,
+		},
+		"flush filtered": {
+			Method:     http.MethodDelete,
+			URL:        "/lookup_cache?src=1-ff00:0:110&dst=1-ff00:0:111",
+			Authorized: true,
+			Status:     http.StatusNoContent,
+			Flushed:    true,
+			Src:        src,
+			Dst:        dst,
+		},
+		"flush invalid ISD-AS": {
+			Method:     http.MethodDelete,
+			URL:        "/lookup_cache?dst=garbage",
+			Authorized: true,
+			Status:     http.StatusBadRequest,
+		},
+		"flush unauthorized": {
+			Method: http.MethodDelete,
+			URL:    "/lookup_cache",
+			Status: http.StatusUnauthorized,
+		},
+		"flush disabled": {
+			Method:     http.MethodDelete,
+			URL:        "/lookup_cache",
+			Authorized: true,
+			Disabled:   true,
+			Status:     http.StatusNotFound,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cache := &fakeLookupCache{
+				entries: []segreq.CacheEntry{{
+					Src:        src,
+					Dst:        dst,
+					Segments:   3,
+					Expiration: time.Date(2021, 11, 25, 12, 20, 50, 0, time.UTC),
+				}},
+			}
+			s := &api.Server{
+				LookupCache: cache,
+				AdminAuth:   auth,
+			}
+			if tc.Disabled {
+				s.LookupCache = nil
+			}
+			req := httptest.NewRequest(tc.Method, tc.URL, nil)
+			if tc.Authorized {
+				req.Header.Set("Authorization", "ok")
+			}
+			rr := httptest.NewRecorder()
+			api.Handler(s).ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+			if tc.Response != "" {
+				assert.Equal(t, tc.Response, rr.Body.String())
+			}
+			assert.Equal(t, tc.Flushed, cache.flushed)
+			assert.Equal(t, tc.Src, cache.src)
+			assert.Equal(t, tc.Dst, cache.dst)
+		})
+	}
+}
+
+func TestGracePeriods(t *testing.T) {
+	auth := func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "ok" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+	notBefore := time.Now().UTC().Truncate(time.Second).Add(-time.Minute)
+	// The latest TRC of ISD 1 is in its grace period, the one of ISD 2 is a base TRC.
+	inGrace := cppki.SignedTRC{TRC: cppki.TRC{
+		ID:          cppki.TRCID{ISD: 1, Base: 1, Serial: 2},
+		Validity:    cppki.Validity{NotBefore: notBefore, NotAfter: notBefore.Add(time.Hour)},
+		GracePeriod: time.Hour,
+	}}
+	base := cppki.SignedTRC{TRC: cppki.TRC{
+		ID:       cppki.TRCID{ISD: 2, Base: 1, Serial: 1},
+		Validity: cppki.Validity{NotBefore: notBefore, NotAfter: notBefore.Add(time.Hour)},
+	}}
+	predecessor := cppki.TRCID{ISD: 1, Base: 1, Serial: 1}
+	response := fmt.Sprintf(`{
+    "grace_period_end": "%s",
+    "isd": 1,
+    "predecessor": {
+        "base_number": 1,
+        "isd": 1,
+        "serial_number": 1
+    },
+    "refused": true,
+    "trc": {
+        "base_number": 1,
+        "isd": 1,
+        "serial_number": 2
+    }
+}
+`, notBefore.Add(time.Hour).Format(time.RFC3339))
+	list := "[\n    " + strings.ReplaceAll(strings.TrimSpace(response), "\n", "\n    ") + "\n]\n"
+
+	testCases := map[string]struct {
+		Method     string
+		URL        string
+		Authorized bool
+		Disabled   bool
+		Refused    bool
+		Status     int
+		Response   string
+		// WantRefused indicates whether the predecessor is refused after the request.
+		WantRefused bool
+	}{
+		"list": {
+			Method:      http.MethodGet,
+			URL:         "/grace_periods",
+			Refused:     true,
+			Status:      http.StatusOK,
+			Response:    list,
+			WantRefused: true,
+		},
+		"list disabled": {
+			Method:   http.MethodGet,
+			URL:      "/grace_periods",
+			Disabled: true,
+			Status:   http.StatusNotFound,
+		},
+		"refuse": {
+			Method:      http.MethodPut,
+			URL:         "/grace_periods/1",
+			Authorized:  true,
+			Status:      http.StatusOK,
+			Response:    response,
+			WantRefused: true,
+		},
+		"refuse not in grace period": {
+			Method:     http.MethodPut,
+			URL:        "/grace_periods/2",
+			Authorized: true,
+			Status:     http.StatusNotFound,
+		},
+		"refuse unauthorized": {
+			Method: http.MethodPut,
+			URL:    "/grace_periods/1",
+			Status: http.StatusUnauthorized,
+		},
+		"accept": {
+			Method:     http.MethodDelete,
+			URL:        "/grace_periods/1",
+			Authorized: true,
+			Refused:    true,
+			Status:     http.StatusNoContent,
+		},
+		"accept unknown ISD": {
+			Method:      http.MethodDelete,
+			URL:         "/grace_periods/3",
+			Authorized:  true,
+			Refused:     true,
+			Status:      http.StatusNotFound,
+			WantRefused: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			db := mock_storage.NewMockTrustDB(ctrl)
+			db.EXPECT().SignedTRCs(gomock.Any(), truststorage.TRCsQuery{Latest: true}).Return(
+				cppki.SignedTRCs{base, inGrace}, nil).AnyTimes()
+			db.EXPECT().SignedTRC(gomock.Any(), cppki.TRCID{ISD: 1}).Return(
+				inGrace, nil).AnyTimes()
+			db.EXPECT().SignedTRC(gomock.Any(), cppki.TRCID{ISD: 2}).Return(
+				base, nil).AnyTimes()
+			db.EXPECT().SignedTRC(gomock.Any(), cppki.TRCID{ISD: 3}).Return(
+				cppki.SignedTRC{}, nil).AnyTimes()
+
+			grace, err := trust.NewGracePeriod("")
+			require.NoError(t, err)
+			if tc.Refused {
+				require.NoError(t, grace.Refuse(predecessor))
+			}
+			s := &api.Server{
+				TrustDB:      db,
+				GracePeriods: grace,
+				AdminAuth:    auth,
+			}
+			if tc.Disabled {
+				s.GracePeriods = nil
+			}
+			req := httptest.NewRequest(tc.Method, tc.URL, nil)
+			if tc.Authorized {
+				req.Header.Set("Authorization", "ok")
+			}
+			rr := httptest.NewRecorder()
+			api.Handler(s).ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+			if tc.Response != "" {
+				assert.Equal(t, tc.Response, rr.Body.String())
+			}
+			assert.Equal(t, tc.WantRefused, grace.Refused(predecessor))
+		})
+	}
+}
@@ -101,6 +101,9 @@ type ClientInterface interface {
 	// GetBeaconBlob request
 	GetBeaconBlob(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteBeaconUsages request
+	DeleteBeaconUsages(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetCa request
 	GetCa(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -116,9 +119,35 @@ type ClientInterface interface {
 	// GetConfig request
 	GetConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetDrkeySecretValues request
+	GetDrkeySecretValues(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostDrkeySecretValuesRotation request
+	PostDrkeySecretValuesRotation(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetGracePeriods request
+	GetGracePeriods(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AcceptGracePeriod request
+	AcceptGracePeriod(ctx context.Context, isd int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RefuseGracePeriod request
+	RefuseGracePeriod(ctx context.Context, isd int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetHealth request
 	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetHiddenPathAcls request
+	GetHiddenPathAcls(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResetHiddenPathAcl request
+	ResetHiddenPathAcl(ctx context.Context, groupId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetHiddenPathAclWithBody request with any body
+	SetHiddenPathAclWithBody(ctx context.Context, groupId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetHiddenPathAcl(ctx context.Context, groupId string, body SetHiddenPathAclJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetInfo request
 	GetInfo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -130,6 +159,18 @@ type ClientInterface interface {
 
 	SetLogLevel(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// FlushLookupCache request
+	FlushLookupCache(ctx context.Context, params *FlushLookupCacheParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLookupCache request
+	GetLookupCache(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostOrigination request
+	PostOrigination(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRegistration request
+	PostRegistration(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetSegments request
 	GetSegments(ctx context.Context, params *GetSegmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -209,6 +250,18 @@ func (c *Client) GetBeaconBlob(ctx context.Context, segmentId SegmentID, reqEdit
 	return c.Client.Do(req)
 }
 
+func (c *Client) DeleteBeaconUsages(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteBeaconUsagesRequest(c.Server, segmentId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetCa(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetCaRequest(c.Server)
 	if err != nil {
@@ -269,6 +322,66 @@ func (c *Client) GetConfig(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetDrkeySecretValues(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDrkeySecretValuesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostDrkeySecretValuesRotation(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostDrkeySecretValuesRotationRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetGracePeriods(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetGracePeriodsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcceptGracePeriod(ctx context.Context, isd int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcceptGracePeriodRequest(c.Server, isd)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RefuseGracePeriod(ctx context.Context, isd int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRefuseGracePeriodRequest(c.Server, isd)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetHealthRequest(c.Server)
 	if err != nil {
@@ -281,6 +394,54 @@ func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetHiddenPathAcls(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHiddenPathAclsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResetHiddenPathAcl(ctx context.Context, groupId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResetHiddenPathAclRequest(c.Server, groupId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetHiddenPathAclWithBody(ctx context.Context, groupId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetHiddenPathAclRequestWithBody(c.Server, groupId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetHiddenPathAcl(ctx context.Context, groupId string, body SetHiddenPathAclJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetHiddenPathAclRequest(c.Server, groupId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetInfo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetInfoRequest(c.Server)
 	if err != nil {
@@ -329,6 +490,54 @@ func (c *Client) SetLogLevel(ctx context.Context, body SetLogLevelJSONRequestBod
 	return c.Client.Do(req)
 }
 
+func (c *Client) FlushLookupCache(ctx context.Context, params *FlushLookupCacheParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFlushLookupCacheRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLookupCache(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLookupCacheRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostOrigination(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostOriginationRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostRegistration(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRegistrationRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetSegments(ctx context.Context, params *GetSegmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetSegmentsRequest(c.Server, params)
 	if err != nil {
@@ -535,6 +744,22 @@ func NewGetBeaconsRequest(server string, params *GetBeaconsParams) (*http.Reques
 
 		}
 
+		if params.MaxAge != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_age", runtime.ParamLocationQuery, *params.MaxAge); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
 		if params.All != nil {
 
 			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "all", runtime.ParamLocationQuery, *params.All); err != nil {
@@ -696,6 +921,40 @@ func NewGetBeaconBlobRequest(server string, segmentId SegmentID) (*http.Request,
 	return req, nil
 }
 
+// NewDeleteBeaconUsagesRequest generates requests for DeleteBeaconUsages
+func NewDeleteBeaconUsagesRequest(server string, segmentId SegmentID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/beacons/%s/usages", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetCaRequest generates requests for GetCa
 func NewGetCaRequest(server string) (*http.Request, error) {
 	var err error
@@ -899,8 +1158,8 @@ func NewGetConfigRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetHealthRequest generates requests for GetHealth
-func NewGetHealthRequest(server string) (*http.Request, error) {
+// NewGetDrkeySecretValuesRequest generates requests for GetDrkeySecretValues
+func NewGetDrkeySecretValuesRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -908,7 +1167,7 @@ func NewGetHealthRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/health")
+	operationPath := fmt.Sprintf("/drkey/secret_values")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -926,8 +1185,8 @@ func NewGetHealthRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetInfoRequest generates requests for GetInfo
-func NewGetInfoRequest(server string) (*http.Request, error) {
+// NewPostDrkeySecretValuesRotationRequest generates requests for PostDrkeySecretValuesRotation
+func NewPostDrkeySecretValuesRotationRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -935,7 +1194,7 @@ func NewGetInfoRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/info")
+	operationPath := fmt.Sprintf("/drkey/secret_values/rotation")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -945,7 +1204,7 @@ func NewGetInfoRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -953,8 +1212,8 @@ func NewGetInfoRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetLogLevelRequest generates requests for GetLogLevel
-func NewGetLogLevelRequest(server string) (*http.Request, error) {
+// NewGetGracePeriodsRequest generates requests for GetGracePeriods
+func NewGetGracePeriodsRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -962,7 +1221,7 @@ func NewGetLogLevelRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/log/level")
+	operationPath := fmt.Sprintf("/grace_periods")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -980,27 +1239,23 @@ func NewGetLogLevelRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewSetLogLevelRequest calls the generic SetLogLevel builder with application/json body
-func NewSetLogLevelRequest(server string, body SetLogLevelJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+// NewAcceptGracePeriodRequest generates requests for AcceptGracePeriod
+func NewAcceptGracePeriodRequest(server string, isd int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "isd", runtime.ParamLocationPath, isd)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewSetLogLevelRequestWithBody(server, "application/json", bodyReader)
-}
-
-// NewSetLogLevelRequestWithBody generates requests for SetLogLevel with any type of body
-func NewSetLogLevelRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/log/level")
+	operationPath := fmt.Sprintf("/grace_periods/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1010,26 +1265,31 @@ func NewSetLogLevelRequestWithBody(server string, contentType string, body io.Re
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewGetSegmentsRequest generates requests for GetSegments
-func NewGetSegmentsRequest(server string, params *GetSegmentsParams) (*http.Request, error) {
+// NewRefuseGracePeriodRequest generates requests for RefuseGracePeriod
+func NewRefuseGracePeriodRequest(server string, isd int) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "isd", runtime.ParamLocationPath, isd)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/segments")
+	operationPath := fmt.Sprintf("/grace_periods/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1039,42 +1299,31 @@ func NewGetSegmentsRequest(server string, params *GetSegmentsParams) (*http.Requ
 		return nil, err
 	}
 
-	if params != nil {
-		queryValues := queryURL.Query()
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-		if params.StartIsdAs != nil {
+	return req, nil
+}
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_isd_as", runtime.ParamLocationQuery, *params.StartIsdAs); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		if params.EndIsdAs != nil {
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end_isd_as", runtime.ParamLocationQuery, *params.EndIsdAs); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-		}
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-		queryURL.RawQuery = queryValues.Encode()
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
 
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
@@ -1085,23 +1334,16 @@ func NewGetSegmentsRequest(server string, params *GetSegmentsParams) (*http.Requ
 	return req, nil
 }
 
-// NewDeleteSegmentRequest generates requests for DeleteSegment
-func NewDeleteSegmentRequest(server string, segmentId SegmentID) (*http.Request, error) {
+// NewGetHiddenPathAclsRequest generates requests for GetHiddenPathAcls
+func NewGetHiddenPathAclsRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/segments/%s", pathParam0)
+	operationPath := fmt.Sprintf("/hidden_paths/acls")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1111,7 +1353,7 @@ func NewDeleteSegmentRequest(server string, segmentId SegmentID) (*http.Request,
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1119,13 +1361,13 @@ func NewDeleteSegmentRequest(server string, segmentId SegmentID) (*http.Request,
 	return req, nil
 }
 
-// NewGetSegmentRequest generates requests for GetSegment
-func NewGetSegmentRequest(server string, segmentId SegmentID) (*http.Request, error) {
+// NewResetHiddenPathAclRequest generates requests for ResetHiddenPathAcl
+func NewResetHiddenPathAclRequest(server string, groupId string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "group-id", runtime.ParamLocationPath, groupId)
 	if err != nil {
 		return nil, err
 	}
@@ -1135,7 +1377,7 @@ func NewGetSegmentRequest(server string, segmentId SegmentID) (*http.Request, er
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/segments/%s", pathParam0)
+	operationPath := fmt.Sprintf("/hidden_paths/acls/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1145,7 +1387,7 @@ func NewGetSegmentRequest(server string, segmentId SegmentID) (*http.Request, er
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1153,13 +1395,24 @@ func NewGetSegmentRequest(server string, segmentId SegmentID) (*http.Request, er
 	return req, nil
 }
 
-// NewGetSegmentBlobRequest generates requests for GetSegmentBlob
-func NewGetSegmentBlobRequest(server string, segmentId SegmentID) (*http.Request, error) {
+// NewSetHiddenPathAclRequest calls the generic SetHiddenPathAcl builder with application/json body
+func NewSetHiddenPathAclRequest(server string, groupId string, body SetHiddenPathAclJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetHiddenPathAclRequestWithBody(server, groupId, "application/json", bodyReader)
+}
+
+// NewSetHiddenPathAclRequestWithBody generates requests for SetHiddenPathAcl with any type of body
+func NewSetHiddenPathAclRequestWithBody(server string, groupId string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "group-id", runtime.ParamLocationPath, groupId)
 	if err != nil {
 		return nil, err
 	}
@@ -1169,7 +1422,7 @@ func NewGetSegmentBlobRequest(server string, segmentId SegmentID) (*http.Request
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/segments/%s/blob", pathParam0)
+	operationPath := fmt.Sprintf("/hidden_paths/acls/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1179,16 +1432,18 @@ func NewGetSegmentBlobRequest(server string, segmentId SegmentID) (*http.Request
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewGetSignerRequest generates requests for GetSigner
-func NewGetSignerRequest(server string) (*http.Request, error) {
+// NewGetInfoRequest generates requests for GetInfo
+func NewGetInfoRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1196,7 +1451,7 @@ func NewGetSignerRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/signer")
+	operationPath := fmt.Sprintf("/info")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1214,8 +1469,8 @@ func NewGetSignerRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetSignerChainRequest generates requests for GetSignerChain
-func NewGetSignerChainRequest(server string) (*http.Request, error) {
+// NewGetLogLevelRequest generates requests for GetLogLevel
+func NewGetLogLevelRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1223,7 +1478,7 @@ func NewGetSignerChainRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/signer/blob")
+	operationPath := fmt.Sprintf("/log/level")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1241,8 +1496,19 @@ func NewGetSignerChainRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetTopologyRequest generates requests for GetTopology
-func NewGetTopologyRequest(server string) (*http.Request, error) {
+// NewSetLogLevelRequest calls the generic SetLogLevel builder with application/json body
+func NewSetLogLevelRequest(server string, body SetLogLevelJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetLogLevelRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetLogLevelRequestWithBody generates requests for SetLogLevel with any type of body
+func NewSetLogLevelRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1250,7 +1516,7 @@ func NewGetTopologyRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/topology")
+	operationPath := fmt.Sprintf("/log/level")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1260,16 +1526,18 @@ func NewGetTopologyRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewGetTrcsRequest generates requests for GetTrcs
-func NewGetTrcsRequest(server string, params *GetTrcsParams) (*http.Request, error) {
+// NewFlushLookupCacheRequest generates requests for FlushLookupCache
+func NewFlushLookupCacheRequest(server string, params *FlushLookupCacheParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1277,7 +1545,7 @@ func NewGetTrcsRequest(server string, params *GetTrcsParams) (*http.Request, err
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trcs")
+	operationPath := fmt.Sprintf("/lookup_cache")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1290,9 +1558,9 @@ func NewGetTrcsRequest(server string, params *GetTrcsParams) (*http.Request, err
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if params.Isd != nil {
+		if params.Src != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", false, "isd", runtime.ParamLocationQuery, *params.Isd); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "src", runtime.ParamLocationQuery, *params.Src); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -1306,9 +1574,9 @@ func NewGetTrcsRequest(server string, params *GetTrcsParams) (*http.Request, err
 
 		}
 
-		if params.All != nil {
+		if params.Dst != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "all", runtime.ParamLocationQuery, *params.All); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dst", runtime.ParamLocationQuery, *params.Dst); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -1325,7 +1593,7 @@ func NewGetTrcsRequest(server string, params *GetTrcsParams) (*http.Request, err
 		queryURL.RawQuery = queryValues.Encode()
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1333,37 +1601,43 @@ func NewGetTrcsRequest(server string, params *GetTrcsParams) (*http.Request, err
 	return req, nil
 }
 
-// NewGetTrcRequest generates requests for GetTrc
-func NewGetTrcRequest(server string, isd int, base int, serial int) (*http.Request, error) {
+// NewGetLookupCacheRequest generates requests for GetLookupCache
+func NewGetLookupCacheRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "isd", runtime.ParamLocationPath, isd)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	var pathParam1 string
+	operationPath := fmt.Sprintf("/lookup_cache")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "base", runtime.ParamLocationPath, base)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
 
-	var pathParam2 string
-
-	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "serial", runtime.ParamLocationPath, serial)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	return req, nil
+}
+
+// NewPostOriginationRequest generates requests for PostOrigination
+func NewPostOriginationRequest(server string) (*http.Request, error) {
+	var err error
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trcs/isd%s-b%s-s%s", pathParam0, pathParam1, pathParam2)
+	operationPath := fmt.Sprintf("/origination")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1373,7 +1647,7 @@ func NewGetTrcRequest(server string, isd int, base int, serial int) (*http.Reque
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1381,37 +1655,43 @@ func NewGetTrcRequest(server string, isd int, base int, serial int) (*http.Reque
 	return req, nil
 }
 
-// NewGetTrcBlobRequest generates requests for GetTrcBlob
-func NewGetTrcBlobRequest(server string, isd int, base int, serial int) (*http.Request, error) {
+// NewPostRegistrationRequest generates requests for PostRegistration
+func NewPostRegistrationRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "isd", runtime.ParamLocationPath, isd)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	var pathParam1 string
+	operationPath := fmt.Sprintf("/registration")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "base", runtime.ParamLocationPath, base)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
 
-	var pathParam2 string
-
-	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "serial", runtime.ParamLocationPath, serial)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	return req, nil
+}
+
+// NewGetSegmentsRequest generates requests for GetSegments
+func NewGetSegmentsRequest(server string, params *GetSegmentsParams) (*http.Request, error) {
+	var err error
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/trcs/isd%s-b%s-s%s/blob", pathParam0, pathParam1, pathParam2)
+	operationPath := fmt.Sprintf("/segments")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1421,6 +1701,44 @@ func NewGetTrcBlobRequest(server string, isd int, base int, serial int) (*http.R
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.StartIsdAs != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start_isd_as", runtime.ParamLocationQuery, *params.StartIsdAs); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.EndIsdAs != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end_isd_as", runtime.ParamLocationQuery, *params.EndIsdAs); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1429,132 +1747,956 @@ func NewGetTrcBlobRequest(server string, isd int, base int, serial int) (*http.R
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewDeleteSegmentRequest generates requests for DeleteSegment
+func NewDeleteSegmentRequest(server string, segmentId SegmentID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	operationPath := fmt.Sprintf("/segments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
+
+	return req, nil
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetBeaconsWithResponse request
-	GetBeaconsWithResponse(ctx context.Context, params *GetBeaconsParams, reqEditors ...RequestEditorFn) (*GetBeaconsResponse, error)
+// NewGetSegmentRequest generates requests for GetSegment
+func NewGetSegmentRequest(server string, segmentId SegmentID) (*http.Request, error) {
+	var err error
 
-	// DeleteBeaconWithResponse request
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/segments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSegmentBlobRequest generates requests for GetSegmentBlob
+func NewGetSegmentBlobRequest(server string, segmentId SegmentID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "segment-id", runtime.ParamLocationPath, segmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/segments/%s/blob", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSignerRequest generates requests for GetSigner
+func NewGetSignerRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/signer")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSignerChainRequest generates requests for GetSignerChain
+func NewGetSignerChainRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/signer/blob")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTopologyRequest generates requests for GetTopology
+func NewGetTopologyRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/topology")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTrcsRequest generates requests for GetTrcs
+func NewGetTrcsRequest(server string, params *GetTrcsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trcs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Isd != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", false, "isd", runtime.ParamLocationQuery, *params.Isd); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.All != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "all", runtime.ParamLocationQuery, *params.All); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTrcRequest generates requests for GetTrc
+func NewGetTrcRequest(server string, isd int, base int, serial int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "isd", runtime.ParamLocationPath, isd)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "base", runtime.ParamLocationPath, base)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "serial", runtime.ParamLocationPath, serial)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trcs/isd%s-b%s-s%s", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTrcBlobRequest generates requests for GetTrcBlob
+func NewGetTrcBlobRequest(server string, isd int, base int, serial int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "isd", runtime.ParamLocationPath, isd)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "base", runtime.ParamLocationPath, base)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "serial", runtime.ParamLocationPath, serial)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/trcs/isd%s-b%s-s%s/blob", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetBeaconsWithResponse request
+	GetBeaconsWithResponse(ctx context.Context, params *GetBeaconsParams, reqEditors ...RequestEditorFn) (*GetBeaconsResponse, error)
+
+	// DeleteBeaconWithResponse request
 	DeleteBeaconWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*DeleteBeaconResponse, error)
 
-	// GetBeaconWithResponse request
-	GetBeaconWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetBeaconResponse, error)
+	// GetBeaconWithResponse request
+	GetBeaconWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetBeaconResponse, error)
+
+	// GetBeaconBlobWithResponse request
+	GetBeaconBlobWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetBeaconBlobResponse, error)
+
+	// DeleteBeaconUsagesWithResponse request
+	DeleteBeaconUsagesWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*DeleteBeaconUsagesResponse, error)
+
+	// GetCaWithResponse request
+	GetCaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCaResponse, error)
+
+	// GetCertificatesWithResponse request
+	GetCertificatesWithResponse(ctx context.Context, params *GetCertificatesParams, reqEditors ...RequestEditorFn) (*GetCertificatesResponse, error)
+
+	// GetCertificateWithResponse request
+	GetCertificateWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetCertificateResponse, error)
+
+	// GetCertificateBlobWithResponse request
+	GetCertificateBlobWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetCertificateBlobResponse, error)
+
+	// GetConfigWithResponse request
+	GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error)
+
+	// GetDrkeySecretValuesWithResponse request
+	GetDrkeySecretValuesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDrkeySecretValuesResponse, error)
+
+	// PostDrkeySecretValuesRotationWithResponse request
+	PostDrkeySecretValuesRotationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostDrkeySecretValuesRotationResponse, error)
+
+	// GetGracePeriodsWithResponse request
+	GetGracePeriodsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetGracePeriodsResponse, error)
+
+	// AcceptGracePeriodWithResponse request
+	AcceptGracePeriodWithResponse(ctx context.Context, isd int, reqEditors ...RequestEditorFn) (*AcceptGracePeriodResponse, error)
+
+	// RefuseGracePeriodWithResponse request
+	RefuseGracePeriodWithResponse(ctx context.Context, isd int, reqEditors ...RequestEditorFn) (*RefuseGracePeriodResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// GetHiddenPathAclsWithResponse request
+	GetHiddenPathAclsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHiddenPathAclsResponse, error)
+
+	// ResetHiddenPathAclWithResponse request
+	ResetHiddenPathAclWithResponse(ctx context.Context, groupId string, reqEditors ...RequestEditorFn) (*ResetHiddenPathAclResponse, error)
+
+	// SetHiddenPathAclWithBodyWithResponse request with any body
+	SetHiddenPathAclWithBodyWithResponse(ctx context.Context, groupId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetHiddenPathAclResponse, error)
+
+	SetHiddenPathAclWithResponse(ctx context.Context, groupId string, body SetHiddenPathAclJSONRequestBody, reqEditors ...RequestEditorFn) (*SetHiddenPathAclResponse, error)
+
+	// GetInfoWithResponse request
+	GetInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInfoResponse, error)
+
+	// GetLogLevelWithResponse request
+	GetLogLevelWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogLevelResponse, error)
+
+	// SetLogLevelWithBodyWithResponse request with any body
+	SetLogLevelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
+
+	SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
+
+	// FlushLookupCacheWithResponse request
+	FlushLookupCacheWithResponse(ctx context.Context, params *FlushLookupCacheParams, reqEditors ...RequestEditorFn) (*FlushLookupCacheResponse, error)
+
+	// GetLookupCacheWithResponse request
+	GetLookupCacheWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLookupCacheResponse, error)
+
+	// PostOriginationWithResponse request
+	PostOriginationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostOriginationResponse, error)
+
+	// PostRegistrationWithResponse request
+	PostRegistrationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostRegistrationResponse, error)
+
+	// GetSegmentsWithResponse request
+	GetSegmentsWithResponse(ctx context.Context, params *GetSegmentsParams, reqEditors ...RequestEditorFn) (*GetSegmentsResponse, error)
+
+	// DeleteSegmentWithResponse request
+	DeleteSegmentWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*DeleteSegmentResponse, error)
+
+	// GetSegmentWithResponse request
+	GetSegmentWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetSegmentResponse, error)
+
+	// GetSegmentBlobWithResponse request
+	GetSegmentBlobWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetSegmentBlobResponse, error)
+
+	// GetSignerWithResponse request
+	GetSignerWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerResponse, error)
+
+	// GetSignerChainWithResponse request
+	GetSignerChainWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerChainResponse, error)
+
+	// GetTopologyWithResponse request
+	GetTopologyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTopologyResponse, error)
+
+	// GetTrcsWithResponse request
+	GetTrcsWithResponse(ctx context.Context, params *GetTrcsParams, reqEditors ...RequestEditorFn) (*GetTrcsResponse, error)
+
+	// GetTrcWithResponse request
+	GetTrcWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcResponse, error)
+
+	// GetTrcBlobWithResponse request
+	GetTrcBlobWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcBlobResponse, error)
+}
+
+type GetBeaconsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Beacons *[]Beacon `json:"beacons,omitempty"`
+	}
+	JSON400 *BadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBeaconsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBeaconsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// GetBeaconBlobWithResponse request
-	GetBeaconBlobWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetBeaconBlobResponse, error)
+type DeleteBeaconResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON400                   *BadRequest
+	ApplicationproblemJSON404 *AdminNotAvailable
+	JSON500                   *Internal
+}
 
-	// GetCaWithResponse request
-	GetCaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCaResponse, error)
+// Status returns HTTPResponse.Status
+func (r DeleteBeaconResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteBeaconResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBeaconResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BeaconGetResponseJson
+	JSON400      *BadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBeaconResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBeaconResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBeaconBlobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBeaconBlobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBeaconBlobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteBeaconUsagesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON400                   *BadRequest
+	ApplicationproblemJSON404 *AdminNotAvailable
+	JSON500                   *Internal
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteBeaconUsagesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteBeaconUsagesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CA
+	JSON400      *BadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCertificatesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]ChainBrief
+	ApplicationproblemJSON400 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCertificatesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCertificatesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCertificateResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Chain
+	ApplicationproblemJSON400 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCertificateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCertificateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCertificateBlobResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCertificateBlobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCertificateBlobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r GetConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDrkeySecretValuesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]SecretValueStatus
+	ApplicationproblemJSON404 *DRKeyNotAvailable
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDrkeySecretValuesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDrkeySecretValuesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostDrkeySecretValuesRotationResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON404 *AdminNotAvailable
+	ApplicationproblemJSON500 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r PostDrkeySecretValuesRotationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostDrkeySecretValuesRotationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetGracePeriodsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]GracePeriod
+	ApplicationproblemJSON500 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetGracePeriodsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetCertificatesWithResponse request
-	GetCertificatesWithResponse(ctx context.Context, params *GetCertificatesParams, reqEditors ...RequestEditorFn) (*GetCertificatesResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetGracePeriodsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// GetCertificateWithResponse request
-	GetCertificateWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetCertificateResponse, error)
+type AcceptGracePeriodResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON404 *Problem
+	ApplicationproblemJSON500 *Problem
+}
 
-	// GetCertificateBlobWithResponse request
-	GetCertificateBlobWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetCertificateBlobResponse, error)
+// Status returns HTTPResponse.Status
+func (r AcceptGracePeriodResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetConfigWithResponse request
-	GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r AcceptGracePeriodResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// GetHealthWithResponse request
-	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+type RefuseGracePeriodResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *GracePeriod
+	ApplicationproblemJSON404 *Problem
+	ApplicationproblemJSON500 *Problem
+}
 
-	// GetInfoWithResponse request
-	GetInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInfoResponse, error)
+// Status returns HTTPResponse.Status
+func (r RefuseGracePeriodResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetLogLevelWithResponse request
-	GetLogLevelWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogLevelResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r RefuseGracePeriodResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// SetLogLevelWithBodyWithResponse request with any body
-	SetLogLevelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthResponse
+	JSON400      *BadRequest
+}
 
-	SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error)
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetSegmentsWithResponse request
-	GetSegmentsWithResponse(ctx context.Context, params *GetSegmentsParams, reqEditors ...RequestEditorFn) (*GetSegmentsResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// DeleteSegmentWithResponse request
-	DeleteSegmentWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*DeleteSegmentResponse, error)
+type GetHiddenPathAclsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]HiddenPathACL
+	ApplicationproblemJSON404 *HiddenPathsNotAvailable
+}
 
-	// GetSegmentWithResponse request
-	GetSegmentWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetSegmentResponse, error)
+// Status returns HTTPResponse.Status
+func (r GetHiddenPathAclsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetSegmentBlobWithResponse request
-	GetSegmentBlobWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetSegmentBlobResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHiddenPathAclsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// GetSignerWithResponse request
-	GetSignerWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerResponse, error)
+type ResetHiddenPathAclResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *Problem
+	ApplicationproblemJSON404 *Problem
+	ApplicationproblemJSON500 *Problem
+}
 
-	// GetSignerChainWithResponse request
-	GetSignerChainWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerChainResponse, error)
+// Status returns HTTPResponse.Status
+func (r ResetHiddenPathAclResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetTopologyWithResponse request
-	GetTopologyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTopologyResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResetHiddenPathAclResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
 
-	// GetTrcsWithResponse request
-	GetTrcsWithResponse(ctx context.Context, params *GetTrcsParams, reqEditors ...RequestEditorFn) (*GetTrcsResponse, error)
+type SetHiddenPathAclResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *HiddenPathACL
+	ApplicationproblemJSON400 *Problem
+	ApplicationproblemJSON404 *Problem
+	ApplicationproblemJSON500 *Problem
+}
 
-	// GetTrcWithResponse request
-	GetTrcWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcResponse, error)
+// Status returns HTTPResponse.Status
+func (r SetHiddenPathAclResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	// GetTrcBlobWithResponse request
-	GetTrcBlobWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcBlobResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetHiddenPathAclResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
-type GetBeaconsResponse struct {
+type GetInfoResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *struct {
-		Beacons *[]Beacon `json:"beacons,omitempty"`
-	}
-	JSON400 *BadRequest
+	JSON400      *BadRequest
 }
 
 // Status returns HTTPResponse.Status
-func (r GetBeaconsResponse) Status() string {
+func (r GetInfoResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1562,22 +2704,22 @@ func (r GetBeaconsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetBeaconsResponse) StatusCode() int {
+func (r GetInfoResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type DeleteBeaconResponse struct {
+type GetLogLevelResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON200      *LogLevel
 	JSON400      *BadRequest
-	JSON500      *Internal
 }
 
 // Status returns HTTPResponse.Status
-func (r DeleteBeaconResponse) Status() string {
+func (r GetLogLevelResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1585,22 +2727,22 @@ func (r DeleteBeaconResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r DeleteBeaconResponse) StatusCode() int {
+func (r GetLogLevelResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetBeaconResponse struct {
+type SetLogLevelResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *BeaconGetResponseJson
+	JSON200      *LogLevel
 	JSON400      *BadRequest
 }
 
 // Status returns HTTPResponse.Status
-func (r GetBeaconResponse) Status() string {
+func (r SetLogLevelResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1608,21 +2750,22 @@ func (r GetBeaconResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetBeaconResponse) StatusCode() int {
+func (r SetLogLevelResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetBeaconBlobResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *BadRequest
+type FlushLookupCacheResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *Problem
+	ApplicationproblemJSON404 *Problem
 }
 
 // Status returns HTTPResponse.Status
-func (r GetBeaconBlobResponse) Status() string {
+func (r FlushLookupCacheResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1630,22 +2773,22 @@ func (r GetBeaconBlobResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetBeaconBlobResponse) StatusCode() int {
+func (r FlushLookupCacheResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetCaResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *CA
-	JSON400      *BadRequest
+type GetLookupCacheResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]LookupCacheEntry
+	ApplicationproblemJSON404 *LookupCacheNotAvailable
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCaResponse) Status() string {
+func (r GetLookupCacheResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1653,22 +2796,66 @@ func (r GetCaResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCaResponse) StatusCode() int {
+func (r GetLookupCacheResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetCertificatesResponse struct {
+type PostOriginationResponse struct {
 	Body                      []byte
 	HTTPResponse              *http.Response
-	JSON200                   *[]ChainBrief
+	ApplicationproblemJSON404 *AdminNotAvailable
+}
+
+// Status returns HTTPResponse.Status
+func (r PostOriginationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostOriginationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRegistrationResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON404 *AdminNotAvailable
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRegistrationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRegistrationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSegmentsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]SegmentBrief
 	ApplicationproblemJSON400 *Problem
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCertificatesResponse) Status() string {
+func (r GetSegmentsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1676,22 +2863,45 @@ func (r GetCertificatesResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCertificatesResponse) StatusCode() int {
+func (r GetSegmentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSegmentResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *Problem
+	ApplicationproblemJSON500 *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSegmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSegmentResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetCertificateResponse struct {
+type GetSegmentResponse struct {
 	Body                      []byte
 	HTTPResponse              *http.Response
-	JSON200                   *Chain
+	JSON200                   *Segment
 	ApplicationproblemJSON400 *Problem
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCertificateResponse) Status() string {
+func (r GetSegmentResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1699,21 +2909,21 @@ func (r GetCertificateResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCertificateResponse) StatusCode() int {
+func (r GetSegmentResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetCertificateBlobResponse struct {
+type GetSegmentBlobResponse struct {
 	Body                      []byte
 	HTTPResponse              *http.Response
 	ApplicationproblemJSON400 *Problem
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCertificateBlobResponse) Status() string {
+func (r GetSegmentBlobResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1721,21 +2931,22 @@ func (r GetCertificateBlobResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCertificateBlobResponse) StatusCode() int {
+func (r GetSegmentBlobResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetConfigResponse struct {
+type GetSignerResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON200      *Signer
 	JSON400      *BadRequest
 }
 
 // Status returns HTTPResponse.Status
-func (r GetConfigResponse) Status() string {
+func (r GetSignerResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1743,22 +2954,21 @@ func (r GetConfigResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetConfigResponse) StatusCode() int {
+func (r GetSignerResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetHealthResponse struct {
+type GetSignerChainResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *HealthResponse
 	JSON400      *BadRequest
 }
 
 // Status returns HTTPResponse.Status
-func (r GetHealthResponse) Status() string {
+func (r GetSignerChainResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1766,21 +2976,22 @@ func (r GetHealthResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetHealthResponse) StatusCode() int {
+func (r GetSignerChainResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetInfoResponse struct {
+type GetTopologyResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON200      *Topology
 	JSON400      *BadRequest
 }
 
 // Status returns HTTPResponse.Status
-func (r GetInfoResponse) Status() string {
+func (r GetTopologyResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1788,22 +2999,22 @@ func (r GetInfoResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetInfoResponse) StatusCode() int {
+func (r GetTopologyResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetLogLevelResponse struct {
+type GetTrcsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *LogLevel
+	JSON200      *[]TRCBrief
 	JSON400      *BadRequest
 }
 
 // Status returns HTTPResponse.Status
-func (r GetLogLevelResponse) Status() string {
+func (r GetTrcsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1811,22 +3022,22 @@ func (r GetLogLevelResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetLogLevelResponse) StatusCode() int {
+func (r GetTrcsResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type SetLogLevelResponse struct {
+type GetTrcResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *LogLevel
+	JSON200      *TRC
 	JSON400      *BadRequest
 }
 
 // Status returns HTTPResponse.Status
-func (r SetLogLevelResponse) Status() string {
+func (r GetTrcResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1834,22 +3045,21 @@ func (r SetLogLevelResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r SetLogLevelResponse) StatusCode() int {
+func (r GetTrcResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetSegmentsResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *[]SegmentBrief
-	ApplicationproblemJSON400 *Problem
+type GetTrcBlobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
 }
 
 // Status returns HTTPResponse.Status
-func (r GetSegmentsResponse) Status() string {
+func (r GetTrcBlobResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1857,547 +3067,871 @@ func (r GetSegmentsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetSegmentsResponse) StatusCode() int {
+func (r GetTrcBlobResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type DeleteSegmentResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	ApplicationproblemJSON400 *Problem
-	ApplicationproblemJSON500 *Problem
+// GetBeaconsWithResponse request returning *GetBeaconsResponse
+func (c *ClientWithResponses) GetBeaconsWithResponse(ctx context.Context, params *GetBeaconsParams, reqEditors ...RequestEditorFn) (*GetBeaconsResponse, error) {
+	rsp, err := c.GetBeacons(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBeaconsResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r DeleteSegmentResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// DeleteBeaconWithResponse request returning *DeleteBeaconResponse
+func (c *ClientWithResponses) DeleteBeaconWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*DeleteBeaconResponse, error) {
+	rsp, err := c.DeleteBeacon(ctx, segmentId, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseDeleteBeaconResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteSegmentResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// GetBeaconWithResponse request returning *GetBeaconResponse
+func (c *ClientWithResponses) GetBeaconWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetBeaconResponse, error) {
+	rsp, err := c.GetBeacon(ctx, segmentId, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseGetBeaconResponse(rsp)
 }
 
-type GetSegmentResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	JSON200                   *Segment
-	ApplicationproblemJSON400 *Problem
+// GetBeaconBlobWithResponse request returning *GetBeaconBlobResponse
+func (c *ClientWithResponses) GetBeaconBlobWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetBeaconBlobResponse, error) {
+	rsp, err := c.GetBeaconBlob(ctx, segmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBeaconBlobResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetSegmentResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// DeleteBeaconUsagesWithResponse request returning *DeleteBeaconUsagesResponse
+func (c *ClientWithResponses) DeleteBeaconUsagesWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*DeleteBeaconUsagesResponse, error) {
+	rsp, err := c.DeleteBeaconUsages(ctx, segmentId, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseDeleteBeaconUsagesResponse(rsp)
+}
+
+// GetCaWithResponse request returning *GetCaResponse
+func (c *ClientWithResponses) GetCaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCaResponse, error) {
+	rsp, err := c.GetCa(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCaResponse(rsp)
+}
+
+// GetCertificatesWithResponse request returning *GetCertificatesResponse
+func (c *ClientWithResponses) GetCertificatesWithResponse(ctx context.Context, params *GetCertificatesParams, reqEditors ...RequestEditorFn) (*GetCertificatesResponse, error) {
+	rsp, err := c.GetCertificates(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCertificatesResponse(rsp)
+}
+
+// GetCertificateWithResponse request returning *GetCertificateResponse
+func (c *ClientWithResponses) GetCertificateWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetCertificateResponse, error) {
+	rsp, err := c.GetCertificate(ctx, chainId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCertificateResponse(rsp)
+}
+
+// GetCertificateBlobWithResponse request returning *GetCertificateBlobResponse
+func (c *ClientWithResponses) GetCertificateBlobWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetCertificateBlobResponse, error) {
+	rsp, err := c.GetCertificateBlob(ctx, chainId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCertificateBlobResponse(rsp)
+}
+
+// GetConfigWithResponse request returning *GetConfigResponse
+func (c *ClientWithResponses) GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error) {
+	rsp, err := c.GetConfig(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetConfigResponse(rsp)
+}
+
+// GetDrkeySecretValuesWithResponse request returning *GetDrkeySecretValuesResponse
+func (c *ClientWithResponses) GetDrkeySecretValuesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDrkeySecretValuesResponse, error) {
+	rsp, err := c.GetDrkeySecretValues(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDrkeySecretValuesResponse(rsp)
+}
+
+// PostDrkeySecretValuesRotationWithResponse request returning *PostDrkeySecretValuesRotationResponse
+func (c *ClientWithResponses) PostDrkeySecretValuesRotationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostDrkeySecretValuesRotationResponse, error) {
+	rsp, err := c.PostDrkeySecretValuesRotation(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostDrkeySecretValuesRotationResponse(rsp)
+}
+
+// GetGracePeriodsWithResponse request returning *GetGracePeriodsResponse
+func (c *ClientWithResponses) GetGracePeriodsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetGracePeriodsResponse, error) {
+	rsp, err := c.GetGracePeriods(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetGracePeriodsResponse(rsp)
+}
+
+// AcceptGracePeriodWithResponse request returning *AcceptGracePeriodResponse
+func (c *ClientWithResponses) AcceptGracePeriodWithResponse(ctx context.Context, isd int, reqEditors ...RequestEditorFn) (*AcceptGracePeriodResponse, error) {
+	rsp, err := c.AcceptGracePeriod(ctx, isd, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcceptGracePeriodResponse(rsp)
+}
+
+// RefuseGracePeriodWithResponse request returning *RefuseGracePeriodResponse
+func (c *ClientWithResponses) RefuseGracePeriodWithResponse(ctx context.Context, isd int, reqEditors ...RequestEditorFn) (*RefuseGracePeriodResponse, error) {
+	rsp, err := c.RefuseGracePeriod(ctx, isd, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRefuseGracePeriodResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// GetHiddenPathAclsWithResponse request returning *GetHiddenPathAclsResponse
+func (c *ClientWithResponses) GetHiddenPathAclsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHiddenPathAclsResponse, error) {
+	rsp, err := c.GetHiddenPathAcls(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHiddenPathAclsResponse(rsp)
+}
+
+// ResetHiddenPathAclWithResponse request returning *ResetHiddenPathAclResponse
+func (c *ClientWithResponses) ResetHiddenPathAclWithResponse(ctx context.Context, groupId string, reqEditors ...RequestEditorFn) (*ResetHiddenPathAclResponse, error) {
+	rsp, err := c.ResetHiddenPathAcl(ctx, groupId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResetHiddenPathAclResponse(rsp)
+}
+
+// SetHiddenPathAclWithBodyWithResponse request with arbitrary body returning *SetHiddenPathAclResponse
+func (c *ClientWithResponses) SetHiddenPathAclWithBodyWithResponse(ctx context.Context, groupId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetHiddenPathAclResponse, error) {
+	rsp, err := c.SetHiddenPathAclWithBody(ctx, groupId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetHiddenPathAclResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetHiddenPathAclWithResponse(ctx context.Context, groupId string, body SetHiddenPathAclJSONRequestBody, reqEditors ...RequestEditorFn) (*SetHiddenPathAclResponse, error) {
+	rsp, err := c.SetHiddenPathAcl(ctx, groupId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetHiddenPathAclResponse(rsp)
+}
+
+// GetInfoWithResponse request returning *GetInfoResponse
+func (c *ClientWithResponses) GetInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInfoResponse, error) {
+	rsp, err := c.GetInfo(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInfoResponse(rsp)
+}
+
+// GetLogLevelWithResponse request returning *GetLogLevelResponse
+func (c *ClientWithResponses) GetLogLevelWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogLevelResponse, error) {
+	rsp, err := c.GetLogLevel(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLogLevelResponse(rsp)
+}
+
+// SetLogLevelWithBodyWithResponse request with arbitrary body returning *SetLogLevelResponse
+func (c *ClientWithResponses) SetLogLevelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error) {
+	rsp, err := c.SetLogLevelWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLogLevelResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error) {
+	rsp, err := c.SetLogLevel(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLogLevelResponse(rsp)
+}
+
+// FlushLookupCacheWithResponse request returning *FlushLookupCacheResponse
+func (c *ClientWithResponses) FlushLookupCacheWithResponse(ctx context.Context, params *FlushLookupCacheParams, reqEditors ...RequestEditorFn) (*FlushLookupCacheResponse, error) {
+	rsp, err := c.FlushLookupCache(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFlushLookupCacheResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSegmentResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// GetLookupCacheWithResponse request returning *GetLookupCacheResponse
+func (c *ClientWithResponses) GetLookupCacheWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLookupCacheResponse, error) {
+	rsp, err := c.GetLookupCache(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseGetLookupCacheResponse(rsp)
 }
 
-type GetSegmentBlobResponse struct {
-	Body                      []byte
-	HTTPResponse              *http.Response
-	ApplicationproblemJSON400 *Problem
+// PostOriginationWithResponse request returning *PostOriginationResponse
+func (c *ClientWithResponses) PostOriginationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostOriginationResponse, error) {
+	rsp, err := c.PostOrigination(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostOriginationResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetSegmentBlobResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// PostRegistrationWithResponse request returning *PostRegistrationResponse
+func (c *ClientWithResponses) PostRegistrationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostRegistrationResponse, error) {
+	rsp, err := c.PostRegistration(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParsePostRegistrationResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSegmentBlobResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// GetSegmentsWithResponse request returning *GetSegmentsResponse
+func (c *ClientWithResponses) GetSegmentsWithResponse(ctx context.Context, params *GetSegmentsParams, reqEditors ...RequestEditorFn) (*GetSegmentsResponse, error) {
+	rsp, err := c.GetSegments(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseGetSegmentsResponse(rsp)
 }
 
-type GetSignerResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Signer
-	JSON400      *BadRequest
+// DeleteSegmentWithResponse request returning *DeleteSegmentResponse
+func (c *ClientWithResponses) DeleteSegmentWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*DeleteSegmentResponse, error) {
+	rsp, err := c.DeleteSegment(ctx, segmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteSegmentResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetSignerResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// GetSegmentWithResponse request returning *GetSegmentResponse
+func (c *ClientWithResponses) GetSegmentWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetSegmentResponse, error) {
+	rsp, err := c.GetSegment(ctx, segmentId, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseGetSegmentResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSignerResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// GetSegmentBlobWithResponse request returning *GetSegmentBlobResponse
+func (c *ClientWithResponses) GetSegmentBlobWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetSegmentBlobResponse, error) {
+	rsp, err := c.GetSegmentBlob(ctx, segmentId, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseGetSegmentBlobResponse(rsp)
 }
 
-type GetSignerChainResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *BadRequest
+// GetSignerWithResponse request returning *GetSignerResponse
+func (c *ClientWithResponses) GetSignerWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerResponse, error) {
+	rsp, err := c.GetSigner(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSignerResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetSignerChainResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// GetSignerChainWithResponse request returning *GetSignerChainResponse
+func (c *ClientWithResponses) GetSignerChainWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerChainResponse, error) {
+	rsp, err := c.GetSignerChain(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseGetSignerChainResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSignerChainResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// GetTopologyWithResponse request returning *GetTopologyResponse
+func (c *ClientWithResponses) GetTopologyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTopologyResponse, error) {
+	rsp, err := c.GetTopology(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseGetTopologyResponse(rsp)
 }
 
-type GetTopologyResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Topology
-	JSON400      *BadRequest
+// GetTrcsWithResponse request returning *GetTrcsResponse
+func (c *ClientWithResponses) GetTrcsWithResponse(ctx context.Context, params *GetTrcsParams, reqEditors ...RequestEditorFn) (*GetTrcsResponse, error) {
+	rsp, err := c.GetTrcs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTrcsResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetTopologyResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// GetTrcWithResponse request returning *GetTrcResponse
+func (c *ClientWithResponses) GetTrcWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcResponse, error) {
+	rsp, err := c.GetTrc(ctx, isd, base, serial, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseGetTrcResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetTopologyResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// GetTrcBlobWithResponse request returning *GetTrcBlobResponse
+func (c *ClientWithResponses) GetTrcBlobWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcBlobResponse, error) {
+	rsp, err := c.GetTrcBlob(ctx, isd, base, serial, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParseGetTrcBlobResponse(rsp)
 }
 
-type GetTrcsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]TRCBrief
-	JSON400      *BadRequest
-}
+// ParseGetBeaconsResponse parses an HTTP response from a GetBeaconsWithResponse call
+func ParseGetBeaconsResponse(rsp *http.Response) (*GetBeaconsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetTrcsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	response := &GetBeaconsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetTrcsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Beacons *[]Beacon `json:"beacons,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	}
-	return 0
-}
 
-type GetTrcResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *TRC
-	JSON400      *BadRequest
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetTrcResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseDeleteBeaconResponse parses an HTTP response from a DeleteBeaconWithResponse call
+func ParseDeleteBeaconResponse(rsp *http.Response) (*DeleteBeaconResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetTrcResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &DeleteBeaconResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetTrcBlobResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *BadRequest
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest AdminNotAvailable
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Internal
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetTrcBlobResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseGetBeaconResponse parses an HTTP response from a GetBeaconWithResponse call
+func ParseGetBeaconResponse(rsp *http.Response) (*GetBeaconResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBeaconResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BeaconGetResponseJson
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetTrcBlobResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-// GetBeaconsWithResponse request returning *GetBeaconsResponse
-func (c *ClientWithResponses) GetBeaconsWithResponse(ctx context.Context, params *GetBeaconsParams, reqEditors ...RequestEditorFn) (*GetBeaconsResponse, error) {
-	rsp, err := c.GetBeacons(ctx, params, reqEditors...)
+// ParseGetBeaconBlobResponse parses an HTTP response from a GetBeaconBlobWithResponse call
+func ParseGetBeaconBlobResponse(rsp *http.Response) (*GetBeaconBlobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetBeaconsResponse(rsp)
-}
 
-// DeleteBeaconWithResponse request returning *DeleteBeaconResponse
-func (c *ClientWithResponses) DeleteBeaconWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*DeleteBeaconResponse, error) {
-	rsp, err := c.DeleteBeacon(ctx, segmentId, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetBeaconBlobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseDeleteBeaconResponse(rsp)
-}
 
-// GetBeaconWithResponse request returning *GetBeaconResponse
-func (c *ClientWithResponses) GetBeaconWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetBeaconResponse, error) {
-	rsp, err := c.GetBeacon(ctx, segmentId, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	}
-	return ParseGetBeaconResponse(rsp)
+
+	return response, nil
 }
 
-// GetBeaconBlobWithResponse request returning *GetBeaconBlobResponse
-func (c *ClientWithResponses) GetBeaconBlobWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetBeaconBlobResponse, error) {
-	rsp, err := c.GetBeaconBlob(ctx, segmentId, reqEditors...)
+// ParseDeleteBeaconUsagesResponse parses an HTTP response from a DeleteBeaconUsagesWithResponse call
+func ParseDeleteBeaconUsagesResponse(rsp *http.Response) (*DeleteBeaconUsagesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetBeaconBlobResponse(rsp)
-}
 
-// GetCaWithResponse request returning *GetCaResponse
-func (c *ClientWithResponses) GetCaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCaResponse, error) {
-	rsp, err := c.GetCa(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &DeleteBeaconUsagesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetCaResponse(rsp)
-}
 
-// GetCertificatesWithResponse request returning *GetCertificatesResponse
-func (c *ClientWithResponses) GetCertificatesWithResponse(ctx context.Context, params *GetCertificatesParams, reqEditors ...RequestEditorFn) (*GetCertificatesResponse, error) {
-	rsp, err := c.GetCertificates(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest AdminNotAvailable
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Internal
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
-	return ParseGetCertificatesResponse(rsp)
+
+	return response, nil
 }
 
-// GetCertificateWithResponse request returning *GetCertificateResponse
-func (c *ClientWithResponses) GetCertificateWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetCertificateResponse, error) {
-	rsp, err := c.GetCertificate(ctx, chainId, reqEditors...)
+// ParseGetCaResponse parses an HTTP response from a GetCaWithResponse call
+func ParseGetCaResponse(rsp *http.Response) (*GetCaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetCertificateResponse(rsp)
-}
 
-// GetCertificateBlobWithResponse request returning *GetCertificateBlobResponse
-func (c *ClientWithResponses) GetCertificateBlobWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetCertificateBlobResponse, error) {
-	rsp, err := c.GetCertificateBlob(ctx, chainId, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetCaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetCertificateBlobResponse(rsp)
-}
 
-// GetConfigWithResponse request returning *GetConfigResponse
-func (c *ClientWithResponses) GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error) {
-	rsp, err := c.GetConfig(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CA
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	}
-	return ParseGetConfigResponse(rsp)
+
+	return response, nil
 }
 
-// GetHealthWithResponse request returning *GetHealthResponse
-func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
-	rsp, err := c.GetHealth(ctx, reqEditors...)
+// ParseGetCertificatesResponse parses an HTTP response from a GetCertificatesWithResponse call
+func ParseGetCertificatesResponse(rsp *http.Response) (*GetCertificatesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetHealthResponse(rsp)
-}
 
-// GetInfoWithResponse request returning *GetInfoResponse
-func (c *ClientWithResponses) GetInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInfoResponse, error) {
-	rsp, err := c.GetInfo(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetCertificatesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetInfoResponse(rsp)
-}
 
-// GetLogLevelWithResponse request returning *GetLogLevelResponse
-func (c *ClientWithResponses) GetLogLevelWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLogLevelResponse, error) {
-	rsp, err := c.GetLogLevel(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ChainBrief
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
 	}
-	return ParseGetLogLevelResponse(rsp)
+
+	return response, nil
 }
 
-// SetLogLevelWithBodyWithResponse request with arbitrary body returning *SetLogLevelResponse
-func (c *ClientWithResponses) SetLogLevelWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error) {
-	rsp, err := c.SetLogLevelWithBody(ctx, contentType, body, reqEditors...)
+// ParseGetCertificateResponse parses an HTTP response from a GetCertificateWithResponse call
+func ParseGetCertificateResponse(rsp *http.Response) (*GetCertificateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseSetLogLevelResponse(rsp)
-}
 
-func (c *ClientWithResponses) SetLogLevelWithResponse(ctx context.Context, body SetLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLogLevelResponse, error) {
-	rsp, err := c.SetLogLevel(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetCertificateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseSetLogLevelResponse(rsp)
-}
 
-// GetSegmentsWithResponse request returning *GetSegmentsResponse
-func (c *ClientWithResponses) GetSegmentsWithResponse(ctx context.Context, params *GetSegmentsParams, reqEditors ...RequestEditorFn) (*GetSegmentsResponse, error) {
-	rsp, err := c.GetSegments(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Chain
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
 	}
-	return ParseGetSegmentsResponse(rsp)
+
+	return response, nil
 }
 
-// DeleteSegmentWithResponse request returning *DeleteSegmentResponse
-func (c *ClientWithResponses) DeleteSegmentWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*DeleteSegmentResponse, error) {
-	rsp, err := c.DeleteSegment(ctx, segmentId, reqEditors...)
+// ParseGetCertificateBlobResponse parses an HTTP response from a GetCertificateBlobWithResponse call
+func ParseGetCertificateBlobResponse(rsp *http.Response) (*GetCertificateBlobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteSegmentResponse(rsp)
-}
 
-// GetSegmentWithResponse request returning *GetSegmentResponse
-func (c *ClientWithResponses) GetSegmentWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetSegmentResponse, error) {
-	rsp, err := c.GetSegment(ctx, segmentId, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetCertificateBlobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetSegmentResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	}
+
+	return response, nil
 }
 
-// GetSegmentBlobWithResponse request returning *GetSegmentBlobResponse
-func (c *ClientWithResponses) GetSegmentBlobWithResponse(ctx context.Context, segmentId SegmentID, reqEditors ...RequestEditorFn) (*GetSegmentBlobResponse, error) {
-	rsp, err := c.GetSegmentBlob(ctx, segmentId, reqEditors...)
+// ParseGetConfigResponse parses an HTTP response from a GetConfigWithResponse call
+func ParseGetConfigResponse(rsp *http.Response) (*GetConfigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetSegmentBlobResponse(rsp)
-}
 
-// GetSignerWithResponse request returning *GetSignerResponse
-func (c *ClientWithResponses) GetSignerWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerResponse, error) {
-	rsp, err := c.GetSigner(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetSignerResponse(rsp)
-}
 
-// GetSignerChainWithResponse request returning *GetSignerChainResponse
-func (c *ClientWithResponses) GetSignerChainWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSignerChainResponse, error) {
-	rsp, err := c.GetSignerChain(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	}
-	return ParseGetSignerChainResponse(rsp)
+
+	return response, nil
 }
 
-// GetTopologyWithResponse request returning *GetTopologyResponse
-func (c *ClientWithResponses) GetTopologyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTopologyResponse, error) {
-	rsp, err := c.GetTopology(ctx, reqEditors...)
+// ParseGetDrkeySecretValuesResponse parses an HTTP response from a GetDrkeySecretValuesWithResponse call
+func ParseGetDrkeySecretValuesResponse(rsp *http.Response) (*GetDrkeySecretValuesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetTopologyResponse(rsp)
-}
 
-// GetTrcsWithResponse request returning *GetTrcsResponse
-func (c *ClientWithResponses) GetTrcsWithResponse(ctx context.Context, params *GetTrcsParams, reqEditors ...RequestEditorFn) (*GetTrcsResponse, error) {
-	rsp, err := c.GetTrcs(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetDrkeySecretValuesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetTrcsResponse(rsp)
-}
 
-// GetTrcWithResponse request returning *GetTrcResponse
-func (c *ClientWithResponses) GetTrcWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcResponse, error) {
-	rsp, err := c.GetTrc(ctx, isd, base, serial, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []SecretValueStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest DRKeyNotAvailable
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
 	}
-	return ParseGetTrcResponse(rsp)
+
+	return response, nil
 }
 
-// GetTrcBlobWithResponse request returning *GetTrcBlobResponse
-func (c *ClientWithResponses) GetTrcBlobWithResponse(ctx context.Context, isd int, base int, serial int, reqEditors ...RequestEditorFn) (*GetTrcBlobResponse, error) {
-	rsp, err := c.GetTrcBlob(ctx, isd, base, serial, reqEditors...)
+// ParsePostDrkeySecretValuesRotationResponse parses an HTTP response from a PostDrkeySecretValuesRotationWithResponse call
+func ParsePostDrkeySecretValuesRotationResponse(rsp *http.Response) (*PostDrkeySecretValuesRotationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetTrcBlobResponse(rsp)
+
+	response := &PostDrkeySecretValuesRotationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest AdminNotAvailable
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseGetBeaconsResponse parses an HTTP response from a GetBeaconsWithResponse call
-func ParseGetBeaconsResponse(rsp *http.Response) (*GetBeaconsResponse, error) {
+// ParseGetGracePeriodsResponse parses an HTTP response from a GetGracePeriodsWithResponse call
+func ParseGetGracePeriodsResponse(rsp *http.Response) (*GetGracePeriodsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetBeaconsResponse{
+	response := &GetGracePeriodsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			Beacons *[]Beacon `json:"beacons,omitempty"`
-		}
+		var dest []GracePeriod
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Problem
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.ApplicationproblemJSON500 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseDeleteBeaconResponse parses an HTTP response from a DeleteBeaconWithResponse call
-func ParseDeleteBeaconResponse(rsp *http.Response) (*DeleteBeaconResponse, error) {
+// ParseAcceptGracePeriodResponse parses an HTTP response from a AcceptGracePeriodWithResponse call
+func ParseAcceptGracePeriodResponse(rsp *http.Response) (*AcceptGracePeriodResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteBeaconResponse{
+	response := &AcceptGracePeriodResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.ApplicationproblemJSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Internal
+		var dest Problem
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.ApplicationproblemJSON500 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetBeaconResponse parses an HTTP response from a GetBeaconWithResponse call
-func ParseGetBeaconResponse(rsp *http.Response) (*GetBeaconResponse, error) {
+// ParseRefuseGracePeriodResponse parses an HTTP response from a RefuseGracePeriodWithResponse call
+func ParseRefuseGracePeriodResponse(rsp *http.Response) (*RefuseGracePeriodResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetBeaconResponse{
+	response := &RefuseGracePeriodResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest BeaconGetResponseJson
+		var dest GracePeriod
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetBeaconBlobResponse parses an HTTP response from a GetBeaconBlobWithResponse call
-func ParseGetBeaconBlobResponse(rsp *http.Response) (*GetBeaconBlobResponse, error) {
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetBeaconBlobResponse{
+	response := &GetHealthResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest BadRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -2410,88 +3944,95 @@ func ParseGetBeaconBlobResponse(rsp *http.Response) (*GetBeaconBlobResponse, err
 	return response, nil
 }
 
-// ParseGetCaResponse parses an HTTP response from a GetCaWithResponse call
-func ParseGetCaResponse(rsp *http.Response) (*GetCaResponse, error) {
+// ParseGetHiddenPathAclsResponse parses an HTTP response from a GetHiddenPathAclsWithResponse call
+func ParseGetHiddenPathAclsResponse(rsp *http.Response) (*GetHiddenPathAclsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetCaResponse{
+	response := &GetHiddenPathAclsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest CA
+		var dest []HiddenPathACL
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest HiddenPathsNotAvailable
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.ApplicationproblemJSON404 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetCertificatesResponse parses an HTTP response from a GetCertificatesWithResponse call
-func ParseGetCertificatesResponse(rsp *http.Response) (*GetCertificatesResponse, error) {
+// ParseResetHiddenPathAclResponse parses an HTTP response from a ResetHiddenPathAclWithResponse call
+func ParseResetHiddenPathAclResponse(rsp *http.Response) (*ResetHiddenPathAclResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetCertificatesResponse{
+	response := &ResetHiddenPathAclResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []ChainBrief
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Problem
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.ApplicationproblemJSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Problem
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.ApplicationproblemJSON400 = &dest
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetCertificateResponse parses an HTTP response from a GetCertificateWithResponse call
-func ParseGetCertificateResponse(rsp *http.Response) (*GetCertificateResponse, error) {
+// ParseSetHiddenPathAclResponse parses an HTTP response from a SetHiddenPathAclWithResponse call
+func ParseSetHiddenPathAclResponse(rsp *http.Response) (*SetHiddenPathAclResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetCertificateResponse{
+	response := &SetHiddenPathAclResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Chain
+		var dest HiddenPathACL
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2504,51 +4045,72 @@ func ParseGetCertificateResponse(rsp *http.Response) (*GetCertificateResponse, e
 		}
 		response.ApplicationproblemJSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetCertificateBlobResponse parses an HTTP response from a GetCertificateBlobWithResponse call
-func ParseGetCertificateBlobResponse(rsp *http.Response) (*GetCertificateBlobResponse, error) {
+// ParseGetInfoResponse parses an HTTP response from a GetInfoWithResponse call
+func ParseGetInfoResponse(rsp *http.Response) (*GetInfoResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetCertificateBlobResponse{
+	response := &GetInfoResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Problem
+		var dest BadRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.ApplicationproblemJSON400 = &dest
+		response.JSON400 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetConfigResponse parses an HTTP response from a GetConfigWithResponse call
-func ParseGetConfigResponse(rsp *http.Response) (*GetConfigResponse, error) {
+// ParseGetLogLevelResponse parses an HTTP response from a GetLogLevelWithResponse call
+func ParseGetLogLevelResponse(rsp *http.Response) (*GetLogLevelResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetConfigResponse{
+	response := &GetLogLevelResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LogLevel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest BadRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -2561,22 +4123,22 @@ func ParseGetConfigResponse(rsp *http.Response) (*GetConfigResponse, error) {
 	return response, nil
 }
 
-// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
-func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+// ParseSetLogLevelResponse parses an HTTP response from a SetLogLevelWithResponse call
+func ParseSetLogLevelResponse(rsp *http.Response) (*SetLogLevelResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetHealthResponse{
+	response := &SetLogLevelResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest HealthResponse
+		var dest LogLevel
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2594,92 +4156,118 @@ func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
 	return response, nil
 }
 
-// ParseGetInfoResponse parses an HTTP response from a GetInfoWithResponse call
-func ParseGetInfoResponse(rsp *http.Response) (*GetInfoResponse, error) {
+// ParseFlushLookupCacheResponse parses an HTTP response from a FlushLookupCacheWithResponse call
+func ParseFlushLookupCacheResponse(rsp *http.Response) (*FlushLookupCacheResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetInfoResponse{
+	response := &FlushLookupCacheResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+		var dest Problem
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetLogLevelResponse parses an HTTP response from a GetLogLevelWithResponse call
-func ParseGetLogLevelResponse(rsp *http.Response) (*GetLogLevelResponse, error) {
+// ParseGetLookupCacheResponse parses an HTTP response from a GetLookupCacheWithResponse call
+func ParseGetLookupCacheResponse(rsp *http.Response) (*GetLookupCacheResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetLogLevelResponse{
+	response := &GetLookupCacheResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest LogLevel
+		var dest []LookupCacheEntry
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest LookupCacheNotAvailable
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.ApplicationproblemJSON404 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseSetLogLevelResponse parses an HTTP response from a SetLogLevelWithResponse call
-func ParseSetLogLevelResponse(rsp *http.Response) (*SetLogLevelResponse, error) {
+// ParsePostOriginationResponse parses an HTTP response from a PostOriginationWithResponse call
+func ParsePostOriginationResponse(rsp *http.Response) (*PostOriginationResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &SetLogLevelResponse{
+	response := &PostOriginationResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest LogLevel
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest AdminNotAvailable
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.ApplicationproblemJSON404 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+	}
+
+	return response, nil
+}
+
+// ParsePostRegistrationResponse parses an HTTP response from a PostRegistrationWithResponse call
+func ParsePostRegistrationResponse(rsp *http.Response) (*PostRegistrationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostRegistrationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest AdminNotAvailable
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.ApplicationproblemJSON404 = &dest
 
 	}
 
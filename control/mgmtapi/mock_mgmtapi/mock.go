@@ -65,6 +65,20 @@ func (mr *MockBeaconStoreMockRecorder) GetBeacons(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeacons", reflect.TypeOf((*MockBeaconStore)(nil).GetBeacons), arg0, arg1)
 }
 
+// MarkBeaconUnusable mocks base method.
+func (m *MockBeaconStore) MarkBeaconUnusable(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBeaconUnusable", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkBeaconUnusable indicates an expected call of MarkBeaconUnusable.
+func (mr *MockBeaconStoreMockRecorder) MarkBeaconUnusable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBeaconUnusable", reflect.TypeOf((*MockBeaconStore)(nil).MarkBeaconUnusable), arg0, arg1)
+}
+
 // MockHealther is a mock of Healther interface.
 type MockHealther struct {
 	ctrl     *gomock.Controller
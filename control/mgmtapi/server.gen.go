@@ -25,6 +25,9 @@ type ServerInterface interface {
 	// Get the SCION beacon blob
 	// (GET /beacons/{segment-id}/blob)
 	GetBeaconBlob(w http.ResponseWriter, r *http.Request, segmentId SegmentID)
+	// Mark the SCION beacon unusable
+	// (DELETE /beacons/{segment-id}/usages)
+	DeleteBeaconUsages(w http.ResponseWriter, r *http.Request, segmentId SegmentID)
 	// Information about the CA.
 	// (GET /ca)
 	GetCa(w http.ResponseWriter, r *http.Request)
@@ -40,9 +43,33 @@ type ServerInterface interface {
 	// Prints the TOML configuration file.
 	// (GET /config)
 	GetConfig(w http.ResponseWriter, r *http.Request)
+	// List the epochs of the DRKey secret values
+	// (GET /drkey/secret_values)
+	GetDrkeySecretValues(w http.ResponseWriter, r *http.Request)
+	// Rotate the DRKey secret values
+	// (POST /drkey/secret_values/rotation)
+	PostDrkeySecretValuesRotation(w http.ResponseWriter, r *http.Request)
+	// List the TRC grace periods
+	// (GET /grace_periods)
+	GetGracePeriods(w http.ResponseWriter, r *http.Request)
+	// Accept the predecessor TRC
+	// (DELETE /grace_periods/{isd})
+	AcceptGracePeriod(w http.ResponseWriter, r *http.Request, isd int)
+	// Refuse the predecessor TRC
+	// (PUT /grace_periods/{isd})
+	RefuseGracePeriod(w http.ResponseWriter, r *http.Request, isd int)
 	// Indicate the service health.
 	// (GET /health)
 	GetHealth(w http.ResponseWriter, r *http.Request)
+	// List the ACLs of the hidden path groups
+	// (GET /hidden_paths/acls)
+	GetHiddenPathAcls(w http.ResponseWriter, r *http.Request)
+	// Reset the ACL of a hidden path group
+	// (DELETE /hidden_paths/acls/{group-id})
+	ResetHiddenPathAcl(w http.ResponseWriter, r *http.Request, groupId string)
+	// Set the ACL of a hidden path group
+	// (PUT /hidden_paths/acls/{group-id})
+	SetHiddenPathAcl(w http.ResponseWriter, r *http.Request, groupId string)
 	// Basic information page about the control service process.
 	// (GET /info)
 	GetInfo(w http.ResponseWriter, r *http.Request)
@@ -52,6 +79,18 @@ type ServerInterface interface {
 	// Set logging level
 	// (PUT /log/level)
 	SetLogLevel(w http.ResponseWriter, r *http.Request)
+	// Flush the cached segment lookups
+	// (DELETE /lookup_cache)
+	FlushLookupCache(w http.ResponseWriter, r *http.Request, params FlushLookupCacheParams)
+	// List the cached segment lookups
+	// (GET /lookup_cache)
+	GetLookupCache(w http.ResponseWriter, r *http.Request)
+	// Originate and propagate the SCION beacons
+	// (POST /origination)
+	PostOrigination(w http.ResponseWriter, r *http.Request)
+	// Register the path segments
+	// (POST /registration)
+	PostRegistration(w http.ResponseWriter, r *http.Request)
 	// List the SCION path segments
 	// (GET /segments)
 	GetSegments(w http.ResponseWriter, r *http.Request, params GetSegmentsParams)
@@ -112,6 +151,12 @@ func (_ Unimplemented) GetBeaconBlob(w http.ResponseWriter, r *http.Request, seg
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Mark the SCION beacon unusable
+// (DELETE /beacons/{segment-id}/usages)
+func (_ Unimplemented) DeleteBeaconUsages(w http.ResponseWriter, r *http.Request, segmentId SegmentID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Information about the CA.
 // (GET /ca)
 func (_ Unimplemented) GetCa(w http.ResponseWriter, r *http.Request) {
@@ -142,12 +187,60 @@ func (_ Unimplemented) GetConfig(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List the epochs of the DRKey secret values
+// (GET /drkey/secret_values)
+func (_ Unimplemented) GetDrkeySecretValues(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rotate the DRKey secret values
+// (POST /drkey/secret_values/rotation)
+func (_ Unimplemented) PostDrkeySecretValuesRotation(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the TRC grace periods
+// (GET /grace_periods)
+func (_ Unimplemented) GetGracePeriods(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Accept the predecessor TRC
+// (DELETE /grace_periods/{isd})
+func (_ Unimplemented) AcceptGracePeriod(w http.ResponseWriter, r *http.Request, isd int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refuse the predecessor TRC
+// (PUT /grace_periods/{isd})
+func (_ Unimplemented) RefuseGracePeriod(w http.ResponseWriter, r *http.Request, isd int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Indicate the service health.
 // (GET /health)
 func (_ Unimplemented) GetHealth(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List the ACLs of the hidden path groups
+// (GET /hidden_paths/acls)
+func (_ Unimplemented) GetHiddenPathAcls(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset the ACL of a hidden path group
+// (DELETE /hidden_paths/acls/{group-id})
+func (_ Unimplemented) ResetHiddenPathAcl(w http.ResponseWriter, r *http.Request, groupId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set the ACL of a hidden path group
+// (PUT /hidden_paths/acls/{group-id})
+func (_ Unimplemented) SetHiddenPathAcl(w http.ResponseWriter, r *http.Request, groupId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Basic information page about the control service process.
 // (GET /info)
 func (_ Unimplemented) GetInfo(w http.ResponseWriter, r *http.Request) {
@@ -166,6 +259,30 @@ func (_ Unimplemented) SetLogLevel(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Flush the cached segment lookups
+// (DELETE /lookup_cache)
+func (_ Unimplemented) FlushLookupCache(w http.ResponseWriter, r *http.Request, params FlushLookupCacheParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the cached segment lookups
+// (GET /lookup_cache)
+func (_ Unimplemented) GetLookupCache(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Originate and propagate the SCION beacons
+// (POST /origination)
+func (_ Unimplemented) PostOrigination(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register the path segments
+// (POST /registration)
+func (_ Unimplemented) PostRegistration(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List the SCION path segments
 // (GET /segments)
 func (_ Unimplemented) GetSegments(w http.ResponseWriter, r *http.Request, params GetSegmentsParams) {
@@ -276,6 +393,14 @@ func (siw *ServerInterfaceWrapper) GetBeacons(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// ------------- Optional query parameter "max_age" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_age", r.URL.Query(), &params.MaxAge)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_age", Err: err})
+		return
+	}
+
 	// ------------- Optional query parameter "all" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "all", r.URL.Query(), &params.All)
@@ -389,6 +514,32 @@ func (siw *ServerInterfaceWrapper) GetBeaconBlob(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// DeleteBeaconUsages operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBeaconUsages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "segment-id" -------------
+	var segmentId SegmentID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "segment-id", chi.URLParam(r, "segment-id"), &segmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "segment-id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBeaconUsages(w, r, segmentId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetCa operation middleware
 func (siw *ServerInterfaceWrapper) GetCa(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -515,6 +666,103 @@ func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetDrkeySecretValues operation middleware
+func (siw *ServerInterfaceWrapper) GetDrkeySecretValues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDrkeySecretValues(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostDrkeySecretValuesRotation operation middleware
+func (siw *ServerInterfaceWrapper) PostDrkeySecretValuesRotation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDrkeySecretValuesRotation(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetGracePeriods operation middleware
+func (siw *ServerInterfaceWrapper) GetGracePeriods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGracePeriods(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AcceptGracePeriod operation middleware
+func (siw *ServerInterfaceWrapper) AcceptGracePeriod(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd" -------------
+	var isd int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "isd", chi.URLParam(r, "isd"), &isd, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isd", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AcceptGracePeriod(w, r, isd)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RefuseGracePeriod operation middleware
+func (siw *ServerInterfaceWrapper) RefuseGracePeriod(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd" -------------
+	var isd int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "isd", chi.URLParam(r, "isd"), &isd, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isd", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefuseGracePeriod(w, r, isd)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetHealth operation middleware
 func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -530,6 +778,73 @@ func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetHiddenPathAcls operation middleware
+func (siw *ServerInterfaceWrapper) GetHiddenPathAcls(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHiddenPathAcls(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ResetHiddenPathAcl operation middleware
+func (siw *ServerInterfaceWrapper) ResetHiddenPathAcl(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "group-id" -------------
+	var groupId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group-id", chi.URLParam(r, "group-id"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group-id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetHiddenPathAcl(w, r, groupId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetHiddenPathAcl operation middleware
+func (siw *ServerInterfaceWrapper) SetHiddenPathAcl(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "group-id" -------------
+	var groupId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group-id", chi.URLParam(r, "group-id"), &groupId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group-id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetHiddenPathAcl(w, r, groupId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetInfo operation middleware
 func (siw *ServerInterfaceWrapper) GetInfo(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -575,6 +890,87 @@ func (siw *ServerInterfaceWrapper) SetLogLevel(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// FlushLookupCache operation middleware
+func (siw *ServerInterfaceWrapper) FlushLookupCache(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params FlushLookupCacheParams
+
+	// ------------- Optional query parameter "src" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "src", r.URL.Query(), &params.Src)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "src", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "dst" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dst", r.URL.Query(), &params.Dst)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dst", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FlushLookupCache(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetLookupCache operation middleware
+func (siw *ServerInterfaceWrapper) GetLookupCache(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLookupCache(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostOrigination operation middleware
+func (siw *ServerInterfaceWrapper) PostOrigination(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostOrigination(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostRegistration operation middleware
+func (siw *ServerInterfaceWrapper) PostRegistration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRegistration(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetSegments operation middleware
 func (siw *ServerInterfaceWrapper) GetSegments(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -983,6 +1379,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/beacons/{segment-id}/blob", wrapper.GetBeaconBlob)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/beacons/{segment-id}/usages", wrapper.DeleteBeaconUsages)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/ca", wrapper.GetCa)
 	})
@@ -998,9 +1397,33 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/config", wrapper.GetConfig)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/drkey/secret_values", wrapper.GetDrkeySecretValues)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/drkey/secret_values/rotation", wrapper.PostDrkeySecretValuesRotation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/grace_periods", wrapper.GetGracePeriods)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/grace_periods/{isd}", wrapper.AcceptGracePeriod)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/grace_periods/{isd}", wrapper.RefuseGracePeriod)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/health", wrapper.GetHealth)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/hidden_paths/acls", wrapper.GetHiddenPathAcls)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/hidden_paths/acls/{group-id}", wrapper.ResetHiddenPathAcl)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/hidden_paths/acls/{group-id}", wrapper.SetHiddenPathAcl)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/info", wrapper.GetInfo)
 	})
@@ -1010,6 +1433,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/log/level", wrapper.SetLogLevel)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/lookup_cache", wrapper.FlushLookupCache)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/lookup_cache", wrapper.GetLookupCache)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/origination", wrapper.PostOrigination)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/registration", wrapper.PostRegistration)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/segments", wrapper.GetSegments)
 	})
@@ -18,85 +18,119 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x8bXPbNrbwX8Fw90M7K8myY29rzTwfFNlp9WyTeGx1d6ZNrgORRyIaCGAB0Laur/77",
-	"HbyQAklQouwkze5tJh8sCjw472840GMU81XGGTAlo9FjJEBmnEkwH17i5Bp+z0Eq/SnmTAEzf+IsoyTG",
-	"inB29JvkTD+TcQorrP/6q4BFNIr+crQFfWS/lUc3CrMEi+RSCC6izWbTixKQsSCZBhaN9J5IuE03vWjK",
-	"FAiG6ZdDoNgR3YC4A4GKhT23geUM4Njuiil9u4hGv+7ZFZYrjfqm9xhlgmcgFLE8JmwpQMpbordd4Bj0",
-	"wzpGZgkqlyC+QCoFNDdYDKJepNYZRKNIr1iC0IzLJV7aHXbhZen42a7VNGrWEwFJNPq1ANEL4Pi+3JLP",
-	"f4NYRRv9hCiqH91Mpm/foAyrtC8t3SjmTCqRx5oih7ZG0m7/A6hrp3b/38myyqN5ye39tDSocC83MS62",
-	"N9Rr4MDylaE7uxWwJFIJo2BRL0r4Pas/i7mA+jONNl7aTx5DxpTye0iQ3Q8ZvnpSk0oQtqwhZJVDweoQ",
-	"GWoYxaY/Eam0omC3+dzbXHq7YyHwOupFOSO/5zC1OyqRw6YXTcZNYcQg1O0dpiQhar0Pt38W6za9KOOU",
-	"xHvfuLKrtLnlVlD7DDov5eneuP0I61uSdHzxH7CeXjS0pti8AbSko1fjREjBJpptC+2ooMnIhEhF2DIn",
-	"MoXkluGVWdPQCSKTW7xXCaYyGcs6DzBdcqPYD3iVGaW4nFzcjEOa9xzW9aLD1aHG7gAvSso98AHyGqh7",
-	"duexH/kuNSSpFJOA5yFS5iD2keWLubviVt5qVT+HQQtVsUa7E20vBdGoNAjcK2vzthVzN27UVbHz+mdr",
-	"kTHPBus8wB4XDT9Q/CReTi+qVrXAZy/w8BRHvWjBxQqraBSl8NB35rVLdNMEmH5k8GxY5SSF+GPAc2CF",
-	"94sN4o8XeqHJcBQmtJlZjJOE6D8xRYRZ1IlNKLbEhfAqnFUV2hu8MqlJCpiqFMUagyosIwgkyZKBQPgO",
-	"E4rnFEI7CMAuFajucW2eowUXFj5aYEJzAftxlgqrXHZID/WqumY5j+Rg9KwEPG360ZI8KUgO6E0hDp0z",
-	"lmy/8uSqY+4W4isBoMlcoe1qpLc1tOvsr87mxp4WqUAE12/IJm+LjMEHbDKFTmmI1dVNLa94LuNLjjuk",
-	"/TQzX62wWHsY28UIs8RDvoUtRcbZZE9asm0Xvo65dXzdyz6aIO5IXIqrZmdN7HgW8NJ+cVCq+elJKPE/",
-	"KF+oO9Ai4lYzfUfJFdY8dhl9yrMQ+hZuxTse9xeL4XA0HB0fD3X6hJUusKJR9F/v3iV/63/zK+4vhv3z",
-	"94/HvdPN6NvHk0310bf/o9f91XOj05uL/vhmj+/8iS9/gjugTW7S4nFN/flySdgS2a97ZTmQwDxfGp4s",
-	"dLoBph5877sb900NhRpvLdhQlnhVJsZ1O8WE3VKyAEVWVdFH352kw9VQ7t21BiO4veBzCqtAmGmLGijN",
-	"V5ghATjR/hvBQ0YxMzqNZAaxDnFIcaRSIhGP41wIYNuyNbMbIpVihYhEKdBskVP9BuUmNvqrtDUvyR0g",
-	"nBg74gyl/F4vzgSPAZIB+pcgSgFDhKFLtqREpuatEj/tMYEtCQMQsodymWNK14hxhWROFCRmBeMMKYhT",
-	"RmJMtS/5CCmnCQjrUfRqjR4l/w1JNdxMOGNga1vFjZOeYwlIczxBPFch9SRMKsxC5f4Y/Xw9RQIWYLlm",
-	"2VToujTMKbncyt0egsFygOZrEz/YEmG0ENjabglMIC6QzOd9XaxbiXniWWcwQK/xGs0B5RKSmoAE58pu",
-	"SmT5EmEWP56LGFDMk1pkPnILj+KSZ31jUX9R/COwvjalvhZc33Cvb7lXZlW5IP2SM7ujfJWpsxTQj7PZ",
-	"VREjNGZoCQwE1vKfrw3aXJAlYUjazo8NtLtUuELb2fBFL1rhB7LSfuPs/LwXrQizn46Hw5Cvdg6tqQEy",
-	"5UIrZxnhmoL5o5W+iGs/s52JnH2gKVzgnGoZ4jnP1WhOMfsY9brovu1M0HXdCHx+IM70Aqt9plH4oDy+",
-	"3ZEEEjS+mg7Q2yzjTpl9S7LeizB0/WrS/+774Xc9RIx3YkBUCgIJiPlqBSyx7851SVkgahiu+ZVxwpT+",
-	"Glsf2S/FkfA418Zn92FcoCXlcyMSS1+Z11XE3M14DjCRtvzKqmIoPhS9y0Z8gIeMuNbX6HGLQIIVGOsN",
-	"qUPKs+6dLZ0LBRLKDv0Ji7KtWimW6jbPNFpJd0T1c6nwKuv6SqgW3QLp+dyq4eS4EuyglvnWnrrUUdxS",
-	"5QNLbg/sIx3KZGBLmzTXkirzvLBER0xFq49DjlEqLNTts1LZJKqB6flsKDFutASezPtGV2B+epacniZ7",
-	"uwLu/T357I2pmpuyxfI2rrYZD2hVVU24Kjq7IdouQWRlXed87boX2uXNrieoaLBU3dXJ8OSkPzzuD09n",
-	"w/PR2fnoxYtffGbstj8Rd2hEzq4nVv/Mcna7FDiG2wwE4UkgCbie2EQGS6RELpXNYYjUft+8iuyrPUOZ",
-	"1liKFUhliIwxY1y9Y0U2VAEyeOepxpxzCrh5FFFxATW5lRSHafH7f5wpwSnSOTcUzRSvrAyqaOXUq+kf",
-	"isdVfpnVaAXSnC3s83hlYRTa3SVlRU2VYSmtESSwFDgxXnCBCdUPK7XVdmWt1+ISudKzmGwkeKpys+1D",
-	"1ru7zy6Vg+T63fGKS/j+HL08R6fnaHKCTl7p/+cTdHGBhhfoZIzOvkPjc3Rxib6/NF+doVcv0PAcHQ/R",
-	"xbFvODLDMST9qjOpUz27ngScRa5SLojOQu7gFssDjpnKyFAPx+Yg7NOAqqhf6Cyku0P4NM1k7+RhS2Yv",
-	"xMYq8p65atexJ4DMridPbs87gpvINwJbN0SsytbOX7GEW5av5jb+7A7dRCYdulQSBME0BPRFc3nT9KJe",
-	"Bak6vBr7Q4HVI5pnnPLlem9ntv7iPz0VqzKMcXWLF6pG2fMCooY5hwUX0AB6/ESg9Sb3doeeR4LHzIJi",
-	"Fyab3NxsXJ+sWdNeTcsKx6ZYRRxzhWTUjHBFiTm+mmpbBCEtrOFgODjWPOEZMJyRaBS9GAwHJ7a7mBoR",
-	"HNnzbvP3ElRLt3uLjVtuK04sAH1k/J4VVWLsMCrCDJqlgATInCqpEwNdDi4IVSC2zQSTfKLxTQ+RxgCH",
-	"Ti/MSXxtlAO9XCNXKfcQphTlzCQN5fm9NLgJULlgkGg8iERzSPEd4aLAJE4xW0KC7olKDfQPmNIPZtMP",
-	"xqPdYvUBZVjgFSgQpk2u1dekD9MkGkU/gHrp+Kd5Wiw0cy61LNFQ6TqyfFGgaTmEk8QQrvEiLKZ5Auie",
-	"0CTGIpHom+G3aM5VWurF9ObCIDm+8VpU1Zyy1kwmGoXfcxDaQ9tTqXrS320sqAzydfpe2xZOOUZh5ycK",
-	"sRWC2JL9ltF1U5mKt3XOTKl51QFyLQuqtfGeUKrlV4rXJ73bXMr7ME/KUZ5u3KiPBe2fSCJVZE/CaDQH",
-	"iXyMyt7Z38/OXpx53bNhKCQ0kvui1kZYofuUxGlDOkYUxgAGaLpAOZNgXIDrGpken0LaYWp/qesCneg7",
-	"IzMNphRLhBmCxQJihcjCWNb/W2Aq4UOj+DnuHx/3T85mxyejk+HobDg4O/mlRWcLq6zwo5sLb8rG2llB",
-	"s4AlFgnV4uILv5ozx2QC7AcNfdCCHKa0glfZyjN0h6qeOk7/SsH00BRHArQfB9clFgpxkYBA32AZAzON",
-	"6nnpAr9tw0hDfyZKY6UEmecK9H6Fulh/rrVEo2ZFbzQmB/TB9ysfbI9SFvHB+T+/sW4dxIIIaQ7LqtpR",
-	"qQSDTowLFaaw3jsqSqoKSL/xVPOHtdd3zfaVSva+Vx0MPRkODxrIDI3zHTrg1qwXNsH0I3ymvcIqTrV2",
-	"VaL9QAM9tcSEMCiJPvJGYc0oqO3Mt6YRWgR4Kf35Q/1akZQcPbrWUp8kGytdCipwEnBhnjfgbyP7ktwB",
-	"KxtV04tmKLcgHA/3BPPZtkeHphfV3KRs3mnXqR8TluXKGQeR9shC23iKGcIemKKRrgknicmQMMoELMiD",
-	"8UE6IJbi8T21ZUrhf603XuTUpAvmO/8FXQ4kiBtXTgSi7jBXb2+tm9hDg+MTNF8rKBBwJOJY5Zj6fDT9",
-	"HHjIKE+gdCvGUnWK6RlqKcjIz6ZtydBxJNlvpEq1Nh5CEuMqAqZ32lQTN1jqGIZkHscg5SKndP00Fe9F",
-	"Z11eKaezqzbRorUho+iFk/MfbGD261Uzy7o9+PQB78hf/yCNn+fK6nR5VOVrW3VDeMCxomvEWbFxr0hK",
-	"TG5vUCGylhV+hYo5/GRD+uG58IB7rzjFymjNsx17oYKVLWr9k64u/mhO+by1Eg3upN/QxcHV5WsELOY6",
-	"Ndqh5y/1Bg1d/7dTk4d+Bqv+gtBak6Ov/728/GH6Bl2NZz+im8sfXl++mZnH75hhnOXDYDB4x8zjyzcX",
-	"obXRHiUykvo8yjO3MgpqTYw99WjIeIKjz2htk3HQtMoggt4W+DyfMdOtjSIzCGDYNBkPPMbEWfaRFHzZ",
-	"HpF0aOW4Eo6udUAnd9Cc721p8LxjOzo8oQaPTfgH6FUudGWz4gJ675h24XpxhqXUSQ4WisQ5xcINBhBb",
-	"aG0rVIP0Fsd3zCFZFqoISxt2BmiMXDlT4FPONZiySgcHnUu9Yz7PerX6z2ZHtn2nP99h4o7uTMLT1Dyf",
-	"/w3/Eqzxn9x4+eSFcZditlEpPjeudRyWLUfym2VNaxHT1GbPIFsQdCMjfzvMJRQzgcH7cVYxxe5yKIDr",
-	"fgs/ejRLi6poZ7Rsju7rugC7isjN6e/X6halrgbJAqsnh8jyEsVnTZvsJZaAzBr3Dr46vWmV6mFa0y3R",
-	"aqqOybDsmb5OuHSFKG0K9iSlCmdjX5NidUi0JpfXs+mr6WQ8u3S50/jGV6RqqtVcvRPUZHwIqKiDStcz",
-	"t69cr+vZYEW5OVuQ5c6E0K7YK3IFD+ooo+5uWyPqlcHyC2V/V4IwZSvi2dvXPyFLaO4a0lobK3kgX63K",
-	"BHl7KyNo2lcCpC6pvYsx1ckQhClny23jDB4gzhUkzdsuDWa7qx6f0XHXrqSE5LHjFsknSMoTUo51y8pO",
-	"vjyKuy1GHsUpb5uGTu2diH8v/XyJJYl95qIML8ErVGpVgr2CIGWr1lK+PCqvm7Sxqryp8hk1rNzji/FS",
-	"ez5au1LT4FEvyvIAU25qTDHwX/Jk/UX4UVwE8vffRubNf5SUbrpISWuy6xN1nqfwB2jbpioOn6bALEFg",
-	"JwRqI8VoymQGsXKN2oTckcRr6UuXyOlCHdmLTZCgOwL3QZd/U1B74PRDaMD5y88szECsCMMU7UDqpEDq",
-	"pBWpyrj0YSh9kSK6MvN+QBldOwusaOrg662oA9h6xuoe1az16QeNlQH4g48bizsjTzp98bf+vKeNVSfV",
-	"+cyx+tr/6ZPH4G0Jw8KvwZDKY8wvh0Hrj0e1n4763Ata9DMPSSv2tCPa/QecHx34W2DFr3K1nQlV9Lql",
-	"5vq6+gz7by91jxeHnFpWdmztpu3Svj9PMK+wSou7YE8/x6xI4qvuibXh26qk5Q24tkra3ZH7nC7D7vCl",
-	"O2YkeGw6vkF+G7S4o6/55Hcr+vammLvH1XbSarn71Aa66ZVX7b6lTW45OHG9/T9b1p9u2OCgHrPybr20",
-	"mVN5M+YzGlS5xx/RhHYUlOP84xtU8GV3N1qJuEMnxF0etX5uZu6KXnOu0MRve9vOBOA4NfcfDr5/0jKd",
-	"MEBvM3uTia7tVZLZ9aTsrjjHbG4kSAXYzAKYCXcPb84g3BCfaeq7hermcECll1DW+YHfxmj8jJQNy9oR",
-	"Rl/36X55n++ApoTbdk7BCOpTTiZreG1eQMTyiMjkkchk058/6lp205eP9jrdpmPy16baLRFgJuJOh6NW",
-	"Wdozup1XDEsNqcLUBHYDetwZpmVWN6ih242fs8SZXU9CWje7nnzCEUm9yZP065AKo03JiiqjSD5Mj8UU",
-	"G63a1/l4/k8NfGIiNrueuDzol9/G929/G//99ezyflrLmraroqCKfuL8qIQY0FV7Jfmu0IVc0GgUpUpl",
-	"o6Ojx5RLtRk9ZlyojbkULoh21PbXArlUtfs5PMbUPDY/eixqX78Ynp6daJt8X6LR+N2FOxBrZTqUAqj5",
-	"gSrFw93qehUcNU8DdkGbXF39Y4pWWBkF8sBZxjSBTUwWhMZXUwQP5a+BWGAuOfGxcklTACmWmJFI6ePk",
-	"Hd5vf90hANUdRG/eb/43AAD//y7RBnW/XgAA",
+	"H4sIAAAAAAAC/+x9a3PbuNXwX8Gw/dCdSrLsxLuNZ94Piuzsqk02HsvbnWmT14HIIxFrCmAB0LLqR//9",
+	"GVxIgiQoUb4mz26nM+tQBHBwbjg3HN4FIVumjAKVIji5CziIlFEB+h+jaEnoz0yObjBJ8CwB9TBkVAKV",
+	"6k+cpgkJsSSMHqSczRJY/vU3waj6TYQxLLH6688c5sFJ8KeDcqUD86s4ODejgs1m0wsiECEnqZouOAl+",
+	"Zgir9YmQXC+BRIw5REhAyEEiIlDI6JwsMg7RINj0grc4uoD/ZCDkFjj3g28qMY0wj844Z9wH5VscIW4X",
+	"3fSC04t/wPqlMKYXV3ihTCKganmDmJ9IFAE9xzIWLwXbZUwEGk1z6DDisFCUXSM2R5iuUaxhRCmWMVpw",
+	"lqUa8gmVwClOno+g+YpoCvwGOLIv9oL3jF1n6RiHMbwcEgElGgwUKjgUNiMickJvenYJLbxvAYdmXZwk",
+	"H+fByb93YAYWS7WJTe8uSDlLgUti1AChCw5CXBGFmjkO9Z7rWNOvoOIVRVYZA5ppKAZBL5DrFIKTQL2x",
+	"AI3RTOCFWWEbXGYfv5h31R6VuBEOUXDy73yKngfGz8WSbPYbhDLYqCdEKpIF0/Hk48+a2frC7FspEyF5",
+	"FmpVY8DWSkX/9SPIC6sZ/26pWcXRrMD27r00dmEHNyHuBc7u1eRAs6Xed3plBUizWtALIrai9Wch41B/",
+	"psDGC/MvByGjJGEriJBZD2m8OlQTkhO6qAFkmEPCch8aBpty0fdESC3/dvGZs7hwVsec43XQCzJK/pPB",
+	"xKwoeQabXjAeNYkRApdXNzghEZHrXbD9M39v0wtSlpBw54hz85YSt8wQapfSyQp62hFX17C+IlHHgf+A",
+	"9eS0wTX54o1Ji330apjwMdhYoW2uVBY0ERkRIQldZETEEF1RvNTvNHiCiOgK72SCiYhGoo4DnCyYGgi3",
+	"eJlqpjgbn05HPs57COp6wf7sUEO3BxfFzp3pPdtrgO7InYN+5KpUH6ViTDyahwiRAd+1LZfM3Rm3MqqV",
+	"/SwELbsKFdid9vaWE5h7NriT1nq0IXM3bNRZsfP7D+YiLZ4N1DkTO1jU+EDhvXA5Oa1K1Rwfv8LD1zjo",
+	"BXPGl1gGJ0EMt30rXttIN4mAqkfAy9VKqRzHEF57NAeWeDfZILw+VS9qS0dikjQti1EUEfUnThChBnRi",
+	"DIpycz64cmVVcyrwUpsmMeBExihUEFTn0oRAgiwocIQLK8+zAgdsTYHqGhf6OZozbuZHc0ySjMNumIXE",
+	"MhMdTFj1Vp2zrEayc/QMBRxu+slseZxv2cM3OTmUzVig/dyhqzpzyxnfcQC1zSUq30ZqWb13GUMDzY01",
+	"f+Q4hHPghEVNHlqoH69S/esV0KiJamUQS7IEhCVaxSSM9ap6HDLjENBIVDF/NDw67B8e9o+OLw+PTo6G",
+	"J8fDwfHRv1zJiLCEvprYRyUioopsHfps25RDBCEIwXYqo8uLsdFdHOaZAM82f41BxmBQ6kyMLi/GiAhk",
+	"xzkInjGWANb2q+RhRwDqmkpEgRle3U2vSZcSdIfffnSpoIw8Ba6XCQxnesw4xTaiiY7cbHS5S9O4ky1q",
+	"FNamZlw+VPoKsbNAu75GtlxivnYgNi8jTCMH+Ba05G5HEz1xgbZt8Frk1uG1g10wgd+QsJDZmrJtQlfE",
+	"FEbj9z7ZZVlqzbSmzE5Oc//Q4/a7sjqfD4cnw5PDw2H/+zezY584shvgXM+yXXBG4/dohQUSIJW64BnV",
+	"moNQIQFrDp0BoYtKUMknUBxwBFz4NzaagkAyxhIt8Vr76ihLkXUxRQ/NQJBIvwKIrSjwnv7T+mgEDFOo",
+	"RytOJPDubF2YKHW2thN1gdeAAbwA+KGr15iuYIoSqhKfFUq6nun4vVEfXlbZzpdjTcsmdz6AhgN02ZV2",
+	"ejxOVngtzDQyhiXK0odi9cVpWpDGv5JCQ442hY585b3IyFKPJ+AGoAol8frIdwDv5ZM2jz7j1VWjSXbX",
+	"irfyPaGYpT7wzbwulMFhv1Rm6kjFUgJXNPv/nz5Ff+3/5d+4Px/233y+O+y93px8d3e0qT767n/Ue392",
+	"TPXJ9LQ/mu6wz9+zxXu4gaSJzSR/XDtd2WKh9KD5uVeEnCKYZQuNkzlTj3Vc9LOrrO0vNRBquDXT+iIR",
+	"ToD1jEq+bkIcie6eGtymxAa9OpqNoBZFehw8ssmYS4AfFJotZ8CVdOioblQR1QKIVz4uFzzsiJG6vaLt",
+	"OoVQB7oK1lx3vgKWDUF7xfa8CKDVTTlM6FVC5qBRVBGMH47i4XIodnJObQ4fC+Wx8ybntHmXKM6WmCJ1",
+	"ICg/T5E/wdTmm1IIlSuMJENS5S9YGGacAy3D2zbEb5QgESiGJJ1niRqRMO1Du28pxbggN4BwpE0tRlHM",
+	"VurllLMQIBqgXzmREigiFJ3RRUJErEcV8CnPCuiCUAAueigTGU6Stc6piIxIiPQblFEkIYwpCXGChMTX",
+	"ELNEHXl6NvW2Ai8h/zVWTkmMMaMUTAxcMu3MzbAw0hIhlkmvQ0SFxNSXFhihXy4myj8BgzWDplxfGTOo",
+	"wHIrdnsIBosBmq21n0kXCKM5x4YXi8k4YhyJbNbXB4tk7gRIgTxAH/AazQApR6VGIM6YNIsSUQwi1MDH",
+	"Mh4CCllU8+Dz/M5BWOCsr7XinyS7BtpX6rCvCKfVQtQ32CsURsZJv8DM9mhAU2X8dHl5nrsRCjK0AAoc",
+	"K/rP1sa65GRBKBImi2Uc8m0sXNnb8fBVL1jiW7JUuv/4zZteoDKx+l+Hw6FPE1ld0eQAETOumLNwgpqE",
+	"eWmmz12fX+jWgI95oHY4x1miaIhnLJMnswTT66DXhfdNBiNZ14XAxQdiNFnn3KdTi7fSwdsNiSBCo/PJ",
+	"AH1MU2aZ2ZUko70IRRfvxv0f/jb8oYeI1k4UiHaHOIRsuQQambEzQBHkgGqEK3yljFCpfsZGR/YLckQs",
+	"zJTwmXUo42iRsJkmidlfEf+pkLmb8OwhIm0uuGFF3/kw1aUD/8RJBtNCumonlRYPuU/WiMLtXu+nnEkW",
+	"Ms9xZDL4+e9VjIlwme7EQTF1r9iI6+IbhWF5ydZR3ChsWNvcXdmDPJMgbqCsamp1s4hilnZPHypnwOMF",
+	"dUgCGZBNeC3BQl5lqQIr6g6oei4kXqZdh/gC/uUkFROrBpPFijdNXRhfO4L/dsctqRSg0dWeybp9kQx0",
+	"YYJSNa9CPy9ZTw+pMLg3jCok5vLqQb5cFNSm6bloKCBu5F3ujftG6mX2+jh6/TramXqx43c4dFOdmmjS",
+	"FoursJrL3SMfuM1bMgui8hVElubcma1tikidFyoYnWexGt7TUX942B++vhy+OTl+c/LqVXfXSfKwQ7a3",
+	"iKHr1+mVG6L2WFAXY2MFYoEkz4Q0BiAR6tB0swe9IqSTYAlC6k2GmFImP9EZeCYZfKKeqGGNJysqoEa3",
+	"Ysf+vbheGaOSswQphwXyjJUTtvWyaKX8qakf8sdVfOm30RKELuDYpfGKyIBvdXvm5kGFFAthhCCCBceR",
+	"1oIqX6YeVoIL5Zu1hJaoHWralPOWrkzLZG89hf7gWJF3u24JQkUl/O0NevsGvX6Dxkfo6J36/5sxOj1F",
+	"w1N0NELHP6DRG3R6hv52pn86Ru9eoeEbdDhEp4eu4IgUhxD1q8qkvuvLi7FHWWQyZpwoE+4GrrDYo5an",
+	"NSipq40eZ6oK+/kKTrorhMfJ2DvlHeU2ez40VoF3xFWpjh0HyOXF+N41EC1JvKh5sHUDZHLahEKFAq5M",
+	"tGp3BrSeJvWHaQVwghPfpJ54lzdD6QJVn6+Gft/B6myapSxhi/XO9Hd94D8dFqsijDJ5heeytrOHHYhq",
+	"zhnMGYfGpIf3nLSGV2eFnrMFB5n5ju0x2cTmZmMDxc2AwPmkcA+NiZWfY9YLD5onnP1FOb1KFoELM9dw",
+	"MBwc6hxgChSnJDgJXg2GgyMTXo81CQ5MUaH+ewGyJZtcQmNfN+465oCuKVvR3MUOLUT5MaOTQRxElkih",
+	"DAPlS89JIoGXkRhtfKLRtIdIvUq2Z2odtZWh/lstmkVv18jGGnqqRhJl1ESnowJKBSAHmXGqgoe6yHoG",
+	"Mb4hjOfghDGmC4jQikgT5/6Ck+SLXvKLVmtXWH5BKeZ4CXnGUfGwtiEmkcrkg3xrkdgLyhd1RXHNVNRb",
+	"tXkJnVHVwwyacBTp3Su4CA2TLAK0IkkUYh4J9Jfhd2jGZFwwx2R6qoFUReOF3FYNy1pKhSgQ/pMBV2ra",
+	"1P/ULf9uJdjFSV/f3wcTBCsKVjX1CtsjJ0S57Y8qktPgqHy0MpyTRA+1E9mgT6JYckWSBM3KWStb71YB",
+	"/NmPk6Jouhs26gXYu2u/SRXYIz8YzZJtF6Ii+vj98fGrYyf+OPSdCw0LP3e4yxRPnTqaFFoABmgyRxkV",
+	"oPWAjbuZ6AlSWtPUCQht7Vsh0yG6GAuEKYL5HEKJyFxL1v+b40TAl675Ix9icqms4KObHm8wrMGiq1wa",
+	"eNBR4pDRSPQQGcDApLUt/k2eTBATwYQ1WgEHFHLwxJpH02rC6vvh0L/BJb69Mp6EQ++96Dux+iPfA4cF",
+	"5lECQguj46rqGhsO5h9qL4MWpOMkqcBTBHk1PX0uXWuxCUMc1CEFNn/AJWI8Ao7+gkUIVKcwZoVq/64N",
+	"IjX7A0EaScnJLJOg1svFwBxWmBvQDEuDCQOiL66+/GKi1yI//Kxed1MuhqXmhAudCq9yfcXN9SpnxqV/",
+	"h/XAWO4vVqZ0o2o1PV8bvu12SCE8n3vV229Hw+Fe1458F0L2vSLhL7do2lb+grgllmGsuKtiyuiLVK+H",
+	"wzYIik0fOBfoNro4WudsWm0kRQK8EO4NFjUst7gO7mzcrE+ijaFuAtKTIzrVzxvzlxaLypnSIgo3Oc3N",
+	"Lg0qWmZCqoPSOmP/zW0djP7+66Wai2uhvAZqgiSOKbTtgmHTDjJwWkLtsIQuyyinU+1mN2Z/0OeOekxo",
+	"mtlAvDpndMZMwRxjirAzTZ7HUdglkbYxVcwe5uTWmJBJUvKAe8wZzOeHlznKVJpa2Vr6N3eAcqgixPQ5",
+	"SDhKbD2IWt6oEGJyVodHaLaWkANgt4hDmeHEAdpExFRenUVQ6C6tDpSR7miDglsC1x8xTlfH231uKFrI",
+	"tUmcEK2PPPL9usmLhro5wpDIwhCEmGdJsr6fHKkhr3cPaV613fSC4y6LFVckqyLbIlQ+me35HaMfjT3k",
+	"xgpMlqjI2LsTb3EbXkhWZpk00lDkWF0+rS4ItziUyRoxmi/cy21BIuwTU2rtGuNfIUsPH+2mrP/io+f0",
+	"qejsStnwg8+dnAUrS9RiV11PoINZwmatUQDvSmqEMo/Pzz4goCFTltsWPn+rFmjw+jfHJrf9FJb9OUlq",
+	"Aaa++t/bsx8nP6Pz0eVPaHr244ezny/1409UI87gYTAYfKL68dnPp753gx1MpCn1NMwzMzTqzjXlJeU2",
+	"8+UCluwGTICmEg/oZsz0kGC2MKpSpJFf1IVIl1jk1bW5vV7qpGtIJVLV7ImawdYv9hDj5UOtuUIgN6Di",
+	"TJhYcDCisAKOCkP6KzCsfsmDE9+QebXE/BoilNFM38X/Ji2e2h6+YcvnA+bXTfnLN9Ym/CF2zoaGgh/j",
+	"4AmP2vHIe64WFEAfc3gerhUn5QGNdPmaRtV4NHAQE6bpNcnxUuamO8TQbdgsWSs/QFW6Nm6vtkTWP9Et",
+	"oXVfZN0EIwboXcaVulwyDr1PlFHQL6dYCCW8mEsSZgnmtpyNUE/htwPjJ2qBLIKDCAujQAZohGyoJYen",
+	"qMaTzFqGSkd8oi7OerXYlHGqymtxiutNzYTWGk3Oc/Hf0IreuOq9g92PHozsEmhrRLEeatR2vAVYXDhv",
+	"hlxaAyxNbnYE8rm6wEyoYUy+PVTjgXW3hB/c6VfziM1WU7mxgA4nYGvg2Fvou7m6hamrJ2UO1b3PyaJF",
+	"wJP6THoVH80at+q/Or5ppep+XNPNy2qyjnavTDGV8rZUYEkY/+teTOV3xb4mxurgZY3PLi4n7ybj0eWZ",
+	"dZxGU5eRqn5W8+2tU41H+0wVdGDputv2lfN13RWsMHdxb7TVIDRv7CS5hFt5kCa2c0vj1CsOy2ey/s45",
+	"odKEwy4/fnhfXHbW0yv7Cip2IFsuCwM54tewPjAu3JUpGt9tD0LKwrhwiPOkal5UqQrnK2XozjUHXYcu",
+	"0Cpmol6qbiLa3Hizsb28rXN76LJR1y5jWApIbuw43RrvVpeveiM5p2qXzjUBETyHNdK8l9DBKGnnkA5e",
+	"V7NRYYsZUaWgHlbFsMMvmkXa2eWAM1nUGKdMSF8qSJHVfz2h4BgNEiLLJUQES0jWHhtb++0gwxitCI3Y",
+	"yrCG3YwpwjAhC5vbNHNGVhJsTKbkWPszg7y5YoQA82T9QgGTcyaanHqRY7eL1z+tYFcTxp/ueKAH/5yt",
+	"EascE7IsMbe+ZoW6GNS4XKMM9uRstx67gwqcTE9zNeYUkZtacyJFrd7cFGAom8f4px7/WWfupaoOugGu",
+	"8vAOG9W6w5j69VVL+5hq65iGKnR68zyPFnQWfIj+ewHOu7wY1xguYThq8lvBFIoFXMK3+WYVXju4I2J7",
+	"Jv1XIuOI45Ut9JlnAieOQiwobx85/GifTKYqHp3pwAiWbdxX5TuSR5/9vZdeRkOOwhBSl4W7RJRVyaGp",
+	"HO6Y3zMVyO2Ow67LTfcI115Yqq4sqWmr0n7WrrQ+Tsqb/XoVnb6wu2+H5xcQ7VyKKtKdAhdEyKaAG7bz",
+	"qWKPgPeCNJMtFIa9ZBaZcm2/BJrr86p4Awwh9GWna1gbYVYo4WxJdHHj2C/wOhWuax6bR04J0AC5CCOi",
+	"RBMic4S1e1Elqj7oiHwhHWEQ/a3riMeLXlXO36Y0nFcZOrcd/tA/X5H+adEdfv2jDIyyiZzXij3nIIBK",
+	"t5lj9aIdwgmji1IC4RbCTEl8ozlfw760nemekKFrHfR8NuSWpnePkGqLSNFiRFRWcqMseSs+Qw/dHetK",
+	"X185wGHSwcG4RxcuwGHcbMTluB2ybNdftur3U7Hst6agfQ4/odp68KkjJW0fT2ix7Efj96K1uaFr5ruU",
+	"biP+wZ0etquA1lag2OVrHQ5de95tTpd3WAHug1fUQoOY57edVfnIix3YosZwnU7s9maT7oUH3dJP8bw5",
+	"3YsgZQy3RXZCZPM5ue148Oek63b6d2l1eS+HQXEEBwGytaTjhQL1L2UrNPUeESijuhrh27ELFFm72gT2",
+	"ZhNqb6XZrpdanZQ0wSHsoVHMQkZzaEBMt08Q9RdrqsfEsExl1lfuUEz/0E517aQp8JZF68cz6jwNXjeb",
+	"TX0bm6e0K6sGSFNA81P4D437u9S40wfoW2UH5jfn25LPqobvm0s9v8WChK6HhVJ99b2oQawVAJqemEK0",
+	"JqQTtjgoeti2oapof/uE6qBY49lwqYoaklqf3gaOipO7cUhVkPL4GnobPvLuwu76z6O0n59K0y5UMpys",
+	"Gupe6e6/Hf280NeSV9R8vbV22+aMrzCPylx3yLiNGjjOnJ3BV2prO6AqkyMCIYlpj/tCNta7JBOx0yp6",
+	"Z0sKA3zZk8KkxhQio3zTZQMA02fDbFiYYv/9W07w8OGdJk5LRO8Hu0OhXRs4bNmA7Qq91wY6uaLvLY/N",
+	"FQ233K78nZlHbZ90vJdpVNFAWli26AtHIdlf2q+DFhGu2hxlPb9dwzaFqB3nXtWEqVgBt8pA2QFYXCtt",
+	"6dNSvuBjVQ88feSx0aL+qYOPbR8dbav57k5lde6Yvh07SrI+2pdsV+D8VlpxAYqZLjZFawWxqzLLWbUy",
+	"JdHXRyXwG5wUSUvdegrNlKmqGCNLzaU1lBB6jfQXtrD5pbz/pf6lP4BIIG/BU/ISK3ZT6U30IhVcHx30",
+	"d1GfH7cgTnKyWAD3K9X71XBVWKyNCzo3hag0Jmpltos8e6HmdXuv7uQqd/6CjV6QuBfV5kxdCjucDTw1",
+	"Odvx3EY/9xMWXfqoVYnn76a2fxc1xXxgmoLVWgmjCRUphNLe+IzIDYmcRhTC3iNYMl08LDFJIEI3BFbe",
+	"g2VaYmOvhme+xsbP36bsEviSUJygLUAd5UAdtQJVaZP8UHPwSaqmnV7Xe9ziqrXJqXDq4Ou90OWBtvVk",
+	"z1+4fw8ed50tnXha7nJb0tzvGre79NNe4q4qqc6dcqrDftf9crxd0jUKvwZBevY4cX4lXbcsBY5Mn+0d",
+	"nXlc7O3jkXVt0FORpy2n3f+B3iX7ETLfd2tTmwpftxQHfV3X3HZ/taD7ebFPx5zKiq2XObdx3x/dc1RC",
+	"0UKC7t9Dp0KJr/pKZhu8rUxafPmiLdtjv43xlCrDrPDcFzaJt2vHaFop2M4/bKbw5Ibg+uYLEfb7DW2N",
+	"Pgx273t/Ww2ryX3LLW2DwbG9Wv7HjenHa3S11xVn6XS7bxOnoiP+EwpUscZL3IG2OyiCSKMpyvGy/TK0",
+	"5GGHSIitSjd67lJ/I+aCMYnGbn2TiUzoilxVs7533/mW5jjq63DmCwYqXqa8HnNJzbxsFTNE7vev9QUP",
+	"B25GwV+5fal23+2obvamCXo+P9/zQcHG59nNsawUYfB1N5cpvuOxR1DCLjtLzG3Cx2zaq+Zr0wI8FAdE",
+	"RPpmYX92p3zZTV/cmc9obDoaf22s3XICXPKwyT0PuTnj+7TIpuedU23w3tdxWuY0yOo2q++rJk/p4qib",
+	"H76048X4Edtztl8v2cFf+3gYbUyWexm58aFjLNrZaOW+zt1h/uDAexpilxdjawf967fR6uNvo+8/XJ6t",
+	"JjWrqXwr8LLoI9tHxYweXt3oTwfd5LyQ8SQ4CWIp05ODg7uYCbk5uUsZlxv9MShOlKLWqIqLPFbRul59",
+	"ZFY/ViVYjNd+fjV8fXykZPJzAUbje2s3wNdSRyg5JLozg2T+aHXdCw42vX1mG5+f/2OCllhqBnKmM4hp",
+	"TjbWVpD6Ek/eRkXZG2Yya5y4UFmjyQMUjXRHPuHC5NwyK7/q5pnVvNN5q7q1gzPcNHPoOtqtTHdhcMs2",
+	"N583/zsAnLjsmUmXAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
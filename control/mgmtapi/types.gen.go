@@ -114,6 +114,18 @@ type Check struct {
 // CheckData defines model for CheckData.
 type CheckData map[string]interface{}
 
+// GracePeriod defines model for GracePeriod.
+type GracePeriod struct {
+	// GracePeriodEnd The time at which the grace period ends.
+	GracePeriodEnd time.Time `json:"grace_period_end"`
+	Isd            int       `json:"isd"`
+	Predecessor    TRCID     `json:"predecessor"`
+
+	// Refused Whether the predecessor TRC is refused.
+	Refused bool  `json:"refused"`
+	Trc     TRCID `json:"trc"`
+}
+
 // Health defines model for Health.
 type Health struct {
 	// Checks List of health checks.
@@ -126,6 +138,30 @@ type HealthResponse struct {
 	Health Health `json:"health"`
 }
 
+// HiddenPathACL defines model for HiddenPathACL.
+type HiddenPathACL struct {
+	// GroupId The ID of the hidden path group.
+	GroupId string `json:"group_id"`
+
+	// Overridden Whether the ACL was set at runtime instead of being configured.
+	Overridden bool `json:"overridden"`
+
+	// Readers The ASes that may look up segments, besides the owner, the registries and the writers.
+	Readers []IsdAs `json:"readers"`
+
+	// Writers The ASes that may register segments.
+	Writers []IsdAs `json:"writers"`
+}
+
+// HiddenPathACLConfig defines model for HiddenPathACLConfig.
+type HiddenPathACLConfig struct {
+	// Readers The ASes that may look up segments. The owner, the registries and the writers may always look them up.
+	Readers *[]IsdAs `json:"readers,omitempty"`
+
+	// Writers The ASes that may register segments.
+	Writers *[]IsdAs `json:"writers,omitempty"`
+}
+
 // Hop defines model for Hop.
 type Hop struct {
 	Interface int   `json:"interface"`
@@ -144,6 +180,18 @@ type LogLevel struct {
 // LogLevelLevel Logging level
 type LogLevelLevel string
 
+// LookupCacheEntry defines model for LookupCacheEntry.
+type LookupCacheEntry struct {
+	Dst IsdAs `json:"dst"`
+
+	// Expiration The time at which the entry expires.
+	Expiration time.Time `json:"expiration"`
+
+	// Segments The number of cached segments.
+	Segments int   `json:"segments"`
+	Src      IsdAs `json:"src"`
+}
+
 // Policy defines model for Policy.
 type Policy struct {
 	ChainLifetime string `json:"chain_lifetime"`
@@ -167,6 +215,15 @@ type Problem struct {
 	Type *string `json:"type,omitempty"`
 }
 
+// SecretValueStatus defines model for SecretValueStatus.
+type SecretValueStatus struct {
+	Current Validity  `json:"current"`
+	Next    *Validity `json:"next,omitempty"`
+
+	// Protocol DRKey protocol.
+	Protocol string `json:"protocol"`
+}
+
 // Segment defines model for Segment.
 type Segment struct {
 	Expiration  time.Time `json:"expiration"`
@@ -249,12 +306,24 @@ type Validity struct {
 	NotBefore time.Time `json:"not_before"`
 }
 
+// AdminNotAvailable defines model for AdminNotAvailable.
+type AdminNotAvailable = Problem
+
 // BadRequest defines model for BadRequest.
 type BadRequest = StandardError
 
+// DRKeyNotAvailable defines model for DRKeyNotAvailable.
+type DRKeyNotAvailable = Problem
+
+// HiddenPathsNotAvailable defines model for HiddenPathsNotAvailable.
+type HiddenPathsNotAvailable = Problem
+
 // Internal defines model for Internal.
 type Internal = StandardError
 
+// LookupCacheNotAvailable defines model for LookupCacheNotAvailable.
+type LookupCacheNotAvailable = Problem
+
 // GetBeaconsParams defines parameters for GetBeacons.
 type GetBeaconsParams struct {
 	// StartIsdAs Start ISD-AS of beacons. The address can include wildcards (0) both for the ISD and AS identifier.
@@ -269,6 +338,9 @@ type GetBeaconsParams struct {
 	// ValidAt Timestamp at which returned beacons are valid. If unset then the current datetime is used. This only has an effect if `all=false`.
 	ValidAt *time.Time `form:"valid_at,omitempty" json:"valid_at,omitempty"`
 
+	// MaxAge Maximum age of the returned beacons in seconds, i.e., the maximum time since they were created by the origin AS.
+	MaxAge *int `form:"max_age,omitempty" json:"max_age,omitempty"`
+
 	// All Include beacons regardless of expiration and creation time.
 	All *bool `form:"all,omitempty" json:"all,omitempty"`
 
@@ -289,6 +361,15 @@ type GetCertificatesParams struct {
 	All     *bool      `form:"all,omitempty" json:"all,omitempty"`
 }
 
+// FlushLookupCacheParams defines parameters for FlushLookupCache.
+type FlushLookupCacheParams struct {
+	// Src Source ISD-AS of the removed lookups. If unset, all sources match.
+	Src *IsdAs `form:"src,omitempty" json:"src,omitempty"`
+
+	// Dst Destination ISD-AS of the removed lookups. If unset, all destinations match.
+	Dst *IsdAs `form:"dst,omitempty" json:"dst,omitempty"`
+}
+
 // GetSegmentsParams defines parameters for GetSegments.
 type GetSegmentsParams struct {
 	// StartIsdAs Start ISD-AS of segment.
@@ -304,5 +385,8 @@ type GetTrcsParams struct {
 	All *bool  `form:"all,omitempty" json:"all,omitempty"`
 }
 
+// SetHiddenPathAclJSONRequestBody defines body for SetHiddenPathAcl for application/json ContentType.
+type SetHiddenPathAclJSONRequestBody = HiddenPathACLConfig
+
 // SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
 type SetLogLevelJSONRequestBody = LogLevel
@@ -60,23 +60,30 @@ func InitTracer(tracing env.Tracing, id string) (io.Closer, error) {
 // eventually be moved here.
 type Metrics struct {
 	BeaconDBQueriesTotal                   *prometheus.CounterVec
+	BeaconingAnomaliesTotal                *prometheus.CounterVec
+	BeaconingLeader                        *prometheus.GaugeVec
 	BeaconingOriginatedTotal               *prometheus.CounterVec
 	BeaconingPropagatedTotal               *prometheus.CounterVec
 	BeaconingPropagatorInternalErrorsTotal *prometheus.CounterVec
 	BeaconingReceivedTotal                 *prometheus.CounterVec
 	BeaconingRegisteredTotal               *prometheus.CounterVec
 	BeaconingRegistrarInternalErrorsTotal  *prometheus.CounterVec
+	BeaconingVerificationCacheTotal        *prometheus.CounterVec
 	CAHealth                               *prometheus.GaugeVec
 	DiscoveryRequestsTotal                 *prometheus.CounterVec
 	PathDBQueriesTotal                     *prometheus.CounterVec
 	RenewalServerRequestsTotal             *prometheus.CounterVec
 	RenewalHandledRequestsTotal            *prometheus.CounterVec
 	RenewalRegisteredHandlers              *prometheus.GaugeVec
+	RevocationNotificationsTotal           *prometheus.CounterVec
+	SegmentLookupCacheTotal                *prometheus.CounterVec
 	SegmentLookupRequestsTotal             *prometheus.CounterVec
 	SegmentLookupSegmentsSentTotal         *prometheus.CounterVec
 	SegmentRegistrationsTotal              *prometheus.CounterVec
 	SegmentExpirationDeficient             *prometheus.GaugeVec
 	TrustDBQueriesTotal                    *prometheus.CounterVec
+	TrustExpirationTime                    *prometheus.GaugeVec
+	TrustExpiryAlerting                    *prometheus.GaugeVec
 	TrustLatestTRCNotBefore                prometheus.Gauge
 	TrustLatestTRCNotAfter                 prometheus.Gauge
 	TrustLatestTRCSerial                   prometheus.Gauge
@@ -137,6 +144,21 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"driver", "operation", prom.LabelResult},
 		),
+		BeaconingAnomaliesTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_beaconing_anomalous_beacons_total",
+				Help: "Total number of received beacons flagged by the anomaly detectors.",
+			},
+			[]string{"detector", "action", prom.LabelNeighIA},
+		),
+		BeaconingLeader: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "control_beaconing_leader",
+				Help: "Whether the instance is the leader that runs the beaconing tasks, " +
+					"if leader election is enabled.",
+			},
+			[]string{},
+		),
 		BeaconingOriginatedTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "control_beaconing_originated_beacons_total",
@@ -179,6 +201,14 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"seg_type"},
 		),
+		BeaconingVerificationCacheTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_beaconing_verification_cache_lookups_total",
+				Help: "Total number of lookups of verified beacon prefixes, by whether the " +
+					"whole beacon, a prefix of it or nothing was cached.",
+			},
+			[]string{prom.LabelResult},
+		),
 		CAHealth: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "renewal_ca_health_status",
@@ -224,6 +254,21 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"type"},
 		),
+		RevocationNotificationsTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_revocation_notifications_total",
+				Help: "Total number of revocations pushed to daemons and neighbor ASes.",
+			},
+			[]string{"recipient", prom.LabelResult},
+		),
+		SegmentLookupCacheTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "control_segment_lookup_cache_lookups_total",
+				Help: "Total number of lookups of cached path segment requests, by whether " +
+					"they were cached.",
+			},
+			[]string{prom.LabelResult},
+		),
 		SegmentLookupRequestsTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "control_segment_lookup_requests_total",
@@ -281,6 +326,21 @@ func NewMetrics() *Metrics {
 				Help: "The serial number of the latest TRC for the local ISD.",
 			},
 		),
+		TrustExpirationTime: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "trustengine_expiration_time_seconds",
+				Help: "The not_after time of the trust material of the local AS " +
+					"in seconds since UNIX epoch.",
+			},
+			[]string{"artifact"},
+		),
+		TrustExpiryAlerting: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "trustengine_expiry_alert_boolean",
+				Help: "Whether the trust material of the local AS is about to expire.",
+			},
+			[]string{"artifact"},
+		),
 		TrustTRCFileWritesTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "trustengine_trc_file_writes_total",
@@ -16,7 +16,10 @@ package control
 
 import (
 	"context"
+	"time"
 
+	"github.com/scionproto/scion/control/beaconing"
+	"github.com/scionproto/scion/control/revocation"
 	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/private/revcache"
@@ -26,15 +29,29 @@ import (
 // them into the
 type RevocationHandler struct {
 	RevCache revcache.RevCache
+	// Stability is notified of new revocations. It is optional.
+	Stability *beaconing.Stability
+	// Pusher pushes new revocations to the daemons and the neighbor ASes. It is optional.
+	Pusher *revocation.Pusher
 }
 
 func (h RevocationHandler) Revoke(ctx context.Context, revInfo *path_mgmt.RevInfo) error {
-	if _, err := h.RevCache.Insert(ctx, revInfo); err != nil {
+	inserted, err := h.RevCache.Insert(ctx, revInfo)
+	if err != nil {
 		return serrors.Wrap("inserting revocation", err,
 			"isd_as", revInfo.IA(),
 			"interface_id", revInfo.IfID,
 			"expiration", revInfo.Expiration())
 
 	}
+	if !inserted {
+		return nil
+	}
+	if h.Stability != nil {
+		h.Stability.Change(time.Now())
+	}
+	if h.Pusher != nil {
+		h.Pusher.Push(revInfo)
+	}
 	return nil
 }
@@ -0,0 +1,152 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package revocation pushes the revocations of interfaces to the daemons of the local AS and to
+// the control services of the neighbor ASes.
+//
+// The routers notify the control services of the local AS as soon as an interface goes down, and
+// the control services derive short-lived revocations from the notifications. Pushing them on
+// lets the end hosts stop using the paths over the interface within seconds, instead of waiting
+// for the path segments to expire. The neighbor ASes do not push the revocations of other ASes
+// any further than to their daemons.
+package revocation
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/prom"
+)
+
+// DefaultTimeout is the default timeout of a notification.
+const DefaultTimeout = 2 * time.Second
+
+const (
+	recipientDaemon   = "daemon"
+	recipientNeighbor = "neighbor"
+)
+
+// DaemonNotifier notifies a daemon about a revocation.
+type DaemonNotifier interface {
+	RevNotification(ctx context.Context, revInfo *path_mgmt.RevInfo) error
+}
+
+// NeighborNotifier notifies the control service of a neighbor AS about a revocation.
+type NeighborNotifier interface {
+	// NotifyNeighbor notifies the control service of the neighbor AS ia, which is reached over
+	// the egress interface.
+	NotifyNeighbor(ctx context.Context, ia addr.IA, egress uint16,
+		revInfo *path_mgmt.RevInfo) error
+}
+
+// Pusher pushes revocations to the daemons of the local AS and to the control services of the
+// neighbor ASes.
+type Pusher struct {
+	// IA is the ISD-AS of the local AS. Only the revocations of its interfaces are pushed to the
+	// neighbor ASes.
+	IA addr.IA
+	// Daemons are notified about all revocations.
+	Daemons []DaemonNotifier
+	// Neighbors notifies the neighbor ASes. If it is nil, they are not notified.
+	Neighbors NeighborNotifier
+	// AllInterfaces are the interfaces of the local AS. The neighbor ASes are notified over
+	// them.
+	AllInterfaces *ifstate.Interfaces
+	// Timeout is the timeout of a notification. If it is zero, DefaultTimeout is used.
+	Timeout time.Duration
+	// Notifications counts the notifications by recipient and result. It is optional.
+	Notifications metrics.Counter
+}
+
+// Push notifies the daemons and the neighbor ASes about the revocation in the background. It
+// does not block.
+func (p *Pusher) Push(revInfo *path_mgmt.RevInfo) {
+	go func() {
+		defer log.HandlePanic()
+		p.push(revInfo)
+	}()
+}
+
+func (p *Pusher) push(revInfo *path_mgmt.RevInfo) {
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	logger := log.FromCtx(ctx).New("isd_as", revInfo.IA(), "interface_id", revInfo.IfID)
+
+	for _, d := range p.Daemons {
+		err := d.RevNotification(ctx, revInfo)
+		if err != nil {
+			logger.Info("Failed to notify daemon about revocation", "err", err)
+		}
+		p.count(recipientDaemon, err)
+	}
+	if p.Neighbors == nil || revInfo.IA() != p.IA {
+		return
+	}
+	for ia, egress := range p.neighbors(uint16(revInfo.IfID)) {
+		err := p.Neighbors.NotifyNeighbor(ctx, ia, egress, revInfo)
+		if err != nil {
+			logger.Info("Failed to notify neighbor about revocation", "neighbor_isd_as", ia,
+				"egress", egress, "err", err)
+		}
+		p.count(recipientNeighbor, err)
+	}
+}
+
+// neighbors returns the neighbor ASes, except over the revoked interface, with the interface with
+// the lowest ID to reach each of them.
+func (p *Pusher) neighbors(revoked uint16) map[addr.IA]uint16 {
+	if p.AllInterfaces == nil {
+		return nil
+	}
+	all := p.AllInterfaces.All()
+	ids := make([]uint16, 0, len(all))
+	for id := range all {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	neighbors := make(map[addr.IA]uint16)
+	for _, id := range ids {
+		ia := all[id].TopoInfo().IA
+		if _, ok := neighbors[ia]; id == revoked || ia == 0 || ok {
+			continue
+		}
+		neighbors[ia] = id
+	}
+	return neighbors
+}
+
+func (p *Pusher) count(recipient string, err error) {
+	if p.Notifications == nil {
+		return
+	}
+	result := prom.Success
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		result = prom.ErrTimeout
+	case err != nil:
+		result = prom.ErrNetwork
+	}
+	p.Notifications.With("recipient", recipient, prom.LabelResult, result).Add(1)
+}
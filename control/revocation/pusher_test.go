@@ -0,0 +1,128 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/control/revocation"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/segment/iface"
+)
+
+type notification struct {
+	ia     addr.IA
+	egress uint16
+	rev    *path_mgmt.RevInfo
+}
+
+// recorder records the notifications of daemons and neighbors.
+type recorder struct {
+	mtx           sync.Mutex
+	notifications []notification
+	err           error
+}
+
+func (r *recorder) RevNotification(_ context.Context, revInfo *path_mgmt.RevInfo) error {
+	return r.NotifyNeighbor(context.Background(), 0, 0, revInfo)
+}
+
+func (r *recorder) NotifyNeighbor(_ context.Context, ia addr.IA, egress uint16,
+	revInfo *path_mgmt.RevInfo) error {
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.notifications = append(r.notifications, notification{ia: ia, egress: egress, rev: revInfo})
+	return r.err
+}
+
+func (r *recorder) recorded() []notification {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return append([]notification(nil), r.notifications...)
+}
+
+func TestPusherPush(t *testing.T) {
+	local := addr.MustParseIA("1-ff00:0:110")
+	neighbor1 := addr.MustParseIA("1-ff00:0:111")
+	neighbor2 := addr.MustParseIA("1-ff00:0:112")
+	intfs := ifstate.NewInterfaces(map[uint16]ifstate.InterfaceInfo{
+		1: {ID: 1, IA: neighbor1},
+		2: {ID: 2, IA: neighbor2},
+		3: {ID: 3, IA: neighbor2},
+		4: {ID: 4, IA: neighbor1},
+	}, ifstate.Config{})
+	newRev := func(ia addr.IA, ifID uint16) *path_mgmt.RevInfo {
+		return &path_mgmt.RevInfo{
+			IfID:         iface.ID(ifID),
+			RawIsdas:     ia,
+			RawTimestamp: util.TimeToSecs(time.Now()),
+			RawTTL:       10,
+		}
+	}
+
+	testCases := map[string]struct {
+		rev       *path_mgmt.RevInfo
+		neighbors []notification
+	}{
+		"local interface": {
+			rev: newRev(local, 1),
+			// Each neighbor is notified once, not over the revoked interface.
+			neighbors: []notification{
+				{ia: neighbor1, egress: 4},
+				{ia: neighbor2, egress: 2},
+			},
+		},
+		"remote interface": {
+			rev: newRev(neighbor1, 1),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			daemon1, daemon2 := &recorder{err: errors.New("unreachable")}, &recorder{}
+			neighbors := &recorder{}
+			p := &revocation.Pusher{
+				IA:            local,
+				Daemons:       []revocation.DaemonNotifier{daemon1, daemon2},
+				Neighbors:     neighbors,
+				AllInterfaces: intfs,
+			}
+			p.Push(tc.rev)
+
+			assert.Eventually(t, func() bool {
+				return len(daemon2.recorded()) == 1 &&
+					len(neighbors.recorded()) == len(tc.neighbors)
+			}, time.Second, 10*time.Millisecond)
+			// A failing daemon does not prevent the others from being notified.
+			assert.Len(t, daemon1.recorded(), 1)
+			assert.Same(t, tc.rev, daemon2.recorded()[0].rev)
+			var got []notification
+			for _, n := range neighbors.recorded() {
+				assert.Same(t, tc.rev, n.rev)
+				got = append(got, notification{ia: n.ia, egress: n.egress})
+			}
+			assert.ElementsMatch(t, tc.neighbors, got)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/control/drkey"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/slayers/path/empty"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/path"
+)
+
+// SCMPNotifier notifies the control services of the neighbor ASes with SCMP external interface
+// down messages, sent over one-hop paths to their anycast address. The control services handle
+// them like the notifications of the routers of their own AS.
+type SCMPNotifier struct {
+	// Conn is used to send the messages.
+	Conn snet.PacketConn
+	// Source is the address of the local control service.
+	Source snet.SCIONAddress
+	// NextHopper returns the router that owns an interface.
+	NextHopper interface {
+		UnderlayNextHop(uint16) *net.UDPAddr
+	}
+	// MAC is used to issue the hop fields of the one-hop paths.
+	MAC hash.Hash
+	// Auth authenticates the messages with DRKey. If it is nil, or the MAC cannot be computed,
+	// the messages are sent without MAC.
+	Auth *drkey.Authenticator
+
+	// macMtx protects the MAC.
+	macMtx sync.Mutex
+}
+
+// NotifyNeighbor sends an SCMP external interface down message for the revocation to the control
+// service of the neighbor AS, over the egress interface.
+func (n *SCMPNotifier) NotifyNeighbor(
+	ctx context.Context,
+	ia addr.IA,
+	egress uint16,
+	revInfo *path_mgmt.RevInfo,
+) error {
+
+	nextHop := n.NextHopper.UnderlayNextHop(egress)
+	if nextHop == nil {
+		return serrors.New("next hop not found", "egress", egress)
+	}
+	n.macMtx.Lock()
+	p, err := path.NewOneHop(egress, time.Now(), 63, n.MAC)
+	n.macMtx.Unlock()
+	if err != nil {
+		return serrors.Wrap("creating one-hop path", err)
+	}
+	down := snet.SCMPExternalInterfaceDown{
+		IA:        revInfo.IA(),
+		Interface: uint64(revInfo.IfID),
+	}
+	if n.Auth != nil {
+		now := time.Now()
+		mac, err := n.Auth.MAC(ctx, ia, scmpAuthLabel, now, scmpAuthMessage(down))
+		if err != nil {
+			log.FromCtx(ctx).Debug("Sending notification without DRKey MAC", "isd_as", ia,
+				"err", err)
+		} else {
+			down.Payload = binary.BigEndian.AppendUint64(nil, uint64(now.UnixMilli()))
+			down.Payload = append(down.Payload, mac...)
+		}
+	}
+	pkt := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Destination: snet.SCIONAddress{
+				IA:   ia,
+				Host: addr.HostSVC(addr.SvcCS),
+			},
+			Source:  n.Source,
+			Path:    p,
+			Payload: down,
+		},
+	}
+	if err := n.Conn.WriteTo(pkt, nextHop); err != nil {
+		return serrors.Wrap("sending notification", err, "isd_as", ia)
+	}
+	return nil
+}
+
+// scmpAuthLabel is the label of the DRKey MACs of the notifications.
+const scmpAuthLabel = "scmp_external_interface_down"
+
+// timestampLen is the length of the timestamp that precedes the DRKey MAC in the payload.
+const timestampLen = 8
+
+var (
+	errNoMAC         = serrors.New("notification has no DRKey MAC")
+	errNotFromRouter = serrors.New("local notification not sent by a router")
+	errNoAuth        = serrors.New("DRKey authentication is disabled")
+)
+
+// AuthHandler authenticates the external interface down and internal connectivity down messages
+// before passing them to Handler. All other SCMP messages are passed on as they are.
+//
+// The messages of the local AS are only accepted if they were sent by one of its routers, i.e.,
+// they have an empty path and the underlay address they were received from is the internal
+// address of a router. The messages of other ASes, which are sent by their control services, are
+// only accepted if they are external interface down messages with a valid DRKey MAC. If Required
+// is not set, messages without a MAC are accepted too. As the source ISD-AS of the messages is
+// not authenticated otherwise, these rules apply regardless of their destination.
+//
+// The underlay address is only known if the handler is called with HandleUnderlay.
+type AuthHandler struct {
+	// Handler handles the SCMP messages that are not dropped.
+	Handler snet.SCMPHandler
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+	// Routers returns the internal addresses of the routers of the local AS.
+	Routers func() []netip.Addr
+	// Auth verifies the MACs. If it is nil, the messages of other ASes are dropped.
+	Auth *drkey.Authenticator
+	// Required indicates whether messages without a MAC are dropped.
+	Required bool
+}
+
+func (h AuthHandler) Handle(pkt *snet.Packet) error {
+	return h.HandleUnderlay(pkt, nil)
+}
+
+func (h AuthHandler) HandleUnderlay(pkt *snet.Packet, underlay *net.UDPAddr) error {
+	switch pkt.Payload.(type) {
+	case snet.SCMPExternalInterfaceDown, snet.SCMPInternalConnectivityDown:
+	default:
+		return h.Handler.Handle(pkt)
+	}
+	var err error
+	down, isDown := pkt.Payload.(snet.SCMPExternalInterfaceDown)
+	switch {
+	case h.fromRouter(pkt, underlay):
+		return h.Handler.Handle(pkt)
+	case pkt.Source.IA == h.LocalIA || !isDown:
+		err = errNotFromRouter
+	case h.Auth == nil:
+		err = errNoAuth
+	default:
+		err = h.verify(pkt.Source.IA, down)
+		if err == nil || (errors.Is(err, errNoMAC) && !h.Required) {
+			return h.Handler.Handle(pkt)
+		}
+	}
+	log.Debug("Dropping unauthenticated interface down notification",
+		"src", pkt.Source, "underlay", underlay, "err", err)
+	return nil
+}
+
+// fromRouter returns whether the packet was sent by a router of the local AS.
+func (h AuthHandler) fromRouter(pkt *snet.Packet, underlay *net.UDPAddr) bool {
+	if underlay == nil || h.Routers == nil || pkt.Source.IA != h.LocalIA {
+		return false
+	}
+	if p, ok := pkt.Path.(snet.RawPath); !ok || p.PathType != empty.PathType {
+		return false
+	}
+	src := underlay.AddrPort().Addr().Unmap()
+	for _, r := range h.Routers() {
+		if r.Unmap() == src {
+			return true
+		}
+	}
+	return false
+}
+
+func (h AuthHandler) verify(remote addr.IA, down snet.SCMPExternalInterfaceDown) error {
+	if len(down.Payload) == 0 {
+		return errNoMAC
+	}
+	if len(down.Payload) <= timestampLen {
+		return drkey.ErrNotAuthenticated
+	}
+	ts := time.UnixMilli(int64(binary.BigEndian.Uint64(down.Payload[:timestampLen])))
+	mac := down.Payload[timestampLen:]
+	down.Payload = nil
+	return h.Auth.Verify(remote, scmpAuthLabel, ts, scmpAuthMessage(down), mac)
+}
+
+// scmpAuthMessage returns the part of the notification that is covered by the DRKey MAC, i.e.,
+// the ISD-AS and the ID of the interface.
+func scmpAuthMessage(down snet.SCMPExternalInterfaceDown) []byte {
+	msg := binary.BigEndian.AppendUint64(nil, uint64(down.IA))
+	return binary.BigEndian.AppendUint64(msg, down.Interface)
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation_test
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	csdrkey "github.com/scionproto/scion/control/drkey"
+	"github.com/scionproto/scion/control/revocation"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/slayers/path/empty"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+func TestSCMPAuthentication(t *testing.T) {
+	ia110 := addr.MustParseIA("1-ff00:0:110")
+	ia111 := addr.MustParseIA("1-ff00:0:111")
+	newAuth := func(ia addr.IA) *csdrkey.Authenticator {
+		auth := &csdrkey.Authenticator{LocalIA: ia}
+		auth.SetEngine(authEngine{})
+		return auth
+	}
+	mac, err := scrypto.InitMac(make([]byte, 16))
+	require.NoError(t, err)
+	revInfo := &path_mgmt.RevInfo{
+		RawIsdas:     ia111,
+		IfID:         2,
+		RawTimestamp: util.TimeToSecs(time.Now()),
+		RawTTL:       10,
+	}
+	// notify returns the notification, as received by the control service of AS 110.
+	notify := func(t *testing.T, auth *csdrkey.Authenticator) *snet.Packet {
+		conn := &packetRecorder{}
+		notifier := &revocation.SCMPNotifier{
+			Conn:       conn,
+			Source:     snet.SCIONAddress{IA: ia111, Host: addr.MustParseHost("10.0.0.1")},
+			NextHopper: nextHopper{},
+			MAC:        mac,
+			Auth:       auth,
+		}
+		err := notifier.NotifyNeighbor(context.Background(), ia110, 1, revInfo)
+		require.NoError(t, err)
+		require.NotNil(t, conn.pkt)
+		return conn.pkt
+	}
+	router := netip.MustParseAddr("10.0.0.2")
+	newHandler := func(h snet.SCMPHandler, auth *csdrkey.Authenticator,
+		required bool) revocation.AuthHandler {
+
+		return revocation.AuthHandler{
+			Handler:  h,
+			LocalIA:  ia110,
+			Routers:  func() []netip.Addr { return []netip.Addr{router} },
+			Auth:     auth,
+			Required: required,
+		}
+	}
+	handle := func(pkt *snet.Packet, required bool) bool {
+		h := &handledRecorder{}
+		_ = newHandler(h, newAuth(ia110), required).Handle(pkt)
+		return h.handled
+	}
+	// routerNotification returns a notification as sent by a router of AS 110.
+	routerNotification := func() *snet.Packet {
+		return &snet.Packet{
+			PacketInfo: snet.PacketInfo{
+				Destination: snet.SCIONAddress{IA: ia110, Host: addr.MustParseHost("10.0.0.3")},
+				Source:      snet.SCIONAddress{IA: ia110, Host: addr.HostIP(router)},
+				Path:        snet.RawPath{PathType: empty.PathType},
+				Payload:     snet.SCMPExternalInterfaceDown{IA: ia110, Interface: 1},
+			},
+		}
+	}
+	handleFrom := func(pkt *snet.Packet, auth *csdrkey.Authenticator, from netip.Addr) bool {
+		h := &handledRecorder{}
+		underlay := net.UDPAddrFromAddrPort(netip.AddrPortFrom(from, 30042))
+		_ = newHandler(h, auth, true).HandleUnderlay(pkt, underlay)
+		return h.handled
+	}
+
+	t.Run("authenticated", func(t *testing.T) {
+		pkt := notify(t, newAuth(ia111))
+		assert.True(t, handle(pkt, true))
+	})
+	t.Run("modified", func(t *testing.T) {
+		pkt := notify(t, newAuth(ia111))
+		down := pkt.Payload.(snet.SCMPExternalInterfaceDown)
+		down.Interface = 3
+		pkt.Payload = down
+		assert.False(t, handle(pkt, false))
+	})
+	t.Run("spoofed source", func(t *testing.T) {
+		pkt := notify(t, newAuth(ia111))
+		pkt.Source.IA = addr.MustParseIA("1-ff00:0:112")
+		assert.False(t, handle(pkt, false))
+	})
+	t.Run("without MAC", func(t *testing.T) {
+		pkt := notify(t, nil)
+		assert.True(t, handle(pkt, false))
+		assert.False(t, handle(pkt, true))
+	})
+	t.Run("spoofed local source", func(t *testing.T) {
+		pkt := notify(t, nil)
+		pkt.Source.IA = ia110
+		assert.False(t, handle(pkt, false))
+		assert.False(t, handleFrom(pkt, newAuth(ia110), router))
+	})
+	t.Run("local router", func(t *testing.T) {
+		assert.True(t, handleFrom(routerNotification(), newAuth(ia110), router))
+		assert.True(t, handleFrom(routerNotification(), nil, router))
+	})
+	t.Run("local host", func(t *testing.T) {
+		host := netip.MustParseAddr("10.0.0.4")
+		assert.False(t, handleFrom(routerNotification(), newAuth(ia110), host))
+		assert.False(t, handle(routerNotification(), false))
+	})
+	t.Run("without DRKey", func(t *testing.T) {
+		h := &handledRecorder{}
+		_ = newHandler(h, nil, false).Handle(notify(t, nil))
+		assert.False(t, h.handled)
+	})
+	t.Run("internal connectivity down", func(t *testing.T) {
+		pkt := notify(t, nil)
+		pkt.Payload = snet.SCMPInternalConnectivityDown{IA: ia111, Ingress: 1, Egress: 2}
+		assert.False(t, handle(pkt, false))
+	})
+	t.Run("other SCMP", func(t *testing.T) {
+		pkt := notify(t, nil)
+		pkt.Payload = snet.SCMPEchoReply{Identifier: 1}
+		assert.True(t, handle(pkt, true))
+	})
+}
+
+// packetRecorder records the last packet that is written.
+type packetRecorder struct {
+	snet.PacketConn
+	pkt *snet.Packet
+}
+
+func (c *packetRecorder) WriteTo(pkt *snet.Packet, _ *net.UDPAddr) error {
+	c.pkt = pkt
+	return nil
+}
+
+type nextHopper struct{}
+
+func (nextHopper) UnderlayNextHop(uint16) *net.UDPAddr {
+	return &net.UDPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 30042}
+}
+
+type handledRecorder struct {
+	handled bool
+}
+
+func (h *handledRecorder) Handle(*snet.Packet) error {
+	h.handled = true
+	return nil
+}
+
+type authEngine struct{}
+
+func (e authEngine) GetLevel1Key(
+	_ context.Context,
+	meta drkey.Level1Meta,
+) (drkey.Level1Key, error) {
+
+	return e.DeriveLevel1(meta)
+}
+
+func (authEngine) DeriveLevel1(meta drkey.Level1Meta) (drkey.Level1Key, error) {
+	key := drkey.Level1Key{SrcIA: meta.SrcIA, DstIA: meta.DstIA, ProtoId: meta.ProtoId}
+	binary.BigEndian.PutUint64(key.Key[:8], uint64(meta.SrcIA))
+	binary.BigEndian.PutUint64(key.Key[8:], uint64(meta.DstIA))
+	return key, nil
+}
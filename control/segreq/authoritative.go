@@ -16,6 +16,7 @@ package segreq
 
 import (
 	"context"
+	"slices"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -26,12 +27,16 @@ import (
 )
 
 // AuthoritativeLookup handles path segment lookup requests in a core AS. It
-// only returns down and core segments starting at this core AS. It should only
-// be used in a core AS.
+// returns core segments starting at this core AS, and down segments to the
+// destination, including those that non-core ASes registered at this core AS in
+// addition to the core AS they start at. It should only be used in a core AS.
 type AuthoritativeLookup struct {
-	LocalIA     addr.IA
-	CoreChecker CoreChecker
-	PathDB      pathdb.DB
+	LocalIA addr.IA
+	// AdditionalIAs are the ISD-ASes of the local AS in the additional ISDs it
+	// is a member of.
+	AdditionalIAs []addr.IA
+	CoreChecker   CoreChecker
+	PathDB        pathdb.DB
 }
 
 func (a AuthoritativeLookup) LookupSegments(ctx context.Context, src,
@@ -42,11 +47,12 @@ func (a AuthoritativeLookup) LookupSegments(ctx context.Context, src,
 		return nil, err
 	}
 
+	// The source is one of the ISD-ASes of the local AS, see classify.
 	switch segType {
 	case seg.TypeDown:
-		return getDownSegments(ctx, a.PathDB, a.LocalIA, dst)
+		return getDownSegments(ctx, a.PathDB, dst)
 	case seg.TypeCore:
-		return getCoreSegments(ctx, a.PathDB, a.LocalIA, dst)
+		return getCoreSegments(ctx, a.PathDB, src, dst)
 	default:
 		panic("unexpected segType")
 	}
@@ -57,7 +63,7 @@ func (a AuthoritativeLookup) classify(ctx context.Context,
 	src, dst addr.IA) (seg.Type, error) {
 
 	switch {
-	case src != a.LocalIA:
+	case !isLocalIA(src, a.LocalIA, a.AdditionalIAs):
 		return 0, serrors.JoinNoStack(segfetcher.ErrInvalidRequest, nil,
 			"src", src, "dst", dst, "reason", "src must be local AS")
 
@@ -65,7 +71,7 @@ func (a AuthoritativeLookup) classify(ctx context.Context,
 		return 0, serrors.JoinNoStack(segfetcher.ErrInvalidRequest, nil,
 			"src", src, "dst", dst, "reason", "zero ISD dst")
 
-	case dst.ISD() == a.LocalIA.ISD():
+	case dst.ISD() == src.ISD():
 		dstCore, err := a.CoreChecker.IsCore(ctx, dst)
 		if err != nil {
 			return 0, err
@@ -97,13 +103,14 @@ func getCoreSegments(ctx context.Context, pathDB pathdb.DB,
 	return res.SegMetas(), nil
 }
 
-// getDownSegments loads down segments from localIA to dstIA from the path DB.
-// Wildcard dstIA is _not_ allowed.
+// getDownSegments loads down segments to dstIA from the path DB. Besides the
+// segments from the local AS, these are the segments starting at other core ASes
+// that were registered at this AS as well, such that they can be looked up if
+// the core AS they start at is unreachable. Wildcard dstIA is _not_ allowed.
 func getDownSegments(ctx context.Context, pathDB pathdb.DB,
-	localIA, dstIA addr.IA) (segfetcher.Segments, error) {
+	dstIA addr.IA) (segfetcher.Segments, error) {
 
 	res, err := pathDB.Get(ctx, &query.Params{
-		StartsAt: []addr.IA{localIA},
 		EndsAt:   []addr.IA{dstIA},
 		SegTypes: []seg.Type{seg.TypeDown},
 	})
@@ -112,3 +119,19 @@ func getDownSegments(ctx context.Context, pathDB pathdb.DB,
 	}
 	return res.SegMetas(), nil
 }
+
+// isLocalIA returns whether ia is the local ISD-AS, or one of the additional
+// ISD-ASes of the local AS.
+func isLocalIA(ia, local addr.IA, additional []addr.IA) bool {
+	return ia == local || slices.Contains(additional, ia)
+}
+
+// localIAInISD returns the ISD-AS of the local AS in the given ISD.
+func localIAInISD(isd addr.ISD, local addr.IA, additional []addr.IA) (addr.IA, bool) {
+	for _, ia := range append([]addr.IA{local}, additional...) {
+		if ia.ISD() == isd {
+			return ia, true
+		}
+	}
+	return 0, false
+}
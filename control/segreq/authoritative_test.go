@@ -31,8 +31,10 @@ func TestAuthoritativeClassify(t *testing.T) {
 		Src addr.IA
 		Dst addr.IA
 	}
+	additional210 := addr.MustParseIA("2-ff00:0:110")
 	tests := map[string]struct {
 		LocalIA         addr.IA
+		AdditionalIAs   []addr.IA
 		Request         request
 		ErrorAssertion  require.ErrorAssertionFunc
 		ExpectedSegType seg.Type
@@ -116,6 +118,34 @@ func TestAuthoritativeClassify(t *testing.T) {
 			ErrorAssertion:  require.NoError,
 			ExpectedSegType: seg.TypeDown,
 		},
+		"Invalid Src Not Member Of Additional ISD": {
+			LocalIA: core110,
+			Request: request{
+				Src: additional210,
+				Dst: nonCore211,
+			},
+			ErrorAssertion: require.Error,
+		},
+		"Core Additional ISD": {
+			LocalIA:       core110,
+			AdditionalIAs: []addr.IA{additional210},
+			Request: request{
+				Src: additional210,
+				Dst: core210,
+			},
+			ErrorAssertion:  require.NoError,
+			ExpectedSegType: seg.TypeCore,
+		},
+		"Down Additional ISD": {
+			LocalIA:       core110,
+			AdditionalIAs: []addr.IA{additional210},
+			Request: request{
+				Src: additional210,
+				Dst: nonCore211,
+			},
+			ErrorAssertion:  require.NoError,
+			ExpectedSegType: seg.TypeDown,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -123,8 +153,9 @@ func TestAuthoritativeClassify(t *testing.T) {
 			defer ctrl.Finish()
 
 			p := AuthoritativeLookup{
-				LocalIA:     test.LocalIA,
-				CoreChecker: newMockCoreChecker(ctrl),
+				LocalIA:       test.LocalIA,
+				AdditionalIAs: test.AdditionalIAs,
+				CoreChecker:   newMockCoreChecker(ctrl),
 			}
 			segType, err := p.classify(context.Background(), test.Request.Src, test.Request.Dst)
 			test.ErrorAssertion(t, err)
@@ -0,0 +1,209 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreq
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/private/revcache"
+	"github.com/scionproto/scion/private/segment/segfetcher"
+)
+
+// DefaultCacheMaxEntries is the default maximum number of lookups cached by the CachingLookup.
+const DefaultCacheMaxEntries = 10000
+
+// Lookuper looks up path segments.
+type Lookuper interface {
+	LookupSegments(ctx context.Context, src, dst addr.IA) (segfetcher.Segments, error)
+}
+
+// CacheEntry describes a cached lookup.
+type CacheEntry struct {
+	Src, Dst addr.IA
+	// Segments is the number of cached segments.
+	Segments int
+	// Expiration is the time at which the entry expires.
+	Expiration time.Time
+}
+
+// CachingLookup caches the results of the segment lookups of the wrapped Lookuper, such that
+// repeated lookups for popular destinations are answered without asking the core ASes again.
+// Lookups that fail, even partially, are not cached. Cached results that contain a revoked
+// interface are looked up again. It is safe for concurrent use.
+type CachingLookup struct {
+	// Lookuper looks up the segments that are not cached.
+	Lookuper Lookuper
+	// TTL is the time lookups with segments are cached for. Entries never outlive their
+	// segments.
+	TTL time.Duration
+	// EmptyTTL is the time lookups without segments are cached for. If it is zero, they are
+	// not cached.
+	EmptyTTL time.Duration
+	// MaxEntries is the maximum number of cached lookups. If it is zero,
+	// DefaultCacheMaxEntries is used.
+	MaxEntries int
+	// RevCache is used to check the cached segments for revocations. If it is nil, they are not
+	// checked.
+	RevCache revcache.RevCache
+	// Lookups counts the lookups, by whether they were cached. If it is nil, nothing is
+	// reported.
+	Lookups metrics.Counter
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+type cacheKey struct {
+	src, dst addr.IA
+}
+
+type cacheEntry struct {
+	segs       segfetcher.Segments
+	expiration time.Time
+}
+
+// LookupSegments looks up the segments, from the cache if possible.
+func (c *CachingLookup) LookupSegments(ctx context.Context, src,
+	dst addr.IA) (segfetcher.Segments, error) {
+
+	key := cacheKey{src: src, dst: dst}
+	if segs, ok := c.get(ctx, key); ok {
+		metrics.CounterInc(metrics.CounterWith(c.Lookups, prom.LabelResult, "hit"))
+		return segs, nil
+	}
+	metrics.CounterInc(metrics.CounterWith(c.Lookups, prom.LabelResult, "miss"))
+	segs, err := c.Lookuper.LookupSegments(ctx, src, dst)
+	if err != nil {
+		return segs, err
+	}
+	c.put(key, segs)
+	return segs, nil
+}
+
+// Entries returns the cached lookups that have not expired, sorted by source and destination.
+func (c *CachingLookup) Entries() []CacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	entries := make([]CacheEntry, 0, len(c.entries))
+	for key, e := range c.entries {
+		if !now.Before(e.expiration) {
+			continue
+		}
+		entries = append(entries, CacheEntry{
+			Src:        key.src,
+			Dst:        key.dst,
+			Segments:   len(e.segs),
+			Expiration: e.expiration,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Src != entries[j].Src {
+			return entries[i].Src < entries[j].Src
+		}
+		return entries[i].Dst < entries[j].Dst
+	})
+	return entries
+}
+
+// Flush removes the cached lookups from src to dst. A zero source or destination matches all
+// ISD-ASes. It returns the number of removed entries.
+func (c *CachingLookup) Flush(src, dst addr.IA) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var flushed int
+	for key := range c.entries {
+		if (src.IsZero() || key.src == src) && (dst.IsZero() || key.dst == dst) {
+			delete(c.entries, key)
+			flushed++
+		}
+	}
+	return flushed
+}
+
+func (c *CachingLookup) get(ctx context.Context, key cacheKey) (segfetcher.Segments, bool) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if !ok || !time.Now().Before(e.expiration) {
+		return nil, false
+	}
+	if c.RevCache == nil {
+		return e.segs, true
+	}
+	for _, s := range e.segs {
+		ok, err := revcache.NoRevokedHopIntf(ctx, c.RevCache, s.Segment)
+		if err != nil {
+			log.FromCtx(ctx).Debug("Failed to check cached segment for revocations", "err", err)
+		}
+		if !ok {
+			c.mu.Lock()
+			if cur, ok := c.entries[key]; ok && cur.expiration.Equal(e.expiration) {
+				delete(c.entries, key)
+			}
+			c.mu.Unlock()
+			return nil, false
+		}
+	}
+	return e.segs, true
+}
+
+func (c *CachingLookup) put(key cacheKey, segs segfetcher.Segments) {
+	ttl := c.TTL
+	if len(segs) == 0 {
+		ttl = c.EmptyTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+	now := time.Now()
+	expiration := now.Add(ttl)
+	for _, s := range segs {
+		if exp := s.Segment.MinExpiry(); exp.Before(expiration) {
+			expiration = exp
+		}
+	}
+	if !now.Before(expiration) {
+		return
+	}
+	maxEntries := c.MaxEntries
+	if maxEntries == 0 {
+		maxEntries = DefaultCacheMaxEntries
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[cacheKey]cacheEntry)
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expiration) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxEntries {
+			return
+		}
+	}
+	c.entries[key] = cacheEntry{segs: segs, expiration: expiration}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt/proto"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/private/revcache/memrevcache"
+	"github.com/scionproto/scion/private/segment/segfetcher"
+)
+
+// countingLookuper returns fixed segments and counts the lookups.
+type countingLookuper struct {
+	segs    segfetcher.Segments
+	err     error
+	lookups int
+}
+
+func (l *countingLookuper) LookupSegments(_ context.Context, _,
+	_ addr.IA) (segfetcher.Segments, error) {
+
+	l.lookups++
+	return l.segs, l.err
+}
+
+func TestCachingLookup(t *testing.T) {
+	ctx := context.Background()
+	g := graph.NewDefaultGraph(gomock.NewController(t))
+	down := g.Beacon([]uint16{graph.If_210_X_211_A, graph.If_211_A_222_X})
+	segs := segfetcher.Segments{{Type: seg.TypeDown, Segment: down}}
+	dst211 := addr.MustParseIA("2-ff00:0:211")
+	dst222 := addr.MustParseIA("2-ff00:0:222")
+
+	t.Run("cached", func(t *testing.T) {
+		lookuper := &countingLookuper{segs: segs}
+		c := &CachingLookup{Lookuper: lookuper, TTL: time.Minute}
+		for range 3 {
+			got, err := c.LookupSegments(ctx, core210, dst222)
+			require.NoError(t, err)
+			assert.Equal(t, segs, got)
+		}
+		assert.Equal(t, 1, lookuper.lookups)
+		entries := c.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, core210, entries[0].Src)
+		assert.Equal(t, dst222, entries[0].Dst)
+		assert.Equal(t, 1, entries[0].Segments)
+	})
+	t.Run("expired", func(t *testing.T) {
+		lookuper := &countingLookuper{segs: segs}
+		c := &CachingLookup{Lookuper: lookuper, TTL: 10 * time.Millisecond}
+		_, err := c.LookupSegments(ctx, core210, dst222)
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+		assert.Empty(t, c.Entries())
+		_, err = c.LookupSegments(ctx, core210, dst222)
+		require.NoError(t, err)
+		assert.Equal(t, 2, lookuper.lookups)
+	})
+	t.Run("empty and failed lookups", func(t *testing.T) {
+		lookuper := &countingLookuper{}
+		c := &CachingLookup{Lookuper: lookuper, TTL: time.Minute}
+		_, err := c.LookupSegments(ctx, core210, dst222)
+		require.NoError(t, err)
+		_, err = c.LookupSegments(ctx, core210, dst222)
+		require.NoError(t, err)
+		assert.Equal(t, 2, lookuper.lookups)
+
+		c.EmptyTTL = time.Minute
+		_, err = c.LookupSegments(ctx, core210, dst222)
+		require.NoError(t, err)
+		_, err = c.LookupSegments(ctx, core210, dst222)
+		require.NoError(t, err)
+		assert.Equal(t, 3, lookuper.lookups)
+
+		lookuper.segs, lookuper.err = segs, serrors.New("partial")
+		_, err = c.LookupSegments(ctx, core210, dst211)
+		assert.Error(t, err)
+		_, err = c.LookupSegments(ctx, core210, dst211)
+		assert.Error(t, err)
+		assert.Equal(t, 5, lookuper.lookups)
+	})
+	t.Run("revoked", func(t *testing.T) {
+		lookuper := &countingLookuper{segs: segs}
+		revCache := memrevcache.New()
+		c := &CachingLookup{Lookuper: lookuper, TTL: time.Minute, RevCache: revCache}
+		_, err := c.LookupSegments(ctx, core210, dst222)
+		require.NoError(t, err)
+		_, err = revCache.Insert(ctx, &path_mgmt.RevInfo{
+			IfID:         iface.ID(graph.If_211_A_210_X),
+			RawIsdas:     dst211,
+			LinkType:     proto.LinkType_parent,
+			RawTimestamp: util.TimeToSecs(time.Now()),
+			RawTTL:       10,
+		})
+		require.NoError(t, err)
+		_, err = c.LookupSegments(ctx, core210, dst222)
+		require.NoError(t, err)
+		assert.Equal(t, 2, lookuper.lookups)
+	})
+	t.Run("max entries and flush", func(t *testing.T) {
+		lookuper := &countingLookuper{segs: segs}
+		c := &CachingLookup{Lookuper: lookuper, TTL: time.Minute, MaxEntries: 2}
+		for _, key := range [][2]addr.IA{
+			{core210, dst211}, {core210, dst222}, {core110, dst222},
+		} {
+			_, err := c.LookupSegments(ctx, key[0], key[1])
+			require.NoError(t, err)
+		}
+		assert.Len(t, c.Entries(), 2)
+
+		assert.Equal(t, 1, c.Flush(0, dst211))
+		assert.Len(t, c.Entries(), 1)
+		assert.Equal(t, 1, c.Flush(0, 0))
+		assert.Empty(t, c.Entries())
+	})
+}
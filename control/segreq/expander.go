@@ -27,10 +27,13 @@ import (
 )
 
 type WildcardExpander struct {
-	LocalIA   addr.IA
-	Core      bool
-	Inspector trust.Inspector
-	PathDB    pathdb.DB
+	LocalIA addr.IA
+	// AdditionalIAs are the ISD-ASes of the local AS in the additional ISDs it
+	// is a member of.
+	AdditionalIAs []addr.IA
+	Core          bool
+	Inspector     trust.Inspector
+	PathDB        pathdb.DB
 }
 
 func (e *WildcardExpander) ExpandSrcWildcard(ctx context.Context,
@@ -42,7 +45,7 @@ func (e *WildcardExpander) ExpandSrcWildcard(ctx context.Context,
 
 	switch req.SegType {
 	case seg.TypeCore:
-		cores, err := e.providerCoreASes(ctx)
+		cores, err := e.providerCoreASes(ctx, req.Src.ISD())
 		if err != nil {
 			return nil, err
 		}
@@ -69,10 +72,18 @@ func (e *WildcardExpander) coreASes(ctx context.Context, isd addr.ISD) ([]addr.I
 }
 
 // providerCoreASes returns the core ASes that are providers of this AS, i.e.
-// those core ASes that are directly reachable with an up segment
-func (e *WildcardExpander) providerCoreASes(ctx context.Context) ([]addr.IA, error) {
+// those core ASes that are directly reachable with an up segment. In a core
+// AS, this is the ISD-AS of the local AS in the given ISD, if it is a member
+// of it, or the local ISD-AS otherwise.
+func (e *WildcardExpander) providerCoreASes(
+	ctx context.Context,
+	isd addr.ISD,
+) ([]addr.IA, error) {
 
 	if e.Core {
+		if ia, ok := localIAInISD(isd, e.LocalIA, e.AdditionalIAs); ok {
+			return []addr.IA{ia}, nil
+		}
 		return []addr.IA{e.LocalIA}, nil
 	}
 
@@ -27,10 +27,13 @@ import (
 // segments are missing, the request is forwarded to the respective core ASes.
 // It should only be used in a non-core AS.
 type ForwardingLookup struct {
-	LocalIA     addr.IA
-	CoreChecker CoreChecker
-	Fetcher     *segfetcher.Fetcher
-	Expander    WildcardExpander
+	LocalIA addr.IA
+	// AdditionalIAs are the ISD-ASes of the local AS in the additional ISDs it
+	// is a member of.
+	AdditionalIAs []addr.IA
+	CoreChecker   CoreChecker
+	Fetcher       *segfetcher.Fetcher
+	Expander      WildcardExpander
 }
 
 // LookupSegments looks up the segments for the given request
@@ -68,7 +71,7 @@ func (f ForwardingLookup) classify(ctx context.Context,
 			"src", src, "dst", dst, "reason", "zero ISD src or dst")
 
 	}
-	if dst == f.LocalIA {
+	if isLocalIA(dst, f.LocalIA, f.AdditionalIAs) {
 		// this could be an otherwise valid request, but probably the requester switched Src and Dst
 		return 0, serrors.JoinNoStack(segfetcher.ErrInvalidRequest, nil,
 			"src", src, "dst", dst, "reason", "dst is local AS, confusion?")
@@ -85,7 +88,7 @@ func (f ForwardingLookup) classify(ctx context.Context,
 	switch {
 	case srcCore && dstCore:
 		// core
-		if src.ISD() != f.LocalIA.ISD() {
+		if _, ok := localIAInISD(src.ISD(), f.LocalIA, f.AdditionalIAs); !ok {
 			return 0, serrors.JoinNoStack(segfetcher.ErrInvalidRequest, nil,
 				"src", src, "dst", dst, "reason", "core segment request src ISD not local ISD")
 
@@ -101,12 +104,12 @@ func (f ForwardingLookup) classify(ctx context.Context,
 		return seg.TypeDown, nil
 	case dstCore:
 		// up
-		if src != f.LocalIA {
+		if !isLocalIA(src, f.LocalIA, f.AdditionalIAs) {
 			return 0, serrors.JoinNoStack(segfetcher.ErrInvalidRequest, nil,
 				"src", src, "dst", dst, "reason", "up segment request src not local AS")
 
 		}
-		if dst.ISD() != f.LocalIA.ISD() {
+		if dst.ISD() != src.ISD() {
 			return 0, serrors.JoinNoStack(segfetcher.ErrInvalidRequest, nil,
 				"src", src, "dst", dst, "reason", "up segment request dst in different ISD")
 
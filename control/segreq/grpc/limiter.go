@@ -0,0 +1,199 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+const (
+	// maxTrackedClients bounds the number of clients for which a rate limiter is kept.
+	maxTrackedClients = 4096
+	// errRateLimitedLabel is the result label of lookups rejected by a rate limit.
+	errRateLimitedLabel = "err_rate_limited"
+)
+
+var (
+	errRateLimited = status.Error(codes.ResourceExhausted, "segment lookup rate limit exceeded")
+	errOverloaded  = status.Error(codes.Unavailable, "too many concurrent segment lookups")
+)
+
+// LimiterConfig configures a Limiter. Zero values disable the respective limit.
+type LimiterConfig struct {
+	// ClientRate is the number of lookups per second allowed per client on average.
+	ClientRate float64
+	// ClientBurst is the number of lookups a client can send at once. If it is zero, it is
+	// the client rate rounded up.
+	ClientBurst int
+	// GlobalRate is the number of lookups per second allowed from all clients on average.
+	GlobalRate float64
+	// GlobalBurst is the number of lookups all clients can send at once. If it is zero, it is
+	// the global rate rounded up.
+	GlobalBurst int
+	// MaxConcurrent is the number of lookups that are processed concurrently. Lookups beyond
+	// this number are rejected, such that an overloaded service sheds the load instead of
+	// queuing it.
+	MaxConcurrent int
+}
+
+// Limiter limits the segment lookups, per client and globally. Clients are
+// identified by their ISD-AS, if known, and their IP address.
+type Limiter struct {
+	cfg    LimiterConfig
+	global *tokenBucket
+	// inflight is a semaphore with MaxConcurrent slots.
+	inflight chan struct{}
+
+	mu      sync.Mutex
+	clients map[string]*tokenBucket
+}
+
+// NewLimiter creates a new limiter.
+func NewLimiter(cfg LimiterConfig) *Limiter {
+	l := &Limiter{
+		cfg:     cfg,
+		clients: make(map[string]*tokenBucket),
+	}
+	if cfg.GlobalRate > 0 {
+		l.global = newTokenBucket(cfg.GlobalRate, cfg.GlobalBurst)
+	}
+	if cfg.MaxConcurrent > 0 {
+		l.inflight = make(chan struct{}, cfg.MaxConcurrent)
+	}
+	return l
+}
+
+// Acquire admits a lookup of the client in ctx. If the lookup is admitted, the
+// returned function must be called once it has been processed. Otherwise, a
+// gRPC status error is returned: ResourceExhausted if a rate limit is
+// exceeded, and Unavailable if too many lookups are processed concurrently.
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	now := time.Now()
+	if l.cfg.ClientRate > 0 && !l.allowClient(clientKey(ctx), now) {
+		return nil, errRateLimited
+	}
+	if l.global != nil && !l.global.allow(now) {
+		return nil, errRateLimited
+	}
+	if l.inflight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inflight <- struct{}{}:
+		return func() { <-l.inflight }, nil
+	default:
+		return nil, errOverloaded
+	}
+}
+
+func (l *Limiter) allowClient(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.clients[key]
+	if !ok {
+		if len(l.clients) >= maxTrackedClients {
+			for k, v := range l.clients {
+				if v.full(now) {
+					delete(l.clients, k)
+				}
+			}
+			if len(l.clients) >= maxTrackedClients {
+				return false
+			}
+		}
+		b = newTokenBucket(l.cfg.ClientRate, l.cfg.ClientBurst)
+		l.clients[key] = b
+	}
+	return b.allow(now)
+}
+
+// clientKey identifies the client of the request in ctx.
+func clientKey(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	switch a := p.Addr.(type) {
+	case *snet.UDPAddr:
+		if a.Host != nil {
+			return a.IA.String() + "," + a.Host.IP.String()
+		}
+		return a.IA.String()
+	case *net.TCPAddr:
+		return a.IP.String()
+	case *net.UDPAddr:
+		return a.IP.String()
+	default:
+		return a.String()
+	}
+}
+
+// tokenBucket is a token bucket that is refilled continuously.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst <= 0 {
+		burst = int(math.Ceil(rate))
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// allow consumes a token and returns true if one was available.
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// full returns true if the bucket is full at the given time, i.e., if it holds
+// no state worth keeping.
+func (b *tokenBucket) full(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func clientCtx(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 30000},
+	})
+}
+
+func TestLimiterClientRate(t *testing.T) {
+	l := NewLimiter(LimiterConfig{ClientRate: 0.001, ClientBurst: 2})
+	for i := 0; i < 2; i++ {
+		release, err := l.Acquire(clientCtx("10.0.0.1"))
+		require.NoError(t, err)
+		release()
+	}
+	_, err := l.Acquire(clientCtx("10.0.0.1"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Other clients are not affected.
+	release, err := l.Acquire(clientCtx("10.0.0.2"))
+	require.NoError(t, err)
+	release()
+}
+
+func TestLimiterGlobalRate(t *testing.T) {
+	l := NewLimiter(LimiterConfig{GlobalRate: 0.001, GlobalBurst: 1})
+	release, err := l.Acquire(clientCtx("10.0.0.1"))
+	require.NoError(t, err)
+	release()
+	_, err = l.Acquire(clientCtx("10.0.0.2"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestLimiterMaxConcurrent(t *testing.T) {
+	l := NewLimiter(LimiterConfig{MaxConcurrent: 1})
+	release, err := l.Acquire(clientCtx("10.0.0.1"))
+	require.NoError(t, err)
+	_, err = l.Acquire(clientCtx("10.0.0.2"))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// Once the first lookup is done, the next one is admitted.
+	release()
+	release, err = l.Acquire(clientCtx("10.0.0.2"))
+	require.NoError(t, err)
+	release()
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(10, 0)
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		assert.True(t, b.allow(now))
+	}
+	assert.False(t, b.allow(now))
+	assert.False(t, b.full(now))
+
+	// A token is earned every 100ms.
+	assert.True(t, b.allow(now.Add(100*time.Millisecond)))
+	assert.False(t, b.allow(now.Add(100*time.Millisecond)))
+	assert.True(t, b.full(now.Add(2*time.Second)))
+}
@@ -39,6 +39,8 @@ type Lookuper interface {
 type LookupServer struct {
 	Lookuper Lookuper
 	RevCache revcache.RevCache
+	// Limiter limits the lookups. If it is nil, the lookups are not limited.
+	Limiter *Limiter
 
 	// Requests aggregates all the incoming requests received by the handler.
 	// If it is not initialized, nothing is reported.
@@ -62,6 +64,16 @@ func (s LookupServer) Segments(ctx context.Context,
 	setQueryTags(span, src, dst)
 	logger.Debug("Received segment request", "src", src, "dst", dst)
 
+	if s.Limiter != nil {
+		release, err := s.Limiter.Acquire(ctx)
+		if err != nil {
+			logger.Debug("Rejected segment request", "err", err)
+			s.updateMetric(span, labels.WithResult(limitResult(err)), err)
+			return nil, err
+		}
+		defer release()
+	}
+
 	segs, err := s.Lookuper.LookupSegments(ctx, src, dst)
 	if err != nil {
 		logger.Debug("Failed to lookup requested segments", "err", err)
@@ -97,6 +109,14 @@ func (s LookupServer) Segments(ctx context.Context,
 	}, nil
 }
 
+// limitResult returns the metrics label of a lookup rejected by the limiter.
+func limitResult(err error) string {
+	if err == errOverloaded {
+		return prom.ErrUnavailable
+	}
+	return errRateLimitedLabel
+}
+
 func (s LookupServer) updateMetric(span opentracing.Span, l requestLabels, err error) {
 	if s.Requests != nil {
 		s.Requests.With(l.Expand()...).Add(1)
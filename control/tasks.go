@@ -61,6 +61,10 @@ type TasksConfig struct {
 	Metrics               *Metrics
 	DRKeyEngine           *drkey.ServiceEngine
 
+	// AdditionalSignerGens are the signer generators of the additional
+	// ISD-ASes of a core AS that is a member of several ISDs.
+	AdditionalSignerGens map[addr.IA]beaconing.SignerGen
+
 	MACGen     func() hash.Hash
 	StaticInfo func() *beaconing.StaticInfoCfg
 
@@ -68,6 +72,12 @@ type TasksConfig struct {
 	PropagationInterval  time.Duration
 	RegistrationInterval time.Duration
 	DRKeyEpochInterval   time.Duration
+	// Stability adapts the origination and propagation intervals to the
+	// stability of the network. If it is nil, the intervals are fixed.
+	Stability *beaconing.Stability
+	// Jitter is the maximum random deviation of the origination and propagation
+	// intervals, as a fraction of the intervals.
+	Jitter float64
 	// HiddenPathRegistrationCfg contains the required options to configure
 	// hidden paths down segment registration. If it is nil, normal path
 	// registration is used instead.
@@ -76,6 +86,22 @@ type TasksConfig struct {
 	AllowIsdLoop bool
 
 	EPIC bool
+
+	// RegistrationCores are the core ASes at which the down segments are registered, in
+	// addition to the core AS at which each segment starts.
+	RegistrationCores []addr.IA
+
+	// Leader reports whether this instance is the leader among the instances that share the
+	// beacon and path databases. Only the leader originates, propagates and registers beacons.
+	// If it is nil, the instance is always the leader.
+	Leader interface {
+		IsLeader() bool
+	}
+
+	// originator, propagator and writeSchedulers are the started beaconing tasks.
+	originator      *beaconing.Originator
+	propagator      *beaconing.Propagator
+	writeSchedulers []*beaconing.WriteScheduler
 }
 
 // Originator starts a periodic beacon origination task. For non-core ASes, no
@@ -84,6 +110,8 @@ func (t *TasksConfig) Originator() *periodic.Runner {
 	if !t.Core {
 		return nil
 	}
+	tick := beaconing.NewAdaptiveTick(t.OriginationInterval, t.Stability)
+	tick.SetJitter(t.Jitter)
 	s := &beaconing.Originator{
 		Extender: t.extender("originator", t.IA, t.MTU, func() uint8 {
 			return t.BeaconStore.MaxExpTime(beacon.PropPolicy)
@@ -92,16 +120,19 @@ func (t *TasksConfig) Originator() *periodic.Runner {
 		IA:                    t.IA,
 		AllInterfaces:         t.AllInterfaces,
 		OriginationInterfaces: t.OriginationInterfaces,
-		Tick:                  beaconing.NewTick(t.OriginationInterval),
+		Tick:                  tick,
 	}
 	if t.Metrics != nil {
 		s.Originated = metrics.NewPromCounter(t.Metrics.BeaconingOriginatedTotal)
 	}
-	return periodic.Start(s, 500*time.Millisecond, t.OriginationInterval)
+	t.originator = s
+	return periodic.Start(t.leaderOnly(s), 500*time.Millisecond, t.OriginationInterval)
 }
 
 // Propagator starts a periodic beacon propagation task.
 func (t *TasksConfig) Propagator() *periodic.Runner {
+	tick := beaconing.NewAdaptiveTick(t.PropagationInterval, t.Stability)
+	tick.SetJitter(t.Jitter)
 	p := &beaconing.Propagator{
 		Extender: t.extender("propagator", t.IA, t.MTU, func() uint8 {
 			return t.BeaconStore.MaxExpTime(beacon.PropPolicy)
@@ -112,13 +143,15 @@ func (t *TasksConfig) Propagator() *periodic.Runner {
 		AllInterfaces:         t.AllInterfaces,
 		PropagationInterfaces: t.PropagationInterfaces,
 		AllowIsdLoop:          t.AllowIsdLoop,
-		Tick:                  beaconing.NewTick(t.PropagationInterval),
+		StaticInfo:            t.StaticInfo,
+		Tick:                  tick,
 	}
 	if t.Metrics != nil {
 		p.Propagated = metrics.NewPromCounter(t.Metrics.BeaconingPropagatedTotal)
 		p.InternalErrors = metrics.NewPromCounter(t.Metrics.BeaconingPropagatorInternalErrorsTotal)
 	}
-	return periodic.Start(p, 500*time.Millisecond, t.PropagationInterval)
+	t.propagator = p
+	return periodic.Start(t.leaderOnly(p), 500*time.Millisecond, t.PropagationInterval)
 }
 
 // SegmentWriters starts periodic segment registration tasks.
@@ -185,6 +218,7 @@ func (t *TasksConfig) segmentWriter(segType seg.Type,
 			Pather: addrutil.Pather{
 				NextHopper: t.NextHopper,
 			},
+			AdditionalCores: t.RegistrationCores,
 		}
 	}
 	r := &beaconing.WriteScheduler{
@@ -199,7 +233,30 @@ func (t *TasksConfig) segmentWriter(segType seg.Type,
 	// as we can until we succeed. After succeeding, the task does nothing
 	// until the end of the interval. The interval itself is used as a
 	// timeout. If we fail slow we give up at the end of the cycle.
-	return periodic.Start(r, 500*time.Millisecond, t.RegistrationInterval)
+	t.writeSchedulers = append(t.writeSchedulers, r)
+	return periodic.Start(t.leaderOnly(r), 500*time.Millisecond, t.RegistrationInterval)
+}
+
+// leaderOnly wraps the task such that it only runs while the instance is the leader.
+func (t *TasksConfig) leaderOnly(task periodic.Task) periodic.Task {
+	if t.Leader == nil {
+		return task
+	}
+	return leaderTask{Task: task, leader: t.Leader}
+}
+
+type leaderTask struct {
+	periodic.Task
+	leader interface {
+		IsLeader() bool
+	}
+}
+
+func (t leaderTask) Run(ctx context.Context) {
+	if !t.leader.IsLeader() {
+		return
+	}
+	t.Task.Run(ctx)
 }
 
 func (t *TasksConfig) extender(
@@ -210,15 +267,16 @@ func (t *TasksConfig) extender(
 ) beaconing.Extender {
 
 	return &beaconing.DefaultExtender{
-		IA:         ia,
-		SignerGen:  t.SignerGen,
-		MAC:        t.MACGen,
-		Intfs:      t.AllInterfaces,
-		MTU:        mtu,
-		MaxExpTime: func() uint8 { return maxExp() },
-		StaticInfo: t.StaticInfo,
-		Task:       task,
-		EPIC:       t.EPIC,
+		IA:                   ia,
+		SignerGen:            t.SignerGen,
+		AdditionalSignerGens: t.AdditionalSignerGens,
+		MAC:                  t.MACGen,
+		Intfs:                t.AllInterfaces,
+		MTU:                  mtu,
+		MaxExpTime:           func() uint8 { return maxExp() },
+		StaticInfo:           t.StaticInfo,
+		Task:                 task,
+		EPIC:                 t.EPIC,
 		SegmentExpirationDeficient: func() metrics.Gauge {
 			if t.Metrics == nil {
 				return nil
@@ -266,16 +324,26 @@ type Tasks struct {
 
 	PathCleaner   *periodic.Runner
 	DRKeyCleaners []*periodic.Runner
+
+	originator      *beaconing.Originator
+	propagator      *beaconing.Propagator
+	writeSchedulers []*beaconing.WriteScheduler
 }
 
 func StartTasks(cfg TasksConfig) (*Tasks, error) {
 
 	segCleaner := pathdb.NewCleaner(cfg.PathDB, "control_pathstorage_segments")
 	segRevCleaner := revcache.NewCleaner(cfg.RevCache, "control_pathstorage_revocation")
+	originator := cfg.Originator()
+	propagator := cfg.Propagator()
+	registrars := cfg.SegmentWriters()
 	return &Tasks{
-		Originator: cfg.Originator(),
-		Propagator: cfg.Propagator(),
-		Registrars: cfg.SegmentWriters(),
+		Originator:      originator,
+		Propagator:      propagator,
+		Registrars:      registrars,
+		originator:      cfg.originator,
+		propagator:      cfg.propagator,
+		writeSchedulers: cfg.writeSchedulers,
 		PathCleaner: periodic.Start(
 			periodic.Func{
 				Task: func(ctx context.Context) {
@@ -293,6 +361,30 @@ func StartTasks(cfg TasksConfig) (*Tasks, error) {
 
 }
 
+// Originate makes the origination and propagation tasks originate and propagate
+// beacons on all interfaces immediately, regardless of the intervals.
+func (t *Tasks) Originate() {
+	if t.originator != nil {
+		t.originator.Force()
+		t.Originator.TriggerRun()
+	}
+	if t.propagator != nil {
+		t.propagator.Force()
+		t.Propagator.TriggerRun()
+	}
+}
+
+// Reregister makes the segment registration tasks register the segments
+// immediately, regardless of the registration interval.
+func (t *Tasks) Reregister() {
+	for _, s := range t.writeSchedulers {
+		s.Force()
+	}
+	for _, r := range t.Registrars {
+		r.TriggerRun()
+	}
+}
+
 // Kill stops all running tasks immediately.
 func (t *Tasks) Kill() {
 	if t == nil {
@@ -310,6 +402,9 @@ func (t *Tasks) Kill() {
 	t.Propagator = nil
 	t.PathCleaner = nil
 	t.Registrars = nil
+	t.originator = nil
+	t.propagator = nil
+	t.writeSchedulers = nil
 	t.DRKeyPrefetcher = nil
 	t.DRKeyCleaners = nil
 }
@@ -323,7 +418,7 @@ func killRunners(runners []*periodic.Runner) {
 // HiddenPathRegistrationCfg contains the required options to configure hidden
 // paths down segment registration.
 type HiddenPathRegistrationCfg struct {
-	Policy     hiddenpath.RegistrationPolicy
+	Policy     hiddenpath.PolicyProvider
 	Router     snet.Router
 	Discoverer hiddenpath.Discoverer
 	RPC        hiddenpath.Register
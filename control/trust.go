@@ -18,9 +18,11 @@ import (
 	"context"
 	"crypto/x509"
 	"errors"
+	"net/http"
 	"path/filepath"
 	"time"
 
+	"github.com/scionproto/scion/control/config"
 	cstrust "github.com/scionproto/scion/control/trust"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
@@ -65,22 +67,57 @@ func LoadTrustMaterial(ctx context.Context, configDir string, db trust.DB) error
 	return nil
 }
 
+// KeyRings are the key rings with the private keys of the AS and the CA certificates.
+type KeyRings struct {
+	AS trust.KeyRing
+	CA trust.KeyRing
+}
+
+// NewKeyRings creates the key rings of the configured key backend.
+func NewKeyRings(cfg config.Keys, cfgDir string) (KeyRings, error) {
+	if cfg.Backend == config.KeyBackendVault {
+		var client *http.Client
+		if cfg.Vault.CAFile != "" {
+			var err error
+			if client, err = cstrust.NewVaultClient(cfg.Vault.CAFile); err != nil {
+				return KeyRings{}, err
+			}
+		}
+		ring := func(keys []string) cstrust.VaultRing {
+			return cstrust.VaultRing{
+				Address:   cfg.Vault.Address,
+				Mount:     cfg.Vault.Mount,
+				Keys:      keys,
+				TokenFile: cfg.Vault.TokenFile,
+				Client:    client,
+			}
+		}
+		return KeyRings{AS: ring(cfg.Vault.ASKeys), CA: ring(cfg.Vault.CAKeys)}, nil
+	}
+	return KeyRings{
+		AS: cstrust.LoadingRing{Dir: filepath.Join(cfgDir, "crypto/as")},
+		CA: cstrust.LoadingRing{Dir: filepath.Join(cfgDir, "crypto/ca")},
+	}, nil
+}
+
 func NewTLSCertificateLoader(
 	ia addr.IA,
 	extKeyUsage x509.ExtKeyUsage,
 	db trust.DB,
 	cfgDir string,
+	keys trust.KeyRing,
 ) cstrust.TLSCertificateLoader {
 
 	return cstrust.TLSCertificateLoader{
-		SignerGen: newCachingSignerGen(ia, extKeyUsage, db, cfgDir),
+		SignerGen: newCachingSignerGen(ia, extKeyUsage, db, cfgDir, keys),
 	}
 }
 
-// NewSigner creates a renewing signer backed by a certificate chain.
-func NewSigner(ia addr.IA, db trust.DB, cfgDir string) cstrust.RenewingSigner {
+// NewSigner creates a renewing signer backed by a certificate chain and the private keys of the
+// key ring.
+func NewSigner(ia addr.IA, db trust.DB, cfgDir string, keys trust.KeyRing) cstrust.RenewingSigner {
 	signer := cstrust.RenewingSigner{
-		SignerGen: newCachingSignerGen(ia, x509.ExtKeyUsageAny, db, cfgDir),
+		SignerGen: newCachingSignerGen(ia, x509.ExtKeyUsageAny, db, cfgDir, keys),
 	}
 
 	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
@@ -99,6 +136,7 @@ func newCachingSignerGen(
 	extKeyUsage x509.ExtKeyUsage,
 	db trust.DB,
 	cfgDir string,
+	keys trust.KeyRing,
 ) *cstrust.CachingSignerGen {
 
 	gen := trust.SignerGen{
@@ -108,9 +146,7 @@ func newCachingSignerGen(
 			TRCDirs: []string{filepath.Join(cfgDir, "certs")},
 			DB:      db,
 		},
-		KeyRing: cstrust.LoadingRing{
-			Dir: filepath.Join(cfgDir, "crypto/as"),
-		},
+		KeyRing:     keys,
 		ExtKeyUsage: extKeyUsage,
 	}
 	return &cstrust.CachingSignerGen{
@@ -124,7 +160,11 @@ type ChainBuilderConfig struct {
 	DB          trust.DB
 	MaxValidity time.Duration
 	ConfigDir   string
-	Metrics     renewal.Metrics
+	// KeyRing provides the private keys of the CA certificates.
+	KeyRing trust.KeyRing
+	Metrics renewal.Metrics
+	// IssuancePolicy constrains the issued certificate chains. It is optional.
+	IssuancePolicy *renewal.IssuancePolicy
 
 	// ForceECDSAWithSHA512 forces the CA policy to use ECDSAWithSHA512 as the
 	// signature algorithm for signing the issued certificate. This field
@@ -146,9 +186,7 @@ func NewChainBuilder(cfg ChainBuilderConfig) renewal.ChainBuilder {
 					DB:  cfg.DB,
 					Dir: filepath.Join(cfg.ConfigDir, "crypto/ca"),
 				},
-				KeyRing: cstrust.LoadingRing{
-					Dir: filepath.Join(cfg.ConfigDir, "crypto/ca"),
-				},
+				KeyRing:              cfg.KeyRing,
 				ForceECDSAWithSHA512: cfg.ForceECDSAWithSHA512,
 				CASigners:            cfg.Metrics.CASigners,
 			},
@@ -156,6 +194,7 @@ func NewChainBuilder(cfg ChainBuilderConfig) renewal.ChainBuilder {
 			LastGeneratedCA: cfg.Metrics.LastGeneratedCA,
 			ExpirationCA:    cfg.Metrics.ExpirationCA,
 		},
-		SignedChains: cfg.Metrics.SignedChains,
+		IssuancePolicy: cfg.IssuancePolicy,
+		SignedChains:   cfg.Metrics.SignedChains,
 	}
 }
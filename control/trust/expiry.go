@@ -0,0 +1,228 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/trust"
+)
+
+// The trust material artifacts whose expiration is checked.
+const (
+	ArtifactTRC = "trc"
+	ArtifactCA  = "ca_certificate"
+	ArtifactAS  = "as_certificate"
+)
+
+// ExpiryAlert describes trust material that is about to expire.
+type ExpiryAlert struct {
+	// Artifact is the type of the trust material.
+	Artifact string `json:"artifact"`
+	// ID identifies the trust material. It is the TRC ID for TRCs, and the subject key ID for
+	// certificates.
+	ID string `json:"id"`
+	// IA is the local ISD-AS.
+	IA addr.IA `json:"isd_as"`
+	// NotAfter is the end of the validity period of the trust material.
+	NotAfter time.Time `json:"not_after"`
+}
+
+// Alerter delivers expiry alerts.
+type Alerter interface {
+	Alert(ctx context.Context, alert ExpiryAlert) error
+}
+
+// WebhookAlerter posts the expiry alerts as JSON to a URL.
+type WebhookAlerter struct {
+	// URL is the URL the alerts are posted to.
+	URL string
+	// Client is the HTTP client. If it is nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Alert posts the alert to the URL. Any response status other than 2xx is an error.
+func (a WebhookAlerter) Alert(ctx context.Context, alert ExpiryAlert) error {
+	raw, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	rep, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rep.Body.Close()
+	if rep.StatusCode < 200 || rep.StatusCode > 299 {
+		return serrors.New("webhook failed", "status", rep.StatusCode)
+	}
+	return nil
+}
+
+// ExpiryChecker is a periodic task that checks the expiration of the latest TRC of the local
+// ISD, the CA certificate and the AS certificate chain. It exports their expiration times as
+// metrics, and raises an alert once the remaining validity of an artifact drops below its
+// threshold. Every artifact is alerted once; failed alerts are retried on the next run.
+type ExpiryChecker struct {
+	// IA is the local ISD-AS.
+	IA addr.IA
+	// TRCs provides the latest TRC of the local ISD.
+	TRCs renewal.TRCFetcher
+	// SignerGen generates the signers with the AS certificate chains.
+	SignerGen SignerGen
+	// CA generates the CA policy with the CA certificate. If it is nil, the CA certificate is
+	// not checked.
+	CA renewal.PolicyGen
+	// Thresholds are the remaining validities, per artifact, at which an alert is raised.
+	// Artifacts without a threshold are not alerted on.
+	Thresholds map[string]time.Duration
+	// Alerter delivers the alerts. If it is nil, the alerts are only logged.
+	Alerter Alerter
+	// Expiration is set to the expiration time of each artifact, with the artifact label.
+	Expiration metrics.Gauge
+	// Alerting is set to 1 for each artifact that is about to expire, and to 0 otherwise.
+	Alerting metrics.Gauge
+
+	// alerted is the ID of the alerted trust material per artifact.
+	alerted map[string]string
+}
+
+// Name returns the task name.
+func (c *ExpiryChecker) Name() string {
+	return "control_trust_expiry_checker"
+}
+
+// Run checks the expiration of the trust material.
+func (c *ExpiryChecker) Run(ctx context.Context) {
+	if c.alerted == nil {
+		c.alerted = make(map[string]string)
+	}
+	logger := log.FromCtx(ctx)
+	now := time.Now()
+	if id, notAfter, err := c.trc(ctx); err != nil {
+		logger.Info("Failed to check TRC expiration", "err", err)
+	} else {
+		c.check(ctx, now, ArtifactTRC, id, notAfter)
+	}
+	if id, notAfter, err := c.chain(ctx); err != nil {
+		logger.Info("Failed to check AS certificate expiration", "err", err)
+	} else {
+		c.check(ctx, now, ArtifactAS, id, notAfter)
+	}
+	if c.CA == nil {
+		return
+	}
+	if id, notAfter, err := c.caCert(ctx); err != nil {
+		logger.Info("Failed to check CA certificate expiration", "err", err)
+	} else {
+		c.check(ctx, now, ArtifactCA, id, notAfter)
+	}
+}
+
+func (c *ExpiryChecker) check(
+	ctx context.Context,
+	now time.Time,
+	artifact string,
+	id string,
+	notAfter time.Time,
+) {
+
+	metrics.GaugeSetTimestamp(metrics.GaugeWith(c.Expiration, "artifact", artifact), notAfter)
+	threshold, ok := c.Thresholds[artifact]
+	alerting := ok && notAfter.Sub(now) < threshold
+	alertingGauge := metrics.GaugeWith(c.Alerting, "artifact", artifact)
+	if !alerting {
+		metrics.GaugeSet(alertingGauge, 0)
+		delete(c.alerted, artifact)
+		return
+	}
+	metrics.GaugeSet(alertingGauge, 1)
+	if c.alerted[artifact] == id {
+		return
+	}
+	logger := log.FromCtx(ctx)
+	logger.Error("Trust material is about to expire", "artifact", artifact, "id", id,
+		"not_after", notAfter, "remaining", notAfter.Sub(now).Truncate(time.Second))
+	if c.Alerter != nil {
+		alert := ExpiryAlert{Artifact: artifact, ID: id, IA: c.IA, NotAfter: notAfter}
+		if err := c.Alerter.Alert(ctx, alert); err != nil {
+			logger.Info("Failed to deliver expiry alert", "artifact", artifact, "err", err)
+			return
+		}
+	}
+	c.alerted[artifact] = id
+}
+
+func (c *ExpiryChecker) trc(ctx context.Context) (string, time.Time, error) {
+	trc, err := c.TRCs.SignedTRC(ctx, cppki.TRCID{
+		ISD:    c.IA.ISD(),
+		Base:   scrypto.LatestVer,
+		Serial: scrypto.LatestVer,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if trc.IsZero() {
+		return "", time.Time{}, serrors.New("no TRC for local ISD")
+	}
+	return trc.TRC.ID.String(), trc.TRC.Validity.NotAfter, nil
+}
+
+// chain returns the AS certificate chain that expires last.
+func (c *ExpiryChecker) chain(ctx context.Context) (string, time.Time, error) {
+	signers, err := c.SignerGen.Generate(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	var last *trust.Signer
+	for i := range signers {
+		if last == nil || signers[i].ChainValidity.NotAfter.After(last.ChainValidity.NotAfter) {
+			last = &signers[i]
+		}
+	}
+	if last == nil {
+		return "", time.Time{}, serrors.New("no AS certificate")
+	}
+	return fmt.Sprintf("%X", last.SubjectKeyID), last.ChainValidity.NotAfter, nil
+}
+
+func (c *ExpiryChecker) caCert(ctx context.Context) (string, time.Time, error) {
+	policy, err := c.CA.Generate(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	cert := policy.Certificate
+	return fmt.Sprintf("%X", cert.SubjectKeyId), cert.NotAfter, nil
+}
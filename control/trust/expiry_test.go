@@ -0,0 +1,175 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstrust "github.com/scionproto/scion/control/trust"
+	"github.com/scionproto/scion/control/trust/mock_trust"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/trust"
+)
+
+type trcFetcher struct {
+	trc cppki.SignedTRC
+}
+
+func (f *trcFetcher) SignedTRC(context.Context, cppki.TRCID) (cppki.SignedTRC, error) {
+	return f.trc, nil
+}
+
+type policyGen struct {
+	notAfter time.Time
+}
+
+func (g policyGen) Generate(context.Context) (cppki.CAPolicy, error) {
+	return cppki.CAPolicy{
+		Certificate: &x509.Certificate{SubjectKeyId: []byte{0xca}, NotAfter: g.notAfter},
+	}, nil
+}
+
+// recordingAlerter records the alerts.
+type recordingAlerter struct {
+	failing bool
+	alerts  []cstrust.ExpiryAlert
+}
+
+func (a *recordingAlerter) Alert(_ context.Context, alert cstrust.ExpiryAlert) error {
+	a.alerts = append(a.alerts, alert)
+	if a.failing {
+		return serrors.New("unreachable")
+	}
+	return nil
+}
+
+func TestExpiryChecker(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	ia := addr.MustParseIA("1-ff00:0:110")
+	now := time.Now().Truncate(time.Second)
+	trcID := cppki.TRCID{ISD: 1, Base: 1, Serial: 1}
+	trcs := &trcFetcher{trc: cppki.SignedTRC{TRC: cppki.TRC{
+		ID:       trcID,
+		Validity: cppki.Validity{NotBefore: now, NotAfter: now.Add(20 * 24 * time.Hour)},
+	}}}
+	signerGen := mock_trust.NewMockSignerGen(mctrl)
+	asNotAfter := now.Add(48 * time.Hour)
+	signerGen.EXPECT().Generate(gomock.Any()).DoAndReturn(
+		func(context.Context) ([]trust.Signer, error) {
+			return []trust.Signer{
+				{SubjectKeyID: []byte{0x01}, ChainValidity: cppki.Validity{NotAfter: now}},
+				{SubjectKeyID: []byte{0x02}, ChainValidity: cppki.Validity{NotAfter: asNotAfter}},
+			}, nil
+		},
+	).AnyTimes()
+	alerter := &recordingAlerter{failing: true}
+	expiration, alerting := metrics.NewTestGauge(), metrics.NewTestGauge()
+	checker := &cstrust.ExpiryChecker{
+		IA:        ia,
+		TRCs:      trcs,
+		SignerGen: signerGen,
+		CA:        policyGen{notAfter: now.Add(time.Hour)},
+		Thresholds: map[string]time.Duration{
+			cstrust.ArtifactTRC: 30 * 24 * time.Hour,
+			cstrust.ArtifactCA:  2 * time.Hour,
+			cstrust.ArtifactAS:  24 * time.Hour,
+		},
+		Alerter:    alerter,
+		Expiration: expiration,
+		Alerting:   alerting,
+	}
+	gauge := func(g metrics.Gauge, artifact string) float64 {
+		return metrics.GaugeValue(g.With("artifact", artifact))
+	}
+	alerts := func() []cstrust.ExpiryAlert {
+		a := alerter.alerts
+		alerter.alerts = nil
+		return a
+	}
+
+	checker.Run(context.Background())
+	assert.Equal(t, float64(now.Add(20*24*time.Hour).Unix()), gauge(expiration, "trc"))
+	assert.Equal(t, float64(asNotAfter.Unix()), gauge(expiration, "as_certificate"))
+	assert.Equal(t, float64(now.Add(time.Hour).Unix()), gauge(expiration, "ca_certificate"))
+	assert.Equal(t, float64(1), gauge(alerting, "trc"))
+	assert.Equal(t, float64(0), gauge(alerting, "as_certificate"))
+	assert.Equal(t, float64(1), gauge(alerting, "ca_certificate"))
+	assert.ElementsMatch(t, []cstrust.ExpiryAlert{
+		{Artifact: "trc", ID: trcID.String(), IA: ia, NotAfter: now.Add(20 * 24 * time.Hour)},
+		{Artifact: "ca_certificate", ID: "CA", IA: ia, NotAfter: now.Add(time.Hour)},
+	}, alerts())
+
+	// Failed alerts are retried, successful alerts are not repeated.
+	alerter.failing = false
+	checker.Run(context.Background())
+	assert.Len(t, alerts(), 2)
+	checker.Run(context.Background())
+	assert.Empty(t, alerts())
+
+	// An update of the artifact clears the alert, an expiring update alerts again.
+	trcs.trc.TRC.Validity.NotAfter = now.Add(365 * 24 * time.Hour)
+	checker.Run(context.Background())
+	assert.Equal(t, float64(0), gauge(alerting, "trc"))
+	assert.Empty(t, alerts())
+	trcs.trc.TRC.ID.Serial = 2
+	trcs.trc.TRC.Validity.NotAfter = now.Add(24 * time.Hour)
+	checker.Run(context.Background())
+	assert.Equal(t, []cstrust.ExpiryAlert{{
+		Artifact: "trc",
+		ID:       trcs.trc.TRC.ID.String(),
+		IA:       ia,
+		NotAfter: now.Add(24 * time.Hour),
+	}}, alerts())
+}
+
+func TestWebhookAlerter(t *testing.T) {
+	alert := cstrust.ExpiryAlert{
+		Artifact: cstrust.ArtifactAS,
+		ID:       "02",
+		IA:       addr.MustParseIA("1-ff00:0:110"),
+		NotAfter: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	var received cstrust.ExpiryAlert
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	alerter := cstrust.WebhookAlerter{URL: srv.URL}
+	require.NoError(t, alerter.Alert(context.Background(), alert))
+	assert.Equal(t, alert, received)
+
+	status = http.StatusInternalServerError
+	assert.Error(t, alerter.Alert(context.Background(), alert))
+}
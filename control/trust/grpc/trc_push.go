@@ -0,0 +1,170 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/peer"
+
+	"github.com/scionproto/scion/control/onehop"
+	trustmetrics "github.com/scionproto/scion/control/trust/metrics"
+	"github.com/scionproto/scion/pkg/addr"
+	libgrpc "github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/trust"
+)
+
+// TRCPushServer accepts the TRCs that the control services of the parent ASes push.
+type TRCPushServer struct {
+	// DB stores the accepted TRCs.
+	DB trust.DB
+	// Provider resolves the TRC updates between the latest TRC in the DB and a pushed TRC, if
+	// there are any.
+	Provider trust.Provider
+	// IA is the local ISD-AS.
+	IA addr.IA
+	// Accepted is called with the ID of every accepted TRC. It is optional.
+	Accepted func(cppki.TRCID)
+
+	// Requests aggregates all the incoming requests received by the handler. If
+	// it is not initialized, nothing is reported.
+	Requests metrics.Counter
+}
+
+// PushTRC accepts the pushed TRC if it is a verifiable update of the latest TRC of its ISD.
+// Pushed TRCs that are already known are ignored. A TRC with another base number is rejected,
+// since it cannot be verified against its predecessor.
+func (s TRCPushServer) PushTRC(ctx context.Context,
+	req *cppb.PushTRCRequest) (*cppb.PushTRCResponse, error) {
+
+	labels := requestLabels{
+		ReqType: trustmetrics.TRCPush,
+		Client:  "unknown",
+	}
+	var client net.Addr
+	if peer, ok := peer.FromContext(ctx); ok {
+		client = peer.Addr
+		labels.Client = trustmetrics.PeerToLabel(client, s.IA)
+	}
+	span := opentracing.SpanFromContext(ctx)
+	logger := log.FromCtx(ctx)
+
+	trc, err := cppki.DecodeSignedTRC(req.Trc)
+	if err != nil {
+		logger.Debug("Invalid pushed TRC", "peer", client, "err", err)
+		s.updateMetric(span, labels.WithResult(trustmetrics.ErrParse), err)
+		return nil, serrors.Wrap("parsing TRC", err)
+	}
+	id := trc.TRC.ID
+	setTRCTags(span, id)
+	logger.Debug("Received pushed TRC", "id", id, "peer", client)
+
+	latest, err := s.DB.SignedTRC(ctx, cppki.TRCID{
+		ISD:    id.ISD,
+		Base:   scrypto.LatestVer,
+		Serial: scrypto.LatestVer,
+	})
+	if err != nil {
+		s.updateMetric(span, labels.WithResult(trustmetrics.ErrInternal), err)
+		return nil, serrors.Wrap("loading latest TRC", err)
+	}
+	if latest.IsZero() {
+		err := serrors.New("no TRC for ISD present", "isd", id.ISD)
+		s.updateMetric(span, labels.WithResult(trustmetrics.ErrVerify), err)
+		return nil, err
+	}
+	if latest.TRC.ID.Base != id.Base {
+		err := serrors.New("base number mismatch",
+			"expected", latest.TRC.ID.Base, "actual", id.Base)
+		s.updateMetric(span, labels.WithResult(trustmetrics.ErrVerify), err)
+		return nil, err
+	}
+	if id.Serial <= latest.TRC.ID.Serial {
+		s.updateMetric(span, labels.WithResult(trustmetrics.Success), nil)
+		return &cppb.PushTRCResponse{}, nil
+	}
+	predecessor := latest
+	if id.Serial > latest.TRC.ID.Serial+1 {
+		// Resolve the missing updates first, such that the pushed TRC can be verified against
+		// its predecessor.
+		predID := cppki.TRCID{ISD: id.ISD, Base: id.Base, Serial: id.Serial - 1}
+		if err := s.Provider.NotifyTRC(ctx, predID); err != nil {
+			s.updateMetric(span, labels.WithResult(trustmetrics.ErrInternal), err)
+			return nil, serrors.Wrap("resolving TRC updates", err, "id", predID)
+		}
+		if predecessor, err = s.DB.SignedTRC(ctx, predID); err != nil {
+			s.updateMetric(span, labels.WithResult(trustmetrics.ErrInternal), err)
+			return nil, serrors.Wrap("loading predecessor TRC", err, "id", predID)
+		}
+	}
+	if err := trc.Verify(&predecessor.TRC); err != nil {
+		logger.Info("Failed to verify pushed TRC", "id", id, "peer", client, "err", err)
+		s.updateMetric(span, labels.WithResult(trustmetrics.ErrVerify), err)
+		return nil, serrors.Wrap("verifying TRC update", err)
+	}
+	if _, err := s.DB.InsertTRC(ctx, trc); err != nil {
+		s.updateMetric(span, labels.WithResult(trustmetrics.ErrInternal), err)
+		return nil, serrors.Wrap("inserting TRC", err)
+	}
+	logger.Info("Accepted pushed TRC", "id", id, "peer", client)
+	s.updateMetric(span, labels.WithResult(trustmetrics.Success), nil)
+	if s.Accepted != nil {
+		s.Accepted(id)
+	}
+	return &cppb.PushTRCResponse{}, nil
+}
+
+func (s TRCPushServer) updateMetric(span opentracing.Span, l requestLabels, err error) {
+	MaterialServer{Requests: s.Requests}.updateMetric(span, l, err)
+}
+
+// TRCPushSender pushes TRCs to the control services of the child ASes.
+type TRCPushSender struct {
+	// Dialer dials a new gRPC connection.
+	Dialer libgrpc.Dialer
+}
+
+// PushTRC pushes the TRC to the control service of the child AS, which is reached over the
+// egress interface.
+func (s TRCPushSender) PushTRC(
+	ctx context.Context,
+	ia addr.IA,
+	egress uint16,
+	nextHop *net.UDPAddr,
+	trc cppki.SignedTRC,
+) error {
+
+	conn, err := s.Dialer.Dial(ctx, &onehop.Addr{
+		IA:      ia,
+		Egress:  egress,
+		SVC:     addr.SvcCS,
+		NextHop: nextHop,
+	})
+	if err != nil {
+		return serrors.Wrap("dialing", err)
+	}
+	defer conn.Close()
+	client := cppb.NewTRCPushServiceClient(conn)
+	_, err = client.PushTRC(ctx, &cppb.PushTRCRequest{Trc: trc.Raw}, libgrpc.RetryProfile...)
+	return err
+}
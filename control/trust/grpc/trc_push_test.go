@@ -0,0 +1,138 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	trustgrpc "github.com/scionproto/scion/control/trust/grpc"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/trust/mock_trust"
+)
+
+func TestTRCPushServerPushTRC(t *testing.T) {
+	trc1 := xtest.LoadTRC(t, "testdata/ISD1-B1-S1.trc")
+	trc2 := xtest.LoadTRC(t, "testdata/ISD1-B1-S2.trc")
+	trc3 := xtest.LoadTRC(t, "testdata/ISD1-B1-S3.trc")
+	latestID := cppki.TRCID{ISD: 1, Base: scrypto.LatestVer, Serial: scrypto.LatestVer}
+
+	testCases := map[string]struct {
+		Pushed    []byte
+		DB        func(mctrl *gomock.Controller) *mock_trust.MockDB
+		Provider  func(mctrl *gomock.Controller) *mock_trust.MockProvider
+		Accepted  []cppki.TRCID
+		ErrAssert assert.ErrorAssertionFunc
+	}{
+		"update": {
+			Pushed: trc2.Raw,
+			DB: func(mctrl *gomock.Controller) *mock_trust.MockDB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), latestID).Return(trc1, nil)
+				db.EXPECT().InsertTRC(gomock.Any(), trc2).Return(true, nil)
+				return db
+			},
+			Provider:  mock_trust.NewMockProvider,
+			Accepted:  []cppki.TRCID{trc2.TRC.ID},
+			ErrAssert: assert.NoError,
+		},
+		"missing update": {
+			Pushed: trc3.Raw,
+			DB: func(mctrl *gomock.Controller) *mock_trust.MockDB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), latestID).Return(trc1, nil)
+				db.EXPECT().SignedTRC(gomock.Any(), trc2.TRC.ID).Return(trc2, nil)
+				db.EXPECT().InsertTRC(gomock.Any(), trc3).Return(true, nil)
+				return db
+			},
+			Provider: func(mctrl *gomock.Controller) *mock_trust.MockProvider {
+				p := mock_trust.NewMockProvider(mctrl)
+				p.EXPECT().NotifyTRC(gomock.Any(), trc2.TRC.ID).Return(nil)
+				return p
+			},
+			Accepted:  []cppki.TRCID{trc3.TRC.ID},
+			ErrAssert: assert.NoError,
+		},
+		"known": {
+			Pushed: trc1.Raw,
+			DB: func(mctrl *gomock.Controller) *mock_trust.MockDB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), latestID).Return(trc2, nil)
+				return db
+			},
+			Provider:  mock_trust.NewMockProvider,
+			ErrAssert: assert.NoError,
+		},
+		"not verifiable": {
+			Pushed: trc3.Raw,
+			DB: func(mctrl *gomock.Controller) *mock_trust.MockDB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), latestID).Return(trc1, nil)
+				db.EXPECT().SignedTRC(gomock.Any(), trc2.TRC.ID).Return(trc1, nil)
+				return db
+			},
+			Provider: func(mctrl *gomock.Controller) *mock_trust.MockProvider {
+				p := mock_trust.NewMockProvider(mctrl)
+				p.EXPECT().NotifyTRC(gomock.Any(), trc2.TRC.ID).Return(nil)
+				return p
+			},
+			ErrAssert: assert.Error,
+		},
+		"no local TRC": {
+			Pushed: trc2.Raw,
+			DB: func(mctrl *gomock.Controller) *mock_trust.MockDB {
+				db := mock_trust.NewMockDB(mctrl)
+				db.EXPECT().SignedTRC(gomock.Any(), latestID).Return(cppki.SignedTRC{}, nil)
+				return db
+			},
+			Provider:  mock_trust.NewMockProvider,
+			ErrAssert: assert.Error,
+		},
+		"garbage": {
+			Pushed:    []byte("garbage"),
+			DB:        mock_trust.NewMockDB,
+			Provider:  mock_trust.NewMockProvider,
+			ErrAssert: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			var accepted []cppki.TRCID
+			s := trustgrpc.TRCPushServer{
+				DB:       tc.DB(mctrl),
+				Provider: tc.Provider(mctrl),
+				IA:       addr.MustParseIA("1-ff00:0:111"),
+				Accepted: func(id cppki.TRCID) { accepted = append(accepted, id) },
+			}
+			rep, err := s.PushTRC(context.Background(), &cppb.PushTRCRequest{Trc: tc.Pushed})
+			tc.ErrAssert(t, err)
+			if err == nil {
+				require.NotNil(t, rep)
+			}
+			assert.Equal(t, tc.Accepted, accepted)
+		})
+	}
+}
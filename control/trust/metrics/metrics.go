@@ -35,6 +35,7 @@ const (
 const (
 	TRCReq   = "trc_request"
 	ChainReq = "chain_request"
+	TRCPush  = "trc_push"
 )
 
 // Result types
@@ -43,6 +44,7 @@ const (
 
 	ErrInternal = prom.ErrInternal
 	ErrParse    = prom.ErrParse
+	ErrVerify   = prom.ErrVerify
 )
 
 // Triggers
@@ -0,0 +1,259 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/trust"
+)
+
+const (
+	// DefaultRenewalMinBackoff is the default time to wait after the first failed renewal.
+	DefaultRenewalMinBackoff = time.Minute
+	// DefaultRenewalMaxBackoff is the default maximum time to wait between failed renewals.
+	DefaultRenewalMaxBackoff = time.Hour
+)
+
+// ChainRequester requests renewed certificate chains from a CA.
+type ChainRequester interface {
+	RequestChain(ctx context.Context, ca addr.IA,
+		req *cppb.ChainRenewalRequest) ([]*x509.Certificate, error)
+}
+
+// Renewer is a periodic task that renews the AS certificate before it expires.
+//
+// The renewal request contains a CSR for a fresh private key, and it is signed with the current
+// AS certificate and private key to prove their possession. The renewed certificate chain and
+// private key are written to the directory, from which they are picked up by the signers.
+// Failed renewals are retried with an exponential backoff.
+type Renewer struct {
+	// IA is the local ISD-AS.
+	IA addr.IA
+	// SignerGen generates the signers with the current AS certificates.
+	SignerGen SignerGen
+	// Requester requests the renewed certificate chains.
+	Requester ChainRequester
+	// CAs are the ISD-ASes of the CAs, in order of preference.
+	CAs []addr.IA
+	// TRCs provides the TRCs the renewed certificate chains are verified against.
+	TRCs renewal.TRCFetcher
+	// Dir is the directory the renewed certificate chains and private keys are written to.
+	Dir string
+	// LeadTime is the remaining validity of the AS certificate at which it is renewed. The
+	// certificate is not renewed before half of its validity has passed.
+	LeadTime time.Duration
+	// MinBackoff is the time to wait after the first failed renewal. If it is zero,
+	// DefaultRenewalMinBackoff is used.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum time to wait between failed renewals. If it is zero,
+	// DefaultRenewalMaxBackoff is used.
+	MaxBackoff time.Duration
+
+	backoff time.Duration
+	retry   time.Time
+}
+
+// Name returns the task name.
+func (r *Renewer) Name() string {
+	return "control_as_certificate_renewer"
+}
+
+// Run renews the AS certificate if it is due for renewal and no failed renewal is waiting to be
+// retried.
+func (r *Renewer) Run(ctx context.Context) {
+	now := time.Now()
+	if now.Before(r.retry) {
+		return
+	}
+	logger := log.FromCtx(ctx)
+	signers, err := r.SignerGen.Generate(ctx)
+	if err != nil {
+		logger.Info("Failed to generate signers for certificate renewal", "err", err)
+		return
+	}
+	signer, err := trust.LastExpiring(signers, cppki.Validity{NotBefore: now, NotAfter: now})
+	if err != nil {
+		logger.Error("No valid AS certificate to renew", "err", err)
+		return
+	}
+	if now.Before(r.renewalTime(signer.ChainValidity)) {
+		return
+	}
+	chain, err := r.renew(ctx, signer)
+	if err != nil {
+		r.backoff = r.nextBackoff()
+		r.retry = now.Add(r.backoff)
+		logger.Info("Failed to renew AS certificate", "retry", r.retry, "err", err)
+		return
+	}
+	r.backoff, r.retry = 0, time.Time{}
+	logger.Info("Renewed AS certificate",
+		"subject_key_id", chain[0].SubjectKeyId,
+		"validity", cppki.Validity{NotBefore: chain[0].NotBefore, NotAfter: chain[0].NotAfter},
+	)
+}
+
+// renewalTime returns the time at which a certificate with the validity is renewed.
+func (r *Renewer) renewalTime(validity cppki.Validity) time.Time {
+	half := validity.NotBefore.Add(validity.NotAfter.Sub(validity.NotBefore) / 2)
+	if t := validity.NotAfter.Add(-r.LeadTime); t.After(half) {
+		return t
+	}
+	return half
+}
+
+func (r *Renewer) nextBackoff() time.Duration {
+	minBackoff, maxBackoff := r.MinBackoff, r.MaxBackoff
+	if minBackoff == 0 {
+		minBackoff = DefaultRenewalMinBackoff
+	}
+	if maxBackoff == 0 {
+		maxBackoff = DefaultRenewalMaxBackoff
+	}
+	if r.backoff == 0 {
+		return minBackoff
+	}
+	return min(2*r.backoff, maxBackoff)
+}
+
+// renew requests a certificate chain for a fresh private key from the CAs, and writes the first
+// chain that is verifiable to the directory.
+func (r *Renewer) renew(ctx context.Context, signer trust.Signer) ([]*x509.Certificate, error) {
+	curve := elliptic.P256()
+	if pub, ok := signer.PrivateKey.Public().(*ecdsa.PublicKey); ok {
+		curve = pub.Curve
+	}
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, serrors.Wrap("generating private key", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		RawSubject: signer.Chain[0].RawSubject,
+	}, key)
+	if err != nil {
+		return nil, serrors.Wrap("creating CSR", err)
+	}
+	req, err := renewal.NewChainRenewalRequest(ctx, csr, signer)
+	if err != nil {
+		return nil, serrors.Wrap("signing renewal request", err)
+	}
+	var errs serrors.List
+	for _, ca := range r.CAs {
+		chain, err := r.Requester.RequestChain(ctx, ca, req)
+		if err != nil {
+			errs = append(errs, serrors.Wrap("requesting certificate chain", err, "ca", ca))
+			continue
+		}
+		if err := r.verify(ctx, chain, key); err != nil {
+			errs = append(errs, serrors.Wrap("verifying certificate chain", err, "ca", ca))
+			continue
+		}
+		if err := r.write(chain, key); err != nil {
+			return nil, err
+		}
+		return chain, nil
+	}
+	if len(errs) == 0 {
+		return nil, serrors.New("no CA configured")
+	}
+	return nil, errs.ToError()
+}
+
+// verify verifies that the chain is issued to the local AS for the key, and that it is
+// verifiable with the latest TRC or the TRC in grace period.
+func (r *Renewer) verify(ctx context.Context, chain []*x509.Certificate,
+	key *ecdsa.PrivateKey) error {
+
+	ia, err := cppki.ExtractIA(chain[0].Subject)
+	if err != nil {
+		return err
+	}
+	if !ia.Equal(r.IA) {
+		return serrors.New("certificate issued to other ISD-AS", "isd_as", ia)
+	}
+	if !key.PublicKey.Equal(chain[0].PublicKey) {
+		return serrors.New("certificate issued for other key")
+	}
+	latest, err := r.TRCs.SignedTRC(ctx, cppki.TRCID{
+		ISD:    r.IA.ISD(),
+		Base:   scrypto.LatestVer,
+		Serial: scrypto.LatestVer,
+	})
+	if err != nil {
+		return serrors.Wrap("loading latest TRC", err)
+	}
+	if latest.IsZero() {
+		return serrors.New("TRC not found", "isd", r.IA.ISD())
+	}
+	trcs := []*cppki.TRC{&latest.TRC}
+	if time.Now().Before(latest.TRC.GracePeriodEnd()) {
+		id := latest.TRC.ID
+		id.Serial--
+		if grace, err := r.TRCs.SignedTRC(ctx, id); err == nil && !grace.IsZero() {
+			trcs = append(trcs, &grace.TRC)
+		}
+	}
+	return cppki.VerifyChain(chain, cppki.VerifyOptions{TRC: trcs})
+}
+
+// write writes the private key and the chain to the directory. The key is written first, such
+// that the chain is never loaded without its key.
+func (r *Renewer) write(chain []*x509.Certificate, key *ecdsa.PrivateKey) error {
+	rawKey, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return serrors.Wrap("encoding private key", err)
+	}
+	var pemChain bytes.Buffer
+	for _, c := range chain {
+		if err := pem.Encode(&pemChain, &pem.Block{Type: "CERTIFICATE", Bytes: c.Raw}); err != nil {
+			return serrors.Wrap("encoding certificate chain", err)
+		}
+	}
+	name := filepath.Join(r.Dir, "renewed-"+chain[0].NotBefore.UTC().Format("20060102T150405Z"))
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rawKey})
+	if err := writeFile(name+".key", pemKey, 0600); err != nil {
+		return serrors.Wrap("writing private key", err)
+	}
+	if err := writeFile(name+".pem", pemChain.Bytes(), 0644); err != nil {
+		return serrors.Wrap("writing certificate chain", err)
+	}
+	return nil
+}
+
+// writeFile writes the file atomically, such that it is never loaded partially.
+func writeFile(name string, data []byte, perm os.FileMode) error {
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstrust "github.com/scionproto/scion/control/trust"
+	"github.com/scionproto/scion/control/trust/mock_trust"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/pkg/scrypto/signed"
+	"github.com/scionproto/scion/private/ca/renewal"
+	"github.com/scionproto/scion/private/trust"
+	mock_ptrust "github.com/scionproto/scion/private/trust/mock_trust"
+)
+
+// caRequester verifies the renewal requests and issues the certificate chains like a CA.
+type caRequester struct {
+	verifier renewal.RequestVerifier
+	ca       cppki.CAPolicy
+	failing  map[addr.IA]bool
+	requests []addr.IA
+}
+
+func (r *caRequester) RequestChain(
+	ctx context.Context,
+	ca addr.IA,
+	req *cppb.ChainRenewalRequest,
+) ([]*x509.Certificate, error) {
+
+	r.requests = append(r.requests, ca)
+	if r.failing[ca] {
+		return nil, serrors.New("unavailable")
+	}
+	csr, err := r.verifier.VerifyCMSSignedRenewalRequest(ctx, req.CmsSignedRequest)
+	if err != nil {
+		return nil, err
+	}
+	return r.ca.CreateChain(csr)
+}
+
+func TestRenewer(t *testing.T) {
+	dir := genCrypto(t)
+	ia := addr.MustParseIA("1-ff00:0:111")
+	ca1, ca2 := addr.MustParseIA("1-ff00:0:110"), addr.MustParseIA("1-ff00:0:120")
+	asDir := filepath.Join(dir, "ISD1/ASff00_0_111/crypto/as")
+	caDir := filepath.Join(dir, "ISD1/ASff00_0_110/crypto/ca")
+	trc := xtest.LoadTRC(t, filepath.Join(dir, "trcs/ISD1-B1-S1.trc"))
+	chain := xtest.LoadChain(t, filepath.Join(asDir, "ISD1-ASff00_0_111.pem"))
+	key := xtest.LoadSigner(t, filepath.Join(asDir, "cp-as.key"))
+	algo, err := signed.SelectSignatureAlgorithm(key.Public())
+	require.NoError(t, err)
+
+	// newSigner returns a signer with the AS certificate, which is treated as if it had the
+	// given validity.
+	newSigner := func(validity cppki.Validity) trust.Signer {
+		return trust.Signer{
+			PrivateKey:    key,
+			Algorithm:     algo,
+			IA:            ia,
+			Subject:       chain[0].Subject,
+			Chain:         chain,
+			SubjectKeyID:  chain[0].SubjectKeyId,
+			Expiration:    validity.NotAfter,
+			TRCID:         trc.TRC.ID,
+			ChainValidity: validity,
+		}
+	}
+	newRenewer := func(t *testing.T, signer trust.Signer,
+		requester *caRequester) *cstrust.Renewer {
+
+		ctrl := gomock.NewController(t)
+		gen := mock_trust.NewMockSignerGen(ctrl)
+		gen.EXPECT().Generate(gomock.Any()).Return([]trust.Signer{signer}, nil).AnyTimes()
+		db := mock_ptrust.NewMockDB(ctrl)
+		db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc, nil).AnyTimes()
+		requester.verifier = renewal.RequestVerifier{TRCFetcher: db}
+		requester.ca = cppki.CAPolicy{
+			Validity:    24 * time.Hour,
+			Certificate: xtest.LoadChain(t, filepath.Join(caDir, "ISD1-ASff00_0_110.ca.crt"))[0],
+			Signer:      xtest.LoadSigner(t, filepath.Join(caDir, "cp-ca.key")),
+		}
+		return &cstrust.Renewer{
+			IA:         ia,
+			SignerGen:  gen,
+			Requester:  requester,
+			CAs:        []addr.IA{ca2, ca1},
+			TRCs:       db,
+			Dir:        t.TempDir(),
+			LeadTime:   24 * time.Hour,
+			MinBackoff: time.Hour,
+		}
+	}
+	now := time.Now()
+
+	t.Run("not renewed before the lead time", func(t *testing.T) {
+		requester := &caRequester{}
+		r := newRenewer(t, newSigner(cppki.Validity{
+			NotBefore: now.Add(-time.Hour),
+			NotAfter:  now.Add(48 * time.Hour),
+		}), requester)
+		r.Run(context.Background())
+		assert.Empty(t, requester.requests)
+	})
+	t.Run("not renewed before half of the validity", func(t *testing.T) {
+		requester := &caRequester{}
+		r := newRenewer(t, newSigner(cppki.Validity{
+			NotBefore: now.Add(-time.Hour),
+			NotAfter:  now.Add(12 * time.Hour),
+		}), requester)
+		r.Run(context.Background())
+		assert.Empty(t, requester.requests)
+	})
+	t.Run("renewed with the next CA", func(t *testing.T) {
+		requester := &caRequester{failing: map[addr.IA]bool{ca2: true}}
+		r := newRenewer(t, newSigner(cppki.Validity{
+			NotBefore: now.Add(-48 * time.Hour),
+			NotAfter:  now.Add(time.Hour),
+		}), requester)
+		r.Run(context.Background())
+		assert.Equal(t, []addr.IA{ca2, ca1}, requester.requests)
+
+		files, err := filepath.Glob(filepath.Join(r.Dir, "*.pem"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		renewed := xtest.LoadChain(t, files[0])
+		assert.Equal(t, ia, xtest.MustExtractIA(t, renewed[0]))
+		keys, err := cstrust.LoadingRing{Dir: r.Dir}.PrivateKeys(context.Background())
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.True(t, keys[0].Public().(*ecdsa.PublicKey).Equal(renewed[0].PublicKey))
+		assert.False(t, keys[0].Public().(*ecdsa.PublicKey).Equal(key.Public()))
+	})
+	t.Run("failed renewal is retried after the backoff", func(t *testing.T) {
+		requester := &caRequester{failing: map[addr.IA]bool{ca1: true, ca2: true}}
+		r := newRenewer(t, newSigner(cppki.Validity{
+			NotBefore: now.Add(-48 * time.Hour),
+			NotAfter:  now.Add(time.Hour),
+		}), requester)
+		r.Run(context.Background())
+		r.Run(context.Background())
+		assert.Equal(t, []addr.IA{ca2, ca1}, requester.requests)
+
+		files, err := filepath.Glob(filepath.Join(r.Dir, "*"))
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/control/ifstate"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/trust"
+)
+
+// DefaultTRCPushTimeout is the default timeout for pushing a TRC to a child AS.
+const DefaultTRCPushTimeout = 5 * time.Second
+
+// TRCSender pushes a TRC to the control service of a neighboring AS.
+type TRCSender interface {
+	PushTRC(ctx context.Context, ia addr.IA, egress uint16, nextHop *net.UDPAddr,
+		trc cppki.SignedTRC) error
+}
+
+// TRCPusher is a periodic task that pushes the latest TRC of the local ISD to the control
+// services of the child ASes. The child ASes verify the TRC against its predecessor and push it
+// further down to their own children, such that TRC updates reach the whole ISD without waiting
+// for the ASes to request them.
+//
+// The TRC is pushed once to every child AS. Failed pushes are retried on the next run.
+type TRCPusher struct {
+	// IA is the local ISD-AS.
+	IA addr.IA
+	// DB provides the latest TRC.
+	DB trust.DB
+	// Sender pushes the TRC.
+	Sender TRCSender
+	// Children returns the interfaces to the child ASes.
+	Children func() []*ifstate.Interface
+	// Timeout is the timeout for pushing the TRC to a child AS. If it is zero,
+	// DefaultTRCPushTimeout is used.
+	Timeout time.Duration
+
+	// pushed is the ID of the TRC that was last pushed to each child AS.
+	pushed map[addr.IA]cppki.TRCID
+}
+
+// Name returns the task name.
+func (p *TRCPusher) Name() string {
+	return "control_trc_pusher"
+}
+
+// Run pushes the latest TRC to the child ASes that do not have it yet.
+func (p *TRCPusher) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	trc, err := p.DB.SignedTRC(ctx, cppki.TRCID{
+		ISD:    p.IA.ISD(),
+		Base:   scrypto.LatestVer,
+		Serial: scrypto.LatestVer,
+	})
+	if err != nil {
+		logger.Info("Failed to load latest TRC for pushing", "err", err)
+		return
+	}
+	if trc.IsZero() {
+		return
+	}
+	if p.pushed == nil {
+		p.pushed = make(map[addr.IA]cppki.TRCID)
+	}
+	for ia, intf := range p.childInterfaces() {
+		if p.pushed[ia] == trc.TRC.ID {
+			continue
+		}
+		info := intf.TopoInfo()
+		if err := p.push(ctx, ia, info, trc); err != nil {
+			logger.Info("Failed to push TRC", "id", trc.TRC.ID, "isd_as", ia,
+				"egress_interface", info.ID, "err", err)
+			continue
+		}
+		logger.Debug("Pushed TRC", "id", trc.TRC.ID, "isd_as", ia, "egress_interface", info.ID)
+		p.pushed[ia] = trc.TRC.ID
+	}
+}
+
+func (p *TRCPusher) push(
+	ctx context.Context,
+	ia addr.IA,
+	info ifstate.InterfaceInfo,
+	trc cppki.SignedTRC,
+) error {
+
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = DefaultTRCPushTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	nextHop := net.UDPAddrFromAddrPort(info.InternalAddr)
+	return p.Sender.PushTRC(ctx, ia, info.ID, nextHop, trc)
+}
+
+// childInterfaces returns one interface per child AS, the one with the lowest interface ID.
+func (p *TRCPusher) childInterfaces() map[addr.IA]*ifstate.Interface {
+	children := make(map[addr.IA]*ifstate.Interface)
+	for _, intf := range p.Children() {
+		info := intf.TopoInfo()
+		if other, ok := children[info.IA]; ok && other.TopoInfo().ID < info.ID {
+			continue
+		}
+		children[info.IA] = intf
+	}
+	return children
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/control/ifstate"
+	cstrust "github.com/scionproto/scion/control/trust"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/topology"
+	mock_ptrust "github.com/scionproto/scion/private/trust/mock_trust"
+)
+
+type push struct {
+	IA      addr.IA
+	Egress  uint16
+	NextHop *net.UDPAddr
+	ID      cppki.TRCID
+}
+
+// fakeSender records the pushes.
+type fakeSender struct {
+	failing map[addr.IA]bool
+	pushes  []push
+}
+
+func (s *fakeSender) PushTRC(
+	_ context.Context,
+	ia addr.IA,
+	egress uint16,
+	nextHop *net.UDPAddr,
+	trc cppki.SignedTRC,
+) error {
+
+	s.pushes = append(s.pushes, push{IA: ia, Egress: egress, NextHop: nextHop, ID: trc.TRC.ID})
+	if s.failing[ia] {
+		return serrors.New("unreachable")
+	}
+	return nil
+}
+
+func TestTRCPusher(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	child1, child2 := addr.MustParseIA("1-ff00:0:111"), addr.MustParseIA("1-ff00:0:112")
+	router := netip.MustParseAddrPort("127.0.0.1:30042")
+	intfs := ifstate.NewInterfaces(map[uint16]ifstate.InterfaceInfo{
+		3: {ID: 3, IA: child1, LinkType: topology.Child, InternalAddr: router},
+		1: {ID: 1, IA: child1, LinkType: topology.Child, InternalAddr: router},
+		2: {ID: 2, IA: child2, LinkType: topology.Child, InternalAddr: router},
+	}, ifstate.Config{})
+	children := func() []*ifstate.Interface {
+		return intfs.Filtered(func(*ifstate.Interface) bool { return true })
+	}
+	trc1 := cppki.SignedTRC{TRC: cppki.TRC{ID: cppki.TRCID{ISD: 1, Base: 1, Serial: 1}}}
+	trc2 := cppki.SignedTRC{TRC: cppki.TRC{ID: cppki.TRCID{ISD: 1, Base: 1, Serial: 2}}}
+	nextHop := net.UDPAddrFromAddrPort(router)
+
+	db := mock_ptrust.NewMockDB(mctrl)
+	sender := &fakeSender{failing: map[addr.IA]bool{child2: true}}
+	pusher := &cstrust.TRCPusher{
+		IA:       addr.MustParseIA("1-ff00:0:110"),
+		DB:       db,
+		Sender:   sender,
+		Children: children,
+	}
+	pushes := func() []push {
+		p := sender.pushes
+		sender.pushes = nil
+		return p
+	}
+
+	// The TRC is pushed over the interface with the lowest ID to each child.
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc1, nil)
+	pusher.Run(context.Background())
+	assert.ElementsMatch(t, []push{
+		{IA: child1, Egress: 1, NextHop: nextHop, ID: trc1.TRC.ID},
+		{IA: child2, Egress: 2, NextHop: nextHop, ID: trc1.TRC.ID},
+	}, pushes())
+
+	// Only the failed push is retried.
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc1, nil)
+	sender.failing = nil
+	pusher.Run(context.Background())
+	assert.Equal(t, []push{{IA: child2, Egress: 2, NextHop: nextHop, ID: trc1.TRC.ID}}, pushes())
+
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc1, nil)
+	pusher.Run(context.Background())
+	assert.Empty(t, pushes())
+
+	// A new TRC is pushed to all children.
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).Return(trc2, nil)
+	pusher.Run(context.Background())
+	assert.ElementsMatch(t, []push{
+		{IA: child1, Egress: 1, NextHop: nextHop, ID: trc2.TRC.ID},
+		{IA: child2, Egress: 2, NextHop: nextHop, ID: trc2.TRC.ID},
+	}, pushes())
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// vaultSignTimeout is the timeout of a signing request to Vault. The crypto.Signer interface
+// does not take a context.
+const vaultSignTimeout = 5 * time.Second
+
+// vaultHashes maps the hash functions to the names of the transit secrets engine.
+var vaultHashes = map[crypto.Hash]string{
+	crypto.SHA256: "sha2-256",
+	crypto.SHA384: "sha2-384",
+	crypto.SHA512: "sha2-512",
+}
+
+// VaultRing is a key ring that keeps the private keys in the transit secrets engine of
+// HashiCorp Vault. The keys never leave Vault, the signatures are created by it. Every version
+// of the keys is available, such that a rotated key can be used until the certificate of the
+// new version is issued.
+type VaultRing struct {
+	// Address is the address of Vault, e.g., https://vault.local:8200.
+	Address string
+	// Mount is the path the transit secrets engine is mounted at.
+	Mount string
+	// Keys are the names of the keys.
+	Keys []string
+	// TokenFile is the file with the token that authenticates the requests. It is read for
+	// every request, such that the token can be renewed by an agent.
+	TokenFile string
+	// Client is the HTTP client. If it is nil, http.DefaultClient is used, which verifies the
+	// certificate of Vault with the system roots. See NewVaultClient.
+	Client *http.Client
+}
+
+// NewVaultClient returns an HTTP client that verifies the certificate of Vault with the CA
+// certificates of the PEM file caFile instead of the system roots.
+func NewVaultClient(caFile string) (*http.Client, error) {
+	raw, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, serrors.Wrap("reading CA certificates", err, "file", caFile)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(raw) {
+		return nil, serrors.New("no CA certificate found", "file", caFile)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	return &http.Client{Transport: transport}, nil
+}
+
+// PrivateKeys returns a signer for every version of the keys.
+func (r VaultRing) PrivateKeys(ctx context.Context) ([]crypto.Signer, error) {
+	var signers []crypto.Signer
+	for _, name := range r.Keys {
+		var rep struct {
+			Data struct {
+				Keys map[string]struct {
+					PublicKey string `json:"public_key"`
+				} `json:"keys"`
+			} `json:"data"`
+		}
+		if err := r.do(ctx, http.MethodGet, "keys/"+name, nil, &rep); err != nil {
+			return nil, serrors.Wrap("reading key", err, "key", name)
+		}
+		for v, k := range rep.Data.Keys {
+			version, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, serrors.Wrap("parsing key version", err, "key", name)
+			}
+			block, _ := pem.Decode([]byte(k.PublicKey))
+			if block == nil {
+				return nil, serrors.New("no PEM public key", "key", name, "version", version)
+			}
+			pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, serrors.Wrap("parsing public key", err, "key", name,
+					"version", version)
+			}
+			signers = append(signers, vaultSigner{ring: r, name: name, version: version,
+				pub: pub})
+		}
+	}
+	return signers, nil
+}
+
+// do sends a request to the transit secrets engine and decodes the reply into rep.
+func (r VaultRing) do(ctx context.Context, method, path string, req, rep any) error {
+	token, err := os.ReadFile(r.TokenFile)
+	if err != nil {
+		return serrors.Wrap("reading token", err, "file", r.TokenFile)
+	}
+	var body io.Reader
+	if req != nil {
+		raw, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	}
+	url := strings.TrimSuffix(r.Address, "/") + "/v1/" + strings.Trim(r.Mount, "/") + "/" + path
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("X-Vault-Token", strings.TrimSpace(string(token)))
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpRep, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpRep.Body.Close()
+	if httpRep.StatusCode != http.StatusOK {
+		var e struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(httpRep.Body).Decode(&e)
+		return serrors.New("request failed", "status", httpRep.StatusCode, "errors", e.Errors)
+	}
+	return json.NewDecoder(httpRep.Body).Decode(rep)
+}
+
+// vaultSigner signs with a version of a key in the transit secrets engine.
+type vaultSigner struct {
+	ring    VaultRing
+	name    string
+	version int
+	pub     crypto.PublicKey
+}
+
+func (s vaultSigner) Public() crypto.PublicKey {
+	return s.pub
+}
+
+// Sign signs the digest with the key in Vault. The signature is ASN.1 encoded, as the one of
+// the ECDSA keys of the standard library.
+func (s vaultSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	hash, ok := vaultHashes[opts.HashFunc()]
+	if !ok {
+		return nil, serrors.New("unsupported hash function", "hash", opts.HashFunc())
+	}
+	req := map[string]any{
+		"input":                base64.StdEncoding.EncodeToString(digest),
+		"prehashed":            true,
+		"key_version":          s.version,
+		"marshaling_algorithm": "asn1",
+	}
+	var rep struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), vaultSignTimeout)
+	defer cancel()
+	if err := s.ring.do(ctx, http.MethodPost, "sign/"+s.name+"/"+hash, req, &rep); err != nil {
+		return nil, serrors.Wrap("signing", err, "key", s.name, "version", s.version)
+	}
+	// The signature has the format vault:v<version>:<base64 signature>.
+	parts := strings.Split(rep.Data.Signature, ":")
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, serrors.New("malformed signature", "key", s.name, "version", s.version)
+	}
+	return base64.StdEncoding.DecodeString(parts[2])
+}
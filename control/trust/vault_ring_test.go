@@ -0,0 +1,144 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/trust"
+)
+
+func TestVaultRing(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rawPub, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rawPub})
+
+	// The server emulates the transit secrets engine with a single version of the key.
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/transit/keys/cp-as", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"keys": map[string]any{"2": map[string]any{"public_key": string(pubPEM)}},
+			},
+		})
+	})
+	mux.HandleFunc("POST /v1/transit/sign/cp-as/sha2-256",
+		func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Input      string `json:"input"`
+				Prehashed  bool   `json:"prehashed"`
+				KeyVersion int    `json:"key_version"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.True(t, req.Prehashed)
+			assert.Equal(t, 2, req.KeyVersion)
+			digest, err := base64.StdEncoding.DecodeString(req.Input)
+			require.NoError(t, err)
+			sig, err := ecdsa.SignASN1(rand.Reader, key, digest)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"signature": "vault:v2:" + base64.StdEncoding.EncodeToString(sig),
+				},
+			})
+		},
+	)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0600))
+	ring := trust.VaultRing{
+		Address:   srv.URL,
+		Mount:     "transit",
+		Keys:      []string{"cp-as"},
+		TokenFile: tokenFile,
+	}
+	signers, err := ring.PrivateKeys(context.Background())
+	require.NoError(t, err)
+	require.Len(t, signers, 1)
+	assert.True(t, key.PublicKey.Equal(signers[0].Public()))
+
+	digest := sha256.Sum256([]byte("beacon"))
+	sig, err := signers[0].Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig))
+
+	_, err = signers[0].Sign(rand.Reader, digest[:], crypto.SHA1)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("expired"), 0600))
+	_, err = ring.PrivateKeys(context.Background())
+	assert.Error(t, err)
+}
+
+func TestNewVaultClient(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/transit/keys/cp-as", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"keys":{}}}`))
+	})
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret"), 0600))
+	caFile := filepath.Join(dir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0600))
+
+	ring := trust.VaultRing{
+		Address:   srv.URL,
+		Mount:     "transit",
+		Keys:      []string{"cp-as"},
+		TokenFile: tokenFile,
+	}
+	// The certificate of the server is not signed by the system roots.
+	_, err := ring.PrivateKeys(context.Background())
+	assert.Error(t, err)
+
+	ring.Client, err = trust.NewVaultClient(caFile)
+	require.NoError(t, err)
+	signers, err := ring.PrivateKeys(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, signers)
+
+	_, err = trust.NewVaultClient(tokenFile)
+	assert.Error(t, err)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	cs "github.com/scionproto/scion/control"
+	"github.com/scionproto/scion/control/config"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/private/app/command"
 	"github.com/scionproto/scion/private/storage/trust/sqlite"
@@ -35,11 +36,10 @@ func TestNewSigner(t *testing.T) {
 	db, err := sqlite.New("file::memory:")
 	require.NoError(t, err)
 
-	signer := cs.NewSigner(
-		addr.MustParseIA("1-ff00:0:110"),
-		db,
-		filepath.Join(dir, "/ISD1/ASff00_0_110"),
-	)
+	cfgDir := filepath.Join(dir, "/ISD1/ASff00_0_110")
+	keyRings, err := cs.NewKeyRings(config.Keys{Backend: config.KeyBackendFile}, cfgDir)
+	require.NoError(t, err)
+	signer := cs.NewSigner(addr.MustParseIA("1-ff00:0:110"), db, cfgDir, keyRings.AS)
 
 	_, err = signer.Sign(context.Background(), []byte("message"))
 	require.NoError(t, err)
@@ -0,0 +1,151 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth restricts the sensitive calls of the daemon API to authorized applications.
+//
+// Applications are authorized either by one of the configured tokens, which they send in the
+// "authorization" metadata of their requests as "Bearer <token>", or, if they connect over the
+// Unix domain socket of the daemon, by the user or group ID of their process. The IDs are
+// obtained from the kernel with SO_PEERCRED, see PeerCredentials.
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"os"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// MetadataKey is the key of the request metadata that holds the token.
+	MetadataKey = "authorization"
+	// TokenPrefix precedes the token in the metadata value.
+	TokenPrefix = "Bearer "
+)
+
+// SensitiveMethods are the daemon API methods that require authorization. They hand out
+// DRKeys, or change the state of the daemon for all applications.
+var SensitiveMethods = []string{
+	"/proto.daemon.v1.DaemonService/DRKeyASHost",
+	"/proto.daemon.v1.DaemonService/DRKeyHostAS",
+	"/proto.daemon.v1.DaemonService/DRKeyHostHost",
+	"/proto.daemon.v1.DaemonService/ImportPathDB",
+	"/proto.daemon.v1.DaemonService/NotifyInterfaceDown",
+	"/proto.daemon.v1.DaemonService/ReportPathError",
+	"/proto.daemon.v1.DaemonService/SetPathOverrides",
+}
+
+// Authorizer authorizes the applications that call sensitive methods.
+type Authorizer struct {
+	// Tokens are the tokens of the authorized applications.
+	Tokens []string
+	// UIDs are the user IDs of the authorized applications.
+	UIDs []uint32
+	// GIDs are the group IDs of the authorized applications.
+	GIDs []uint32
+}
+
+// Authorize checks that the caller of the request with the given context is authorized. The
+// error is a gRPC status error with the code Unauthenticated or PermissionDenied.
+func (a *Authorizer) Authorize(ctx context.Context) error {
+	if token, ok := tokenFromContext(ctx); ok {
+		if a.validToken(token) {
+			return nil
+		}
+		return status.Error(codes.PermissionDenied, "invalid token")
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(PeerInfo); ok && info.Known {
+			if slices.Contains(a.UIDs, info.UID) || slices.Contains(a.GIDs, info.GID) {
+				return nil
+			}
+			return status.Error(codes.PermissionDenied, "process not authorized")
+		}
+	}
+	return status.Error(codes.Unauthenticated, "authorization required")
+}
+
+// UnaryServerInterceptor returns an interceptor that authorizes the calls of sensitive
+// methods. The calls of other methods are not checked.
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+
+		if slices.Contains(SensitiveMethods, info.FullMethod) {
+			if err := a.Authorize(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authorizer) validToken(token string) bool {
+	valid := false
+	for _, t := range a.Tokens {
+		// Compare with all tokens in constant time, to not leak which prefix matched.
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+func tokenFromContext(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return "", false
+	}
+	return strings.TrimPrefix(values[0], TokenPrefix), true
+}
+
+// LoadTokens loads the tokens from the given file, one token per line. Empty lines and lines
+// starting with # are ignored.
+func LoadTokens(file string) ([]string, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, serrors.Wrap("reading tokens", err, "file", file)
+	}
+	var tokens []string
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens = append(tokens, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, serrors.Wrap("parsing tokens", err, "file", file)
+	}
+	if len(tokens) == 0 {
+		return nil, serrors.New("no tokens", "file", file)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/daemon/auth"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+)
+
+func TestAuthorize(t *testing.T) {
+	a := &auth.Authorizer{
+		Tokens: []string{"secret", "other"},
+		UIDs:   []uint32{1000},
+		GIDs:   []uint32{100},
+	}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(),
+			metadata.Pairs(auth.MetadataKey, auth.TokenPrefix+token))
+	}
+	withPeer := func(info auth.PeerInfo) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+	}
+	testCases := map[string]struct {
+		ctx  context.Context
+		code codes.Code
+	}{
+		"valid token": {
+			ctx:  withToken("other"),
+			code: codes.OK,
+		},
+		"invalid token": {
+			ctx:  withToken("secre"),
+			code: codes.PermissionDenied,
+		},
+		"authorized uid": {
+			ctx:  withPeer(auth.PeerInfo{Known: true, UID: 1000, GID: 1000}),
+			code: codes.OK,
+		},
+		"authorized gid": {
+			ctx:  withPeer(auth.PeerInfo{Known: true, UID: 1001, GID: 100}),
+			code: codes.OK,
+		},
+		"unauthorized process": {
+			ctx:  withPeer(auth.PeerInfo{Known: true, UID: 1001, GID: 1001}),
+			code: codes.PermissionDenied,
+		},
+		"unknown process": {
+			ctx:  withPeer(auth.PeerInfo{}),
+			code: codes.Unauthenticated,
+		},
+		"no credentials": {
+			ctx:  context.Background(),
+			code: codes.Unauthenticated,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := a.Authorize(tc.ctx)
+			assert.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "sd.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	a := &auth.Authorizer{Tokens: []string{"secret"}}
+	server := grpc.NewServer(
+		grpc.Creds(auth.PeerCredentials{}),
+		grpc.UnaryInterceptor(a.UnaryServerInterceptor()),
+	)
+	sdpb.RegisterDaemonServiceServer(server, &fakeServer{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	connect := func(t *testing.T, token string) daemon.Connector {
+		conn, err := daemon.Service{Address: "unix://" + socket, Token: token}.Connect(
+			context.Background())
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	ctx := context.Background()
+
+	t.Run("not sensitive", func(t *testing.T) {
+		ia, err := connect(t, "").LocalIA(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, addr.MustParseIA("1-ff00:0:110"), ia)
+	})
+	t.Run("no token", func(t *testing.T) {
+		// On Linux, the process is known but not authorized.
+		expected := codes.PermissionDenied
+		if runtime.GOOS != "linux" {
+			expected = codes.Unauthenticated
+		}
+		err := connect(t, "").RevNotification(ctx, &path_mgmt.RevInfo{})
+		assert.Equal(t, expected, status.Code(err))
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		err := connect(t, "wrong").RevNotification(ctx, &path_mgmt.RevInfo{})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("valid token", func(t *testing.T) {
+		err := connect(t, "secret").RevNotification(ctx, &path_mgmt.RevInfo{})
+		assert.NoError(t, err)
+	})
+	t.Run("peer credentials", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("peer credentials are only supported on Linux")
+		}
+		a.UIDs = []uint32{uint32(os.Getuid())}
+		defer func() { a.UIDs = nil }()
+		err := connect(t, "").RevNotification(ctx, &path_mgmt.RevInfo{})
+		assert.NoError(t, err)
+	})
+}
+
+type fakeServer struct {
+	sdpb.UnimplementedDaemonServiceServer
+}
+
+func (*fakeServer) AS(context.Context, *sdpb.ASRequest) (*sdpb.ASResponse, error) {
+	return &sdpb.ASResponse{IsdAs: uint64(addr.MustParseIA("1-ff00:0:110"))}, nil
+}
+
+func (*fakeServer) NotifyInterfaceDown(context.Context,
+	*sdpb.NotifyInterfaceDownRequest) (*sdpb.NotifyInterfaceDownResponse, error) {
+
+	return &sdpb.NotifyInterfaceDownResponse{}, nil
+}
+
+func TestLoadTokens(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+		return file
+	}
+
+	tokens, err := auth.LoadTokens(write("tokens", "# app1\nsecret\n\n  other  \n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"secret", "other"}, tokens)
+
+	_, err = auth.LoadTokens(write("empty", "# no tokens\n"))
+	assert.Error(t, err)
+	_, err = auth.LoadTokens(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// PeerInfo is the authentication information of a connection to the daemon.
+type PeerInfo struct {
+	credentials.CommonAuthInfo
+	// Known indicates that the IDs of the peer process are known. They are only known for
+	// connections over Unix domain sockets, on Linux.
+	Known bool
+	// UID is the user ID of the peer process.
+	UID uint32
+	// GID is the group ID of the peer process.
+	GID uint32
+}
+
+// AuthType returns the authentication type.
+func (PeerInfo) AuthType() string {
+	return "peercred"
+}
+
+// PeerCredentials are the transport credentials of the daemon server. Like the insecure
+// credentials, they do not secure the connections. They only obtain the user and group IDs of
+// the processes that connect over Unix domain sockets, which the Authorizer checks.
+type PeerCredentials struct{}
+
+func (PeerCredentials) ClientHandshake(
+	ctx context.Context,
+	authority string,
+	conn net.Conn,
+) (net.Conn, credentials.AuthInfo, error) {
+
+	return conn, newPeerInfo(), nil
+}
+
+func (PeerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	info := newPeerInfo()
+	if unixConn, ok := conn.(*net.UnixConn); ok {
+		var err error
+		info.UID, info.GID, info.Known, err = peerIDs(unixConn)
+		if err != nil {
+			return nil, nil, serrors.Wrap("getting peer credentials", err)
+		}
+	}
+	return conn, info, nil
+}
+
+func (PeerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "insecure"}
+}
+
+func (PeerCredentials) Clone() credentials.TransportCredentials {
+	return PeerCredentials{}
+}
+
+func (PeerCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+func newPeerInfo() PeerInfo {
+	return PeerInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package auth
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerIDs returns the user and group ID of the peer process of the connection.
+func peerIDs(conn *net.UnixConn) (uint32, uint32, bool, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	var cred *unix.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if credErr != nil {
+		return 0, 0, false, credErr
+	}
+	return cred.Uid, cred.Gid, true, nil
+}
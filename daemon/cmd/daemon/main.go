@@ -16,10 +16,12 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
+	"net/netip"
 	"path/filepath"
 	"time"
 
@@ -30,30 +32,39 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/resolver"
 
 	"github.com/scionproto/scion/daemon"
+	"github.com/scionproto/scion/daemon/auth"
 	"github.com/scionproto/scion/daemon/config"
 	sd_drkey "github.com/scionproto/scion/daemon/drkey"
 	sd_grpc "github.com/scionproto/scion/daemon/drkey/grpc"
 	"github.com/scionproto/scion/daemon/fetcher"
+	"github.com/scionproto/scion/daemon/internal/servers"
 	api "github.com/scionproto/scion/daemon/mgmtapi"
+	"github.com/scionproto/scion/daemon/probe"
+	nameresolver "github.com/scionproto/scion/daemon/resolver"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
 	hpgrpc "github.com/scionproto/scion/pkg/experimental/hiddenpath/grpc"
 	libgrpc "github.com/scionproto/scion/pkg/grpc"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
 	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
 	"github.com/scionproto/scion/pkg/scrypto/cppki"
 	"github.com/scionproto/scion/pkg/scrypto/signed"
+	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/private/app"
 	"github.com/scionproto/scion/private/app/launcher"
+	"github.com/scionproto/scion/private/env"
 	cppkiapi "github.com/scionproto/scion/private/mgmtapi/cppki/api"
 	segapi "github.com/scionproto/scion/private/mgmtapi/segments/api"
+	"github.com/scionproto/scion/private/path/pathpol"
 	"github.com/scionproto/scion/private/pathdb"
 	"github.com/scionproto/scion/private/periodic"
 	"github.com/scionproto/scion/private/revcache"
@@ -71,6 +82,9 @@ import (
 	trustmetrics "github.com/scionproto/scion/private/trust/metrics"
 )
 
+// topologyWatchInterval is the interval at which the topology files are checked for changes.
+const topologyWatchInterval = 10 * time.Second
+
 var globalCfg config.Config
 
 func main() {
@@ -85,11 +99,13 @@ func main() {
 }
 
 func realMain(ctx context.Context) error {
+	topoMetrics := loaderMetrics()
 	topo, err := topology.NewLoader(topology.LoaderCfg{
-		File:      globalCfg.General.Topology(),
-		Reload:    app.SIGHUPChannel(ctx),
-		Validator: &topology.DefaultValidator{},
-		Metrics:   loaderMetrics(),
+		File:          globalCfg.General.Topology(),
+		Reload:        app.SIGHUPChannel(ctx),
+		WatchInterval: topologyWatchInterval,
+		Validator:     &topology.DefaultValidator{},
+		Metrics:       topoMetrics,
 	})
 	if err != nil {
 		return serrors.Wrap("creating topology loader", err)
@@ -106,7 +122,30 @@ func realMain(ctx context.Context) error {
 	}
 	defer closer.Close()
 
-	revCache := storage.NewRevocationStorage()
+	// The applications are notified about every revocation that is inserted into the
+	// revocation cache, and the tracked paths through the revoked interfaces are marked as
+	// unreachable.
+	revocationFeed := &servers.RevocationFeed{}
+	var liveness *probe.Tracker
+	if probeCfg := globalCfg.SD.Probe; probeCfg.Enable {
+		liveness = &probe.Tracker{
+			Prober: probe.SCMPProber{
+				LocalIA:  topo.IA(),
+				Topology: adaptTopology(topo),
+			},
+			Unreachable: revocationFeed.Unreachable,
+		}
+		prober := periodic.Start(liveness, probeCfg.Interval.Duration, probeCfg.Timeout.Duration)
+		defer prober.Stop()
+	}
+	revCache := revcache.WithNotify(storage.NewRevocationStorage(),
+		func(rev *path_mgmt.RevInfo) {
+			revocationFeed.Revoked(rev)
+			if liveness != nil {
+				liveness.Revoke(rev.IA(), rev.IfID)
+			}
+		},
+	)
 	pathDB, err := storage.NewPathStorage(globalCfg.PathDB)
 	if err != nil {
 		return serrors.Wrap("initializing path storage", err)
@@ -180,6 +219,7 @@ func realMain(ctx context.Context) error {
 	defer trcLoaderTask.Stop()
 
 	var drkeyClientEngine *sd_drkey.ClientEngine
+	var level2DB *level2.Database
 	if globalCfg.DRKeyLevel2DB.Connection != "" {
 		backend, err := storage.NewDRKeyLevel2Storage(globalCfg.DRKeyLevel2DB)
 		if err != nil {
@@ -194,7 +234,7 @@ func realMain(ctx context.Context) error {
 				[]string{"operation", prom.LabelResult},
 			),
 		)
-		level2DB := &level2.Database{
+		level2DB = &level2.Database{
 			Backend: backend,
 			Metrics: &level2.Metrics{
 				QueriesTotal: func(op, label string) metrics.Counter {
@@ -224,78 +264,202 @@ func realMain(ctx context.Context) error {
 		}
 	}
 
-	listen := daemon.APIAddress(globalCfg.SD.Address)
-	listener, err := net.Listen("tcp", listen)
-	if err != nil {
-		return serrors.Wrap("listening", err)
+	var serverTLS *tls.Config
+	if tlsCfg := globalCfg.SD.TLS; tlsCfg.Enabled() {
+		serverTLS, err = daemon.NewTLSConfig(tlsCfg.CertFile, tlsCfg.KeyFile,
+			tlsCfg.ClientCAFile)
+		if err != nil {
+			return serrors.Wrap("loading TLS configuration", err)
+		}
+	}
+	listeners := make(map[string]net.Listener)
+	for _, l := range globalCfg.SD.APIListeners() {
+		listen := daemon.APIAddress(l.Address)
+		listener, err := net.Listen("tcp", listen)
+		if err != nil {
+			return serrors.Wrap("listening", err, "addr", listen)
+		}
+		defer listener.Close()
+		if l.TLS {
+			// TLS is terminated below gRPC, so that the same server also serves the Unix
+			// domain socket with the peer credentials.
+			listener = tls.NewListener(listener, serverTLS)
+			log.Info("Serving the API with TLS", "addr", listen)
+		}
+		listeners[listen] = listener
 	}
 
-	hpGroups, err := hiddenpath.LoadHiddenPathGroups(globalCfg.SD.HiddenPathGroups)
+	pathPolicies, err := daemon.LoadPathPolicies(globalCfg.SD.PathPolicies)
 	if err != nil {
-		return serrors.Wrap("loading hidden path groups", err)
+		return serrors.Wrap("loading path policies", err)
 	}
+	nameResolver := daemon.NewNameResolver(globalCfg.SD.Names.HostsFile,
+		!globalCfg.SD.Names.DisableDNS)
 	var requester segfetcher.RPC = &segfetchergrpc.Requester{
 		Dialer: dialer,
 	}
-	if len(hpGroups) > 0 {
+	if hpLocation := globalCfg.SD.HiddenPathGroups; hpLocation != "" {
+		hpGroups, err := hiddenpath.NewGroupsReloader(hpLocation)
+		if err != nil {
+			return serrors.Wrap("loading hidden path groups", err)
+		}
+		hpReloader := periodic.Start(hpGroups, 10*time.Second, 10*time.Second)
+		defer hpReloader.Stop()
+		g.Go(func() error {
+			defer log.HandlePanic()
+			reload := app.SIGHUPChannel(errCtx)
+			for {
+				select {
+				case <-errCtx.Done():
+					return nil
+				case <-reload:
+					hpReloader.TriggerRun()
+				}
+			}
+		})
 		requester = &hpgrpc.Requester{
 			RegularLookup: requester,
-			HPGroups:      hpGroups,
+			Groups:        hpGroups.Groups,
 			Dialer:        dialer,
 		}
 	}
 
-	createVerifier := func() infra.Verifier {
-		if globalCfg.SD.DisableSegVerification {
-			return acceptAllVerifier{}
-		}
-		return compat.Verifier{Verifier: trust.Verifier{
-			Engine:             engine,
-			Cache:              globalCfg.TrustEngine.Cache.New(),
-			CacheHits:          metrics.NewPromCounter(trustmetrics.CacheHitsTotal),
-			MaxCacheExpiration: globalCfg.TrustEngine.Cache.Expiration.Duration,
-		}}
-	}
-
-	server := grpc.NewServer(
+	serverOpts := []grpc.ServerOption{
+		grpc.Creds(auth.PeerCredentials{}),
 		libgrpc.UnaryServerInterceptor(),
 		libgrpc.DefaultMaxConcurrentStreams(),
+	}
+	var authorizer *auth.Authorizer
+	if authCfg := globalCfg.SD.Auth; authCfg.Enabled() {
+		authorizer = &auth.Authorizer{UIDs: authCfg.UIDs, GIDs: authCfg.GIDs}
+		if authCfg.TokenFile != "" {
+			if authorizer.Tokens, err = auth.LoadTokens(authCfg.TokenFile); err != nil {
+				return serrors.Wrap("loading API tokens", err)
+			}
+		}
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(authorizer.UnaryServerInterceptor()))
+	}
+	server := grpc.NewServer(serverOpts...)
+	pathFetcher := fetcher.NewFetcher(
+		fetcher.FetcherConfig{
+			IA:         topo.IA(),
+			MTU:        topo.MTU(),
+			Core:       topo.Core(),
+			NextHopper: topo,
+			RPC:        requester,
+			PathDB:     pathDB,
+			Inspector:  engine,
+			Verifier:   newVerifier(engine),
+			RevCache:   revCache,
+			Cfg:        globalCfg.SD,
+			Metrics:    daemon.PathLookupMetrics(),
+		},
 	)
-	sdpb.RegisterDaemonServiceServer(server, daemon.NewServer(
+	if dsts := globalCfg.SD.Prefetch.Destinations; len(dsts) > 0 {
+		prefetcher := periodic.Start(&fetcher.Prefetcher{
+			Fetcher:      pathFetcher,
+			Destinations: dsts,
+		}, 10*time.Second, 10*time.Second)
+		defer prefetcher.Stop()
+	}
+	if !globalCfg.SD.Prefetch.DisableWarmUp {
+		g.Go(func() error {
+			defer log.HandlePanic()
+			warmUpPaths(errCtx, pathDB, pathFetcher, topo.IA())
+			return nil
+		})
+	}
+
+	// The path overrides are set by the operators through the API, they apply to the paths of
+	// all local ASes and they are not persisted.
+	pathOverrides := &servers.PathOverrides{}
+	// The path errors reported by the applications likewise apply to the paths of all local
+	// ASes.
+	pathErrors := &servers.PathErrors{}
+	daemonServer := daemon.NewServer(
 		daemon.ServerConfig{
-			IA:       topo.IA(),
-			MTU:      topo.MTU(),
-			Topology: topo,
-			Fetcher: fetcher.NewFetcher(
-				fetcher.FetcherConfig{
-					IA:         topo.IA(),
-					MTU:        topo.MTU(),
-					Core:       topo.Core(),
-					NextHopper: topo,
-					RPC:        requester,
-					PathDB:     pathDB,
-					Inspector:  engine,
-					Verifier:   createVerifier(),
-					RevCache:   revCache,
-					Cfg:        globalCfg.SD,
-				},
-			),
-			Engine:      engine,
-			RevCache:    revCache,
-			DRKeyClient: drkeyClientEngine,
+			IA:           topo.IA(),
+			MTU:          topo.MTU(),
+			Topology:     topo,
+			Fetcher:      pathFetcher,
+			Engine:       engine,
+			RevCache:     revCache,
+			DRKeyClient:  drkeyClientEngine,
+			Resolver:     nameResolver,
+			PathPolicies: pathPolicies,
+			Liveness:     liveness,
+			Revocations:  revocationFeed,
+			Overrides:    pathOverrides,
+			PathErrors:   pathErrors,
+			PathDB:       pathDB,
+
+			AllowPathDBImport: globalCfg.SD.AllowPathDBImport,
 		},
-	))
+	)
+	g.Go(func() error {
+		defer log.HandlePanic()
+		notifyTopologyChanges(errCtx, topo, daemonServer.TopologyChanged)
+		return nil
+	})
+	var apiServer sdpb.DaemonServiceServer = daemonServer
+	if dirs := globalCfg.SD.AdditionalConfigDirs; len(dirs) > 0 {
+		ases := map[addr.IA]sdpb.DaemonServiceServer{topo.IA(): daemonServer}
+		shared := sharedComponents{
+			PathDB:        pathDB,
+			TrustDB:       trustDB,
+			RevCache:      revCache,
+			Revocations:   revocationFeed,
+			Overrides:     pathOverrides,
+			PathErrors:    pathErrors,
+			Level2DB:      level2DB,
+			NameResolver:  nameResolver,
+			PathPolicies:  pathPolicies,
+			LoaderMetrics: topoMetrics,
+		}
+		for _, dir := range dirs {
+			ia, s, stop, err := newAdditionalASServer(errCtx, g, dir, shared)
+			if err != nil {
+				return serrors.Wrap("creating server of additional AS", err, "config_dir", dir)
+			}
+			defer stop()
+			if _, ok := ases[ia]; ok {
+				return serrors.New("local AS configured twice", "isd_as", ia, "config_dir", dir)
+			}
+			ases[ia] = s
+			log.Info("Serving additional local AS", "isd_as", ia, "config_dir", dir)
+		}
+		apiServer = daemon.NewMultiASServer(topo.IA(), ases)
+	}
+	sdpb.RegisterDaemonServiceServer(server, apiServer)
 
 	promgrpc.Register(server)
+	// Reflection lets generic tools, e.g., grpcurl, discover the services of the daemon.
+	reflection.Register(server)
 
 	var cleanup app.Cleanup
-	g.Go(func() error {
-		defer log.HandlePanic()
-		if err := server.Serve(listener); err != nil {
-			return serrors.Wrap("serving gRPC API", err, "addr", listen)
+	for listen, listener := range listeners {
+		g.Go(func() error {
+			defer log.HandlePanic()
+			if err := server.Serve(listener); err != nil {
+				return serrors.Wrap("serving gRPC API", err, "addr", listen)
+			}
+			return nil
+		})
+	}
+	if socket := globalCfg.SD.Socket; socket != "" {
+		unixListener, err := daemon.ListenUnix(socket, globalCfg.SD.SocketMode)
+		if err != nil {
+			return serrors.Wrap("listening on unix socket", err)
 		}
-		return nil
-	})
+		g.Go(func() error {
+			defer log.HandlePanic()
+			if err := server.Serve(unixListener); err != nil {
+				return serrors.Wrap("serving gRPC API", err, "socket", socket)
+			}
+			return nil
+		})
+	}
 	cleanup.Add(func() error { server.GracefulStop(); return nil })
 
 	if globalCfg.API.Addr != "" {
@@ -312,9 +476,12 @@ func realMain(ctx context.Context) error {
 			CPPKIServer: cppkiapi.Server{
 				TrustDB: trustDB,
 			},
-			Config:   service.NewConfigStatusPage(globalCfg).Handler,
-			Info:     service.NewInfoStatusPage().Handler,
-			LogLevel: service.NewLogLevelStatusPage().Handler,
+			Config:     service.NewConfigStatusPage(globalCfg).Handler,
+			Info:       service.NewInfoStatusPage().Handler,
+			LogLevel:   service.NewLogLevelStatusPage().Handler,
+			IA:         topo.IA(),
+			Daemon:     apiServer,
+			Authorizer: authorizer,
 		}
 		log.Info("Exposing API", "addr", globalCfg.API.Addr)
 		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
@@ -378,6 +545,158 @@ func (v acceptAllVerifier) WithValidity(cppki.Validity) infra.Verifier {
 	return v
 }
 
+func newVerifier(engine trust.Engine) infra.Verifier {
+	if globalCfg.SD.DisableSegVerification {
+		return acceptAllVerifier{}
+	}
+	return compat.Verifier{Verifier: trust.Verifier{
+		Engine:             engine,
+		Cache:              globalCfg.TrustEngine.Cache.New(),
+		CacheHits:          metrics.NewPromCounter(trustmetrics.CacheHitsTotal),
+		MaxCacheExpiration: globalCfg.TrustEngine.Cache.Expiration.Duration,
+	}}
+}
+
+// sharedComponents are the components of the daemon that the servers of all local ASes share.
+// The databases and the revocation cache are keyed by ISD-AS, and the path database keys the
+// queries by their source AS.
+type sharedComponents struct {
+	PathDB        pathdb.DB
+	TrustDB       storage.TrustDB
+	RevCache      revcache.RevCache
+	Revocations   *servers.RevocationFeed
+	Overrides     *servers.PathOverrides
+	PathErrors    *servers.PathErrors
+	Level2DB      *level2.Database
+	NameResolver  nameresolver.Resolver
+	PathPolicies  map[string]*pathpol.Policy
+	LoaderMetrics topology.LoaderMetrics
+}
+
+// newAdditionalASServer creates the server of an additional local AS, from the topology and the
+// certificates in its configuration directory. The background tasks of the server run in the
+// group until the context is canceled, or until stop is called. Hidden paths, path prefetching
+// and path probing are only supported for the primary AS.
+func newAdditionalASServer(
+	ctx context.Context,
+	g *errgroup.Group,
+	cfgDir string,
+	shared sharedComponents,
+) (addr.IA, sdpb.DaemonServiceServer, func(), error) {
+
+	topo, err := topology.NewLoader(topology.LoaderCfg{
+		File:          filepath.Join(cfgDir, env.TopologyFile),
+		Reload:        app.SIGHUPChannel(ctx),
+		WatchInterval: topologyWatchInterval,
+		Validator:     &topology.DefaultValidator{},
+		Metrics:       shared.LoaderMetrics,
+	})
+	if err != nil {
+		return 0, nil, nil, serrors.Wrap("creating topology loader", err)
+	}
+	g.Go(func() error {
+		defer log.HandlePanic()
+		return topo.Run(ctx)
+	})
+	dialer := &libgrpc.TCPDialer{
+		SvcResolver: func(dst addr.SVC) []resolver.Address {
+			var targets []resolver.Address
+			for _, entry := range topo.ControlServiceAddresses() {
+				targets = append(targets, resolver.Address{Addr: entry.String()})
+			}
+			return targets
+		},
+	}
+	engine, err := daemon.TrustEngine(cfgDir, topo.IA(), shared.TrustDB, dialer)
+	if err != nil {
+		return 0, nil, nil, serrors.Wrap("creating trust engine", err)
+	}
+	engine.Inspector = trust.CachingInspector{
+		Inspector:          engine.Inspector,
+		Cache:              globalCfg.TrustEngine.Cache.New(),
+		CacheHits:          metrics.NewPromCounter(trustmetrics.CacheHitsTotal),
+		MaxCacheExpiration: globalCfg.TrustEngine.Cache.Expiration.Duration,
+	}
+	trcLoader := trust.TRCLoader{
+		Dir: filepath.Join(cfgDir, "certs"),
+		DB:  shared.TrustDB,
+	}
+	trcLoaderTask := periodic.Start(periodic.Func{
+		Task: func(ctx context.Context) {
+			res, err := trcLoader.Load(ctx)
+			if err != nil {
+				log.SafeInfo(log.FromCtx(ctx), "TRC loading failed", "err", err)
+			}
+			if len(res.Loaded) > 0 {
+				log.SafeInfo(log.FromCtx(ctx), "Loaded TRCs from disk", "trcs", res.Loaded)
+			}
+		},
+		TaskName: "daemon_trc_loader_" + topo.IA().String(),
+	}, 10*time.Second, 10*time.Second)
+
+	var drkeyClientEngine *sd_drkey.ClientEngine
+	if shared.Level2DB != nil {
+		drkeyClientEngine = &sd_drkey.ClientEngine{
+			IA:      topo.IA(),
+			DB:      shared.Level2DB,
+			Fetcher: &sd_grpc.Fetcher{Dialer: dialer},
+		}
+	}
+	server := daemon.NewServer(
+		daemon.ServerConfig{
+			IA:       topo.IA(),
+			MTU:      topo.MTU(),
+			Topology: topo,
+			Fetcher: fetcher.NewFetcher(
+				fetcher.FetcherConfig{
+					IA:         topo.IA(),
+					MTU:        topo.MTU(),
+					Core:       topo.Core(),
+					NextHopper: topo,
+					RPC:        &segfetchergrpc.Requester{Dialer: dialer},
+					PathDB:     shared.PathDB,
+					Inspector:  engine,
+					Verifier:   newVerifier(engine),
+					RevCache:   shared.RevCache,
+					Cfg:        globalCfg.SD,
+					Metrics:    daemon.PathLookupMetrics(),
+				},
+			),
+			Engine:            engine,
+			RevCache:          shared.RevCache,
+			DRKeyClient:       drkeyClientEngine,
+			Resolver:          shared.NameResolver,
+			PathPolicies:      shared.PathPolicies,
+			Revocations:       shared.Revocations,
+			Overrides:         shared.Overrides,
+			PathErrors:        shared.PathErrors,
+			PathDB:            shared.PathDB,
+			AllowPathDBImport: globalCfg.SD.AllowPathDBImport,
+		},
+	)
+	g.Go(func() error {
+		defer log.HandlePanic()
+		notifyTopologyChanges(ctx, topo, server.TopologyChanged)
+		return nil
+	})
+	return topo.IA(), server, trcLoaderTask.Stop, nil
+}
+
+// notifyTopologyChanges calls notify whenever the topology is reloaded, until the context is
+// canceled.
+func notifyTopologyChanges(ctx context.Context, topo *topology.Loader, notify func()) {
+	sub := topo.Subscribe()
+	defer sub.Close()
+	for {
+		select {
+		case <-sub.Updates:
+			notify()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func loaderMetrics() topology.LoaderMetrics {
 	updates := prom.NewCounterVec("", "",
 		"topology_updates_total",
@@ -397,3 +716,39 @@ func loaderMetrics() topology.LoaderMetrics {
 		Updates: metrics.NewPromCounter(updates).With(prom.LabelResult, prom.Success),
 	}
 }
+
+// warmUpPaths fetches the paths to the destinations of the segments that are cached in the path
+// database once, e.g., after a restart of the daemon. Only the stale paths are fetched from the
+// control service, the others are served from the path database.
+func warmUpPaths(ctx context.Context, db pathdb.DB, f fetcher.Fetcher, local addr.IA) {
+	ctx, cancelF := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelF()
+	dsts, err := fetcher.CachedDestinations(ctx, db, local)
+	if err != nil {
+		log.Info("Failed to load the cached destinations", "err", err)
+		return
+	}
+	if len(dsts) == 0 {
+		return
+	}
+	log.Info("Warming up the paths to the cached destinations", "destinations", len(dsts))
+	(&fetcher.Prefetcher{Fetcher: f, Destinations: dsts}).Run(ctx)
+}
+
+func adaptTopology(topo *topology.Loader) snet.Topology {
+	start, end := topo.PortRange()
+	return snet.Topology{
+		LocalIA: topo.IA(),
+		PortRange: snet.TopologyPortRange{
+			Start: start,
+			End:   end,
+		},
+		Interface: func(ifID uint16) (netip.AddrPort, bool) {
+			a := topo.UnderlayNextHop(ifID)
+			if a == nil {
+				return netip.AddrPort{}, false
+			}
+			return a.AddrPort(), true
+		},
+	}
+}
@@ -18,8 +18,10 @@ package config
 import (
 	"fmt"
 	"io"
+	"os"
 	"time"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/daemon"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
@@ -33,6 +35,10 @@ import (
 
 var (
 	DefaultQueryInterval = 5 * time.Minute
+	DefaultProbeInterval = 30 * time.Second
+	DefaultProbeTimeout  = time.Second
+	DefaultSocketMode    = os.FileMode(0660)
+	DefaultHostsFile     = "/etc/scion/hosts"
 )
 
 var _ config.Config = (*Config)(nil)
@@ -121,38 +127,323 @@ type SDConfig struct {
 	// Address is the local address to listen on for SCION messages, and to send out messages to
 	// other nodes.
 	Address string `toml:"address,omitempty"`
+	// Listen are the addresses on which the API is exposed, instead of Address, e.g., the
+	// loopback address and a management address with TLS.
+	Listen []ListenConfig `toml:"listen,omitempty"`
+	// Socket is the path of a Unix domain socket on which the API is exposed in addition to
+	// Address. If empty, the API is not exposed on a Unix domain socket.
+	Socket string `toml:"socket,omitempty"`
+	// SocketMode is the file mode of the Unix domain socket. Only the users that have write
+	// permission on the socket can use the API over it.
+	SocketMode os.FileMode `toml:"socket_mode,omitempty"`
 	// DisableSegVerification indicates that segment verification should be
 	// disabled.
 	DisableSegVerification bool `toml:"disable_seg_verification,omitempty"`
+	// AllowPathDBImport allows importing snapshots of the path database through the API, e.g.,
+	// in a test daemon that reproduces a failing path lookup. The imported path segments are
+	// not verified.
+	AllowPathDBImport bool `toml:"allow_path_db_import,omitempty"`
 	// QueryInterval specifies after how much time segments
 	// for a destination should be refetched.
 	QueryInterval util.DurWrap `toml:"query_interval,omitempty"`
+	// QueryIntervalOverrides override the query interval for the destinations that match their
+	// pattern. The first matching override applies.
+	QueryIntervalOverrides []QueryIntervalOverride `toml:"query_interval_overrides,omitempty"`
 	// HiddenPathGroup is a file that contains the hiddenpath groups.
 	// If HiddenPathGroups begins with http:// or https://, it will be fetched
-	// over the network from the specified URL instead.
+	// over the network from the specified URL instead. The groups are reloaded
+	// when they change.
 	HiddenPathGroups string `toml:"hidden_path_groups,omitempty"`
+	// PathPolicies is a JSON file that contains the path policies, keyed by their name, that
+	// applications can refer to in their path requests.
+	PathPolicies string `toml:"path_policies,omitempty"`
+	// AdditionalConfigDirs are the configuration directories of the additional local ASes that
+	// the daemon serves, e.g., on a host that is attached to several ASes. Each directory
+	// contains the topology and the certificates of an AS, like the general configuration
+	// directory for the primary AS.
+	AdditionalConfigDirs []string `toml:"additional_config_dirs,omitempty"`
+	// Prefetch configures the destinations whose paths are fetched in the background.
+	Prefetch PrefetchConfig `toml:"prefetch,omitempty"`
+	// Probe configures the probing of the paths returned to applications.
+	Probe ProbeConfig `toml:"probe,omitempty"`
+	// Auth configures the applications that are authorized to make sensitive calls.
+	Auth AuthConfig `toml:"auth,omitempty"`
+	// Names configures the resolution of host names to SCION addresses.
+	Names NamesConfig `toml:"names,omitempty"`
+	// TLS configures TLS on the API address, for the hosts that use the daemon remotely.
+	TLS TLSConfig `toml:"tls,omitempty"`
 }
 
 func (cfg *SDConfig) InitDefaults() {
 	if cfg.Address == "" {
 		cfg.Address = daemon.DefaultAPIAddress
 	}
+	if cfg.SocketMode == 0 {
+		cfg.SocketMode = DefaultSocketMode
+	}
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	config.InitAll(&cfg.Probe, &cfg.Names)
 }
 
 func (cfg *SDConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("QueryInterval must not be zero")
 	}
-	return nil
+	for _, o := range cfg.QueryIntervalOverrides {
+		if o.QueryInterval.Duration <= 0 {
+			return serrors.New("query interval override must be positive",
+				"destination", o.Destination, "query_interval", o.QueryInterval)
+		}
+	}
+	for _, l := range cfg.Listen {
+		if l.Address == "" {
+			return serrors.New("listen address must not be empty")
+		}
+		if l.TLS && !cfg.TLS.Enabled() {
+			return serrors.New("listen address with TLS requires a TLS certificate",
+				"address", l.Address)
+		}
+	}
+	for _, dir := range cfg.AdditionalConfigDirs {
+		if dir == "" {
+			return serrors.New("additional config dir must not be empty")
+		}
+	}
+	if cfg.SocketMode&^os.ModePerm != 0 {
+		return serrors.New("socket mode must only contain permission bits",
+			"socket_mode", fmt.Sprintf("%#o", uint32(cfg.SocketMode)))
+	}
+	return config.ValidateAll(&cfg.Prefetch, &cfg.Probe, &cfg.Auth, &cfg.Names, &cfg.TLS)
 }
 
 func (cfg *SDConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, sdSample)
+	config.WriteSample(dst, path, ctx, &cfg.Prefetch, &cfg.Probe, &cfg.Auth, &cfg.Names,
+		&cfg.TLS)
 }
 
 func (cfg *SDConfig) ConfigName() string {
 	return "sd"
 }
+
+// ListenConfig is an address on which the API is exposed.
+type ListenConfig struct {
+	// Address is the address to listen on. If it has no port, the default port of the API is
+	// used.
+	Address string `toml:"address"`
+	// TLS serves the address with TLS, with the certificate configured in TLSConfig.
+	TLS bool `toml:"tls,omitempty"`
+}
+
+// APIListeners returns the addresses on which the API is exposed, i.e., the listen addresses if
+// any are configured, or Address with TLS if a TLS certificate is configured.
+func (cfg *SDConfig) APIListeners() []ListenConfig {
+	if len(cfg.Listen) > 0 {
+		return cfg.Listen
+	}
+	return []ListenConfig{{Address: cfg.Address, TLS: cfg.TLS.Enabled()}}
+}
+
+// QueryIntervalFor returns the query interval for the segment requests to dst, i.e., the query
+// interval of the first override that matches dst, or the default query interval.
+func (cfg *SDConfig) QueryIntervalFor(dst addr.IA) time.Duration {
+	for _, o := range cfg.QueryIntervalOverrides {
+		if o.Matches(dst) {
+			return o.QueryInterval.Duration
+		}
+	}
+	return cfg.QueryInterval.Duration
+}
+
+// QueryIntervalOverride overrides the query interval for the destinations that match an ISD-AS
+// pattern, e.g., to refresh the paths to critical peers more often.
+type QueryIntervalOverride struct {
+	// Destination is the ISD-AS pattern of the destinations. The ISD or AS number 0 matches
+	// any, e.g., "2-0" matches all ASes of ISD 2.
+	Destination addr.IA `toml:"destination"`
+	// QueryInterval is the time after which the segments for the destinations are refetched.
+	QueryInterval util.DurWrap `toml:"query_interval"`
+}
+
+// Matches returns whether the destination matches the pattern of the override.
+func (o QueryIntervalOverride) Matches(dst addr.IA) bool {
+	if o.Destination.ISD() != 0 && o.Destination.ISD() != dst.ISD() {
+		return false
+	}
+	return o.Destination.AS() == 0 || o.Destination.AS() == dst.AS()
+}
+
+var _ config.Config = (*PrefetchConfig)(nil)
+
+// PrefetchConfig lists the destinations whose paths the daemon keeps warm, so that the path
+// requests of applications do not wait on segment lookups. The segments are refetched after
+// the query interval, like for the requests of applications.
+type PrefetchConfig struct {
+	config.NoDefaulter
+	// Destinations are the destination ASes.
+	Destinations []addr.IA `toml:"destinations,omitempty"`
+	// DisableWarmUp disables fetching the paths to the destinations of the segments that are
+	// cached in the path database once on startup.
+	DisableWarmUp bool `toml:"disable_warm_up,omitempty"`
+}
+
+func (cfg *PrefetchConfig) Validate() error {
+	for _, dst := range cfg.Destinations {
+		if dst.IsWildcard() {
+			return serrors.New("prefetch destination must not be a wildcard", "dst", dst)
+		}
+	}
+	return nil
+}
+
+func (cfg *PrefetchConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, prefetchSample)
+}
+
+func (cfg *PrefetchConfig) ConfigName() string {
+	return "prefetch"
+}
+
+var _ config.Config = (*ProbeConfig)(nil)
+
+// ProbeConfig configures the probing of the paths returned to applications. The paths are
+// probed in the background, and annotated with the results of their latest probes, so that
+// applications can pick paths that are known to be alive.
+type ProbeConfig struct {
+	// Enable enables the probing.
+	Enable bool `toml:"enable,omitempty"`
+	// Interval is the interval at which the paths are probed.
+	Interval util.DurWrap `toml:"interval,omitempty"`
+	// Timeout is the time to wait for the replies to the probes.
+	Timeout util.DurWrap `toml:"timeout,omitempty"`
+}
+
+func (cfg *ProbeConfig) InitDefaults() {
+	if cfg.Interval.Duration == 0 {
+		cfg.Interval.Duration = DefaultProbeInterval
+	}
+	if cfg.Timeout.Duration == 0 {
+		cfg.Timeout.Duration = DefaultProbeTimeout
+	}
+}
+
+func (cfg *ProbeConfig) Validate() error {
+	if cfg.Timeout.Duration > cfg.Interval.Duration {
+		return serrors.New("probe timeout must not exceed the interval",
+			"timeout", cfg.Timeout, "interval", cfg.Interval)
+	}
+	return nil
+}
+
+func (cfg *ProbeConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, probeSample)
+}
+
+func (cfg *ProbeConfig) ConfigName() string {
+	return "probe"
+}
+
+var _ config.Config = (*AuthConfig)(nil)
+
+// AuthConfig configures the applications that are authorized to make the sensitive calls of the
+// daemon API, i.e., to get DRKeys and to notify the daemon of interfaces that are down. If
+// nothing is configured, all applications are authorized.
+type AuthConfig struct {
+	config.NoDefaulter
+	config.NoValidator
+	// TokenFile is the file that contains the tokens of the authorized applications, one token
+	// per line.
+	TokenFile string `toml:"token_file,omitempty"`
+	// UIDs are the user IDs of the authorized applications that connect over the Unix domain
+	// socket.
+	UIDs []uint32 `toml:"uids,omitempty"`
+	// GIDs are the group IDs of the authorized applications that connect over the Unix domain
+	// socket.
+	GIDs []uint32 `toml:"gids,omitempty"`
+}
+
+// Enabled returns whether the sensitive calls require authorization.
+func (cfg *AuthConfig) Enabled() bool {
+	return cfg.TokenFile != "" || len(cfg.UIDs) > 0 || len(cfg.GIDs) > 0
+}
+
+func (cfg *AuthConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, authSample)
+}
+
+func (cfg *AuthConfig) ConfigName() string {
+	return "auth"
+}
+
+var _ config.Config = (*NamesConfig)(nil)
+
+// NamesConfig configures the resolution of host names to SCION addresses. The names are looked
+// up in the hosts file first, and then in the TXT records of the DNS.
+type NamesConfig struct {
+	config.NoValidator
+	// HostsFile is the hosts file that maps SCION addresses to names, in the format of
+	// /etc/hosts. If the file does not exist, no names are resolved from it.
+	HostsFile string `toml:"hosts_file,omitempty"`
+	// DisableDNS disables the resolution of names from the DNS.
+	DisableDNS bool `toml:"disable_dns,omitempty"`
+}
+
+func (cfg *NamesConfig) InitDefaults() {
+	if cfg.HostsFile == "" {
+		cfg.HostsFile = DefaultHostsFile
+	}
+}
+
+func (cfg *NamesConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, namesSample)
+}
+
+func (cfg *NamesConfig) ConfigName() string {
+	return "names"
+}
+
+var _ config.Config = (*TLSConfig)(nil)
+
+// TLSConfig configures TLS on the API address, so that the hosts that do not run a daemon
+// themselves, e.g., small devices, can use the daemon of another host over the network. The Unix
+// domain socket is not affected. If no certificate is configured, the API address is served
+// without TLS. If listen addresses are configured, only the ones with TLS set are served with
+// TLS.
+type TLSConfig struct {
+	config.NoDefaulter
+	// CertFile is the PEM file with the certificate chain of the daemon.
+	CertFile string `toml:"cert_file,omitempty"`
+	// KeyFile is the PEM file with the private key of the certificate.
+	KeyFile string `toml:"key_file,omitempty"`
+	// ClientCAFile is the PEM file with the certificates of the CAs that issue the client
+	// certificates. If it is set, the clients must present a certificate that is issued by one
+	// of them.
+	ClientCAFile string `toml:"client_ca_file,omitempty"`
+}
+
+// Enabled returns whether the API address is served with TLS.
+func (cfg *TLSConfig) Enabled() bool {
+	return cfg.CertFile != ""
+}
+
+func (cfg *TLSConfig) Validate() error {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return serrors.New("TLS certificate and key must be set together",
+			"cert_file", cfg.CertFile, "key_file", cfg.KeyFile)
+	}
+	if cfg.ClientCAFile != "" && cfg.CertFile == "" {
+		return serrors.New("TLS client CA requires a certificate",
+			"client_ca_file", cfg.ClientCAFile)
+	}
+	return nil
+}
+
+func (cfg *TLSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, tlsSample)
+}
+
+func (cfg *TLSConfig) ConfigName() string {
+	return "tls"
+}
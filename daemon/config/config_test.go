@@ -17,12 +17,15 @@ package config
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/pelletier/go-toml/v2"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/daemon"
 	"github.com/scionproto/scion/pkg/log/logtest"
+	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/private/env/envtest"
 	apitest "github.com/scionproto/scion/private/mgmtapi/mgmtapitest"
 	storagetest "github.com/scionproto/scion/private/storage/test"
@@ -48,6 +51,7 @@ func InitTestConfig(cfg *Config) {
 
 func InitTestSDConfig(cfg *SDConfig) {
 	cfg.Address = "garbage"
+	cfg.SocketMode = 0777
 	cfg.DisableSegVerification = true
 }
 
@@ -62,6 +66,96 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 
 func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	assert.Equal(t, daemon.DefaultAPIAddress, cfg.Address)
+	assert.Empty(t, cfg.Listen)
+	assert.Empty(t, cfg.Socket)
+	assert.Equal(t, DefaultSocketMode, cfg.SocketMode)
 	assert.False(t, cfg.DisableSegVerification)
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.Empty(t, cfg.QueryIntervalOverrides)
+	assert.Empty(t, cfg.AdditionalConfigDirs)
+	assert.Empty(t, cfg.Prefetch.Destinations)
+	assert.False(t, cfg.Prefetch.DisableWarmUp)
+	assert.False(t, cfg.Probe.Enable)
+	assert.Equal(t, DefaultProbeInterval, cfg.Probe.Interval.Duration)
+	assert.Equal(t, DefaultProbeTimeout, cfg.Probe.Timeout.Duration)
+	assert.False(t, cfg.Auth.Enabled())
+	assert.Equal(t, DefaultHostsFile, cfg.Names.HostsFile)
+	assert.False(t, cfg.Names.DisableDNS)
+	assert.False(t, cfg.TLS.Enabled())
+}
+
+func TestQueryIntervalFor(t *testing.T) {
+	cfg := SDConfig{
+		QueryInterval: util.DurWrap{Duration: 5 * time.Minute},
+		QueryIntervalOverrides: []QueryIntervalOverride{
+			{
+				Destination:   addr.MustParseIA("1-ff00:0:110"),
+				QueryInterval: util.DurWrap{Duration: 10 * time.Second},
+			},
+			{
+				Destination:   addr.MustParseIA("1-0"),
+				QueryInterval: util.DurWrap{Duration: time.Minute},
+			},
+			{
+				Destination:   addr.MustParseIA("0-ff00:0:210"),
+				QueryInterval: util.DurWrap{Duration: 2 * time.Minute},
+			},
+		},
+	}
+	testCases := map[string]struct {
+		Dst      addr.IA
+		Expected time.Duration
+	}{
+		"exact":        {Dst: addr.MustParseIA("1-ff00:0:110"), Expected: 10 * time.Second},
+		"wildcard AS":  {Dst: addr.MustParseIA("1-ff00:0:111"), Expected: time.Minute},
+		"wildcard ISD": {Dst: addr.MustParseIA("2-ff00:0:210"), Expected: 2 * time.Minute},
+		"no match":     {Dst: addr.MustParseIA("2-ff00:0:211"), Expected: 5 * time.Minute},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, cfg.QueryIntervalFor(tc.Dst))
+		})
+	}
+
+	t.Run("invalid override", func(t *testing.T) {
+		var invalid SDConfig
+		invalid.InitDefaults()
+		invalid.QueryIntervalOverrides = []QueryIntervalOverride{
+			{Destination: addr.MustParseIA("1-0")},
+		}
+		assert.Error(t, invalid.Validate())
+	})
+}
+
+func TestAPIListeners(t *testing.T) {
+	t.Run("address", func(t *testing.T) {
+		var cfg SDConfig
+		cfg.InitDefaults()
+		assert.Equal(t, []ListenConfig{{Address: daemon.DefaultAPIAddress}}, cfg.APIListeners())
+		cfg.TLS = TLSConfig{CertFile: "cert.pem", KeyFile: "key.pem"}
+		assert.Equal(t, []ListenConfig{{Address: daemon.DefaultAPIAddress, TLS: true}},
+			cfg.APIListeners())
+	})
+	t.Run("listen addresses", func(t *testing.T) {
+		var cfg SDConfig
+		cfg.InitDefaults()
+		cfg.TLS = TLSConfig{CertFile: "cert.pem", KeyFile: "key.pem"}
+		cfg.Listen = []ListenConfig{
+			{Address: "127.0.0.1:30255"},
+			{Address: "10.0.0.1:30255", TLS: true},
+		}
+		assert.NoError(t, cfg.Validate())
+		assert.Equal(t, cfg.Listen, cfg.APIListeners())
+	})
+	t.Run("invalid listen addresses", func(t *testing.T) {
+		for name, listen := range map[string]ListenConfig{
+			"empty address":      {},
+			"TLS without a cert": {Address: "10.0.0.1:30255", TLS: true},
+		} {
+			var cfg SDConfig
+			cfg.InitDefaults()
+			cfg.Listen = []ListenConfig{listen}
+			assert.Error(t, cfg.Validate(), name)
+		}
+	})
 }
@@ -20,13 +20,120 @@ const sdSample = `
 # Address where the SCION Daemon server API is exposed. (default 127.0.0.1:30255)
 address = "127.0.0.1:30255"
 
+# The addresses where the SCION Daemon server API is exposed, instead of the
+# address above, e.g., the loopback address and a management address with TLS:
+# [{ address = "127.0.0.1:30255" }, { address = "10.0.0.1:30255", tls = true }].
+# The addresses with TLS use the certificate of the tls section. (default [])
+listen = []
+
+# Path of a Unix domain socket where the SCION Daemon server API is exposed in
+# addition to the address above. Clients connect to it with the address
+# "unix:///path/to/socket". (default "")
+socket = ""
+
+# File mode of the Unix domain socket. Only the local users with write
+# permission on the socket can use the API over it. (default 0o660)
+socket_mode = 0o660
+
 # Disable segment verification of the daemon. This can be done if it runs in
 # the same trust zone as the control service. (default false)
 disable_seg_verification = false
 
+# Allow importing snapshots of the path database through the API, e.g., in a
+# test daemon that reproduces a failing path lookup. The imported path segments
+# are not verified. (default false)
+allow_path_db_import = false
+
 # The time after which segments for a destination are refetched. (default 5m)
 query_interval = "5m"
 
-# The configuration containing hidden path groups. (default "")
+# Overrides of the query interval for the destinations that match an ISD-AS
+# pattern, e.g., to refresh the paths to critical peers more often. The ISD or
+# AS number 0 matches any, and the first matching override applies, e.g.,
+# [{ destination = "1-ff00:0:110", query_interval = "30s" }]. (default [])
+query_interval_overrides = []
+
+# The YAML file containing the hidden path groups, or an http:// or https:// URL
+# to fetch it from. The groups are reloaded when the file changes, it is checked
+# every 10 seconds and on SIGHUP. (default "")
 hidden_path_groups =  ""
+
+# The JSON file containing the path policies, keyed by their name, that
+# applications can refer to in their path requests. (default "")
+path_policies = ""
+
+# The configuration directories of the additional local ASes that the daemon
+# serves, e.g., on a host that is attached to several ASes. Each directory
+# contains the topology.json and the certs directory of an AS. Applications
+# select the local AS of their requests, otherwise the requests are served for
+# the AS of the general configuration directory. (default [])
+additional_config_dirs = []
+`
+
+const prefetchSample = `
+# The destination ASes whose paths are fetched in the background, e.g.,
+# ["1-ff00:0:110"]. (default [])
+destinations = []
+
+# Disable fetching the paths to the destinations of the segments that are cached
+# in the path database once on startup. The path database persists the segments
+# across restarts, if it is stored in a file. (default false)
+disable_warm_up = false
+`
+
+const probeSample = `
+# Probe the paths returned to applications in the background with SCMP, and
+# annotate them with their reachability and round trip time. (default false)
+enable = false
+
+# The interval at which the paths are probed. (default 30s)
+interval = "30s"
+
+# The time to wait for the replies to the probes. (default 1s)
+timeout = "1s"
+`
+
+const authSample = `
+# The file containing the tokens of the applications that are authorized to get
+# DRKeys and to notify the daemon of interfaces that are down, one token per
+# line. The applications send their token with their requests. If neither
+# tokens nor IDs are configured, all applications are authorized. (default "")
+token_file = ""
+
+# The user IDs of the authorized applications that connect over the Unix domain
+# socket. (default [])
+uids = []
+
+# The group IDs of the authorized applications that connect over the Unix domain
+# socket. (default [])
+gids = []
+`
+
+const namesSample = `
+# The hosts file that maps SCION addresses to host names, in the format of
+# /etc/hosts, e.g., "1-ff00:0:110,10.0.0.1 server.example.org". Names are looked
+# up in it before the DNS. The file is read again when it changes.
+# (default "/etc/scion/hosts")
+hosts_file = "/etc/scion/hosts"
+
+# Disable the resolution of host names from TXT records of the form
+# "scion=1-ff00:0:110,10.0.0.1" in the DNS. (default false)
+disable_dns = false
+`
+
+const tlsSample = `
+# The PEM file with the certificate chain of the daemon. If it is set, the API
+# address is served with TLS, so that hosts without a daemon can use this one
+# remotely with the daemon address "tls://host:port". If listen addresses are
+# configured, only the ones with tls = true are served with TLS. The Unix domain
+# socket is not affected. The file is read again when it changes. (default "")
+cert_file = ""
+
+# The PEM file with the private key of the certificate. (default "")
+key_file = ""
+
+# The PEM file with the certificates of the CAs that issue the client
+# certificates. If it is set, the clients must present a certificate issued by
+# one of them. (default "")
+client_ca_file = ""
 `
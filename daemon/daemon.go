@@ -16,11 +16,15 @@ package daemon
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
+	"io/fs"
 	"net"
+	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
@@ -28,6 +32,8 @@ import (
 	"github.com/scionproto/scion/daemon/drkey"
 	"github.com/scionproto/scion/daemon/fetcher"
 	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/daemon/probe"
+	"github.com/scionproto/scion/daemon/resolver"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/daemon"
 	libgrpc "github.com/scionproto/scion/pkg/grpc"
@@ -35,8 +41,12 @@ import (
 	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
 	"github.com/scionproto/scion/private/env"
+	"github.com/scionproto/scion/private/path/pathpol"
+	"github.com/scionproto/scion/private/pathdb"
 	"github.com/scionproto/scion/private/revcache"
+	"github.com/scionproto/scion/private/segment/segfetcher"
 	"github.com/scionproto/scion/private/trust"
 	trustgrpc "github.com/scionproto/scion/private/trust/grpc"
 	trustmetrics "github.com/scionproto/scion/private/trust/metrics"
@@ -106,109 +116,229 @@ func TrustEngine(
 	}, nil
 }
 
+// LoadPathPolicies loads the path policies from the JSON file, keyed by their name. Policies
+// can extend the other policies in the file. If file is empty, no policies are loaded.
+func LoadPathPolicies(file string) (map[string]*pathpol.Policy, error) {
+	if file == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, serrors.Wrap("reading path policies", err, "file", file)
+	}
+	var policyMap pathpol.PolicyMap
+	if err := json.Unmarshal(raw, &policyMap); err != nil {
+		return nil, serrors.Wrap("parsing path policies", err, "file", file)
+	}
+	extended := make([]*pathpol.ExtPolicy, 0, len(policyMap))
+	for name, ext := range policyMap {
+		if ext == nil {
+			ext = &pathpol.ExtPolicy{}
+			policyMap[name] = ext
+		}
+		if ext.Policy == nil {
+			ext.Policy = &pathpol.Policy{}
+		}
+		ext.Name = name
+		extended = append(extended, ext)
+	}
+	policies := make(map[string]*pathpol.Policy, len(policyMap))
+	for name, ext := range policyMap {
+		policy, err := pathpol.PolicyFromExtPolicy(ext, extended)
+		if err != nil {
+			return nil, serrors.Wrap("compiling path policy", err, "policy", name)
+		}
+		policies[name] = policy
+	}
+	return policies, nil
+}
+
+// NewNameResolver creates the resolver of host names. It looks up the names in the hosts file, if
+// any, and then in the DNS, if enabled. It returns nil if neither is configured.
+func NewNameResolver(hostsFile string, dns bool) resolver.Resolver {
+	var resolvers resolver.Resolvers
+	if hostsFile != "" {
+		resolvers = append(resolvers, &resolver.HostsFile{Path: hostsFile})
+	}
+	if dns {
+		resolvers = append(resolvers, resolver.DNS{})
+	}
+	if len(resolvers) == 0 {
+		return nil
+	}
+	return resolvers
+}
+
 // ServerConfig is the configuration for the daemon API server.
 type ServerConfig struct {
-	IA          addr.IA
-	MTU         uint16
-	Fetcher     fetcher.Fetcher
-	RevCache    revcache.RevCache
-	Engine      trust.Engine
-	Topology    servers.Topology
-	DRKeyClient *drkey.ClientEngine
+	IA           addr.IA
+	MTU          uint16
+	Fetcher      fetcher.Fetcher
+	RevCache     revcache.RevCache
+	Engine       trust.Engine
+	Topology     servers.Topology
+	DRKeyClient  *drkey.ClientEngine
+	PathPolicies map[string]*pathpol.Policy
+	// Resolver resolves the host names. If nil, name resolution is not supported.
+	Resolver resolver.Resolver
+	// Liveness probes the returned paths. If nil, the paths are not probed.
+	Liveness *probe.Tracker
+	// Revocations distributes the revocations to the subscribed applications. If nil,
+	// revocation subscriptions are not supported.
+	Revocations *servers.RevocationFeed
+	// Overrides are the path overrides that are applied to the paths of all applications. If
+	// nil, path overrides are not supported.
+	Overrides *servers.PathOverrides
+	// PathErrors are the path errors reported by the applications, they are applied to the
+	// paths of all applications. If nil, only the interface down errors are supported.
+	PathErrors *servers.PathErrors
+	// PathDB is the path database, for the path database snapshots. If nil, the snapshots are
+	// not supported.
+	PathDB pathdb.DB
+	// AllowPathDBImport allows importing path database snapshots.
+	AllowPathDBImport bool
 }
 
 // NewServer constructs a daemon API server.
 func NewServer(cfg ServerConfig) *servers.DaemonServer {
-	return &servers.DaemonServer{
+	s := &servers.DaemonServer{
 		IA:  cfg.IA,
 		MTU: cfg.MTU,
 		// TODO(JordiSubira): This will be changed in the future to fetch
 		// the information from the CS instead of feeding the configuration
 		// file into.
-		Topology:    cfg.Topology,
-		Fetcher:     cfg.Fetcher,
-		ASInspector: cfg.Engine.Inspector,
-		RevCache:    cfg.RevCache,
-		DRKeyClient: cfg.DRKeyClient,
-		Metrics: servers.Metrics{
-			PathsRequests: servers.RequestMetrics{
-				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
-					Namespace: "sd",
-					Subsystem: "path",
-					Name:      "requests_total",
-					Help:      "The amount of path requests received.",
-				}, servers.PathsRequestsLabels),
-				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
-					Namespace: "sd",
-					Subsystem: "path",
-					Name:      "request_duration_seconds",
-					Help:      "Time to handle path requests.",
-					Buckets:   prom.DefaultLatencyBuckets,
-				}, servers.LatencyLabels),
-			},
-			ASRequests: servers.RequestMetrics{
-				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
-					Namespace: "sd",
-					Subsystem: "as_info",
-					Name:      "requests_total",
-					Help:      "The amount of AS requests received.",
-				}, servers.ASRequestsLabels),
-				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
-					Namespace: "sd",
-					Subsystem: "as_info",
-					Name:      "request_duration_seconds",
-					Help:      "Time to handle AS requests.",
-					Buckets:   prom.DefaultLatencyBuckets,
-				}, servers.LatencyLabels),
-			},
-			InterfacesRequests: servers.RequestMetrics{
-				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
-					Namespace: "sd",
-					Subsystem: "if_info",
-					Name:      "requests_total",
-					Help:      "The amount of interfaces requests received.",
-				}, servers.InterfacesRequestsLabels),
-				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
-					Namespace: "sd",
-					Subsystem: "if_info",
-					Name:      "request_duration_seconds",
-					Help:      "Time to handle interfaces requests.",
-					Buckets:   prom.DefaultLatencyBuckets,
-				}, servers.LatencyLabels),
-			},
-			ServicesRequests: servers.RequestMetrics{
-				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
-					Namespace: "sd",
-					Subsystem: "service_info",
-					Name:      "requests_total",
-					Help:      "The amount of services requests received.",
-				}, servers.ServicesRequestsLabels),
-				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
-					Namespace: "sd",
-					Subsystem: "service_info",
-					Name:      "request_duration_seconds",
-					Help:      "Time to handle services requests.",
-					Buckets:   prom.DefaultLatencyBuckets,
-				}, servers.LatencyLabels),
-			},
-			InterfaceDownNotifications: servers.RequestMetrics{
-				Requests: metrics.NewPromCounter(prom.SafeRegister(
-					prometheus.NewCounterVec(prometheus.CounterOpts{
-						Namespace: "sd",
-						Name:      "received_revocations_total",
-						Help:      "The amount of revocations received.",
-					}, servers.InterfaceDownNotificationsLabels)).(*prometheus.CounterVec),
-				),
-				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+		Topology:          cfg.Topology,
+		Fetcher:           cfg.Fetcher,
+		ASInspector:       cfg.Engine.Inspector,
+		RevCache:          cfg.RevCache,
+		DRKeyClient:       cfg.DRKeyClient,
+		Resolver:          cfg.Resolver,
+		PathPolicies:      cfg.PathPolicies,
+		RevocationFeed:    cfg.Revocations,
+		Overrides:         cfg.Overrides,
+		PathErrors:        cfg.PathErrors,
+		PathDB:            cfg.PathDB,
+		AllowPathDBImport: cfg.AllowPathDBImport,
+		Metrics:           serverMetrics(),
+	}
+	if cfg.Liveness != nil {
+		s.Liveness = cfg.Liveness
+	}
+	return s
+}
+
+// serverMetrics are the metrics of the daemon API servers. They are shared by the servers of
+// all local ASes.
+var serverMetrics = sync.OnceValue(func() servers.Metrics {
+	return servers.Metrics{
+		PathsRequests: servers.RequestMetrics{
+			Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+				Namespace: "sd",
+				Subsystem: "path",
+				Name:      "requests_total",
+				Help:      "The amount of path requests received.",
+			}, servers.PathsRequestsLabels),
+			Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "sd",
+				Subsystem: "path",
+				Name:      "request_duration_seconds",
+				Help:      "Time to handle path requests.",
+				Buckets:   prom.DefaultLatencyBuckets,
+			}, servers.LatencyLabels),
+		},
+		ASRequests: servers.RequestMetrics{
+			Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+				Namespace: "sd",
+				Subsystem: "as_info",
+				Name:      "requests_total",
+				Help:      "The amount of AS requests received.",
+			}, servers.ASRequestsLabels),
+			Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "sd",
+				Subsystem: "as_info",
+				Name:      "request_duration_seconds",
+				Help:      "Time to handle AS requests.",
+				Buckets:   prom.DefaultLatencyBuckets,
+			}, servers.LatencyLabels),
+		},
+		InterfacesRequests: servers.RequestMetrics{
+			Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+				Namespace: "sd",
+				Subsystem: "if_info",
+				Name:      "requests_total",
+				Help:      "The amount of interfaces requests received.",
+			}, servers.InterfacesRequestsLabels),
+			Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "sd",
+				Subsystem: "if_info",
+				Name:      "request_duration_seconds",
+				Help:      "Time to handle interfaces requests.",
+				Buckets:   prom.DefaultLatencyBuckets,
+			}, servers.LatencyLabels),
+		},
+		ServicesRequests: servers.RequestMetrics{
+			Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+				Namespace: "sd",
+				Subsystem: "service_info",
+				Name:      "requests_total",
+				Help:      "The amount of services requests received.",
+			}, servers.ServicesRequestsLabels),
+			Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "sd",
+				Subsystem: "service_info",
+				Name:      "request_duration_seconds",
+				Help:      "Time to handle services requests.",
+				Buckets:   prom.DefaultLatencyBuckets,
+			}, servers.LatencyLabels),
+		},
+		InterfaceDownNotifications: servers.RequestMetrics{
+			Requests: metrics.NewPromCounter(prom.SafeRegister(
+				prometheus.NewCounterVec(prometheus.CounterOpts{
 					Namespace: "sd",
-					Subsystem: "revocation",
-					Name:      "notification_duration_seconds",
-					Help:      "Time to handle interface down notifications.",
-					Buckets:   prom.DefaultLatencyBuckets,
-				}, servers.LatencyLabels),
-			},
+					Name:      "received_revocations_total",
+					Help:      "The amount of revocations received.",
+				}, servers.InterfaceDownNotificationsLabels)).(*prometheus.CounterVec),
+			),
+			Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Namespace: "sd",
+				Subsystem: "revocation",
+				Name:      "notification_duration_seconds",
+				Help:      "Time to handle interface down notifications.",
+				Buckets:   prom.DefaultLatencyBuckets,
+			}, servers.LatencyLabels),
 		},
 	}
+})
+
+// PathLookupMetrics are the metrics of the path lookups, per destination AS. They are shared by
+// the path fetchers of all local ASes.
+var PathLookupMetrics = sync.OnceValue(func() segfetcher.PatherMetrics {
+	return segfetcher.PatherMetrics{
+		Lookups: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+			Namespace: "sd",
+			Subsystem: "path",
+			Name:      "lookups_total",
+			Help:      "The amount of path lookups, per destination AS.",
+		}, segfetcher.PathLookupsLabels),
+		LookupLatency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+			Namespace: "sd",
+			Subsystem: "path",
+			Name:      "lookup_duration_seconds",
+			Help:      "Time to look up paths, per destination AS.",
+			Buckets:   prom.DefaultLatencyBuckets,
+		}, segfetcher.PathLookupLatencyLabels),
+	}
+})
+
+// NewMultiASServer constructs a daemon API server that serves the requests of several local
+// ASes with their servers. The requests that do not select a local AS are served by the server
+// of the primary AS.
+func NewMultiASServer(
+	primary addr.IA,
+	ases map[addr.IA]sdpb.DaemonServiceServer,
+) sdpb.DaemonServiceServer {
+
+	return &servers.ASMux{Primary: primary, Servers: ases}
 }
 
 // APIAddress returns the API address to listen on, based on the provided
@@ -226,3 +356,60 @@ func APIAddress(listen string) string {
 		return listen
 	}
 }
+
+// ListenUnix listens on the Unix domain socket at the given path, and sets the permissions of the
+// socket file to mode. Only the local users that have write permission on the socket file can
+// connect to it. A socket file that was left behind by a previous run is removed. The socket
+// file is removed again when the listener is closed.
+//
+// The socket is created in a new directory that only the daemon can access, and only moved to
+// path once its permissions are set, so that it is never reachable with looser permissions.
+func ListenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, serrors.New("file exists and is not a socket", "path", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, serrors.Wrap("removing stale socket", err, "path", path)
+		}
+	}
+	// MkdirTemp creates the directory with mode 0700.
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".sock")
+	if err != nil {
+		return nil, serrors.Wrap("creating socket directory", err, "path", path)
+	}
+	defer os.RemoveAll(dir)
+	tmp := filepath.Join(dir, "s")
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmp, Net: "unix"})
+	if err != nil {
+		return nil, serrors.Wrap("listening", err, "path", path)
+	}
+	// The socket file is moved, so it is removed by unixListener.
+	listener.SetUnlinkOnClose(false)
+	if err := os.Chmod(tmp, mode); err != nil {
+		listener.Close()
+		return nil, serrors.Wrap("setting socket permissions", err, "path", path)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		listener.Close()
+		return nil, serrors.Wrap("moving socket", err, "path", path)
+	}
+	return &unixListener{UnixListener: listener, path: path}, nil
+}
+
+// unixListener removes the socket file at path when it is closed.
+type unixListener struct {
+	*net.UnixListener
+	path   string
+	unlink sync.Once
+}
+
+func (l *unixListener) Close() error {
+	err := l.UnixListener.Close()
+	l.unlink.Do(func() {
+		if rmErr := os.Remove(l.path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+			err = errors.Join(err, rmErr)
+		}
+	})
+	return err
+}
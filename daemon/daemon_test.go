@@ -15,9 +15,13 @@
 package daemon_test
 
 import (
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/daemon"
 )
@@ -57,3 +61,78 @@ func TestAPIAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestListenUnix(t *testing.T) {
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "sd.sock")
+
+	listener, err := daemon.ListenUnix(socket, 0600)
+	require.NoError(t, err)
+	info, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	// The directory the socket was created in is removed.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	conn, err := net.Dial("unix", socket)
+	require.NoError(t, err)
+	conn.Close()
+	require.NoError(t, listener.Close())
+	_, err = os.Stat(socket)
+	assert.True(t, os.IsNotExist(err))
+
+	t.Run("stale socket", func(t *testing.T) {
+		// A socket that is not unlinked on close, like after a crash.
+		stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: socket, Net: "unix"})
+		require.NoError(t, err)
+		stale.SetUnlinkOnClose(false)
+		require.NoError(t, stale.Close())
+
+		listener, err := daemon.ListenUnix(socket, 0660)
+		require.NoError(t, err)
+		defer listener.Close()
+		info, err := os.Stat(socket)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0660), info.Mode().Perm())
+	})
+	t.Run("regular file", func(t *testing.T) {
+		file := filepath.Join(dir, "file")
+		require.NoError(t, os.WriteFile(file, nil, 0644))
+		_, err := daemon.ListenUnix(file, 0660)
+		assert.Error(t, err)
+		_, err = os.Stat(file)
+		assert.NoError(t, err)
+	})
+}
+
+func TestLoadPathPolicies(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+		return file
+	}
+
+	policies, err := daemon.LoadPathPolicies("")
+	assert.NoError(t, err)
+	assert.Empty(t, policies)
+
+	policies, err = daemon.LoadPathPolicies(write("valid.json", `{
+		"base": {"acl": ["- 1-ff00:0:112", "+"]},
+		"extending": {"extends": ["base"], "sequence": "1-ff00:0:110 0*"}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "extending", policies["extending"].Name)
+	assert.NotNil(t, policies["extending"].ACL)
+	assert.NotNil(t, policies["extending"].Sequence)
+
+	_, err = daemon.LoadPathPolicies(write("unknown.json", `{"a": {"extends": ["b"]}}`))
+	assert.Error(t, err)
+	_, err = daemon.LoadPathPolicies(write("invalid.json", `{"a": 1}`))
+	assert.Error(t, err)
+	_, err = daemon.LoadPathPolicies(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
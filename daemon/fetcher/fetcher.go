@@ -65,6 +65,8 @@ type FetcherConfig struct {
 	Verifier infra.Verifier
 	RevCache revcache.RevCache
 	Cfg      config.SDConfig
+	// Metrics are the metrics of the path lookups. The zero value records no metrics.
+	Metrics segfetcher.PatherMetrics
 }
 
 func NewFetcher(cfg FetcherConfig) Fetcher {
@@ -75,8 +77,9 @@ func NewFetcher(cfg FetcherConfig) Fetcher {
 			NextHopper: cfg.NextHopper,
 			RevCache:   cfg.RevCache,
 			Fetcher: &segfetcher.Fetcher{
-				QueryInterval: cfg.Cfg.QueryInterval.Duration,
-				PathDB:        cfg.PathDB,
+				QueryInterval:    cfg.Cfg.QueryInterval.Duration,
+				QueryIntervalFor: cfg.Cfg.QueryIntervalFor,
+				PathDB:           cfg.PathDB,
 				Resolver: segfetcher.NewResolver(
 					cfg.PathDB,
 					cfg.RevCache,
@@ -100,6 +103,7 @@ func NewFetcher(cfg FetcherConfig) Fetcher {
 				Core:      cfg.Core,
 				Inspector: cfg.Inspector,
 			},
+			Metrics: cfg.Metrics,
 		},
 		config: cfg.Cfg,
 	}
@@ -0,0 +1,90 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/pathdb"
+	"github.com/scionproto/scion/private/pathdb/query"
+)
+
+// Prefetcher is a periodic task that keeps the paths to the destinations warm, so that the
+// first path request of an application does not wait on a segment lookup. The fetcher only
+// refetches the segments after the query interval, the other runs are served from the path
+// database.
+type Prefetcher struct {
+	// Fetcher fetches the paths.
+	Fetcher Fetcher
+	// Destinations are the destination ASes.
+	Destinations []addr.IA
+}
+
+// Name returns the task name.
+func (p *Prefetcher) Name() string {
+	return "daemon_path_prefetcher"
+}
+
+// Run fetches the paths to all destinations concurrently.
+func (p *Prefetcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, dst := range p.Destinations {
+		wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			if _, err := p.Fetcher.GetPaths(ctx, 0, dst, false); err != nil {
+				log.FromCtx(ctx).Info("Failed to prefetch paths", "dst", dst, "err", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// CachedDestinations returns the destinations of the unexpired down and core segments that are
+// cached in the path database, except the local AS. The segments are persisted in the path
+// database across restarts of the daemon, so prefetching the paths to these destinations on
+// startup refreshes the stale ones before applications request them.
+func CachedDestinations(ctx context.Context, db pathdb.DB, local addr.IA) ([]addr.IA, error) {
+	res, err := db.Get(ctx, &query.Params{SegTypes: []seg.Type{seg.TypeDown, seg.TypeCore}})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	seen := map[addr.IA]struct{}{local: {}}
+	var dsts []addr.IA
+	add := func(ia addr.IA) {
+		if _, ok := seen[ia]; !ok {
+			seen[ia] = struct{}{}
+			dsts = append(dsts, ia)
+		}
+	}
+	for _, r := range res {
+		if r.Seg.MaxExpiry().Before(now) {
+			continue
+		}
+		// Down segments end at the destination, core segments connect any two core ASes.
+		add(r.Seg.LastIA())
+		if r.Type == seg.TypeCore {
+			add(r.Seg.FirstIA())
+		}
+	}
+	return dsts, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/daemon/fetcher"
+	"github.com/scionproto/scion/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/pathdb/mock_pathdb"
+	"github.com/scionproto/scion/private/pathdb/query"
+)
+
+func TestPrefetcher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	dst1, dst2 := addr.MustParseIA("1-ff00:0:110"), addr.MustParseIA("2-ff00:0:210")
+	// A failing destination does not stop the others from being fetched.
+	f.EXPECT().GetPaths(gomock.Any(), addr.IA(0), dst1, false).Return(nil, nil)
+	f.EXPECT().GetPaths(gomock.Any(), addr.IA(0), dst2, false).Return(nil,
+		serrors.New("test error"))
+
+	p := &fetcher.Prefetcher{
+		Fetcher:      f,
+		Destinations: []addr.IA{dst1, dst2},
+	}
+	p.Run(context.Background())
+}
+
+func TestCachedDestinations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := graph.NewDefaultGraph(ctrl)
+	db := mock_pathdb.NewMockDB(ctrl)
+	db.EXPECT().Get(gomock.Any(), &query.Params{
+		SegTypes: []seg.Type{seg.TypeDown, seg.TypeCore},
+	}).Return(query.Results{
+		{Type: seg.TypeCore, Seg: g.Beacon([]uint16{graph.If_110_X_120_A})},
+		{Type: seg.TypeDown, Seg: g.Beacon([]uint16{graph.If_120_X_111_B})},
+		{Type: seg.TypeDown, Seg: g.Beacon([]uint16{graph.If_130_B_111_A})},
+	}, nil)
+
+	dsts, err := fetcher.CachedDestinations(context.Background(), db,
+		addr.MustParseIA("1-ff00:0:111"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []addr.IA{
+		addr.MustParseIA("1-ff00:0:110"),
+		addr.MustParseIA("1-ff00:0:120"),
+	}, dsts)
+}
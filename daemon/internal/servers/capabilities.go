@@ -0,0 +1,53 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+
+	"github.com/scionproto/scion/pkg/daemon"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+)
+
+// Capabilities returns the version of the API and the optional features that the server
+// supports, given the components that it is configured with.
+func (s *DaemonServer) Capabilities(_ context.Context,
+	_ *sdpb.CapabilitiesRequest) (*sdpb.CapabilitiesResponse, error) {
+
+	features := []string{daemon.CapabilityPathSubscriptions, daemon.CapabilityPathOrdering}
+	optional := []struct {
+		feature string
+		enabled bool
+	}{
+		{daemon.CapabilityPathPolicies, len(s.PathPolicies) > 0},
+		{daemon.CapabilityPathProbing, s.Liveness != nil},
+		{daemon.CapabilityRevocationSubscriptions, s.RevocationFeed != nil},
+		{daemon.CapabilityNameResolution, s.Resolver != nil},
+		{daemon.CapabilityPathOverrides, s.Overrides != nil},
+		{daemon.CapabilityPathErrors, s.PathErrors != nil},
+		{daemon.CapabilityPathDBExport, s.PathDB != nil},
+		{daemon.CapabilityPathDBImport, s.PathDB != nil && s.AllowPathDBImport},
+		{daemon.CapabilityDRKey, s.DRKeyClient != nil},
+	}
+	for _, o := range optional {
+		if o.enabled {
+			features = append(features, o.feature)
+		}
+	}
+	return &sdpb.CapabilitiesResponse{
+		ApiVersion:   daemon.APIVersion,
+		Capabilities: features,
+	}, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/private/path/pathpol"
+)
+
+func TestCapabilities(t *testing.T) {
+	ctx := context.Background()
+	capabilities := func(t *testing.T, s sdpb.DaemonServiceServer) []string {
+		response, err := s.Capabilities(ctx, &sdpb.CapabilitiesRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(daemon.APIVersion), response.ApiVersion)
+		return response.Capabilities
+	}
+
+	t.Run("minimal", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{
+			daemon.CapabilityPathSubscriptions,
+			daemon.CapabilityPathOrdering,
+		}, capabilities(t, &servers.DaemonServer{}))
+	})
+	t.Run("configured", func(t *testing.T) {
+		s := &servers.DaemonServer{
+			PathPolicies:   map[string]*pathpol.Policy{"policy": {}},
+			RevocationFeed: &servers.RevocationFeed{},
+			Overrides:      &servers.PathOverrides{},
+			PathErrors:     &servers.PathErrors{},
+		}
+		assert.ElementsMatch(t, []string{
+			daemon.CapabilityPathSubscriptions,
+			daemon.CapabilityPathOrdering,
+			daemon.CapabilityPathPolicies,
+			daemon.CapabilityRevocationSubscriptions,
+			daemon.CapabilityPathOverrides,
+			daemon.CapabilityPathErrors,
+		}, capabilities(t, s))
+	})
+	t.Run("several local ASes", func(t *testing.T) {
+		primary := addr.MustParseIA("1-ff00:0:110")
+		mux := &servers.ASMux{
+			Primary: primary,
+			Servers: map[addr.IA]sdpb.DaemonServiceServer{primary: &servers.DaemonServer{}},
+		}
+		assert.ElementsMatch(t, []string{
+			daemon.CapabilityPathSubscriptions,
+			daemon.CapabilityPathOrdering,
+			daemon.CapabilityMultipleASes,
+		}, capabilities(t, mux))
+	})
+}
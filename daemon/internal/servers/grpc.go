@@ -28,6 +28,7 @@ import (
 
 	drkey_daemon "github.com/scionproto/scion/daemon/drkey"
 	"github.com/scionproto/scion/daemon/fetcher"
+	"github.com/scionproto/scion/daemon/resolver"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/drkey"
 	"github.com/scionproto/scion/pkg/log"
@@ -41,6 +42,8 @@ import (
 	"github.com/scionproto/scion/pkg/segment/iface"
 	"github.com/scionproto/scion/pkg/snet"
 	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/path/pathpol"
+	"github.com/scionproto/scion/private/pathdb"
 	"github.com/scionproto/scion/private/revcache"
 	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/private/trust"
@@ -62,11 +65,38 @@ type DaemonServer struct {
 	RevCache    revcache.RevCache
 	ASInspector trust.Inspector
 	DRKeyClient *drkey_daemon.ClientEngine
+	// PathPolicies are the path policies that requests can refer to by name.
+	PathPolicies map[string]*pathpol.Policy
+	// PathUpdateInterval is the interval at which the paths of a subscription are fetched
+	// again. If zero, DefaultPathUpdateInterval is used.
+	PathUpdateInterval time.Duration
+	// Liveness probes the returned paths. If nil, the paths are not probed.
+	Liveness LivenessTracker
+	// Resolver resolves the host names. If nil, name resolution is not supported.
+	Resolver resolver.Resolver
+	// RevocationFeed distributes the revocations to the subscribed applications. If nil,
+	// revocation subscriptions are not supported.
+	RevocationFeed *RevocationFeed
+	// Overrides are the path overrides that are applied to the paths of all requests. If nil,
+	// path overrides are not supported.
+	Overrides *PathOverrides
+	// PathErrors are the path errors reported by the applications, they are applied to the
+	// paths of all requests. If nil, the reports of path errors other than interface down
+	// errors are not supported.
+	PathErrors *PathErrors
+	// PathDB is the path database, for the snapshots of the ExportPathDB and ImportPathDB
+	// calls. If nil, the snapshots are not supported.
+	PathDB pathdb.DB
+	// AllowPathDBImport allows the ImportPathDB call. The imported path segments are not
+	// verified.
+	AllowPathDBImport bool
 
 	Metrics Metrics
 
 	foregroundPathDedupe singleflight.Group
 	backgroundPathDedupe singleflight.Group
+	revocations          subscribers
+	topologyChanges      subscribers
 }
 
 // Paths serves the paths request.
@@ -91,6 +121,13 @@ func (s *DaemonServer) paths(ctx context.Context,
 		ctx, cancelF = context.WithTimeout(ctx, 10*time.Second)
 		defer cancelF()
 	}
+	policy, err := s.pathPolicy(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePathOrdering(req.OrderBy); err != nil {
+		return nil, err
+	}
 	srcIA, dstIA := addr.IA(req.SourceIsdAs), addr.IA(req.DestinationIsdAs)
 	go func() {
 		defer log.HandlePanic()
@@ -102,11 +139,11 @@ func (s *DaemonServer) paths(ctx context.Context,
 			"src", srcIA, "dst", dstIA, "refresh", req.Refresh)
 		return nil, err
 	}
-	reply := &sdpb.PathsResponse{}
-	for _, p := range paths {
-		reply.Paths = append(reply.Paths, pathToPB(p))
-	}
-	return reply, nil
+	paths = applyPathPolicy(policy, paths)
+	paths = s.PathErrors.Apply(orderPaths(req.OrderBy, paths))
+	// The limit applies after the paths that are known to be unreachable are moved last.
+	pbPaths := limitPaths(req.MaxPaths, s.pathsToPB(dstIA, paths))
+	return &sdpb.PathsResponse{Paths: pbPaths}, nil
 }
 
 func (s *DaemonServer) fetchPaths(
@@ -124,7 +161,7 @@ func (s *DaemonServer) fetchPaths(
 	// just cast to the correct type, ignore the "ok", since that can only be
 	// false in case of a nil result.
 	paths, _ := r.([]snet.Path)
-	return paths, err
+	return s.Overrides.Apply(dst, paths), err
 }
 
 func pathToPB(path snet.Path) *sdpb.Path {
@@ -335,22 +372,32 @@ func (s *DaemonServer) NotifyInterfaceDown(ctx context.Context,
 func (s *DaemonServer) notifyInterfaceDown(ctx context.Context,
 	req *sdpb.NotifyInterfaceDownRequest) (*sdpb.NotifyInterfaceDownResponse, error) {
 
+	if err := s.revokeInterface(ctx, addr.IA(req.IsdAs), req.Id); err != nil {
+		return nil, err
+	}
+	return &sdpb.NotifyInterfaceDownResponse{}, nil
+}
+
+// revokeInterface inserts a revocation of the interface into the revocation cache, and notifies
+// the subscribers.
+func (s *DaemonServer) revokeInterface(ctx context.Context, ia addr.IA, id uint64) error {
 	revInfo := &path_mgmt.RevInfo{
-		RawIsdas:     addr.IA(req.IsdAs),
-		IfID:         iface.ID(req.Id),
+		RawIsdas:     ia,
+		IfID:         iface.ID(id),
 		LinkType:     proto.LinkType_core,
 		RawTTL:       10,
 		RawTimestamp: util.TimeToSecs(time.Now()),
 	}
 	_, err := s.RevCache.Insert(ctx, revInfo)
 	if err != nil {
-		log.FromCtx(ctx).Error("Inserting revocation", "err", err, "req", req)
-		return nil, metricsError{
+		log.FromCtx(ctx).Error("Inserting revocation", "err", err, "isd_as", ia, "id", id)
+		return metricsError{
 			err:    serrors.Wrap("inserting revocation", err),
 			result: prom.ErrDB,
 		}
 	}
-	return &sdpb.NotifyInterfaceDownResponse{}, nil
+	s.revocations.notify()
+	return nil
 }
 
 // PortRange returns the port range for the dispatched ports.
@@ -0,0 +1,73 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/pkg/addr"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// LivenessTracker probes the paths returned to applications in the background.
+type LivenessTracker interface {
+	// Track registers the paths to the destination for probing.
+	Track(dst addr.IA, paths []snet.Path)
+	// Liveness returns the result of the latest probe of the path, if it was probed.
+	Liveness(path snet.Path) (snet.PathLiveness, bool)
+}
+
+// pathsToPB converts the paths, and annotates them with the results of their latest probes if
+// the paths are probed. Paths that are known to be unreachable are ordered last, otherwise the
+// order is kept.
+func (s *DaemonServer) pathsToPB(dst addr.IA, paths []snet.Path) []*sdpb.Path {
+	pbPaths := make([]*sdpb.Path, 0, len(paths))
+	for _, p := range paths {
+		pbPaths = append(pbPaths, pathToPB(p))
+	}
+	if s.Liveness == nil {
+		return pbPaths
+	}
+	s.Liveness.Track(dst, paths)
+	for i, p := range paths {
+		if l, ok := s.Liveness.Liveness(p); ok {
+			pbPaths[i].Liveness = livenessToPB(l)
+		}
+	}
+	sort.SliceStable(pbPaths, func(i, j int) bool {
+		return reachable(pbPaths[i]) && !reachable(pbPaths[j])
+	})
+	return pbPaths
+}
+
+// reachable returns false if the path is known to be unreachable.
+func reachable(p *sdpb.Path) bool {
+	return p.Liveness == nil || p.Liveness.Reachable
+}
+
+func livenessToPB(l snet.PathLiveness) *sdpb.PathLiveness {
+	pb := &sdpb.PathLiveness{
+		Reachable: l.Reachable,
+		ProbedAt:  timestamppb.New(l.ProbedAt),
+	}
+	if l.Reachable {
+		pb.Rtt = durationpb.New(l.RTT)
+	}
+	return pb
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/pkg/addr"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+// fakeLiveness returns fixed results for the paths, keyed by their raw dataplane path.
+type fakeLiveness struct {
+	results map[string]snet.PathLiveness
+	tracked []snet.Path
+}
+
+func (l *fakeLiveness) Track(_ addr.IA, paths []snet.Path) {
+	l.tracked = paths
+}
+
+func (l *fakeLiveness) Liveness(p snet.Path) (snet.PathLiveness, bool) {
+	r, ok := l.results[string(p.Dataplane().(snetpath.SCION).Raw)]
+	return r, ok
+}
+
+func TestPathsLiveness(t *testing.T) {
+	dst := addr.MustParseIA("1-ff00:0:111")
+	newPath := func(raw string) snet.Path {
+		return snetpath.Path{
+			Dst:           dst,
+			DataplanePath: snetpath.SCION{Raw: []byte(raw)},
+			Meta: snet.PathMetadata{
+				Expiry: time.Now().Add(time.Hour),
+			},
+		}
+	}
+	paths := []snet.Path{newPath("dead"), newPath("unknown"), newPath("alive")}
+	probedAt := time.Now()
+	liveness := &fakeLiveness{results: map[string]snet.PathLiveness{
+		"dead":  {Reachable: false, ProbedAt: probedAt},
+		"alive": {Reachable: true, RTT: time.Millisecond, ProbedAt: probedAt},
+	}}
+
+	ctrl := gomock.NewController(t)
+	f := mock_fetcher.NewMockFetcher(ctrl)
+	f.EXPECT().GetPaths(gomock.Any(), gomock.Any(), dst, false).AnyTimes().Return(paths, nil)
+	s := &servers.DaemonServer{Fetcher: f, Liveness: liveness}
+
+	reply, err := s.Paths(context.Background(), &sdpb.PathsRequest{
+		DestinationIsdAs: uint64(dst),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, paths, liveness.tracked)
+
+	// The paths that are known to be unreachable are ordered last.
+	var raws []string
+	for _, p := range reply.Paths {
+		raws = append(raws, string(p.Raw))
+	}
+	assert.Equal(t, []string{"unknown", "alive", "dead"}, raws)
+	assert.Nil(t, reply.Paths[0].Liveness)
+	assert.True(t, reply.Paths[1].Liveness.Reachable)
+	assert.Equal(t, time.Millisecond, reply.Paths[1].Liveness.Rtt.AsDuration())
+	assert.False(t, reply.Paths[2].Liveness.Reachable)
+	assert.Nil(t, reply.Paths[2].Liveness.Rtt)
+}
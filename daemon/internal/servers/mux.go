@@ -0,0 +1,256 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+)
+
+// ASMux serves the requests of several local ASes, each with the server of the AS. The local
+// AS of a request is selected by the daemon.LocalIAMetadataKey metadata of the request. Without
+// it, the requests that refer to a local AS, i.e., the path requests with their source and the
+// AS requests, are served by the server of that AS, and all other requests by the server of the
+// primary AS.
+type ASMux struct {
+	// Primary is the primary local AS.
+	Primary addr.IA
+	// Servers are the servers of the local ASes, including the primary one.
+	Servers map[addr.IA]sdpb.DaemonServiceServer
+}
+
+// server returns the server for the request with the given context. The hint is the local AS
+// that the request refers to, if any.
+func (m *ASMux) server(ctx context.Context, hint addr.IA) (sdpb.DaemonServiceServer, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(daemon.LocalIAMetadataKey); len(values) > 0 {
+			ia, err := addr.ParseIA(values[0])
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "malformed local ISD-AS")
+			}
+			s, ok := m.Servers[ia]
+			if !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "unknown local ISD-AS %s", ia)
+			}
+			return s, nil
+		}
+	}
+	if s, ok := m.Servers[hint]; ok {
+		return s, nil
+	}
+	return m.Servers[m.Primary], nil
+}
+
+func (m *ASMux) Paths(ctx context.Context, req *sdpb.PathsRequest) (*sdpb.PathsResponse, error) {
+	s, err := m.server(ctx, addr.IA(req.SourceIsdAs))
+	if err != nil {
+		return nil, err
+	}
+	return s.Paths(ctx, req)
+}
+
+func (m *ASMux) AS(ctx context.Context, req *sdpb.ASRequest) (*sdpb.ASResponse, error) {
+	s, err := m.server(ctx, addr.IA(req.IsdAs))
+	if err != nil {
+		return nil, err
+	}
+	return s.AS(ctx, req)
+}
+
+func (m *ASMux) Interfaces(ctx context.Context,
+	req *sdpb.InterfacesRequest) (*sdpb.InterfacesResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.Interfaces(ctx, req)
+}
+
+func (m *ASMux) Services(ctx context.Context,
+	req *sdpb.ServicesRequest) (*sdpb.ServicesResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.Services(ctx, req)
+}
+
+// Capabilities returns the capabilities of the server of the local AS, and the selection of the
+// local AS.
+func (m *ASMux) Capabilities(ctx context.Context,
+	req *sdpb.CapabilitiesRequest) (*sdpb.CapabilitiesResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.Capabilities(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	response.Capabilities = append(response.Capabilities, daemon.CapabilityMultipleASes)
+	return response, nil
+}
+
+// NotifyInterfaceDown notifies the servers of all local ASes, the revocation may affect the
+// paths of all of them.
+func (m *ASMux) NotifyInterfaceDown(ctx context.Context,
+	req *sdpb.NotifyInterfaceDownRequest) (*sdpb.NotifyInterfaceDownResponse, error) {
+
+	ias := make([]addr.IA, 0, len(m.Servers))
+	for ia := range m.Servers {
+		ias = append(ias, ia)
+	}
+	sort.Slice(ias, func(i, j int) bool { return ias[i] < ias[j] })
+	var reply *sdpb.NotifyInterfaceDownResponse
+	for _, ia := range ias {
+		var err error
+		if reply, err = m.Servers[ia].NotifyInterfaceDown(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return reply, nil
+}
+
+func (m *ASMux) PortRange(ctx context.Context,
+	req *emptypb.Empty) (*sdpb.PortRangeResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.PortRange(ctx, req)
+}
+
+func (m *ASMux) DRKeyASHost(ctx context.Context,
+	req *sdpb.DRKeyASHostRequest) (*sdpb.DRKeyASHostResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.DRKeyASHost(ctx, req)
+}
+
+func (m *ASMux) DRKeyHostAS(ctx context.Context,
+	req *sdpb.DRKeyHostASRequest) (*sdpb.DRKeyHostASResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.DRKeyHostAS(ctx, req)
+}
+
+func (m *ASMux) DRKeyHostHost(ctx context.Context,
+	req *sdpb.DRKeyHostHostRequest) (*sdpb.DRKeyHostHostResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.DRKeyHostHost(ctx, req)
+}
+
+func (m *ASMux) SubscribePaths(req *sdpb.SubscribePathsRequest,
+	stream sdpb.DaemonService_SubscribePathsServer) error {
+
+	s, err := m.server(stream.Context(), addr.IA(req.SourceIsdAs))
+	if err != nil {
+		return err
+	}
+	return s.SubscribePaths(req, stream)
+}
+
+func (m *ASMux) ResolveName(ctx context.Context,
+	req *sdpb.ResolveNameRequest) (*sdpb.ResolveNameResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.ResolveName(ctx, req)
+}
+
+func (m *ASMux) SubscribeRevocations(req *sdpb.SubscribeRevocationsRequest,
+	stream sdpb.DaemonService_SubscribeRevocationsServer) error {
+
+	s, err := m.server(stream.Context(), 0)
+	if err != nil {
+		return err
+	}
+	return s.SubscribeRevocations(req, stream)
+}
+
+func (m *ASMux) PathOverrides(ctx context.Context,
+	req *sdpb.PathOverridesRequest) (*sdpb.PathOverridesResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.PathOverrides(ctx, req)
+}
+
+func (m *ASMux) SetPathOverrides(ctx context.Context,
+	req *sdpb.SetPathOverridesRequest) (*sdpb.SetPathOverridesResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.SetPathOverrides(ctx, req)
+}
+
+func (m *ASMux) ExportPathDB(ctx context.Context,
+	req *sdpb.ExportPathDBRequest) (*sdpb.ExportPathDBResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.ExportPathDB(ctx, req)
+}
+
+func (m *ASMux) ImportPathDB(ctx context.Context,
+	req *sdpb.ImportPathDBRequest) (*sdpb.ImportPathDBResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportPathDB(ctx, req)
+}
+
+func (m *ASMux) ReportPathError(ctx context.Context,
+	req *sdpb.ReportPathErrorRequest) (*sdpb.ReportPathErrorResponse, error) {
+
+	s, err := m.server(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.ReportPathError(ctx, req)
+}
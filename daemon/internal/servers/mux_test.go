@@ -0,0 +1,115 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+)
+
+// iaServer answers the requests with its ISD-AS.
+type iaServer struct {
+	sdpb.UnimplementedDaemonServiceServer
+	ia       addr.IA
+	notified int
+}
+
+func (s *iaServer) Paths(context.Context, *sdpb.PathsRequest) (*sdpb.PathsResponse, error) {
+	return &sdpb.PathsResponse{Paths: []*sdpb.Path{{
+		Interfaces: []*sdpb.PathInterface{{IsdAs: uint64(s.ia)}},
+	}}}, nil
+}
+
+func (s *iaServer) AS(context.Context, *sdpb.ASRequest) (*sdpb.ASResponse, error) {
+	return &sdpb.ASResponse{IsdAs: uint64(s.ia)}, nil
+}
+
+func (s *iaServer) NotifyInterfaceDown(context.Context,
+	*sdpb.NotifyInterfaceDownRequest) (*sdpb.NotifyInterfaceDownResponse, error) {
+
+	s.notified++
+	return &sdpb.NotifyInterfaceDownResponse{}, nil
+}
+
+func TestASMux(t *testing.T) {
+	primary := addr.MustParseIA("1-ff00:0:110")
+	other := addr.MustParseIA("2-ff00:0:210")
+	primaryServer := &iaServer{ia: primary}
+	otherServer := &iaServer{ia: other}
+	mux := &servers.ASMux{
+		Primary: primary,
+		Servers: map[addr.IA]sdpb.DaemonServiceServer{
+			primary: primaryServer,
+			other:   otherServer,
+		},
+	}
+	withIA := func(ia string) context.Context {
+		return metadata.NewIncomingContext(context.Background(),
+			metadata.Pairs(daemon.LocalIAMetadataKey, ia))
+	}
+	servedBy := func(t *testing.T, reply *sdpb.ASResponse, err error) addr.IA {
+		require.NoError(t, err)
+		return addr.IA(reply.IsdAs)
+	}
+
+	t.Run("primary by default", func(t *testing.T) {
+		reply, err := mux.AS(context.Background(), &sdpb.ASRequest{})
+		assert.Equal(t, primary, servedBy(t, reply, err))
+	})
+	t.Run("metadata", func(t *testing.T) {
+		reply, err := mux.AS(withIA(other.String()), &sdpb.ASRequest{})
+		assert.Equal(t, other, servedBy(t, reply, err))
+	})
+	t.Run("local AS of the request", func(t *testing.T) {
+		reply, err := mux.AS(context.Background(), &sdpb.ASRequest{IsdAs: uint64(other)})
+		assert.Equal(t, other, servedBy(t, reply, err))
+
+		paths, err := mux.Paths(context.Background(), &sdpb.PathsRequest{
+			SourceIsdAs:      uint64(other),
+			DestinationIsdAs: uint64(primary),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(other), paths.Paths[0].Interfaces[0].IsdAs)
+	})
+	t.Run("remote AS of the request", func(t *testing.T) {
+		remote := addr.MustParseIA("1-ff00:0:111")
+		reply, err := mux.AS(context.Background(), &sdpb.ASRequest{IsdAs: uint64(remote)})
+		assert.Equal(t, primary, servedBy(t, reply, err))
+	})
+	t.Run("unknown local AS", func(t *testing.T) {
+		_, err := mux.AS(withIA("1-ff00:0:111"), &sdpb.ASRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = mux.AS(withIA("garbage"), &sdpb.ASRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("interface down", func(t *testing.T) {
+		_, err := mux.NotifyInterfaceDown(context.Background(),
+			&sdpb.NotifyInterfaceDownRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, primaryServer.notified)
+		assert.Equal(t, 1, otherServer.notified)
+	})
+}
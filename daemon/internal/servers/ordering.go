@@ -0,0 +1,116 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// pathComparators compare two paths by the ordering criteria of PathsRequest.
+var pathComparators = map[sdpb.PathOrdering]func(a, b snet.Path) int{
+	sdpb.PathOrdering_PATH_ORDERING_HOPS: func(a, b snet.Path) int {
+		return cmp.Compare(len(a.Metadata().Interfaces), len(b.Metadata().Interfaces))
+	},
+	sdpb.PathOrdering_PATH_ORDERING_LATENCY: func(a, b snet.Path) int {
+		la, completeA := pathLatency(a.Metadata())
+		lb, completeB := pathLatency(b.Metadata())
+		if completeA != completeB {
+			if completeA {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(la, lb)
+	},
+	sdpb.PathOrdering_PATH_ORDERING_BANDWIDTH: func(a, b snet.Path) int {
+		return cmp.Compare(pathBandwidth(b.Metadata()), pathBandwidth(a.Metadata()))
+	},
+	sdpb.PathOrdering_PATH_ORDERING_EXPIRY: func(a, b snet.Path) int {
+		return b.Metadata().Expiry.Compare(a.Metadata().Expiry)
+	},
+}
+
+// validatePathOrdering checks that the ordering criteria of the request are known.
+func validatePathOrdering(order []sdpb.PathOrdering) error {
+	for _, o := range order {
+		if _, ok := pathComparators[o]; !ok {
+			return metricsError{
+				err:    invalidArgument(serrors.New("invalid path ordering", "order_by", o)),
+				result: prom.ErrInvalidReq,
+			}
+		}
+	}
+	return nil
+}
+
+// orderPaths orders the paths by the criteria, in decreasing precedence.
+func orderPaths(order []sdpb.PathOrdering, paths []snet.Path) []snet.Path {
+	if len(order) == 0 {
+		return paths
+	}
+	// The fetched paths can be shared with concurrent requests, sort a copy.
+	paths = slices.Clone(paths)
+	slices.SortStableFunc(paths, func(a, b snet.Path) int {
+		for _, o := range order {
+			if c := pathComparators[o](a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return paths
+}
+
+// limitPaths returns at most maxPaths of the paths. A maxPaths of zero means no limit.
+func limitPaths(maxPaths uint32, paths []*sdpb.Path) []*sdpb.Path {
+	if maxPaths > 0 && len(paths) > int(maxPaths) {
+		return paths[:maxPaths]
+	}
+	return paths
+}
+
+// pathLatency returns the sum of the announced latencies of the path, and whether the latency
+// is announced for all its hops.
+func pathLatency(meta *snet.PathMetadata) (time.Duration, bool) {
+	var total time.Duration
+	complete := len(meta.Latency) > 0 || len(meta.Interfaces) == 0
+	for _, l := range meta.Latency {
+		if l < 0 {
+			complete = false
+			continue
+		}
+		total += l
+	}
+	return total, complete
+}
+
+// pathBandwidth returns the lowest announced bandwidth of the hops of the path, or zero if no
+// bandwidth is announced.
+func pathBandwidth(meta *snet.PathMetadata) uint64 {
+	var lowest uint64
+	for _, bw := range meta.Bandwidth {
+		if bw != 0 && (lowest == 0 || bw < lowest) {
+			lowest = bw
+		}
+	}
+	return lowest
+}
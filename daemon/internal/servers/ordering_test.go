@@ -0,0 +1,144 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/pkg/addr"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestPathsOrdering(t *testing.T) {
+	src, dst := addr.MustParseIA("1-ff00:0:110"), addr.MustParseIA("1-ff00:0:111")
+	now := time.Now()
+	// The paths are identified by their MTU.
+	newPath := func(mtu uint16, hops int, latency []time.Duration, bandwidth []uint64,
+		expiry time.Duration) snet.Path {
+
+		intfs := make([]snet.PathInterface, 2*hops)
+		for i := range intfs {
+			intfs[i] = snet.PathInterface{IA: src, ID: 1}
+		}
+		return snetpath.Path{
+			Src:           src,
+			Dst:           dst,
+			DataplanePath: snetpath.Empty{},
+			Meta: snet.PathMetadata{
+				Interfaces: intfs,
+				MTU:        mtu,
+				Latency:    latency,
+				Bandwidth:  bandwidth,
+				Expiry:     now.Add(expiry),
+			},
+		}
+	}
+	ms := time.Millisecond
+	paths := []snet.Path{
+		newPath(1, 1, []time.Duration{30 * ms}, []uint64{100}, time.Hour),
+		newPath(2, 2, []time.Duration{5 * ms, 5 * ms, 0}, []uint64{1000, 500, 0}, 3*time.Hour),
+		newPath(3, 1, []time.Duration{snet.LatencyUnset}, []uint64{0}, 2*time.Hour),
+		newPath(4, 2, []time.Duration{1 * ms, snet.LatencyUnset, 0}, []uint64{2000, 0, 0},
+			time.Hour),
+	}
+
+	testCases := map[string]struct {
+		OrderBy   []sdpb.PathOrdering
+		MaxPaths  uint32
+		MTUs      []uint32
+		AssertErr assert.ErrorAssertionFunc
+	}{
+		"daemon order": {
+			MTUs:      []uint32{1, 2, 3, 4},
+			AssertErr: assert.NoError,
+		},
+		"hops": {
+			OrderBy:   []sdpb.PathOrdering{sdpb.PathOrdering_PATH_ORDERING_HOPS},
+			MTUs:      []uint32{1, 3, 2, 4},
+			AssertErr: assert.NoError,
+		},
+		"latency": {
+			OrderBy:   []sdpb.PathOrdering{sdpb.PathOrdering_PATH_ORDERING_LATENCY},
+			MTUs:      []uint32{2, 1, 3, 4},
+			AssertErr: assert.NoError,
+		},
+		"bandwidth": {
+			OrderBy:   []sdpb.PathOrdering{sdpb.PathOrdering_PATH_ORDERING_BANDWIDTH},
+			MTUs:      []uint32{4, 2, 1, 3},
+			AssertErr: assert.NoError,
+		},
+		"expiry": {
+			OrderBy:   []sdpb.PathOrdering{sdpb.PathOrdering_PATH_ORDERING_EXPIRY},
+			MTUs:      []uint32{2, 3, 1, 4},
+			AssertErr: assert.NoError,
+		},
+		"hops then expiry": {
+			OrderBy: []sdpb.PathOrdering{
+				sdpb.PathOrdering_PATH_ORDERING_HOPS,
+				sdpb.PathOrdering_PATH_ORDERING_EXPIRY,
+			},
+			MTUs:      []uint32{3, 1, 2, 4},
+			AssertErr: assert.NoError,
+		},
+		"limit": {
+			MaxPaths:  3,
+			MTUs:      []uint32{1, 2, 3},
+			AssertErr: assert.NoError,
+		},
+		"limit after ordering": {
+			OrderBy:   []sdpb.PathOrdering{sdpb.PathOrdering_PATH_ORDERING_BANDWIDTH},
+			MaxPaths:  2,
+			MTUs:      []uint32{4, 2},
+			AssertErr: assert.NoError,
+		},
+		"unspecified ordering": {
+			OrderBy:   []sdpb.PathOrdering{sdpb.PathOrdering_PATH_ORDERING_UNSPECIFIED},
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fetcher := mock_fetcher.NewMockFetcher(ctrl)
+			fetcher.EXPECT().GetPaths(gomock.Any(), src, dst, false).Return(paths, nil).AnyTimes()
+			s := &servers.DaemonServer{Fetcher: fetcher}
+			reply, err := s.Paths(context.Background(), &sdpb.PathsRequest{
+				SourceIsdAs:      uint64(src),
+				DestinationIsdAs: uint64(dst),
+				OrderBy:          tc.OrderBy,
+				MaxPaths:         tc.MaxPaths,
+			})
+			tc.AssertErr(t, err)
+			if err != nil {
+				return
+			}
+			var mtus []uint32
+			for _, p := range reply.Paths {
+				mtus = append(mtus, p.Mtu)
+			}
+			assert.Equal(t, tc.MTUs, mtus)
+			assert.Equal(t, uint16(1), paths[0].Metadata().MTU, "fetched paths modified")
+		})
+	}
+}
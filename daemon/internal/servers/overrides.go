@@ -0,0 +1,193 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// PathOverrides are the overrides that the daemon applies to the paths that it returns to all
+// local applications. They let the operators steer the traffic at runtime, e.g., around an
+// incident, without touching the applications. The zero value has no overrides, and it is safe
+// for concurrent use.
+type PathOverrides struct {
+	mu      sync.RWMutex
+	current *sdpb.PathOverrides
+	// blocked are the blocked interfaces. An interface with ID 0 blocks all the interfaces of
+	// its AS.
+	blocked map[snet.PathInterface]struct{}
+	// pinned are the interfaces of the pinned paths, by destination, in order of preference.
+	pinned map[addr.IA][][]snet.PathInterface
+	// changes notifies the path subscriptions when the overrides are replaced.
+	changes subscribers
+}
+
+// Set replaces the overrides. The overrides are validated first, and they are not replaced if
+// they are invalid.
+func (o *PathOverrides) Set(overrides *sdpb.PathOverrides) error {
+	blocked := make(map[snet.PathInterface]struct{}, len(overrides.GetBlocked()))
+	for _, b := range overrides.GetBlocked() {
+		ia := addr.IA(b.IsdAs)
+		if ia.IsWildcard() {
+			return serrors.New("blocked interface with wildcard ISD-AS", "isd_as", ia)
+		}
+		blocked[snet.PathInterface{IA: ia, ID: iface.ID(b.Id)}] = struct{}{}
+	}
+	pinned := make(map[addr.IA][][]snet.PathInterface)
+	for _, p := range overrides.GetPinned() {
+		dst := addr.IA(p.DestinationIsdAs)
+		if dst.IsWildcard() {
+			return serrors.New("pinned path with wildcard destination", "destination", dst)
+		}
+		if len(p.Interfaces) == 0 {
+			return serrors.New("pinned path without interfaces", "destination", dst)
+		}
+		interfaces := make([]snet.PathInterface, 0, len(p.Interfaces))
+		for _, intf := range p.Interfaces {
+			interfaces = append(interfaces, snet.PathInterface{
+				IA: addr.IA(intf.IsdAs),
+				ID: iface.ID(intf.Id),
+			})
+		}
+		pinned[dst] = append(pinned[dst], interfaces)
+	}
+
+	o.mu.Lock()
+	o.current = proto.Clone(overrides).(*sdpb.PathOverrides)
+	o.blocked, o.pinned = blocked, pinned
+	o.mu.Unlock()
+	o.changes.notify()
+	return nil
+}
+
+// Get returns the current overrides.
+func (o *PathOverrides) Get() *sdpb.PathOverrides {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if o.current == nil {
+		return &sdpb.PathOverrides{}
+	}
+	return proto.Clone(o.current).(*sdpb.PathOverrides)
+}
+
+// Apply applies the overrides to the paths to the destination. The paths through a blocked
+// interface are removed, and the pinned paths are moved to the front, in the order in which
+// they were pinned. The input is not modified, it may be shared with other requests. A nil
+// overrides returns the paths unchanged.
+func (o *PathOverrides) Apply(dst addr.IA, paths []snet.Path) []snet.Path {
+	if o == nil {
+		return paths
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	pins := o.pinned[dst]
+	if len(o.blocked) == 0 && len(pins) == 0 {
+		return paths
+	}
+	result := make([]snet.Path, 0, len(paths))
+	for _, p := range paths {
+		if !o.isBlocked(p) {
+			result = append(result, p)
+		}
+	}
+	front := 0
+	for _, pin := range pins {
+		for i := front; i < len(result); i++ {
+			if !slices.Equal(pathInterfaces(result[i]), pin) {
+				continue
+			}
+			p := result[i]
+			copy(result[front+1:i+1], result[front:i])
+			result[front] = p
+			front++
+			break
+		}
+	}
+	return result
+}
+
+func (o *PathOverrides) isBlocked(p snet.Path) bool {
+	for _, intf := range pathInterfaces(p) {
+		if _, ok := o.blocked[intf]; ok {
+			return true
+		}
+		if _, ok := o.blocked[snet.PathInterface{IA: intf.IA}]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *PathOverrides) subscribe() chan struct{} {
+	if o == nil {
+		return nil
+	}
+	return o.changes.subscribe()
+}
+
+func (o *PathOverrides) unsubscribe(c chan struct{}) {
+	if o == nil {
+		return
+	}
+	o.changes.unsubscribe(c)
+}
+
+func pathInterfaces(p snet.Path) []snet.PathInterface {
+	meta := p.Metadata()
+	if meta == nil {
+		return nil
+	}
+	return meta.Interfaces
+}
+
+// PathOverrides returns the path overrides that the daemon applies to the paths of all
+// applications.
+func (s *DaemonServer) PathOverrides(_ context.Context,
+	_ *sdpb.PathOverridesRequest) (*sdpb.PathOverridesResponse, error) {
+
+	if s.Overrides == nil {
+		return nil, status.Error(codes.Unimplemented, "path overrides are not enabled")
+	}
+	return &sdpb.PathOverridesResponse{Overrides: s.Overrides.Get()}, nil
+}
+
+// SetPathOverrides replaces the path overrides that the daemon applies to the paths of all
+// applications. The path subscriptions are updated immediately.
+func (s *DaemonServer) SetPathOverrides(ctx context.Context,
+	req *sdpb.SetPathOverridesRequest) (*sdpb.SetPathOverridesResponse, error) {
+
+	if s.Overrides == nil {
+		return nil, status.Error(codes.Unimplemented, "path overrides are not enabled")
+	}
+	if err := s.Overrides.Set(req.Overrides); err != nil {
+		return nil, invalidArgument(serrors.Wrap("setting path overrides", err))
+	}
+	log.FromCtx(ctx).Info("Path overrides set",
+		"blocked", len(req.Overrides.GetBlocked()), "pinned", len(req.Overrides.GetPinned()))
+	return &sdpb.SetPathOverridesResponse{}, nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/pkg/addr"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+var (
+	overridesSrc = addr.MustParseIA("1-ff00:0:110")
+	overridesDst = addr.MustParseIA("1-ff00:0:111")
+	overridesVia = addr.MustParseIA("1-ff00:0:112")
+)
+
+// overridesPath returns a path from overridesSrc to overridesDst, through the given transit
+// ASes. The interfaces of a hop use the ID of the hop.
+func overridesPath(via ...addr.IA) snet.Path {
+	ias := append(append([]addr.IA{overridesSrc}, via...), overridesDst)
+	var intfs []snet.PathInterface
+	for i := 0; i < len(ias)-1; i++ {
+		intfs = append(intfs,
+			snet.PathInterface{IA: ias[i], ID: 1},
+			snet.PathInterface{IA: ias[i+1], ID: 2},
+		)
+	}
+	return snetpath.Path{
+		Src:           overridesSrc,
+		Dst:           overridesDst,
+		DataplanePath: snetpath.Empty{},
+		Meta: snet.PathMetadata{
+			Interfaces: intfs,
+			Expiry:     time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func pinnedPath(p snet.Path) *sdpb.PinnedPath {
+	pinned := &sdpb.PinnedPath{DestinationIsdAs: uint64(overridesDst)}
+	for _, intf := range p.Metadata().Interfaces {
+		pinned.Interfaces = append(pinned.Interfaces, &sdpb.PathInterface{
+			IsdAs: uint64(intf.IA),
+			Id:    uint64(intf.ID),
+		})
+	}
+	return pinned
+}
+
+func TestPathOverridesApply(t *testing.T) {
+	direct := overridesPath()
+	transit := overridesPath(overridesVia)
+	other := addr.MustParseIA("1-ff00:0:113")
+	detour := overridesPath(other)
+	paths := []snet.Path{direct, transit, detour}
+
+	testCases := map[string]struct {
+		Overrides *sdpb.PathOverrides
+		Dst       addr.IA
+		Expected  []snet.Path
+	}{
+		"no overrides": {
+			Overrides: &sdpb.PathOverrides{},
+			Dst:       overridesDst,
+			Expected:  paths,
+		},
+		"blocked interface": {
+			Overrides: &sdpb.PathOverrides{
+				Blocked: []*sdpb.BlockedInterface{{IsdAs: uint64(overridesVia), Id: 2}},
+			},
+			Dst:      overridesDst,
+			Expected: []snet.Path{direct, detour},
+		},
+		"blocked AS": {
+			Overrides: &sdpb.PathOverrides{
+				Blocked: []*sdpb.BlockedInterface{
+					{IsdAs: uint64(overridesVia)},
+					{IsdAs: uint64(other)},
+				},
+			},
+			Dst:      overridesDst,
+			Expected: []snet.Path{direct},
+		},
+		"unused interface": {
+			Overrides: &sdpb.PathOverrides{
+				Blocked: []*sdpb.BlockedInterface{{IsdAs: uint64(overridesVia), Id: 3}},
+			},
+			Dst:      overridesDst,
+			Expected: paths,
+		},
+		"pinned paths": {
+			Overrides: &sdpb.PathOverrides{
+				Pinned: []*sdpb.PinnedPath{pinnedPath(detour), pinnedPath(transit)},
+			},
+			Dst:      overridesDst,
+			Expected: []snet.Path{detour, transit, direct},
+		},
+		"pinned path of other destination": {
+			Overrides: &sdpb.PathOverrides{
+				Pinned: []*sdpb.PinnedPath{pinnedPath(detour)},
+			},
+			Dst:      overridesVia,
+			Expected: paths,
+		},
+		"blocked pinned path": {
+			Overrides: &sdpb.PathOverrides{
+				Blocked: []*sdpb.BlockedInterface{{IsdAs: uint64(other)}},
+				Pinned:  []*sdpb.PinnedPath{pinnedPath(detour), pinnedPath(transit)},
+			},
+			Dst:      overridesDst,
+			Expected: []snet.Path{transit, direct},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var overrides servers.PathOverrides
+			require.NoError(t, overrides.Set(tc.Overrides))
+			input := append([]snet.Path(nil), paths...)
+			assert.Equal(t, tc.Expected, overrides.Apply(tc.Dst, input))
+			// The input may be shared with other requests, it must not be modified.
+			assert.Equal(t, paths, input)
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		var overrides *servers.PathOverrides
+		assert.Equal(t, paths, overrides.Apply(overridesDst, paths))
+	})
+}
+
+func TestSetPathOverrides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fetcher := mock_fetcher.NewMockFetcher(ctrl)
+	fetcher.EXPECT().GetPaths(gomock.Any(), overridesSrc, overridesDst, false).
+		Return([]snet.Path{overridesPath(), overridesPath(overridesVia)}, nil).AnyTimes()
+	s := &servers.DaemonServer{
+		Fetcher:   fetcher,
+		Overrides: &servers.PathOverrides{},
+	}
+	ctx := context.Background()
+	paths := func() []*sdpb.Path {
+		response, err := s.Paths(ctx, &sdpb.PathsRequest{
+			SourceIsdAs:      uint64(overridesSrc),
+			DestinationIsdAs: uint64(overridesDst),
+		})
+		require.NoError(t, err)
+		return response.Paths
+	}
+	assert.Len(t, paths(), 2)
+
+	overrides := &sdpb.PathOverrides{
+		Blocked: []*sdpb.BlockedInterface{{IsdAs: uint64(overridesVia)}},
+	}
+	_, err := s.SetPathOverrides(ctx, &sdpb.SetPathOverridesRequest{Overrides: overrides})
+	require.NoError(t, err)
+	assert.Len(t, paths(), 1)
+	response, err := s.PathOverrides(ctx, &sdpb.PathOverridesRequest{})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(overrides, response.Overrides))
+
+	// Invalid overrides are rejected, and the current ones are kept.
+	_, err = s.SetPathOverrides(ctx, &sdpb.SetPathOverridesRequest{
+		Overrides: &sdpb.PathOverrides{
+			Pinned: []*sdpb.PinnedPath{{DestinationIsdAs: uint64(overridesDst)}},
+		},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Len(t, paths(), 1)
+
+	// Empty overrides clear the current ones.
+	_, err = s.SetPathOverrides(ctx, &sdpb.SetPathOverridesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, paths(), 2)
+
+	disabled := &servers.DaemonServer{}
+	_, err = disabled.SetPathOverrides(ctx, &sdpb.SetPathOverridesRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestSubscribePathsOverrides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	direct, transit := overridesPath(), overridesPath(overridesVia)
+	fetcher := mock_fetcher.NewMockFetcher(ctrl)
+	fetcher.EXPECT().GetPaths(gomock.Any(), overridesSrc, overridesDst, false).
+		Return([]snet.Path{direct, transit}, nil).AnyTimes()
+	s := &servers.DaemonServer{
+		Fetcher:            fetcher,
+		PathUpdateInterval: time.Hour,
+		Overrides:          &servers.PathOverrides{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &subscribeStream{
+		ctx:       ctx,
+		responses: make(chan *sdpb.SubscribePathsResponse, 2),
+	}
+	done := make(chan error)
+	go func() {
+		done <- s.SubscribePaths(&sdpb.SubscribePathsRequest{
+			SourceIsdAs:      uint64(overridesSrc),
+			DestinationIsdAs: uint64(overridesDst),
+		}, stream)
+	}()
+
+	first := <-stream.responses
+	require.Len(t, first.Paths, 2)
+	assert.Len(t, first.Paths[0].Interfaces, 2)
+
+	// Pinning a path only changes the order of the paths, the subscription is updated anyway.
+	_, err := s.SetPathOverrides(context.Background(), &sdpb.SetPathOverridesRequest{
+		Overrides: &sdpb.PathOverrides{Pinned: []*sdpb.PinnedPath{pinnedPath(transit)}},
+	})
+	require.NoError(t, err)
+	second := <-stream.responses
+	require.Len(t, second.Paths, 2)
+	assert.Len(t, second.Paths[0].Interfaces, 4)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
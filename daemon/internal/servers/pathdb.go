@@ -0,0 +1,137 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	seg "github.com/scionproto/scion/pkg/segment"
+)
+
+// ExportPathDB returns a snapshot of the path segments in the path database, e.g., to reproduce
+// a failing path lookup offline.
+func (s *DaemonServer) ExportPathDB(ctx context.Context,
+	_ *sdpb.ExportPathDBRequest) (*sdpb.ExportPathDBResponse, error) {
+
+	if s.PathDB == nil {
+		return nil, status.Error(codes.Unimplemented, "path database snapshots are not enabled")
+	}
+	results, err := s.PathDB.GetAll(ctx)
+	if err != nil {
+		return nil, serrors.Wrap("reading path database", err)
+	}
+	segments := make([]*sdpb.PathDBSegment, 0, len(results))
+	for _, r := range results {
+		segments = append(segments, &sdpb.PathDBSegment{
+			Segment:            seg.PathSegmentToPB(r.Seg),
+			Type:               cppb.SegmentType(r.Type),
+			LastUpdate:         timestamppb.New(r.LastUpdate),
+			HiddenPathGroupIds: r.HPGroupIDs,
+		})
+	}
+	return &sdpb.ExportPathDBResponse{
+		Snapshot: &sdpb.PathDBSnapshot{
+			IsdAs:     uint64(s.IA),
+			Timestamp: timestamppb.Now(),
+			Segments:  segments,
+		},
+	}, nil
+}
+
+// ImportPathDB inserts the path segments of a snapshot into the path database. The segments are
+// not verified, so importing is only allowed if AllowPathDBImport is set, e.g., in a test daemon
+// that reproduces a failing path lookup.
+//
+// The segment lookups that the imported segments answer are not repeated until the segments
+// expire, so that the daemon returns the paths of the snapshot without fetching segments from
+// the control service.
+func (s *DaemonServer) ImportPathDB(ctx context.Context,
+	req *sdpb.ImportPathDBRequest) (*sdpb.ImportPathDBResponse, error) {
+
+	if s.PathDB == nil {
+		return nil, status.Error(codes.Unimplemented, "path database snapshots are not enabled")
+	}
+	if !s.AllowPathDBImport {
+		return nil, status.Error(codes.FailedPrecondition,
+			"importing path database snapshots is not allowed")
+	}
+	metas := make([]*seg.Meta, 0, len(req.Snapshot.GetSegments()))
+	for i, pb := range req.Snapshot.GetSegments() {
+		ps, err := seg.SegmentFromPB(pb.Segment)
+		if err != nil {
+			return nil, invalidArgument(serrors.Wrap("parsing segment", err, "index", i))
+		}
+		typ := seg.Type(pb.Type)
+		if typ != seg.TypeUp && typ != seg.TypeDown && typ != seg.TypeCore {
+			return nil, invalidArgument(serrors.New("invalid segment type",
+				"index", i, "type", pb.Type))
+		}
+		metas = append(metas, &seg.Meta{Type: typ, Segment: ps})
+	}
+	var inserted, updated int
+	for i, meta := range metas {
+		stats, err := s.PathDB.InsertWithHPGroupIDs(ctx, meta,
+			req.Snapshot.Segments[i].HiddenPathGroupIds)
+		if err != nil {
+			return nil, serrors.Wrap("inserting segment", err, "index", i)
+		}
+		inserted += stats.Inserted
+		updated += stats.Updated
+		for _, lookup := range segmentLookups(meta) {
+			_, err := s.PathDB.InsertNextQuery(ctx, lookup[0], lookup[1],
+				meta.Segment.MaxExpiry())
+			if err != nil {
+				return nil, serrors.Wrap("inserting next query", err, "index", i)
+			}
+		}
+	}
+	log.FromCtx(ctx).Info("Path database snapshot imported",
+		"isd_as", addr.IA(req.Snapshot.GetIsdAs()),
+		"timestamp", req.Snapshot.GetTimestamp().AsTime(),
+		"inserted", inserted, "updated", updated)
+	return &sdpb.ImportPathDBResponse{
+		Inserted: uint32(inserted),
+		Updated:  uint32(updated),
+	}, nil
+}
+
+// segmentLookups returns the source and destination of the segment lookups that the segment
+// answers. The lookups are for the ISD-AS at either end of the segment, or for the wildcard
+// ISD-AS of its ISD.
+func segmentLookups(meta *seg.Meta) [][2]addr.IA {
+	// The up and core segments are looked up against their construction direction.
+	src, dst := meta.Segment.LastIA(), meta.Segment.FirstIA()
+	if meta.Type == seg.TypeDown {
+		src, dst = dst, src
+	}
+	wildcard := func(ia addr.IA) addr.IA {
+		return addr.MustIAFrom(ia.ISD(), 0)
+	}
+	return [][2]addr.IA{
+		{src, dst},
+		{wildcard(src), dst},
+		{src, wildcard(dst)},
+		{wildcard(src), wildcard(dst)},
+	}
+}
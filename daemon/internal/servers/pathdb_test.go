@@ -0,0 +1,132 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/pathdb"
+	"github.com/scionproto/scion/private/storage/path/sqlite"
+)
+
+func newPathDB(t *testing.T) pathdb.DB {
+	db, err := sqlite.New("file::memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestPathDBSnapshot(t *testing.T) {
+	ctx := context.Background()
+	g := graph.NewDefaultGraph(gomock.NewController(t))
+	up := g.Beacon([]uint16{graph.If_120_X_111_B})
+	core := g.Beacon([]uint16{graph.If_110_X_120_A})
+	down := g.Beacon([]uint16{graph.If_130_B_111_A})
+
+	exportDB := newPathDB(t)
+	_, err := exportDB.Insert(ctx, &seg.Meta{Type: seg.TypeUp, Segment: up})
+	require.NoError(t, err)
+	_, err = exportDB.Insert(ctx, &seg.Meta{Type: seg.TypeCore, Segment: core})
+	require.NoError(t, err)
+	_, err = exportDB.InsertWithHPGroupIDs(ctx, &seg.Meta{Type: seg.TypeDown, Segment: down},
+		[]uint64{42})
+	require.NoError(t, err)
+	exporter := &servers.DaemonServer{IA: addr.MustParseIA("1-ff00:0:111"), PathDB: exportDB}
+	exported, err := exporter.ExportPathDB(ctx, &sdpb.ExportPathDBRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(addr.MustParseIA("1-ff00:0:111")), exported.Snapshot.IsdAs)
+	assert.Len(t, exported.Snapshot.Segments, 3)
+
+	t.Run("import", func(t *testing.T) {
+		importDB := newPathDB(t)
+		importer := &servers.DaemonServer{PathDB: importDB, AllowPathDBImport: true}
+		rep, err := importer.ImportPathDB(ctx,
+			&sdpb.ImportPathDBRequest{Snapshot: exported.Snapshot})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3), rep.Inserted)
+
+		want, err := exportDB.GetAll(ctx)
+		require.NoError(t, err)
+		got, err := importDB.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, got, len(want))
+		segs := map[string]seg.Type{}
+		groups := map[string][]uint64{}
+		for _, r := range got {
+			segs[r.Seg.GetLoggingID()] = r.Type
+			groups[r.Seg.GetLoggingID()] = r.HPGroupIDs
+		}
+		for _, r := range want {
+			assert.Equal(t, r.Type, segs[r.Seg.GetLoggingID()])
+			assert.Equal(t, r.HPGroupIDs, groups[r.Seg.GetLoggingID()])
+		}
+
+		// The lookups that the imported segments answer are not repeated before the segments
+		// expire.
+		for _, lookup := range []struct {
+			src, dst string
+			seg      *seg.PathSegment
+		}{
+			{src: "1-ff00:0:111", dst: "1-ff00:0:120", seg: up},
+			{src: "1-ff00:0:111", dst: "1-0", seg: up},
+			{src: "1-0", dst: "1-0", seg: core},
+			{src: "1-ff00:0:120", dst: "1-ff00:0:110", seg: core},
+			{src: "1-0", dst: "1-ff00:0:111", seg: down},
+			{src: "1-ff00:0:130", dst: "1-ff00:0:111", seg: down},
+		} {
+			nq, err := importDB.GetNextQuery(ctx, addr.MustParseIA(lookup.src),
+				addr.MustParseIA(lookup.dst))
+			require.NoError(t, err)
+			assert.Equal(t, lookup.seg.MaxExpiry().Unix(), nq.Unix(), lookup.src+" "+lookup.dst)
+		}
+
+		// Importing the snapshot again updates nothing.
+		rep, err = importer.ImportPathDB(ctx,
+			&sdpb.ImportPathDBRequest{Snapshot: exported.Snapshot})
+		require.NoError(t, err)
+		assert.Equal(t, &sdpb.ImportPathDBResponse{}, rep)
+	})
+	t.Run("import not allowed", func(t *testing.T) {
+		importer := &servers.DaemonServer{PathDB: newPathDB(t)}
+		_, err := importer.ImportPathDB(ctx,
+			&sdpb.ImportPathDBRequest{Snapshot: exported.Snapshot})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+	t.Run("invalid segment type", func(t *testing.T) {
+		importer := &servers.DaemonServer{PathDB: newPathDB(t), AllowPathDBImport: true}
+		_, err := importer.ImportPathDB(ctx, &sdpb.ImportPathDBRequest{
+			Snapshot: &sdpb.PathDBSnapshot{
+				Segments: []*sdpb.PathDBSegment{{Segment: exported.Snapshot.Segments[0].Segment}},
+			},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("not enabled", func(t *testing.T) {
+		_, err := (&servers.DaemonServer{}).ExportPathDB(ctx, &sdpb.ExportPathDBRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+// DefaultPathErrorTTL is the default time for which a reported path error affects the path.
+// It is the TTL of the revocations that the applications report.
+const DefaultPathErrorTTL = 10 * time.Second
+
+// PathErrors are the SCMP errors that the local applications reported on paths. The daemon
+// drops or demotes the affected paths for all applications until the reports expire. The zero
+// value has no reports, and it is safe for concurrent use.
+type PathErrors struct {
+	// TTL is the time for which a report affects the path. If zero, DefaultPathErrorTTL is
+	// used.
+	TTL time.Duration
+
+	mu sync.Mutex
+	// reports are the reports by path, see pathErrorKey.
+	reports map[string]pathErrorReport
+	// changes notifies the path subscriptions when a path is reported.
+	changes subscribers
+}
+
+type pathErrorReport struct {
+	expires time.Time
+	// drop drops the path, otherwise it is demoted.
+	drop bool
+}
+
+// Report reports an error on the raw SCION path. If drop is set, the path is dropped, otherwise
+// it is demoted.
+func (e *PathErrors) Report(raw []byte, drop bool) error {
+	key, err := pathErrorKey(raw)
+	if err != nil {
+		return err
+	}
+	ttl := e.TTL
+	if ttl == 0 {
+		ttl = DefaultPathErrorTTL
+	}
+	e.mu.Lock()
+	if e.reports == nil {
+		e.reports = make(map[string]pathErrorReport)
+	}
+	prev, ok := e.reports[key]
+	e.reports[key] = pathErrorReport{
+		expires: time.Now().Add(ttl),
+		drop:    drop || (ok && prev.drop && time.Now().Before(prev.expires)),
+	}
+	e.mu.Unlock()
+	e.changes.notify()
+	return nil
+}
+
+// Apply applies the reports to the paths. The dropped paths are removed, and the demoted paths
+// are moved to the back. The input is not modified, it may be shared with other requests. A nil
+// PathErrors returns the paths unchanged.
+func (e *PathErrors) Apply(paths []snet.Path) []snet.Path {
+	if e == nil {
+		return paths
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	for key, r := range e.reports {
+		if !now.Before(r.expires) {
+			delete(e.reports, key)
+		}
+	}
+	if len(e.reports) == 0 {
+		return paths
+	}
+	result := make([]snet.Path, 0, len(paths))
+	var demoted []snet.Path
+	for _, p := range paths {
+		r, ok := e.report(p)
+		switch {
+		case !ok:
+			result = append(result, p)
+		case !r.drop:
+			demoted = append(demoted, p)
+		}
+	}
+	return append(result, demoted...)
+}
+
+func (e *PathErrors) report(p snet.Path) (pathErrorReport, bool) {
+	scionPath, ok := p.Dataplane().(snetpath.SCION)
+	if !ok {
+		return pathErrorReport{}, false
+	}
+	key, err := pathErrorKey(scionPath.Raw)
+	if err != nil {
+		return pathErrorReport{}, false
+	}
+	r, ok := e.reports[key]
+	return r, ok
+}
+
+func (e *PathErrors) subscribe() chan struct{} {
+	if e == nil {
+		return nil
+	}
+	return e.changes.subscribe()
+}
+
+func (e *PathErrors) unsubscribe(c chan struct{}) {
+	if e == nil {
+		return
+	}
+	e.changes.unsubscribe(c)
+}
+
+// pathErrorKey identifies a raw SCION path, independently of the fields that the routers update
+// while they forward the packets, so that the paths quoted in the SCMP errors match the paths
+// returned by the daemon.
+func pathErrorKey(raw []byte) (string, error) {
+	var p scion.Decoded
+	if err := p.DecodeFromBytes(raw); err != nil {
+		return "", serrors.Wrap("decoding path", err)
+	}
+	p.PathMeta.CurrINF, p.PathMeta.CurrHF = 0, 0
+	for i := range p.InfoFields {
+		p.InfoFields[i].SegID = 0
+	}
+	b := make([]byte, p.Len())
+	if err := p.SerializeTo(b); err != nil {
+		return "", serrors.Wrap("serializing path", err)
+	}
+	return string(b), nil
+}
+
+// ReportPathError handles an SCMP error that an application received for a packet sent on a
+// path. The interface down errors revoke the interface. The parameter problems, which are
+// caused by the path itself, drop the path, and the other errors demote it.
+func (s *DaemonServer) ReportPathError(ctx context.Context,
+	req *sdpb.ReportPathErrorRequest) (*sdpb.ReportPathErrorResponse, error) {
+
+	if req.ScmpType > 255 || req.ScmpCode > 255 {
+		return nil, invalidArgument(serrors.New("invalid SCMP type or code",
+			"type", req.ScmpType, "code", req.ScmpCode))
+	}
+	typeCode := slayers.CreateSCMPTypeCode(slayers.SCMPType(req.ScmpType),
+		slayers.SCMPCode(req.ScmpCode))
+	if typeCode.InfoMsg() {
+		return nil, invalidArgument(serrors.New("not an SCMP error", "scmp", typeCode))
+	}
+	logger := log.FromCtx(ctx)
+	switch typeCode.Type() {
+	case slayers.SCMPTypeExternalInterfaceDown, slayers.SCMPTypeInternalConnectivityDown:
+		ia := addr.IA(req.IsdAs)
+		if ia.IsWildcard() || req.InterfaceId == 0 {
+			return nil, invalidArgument(serrors.New("interface down error without interface",
+				"isd_as", ia, "interface_id", req.InterfaceId))
+		}
+		start := time.Now()
+		err := s.revokeInterface(ctx, ia, req.InterfaceId)
+		s.Metrics.InterfaceDownNotifications.inc(
+			ifDownLabels{Result: errToMetricResult(err), Src: "path_error"},
+			time.Since(start).Seconds(),
+		)
+		if err != nil {
+			return nil, unwrapMetricsError(err)
+		}
+	default:
+		if s.PathErrors == nil {
+			return nil, status.Error(codes.Unimplemented, "path error reports are not enabled")
+		}
+		drop := typeCode.Type() == slayers.SCMPTypeParameterProblem
+		if err := s.PathErrors.Report(req.Path, drop); err != nil {
+			return nil, invalidArgument(serrors.Wrap("reporting path error", err))
+		}
+		logger.Debug("Path error reported", "scmp", typeCode, "src", addr.IA(req.IsdAs),
+			"drop", drop)
+	}
+	return &sdpb.ReportPathErrorResponse{}, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/revcache/mock_revcache"
+)
+
+// pathErrorsDecoded returns a single segment SCION path that leaves the local AS over the
+// egress interface.
+func pathErrorsDecoded(egress uint16) scion.Decoded {
+	return scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{SegLen: [3]uint8{2, 0, 0}},
+			NumINF:   1,
+			NumHops:  2,
+		},
+		InfoFields: []path.InfoField{{ConsDir: true, SegID: 0x1234}},
+		HopFields: []path.HopField{
+			{ConsEgress: egress, ExpTime: 63},
+			{ConsIngress: 2, ExpTime: 63},
+		},
+	}
+}
+
+func pathErrorsRaw(t *testing.T, d scion.Decoded) []byte {
+	raw := make([]byte, d.Len())
+	require.NoError(t, d.SerializeTo(raw))
+	return raw
+}
+
+func pathErrorsPath(t *testing.T, egress uint16) snet.Path {
+	dataplane, err := snetpath.NewSCIONFromDecoded(pathErrorsDecoded(egress))
+	require.NoError(t, err)
+	return snetpath.Path{DataplanePath: dataplane}
+}
+
+func TestPathErrorsApply(t *testing.T) {
+	first, second, third := pathErrorsPath(t, 1), pathErrorsPath(t, 2), pathErrorsPath(t, 3)
+	paths := []snet.Path{first, second, third}
+
+	t.Run("dropped and demoted paths", func(t *testing.T) {
+		var errs servers.PathErrors
+		require.NoError(t, errs.Report(pathErrorsRaw(t, pathErrorsDecoded(1)), false))
+		require.NoError(t, errs.Report(pathErrorsRaw(t, pathErrorsDecoded(2)), true))
+		input := append([]snet.Path(nil), paths...)
+		assert.Equal(t, []snet.Path{third, first}, errs.Apply(input))
+		// The input may be shared with other requests, it must not be modified.
+		assert.Equal(t, paths, input)
+	})
+	t.Run("quoted path", func(t *testing.T) {
+		// The routers update the current hop and the segment IDs of the packets, the quoted
+		// path must still match.
+		quoted := pathErrorsDecoded(2)
+		quoted.PathMeta.CurrHF = 1
+		quoted.InfoFields[0].SegID = 0x4321
+		var errs servers.PathErrors
+		require.NoError(t, errs.Report(pathErrorsRaw(t, quoted), true))
+		assert.Equal(t, []snet.Path{first, third}, errs.Apply(paths))
+	})
+	t.Run("demotion keeps drop", func(t *testing.T) {
+		var errs servers.PathErrors
+		require.NoError(t, errs.Report(pathErrorsRaw(t, pathErrorsDecoded(2)), true))
+		require.NoError(t, errs.Report(pathErrorsRaw(t, pathErrorsDecoded(2)), false))
+		assert.Equal(t, []snet.Path{first, third}, errs.Apply(paths))
+	})
+	t.Run("expired report", func(t *testing.T) {
+		errs := servers.PathErrors{TTL: time.Millisecond}
+		require.NoError(t, errs.Report(pathErrorsRaw(t, pathErrorsDecoded(2)), true))
+		time.Sleep(5 * time.Millisecond)
+		assert.Equal(t, paths, errs.Apply(paths))
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		var errs servers.PathErrors
+		assert.Error(t, errs.Report([]byte{1, 2, 3}, true))
+	})
+	t.Run("nil", func(t *testing.T) {
+		var errs *servers.PathErrors
+		assert.Equal(t, paths, errs.Apply(paths))
+	})
+}
+
+func TestReportPathError(t *testing.T) {
+	ctx := context.Background()
+	raw := pathErrorsRaw(t, pathErrorsDecoded(1))
+	destinationUnreachable := uint32(slayers.SCMPTypeDestinationUnreachable)
+
+	t.Run("not enabled", func(t *testing.T) {
+		s := &servers.DaemonServer{}
+		_, err := s.ReportPathError(ctx, &sdpb.ReportPathErrorRequest{
+			Path:     raw,
+			ScmpType: destinationUnreachable,
+		})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+	t.Run("destination unreachable", func(t *testing.T) {
+		s := &servers.DaemonServer{PathErrors: &servers.PathErrors{}}
+		_, err := s.ReportPathError(ctx, &sdpb.ReportPathErrorRequest{
+			Path:     raw,
+			ScmpType: destinationUnreachable,
+		})
+		require.NoError(t, err)
+		first, second := pathErrorsPath(t, 1), pathErrorsPath(t, 2)
+		assert.Equal(t, []snet.Path{second, first},
+			s.PathErrors.Apply([]snet.Path{first, second}))
+	})
+	t.Run("parameter problem", func(t *testing.T) {
+		s := &servers.DaemonServer{PathErrors: &servers.PathErrors{}}
+		_, err := s.ReportPathError(ctx, &sdpb.ReportPathErrorRequest{
+			Path:     raw,
+			ScmpType: uint32(slayers.SCMPTypeParameterProblem),
+		})
+		require.NoError(t, err)
+		first, second := pathErrorsPath(t, 1), pathErrorsPath(t, 2)
+		assert.Equal(t, []snet.Path{second}, s.PathErrors.Apply([]snet.Path{first, second}))
+	})
+	t.Run("interface down", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ia := addr.MustParseIA("1-ff00:0:110")
+		revCache := mock_revcache.NewMockRevCache(ctrl)
+		revCache.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, rev *path_mgmt.RevInfo) (bool, error) {
+				assert.Equal(t, ia, rev.IA())
+				assert.Equal(t, iface.ID(5), rev.IfID)
+				return true, nil
+			},
+		)
+		s := &servers.DaemonServer{RevCache: revCache}
+		_, err := s.ReportPathError(ctx, &sdpb.ReportPathErrorRequest{
+			Path:        raw,
+			ScmpType:    uint32(slayers.SCMPTypeExternalInterfaceDown),
+			IsdAs:       uint64(ia),
+			InterfaceId: 5,
+		})
+		require.NoError(t, err)
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		s := &servers.DaemonServer{PathErrors: &servers.PathErrors{}}
+		for name, req := range map[string]*sdpb.ReportPathErrorRequest{
+			"info message": {
+				Path:     raw,
+				ScmpType: uint32(slayers.SCMPTypeEchoRequest),
+			},
+			"invalid type": {
+				Path:     raw,
+				ScmpType: 256,
+			},
+			"invalid path": {
+				Path:     []byte{1, 2, 3},
+				ScmpType: destinationUnreachable,
+			},
+			"interface down without interface": {
+				ScmpType: uint32(slayers.SCMPTypeExternalInterfaceDown),
+			},
+		} {
+			_, err := s.ReportPathError(ctx, req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"encoding/json"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/path/pathpol"
+)
+
+// pathPolicy returns the path policy of the request, or nil if the request has none.
+func (s *DaemonServer) pathPolicy(req *sdpb.PathsRequest) (*pathpol.Policy, error) {
+	switch {
+	case req.Policy != "" && len(req.InlinePolicy) > 0:
+		return nil, metricsError{
+			err:    invalidArgument(serrors.New("policy and inline policy are mutually exclusive")),
+			result: prom.ErrInvalidReq,
+		}
+	case req.Policy != "":
+		policy, ok := s.PathPolicies[req.Policy]
+		if !ok {
+			return nil, metricsError{
+				err:    invalidArgument(serrors.New("unknown path policy", "policy", req.Policy)),
+				result: prom.ErrInvalidReq,
+			}
+		}
+		return policy, nil
+	case len(req.InlinePolicy) > 0:
+		policy, err := s.inlinePathPolicy(req.InlinePolicy)
+		if err != nil {
+			return nil, metricsError{
+				err:    invalidArgument(serrors.Wrap("parsing inline path policy", err)),
+				result: prom.ErrParse,
+			}
+		}
+		return policy, nil
+	default:
+		return nil, nil
+	}
+}
+
+// invalidArgument marks the error as caused by an invalid request.
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// inlinePathPolicy parses a JSON encoded path policy, which can extend the installed path
+// policies. The installed policies are copied, they are shared by all requests.
+func (s *DaemonServer) inlinePathPolicy(raw []byte) (*pathpol.Policy, error) {
+	var ext pathpol.ExtPolicy
+	if err := json.Unmarshal(raw, &ext); err != nil {
+		return nil, err
+	}
+	if ext.Policy == nil {
+		ext.Policy = &pathpol.Policy{}
+	}
+	installed := make([]*pathpol.ExtPolicy, 0, len(s.PathPolicies))
+	for _, p := range s.PathPolicies {
+		p := *p
+		installed = append(installed, &pathpol.ExtPolicy{Policy: &p})
+	}
+	return pathpol.PolicyFromExtPolicy(&ext, installed)
+}
+
+// applyPathPolicy filters the paths with the policy and orders the remaining ones by the number
+// of hops.
+func applyPathPolicy(policy *pathpol.Policy, paths []snet.Path) []snet.Path {
+	if policy == nil {
+		return paths
+	}
+	// The fetched paths can be shared with concurrent requests, sort a copy.
+	paths = append([]snet.Path(nil), policy.Filter(paths)...)
+	sort.SliceStable(paths, func(i, j int) bool {
+		return len(paths[i].Metadata().Interfaces) < len(paths[j].Metadata().Interfaces)
+	})
+	return paths
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/pkg/addr"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/path/pathpol"
+)
+
+func TestPathsPolicy(t *testing.T) {
+	src, dst := addr.MustParseIA("1-ff00:0:110"), addr.MustParseIA("1-ff00:0:111")
+	via := addr.MustParseIA("1-ff00:0:112")
+	newPath := func(ias ...addr.IA) snet.Path {
+		var intfs []snet.PathInterface
+		for i := 0; i < len(ias)-1; i++ {
+			intfs = append(intfs,
+				snet.PathInterface{IA: ias[i], ID: 1},
+				snet.PathInterface{IA: ias[i+1], ID: 2},
+			)
+		}
+		return snetpath.Path{
+			Src:           src,
+			Dst:           dst,
+			DataplanePath: snetpath.Empty{},
+			Meta: snet.PathMetadata{
+				Interfaces: intfs,
+				Expiry:     time.Now().Add(time.Hour),
+			},
+		}
+	}
+	paths := []snet.Path{newPath(src, via, dst), newPath(src, dst)}
+
+	var policies pathpol.PolicyMap
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"avoid_112": {"acl": ["- 1-ff00:0:112", "+"]},
+		"all": {"acl": ["+"]}
+	}`), &policies))
+	installed := map[string]*pathpol.Policy{}
+	for name, p := range policies {
+		p.Name = name
+		installed[name] = p.Policy
+	}
+
+	testCases := map[string]struct {
+		Policy       string
+		InlinePolicy string
+		Hops         []int
+		AssertErr    assert.ErrorAssertionFunc
+	}{
+		"no policy": {
+			Hops:      []int{4, 2},
+			AssertErr: assert.NoError,
+		},
+		"installed policy": {
+			Policy:    "avoid_112",
+			Hops:      []int{2},
+			AssertErr: assert.NoError,
+		},
+		"ordered by hops": {
+			Policy:    "all",
+			Hops:      []int{2, 4},
+			AssertErr: assert.NoError,
+		},
+		"inline policy": {
+			InlinePolicy: `{"acl": ["- 1-ff00:0:111#2", "+"]}`,
+			Hops:         nil,
+			AssertErr:    assert.NoError,
+		},
+		"inline policy extending installed policy": {
+			InlinePolicy: `{"extends": ["avoid_112"]}`,
+			Hops:         []int{2},
+			AssertErr:    assert.NoError,
+		},
+		"unknown policy": {
+			Policy:    "unknown",
+			AssertErr: assert.Error,
+		},
+		"invalid inline policy": {
+			InlinePolicy: `{"acl": 1}`,
+			AssertErr:    assert.Error,
+		},
+		"both policies": {
+			Policy:       "all",
+			InlinePolicy: `{}`,
+			AssertErr:    assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fetcher := mock_fetcher.NewMockFetcher(ctrl)
+			fetcher.EXPECT().GetPaths(gomock.Any(), src, dst, false).Return(paths, nil).AnyTimes()
+			s := &servers.DaemonServer{
+				Fetcher:      fetcher,
+				PathPolicies: installed,
+			}
+			req := &sdpb.PathsRequest{
+				SourceIsdAs:      uint64(src),
+				DestinationIsdAs: uint64(dst),
+				Policy:           tc.Policy,
+			}
+			if tc.InlinePolicy != "" {
+				req.InlinePolicy = []byte(tc.InlinePolicy)
+			}
+			reply, err := s.Paths(context.Background(), req)
+			tc.AssertErr(t, err)
+			if err != nil {
+				return
+			}
+			var hops []int
+			for _, p := range reply.Paths {
+				hops = append(hops, len(p.Interfaces))
+			}
+			assert.Equal(t, tc.Hops, hops)
+		})
+	}
+}
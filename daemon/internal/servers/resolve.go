@@ -0,0 +1,55 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/daemon/resolver"
+	"github.com/scionproto/scion/pkg/log"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+)
+
+// ResolveName resolves the host name of the request to SCION addresses.
+func (s *DaemonServer) ResolveName(ctx context.Context,
+	req *sdpb.ResolveNameRequest) (*sdpb.ResolveNameResponse, error) {
+
+	if s.Resolver == nil {
+		return nil, status.Error(codes.Unimplemented, "name resolution is not enabled")
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty name")
+	}
+	addrs, err := s.Resolver.Resolve(ctx, req.Name)
+	if errors.Is(err, resolver.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "name %q not found", req.Name)
+	}
+	if err != nil {
+		log.FromCtx(ctx).Debug("Resolving name", "name", req.Name, "err", err)
+		return nil, status.Errorf(codes.Unavailable, "resolving name: %s", err)
+	}
+	response := &sdpb.ResolveNameResponse{}
+	for _, a := range addrs {
+		response.Addresses = append(response.Addresses, &sdpb.HostAddress{
+			IsdAs: uint64(a.IA),
+			Ip:    a.Host.IP().String(),
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/daemon/resolver"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+)
+
+func TestResolveName(t *testing.T) {
+	names := nameResolver{
+		"server.example.org": {
+			addr.MustParseAddr("1-ff00:0:110,10.0.0.1"),
+			addr.MustParseAddr("1-ff00:0:111,2001:db8::1"),
+		},
+	}
+	testCases := map[string]struct {
+		Resolver resolver.Resolver
+		Name     string
+		Expected []*sdpb.HostAddress
+		Code     codes.Code
+	}{
+		"found": {
+			Resolver: names,
+			Name:     "server.example.org",
+			Expected: []*sdpb.HostAddress{
+				{IsdAs: uint64(addr.MustParseIA("1-ff00:0:110")), Ip: "10.0.0.1"},
+				{IsdAs: uint64(addr.MustParseIA("1-ff00:0:111")), Ip: "2001:db8::1"},
+			},
+			Code: codes.OK,
+		},
+		"not found": {
+			Resolver: names,
+			Name:     "other.example.org",
+			Code:     codes.NotFound,
+		},
+		"empty name": {
+			Resolver: names,
+			Code:     codes.InvalidArgument,
+		},
+		"resolver failure": {
+			Resolver: nameResolverFunc(func(string) ([]addr.Addr, error) {
+				return nil, serrors.New("DNS server unreachable")
+			}),
+			Name: "server.example.org",
+			Code: codes.Unavailable,
+		},
+		"disabled": {
+			Name: "server.example.org",
+			Code: codes.Unimplemented,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := &servers.DaemonServer{Resolver: tc.Resolver}
+			response, err := s.ResolveName(context.Background(),
+				&sdpb.ResolveNameRequest{Name: tc.Name})
+			require.Equal(t, tc.Code, status.Code(err), "err: %v", err)
+			if tc.Code != codes.OK {
+				return
+			}
+			assert.Equal(t, tc.Expected, response.Addresses)
+		})
+	}
+}
+
+type nameResolver map[string][]addr.Addr
+
+func (r nameResolver) Resolve(_ context.Context, name string) ([]addr.Addr, error) {
+	if addrs, ok := r[name]; ok {
+		return addrs, nil
+	}
+	return nil, resolver.ErrNotFound
+}
+
+type nameResolverFunc func(name string) ([]addr.Addr, error)
+
+func (f nameResolverFunc) Resolve(_ context.Context, name string) ([]addr.Addr, error) {
+	return f(name)
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// revocationBufferSize is the number of notifications that are buffered for each revocation
+// subscriber. Subscribers that fall further behind are disconnected.
+const revocationBufferSize = 64
+
+// RevocationFeed distributes the revoked interfaces and the unreachable paths that the daemon
+// learns about to the subscribed applications. The zero value is ready to use, and it is safe
+// for concurrent use.
+type RevocationFeed struct {
+	mu   sync.Mutex
+	subs map[chan *sdpb.SubscribeRevocationsResponse]struct{}
+}
+
+// Revoked notifies the subscribers that the interface of the revocation is revoked.
+func (f *RevocationFeed) Revoked(rev *path_mgmt.RevInfo) {
+	f.publish(&sdpb.SubscribeRevocationsResponse{
+		Interfaces: []*sdpb.RevokedInterface{{
+			IsdAs:      uint64(rev.IA()),
+			Id:         uint64(rev.IfID),
+			Expiration: timestamppb.New(rev.Expiration()),
+		}},
+	})
+}
+
+// Unreachable notifies the subscribers that the paths were found to be unreachable.
+func (f *RevocationFeed) Unreachable(paths []snet.Path) {
+	fingerprints := make([][]byte, 0, len(paths))
+	for _, p := range paths {
+		fingerprints = append(fingerprints, []byte(snet.Fingerprint(p)))
+	}
+	f.publish(&sdpb.SubscribeRevocationsResponse{UnreachablePaths: fingerprints})
+}
+
+func (f *RevocationFeed) subscribe() chan *sdpb.SubscribeRevocationsResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.subs == nil {
+		f.subs = make(map[chan *sdpb.SubscribeRevocationsResponse]struct{})
+	}
+	c := make(chan *sdpb.SubscribeRevocationsResponse, revocationBufferSize)
+	f.subs[c] = struct{}{}
+	return c
+}
+
+func (f *RevocationFeed) unsubscribe(c chan *sdpb.SubscribeRevocationsResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.subs, c)
+}
+
+// publish sends the notification to all subscribers. The channels of the subscribers whose
+// buffer is full are closed, so that they don't miss notifications silently.
+func (f *RevocationFeed) publish(n *sdpb.SubscribeRevocationsResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for c := range f.subs {
+		select {
+		case c <- n:
+		default:
+			delete(f.subs, c)
+			close(c)
+		}
+	}
+}
+
+// SubscribeRevocations streams the revoked interfaces and the unreachable paths that the daemon
+// learns about, from interface down notifications, from the path segments, and from the path
+// probes. The stream ends when the client cancels it, or with codes.ResourceExhausted if the
+// client does not keep up with the notifications.
+func (s *DaemonServer) SubscribeRevocations(_ *sdpb.SubscribeRevocationsRequest,
+	stream sdpb.DaemonService_SubscribeRevocationsServer) error {
+
+	if s.RevocationFeed == nil {
+		return status.Error(codes.Unimplemented, "revocation subscriptions are not enabled")
+	}
+	ctx := stream.Context()
+	c := s.RevocationFeed.subscribe()
+	defer s.RevocationFeed.unsubscribe(c)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n, ok := <-c:
+			if !ok {
+				return status.Error(codes.ResourceExhausted,
+					"subscriber does not keep up with the revocations")
+			}
+			if err := stream.Send(n); err != nil {
+				return err
+			}
+		}
+	}
+}
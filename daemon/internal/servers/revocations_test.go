@@ -0,0 +1,141 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
+	"github.com/scionproto/scion/pkg/private/util"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+type revocationStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *sdpb.SubscribeRevocationsResponse
+}
+
+func (s *revocationStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *revocationStream) Send(r *sdpb.SubscribeRevocationsResponse) error {
+	s.responses <- r
+	return nil
+}
+
+// subscribeRevocations starts a revocation subscription on the server, and waits until the
+// subscription receives the revocation. The subscription ends when cancel is called.
+func subscribeRevocations(t *testing.T, s *servers.DaemonServer,
+	rev *path_mgmt.RevInfo) (*revocationStream, func(), chan error) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	stream := &revocationStream{
+		ctx:       ctx,
+		responses: make(chan *sdpb.SubscribeRevocationsResponse),
+	}
+	errC := make(chan error, 1)
+	go func() {
+		errC <- s.SubscribeRevocations(&sdpb.SubscribeRevocationsRequest{}, stream)
+	}()
+	var r *sdpb.SubscribeRevocationsResponse
+	// Notifications that are published before the subscription is registered are not
+	// delivered, so publish until the first one arrives.
+	require.Eventually(t, func() bool {
+		s.RevocationFeed.Revoked(rev)
+		select {
+		case r = <-stream.responses:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+	require.Len(t, r.Interfaces, 1)
+	assert.Equal(t, uint64(rev.IA()), r.Interfaces[0].IsdAs)
+	assert.Equal(t, uint64(rev.IfID), r.Interfaces[0].Id)
+	assert.WithinDuration(t, rev.Expiration(), r.Interfaces[0].Expiration.AsTime(), 0)
+	return stream, cancel, errC
+}
+
+func TestSubscribeRevocations(t *testing.T) {
+	ia := addr.MustParseIA("1-ff00:0:110")
+	rev := &path_mgmt.RevInfo{
+		RawIsdas:     ia,
+		IfID:         3,
+		RawTTL:       10,
+		RawTimestamp: util.TimeToSecs(time.Now()),
+	}
+
+	t.Run("not enabled", func(t *testing.T) {
+		s := &servers.DaemonServer{}
+		err := s.SubscribeRevocations(&sdpb.SubscribeRevocationsRequest{},
+			&revocationStream{ctx: context.Background()})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+	t.Run("revoked interfaces and unreachable paths", func(t *testing.T) {
+		s := &servers.DaemonServer{RevocationFeed: &servers.RevocationFeed{}}
+		stream, cancel, errC := subscribeRevocations(t, s, rev)
+
+		path := snetpath.Path{
+			DataplanePath: snetpath.Empty{},
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{{IA: ia, ID: 3}},
+			},
+		}
+		s.RevocationFeed.Unreachable([]snet.Path{path})
+		for r := range stream.responses {
+			// Skip the remaining notifications of the subscription setup.
+			if len(r.Interfaces) > 0 {
+				continue
+			}
+			assert.Equal(t, [][]byte{[]byte(snet.Fingerprint(path))}, r.UnreachablePaths)
+			break
+		}
+		cancel()
+		assert.NoError(t, <-errC)
+	})
+	t.Run("slow subscriber", func(t *testing.T) {
+		s := &servers.DaemonServer{RevocationFeed: &servers.RevocationFeed{}}
+		stream, _, errC := subscribeRevocations(t, s, rev)
+
+		// The stream is not read, so the buffer of the subscription fills up.
+		for range 100 {
+			s.RevocationFeed.Revoked(rev)
+		}
+		for {
+			select {
+			case <-stream.responses:
+				continue
+			case err := <-errC:
+				assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			}
+			break
+		}
+	})
+}
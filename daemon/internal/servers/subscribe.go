@@ -0,0 +1,176 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// DefaultPathUpdateInterval is the default interval at which the paths of a subscription are
+// fetched again.
+const DefaultPathUpdateInterval = 10 * time.Second
+
+// subscribers notifies the path subscriptions about events that may change their paths, e.g.,
+// revocations or topology changes, so that they don't have to wait for the next update interval.
+type subscribers struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+func (r *subscribers) subscribe() chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.subs == nil {
+		r.subs = make(map[chan struct{}]struct{})
+	}
+	c := make(chan struct{}, 1)
+	r.subs[c] = struct{}{}
+	return c
+}
+
+func (r *subscribers) unsubscribe(c chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subs, c)
+}
+
+func (r *subscribers) notify() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for c := range r.subs {
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// SubscribePaths streams the paths to the destination. The current paths are sent immediately,
+// and again whenever they change, either because the paths were fetched again after the update
+// interval, because an interface on them was revoked, because the topology of the local AS
+// changed, because the path overrides changed, or because an application reported an error on
+// one of them. The stream ends when the client cancels it.
+func (s *DaemonServer) SubscribePaths(req *sdpb.SubscribePathsRequest,
+	stream sdpb.DaemonService_SubscribePathsServer) error {
+
+	ctx := stream.Context()
+	srcIA, dstIA := addr.IA(req.SourceIsdAs), addr.IA(req.DestinationIsdAs)
+	logger := log.FromCtx(ctx).New("src", srcIA, "dst", dstIA)
+
+	revoked := s.revocations.subscribe()
+	defer s.revocations.unsubscribe(revoked)
+	topologyChanged := s.topologyChanges.subscribe()
+	defer s.topologyChanges.unsubscribe(topologyChanged)
+	overridesChanged := s.Overrides.subscribe()
+	defer s.Overrides.unsubscribe(overridesChanged)
+	pathErrorReported := s.PathErrors.subscribe()
+	defer s.PathErrors.unsubscribe(pathErrorReported)
+	interval := s.PathUpdateInterval
+	if interval == 0 {
+		interval = DefaultPathUpdateInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	paths, err := s.subscriptionPaths(ctx, srcIA, dstIA, false)
+	if err != nil {
+		logger.Debug("Fetching paths for subscription", "err", err)
+		return err
+	}
+	key := pathSetKey(paths)
+	if err := stream.Send(s.pathsToSubscribeResponse(dstIA, paths)); err != nil {
+		return err
+	}
+	for {
+		refresh, overridden := false, false
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-revoked:
+			refresh = true
+		case <-topologyChanged:
+		case <-overridesChanged:
+			// The pinned paths may only change the order of the paths, they are sent even
+			// if the set of paths is the same.
+			overridden = true
+		case <-pathErrorReported:
+			// Likewise, the demoted paths only change the order.
+			overridden = true
+		}
+		paths, err := s.subscriptionPaths(ctx, srcIA, dstIA, refresh)
+		if err != nil {
+			// The subscription outlives transient errors, the paths are fetched again at the
+			// next interval.
+			logger.Debug("Fetching paths for subscription", "err", err)
+			continue
+		}
+		if k := pathSetKey(paths); overridden || k != key {
+			key = k
+			if err := stream.Send(s.pathsToSubscribeResponse(dstIA, paths)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// TopologyChanged notifies the server that the topology of the local AS changed. The path
+// subscriptions are updated, so that their clients get the paths with the new underlay next
+// hops.
+func (s *DaemonServer) TopologyChanged() {
+	s.topologyChanges.notify()
+}
+
+func (s *DaemonServer) subscriptionPaths(
+	ctx context.Context,
+	src, dst addr.IA,
+	refresh bool,
+) ([]snet.Path, error) {
+
+	ctx, cancelF := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelF()
+	paths, err := s.fetchPaths(ctx, &s.foregroundPathDedupe, src, dst, refresh)
+	return s.PathErrors.Apply(paths), err
+}
+
+func (s *DaemonServer) pathsToSubscribeResponse(
+	dst addr.IA,
+	paths []snet.Path,
+) *sdpb.SubscribePathsResponse {
+
+	return &sdpb.SubscribePathsResponse{Paths: s.pathsToPB(dst, paths)}
+}
+
+// pathSetKey identifies a set of paths, independently of their order. Paths that are refreshed
+// with a new expiration time, or whose underlay next hop changed, change the key.
+func pathSetKey(paths []snet.Path) string {
+	keys := make([]string, 0, len(paths))
+	for _, p := range paths {
+		keys = append(keys, fmt.Sprintf("%s@%d@%s", snet.Fingerprint(p),
+			p.Metadata().Expiry.Unix(), p.UnderlayNextHop()))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/scionproto/scion/daemon/fetcher/mock_fetcher"
+	"github.com/scionproto/scion/daemon/internal/servers"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/revcache/mock_revcache"
+)
+
+type subscribeStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *sdpb.SubscribePathsResponse
+}
+
+func (s *subscribeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *subscribeStream) Send(r *sdpb.SubscribePathsResponse) error {
+	s.responses <- r
+	return nil
+}
+
+func TestSubscribePaths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	src, dst := addr.MustParseIA("1-ff00:0:110"), addr.MustParseIA("1-ff00:0:111")
+	newPath := func(ifID iface.ID) snet.Path {
+		return snetpath.Path{
+			Src:           src,
+			Dst:           dst,
+			DataplanePath: snetpath.Empty{},
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: src, ID: ifID},
+					{IA: dst, ID: ifID},
+				},
+				Expiry: time.Now().Add(time.Hour),
+			},
+		}
+	}
+	fetcher := mock_fetcher.NewMockFetcher(ctrl)
+	gomock.InOrder(
+		fetcher.EXPECT().GetPaths(gomock.Any(), src, dst, false).
+			Return([]snet.Path{newPath(1), newPath(2)}, nil),
+		fetcher.EXPECT().GetPaths(gomock.Any(), src, dst, true).
+			Return([]snet.Path{newPath(2)}, nil),
+	)
+	revCache := mock_revcache.NewMockRevCache(ctrl)
+	revCache.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(true, nil)
+	s := &servers.DaemonServer{
+		Fetcher:            fetcher,
+		RevCache:           revCache,
+		PathUpdateInterval: time.Hour,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &subscribeStream{
+		ctx:       ctx,
+		responses: make(chan *sdpb.SubscribePathsResponse, 2),
+	}
+	done := make(chan error)
+	go func() {
+		done <- s.SubscribePaths(&sdpb.SubscribePathsRequest{
+			SourceIsdAs:      uint64(src),
+			DestinationIsdAs: uint64(dst),
+		}, stream)
+	}()
+
+	// The current paths are sent immediately.
+	assert.Len(t, (<-stream.responses).Paths, 2)
+
+	// A revocation refreshes the paths.
+	_, err := s.NotifyInterfaceDown(context.Background(), &sdpb.NotifyInterfaceDownRequest{
+		IsdAs: uint64(src),
+		Id:    1,
+	})
+	require.NoError(t, err)
+	assert.Len(t, (<-stream.responses).Paths, 1)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestSubscribePathsTopologyChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	src, dst := addr.MustParseIA("1-ff00:0:110"), addr.MustParseIA("1-ff00:0:111")
+	newPath := func(nextHop string) snet.Path {
+		return snetpath.Path{
+			Src:           src,
+			Dst:           dst,
+			DataplanePath: snetpath.Empty{},
+			NextHop:       xtest.MustParseUDPAddr(t, nextHop),
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: src, ID: 1},
+					{IA: dst, ID: 1},
+				},
+				Expiry: time.Now().Add(time.Hour),
+			},
+		}
+	}
+	fetcher := mock_fetcher.NewMockFetcher(ctrl)
+	gomock.InOrder(
+		fetcher.EXPECT().GetPaths(gomock.Any(), src, dst, false).
+			Return([]snet.Path{newPath("10.0.0.1:31000")}, nil),
+		fetcher.EXPECT().GetPaths(gomock.Any(), src, dst, false).
+			Return([]snet.Path{newPath("10.0.0.2:31000")}, nil),
+	)
+	s := &servers.DaemonServer{
+		Fetcher:            fetcher,
+		PathUpdateInterval: time.Hour,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &subscribeStream{
+		ctx:       ctx,
+		responses: make(chan *sdpb.SubscribePathsResponse, 2),
+	}
+	done := make(chan error)
+	go func() {
+		done <- s.SubscribePaths(&sdpb.SubscribePathsRequest{
+			SourceIsdAs:      uint64(src),
+			DestinationIsdAs: uint64(dst),
+		}, stream)
+	}()
+
+	first := <-stream.responses
+	require.Len(t, first.Paths, 1)
+	assert.Equal(t, "10.0.0.1:31000", first.Paths[0].Interface.Address.Address)
+
+	// A topology change updates the paths with the new underlay next hop.
+	s.TopologyChanged()
+	second := <-stream.responses
+	require.Len(t, second.Paths, 1)
+	assert.Equal(t, "10.0.0.2:31000", second.Paths[0].Interface.Address.Address)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -17,6 +17,9 @@ package mgmtapi
 import (
 	"net/http"
 
+	"github.com/scionproto/scion/daemon/auth"
+	"github.com/scionproto/scion/pkg/addr"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
 	cppkiapi "github.com/scionproto/scion/private/mgmtapi/cppki/api"
 	segapi "github.com/scionproto/scion/private/mgmtapi/segments/api"
 )
@@ -28,6 +31,13 @@ type Server struct {
 	Config         http.HandlerFunc
 	Info           http.HandlerFunc
 	LogLevel       http.HandlerFunc
+	// IA is the local ISD-AS, the source of the paths.
+	IA addr.IA
+	// Daemon serves the daemon API calls.
+	Daemon sdpb.DaemonServiceServer
+	// Authorizer authorizes the requests for DRKeys, if it is set. The token is taken from the
+	// Authorization header of the requests.
+	Authorizer *auth.Authorizer
 }
 
 // GetConfig is an indirection to the http handler.
@@ -0,0 +1,471 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmtapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/daemon/auth"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	drkeypb "github.com/scionproto/scion/pkg/proto/drkey"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	api "github.com/scionproto/scion/private/mgmtapi"
+)
+
+// GetPaths lists the paths to the destination.
+func (s *Server) GetPaths(w http.ResponseWriter, r *http.Request, isdAs IsdAs,
+	params GetPathsParams) {
+
+	dst, err := addr.ParseIA(isdAs)
+	if err != nil {
+		badRequest(w, "malformed destination ISD-AS", err)
+		return
+	}
+	req := &sdpb.PathsRequest{
+		SourceIsdAs:      uint64(s.IA),
+		DestinationIsdAs: uint64(dst),
+	}
+	if params.Refresh != nil {
+		req.Refresh = *params.Refresh
+	}
+	if params.Hidden != nil {
+		req.Hidden = *params.Hidden
+	}
+	if params.Policy != nil {
+		req.Policy = *params.Policy
+	}
+	if params.OrderBy != nil {
+		for _, o := range *params.OrderBy {
+			ordering, ok := pathOrderings[o]
+			if !ok {
+				badRequest(w, "invalid path ordering",
+					serrors.New("unknown path ordering", "order_by", o))
+				return
+			}
+			req.OrderBy = append(req.OrderBy, ordering)
+		}
+	}
+	if params.MaxPaths != nil {
+		if *params.MaxPaths < 1 {
+			badRequest(w, "invalid maximum number of paths",
+				serrors.New("maximum number of paths must be positive",
+					"max_paths", *params.MaxPaths))
+			return
+		}
+		req.MaxPaths = uint32(*params.MaxPaths)
+	}
+	reply, err := s.Daemon.Paths(r.Context(), req)
+	if err != nil {
+		daemonError(w, "error fetching paths", err)
+		return
+	}
+	paths := make([]Path, 0, len(reply.Paths))
+	for _, p := range reply.Paths {
+		paths = append(paths, pathFromPB(p))
+	}
+	writeJSON(w, paths)
+}
+
+var pathOrderings = map[GetPathsParamsOrderBy]sdpb.PathOrdering{
+	Hops:      sdpb.PathOrdering_PATH_ORDERING_HOPS,
+	Latency:   sdpb.PathOrdering_PATH_ORDERING_LATENCY,
+	Bandwidth: sdpb.PathOrdering_PATH_ORDERING_BANDWIDTH,
+	Expiry:    sdpb.PathOrdering_PATH_ORDERING_EXPIRY,
+}
+
+// GetAs returns information about the AS.
+func (s *Server) GetAs(w http.ResponseWriter, r *http.Request, isdAs IsdAs) {
+	ia, err := addr.ParseIA(isdAs)
+	if err != nil {
+		badRequest(w, "malformed ISD-AS", err)
+		return
+	}
+	reply, err := s.Daemon.AS(r.Context(), &sdpb.ASRequest{IsdAs: uint64(ia)})
+	if err != nil {
+		daemonError(w, "error getting AS information", err)
+		return
+	}
+	writeJSON(w, ASInfo{
+		IsdAs: addr.IA(reply.IsdAs).String(),
+		Core:  reply.Core,
+		Mtu:   int(reply.Mtu),
+	})
+}
+
+// GetInterfaces lists the interfaces of the local AS.
+func (s *Server) GetInterfaces(w http.ResponseWriter, r *http.Request) {
+	reply, err := s.Daemon.Interfaces(r.Context(), &sdpb.InterfacesRequest{})
+	if err != nil {
+		daemonError(w, "error getting interfaces", err)
+		return
+	}
+	intfs := make([]Interface, 0, len(reply.Interfaces))
+	for id, intf := range reply.Interfaces {
+		intfs = append(intfs, Interface{
+			Id:      int(id),
+			NextHop: intf.GetAddress().GetAddress(),
+		})
+	}
+	sort.Slice(intfs, func(i, j int) bool { return intfs[i].Id < intfs[j].Id })
+	writeJSON(w, intfs)
+}
+
+// GetDrkeyAsHost returns the AS-Host key.
+func (s *Server) GetDrkeyAsHost(w http.ResponseWriter, r *http.Request,
+	params GetDrkeyAsHostParams) {
+
+	if !s.authorize(w, r) {
+		return
+	}
+	meta, err := parseDRKeyMeta(params.Protocol, params.SrcIsdAs, params.DstIsdAs,
+		params.ValidAt)
+	if err != nil {
+		badRequest(w, "malformed query parameters", err)
+		return
+	}
+	reply, err := s.Daemon.DRKeyASHost(r.Context(), &sdpb.DRKeyASHostRequest{
+		ValTime:    meta.validAt,
+		ProtocolId: meta.protocol,
+		SrcIa:      meta.src,
+		DstIa:      meta.dst,
+		DstHost:    params.DstHost,
+	})
+	if err != nil {
+		daemonError(w, "error getting DRKey", err)
+		return
+	}
+	writeJSON(w, drkeyFromPB(reply.EpochBegin, reply.EpochEnd, reply.Key))
+}
+
+// GetDrkeyHostAs returns the Host-AS key.
+func (s *Server) GetDrkeyHostAs(w http.ResponseWriter, r *http.Request,
+	params GetDrkeyHostAsParams) {
+
+	if !s.authorize(w, r) {
+		return
+	}
+	meta, err := parseDRKeyMeta(params.Protocol, params.SrcIsdAs, params.DstIsdAs,
+		params.ValidAt)
+	if err != nil {
+		badRequest(w, "malformed query parameters", err)
+		return
+	}
+	reply, err := s.Daemon.DRKeyHostAS(r.Context(), &sdpb.DRKeyHostASRequest{
+		ValTime:    meta.validAt,
+		ProtocolId: meta.protocol,
+		SrcIa:      meta.src,
+		DstIa:      meta.dst,
+		SrcHost:    params.SrcHost,
+	})
+	if err != nil {
+		daemonError(w, "error getting DRKey", err)
+		return
+	}
+	writeJSON(w, drkeyFromPB(reply.EpochBegin, reply.EpochEnd, reply.Key))
+}
+
+// GetDrkeyHostHost returns the Host-Host key.
+func (s *Server) GetDrkeyHostHost(w http.ResponseWriter, r *http.Request,
+	params GetDrkeyHostHostParams) {
+
+	if !s.authorize(w, r) {
+		return
+	}
+	meta, err := parseDRKeyMeta(params.Protocol, params.SrcIsdAs, params.DstIsdAs,
+		params.ValidAt)
+	if err != nil {
+		badRequest(w, "malformed query parameters", err)
+		return
+	}
+	reply, err := s.Daemon.DRKeyHostHost(r.Context(), &sdpb.DRKeyHostHostRequest{
+		ValTime:    meta.validAt,
+		ProtocolId: meta.protocol,
+		SrcIa:      meta.src,
+		DstIa:      meta.dst,
+		SrcHost:    params.SrcHost,
+		DstHost:    params.DstHost,
+	})
+	if err != nil {
+		daemonError(w, "error getting DRKey", err)
+		return
+	}
+	writeJSON(w, drkeyFromPB(reply.EpochBegin, reply.EpochEnd, reply.Key))
+}
+
+// GetPathDbSnapshot exports a snapshot of the path database, encoded as a serialized
+// proto.daemon.v1.PathDBSnapshot message.
+func (s *Server) GetPathDbSnapshot(w http.ResponseWriter, r *http.Request) {
+	reply, err := s.Daemon.ExportPathDB(r.Context(), &sdpb.ExportPathDBRequest{})
+	if err != nil {
+		daemonError(w, "error exporting path database", err)
+		return
+	}
+	raw, err := proto.Marshal(reply.Snapshot)
+	if err != nil {
+		daemonError(w, "error encoding snapshot", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(raw)
+}
+
+// PutPathDbSnapshot imports a snapshot of the path database that was exported with
+// GetPathDbSnapshot.
+func (s *Server) PutPathDbSnapshot(w http.ResponseWriter, r *http.Request) {
+	if !s.authorize(w, r) {
+		return
+	}
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, "error reading snapshot", err)
+		return
+	}
+	snapshot := &sdpb.PathDBSnapshot{}
+	if err := proto.Unmarshal(raw, snapshot); err != nil {
+		badRequest(w, "malformed snapshot", err)
+		return
+	}
+	reply, err := s.Daemon.ImportPathDB(r.Context(),
+		&sdpb.ImportPathDBRequest{Snapshot: snapshot})
+	if err != nil {
+		daemonError(w, "error importing path database", err)
+		return
+	}
+	writeJSON(w, PathDBImport{
+		Inserted: int(reply.Inserted),
+		Updated:  int(reply.Updated),
+	})
+}
+
+type drkeyMeta struct {
+	protocol drkeypb.Protocol
+	src      uint64
+	dst      uint64
+	validAt  *timestamppb.Timestamp
+}
+
+func parseDRKeyMeta(protocol string, src, dst IsdAs, validAt *time.Time) (drkeyMeta, error) {
+	var errs serrors.List
+	protoID, err := parseDRKeyProtocol(protocol)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	srcIA, err := addr.ParseIA(src)
+	if err != nil {
+		errs = append(errs, serrors.Wrap("invalid source ISD-AS", err))
+	}
+	dstIA, err := addr.ParseIA(dst)
+	if err != nil {
+		errs = append(errs, serrors.Wrap("invalid destination ISD-AS", err))
+	}
+	if err := errs.ToError(); err != nil {
+		return drkeyMeta{}, err
+	}
+	t := time.Now()
+	if validAt != nil {
+		t = *validAt
+	}
+	return drkeyMeta{
+		protocol: drkeypb.Protocol(protoID),
+		src:      uint64(srcIA),
+		dst:      uint64(dstIA),
+		validAt:  timestamppb.New(t),
+	}, nil
+}
+
+// parseDRKeyProtocol parses the lower case name or the number of the protocol.
+func parseDRKeyProtocol(protocol string) (drkey.Protocol, error) {
+	if id, err := strconv.ParseUint(protocol, 10, 16); err == nil {
+		return drkey.Protocol(id), nil
+	}
+	id, ok := drkey.ProtocolStringToId("PROTOCOL_" + strings.ToUpper(protocol))
+	if !ok {
+		return 0, serrors.New("unknown DRKey protocol", "protocol", protocol)
+	}
+	return id, nil
+}
+
+func pathFromPB(p *sdpb.Path) Path {
+	intfs := make([]snet.PathInterface, 0, len(p.Interfaces))
+	hops := make([]Hop, 0, len(p.Interfaces))
+	for _, intf := range p.Interfaces {
+		ia := addr.IA(intf.IsdAs)
+		intfs = append(intfs, snet.PathInterface{IA: ia, ID: iface.ID(intf.Id)})
+		hops = append(hops, Hop{IsdAs: ia.String(), Interface: int(intf.Id)})
+	}
+	fingerprint := snet.Fingerprint(snetpath.Path{
+		Meta: snet.PathMetadata{Interfaces: intfs},
+	})
+	return Path{
+		Fingerprint: fingerprint.String(),
+		Hops:        hops,
+		NextHop:     p.GetInterface().GetAddress().GetAddress(),
+		Mtu:         int(p.Mtu),
+		Expiration:  p.GetExpiration().AsTime().UTC(),
+		Raw:         p.Raw,
+		Metadata:    metadataFromPB(p),
+	}
+}
+
+// metadataFromPB converts the static info metadata of the path. The slices are never nil, so
+// that they are encoded as empty lists.
+func metadataFromPB(p *sdpb.Path) PathMetadata {
+	meta := PathMetadata{
+		Latency:      make([]string, 0, len(p.Latency)),
+		Bandwidth:    make([]int64, 0, len(p.Bandwidth)),
+		Geo:          make([]GeoCoordinates, 0, len(p.Geo)),
+		LinkType:     make([]PathMetadataLinkType, 0, len(p.LinkType)),
+		InternalHops: make([]int, 0, len(p.InternalHops)),
+		Notes:        append([]string{}, p.Notes...),
+	}
+	for _, l := range p.Latency {
+		latency := ""
+		if d := l.AsDuration(); d >= 0 {
+			latency = d.String()
+		}
+		meta.Latency = append(meta.Latency, latency)
+	}
+	for _, b := range p.Bandwidth {
+		meta.Bandwidth = append(meta.Bandwidth, int64(b))
+	}
+	for _, g := range p.Geo {
+		meta.Geo = append(meta.Geo, GeoCoordinates{
+			Latitude:  g.Latitude,
+			Longitude: g.Longitude,
+			Address:   g.Address,
+		})
+	}
+	for _, lt := range p.LinkType {
+		meta.LinkType = append(meta.LinkType, linkTypeFromPB(lt))
+	}
+	for _, h := range p.InternalHops {
+		meta.InternalHops = append(meta.InternalHops, int(h))
+	}
+	return meta
+}
+
+func linkTypeFromPB(lt sdpb.LinkType) PathMetadataLinkType {
+	switch lt {
+	case sdpb.LinkType_LINK_TYPE_DIRECT:
+		return Direct
+	case sdpb.LinkType_LINK_TYPE_MULTI_HOP:
+		return Multihop
+	case sdpb.LinkType_LINK_TYPE_OPEN_NET:
+		return Opennet
+	default:
+		return Unset
+	}
+}
+
+func drkeyFromPB(begin, end *timestamppb.Timestamp, key []byte) DRKey {
+	return DRKey{
+		Epoch: Validity{
+			NotBefore: begin.AsTime().UTC(),
+			NotAfter:  end.AsTime().UTC(),
+		},
+		Key: key,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(v); err != nil {
+		writeProblem(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(buf.Bytes())
+}
+
+// authorize authorizes the request, if an authorizer is set. It writes the error response and
+// returns false if the request is not authorized.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if s.Authorizer == nil {
+		return true
+	}
+	ctx := r.Context()
+	if header := r.Header.Get("Authorization"); header != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(auth.MetadataKey, header))
+	}
+	if err := s.Authorizer.Authorize(ctx); err != nil {
+		writeProblem(w, Problem{
+			Detail: api.StringRef(status.Convert(err).Message()),
+			Status: http.StatusForbidden,
+			Title:  "not authorized",
+			Type:   api.StringRef(api.Forbidden),
+		})
+		return false
+	}
+	return true
+}
+
+func badRequest(w http.ResponseWriter, title string, err error) {
+	writeProblem(w, Problem{
+		Detail: api.StringRef(err.Error()),
+		Status: http.StatusBadRequest,
+		Title:  title,
+		Type:   api.StringRef(api.BadRequest),
+	})
+}
+
+// daemonError reports an error of the daemon API. Invalid requests are reported as bad
+// requests.
+func daemonError(w http.ResponseWriter, title string, err error) {
+	if status.Code(err) == codes.InvalidArgument {
+		badRequest(w, title, err)
+		return
+	}
+	writeProblem(w, Problem{
+		Detail: api.StringRef(err.Error()),
+		Status: http.StatusInternalServerError,
+		Title:  title,
+		Type:   api.StringRef(api.InternalError),
+	})
+}
+
+// writeProblem writes a detailed error response.
+func writeProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	// no point in catching error here, there is nothing we can do about it anymore.
+	_ = enc.Encode(p)
+}
@@ -0,0 +1,274 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmtapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/scionproto/scion/daemon/auth"
+	"github.com/scionproto/scion/daemon/mgmtapi"
+	"github.com/scionproto/scion/pkg/addr"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+	drkeypb "github.com/scionproto/scion/pkg/proto/drkey"
+)
+
+// fakeDaemon answers the daemon API calls with fixed replies.
+type fakeDaemon struct {
+	sdpb.UnimplementedDaemonServiceServer
+	paths    *sdpb.PathsRequest
+	asHost   *sdpb.DRKeyASHostRequest
+	snapshot *sdpb.PathDBSnapshot
+	expires  time.Time
+}
+
+func (d *fakeDaemon) Paths(_ context.Context,
+	req *sdpb.PathsRequest) (*sdpb.PathsResponse, error) {
+
+	d.paths = req
+	if req.Policy == "unknown" {
+		return nil, status.Error(codes.InvalidArgument, "unknown path policy")
+	}
+	return &sdpb.PathsResponse{Paths: []*sdpb.Path{{
+		Raw: []byte{0x01, 0x02},
+		Interface: &sdpb.Interface{
+			Address: &sdpb.Underlay{Address: "10.0.0.1:31000"},
+		},
+		Interfaces: []*sdpb.PathInterface{
+			{IsdAs: uint64(addr.MustParseIA("1-ff00:0:110")), Id: 1},
+			{IsdAs: uint64(addr.MustParseIA("1-ff00:0:111")), Id: 2},
+		},
+		Mtu:        1472,
+		Expiration: timestamppb.New(d.expires),
+		Latency:    []*durationpb.Duration{durationpb.New(1200 * time.Microsecond)},
+		Bandwidth:  []uint64{1000000},
+		Geo: []*sdpb.GeoCoordinates{
+			{Latitude: 47.5, Longitude: 8.5, Address: "Zurich"},
+			{},
+		},
+		LinkType: []sdpb.LinkType{sdpb.LinkType_LINK_TYPE_DIRECT},
+		Notes:    []string{"first", "second"},
+	}}}, nil
+}
+
+func (d *fakeDaemon) Interfaces(context.Context,
+	*sdpb.InterfacesRequest) (*sdpb.InterfacesResponse, error) {
+
+	return &sdpb.InterfacesResponse{Interfaces: map[uint64]*sdpb.Interface{
+		2: {Address: &sdpb.Underlay{Address: "10.0.0.2:31000"}},
+		1: {Address: &sdpb.Underlay{Address: "10.0.0.1:31000"}},
+	}}, nil
+}
+
+func (d *fakeDaemon) DRKeyASHost(_ context.Context,
+	req *sdpb.DRKeyASHostRequest) (*sdpb.DRKeyASHostResponse, error) {
+
+	d.asHost = req
+	return &sdpb.DRKeyASHostResponse{
+		EpochBegin: timestamppb.New(d.expires.Add(-time.Hour)),
+		EpochEnd:   timestamppb.New(d.expires),
+		Key:        make([]byte, 16),
+	}, nil
+}
+
+func (d *fakeDaemon) ExportPathDB(context.Context,
+	*sdpb.ExportPathDBRequest) (*sdpb.ExportPathDBResponse, error) {
+
+	return &sdpb.ExportPathDBResponse{Snapshot: &sdpb.PathDBSnapshot{
+		IsdAs:     uint64(addr.MustParseIA("1-ff00:0:110")),
+		Timestamp: timestamppb.New(d.expires),
+	}}, nil
+}
+
+func (d *fakeDaemon) ImportPathDB(_ context.Context,
+	req *sdpb.ImportPathDBRequest) (*sdpb.ImportPathDBResponse, error) {
+
+	d.snapshot = req.Snapshot
+	return &sdpb.ImportPathDBResponse{Inserted: 3, Updated: 1}, nil
+}
+
+func TestGateway(t *testing.T) {
+	expires := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newHandler := func() (http.Handler, *fakeDaemon) {
+		d := &fakeDaemon{expires: expires}
+		s := &mgmtapi.Server{IA: addr.MustParseIA("1-ff00:0:110"), Daemon: d}
+		return mgmtapi.Handler(s), d
+	}
+	get := func(h http.Handler, url string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, url, nil))
+		return rr
+	}
+
+	t.Run("paths", func(t *testing.T) {
+		h, d := newHandler()
+		rr := get(h, "/paths/1-ff00:0:111?refresh=true&policy=short")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, uint64(addr.MustParseIA("1-ff00:0:111")), d.paths.DestinationIsdAs)
+		assert.Equal(t, uint64(addr.MustParseIA("1-ff00:0:110")), d.paths.SourceIsdAs)
+		assert.True(t, d.paths.Refresh)
+		assert.Equal(t, "short", d.paths.Policy)
+
+		var paths []mgmtapi.Path
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &paths))
+		require.Len(t, paths, 1)
+		assert.Equal(t, []mgmtapi.Hop{
+			{IsdAs: "1-ff00:0:110", Interface: 1},
+			{IsdAs: "1-ff00:0:111", Interface: 2},
+		}, paths[0].Hops)
+		assert.NotEmpty(t, paths[0].Fingerprint)
+		assert.Equal(t, "10.0.0.1:31000", paths[0].NextHop)
+		assert.Equal(t, 1472, paths[0].Mtu)
+		assert.Equal(t, expires, paths[0].Expiration)
+		assert.Equal(t, []byte{0x01, 0x02}, paths[0].Raw)
+		assert.Equal(t, mgmtapi.PathMetadata{
+			Latency:   []string{"1.2ms"},
+			Bandwidth: []int64{1000000},
+			Geo: []mgmtapi.GeoCoordinates{
+				{Latitude: 47.5, Longitude: 8.5, Address: "Zurich"},
+				{},
+			},
+			LinkType:     []mgmtapi.PathMetadataLinkType{mgmtapi.Direct},
+			InternalHops: []int{},
+			Notes:        []string{"first", "second"},
+		}, paths[0].Metadata)
+	})
+	t.Run("paths malformed destination", func(t *testing.T) {
+		h, _ := newHandler()
+		rr := get(h, "/paths/garbage")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+	t.Run("paths unknown policy", func(t *testing.T) {
+		h, _ := newHandler()
+		rr := get(h, "/paths/1-ff00:0:111?policy=unknown")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+	t.Run("paths ordering", func(t *testing.T) {
+		h, d := newHandler()
+		rr := get(h, "/paths/1-ff00:0:111?order_by=latency,hops&max_paths=3")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, []sdpb.PathOrdering{
+			sdpb.PathOrdering_PATH_ORDERING_LATENCY,
+			sdpb.PathOrdering_PATH_ORDERING_HOPS,
+		}, d.paths.OrderBy)
+		assert.Equal(t, uint32(3), d.paths.MaxPaths)
+	})
+	t.Run("paths invalid ordering", func(t *testing.T) {
+		h, _ := newHandler()
+		for _, query := range []string{"order_by=shortest", "max_paths=0"} {
+			rr := get(h, "/paths/1-ff00:0:111?"+query)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+	})
+	t.Run("interfaces", func(t *testing.T) {
+		h, _ := newHandler()
+		rr := get(h, "/interfaces")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var intfs []mgmtapi.Interface
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &intfs))
+		assert.Equal(t, []mgmtapi.Interface{
+			{Id: 1, NextHop: "10.0.0.1:31000"},
+			{Id: 2, NextHop: "10.0.0.2:31000"},
+		}, intfs)
+	})
+	t.Run("as unavailable", func(t *testing.T) {
+		h, _ := newHandler()
+		rr := get(h, "/as/1-ff00:0:110")
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+	t.Run("drkey as-host", func(t *testing.T) {
+		h, d := newHandler()
+		rr := get(h, "/drkey/as-host?protocol=scmp&src_isd_as=1-ff00:0:110"+
+			"&dst_isd_as=1-ff00:0:111&dst_host=10.0.0.3")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, drkeypb.Protocol_PROTOCOL_SCMP, d.asHost.ProtocolId)
+		assert.Equal(t, "10.0.0.3", d.asHost.DstHost)
+
+		var key mgmtapi.DRKey
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &key))
+		assert.Equal(t, expires, key.Epoch.NotAfter)
+		assert.Len(t, key.Key, 16)
+	})
+	t.Run("drkey unknown protocol", func(t *testing.T) {
+		h, _ := newHandler()
+		rr := get(h, "/drkey/as-host?protocol=garbage&src_isd_as=1-ff00:0:110"+
+			"&dst_isd_as=1-ff00:0:111&dst_host=10.0.0.3")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+	t.Run("path db snapshot", func(t *testing.T) {
+		h, d := newHandler()
+		rr := get(h, "/path_db/snapshot")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "application/octet-stream", rr.Header().Get("Content-Type"))
+		exported := rr.Body.Bytes()
+		snapshot := &sdpb.PathDBSnapshot{}
+		require.NoError(t, proto.Unmarshal(exported, snapshot))
+		assert.Equal(t, expires, snapshot.Timestamp.AsTime())
+
+		rr = httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/path_db/snapshot",
+			bytes.NewReader(exported)))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.True(t, proto.Equal(snapshot, d.snapshot))
+		var imported mgmtapi.PathDBImport
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &imported))
+		assert.Equal(t, mgmtapi.PathDBImport{Inserted: 3, Updated: 1}, imported)
+
+		rr = httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/path_db/snapshot",
+			strings.NewReader("garbage")))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+	t.Run("drkey authorization", func(t *testing.T) {
+		s := &mgmtapi.Server{
+			IA:         addr.MustParseIA("1-ff00:0:110"),
+			Daemon:     &fakeDaemon{expires: expires},
+			Authorizer: &auth.Authorizer{Tokens: []string{"secret"}},
+		}
+		h := mgmtapi.Handler(s)
+		url := "/drkey/as-host?protocol=scmp&src_isd_as=1-ff00:0:110" +
+			"&dst_isd_as=1-ff00:0:111&dst_host=10.0.0.3"
+
+		rr := get(h, url)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		for token, code := range map[string]int{
+			"wrong":  http.StatusForbidden,
+			"secret": http.StatusOK,
+		} {
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+			assert.Equal(t, code, rr.Code, token)
+		}
+		// Calls that are not sensitive do not require authorization.
+		assert.Equal(t, http.StatusOK, get(h, "/interfaces").Code)
+	})
+}
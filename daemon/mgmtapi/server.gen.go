@@ -13,6 +13,9 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get information about an AS
+	// (GET /as/{isd-as})
+	GetAs(w http.ResponseWriter, r *http.Request, isdAs IsdAs)
 	// List the certificate chains
 	// (GET /certificates)
 	GetCertificates(w http.ResponseWriter, r *http.Request, params GetCertificatesParams)
@@ -25,15 +28,36 @@ type ServerInterface interface {
 	// Prints the TOML configuration file.
 	// (GET /config)
 	GetConfig(w http.ResponseWriter, r *http.Request)
+	// Get an AS-Host DRKey
+	// (GET /drkey/as-host)
+	GetDrkeyAsHost(w http.ResponseWriter, r *http.Request, params GetDrkeyAsHostParams)
+	// Get a Host-AS DRKey
+	// (GET /drkey/host-as)
+	GetDrkeyHostAs(w http.ResponseWriter, r *http.Request, params GetDrkeyHostAsParams)
+	// Get a Host-Host DRKey
+	// (GET /drkey/host-host)
+	GetDrkeyHostHost(w http.ResponseWriter, r *http.Request, params GetDrkeyHostHostParams)
 	// Basic information page about the control service process.
 	// (GET /info)
 	GetInfo(w http.ResponseWriter, r *http.Request)
+	// List the interfaces of the local AS
+	// (GET /interfaces)
+	GetInterfaces(w http.ResponseWriter, r *http.Request)
 	// Get logging level
 	// (GET /log/level)
 	GetLogLevel(w http.ResponseWriter, r *http.Request)
 	// Set logging level
 	// (PUT /log/level)
 	SetLogLevel(w http.ResponseWriter, r *http.Request)
+	// Export a snapshot of the path database
+	// (GET /path_db/snapshot)
+	GetPathDbSnapshot(w http.ResponseWriter, r *http.Request)
+	// Import a snapshot of the path database
+	// (PUT /path_db/snapshot)
+	PutPathDbSnapshot(w http.ResponseWriter, r *http.Request)
+	// List the paths to a destination
+	// (GET /paths/{isd-as})
+	GetPaths(w http.ResponseWriter, r *http.Request, isdAs IsdAs, params GetPathsParams)
 	// List the SCION path segments
 	// (GET /segments)
 	GetSegments(w http.ResponseWriter, r *http.Request, params GetSegmentsParams)
@@ -61,6 +85,12 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
+// Get information about an AS
+// (GET /as/{isd-as})
+func (_ Unimplemented) GetAs(w http.ResponseWriter, r *http.Request, isdAs IsdAs) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List the certificate chains
 // (GET /certificates)
 func (_ Unimplemented) GetCertificates(w http.ResponseWriter, r *http.Request, params GetCertificatesParams) {
@@ -85,12 +115,36 @@ func (_ Unimplemented) GetConfig(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get an AS-Host DRKey
+// (GET /drkey/as-host)
+func (_ Unimplemented) GetDrkeyAsHost(w http.ResponseWriter, r *http.Request, params GetDrkeyAsHostParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a Host-AS DRKey
+// (GET /drkey/host-as)
+func (_ Unimplemented) GetDrkeyHostAs(w http.ResponseWriter, r *http.Request, params GetDrkeyHostAsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a Host-Host DRKey
+// (GET /drkey/host-host)
+func (_ Unimplemented) GetDrkeyHostHost(w http.ResponseWriter, r *http.Request, params GetDrkeyHostHostParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Basic information page about the control service process.
 // (GET /info)
 func (_ Unimplemented) GetInfo(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List the interfaces of the local AS
+// (GET /interfaces)
+func (_ Unimplemented) GetInterfaces(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get logging level
 // (GET /log/level)
 func (_ Unimplemented) GetLogLevel(w http.ResponseWriter, r *http.Request) {
@@ -103,6 +157,24 @@ func (_ Unimplemented) SetLogLevel(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Export a snapshot of the path database
+// (GET /path_db/snapshot)
+func (_ Unimplemented) GetPathDbSnapshot(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import a snapshot of the path database
+// (PUT /path_db/snapshot)
+func (_ Unimplemented) PutPathDbSnapshot(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the paths to a destination
+// (GET /paths/{isd-as})
+func (_ Unimplemented) GetPaths(w http.ResponseWriter, r *http.Request, isdAs IsdAs, params GetPathsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List the SCION path segments
 // (GET /segments)
 func (_ Unimplemented) GetSegments(w http.ResponseWriter, r *http.Request, params GetSegmentsParams) {
@@ -154,6 +226,32 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// GetAs operation middleware
+func (siw *ServerInterfaceWrapper) GetAs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd-as" -------------
+	var isdAs IsdAs
+
+	err = runtime.BindStyledParameterWithOptions("simple", "isd-as", chi.URLParam(r, "isd-as"), &isdAs, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isd-as", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAs(w, r, isdAs)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetCertificates operation middleware
 func (siw *ServerInterfaceWrapper) GetCertificates(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -265,6 +363,285 @@ func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetDrkeyAsHost operation middleware
+func (siw *ServerInterfaceWrapper) GetDrkeyAsHost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDrkeyAsHostParams
+
+	// ------------- Required query parameter "protocol" -------------
+
+	if paramValue := r.URL.Query().Get("protocol"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "protocol"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "protocol", r.URL.Query(), &params.Protocol)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "protocol", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "src_isd_as" -------------
+
+	if paramValue := r.URL.Query().Get("src_isd_as"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "src_isd_as"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "src_isd_as", r.URL.Query(), &params.SrcIsdAs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "src_isd_as", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "dst_isd_as" -------------
+
+	if paramValue := r.URL.Query().Get("dst_isd_as"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "dst_isd_as"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "dst_isd_as", r.URL.Query(), &params.DstIsdAs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dst_isd_as", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "dst_host" -------------
+
+	if paramValue := r.URL.Query().Get("dst_host"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "dst_host"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "dst_host", r.URL.Query(), &params.DstHost)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dst_host", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "valid_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "valid_at", r.URL.Query(), &params.ValidAt)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "valid_at", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDrkeyAsHost(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetDrkeyHostAs operation middleware
+func (siw *ServerInterfaceWrapper) GetDrkeyHostAs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDrkeyHostAsParams
+
+	// ------------- Required query parameter "protocol" -------------
+
+	if paramValue := r.URL.Query().Get("protocol"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "protocol"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "protocol", r.URL.Query(), &params.Protocol)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "protocol", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "src_isd_as" -------------
+
+	if paramValue := r.URL.Query().Get("src_isd_as"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "src_isd_as"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "src_isd_as", r.URL.Query(), &params.SrcIsdAs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "src_isd_as", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "dst_isd_as" -------------
+
+	if paramValue := r.URL.Query().Get("dst_isd_as"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "dst_isd_as"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "dst_isd_as", r.URL.Query(), &params.DstIsdAs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dst_isd_as", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "src_host" -------------
+
+	if paramValue := r.URL.Query().Get("src_host"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "src_host"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "src_host", r.URL.Query(), &params.SrcHost)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "src_host", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "valid_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "valid_at", r.URL.Query(), &params.ValidAt)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "valid_at", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDrkeyHostAs(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetDrkeyHostHost operation middleware
+func (siw *ServerInterfaceWrapper) GetDrkeyHostHost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDrkeyHostHostParams
+
+	// ------------- Required query parameter "protocol" -------------
+
+	if paramValue := r.URL.Query().Get("protocol"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "protocol"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "protocol", r.URL.Query(), &params.Protocol)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "protocol", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "src_isd_as" -------------
+
+	if paramValue := r.URL.Query().Get("src_isd_as"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "src_isd_as"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "src_isd_as", r.URL.Query(), &params.SrcIsdAs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "src_isd_as", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "dst_isd_as" -------------
+
+	if paramValue := r.URL.Query().Get("dst_isd_as"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "dst_isd_as"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "dst_isd_as", r.URL.Query(), &params.DstIsdAs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dst_isd_as", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "src_host" -------------
+
+	if paramValue := r.URL.Query().Get("src_host"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "src_host"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "src_host", r.URL.Query(), &params.SrcHost)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "src_host", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "dst_host" -------------
+
+	if paramValue := r.URL.Query().Get("dst_host"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "dst_host"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "dst_host", r.URL.Query(), &params.DstHost)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dst_host", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "valid_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "valid_at", r.URL.Query(), &params.ValidAt)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "valid_at", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDrkeyHostHost(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetInfo operation middleware
 func (siw *ServerInterfaceWrapper) GetInfo(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -280,6 +657,21 @@ func (siw *ServerInterfaceWrapper) GetInfo(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetInterfaces operation middleware
+func (siw *ServerInterfaceWrapper) GetInterfaces(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInterfaces(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetLogLevel operation middleware
 func (siw *ServerInterfaceWrapper) GetLogLevel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -310,6 +702,105 @@ func (siw *ServerInterfaceWrapper) SetLogLevel(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetPathDbSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) GetPathDbSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPathDbSnapshot(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PutPathDbSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) PutPathDbSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutPathDbSnapshot(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetPaths operation middleware
+func (siw *ServerInterfaceWrapper) GetPaths(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd-as" -------------
+	var isdAs IsdAs
+
+	err = runtime.BindStyledParameterWithOptions("simple", "isd-as", chi.URLParam(r, "isd-as"), &isdAs, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "isd-as", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPathsParams
+
+	// ------------- Optional query parameter "refresh" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "refresh", r.URL.Query(), &params.Refresh)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refresh", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "hidden" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "hidden", r.URL.Query(), &params.Hidden)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hidden", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "policy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "policy", r.URL.Query(), &params.Policy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "policy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order_by" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "order_by", r.URL.Query(), &params.OrderBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_paths" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_paths", r.URL.Query(), &params.MaxPaths)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_paths", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaths(w, r, isdAs, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetSegments operation middleware
 func (siw *ServerInterfaceWrapper) GetSegments(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -661,6 +1152,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/as/{isd-as}", wrapper.GetAs)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/certificates", wrapper.GetCertificates)
 	})
@@ -673,15 +1167,36 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/config", wrapper.GetConfig)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/drkey/as-host", wrapper.GetDrkeyAsHost)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/drkey/host-as", wrapper.GetDrkeyHostAs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/drkey/host-host", wrapper.GetDrkeyHostHost)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/info", wrapper.GetInfo)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/interfaces", wrapper.GetInterfaces)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/log/level", wrapper.GetLogLevel)
 	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/log/level", wrapper.SetLogLevel)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/path_db/snapshot", wrapper.GetPathDbSnapshot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/path_db/snapshot", wrapper.PutPathDbSnapshot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/paths/{isd-as}", wrapper.GetPaths)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/segments", wrapper.GetSegments)
 	})
@@ -18,59 +18,94 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaWXPjNhL+K11MHjYV6vKxifWmkTUTVeZwWUq2KrHXBZEtETMkwACgbK1X/30LAEnx",
-	"kkV5JrPO1k7Ng0UCjcaHrxt98NHxeBRzhkxJZ/joCJQxZxLNj1fEv8Y/EpRK//I4U8jMnySOQ+oRRTnr",
-	"fZSc6WfSCzAi+q9vBS6dofNNbye6Z9/K3kwR5hPhT4Tgwtlut67jo/QEjbUwZ6jXBJEuqt+mE7XcMQpF",
-	"l3pd1D9jwWP9xOrqU6koWyVUBujfMRKZMWoTozN0pBKUrZyt61Dp31lpT2k5lf5I6uEyWXxET919ws0d",
-	"CVdcT8QHEsWhFjsZX85GjltfpTiN+gcxsaN/xs30Us9ek5D6VG0Ozfs1G6dx0phRgb4z/L0Ji3znBfEN",
-	"26upfus6iiqz2wL8UDyzfP/czNQ7GAeEsvoZUSkTFIe2VTzmHZZHzargkYlwMw327MrTarfa2ytBtSq1",
-	"DR48azPbHnM7NKpUbD3+s1lE/R0ddtAVBBdQNHiA9ywsp5dlq1qS81PSPyOO6yy5iIhyhk6AD53UvJ46",
-	"uqmPTD8yetas8iceNxwZUyiWxMOSEmcn+Xw9YIXiaOdRRTMzv92CBfyuiApA4ipCpiDgcRNYVm4JqkFn",
-	"uez3h/3hYNB3XCcmSqHQXvSfNzf+952//U46y37n4vZx4J5th989nmzLj777tx73bQHT6eyyM5odAPIt",
-	"X73FNYZ1NMPscdmpv+WrFWUrsK9dB1kSGUeFi2RlMFlq34PmUrh1CztM31RUqGBrxd42YHYl+CLEqOG6",
-	"QEVog6YjCJKIMBBIfLIIEfAhDgkzVx3IGD1NOFAcVEAlcM9LhEDmIfAlqAAhtguCCogCKiHAMF4moZ4R",
-	"csPU4ijCfFjRNQLx11QLYRDwez04FtxD9LvwD0GVQgaUwYStQioDMyvXb8kFIFtRhiikC4lMSBhugHEF",
-	"MqEKfTOCcQYKvYBRj4QgFfmEAQ99FNJI06O1eiH9F/pdp3gAY84Yemb7ioNPFFkQiaBohD7wRDXxgzKp",
-	"CLMmVYX3l+spCFyiRc3ClJFNGnBylPei6wJ2V11YbID4vuYVgaUg1nhyYQK4AJksOrG2LXNihePZxNiF",
-	"d2QDC4REol85IMG5sotSmU+izOrHE+EheNzHMlS9dGDPyzHrGEp/o/gnZB3N5Y4+uI5Br2PRy31cImgn",
-	"R6YxqlBEJbIO6jxA+Gk+vwI7wGgGK2QoiD7/xcaozQVdUQYSxRqFIcXTFC7t7bx/6joReaCRNtzziwvX",
-	"iSizvwb9fpOzTD1KnQEy4EKTM4qI2NTsxhzMf5v0MxTGHn9hZE1oqNdsOhD7QO9wSZJQnyFZ8EQNFyFh",
-	"nxy3DfcTRv9IMNxUjaCIB3CmB1j2mSj8QRVwW1MffRhdTbvwIY55SuaiJVnvRRlcvx53fvix/4ML1Hgn",
-	"hlQFKECgx6MImW/nLnSAlylqANd4xZwypV8T6yM7+XH43Eu08dl1GBewCvnCHIndX0q3yjG3M54jTKQa",
-	"9ll7yajYdD/M7JVbvx/wIaaC2JN73CngE4XGepvoEPDYhhQKo4NRgg5Gcgo5RAiyMa7zcLZgVbYxZEik",
-	"uktirZbfXlH9XCoSxW2nNEWGOyFuEa2KTikqhVBnNp5+eA9xMeA5ECWmO94TcyPz747M6o4FGdlKBQ1R",
-	"jXmeWWK6mRKrB02OUSoi1N1nxZImMi+KcYsw5BrXAvRnY1+L0Rdn5/7ZmX8wRk/nHwgoy1WB+hFnj8v4",
-	"m9EQoZRkdZi0eXBZ32Mx/y5t88cLeHUBZxcwPoGT1/r/xRguL6F/CScjOP8BRhdwOYEfJ+bVObw+hf4F",
-	"DPpwOSgiI2Piod8pA1TFYH49ru+cJCrggmrPusY7ktZlWjmYnO1VF+Nx8aVElc6jqdpy0NDm1+MvVPQw",
-	"RlGobey26TbBWFa+YCnz6/Eho5hfj59dAEg3XFe+ZqztFLGULWuhI/Q7lkQLW1542h1R6bdIfSUKSsIm",
-	"oaf14fXU13FLSlXlVeBvcha7Tf9aYEp534yrO7JUFQWdk/7JSac/6PTP5v2L4fnF8PT0t6J5PnlXapkL",
-	"XHKBNaGDZwqtwFNYwS1soYBJtmOIUVDu10HZbtMcuuYjs0h2dDXNgzB7C1wSjCyrShezfazHa3NCIa2c",
-	"frffHWg8eIyMxNQZOqfdfvfEVh0CA3+vUP8xD1aoGm5NKpWNZE3eocINEE/bZb18JG2MTATCJ8bvWRrX",
-	"3jAdBAsemmSGetgFnQIJlEmowCNMB7BLGioUNv2xRY0uvE6EDncjLtC9YZyhGRwTKYFATISiXhISkUa6",
-	"OuCmEQJRcB9QL7BK73S8YamSWj/jeIBIoCxOVBdGsOA8RMIyffJAXXEQqBLBgIThDSti5oLAFRF+iFKm",
-	"YQUV6aHr3zoXMUTo3uiD09Q3QdfUd4bOG1TjIv76YASJUKGQzvD3R4dq9P9IUGjvaAvku6pUu+p9Ho40",
-	"SzMg3BFVktfOIpoFkjAsyUqnpdA62+2tW+5YnPT7R7UqWl1/hYpv7Q6sNzAMv3lDMdTcoGdPKpjmQN8f",
-	"11PJilwNykyZJWapo2Iz75Ip1nV1HUVWmjiOF8efqHOrp5YsvPdohnaov91r7G9wzwLGGRFT/GKQloEP",
-	"s3oPqbUH2pEm08opulklEmzL8rxG/9n0OrhK05nVytovjjd7T/U41vQWIV88gzrIPK49KZFwNXkHi41C",
-	"CVrW80j1Smvxoon10Ikx6ixpWIlBOvrfq8mb6XsYT67n09fT8Wg+MU9v2GhWJFK3271h5s3k/WXD6CdF",
-	"jUfHiHJaUNoc11+H11bdPeTmbElXBRrXuWZHHDxyhQ+qF4dp67R26+WXZW1Xs8TzUMplEsKHbPECuE1Y",
-	"5ar0Ck3+MhpXgjJlq5HzD+/egt1oYsXr+MpU8HJIeKTDSYtJFovuQ2Rquzp/LTxeEUk90FvTAY3GICYr",
-	"BFPyzUuzhajU9nCk3ItSyFe9vGG2D6q81/YnXkX5Gl8NS21pYaUpWMPIdeKkAZRZBRQj/xX3N18Fj6yV",
-	"WVx/dxNs/6dOadbmlDST02Jii6SvXoHck+Q15XayKbkzYxURyjQpkPkwmlVrsjBlMkbPqkCZT9fUT0i4",
-	"U8EGDjoxBNsZRh/WFO+7TbHDLNttLWioHIrRKu2o82Vjhbjawm/KgiqV3qNTtUq3EEVEGQnhCaVOMqVO",
-	"9ipVqjcfp9JXSdpKTYMj0raIKC/QhG9gavflZnAN2haMNX1UsdbeY/pXlsL5GKJq6Nlemud71oF7qmxt",
-	"xMbd2ePpZd14rKCs6XbAfOY7A4bpZd7wLCzdhekyNek4UbAmYYJApe0wo9COhQEpCMn6nh5nkvrGgxCI",
-	"BS7pg/EeJAx3BCg7KWJcg1bf1y6JSi0nkahdrvYe5l192oJI9IGztJaTeVOtii0qURthDU5MHpMpk26W",
-	"eKrgpiDLZvAhDrmPznBJQoluU66yO9lnZyulHphUG+MZJDUuosGGzxqKj03tJgPhSzAk1zn/2hooFNrz",
-	"zuw3GNk3sEWDftLUGi3afTp9LjzVrCK7z2vq8p+67erW+iJZ+OXCrWzfTdFWndeFtKD7YvPaw+3f9vdF",
-	"u+JNw4p7qzdPsa+5RvOXY2CLQs7VaP4TzCZv3k3ez9OCigHxiqgga6aXKzANM5xWnH3RNZh9+u4jqRJe",
-	"i/QjJAqlSoXPRSIVXHOuYFysbdh0AIkX6OB9T3pyfAuqCx+MPiQMN64JNebX4zylSdFAHyiTColp+JgP",
-	"vwp6c4ay0Uzmevft7KPeASoF8Hlw3fBFX6X7n9mC9nzOy27h5B37IzKBdNlFiOagup+fT+c01PL2lBM1",
-	"j3tU+o9U+tvO4lEHkNuOfLQN821Lj7uP2nuq4XPhtaqAW7Lsd6NPfkSQM6QsU2+wndBBa5kWrHZSm75f",
-	"+DPjivn1uIl18+tx98vU1VKCPY9fx1zr+0iWXe3ZTW8SG3PD72Vf6x7M/xn4zLhifj1Og4PfPo7uP3wc",
-	"/f3dfHI/rcQSu1FOI0WrMcPn03Rva8V+dLTOuJCI0Bk6gVLxsNd7DLhU2+FjzIXa9khMe+uB+fpLUO2v",
-	"DWJ6SPnjbPOxt3m8dR09tfz6dDA4P9GmeZtrU+X/2BQ9zcc0+GA/tV5sUmtIAwFzP2cNQ1sjrdfgJmsU",
-	"G2WqDAJD85W+4s0Vp2oke6S08dXVz1OIiDJ8LOpmcN7ebv8TAAD//yDXdgQCOgAA",
+	"H4sIAAAAAAAC/+w9+XPbNrr/Coa7P7x9pU7bTazfFNlJNc3hsdTdmTZ5Hoj8JKEmARYA7ah5+t/f4OAN",
+	"WpSdpOmbdHemMonjw3dfYD95AYsTRoFK4U0+eRxEwqgA/ccLHF7DHykIqf4KGJVA9U+cJBEJsCSMDn4X",
+	"jKpnIthCjNWvf3JYexPvH4Ni6YF5KwYLiWmIeXjJOePefr/3vRBEwEmiFvMmak/E7aZ737u4/hl2n213",
+	"s5pj10UaBCDEOo3QuwS4XltvjyFm1ADbDkTC2SqC+IfjgLkys1zgLLeAQr01ClgahYgyiQTwO0ByCxl+",
+	"+grCl4yvSBgC/drwlTZARGgIcSq3jJM/IdSgzekdjkgHFvrs8NmdS4ja+3ZVzdjTxZyumfqVcJYAl8Qw",
+	"fMA4qH9XV/vPFuQWuEb9dKEOi5EaiaaLvud7cpeAN/FWjEWANdcQEd5gcQj+uQinQg2PZdrcVKH4zfIX",
+	"xNZ23z6aS7U3o9EO3VJ2T9GaGaAiFuAITRc+wjREQ8QUuPdEgAIPPuI4icCbjE6fjXNoCZWwAa4Ro7BE",
+	"OITe5LcMdN+gwsD2wfckkWoJT6GNx4boeMVSiTCtooGtfodAi+5M4XWtaAxNTIdESEI3KRFbCG8ojvUY",
+	"u4aQnNDNIzApUr37zS3sbnC00RTOz+9dzi4WU89v7lKeRsKDKsyM/hl28ws1W7MakbtD8/6djavj3IEL",
+	"vyBEvrzjeA3QS6QqoR+VWctFqS0mtEkjIkQK/NCxymQucHnUrBo+siX8DIKWUwUK7E5ne8EJrB0HPEhr",
+	"PduQuRs26qzYefyTuYiEnt9EXWnhEhY1PlDwKFzOL6pStcZnJ3h4ij3fM7rBm3hb+Niz4vUQ6eYhUPUI",
+	"eLFbIZW56a8SDRIWbLtjyfduzSpN9brCAn48RUADFkKIbmHXLx9itZPQhKuGdQON2aSEYA27Uyu+AjZj",
+	"jIeEYgmieTwchhyEaEI8I3ckQPZ1ZhaU5lfvK4re+zXlJNj6aHFP5J/AI0xDF4IjLIlMQ4fFe23fIEL1",
+	"Nv95tUDPT1GIZRpXtjp91j959uN5CWvriGFZ7EbTeKXMjO9FjG7atsteHdzvef/sdPTs8HY1KuUnLYPh",
+	"57guEe4VsA3HyZYoi5owQTLsNgj5E0scGoVK4GscQEVGTh1290jb1mamiw1Lh7jCcosEbGKgEm1Z4gJ/",
+	"XobUpRYPQE/ho7zZsqRJzV9oqHhuV+dVzlKpvSgsEbunQj/M4a9y8GjYV/8bTU6Gw9PxQSnUui+HqOKv",
+	"2OXLAhO1eSwG1+Wze6Peej0cToaT0Wjo+V6CpQSuTvk/79+HP/T+6zfcWw975x8+jfzT/eRfn8b76qN/",
+	"/a8a98+SGpwvLnrTxQHd95ptXsMdRE3iRNnjughtNoRukHnte0DTWPsWsEo3mk/WTD3WkcyHMqrtm4cR",
+	"bJb94MCZ4rUmlPAxITaKcqpeSWJAWKL7LQm2mjCJ4lk9DUSVF8bD8ag3GvXGZ8vReDIeTs6G/bPxr2VV",
+	"HWIJPbWmC5drQjfAE06obALzsniZsYiCpArBeXgKwx+DdfAcr06GZ2vXLluWCPdZcxYXxUElx3fAhTkq",
+	"kRAf1ANK3ezzfTHnWBu3GCQOsTwcLGG5fZONfSjmiPFHEqexgpCKmAihXP2UEokYdaPHHVc8RkOsCRcy",
+	"0xNt2x3UDb7H8X0ni6+Q0UsiTIttjrP+ZdayLFA6t8GyXxYGA1uJbCVddUnDnmQ9oGEOjVPaLl7M44Rx",
+	"6bI+AriEsHn4t9ouKjwnJdsgjDK+Bw4om1slrZOwaRLiR+6CIw443KGEgwAqdbyqX9g1K7sPD0er2YEL",
+	"oEoIvQaRRlqssQFIYVyxACIaga0YflMSKoeE2LfmWCY4B1HmV4QpZSkNcn+mgo4+UqsAlZyAxY0OolFI",
+	"TKInn405oD+BM3SHo9ToiirBV5iG9ySUWzek+Wu0AnkPQBGmOyTvGQoYFRCkktyV9ZOv4P15ReRA9NEl",
+	"lXyHiI0JVlZ7NZfMpyOiyUl+GFWUWsFMQ/1PScYIlT+eei4Wq6u5DTD3ETcufy1XKCvGQ+BWo+RPiwO3",
+	"nTJbqOa6sHX5tJ1Vd83ldxxPr0pxdNNuRWguWyoFZccr505kTDZdZOzkMDMtB9Xzm5Qc/zf5YaSpqX6N",
+	"Kyc9TKwIS6BBS9BlXhIotn2YJ9sgt5s8yIZoShHEidwho78RoaGOP0VZdh1yl6+eZdi2LHEztTfqj2Ph",
+	"MkINrBB6e2MeOvFC6C1Sr6ts2gtZrEJl9bodF2quk4o5DWsyab3DlAqQnu+FhJusQZxGkhjbxRKgFKT3",
+	"ocPZKLOxrINvmSyOVFeUWuGoH0gLqovP2jZtRnia5/ySTjRqo4z5uqRlkJeMRqb7C7PhNhM2+dxMbILE",
+	"xOGgT9E2jTFFyv7hVQTK140wNalUkUCgUiNIMiS3KskbBCnnQIvAxebIDeMSgbYQJapcIZlJAkBllCL7",
+	"RgkSDu+IWoSiLbtXgxPOAoCwj/7DiZSaX9Al3UREbPWsHD7F+kA3hAJw4aNUpDiKdqYSkRIJoR5BFTUh",
+	"2FKtgIXEt7BlUai0rVpNjdYhF/mzZty9GaMUAn18yQrTLEkMIWKpdMkUoUJiGoALvb9czxGHNRisGTRl",
+	"MZYRkxzLrdj1EfQ3fbTSnqlSGBitOTZxdL4YR4wjka56RsWyKnl2CfTRG6wUE0oFhDUCccZsoEFEPskq",
+	"cMFSHgBSvmkVVVmBZBDkOOvpSO4fkt0C7akQrqcIp2OgsGewl5vZlJNejhkXWoXEMm2R35+WyytkBmjI",
+	"0AYocOVqKTQpsBknG0JNeYpbjfkQC1fOdjY88T0bc3iTs/Nz34sJNX+NhkOnb2AktckBYsu4Ys44xnzX",
+	"kBtNmL+a6RfAtTz+QvEdJpHas912qBOucRopGupiy2QVYXrr+V14P6XkjxSiXV0IyvgwtSTLfbo291GW",
+	"8HZHVJA0vZr30bskYZaZy5Jkq38UXb+c9Z49Hz7zEdHaiQLRJTMOAYtjoKGZuwIUQgaoRrjCV8JU9C0Z",
+	"wkZH9nJyhCxIlfCZfSjjaBOxlSaJOV9uoCtk7iY8R4hIvUBh5CVjRVdaZGGc/UOZkW4ZjMwrfEqioENd",
+	"y4Bsqh0RFvKmFOh1A1Q9FxLHSdcprjxesUgtdK7AZLFSstyL2fzd20qodaieYU/cUh0CGt4cWX88FslA",
+	"N67Y7bV+nkmiPUw1MHcpRiExlzdPSiuHXm0Zv4yGHOJGKenRuG9Uk1anZ+HpaXiwmmTnH8ijVttNmiTO",
+	"Hlfxr0ejGITAm8NMm+dUm2csV4orx3x+jl6co9NzNBuj8Uv1//MZurhAwws0nqKzZ2h6ji4u0fNL/eoM",
+	"vTxBw3M0GqKLURkzIsEBhL0qguo4WF7Pmie3rRpKs97BDRbQXcHk3F5XMQHjn2upCj1cfQEHBW15PftM",
+	"5XktFKUqfHFM34XGKvAlSVlezw4JxfJ69uhStT1wE/iGsHYDZH7RhEJ56De2xlZJ7bTUtzrUkQRwgiPX",
+	"oiddmlU8vwJUfb0a+l3Kojj0v0ucUj03ZfIGr2UNQFWZGPeGo97wdDk8n5ydT05Oupcl1JorWNuOo1q5",
+	"43GL1tBT2sEvHaGEk+zEKAFOWNhEyn5vS0cNHZl5stOree6EGStgeua8umE2j9V4JU7AhVlHp/K9vck3",
+	"4IR4E++kP+yPTbFtq9E/wGLwiYiwh8Ve/b0BRyXnFUhEGu1JWe/UslT7QwGmyhW13WEQIizQsDfsm6yH",
+	"8TfmoVlzKjQkHMcggQtv8lt9Y1vQKxq1jN8SsRC8yRpHAhQOvYk+j6KEbnbyzHm8MskkT8Hv2P1WNJrI",
+	"ncayIJqB9h/8ahPneDj8bP2Ttm/uiAbK0+GwbdEcykGtUXDve2ddppWbM/e65UZHfq28oLPsnu9JvBG6",
+	"Nqrnex/U3EGpGUa08thrIgxLmdBWRjuEA52wbPTSZJl9DrZZz4RO76kiBGeRjpdJAIY1uSlVWMZck0gC",
+	"NxG24a4+eplyFVHFjIP/njIKenCChdBpKi5JkEaY22CKUEeJtQTje2qBVPBp5CsZIDRJZR9Nke1lzODJ",
+	"Y0HJEAeZcopwFL2nZZz5iMMG8zAq6nmEW72i/lbhrtY1/ffUJWezMv4bEqfl548U+K4iQMYpPUpg9r57",
+	"NY2EG91LUqzXTem6F8RRVFmr3ib6ZEHt5GGV2t+aKdS97+Jv5ugMEyVZ/ouaeGsiXohiE9ZCxIMkuSUO",
+	"CR980kN7JHzYoDg30PYO6/wqRbYn7jBXtzB11ShkUD3aLOQNi1/UDuhdXDRr9Ph9c3zTStXjuGawitjq",
+	"EayTtR5gga4u3yDVZSCQWutxTPVCQfFNM9bHXgJxb02impvbU/+8uHw1f4tml9fL+cv5bLq81E/f0+mi",
+	"zEj9fv891W8u3144Rj+41Gx6zFJeB5bW5Pr78LUBt4W5GV2TTYmNm7xmRhwkuUodD5LI9pE3rF5uLD+v",
+	"41i6oFTFxhUnpvEE0PLdm9fIHDQ1yyv/CvpllLC4cARDfgu7ARa9LRPyIdRcqIFT8ZMadiBE0O3Ayo2S",
+	"LGCRj2x2nEiBlHwiZn/roLXv+U6HIpv+oAgXAiaCOHF7Kw+FL6YG1QaC4EGRC3xS3HIIjhCEJDTvbXYB",
+	"Ewr5xYCZVhvTStAgxRYPgbQ1/NCFRLaTbdyFTFftfv0t7FCcCqlcda0i+ujCFI6E8tgpu2+D1+n0fq7O",
+	"yzZDcSCoM1f0Hh05ng5PDk8rLs19nlhTR5Y9pQmQgb8UZHJ9NaCkWhSD9LA4qFrUcoezD99Vy99btdii",
+	"/0NaRaHmeK0y+q5V/u5aBSkVoDi3o1Lp5LGoNb/7LN8Vy5dVLN8dqO+q7nhVd9CDympRbRpOlyf+bsHq",
+	"CyxIUKlXJHgDpQJWrWRgejiFaA1hi+7pw5WMYmz9ihy6J9LIR3rEjb7YWUWbFxB9jax3vl2XpHcbUZ/I",
+	"1h0w3FaLithmkN/za2P1/IrgF8zz5nt8NVlQyiCq3WVs8LjvJakDKYsaUvT6L1i4+yr4yG5glvcv7Nf+",
+	"/xWVFl2opDhZJaFvwtVAUJyILZOt+ujyY8K4bF6WqtygyprETZu2b8qRCWdhqi9trFWJkW7M2Iix2zRB",
+	"bL2OCLVV1gwK8wkX0ymienSNl9s3Mti/G/XNNbtFNtx2gjk1mx66yoYeJ4wskCBV5xbguEru4hoioVg7",
+	"HIdtUwYtWzcx9hn0maUQRuLBjRwqLRfXej5bgJPkbF3ehVDJikHZPoag1XmY6+sm6A44WRMIfT3NgGFa",
+	"nXEUsXth+tPJGomwr5/cWDa9MfcCFXsIkE1yX6UucndRM0+k9NfTJZULpl++z+OrO5vmZI/h4kyddWhF",
+	"yk2/Ho/WnMVVz0qyRlSkv94kbHtFcbniSq8Q4CjKAN1cX810U36bNjqyYakWtf6FnUuNkO0lyGCL1hzE",
+	"1qKSUCEB64YSDkm0U+o+R2+Ag21rGoCDXqcSqOXXK+xZGy0aDYgs66Kt5scmUML2G5s3bbCY2U8E5a1O",
+	"wuR3mxMWkWCnYcFRBGFmOK1RQ++U/it4UvvsMZbZ1xfMbKVBIyKk0p36Rl7BiMX9U9V333Yys05LNIzv",
+	"GAlvRqNO4fqME6kMtJIVDUoJ+NVOXxwMIeCAhTb5HAIIgQYw0ePWcK/JxBLhW9HTD+w1QfNsSzZb9bC4",
+	"05x9Tw1L9by4fNAqGLXja0BvVi0IKN9SzC4vNK5k6hd+y31GDdGu05XMTLiUfXGI1hv7mQdaubKv0x2K",
+	"AXyke0UVNgxXWG5oo3uMP2ojKtwnPynf6nK09X6VViilG58SD/41rYw1W6JvSZVUdpulypyiwzaqeXWj",
+	"pXXR1bEoXC2LeqzE3HzeAWiISrlas0UfzalIIMgi5JDckTDFUfZe2HaYmHFA5kothOiOwL3T7C2y0x6w",
+	"fAsNVWH/XFdr6p/8cSZOq1dknpi4XQKPCTWeQRtQ4wyocStQlYs6x4H0VeSvctvqiGZEbaWUkndwav/b",
+	"7Ut0QFsSVvuoJq2DT/ZX1pgYQgTScdn1Qj9v2adI3Jlusuzx/KIpPGYhS5pD4rMsBBjNLyqecyHXayvS",
+	"SSrNd0t0OKWu5ppUIUU4G43mF9mF0YBRQYzDgZU1X5OPWnsoxzdngGa4Z/ATKpWkQjqBUgFKcSvtod81",
+	"pyn/PrQfISA8T2MoUExFgJi+odFYd+dlwNjD4kCW1BTKevQ6eM0FZR/tOVcuDx7u+z913Npw3dPTKPwW",
+	"BCk3ll8PAvsdlYW5vO4ywA+KmlOi/YebQktPbaoj+y5Bc/2HrF1TWr9JLvx8uYns3C6frcnXpXpK/5vt",
+	"1jx8b7a7vejWkuzYsbUn+SHuc3ce/+04sEN78tV0+RNaXL56c/l2aduENRJVPGEhqfUVO2Z4nXj2m+4s",
+	"boO3jUklDzqEHzbWNosvuarCXzMm0azcsWvCAcDBVjnvLeHJ8Rer1Hct1PLqgxK+djWW1zPRvB5YSvCw",
+	"LI1i4WYUhFNMlur03eSjea/J813O9eEvbzWC/m/3YlJ+1fmISMBuq74MogjVf3ohK2dDtV5Lk7zi4wER",
+	"oUr77nurT8qB3PfEJ1M/2nfUuG2s3XLHY8mDTvc6DLN06JU5dX7737mmzYB3WHTUeU2DrG6rnnyBDNEB",
+	"VnT+Ry6uZ/3PU9C2DPY4/jrGrLcxWWbaM0uvAxtt4Vu5r/PNou8c+Ei/Ynk9s87Br79P79/9Pv3xzfLy",
+	"fl7zJYpRnpNF6z7D09m09cKQmqDjJMMLKY+8ibeVMpkMBp+2TMj95JOqru0HOCGDu5H+bAYnSl9rjGXt",
+	"qcVXrXRBTD9WRWLGa69PRqOzsRLNDzk0jTKB7jbQXyGAj+YbVaudlQbrCIh+wQS2OaGZg7u8A76TOsvA",
+	"IdKfN5PMnXGqe7JHrja7uvp5rnIamh/LsGk8O9KDRRF7ejXXVcLsqEwFraogWFrGDO0MVP5fKsim6267",
+	"/Yf9/w0ANpbnYBpqAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
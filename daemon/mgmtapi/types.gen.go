@@ -14,6 +14,32 @@ const (
 	Info  LogLevelLevel = "info"
 )
 
+// Defines values for PathMetadataLinkType.
+const (
+	Direct   PathMetadataLinkType = "direct"
+	Multihop PathMetadataLinkType = "multihop"
+	Opennet  PathMetadataLinkType = "opennet"
+	Unset    PathMetadataLinkType = "unset"
+)
+
+// Defines values for GetPathsParamsOrderBy.
+const (
+	Bandwidth GetPathsParamsOrderBy = "bandwidth"
+	Expiry    GetPathsParamsOrderBy = "expiry"
+	Hops      GetPathsParamsOrderBy = "hops"
+	Latency   GetPathsParamsOrderBy = "latency"
+)
+
+// ASInfo defines model for ASInfo.
+type ASInfo struct {
+	// Core Whether the AS is a core AS.
+	Core  bool  `json:"core"`
+	IsdAs IsdAs `json:"isd_as"`
+
+	// Mtu The MTU of the AS. It is only known for the local AS, and 0 otherwise.
+	Mtu int `json:"mtu"`
+}
+
 // Certificate defines model for Certificate.
 type Certificate struct {
 	DistinguishedName string       `json:"distinguished_name"`
@@ -40,12 +66,40 @@ type ChainBrief struct {
 // ChainID defines model for ChainID.
 type ChainID = string
 
+// DRKey defines model for DRKey.
+type DRKey struct {
+	Epoch Validity `json:"epoch"`
+
+	// Key The base64 encoded key.
+	Key []byte `json:"key"`
+}
+
+// GeoCoordinates defines model for GeoCoordinates.
+type GeoCoordinates struct {
+	// Address Civic address of the location.
+	Address string `json:"address"`
+
+	// Latitude Latitude in the WGS 84 datum.
+	Latitude float32 `json:"latitude"`
+
+	// Longitude Longitude in the WGS 84 datum.
+	Longitude float32 `json:"longitude"`
+}
+
 // Hop defines model for Hop.
 type Hop struct {
 	Interface int   `json:"interface"`
 	IsdAs     IsdAs `json:"isd_as"`
 }
 
+// Interface defines model for Interface.
+type Interface struct {
+	Id int `json:"id"`
+
+	// NextHop Underlay address of the router that owns the interface.
+	NextHop string `json:"next_hop"`
+}
+
 // IsdAs defines model for IsdAs.
 type IsdAs = string
 
@@ -58,6 +112,63 @@ type LogLevel struct {
 // LogLevelLevel Logging level
 type LogLevelLevel string
 
+// Path defines model for Path.
+type Path struct {
+	// Expiration The time at which the path expires.
+	Expiration time.Time `json:"expiration"`
+
+	// Fingerprint Fingerprint of the path.
+	Fingerprint string `json:"fingerprint"`
+
+	// Hops The interfaces the path traverses.
+	Hops []Hop `json:"hops"`
+
+	// Metadata The metadata that the ASes on the path announce in the path segments. The entries that an AS did not announce are zero values.
+	Metadata PathMetadata `json:"metadata"`
+
+	// Mtu The maximum transmission unit on the path.
+	Mtu int `json:"mtu"`
+
+	// NextHop Underlay address of the first router on the path.
+	NextHop string `json:"next_hop"`
+
+	// Raw The base64 encoded data-plane path.
+	Raw []byte `json:"raw"`
+}
+
+// PathDBImport defines model for PathDBImport.
+type PathDBImport struct {
+	// Inserted Number of path segments that were inserted.
+	Inserted int `json:"inserted"`
+
+	// Updated Number of path segments that were already present and were updated.
+	Updated int `json:"updated"`
+}
+
+// PathMetadata The metadata that the ASes on the path announce in the path segments. The entries that an AS did not announce are zero values.
+type PathMetadata struct {
+	// Bandwidth The bandwidth between any two consecutive interfaces, in Kbit/s. Entry i describes the bandwidth between interface i and i+1.
+	Bandwidth []int64 `json:"bandwidth"`
+
+	// Geo The geographical positions of the border routers of the interfaces. Entry i describes the position of the router of interface i.
+	Geo []GeoCoordinates `json:"geo"`
+
+	// InternalHops The number of AS internal hops in the ASes that the path traverses. Entry i describes the hops between interface 2*i+1 and 2*i+2.
+	InternalHops []int `json:"internal_hops"`
+
+	// Latency The latencies between any two consecutive interfaces. Entry i describes the latency between interface i and i+1. An empty string indicates that the AS did not announce a latency for the hop.
+	Latency []string `json:"latency"`
+
+	// LinkType The link types of the inter-domain links. Entry i describes the link between interface 2*i and 2*i+1.
+	LinkType []PathMetadataLinkType `json:"link_type"`
+
+	// Notes The notes of the ASes on the path, in path order.
+	Notes []string `json:"notes"`
+}
+
+// PathMetadataLinkType defines model for PathMetadata.LinkType.
+type PathMetadataLinkType string
+
 // Problem defines model for Problem.
 type Problem struct {
 	// Detail A human readable explanation specific to this occurrence of the problem that is helpful to locate the problem and give advice on how to proceed. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
@@ -137,6 +248,15 @@ type Validity struct {
 // BadRequest defines model for BadRequest.
 type BadRequest = StandardError
 
+// DaemonError defines model for DaemonError.
+type DaemonError = Problem
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Problem
+
+// InvalidRequest defines model for InvalidRequest.
+type InvalidRequest = Problem
+
 // GetCertificatesParams defines parameters for GetCertificates.
 type GetCertificatesParams struct {
 	IsdAs   *IsdAs     `form:"isd_as,omitempty" json:"isd_as,omitempty"`
@@ -144,6 +264,84 @@ type GetCertificatesParams struct {
 	All     *bool      `form:"all,omitempty" json:"all,omitempty"`
 }
 
+// GetDrkeyAsHostParams defines parameters for GetDrkeyAsHost.
+type GetDrkeyAsHostParams struct {
+	// Protocol DRKey protocol, either its name or its number.
+	Protocol string `form:"protocol" json:"protocol"`
+
+	// SrcIsdAs ISD-AS of the source.
+	SrcIsdAs IsdAs `form:"src_isd_as" json:"src_isd_as"`
+
+	// DstIsdAs ISD-AS of the destination.
+	DstIsdAs IsdAs `form:"dst_isd_as" json:"dst_isd_as"`
+
+	// DstHost Address of the destination host.
+	DstHost string `form:"dst_host" json:"dst_host"`
+
+	// ValidAt Point in time at which the key must be valid. Defaults to now.
+	ValidAt *time.Time `form:"valid_at,omitempty" json:"valid_at,omitempty"`
+}
+
+// GetDrkeyHostAsParams defines parameters for GetDrkeyHostAs.
+type GetDrkeyHostAsParams struct {
+	// Protocol DRKey protocol, either its name or its number.
+	Protocol string `form:"protocol" json:"protocol"`
+
+	// SrcIsdAs ISD-AS of the source.
+	SrcIsdAs IsdAs `form:"src_isd_as" json:"src_isd_as"`
+
+	// DstIsdAs ISD-AS of the destination.
+	DstIsdAs IsdAs `form:"dst_isd_as" json:"dst_isd_as"`
+
+	// SrcHost Address of the source host.
+	SrcHost string `form:"src_host" json:"src_host"`
+
+	// ValidAt Point in time at which the key must be valid. Defaults to now.
+	ValidAt *time.Time `form:"valid_at,omitempty" json:"valid_at,omitempty"`
+}
+
+// GetDrkeyHostHostParams defines parameters for GetDrkeyHostHost.
+type GetDrkeyHostHostParams struct {
+	// Protocol DRKey protocol, either its name or its number.
+	Protocol string `form:"protocol" json:"protocol"`
+
+	// SrcIsdAs ISD-AS of the source.
+	SrcIsdAs IsdAs `form:"src_isd_as" json:"src_isd_as"`
+
+	// DstIsdAs ISD-AS of the destination.
+	DstIsdAs IsdAs `form:"dst_isd_as" json:"dst_isd_as"`
+
+	// SrcHost Address of the source host.
+	SrcHost string `form:"src_host" json:"src_host"`
+
+	// DstHost Address of the destination host.
+	DstHost string `form:"dst_host" json:"dst_host"`
+
+	// ValidAt Point in time at which the key must be valid. Defaults to now.
+	ValidAt *time.Time `form:"valid_at,omitempty" json:"valid_at,omitempty"`
+}
+
+// GetPathsParams defines parameters for GetPaths.
+type GetPathsParams struct {
+	// Refresh Fetch fresh paths instead of replying from the cache.
+	Refresh *bool `form:"refresh,omitempty" json:"refresh,omitempty"`
+
+	// Hidden Request hidden paths instead of standard paths.
+	Hidden *bool `form:"hidden,omitempty" json:"hidden,omitempty"`
+
+	// Policy Name of a path policy installed in the daemon. Only the paths that match the policy are listed, ordered by the number of hops.
+	Policy *string `form:"policy,omitempty" json:"policy,omitempty"`
+
+	// OrderBy Criteria to order the paths by, in decreasing precedence: the fewest hops, the lowest latency, the highest bandwidth or the latest expiration.
+	OrderBy *[]GetPathsParamsOrderBy `form:"order_by,omitempty" json:"order_by,omitempty"`
+
+	// MaxPaths Maximum number of paths to list, after they are ordered.
+	MaxPaths *int `form:"max_paths,omitempty" json:"max_paths,omitempty"`
+}
+
+// GetPathsParamsOrderBy defines parameters for GetPaths.
+type GetPathsParamsOrderBy string
+
 // GetSegmentsParams defines parameters for GetSegments.
 type GetSegmentsParams struct {
 	// StartIsdAs Start ISD-AS of segment.
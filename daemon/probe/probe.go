@@ -0,0 +1,237 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package probe probes the paths that the daemon returns to applications in the background,
+// so that the daemon can annotate the paths with their liveness.
+//
+// The paths are probed with SCMP traceroute requests to the last hop of the path, the same way
+// as with "scion showpaths --probe". The destination host is not known to the daemon.
+package probe
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/app/path/pathprobe"
+)
+
+// DefaultRetention is the default duration for which paths to a destination are probed after
+// they were last returned to an application.
+const DefaultRetention = 5 * time.Minute
+
+// Prober probes paths.
+type Prober interface {
+	// GetStatuses probes the paths to the destination. The statuses are keyed by
+	// pathprobe.PathKey.
+	GetStatuses(ctx context.Context, dst addr.IA,
+		paths []snet.Path) (map[string]pathprobe.Status, error)
+}
+
+// SCMPProber probes the paths with SCMP traceroute requests.
+type SCMPProber struct {
+	// LocalIA is the local ISD-AS.
+	LocalIA addr.IA
+	// Topology is the topology of the local AS.
+	Topology snet.Topology
+}
+
+// GetStatuses probes the paths to the destination.
+func (p SCMPProber) GetStatuses(ctx context.Context, dst addr.IA,
+	paths []snet.Path) (map[string]pathprobe.Status, error) {
+
+	return pathprobe.Prober{
+		DstIA:    dst,
+		LocalIA:  p.LocalIA,
+		Topology: p.Topology,
+	}.GetStatuses(ctx, paths)
+}
+
+// Tracker is a periodic task that probes the tracked paths and keeps the results of the latest
+// probes.
+type Tracker struct {
+	// Prober probes the paths.
+	Prober Prober
+	// Retention is the duration for which a path is probed after it was last tracked. If zero,
+	// DefaultRetention is used.
+	Retention time.Duration
+	// Unreachable, if set, is called with the tracked paths that were found to be unreachable,
+	// either by a probe or because an interface on them was revoked. Paths that were already
+	// unreachable before are not reported again.
+	Unreachable func(paths []snet.Path)
+
+	mu sync.Mutex
+	// paths are the tracked paths, keyed by pathprobe.PathKey.
+	paths map[string]*trackedPath
+}
+
+type trackedPath struct {
+	dst      addr.IA
+	path     snet.Path
+	tracked  time.Time
+	liveness snet.PathLiveness
+}
+
+// Track registers the paths to the destination for probing.
+func (t *Tracker) Track(dst addr.IA, paths []snet.Path) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.paths == nil {
+		t.paths = make(map[string]*trackedPath)
+	}
+	for _, p := range paths {
+		// Only SCION paths can be probed, the empty path to the local AS is not.
+		if _, ok := p.Dataplane().(snetpath.SCION); !ok {
+			continue
+		}
+		key := pathprobe.PathKey(p)
+		if tp, ok := t.paths[key]; ok {
+			tp.path, tp.tracked = p, now
+			continue
+		}
+		t.paths[key] = &trackedPath{dst: dst, path: p, tracked: now}
+	}
+}
+
+// Liveness returns the result of the latest probe of the path, if it was probed.
+func (t *Tracker) Liveness(path snet.Path) (snet.PathLiveness, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tp, ok := t.paths[pathprobe.PathKey(path)]
+	if !ok || tp.liveness.ProbedAt.IsZero() {
+		return snet.PathLiveness{}, false
+	}
+	return tp.liveness, true
+}
+
+// Revoke marks the tracked paths that traverse the interface as unreachable, without waiting
+// for the next probe. The next probe of the paths overrides the mark.
+func (t *Tracker) Revoke(ia addr.IA, ifID iface.ID) {
+	revoked := snet.PathInterface{IA: ia, ID: ifID}
+	now := time.Now()
+	t.mu.Lock()
+	var unreachable []snet.Path
+	for _, tp := range t.paths {
+		if !traverses(tp.path, revoked) {
+			continue
+		}
+		if tp.reachable() {
+			unreachable = append(unreachable, tp.path)
+		}
+		tp.liveness = snet.PathLiveness{Reachable: false, ProbedAt: now}
+	}
+	t.mu.Unlock()
+	t.reportUnreachable(unreachable)
+}
+
+// Name returns the task name.
+func (t *Tracker) Name() string {
+	return "daemon_path_prober"
+}
+
+// Run probes the tracked paths, the paths to each destination concurrently. The paths that were
+// not tracked within the retention period are dropped. If a path cannot be probed, the result
+// of its previous probe is kept.
+func (t *Tracker) Run(ctx context.Context) {
+	dsts := t.expire()
+	var wg sync.WaitGroup
+	for dst, paths := range dsts {
+		wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			statuses, err := t.Prober.GetStatuses(ctx, dst, paths)
+			if err != nil {
+				log.FromCtx(ctx).Info("Failed to probe paths", "dst", dst, "err", err)
+				return
+			}
+			t.reportUnreachable(t.update(statuses, time.Now()))
+		}()
+	}
+	wg.Wait()
+}
+
+// expire drops the paths that were not tracked within the retention period, and returns the
+// remaining ones by destination.
+func (t *Tracker) expire() map[addr.IA][]snet.Path {
+	retention := t.Retention
+	if retention == 0 {
+		retention = DefaultRetention
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	dsts := make(map[addr.IA][]snet.Path)
+	for key, tp := range t.paths {
+		if time.Since(tp.tracked) > retention {
+			delete(t.paths, key)
+			continue
+		}
+		dsts[tp.dst] = append(dsts[tp.dst], tp.path)
+	}
+	return dsts
+}
+
+// update stores the results of the probes, and returns the paths that became unreachable.
+func (t *Tracker) update(statuses map[string]pathprobe.Status, probedAt time.Time) []snet.Path {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var unreachable []snet.Path
+	for key, status := range statuses {
+		tp, ok := t.paths[key]
+		if !ok || status.Status == pathprobe.StatusUnknown {
+			continue
+		}
+		reachable := status.Status == pathprobe.StatusAlive
+		if !reachable && tp.reachable() {
+			unreachable = append(unreachable, tp.path)
+		}
+		tp.liveness = snet.PathLiveness{
+			Reachable: reachable,
+			RTT:       status.RTT,
+			ProbedAt:  probedAt,
+		}
+	}
+	return unreachable
+}
+
+func (t *Tracker) reportUnreachable(paths []snet.Path) {
+	if t.Unreachable != nil && len(paths) > 0 {
+		t.Unreachable(paths)
+	}
+}
+
+// reachable returns whether the path is not known to be unreachable, i.e., whether it was not
+// probed yet or whether the latest probe was answered.
+func (tp *trackedPath) reachable() bool {
+	return tp.liveness.ProbedAt.IsZero() || tp.liveness.Reachable
+}
+
+func traverses(path snet.Path, intf snet.PathInterface) bool {
+	md := path.Metadata()
+	if md == nil {
+		return false
+	}
+	for _, i := range md.Interfaces {
+		if i == intf {
+			return true
+		}
+	}
+	return false
+}
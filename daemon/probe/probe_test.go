@@ -0,0 +1,139 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/daemon/probe"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/private/app/path/pathprobe"
+)
+
+// fakeProber answers the probes with fixed statuses.
+type fakeProber struct {
+	statuses map[string]pathprobe.Status
+	err      error
+	probed   map[addr.IA]int
+}
+
+func (p *fakeProber) GetStatuses(_ context.Context, dst addr.IA,
+	paths []snet.Path) (map[string]pathprobe.Status, error) {
+
+	p.probed[dst] += len(paths)
+	return p.statuses, p.err
+}
+
+func TestTracker(t *testing.T) {
+	dst := addr.MustParseIA("1-ff00:0:110")
+	newPath := func(raw byte) snet.Path {
+		return snetpath.Path{DataplanePath: snetpath.SCION{Raw: []byte{raw}}}
+	}
+	alive, dead := newPath(1), newPath(2)
+	prober := &fakeProber{
+		statuses: map[string]pathprobe.Status{
+			pathprobe.PathKey(alive): {Status: pathprobe.StatusAlive, RTT: time.Millisecond},
+			pathprobe.PathKey(dead):  {Status: pathprobe.StatusTimeout},
+		},
+		probed: map[addr.IA]int{},
+	}
+	tracker := &probe.Tracker{Prober: prober, Retention: time.Hour}
+
+	// Empty paths are not probed.
+	tracker.Track(dst, []snet.Path{alive, dead, snetpath.Path{DataplanePath: snetpath.Empty{}}})
+	_, ok := tracker.Liveness(alive)
+	assert.False(t, ok, "not probed yet")
+
+	tracker.Run(context.Background())
+	assert.Equal(t, 2, prober.probed[dst])
+	l, ok := tracker.Liveness(alive)
+	assert.True(t, ok)
+	assert.True(t, l.Reachable)
+	assert.Equal(t, time.Millisecond, l.RTT)
+	assert.False(t, l.ProbedAt.IsZero())
+	l, ok = tracker.Liveness(dead)
+	assert.True(t, ok)
+	assert.False(t, l.Reachable)
+
+	// The previous results are kept if the paths cannot be probed.
+	prober.err = serrors.New("test error")
+	tracker.Run(context.Background())
+	l, ok = tracker.Liveness(alive)
+	assert.True(t, ok)
+	assert.True(t, l.Reachable)
+
+	// Paths that are not tracked within the retention period are dropped.
+	tracker.Retention = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	tracker.Run(context.Background())
+	_, ok = tracker.Liveness(alive)
+	assert.False(t, ok)
+}
+
+func TestTrackerRevoke(t *testing.T) {
+	dst := addr.MustParseIA("1-ff00:0:110")
+	newPath := func(raw byte, ifID iface.ID) snet.Path {
+		return snetpath.Path{
+			DataplanePath: snetpath.SCION{Raw: []byte{raw}},
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{{IA: dst, ID: ifID}},
+			},
+		}
+	}
+	revoked, other := newPath(1, 1), newPath(2, 2)
+	prober := &fakeProber{
+		statuses: map[string]pathprobe.Status{
+			pathprobe.PathKey(revoked): {Status: pathprobe.StatusAlive},
+			pathprobe.PathKey(other):   {Status: pathprobe.StatusTimeout},
+		},
+		probed: map[addr.IA]int{},
+	}
+	var unreachable [][]snet.Path
+	tracker := &probe.Tracker{
+		Prober:    prober,
+		Retention: time.Hour,
+		Unreachable: func(paths []snet.Path) {
+			unreachable = append(unreachable, paths)
+		},
+	}
+	tracker.Track(dst, []snet.Path{revoked, other})
+
+	tracker.Revoke(dst, 1)
+	l, ok := tracker.Liveness(revoked)
+	assert.True(t, ok)
+	assert.False(t, l.Reachable)
+	_, ok = tracker.Liveness(other)
+	assert.False(t, ok, "not revoked")
+	assert.Equal(t, [][]snet.Path{{revoked}}, unreachable)
+
+	// Paths that are already unreachable are not reported again.
+	tracker.Revoke(dst, 1)
+	assert.Len(t, unreachable, 1)
+
+	// The next probe overrides the mark, and reports the paths that became unreachable.
+	tracker.Run(context.Background())
+	l, ok = tracker.Liveness(revoked)
+	assert.True(t, ok)
+	assert.True(t, l.Reachable)
+	assert.Equal(t, [][]snet.Path{{revoked}, {other}}, unreachable)
+}
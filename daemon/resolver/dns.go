@@ -0,0 +1,74 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// TXTPrefix is the prefix of the TXT records that contain a SCION address.
+const TXTPrefix = "scion="
+
+// DNS resolves names from the TXT records of the names in the DNS. TXT records without the
+// TXTPrefix are ignored.
+type DNS struct {
+	// LookupTXT looks up the TXT records of a name. If nil, the lookup of net.DefaultResolver
+	// is used.
+	LookupTXT func(ctx context.Context, name string) ([]string, error)
+}
+
+// Resolve resolves the name.
+func (d DNS) Resolve(ctx context.Context, name string) ([]addr.Addr, error) {
+	lookup := d.LookupTXT
+	if lookup == nil {
+		lookup = net.DefaultResolver.LookupTXT
+	}
+	records, err := lookup(ctx, name)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, serrors.JoinNoStack(ErrNotFound, nil, "name", name)
+		}
+		return nil, serrors.Wrap("looking up TXT records", err, "name", name)
+	}
+	var addrs []addr.Addr
+	for _, record := range records {
+		value, ok := strings.CutPrefix(record, TXTPrefix)
+		if !ok {
+			continue
+		}
+		a, err := ParseAddress(value)
+		if err != nil {
+			log.FromCtx(ctx).Debug("Ignoring invalid SCION TXT record", "name", name,
+				"record", record, "err", err)
+			continue
+		}
+		if !slices.Contains(addrs, a) {
+			addrs = append(addrs, a)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, serrors.JoinNoStack(ErrNotFound, nil, "name", name)
+	}
+	return addrs, nil
+}
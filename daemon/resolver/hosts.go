@@ -0,0 +1,115 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// HostsFile resolves names from a hosts file. The file is read again when its modification
+// time or size changes. A missing file is treated like an empty one.
+type HostsFile struct {
+	// Path is the path of the hosts file.
+	Path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	hosts   map[string][]addr.Addr
+}
+
+// Resolve resolves the name.
+func (h *HostsFile) Resolve(_ context.Context, name string) ([]addr.Addr, error) {
+	hosts, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+	addrs, ok := hosts[canonicalName(name)]
+	if !ok {
+		return nil, serrors.JoinNoStack(ErrNotFound, nil, "name", name)
+	}
+	return slices.Clone(addrs), nil
+}
+
+func (h *HostsFile) load() (map[string][]addr.Addr, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	info, err := os.Stat(h.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		h.modTime, h.size, h.hosts = time.Time{}, 0, nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, serrors.Wrap("reading hosts file", err, "file", h.Path)
+	}
+	if h.hosts != nil && info.ModTime().Equal(h.modTime) && info.Size() == h.size {
+		return h.hosts, nil
+	}
+	f, err := os.Open(h.Path)
+	if err != nil {
+		return nil, serrors.Wrap("reading hosts file", err, "file", h.Path)
+	}
+	defer f.Close()
+	hosts, err := ParseHosts(f)
+	if err != nil {
+		return nil, serrors.Wrap("parsing hosts file", err, "file", h.Path)
+	}
+	h.modTime, h.size, h.hosts = info.ModTime(), info.Size(), hosts
+	return hosts, nil
+}
+
+// ParseHosts parses the content of a hosts file. It returns the addresses of each name, in the
+// order in which they appear in the file.
+func ParseHosts(r io.Reader) (map[string][]addr.Addr, error) {
+	hosts := make(map[string][]addr.Addr)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, serrors.New("missing host name", "line", line)
+		}
+		a, err := ParseAddress(fields[0])
+		if err != nil {
+			return nil, serrors.Wrap("invalid address", err, "line", line)
+		}
+		for _, name := range fields[1:] {
+			name = canonicalName(name)
+			if !slices.Contains(hosts[name], a) {
+				hosts[name] = append(hosts[name], a)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return hosts, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/daemon/resolver"
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+func TestParseHosts(t *testing.T) {
+	testCases := map[string]struct {
+		Input     string
+		Expected  map[string][]addr.Addr
+		AssertErr assert.ErrorAssertionFunc
+	}{
+		"empty": {
+			Input:     "",
+			Expected:  map[string][]addr.Addr{},
+			AssertErr: assert.NoError,
+		},
+		"valid": {
+			Input: `
+# comment
+1-ff00:0:110,10.0.0.1  server.example.org server # trailing comment
+1-ff00:0:111,[2001:db8::1] Server.Example.org.
+1-ff00:0:110,10.0.0.1  server
+`,
+			Expected: map[string][]addr.Addr{
+				"server.example.org": {
+					addr.MustParseAddr("1-ff00:0:110,10.0.0.1"),
+					addr.MustParseAddr("1-ff00:0:111,2001:db8::1"),
+				},
+				"server": {addr.MustParseAddr("1-ff00:0:110,10.0.0.1")},
+			},
+			AssertErr: assert.NoError,
+		},
+		"missing name": {
+			Input:     "1-ff00:0:110,10.0.0.1\n",
+			AssertErr: assert.Error,
+		},
+		"IP address": {
+			Input:     "10.0.0.1 server\n",
+			AssertErr: assert.Error,
+		},
+		"SVC address": {
+			Input:     "1-ff00:0:110,CS server\n",
+			AssertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			hosts, err := resolver.ParseHosts(strings.NewReader(tc.Input))
+			tc.AssertErr(t, err)
+			assert.Equal(t, tc.Expected, hosts)
+		})
+	}
+}
+
+func TestHostsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "hosts")
+	// write writes the file with a distinct modification time for each version.
+	version := 0
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+		version++
+		mtime := time.Unix(int64(version), 0)
+		require.NoError(t, os.Chtimes(file, mtime, mtime))
+	}
+	h := &resolver.HostsFile{Path: file}
+	ctx := context.Background()
+
+	_, err := h.Resolve(ctx, "server.example.org")
+	assert.ErrorIs(t, err, resolver.ErrNotFound, "missing file")
+
+	write("1-ff00:0:110,10.0.0.1 server.example.org\n")
+	addrs, err := h.Resolve(ctx, "server.example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []addr.Addr{addr.MustParseAddr("1-ff00:0:110,10.0.0.1")}, addrs)
+	_, err = h.Resolve(ctx, "other.example.org")
+	assert.ErrorIs(t, err, resolver.ErrNotFound)
+
+	write("1-ff00:0:111,10.0.0.2 server.example.org\n")
+	addrs, err = h.Resolve(ctx, "SERVER.example.org.")
+	require.NoError(t, err)
+	assert.Equal(t, []addr.Addr{addr.MustParseAddr("1-ff00:0:111,10.0.0.2")}, addrs)
+
+	write("garbage\n")
+	_, err = h.Resolve(ctx, "server.example.org")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, resolver.ErrNotFound)
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resolver resolves host names to SCION addresses, so that applications can refer to
+// a host by its name instead of its ISD-AS and IP address.
+//
+// The names are looked up in a hosts file and in the DNS. The hosts file has the format of
+// /etc/hosts, with SCION addresses instead of IP addresses:
+//
+//	# SCION address        names
+//	1-ff00:0:110,10.0.0.1  server.example.org server
+//	1-ff00:0:111,[2001:db8::1] other.example.org
+//
+// In the DNS, the SCION addresses of a host are published as TXT records of the host name in
+// the form
+//
+//	"scion=1-ff00:0:110,10.0.0.1"
+package resolver
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// ErrNotFound is returned if a name is not known.
+var ErrNotFound = serrors.New("name not found")
+
+// Resolver resolves host names to SCION addresses.
+type Resolver interface {
+	// Resolve returns the SCION addresses of the host with the given name. It returns an error
+	// that wraps ErrNotFound if the name is not known.
+	Resolve(ctx context.Context, name string) ([]addr.Addr, error)
+}
+
+// Resolvers resolves names with each resolver in turn, and returns the addresses of the first
+// resolver that knows the name.
+type Resolvers []Resolver
+
+// Resolve resolves the name.
+func (r Resolvers) Resolve(ctx context.Context, name string) ([]addr.Addr, error) {
+	for _, resolver := range r {
+		addrs, err := resolver.Resolve(ctx, name)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		return addrs, err
+	}
+	return nil, serrors.JoinNoStack(ErrNotFound, nil, "name", name)
+}
+
+// ParseAddress parses a SCION address of a host as it appears in the hosts file and in the
+// TXT records, i.e., an ISD-AS and an IP address separated by a comma. The IP address may be
+// enclosed in square brackets.
+func ParseAddress(s string) (addr.Addr, error) {
+	rawIA, rawIP, ok := strings.Cut(s, ",")
+	if !ok {
+		return addr.Addr{}, serrors.New("invalid address: expected comma", "value", s)
+	}
+	ia, err := addr.ParseIA(rawIA)
+	if err != nil {
+		return addr.Addr{}, err
+	}
+	if strings.HasPrefix(rawIP, "[") && strings.HasSuffix(rawIP, "]") {
+		rawIP = rawIP[1 : len(rawIP)-1]
+	}
+	ip, err := netip.ParseAddr(rawIP)
+	if err != nil {
+		return addr.Addr{}, serrors.Wrap("invalid address: parsing IP", err, "value", s)
+	}
+	return addr.Addr{IA: ia, Host: addr.HostIP(ip)}, nil
+}
+
+// canonicalName returns the name in the form used to look it up, i.e., in lower case and
+// without the trailing dot of a fully qualified name.
+func canonicalName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
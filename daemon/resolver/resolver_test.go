@@ -0,0 +1,107 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/daemon/resolver"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+func TestDNS(t *testing.T) {
+	records := map[string][]string{
+		"server.example.org": {
+			"v=spf1 -all",
+			"scion=1-ff00:0:110,10.0.0.1",
+			"scion=garbage",
+			"scion=1-ff00:0:111,[2001:db8::1]",
+			"scion=1-ff00:0:110,10.0.0.1",
+		},
+		"ip.example.org": {"v=spf1 -all"},
+	}
+	d := resolver.DNS{
+		LookupTXT: func(_ context.Context, name string) ([]string, error) {
+			if name == "broken.example.org" {
+				return nil, &net.DNSError{Err: "server misbehaving", Name: name}
+			}
+			r, ok := records[name]
+			if !ok {
+				return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+			}
+			return r, nil
+		},
+	}
+	ctx := context.Background()
+
+	addrs, err := d.Resolve(ctx, "server.example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []addr.Addr{
+		addr.MustParseAddr("1-ff00:0:110,10.0.0.1"),
+		addr.MustParseAddr("1-ff00:0:111,2001:db8::1"),
+	}, addrs)
+
+	_, err = d.Resolve(ctx, "ip.example.org")
+	assert.ErrorIs(t, err, resolver.ErrNotFound)
+	_, err = d.Resolve(ctx, "unknown.example.org")
+	assert.ErrorIs(t, err, resolver.ErrNotFound)
+	_, err = d.Resolve(ctx, "broken.example.org")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, resolver.ErrNotFound)
+}
+
+func TestResolvers(t *testing.T) {
+	first := addr.MustParseAddr("1-ff00:0:110,10.0.0.1")
+	second := addr.MustParseAddr("1-ff00:0:111,10.0.0.2")
+	r := resolver.Resolvers{
+		staticResolver{"a.example.org": {first}},
+		staticResolver{"a.example.org": {second}, "b.example.org": {second}},
+	}
+	ctx := context.Background()
+
+	addrs, err := r.Resolve(ctx, "a.example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []addr.Addr{first}, addrs)
+	addrs, err = r.Resolve(ctx, "b.example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []addr.Addr{second}, addrs)
+	_, err = r.Resolve(ctx, "c.example.org")
+	assert.ErrorIs(t, err, resolver.ErrNotFound)
+
+	broken := resolver.Resolvers{failingResolver{}, staticResolver{"a.example.org": {first}}}
+	_, err = broken.Resolve(ctx, "a.example.org")
+	assert.Error(t, err)
+}
+
+type staticResolver map[string][]addr.Addr
+
+func (r staticResolver) Resolve(_ context.Context, name string) ([]addr.Addr, error) {
+	if addrs, ok := r[name]; ok {
+		return addrs, nil
+	}
+	return nil, resolver.ErrNotFound
+}
+
+type failingResolver struct{}
+
+func (failingResolver) Resolve(context.Context, string) ([]addr.Addr, error) {
+	return nil, serrors.New("failing")
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// NewTLSConfig returns the TLS configuration of the API address, for the hosts that use the
+// daemon remotely. The certificate is loaded again when the modification time of the
+// certificate or the key file changes, so that it can be renewed without restarting the daemon.
+// If clientCAFile is not empty, the clients must present a certificate that is issued by one of
+// the CAs in it.
+func NewTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	certs := &certificateFiles{certFile: certFile, keyFile: keyFile}
+	if _, err := certs.load(); err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		NextProtos:     []string{"h2"},
+		GetCertificate: certs.GetCertificate,
+	}
+	if clientCAFile != "" {
+		raw, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, serrors.Wrap("reading client CA file", err, "file", clientCAFile)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(raw) {
+			return nil, serrors.New("no certificates in client CA file", "file", clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// certificateFiles loads the certificate from its files, and again whenever they change.
+type certificateFiles struct {
+	certFile, keyFile string
+
+	mu              sync.Mutex
+	certMod, keyMod time.Time
+	cert            *tls.Certificate
+}
+
+// GetCertificate returns the current certificate. If the files changed but cannot be loaded,
+// e.g., because only one of them was replaced yet, the previous certificate is returned.
+func (c *certificateFiles) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, err := c.load()
+	if err != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.cert == nil {
+			return nil, err
+		}
+		log.Info("Loading TLS certificate, using the previous one", "err", err)
+		return c.cert, nil
+	}
+	return cert, nil
+}
+
+func (c *certificateFiles) load() (*tls.Certificate, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	certInfo, err := os.Stat(c.certFile)
+	if err != nil {
+		return nil, serrors.Wrap("reading TLS certificate", err, "file", c.certFile)
+	}
+	keyInfo, err := os.Stat(c.keyFile)
+	if err != nil {
+		return nil, serrors.Wrap("reading TLS key", err, "file", c.keyFile)
+	}
+	if c.cert != nil && certInfo.ModTime().Equal(c.certMod) && keyInfo.ModTime().Equal(c.keyMod) {
+		return c.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return nil, serrors.Wrap("loading TLS certificate", err,
+			"cert_file", c.certFile, "key_file", c.keyFile)
+	}
+	c.certMod, c.keyMod, c.cert = certInfo.ModTime(), keyInfo.ModTime(), &cert
+	return c.cert, nil
+}
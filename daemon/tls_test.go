@@ -0,0 +1,207 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/scionproto/scion/daemon"
+	"github.com/scionproto/scion/daemon/auth"
+	"github.com/scionproto/scion/pkg/addr"
+	sddaemon "github.com/scionproto/scion/pkg/daemon"
+	sdpb "github.com/scionproto/scion/pkg/proto/daemon"
+)
+
+// asServer answers the AS requests with a fixed ISD-AS.
+type asServer struct {
+	sdpb.UnimplementedDaemonServiceServer
+}
+
+func (*asServer) AS(context.Context, *sdpb.ASRequest) (*sdpb.ASResponse, error) {
+	return &sdpb.ASResponse{IsdAs: uint64(addr.MustParseIA("1-ff00:0:110"))}, nil
+}
+
+// testCA issues the certificates of the tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pool: pool,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}),
+	}
+}
+
+// issue returns the PEM encoded certificate and key of a leaf certificate for 127.0.0.1.
+func (ca *testCA) issue(t *testing.T, serial int64) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "daemon"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	rawKey, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: rawKey})
+}
+
+// serveTLS serves the daemon API with TLS, like the daemon does, and returns the address.
+func serveTLS(t *testing.T, cfg *tls.Config) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(auth.PeerCredentials{}))
+	sdpb.RegisterDaemonServiceServer(server, &asServer{})
+	go func() { _ = server.Serve(tls.NewListener(listener, cfg)) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func writeFile(t *testing.T, path string, content []byte, mod time.Time) {
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	require.NoError(t, os.Chtimes(path, mod, mod))
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "sd.crt"), filepath.Join(dir, "sd.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	cert, key := ca.issue(t, 2)
+	writeFile(t, certFile, cert, time.Now().Add(-time.Minute))
+	writeFile(t, keyFile, key, time.Now().Add(-time.Minute))
+	writeFile(t, caFile, ca.pem, time.Now())
+
+	localIA := func(t *testing.T, svc sddaemon.Service) (addr.IA, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, err := svc.Connect(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+		return conn.LocalIA(ctx)
+	}
+
+	t.Run("server authentication", func(t *testing.T) {
+		cfg, err := daemon.NewTLSConfig(certFile, keyFile, "")
+		require.NoError(t, err)
+		address := serveTLS(t, cfg)
+
+		ia, err := localIA(t, sddaemon.Service{
+			Address: "tls://" + address,
+			TLS:     &tls.Config{RootCAs: ca.pool},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, addr.MustParseIA("1-ff00:0:110"), ia)
+
+		// The certificate is not issued by the system roots.
+		_, err = localIA(t, sddaemon.Service{Address: "tls://" + address})
+		assert.Error(t, err)
+		_, err = localIA(t, sddaemon.Service{Address: address})
+		assert.Error(t, err)
+	})
+	t.Run("client authentication", func(t *testing.T) {
+		cfg, err := daemon.NewTLSConfig(certFile, keyFile, caFile)
+		require.NoError(t, err)
+		address := serveTLS(t, cfg)
+
+		_, err = localIA(t, sddaemon.Service{
+			Address: "tls://" + address,
+			TLS:     &tls.Config{RootCAs: ca.pool},
+		})
+		assert.Error(t, err)
+
+		clientCert, clientKey := ca.issue(t, 3)
+		pair, err := tls.X509KeyPair(clientCert, clientKey)
+		require.NoError(t, err)
+		_, err = localIA(t, sddaemon.Service{
+			Address: "tls://" + address,
+			TLS:     &tls.Config{RootCAs: ca.pool, Certificates: []tls.Certificate{pair}},
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("renewal", func(t *testing.T) {
+		cfg, err := daemon.NewTLSConfig(certFile, keyFile, "")
+		require.NoError(t, err)
+		address := serveTLS(t, cfg)
+		serial := func() int64 {
+			conn, err := tls.Dial("tcp", address, &tls.Config{RootCAs: ca.pool})
+			require.NoError(t, err)
+			defer conn.Close()
+			return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+		}
+		assert.Equal(t, int64(2), serial())
+
+		cert, key := ca.issue(t, 4)
+		writeFile(t, certFile, cert, time.Now())
+		writeFile(t, keyFile, key, time.Now())
+		assert.Equal(t, int64(4), serial())
+	})
+	t.Run("missing file", func(t *testing.T) {
+		_, err := daemon.NewTLSConfig(filepath.Join(dir, "missing"), keyFile, "")
+		assert.Error(t, err)
+		_, err = daemon.NewTLSConfig(certFile, keyFile, filepath.Join(dir, "missing"))
+		assert.Error(t, err)
+	})
+}
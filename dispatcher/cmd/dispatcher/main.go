@@ -13,8 +13,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build linux || darwin
-// +build linux darwin
+//go:build linux || darwin || windows
+// +build linux darwin windows
 
 package main
 
@@ -61,6 +61,7 @@ func realMain(ctx context.Context) error {
 	path.StrictDecoding(false)
 
 	var cleanup app.Cleanup
+	stats := dispatcher.NewSocketStats()
 	g, errCtx := errgroup.WithContext(ctx)
 	g.Go(func() error {
 		defer log.HandlePanic()
@@ -71,6 +72,9 @@ func realMain(ctx context.Context) error {
 				globalCfg.Dispatcher.UnderlayAddr,
 				underlay.EndhostPort,
 			),
+			globalCfg.Dispatcher.Shards,
+			globalCfg.Dispatcher.SCMPListeners,
+			stats,
 		)
 	})
 
@@ -86,6 +90,7 @@ func realMain(ctx context.Context) error {
 			Config:   service.NewConfigStatusPage(globalCfg).Handler,
 			Info:     service.NewInfoStatusPage().Handler,
 			LogLevel: service.NewLogLevelStatusPage().Handler,
+			Sockets:  stats.Sockets,
 		}
 		log.Info("Exposing API", "addr", globalCfg.API.Addr)
 		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
@@ -139,10 +144,15 @@ func runDispatcher(
 	isDispatcher bool,
 	svcAddrs map[addr.Addr]netip.AddrPort,
 	underlayAddr netip.AddrPort,
+	shards int,
+	scmpListeners []netip.AddrPort,
+	stats *dispatcher.SocketStats,
 ) error {
 
-	log.Debug("Dispatcher starting", "localAddr", underlayAddr, "dispatcher feature", isDispatcher)
-	return dispatcher.ListenAndServe(isDispatcher, svcAddrs, net.UDPAddrFromAddrPort(underlayAddr))
+	log.Debug("Dispatcher starting", "localAddr", underlayAddr, "dispatcher feature", isDispatcher,
+		"shards", shards)
+	return dispatcher.ListenAndServe(isDispatcher, svcAddrs, net.UDPAddrFromAddrPort(underlayAddr),
+		shards, scmpListeners, stats)
 }
 
 func requiredIPs() ([]net.IP, error) {
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"net/netip"
+	"runtime"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
@@ -87,6 +88,15 @@ type Dispatcher struct {
 	ServiceAddresses map[addr.Addr]netip.AddrPort `toml:"service_addresses,omitempty"`
 	// UnderlayAddr is the IP address where the shim dispatcher listens on (default ::).
 	UnderlayAddr netip.Addr `toml:"underlay_addr,omitempty"`
+	// Shards is the number of dispatcher instances that share the underlay
+	// port. Each instance is started with the same configuration and the
+	// packets are distributed among them by flow. Only supported on Linux.
+	// Values of 0 and 1 disable sharding.
+	Shards int `toml:"shards,omitempty"`
+	// SCMPListeners are the underlay UDP/IP addresses that receive a copy of
+	// each SCMP error message that cannot be delivered to an application
+	// socket.
+	SCMPListeners []netip.AddrPort `toml:"scmp_listeners,omitempty"`
 }
 
 func (cfg *Dispatcher) InitDefaults() {
@@ -102,6 +112,19 @@ func (cfg *Dispatcher) Validate() error {
 	if cfg.ID == "" {
 		return serrors.New("id must be set")
 	}
+	if cfg.LocalUDPForwarding && runtime.GOOS == "windows" {
+		// The destination address of incoming packets (IP_PKTINFO) is not
+		// available on Windows, so forwarded packets cannot be validated.
+		return serrors.New("local_udp_forwarding is not supported on windows")
+	}
+	if cfg.Shards < 0 {
+		return serrors.New("shards must not be negative", "shards", cfg.Shards)
+	}
+	for _, l := range cfg.SCMPListeners {
+		if !l.IsValid() || l.Port() == 0 {
+			return serrors.New("invalid SCMP listener address", "addr", l)
+		}
+	}
 
 	// Process ServiceAddresses
 	for iaSVC := range cfg.ServiceAddresses {
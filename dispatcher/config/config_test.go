@@ -52,4 +52,6 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	assert.Equal(t, id, cfg.Dispatcher.ID)
 	assert.True(t, cfg.Dispatcher.UnderlayAddr.IsValid())
 	assert.Len(t, cfg.Dispatcher.ServiceAddresses, 6)
+	assert.Zero(t, cfg.Dispatcher.Shards)
+	assert.Empty(t, cfg.Dispatcher.SCMPListeners)
 }
@@ -24,6 +24,17 @@ id = "%s"
 # The underlay IP address opened by the dispatcher. (default ::)
 # underlay_addr = "::"
 
+# The number of dispatcher instances that share the underlay port. Start that
+# many dispatchers with the same configuration; the packets are distributed
+# among them by SCION flow ID and source ISD-AS. Only supported on Linux.
+# (default 0, i.e., a single instance)
+# shards = 0
+
+# The underlay UDP/IP addresses that receive a copy of each SCMP error message
+# that cannot be delivered to an application socket, e.g., because the quoted
+# packet is truncated. (default [])
+# scmp_listeners = ["[::1]:31500"]
+
 # ServiceAddresses is the map of IA,SVC -> underlay UDP/IP address.
 # The map should be configured provided that the shim dispatcher runs colocated to such
 # mapped services, e.g., the shim dispatcher runs on the same host,
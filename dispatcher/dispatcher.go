@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"strconv"
 
 	"github.com/gopacket/gopacket"
 	"golang.org/x/net/ipv4"
@@ -62,6 +63,11 @@ type Server struct {
 	e2e        slayers.EndToEndExtn
 	udpLayer   slayers.UDP
 	scmpLayer  slayers.SCMP
+
+	scmpListeners []SCMPListener
+	// Stats counts the packets delivered to each socket. If nil, they are not
+	// counted.
+	Stats *SocketStats
 }
 
 // NewServer creates new instance of Server.
@@ -132,8 +138,17 @@ func (s *Server) Serve() error {
 			// we discard the packet and keep serving.
 			continue
 		}
+		if s.isDispatcher {
+			nextHopAddr = s.withIncomingZone(nextHopAddr)
+		}
 
 		m, err := s.conn.WriteToUDPAddrPort(outBuf, nextHopAddr)
+		if nextHopAddr != prevHop {
+			// Replies to SCMP informational requests are sent back to the
+			// border router, everything else is delivered to a socket.
+			s.Stats.delivered(nextHopAddr, len(outBuf),
+				s.decoded[len(s.decoded)-1] == slayers.LayerTypeSCMP, err)
+		}
 		if err != nil {
 			log.Error("writing packet out", "err", err)
 			continue
@@ -205,6 +220,7 @@ func (s *Server) processMsgNextHop(
 			dstAddrPort, err = s.getDstSCMP()
 			if err != nil {
 				log.Error("Getting destination for SCMP message", "err", err)
+				s.notifySCMPError(buf, netip.AddrPort{})
 				return nil, netip.AddrPort{}, nil
 			}
 			if dstAddrPort.Addr().Unmap().Compare(underlay.Unmap()) != 0 {
@@ -213,6 +229,7 @@ func (s *Server) processMsgNextHop(
 					"UDP/SCION:", dstAddrPort.Addr().Unmap().String())
 				return nil, netip.AddrPort{}, nil
 			}
+			s.notifySCMPError(buf, dstAddrPort)
 		}
 	case slayers.LayerTypeSCIONUDP:
 		dstAddrPort, err = s.getDstSCIONUDP()
@@ -431,6 +448,8 @@ func (s *Server) getDstSCIONUDP() (netip.AddrPort, error) {
 
 type controlMessageParser interface {
 	Destination() net.IP
+	// Interface returns the index of the interface the packet was received on.
+	Interface() int
 	Parse(b []byte) error
 	String() string
 }
@@ -443,6 +462,10 @@ func (m ipv4ControlMessage) Destination() net.IP {
 	return m.Dst
 }
 
+func (m ipv4ControlMessage) Interface() int {
+	return m.IfIndex
+}
+
 type ipv6ControlMessage struct {
 	*ipv6.ControlMessage
 }
@@ -451,6 +474,10 @@ func (m ipv6ControlMessage) Destination() net.IP {
 	return m.Dst
 }
 
+func (m ipv6ControlMessage) Interface() int {
+	return m.IfIndex
+}
+
 // parseUnderlayAddr returns the underlay destination address on the outer UDP/IP wrapper.
 // It returns an empty address, if the control message information is not present
 // or it cannot be parsed.
@@ -474,23 +501,52 @@ func (s *Server) parseUnderlayAddr(oobuffer []byte) netip.Addr {
 	return netip.Addr{}
 }
 
+// ListenAndServe opens the underlay socket on addr and serves it. If shards is
+// larger than one, the socket is shared with the other dispatcher instances
+// listening on addr, see listenUDP. The SCMP errors that cannot be delivered
+// to an application are forwarded to scmpListeners. If stats is not nil, the
+// packets delivered to each socket are counted in it.
 func ListenAndServe(
 	isDispatcher bool,
 	svcAddrs map[addr.Addr]netip.AddrPort,
 	addr *net.UDPAddr,
+	shards int,
+	scmpListeners []netip.AddrPort,
+	stats *SocketStats,
 ) error {
 
-	conn, err := net.ListenUDP(addr.Network(), addr)
+	conn, err := listenUDP(addr, shards)
 	if err != nil {
 		return err
 	}
 	defer conn.Close()
 	log.Debug(fmt.Sprintf("local address: %s", conn.LocalAddr()))
 	dispServer := NewServer(isDispatcher, svcAddrs, conn)
+	dispServer.Stats = stats
+	if len(scmpListeners) > 0 {
+		dispServer.RegisterSCMPListener(scmpForwarder{conn: conn, addrs: scmpListeners})
+	}
 
 	return dispServer.Serve()
 }
 
+// withIncomingZone adds the zone of the interface the last packet was received
+// on to link-local IPv6 destinations. The addresses in the UDP/SCION header
+// carry no zone, but the packet can only be delivered on a link-local address
+// if the interface is known. The end host is on the same link as the
+// dispatcher, so the interface of the incoming packet is used.
+func (s *Server) withIncomingZone(dst netip.AddrPort) netip.AddrPort {
+	a := dst.Addr()
+	if !a.Is6() || a.Is4In6() || !a.IsLinkLocalUnicast() || a.Zone() != "" {
+		return dst
+	}
+	ifIndex := s.cmParser.Interface()
+	if ifIndex <= 0 {
+		return dst
+	}
+	return netip.AddrPortFrom(a.WithZone(strconv.Itoa(ifIndex)), dst.Port())
+}
+
 // decodeSCMP decodes the SCMP payload. WARNING: Decoding is done with NoCopy set.
 func decodeSCMP(scmp *slayers.SCMP) ([]gopacket.SerializableLayer, error) {
 	gpkt := gopacket.NewPacket(scmp.Payload, scmp.NextLayerType(),
@@ -518,7 +574,7 @@ func addrPortFromBytes(addr []byte, port uint16) (netip.AddrPort, error) {
 	return netip.AddrPortFrom(a, port), nil
 }
 
-// setIPPktInfo sets the IP_PKTINFO.DST flag to the underlay socket. The IPv4 part
+// setIPPktInfo sets the IP_PKTINFO.DST and interface flags to the underlay socket. The IPv4 part
 // covers the case for IPv4-only hosts. For hosts supporting dual stack, the IPv6
 // part handles both 6 and 4 (with mapped addresses).
 // The argument conn must not be nil. The returned conn will have the flag set,
@@ -533,7 +589,7 @@ func setIPPktInfo(conn *net.UDPConn) (*net.UDPConn, controlMessageParser) {
 
 	var cm controlMessageParser
 	if udpAddr.AddrPort().Addr().Unmap().Is4() {
-		err := ipv4.NewPacketConn(conn).SetControlMessage(ipv4.FlagDst, true)
+		err := ipv4.NewPacketConn(conn).SetControlMessage(ipv4.FlagDst|ipv4.FlagInterface, true)
 		if err != nil {
 			panic(fmt.Sprintf("cannot set IP_PKTINFO on socket: %s", err))
 		}
@@ -542,7 +598,7 @@ func setIPPktInfo(conn *net.UDPConn) (*net.UDPConn, controlMessageParser) {
 		}
 	}
 	if udpAddr.AddrPort().Addr().Unmap().Is6() {
-		err := ipv6.NewPacketConn(conn).SetControlMessage(ipv6.FlagDst, true)
+		err := ipv6.NewPacketConn(conn).SetControlMessage(ipv6.FlagDst|ipv6.FlagInterface, true)
 		if err != nil {
 			panic(fmt.Sprintf("cannot set IP_PKTINFO on socket: %s", err))
 		}
@@ -21,8 +21,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/ipv6"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
 	"github.com/scionproto/scion/pkg/snet"
 	"github.com/scionproto/scion/pkg/snet/path"
 )
@@ -387,3 +389,106 @@ func MustPack(pkt snet.Packet) []byte {
 	}
 	return pkt.Bytes
 }
+
+func TestWithIncomingZone(t *testing.T) {
+	server := Server{
+		cmParser: ipv6ControlMessage{
+			ControlMessage: &ipv6.ControlMessage{IfIndex: 3},
+		},
+	}
+	testCases := map[string]struct {
+		Dst      netip.AddrPort
+		Expected netip.AddrPort
+	}{
+		"link-local": {
+			Dst:      netip.MustParseAddrPort("[fe80::1]:31000"),
+			Expected: netip.MustParseAddrPort("[fe80::1%3]:31000"),
+		},
+		"link-local with zone": {
+			Dst:      netip.MustParseAddrPort("[fe80::1%1]:31000"),
+			Expected: netip.MustParseAddrPort("[fe80::1%1]:31000"),
+		},
+		"global IPv6": {
+			Dst:      netip.MustParseAddrPort("[2001:db8::1]:31000"),
+			Expected: netip.MustParseAddrPort("[2001:db8::1]:31000"),
+		},
+		"IPv4": {
+			Dst:      netip.MustParseAddrPort("169.254.0.1:31000"),
+			Expected: netip.MustParseAddrPort("169.254.0.1:31000"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, server.withIncomingZone(tc.Dst))
+		})
+	}
+}
+
+type recordingSCMPListener []SCMPError
+
+func (r *recordingSCMPListener) HandleSCMPError(e SCMPError) {
+	*r = append(*r, e)
+}
+
+func TestSCMPListener(t *testing.T) {
+	clientHost := addr.MustParseHost("127.0.0.1")
+	clientIA := addr.MustParseIA("1-ff00:0:1")
+	routerHost := addr.MustParseHost("127.0.0.2")
+	routerIA := addr.MustParseIA("1-ff00:0:2")
+	scmpError := func(quote []byte) []byte {
+		return MustPack(snet.Packet{
+			PacketInfo: snet.PacketInfo{
+				Source:      snet.SCIONAddress{IA: routerIA, Host: routerHost},
+				Destination: snet.SCIONAddress{IA: clientIA, Host: clientHost},
+				Payload:     snet.SCMPDestinationUnreachable{Payload: quote},
+				Path:        path.Empty{},
+			},
+		})
+	}
+	quote := func(payload snet.Payload) []byte {
+		return MustPack(snet.Packet{
+			PacketInfo: snet.PacketInfo{
+				Source:      snet.SCIONAddress{IA: clientIA, Host: clientHost},
+				Destination: snet.SCIONAddress{IA: routerIA, Host: routerHost},
+				Payload:     payload,
+				Path:        path.Empty{},
+			},
+		})
+	}
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer conn.Close()
+	server := NewServer(true, map[addr.Addr]netip.AddrPort{}, conn)
+	var listener recordingSCMPListener
+	server.RegisterSCMPListener(&listener)
+	underlay := netip.MustParseAddr("127.0.0.1")
+	prevHop := netip.MustParseAddrPort("127.0.0.2:30042")
+
+	t.Run("delivered", func(t *testing.T) {
+		listener = nil
+		q := quote(snet.SCMPEchoRequest{Identifier: 0xdead})
+		_, dst, err := server.processMsgNextHop(scmpError(q), underlay, prevHop)
+		require.NoError(t, err)
+		assert.Equal(t, netip.AddrPortFrom(underlay, 0xdead), dst)
+
+		require.Len(t, listener, 1)
+		e := listener[0]
+		assert.Equal(t, slayers.SCMPTypeDestinationUnreachable, e.TypeCode.Type())
+		assert.Equal(t, addr.Addr{IA: routerIA, Host: routerHost}, e.Source)
+		assert.Equal(t, addr.Addr{IA: clientIA, Host: clientHost}, e.Destination)
+		assert.Equal(t, q, e.Quote)
+		assert.Equal(t, dst, e.Owner)
+	})
+	t.Run("unmatched", func(t *testing.T) {
+		listener = nil
+		raw := scmpError(quote(snet.SCMPDestinationUnreachable{}))
+		_, dst, err := server.processMsgNextHop(raw, underlay, prevHop)
+		require.NoError(t, err)
+		assert.False(t, dst.IsValid())
+
+		require.Len(t, listener, 1)
+		assert.False(t, listener[0].Owner.IsValid())
+		assert.Equal(t, raw, listener[0].Raw)
+	})
+}
@@ -15,7 +15,10 @@
 package mgmtapi
 
 import (
+	"encoding/json"
 	"net/http"
+
+	"github.com/scionproto/scion/dispatcher"
 )
 
 // Server implements the Dispatcher Service API.
@@ -23,6 +26,9 @@ type Server struct {
 	Config   http.HandlerFunc
 	Info     http.HandlerFunc
 	LogLevel http.HandlerFunc
+	// Sockets returns the statistics of the sockets the dispatcher delivered
+	// packets to.
+	Sockets func() []dispatcher.SocketStat
 }
 
 // GetConfig is an indirection to the http handler.
@@ -44,3 +50,31 @@ func (s *Server) GetLogLevel(w http.ResponseWriter, r *http.Request) {
 func (s *Server) SetLogLevel(w http.ResponseWriter, r *http.Request) {
 	s.LogLevel(w, r)
 }
+
+// GetSockets lists the statistics of the sockets the dispatcher delivered
+// packets to.
+func (s *Server) GetSockets(w http.ResponseWriter, r *http.Request) {
+	stats := s.Sockets()
+	rep := Sockets{Sockets: make([]Socket, 0, len(stats))}
+	for _, st := range stats {
+		sock := Socket{
+			Address:      st.Address.String(),
+			Packets:      int(st.Packets),
+			Bytes:        int(st.Bytes),
+			ScmpMessages: int(st.SCMP),
+			SendErrors:   int(st.SendErrors),
+			LastPacket:   st.LastPacket.UTC(),
+		}
+		if st.ReceiveBufferDrops >= 0 {
+			drops := int(st.ReceiveBufferDrops)
+			sock.ReceiveBufferDrops = &drops
+		}
+		rep.Sockets = append(rep.Sockets, sock)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		http.Error(w, "unable to marshal response: "+err.Error(), http.StatusInternalServerError)
+	}
+}
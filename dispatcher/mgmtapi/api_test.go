@@ -0,0 +1,70 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmtapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/dispatcher"
+	"github.com/scionproto/scion/pkg/private/xtest"
+)
+
+var update = xtest.UpdateGoldenFiles()
+
+func TestGetSockets(t *testing.T) {
+	s := &Server{
+		Sockets: func() []dispatcher.SocketStat {
+			return []dispatcher.SocketStat{
+				{
+					Address:            netip.MustParseAddrPort("192.0.2.10:31000"),
+					Packets:            1200,
+					Bytes:              1440000,
+					SCMP:               2,
+					ReceiveBufferDrops: 14,
+					LastPacket:         time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC),
+				},
+				{
+					Address:            netip.MustParseAddrPort("[2001:db8::10]:31001"),
+					Packets:            1,
+					Bytes:              100,
+					SendErrors:         3,
+					ReceiveBufferDrops: -1,
+					LastPacket:         time.Date(2026, 10, 14, 12, 0, 1, 0, time.UTC),
+				},
+			}
+		},
+	}
+	req, err := http.NewRequest("GET", "/sockets", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	Handler(s).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	const responseFile = "testdata/sockets.json"
+	if *update {
+		require.NoError(t, os.WriteFile(responseFile, rr.Body.Bytes(), 0666))
+	}
+	golden, err := os.ReadFile(responseFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), rr.Body.String())
+}
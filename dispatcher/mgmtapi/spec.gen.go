@@ -18,17 +18,27 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xVTW8bIRD9K2ja48rrfJz21qRVZMlporq3yAfMjtdELNCBtWJZ+9+rAduJ14lS9RCp",
-	"vcEAb948HsMWlGu9s2hjgGoLhME7GzBNrmT9A391GCLPlLMRbRpK741WMmpny8fgLMeCWmErefSZcAkV",
-	"fCqfocu8GspZlLaWVH8jcgR93xdQY1CkPYNBxTkF7ZLy6u4g405dM8U1Gh57ch4p6kzU7MPHWFPXNNo2",
-	"Ii8XgLZroXqAGhddAwVou3QcTlzmBeCTbL1BqPYrceN5FiJp2yQ6TE0T1gyTYeeHbW7xiCpCX8BxlSd0",
-	"cR8+ppt2ixZDkA2+m/3AepCd9yX6J/gzpLVWKG6llQ22aKP4cj8RS0cirlDMrid338VXHbyMaoXEDHRM",
-	"cjwHB4ehgDVSyPjj0dlozOU7j1Z6DRVcjMajcyjAy7hKpZfK2aVueNhg8hILk5w0qaGCG4zXeUdx7MXz",
-	"8XhgwohPsfRG6oH9hrKdWGzWKYUhLDsj7vbJmfZlTvGafQ9UyhdvItmza1tJG6jgnrSNISn58+52KnKh",
-	"XYYXS21wxIrKJvDtKde2zsKcMcr9db2lyCS78d/S40oGrQSXRm3WwMsGhVy4LiaVmDo5I8LOlp4c03hT",
-	"JeOa8vDQ35Lq0CPelevve9ghx4dpeYNRmEEzO9GoAN+9IspsIErCv3L15kP02Lfgl/lzB4vUYf9f3dLs",
-	"T24pHUHirgnVwxY6MlDBKkZfleV25ULsq613FPtSel2uz7jFStJyYbJGvCX39qXsTIQKjFPSpDB7wNFg",
-	"+WJ8eXnGKswPdIY/w3Vil34DfPIuYC0Wm92HsHue6V1a2XIr2RXTz/vfAQAA//9Se+9IPwgAAA==",
+	"H4sIAAAAAAACA91X227jNhD9FULtoyLJjrtA3acmLYoASRPUeerCMGiJkrlLiSpJJTEM/3tnSEnWzbBR",
+	"oAu0gB8kcThn7nN88GKZl7JghdHe8uAppuFNM/tyR5M/2F8V0wbfYlkYEMNHWpaCx9RwWYRftCzwm453",
+	"LKf49L1iqbf0vgtPqkN3qsOVoUVCVfKrUlJ5x+PR9xKmY8VLVAa3AJOoGhRP64uo91Fmj+yNCXwulSyZ",
+	"MtwZKprPfV1wIeNFRtyx77Giyr3lZ5DaVhm88yKV+NnasoaHD5qXgsHN+sTsS3zTRoEaaw6axhVLUI1T",
+	"u27F5PYLi8Fq31vJ+CszYztpkkCA9djS1x0j9SGRKTHwqq2OwOuaNftxHkTBPJhFy9tZFEVjE31vuzfs",
+	"DAK4v2UKAawQSZjgbwy8IUaew5wtFgAUtUAciiBjCpEE1WZT0sbVMZ7hObN6UZI4SfJOrwP25tH8080s",
+	"upktXmfzZRTBL5jNbxc/fPoT5FKpcgq4XkINu0GkqWA4zIvhqMWuC8h8OhqKxQwub7ZVmjK1SSDxV+Mi",
+	"1FemCiYI3ivBgC2LaaVd+GrVxKnuF4iNZ1oJ4RNexKJKsODx+KSbGpLwhBTSEOhIRlIlcyuScF1SAw2m",
+	"AvJciD0poQChXQnvIXCoycJ9oHB9JyGZVA80+AQaG+UeeVF9DEpoKl46zstNDgVPs8v1urp/eiGNMKG5",
+	"HDh5TeLmk1awItnYAXB1sqDyutGx8e3HgqSUC2cL6vcJC7LAb1PKQYl8L+xZk9Mmiz2LJ+psMIKagXKq",
+	"9GYADAPcd7XfvDjCuLFNB/PZcG14bOcQrZ30zs44PR5y+nTADcv1xZXgII4tBlWK7keuNmq7xroM6Nbm",
+	"STt7G2dkLWs+91NvpZuKu7gJ2g0yQEc5u0pG+ldMvfGYkSdaAECOTffzy0NbW6v7h+ffyS9tRXknp08f",
+	"B5dBBlpAO/0wKoMI3QdfC1py+HSLq8NWitlZ1yEZRcozfMzcCMfA2K3+AG55vzFz7yT8Pi+ACTggBIZ9",
+	"mLAUlA+owDBso3W/quIYggylT54bcDR74SCm6qY1JezwE0sVqjynag/yLwBXj9XX56dH4hytnHqSQnNi",
+	"nxmaacwe6IaB4q1RR9ik61xEHhwz+G/F445qHhN0DZcmxqCEwiF0Kys3u9B0JQWMJFeW0CNoxtkoCZmF",
+	"Lek6F6qWr10M1z/nky3GN4slOEbEgFiOYgRdVk0EZTUIitV/J5P9N4lHQ4e7+G6CGVWx4/8qS6trsoSV",
+	"3NlW2RSPfYTN0ln3LT3H3W2Z0NT+P9GRloVJn7xzs7OSY06B3GnAyf16EUwSn9zRrS4FEmhMzdPe6T4g",
+	"r7avK0ifgpsKGWZpAAUkgNF1rK2bHS6L/U/OSr2DjQmxA+oCxuxbEatP93C56bgL1IaJFMfGaBo0fOFf",
+	"LLMGYqrKerymk89gUDinfI9uQMpvbMp160pTUi05sdA4RRkSys8Hr1IwI72dMeUyDA94/bg8lFKZYwhr",
+	"OXyb4dqmitOtcAFBEVeGKa0E/sMRMqbCfsa5Alf7x7fRYjFDn9etPYeL3g99wZQVwO5xOTVR9Idq7m3j",
+	"WKLCPkqp8W/KvuYq9eboKqr77Lg+/g05SyGWZhAAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
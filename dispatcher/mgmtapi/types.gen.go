@@ -3,6 +3,10 @@
 // Code generated by unknown module path version unknown version DO NOT EDIT.
 package mgmtapi
 
+import (
+	"time"
+)
+
 // Defines values for LogLevelLevel.
 const (
 	Debug LogLevelLevel = "debug"
@@ -19,6 +23,35 @@ type LogLevel struct {
 // LogLevelLevel Logging level
 type LogLevelLevel string
 
+// Socket defines model for Socket.
+type Socket struct {
+	// Address The address of the socket.
+	Address string `json:"address"`
+
+	// Bytes The number of bytes delivered to the socket.
+	Bytes int `json:"bytes"`
+
+	// LastPacket The time the last packet was delivered to the socket.
+	LastPacket time.Time `json:"last_packet"`
+
+	// Packets The number of packets delivered to the socket.
+	Packets int `json:"packets"`
+
+	// ReceiveBufferDrops The number of packets the kernel dropped because the receive buffer of the socket was full, including the packets that did not come from the dispatcher. Only present if the socket is on the same host as the dispatcher, and on Linux.
+	ReceiveBufferDrops *int `json:"receive_buffer_drops,omitempty"`
+
+	// ScmpMessages The number of SCMP messages among the packets delivered to the socket.
+	ScmpMessages int `json:"scmp_messages"`
+
+	// SendErrors The number of packets for the socket that the dispatcher failed to send, e.g., because its own send buffer was full.
+	SendErrors int `json:"send_errors"`
+}
+
+// Sockets defines model for Sockets.
+type Sockets struct {
+	Sockets []Socket `json:"sockets"`
+}
+
 // StandardError defines model for StandardError.
 type StandardError struct {
 	// Error Error message
@@ -0,0 +1,53 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"golang.org/x/net/bpf"
+
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+// Offsets into the SCION header that the shard selection program reads. The
+// first word holds version, traffic class and flow ID, the source ISD-AS
+// follows the destination ISD-AS in the address header.
+const (
+	flowIDOffset = 0
+	srcIAOffset  = slayers.CmnHdrLen + 8
+)
+
+// shardProgram returns the classic BPF program that selects the socket of a
+// SO_REUSEPORT group for an incoming packet. The program is run on the UDP
+// payload, i.e., the SCION header. It hashes the flow ID and the source ISD-AS
+// so that all packets of a flow end up at the same dispatcher instance. The
+// kernel's default 4-tuple hash is not useful here, because most of the
+// traffic arrives from the few border routers of the AS.
+//
+// Packets that are too short to be SCION packets are steered to the first
+// instance.
+func shardProgram(shards int) []bpf.Instruction {
+	return []bpf.Instruction{
+		bpf.LoadAbsolute{Off: flowIDOffset, Size: 4},
+		bpf.ALUOpConstant{Op: bpf.ALUOpAnd, Val: 0xfffff},
+		bpf.TAX{},
+		bpf.LoadAbsolute{Off: srcIAOffset, Size: 4},
+		bpf.ALUOpX{Op: bpf.ALUOpXor},
+		bpf.TAX{},
+		bpf.LoadAbsolute{Off: srcIAOffset + 4, Size: 4},
+		bpf.ALUOpX{Op: bpf.ALUOpXor},
+		bpf.ALUOpConstant{Op: bpf.ALUOpMod, Val: uint32(shards)},
+		bpf.RetA{},
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package dispatcher
+
+import (
+	"context"
+	"net"
+	"syscall"
+
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// listenUDP opens the underlay socket. If shards is larger than one, the
+// socket joins the SO_REUSEPORT group of the address and incoming packets are
+// distributed among the members of the group by flow.
+func listenUDP(addr *net.UDPAddr, shards int) (*net.UDPConn, error) {
+	if shards <= 1 {
+		return net.ListenUDP(addr.Network(), addr)
+	}
+	raw, err := bpf.Assemble(shardProgram(shards))
+	if err != nil {
+		return nil, serrors.Wrap("assembling shard program", err)
+	}
+	filter := make([]unix.SockFilter, 0, len(raw))
+	for _, ins := range raw {
+		filter = append(filter, unix.SockFilter{Code: ins.Op, Jt: ins.Jt, Jf: ins.Jf, K: ins.K})
+	}
+	prog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+
+	lc := net.ListenConfig{
+		Control: func(_, _ string, c syscall.RawConn) error {
+			return control(c, func(fd int) error {
+				err := unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_REUSEPORT, 1)
+				if err != nil {
+					return serrors.Wrap("enabling SO_REUSEPORT", err)
+				}
+				return nil
+			})
+		},
+	}
+	pconn, err := lc.ListenPacket(context.Background(), addr.Network(), addr.String())
+	if err != nil {
+		return nil, err
+	}
+	conn := pconn.(*net.UDPConn)
+	// The program is attached to the group once the socket is bound. Attaching
+	// it to an unbound socket creates a group of its own, which prevents the
+	// socket from joining the group of the other instances.
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	err = control(rawConn, func(fd int) error {
+		err := unix.SetsockoptSockFprog(fd, unix.SOL_SOCKET, unix.SO_ATTACH_REUSEPORT_CBPF, &prog)
+		if err != nil {
+			return serrors.Wrap("attaching shard program", err)
+		}
+		return nil
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// control runs f on the file descriptor of c.
+func control(c syscall.RawConn, f func(fd int) error) error {
+	var fErr error
+	if err := c.Control(func(fd uintptr) { fErr = f(int(fd)) }); err != nil {
+		return err
+	}
+	return fErr
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package dispatcher
+
+import (
+	"net"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// listenUDP opens the underlay socket. Sharding the socket among several
+// dispatcher instances is only supported on Linux.
+func listenUDP(addr *net.UDPAddr, shards int) (*net.UDPConn, error) {
+	if shards > 1 {
+		return nil, serrors.New("sharding is only supported on linux", "shards", shards)
+	}
+	return net.ListenUDP(addr.Network(), addr)
+}
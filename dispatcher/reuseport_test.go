@@ -0,0 +1,124 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/bpf"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path/empty"
+)
+
+func TestShardProgram(t *testing.T) {
+	const shards = 4
+	vm, err := bpf.NewVM(shardProgram(shards))
+	require.NoError(t, err)
+	src := addr.MustParseIA("1-ff00:0:2")
+
+	t.Run("same flow same shard", func(t *testing.T) {
+		first, err := vm.Run(testSCIONPacket(t, 42, src))
+		require.NoError(t, err)
+		for range 10 {
+			shard, err := vm.Run(testSCIONPacket(t, 42, src))
+			require.NoError(t, err)
+			assert.Equal(t, first, shard)
+		}
+	})
+	t.Run("flows are spread", func(t *testing.T) {
+		seen := map[int]bool{}
+		for flowID := range uint32(64) {
+			shard, err := vm.Run(testSCIONPacket(t, flowID, src))
+			require.NoError(t, err)
+			assert.Less(t, shard, shards)
+			seen[shard] = true
+		}
+		assert.Len(t, seen, shards)
+	})
+	t.Run("source AS is considered", func(t *testing.T) {
+		a, err := vm.Run(testSCIONPacket(t, 1, addr.MustParseIA("1-ff00:0:2")))
+		require.NoError(t, err)
+		b, err := vm.Run(testSCIONPacket(t, 1, addr.MustParseIA("1-ff00:0:3")))
+		require.NoError(t, err)
+		assert.NotEqual(t, a, b)
+	})
+	t.Run("short packet", func(t *testing.T) {
+		shard, err := vm.Run([]byte{0x00, 0x01})
+		require.NoError(t, err)
+		assert.Equal(t, 0, shard)
+	})
+}
+
+func TestListenUDPShards(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("sharding is only supported on linux")
+	}
+	first, err := listenUDP(&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}, 2)
+	require.NoError(t, err)
+	defer first.Close()
+
+	second, err := listenUDP(first.LocalAddr().(*net.UDPAddr), 2)
+	require.NoError(t, err)
+	defer second.Close()
+
+	_, err = listenUDP(first.LocalAddr().(*net.UDPAddr), 1)
+	assert.Error(t, err)
+
+	vm, err := bpf.NewVM(shardProgram(2))
+	require.NoError(t, err)
+	client, err := net.DialUDP("udp", nil, first.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+	defer client.Close()
+	conns := []*net.UDPConn{first, second}
+	for flowID := range uint32(4) {
+		raw := testSCIONPacket(t, flowID, addr.MustParseIA("1-ff00:0:2"))
+		shard, err := vm.Run(raw)
+		require.NoError(t, err)
+		_, err = client.Write(raw)
+		require.NoError(t, err)
+
+		conn := conns[shard]
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		buf := make([]byte, 1024)
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, raw, buf[:n])
+	}
+}
+
+func testSCIONPacket(t *testing.T, flowID uint32, src addr.IA) []byte {
+	scn := &slayers.SCION{
+		FlowID:   flowID,
+		NextHdr:  slayers.L4UDP,
+		PathType: empty.PathType,
+		Path:     empty.Path{},
+		SrcIA:    src,
+		DstIA:    addr.MustParseIA("1-ff00:0:1"),
+	}
+	require.NoError(t, scn.SetSrcAddr(addr.MustParseHost("127.0.0.1")))
+	require.NoError(t, scn.SetDstAddr(addr.MustParseHost("127.0.0.2")))
+	buf := gopacket.NewSerializeBuffer()
+	require.NoError(t, gopacket.SerializeLayers(buf,
+		gopacket.SerializeOptions{FixLengths: true}, scn))
+	return buf.Bytes()
+}
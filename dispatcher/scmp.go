@@ -0,0 +1,110 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"net"
+	"net/netip"
+
+	"github.com/gopacket/gopacket"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+// SCMPError describes an SCMP error message received by the dispatcher. The
+// byte slices are owned by the listener.
+type SCMPError struct {
+	// TypeCode is the type and code of the SCMP message.
+	TypeCode slayers.SCMPTypeCode
+	// Source is the sender of the SCMP message, typically a router.
+	Source addr.Addr
+	// Destination is the destination of the SCMP message, i.e., the host
+	// that sent the offending packet.
+	Destination addr.Addr
+	// Path is the raw path of the SCMP message.
+	Path []byte
+	// Quote is the quoted offending packet. It is nil for unknown SCMP types.
+	Quote []byte
+	// Raw is the complete SCION packet carrying the SCMP message.
+	Raw []byte
+	// Owner is the address of the application socket the message was
+	// delivered to. It is the zero value if no owner could be determined, in
+	// which case the message is dropped after the listeners were notified.
+	Owner netip.AddrPort
+}
+
+// SCMPListener is notified of the SCMP errors received by the dispatcher.
+// Listeners are called synchronously from Server.Serve and must not block.
+type SCMPListener interface {
+	HandleSCMPError(SCMPError)
+}
+
+// RegisterSCMPListener registers l to be notified of all SCMP errors the
+// dispatcher receives, whether they are delivered to an application or not.
+// It must not be called concurrently with Serve.
+func (s *Server) RegisterSCMPListener(l SCMPListener) {
+	s.scmpListeners = append(s.scmpListeners, l)
+}
+
+// notifySCMPError notifies the listeners of the SCMP error in the last
+// decoded packet raw.
+func (s *Server) notifySCMPError(raw []byte, owner netip.AddrPort) {
+	if len(s.scmpListeners) == 0 || s.scmpLayer.TypeCode.InfoMsg() {
+		return
+	}
+	e := SCMPError{
+		TypeCode: s.scmpLayer.TypeCode,
+		Raw:      append([]byte(nil), raw...),
+		Owner:    owner,
+	}
+	if src, err := s.scionLayer.SrcAddr(); err == nil {
+		e.Source = addr.Addr{IA: s.scionLayer.SrcIA, Host: src}
+	}
+	if dst, err := s.scionLayer.DstAddr(); err == nil {
+		e.Destination = addr.Addr{IA: s.scionLayer.DstIA, Host: dst}
+	}
+	pathStart := slayers.CmnHdrLen + s.scionLayer.AddrHdrLen()
+	if pathEnd := pathStart + s.scionLayer.Path.Len(); pathEnd <= len(s.scionLayer.Contents) {
+		e.Path = append([]byte(nil), s.scionLayer.Contents[pathStart:pathEnd]...)
+	}
+	if s.scmpLayer.NextLayerType() != gopacket.LayerTypePayload {
+		if l, err := decodeSCMP(&s.scmpLayer); err == nil && len(l) == 2 {
+			e.Quote = append([]byte(nil), *l[1].(*gopacket.Payload)...)
+		}
+	}
+	for _, l := range s.scmpListeners {
+		l.HandleSCMPError(e)
+	}
+}
+
+// scmpForwarder forwards the SCMP errors that could not be delivered to an
+// application to a fixed set of addresses.
+type scmpForwarder struct {
+	conn  *net.UDPConn
+	addrs []netip.AddrPort
+}
+
+func (f scmpForwarder) HandleSCMPError(e SCMPError) {
+	if e.Owner.IsValid() {
+		return
+	}
+	for _, a := range f.addrs {
+		if _, err := f.conn.WriteToUDPAddrPort(e.Raw, a); err != nil {
+			log.Debug("Forwarding unmatched SCMP error", "listener", a, "err", err)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"bufio"
+	"encoding/hex"
+	"io"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// maxSockets is the maximum number of sockets for which statistics are
+	// kept.
+	maxSockets = 1 << 12
+	// socketIdleTimeout is the time after the last packet after which the
+	// statistics of a socket may be forgotten to make room for another one.
+	socketIdleTimeout = 10 * time.Minute
+)
+
+// SocketStats counts the packets the dispatcher delivers to each socket of the
+// end host. It is safe for concurrent use.
+type SocketStats struct {
+	mtx     sync.Mutex
+	sockets map[netip.AddrPort]*socketCounters
+}
+
+type socketCounters struct {
+	packets    uint64
+	bytes      uint64
+	scmp       uint64
+	sendErrors uint64
+	lastPacket time.Time
+}
+
+// SocketStat are the statistics of a socket.
+type SocketStat struct {
+	Address netip.AddrPort
+	// Packets and Bytes count the packets delivered to the socket.
+	Packets uint64
+	Bytes   uint64
+	// SCMP is the number of SCMP messages among the delivered packets.
+	SCMP uint64
+	// SendErrors is the number of packets for the socket that could not be
+	// sent.
+	SendErrors uint64
+	// ReceiveBufferDrops is the number of packets the kernel dropped because
+	// the receive buffer of the socket was full. It is only known for the
+	// sockets of the local host on Linux, and is -1 otherwise.
+	ReceiveBufferDrops int64
+	LastPacket         time.Time
+}
+
+// NewSocketStats returns empty socket statistics.
+func NewSocketStats() *SocketStats {
+	return &SocketStats{sockets: make(map[netip.AddrPort]*socketCounters)}
+}
+
+// delivered records a packet of n bytes sent to the socket at dst. err is the
+// error of the send operation.
+func (s *SocketStats) delivered(dst netip.AddrPort, n int, scmp bool, err error) {
+	if s == nil {
+		return
+	}
+	now := time.Now()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	c, ok := s.sockets[dst]
+	if !ok {
+		if len(s.sockets) >= maxSockets && !s.evictIdle(now) {
+			return
+		}
+		c = &socketCounters{}
+		s.sockets[dst] = c
+	}
+	c.lastPacket = now
+	if err != nil {
+		c.sendErrors++
+		return
+	}
+	c.packets++
+	c.bytes += uint64(n)
+	if scmp {
+		c.scmp++
+	}
+}
+
+// evictIdle forgets the sockets that did not receive packets for the idle
+// timeout, and returns whether there is room for another socket.
+func (s *SocketStats) evictIdle(now time.Time) bool {
+	for a, c := range s.sockets {
+		if now.Sub(c.lastPacket) > socketIdleTimeout {
+			delete(s.sockets, a)
+		}
+	}
+	return len(s.sockets) < maxSockets
+}
+
+// Sockets returns the statistics of the sockets, ordered by address.
+func (s *SocketStats) Sockets() []SocketStat {
+	s.mtx.Lock()
+	stats := make([]SocketStat, 0, len(s.sockets))
+	for a, c := range s.sockets {
+		stats = append(stats, SocketStat{
+			Address:            a,
+			Packets:            c.packets,
+			Bytes:              c.bytes,
+			SCMP:               c.scmp,
+			SendErrors:         c.sendErrors,
+			ReceiveBufferDrops: -1,
+			LastPacket:         c.lastPacket,
+		})
+	}
+	s.mtx.Unlock()
+	slices.SortFunc(stats, func(a, b SocketStat) int {
+		return a.Address.Compare(b.Address)
+	})
+
+	drops, err := receiveBufferDrops()
+	if err != nil {
+		log.Debug("Reading the receive buffer drops of the sockets", "err", err)
+		return stats
+	}
+	for i, st := range stats {
+		if d, ok := lookupDrops(drops, st.Address); ok {
+			stats[i].ReceiveBufferDrops = int64(d)
+		}
+	}
+	return stats
+}
+
+// lookupDrops returns the drops of the socket at a. Sockets bound to an
+// unspecified address receive the packets for all the addresses of the host.
+func lookupDrops(drops map[netip.AddrPort]uint64, a netip.AddrPort) (uint64, bool) {
+	ip := a.Addr().WithZone("").Unmap()
+	candidates := []netip.AddrPort{netip.AddrPortFrom(ip, a.Port())}
+	if ip.Is4() {
+		candidates = append(candidates,
+			netip.AddrPortFrom(netip.IPv4Unspecified(), a.Port()),
+			netip.AddrPortFrom(netip.AddrFrom16(ip.As16()), a.Port()))
+	}
+	candidates = append(candidates, netip.AddrPortFrom(netip.IPv6Unspecified(), a.Port()))
+	for _, c := range candidates {
+		if d, ok := drops[c]; ok {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// parseProcNetUDP parses the UDP socket table in the format of /proc/net/udp
+// and /proc/net/udp6 on Linux, and returns the drops per local address.
+func parseProcNetUDP(r io.Reader, drops map[netip.AddrPort]uint64) error {
+	scanner := bufio.NewScanner(r)
+	// Skip the header.
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 13 {
+			return serrors.New("invalid socket entry", "entry", scanner.Text())
+		}
+		local, err := parseProcNetAddr(fields[1])
+		if err != nil {
+			return err
+		}
+		d, err := strconv.ParseUint(fields[12], 10, 64)
+		if err != nil {
+			return serrors.Wrap("parsing drops", err, "entry", scanner.Text())
+		}
+		drops[local] += d
+	}
+	return scanner.Err()
+}
+
+// parseProcNetAddr parses an address of /proc/net/udp{,6}: the IP address is
+// written as 32-bit words in host byte order, which is little endian on the
+// platforms that are supported.
+func parseProcNetAddr(s string) (netip.AddrPort, error) {
+	ipHex, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return netip.AddrPort{}, serrors.New("invalid address", "addr", s)
+	}
+	b, err := hex.DecodeString(ipHex)
+	if err != nil || (len(b) != 4 && len(b) != 16) {
+		return netip.AddrPort{}, serrors.New("invalid IP address", "addr", s)
+	}
+	for i := 0; i < len(b); i += 4 {
+		b[i], b[i+1], b[i+2], b[i+3] = b[i+3], b[i+2], b[i+1], b[i]
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return netip.AddrPort{}, serrors.Wrap("parsing port", err, "addr", s)
+	}
+	ip, _ := netip.AddrFromSlice(b)
+	return netip.AddrPortFrom(ip, uint16(port)), nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"errors"
+	"io/fs"
+	"net/netip"
+	"os"
+)
+
+// receiveBufferDrops returns the number of packets dropped by the kernel
+// because the receive buffer was full, per local UDP socket address.
+func receiveBufferDrops() (map[netip.AddrPort]uint64, error) {
+	drops := make(map[netip.AddrPort]uint64)
+	for _, name := range []string{"/proc/net/udp", "/proc/net/udp6"} {
+		f, err := os.Open(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			// IPv6 is disabled.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = parseProcNetUDP(f, drops)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return drops, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"net"
+	"net/netip"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSocketStats(t *testing.T) {
+	s := NewSocketStats()
+	a := netip.MustParseAddrPort("192.0.2.10:31000")
+	b := netip.MustParseAddrPort("[2001:db8::10]:31001")
+	s.delivered(a, 100, false, nil)
+	s.delivered(a, 50, true, nil)
+	s.delivered(b, 100, false, &net.OpError{Op: "write"})
+
+	stats := s.Sockets()
+	require.Len(t, stats, 2)
+	assert.Equal(t, a, stats[0].Address)
+	assert.Equal(t, uint64(2), stats[0].Packets)
+	assert.Equal(t, uint64(150), stats[0].Bytes)
+	assert.Equal(t, uint64(1), stats[0].SCMP)
+	assert.Equal(t, uint64(0), stats[0].SendErrors)
+	assert.Equal(t, b, stats[1].Address)
+	assert.Equal(t, uint64(0), stats[1].Packets)
+	assert.Equal(t, uint64(1), stats[1].SendErrors)
+
+	t.Run("idle sockets are evicted when full", func(t *testing.T) {
+		s := NewSocketStats()
+		for i := range maxSockets {
+			s.delivered(netip.AddrPortFrom(a.Addr(), uint16(i)), 1, false, nil)
+		}
+		s.delivered(b, 1, false, nil)
+		assert.Len(t, s.sockets, maxSockets)
+		assert.NotContains(t, s.sockets, b)
+
+		s.sockets[netip.AddrPortFrom(a.Addr(), 0)].lastPacket =
+			time.Now().Add(-socketIdleTimeout - time.Second)
+		s.delivered(b, 1, false, nil)
+		assert.Len(t, s.sockets, maxSockets)
+		assert.Contains(t, s.sockets, b)
+	})
+}
+
+func TestParseProcNetUDP(t *testing.T) {
+	const udp = "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when " +
+		"retrnsmt   uid  timeout inode ref pointer drops\n" +
+		"  123: 0A0200C0:7918 00000000:0000 07 00000000:00000000 00:00000000 " +
+		"00000000     0        0 12345 2 0 7\n" +
+		"  124: 00000000:7919 00000000:0000 07 00000000:00000000 00:00000000 " +
+		"00000000     0        0 12346 2 0 0\n"
+	const udp6 = "  sl  local_address                         remote_address " +
+		"st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops\n" +
+		"  125: B80D0120000000000000000010000000:791A " +
+		"00000000000000000000000000000000:0000 07 00000000:00000000 00:00000000 " +
+		"00000000     0        0 12347 2 0 3\n"
+	drops := make(map[netip.AddrPort]uint64)
+	require.NoError(t, parseProcNetUDP(strings.NewReader(udp), drops))
+	require.NoError(t, parseProcNetUDP(strings.NewReader(udp6), drops))
+	assert.Equal(t, map[netip.AddrPort]uint64{
+		netip.MustParseAddrPort("192.0.2.10:31000"):     7,
+		netip.MustParseAddrPort("0.0.0.0:31001"):        0,
+		netip.MustParseAddrPort("[2001:db8::10]:31002"): 3,
+	}, drops)
+
+	d, ok := lookupDrops(drops, netip.MustParseAddrPort("192.0.2.10:31000"))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(7), d)
+	_, ok = lookupDrops(drops, netip.MustParseAddrPort("192.0.2.11:31001"))
+	assert.True(t, ok, "wildcard socket")
+	_, ok = lookupDrops(drops, netip.MustParseAddrPort("192.0.2.10:31003"))
+	assert.False(t, ok)
+
+	err := parseProcNetUDP(strings.NewReader("header\n  1: invalid\n"), drops)
+	assert.Error(t, err)
+}
+
+func TestSocketStatsReceiveBufferDrops(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("receive buffer drops are only known on linux")
+	}
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer conn.Close()
+	s := NewSocketStats()
+	s.delivered(conn.LocalAddr().(*net.UDPAddr).AddrPort(), 1, false, nil)
+	stats := s.Sockets()
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(0), stats[0].ReceiveBufferDrops)
+}
@@ -29,8 +29,33 @@ import (
 type PathReqFlags struct {
 	Refresh bool
 	Hidden  bool
+	// Policy is the name of a path policy installed in the daemon. The daemon only returns the
+	// paths that match it.
+	Policy string
+	// InlinePolicy is a JSON encoded path policy that the daemon applies to the paths. It is
+	// mutually exclusive with Policy.
+	InlinePolicy []byte
+	// OrderBy are the criteria that the daemon orders the paths by, in decreasing precedence.
+	OrderBy []PathOrdering
+	// MaxPaths is the maximum number of paths that the daemon returns, after it ordered them.
+	// Zero means no limit.
+	MaxPaths int
 }
 
+// PathOrdering is a criterion to order paths by.
+type PathOrdering int
+
+const (
+	// OrderByHops orders the paths with the fewest hops first.
+	OrderByHops PathOrdering = iota + 1
+	// OrderByLatency orders the paths with the lowest announced latency first.
+	OrderByLatency
+	// OrderByBandwidth orders the paths with the highest announced bandwidth first.
+	OrderByBandwidth
+	// OrderByExpiry orders the paths with the latest expiration first.
+	OrderByExpiry
+)
+
 // ASInfo provides information about the local AS.
 type ASInfo struct {
 	IA  addr.IA
@@ -59,6 +84,16 @@ func (h RevHandler) Revoke(ctx context.Context, revInfo *path_mgmt.RevInfo) erro
 	return h.Connector.RevNotification(ctx, revInfo)
 }
 
+// PathErrorHandler is an adapter for SCION Daemon connector to implement
+// snet.PathErrorHandler.
+type PathErrorHandler struct {
+	Connector Connector
+}
+
+func (h PathErrorHandler) ReportPathError(ctx context.Context, pathErr snet.PathError) error {
+	return h.Connector.ReportPathError(ctx, pathErr)
+}
+
 // TopoQuerier can be used to get topology information from the SCION Daemon.
 type TopoQuerier struct {
 	Connector Connector
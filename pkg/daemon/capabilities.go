@@ -0,0 +1,70 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import "slices"
+
+// APIVersion is the version of the daemon API that this package implements. It is incremented
+// when calls or fields are added to the API.
+const APIVersion = 1
+
+// The optional features of the daemon API. A daemon lists the features that it supports, and
+// that are configured, in its Capabilities.
+const (
+	// CapabilityPathSubscriptions is the SubscribePaths call.
+	CapabilityPathSubscriptions = "path_subscriptions"
+	// CapabilityPathOrdering is the ordering and the limit of the paths in path requests, see
+	// PathReqFlags.
+	CapabilityPathOrdering = "path_ordering"
+	// CapabilityPathPolicies is the selection of a path policy of the daemon by name in path
+	// requests.
+	CapabilityPathPolicies = "path_policies"
+	// CapabilityPathProbing is the probing of the paths, which annotates them with their
+	// reachability and round trip time.
+	CapabilityPathProbing = "path_probing"
+	// CapabilityRevocationSubscriptions is the SubscribeRevocations call.
+	CapabilityRevocationSubscriptions = "revocation_subscriptions"
+	// CapabilityNameResolution is the ResolveName call.
+	CapabilityNameResolution = "name_resolution"
+	// CapabilityPathOverrides are the PathOverrides and SetPathOverrides calls.
+	CapabilityPathOverrides = "path_overrides"
+	// CapabilityPathErrors is the ReportPathError call, for the errors other than the interface
+	// down errors.
+	CapabilityPathErrors = "path_errors"
+	// CapabilityPathDBExport is the ExportPathDB call.
+	CapabilityPathDBExport = "path_db_export"
+	// CapabilityPathDBImport is the ImportPathDB call.
+	CapabilityPathDBImport = "path_db_import"
+	// CapabilityDRKey are the DRKey calls.
+	CapabilityDRKey = "drkey"
+	// CapabilityMultipleASes is the selection of the local AS of the requests with the
+	// LocalIAMetadataKey metadata.
+	CapabilityMultipleASes = "multiple_ases"
+)
+
+// Capabilities are the version of the API and the optional features that a daemon supports.
+type Capabilities struct {
+	// APIVersion is the version of the daemon API. It is 0 for the daemons that predate the
+	// discovery of the capabilities.
+	APIVersion int
+	// Features are the names of the optional features that the daemon supports, e.g.,
+	// CapabilityDRKey.
+	Features []string
+}
+
+// Has returns whether the daemon supports the optional feature.
+func (c Capabilities) Has(feature string) bool {
+	return slices.Contains(c.Features, feature)
+}
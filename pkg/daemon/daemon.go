@@ -19,6 +19,7 @@ package daemon
 import (
 	"context"
 	"net/netip"
+	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/daemon/internal/metrics"
@@ -26,6 +27,7 @@ import (
 	libmetrics "github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/segment/iface"
 	"github.com/scionproto/scion/pkg/snet"
 )
 
@@ -39,6 +41,9 @@ const (
 	DefaultAPIAddress = "127.0.0.1:30255"
 	// DefaultAPIPort contains the default port for a daemon client API socket.
 	DefaultAPIPort = 30255
+	// LocalIAMetadataKey is the key of the request metadata that selects the local AS, for a
+	// daemon that serves several local ASes.
+	LocalIAMetadataKey = "scion-local-isd-as"
 )
 
 // NewService returns a SCION Daemon API connection factory.
@@ -58,6 +63,54 @@ func NewService(name string) Service {
 	}
 }
 
+// PathSubscription receives the path updates of a subscription.
+type PathSubscription interface {
+	// Recv blocks until the daemon sends the next set of paths. It returns an error once the
+	// subscription has ended.
+	Recv() ([]snet.Path, error)
+}
+
+// RevokedInterface is an interface that the daemon learned to be revoked.
+type RevokedInterface struct {
+	IA addr.IA
+	ID iface.ID
+	// Expiration is the point in time until which the interface is revoked.
+	Expiration time.Time
+}
+
+// Revocations are the revocations that the daemon notifies the subscribers about.
+type Revocations struct {
+	// Interfaces are the revoked interfaces.
+	Interfaces []RevokedInterface
+	// UnreachablePaths are the fingerprints of the paths that were found to be unreachable,
+	// either by a probe of the daemon or because an interface on them was revoked.
+	UnreachablePaths []snet.PathFingerprint
+}
+
+// RevocationSubscription receives the revocations of a subscription.
+type RevocationSubscription interface {
+	// Recv blocks until the daemon sends the next revocations. It returns an error once the
+	// subscription has ended.
+	Recv() (Revocations, error)
+}
+
+// PathOverrides are the overrides that the daemon applies to the paths of all applications.
+type PathOverrides struct {
+	// Blocked are the interfaces whose paths are not returned. An interface with ID 0 blocks
+	// all the interfaces of its AS.
+	Blocked []snet.PathInterface
+	// Pinned are the paths that are returned first for their destination, if they are
+	// available, in the order in which they are listed.
+	Pinned []PinnedPath
+}
+
+// PinnedPath is a path that is preferred for its destination.
+type PinnedPath struct {
+	Destination addr.IA
+	// Interfaces are the interfaces of the path, in path order.
+	Interfaces []snet.PathInterface
+}
+
 // A Connector is used to query the SCION daemon. All connector methods block until
 // either an error occurs, or the method successfully returns.
 type Connector interface {
@@ -71,6 +124,14 @@ type Connector interface {
 	Interfaces(ctx context.Context) (map[uint16]netip.AddrPort, error)
 	// Paths requests from the daemon a set of end to end paths between the source and destination.
 	Paths(ctx context.Context, dst, src addr.IA, f PathReqFlags) ([]snet.Path, error)
+	// SubscribePaths subscribes to the paths between the source and destination. The current
+	// paths are delivered first, and then again whenever they change. The subscription ends when
+	// ctx is canceled.
+	SubscribePaths(ctx context.Context, dst, src addr.IA, f PathReqFlags) (PathSubscription, error)
+	// Capabilities requests from the daemon the version of its API and the optional features
+	// that it supports. The daemons that predate the call report the API version 0 and no
+	// features.
+	Capabilities(ctx context.Context) (Capabilities, error)
 	// ASInfo requests from the daemon information about AS ia, the zero IA can be
 	// used to detect the local IA.
 	ASInfo(ctx context.Context, ia addr.IA) (ASInfo, error)
@@ -80,8 +141,29 @@ type Connector interface {
 	// service types is returned. The reply is a map from service type to a list
 	// of URIs of the service in the local AS.
 	SVCInfo(ctx context.Context, svcTypes []addr.SVC) (map[addr.SVC][]string, error)
+	// ResolveName requests from the daemon the SCION addresses of the host with the given
+	// name, e.g., "server.example.org".
+	ResolveName(ctx context.Context, name string) ([]addr.Addr, error)
 	// RevNotification sends a RevocationInfo message to the daemon.
 	RevNotification(ctx context.Context, revInfo *path_mgmt.RevInfo) error
+	// ReportPathError reports to the daemon an SCMP error that was received for a packet sent
+	// on a path, so that the daemon demotes or drops the path for all applications.
+	ReportPathError(ctx context.Context, pathErr snet.PathError) error
+	// SubscribeRevocations subscribes to the revocations that the daemon learns about. The
+	// subscription ends when ctx is canceled.
+	SubscribeRevocations(ctx context.Context) (RevocationSubscription, error)
+	// PathOverrides requests from the daemon the path overrides that it applies to the paths
+	// of all applications.
+	PathOverrides(ctx context.Context) (PathOverrides, error)
+	// SetPathOverrides replaces the path overrides that the daemon applies to the paths of all
+	// applications.
+	SetPathOverrides(ctx context.Context, overrides PathOverrides) error
+	// ExportPathDB requests from the daemon a snapshot of the path segments in its path
+	// database. The snapshot is a serialized proto.daemon.v1.PathDBSnapshot message.
+	ExportPathDB(ctx context.Context) ([]byte, error)
+	// ImportPathDB inserts the path segments of a snapshot that was returned by ExportPathDB
+	// into the path database of the daemon. The daemon must be configured to allow it.
+	ImportPathDB(ctx context.Context, snapshot []byte) error
 	// DRKeyGetASHostKey requests a AS-Host Key from the daemon.
 	DRKeyGetASHostKey(ctx context.Context, meta drkey.ASHostMeta) (drkey.ASHostKey, error)
 	// DRKeyGetHostASKey requests a Host-AS Key from the daemon.
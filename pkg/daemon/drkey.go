@@ -0,0 +1,231 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/drkey/generic"
+	"github.com/scionproto/scion/pkg/drkey/specific"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// drkeyPruneInterval is the minimum interval between two removals of the expired keys from the
+// DRKey cache.
+const drkeyPruneInterval = time.Minute
+
+// DRKeyCache obtains the level 2 and level 3 DRKeys of the local host from the daemon and caches
+// them for their epoch, so that the protocols that authenticate their packets with DRKey, e.g.,
+// SPAO, do not make a request to the daemon per packet.
+//
+// The key of the epoch that contains the validity time of the metadata is returned. If the
+// validity time is zero, the key of the current epoch is returned. The keys of the previous epoch
+// are kept in the cache after its end, for the packets that were sent shortly before the end of
+// the epoch. The zero value is not usable, the Connector must be set. A DRKeyCache is safe for
+// concurrent use.
+type DRKeyCache struct {
+	// Connector is the connection to the daemon.
+	Connector Connector
+
+	mu        sync.Mutex
+	asHost    keyCache[drkey.ASHostMeta, drkey.ASHostKey]
+	hostAS    keyCache[drkey.HostASMeta, drkey.HostASKey]
+	hostHost  keyCache[drkey.HostHostMeta, drkey.HostHostKey]
+	lastPrune time.Time
+}
+
+// ASHostKey returns the AS-Host key of the metadata.
+func (c *DRKeyCache) ASHostKey(
+	ctx context.Context,
+	meta drkey.ASHostMeta,
+) (drkey.ASHostKey, error) {
+
+	validity := validAt(meta.Validity)
+	meta.Validity = time.Time{}
+	return lookupKey(c, &c.asHost, meta, validity, func() (drkey.ASHostKey, drkey.Epoch, error) {
+		meta.Validity = validity
+		key, err := c.Connector.DRKeyGetASHostKey(ctx, meta)
+		return key, key.Epoch, err
+	})
+}
+
+// HostASKey returns the Host-AS key of the metadata.
+func (c *DRKeyCache) HostASKey(
+	ctx context.Context,
+	meta drkey.HostASMeta,
+) (drkey.HostASKey, error) {
+
+	validity := validAt(meta.Validity)
+	meta.Validity = time.Time{}
+	return lookupKey(c, &c.hostAS, meta, validity, func() (drkey.HostASKey, drkey.Epoch, error) {
+		meta.Validity = validity
+		key, err := c.Connector.DRKeyGetHostASKey(ctx, meta)
+		return key, key.Epoch, err
+	})
+}
+
+// HostHostKey returns the Host-Host key of the metadata. It is obtained from the daemon, which
+// in turn obtains it from the local control service. The destination host uses this method; the
+// source host can derive the key itself with DeriveHostHostKey.
+func (c *DRKeyCache) HostHostKey(
+	ctx context.Context,
+	meta drkey.HostHostMeta,
+) (drkey.HostHostKey, error) {
+
+	validity := validAt(meta.Validity)
+	meta.Validity = time.Time{}
+	return lookupKey(c, &c.hostHost, meta, validity,
+		func() (drkey.HostHostKey, drkey.Epoch, error) {
+			meta.Validity = validity
+			key, err := c.Connector.DRKeyGetHostHostKey(ctx, meta)
+			return key, key.Epoch, err
+		},
+	)
+}
+
+// DeriveHostHostKey derives the Host-Host key of the metadata from the Host-AS key of the
+// source host. The local host must be the source host, as the daemon only returns the Host-AS
+// key to the source host itself. Only the Host-AS key is obtained from the daemon, once per
+// epoch and destination AS, so that a server can derive the keys for many destination hosts
+// without any further request.
+func (c *DRKeyCache) DeriveHostHostKey(
+	ctx context.Context,
+	meta drkey.HostHostMeta,
+) (drkey.HostHostKey, error) {
+
+	hostASKey, err := c.HostASKey(ctx, drkey.HostASMeta{
+		ProtoId:  meta.ProtoId,
+		Validity: meta.Validity,
+		SrcIA:    meta.SrcIA,
+		DstIA:    meta.DstIA,
+		SrcHost:  meta.SrcHost,
+	})
+	if err != nil {
+		return drkey.HostHostKey{}, serrors.Wrap("getting Host-AS key", err)
+	}
+	var deriver interface {
+		DeriveHostHost(dstHost string, key drkey.Key) (drkey.Key, error)
+	} = generic.Deriver{Proto: meta.ProtoId}
+	if meta.ProtoId.IsPredefined() {
+		deriver = specific.Deriver{}
+	}
+	key, err := deriver.DeriveHostHost(meta.DstHost, hostASKey.Key)
+	if err != nil {
+		return drkey.HostHostKey{}, serrors.Wrap("deriving Host-Host key", err)
+	}
+	return drkey.HostHostKey{
+		ProtoId: hostASKey.ProtoId,
+		Epoch:   hostASKey.Epoch,
+		SrcIA:   meta.SrcIA,
+		DstIA:   meta.DstIA,
+		SrcHost: meta.SrcHost,
+		DstHost: meta.DstHost,
+		Key:     key,
+	}, nil
+}
+
+// lookupKey returns the cached key of the metadata for the validity time, or fetches and caches
+// it. The metadata must not contain the validity time.
+func lookupKey[M comparable, K any](
+	c *DRKeyCache,
+	cache *keyCache[M, K],
+	meta M,
+	validity time.Time,
+	fetch func() (K, drkey.Epoch, error),
+) (K, error) {
+
+	c.mu.Lock()
+	key, ok := cache.get(meta, validity)
+	c.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	key, epoch, err := fetch()
+	if err != nil {
+		var zero K
+		return zero, err
+	}
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.lastPrune) >= drkeyPruneInterval {
+		c.asHost.prune(now)
+		c.hostAS.prune(now)
+		c.hostHost.prune(now)
+		c.lastPrune = now
+	}
+	cache.add(meta, epoch, key)
+	return key, nil
+}
+
+func validAt(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+	return t
+}
+
+// keyCache caches the keys of one type by their metadata without the validity time.
+type keyCache[M comparable, K any] struct {
+	keys map[M][]epochKey[K]
+}
+
+type epochKey[K any] struct {
+	epoch drkey.Epoch
+	key   K
+}
+
+func (c *keyCache[M, K]) get(meta M, validity time.Time) (K, bool) {
+	for _, k := range c.keys[meta] {
+		if k.epoch.Contains(validity) {
+			return k.key, true
+		}
+	}
+	var zero K
+	return zero, false
+}
+
+func (c *keyCache[M, K]) add(meta M, epoch drkey.Epoch, key K) {
+	if c.keys == nil {
+		c.keys = make(map[M][]epochKey[K])
+	}
+	for _, k := range c.keys[meta] {
+		if k.epoch == epoch {
+			return
+		}
+	}
+	c.keys[meta] = append(c.keys[meta], epochKey[K]{epoch: epoch, key: key})
+}
+
+// prune removes the keys whose epoch ended more than an epoch duration ago.
+func (c *keyCache[M, K]) prune(now time.Time) {
+	for meta, keys := range c.keys {
+		kept := keys[:0]
+		for _, k := range keys {
+			duration := k.epoch.NotAfter.Sub(k.epoch.NotBefore)
+			if now.Before(k.epoch.NotAfter.Add(duration)) {
+				kept = append(kept, k)
+			}
+		}
+		if len(kept) == 0 {
+			delete(c.keys, meta)
+			continue
+		}
+		c.keys[meta] = kept
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/daemon"
+	"github.com/scionproto/scion/pkg/daemon/mock_daemon"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/drkey/specific"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+func TestDRKeyCache(t *testing.T) {
+	srcIA, dstIA := addr.MustParseIA("1-ff00:0:110"), addr.MustParseIA("1-ff00:0:111")
+	now := time.Now().Truncate(time.Second)
+	current := drkey.Epoch{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)}
+	previous := drkey.Epoch{NotBefore: current.NotBefore.Add(-2 * time.Hour),
+		NotAfter: current.NotBefore}
+	hostASKey := func(meta drkey.HostASMeta) drkey.HostASKey {
+		epoch := current
+		if meta.Validity.Before(current.NotBefore) {
+			epoch = previous
+		}
+		return drkey.HostASKey{
+			ProtoId: meta.ProtoId,
+			Epoch:   epoch,
+			SrcIA:   meta.SrcIA,
+			DstIA:   meta.DstIA,
+			SrcHost: meta.SrcHost,
+			Key:     drkey.Key{byte(epoch.NotBefore.Unix())},
+		}
+	}
+
+	t.Run("caches the keys per epoch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		conn := mock_daemon.NewMockConnector(ctrl)
+		conn.EXPECT().DRKeyGetHostASKey(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, meta drkey.HostASMeta) (drkey.HostASKey, error) {
+				return hostASKey(meta), nil
+			},
+		).Times(2)
+		cache := &daemon.DRKeyCache{Connector: conn}
+		meta := drkey.HostASMeta{
+			ProtoId: drkey.SCMP,
+			SrcIA:   srcIA,
+			DstIA:   dstIA,
+			SrcHost: "10.0.0.1",
+		}
+
+		// The zero validity time is the current epoch.
+		key, err := cache.HostASKey(context.Background(), meta)
+		require.NoError(t, err)
+		assert.Equal(t, current, key.Epoch)
+		meta.Validity = now.Add(30 * time.Minute)
+		key, err = cache.HostASKey(context.Background(), meta)
+		require.NoError(t, err)
+		assert.Equal(t, current, key.Epoch)
+
+		meta.Validity = now.Add(-90 * time.Minute)
+		key, err = cache.HostASKey(context.Background(), meta)
+		require.NoError(t, err)
+		assert.Equal(t, previous, key.Epoch)
+		key, err = cache.HostASKey(context.Background(), meta)
+		require.NoError(t, err)
+		assert.Equal(t, previous, key.Epoch)
+	})
+	t.Run("does not cache errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		conn := mock_daemon.NewMockConnector(ctrl)
+		meta := drkey.ASHostMeta{
+			ProtoId: drkey.SCMP,
+			SrcIA:   srcIA,
+			DstIA:   dstIA,
+			DstHost: "10.0.0.2",
+		}
+		want := drkey.ASHostKey{
+			ProtoId: meta.ProtoId,
+			Epoch:   current,
+			SrcIA:   meta.SrcIA,
+			DstIA:   meta.DstIA,
+			DstHost: meta.DstHost,
+		}
+		gomock.InOrder(
+			conn.EXPECT().DRKeyGetASHostKey(gomock.Any(), gomock.Any()).
+				Return(drkey.ASHostKey{}, serrors.New("test error")),
+			conn.EXPECT().DRKeyGetASHostKey(gomock.Any(), gomock.Any()).Return(want, nil),
+		)
+		cache := &daemon.DRKeyCache{Connector: conn}
+
+		_, err := cache.ASHostKey(context.Background(), meta)
+		assert.Error(t, err)
+		for range 2 {
+			key, err := cache.ASHostKey(context.Background(), meta)
+			require.NoError(t, err)
+			assert.Equal(t, want, key)
+		}
+	})
+	t.Run("derives the Host-Host keys", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		conn := mock_daemon.NewMockConnector(ctrl)
+		conn.EXPECT().DRKeyGetHostASKey(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, meta drkey.HostASMeta) (drkey.HostASKey, error) {
+				return hostASKey(meta), nil
+			},
+		)
+		cache := &daemon.DRKeyCache{Connector: conn}
+
+		for _, dstHost := range []string{"10.0.0.2", "10.0.0.3"} {
+			meta := drkey.HostHostMeta{
+				ProtoId: drkey.SCMP,
+				SrcIA:   srcIA,
+				DstIA:   dstIA,
+				SrcHost: "10.0.0.1",
+				DstHost: dstHost,
+			}
+			key, err := cache.DeriveHostHostKey(context.Background(), meta)
+			require.NoError(t, err)
+
+			want, err := specific.Deriver{}.DeriveHostHost(dstHost,
+				hostASKey(drkey.HostASMeta{Validity: now}).Key)
+			require.NoError(t, err)
+			assert.Equal(t, drkey.HostHostKey{
+				ProtoId: drkey.SCMP,
+				Epoch:   current,
+				SrcIA:   srcIA,
+				DstIA:   dstIA,
+				SrcHost: "10.0.0.1",
+				DstHost: dstHost,
+				Key:     want,
+			}, key)
+		}
+	})
+}
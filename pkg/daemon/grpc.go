@@ -16,12 +16,19 @@ package daemon
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"net/netip"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -40,19 +47,45 @@ import (
 
 // Service exposes the API to connect to a SCION daemon service.
 type Service struct {
-	// Address is the address of the SCION daemon to connect to.
+	// Address is the address of the SCION daemon to connect to. It is either a TCP address,
+	// e.g., "127.0.0.1:30255", or the path of a Unix domain socket prefixed with "unix://",
+	// e.g., "unix:///run/scion/sd.sock". The TCP address of a remote daemon that serves its API
+	// with TLS is prefixed with "tls://", e.g., "tls://sd.example.org:30255".
 	Address string
+	// TLS is the TLS configuration of the connection to a remote daemon, e.g., with the
+	// certificates of a private CA, or with a client certificate. If it is set, the connection
+	// is secured with TLS also if Address is not prefixed with "tls://". If it is nil, the
+	// addresses that are prefixed with "tls://" are verified with the system roots.
+	TLS *tls.Config
+	// Token is sent with each request to authorize the application, if it is not empty. The
+	// daemon requires it for the sensitive calls, e.g., for the DRKeys, if it is configured to
+	// authorize applications by token.
+	Token string
+	// LocalIA selects the local AS of the requests, if it is not zero, for a daemon that serves
+	// several local ASes. Otherwise, the daemon serves the requests for its primary AS.
+	LocalIA addr.IA
 	// Metrics are the metric counters that should be incremented when using the
 	// connector.
 	Metrics Metrics
 }
 
 func (s Service) Connect(ctx context.Context) (Connector, error) {
-	conn, err := grpc.NewClient(s.Address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	target, creds := s.Address, insecure.NewCredentials()
+	if remote, ok := strings.CutPrefix(s.Address, tlsScheme); ok || s.TLS != nil {
+		target, creds = remote, credentials.NewTLS(s.TLS)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
 		libgrpc.UnaryClientInterceptor(),
 		libgrpc.StreamClientInterceptor(),
-	)
+	}
+	if s.Token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials(s.Token)))
+	}
+	if !s.LocalIA.IsZero() {
+		opts = append(opts, localIAInterceptors(s.LocalIA)...)
+	}
+	conn, err := grpc.NewClient(target, opts...)
 	if err != nil {
 		s.Metrics.incConnects(err)
 		return nil, serrors.Wrap("creating client", err)
@@ -61,6 +94,43 @@ func (s Service) Connect(ctx context.Context) (Connector, error) {
 	return grpcConn{conn: conn, metrics: s.Metrics}, nil
 }
 
+// tlsScheme prefixes the addresses of the remote daemons that serve their API with TLS.
+const tlsScheme = "tls://"
+
+// tokenCredentials sends the token in the authorization metadata of each request.
+type tokenCredentials string
+
+func (c tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string,
+	error) {
+
+	return map[string]string{"authorization": "Bearer " + string(c)}, nil
+}
+
+// RequireTransportSecurity returns false, the connections to a local daemon are not secured.
+func (c tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// localIAInterceptors add the local AS to the metadata of each request.
+func localIAInterceptors(ia addr.IA) []grpc.DialOption {
+	withIA := func(ctx context.Context) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, LocalIAMetadataKey, ia.String())
+	}
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any,
+			cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+			return invoker(withIA(ctx), method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc,
+			cc *grpc.ClientConn, method string, streamer grpc.Streamer,
+			opts ...grpc.CallOption) (grpc.ClientStream, error) {
+
+			return streamer(withIA(ctx), desc, cc, method, opts...)
+		}),
+	}
+}
+
 type grpcConn struct {
 	conn    *grpc.ClientConn
 	metrics Metrics
@@ -113,6 +183,10 @@ func (c grpcConn) Paths(ctx context.Context, dst, src addr.IA,
 		DestinationIsdAs: uint64(dst),
 		Hidden:           f.Hidden,
 		Refresh:          f.Refresh,
+		Policy:           f.Policy,
+		InlinePolicy:     f.InlinePolicy,
+		OrderBy:          pathOrderingToPB(f.OrderBy),
+		MaxPaths:         uint32(f.MaxPaths),
 	})
 	if err != nil {
 		c.metrics.incPaths(err)
@@ -123,6 +197,162 @@ func (c grpcConn) Paths(ctx context.Context, dst, src addr.IA,
 	return paths, err
 }
 
+func pathOrderingToPB(order []PathOrdering) []sdpb.PathOrdering {
+	if len(order) == 0 {
+		return nil
+	}
+	pb := make([]sdpb.PathOrdering, 0, len(order))
+	for _, o := range order {
+		// The values of PathOrdering are the ones of the protobuf enum.
+		pb = append(pb, sdpb.PathOrdering(o))
+	}
+	return pb
+}
+
+func (c grpcConn) SubscribePaths(ctx context.Context, dst, src addr.IA,
+	f PathReqFlags) (PathSubscription, error) {
+
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	stream, err := client.SubscribePaths(ctx, &sdpb.SubscribePathsRequest{
+		SourceIsdAs:      uint64(src),
+		DestinationIsdAs: uint64(dst),
+		Hidden:           f.Hidden,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pathSubscription{stream: stream, dst: dst}, nil
+}
+
+type pathSubscription struct {
+	stream sdpb.DaemonService_SubscribePathsClient
+	dst    addr.IA
+}
+
+func (s pathSubscription) Recv() ([]snet.Path, error) {
+	response, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return pathResponseToPaths(response.Paths, s.dst)
+}
+
+func (c grpcConn) SubscribeRevocations(ctx context.Context) (RevocationSubscription, error) {
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	stream, err := client.SubscribeRevocations(ctx, &sdpb.SubscribeRevocationsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return revocationSubscription{stream: stream}, nil
+}
+
+type revocationSubscription struct {
+	stream sdpb.DaemonService_SubscribeRevocationsClient
+}
+
+func (s revocationSubscription) Recv() (Revocations, error) {
+	response, err := s.stream.Recv()
+	if err != nil {
+		return Revocations{}, err
+	}
+	var revocations Revocations
+	for _, intf := range response.Interfaces {
+		revocations.Interfaces = append(revocations.Interfaces, RevokedInterface{
+			IA:         addr.IA(intf.IsdAs),
+			ID:         iface.ID(intf.Id),
+			Expiration: intf.Expiration.AsTime(),
+		})
+	}
+	for _, fp := range response.UnreachablePaths {
+		revocations.UnreachablePaths = append(revocations.UnreachablePaths,
+			snet.PathFingerprint(fp))
+	}
+	return revocations, nil
+}
+
+func (c grpcConn) PathOverrides(ctx context.Context) (PathOverrides, error) {
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.PathOverrides(ctx, &sdpb.PathOverridesRequest{})
+	if err != nil {
+		return PathOverrides{}, err
+	}
+	var overrides PathOverrides
+	for _, b := range response.Overrides.GetBlocked() {
+		overrides.Blocked = append(overrides.Blocked, snet.PathInterface{
+			IA: addr.IA(b.IsdAs),
+			ID: iface.ID(b.Id),
+		})
+	}
+	for _, p := range response.Overrides.GetPinned() {
+		pinned := PinnedPath{Destination: addr.IA(p.DestinationIsdAs)}
+		for _, intf := range p.Interfaces {
+			pinned.Interfaces = append(pinned.Interfaces, snet.PathInterface{
+				IA: addr.IA(intf.IsdAs),
+				ID: iface.ID(intf.Id),
+			})
+		}
+		overrides.Pinned = append(overrides.Pinned, pinned)
+	}
+	return overrides, nil
+}
+
+func (c grpcConn) SetPathOverrides(ctx context.Context, overrides PathOverrides) error {
+	pb := &sdpb.PathOverrides{}
+	for _, b := range overrides.Blocked {
+		pb.Blocked = append(pb.Blocked, &sdpb.BlockedInterface{
+			IsdAs: uint64(b.IA),
+			Id:    uint64(b.ID),
+		})
+	}
+	for _, p := range overrides.Pinned {
+		pinned := &sdpb.PinnedPath{DestinationIsdAs: uint64(p.Destination)}
+		for _, intf := range p.Interfaces {
+			pinned.Interfaces = append(pinned.Interfaces, &sdpb.PathInterface{
+				IsdAs: uint64(intf.IA),
+				Id:    uint64(intf.ID),
+			})
+		}
+		pb.Pinned = append(pb.Pinned, pinned)
+	}
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	_, err := client.SetPathOverrides(ctx, &sdpb.SetPathOverridesRequest{Overrides: pb})
+	return err
+}
+
+func (c grpcConn) ExportPathDB(ctx context.Context) ([]byte, error) {
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.ExportPathDB(ctx, &sdpb.ExportPathDBRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(response.Snapshot)
+}
+
+func (c grpcConn) ImportPathDB(ctx context.Context, snapshot []byte) error {
+	pb := &sdpb.PathDBSnapshot{}
+	if err := proto.Unmarshal(snapshot, pb); err != nil {
+		return serrors.Wrap("parsing snapshot", err)
+	}
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	_, err := client.ImportPathDB(ctx, &sdpb.ImportPathDBRequest{Snapshot: pb})
+	return err
+}
+
+func (c grpcConn) Capabilities(ctx context.Context) (Capabilities, error) {
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.Capabilities(ctx, &sdpb.CapabilitiesRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return Capabilities{}, nil
+		}
+		return Capabilities{}, err
+	}
+	return Capabilities{
+		APIVersion: int(response.ApiVersion),
+		Features:   response.Capabilities,
+	}, nil
+}
+
 func (c grpcConn) ASInfo(ctx context.Context, ia addr.IA) (ASInfo, error) {
 	client := sdpb.NewDaemonServiceClient(c.conn)
 	response, err := client.AS(ctx, &sdpb.ASRequest{IsdAs: uint64(ia)})
@@ -164,6 +394,23 @@ func (c grpcConn) SVCInfo(
 	return result, nil
 }
 
+func (c grpcConn) ResolveName(ctx context.Context, name string) ([]addr.Addr, error) {
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.ResolveName(ctx, &sdpb.ResolveNameRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]addr.Addr, 0, len(response.Addresses))
+	for _, a := range response.Addresses {
+		ip, err := netip.ParseAddr(a.Ip)
+		if err != nil {
+			return nil, serrors.Wrap("parsing host address", err, "ip", a.Ip)
+		}
+		addrs = append(addrs, addr.Addr{IA: addr.IA(a.IsdAs), Host: addr.HostIP(ip)})
+	}
+	return addrs, nil
+}
+
 func (c grpcConn) RevNotification(ctx context.Context, revInfo *path_mgmt.RevInfo) error {
 	client := sdpb.NewDaemonServiceClient(c.conn)
 	_, err := client.NotifyInterfaceDown(ctx, &sdpb.NotifyInterfaceDownRequest{
@@ -175,6 +422,17 @@ func (c grpcConn) RevNotification(ctx context.Context, revInfo *path_mgmt.RevInf
 
 }
 
+func (c grpcConn) ReportPathError(ctx context.Context, pathErr snet.PathError) error {
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	_, err := client.ReportPathError(ctx, &sdpb.ReportPathErrorRequest{
+		Path:     pathErr.Path,
+		ScmpType: uint32(pathErr.TypeCode.Type()),
+		ScmpCode: uint32(pathErr.TypeCode.Code()),
+		IsdAs:    uint64(pathErr.Source),
+	})
+	return err
+}
+
 func (c grpcConn) DRKeyGetASHostKey(ctx context.Context,
 	meta drkey.ASHostMeta) (drkey.ASHostKey, error) {
 
@@ -300,6 +558,13 @@ func convertPath(p *sdpb.Path, dst addr.IA) (path.Path, error) {
 		},
 	}
 
+	if l := p.Liveness; l != nil && l.ProbedAt != nil {
+		res.Meta.Liveness = snet.PathLiveness{
+			Reachable: l.Reachable,
+			RTT:       l.Rtt.AsDuration(),
+			ProbedAt:  l.ProbedAt.AsTime(),
+		}
+	}
 	if p.EpicAuths == nil {
 		return res, nil
 	}
@@ -55,6 +55,21 @@ func (mr *MockConnectorMockRecorder) ASInfo(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ASInfo", reflect.TypeOf((*MockConnector)(nil).ASInfo), arg0, arg1)
 }
 
+// Capabilities mocks base method.
+func (m *MockConnector) Capabilities(arg0 context.Context) (daemon.Capabilities, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capabilities", arg0)
+	ret0, _ := ret[0].(daemon.Capabilities)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capabilities indicates an expected call of Capabilities.
+func (mr *MockConnectorMockRecorder) Capabilities(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capabilities", reflect.TypeOf((*MockConnector)(nil).Capabilities), arg0)
+}
+
 // Close mocks base method.
 func (m *MockConnector) Close() error {
 	m.ctrl.T.Helper()
@@ -114,6 +129,35 @@ func (mr *MockConnectorMockRecorder) DRKeyGetHostHostKey(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DRKeyGetHostHostKey", reflect.TypeOf((*MockConnector)(nil).DRKeyGetHostHostKey), arg0, arg1)
 }
 
+// ExportPathDB mocks base method.
+func (m *MockConnector) ExportPathDB(arg0 context.Context) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPathDB", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportPathDB indicates an expected call of ExportPathDB.
+func (mr *MockConnectorMockRecorder) ExportPathDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPathDB", reflect.TypeOf((*MockConnector)(nil).ExportPathDB), arg0)
+}
+
+// ImportPathDB mocks base method.
+func (m *MockConnector) ImportPathDB(arg0 context.Context, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPathDB", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportPathDB indicates an expected call of ImportPathDB.
+func (mr *MockConnectorMockRecorder) ImportPathDB(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPathDB", reflect.TypeOf((*MockConnector)(nil).ImportPathDB), arg0, arg1)
+}
+
 // Interfaces mocks base method.
 func (m *MockConnector) Interfaces(arg0 context.Context) (map[uint16]netip.AddrPort, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +188,21 @@ func (mr *MockConnectorMockRecorder) LocalIA(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalIA", reflect.TypeOf((*MockConnector)(nil).LocalIA), arg0)
 }
 
+// PathOverrides mocks base method.
+func (m *MockConnector) PathOverrides(arg0 context.Context) (daemon.PathOverrides, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathOverrides", arg0)
+	ret0, _ := ret[0].(daemon.PathOverrides)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PathOverrides indicates an expected call of PathOverrides.
+func (mr *MockConnectorMockRecorder) PathOverrides(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathOverrides", reflect.TypeOf((*MockConnector)(nil).PathOverrides), arg0)
+}
+
 // Paths mocks base method.
 func (m *MockConnector) Paths(arg0 context.Context, arg1, arg2 addr.IA, arg3 daemon.PathReqFlags) ([]snet.Path, error) {
 	m.ctrl.T.Helper()
@@ -175,6 +234,35 @@ func (mr *MockConnectorMockRecorder) PortRange(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PortRange", reflect.TypeOf((*MockConnector)(nil).PortRange), arg0)
 }
 
+// ReportPathError mocks base method.
+func (m *MockConnector) ReportPathError(arg0 context.Context, arg1 snet.PathError) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportPathError", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportPathError indicates an expected call of ReportPathError.
+func (mr *MockConnectorMockRecorder) ReportPathError(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportPathError", reflect.TypeOf((*MockConnector)(nil).ReportPathError), arg0, arg1)
+}
+
+// ResolveName mocks base method.
+func (m *MockConnector) ResolveName(arg0 context.Context, arg1 string) ([]addr.Addr, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveName", arg0, arg1)
+	ret0, _ := ret[0].([]addr.Addr)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveName indicates an expected call of ResolveName.
+func (mr *MockConnectorMockRecorder) ResolveName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveName", reflect.TypeOf((*MockConnector)(nil).ResolveName), arg0, arg1)
+}
+
 // RevNotification mocks base method.
 func (m *MockConnector) RevNotification(arg0 context.Context, arg1 *path_mgmt.RevInfo) error {
 	m.ctrl.T.Helper()
@@ -203,3 +291,47 @@ func (mr *MockConnectorMockRecorder) SVCInfo(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SVCInfo", reflect.TypeOf((*MockConnector)(nil).SVCInfo), arg0, arg1)
 }
+
+// SetPathOverrides mocks base method.
+func (m *MockConnector) SetPathOverrides(arg0 context.Context, arg1 daemon.PathOverrides) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPathOverrides", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPathOverrides indicates an expected call of SetPathOverrides.
+func (mr *MockConnectorMockRecorder) SetPathOverrides(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPathOverrides", reflect.TypeOf((*MockConnector)(nil).SetPathOverrides), arg0, arg1)
+}
+
+// SubscribePaths mocks base method.
+func (m *MockConnector) SubscribePaths(arg0 context.Context, arg1, arg2 addr.IA, arg3 daemon.PathReqFlags) (daemon.PathSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribePaths", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(daemon.PathSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribePaths indicates an expected call of SubscribePaths.
+func (mr *MockConnectorMockRecorder) SubscribePaths(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribePaths", reflect.TypeOf((*MockConnector)(nil).SubscribePaths), arg0, arg1, arg2, arg3)
+}
+
+// SubscribeRevocations mocks base method.
+func (m *MockConnector) SubscribeRevocations(arg0 context.Context) (daemon.RevocationSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeRevocations", arg0)
+	ret0, _ := ret[0].(daemon.RevocationSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeRevocations indicates an expected call of SubscribeRevocations.
+func (mr *MockConnectorMockRecorder) SubscribeRevocations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeRevocations", reflect.TypeOf((*MockConnector)(nil).SubscribeRevocations), arg0)
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fabrid contains methods for the computation and verification of the entries of the
+// experimental FABRID hop-by-hop option, which authenticate the intra-AS policies that the source
+// of a packet requests from the ASes on its path.
+//
+// The entry of an AS is computed with the DRKey AS-host key that the AS derives for the source
+// host, for the protocol DRKeyProtocol. The source obtains the key from its control service, e.g.
+// through the DRKey API of the daemon, and the routers of the AS derive it from the master key of
+// the AS. See doc/protocols/fabrid-option.rst.
+package fabrid
@@ -0,0 +1,135 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabrid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/drkey/generic"
+	"github.com/scionproto/scion/pkg/drkey/specific"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+const (
+	// DRKeyProtocol is the DRKey protocol identifier of the keys of the FABRID option. It is not
+	// a predefined protocol, so the keys are derived with the generic derivation. It is meant for
+	// experimentation and may change.
+	DRKeyProtocol drkey.Protocol = 65000
+	// BufferSize is the size of the buffer for the input and output of the block cipher.
+	BufferSize = 2 * aes.BlockSize
+)
+
+const (
+	policyIDBlock uint8 = 1
+	hvfBlock      uint8 = 2
+)
+
+// Input is the input, other than the policy ID, to the entry of the FABRID option for an AS.
+type Input struct {
+	// Timestamp and PacketID are those of the FABRID option.
+	Timestamp, PacketID uint32
+	// Ingress and Egress are the interfaces through which the packet enters and leaves the AS, in
+	// the direction of travel. 0 is the AS itself, for the source and destination ASes.
+	Ingress, Egress uint16
+}
+
+// SecretValue derives the secret value of the generic DRKey protocol of the epoch that contains
+// t, from the master key of the AS. The epochs of the given duration start at the Unix epoch, as
+// those of the secret values of the control service.
+func SecretValue(masterKey []byte, t time.Time, duration time.Duration) (drkey.SecretValue, error) {
+	d := int64(duration / time.Second)
+	if d <= 0 {
+		return drkey.SecretValue{}, serrors.New("invalid epoch duration", "duration", duration)
+	}
+	begin := uint32(t.Unix() / d * d)
+	return drkey.DeriveSV(drkey.Generic, drkey.NewEpoch(begin, begin+uint32(d)), masterKey)
+}
+
+// DeriveKey derives the AS-host key of the FABRID protocol for the given source host, from the
+// secret value of the generic DRKey protocol of the AS.
+func DeriveKey(sv drkey.Key, srcIA addr.IA, srcHost addr.Host) (drkey.Key, error) {
+	level1, err := specific.Deriver{}.DeriveLevel1(srcIA, sv)
+	if err != nil {
+		return drkey.Key{}, serrors.Wrap("deriving level 1 key", err)
+	}
+	return generic.Deriver{Proto: DRKeyProtocol}.DeriveASHost(srcHost.String(), level1)
+}
+
+// NewCipher returns the block cipher of the given AS-host key, for ComputeHop and VerifyHop.
+func NewCipher(key drkey.Key) (cipher.Block, error) {
+	return aes.NewCipher(key[:])
+}
+
+// ComputeHop computes the entry of the AS whose AS-host key is that of block, which requests the
+// given policy. The buffer is used for the input and output of the block cipher; if it is shorter
+// than BufferSize, a new one is allocated.
+func ComputeHop(block cipher.Block, in Input, policyID uint8, buf []byte) slayers.FabridHop {
+	if len(buf) < BufferSize {
+		buf = make([]byte, BufferSize)
+	}
+	return slayers.FabridHop{
+		Enabled:     true,
+		EncPolicyID: policyID ^ policyIDMask(block, in, buf),
+		HVF:         hvf(block, in, policyID, buf),
+	}
+}
+
+// VerifyHop decrypts the policy ID of the entry of the AS whose AS-host key is that of block, and
+// verifies its hop validation field. It returns the policy ID, and whether the verification
+// succeeded. The buffer is used as in ComputeHop.
+func VerifyHop(block cipher.Block, in Input, hop slayers.FabridHop, buf []byte) (uint8, bool) {
+	if len(buf) < BufferSize {
+		buf = make([]byte, BufferSize)
+	}
+	policyID := hop.EncPolicyID ^ policyIDMask(block, in, buf)
+	ok := subtle.ConstantTimeEq(int32(hvf(block, in, policyID, buf)), int32(hop.HVF)) == 1
+	return policyID, ok
+}
+
+// policyIDMask returns the byte that encrypts the policy ID:
+//
+//	AES_K(0x01 | Timestamp | PacketID | 0...)[0]
+func policyIDMask(block cipher.Block, in Input, buf []byte) uint8 {
+	input, output := buf[:aes.BlockSize], buf[aes.BlockSize:BufferSize]
+	clear(input)
+	input[0] = policyIDBlock
+	binary.BigEndian.PutUint32(input[1:5], in.Timestamp)
+	binary.BigEndian.PutUint32(input[5:9], in.PacketID)
+	block.Encrypt(output, input)
+	return output[0]
+}
+
+// hvf returns the hop validation field, the first 22 bits of:
+//
+//	AES_K(0x02 | Timestamp | PacketID | Ingress | Egress | PolicyID | 0...)
+func hvf(block cipher.Block, in Input, policyID uint8, buf []byte) uint32 {
+	input, output := buf[:aes.BlockSize], buf[aes.BlockSize:BufferSize]
+	clear(input)
+	input[0] = hvfBlock
+	binary.BigEndian.PutUint32(input[1:5], in.Timestamp)
+	binary.BigEndian.PutUint32(input[5:9], in.PacketID)
+	binary.BigEndian.PutUint16(input[9:11], in.Ingress)
+	binary.BigEndian.PutUint16(input[11:13], in.Egress)
+	input[13] = policyID
+	block.Encrypt(output, input)
+	return binary.BigEndian.Uint32(output[0:4]) >> 10
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabrid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/fabrid"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+func TestSecretValue(t *testing.T) {
+	masterKey := []byte("0123456789abcdef")
+	begin := time.Unix(86400*20000, 0)
+
+	sv, err := fabrid.SecretValue(masterKey, begin.Add(time.Hour), 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, begin.UTC(), sv.Epoch.NotBefore.UTC())
+	assert.Equal(t, begin.Add(24*time.Hour).UTC(), sv.Epoch.NotAfter.UTC())
+
+	same, err := fabrid.SecretValue(masterKey, begin.Add(23*time.Hour), 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, sv, same)
+	next, err := fabrid.SecretValue(masterKey, begin.Add(24*time.Hour), 24*time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, sv.Key, next.Key)
+
+	_, err = fabrid.SecretValue(masterKey, begin, time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestDeriveKey(t *testing.T) {
+	sv, err := fabrid.SecretValue([]byte("0123456789abcdef"), time.Now(), 24*time.Hour)
+	require.NoError(t, err)
+	srcIA := addr.MustParseIA("1-ff00:0:111")
+	srcHost := addr.MustParseHost("10.0.0.1")
+
+	key, err := fabrid.DeriveKey(sv.Key, srcIA, srcHost)
+	require.NoError(t, err)
+	other, err := fabrid.DeriveKey(sv.Key, srcIA, addr.MustParseHost("10.0.0.2"))
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+	other, err = fabrid.DeriveKey(sv.Key, addr.MustParseIA("1-ff00:0:112"), srcHost)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestHop(t *testing.T) {
+	block, err := fabrid.NewCipher([16]byte{1, 2, 3})
+	require.NoError(t, err)
+	otherBlock, err := fabrid.NewCipher([16]byte{3, 2, 1})
+	require.NoError(t, err)
+	in := fabrid.Input{Timestamp: 1700000000, PacketID: 42, Ingress: 1, Egress: 2}
+	buf := make([]byte, fabrid.BufferSize)
+
+	hop := fabrid.ComputeHop(block, in, 5, buf)
+	assert.True(t, hop.Enabled)
+	assert.Zero(t, hop.HVF&^slayers.FabridHVFMask)
+	policyID, ok := fabrid.VerifyHop(block, in, hop, buf)
+	assert.True(t, ok)
+	assert.Equal(t, uint8(5), policyID)
+	// Without a buffer, the same entry is computed.
+	assert.Equal(t, hop, fabrid.ComputeHop(block, in, 5, nil))
+
+	t.Run("other key", func(t *testing.T) {
+		_, ok := fabrid.VerifyHop(otherBlock, in, hop, buf)
+		assert.False(t, ok)
+	})
+	t.Run("other interfaces", func(t *testing.T) {
+		other := in
+		other.Egress = 3
+		_, ok := fabrid.VerifyHop(block, other, hop, buf)
+		assert.False(t, ok)
+	})
+	t.Run("other packet", func(t *testing.T) {
+		other := in
+		other.PacketID++
+		_, ok := fabrid.VerifyHop(block, other, hop, buf)
+		assert.False(t, ok)
+	})
+	t.Run("other policy", func(t *testing.T) {
+		tampered := hop
+		tampered.EncPolicyID ^= 1
+		_, ok := fabrid.VerifyHop(block, in, tampered, buf)
+		assert.False(t, ok)
+	})
+}
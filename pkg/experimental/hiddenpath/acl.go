@@ -0,0 +1,259 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// ErrUnknownGroup indicates that the local AS is not a registry of the group.
+var ErrUnknownGroup = serrors.New("unknown group")
+
+// GroupACL lists the ASes that may register and look up the segments of a hidden path group
+// at a registry.
+type GroupACL struct {
+	// Writers contains the ASes that may register segments.
+	Writers map[addr.IA]struct{}
+	// Readers contains the ASes that may look up segments. The owner, the registries and the
+	// writers may always look them up.
+	Readers map[addr.IA]struct{}
+	// Overridden indicates that the ACL was set at runtime instead of being configured in the
+	// group. It is ignored by ACL.Set.
+	Overridden bool
+}
+
+// ACL contains the access control lists of the hidden path groups for which the local AS is a
+// registry. By default, the writers and readers configured in the groups are used. They can be
+// overridden at runtime, and the overrides are persisted in a file, such that they survive a
+// restart. It is safe for concurrent use. A nil ACL uses the writers and readers of the groups.
+type ACL struct {
+	localIA addr.IA
+	path    string
+
+	mu        sync.RWMutex
+	groups    Groups
+	overrides map[GroupID]GroupACL
+}
+
+// NewACL creates the ACL of the groups for which the local AS is a registry. The overrides are
+// loaded from and persisted in the file at the given path. If the path is empty, the
+// overrides are not persisted. Overrides of groups that are no longer configured are dropped.
+func NewACL(localIA addr.IA, groups Groups, path string) (*ACL, error) {
+	a := &ACL{
+		localIA:   localIA,
+		path:      path,
+		groups:    registryGroups(localIA, groups),
+		overrides: make(map[GroupID]GroupACL),
+	}
+	if path == "" {
+		return a, nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, serrors.Wrap("reading ACL file", err, "file", path)
+	}
+	var file aclFile
+	if err := yaml.UnmarshalStrict(raw, &file); err != nil {
+		return nil, serrors.Wrap("parsing ACL file", err, "file", path)
+	}
+	for rawID, info := range file.ACLs {
+		id, err := ParseGroupID(rawID)
+		if err != nil {
+			return nil, serrors.Wrap("parsing group ID", err, "file", path)
+		}
+		acl, err := info.parse()
+		if err != nil {
+			return nil, serrors.Wrap("parsing ACL", err, "file", path, "group_id", id)
+		}
+		if _, ok := a.groups[id]; !ok {
+			log.Info("Ignoring ACL of unknown hidden path group", "group_id", id)
+			continue
+		}
+		a.overrides[id] = acl
+	}
+	return a, nil
+}
+
+// ACLs returns the current ACLs of all groups.
+func (a *ACL) ACLs() map[GroupID]GroupACL {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	acls := make(map[GroupID]GroupACL, len(a.groups))
+	for id, group := range a.groups {
+		acls[id] = a.get(id, group)
+	}
+	return acls
+}
+
+// SetGroups replaces the groups, e.g., after the hidden path configuration was reloaded. The
+// overrides of the groups for which the local AS is no longer a registry are dropped.
+func (a *ACL) SetGroups(groups Groups) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.groups = registryGroups(a.localIA, groups)
+	var dropped bool
+	for id := range a.overrides {
+		if _, ok := a.groups[id]; !ok {
+			log.Info("Dropping ACL of removed hidden path group", "group_id", id)
+			delete(a.overrides, id)
+			dropped = true
+		}
+	}
+	if !dropped {
+		return nil
+	}
+	return a.persist()
+}
+
+// Set overrides the ACL of the group and persists it.
+func (a *ACL) Set(id GroupID, acl GroupACL) error {
+	for _, set := range []map[addr.IA]struct{}{acl.Writers, acl.Readers} {
+		for ia := range set {
+			if ia.IsWildcard() {
+				return serrors.New("wildcard ISD-AS not allowed", "isd_as", ia)
+			}
+		}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.groups[id]; !ok {
+		return serrors.JoinNoStack(ErrUnknownGroup, nil, "group_id", id)
+	}
+	prev, ok := a.overrides[id]
+	a.overrides[id] = GroupACL{
+		Writers:    maps.Clone(acl.Writers),
+		Readers:    maps.Clone(acl.Readers),
+		Overridden: true,
+	}
+	if err := a.persist(); err != nil {
+		if ok {
+			a.overrides[id] = prev
+		} else {
+			delete(a.overrides, id)
+		}
+		return err
+	}
+	return nil
+}
+
+// Reset removes the override of the ACL of the group, such that the configured writers and
+// readers are used again.
+func (a *ACL) Reset(id GroupID) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.groups[id]; !ok {
+		return serrors.JoinNoStack(ErrUnknownGroup, nil, "group_id", id)
+	}
+	prev, ok := a.overrides[id]
+	if !ok {
+		return nil
+	}
+	delete(a.overrides, id)
+	if err := a.persist(); err != nil {
+		a.overrides[id] = prev
+		return err
+	}
+	return nil
+}
+
+// Get returns the ACL of the group with the given ID.
+func (a *ACL) Get(id GroupID, group *Group) GroupACL {
+	if a == nil {
+		return GroupACL{Writers: group.Writers, Readers: group.Readers}
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.get(id, group)
+}
+
+// get returns the ACL of the group. The caller must hold the lock.
+func (a *ACL) get(id GroupID, group *Group) GroupACL {
+	if acl, ok := a.overrides[id]; ok {
+		return acl
+	}
+	return GroupACL{Writers: group.Writers, Readers: group.Readers}
+}
+
+// registryGroups returns the groups for which the local AS is a registry.
+func registryGroups(localIA addr.IA, groups Groups) Groups {
+	result := make(Groups)
+	for id, group := range groups {
+		if isAuthoritative(localIA, group) {
+			result[id] = group
+		}
+	}
+	return result
+}
+
+// persist writes the overrides to the file. The caller must hold the lock.
+func (a *ACL) persist() error {
+	if a.path == "" {
+		return nil
+	}
+	file := aclFile{ACLs: make(map[string]*aclInfo, len(a.overrides))}
+	for id, acl := range a.overrides {
+		file.ACLs[id.String()] = &aclInfo{
+			Writers: iaSetToStrings(acl.Writers),
+			Readers: iaSetToStrings(acl.Readers),
+		}
+	}
+	raw, err := yaml.Marshal(file)
+	if err != nil {
+		return serrors.Wrap("marshaling ACL file", err)
+	}
+	// Write the file atomically, such that it is never loaded partially.
+	tmp := a.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0644); err != nil {
+		return serrors.Wrap("writing ACL file", err, "file", a.path)
+	}
+	if err := os.Rename(tmp, a.path); err != nil {
+		return serrors.Wrap("writing ACL file", err, "file", a.path)
+	}
+	return nil
+}
+
+type aclFile struct {
+	ACLs map[string]*aclInfo `yaml:"acls"`
+}
+
+type aclInfo struct {
+	Writers []string `yaml:"writers"`
+	Readers []string `yaml:"readers"`
+}
+
+func (info *aclInfo) parse() (GroupACL, error) {
+	writers, err := stringsToIASet(info.Writers)
+	if err != nil {
+		return GroupACL{}, serrors.Wrap("parsing writers", err)
+	}
+	readers, err := stringsToIASet(info.Readers)
+	if err != nil {
+		return GroupACL{}, serrors.Wrap("parsing readers", err)
+	}
+	return GroupACL{Writers: writers, Readers: readers, Overridden: true}, nil
+}
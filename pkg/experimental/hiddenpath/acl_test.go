@@ -0,0 +1,103 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+)
+
+func TestACL(t *testing.T) {
+	registry := addr.MustParseIA("1-ff00:0:111")
+	writer := addr.MustParseIA("1-ff00:0:112")
+	newWriter := addr.MustParseIA("1-ff00:0:116")
+	groups, err := hiddenpath.LoadHiddenPathGroups("testdata/groups.yml")
+	require.NoError(t, err)
+	id := mustParseGroupID(t, "ff00:0:110-69b5")
+	other := mustParseGroupID(t, "ff00:0:222-abcd")
+	file := filepath.Join(t.TempDir(), "acl.yml")
+
+	acl, err := hiddenpath.NewACL(registry, groups, file)
+	require.NoError(t, err)
+	// Only the groups of the registry are managed.
+	acls := acl.ACLs()
+	require.Contains(t, acls, id)
+	assert.NotContains(t, acls, other)
+	assert.False(t, acls[id].Overridden)
+	assert.Equal(t, groups[id].Writers, acls[id].Writers)
+
+	err = acl.Set(other, hiddenpath.GroupACL{})
+	assert.ErrorIs(t, err, hiddenpath.ErrUnknownGroup)
+	err = acl.Set(id, hiddenpath.GroupACL{
+		Writers: map[addr.IA]struct{}{addr.MustParseIA("1-0"): {}},
+	})
+	assert.Error(t, err)
+
+	require.NoError(t, acl.Set(id, hiddenpath.GroupACL{
+		Writers: map[addr.IA]struct{}{newWriter: {}},
+	}))
+	got := acl.Get(id, groups[id])
+	assert.True(t, got.Overridden)
+	assert.Contains(t, got.Writers, newWriter)
+	assert.NotContains(t, got.Writers, writer)
+	assert.Empty(t, got.Readers)
+
+	// The override is persisted.
+	reloaded, err := hiddenpath.NewACL(registry, groups, file)
+	require.NoError(t, err)
+	assert.Equal(t, got.Writers, reloaded.Get(id, groups[id]).Writers)
+	assert.Empty(t, reloaded.Get(id, groups[id]).Readers)
+
+	require.NoError(t, reloaded.Reset(id))
+	got = reloaded.Get(id, groups[id])
+	assert.False(t, got.Overridden)
+	assert.Equal(t, groups[id].Writers, got.Writers)
+	reloaded, err = hiddenpath.NewACL(registry, groups, file)
+	require.NoError(t, err)
+	assert.False(t, reloaded.Get(id, groups[id]).Overridden)
+
+	// A nil ACL uses the groups.
+	var nilACL *hiddenpath.ACL
+	assert.Equal(t, groups[id].Writers, nilACL.Get(id, groups[id]).Writers)
+}
+
+func TestACLSetGroups(t *testing.T) {
+	registry := addr.MustParseIA("1-ff00:0:111")
+	groups, err := hiddenpath.LoadHiddenPathGroups("testdata/groups.yml")
+	require.NoError(t, err)
+	id := mustParseGroupID(t, "ff00:0:110-69b5")
+	file := filepath.Join(t.TempDir(), "acl.yml")
+
+	acl, err := hiddenpath.NewACL(registry, groups, file)
+	require.NoError(t, err)
+	require.NoError(t, acl.Set(id, hiddenpath.GroupACL{}))
+
+	// The override of a removed group is dropped and no longer persisted.
+	require.NoError(t, acl.SetGroups(hiddenpath.Groups{}))
+	assert.Empty(t, acl.ACLs())
+	assert.ErrorIs(t, acl.Set(id, hiddenpath.GroupACL{}), hiddenpath.ErrUnknownGroup)
+	reloaded, err := hiddenpath.NewACL(registry, groups, file)
+	require.NoError(t, err)
+	assert.False(t, reloaded.Get(id, groups[id]).Overridden)
+
+	require.NoError(t, acl.SetGroups(groups))
+	assert.Contains(t, acl.ACLs(), id)
+}
@@ -36,11 +36,13 @@ type SegmentRequest struct {
 // AuthoritativeServer serves segments from the database.
 type AuthoritativeServer struct {
 	// Groups is the current set of groups.
-	Groups map[GroupID]*Group
+	Groups GroupProvider
 	// DB is used to read hidden segments.
 	DB Store
 	// LocalIA is the ISD-AS this server is run in.
 	LocalIA addr.IA
+	// ACL decides which ASes may read the groups. If nil, the readers of the groups are used.
+	ACL *ACL
 }
 
 // Segments returns the segments for the request or errors out if there was an
@@ -52,11 +54,11 @@ func (s AuthoritativeServer) Segments(ctx context.Context,
 		return nil, serrors.New("no group IDs provided")
 	}
 	for _, id := range req.GroupIDs {
-		group, ok := s.Groups[id]
+		group, ok := s.Groups.Group(id)
 		if !ok {
 			return nil, serrors.New("request for unknown group", "group_id", id)
 		}
-		if !canRead(req.Peer, group) {
+		if !canRead(req.Peer, group, s.ACL.Get(id, group)) {
 			return nil, serrors.New("not allowed to read group", "group_id", id)
 		}
 		if !isAuthoritative(s.LocalIA, group) {
@@ -70,11 +72,11 @@ func (s AuthoritativeServer) Segments(ctx context.Context,
 	return segs, nil
 }
 
-func canRead(peer addr.IA, group *Group) bool {
+func canRead(peer addr.IA, group *Group, acl GroupACL) bool {
 	owner := group.Owner.Equal(peer)
 	_, registry := group.Registries[peer]
-	_, writer := group.Writers[peer]
-	_, reader := group.Readers[peer]
+	_, writer := acl.Writers[peer]
+	_, reader := acl.Readers[peer]
 	return owner || registry || writer || reader
 }
 
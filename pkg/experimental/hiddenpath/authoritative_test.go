@@ -34,7 +34,7 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 		request   hiddenpath.SegmentRequest
 		local     addr.IA
 		db        func(ctrl *gomock.Controller) hiddenpath.Store
-		groups    func() map[hiddenpath.GroupID]*hiddenpath.Group
+		groups    func() hiddenpath.Groups
 		want      []*seg.Meta
 		assertErr assert.ErrorAssertionFunc
 	}{
@@ -47,8 +47,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 			db: func(ctrl *gomock.Controller) hiddenpath.Store {
 				return nil
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: addr.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: addr.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{addr.MustParseIA("1-ff00:0:13"): {}},
@@ -71,8 +71,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 			db: func(ctrl *gomock.Controller) hiddenpath.Store {
 				return nil
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: addr.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: addr.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{addr.MustParseIA("1-ff00:0:13"): {}},
@@ -95,8 +95,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 			db: func(ctrl *gomock.Controller) hiddenpath.Store {
 				return nil
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: addr.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: addr.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{addr.MustParseIA("1-ff00:0:13"): {}},
@@ -124,8 +124,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 			db: func(ctrl *gomock.Controller) hiddenpath.Store {
 				return nil
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: addr.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: addr.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{addr.MustParseIA("1-ff00:0:13"): {}},
@@ -159,8 +159,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 					}).Return(nil, serrors.New("test error"))
 				return db
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: addr.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: addr.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{addr.MustParseIA("1-ff00:0:13"): {}},
@@ -194,8 +194,8 @@ func TestAuthoritativeServerSegments(t *testing.T) {
 					}).Return([]*seg.Meta{{Type: seg.TypeDown}}, nil)
 				return db
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: addr.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: addr.MustParseAS("ff00:0:110")},
 						Readers:    map[addr.IA]struct{}{addr.MustParseIA("1-ff00:0:13"): {}},
@@ -62,7 +62,7 @@ type BeaconWriter struct {
 	// Pather is used to construct paths to the originator of a beacon.
 	Pather beaconing.Pather
 	// RegistrationPolicy is the hidden path registration policy.
-	RegistrationPolicy RegistrationPolicy
+	RegistrationPolicy PolicyProvider
 	// AddressResolver is used to resolve remote ASes.
 	AddressResolver AddressResolver
 }
@@ -85,7 +85,7 @@ func (w *BeaconWriter) Write(ctx context.Context, segments []beacon.Beacon,
 			metrics.CounterInc(w.InternalErrors)
 			continue
 		}
-		regPolicy, ok := w.RegistrationPolicy[uint64(b.InIfID)]
+		regPolicy, ok := w.RegistrationPolicy.InterfacePolicy(uint64(b.InIfID))
 		if !ok {
 			logger.Info("no HP nor public registration policy for beacon", "interface", b.InIfID)
 			continue
@@ -0,0 +1,126 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Configuration is the hidden path configuration loaded from a location. It is a periodic task
+// that reloads the configuration, such that groups and their members can be added and removed
+// without a restart. It provides the current groups and registration policy, and is safe for
+// concurrent use.
+//
+// Whether the local AS is a writer can not change at runtime, because the beacon writer is
+// selected on startup. Reloaded configurations that change it are rejected.
+type Configuration struct {
+	// ACL is updated with the reloaded groups. It can be nil.
+	ACL *ACL
+
+	location string
+	localIA  addr.IA
+	writer   bool
+	current  atomic.Pointer[loadedConfiguration]
+}
+
+type loadedConfiguration struct {
+	groups Groups
+	policy RegistrationPolicy
+}
+
+// NewConfiguration loads the hidden path configuration from the given location.
+func NewConfiguration(location string, localIA addr.IA) (*Configuration, error) {
+	groups, policy, err := LoadConfiguration(location)
+	if err != nil {
+		return nil, err
+	}
+	c := &Configuration{
+		location: location,
+		localIA:  localIA,
+		writer:   groups.Roles(localIA).Writer,
+	}
+	c.current.Store(&loadedConfiguration{groups: groups, policy: policy})
+	return c, nil
+}
+
+// Groups returns the current groups.
+func (c *Configuration) Groups() Groups {
+	return c.current.Load().groups
+}
+
+// Group returns the group with the given ID, if it exists.
+func (c *Configuration) Group(id GroupID) (*Group, bool) {
+	return c.current.Load().groups.Group(id)
+}
+
+// RegistrationPolicy returns the current registration policy.
+func (c *Configuration) RegistrationPolicy() RegistrationPolicy {
+	return c.current.Load().policy
+}
+
+// InterfacePolicy returns the registration policy of the ingress interface, if it exists.
+func (c *Configuration) InterfacePolicy(ifID uint64) (InterfacePolicy, bool) {
+	return c.current.Load().policy.InterfacePolicy(ifID)
+}
+
+// Reload loads the configuration from its location, and applies it. It returns whether the
+// configuration changed. If the configuration can not be loaded, the current one is kept.
+func (c *Configuration) Reload() (bool, error) {
+	groups, policy, err := LoadConfiguration(c.location)
+	if err != nil {
+		return false, err
+	}
+	if writer := groups.Roles(c.localIA).Writer; writer != c.writer {
+		return false, serrors.New("writer role changed, restart required",
+			"location", c.location, "writer", writer)
+	}
+	prev := c.current.Load()
+	if reflect.DeepEqual(prev.groups, groups) && reflect.DeepEqual(prev.policy, policy) {
+		return false, nil
+	}
+	c.current.Store(&loadedConfiguration{groups: groups, policy: policy})
+	if c.ACL != nil {
+		if err := c.ACL.SetGroups(groups); err != nil {
+			return true, serrors.Wrap("updating ACL", err)
+		}
+	}
+	return true, nil
+}
+
+// Name returns the task name.
+func (c *Configuration) Name() string {
+	return "control_hiddenpath_configuration_reloader"
+}
+
+// Run reloads the configuration.
+func (c *Configuration) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	changed, err := c.Reload()
+	if err != nil {
+		logger.Info("Failed to reload hidden path configuration", "location", c.location,
+			"err", err)
+		return
+	}
+	if changed {
+		logger.Info("Reloaded hidden path configuration", "location", c.location,
+			"groups", len(c.Groups()))
+	}
+}
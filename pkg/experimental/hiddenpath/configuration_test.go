@@ -0,0 +1,98 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+)
+
+func TestConfigurationReload(t *testing.T) {
+	registry := addr.MustParseIA("1-ff00:0:111")
+	newReader := addr.MustParseIA("1-ff00:0:116")
+	id := mustParseGroupID(t, "ff00:0:110-69b5")
+	other := mustParseGroupID(t, "ff00:0:222-abcd")
+	raw, err := os.ReadFile("testdata/registrationpolicy.yml")
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "hp_groups.yml")
+	write := func(t *testing.T, content string) {
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	}
+	write(t, string(raw))
+
+	c, err := hiddenpath.NewConfiguration(file, registry)
+	require.NoError(t, err)
+	c.ACL, err = hiddenpath.NewACL(registry, c.Groups(), filepath.Join(t.TempDir(), "acl.yml"))
+	require.NoError(t, err)
+	_, ok := c.Group(other)
+	assert.True(t, ok)
+	pol, ok := c.InterfacePolicy(2)
+	require.True(t, ok)
+	assert.Len(t, pol.Groups, 2)
+
+	changed, err := c.Reload()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Members are added and groups are removed.
+	updated := strings.Replace(string(raw), "readers:\n    - 1-ff00:0:114",
+		"readers:\n    - 1-ff00:0:114\n    - 1-ff00:0:116", 1)
+	updated = updated[:strings.Index(updated, "  ff00:0:222-abcd:")] +
+		updated[strings.Index(updated, "registration_policy_per_interface:"):]
+	updated = strings.Replace(updated, "  - ff00:0:222-abcd\n", "", 1)
+	write(t, updated)
+	changed, err = c.Reload()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	group, ok := c.Group(id)
+	require.True(t, ok)
+	assert.Contains(t, group.Readers, newReader)
+	_, ok = c.Group(other)
+	assert.False(t, ok)
+	pol, ok = c.InterfacePolicy(2)
+	require.True(t, ok)
+	assert.Len(t, pol.Groups, 1)
+	assert.Contains(t, c.ACL.Get(id, group).Readers, newReader)
+
+	// Invalid configurations are not applied.
+	write(t, "groups: [")
+	_, err = c.Reload()
+	assert.Error(t, err)
+	_, ok = c.Group(id)
+	assert.True(t, ok)
+
+	// Configurations that change the writer role are rejected.
+	write(t, strings.Replace(updated, "    - 1-ff00:0:111\n    - 1-ff00:0:112",
+		"    - 1-ff00:0:112", 1))
+	_, err = c.Reload()
+	assert.Error(t, err)
+	group, ok = c.Group(id)
+	require.True(t, ok)
+	assert.Contains(t, group.Writers, registry)
+
+	// The task keeps the configuration if reloading fails.
+	c.Run(context.Background())
+	_, ok = c.Group(id)
+	assert.True(t, ok)
+}
@@ -46,7 +46,7 @@ type Verifier interface {
 // For each group id of the request, it requests the segments at the the
 // respective autoritative registry.
 type ForwardServer struct {
-	Groups    map[GroupID]*Group
+	Groups    GroupProvider
 	LocalAuth Lookuper
 	LocalIA   addr.IA
 	RPC       RPC
@@ -65,7 +65,7 @@ func (s ForwardServer) Segments(ctx context.Context,
 	}
 	requests := make(map[addr.IA][]GroupID)
 	for _, id := range req.GroupIDs {
-		group, ok := s.Groups[id]
+		group, ok := s.Groups.Group(id)
 		if !ok {
 			return nil, serrors.New("request for unknown group", "group", id)
 		}
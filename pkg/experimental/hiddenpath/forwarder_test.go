@@ -32,7 +32,7 @@ func TestForwardServerSegments(t *testing.T) {
 	local := addr.MustParseIA("1-ff00:0:110")
 	testCases := map[string]struct {
 		request   hiddenpath.SegmentRequest
-		groups    func() map[hiddenpath.GroupID]*hiddenpath.Group
+		groups    func() hiddenpath.Groups
 		local     addr.IA
 		rpc       func(*gomock.Controller) hiddenpath.RPC
 		verifier  func(*gomock.Controller) hiddenpath.Verifier
@@ -81,8 +81,8 @@ func TestForwardServerSegments(t *testing.T) {
 					Times(1)
 				return ret
 			},
-			groups: func() map[hiddenpath.GroupID]*hiddenpath.Group {
-				return map[hiddenpath.GroupID]*hiddenpath.Group{
+			groups: func() hiddenpath.Groups {
+				return hiddenpath.Groups{
 					{OwnerAS: addr.MustParseAS("ff00:0:110")}: {
 						ID:         hiddenpath.GroupID{OwnerAS: addr.MustParseAS("ff00:0:110")},
 						Registries: map[addr.IA]struct{}{addr.MustParseIA("1-ff00:0:110"): {}},
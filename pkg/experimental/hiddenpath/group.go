@@ -138,9 +138,21 @@ func (r Roles) None() bool {
 	return !r.Owner && !r.Registry && !r.Reader && !r.Writer
 }
 
+// GroupProvider provides the hidden path groups.
+type GroupProvider interface {
+	// Group returns the group with the given ID, if it exists.
+	Group(id GroupID) (*Group, bool)
+}
+
 // Groups is a list of hidden path groups.
 type Groups map[GroupID]*Group
 
+// Group returns the group with the given ID, if it exists.
+func (g Groups) Group(id GroupID) (*Group, bool) {
+	group, ok := g[id]
+	return group, ok
+}
+
 // Validate validates all groups in the map.
 func (g Groups) Validate() error {
 	for _, group := range g {
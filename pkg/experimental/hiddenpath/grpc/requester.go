@@ -35,8 +35,11 @@ type Requester struct {
 	// Dialer dials a new gRPC connection.
 	Dialer libgrpc.Dialer
 	// HPGroups is used to fetch hidden segments when the destination IA belongs
-	// to the writers of a group configuration.
+	// to the writers of a group configuration. It is ignored if Groups is set.
 	HPGroups hiddenpath.Groups
+	// Groups returns the current groups, if it is set. It allows the groups to
+	// change at runtime, e.g., with a hiddenpath.GroupsReloader.
+	Groups func() hiddenpath.Groups
 	// RegularLookup is the regular segment lookup.
 	RegularLookup segfetcher.RPC
 }
@@ -87,14 +90,12 @@ func (f *Requester) Segments(ctx context.Context, req segfetcher.Request,
 func (f *Requester) hiddenSegments(ctx context.Context, req segfetcher.Request,
 	server net.Addr) ([]*seg.Meta, error) {
 
-	conn, err := f.Dialer.Dial(ctx, server)
-	if err != nil {
-		return nil, err
+	hpGroups := f.HPGroups
+	if f.Groups != nil {
+		hpGroups = f.Groups()
 	}
-	defer conn.Close()
-
 	groups := []uint64{}
-	for _, g := range f.HPGroups {
+	for _, g := range hpGroups {
 		if _, ok := g.Writers[req.Dst]; ok {
 			groups = append(groups, g.ID.ToUint64())
 		}
@@ -104,6 +105,12 @@ func (f *Requester) hiddenSegments(ctx context.Context, req segfetcher.Request,
 		return nil, nil
 	}
 
+	conn, err := f.Dialer.Dial(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
 	client := hspb.NewHiddenSegmentLookupServiceClient(conn)
 	rep, err := client.HiddenSegments(ctx,
 		&hspb.HiddenSegmentsRequest{
@@ -31,10 +31,22 @@ type InterfacePolicy struct {
 	Groups map[GroupID]*Group
 }
 
+// PolicyProvider provides the registration policies of the ingress interfaces.
+type PolicyProvider interface {
+	// InterfacePolicy returns the registration policy of the ingress interface, if it exists.
+	InterfacePolicy(ifID uint64) (InterfacePolicy, bool)
+}
+
 // RegistrationPolicy describes the policy for registering segments. The map is
 // keyed by ingress interface ID.
 type RegistrationPolicy map[uint64]InterfacePolicy
 
+// InterfacePolicy returns the registration policy of the ingress interface, if it exists.
+func (p RegistrationPolicy) InterfacePolicy(ifID uint64) (InterfacePolicy, bool) {
+	pol, ok := p[ifID]
+	return pol, ok
+}
+
 // Validate validates the registration policy.
 func (p RegistrationPolicy) Validate() error {
 	for ifID, p := range p {
@@ -42,23 +42,26 @@ type Registration struct {
 // RegistryServer handles hidden segment registrations.
 type RegistryServer struct {
 	// Groups is the current set of groups.
-	Groups map[GroupID]*Group
+	Groups GroupProvider
 	// DB is used to write received segments.
 	DB Store
 	// Verifier is used to verify the received segments.
 	Verifier Verifier
 	// LocalIA is the IA this handler is in.
 	LocalIA addr.IA
+	// ACL decides which ASes may write to the groups. If nil, the writers of the groups are
+	// used.
+	ACL *ACL
 }
 
 // Register registers the given registration.
 func (h RegistryServer) Register(ctx context.Context, reg Registration) error {
 	// validate first
-	group, ok := h.Groups[reg.GroupID]
+	group, ok := h.Groups.Group(reg.GroupID)
 	if !ok {
 		return serrors.New("unknown group")
 	}
-	if _, ok := group.Writers[reg.Peer.IA]; !ok {
+	if _, ok := h.ACL.Get(reg.GroupID, group).Writers[reg.Peer.IA]; !ok {
 		return serrors.New("sender not writer in group")
 	}
 	if _, ok := group.Registries[h.LocalIA]; !ok {
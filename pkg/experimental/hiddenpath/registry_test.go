@@ -20,6 +20,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
@@ -32,7 +33,7 @@ import (
 func TestRegistryRegister(t *testing.T) {
 	localIA := addr.MustParseIA("1-ff00:0:114")
 	writer := addr.MustParseIA("2-ff00:0:221")
-	groups := map[hiddenpath.GroupID]*hiddenpath.Group{
+	groups := hiddenpath.Groups{
 		mustParseGroupID(t, "ff00:0:4-5"): {
 			Writers:    map[addr.IA]struct{}{writer: {}},
 			Registries: map[addr.IA]struct{}{localIA: {}},
@@ -50,6 +51,7 @@ func TestRegistryRegister(t *testing.T) {
 		reg       hiddenpath.Registration
 		db        func(*gomock.Controller) hiddenpath.Store
 		verifier  func(*gomock.Controller) hiddenpath.Verifier
+		acl       func(*testing.T) *hiddenpath.ACL
 		assertErr assert.ErrorAssertionFunc
 	}{
 		"unknown group": {
@@ -80,6 +82,56 @@ func TestRegistryRegister(t *testing.T) {
 			},
 			assertErr: assert.Error,
 		},
+		"peer removed from writers by ACL": {
+			reg: hiddenpath.Registration{
+				GroupID:  mustParseGroupID(t, "ff00:0:4-5"),
+				Segments: []*seg.Meta{{Type: seg.TypeDown}},
+				Peer:     &snet.SVCAddr{IA: writer},
+			},
+			db: func(ctrl *gomock.Controller) hiddenpath.Store {
+				return mock_hiddenpath.NewMockStore(ctrl)
+			},
+			verifier: func(ctrl *gomock.Controller) hiddenpath.Verifier {
+				return mock_hiddenpath.NewMockVerifier(ctrl)
+			},
+			acl: func(t *testing.T) *hiddenpath.ACL {
+				acl, err := hiddenpath.NewACL(localIA, groups, "")
+				require.NoError(t, err)
+				require.NoError(t, acl.Set(mustParseGroupID(t, "ff00:0:4-5"),
+					hiddenpath.GroupACL{}))
+				return acl
+			},
+			assertErr: assert.Error,
+		},
+		"peer added to writers by ACL": {
+			reg: hiddenpath.Registration{
+				GroupID:  mustParseGroupID(t, "ff00:0:4-405"),
+				Segments: []*seg.Meta{{Type: seg.TypeDown}},
+				Peer:     &snet.SVCAddr{IA: writer, SVC: addr.SvcCS},
+			},
+			db: func(ctrl *gomock.Controller) hiddenpath.Store {
+				db := mock_hiddenpath.NewMockStore(ctrl)
+				db.EXPECT().Put(gomock.Any(), []*seg.Meta{{Type: seg.TypeDown}},
+					mustParseGroupID(t, "ff00:0:4-405"))
+				return db
+			},
+			verifier: func(ctrl *gomock.Controller) hiddenpath.Verifier {
+				verifier := mock_hiddenpath.NewMockVerifier(ctrl)
+				verifier.EXPECT().Verify(gomock.Any(),
+					[]*seg.Meta{{Type: seg.TypeDown}},
+					&snet.SVCAddr{IA: writer, SVC: addr.SvcCS},
+				)
+				return verifier
+			},
+			acl: func(t *testing.T) *hiddenpath.ACL {
+				acl, err := hiddenpath.NewACL(localIA, groups, "")
+				require.NoError(t, err)
+				require.NoError(t, acl.Set(mustParseGroupID(t, "ff00:0:4-405"),
+					hiddenpath.GroupACL{Writers: map[addr.IA]struct{}{writer: {}}}))
+				return acl
+			},
+			assertErr: assert.NoError,
+		},
 		"local not registry": {
 			reg: hiddenpath.Registration{
 				GroupID:  mustParseGroupID(t, "ff00:0:4-404"),
@@ -186,6 +238,9 @@ func TestRegistryRegister(t *testing.T) {
 				Verifier: tc.verifier(ctrl),
 				LocalIA:  localIA,
 			}
+			if tc.acl != nil {
+				h.ACL = tc.acl(t)
+			}
 			err := h.Register(context.Background(), tc.reg)
 			tc.assertErr(t, err)
 		})
@@ -0,0 +1,108 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// GroupsReloader holds the hidden path groups loaded from a location, see
+// LoadHiddenPathGroups, and reloads them when they change. It is meant to run as a periodic
+// task. A file is only read again if its modification time or size changed, a resource that is
+// fetched over HTTP on every run. If the groups fail to load, the previous groups are kept.
+type GroupsReloader struct {
+	location string
+	groups   atomic.Pointer[Groups]
+
+	// mu serializes the reloads.
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+// NewGroupsReloader loads the groups from the location.
+func NewGroupsReloader(location string) (*GroupsReloader, error) {
+	r := &GroupsReloader{location: location}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Groups returns the current groups.
+func (r *GroupsReloader) Groups() Groups {
+	if groups := r.groups.Load(); groups != nil {
+		return *groups
+	}
+	return nil
+}
+
+// Name returns the task name.
+func (r *GroupsReloader) Name() string {
+	return "hiddenpath_groups_reloader"
+}
+
+// Run reloads the groups if they changed.
+func (r *GroupsReloader) Run(ctx context.Context) {
+	reloaded, err := r.Reload()
+	if err != nil {
+		log.FromCtx(ctx).Info("Failed to reload hidden path groups",
+			"location", r.location, "err", err)
+		return
+	}
+	if reloaded {
+		log.FromCtx(ctx).Info("Reloaded hidden path groups",
+			"location", r.location, "groups", len(r.Groups()))
+	}
+}
+
+// Reload loads the groups again. It returns whether the groups changed.
+func (r *GroupsReloader) Reload() (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remote := strings.HasPrefix(r.location, "http://") ||
+		strings.HasPrefix(r.location, "https://")
+	var info os.FileInfo
+	if !remote && r.location != "" {
+		var err error
+		if info, err = os.Stat(r.location); err != nil {
+			return false, err
+		}
+		if info.ModTime().Equal(r.modTime) && info.Size() == r.size {
+			return false, nil
+		}
+	}
+	groups, err := LoadHiddenPathGroups(r.location)
+	if err != nil {
+		return false, err
+	}
+	if info != nil {
+		r.modTime, r.size = info.ModTime(), info.Size()
+	}
+	if current := r.groups.Load(); current != nil && reflect.DeepEqual(*current, groups) {
+		return false, nil
+	}
+	r.groups.Store(&groups)
+	return true, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hiddenpath_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/experimental/hiddenpath"
+)
+
+func TestGroupsReloader(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "groups.yml")
+	// write writes the file with a distinct modification time for each version.
+	version := 0
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+		version++
+		mtime := time.Unix(int64(version), 0)
+		require.NoError(t, os.Chtimes(file, mtime, mtime))
+	}
+	const oneGroup = `
+groups:
+  ff00:0:110-69b5:
+    owner: 1-ff00:0:110
+    writers:
+    - 1-ff00:0:111
+    registries:
+    - 1-ff00:0:113
+`
+	const twoGroups = oneGroup + `
+  ff00:0:222-abcd:
+    owner: 1-ff00:0:222
+    writers:
+    - 1-ff00:0:112
+    registries:
+    - 1-ff00:0:115
+`
+	write(oneGroup)
+	r, err := hiddenpath.NewGroupsReloader(file)
+	require.NoError(t, err)
+	assert.Len(t, r.Groups(), 1)
+
+	reloaded, err := r.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded, "unchanged file")
+
+	write(twoGroups)
+	reloaded, err = r.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Len(t, r.Groups(), 2)
+
+	// Touching the file without changing the groups does not change them.
+	write(twoGroups)
+	reloaded, err = r.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	// Invalid groups are not loaded, the previous ones are kept.
+	write("groups:\n  garbage:\n    owner: 1-ff00:0:110\n")
+	r.Run(context.Background())
+	_, err = r.Reload()
+	assert.Error(t, err)
+	assert.Len(t, r.Groups(), 2)
+
+	_, err = hiddenpath.NewGroupsReloader(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.Error(t, err)
+}
@@ -136,6 +136,9 @@ type ConnDialer interface {
 type QUICDialer struct {
 	Rewriter AddressRewriter
 	Dialer   ConnDialer
+	// Interceptors are run after the default unary RPC client-side interceptors.
+	// They are optional.
+	Interceptors []grpc.UnaryClientInterceptor
 }
 
 // Dial dials a gRPC connection over QUIC/SCION.
@@ -160,6 +163,7 @@ func (d *QUICDialer) Dial(ctx context.Context, addr net.Addr) (*grpc.ClientConn,
 		grpc.WithContextDialer(dialer),
 		UnaryClientInterceptor(),
 		StreamClientInterceptor(),
+		grpc.WithChainUnaryInterceptor(d.Interceptors...),
 	)
 }
 
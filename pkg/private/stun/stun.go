@@ -0,0 +1,193 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stun implements the STUN binding requests and responses of RFC 8489
+// that are needed to learn the address under which a host behind a NAT is seen
+// by the border routers of its AS. Authentication and the other attributes of
+// STUN are not supported.
+package stun
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"net/netip"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// HeaderLen is the length of the STUN message header.
+	HeaderLen = 20
+
+	magicCookie = 0x2112a442
+
+	bindingRequest = 0x0001
+	bindingSuccess = 0x0101
+
+	attrMappedAddress    = 0x0001
+	attrXORMappedAddress = 0x0020
+
+	familyIPv4 = 0x01
+	familyIPv6 = 0x02
+)
+
+// TxID is the transaction ID of a STUN message.
+type TxID [12]byte
+
+// NewTxID returns a random transaction ID.
+func NewTxID() TxID {
+	var id TxID
+	if _, err := rand.Read(id[:]); err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Is returns whether b looks like a STUN message. SCION packets are never
+// mistaken for STUN messages: the magic cookie would be a payload length that
+// does not match the length of the datagram.
+func Is(b []byte) bool {
+	return len(b) >= HeaderLen &&
+		b[0]&0xc0 == 0 &&
+		binary.BigEndian.Uint32(b[4:]) == magicCookie &&
+		int(binary.BigEndian.Uint16(b[2:]))+HeaderLen == len(b)
+}
+
+// Request returns a binding request with the given transaction ID.
+func Request(txID TxID) []byte {
+	return appendHeader(make([]byte, 0, HeaderLen), bindingRequest, 0, txID)
+}
+
+// ParseBindingRequest returns the transaction ID of the binding request in b.
+func ParseBindingRequest(b []byte) (TxID, error) {
+	if !Is(b) {
+		return TxID{}, serrors.New("not a STUN message")
+	}
+	if typ := binary.BigEndian.Uint16(b); typ != bindingRequest {
+		return TxID{}, serrors.New("not a binding request", "type", typ)
+	}
+	return TxID(b[8:HeaderLen]), nil
+}
+
+// AppendResponse appends to b a binding success response with the given
+// transaction ID that reports addr as the mapped address.
+func AppendResponse(b []byte, txID TxID, addr netip.AddrPort) []byte {
+	ip := addr.Addr().Unmap()
+	family, ipLen := byte(familyIPv4), 4
+	if ip.Is6() {
+		family, ipLen = familyIPv6, 16
+	}
+	b = appendHeader(b, bindingSuccess, 4+4+ipLen, txID)
+	b = binary.BigEndian.AppendUint16(b, attrXORMappedAddress)
+	b = binary.BigEndian.AppendUint16(b, uint16(4+ipLen))
+	b = append(b, 0, family)
+	b = binary.BigEndian.AppendUint16(b, addr.Port()^magicCookie>>16)
+	key := xorKey(txID)
+	for i, v := range ip.AsSlice() {
+		b = append(b, v^key[i])
+	}
+	return b
+}
+
+// ParseResponse returns the transaction ID and the mapped address of the
+// binding success response in b. The XOR-MAPPED-ADDRESS attribute is preferred
+// over the MAPPED-ADDRESS attribute of older servers.
+func ParseResponse(b []byte) (TxID, netip.AddrPort, error) {
+	if !Is(b) {
+		return TxID{}, netip.AddrPort{}, serrors.New("not a STUN message")
+	}
+	if typ := binary.BigEndian.Uint16(b); typ != bindingSuccess {
+		return TxID{}, netip.AddrPort{}, serrors.New("not a binding success response",
+			"type", typ)
+	}
+	txID := TxID(b[8:HeaderLen])
+	var mapped netip.AddrPort
+	for attrs := b[HeaderLen:]; len(attrs) > 0; {
+		if len(attrs) < 4 {
+			return TxID{}, netip.AddrPort{}, serrors.New("truncated attribute")
+		}
+		typ := binary.BigEndian.Uint16(attrs)
+		l := int(binary.BigEndian.Uint16(attrs[2:]))
+		if len(attrs) < 4+l {
+			return TxID{}, netip.AddrPort{}, serrors.New("truncated attribute",
+				"type", typ, "length", l)
+		}
+		value := attrs[4 : 4+l]
+		// Attributes are padded to a multiple of 4 bytes.
+		attrs = attrs[min(len(attrs), 4+(l+3)&^3):]
+		switch typ {
+		case attrXORMappedAddress:
+			addr, err := parseAddress(value, xorKey(txID))
+			if err != nil {
+				return TxID{}, netip.AddrPort{}, err
+			}
+			return txID, addr, nil
+		case attrMappedAddress:
+			addr, err := parseAddress(value, [16]byte{})
+			if err != nil {
+				return TxID{}, netip.AddrPort{}, err
+			}
+			mapped = addr
+		}
+	}
+	if !mapped.IsValid() {
+		return TxID{}, netip.AddrPort{}, serrors.New("no mapped address")
+	}
+	return txID, mapped, nil
+}
+
+func appendHeader(b []byte, typ uint16, length int, txID TxID) []byte {
+	b = binary.BigEndian.AppendUint16(b, typ)
+	b = binary.BigEndian.AppendUint16(b, uint16(length))
+	b = binary.BigEndian.AppendUint32(b, magicCookie)
+	return append(b, txID[:]...)
+}
+
+// xorKey returns the bytes the mapped IP address is XORed with: the magic
+// cookie followed by the transaction ID.
+func xorKey(txID TxID) [16]byte {
+	var key [16]byte
+	binary.BigEndian.PutUint32(key[:], magicCookie)
+	copy(key[4:], txID[:])
+	return key
+}
+
+// parseAddress parses the value of a (XOR-)MAPPED-ADDRESS attribute. For the
+// MAPPED-ADDRESS attribute, key is zero.
+func parseAddress(value []byte, key [16]byte) (netip.AddrPort, error) {
+	if len(value) < 4 {
+		return netip.AddrPort{}, serrors.New("truncated address", "length", len(value))
+	}
+	var ipLen int
+	switch value[1] {
+	case familyIPv4:
+		ipLen = 4
+	case familyIPv6:
+		ipLen = 16
+	default:
+		return netip.AddrPort{}, serrors.New("unknown address family", "family", value[1])
+	}
+	if len(value) != 4+ipLen {
+		return netip.AddrPort{}, serrors.New("invalid address length", "length", len(value))
+	}
+	port := binary.BigEndian.Uint16(value[2:]) ^ binary.BigEndian.Uint16(key[:])
+	var ip [16]byte
+	for i := range ipLen {
+		ip[i] = value[4+i] ^ key[i]
+	}
+	if ipLen == 4 {
+		return netip.AddrPortFrom(netip.AddrFrom4([4]byte(ip[:4])), port), nil
+	}
+	return netip.AddrPortFrom(netip.AddrFrom16(ip), port), nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stun_test
+
+import (
+	"encoding/hex"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/private/stun"
+)
+
+func TestRequest(t *testing.T) {
+	txID := stun.NewTxID()
+	req := stun.Request(txID)
+	assert.True(t, stun.Is(req))
+	parsed, err := stun.ParseBindingRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, txID, parsed)
+
+	_, _, err = stun.ParseResponse(req)
+	assert.Error(t, err)
+}
+
+func TestResponse(t *testing.T) {
+	// The transaction ID and the XOR-MAPPED-ADDRESS attributes of the sample
+	// responses in RFC 5769.
+	txID := stun.TxID(mustDecode(t, "b7e7a701bc34d686fa87dfae"))
+	testCases := map[string]struct {
+		Addr string
+		Attr string
+	}{
+		"IPv4": {
+			Addr: "192.0.2.1:32853",
+			Attr: "00200008" + "0001a147e112a643",
+		},
+		"IPv6": {
+			Addr: "[2001:db8:1234:5678:11:2233:4455:6677]:32853",
+			Attr: "00200014" + "0002a1470113a9faa5d3f179bc25f4b5bed2b9d9",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			addr := netip.MustParseAddrPort(tc.Addr)
+			resp := stun.AppendResponse(nil, txID, addr)
+			assert.True(t, stun.Is(resp))
+			assert.Equal(t, tc.Attr, hex.EncodeToString(resp[stun.HeaderLen:]))
+
+			parsedID, parsed, err := stun.ParseResponse(resp)
+			require.NoError(t, err)
+			assert.Equal(t, txID, parsedID)
+			assert.Equal(t, addr, parsed)
+
+			_, err = stun.ParseBindingRequest(resp)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseResponseMappedAddress(t *testing.T) {
+	// A response with an unknown attribute and the MAPPED-ADDRESS attribute
+	// only, as sent by servers implementing RFC 3489.
+	resp := mustDecode(t, "01010014"+"2112a442"+"b7e7a701bc34d686fa87dfae"+
+		"80220003"+"41424300"+
+		"00010008"+"00018055c0000201")
+	_, addr, err := stun.ParseResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddrPort("192.0.2.1:32853"), addr)
+}
+
+func TestIs(t *testing.T) {
+	req := stun.Request(stun.NewTxID())
+	assert.False(t, stun.Is(req[:stun.HeaderLen-1]))
+	assert.False(t, stun.Is(append(req, 0, 0, 0, 0)))
+	wrongCookie := append([]byte(nil), req...)
+	wrongCookie[4] = 0
+	assert.False(t, stun.Is(wrongCookie))
+}
+
+func mustDecode(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
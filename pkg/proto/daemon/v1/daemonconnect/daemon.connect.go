@@ -57,20 +57,56 @@ const (
 	// DaemonServiceDRKeyHostHostProcedure is the fully-qualified name of the DaemonService's
 	// DRKeyHostHost RPC.
 	DaemonServiceDRKeyHostHostProcedure = "/proto.daemon.v1.DaemonService/DRKeyHostHost"
+	// DaemonServiceSubscribePathsProcedure is the fully-qualified name of the DaemonService's
+	// SubscribePaths RPC.
+	DaemonServiceSubscribePathsProcedure = "/proto.daemon.v1.DaemonService/SubscribePaths"
+	// DaemonServiceResolveNameProcedure is the fully-qualified name of the DaemonService's ResolveName
+	// RPC.
+	DaemonServiceResolveNameProcedure = "/proto.daemon.v1.DaemonService/ResolveName"
+	// DaemonServiceSubscribeRevocationsProcedure is the fully-qualified name of the DaemonService's
+	// SubscribeRevocations RPC.
+	DaemonServiceSubscribeRevocationsProcedure = "/proto.daemon.v1.DaemonService/SubscribeRevocations"
+	// DaemonServicePathOverridesProcedure is the fully-qualified name of the DaemonService's
+	// PathOverrides RPC.
+	DaemonServicePathOverridesProcedure = "/proto.daemon.v1.DaemonService/PathOverrides"
+	// DaemonServiceSetPathOverridesProcedure is the fully-qualified name of the DaemonService's
+	// SetPathOverrides RPC.
+	DaemonServiceSetPathOverridesProcedure = "/proto.daemon.v1.DaemonService/SetPathOverrides"
+	// DaemonServiceExportPathDBProcedure is the fully-qualified name of the DaemonService's
+	// ExportPathDB RPC.
+	DaemonServiceExportPathDBProcedure = "/proto.daemon.v1.DaemonService/ExportPathDB"
+	// DaemonServiceImportPathDBProcedure is the fully-qualified name of the DaemonService's
+	// ImportPathDB RPC.
+	DaemonServiceImportPathDBProcedure = "/proto.daemon.v1.DaemonService/ImportPathDB"
+	// DaemonServiceReportPathErrorProcedure is the fully-qualified name of the DaemonService's
+	// ReportPathError RPC.
+	DaemonServiceReportPathErrorProcedure = "/proto.daemon.v1.DaemonService/ReportPathError"
+	// DaemonServiceCapabilitiesProcedure is the fully-qualified name of the DaemonService's
+	// Capabilities RPC.
+	DaemonServiceCapabilitiesProcedure = "/proto.daemon.v1.DaemonService/Capabilities"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	daemonServiceServiceDescriptor                   = daemon.File_proto_daemon_v1_daemon_proto.Services().ByName("DaemonService")
-	daemonServicePathsMethodDescriptor               = daemonServiceServiceDescriptor.Methods().ByName("Paths")
-	daemonServiceASMethodDescriptor                  = daemonServiceServiceDescriptor.Methods().ByName("AS")
-	daemonServiceInterfacesMethodDescriptor          = daemonServiceServiceDescriptor.Methods().ByName("Interfaces")
-	daemonServiceServicesMethodDescriptor            = daemonServiceServiceDescriptor.Methods().ByName("Services")
-	daemonServiceNotifyInterfaceDownMethodDescriptor = daemonServiceServiceDescriptor.Methods().ByName("NotifyInterfaceDown")
-	daemonServicePortRangeMethodDescriptor           = daemonServiceServiceDescriptor.Methods().ByName("PortRange")
-	daemonServiceDRKeyASHostMethodDescriptor         = daemonServiceServiceDescriptor.Methods().ByName("DRKeyASHost")
-	daemonServiceDRKeyHostASMethodDescriptor         = daemonServiceServiceDescriptor.Methods().ByName("DRKeyHostAS")
-	daemonServiceDRKeyHostHostMethodDescriptor       = daemonServiceServiceDescriptor.Methods().ByName("DRKeyHostHost")
+	daemonServiceServiceDescriptor                    = daemon.File_proto_daemon_v1_daemon_proto.Services().ByName("DaemonService")
+	daemonServicePathsMethodDescriptor                = daemonServiceServiceDescriptor.Methods().ByName("Paths")
+	daemonServiceASMethodDescriptor                   = daemonServiceServiceDescriptor.Methods().ByName("AS")
+	daemonServiceInterfacesMethodDescriptor           = daemonServiceServiceDescriptor.Methods().ByName("Interfaces")
+	daemonServiceServicesMethodDescriptor             = daemonServiceServiceDescriptor.Methods().ByName("Services")
+	daemonServiceNotifyInterfaceDownMethodDescriptor  = daemonServiceServiceDescriptor.Methods().ByName("NotifyInterfaceDown")
+	daemonServicePortRangeMethodDescriptor            = daemonServiceServiceDescriptor.Methods().ByName("PortRange")
+	daemonServiceDRKeyASHostMethodDescriptor          = daemonServiceServiceDescriptor.Methods().ByName("DRKeyASHost")
+	daemonServiceDRKeyHostASMethodDescriptor          = daemonServiceServiceDescriptor.Methods().ByName("DRKeyHostAS")
+	daemonServiceDRKeyHostHostMethodDescriptor        = daemonServiceServiceDescriptor.Methods().ByName("DRKeyHostHost")
+	daemonServiceSubscribePathsMethodDescriptor       = daemonServiceServiceDescriptor.Methods().ByName("SubscribePaths")
+	daemonServiceResolveNameMethodDescriptor          = daemonServiceServiceDescriptor.Methods().ByName("ResolveName")
+	daemonServiceSubscribeRevocationsMethodDescriptor = daemonServiceServiceDescriptor.Methods().ByName("SubscribeRevocations")
+	daemonServicePathOverridesMethodDescriptor        = daemonServiceServiceDescriptor.Methods().ByName("PathOverrides")
+	daemonServiceSetPathOverridesMethodDescriptor     = daemonServiceServiceDescriptor.Methods().ByName("SetPathOverrides")
+	daemonServiceExportPathDBMethodDescriptor         = daemonServiceServiceDescriptor.Methods().ByName("ExportPathDB")
+	daemonServiceImportPathDBMethodDescriptor         = daemonServiceServiceDescriptor.Methods().ByName("ImportPathDB")
+	daemonServiceReportPathErrorMethodDescriptor      = daemonServiceServiceDescriptor.Methods().ByName("ReportPathError")
+	daemonServiceCapabilitiesMethodDescriptor         = daemonServiceServiceDescriptor.Methods().ByName("Capabilities")
 )
 
 // DaemonServiceClient is a client for the proto.daemon.v1.DaemonService service.
@@ -84,6 +120,15 @@ type DaemonServiceClient interface {
 	DRKeyASHost(context.Context, *connect.Request[daemon.DRKeyASHostRequest]) (*connect.Response[daemon.DRKeyASHostResponse], error)
 	DRKeyHostAS(context.Context, *connect.Request[daemon.DRKeyHostASRequest]) (*connect.Response[daemon.DRKeyHostASResponse], error)
 	DRKeyHostHost(context.Context, *connect.Request[daemon.DRKeyHostHostRequest]) (*connect.Response[daemon.DRKeyHostHostResponse], error)
+	SubscribePaths(context.Context, *connect.Request[daemon.SubscribePathsRequest]) (*connect.ServerStreamForClient[daemon.SubscribePathsResponse], error)
+	ResolveName(context.Context, *connect.Request[daemon.ResolveNameRequest]) (*connect.Response[daemon.ResolveNameResponse], error)
+	SubscribeRevocations(context.Context, *connect.Request[daemon.SubscribeRevocationsRequest]) (*connect.ServerStreamForClient[daemon.SubscribeRevocationsResponse], error)
+	PathOverrides(context.Context, *connect.Request[daemon.PathOverridesRequest]) (*connect.Response[daemon.PathOverridesResponse], error)
+	SetPathOverrides(context.Context, *connect.Request[daemon.SetPathOverridesRequest]) (*connect.Response[daemon.SetPathOverridesResponse], error)
+	ExportPathDB(context.Context, *connect.Request[daemon.ExportPathDBRequest]) (*connect.Response[daemon.ExportPathDBResponse], error)
+	ImportPathDB(context.Context, *connect.Request[daemon.ImportPathDBRequest]) (*connect.Response[daemon.ImportPathDBResponse], error)
+	ReportPathError(context.Context, *connect.Request[daemon.ReportPathErrorRequest]) (*connect.Response[daemon.ReportPathErrorResponse], error)
+	Capabilities(context.Context, *connect.Request[daemon.CapabilitiesRequest]) (*connect.Response[daemon.CapabilitiesResponse], error)
 }
 
 // NewDaemonServiceClient constructs a client for the proto.daemon.v1.DaemonService service. By
@@ -150,20 +195,83 @@ func NewDaemonServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(daemonServiceDRKeyHostHostMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		subscribePaths: connect.NewClient[daemon.SubscribePathsRequest, daemon.SubscribePathsResponse](
+			httpClient,
+			baseURL+DaemonServiceSubscribePathsProcedure,
+			connect.WithSchema(daemonServiceSubscribePathsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		resolveName: connect.NewClient[daemon.ResolveNameRequest, daemon.ResolveNameResponse](
+			httpClient,
+			baseURL+DaemonServiceResolveNameProcedure,
+			connect.WithSchema(daemonServiceResolveNameMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		subscribeRevocations: connect.NewClient[daemon.SubscribeRevocationsRequest, daemon.SubscribeRevocationsResponse](
+			httpClient,
+			baseURL+DaemonServiceSubscribeRevocationsProcedure,
+			connect.WithSchema(daemonServiceSubscribeRevocationsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		pathOverrides: connect.NewClient[daemon.PathOverridesRequest, daemon.PathOverridesResponse](
+			httpClient,
+			baseURL+DaemonServicePathOverridesProcedure,
+			connect.WithSchema(daemonServicePathOverridesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		setPathOverrides: connect.NewClient[daemon.SetPathOverridesRequest, daemon.SetPathOverridesResponse](
+			httpClient,
+			baseURL+DaemonServiceSetPathOverridesProcedure,
+			connect.WithSchema(daemonServiceSetPathOverridesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		exportPathDB: connect.NewClient[daemon.ExportPathDBRequest, daemon.ExportPathDBResponse](
+			httpClient,
+			baseURL+DaemonServiceExportPathDBProcedure,
+			connect.WithSchema(daemonServiceExportPathDBMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		importPathDB: connect.NewClient[daemon.ImportPathDBRequest, daemon.ImportPathDBResponse](
+			httpClient,
+			baseURL+DaemonServiceImportPathDBProcedure,
+			connect.WithSchema(daemonServiceImportPathDBMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		reportPathError: connect.NewClient[daemon.ReportPathErrorRequest, daemon.ReportPathErrorResponse](
+			httpClient,
+			baseURL+DaemonServiceReportPathErrorProcedure,
+			connect.WithSchema(daemonServiceReportPathErrorMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		capabilities: connect.NewClient[daemon.CapabilitiesRequest, daemon.CapabilitiesResponse](
+			httpClient,
+			baseURL+DaemonServiceCapabilitiesProcedure,
+			connect.WithSchema(daemonServiceCapabilitiesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // daemonServiceClient implements DaemonServiceClient.
 type daemonServiceClient struct {
-	paths               *connect.Client[daemon.PathsRequest, daemon.PathsResponse]
-	aS                  *connect.Client[daemon.ASRequest, daemon.ASResponse]
-	interfaces          *connect.Client[daemon.InterfacesRequest, daemon.InterfacesResponse]
-	services            *connect.Client[daemon.ServicesRequest, daemon.ServicesResponse]
-	notifyInterfaceDown *connect.Client[daemon.NotifyInterfaceDownRequest, daemon.NotifyInterfaceDownResponse]
-	portRange           *connect.Client[emptypb.Empty, daemon.PortRangeResponse]
-	dRKeyASHost         *connect.Client[daemon.DRKeyASHostRequest, daemon.DRKeyASHostResponse]
-	dRKeyHostAS         *connect.Client[daemon.DRKeyHostASRequest, daemon.DRKeyHostASResponse]
-	dRKeyHostHost       *connect.Client[daemon.DRKeyHostHostRequest, daemon.DRKeyHostHostResponse]
+	paths                *connect.Client[daemon.PathsRequest, daemon.PathsResponse]
+	aS                   *connect.Client[daemon.ASRequest, daemon.ASResponse]
+	interfaces           *connect.Client[daemon.InterfacesRequest, daemon.InterfacesResponse]
+	services             *connect.Client[daemon.ServicesRequest, daemon.ServicesResponse]
+	notifyInterfaceDown  *connect.Client[daemon.NotifyInterfaceDownRequest, daemon.NotifyInterfaceDownResponse]
+	portRange            *connect.Client[emptypb.Empty, daemon.PortRangeResponse]
+	dRKeyASHost          *connect.Client[daemon.DRKeyASHostRequest, daemon.DRKeyASHostResponse]
+	dRKeyHostAS          *connect.Client[daemon.DRKeyHostASRequest, daemon.DRKeyHostASResponse]
+	dRKeyHostHost        *connect.Client[daemon.DRKeyHostHostRequest, daemon.DRKeyHostHostResponse]
+	subscribePaths       *connect.Client[daemon.SubscribePathsRequest, daemon.SubscribePathsResponse]
+	resolveName          *connect.Client[daemon.ResolveNameRequest, daemon.ResolveNameResponse]
+	subscribeRevocations *connect.Client[daemon.SubscribeRevocationsRequest, daemon.SubscribeRevocationsResponse]
+	pathOverrides        *connect.Client[daemon.PathOverridesRequest, daemon.PathOverridesResponse]
+	setPathOverrides     *connect.Client[daemon.SetPathOverridesRequest, daemon.SetPathOverridesResponse]
+	exportPathDB         *connect.Client[daemon.ExportPathDBRequest, daemon.ExportPathDBResponse]
+	importPathDB         *connect.Client[daemon.ImportPathDBRequest, daemon.ImportPathDBResponse]
+	reportPathError      *connect.Client[daemon.ReportPathErrorRequest, daemon.ReportPathErrorResponse]
+	capabilities         *connect.Client[daemon.CapabilitiesRequest, daemon.CapabilitiesResponse]
 }
 
 // Paths calls proto.daemon.v1.DaemonService.Paths.
@@ -211,6 +319,51 @@ func (c *daemonServiceClient) DRKeyHostHost(ctx context.Context, req *connect.Re
 	return c.dRKeyHostHost.CallUnary(ctx, req)
 }
 
+// SubscribePaths calls proto.daemon.v1.DaemonService.SubscribePaths.
+func (c *daemonServiceClient) SubscribePaths(ctx context.Context, req *connect.Request[daemon.SubscribePathsRequest]) (*connect.ServerStreamForClient[daemon.SubscribePathsResponse], error) {
+	return c.subscribePaths.CallServerStream(ctx, req)
+}
+
+// ResolveName calls proto.daemon.v1.DaemonService.ResolveName.
+func (c *daemonServiceClient) ResolveName(ctx context.Context, req *connect.Request[daemon.ResolveNameRequest]) (*connect.Response[daemon.ResolveNameResponse], error) {
+	return c.resolveName.CallUnary(ctx, req)
+}
+
+// SubscribeRevocations calls proto.daemon.v1.DaemonService.SubscribeRevocations.
+func (c *daemonServiceClient) SubscribeRevocations(ctx context.Context, req *connect.Request[daemon.SubscribeRevocationsRequest]) (*connect.ServerStreamForClient[daemon.SubscribeRevocationsResponse], error) {
+	return c.subscribeRevocations.CallServerStream(ctx, req)
+}
+
+// PathOverrides calls proto.daemon.v1.DaemonService.PathOverrides.
+func (c *daemonServiceClient) PathOverrides(ctx context.Context, req *connect.Request[daemon.PathOverridesRequest]) (*connect.Response[daemon.PathOverridesResponse], error) {
+	return c.pathOverrides.CallUnary(ctx, req)
+}
+
+// SetPathOverrides calls proto.daemon.v1.DaemonService.SetPathOverrides.
+func (c *daemonServiceClient) SetPathOverrides(ctx context.Context, req *connect.Request[daemon.SetPathOverridesRequest]) (*connect.Response[daemon.SetPathOverridesResponse], error) {
+	return c.setPathOverrides.CallUnary(ctx, req)
+}
+
+// ExportPathDB calls proto.daemon.v1.DaemonService.ExportPathDB.
+func (c *daemonServiceClient) ExportPathDB(ctx context.Context, req *connect.Request[daemon.ExportPathDBRequest]) (*connect.Response[daemon.ExportPathDBResponse], error) {
+	return c.exportPathDB.CallUnary(ctx, req)
+}
+
+// ImportPathDB calls proto.daemon.v1.DaemonService.ImportPathDB.
+func (c *daemonServiceClient) ImportPathDB(ctx context.Context, req *connect.Request[daemon.ImportPathDBRequest]) (*connect.Response[daemon.ImportPathDBResponse], error) {
+	return c.importPathDB.CallUnary(ctx, req)
+}
+
+// ReportPathError calls proto.daemon.v1.DaemonService.ReportPathError.
+func (c *daemonServiceClient) ReportPathError(ctx context.Context, req *connect.Request[daemon.ReportPathErrorRequest]) (*connect.Response[daemon.ReportPathErrorResponse], error) {
+	return c.reportPathError.CallUnary(ctx, req)
+}
+
+// Capabilities calls proto.daemon.v1.DaemonService.Capabilities.
+func (c *daemonServiceClient) Capabilities(ctx context.Context, req *connect.Request[daemon.CapabilitiesRequest]) (*connect.Response[daemon.CapabilitiesResponse], error) {
+	return c.capabilities.CallUnary(ctx, req)
+}
+
 // DaemonServiceHandler is an implementation of the proto.daemon.v1.DaemonService service.
 type DaemonServiceHandler interface {
 	Paths(context.Context, *connect.Request[daemon.PathsRequest]) (*connect.Response[daemon.PathsResponse], error)
@@ -222,6 +375,15 @@ type DaemonServiceHandler interface {
 	DRKeyASHost(context.Context, *connect.Request[daemon.DRKeyASHostRequest]) (*connect.Response[daemon.DRKeyASHostResponse], error)
 	DRKeyHostAS(context.Context, *connect.Request[daemon.DRKeyHostASRequest]) (*connect.Response[daemon.DRKeyHostASResponse], error)
 	DRKeyHostHost(context.Context, *connect.Request[daemon.DRKeyHostHostRequest]) (*connect.Response[daemon.DRKeyHostHostResponse], error)
+	SubscribePaths(context.Context, *connect.Request[daemon.SubscribePathsRequest], *connect.ServerStream[daemon.SubscribePathsResponse]) error
+	ResolveName(context.Context, *connect.Request[daemon.ResolveNameRequest]) (*connect.Response[daemon.ResolveNameResponse], error)
+	SubscribeRevocations(context.Context, *connect.Request[daemon.SubscribeRevocationsRequest], *connect.ServerStream[daemon.SubscribeRevocationsResponse]) error
+	PathOverrides(context.Context, *connect.Request[daemon.PathOverridesRequest]) (*connect.Response[daemon.PathOverridesResponse], error)
+	SetPathOverrides(context.Context, *connect.Request[daemon.SetPathOverridesRequest]) (*connect.Response[daemon.SetPathOverridesResponse], error)
+	ExportPathDB(context.Context, *connect.Request[daemon.ExportPathDBRequest]) (*connect.Response[daemon.ExportPathDBResponse], error)
+	ImportPathDB(context.Context, *connect.Request[daemon.ImportPathDBRequest]) (*connect.Response[daemon.ImportPathDBResponse], error)
+	ReportPathError(context.Context, *connect.Request[daemon.ReportPathErrorRequest]) (*connect.Response[daemon.ReportPathErrorResponse], error)
+	Capabilities(context.Context, *connect.Request[daemon.CapabilitiesRequest]) (*connect.Response[daemon.CapabilitiesResponse], error)
 }
 
 // NewDaemonServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -284,6 +446,60 @@ func NewDaemonServiceHandler(svc DaemonServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(daemonServiceDRKeyHostHostMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	daemonServiceSubscribePathsHandler := connect.NewServerStreamHandler(
+		DaemonServiceSubscribePathsProcedure,
+		svc.SubscribePaths,
+		connect.WithSchema(daemonServiceSubscribePathsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceResolveNameHandler := connect.NewUnaryHandler(
+		DaemonServiceResolveNameProcedure,
+		svc.ResolveName,
+		connect.WithSchema(daemonServiceResolveNameMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceSubscribeRevocationsHandler := connect.NewServerStreamHandler(
+		DaemonServiceSubscribeRevocationsProcedure,
+		svc.SubscribeRevocations,
+		connect.WithSchema(daemonServiceSubscribeRevocationsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServicePathOverridesHandler := connect.NewUnaryHandler(
+		DaemonServicePathOverridesProcedure,
+		svc.PathOverrides,
+		connect.WithSchema(daemonServicePathOverridesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceSetPathOverridesHandler := connect.NewUnaryHandler(
+		DaemonServiceSetPathOverridesProcedure,
+		svc.SetPathOverrides,
+		connect.WithSchema(daemonServiceSetPathOverridesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceExportPathDBHandler := connect.NewUnaryHandler(
+		DaemonServiceExportPathDBProcedure,
+		svc.ExportPathDB,
+		connect.WithSchema(daemonServiceExportPathDBMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceImportPathDBHandler := connect.NewUnaryHandler(
+		DaemonServiceImportPathDBProcedure,
+		svc.ImportPathDB,
+		connect.WithSchema(daemonServiceImportPathDBMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceReportPathErrorHandler := connect.NewUnaryHandler(
+		DaemonServiceReportPathErrorProcedure,
+		svc.ReportPathError,
+		connect.WithSchema(daemonServiceReportPathErrorMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	daemonServiceCapabilitiesHandler := connect.NewUnaryHandler(
+		DaemonServiceCapabilitiesProcedure,
+		svc.Capabilities,
+		connect.WithSchema(daemonServiceCapabilitiesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/proto.daemon.v1.DaemonService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case DaemonServicePathsProcedure:
@@ -304,6 +520,24 @@ func NewDaemonServiceHandler(svc DaemonServiceHandler, opts ...connect.HandlerOp
 			daemonServiceDRKeyHostASHandler.ServeHTTP(w, r)
 		case DaemonServiceDRKeyHostHostProcedure:
 			daemonServiceDRKeyHostHostHandler.ServeHTTP(w, r)
+		case DaemonServiceSubscribePathsProcedure:
+			daemonServiceSubscribePathsHandler.ServeHTTP(w, r)
+		case DaemonServiceResolveNameProcedure:
+			daemonServiceResolveNameHandler.ServeHTTP(w, r)
+		case DaemonServiceSubscribeRevocationsProcedure:
+			daemonServiceSubscribeRevocationsHandler.ServeHTTP(w, r)
+		case DaemonServicePathOverridesProcedure:
+			daemonServicePathOverridesHandler.ServeHTTP(w, r)
+		case DaemonServiceSetPathOverridesProcedure:
+			daemonServiceSetPathOverridesHandler.ServeHTTP(w, r)
+		case DaemonServiceExportPathDBProcedure:
+			daemonServiceExportPathDBHandler.ServeHTTP(w, r)
+		case DaemonServiceImportPathDBProcedure:
+			daemonServiceImportPathDBHandler.ServeHTTP(w, r)
+		case DaemonServiceReportPathErrorProcedure:
+			daemonServiceReportPathErrorHandler.ServeHTTP(w, r)
+		case DaemonServiceCapabilitiesProcedure:
+			daemonServiceCapabilitiesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -348,3 +582,39 @@ func (UnimplementedDaemonServiceHandler) DRKeyHostAS(context.Context, *connect.R
 func (UnimplementedDaemonServiceHandler) DRKeyHostHost(context.Context, *connect.Request[daemon.DRKeyHostHostRequest]) (*connect.Response[daemon.DRKeyHostHostResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.daemon.v1.DaemonService.DRKeyHostHost is not implemented"))
 }
+
+func (UnimplementedDaemonServiceHandler) SubscribePaths(context.Context, *connect.Request[daemon.SubscribePathsRequest], *connect.ServerStream[daemon.SubscribePathsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("proto.daemon.v1.DaemonService.SubscribePaths is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) ResolveName(context.Context, *connect.Request[daemon.ResolveNameRequest]) (*connect.Response[daemon.ResolveNameResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.daemon.v1.DaemonService.ResolveName is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) SubscribeRevocations(context.Context, *connect.Request[daemon.SubscribeRevocationsRequest], *connect.ServerStream[daemon.SubscribeRevocationsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("proto.daemon.v1.DaemonService.SubscribeRevocations is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) PathOverrides(context.Context, *connect.Request[daemon.PathOverridesRequest]) (*connect.Response[daemon.PathOverridesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.daemon.v1.DaemonService.PathOverrides is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) SetPathOverrides(context.Context, *connect.Request[daemon.SetPathOverridesRequest]) (*connect.Response[daemon.SetPathOverridesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.daemon.v1.DaemonService.SetPathOverrides is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) ExportPathDB(context.Context, *connect.Request[daemon.ExportPathDBRequest]) (*connect.Response[daemon.ExportPathDBResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.daemon.v1.DaemonService.ExportPathDB is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) ImportPathDB(context.Context, *connect.Request[daemon.ImportPathDBRequest]) (*connect.Response[daemon.ImportPathDBResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.daemon.v1.DaemonService.ImportPathDB is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) ReportPathError(context.Context, *connect.Request[daemon.ReportPathErrorRequest]) (*connect.Response[daemon.ReportPathErrorResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.daemon.v1.DaemonService.ReportPathError is not implemented"))
+}
+
+func (UnimplementedDaemonServiceHandler) Capabilities(context.Context, *connect.Request[daemon.CapabilitiesRequest]) (*connect.Response[daemon.CapabilitiesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.daemon.v1.DaemonService.Capabilities is not implemented"))
+}
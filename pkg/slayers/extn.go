@@ -36,6 +36,17 @@ const (
 	OptTypeAuthenticator
 )
 
+// The experimental options use the option types for experimentation and testing, 253 and 254,
+// and their types may change. The types of the hop-by-hop and end-to-end options are distinct.
+const (
+	// OptTypeFabrid is the type of the experimental FABRID hop-by-hop option.
+	OptTypeFabrid OptionType = 253
+	// OptTypeTelemetry is the type of the experimental telemetry hop-by-hop option.
+	OptTypeTelemetry OptionType = 254
+	// OptTypeSCMPDiagnostic is the type of the experimental SCMP diagnostic end-to-end option.
+	OptTypeSCMPDiagnostic OptionType = 253
+)
+
 type tlvOption struct {
 	OptType      OptionType
 	OptDataLen   uint8
@@ -271,6 +282,17 @@ func (h *HopByHopExtn) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback)
 	return nil
 }
 
+// FindOption returns the first option entry of the given type if any exists,
+// or ErrOptionNotFound otherwise.
+func (h *HopByHopExtn) FindOption(typ OptionType) (*HopByHopOption, error) {
+	for _, o := range h.Options {
+		if o.OptType == typ {
+			return o, nil
+		}
+	}
+	return nil, ErrOptionNotFound
+}
+
 func decodeHopByHopExtn(data []byte, p gopacket.PacketBuilder) error {
 	h := &HopByHopExtn{}
 	err := h.DecodeFromBytes(data, p)
@@ -403,6 +425,14 @@ func (s *HopByHopExtnSkipper) DecodeFromBytes(data []byte, df gopacket.DecodeFee
 	return nil
 }
 
+// FindOption returns the first option entry of the given type if any exists, or
+// ErrOptionNotFound otherwise. The options are parsed on each call; the data of the returned
+// option refers to the decoded buffer.
+func (s *HopByHopExtnSkipper) FindOption(typ OptionType) (HopByHopOption, error) {
+	o, err := findOption(s.Contents, typ)
+	return HopByHopOption(o), err
+}
+
 func (e *HopByHopExtnSkipper) LayerType() gopacket.LayerType {
 	return LayerTypeHopByHopExtn
 }
@@ -436,6 +466,14 @@ func (s *EndToEndExtnSkipper) DecodeFromBytes(data []byte, df gopacket.DecodeFee
 	return nil
 }
 
+// FindOption returns the first option entry of the given type if any exists, or
+// ErrOptionNotFound otherwise. The options are parsed on each call; the data of the returned
+// option refers to the decoded buffer.
+func (s *EndToEndExtnSkipper) FindOption(typ OptionType) (EndToEndOption, error) {
+	o, err := findOption(s.Contents, typ)
+	return EndToEndOption(o), err
+}
+
 func (e *EndToEndExtnSkipper) LayerType() gopacket.LayerType {
 	return LayerTypeEndToEndExtn
 }
@@ -447,3 +485,30 @@ func (s *EndToEndExtnSkipper) CanDecode() gopacket.LayerClass {
 func (e *EndToEndExtnSkipper) NextLayerType() gopacket.LayerType {
 	return scionNextLayerTypeAfterE2E(e.NextHdr)
 }
+
+// findOption returns the first option of the given type in the raw extension header data.
+func findOption(data []byte, typ OptionType) (tlvOption, error) {
+	for offset := 2; offset < len(data); {
+		if OptionType(data[offset]) == OptTypePad1 {
+			offset++
+			continue
+		}
+		if offset+2 > len(data) {
+			break
+		}
+		end := offset + 2 + int(data[offset+1])
+		if end > len(data) {
+			break
+		}
+		if OptionType(data[offset]) == typ {
+			return tlvOption{
+				OptType:      typ,
+				OptDataLen:   data[offset+1],
+				ActualLength: end - offset,
+				OptData:      data[offset+2 : end],
+			}, nil
+		}
+		offset = end
+	}
+	return tlvOption{}, ErrOptionNotFound
+}
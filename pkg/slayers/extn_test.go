@@ -310,6 +310,22 @@ func TestEndToEndExtnDecode(t *testing.T) {
 	assert.Equal(t, raw, b.Bytes(), "Raw Buffer")
 }
 
+func TestEndToEndExtnSkipperFindOption(t *testing.T) {
+	raw := append([]byte{0x11, 0x07}, rawTLVOptionsYX...)
+	e2e := slayers.EndToEndExtnSkipper{}
+	require.NoError(t, e2e.DecodeFromBytes(raw, gopacket.NilDecodeFeedback))
+	opt, err := e2e.FindOption(0x1e)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(12), opt.OptDataLen)
+	assert.Equal(t, 14, opt.ActualLength)
+	assert.Equal(t, optX.OptData, opt.OptData)
+	opt, err = e2e.FindOption(0x3e)
+	require.NoError(t, err)
+	assert.Equal(t, optY.OptData, opt.OptData)
+	_, err = e2e.FindOption(slayers.OptTypeAuthenticator)
+	assert.ErrorIs(t, err, slayers.ErrOptionNotFound)
+}
+
 func TestEndToEndExtnDecodeReuse(t *testing.T) {
 	raw := append([]byte{0x11, 0x07}, rawTLVOptionsYX...)
 	e2e := slayers.EndToEndExtn{}
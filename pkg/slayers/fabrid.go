@@ -0,0 +1,136 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file includes the experimental FABRID hop-by-hop option, as specified in
+// https://docs.scion.org/en/latest/protocols/fabrid-option.html
+
+// The FABRID option format is as follows:
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |   NextHdr     |     ExtLen    |  OptType=253  |  OptDataLen   |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                           Timestamp                           |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                           Packet ID                           |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// | Enc Policy ID |F|R|         Hop Validation Field              |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                              ...                              |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// There is one 4-byte entry per hop field of the path, in the order of the hop fields.
+
+package slayers
+
+import (
+	"encoding/binary"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// FabridMetaLen is the length of the timestamp and packet ID of the FABRID option.
+	FabridMetaLen = 8
+	// FabridHopLen is the length of the entry of a hop field in the FABRID option.
+	FabridHopLen = 4
+	// FabridHVFMask is the mask of the 22 bits of the hop validation field of an entry.
+	FabridHVFMask = 0x3fffff
+
+	fabridEnabledFlag = 0x80
+)
+
+// FabridHop is the FABRID entry of a hop field. It requests that the AS forwards the packet
+// according to one of its intra-AS policies, between the interfaces of the hop field.
+type FabridHop struct {
+	// Enabled is set if the AS must forward the packet according to the policy.
+	Enabled bool
+	// EncPolicyID is the identifier of the policy, encrypted with the key of the AS.
+	EncPolicyID uint8
+	// HVF is the 22-bit hop validation field, which authenticates the policy that the source
+	// requests from the AS.
+	HVF uint32
+}
+
+// FabridOption is the experimental FABRID hop-by-hop option. It can be used to serialize and
+// parse the entries of the policies requested at each hop. The entries are computed and verified
+// with pkg/experimental/fabrid.
+type FabridOption struct {
+	*HopByHopOption
+}
+
+// NewFabridOption creates a new HopByHopOption of OptTypeFabrid, with the given timestamp and
+// packet ID, and the entries of the given hop fields.
+func NewFabridOption(timestamp, packetID uint32, hops []FabridHop) FabridOption {
+	o := FabridOption{HopByHopOption: &HopByHopOption{
+		OptType:  OptTypeFabrid,
+		OptData:  make([]byte, FabridMetaLen+len(hops)*FabridHopLen),
+		OptAlign: [2]uint8{4, 2},
+	}}
+	o.OptDataLen = uint8(len(o.OptData))
+	o.ActualLength = len(o.OptData) + 2
+	binary.BigEndian.PutUint32(o.OptData[0:4], timestamp)
+	binary.BigEndian.PutUint32(o.OptData[4:8], packetID)
+	for i, h := range hops {
+		o.SetHop(i, h)
+	}
+	return o
+}
+
+// ParseFabridOption parses o as a FABRID option. The number of entries must be checked against
+// the number of hop fields of the path by the caller.
+func ParseFabridOption(o *HopByHopOption) (FabridOption, error) {
+	if o.OptType != OptTypeFabrid {
+		return FabridOption{},
+			serrors.New("wrong option type", "expected", OptTypeFabrid, "actual", o.OptType)
+	}
+	if len(o.OptData) < FabridMetaLen || (len(o.OptData)-FabridMetaLen)%FabridHopLen != 0 {
+		return FabridOption{},
+			serrors.New("invalid FABRID option length", "length", len(o.OptData))
+	}
+	return FabridOption{o}, nil
+}
+
+// Timestamp returns the timestamp of the packet, in seconds since the Unix epoch.
+func (o FabridOption) Timestamp() uint32 {
+	return binary.BigEndian.Uint32(o.OptData[0:4])
+}
+
+// PacketID returns the identifier of the packet.
+func (o FabridOption) PacketID() uint32 {
+	return binary.BigEndian.Uint32(o.OptData[4:8])
+}
+
+// NumHops returns the number of hop field entries of the option.
+func (o FabridOption) NumHops() int {
+	return (len(o.OptData) - FabridMetaLen) / FabridHopLen
+}
+
+// Hop returns the entry of the i-th hop field. It panics if i is out of range.
+func (o FabridOption) Hop(i int) FabridHop {
+	b := o.OptData[FabridMetaLen+i*FabridHopLen:]
+	return FabridHop{
+		EncPolicyID: b[0],
+		Enabled:     b[1]&fabridEnabledFlag != 0,
+		HVF:         binary.BigEndian.Uint32(b[0:4]) & FabridHVFMask,
+	}
+}
+
+// SetHop sets the entry of the i-th hop field. It panics if i is out of range.
+func (o FabridOption) SetHop(i int, h FabridHop) {
+	b := o.OptData[FabridMetaLen+i*FabridHopLen:]
+	v := uint32(h.EncPolicyID)<<24 | h.HVF&FabridHVFMask
+	if h.Enabled {
+		v |= fabridEnabledFlag << 16
+	}
+	binary.BigEndian.PutUint32(b[0:4], v)
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slayers_test
+
+import (
+	"testing"
+
+	"github.com/gopacket/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+var rawHBHFabrid = []byte{
+	0x11, 0x05, 0xfd, 0x14,
+	0x01, 0x02, 0x03, 0x04,
+	0x0a, 0x0b, 0x0c, 0x0d,
+	0x05, 0x81, 0x23, 0x45,
+	0x00, 0x00, 0x00, 0x00,
+	0x2a, 0xbf, 0xff, 0xff,
+}
+
+var fabridHops = []slayers.FabridHop{
+	{Enabled: true, EncPolicyID: 5, HVF: 0x12345},
+	{},
+	{Enabled: true, EncPolicyID: 42, HVF: 0x3fffff},
+}
+
+func TestFabridOptionSerialize(t *testing.T) {
+	opt := slayers.NewFabridOption(0x01020304, 0x0a0b0c0d, fabridHops)
+	hbh := slayers.HopByHopExtn{}
+	hbh.NextHdr = slayers.L4UDP
+	hbh.Options = []*slayers.HopByHopOption{opt.HopByHopOption}
+
+	b := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true}
+	require.NoError(t, hbh.SerializeTo(b, opts))
+	assert.Equal(t, rawHBHFabrid, b.Bytes())
+}
+
+func TestFabridOptionDecode(t *testing.T) {
+	hbh := slayers.HopByHopExtn{}
+	require.NoError(t, hbh.DecodeFromBytes(rawHBHFabrid, gopacket.NilDecodeFeedback))
+	o, err := hbh.FindOption(slayers.OptTypeFabrid)
+	require.NoError(t, err)
+	opt, err := slayers.ParseFabridOption(o)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x01020304), opt.Timestamp())
+	assert.Equal(t, uint32(0x0a0b0c0d), opt.PacketID())
+	require.Equal(t, 3, opt.NumHops())
+	for i, h := range fabridHops {
+		assert.Equal(t, h, opt.Hop(i))
+	}
+
+	// The skipper finds the same option without parsing all of them.
+	skipper := slayers.HopByHopExtnSkipper{}
+	require.NoError(t, skipper.DecodeFromBytes(rawHBHFabrid, gopacket.NilDecodeFeedback))
+	so, err := skipper.FindOption(slayers.OptTypeFabrid)
+	require.NoError(t, err)
+	assert.Equal(t, o.OptData, so.OptData)
+	_, err = skipper.FindOption(slayers.OptTypeAuthenticator)
+	assert.ErrorIs(t, err, slayers.ErrOptionNotFound)
+
+	_, err = slayers.ParseFabridOption(&slayers.HopByHopOption{
+		OptType: slayers.OptTypeFabrid,
+		OptData: make([]byte, slayers.FabridMetaLen+2),
+	})
+	assert.Error(t, err)
+	_, err = slayers.ParseFabridOption(&slayers.HopByHopOption{
+		OptType: slayers.OptTypeFabrid,
+		OptData: make([]byte, slayers.FabridMetaLen-4),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file includes the experimental SCMP diagnostic end-to-end option, as specified in
+// https://docs.scion.org/en/latest/protocols/scmp-diagnostic-option.html
+
+// The SCMP diagnostic option format is as follows:
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |   NextHdr     |     ExtLen    |  OptType=253  |  OptDataLen   |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |        Ingress IfID           |         Egress IfID           |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |    CurrINF    |    CurrHF     |              RSV              |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                           Timestamp                           |
+// +                                                               +
+// |                                                               |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+package slayers
+
+import (
+	"encoding/binary"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// SCMPDiagnosticLen is the length of the data of the SCMP diagnostic option.
+const SCMPDiagnosticLen = 16
+
+// SCMPDiagnostic describes where a router rejected a packet. A router adds it to the SCMP error
+// that it sends in response, next to the code that says why.
+type SCMPDiagnostic struct {
+	// Ingress and Egress are the interfaces through which the rejected packet entered and
+	// would have left the router. 0 is the internal interface, or an egress interface that
+	// was not determined yet.
+	Ingress, Egress uint16
+	// CurrINF and CurrHF are the indices of the info field and of the hop field that the
+	// router was processing, in the path of the rejected packet.
+	CurrINF, CurrHF uint8
+	// Timestamp is the time at which the router rejected the packet, in nanoseconds since the
+	// Unix epoch.
+	Timestamp uint64
+}
+
+// SerializeTo writes the diagnostic to b, which must be at least SCMPDiagnosticLen bytes long.
+func (d SCMPDiagnostic) SerializeTo(b []byte) {
+	_ = b[SCMPDiagnosticLen-1]
+	binary.BigEndian.PutUint16(b[0:2], d.Ingress)
+	binary.BigEndian.PutUint16(b[2:4], d.Egress)
+	b[4] = d.CurrINF
+	b[5] = d.CurrHF
+	b[6], b[7] = 0, 0
+	binary.BigEndian.PutUint64(b[8:16], d.Timestamp)
+}
+
+// DecodeFromBytes reads the diagnostic from b, which must be at least SCMPDiagnosticLen bytes
+// long.
+func (d *SCMPDiagnostic) DecodeFromBytes(b []byte) {
+	_ = b[SCMPDiagnosticLen-1]
+	d.Ingress = binary.BigEndian.Uint16(b[0:2])
+	d.Egress = binary.BigEndian.Uint16(b[2:4])
+	d.CurrINF = b[4]
+	d.CurrHF = b[5]
+	d.Timestamp = binary.BigEndian.Uint64(b[8:16])
+}
+
+// NewSCMPDiagnosticOption creates a new EndToEndOption of OptTypeSCMPDiagnostic with the given
+// diagnostic. The diagnostic can be replaced later by serializing another one to the OptData of
+// the option.
+func NewSCMPDiagnosticOption(d SCMPDiagnostic) *EndToEndOption {
+	o := &EndToEndOption{
+		OptType:      OptTypeSCMPDiagnostic,
+		OptData:      make([]byte, SCMPDiagnosticLen),
+		OptDataLen:   SCMPDiagnosticLen,
+		ActualLength: SCMPDiagnosticLen + 2,
+		OptAlign:     [2]uint8{4, 2},
+	}
+	d.SerializeTo(o.OptData)
+	return o
+}
+
+// ParseSCMPDiagnosticOption parses o as an SCMP diagnostic option.
+func ParseSCMPDiagnosticOption(o *EndToEndOption) (SCMPDiagnostic, error) {
+	if o.OptType != OptTypeSCMPDiagnostic {
+		return SCMPDiagnostic{}, serrors.New("wrong option type",
+			"expected", OptTypeSCMPDiagnostic, "actual", o.OptType)
+	}
+	if len(o.OptData) < SCMPDiagnosticLen {
+		return SCMPDiagnostic{}, serrors.New("buffer too short",
+			"expected at least", SCMPDiagnosticLen, "actual", len(o.OptData))
+	}
+	var d SCMPDiagnostic
+	d.DecodeFromBytes(o.OptData)
+	return d, nil
+}
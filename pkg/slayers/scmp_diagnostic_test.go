@@ -0,0 +1,61 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slayers_test
+
+import (
+	"testing"
+
+	"github.com/gopacket/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+func TestSCMPDiagnosticOptionSerializeDecode(t *testing.T) {
+	diag := slayers.SCMPDiagnostic{
+		Ingress:   1,
+		Egress:    2,
+		CurrINF:   1,
+		CurrHF:    4,
+		Timestamp: 1700000000123456789,
+	}
+	e2e := slayers.EndToEndExtn{}
+	e2e.NextHdr = slayers.L4SCMP
+	e2e.Options = []*slayers.EndToEndOption{slayers.NewSCMPDiagnosticOption(diag)}
+	b := gopacket.NewSerializeBuffer()
+	require.NoError(t, e2e.SerializeTo(b, gopacket.SerializeOptions{FixLengths: true}))
+	// The diagnostic follows the extension and option headers without padding.
+	assert.Len(t, b.Bytes(), 4+slayers.SCMPDiagnosticLen)
+
+	decoded := slayers.EndToEndExtn{}
+	require.NoError(t, decoded.DecodeFromBytes(b.Bytes(), gopacket.NilDecodeFeedback))
+	o, err := decoded.FindOption(slayers.OptTypeSCMPDiagnostic)
+	require.NoError(t, err)
+	parsed, err := slayers.ParseSCMPDiagnosticOption(o)
+	require.NoError(t, err)
+	assert.Equal(t, diag, parsed)
+
+	_, err = slayers.ParseSCMPDiagnosticOption(&slayers.EndToEndOption{
+		OptType: slayers.OptTypeSCMPDiagnostic,
+		OptData: make([]byte, 10),
+	})
+	assert.Error(t, err)
+	_, err = slayers.ParseSCMPDiagnosticOption(&slayers.EndToEndOption{
+		OptType: slayers.OptTypeAuthenticator,
+		OptData: make([]byte, slayers.SCMPDiagnosticLen),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file includes the experimental telemetry hop-by-hop option, as specified in
+// https://docs.scion.org/en/latest/protocols/telemetry-option.html
+
+// The telemetry option format is as follows:
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |   NextHdr     |     ExtLen    |  OptType=254  |  OptDataLen   |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                            ISD-AS                             |
+// +                                                               +
+// |                                                               |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |        Ingress IfID           |         Egress IfID           |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                       Ingress Timestamp                       |
+// +                                                               +
+// |                                                               |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                        Residence Time                         |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |          Queue Depth          |              RSV              |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                              ...                              |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// Each router that records its hop appends a 28-byte record to the option data.
+
+package slayers
+
+import (
+	"encoding/binary"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// TelemetryRecordLen is the length of a record of the telemetry option.
+	TelemetryRecordLen = 28
+	// MaxTelemetryRecords is the largest number of records that fit in a telemetry option.
+	MaxTelemetryRecords = 255 / TelemetryRecordLen
+)
+
+// TelemetryRecord is the record of the hop of a packet through a router.
+type TelemetryRecord struct {
+	// IA is the ISD-AS of the router.
+	IA addr.IA
+	// Ingress and Egress are the interfaces through which the packet entered and left the
+	// router. 0 is the internal interface.
+	Ingress, Egress uint16
+	// IngressTime is the time at which the router started processing the packet, in
+	// nanoseconds since the Unix epoch.
+	IngressTime uint64
+	// ResidenceTime is the time, in nanoseconds, from IngressTime until the router queued the
+	// packet for sending. The egress timestamp is their sum.
+	ResidenceTime uint32
+	// QueueDepth is the number of packets that were waiting to be sent over the egress
+	// interface when the packet was queued.
+	QueueDepth uint16
+}
+
+// SerializeTo writes the record to b, which must be at least TelemetryRecordLen bytes long.
+func (r TelemetryRecord) SerializeTo(b []byte) {
+	_ = b[TelemetryRecordLen-1]
+	binary.BigEndian.PutUint64(b[0:8], uint64(r.IA))
+	binary.BigEndian.PutUint16(b[8:10], r.Ingress)
+	binary.BigEndian.PutUint16(b[10:12], r.Egress)
+	binary.BigEndian.PutUint64(b[12:20], r.IngressTime)
+	binary.BigEndian.PutUint32(b[20:24], r.ResidenceTime)
+	binary.BigEndian.PutUint16(b[24:26], r.QueueDepth)
+	b[26], b[27] = 0, 0
+}
+
+// DecodeFromBytes reads the record from b, which must be at least TelemetryRecordLen bytes
+// long.
+func (r *TelemetryRecord) DecodeFromBytes(b []byte) {
+	_ = b[TelemetryRecordLen-1]
+	r.IA = addr.IA(binary.BigEndian.Uint64(b[0:8]))
+	r.Ingress = binary.BigEndian.Uint16(b[8:10])
+	r.Egress = binary.BigEndian.Uint16(b[10:12])
+	r.IngressTime = binary.BigEndian.Uint64(b[12:20])
+	r.ResidenceTime = binary.BigEndian.Uint32(b[20:24])
+	r.QueueDepth = binary.BigEndian.Uint16(b[24:26])
+}
+
+// TelemetryOption is the telemetry hop-by-hop option. It can be used to parse the records that
+// the routers on the path appended to a packet.
+type TelemetryOption struct {
+	*HopByHopOption
+}
+
+// NewTelemetryOption creates a new HopByHopOption of OptTypeTelemetry with the given records.
+func NewTelemetryOption(records []TelemetryRecord) (TelemetryOption, error) {
+	if len(records) > MaxTelemetryRecords {
+		return TelemetryOption{}, serrors.New("too many telemetry records",
+			"records", len(records), "max", MaxTelemetryRecords)
+	}
+	o := TelemetryOption{HopByHopOption: &HopByHopOption{
+		OptType:  OptTypeTelemetry,
+		OptData:  make([]byte, len(records)*TelemetryRecordLen),
+		OptAlign: [2]uint8{4, 2},
+	}}
+	o.OptDataLen = uint8(len(o.OptData))
+	o.ActualLength = len(o.OptData) + 2
+	for i, r := range records {
+		r.SerializeTo(o.OptData[i*TelemetryRecordLen:])
+	}
+	return o, nil
+}
+
+// ParseTelemetryOption parses o as a telemetry option.
+func ParseTelemetryOption(o *HopByHopOption) (TelemetryOption, error) {
+	if o.OptType != OptTypeTelemetry {
+		return TelemetryOption{},
+			serrors.New("wrong option type", "expected", OptTypeTelemetry, "actual", o.OptType)
+	}
+	if len(o.OptData)%TelemetryRecordLen != 0 {
+		return TelemetryOption{},
+			serrors.New("invalid telemetry option length", "length", len(o.OptData))
+	}
+	return TelemetryOption{o}, nil
+}
+
+// NumRecords returns the number of records of the option.
+func (o TelemetryOption) NumRecords() int {
+	return len(o.OptData) / TelemetryRecordLen
+}
+
+// Record returns the i-th record, in the order in which the routers appended them. It panics
+// if i is out of range.
+func (o TelemetryOption) Record(i int) TelemetryRecord {
+	var r TelemetryRecord
+	r.DecodeFromBytes(o.OptData[i*TelemetryRecordLen:])
+	return r
+}
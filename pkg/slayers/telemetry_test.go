@@ -0,0 +1,71 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slayers_test
+
+import (
+	"testing"
+
+	"github.com/gopacket/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+func TestTelemetryOptionSerializeDecode(t *testing.T) {
+	records := []slayers.TelemetryRecord{
+		{
+			IA:            addr.MustParseIA("1-ff00:0:110"),
+			Ingress:       1,
+			Egress:        2,
+			IngressTime:   1700000000123456789,
+			ResidenceTime: 15000,
+			QueueDepth:    3,
+		},
+		{
+			IA:          addr.MustParseIA("1-ff00:0:111"),
+			Ingress:     5,
+			IngressTime: 1700000000123556789,
+		},
+	}
+	opt, err := slayers.NewTelemetryOption(records)
+	require.NoError(t, err)
+	hbh := slayers.HopByHopExtn{}
+	hbh.NextHdr = slayers.L4UDP
+	hbh.Options = []*slayers.HopByHopOption{opt.HopByHopOption}
+	b := gopacket.NewSerializeBuffer()
+	require.NoError(t, hbh.SerializeTo(b, gopacket.SerializeOptions{FixLengths: true}))
+	// The records follow the extension and option headers without padding.
+	assert.Len(t, b.Bytes(), 4+2*slayers.TelemetryRecordLen)
+
+	decoded := slayers.HopByHopExtn{}
+	require.NoError(t, decoded.DecodeFromBytes(b.Bytes(), gopacket.NilDecodeFeedback))
+	o, err := decoded.FindOption(slayers.OptTypeTelemetry)
+	require.NoError(t, err)
+	parsed, err := slayers.ParseTelemetryOption(o)
+	require.NoError(t, err)
+	require.Equal(t, 2, parsed.NumRecords())
+	assert.Equal(t, records[0], parsed.Record(0))
+	assert.Equal(t, records[1], parsed.Record(1))
+
+	_, err = slayers.NewTelemetryOption(make([]slayers.TelemetryRecord, 10))
+	assert.Error(t, err)
+	_, err = slayers.ParseTelemetryOption(&slayers.HopByHopOption{
+		OptType: slayers.OptTypeTelemetry,
+		OptData: make([]byte, 10),
+	})
+	assert.Error(t, err)
+}
@@ -58,7 +58,9 @@ type Conn struct {
 // NewCookedConn returns a "cooked" Conn. The Conn object can be used to
 // send/receive SCION traffic with the usual methods.
 // It takes as arguments a non-nil PacketConn and a non-nil Topology parameter.
-// Nil or unspecified addresses for the PacketConn object are not supported.
+// Nil addresses for the PacketConn object are not supported. If the address is
+// unspecified, the PacketConn must check the destination of received packets
+// as SCIONPacketConn does, see SCIONNetwork.Listen.
 // This is an advanced API, that allows fine-tunning of the Conn underlay functionality.
 // The general methods for obtaining a Conn object are still SCIONNetwork.Listen and
 // SCIONNetwork.Dial.
@@ -72,10 +74,10 @@ func NewCookedConn(
 		IA:   topo.LocalIA,
 		Host: pconn.LocalAddr().(*net.UDPAddr),
 	}
-	if local.Host == nil || local.Host.IP.IsUnspecified() {
-		return nil, serrors.New("nil or unspecified address is not supported.")
+	if local.Host == nil {
+		return nil, serrors.New("nil address is not supported.")
 	}
-	return &Conn{
+	c := &Conn{
 		conn:   pconn,
 		local:  local,
 		remote: o.remote,
@@ -93,7 +95,32 @@ func NewCookedConn(
 			replyPather: o.replyPather,
 			local:       local,
 		},
-	}, nil
+	}
+	if pc, ok := pconn.(*SCIONPacketConn); ok {
+		c.scionConnWriter.public = pc.public
+		c.scionConnReader.public = pc.public
+	}
+	if o.natKeepalive > 0 {
+		c.scionConnWriter.keepalive = newNATKeepalive(o.natKeepalive,
+			c.scionConnWriter.sendKeepalive)
+	}
+	return c, nil
+}
+
+// SetSCMPErrorHandler registers a handler that is called for each SCMP error
+// received on the connection, with the destination and the path of the packet
+// that caused it. The SCMP errors are received while the connection is read
+// from, and are then processed by the SCMP handler of the connection as
+// before. A nil handler removes the registered one. It returns an error if
+// the PacketConn of the connection does not support SCMP error handlers, see
+// SCIONPacketConn.SetSCMPErrorHandler.
+func (c *Conn) SetSCMPErrorHandler(handler SCMPErrorHandler) error {
+	pc, ok := c.conn.(interface{ SetSCMPErrorHandler(SCMPErrorHandler) })
+	if !ok {
+		return serrors.New("SCMP error handlers not supported by the packet connection")
+	}
+	pc.SetSCMPErrorHandler(handler)
+	return nil
 }
 
 func (c *Conn) LocalAddr() net.Addr {
@@ -115,6 +142,9 @@ func (c *Conn) SetDeadline(t time.Time) error {
 }
 
 func (c *Conn) Close() error {
+	if c.scionConnWriter.keepalive != nil {
+		c.scionConnWriter.keepalive.close()
+	}
 	return c.conn.Close()
 }
 
@@ -139,9 +169,22 @@ func WithRemote(addr *UDPAddr) ConnOption {
 	}
 }
 
+// WithNATKeepalive enables NAT keepalives for the connection. If the host
+// appears to be behind a NAT, a keepalive is sent toward each first-hop
+// border router the connection has not sent a packet to for interval. This
+// keeps the NAT mapping for inbound traffic from expiring on idle
+// connections. The replies to the keepalives are consumed by the SCMP handler
+// of the underlying PacketConn. A zero interval disables keepalives.
+func WithNATKeepalive(interval time.Duration) ConnOption {
+	return func(o *options) {
+		o.natKeepalive = interval
+	}
+}
+
 type options struct {
-	replyPather ReplyPather
-	remote      *UDPAddr
+	replyPather  ReplyPather
+	remote       *UDPAddr
+	natKeepalive time.Duration
 }
 
 func apply(opts []ConnOption) options {
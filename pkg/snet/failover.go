@@ -0,0 +1,440 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/segment/iface"
+)
+
+const (
+	// failoverCheckInterval is the interval at which the expiry of the path of
+	// a FailoverConn is checked if probing is disabled.
+	failoverCheckInterval = 10 * time.Second
+	// failoverExpiryMargin is the time before its expiry at which a path is
+	// replaced.
+	failoverExpiryMargin = time.Minute
+	// failoverQueryTimeout is the timeout for querying the paths to the
+	// remote AS.
+	failoverQueryTimeout = 5 * time.Second
+	// failedPathTimeout is the time for which a failed path, or a path via an
+	// interface reported down, is not used again.
+	failedPathTimeout = time.Minute
+	// maxProbeMisses is the number of consecutive probes without reply after
+	// which the path is considered failed.
+	maxProbeMisses = 3
+)
+
+// FailoverConn is a connection to a fixed remote address that switches to
+// another path to the remote AS when the path in use fails, so that the
+// application does not stall until it notices. The path is replaced if
+//   - an SCMP interface down or internal connectivity down error reports a
+//     link of the path,
+//   - the path expires within a minute, or
+//   - probing is enabled and the last hop of the path did not reply to
+//     three consecutive probes.
+//
+// The alternative path is the first path returned by the router, and
+// selected by the path policy if one is set, that is not affected by a
+// failure seen in the last minute. If there is none, the path is kept.
+//
+// The SCMP errors and the probe replies are processed while reading from the
+// connection, so the application must keep reading for them to be noticed.
+// The SCMP errors are passed on to the SCMP handler of the network
+// afterwards, and are returned by Read as usual.
+type FailoverConn struct {
+	*Conn
+	router        Router
+	policy        PathPolicy
+	remote        UDPAddr
+	probeInterval time.Duration
+
+	mtx sync.Mutex
+	// path is the path in use.
+	path Path
+	// failed are the paths that failed, with the time until which they are
+	// not used.
+	failed map[PathFingerprint]time.Time
+	// down are the links reported down, with the time until which the paths
+	// containing them are not used.
+	down map[downLink]time.Time
+	// probeSeq is the sequence number of the last probe, and probing whether
+	// that probe was not replied to yet.
+	probeSeq uint16
+	probing  bool
+	missed   int
+
+	failover chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// downLink is a link reported down by an SCMP error, either an interface, or
+// the connectivity between two interfaces of an AS.
+type downLink struct {
+	a, b PathInterface
+}
+
+// in returns whether the link is on a path with the interfaces ifaces.
+func (l downLink) in(ifaces []PathInterface) bool {
+	return slices.Contains(ifaces, l.a) &&
+		(l.b == PathInterface{} || slices.Contains(ifaces, l.b))
+}
+
+// FailoverOption is a functional option type for configuring a FailoverConn.
+type FailoverOption func(o *failoverOptions)
+
+// WithProbeInterval enables probing the path of a FailoverConn with SCMP
+// traceroute requests to the border router of the remote AS, at the given
+// interval. The path is replaced after three consecutive probes without
+// reply. A zero interval disables probing.
+func WithProbeInterval(interval time.Duration) FailoverOption {
+	return func(o *failoverOptions) {
+		o.probeInterval = interval
+	}
+}
+
+// WithPathPolicy sets the policy that selects and orders the paths of the
+// router that a FailoverConn may use.
+func WithPathPolicy(policy PathPolicy) FailoverOption {
+	return func(o *failoverOptions) {
+		o.policy = policy
+	}
+}
+
+type failoverOptions struct {
+	probeInterval time.Duration
+	policy        PathPolicy
+}
+
+// DialFailover returns a connection to remote that fails over to another
+// path to the remote AS if the path in use fails, see FailoverConn. The
+// paths are obtained from router. The path and next hop of remote are
+// ignored; the remote AS must not be the local AS.
+//
+// The context is used for connection setup, it doesn't affect the returned
+// connection.
+func (n *SCIONNetwork) DialFailover(ctx context.Context, listen *net.UDPAddr,
+	remote *UDPAddr, router Router, opts ...FailoverOption) (*FailoverConn, error) {
+
+	if remote == nil {
+		return nil, serrors.New("Unable to dial to nil remote")
+	}
+	if remote.IA.Equal(n.Topology.LocalIA) {
+		return nil, serrors.New("failover requires a remote AS", "isd_as", remote.IA)
+	}
+	var o failoverOptions
+	for _, option := range opts {
+		option(&o)
+	}
+	c := &FailoverConn{
+		router:        router,
+		policy:        o.policy,
+		remote:        UDPAddr{IA: remote.IA, Host: CopyUDPAddr(remote.Host)},
+		probeInterval: o.probeInterval,
+		failed:        make(map[PathFingerprint]time.Time),
+		down:          make(map[downLink]time.Time),
+		failover:      make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+	}
+	paths, err := c.paths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.path = c.choose(paths, time.Now())
+	if c.path == nil {
+		return nil, serrors.New("no path", "isd_as", remote.IA)
+	}
+	conn, err := n.Dial(ctx, "udp", listen, c.remoteVia(c.path))
+	if err != nil {
+		return nil, err
+	}
+	c.Conn = conn
+	if pc, ok := conn.conn.(*SCIONPacketConn); ok {
+		pc.SCMPHandler = failoverSCMPHandler{conn: c, next: pc.SCMPHandler}
+	}
+	go func() {
+		defer log.HandlePanic()
+		c.run()
+	}()
+	return c, nil
+}
+
+// Path returns the path in use.
+func (c *FailoverConn) Path() Path {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.path
+}
+
+// RemoteAddr returns the remote address, with the path in use.
+func (c *FailoverConn) RemoteAddr() net.Addr {
+	return c.remoteVia(c.Path())
+}
+
+// Write sends b to the remote address on the path in use. If the path has
+// expired, it is replaced first.
+func (c *FailoverConn) Write(b []byte) (int, error) {
+	path := c.Path()
+	if meta := path.Metadata(); meta != nil && !meta.Expiry.IsZero() &&
+		time.Now().After(meta.Expiry) {
+		c.replace()
+		path = c.Path()
+	}
+	return c.Conn.WriteTo(b, c.remoteVia(path))
+}
+
+// WriteTo sends b to raddr. The packets to the remote address are sent on the
+// path in use, whatever the path of raddr, so that the connection can be used
+// as the packet connection of a QUIC transport.
+func (c *FailoverConn) WriteTo(b []byte, raddr net.Addr) (int, error) {
+	if a, ok := raddr.(*UDPAddr); ok && c.isRemote(a) {
+		return c.Write(b)
+	}
+	return c.Conn.WriteTo(b, raddr)
+}
+
+func (c *FailoverConn) isRemote(a *UDPAddr) bool {
+	return a.IA.Equal(c.remote.IA) && a.Host != nil && c.remote.Host != nil &&
+		a.Host.IP.Equal(c.remote.Host.IP) && a.Host.Port == c.remote.Host.Port
+}
+
+func (c *FailoverConn) Close() error {
+	c.stopOnce.Do(func() { close(c.stop) })
+	return c.Conn.Close()
+}
+
+func (c *FailoverConn) remoteVia(path Path) *UDPAddr {
+	return &UDPAddr{
+		IA:      c.remote.IA,
+		Host:    CopyUDPAddr(c.remote.Host),
+		Path:    path.Dataplane(),
+		NextHop: path.UnderlayNextHop(),
+	}
+}
+
+func (c *FailoverConn) run() {
+	interval := c.probeInterval
+	if interval <= 0 {
+		interval = failoverCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-c.failover:
+			c.replace()
+		case now := <-ticker.C:
+			if c.check(now) {
+				c.replace()
+			}
+			if c.probeInterval > 0 {
+				c.probe()
+			}
+		}
+	}
+}
+
+// check returns whether the path in use must be replaced at now, because it
+// expires soon or did not reply to the last probes.
+func (c *FailoverConn) check(now time.Time) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if meta := c.path.Metadata(); meta != nil && !meta.Expiry.IsZero() &&
+		now.Add(failoverExpiryMargin).After(meta.Expiry) {
+		return true
+	}
+	if c.probing {
+		c.probing = false
+		c.missed++
+	}
+	if c.missed >= maxProbeMisses {
+		c.failed[Fingerprint(c.path)] = now.Add(failedPathTimeout)
+		return true
+	}
+	return false
+}
+
+// probe sends a probe to the border router of the remote AS on the path in
+// use.
+func (c *FailoverConn) probe() {
+	c.mtx.Lock()
+	path := c.path
+	c.probeSeq++
+	seq := c.probeSeq
+	c.probing = true
+	c.mtx.Unlock()
+
+	dst := SCIONAddress{IA: c.remote.IA, Host: addr.HostSVC(addr.SvcNone)}
+	err := c.Conn.scionConnWriter.writeTraceroute(path.UnderlayNextHop(), dst,
+		path.Dataplane(), true, seq)
+	if err != nil {
+		log.Debug("Probing path", "isd_as", c.remote.IA, "err", err)
+	}
+}
+
+// replace replaces the path in use with the first path to the remote AS that
+// is usable.
+func (c *FailoverConn) replace() {
+	ctx, cancel := context.WithTimeout(context.Background(), failoverQueryTimeout)
+	defer cancel()
+	paths, err := c.paths(ctx)
+	if err != nil {
+		log.Debug("Failing over", "isd_as", c.remote.IA, "err", err)
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	path := c.choose(paths, time.Now())
+	if path == nil {
+		log.Debug("No alternative path for failover", "isd_as", c.remote.IA)
+		return
+	}
+	if Fingerprint(path) != Fingerprint(c.path) {
+		log.Debug("Failing over to another path", "isd_as", c.remote.IA,
+			"from", c.path, "to", path)
+	}
+	c.path = path
+	c.probing, c.missed = false, 0
+}
+
+// paths returns the paths to the remote AS, selected by the path policy.
+func (c *FailoverConn) paths(ctx context.Context) ([]Path, error) {
+	paths, err := c.router.AllRoutes(ctx, c.remote.IA)
+	if err != nil {
+		return nil, serrors.Wrap("querying paths", err, "isd_as", c.remote.IA)
+	}
+	if c.policy != nil {
+		paths = c.policy.Filter(paths)
+	}
+	return paths, nil
+}
+
+// choose returns the first of paths that did not fail, does not contain a
+// link reported down, and does not expire soon. It returns nil if there is
+// none. The caller must hold mtx, unless the connection is not in use yet.
+func (c *FailoverConn) choose(paths []Path, now time.Time) Path {
+	for fp, until := range c.failed {
+		if now.After(until) {
+			delete(c.failed, fp)
+		}
+	}
+	for l, until := range c.down {
+		if now.After(until) {
+			delete(c.down, l)
+		}
+	}
+	for _, p := range paths {
+		if p.UnderlayNextHop() == nil || p.Dataplane() == nil {
+			continue
+		}
+		meta := p.Metadata()
+		if meta != nil && !meta.Expiry.IsZero() &&
+			now.Add(failoverExpiryMargin).After(meta.Expiry) {
+			continue
+		}
+		if _, ok := c.failed[Fingerprint(p)]; ok {
+			continue
+		}
+		if meta != nil && c.linkDownOn(meta.Interfaces) {
+			continue
+		}
+		return p
+	}
+	return nil
+}
+
+// linkDownOn returns whether a link reported down is on a path with the
+// interfaces ifaces. The caller must hold mtx.
+func (c *FailoverConn) linkDownOn(ifaces []PathInterface) bool {
+	for l := range c.down {
+		if l.in(ifaces) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkDown records that l is down, and fails over if it is on the path in
+// use.
+func (c *FailoverConn) linkDown(l downLink) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	now := time.Now()
+	c.down[l] = now.Add(failedPathTimeout)
+	meta := c.path.Metadata()
+	if meta == nil || !l.in(meta.Interfaces) {
+		return
+	}
+	c.failed[Fingerprint(c.path)] = now.Add(failedPathTimeout)
+	select {
+	case c.failover <- struct{}{}:
+	default:
+	}
+}
+
+// probeReply records the reply to a probe, and returns whether pkt is one.
+func (c *FailoverConn) probeReply(pkt *Packet) bool {
+	reply, ok := pkt.Payload.(SCMPTracerouteReply)
+	if !ok || !pkt.Source.IA.Equal(c.remote.IA) {
+		return false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if reply.Sequence != c.probeSeq {
+		return false
+	}
+	c.probing, c.missed = false, 0
+	return true
+}
+
+// failoverSCMPHandler passes the SCMP messages relevant for the path of a
+// FailoverConn to it, before handling them with the next handler.
+type failoverSCMPHandler struct {
+	conn *FailoverConn
+	next SCMPHandler
+}
+
+func (h failoverSCMPHandler) Handle(pkt *Packet) error {
+	switch msg := pkt.Payload.(type) {
+	case SCMPTracerouteReply:
+		if h.conn.probeReply(pkt) {
+			return nil
+		}
+	case SCMPExternalInterfaceDown:
+		h.conn.linkDown(downLink{
+			a: PathInterface{IA: msg.IA, ID: iface.ID(msg.Interface)},
+		})
+	case SCMPInternalConnectivityDown:
+		h.conn.linkDown(downLink{
+			a: PathInterface{IA: msg.IA, ID: iface.ID(msg.Ingress)},
+			b: PathInterface{IA: msg.IA, ID: iface.ID(msg.Egress)},
+		})
+	}
+	if h.next == nil {
+		return serrors.New("scmp packet received, but no handler found", "src", pkt.Source)
+	}
+	return h.next.Handle(pkt)
+}
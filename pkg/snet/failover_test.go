@@ -0,0 +1,269 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestFailoverConn(t *testing.T) {
+	localIA := addr.MustParseIA("1-ff00:0:110")
+	remoteIA := addr.MustParseIA("1-ff00:0:111")
+	remote := &snet.UDPAddr{
+		IA:   remoteIA,
+		Host: &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 40000},
+	}
+	listen := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}
+	loopback := addr.HostIP(netip.MustParseAddr("127.0.0.1"))
+	network := func() *snet.SCIONNetwork {
+		return &snet.SCIONNetwork{
+			Topology: snet.Topology{
+				LocalIA:   localIA,
+				PortRange: snet.TopologyPortRange{Start: 40130, End: 40139},
+			},
+			SCMPHandler: snet.DefaultSCMPHandler{},
+		}
+	}
+	t.Run("interface down", func(t *testing.T) {
+		br1, br2 := newTestBorderRouter(t, remoteIA), newTestBorderRouter(t, remoteIA)
+		p1 := twoHopPath(t, localIA, remoteIA, br1, 1, 2, time.Hour)
+		p2 := twoHopPath(t, localIA, remoteIA, br2, 3, 4, time.Hour)
+		conn, err := network().DialFailover(context.Background(), listen, remote,
+			failoverTestPaths{p1, p2})
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.Equal(t, snet.Fingerprint(p1), snet.Fingerprint(conn.Path()))
+
+		_, err = conn.Write([]byte("a"))
+		require.NoError(t, err)
+		assert.Equal(t, "a", br1.received(t))
+
+		// The first border router reports its interface down.
+		local := conn.LocalAddr().(*snet.UDPAddr).Host
+		scmp := &snet.Packet{
+			PacketInfo: snet.PacketInfo{
+				Source:      snet.SCIONAddress{IA: localIA, Host: loopback},
+				Destination: snet.SCIONAddress{IA: localIA, Host: loopback},
+				Path:        snetpath.Empty{},
+				Payload:     snet.SCMPExternalInterfaceDown{IA: localIA, Interface: 1},
+			},
+		}
+		require.NoError(t, scmp.Serialize())
+		_, err = br1.conn.WriteToUDP(scmp.Bytes, local)
+		require.NoError(t, err)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		var opErr *snet.OpError
+		_, err = conn.Read(make([]byte, 16))
+		assert.ErrorAs(t, err, &opErr)
+
+		assert.Eventually(t, func() bool {
+			return snet.Fingerprint(conn.Path()) == snet.Fingerprint(p2)
+		}, time.Second, 10*time.Millisecond)
+		_, err = conn.Write([]byte("b"))
+		require.NoError(t, err)
+		assert.Equal(t, "b", br2.received(t))
+
+		// Packets written to the remote address use the new path, whatever
+		// path the address carries.
+		_, err = conn.WriteTo([]byte("c"), &snet.UDPAddr{
+			IA:      remoteIA,
+			Host:    remote.Host,
+			Path:    p1.Dataplane(),
+			NextHop: p1.UnderlayNextHop(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "c", br2.received(t))
+	})
+	t.Run("probe timeout", func(t *testing.T) {
+		br1, br2 := newTestBorderRouter(t, remoteIA), newTestBorderRouter(t, remoteIA)
+		br2.answerProbes.Store(true)
+		p1 := twoHopPath(t, localIA, remoteIA, br1, 1, 2, time.Hour)
+		p2 := twoHopPath(t, localIA, remoteIA, br2, 3, 4, time.Hour)
+		conn, err := network().DialFailover(context.Background(), listen, remote,
+			failoverTestPaths{p1, p2}, snet.WithProbeInterval(20*time.Millisecond))
+		require.NoError(t, err)
+		defer conn.Close()
+		go func() {
+			buf := make([]byte, 16)
+			for {
+				if _, err := conn.Read(buf); err != nil {
+					return
+				}
+			}
+		}()
+
+		assert.Eventually(t, func() bool {
+			return snet.Fingerprint(conn.Path()) == snet.Fingerprint(p2)
+		}, time.Second, 10*time.Millisecond)
+		// The second border router replies to the probes, so the path is
+		// kept.
+		time.Sleep(200 * time.Millisecond)
+		assert.Equal(t, snet.Fingerprint(p2), snet.Fingerprint(conn.Path()))
+		_, err = conn.Write([]byte("a"))
+		require.NoError(t, err)
+		assert.Equal(t, "a", br2.received(t))
+	})
+	t.Run("policy", func(t *testing.T) {
+		br1, br2 := newTestBorderRouter(t, remoteIA), newTestBorderRouter(t, remoteIA)
+		p1 := twoHopPath(t, localIA, remoteIA, br1, 1, 2, time.Hour)
+		p2 := twoHopPath(t, localIA, remoteIA, br2, 3, 4, time.Hour)
+		// The policy only allows the second path.
+		policy := snet.PathPolicyFunc(func(paths []snet.Path) []snet.Path {
+			return paths[1:]
+		})
+		conn, err := network().DialFailover(context.Background(), listen, remote,
+			failoverTestPaths{p1, p2}, snet.WithPathPolicy(policy))
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.Equal(t, snet.Fingerprint(p2), snet.Fingerprint(conn.Path()))
+	})
+	t.Run("expiry", func(t *testing.T) {
+		br1, br2 := newTestBorderRouter(t, remoteIA), newTestBorderRouter(t, remoteIA)
+		p1 := twoHopPath(t, localIA, remoteIA, br1, 1, 2, 10*time.Second)
+		p2 := twoHopPath(t, localIA, remoteIA, br2, 3, 4, time.Hour)
+		conn, err := network().DialFailover(context.Background(), listen, remote,
+			failoverTestPaths{p1, p2})
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.Equal(t, snet.Fingerprint(p2), snet.Fingerprint(conn.Path()))
+
+		_, err = network().DialFailover(context.Background(), listen, remote,
+			failoverTestPaths{p1})
+		assert.Error(t, err)
+	})
+}
+
+// twoHopPath returns a path from localIA to remoteIA via br, with the
+// interfaces egress and ingress, that expires after expiry.
+func twoHopPath(t *testing.T, localIA, remoteIA addr.IA, br *testBorderRouter,
+	egress, ingress uint16, expiry time.Duration) snet.Path {
+
+	decoded := scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{SegLen: [3]uint8{2, 0, 0}},
+			NumINF:   1,
+			NumHops:  2,
+		},
+		InfoFields: []path.InfoField{{ConsDir: true}},
+		HopFields: []path.HopField{
+			{ConsEgress: egress, ExpTime: 63},
+			{ConsIngress: ingress, ExpTime: 63},
+		},
+	}
+	dp, err := snetpath.NewSCIONFromDecoded(decoded)
+	require.NoError(t, err)
+	return snetpath.Path{
+		Src:           localIA,
+		Dst:           remoteIA,
+		DataplanePath: dp,
+		NextHop:       br.conn.LocalAddr().(*net.UDPAddr),
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: localIA, ID: iface.ID(egress)},
+				{IA: remoteIA, ID: iface.ID(ingress)},
+			},
+			Expiry: time.Now().Add(expiry),
+		},
+	}
+}
+
+// failoverTestPaths is a router that returns a fixed set of paths.
+type failoverTestPaths []snet.Path
+
+func (p failoverTestPaths) Route(context.Context, addr.IA) (snet.Path, error) {
+	return p[0], nil
+}
+
+func (p failoverTestPaths) AllRoutes(context.Context, addr.IA) ([]snet.Path, error) {
+	return p, nil
+}
+
+// testBorderRouter is a border router that passes the payloads of the UDP
+// packets it receives to the test, and replies to SCMP traceroute requests
+// with the router alert set if answerProbes is set.
+type testBorderRouter struct {
+	conn         *net.UDPConn
+	payloads     chan string
+	answerProbes atomic.Bool
+}
+
+func newTestBorderRouter(t *testing.T, ia addr.IA) *testBorderRouter {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	loopback := addr.HostIP(netip.MustParseAddr("127.0.0.1"))
+	r := &testBorderRouter{conn: conn, payloads: make(chan string, 16)}
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, src, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			pkt := &snet.Packet{Bytes: append([]byte(nil), buf[:n]...)}
+			if err := pkt.Decode(); err != nil {
+				continue
+			}
+			switch pld := pkt.Payload.(type) {
+			case snet.UDPPayload:
+				r.payloads <- string(pld.Payload)
+			case snet.SCMPTracerouteRequest:
+				if !r.answerProbes.Load() {
+					continue
+				}
+				reply := &snet.Packet{
+					PacketInfo: snet.PacketInfo{
+						Source:      snet.SCIONAddress{IA: ia, Host: loopback},
+						Destination: pkt.Source,
+						Path:        snetpath.Empty{},
+						Payload: snet.SCMPTracerouteReply{
+							Identifier: pld.Identifier,
+							Sequence:   pld.Sequence,
+						},
+					},
+				}
+				if err := reply.Serialize(); err == nil {
+					_, _ = conn.WriteToUDP(reply.Bytes, src)
+				}
+			}
+		}
+	}()
+	return r
+}
+
+func (r *testBorderRouter) received(t *testing.T) string {
+	select {
+	case p := <-r.payloads:
+		return p
+	case <-time.After(time.Second):
+		t.Fatal("no packet received")
+		return ""
+	}
+}
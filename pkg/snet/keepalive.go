@@ -0,0 +1,217 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+)
+
+// keepaliveSessionTimeout is the time after the last packet the application
+// sent via a border router for which the NAT mapping toward that border router
+// is kept alive.
+const keepaliveSessionTimeout = 10 * time.Minute
+
+// sharedAddressSpace is the carrier-grade NAT range defined in RFC 6598.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// behindNAT returns whether packets from src to the border router at nextHop
+// are likely to be translated by a NAT. This is the case if the host uses a
+// private or shared IPv4 address to reach a border router with a public one.
+func behindNAT(src, nextHop netip.Addr) bool {
+	isPrivate := func(a netip.Addr) bool {
+		return a.IsPrivate() || sharedAddressSpace.Contains(a)
+	}
+	src, nextHop = src.Unmap(), nextHop.Unmap()
+	return src.Is4() && isPrivate(src) &&
+		nextHop.Is4() && nextHop.IsGlobalUnicast() && !isPrivate(nextHop)
+}
+
+// natKeepalive keeps the NAT mappings toward the first-hop border routers of
+// a connection alive. If the application has not sent a packet via a border
+// router for the keepalive interval, an SCMP traceroute request is sent to
+// that border router, with the router alert set on the first hop of the last
+// path used. The border router replies to it, which refreshes the mapping in
+// both directions. The reply is consumed by the SCMP handler of the
+// connection.
+type natKeepalive struct {
+	interval time.Duration
+	send     func(h keepaliveHop, seq uint16)
+
+	mtx     sync.Mutex
+	hops    map[netip.AddrPort]*keepaliveHop
+	seq     uint16
+	running bool
+	stop    chan struct{}
+	stopped bool
+}
+
+// keepaliveHop is a first-hop border router for which keepalives are sent.
+type keepaliveHop struct {
+	nextHop *net.UDPAddr
+	dst     SCIONAddress
+	// path is the last path the application used via the border router.
+	path DataplanePath
+	// lastSent is the time of the last packet sent via the border router,
+	// including keepalives.
+	lastSent time.Time
+	// lastUsed is the time of the last packet the application sent via the
+	// border router.
+	lastUsed time.Time
+}
+
+func newNATKeepalive(interval time.Duration, send func(keepaliveHop, uint16)) *natKeepalive {
+	return &natKeepalive{
+		interval: interval,
+		send:     send,
+		hops:     make(map[netip.AddrPort]*keepaliveHop),
+		stop:     make(chan struct{}),
+	}
+}
+
+// sent records that the application sent a packet to dst via the border
+// router at nextHop on path, through a NAT.
+func (k *natKeepalive) sent(nextHop *net.UDPAddr, dst SCIONAddress, path DataplanePath) {
+	key := nextHop.AddrPort()
+	now := time.Now()
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if k.stopped {
+		return
+	}
+	h, ok := k.hops[key]
+	if !ok {
+		h = &keepaliveHop{nextHop: CopyUDPAddr(nextHop)}
+		k.hops[key] = h
+	}
+	h.dst, h.path, h.lastSent, h.lastUsed = dst, path, now, now
+	if !k.running {
+		k.running = true
+		go func() {
+			defer log.HandlePanic()
+			k.run()
+		}()
+	}
+}
+
+func (k *natKeepalive) run() {
+	ticker := time.NewTicker(k.interval / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-k.stop:
+			return
+		case now := <-ticker.C:
+			for _, h := range k.due(now) {
+				k.send(h.hop, h.seq)
+			}
+		}
+	}
+}
+
+type dueKeepalive struct {
+	hop keepaliveHop
+	seq uint16
+}
+
+// due returns the keepalives to send at now, and forgets the border routers
+// the application stopped using.
+func (k *natKeepalive) due(now time.Time) []dueKeepalive {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	var due []dueKeepalive
+	for key, h := range k.hops {
+		if now.Sub(h.lastUsed) > keepaliveSessionTimeout {
+			delete(k.hops, key)
+			continue
+		}
+		if now.Sub(h.lastSent) < k.interval {
+			continue
+		}
+		h.lastSent = now
+		k.seq++
+		due = append(due, dueKeepalive{hop: *h, seq: k.seq})
+	}
+	return due
+}
+
+func (k *natKeepalive) close() {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if !k.stopped {
+		k.stopped = true
+		close(k.stop)
+	}
+}
+
+// routerAlertPath returns a copy of path with the router alert set on the
+// first or the last hop field: for the egress interface of the local AS, or
+// for the ingress interface of the destination AS. Only SCION paths are
+// supported.
+func routerAlertPath(path DataplanePath, last bool) (DataplanePath, error) {
+	if path == nil {
+		return nil, serrors.New("no path")
+	}
+	var scn slayers.SCION
+	if err := path.SetPath(&scn); err != nil {
+		return nil, err
+	}
+	raw, ok := scn.Path.(*scion.Raw)
+	if scn.PathType != scion.PathType || !ok {
+		return nil, serrors.New("unsupported path type", "type", scn.PathType)
+	}
+	var decoded scion.Decoded
+	if err := decoded.DecodeFromBytes(raw.Raw); err != nil {
+		return nil, serrors.Wrap("decoding path", err)
+	}
+	if decoded.NumINF == 0 || decoded.NumHops == 0 {
+		return nil, serrors.New("empty path")
+	}
+	info, hop := &decoded.InfoFields[0], &decoded.HopFields[0]
+	if last {
+		info, hop = &decoded.InfoFields[decoded.NumINF-1], &decoded.HopFields[decoded.NumHops-1]
+	}
+	// The first hop is left on the egress interface and the last hop is
+	// entered on the ingress interface, in the direction of travel.
+	if info.ConsDir != last {
+		hop.EgressRouterAlert = true
+	} else {
+		hop.IngressRouterAlert = true
+	}
+	b := make([]byte, decoded.Len())
+	if err := decoded.SerializeTo(b); err != nil {
+		return nil, serrors.Wrap("serializing path", err)
+	}
+	return alertPath(b), nil
+}
+
+// alertPath is a raw SCION path with a router alert set.
+type alertPath []byte
+
+func (p alertPath) SetPath(s *slayers.SCION) error {
+	var sp scion.Raw
+	if err := sp.DecodeFromBytes(p); err != nil {
+		return err
+	}
+	s.Path, s.PathType = &sp, sp.Type()
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+)
+
+func TestBehindNAT(t *testing.T) {
+	testCases := map[string]struct {
+		Src, NextHop string
+		Expected     bool
+	}{
+		"private to public":         {"192.168.1.10", "203.0.113.1", true},
+		"shared to public":          {"100.64.0.10", "203.0.113.1", true},
+		"mapped private to public":  {"::ffff:10.0.0.10", "203.0.113.1", true},
+		"private to private":        {"10.0.0.10", "10.0.0.1", false},
+		"public to public":          {"198.51.100.10", "203.0.113.1", false},
+		"private to loopback":       {"192.168.1.10", "127.0.0.1", false},
+		"IPv6 unique local to IPv6": {"fd00::10", "2001:db8::1", false},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected,
+				behindNAT(netip.MustParseAddr(tc.Src), netip.MustParseAddr(tc.NextHop)))
+		})
+	}
+}
+
+func TestNATKeepalive(t *testing.T) {
+	const interval = time.Minute
+	var sent []keepaliveHop
+	k := newNATKeepalive(interval, func(h keepaliveHop, _ uint16) { sent = append(sent, h) })
+	// Prevent the background loop from starting.
+	k.running = true
+
+	nextHop := &net.UDPAddr{IP: net.ParseIP("203.0.113.1"), Port: 50000}
+	dst := SCIONAddress{IA: addr.MustParseIA("1-ff00:0:2"), Host: addr.MustParseHost("10.0.0.1")}
+	k.sent(nextHop, dst, testSCIONPath(t))
+	k.sent(nextHop, dst, testSCIONPath(t))
+	require.Len(t, k.hops, 1)
+
+	start := k.hops[nextHop.AddrPort()].lastSent
+	assert.Empty(t, k.due(start.Add(interval/2)))
+	due := k.due(start.Add(interval))
+	require.Len(t, due, 1)
+	assert.Equal(t, dst, due[0].hop.dst)
+	assert.Empty(t, k.due(start.Add(interval+interval/2)))
+	assert.Len(t, k.due(start.Add(2*interval)), 1)
+
+	// A border router that the application stopped using is forgotten.
+	assert.Empty(t, k.due(start.Add(keepaliveSessionTimeout+time.Second)))
+	assert.Empty(t, k.hops)
+
+	k.close()
+	k.sent(nextHop, dst, testSCIONPath(t))
+	assert.Empty(t, k.hops)
+}
+
+func TestFirstHopAlertPath(t *testing.T) {
+	p, err := routerAlertPath(testSCIONPath(t), false)
+	require.NoError(t, err)
+
+	var scn slayers.SCION
+	require.NoError(t, p.SetPath(&scn))
+	var decoded scion.Decoded
+	require.NoError(t, decoded.DecodeFromBytes(scn.Path.(*scion.Raw).Raw))
+	assert.True(t, decoded.HopFields[0].EgressRouterAlert)
+	assert.False(t, decoded.HopFields[0].IngressRouterAlert)
+	assert.False(t, decoded.HopFields[1].IngressRouterAlert)
+
+	_, err = routerAlertPath(RawPath{}, false)
+	assert.Error(t, err)
+}
+
+func TestLastHopAlertPath(t *testing.T) {
+	p, err := routerAlertPath(testSCIONPath(t), true)
+	require.NoError(t, err)
+
+	var scn slayers.SCION
+	require.NoError(t, p.SetPath(&scn))
+	var decoded scion.Decoded
+	require.NoError(t, decoded.DecodeFromBytes(scn.Path.(*scion.Raw).Raw))
+	assert.False(t, decoded.HopFields[0].EgressRouterAlert)
+	assert.True(t, decoded.HopFields[1].IngressRouterAlert)
+	assert.False(t, decoded.HopFields[1].EgressRouterAlert)
+}
+
+// testPath is a DataplanePath backed by a raw SCION path.
+type testPath struct {
+	raw []byte
+}
+
+func (p testPath) SetPath(s *slayers.SCION) error {
+	var sp scion.Raw
+	if err := sp.DecodeFromBytes(p.raw); err != nil {
+		return err
+	}
+	s.Path, s.PathType = &sp, sp.Type()
+	return nil
+}
+
+// testSCIONPath returns a two-hop SCION path in construction direction.
+func testSCIONPath(t *testing.T) DataplanePath {
+	decoded := scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{SegLen: [3]uint8{2, 0, 0}},
+			NumINF:   1,
+			NumHops:  2,
+		},
+		InfoFields: []path.InfoField{{ConsDir: true}},
+		HopFields:  []path.HopField{{ConsEgress: 1}, {ConsIngress: 2}},
+	}
+	raw := make([]byte, decoded.Len())
+	require.NoError(t, decoded.SerializeTo(raw))
+	return testPath{raw: raw}
+}
@@ -0,0 +1,422 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// defaultMultipathProbeInterval is the default interval of the probes of
+	// a MultipathConn.
+	defaultMultipathProbeInterval = time.Second
+	// minCongestionFactor is the lowest factor the weight of a congested path
+	// is reduced to.
+	minCongestionFactor = 1.0 / 16
+	// congestionIncrease is the amount by which the congestion factor of a
+	// path grows with every probe answered in time.
+	congestionIncrease = 1.0 / 8
+	// queueingRTTFactor is the factor by which the RTT of a probe must exceed
+	// the lowest RTT of the path for the path to be considered congested.
+	queueingRTTFactor = 2
+	// minQueueingDelay is the increase of the RTT over the lowest RTT below
+	// which a path is not considered congested, to ignore the jitter of paths
+	// with a low RTT.
+	minQueueingDelay = 10 * time.Millisecond
+)
+
+// SchedulingPolicy is the way a MultipathConn distributes the packets across
+// its paths.
+type SchedulingPolicy int
+
+const (
+	// RoundRobin sends the packets on the paths in turn.
+	RoundRobin SchedulingPolicy = iota
+	// Weighted sends the packets on the paths in proportion to their weight.
+	Weighted
+	// Redundant sends every packet on all paths.
+	Redundant
+)
+
+func (p SchedulingPolicy) String() string {
+	switch p {
+	case RoundRobin:
+		return "round-robin"
+	case Weighted:
+		return "weighted"
+	case Redundant:
+		return "redundant"
+	default:
+		return "unknown"
+	}
+}
+
+// WeightedPath is a path of a MultipathConn with its weight for the Weighted
+// policy.
+type WeightedPath struct {
+	Path Path
+	// Weight is the relative share of the packets sent on the path. Weights
+	// below 1 are treated as 1.
+	Weight int
+}
+
+// MultipathConfig configures a MultipathConn.
+type MultipathConfig struct {
+	// Policy is the way the packets are distributed across the paths.
+	Policy SchedulingPolicy
+	// ProbeInterval is the interval at which each path is probed for
+	// tracking its congestion. If zero, the paths are probed every second.
+	ProbeInterval time.Duration
+}
+
+// PathStats are the statistics of a path of a MultipathConn.
+type PathStats struct {
+	Path Path
+	// Share is the current share of the packets sent on the path, between 0
+	// and 1, with the weights reduced for congestion. For the Redundant
+	// policy, it is 1 for all paths.
+	Share float64
+	// RTT is the smoothed round-trip time of the probes to the border router
+	// of the remote AS. It is zero if no probe was answered yet.
+	RTT time.Duration
+	// Loss is the estimated fraction of the probes that are lost.
+	Loss float64
+	// Packets is the number of packets sent on the path.
+	Packets uint64
+}
+
+// MultipathConn is a connection to a fixed remote address that distributes
+// its packets across several paths to the remote AS, for aggregate bandwidth
+// or, with the Redundant policy, reliability.
+//
+// The congestion of each path is tracked with SCMP traceroute probes to the
+// border router at the last hop of the path: if a probe is lost, or its RTT
+// exceeds both twice the lowest RTT seen on the path and that RTT plus 10ms,
+// the weight of the path is halved, down to a sixteenth; every probe answered
+// in time restores an eighth of it. The probe replies are processed while
+// reading from the connection, so the application must keep reading for them
+// to be noticed.
+type MultipathConn struct {
+	*Conn
+	remote UDPAddr
+
+	mtx       sync.Mutex
+	scheduler multipathScheduler
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// DialMultipath returns a connection to remote that sends its packets on
+// paths according to cfg, see MultipathConn. The path and next hop of remote
+// are ignored; the remote AS must not be the local AS, and all paths must
+// lead to it.
+//
+// The context is used for connection setup, it doesn't affect the returned
+// connection.
+func (n *SCIONNetwork) DialMultipath(ctx context.Context, listen *net.UDPAddr,
+	remote *UDPAddr, paths []WeightedPath, cfg MultipathConfig) (*MultipathConn, error) {
+
+	if remote == nil {
+		return nil, serrors.New("Unable to dial to nil remote")
+	}
+	if remote.IA.Equal(n.Topology.LocalIA) {
+		return nil, serrors.New("multipath requires a remote AS", "isd_as", remote.IA)
+	}
+	if len(paths) == 0 {
+		return nil, serrors.New("no path", "isd_as", remote.IA)
+	}
+	for i, p := range paths {
+		if p.Path == nil || p.Path.UnderlayNextHop() == nil ||
+			!p.Path.Destination().Equal(remote.IA) {
+			return nil, serrors.New("invalid path", "index", i, "isd_as", remote.IA)
+		}
+	}
+	switch cfg.Policy {
+	case RoundRobin, Weighted, Redundant:
+	default:
+		return nil, serrors.New("unknown scheduling policy", "policy", cfg.Policy)
+	}
+	c := &MultipathConn{
+		remote:    UDPAddr{IA: remote.IA, Host: CopyUDPAddr(remote.Host)},
+		scheduler: newMultipathScheduler(paths, cfg.Policy),
+		stop:      make(chan struct{}),
+	}
+	conn, err := n.Dial(ctx, "udp", listen, c.remoteVia(paths[0].Path))
+	if err != nil {
+		return nil, err
+	}
+	c.Conn = conn
+	if pc, ok := conn.conn.(*SCIONPacketConn); ok {
+		pc.SCMPHandler = multipathSCMPHandler{conn: c, next: pc.SCMPHandler}
+	}
+	interval := cfg.ProbeInterval
+	if interval <= 0 {
+		interval = defaultMultipathProbeInterval
+	}
+	go func() {
+		defer log.HandlePanic()
+		c.run(interval)
+	}()
+	return c, nil
+}
+
+// Stats returns the statistics of the paths, in the order they were given.
+func (c *MultipathConn) Stats() []PathStats {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.scheduler.stats()
+}
+
+// Write sends b to the remote address on the next path, or on all paths for
+// the Redundant policy. With the Redundant policy, it returns an error only
+// if sending failed on all paths.
+func (c *MultipathConn) Write(b []byte) (int, error) {
+	c.mtx.Lock()
+	var paths []Path
+	if c.scheduler.policy == Redundant {
+		paths = c.scheduler.all()
+	} else {
+		paths = []Path{c.scheduler.next()}
+	}
+	c.mtx.Unlock()
+
+	var errs serrors.List
+	for _, p := range paths {
+		if _, err := c.Conn.WriteTo(b, c.remoteVia(p)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == len(paths) {
+		return 0, errs.ToError()
+	}
+	return len(b), nil
+}
+
+func (c *MultipathConn) Close() error {
+	c.stopOnce.Do(func() { close(c.stop) })
+	return c.Conn.Close()
+}
+
+func (c *MultipathConn) remoteVia(path Path) *UDPAddr {
+	return &UDPAddr{
+		IA:      c.remote.IA,
+		Host:    CopyUDPAddr(c.remote.Host),
+		Path:    path.Dataplane(),
+		NextHop: path.UnderlayNextHop(),
+	}
+}
+
+func (c *MultipathConn) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-ticker.C:
+			c.mtx.Lock()
+			probes := c.scheduler.probe(now)
+			c.mtx.Unlock()
+			dst := SCIONAddress{IA: c.remote.IA, Host: addr.HostSVC(addr.SvcNone)}
+			for _, p := range probes {
+				err := c.Conn.scionConnWriter.writeTraceroute(p.path.UnderlayNextHop(), dst,
+					p.path.Dataplane(), true, p.seq)
+				if err != nil {
+					log.Debug("Probing path", "isd_as", c.remote.IA, "err", err)
+				}
+			}
+		}
+	}
+}
+
+// probeReply records the reply to a probe, and returns whether pkt is one.
+func (c *MultipathConn) probeReply(pkt *Packet) bool {
+	reply, ok := pkt.Payload.(SCMPTracerouteReply)
+	if !ok || !pkt.Source.IA.Equal(c.remote.IA) {
+		return false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.scheduler.reply(reply.Sequence, time.Now())
+}
+
+// multipathSCMPHandler passes the probe replies of a MultipathConn to it,
+// and the other SCMP messages to the next handler.
+type multipathSCMPHandler struct {
+	conn *MultipathConn
+	next SCMPHandler
+}
+
+func (h multipathSCMPHandler) Handle(pkt *Packet) error {
+	if h.conn.probeReply(pkt) {
+		return nil
+	}
+	if h.next == nil {
+		return serrors.New("scmp packet received, but no handler found", "src", pkt.Source)
+	}
+	return h.next.Handle(pkt)
+}
+
+// multipathScheduler picks the paths of the packets of a MultipathConn, and
+// tracks the congestion of the paths from the probes.
+type multipathScheduler struct {
+	paths  []*pathState
+	policy SchedulingPolicy
+	seq    uint16
+}
+
+// pathState is the scheduling and congestion state of a path.
+type pathState struct {
+	path   Path
+	weight float64
+	// factor is the reduction of the weight for congestion, between
+	// minCongestionFactor and 1.
+	factor float64
+	// current is the running weight for smooth weighted round-robin.
+	current float64
+	srtt    time.Duration
+	minRTT  time.Duration
+	loss    float64
+	packets uint64
+	// probeSeq is the sequence number of the outstanding probe and probeSent
+	// the time it was sent; probeSent is zero if there is none.
+	probeSeq  uint16
+	probeSent time.Time
+}
+
+func newMultipathScheduler(paths []WeightedPath, policy SchedulingPolicy) multipathScheduler {
+	s := multipathScheduler{policy: policy}
+	for _, p := range paths {
+		weight := 1.0
+		if policy == Weighted && p.Weight > 1 {
+			weight = float64(p.Weight)
+		}
+		s.paths = append(s.paths, &pathState{path: p.Path, weight: weight, factor: 1})
+	}
+	return s
+}
+
+// next returns the path for the next packet, with smooth weighted
+// round-robin over the weights reduced for congestion: the paths are picked
+// in proportion to their weight, interleaved as evenly as possible.
+func (s *multipathScheduler) next() Path {
+	var total float64
+	var best *pathState
+	for _, p := range s.paths {
+		w := p.weight * p.factor
+		p.current += w
+		total += w
+		if best == nil || p.current > best.current {
+			best = p
+		}
+	}
+	best.current -= total
+	best.packets++
+	return best.path
+}
+
+// all returns all paths, for a packet sent on every path.
+func (s *multipathScheduler) all() []Path {
+	paths := make([]Path, 0, len(s.paths))
+	for _, p := range s.paths {
+		p.packets++
+		paths = append(paths, p.path)
+	}
+	return paths
+}
+
+type pathProbe struct {
+	path Path
+	seq  uint16
+}
+
+// probe returns the probes to send at now, one per path. A probe that was
+// not answered until the next one is sent is lost.
+func (s *multipathScheduler) probe(now time.Time) []pathProbe {
+	probes := make([]pathProbe, 0, len(s.paths))
+	for _, p := range s.paths {
+		if !p.probeSent.IsZero() {
+			p.loss = 0.9*p.loss + 0.1
+			p.congested()
+		}
+		s.seq++
+		p.probeSeq, p.probeSent = s.seq, now
+		probes = append(probes, pathProbe{path: p.path, seq: s.seq})
+	}
+	return probes
+}
+
+// reply records the reply to the probe seq received at now, and returns
+// whether seq is an outstanding probe.
+func (s *multipathScheduler) reply(seq uint16, now time.Time) bool {
+	for _, p := range s.paths {
+		if p.probeSent.IsZero() || p.probeSeq != seq {
+			continue
+		}
+		rtt := now.Sub(p.probeSent)
+		p.probeSent = time.Time{}
+		if p.srtt == 0 {
+			p.srtt = rtt
+		} else {
+			p.srtt = (7*p.srtt + rtt) / 8
+		}
+		if p.minRTT == 0 || rtt < p.minRTT {
+			p.minRTT = rtt
+		}
+		p.loss *= 0.9
+		if rtt > queueingRTTFactor*p.minRTT && rtt-p.minRTT > minQueueingDelay {
+			p.congested()
+		} else {
+			p.factor = min(1, p.factor+congestionIncrease)
+		}
+		return true
+	}
+	return false
+}
+
+// congested halves the weight of the path, down to minCongestionFactor.
+func (p *pathState) congested() {
+	p.factor = max(minCongestionFactor, p.factor/2)
+}
+
+func (s *multipathScheduler) stats() []PathStats {
+	var total float64
+	for _, p := range s.paths {
+		total += p.weight * p.factor
+	}
+	stats := make([]PathStats, 0, len(s.paths))
+	for _, p := range s.paths {
+		share := 1.0
+		if s.policy != Redundant {
+			share = p.weight * p.factor / total
+		}
+		stats = append(stats, PathStats{
+			Path:    p.path,
+			Share:   share,
+			RTT:     p.srtt,
+			Loss:    p.loss,
+			Packets: p.packets,
+		})
+	}
+	return stats
+}
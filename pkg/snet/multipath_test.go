@@ -0,0 +1,133 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+func TestMultipathConn(t *testing.T) {
+	localIA := addr.MustParseIA("1-ff00:0:110")
+	remoteIA := addr.MustParseIA("1-ff00:0:111")
+	remote := &snet.UDPAddr{
+		IA:   remoteIA,
+		Host: &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 40000},
+	}
+	listen := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}
+	network := &snet.SCIONNetwork{
+		Topology: snet.Topology{
+			LocalIA:   localIA,
+			PortRange: snet.TopologyPortRange{Start: 40140, End: 40149},
+		},
+		SCMPHandler: snet.DefaultSCMPHandler{},
+	}
+	dial := func(t *testing.T, weights [2]int, cfg snet.MultipathConfig) (
+		*snet.MultipathConn, [2]*testBorderRouter) {
+
+		br := [2]*testBorderRouter{
+			newTestBorderRouter(t, remoteIA),
+			newTestBorderRouter(t, remoteIA),
+		}
+		paths := []snet.WeightedPath{
+			{Path: twoHopPath(t, localIA, remoteIA, br[0], 1, 2, time.Hour), Weight: weights[0]},
+			{Path: twoHopPath(t, localIA, remoteIA, br[1], 3, 4, time.Hour), Weight: weights[1]},
+		}
+		conn, err := network.DialMultipath(context.Background(), listen, remote, paths, cfg)
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return conn, br
+	}
+	// Probes are sent rarely enough not to affect the tests that do not
+	// answer them.
+	noProbes := time.Hour
+
+	t.Run("round robin", func(t *testing.T) {
+		conn, br := dial(t, [2]int{3, 1},
+			snet.MultipathConfig{Policy: snet.RoundRobin, ProbeInterval: noProbes})
+		for _, p := range []string{"a", "b", "c", "d"} {
+			_, err := conn.Write([]byte(p))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, "a", br[0].received(t))
+		assert.Equal(t, "c", br[0].received(t))
+		assert.Equal(t, "b", br[1].received(t))
+		assert.Equal(t, "d", br[1].received(t))
+	})
+	t.Run("weighted", func(t *testing.T) {
+		conn, br := dial(t, [2]int{3, 1},
+			snet.MultipathConfig{Policy: snet.Weighted, ProbeInterval: noProbes})
+		for range 8 {
+			_, err := conn.Write([]byte("a"))
+			require.NoError(t, err)
+		}
+		for range 6 {
+			br[0].received(t)
+		}
+		for range 2 {
+			br[1].received(t)
+		}
+		stats := conn.Stats()
+		require.Len(t, stats, 2)
+		assert.Equal(t, uint64(6), stats[0].Packets)
+		assert.Equal(t, uint64(2), stats[1].Packets)
+		assert.InDelta(t, 0.75, stats[0].Share, 1e-9)
+		assert.InDelta(t, 0.25, stats[1].Share, 1e-9)
+	})
+	t.Run("redundant", func(t *testing.T) {
+		conn, br := dial(t, [2]int{1, 1},
+			snet.MultipathConfig{Policy: snet.Redundant, ProbeInterval: noProbes})
+		_, err := conn.Write([]byte("a"))
+		require.NoError(t, err)
+		assert.Equal(t, "a", br[0].received(t))
+		assert.Equal(t, "a", br[1].received(t))
+	})
+	t.Run("congestion", func(t *testing.T) {
+		conn, br := dial(t, [2]int{1, 1},
+			snet.MultipathConfig{Policy: snet.RoundRobin, ProbeInterval: 20 * time.Millisecond})
+		br[1].answerProbes.Store(true)
+		go func() {
+			buf := make([]byte, 16)
+			for {
+				if _, err := conn.Read(buf); err != nil {
+					return
+				}
+			}
+		}()
+
+		// The probes on the first path are lost, so its weight is reduced to
+		// a sixteenth.
+		assert.Eventually(t, func() bool {
+			stats := conn.Stats()
+			return stats[0].Share < 0.1 && stats[1].RTT > 0
+		}, time.Second, 10*time.Millisecond)
+		stats := conn.Stats()
+		assert.Greater(t, stats[0].Loss, 0.0)
+		assert.Less(t, stats[1].Loss, stats[0].Loss)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := network.DialMultipath(context.Background(), listen, remote, nil,
+			snet.MultipathConfig{})
+		assert.Error(t, err)
+	})
+}
@@ -16,6 +16,8 @@ package snet
 
 import (
 	"net"
+	"net/netip"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -124,6 +126,25 @@ type SCIONPacketConn struct {
 	Metrics SCIONPacketConnMetrics
 	// Topology provides interface information for the local AS.
 	Topology Topology
+
+	// public are the public addresses of the connection if the host is behind
+	// a NAT. It is nil if they are not used.
+	public *publicAddresses
+	// scmpErrorHandler is called for the SCMP errors received, see
+	// SetSCMPErrorHandler.
+	scmpErrorHandler atomic.Pointer[SCMPErrorHandler]
+}
+
+// SetSCMPErrorHandler registers a handler that is called for each SCMP error
+// received on the connection, before the SCMPHandler processes it. A nil
+// handler removes the registered one. It may be called while the connection
+// is read from.
+func (c *SCIONPacketConn) SetSCMPErrorHandler(handler SCMPErrorHandler) {
+	if handler == nil {
+		c.scmpErrorHandler.Store(nil)
+		return
+	}
+	c.scmpErrorHandler.Store(&handler)
 }
 
 func (c *SCIONPacketConn) SetReadBuffer(bytes int) error {
@@ -179,13 +200,18 @@ func (c *SCIONPacketConn) ReadFrom(pkt *Packet, ov *net.UDPAddr) error {
 		}
 		*ov = *remoteAddr
 		if scmp, ok := pkt.Payload.(SCMPPayload); ok {
+			if handler := c.scmpErrorHandler.Load(); handler != nil {
+				if scmpErr, ok := newSCMPError(pkt, scmp); ok {
+					(*handler)(scmpErr)
+				}
+			}
 			if c.SCMPHandler == nil {
 				metrics.CounterInc(c.Metrics.SCMPErrors)
 				return serrors.New("scmp packet received, but no handler found",
 					"type_code", slayers.CreateSCMPTypeCode(scmp.Type(), scmp.Code()),
 					"src", pkt.Source)
 			}
-			if err := c.SCMPHandler.Handle(pkt); err != nil {
+			if err := HandleSCMPUnderlay(c.SCMPHandler, pkt, remoteAddr); err != nil {
 				// Return error intact s.t. applications can handle custom
 				// error types returned by SCMP handlers.
 				return err
@@ -204,7 +230,12 @@ func (c *SCIONPacketConn) SyscallConn() (syscall.RawConn, error) {
 
 func (c *SCIONPacketConn) readFrom(pkt *Packet) (*net.UDPAddr, error) {
 	pkt.Prepare()
-	n, remoteAddr, err := c.Conn.ReadFrom(pkt.Bytes)
+	localAddr := c.LocalAddr().(*net.UDPAddr)
+	var oob []byte
+	if isWildcard(localAddr) {
+		oob = newPacketInfoBuffer(localAddr)
+	}
+	n, oobn, _, udpRemoteAddr, err := c.Conn.ReadMsgUDP(pkt.Bytes, oob)
 	if err != nil {
 		metrics.CounterInc(c.Metrics.UnderlayConnectionErrors)
 		return nil, serrors.Wrap("reading underlay connection", err)
@@ -213,6 +244,9 @@ func (c *SCIONPacketConn) readFrom(pkt *Packet) (*net.UDPAddr, error) {
 	metrics.CounterInc(c.Metrics.ReadPackets)
 
 	pkt.Bytes = pkt.Bytes[:n]
+	if c.public.handle(pkt.Bytes, udpRemoteAddr.AddrPort()) {
+		return nil, nil
+	}
 	if err := pkt.Decode(); err != nil {
 		metrics.CounterInc(c.Metrics.ParseErrors)
 		// XXX(JordiSubira): We avoid bubbling up parsing errors to the
@@ -221,10 +255,18 @@ func (c *SCIONPacketConn) readFrom(pkt *Packet) (*net.UDPAddr, error) {
 		log.Debug("decoding packet", "error", err)
 		return nil, nil
 	}
+	localIP := localAddr.IP
+	if oob != nil {
+		underlayDst, err := checkUnderlayDestination(pkt, localAddr, oob[:oobn], c.public)
+		if err != nil {
+			log.Debug("discarding packet", "error", err)
+			return nil, nil
+		}
+		localIP = underlayDst.AsSlice()
+	}
 
-	udpRemoteAddr := remoteAddr.(*net.UDPAddr)
 	lastHop := udpRemoteAddr
-	if c.isShimDispatcher(udpRemoteAddr) {
+	if c.isShimDispatcher(udpRemoteAddr, localIP) {
 		// XXX(JordiSubira): As stated in `SCIONPacketConn.isShimDispatcher()`, we consider
 		// *loopback:30041* as a shim address.
 		// However, if in an alternative setup we find an actual endhost behind
@@ -241,6 +283,29 @@ func (c *SCIONPacketConn) readFrom(pkt *Packet) (*net.UDPAddr, error) {
 	return lastHop, nil
 }
 
+// checkUnderlayDestination checks that the SCION destination address of a
+// packet received on a socket bound to an unspecified address matches the
+// destination address of the underlay packet, or is a public address of the
+// connection. It returns the underlay destination address.
+func checkUnderlayDestination(pkt *Packet, local *net.UDPAddr, oob []byte,
+	public *publicAddresses) (netip.Addr, error) {
+
+	underlayDst, err := parsePacketInfo(local, oob)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if pkt.Destination.Host.Type() != addr.HostTypeIP {
+		return underlayDst, nil
+	}
+	dst := pkt.Destination.Host.IP().Unmap()
+	if dst != underlayDst && !public.contains(netip.AddrPortFrom(dst, 0)) {
+		return netip.Addr{}, serrors.New(
+			"UDP/IP destination different from UDP/SCION destination",
+			"underlay", underlayDst, "scion", dst)
+	}
+	return underlayDst, nil
+}
+
 func (c *SCIONPacketConn) SetReadDeadline(d time.Time) error {
 	return c.Conn.SetReadDeadline(d)
 }
@@ -261,10 +326,12 @@ func (c *SCIONPacketConn) LocalAddr() net.Addr {
 // In IPv4 context, the OS will pick *loopback* as the source IP when reflecting the packet
 // from the shim dispatcher to the destination endhost. Thus, we check here if the packet
 // comes from *loopback:30041*.
-func (c *SCIONPacketConn) isShimDispatcher(udpAddr *net.UDPAddr) bool {
-	localAddr := c.LocalAddr().(*net.UDPAddr)
+//
+// The argument localIP is the address the packet was received on. It is the
+// address of the socket, unless the socket is bound to an unspecified address.
+func (c *SCIONPacketConn) isShimDispatcher(udpAddr *net.UDPAddr, localIP net.IP) bool {
 	return udpAddr.Port == underlay.EndhostPort &&
-		(udpAddr.IP.Equal(localAddr.IP) || udpAddr.IP.IsLoopback())
+		(udpAddr.IP.Equal(localIP) || udpAddr.IP.IsLoopback())
 }
 
 func (c *SCIONPacketConn) lastHop(p *Packet) (*net.UDPAddr, error) {
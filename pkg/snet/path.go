@@ -140,6 +140,10 @@ type PathMetadata struct {
 
 	// EpicAuths contains the EPIC authenticators.
 	EpicAuths EpicAuths
+
+	// Liveness is the result of the latest probe of the path by the daemon. It is only set if
+	// the daemon probes the paths.
+	Liveness PathLiveness
 }
 
 func (pm *PathMetadata) Copy() *PathMetadata {
@@ -161,9 +165,21 @@ func (pm *PathMetadata) Copy() *PathMetadata {
 			AuthPHVF: append([]byte(nil), pm.EpicAuths.AuthPHVF...),
 			AuthLHVF: append([]byte(nil), pm.EpicAuths.AuthLHVF...),
 		},
+		Liveness: pm.Liveness,
 	}
 }
 
+// PathLiveness is the result of a probe of a path.
+type PathLiveness struct {
+	// Reachable indicates whether the probe was answered.
+	Reachable bool
+	// RTT is the round trip time of the probe. It is only set if the path is reachable.
+	RTT time.Duration
+	// ProbedAt is the point in time when the path was probed. It is zero if the path has not
+	// been probed.
+	ProbedAt time.Time
+}
+
 // LinkType describes the underlying network for inter-domain links.
 type LinkType uint8
 
@@ -0,0 +1,143 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pathpolicy provides composable path policies for the snet
+// connections that select their paths, e.g., snet.FailoverConn:
+//
+//	policy := pathpolicy.Chain(
+//		pathpolicy.ExcludeAS(addr.MustParseIA("1-ff00:0:112")),
+//		pathpolicy.MaxHops(4),
+//		pathpolicy.LowestLatency(),
+//	)
+//
+// The policies do not modify the paths passed to them.
+package pathpolicy
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/path/pathpol"
+)
+
+// Chain returns a policy that applies policies in order, each to the paths
+// selected by the previous one.
+func Chain(policies ...snet.PathPolicy) snet.PathPolicy {
+	return snet.PathPolicyFunc(func(paths []snet.Path) []snet.Path {
+		for _, p := range policies {
+			paths = p.Filter(paths)
+		}
+		return paths
+	})
+}
+
+// LowestLatency returns a policy that orders the paths by their total
+// announced latency, lowest first. The paths for which the latency of a hop
+// is not announced come last, in their original order.
+func LowestLatency() snet.PathPolicy {
+	return snet.PathPolicyFunc(func(paths []snet.Path) []snet.Path {
+		type pathLatency struct {
+			path    snet.Path
+			latency time.Duration
+			known   bool
+		}
+		sorted := make([]pathLatency, 0, len(paths))
+		for _, p := range paths {
+			latency, known := totalLatency(p)
+			sorted = append(sorted, pathLatency{path: p, latency: latency, known: known})
+		}
+		slices.SortStableFunc(sorted, func(a, b pathLatency) int {
+			switch {
+			case a.known != b.known:
+				if a.known {
+					return -1
+				}
+				return 1
+			case !a.known:
+				return 0
+			default:
+				return cmp.Compare(a.latency, b.latency)
+			}
+		})
+		result := make([]snet.Path, 0, len(sorted))
+		for _, p := range sorted {
+			result = append(result, p.path)
+		}
+		return result
+	})
+}
+
+// totalLatency returns the sum of the announced latencies of the hops of
+// path, and whether all of them are announced.
+func totalLatency(path snet.Path) (time.Duration, bool) {
+	meta := path.Metadata()
+	if meta == nil || len(meta.Interfaces) == 0 ||
+		len(meta.Latency) != len(meta.Interfaces)-1 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, l := range meta.Latency {
+		if l < 0 {
+			return 0, false
+		}
+		total += l
+	}
+	return total, true
+}
+
+// ExcludeAS returns a policy that drops the paths that traverse any of ias.
+// An ISD-AS with the wildcard AS 0 excludes the whole ISD. The paths without
+// interface metadata are dropped, as they cannot be checked.
+func ExcludeAS(ias ...addr.IA) snet.PathPolicy {
+	return snet.PathPolicyFunc(func(paths []snet.Path) []snet.Path {
+		return slices.DeleteFunc(slices.Clone(paths), func(p snet.Path) bool {
+			meta := p.Metadata()
+			if meta == nil || len(meta.Interfaces) == 0 {
+				return true
+			}
+			return slices.ContainsFunc(meta.Interfaces, func(intf snet.PathInterface) bool {
+				return slices.ContainsFunc(ias, func(ia addr.IA) bool {
+					return ia == intf.IA || (ia.AS() == 0 && ia.ISD() == intf.IA.ISD())
+				})
+			})
+		})
+	})
+}
+
+// MaxHops returns a policy that drops the paths with more than n AS hops,
+// i.e., inter-domain links. The paths without interface metadata are
+// dropped, as they cannot be checked.
+func MaxHops(n int) snet.PathPolicy {
+	return snet.PathPolicyFunc(func(paths []snet.Path) []snet.Path {
+		return slices.DeleteFunc(slices.Clone(paths), func(p snet.Path) bool {
+			meta := p.Metadata()
+			return meta == nil || len(meta.Interfaces) == 0 || len(meta.Interfaces)/2 > n
+		})
+	})
+}
+
+// Sequence returns a policy that selects the paths matching the hop
+// predicate sequence seq, in the syntax of the sequence of the path
+// policies, e.g., "1-ff00:0:110#2 0* 1-ff00:0:112#1". This pins the
+// connection to the paths through particular ASes and interfaces.
+func Sequence(seq string) (snet.PathPolicy, error) {
+	s, err := pathpol.NewSequence(seq)
+	if err != nil {
+		return nil, err
+	}
+	return snet.PathPolicyFunc(s.Eval), nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpolicy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/pkg/snet/pathpolicy"
+)
+
+var (
+	ia110 = addr.MustParseIA("1-ff00:0:110")
+	ia111 = addr.MustParseIA("1-ff00:0:111")
+	ia112 = addr.MustParseIA("1-ff00:0:112")
+	ia210 = addr.MustParseIA("2-ff00:0:210")
+)
+
+// testPath returns a path through the ASes hops, leaving each AS on
+// interface 1 and entering it on interface 2, with the latencies of the
+// hops.
+func testPath(hops []addr.IA, latency ...time.Duration) snet.Path {
+	var intfs []snet.PathInterface
+	for i := range len(hops) - 1 {
+		intfs = append(intfs,
+			snet.PathInterface{IA: hops[i], ID: iface.ID(1)},
+			snet.PathInterface{IA: hops[i+1], ID: iface.ID(2)},
+		)
+	}
+	if latency == nil {
+		latency = make([]time.Duration, max(len(intfs)-1, 0))
+		for i := range latency {
+			latency[i] = snet.LatencyUnset
+		}
+	}
+	return snetpath.Path{
+		Src:  hops[0],
+		Dst:  hops[len(hops)-1],
+		Meta: snet.PathMetadata{Interfaces: intfs, Latency: latency},
+	}
+}
+
+func TestLowestLatency(t *testing.T) {
+	ms := time.Millisecond
+	slow := testPath([]addr.IA{ia110, ia111}, 30*ms)
+	fast := testPath([]addr.IA{ia110, ia112, ia111}, 5*ms, 2*ms, 5*ms)
+	unknown := testPath([]addr.IA{ia110, ia111})
+	partial := testPath([]addr.IA{ia110, ia112, ia111}, 1*ms, snet.LatencyUnset, 1*ms)
+
+	paths := []snet.Path{unknown, slow, partial, fast}
+	assert.Equal(t, []snet.Path{fast, slow, unknown, partial},
+		pathpolicy.LowestLatency().Filter(paths))
+	assert.Equal(t, []snet.Path{unknown, slow, partial, fast}, paths)
+}
+
+func TestExcludeAS(t *testing.T) {
+	direct := testPath([]addr.IA{ia110, ia111})
+	via112 := testPath([]addr.IA{ia110, ia112, ia111})
+	via210 := testPath([]addr.IA{ia110, ia210, ia111})
+	paths := []snet.Path{direct, via112, via210}
+
+	assert.Equal(t, []snet.Path{direct, via210},
+		pathpolicy.ExcludeAS(ia112).Filter(paths))
+	assert.Equal(t, []snet.Path{direct, via112},
+		pathpolicy.ExcludeAS(addr.MustIAFrom(2, 0)).Filter(paths))
+	assert.Empty(t, pathpolicy.ExcludeAS(ia110).Filter(paths))
+	assert.Len(t, paths, 3)
+}
+
+func TestMaxHops(t *testing.T) {
+	direct := testPath([]addr.IA{ia110, ia111})
+	via112 := testPath([]addr.IA{ia110, ia112, ia111})
+	paths := []snet.Path{via112, direct, snetpath.Path{}}
+
+	assert.Equal(t, []snet.Path{direct}, pathpolicy.MaxHops(1).Filter(paths))
+	assert.Equal(t, []snet.Path{via112, direct}, pathpolicy.MaxHops(2).Filter(paths))
+}
+
+func TestSequence(t *testing.T) {
+	direct := testPath([]addr.IA{ia110, ia111})
+	via112 := testPath([]addr.IA{ia110, ia112, ia111})
+
+	policy, err := pathpolicy.Sequence("1-ff00:0:110#1 1-ff00:0:112#2,1 1-ff00:0:111#2")
+	require.NoError(t, err)
+	assert.Equal(t, []snet.Path{via112}, policy.Filter([]snet.Path{direct, via112}))
+
+	_, err = pathpolicy.Sequence("1-ff00:0:110#")
+	assert.Error(t, err)
+}
+
+func TestChain(t *testing.T) {
+	ms := time.Millisecond
+	direct := testPath([]addr.IA{ia110, ia111}, 20*ms)
+	via112 := testPath([]addr.IA{ia110, ia112, ia111}, 1*ms, 1*ms, 1*ms)
+	via210 := testPath([]addr.IA{ia110, ia210, ia111}, 2*ms, 2*ms, 2*ms)
+
+	policy := pathpolicy.Chain(
+		pathpolicy.ExcludeAS(ia112),
+		pathpolicy.LowestLatency(),
+	)
+	assert.Equal(t, []snet.Path{via210, direct},
+		policy.Filter([]snet.Path{direct, via112, via210}))
+	assert.Equal(t, []snet.Path{direct}, pathpolicy.Chain().Filter([]snet.Path{direct}))
+}
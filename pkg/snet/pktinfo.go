@@ -0,0 +1,98 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"net"
+	"net/netip"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// A socket bound to an unspecified address, e.g., [::] on a dual-stack host,
+// receives the packets destined to any of the addresses of the host. The
+// SCION destination address of the packets is only checked against the
+// destination address of the underlay packet, which is retrieved with
+// IP_PKTINFO. This is the same safeguard against traffic reflection the shim
+// dispatcher applies. The source address of outgoing packets is chosen per
+// destination, see scionConnWriter.source.
+
+// isWildcard returns whether the socket is bound to an unspecified address.
+func isWildcard(local *net.UDPAddr) bool {
+	return local != nil && local.IP.IsUnspecified()
+}
+
+// enablePacketInfo enables the reception of the underlay destination address
+// on the socket. For dual-stack sockets, the IPv6 option covers both IPv6 and
+// IPv4 (as mapped addresses).
+func enablePacketInfo(conn *net.UDPConn) error {
+	if conn.LocalAddr().(*net.UDPAddr).IP.To4() != nil {
+		return ipv4.NewPacketConn(conn).SetControlMessage(ipv4.FlagDst, true)
+	}
+	return ipv6.NewPacketConn(conn).SetControlMessage(ipv6.FlagDst, true)
+}
+
+// newPacketInfoBuffer returns a buffer for the control messages enabled by
+// enablePacketInfo.
+func newPacketInfoBuffer(local *net.UDPAddr) []byte {
+	if local.IP.To4() != nil {
+		return ipv4.NewControlMessage(ipv4.FlagDst)
+	}
+	return ipv6.NewControlMessage(ipv6.FlagDst)
+}
+
+// parsePacketInfo returns the underlay destination address from the control
+// messages read on a socket bound to local.
+func parsePacketInfo(local *net.UDPAddr, oob []byte) (netip.Addr, error) {
+	var dst net.IP
+	if local.IP.To4() != nil {
+		var cm ipv4.ControlMessage
+		if err := cm.Parse(oob); err != nil {
+			return netip.Addr{}, err
+		}
+		dst = cm.Dst
+	} else {
+		var cm ipv6.ControlMessage
+		if err := cm.Parse(oob); err != nil {
+			return netip.Addr{}, err
+		}
+		dst = cm.Dst
+	}
+	a, ok := netip.AddrFromSlice(dst)
+	if !ok || a.IsUnspecified() {
+		return netip.Addr{}, serrors.New("no underlay destination in control message")
+	}
+	return a.Unmap(), nil
+}
+
+// resolveSource returns the local address the host uses to send packets to
+// nextHop. The operating system picks the address based on its routing
+// table, as it would for a connected UDP socket. No packet is sent.
+func resolveSource(nextHop *net.UDPAddr) (netip.Addr, error) {
+	conn, err := net.DialUDP("udp", nil, &net.UDPAddr{
+		IP:   nextHop.IP,
+		Port: 1,
+		Zone: nextHop.Zone,
+	})
+	if err != nil {
+		return netip.Addr{}, serrors.Wrap("resolving source address", err,
+			"next_hop", nextHop)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).AddrPort().Addr().WithZone("").Unmap(), nil
+}
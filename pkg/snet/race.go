@@ -0,0 +1,136 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// defaultRaceCandidates is the default number of paths a RacingDialer races.
+const defaultRaceCandidates = 3
+
+// RacingDialer dials connections that need a handshake, such as QUIC, by
+// racing the handshake on several paths, and keeps the connection whose
+// handshake completes first. This avoids failing, or waiting for a timeout,
+// when the first path is stale, in the manner of Happy Eyeballs (RFC 8305).
+type RacingDialer struct {
+	// Router provides the paths to the remote AS.
+	Router Router
+	// Policy selects and orders the paths of the router. If nil, the paths
+	// are used in the order of the router.
+	Policy PathPolicy
+	// Candidates is the number of paths raced, taken in order. If zero, three
+	// paths are raced.
+	Candidates int
+	// Delay is the head start of each path over the next one. An attempt
+	// that fails early starts the next one immediately. If zero, the
+	// handshakes on all paths are started at once.
+	Delay time.Duration
+	// DialPath dials remote on the path and next hop set in remote, and
+	// completes the handshake. It must return when ctx is canceled, which is
+	// the case if the handshake on another path completed first.
+	DialPath func(ctx context.Context, remote *UDPAddr) (net.Conn, error)
+}
+
+// Dial returns a connection to remote, dialed on the path whose handshake
+// completed first. The path and next hop of remote are ignored.
+func (d *RacingDialer) Dial(ctx context.Context, remote *UDPAddr) (net.Conn, error) {
+	paths, err := d.Router.AllRoutes(ctx, remote.IA)
+	if err != nil {
+		return nil, serrors.Wrap("querying paths", err, "isd_as", remote.IA)
+	}
+	if d.Policy != nil {
+		paths = d.Policy.Filter(paths)
+	}
+	candidates := d.Candidates
+	if candidates <= 0 {
+		candidates = defaultRaceCandidates
+	}
+	paths = paths[:min(candidates, len(paths))]
+	if len(paths) == 0 {
+		return nil, serrors.New("no path", "isd_as", remote.IA)
+	}
+
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+	results := make(chan result, len(paths))
+	next := 0
+	start := func() {
+		p := paths[next]
+		next++
+		r := remote.Copy()
+		r.Path, r.NextHop = p.Dataplane(), p.UnderlayNextHop()
+		go func() {
+			defer log.HandlePanic()
+			conn, err := d.DialPath(raceCtx, r)
+			if err != nil {
+				err = serrors.Wrap("dialing path", err, "path", p)
+			}
+			results <- result{conn: conn, err: err}
+		}()
+	}
+	// Without delay, all handshakes are started at once. Otherwise, the timer
+	// starts the next handshake Delay after the last one.
+	timer := time.NewTimer(d.Delay)
+	defer timer.Stop()
+	startNext := func() {
+		for next < len(paths) {
+			start()
+			if d.Delay > 0 {
+				timer.Reset(d.Delay)
+				return
+			}
+		}
+	}
+	startNext()
+	var errs serrors.List
+	for done := 0; done < next; {
+		select {
+		case r := <-results:
+			done++
+			if r.err == nil {
+				cancel()
+				// The handshakes that complete nevertheless are closed.
+				go func(pending int) {
+					defer log.HandlePanic()
+					for range pending {
+						if r := <-results; r.err == nil {
+							r.conn.Close()
+						}
+					}
+				}(next - done)
+				return r.conn, nil
+			}
+			errs = append(errs, r.err)
+			startNext()
+		case <-timer.C:
+			startNext()
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, serrors.Wrap("dialing on all paths failed", err, "isd_as", remote.IA)
+	}
+	return nil, serrors.Wrap("dialing on all paths failed", errs.ToError(),
+		"isd_as", remote.IA)
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestRacingDialer(t *testing.T) {
+	localIA := addr.MustParseIA("1-ff00:0:110")
+	remoteIA := addr.MustParseIA("1-ff00:0:111")
+	remote := &snet.UDPAddr{
+		IA:   remoteIA,
+		Host: &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 40000},
+	}
+	// The paths are told apart by the port of their next hop, which is also
+	// the interface of the path.
+	testPath := func(port int) snet.Path {
+		return snetpath.Path{
+			Src:           localIA,
+			Dst:           remoteIA,
+			DataplanePath: snetpath.Empty{},
+			NextHop:       &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port},
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: localIA, ID: iface.ID(port)},
+					{IA: remoteIA, ID: iface.ID(port)},
+				},
+			},
+		}
+	}
+	paths := failoverTestPaths{testPath(1), testPath(2), testPath(3), testPath(4)}
+
+	// handshakes fake the handshake on each path: it completes after the
+	// delay, fails immediately if the delay is negative, and waits for the
+	// cancellation if there is no delay.
+	type handshakes map[int]time.Duration
+	dialer := func(h handshakes) (*snet.RacingDialer, *raceRecorder) {
+		rec := &raceRecorder{
+			canceled: make(map[int]bool),
+			conns:    make(map[int]*raceConn),
+		}
+		return &snet.RacingDialer{
+			Router: paths,
+			DialPath: func(ctx context.Context, remote *snet.UDPAddr) (net.Conn, error) {
+				port := remote.NextHop.Port
+				rec.attempt(port)
+				delay, ok := h[port]
+				switch {
+				case !ok:
+					<-ctx.Done()
+					rec.cancel(port)
+					return nil, ctx.Err()
+				case delay < 0:
+					return nil, errors.New("handshake failed")
+				}
+				time.Sleep(delay)
+				return rec.conn(port), nil
+			},
+		}, rec
+	}
+
+	t.Run("first handshake wins", func(t *testing.T) {
+		d, rec := dialer(handshakes{2: 100 * time.Millisecond, 3: time.Millisecond})
+		conn, err := d.Dial(context.Background(), remote)
+		require.NoError(t, err)
+		assert.Equal(t, 3, conn.(*raceConn).port)
+		assert.Eventually(t, func() bool {
+			return rec.isCanceled(1) && rec.isClosed(2)
+		}, time.Second, 10*time.Millisecond)
+		assert.False(t, rec.isClosed(3))
+		assert.Equal(t, []int{1, 2, 3}, rec.sortedAttempts())
+	})
+	t.Run("delay", func(t *testing.T) {
+		d, rec := dialer(handshakes{2: 0, 3: time.Millisecond})
+		d.Delay = 50 * time.Millisecond
+		start := time.Now()
+		conn, err := d.Dial(context.Background(), remote)
+		require.NoError(t, err)
+		// The first path hangs, so the second one starts after the delay,
+		// and wins before the third is started.
+		assert.Equal(t, 2, conn.(*raceConn).port)
+		assert.GreaterOrEqual(t, time.Since(start), d.Delay)
+		assert.Equal(t, []int{1, 2}, rec.sortedAttempts())
+	})
+	t.Run("failure starts next", func(t *testing.T) {
+		d, rec := dialer(handshakes{1: -1, 2: 0})
+		d.Delay = time.Hour
+		conn, err := d.Dial(context.Background(), remote)
+		require.NoError(t, err)
+		assert.Equal(t, 2, conn.(*raceConn).port)
+		assert.Equal(t, []int{1, 2}, rec.sortedAttempts())
+	})
+	t.Run("candidates and policy", func(t *testing.T) {
+		d, rec := dialer(handshakes{1: -1, 2: -1, 3: -1, 4: 0})
+		d.Candidates = 2
+		d.Policy = snet.PathPolicyFunc(func(paths []snet.Path) []snet.Path {
+			return paths[1:]
+		})
+		_, err := d.Dial(context.Background(), remote)
+		assert.Error(t, err)
+		assert.Equal(t, []int{2, 3}, rec.sortedAttempts())
+	})
+	t.Run("canceled", func(t *testing.T) {
+		d, _ := dialer(handshakes{})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := d.Dial(ctx, remote)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// raceRecorder records the handshakes of a RacingDialer test.
+type raceRecorder struct {
+	mtx      sync.Mutex
+	attempts []int
+	canceled map[int]bool
+	conns    map[int]*raceConn
+}
+
+func (r *raceRecorder) attempt(port int) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.attempts = append(r.attempts, port)
+}
+
+func (r *raceRecorder) cancel(port int) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.canceled[port] = true
+}
+
+func (r *raceRecorder) conn(port int) *raceConn {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	c := &raceConn{port: port}
+	r.conns[port] = c
+	return c
+}
+
+func (r *raceRecorder) isCanceled(port int) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.canceled[port]
+}
+
+func (r *raceRecorder) isClosed(port int) bool {
+	r.mtx.Lock()
+	c := r.conns[port]
+	r.mtx.Unlock()
+	return c != nil && c.closed.Load()
+}
+
+func (r *raceRecorder) sortedAttempts() []int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return slices.Sorted(slices.Values(r.attempts))
+}
+
+// raceConn is the connection returned by the handshake on a path.
+type raceConn struct {
+	net.Conn
+	port   int
+	closed atomic.Bool
+}
+
+func (c *raceConn) Close() error {
+	c.closed.Store(true)
+	return nil
+}
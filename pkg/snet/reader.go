@@ -35,6 +35,9 @@ type scionConnReader struct {
 	replyPather ReplyPather
 	conn        PacketConn
 	local       *UDPAddr
+	// public are the public addresses of the connection if the host is behind
+	// a NAT, see SCIONPacketConn. It is nil if they are not used.
+	public *publicAddresses
 
 	mtx    sync.Mutex
 	buffer []byte
@@ -88,13 +91,18 @@ func (c *scionConnReader) read(b []byte) (int, *UDPAddr, error) {
 		return 0, nil, serrors.New("unexpected payload", "type", common.TypeOf(pkt.Payload))
 	}
 
-	// XXX(JordiSubira): We explicitly forbid nil or unspecified address in the current constructor
-	// for Conn.
-	// If this were ever to change, we would always fall into the following if statement, then
-	// we would like to replace this logic (e.g., using IP_PKTINFO, with its caveats).
-	pktAddrPort := netip.AddrPortFrom(pkt.Destination.Host.IP(), udp.DstPort)
+	// If the socket is bound to an unspecified address, the PacketConn checks
+	// that the packet is destined to the address it was received on, e.g.,
+	// with IP_PKTINFO, so only the port is compared here.
+	pktAddrPort := netip.AddrPortFrom(pkt.Destination.Host.IP().Unmap(), udp.DstPort)
+	localAddrPort := c.local.Host.AddrPort()
+	localAddrPort = netip.AddrPortFrom(localAddrPort.Addr().WithZone("").Unmap(),
+		localAddrPort.Port())
+	if isWildcard(c.local.Host) {
+		localAddrPort = netip.AddrPortFrom(pktAddrPort.Addr(), localAddrPort.Port())
+	}
 	if c.local.IA != pkt.Destination.IA ||
-		c.local.Host.AddrPort() != pktAddrPort {
+		(localAddrPort != pktAddrPort && !c.public.contains(pktAddrPort)) {
 		return 0, nil, serrors.New("packet is destined to a different host",
 			"local_isd_as", c.local.IA,
 			"local_host", c.local.Host,
@@ -55,3 +55,19 @@ func (r *BaseRouter) Route(ctx context.Context, dst addr.IA) (Path, error) {
 func (r *BaseRouter) AllRoutes(ctx context.Context, dst addr.IA) ([]Path, error) {
 	return r.Querier.Query(ctx, dst)
 }
+
+// PathPolicy selects the paths a connection may use, e.g., a FailoverConn. A
+// pathpol.Policy is a PathPolicy, and package pathpolicy provides composable
+// policies.
+type PathPolicy interface {
+	// Filter returns the paths of paths that may be used, in the order of
+	// preference. It must not modify the paths.
+	Filter(paths []Path) []Path
+}
+
+// PathPolicyFunc is a function that implements PathPolicy.
+type PathPolicyFunc func(paths []Path) []Path
+
+func (f PathPolicyFunc) Filter(paths []Path) []Path {
+	return f(paths)
+}
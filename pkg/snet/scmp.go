@@ -16,8 +16,12 @@ package snet
 
 import (
 	"context"
+	"net"
 	"time"
 
+	"github.com/gopacket/gopacket"
+
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/metrics/v2"
 	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
@@ -25,6 +29,7 @@ import (
 	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/pkg/segment/iface"
 	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
 )
 
 // RevocationHandler is called by the default SCMP Handler whenever revocations are encountered.
@@ -33,6 +38,48 @@ type RevocationHandler interface {
 	Revoke(ctx context.Context, revInfo *path_mgmt.RevInfo) error
 }
 
+// PathErrorHandler is called by the default SCMP Handler whenever SCMP errors are encountered
+// that concern the path of the packet that caused them.
+type PathErrorHandler interface {
+	// ReportPathError handles an SCMP error received for a packet sent on a path.
+	ReportPathError(ctx context.Context, pathErr PathError) error
+}
+
+// PathError is an SCMP error that was received for a packet sent on a SCION path.
+type PathError struct {
+	// Path is the raw SCION path of the packet, as quoted in the SCMP error.
+	Path []byte
+	// TypeCode is the type and code of the SCMP error.
+	TypeCode slayers.SCMPTypeCode
+	// Source is the ISD-AS of the AS that sent the SCMP error.
+	Source addr.IA
+}
+
+// SCMPError is an SCMP error received on a connection, for a packet that was
+// sent on it.
+type SCMPError struct {
+	// TypeCode is the type and code of the SCMP error.
+	TypeCode slayers.SCMPTypeCode
+	// Source is the address of the router or host that sent the SCMP error.
+	Source SCIONAddress
+	// Destination is the destination of the packet that caused the error, as
+	// quoted in the SCMP error. It is unset if the quote is truncated.
+	Destination SCIONAddress
+	// Path is the raw SCION path of the packet that caused the error, as
+	// quoted in the SCMP error. It is nil if the packet was not sent on a
+	// SCION path or the quote is truncated.
+	Path []byte
+	// Message is the SCMP error, e.g., SCMPDestinationUnreachable,
+	// SCMPPacketTooBig or SCMPExternalInterfaceDown. The quote it contains is
+	// only valid during the call to the SCMPErrorHandler.
+	Message SCMPPayload
+}
+
+// SCMPErrorHandler is called for the SCMP errors received on a connection,
+// see Conn.SetSCMPErrorHandler. It is called by the goroutine reading from
+// the connection, so it must not block.
+type SCMPErrorHandler func(SCMPError)
+
 // SCMPHandler customizes the way snet connections deal with SCMP.
 type SCMPHandler interface {
 	// Handle processes the packet as an SCMP packet. If packet is not SCMP, it
@@ -49,13 +96,37 @@ type SCMPHandler interface {
 	Handle(pkt *Packet) error
 }
 
+// SCMPUnderlayHandler is an SCMPHandler that also takes the underlay address
+// the SCMP packet was received from, e.g., to tell the messages sent by the
+// routers of the local AS from the ones that they forward. If the SCMPHandler
+// of a connection implements it, HandleUnderlay is called instead of Handle.
+type SCMPUnderlayHandler interface {
+	SCMPHandler
+	HandleUnderlay(pkt *Packet, underlay *net.UDPAddr) error
+}
+
+// HandleSCMPUnderlay passes the packet to the handler, with the underlay
+// address if the handler is an SCMPUnderlayHandler. Handlers that wrap other
+// handlers use it to pass the underlay address on.
+func HandleSCMPUnderlay(handler SCMPHandler, pkt *Packet, underlay *net.UDPAddr) error {
+	if h, ok := handler.(SCMPUnderlayHandler); ok {
+		return h.HandleUnderlay(pkt, underlay)
+	}
+	return handler.Handle(pkt)
+}
+
 // DefaultSCMPHandler handles SCMP messages received from the network. If a
 // revocation handler is configured, it is informed of any received interface
-// down messages.
+// down messages. If a path error handler is configured, it is informed of the
+// other SCMP errors that quote the path of the offending packet.
 type DefaultSCMPHandler struct {
 	// RevocationHandler manages revocations received via SCMP. If nil, the
 	// handler is not called.
 	RevocationHandler RevocationHandler
+	// PathErrorHandler manages the destination unreachable, packet too big and
+	// parameter problem errors received via SCMP. If nil, the handler is not
+	// called.
+	PathErrorHandler PathErrorHandler
 	// SCMPErrors reports the total number of SCMP Errors encountered.
 	SCMPErrors metrics.Counter
 }
@@ -86,12 +157,106 @@ func (h DefaultSCMPHandler) Handle(pkt *Packet) error {
 			RawTimestamp: util.TimeToSecs(time.Now()),
 			RawTTL:       10,
 		})
+	case slayers.SCMPTypeDestinationUnreachable:
+		msg := pkt.Payload.(SCMPDestinationUnreachable)
+		h.handleSCMPPathError(typeCode, pkt.Source.IA, msg.Payload)
+		return nil
+	case slayers.SCMPTypePacketTooBig:
+		msg := pkt.Payload.(SCMPPacketTooBig)
+		h.handleSCMPPathError(typeCode, pkt.Source.IA, msg.Payload)
+		return nil
+	case slayers.SCMPTypeParameterProblem:
+		msg := pkt.Payload.(SCMPParameterProblem)
+		h.handleSCMPPathError(typeCode, pkt.Source.IA, msg.Payload)
+		return nil
 	default:
 		// Only handle connectivity down for now
 		log.Debug("Ignoring scmp packet", "scmp", typeCode, "src", pkt.Source)
 		return nil
 	}
 }
+
+func (h *DefaultSCMPHandler) handleSCMPPathError(typeCode slayers.SCMPTypeCode,
+	src addr.IA, quote []byte) {
+
+	if h.PathErrorHandler == nil {
+		log.Debug("Ignoring scmp packet", "scmp", typeCode, "src", src)
+		return
+	}
+	raw, err := quotedPath(quote)
+	if err != nil {
+		log.Debug("Ignoring scmp packet", "scmp", typeCode, "src", src, "err", err)
+		return
+	}
+	err = h.PathErrorHandler.ReportPathError(context.TODO(), PathError{
+		Path:     raw,
+		TypeCode: typeCode,
+		Source:   src,
+	})
+	if err != nil {
+		log.Info("Notifying path error handler failed", "err", err)
+	}
+}
+
+// quotedPath returns a copy of the raw SCION path of the packet quoted in an
+// SCMP error.
+func quotedPath(quote []byte) ([]byte, error) {
+	scn, err := decodeQuote(quote)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := scn.Path.(*scion.Raw)
+	if !ok {
+		return nil, serrors.New("quoted packet has no SCION path", "type", scn.PathType)
+	}
+	return append([]byte(nil), raw.Raw...), nil
+}
+
+// decodeQuote decodes the SCION header of the packet quoted in an SCMP error.
+func decodeQuote(quote []byte) (*slayers.SCION, error) {
+	var scn slayers.SCION
+	if err := scn.DecodeFromBytes(quote, gopacket.NilDecodeFeedback); err != nil {
+		return nil, serrors.Wrap("decoding quoted packet", err)
+	}
+	return &scn, nil
+}
+
+// newSCMPError returns the SCMPError of the SCMP message msg of pkt. It
+// returns false if msg is an informational message.
+func newSCMPError(pkt *Packet, msg SCMPPayload) (SCMPError, bool) {
+	var quote []byte
+	switch m := msg.(type) {
+	case SCMPDestinationUnreachable:
+		quote = m.Payload
+	case SCMPPacketTooBig:
+		quote = m.Payload
+	case SCMPParameterProblem:
+		quote = m.Payload
+	case SCMPExternalInterfaceDown:
+		quote = m.Payload
+	case SCMPInternalConnectivityDown:
+		quote = m.Payload
+	default:
+		return SCMPError{}, false
+	}
+	scmpErr := SCMPError{
+		TypeCode: slayers.CreateSCMPTypeCode(msg.Type(), msg.Code()),
+		Source:   pkt.Source,
+		Message:  msg,
+	}
+	scn, err := decodeQuote(quote)
+	if err != nil {
+		return scmpErr, true
+	}
+	if dst, err := scn.DstAddr(); err == nil {
+		scmpErr.Destination = SCIONAddress{IA: scn.DstIA, Host: dst}
+	}
+	if raw, ok := scn.Path.(*scion.Raw); ok {
+		scmpErr.Path = append([]byte(nil), raw.Raw...)
+	}
+	return scmpErr, true
+}
+
 func (h *DefaultSCMPHandler) handleSCMPRev(typeCode slayers.SCMPTypeCode,
 	revInfo *path_mgmt.RevInfo) error {
 
@@ -119,7 +284,11 @@ type SCMPPropagationStopper struct {
 }
 
 func (h SCMPPropagationStopper) Handle(pkt *Packet) error {
-	if err := h.Handler.Handle(pkt); err != nil && h.Log != nil {
+	return h.HandleUnderlay(pkt, nil)
+}
+
+func (h SCMPPropagationStopper) HandleUnderlay(pkt *Packet, underlay *net.UDPAddr) error {
+	if err := HandleSCMPUnderlay(h.Handler, pkt, underlay); err != nil && h.Log != nil {
 		h.Log("Stopped SCMP error propagation", "err", err)
 	}
 	return nil
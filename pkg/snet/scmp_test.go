@@ -0,0 +1,199 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestSCMPErrorHandler(t *testing.T) {
+	localIA := addr.MustParseIA("1-ff00:0:110")
+	remoteIA := addr.MustParseIA("1-ff00:0:111")
+	loopback := addr.HostIP(netip.MustParseAddr("127.0.0.1"))
+	remoteHost := addr.HostIP(netip.MustParseAddr("192.0.2.1"))
+
+	brConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer brConn.Close()
+	p := twoHopPath(t, localIA, remoteIA, &testBorderRouter{conn: brConn}, 1, 2, time.Hour)
+
+	network := &snet.SCIONNetwork{
+		Topology: snet.Topology{
+			LocalIA:   localIA,
+			PortRange: snet.TopologyPortRange{Start: 40160, End: 40169},
+		},
+		SCMPHandler: snet.DefaultSCMPHandler{},
+	}
+	conn, err := network.Dial(context.Background(), "udp",
+		&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)},
+		&snet.UDPAddr{
+			IA:      remoteIA,
+			Host:    &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 40000},
+			Path:    p.Dataplane(),
+			NextHop: p.UnderlayNextHop(),
+		},
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	scmpErrs := make(chan snet.SCMPError, 1)
+	require.NoError(t, conn.SetSCMPErrorHandler(func(e snet.SCMPError) {
+		scmpErrs <- e
+	}))
+
+	// The packet sent on the connection is quoted in the SCMP errors.
+	_, err = conn.Write([]byte("a"))
+	require.NoError(t, err)
+	buf := make([]byte, 1500)
+	require.NoError(t, brConn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err := brConn.ReadFromUDP(buf)
+	require.NoError(t, err)
+	quote := buf[:n]
+
+	testCases := map[string]struct {
+		msg      snet.SCMPPayload
+		typeCode slayers.SCMPTypeCode
+		readErr  assert.ErrorAssertionFunc
+	}{
+		"destination unreachable": {
+			msg: snet.SCMPDestinationUnreachable{Payload: quote},
+			typeCode: slayers.CreateSCMPTypeCode(
+				slayers.SCMPTypeDestinationUnreachable, 0),
+			readErr: isTimeout,
+		},
+		"packet too big": {
+			msg:      snet.SCMPPacketTooBig{MTU: 1280, Payload: quote},
+			typeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypePacketTooBig, 0),
+			readErr:  isTimeout,
+		},
+		"external interface down": {
+			msg: snet.SCMPExternalInterfaceDown{
+				IA:        localIA,
+				Interface: 1,
+				Payload:   quote,
+			},
+			typeCode: slayers.CreateSCMPTypeCode(
+				slayers.SCMPTypeExternalInterfaceDown, 0),
+			readErr: func(t assert.TestingT, err error, _ ...any) bool {
+				var opErr *snet.OpError
+				return assert.ErrorAs(t, err, &opErr)
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sendSCMP(t, brConn, conn, localIA, tc.msg)
+			tc.readErr(t, read(conn))
+
+			select {
+			case e := <-scmpErrs:
+				assert.Equal(t, tc.typeCode, e.TypeCode)
+				assert.Equal(t, snet.SCIONAddress{IA: localIA, Host: loopback}, e.Source)
+				assert.Equal(t, snet.SCIONAddress{IA: remoteIA, Host: remoteHost},
+					e.Destination)
+				assert.Equal(t, p.Dataplane().(snetpath.SCION).Raw, e.Path)
+				assert.Equal(t, tc.msg.Type(), e.Message.Type())
+			default:
+				t.Fatal("SCMP error handler not called")
+			}
+		})
+	}
+	t.Run("informational", func(t *testing.T) {
+		sendSCMP(t, brConn, conn, localIA, snet.SCMPEchoReply{Identifier: 1})
+		isTimeout(t, read(conn))
+		assert.Empty(t, scmpErrs)
+	})
+	t.Run("removed", func(t *testing.T) {
+		require.NoError(t, conn.SetSCMPErrorHandler(nil))
+		sendSCMP(t, brConn, conn, localIA, snet.SCMPPacketTooBig{MTU: 1280, Payload: quote})
+		isTimeout(t, read(conn))
+		assert.Empty(t, scmpErrs)
+	})
+}
+
+// sendSCMP sends the SCMP message msg from the border router on brConn to
+// conn.
+func sendSCMP(t *testing.T, brConn *net.UDPConn, conn *snet.Conn, ia addr.IA,
+	msg snet.SCMPPayload) {
+
+	loopback := addr.HostIP(netip.MustParseAddr("127.0.0.1"))
+	pkt := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Source:      snet.SCIONAddress{IA: ia, Host: loopback},
+			Destination: snet.SCIONAddress{IA: ia, Host: loopback},
+			Path:        snetpath.Empty{},
+			Payload:     msg,
+		},
+	}
+	require.NoError(t, pkt.Serialize())
+	_, err := brConn.WriteToUDP(pkt.Bytes, conn.LocalAddr().(*snet.UDPAddr).Host)
+	require.NoError(t, err)
+}
+
+// read reads from conn until the SCMP message sent to it is processed.
+func read(conn *snet.Conn) error {
+	if err := conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond)); err != nil {
+		return err
+	}
+	_, err := conn.Read(make([]byte, 16))
+	return err
+}
+
+func isTimeout(t assert.TestingT, err error, _ ...any) bool {
+	var netErr net.Error
+	return assert.ErrorAs(t, err, &netErr) && assert.True(t, netErr.Timeout())
+}
+
+func TestHandleSCMPUnderlay(t *testing.T) {
+	underlay := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 2), Port: 30042}
+	pkt := &snet.Packet{}
+
+	h := &underlayRecorder{}
+	stopper := snet.SCMPPropagationStopper{Handler: h}
+	require.NoError(t, snet.HandleSCMPUnderlay(stopper, pkt, underlay))
+	assert.Equal(t, underlay, h.underlay)
+
+	h = &underlayRecorder{}
+	require.NoError(t, snet.HandleSCMPUnderlay(snet.SCMPPropagationStopper{Handler: h}, pkt, nil))
+	assert.Nil(t, h.underlay)
+	assert.True(t, h.handled)
+}
+
+// underlayRecorder records the underlay address of the last handled packet.
+type underlayRecorder struct {
+	handled  bool
+	underlay *net.UDPAddr
+}
+
+func (h *underlayRecorder) Handle(pkt *snet.Packet) error {
+	return h.HandleUnderlay(pkt, nil)
+}
+
+func (h *underlayRecorder) HandleUnderlay(_ *snet.Packet, underlay *net.UDPAddr) error {
+	h.handled = true
+	h.underlay = underlay
+	return nil
+}
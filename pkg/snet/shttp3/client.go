@@ -0,0 +1,159 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shttp3
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+)
+
+// RoundTripper is an http.RoundTripper that sends the requests with HTTP/3
+// over SCION. Each QUIC connection has its own SCION connection, which fails
+// over to another path of the Router if its path fails, see
+// snet.SCIONNetwork.DialFailover. The connections to the local AS use the
+// empty path.
+//
+// The RoundTripper must not be copied after first use.
+type RoundTripper struct {
+	// Network is the SCION network the connections are opened on. The SCMP
+	// errors of the network are not propagated to the QUIC connections.
+	Network *snet.SCIONNetwork
+	// Router provides the paths to the servers.
+	Router snet.Router
+	// Policy selects the paths that may be used. If nil, all paths of the
+	// Router may be used.
+	Policy snet.PathPolicy
+	// Local is the local address of the connections. If unset, the
+	// unspecified address of the IP family of the server is used, with a
+	// port chosen for each connection.
+	Local netip.Addr
+	// Resolve resolves the host names of the URLs to SCION addresses. If nil,
+	// the host names are parsed with ParseHost.
+	Resolve func(ctx context.Context, host string) (addr.IA, netip.Addr, error)
+	// TLSClientConfig is the TLS configuration of the connections. If nil,
+	// the default configuration is used.
+	TLSClientConfig *tls.Config
+	// QUICConfig is the QUIC configuration of the connections. If nil, the
+	// default configuration of quic-go is used.
+	QUICConfig *quic.Config
+
+	initOnce  sync.Once
+	network   snet.SCIONNetwork
+	transport *http3.Transport
+}
+
+// RoundTrip sends the request over SCION.
+func (t *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.initOnce.Do(t.init)
+	return t.transport.RoundTrip(req)
+}
+
+// Close closes the connections of the RoundTripper.
+func (t *RoundTripper) Close() error {
+	t.initOnce.Do(t.init)
+	return t.transport.Close()
+}
+
+func (t *RoundTripper) init() {
+	t.network = withoutSCMPErrors(*t.Network)
+	t.transport = &http3.Transport{
+		TLSClientConfig: t.TLSClientConfig,
+		QUICConfig:      t.QUICConfig,
+		Dial:            t.dial,
+	}
+}
+
+func (t *RoundTripper) dial(ctx context.Context, hostPort string, tlsCfg *tls.Config,
+	cfg *quic.Config) (quic.EarlyConnection, error) {
+
+	remote, err := t.resolve(ctx, hostPort)
+	if err != nil {
+		return nil, err
+	}
+	local := t.Local
+	if !local.IsValid() {
+		local = netip.IPv6Unspecified()
+		if remote.Host.IP.To4() != nil {
+			local = netip.IPv4Unspecified()
+		}
+	}
+	listen := &net.UDPAddr{IP: local.AsSlice(), Zone: local.Zone()}
+
+	var conn net.PacketConn
+	if remote.IA.Equal(t.network.Topology.LocalIA) {
+		remote.Path = snetpath.Empty{}
+		remote.NextHop = snet.CopyUDPAddr(remote.Host)
+		conn, err = t.network.Dial(ctx, "udp", listen, remote)
+	} else {
+		conn, err = t.network.DialFailover(ctx, listen, remote, t.Router,
+			snet.WithPathPolicy(t.Policy))
+	}
+	if err != nil {
+		return nil, serrors.Wrap("dialing SCION", err, "remote", remote)
+	}
+	transport := &quic.Transport{Conn: conn}
+	session, err := transport.DialEarly(ctx, remote, tlsCfg, cfg)
+	if err != nil {
+		transport.Close()
+		conn.Close()
+		return nil, serrors.Wrap("dialing QUIC/SCION", err, "remote", remote)
+	}
+	go func() {
+		defer log.HandlePanic()
+		<-session.Context().Done()
+		transport.Close()
+		conn.Close()
+	}()
+	return session, nil
+}
+
+func (t *RoundTripper) resolve(ctx context.Context, hostPort string) (*snet.UDPAddr, error) {
+	host, rawPort, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return nil, serrors.Wrap("splitting host and port", err, "addr", hostPort)
+	}
+	port, err := strconv.ParseUint(rawPort, 10, 16)
+	if err != nil {
+		return nil, serrors.Wrap("parsing port", err, "addr", hostPort)
+	}
+	var ia addr.IA
+	var ip netip.Addr
+	if t.Resolve != nil {
+		ia, ip, err = t.Resolve(ctx, host)
+	} else {
+		ia, ip, err = ParseHost(host)
+	}
+	if err != nil {
+		return nil, serrors.Wrap("resolving host", err, "host", host)
+	}
+	return &snet.UDPAddr{
+		IA:   ia,
+		Host: &net.UDPAddr{IP: ip.AsSlice(), Port: int(port), Zone: ip.Zone()},
+	}, nil
+}
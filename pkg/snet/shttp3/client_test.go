@@ -0,0 +1,99 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shttp3_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/shttp3"
+)
+
+func TestRoundTripper(t *testing.T) {
+	localIA := addr.MustParseIA("1-ff00:0:110")
+	network := &snet.SCIONNetwork{
+		Topology: snet.Topology{
+			LocalIA:   localIA,
+			PortRange: snet.TopologyPortRange{Start: 40150, End: 40159},
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := network.Listen(ctx, "udp", &net.UDPAddr{IP: net.IP{127, 0, 0, 1}})
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "hello "+r.Proto)
+	})
+	server := shttp3.Server{
+		Server: &http3.Server{
+			Handler:   mux,
+			TLSConfig: tlsConfig(t),
+		},
+	}
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(conn)
+	}()
+
+	rt := &shttp3.RoundTripper{
+		Network:         network,
+		TLSClientConfig: tlsConfig(t),
+	}
+	defer rt.Close()
+	client := &http.Client{Transport: rt}
+	host := shttp3.Host(localIA, netip.MustParseAddr("127.0.0.1"))
+	port := strconv.Itoa(conn.LocalAddr().(*snet.UDPAddr).Host.Port)
+	url := "https://" + host + ":" + port
+	for range 2 {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/hello", nil)
+		require.NoError(t, err)
+		rsp, err := client.Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(rsp.Body)
+		rsp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rsp.StatusCode)
+		assert.Equal(t, "hello HTTP/3.0", string(body))
+	}
+
+	require.NoError(t, server.Close())
+	assert.True(t, errors.Is(<-served, http.ErrServerClosed))
+	conn.Close()
+}
+
+func tlsConfig(t *testing.T) *tls.Config {
+	cert, err := tls.LoadX509KeyPair("testdata/tls.pem", "testdata/tls.key")
+	require.NoError(t, err)
+	return &tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		InsecureSkipVerify: true,
+	}
+}
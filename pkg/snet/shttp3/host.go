@@ -0,0 +1,58 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shttp3 runs HTTP/3 over SCION.
+//
+// The RoundTripper sends the requests of an http.Client over QUIC/SCION, on
+// paths selected with a snet.PathPolicy, and the Server serves HTTP/3 on a
+// SCION connection. The QUIC connections are kept when the path fails, see
+// snet.FailoverConn and squic.MigratingConn.
+//
+// SCION addresses cannot be written in the host of a URL. The host names of
+// the URLs are resolved to SCION addresses by the Resolve function of the
+// RoundTripper, or else parsed with ParseHost, which accepts the host names
+// returned by Host, e.g., https://1-ff00_0_110,192.0.2.1:8443/.
+package shttp3
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Host returns the host name of the SCION address with the AS ia and the IP
+// address ip for a URL. The colons of the address are replaced by
+// underscores, e.g., 1-ff00_0_110,192.0.2.1 or 1-ff00_0_110,2001_db8__1.
+func Host(ia addr.IA, ip netip.Addr) string {
+	return strings.ReplaceAll(ia.String()+","+ip.String(), ":", "_")
+}
+
+// ParseHost parses a host name returned by Host.
+func ParseHost(host string) (addr.IA, netip.Addr, error) {
+	rawIA, rawIP, ok := strings.Cut(strings.ReplaceAll(host, "_", ":"), ",")
+	if !ok {
+		return 0, netip.Addr{}, serrors.New("host is not a SCION address", "host", host)
+	}
+	ia, err := addr.ParseIA(rawIA)
+	if err != nil {
+		return 0, netip.Addr{}, serrors.Wrap("parsing ISD-AS", err, "host", host)
+	}
+	ip, err := netip.ParseAddr(rawIP)
+	if err != nil || ip.Zone() != "" {
+		return 0, netip.Addr{}, serrors.New("invalid IP address", "host", host)
+	}
+	return ia, ip, nil
+}
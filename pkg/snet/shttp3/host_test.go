@@ -0,0 +1,82 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shttp3_test
+
+import (
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/snet/shttp3"
+)
+
+func TestHost(t *testing.T) {
+	testCases := map[string]struct {
+		ia   addr.IA
+		ip   netip.Addr
+		host string
+	}{
+		"IPv4": {
+			ia:   addr.MustParseIA("1-ff00:0:110"),
+			ip:   netip.MustParseAddr("192.0.2.1"),
+			host: "1-ff00_0_110,192.0.2.1",
+		},
+		"IPv6": {
+			ia:   addr.MustParseIA("1-ff00:0:110"),
+			ip:   netip.MustParseAddr("2001:db8::1"),
+			host: "1-ff00_0_110,2001_db8__1",
+		},
+		"BGP AS": {
+			ia:   addr.MustParseIA("64-2:0:9"),
+			ip:   netip.MustParseAddr("10.0.0.1"),
+			host: "64-2_0_9,10.0.0.1",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			host := shttp3.Host(tc.ia, tc.ip)
+			assert.Equal(t, tc.host, host)
+
+			u, err := url.Parse("https://" + host + ":8443/index.html")
+			require.NoError(t, err)
+			assert.Equal(t, host, u.Hostname())
+
+			ia, ip, err := shttp3.ParseHost(u.Hostname())
+			require.NoError(t, err)
+			assert.Equal(t, tc.ia, ia)
+			assert.Equal(t, tc.ip, ip)
+		})
+	}
+}
+
+func TestParseHostErrors(t *testing.T) {
+	for _, host := range []string{
+		"",
+		"example.com",
+		"1-ff00_0_110",
+		"1-ff00_0_110,example.com",
+		"1-ff00_0_110,fe80__1%eth0",
+		"1-ff00_0_110_1,192.0.2.1",
+	} {
+		t.Run(host, func(t *testing.T) {
+			_, _, err := shttp3.ParseHost(host)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shttp3
+
+import (
+	"context"
+	"net"
+
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/squic"
+)
+
+// Server serves HTTP/3 over SCION. The responses follow the clients across
+// path changes and NAT rebinding, see squic.MigratingConn.
+type Server struct {
+	*http3.Server
+}
+
+// Serve serves HTTP/3 on conn, a SCION connection, e.g., returned by Listen
+// of snet.SCIONNetwork. The SCMP errors must not be propagated by conn, see
+// snet.SCMPPropagationStopper. Serve always returns a non-nil error, see
+// http3.Server.Serve.
+func (s Server) Serve(conn net.PacketConn) error {
+	return s.Server.Serve(squic.NewMigratingConn(conn, 0))
+}
+
+// ListenAndServe listens on the address listen of the SCION network and
+// serves HTTP/3 on it. The SCMP errors of the network are not propagated to
+// the QUIC connections.
+func (s Server) ListenAndServe(ctx context.Context, network *snet.SCIONNetwork,
+	listen *net.UDPAddr) error {
+
+	n := withoutSCMPErrors(*network)
+	conn, err := n.Listen(ctx, "udp", listen)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return s.Serve(conn)
+}
+
+// withoutSCMPErrors returns the network n with an SCMP handler that does not
+// propagate the SCMP errors, which would close the QUIC transports.
+func withoutSCMPErrors(n snet.SCIONNetwork) snet.SCIONNetwork {
+	handler := n.SCMPHandler
+	if handler == nil {
+		handler = snet.DefaultSCMPHandler{}
+	}
+	n.SCMPHandler = snet.SCMPPropagationStopper{Handler: handler}
+	return n
+}
@@ -38,10 +38,11 @@ package snet
 
 import (
 	"context"
-	"errors"
+	"math/rand/v2"
 	"net"
 	"net/netip"
 	"syscall"
+	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
@@ -91,17 +92,37 @@ type SCIONNetwork struct {
 	// SCMPHandler describes the network behaviour upon receiving SCMP traffic.
 	SCMPHandler       SCMPHandler
 	PacketConnMetrics SCIONPacketConnMetrics
+	// NATKeepaliveInterval enables NAT keepalives on the connections returned
+	// by Dial and Listen, see WithNATKeepalive. Zero disables them.
+	NATKeepaliveInterval time.Duration
+	// DiscoverPublicAddress makes the connections learn their public address
+	// toward each border router they send packets via, with STUN binding
+	// requests to the router, and use it as the source of the packets sent to
+	// other ASes. This lets hosts behind a NAT receive the replies. The border
+	// routers must answer STUN requests.
+	DiscoverPublicAddress bool
+	// PublicIP is the public IP address of a host behind a static NAT mapping
+	// that preserves the ports. If set, it is used as the source of the packets
+	// sent to other ASes instead of the address learned with STUN.
+	PublicIP netip.Addr
 }
 
 // OpenRaw returns a PacketConn which listens on the specified address.
-// Nil or unspecified addresses are not supported.
+// Nil addresses are not supported.
+// If the address is unspecified, e.g., [::] on a dual-stack host, the
+// connection receives the packets destined to any of the addresses of the
+// host, see Listen. This is not supported on Windows.
 // If the address port is 0 a valid and free SCION/UDP port is automatically chosen.
 // Otherwise, the specified port must be a valid SCION/UDP port.
 func (n *SCIONNetwork) OpenRaw(ctx context.Context, addr *net.UDPAddr) (PacketConn, error) {
 	var pconn *net.UDPConn
 	var err error
-	if addr == nil || addr.IP.IsUnspecified() {
-		return nil, serrors.New("nil or unspecified address is not supported")
+	if addr == nil {
+		return nil, serrors.New("nil address is not supported")
+	}
+	if isWildcard(addr) && !wildcardSupported {
+		return nil, serrors.New("unspecified address is not supported on this platform",
+			"addr", addr)
 	}
 	start, end := n.Topology.PortRange.Start, n.Topology.PortRange.End
 	if addr.Port == 0 {
@@ -122,12 +143,26 @@ func (n *SCIONNetwork) OpenRaw(ctx context.Context, addr *net.UDPAddr) (PacketCo
 	if err != nil {
 		return nil, err
 	}
-	return &SCIONPacketConn{
+	if err := configureConn(pconn); err != nil {
+		pconn.Close()
+		return nil, serrors.Wrap("configuring socket", err)
+	}
+	if isWildcard(addr) {
+		if err := enablePacketInfo(pconn); err != nil {
+			pconn.Close()
+			return nil, serrors.Wrap("enabling packet info", err)
+		}
+	}
+	conn := &SCIONPacketConn{
 		Conn:        pconn,
 		SCMPHandler: n.SCMPHandler,
 		Metrics:     n.PacketConnMetrics,
 		Topology:    n.Topology,
-	}, nil
+	}
+	if n.DiscoverPublicAddress || n.PublicIP.IsValid() {
+		conn.public = newPublicAddresses(pconn, n.PublicIP)
+	}
+	return conn, nil
 }
 
 // Dial returns a SCION connection to remote. Parameter network must be "udp".
@@ -156,13 +191,28 @@ func (n *SCIONNetwork) Dial(ctx context.Context, network string, listen *net.UDP
 		return nil, err
 	}
 	log.FromCtx(ctx).Debug("UDP socket opened on", "addr", packetConn.LocalAddr(), "to", remote)
-	return NewCookedConn(packetConn, n.Topology, WithReplyPather(n.ReplyPather), WithRemote(remote))
+	if pc, ok := packetConn.(*SCIONPacketConn); ok && remote.NextHop != nil &&
+		!remote.IA.Equal(n.Topology.LocalIA) {
+		// Learn the public address before the first packet is sent, so that
+		// the reply to it passes the NAT.
+		if err := pc.public.discover(ctx, remote.NextHop); err != nil {
+			log.FromCtx(ctx).Debug("Discovering public address", "err", err)
+		}
+	}
+	return NewCookedConn(packetConn, n.Topology, WithReplyPather(n.ReplyPather),
+		WithRemote(remote), WithNATKeepalive(n.NATKeepaliveInterval))
 }
 
 // Listen opens a Conn. The returned connection's ReadFrom and WriteTo methods
 // can be used to receive and send SCION packets with per-packet addressing.
 // Parameter network must be "udp".
-// Nil or unspecified addresses are not supported.
+// Nil addresses are not supported.
+//
+// If the address is unspecified, the connection accepts packets whose SCION
+// destination address is the address the underlay packet was sent to, and the
+// SCION source address of sent packets is the local address the host uses to
+// reach the underlay next hop. On a dual-stack host listening on [::], this
+// picks an IPv4 or IPv6 address per destination.
 //
 // The context is used for connection setup, it doesn't affect the returned
 // connection.
@@ -181,44 +231,44 @@ func (n *SCIONNetwork) Listen(
 		return nil, err
 	}
 	log.FromCtx(ctx).Debug("UDP socket openned on", "addr", packetConn.LocalAddr())
-	return NewCookedConn(packetConn, n.Topology, WithReplyPather(n.ReplyPather))
+	return NewCookedConn(packetConn, n.Topology, WithReplyPather(n.ReplyPather),
+		WithNATKeepalive(n.NATKeepaliveInterval))
 }
 
 func listenUDPRange(addr *net.UDPAddr, start, end uint16) (*net.UDPConn, error) {
-	// XXX(JordiSubira): For now, we iterate on the complete SCION/UDP
-	// range, in decreasing order, taking the first unused port.
-	//
-	// If the defined range, intersects with the well-known port range, i.e.,
+	// If the defined range intersects with the well-known port range, i.e.,
 	// 1-1023, we just start considering from 1024 onwards.
-	// The decreasing order first try to use the higher port numbers, normally used
-	// by ephemeral connections, letting free the lower port numbers, normally used
-	// by longer-lived applications, e.g., server applications.
-	//
-	// Ideally we would only take a standard ephemeral range, e.g., 32768-65535,
-	// Unfortunately, this range was ocuppied by the old dispatcher.
-	// The default range for the dispatched ports is 31000-32767.
-	// By configuration other port ranges may be defined and restricting to the default
-	// range for applications may cause problems.
-	//
-	// TODO: Replace this implementation with pseudorandom port checking.
-	restrictedStart := start
-	if start < 1024 {
-		restrictedStart = 1024
+	restrictedStart := max(start, 1024)
+	if restrictedStart > end {
+		return nil, serrors.New("no ports available in port range",
+			"start", start, "end", end)
 	}
-	for port := end; port >= restrictedStart; port-- {
+	// The ports are tried starting from a pseudorandom port of the range, and
+	// then in increasing order, wrapping around at the end of the range, taking
+	// the first unused port. Compared to always scanning from the same end,
+	// concurrently opened sockets do not contend for the same ports, the
+	// ephemeral ports are harder to predict, and a mostly used range does not
+	// need a full scan for every socket.
+	//
+	// The default range for the dispatched ports is 31000-32767. By
+	// configuration other port ranges may be defined, so the whole range is
+	// used instead of a standard ephemeral range, e.g., 32768-65535.
+	size := int(end) - int(restrictedStart) + 1
+	offset := rand.IntN(size)
+	for i := range size {
+		port := int(restrictedStart) + (offset+i)%size
 		pconn, err := net.ListenUDP(addr.Network(), &net.UDPAddr{
 			IP:   addr.IP,
-			Port: int(port),
+			Port: port,
 		})
 		if err == nil {
 			return pconn, nil
 		}
-		if errors.Is(err, syscall.EADDRINUSE) {
+		if isAddrInUse(err) {
 			continue
 		}
 		return nil, err
 	}
 	return nil, serrors.Wrap("binding to port range", syscall.EADDRINUSE,
 		"start", restrictedStart, "end", end)
-
 }
@@ -0,0 +1,269 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/stun"
+	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/pkg/snet/path"
+)
+
+func TestOpenRawPortRange(t *testing.T) {
+	const start, end = 40100, 40103
+	n := &snet.SCIONNetwork{
+		Topology: snet.Topology{
+			PortRange: snet.TopologyPortRange{Start: start, End: end},
+		},
+	}
+	ports := make(map[int]bool)
+	for {
+		conn, err := n.OpenRaw(context.Background(), &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		if err != nil {
+			// The ports that are used by other processes are skipped, so the range is
+			// exhausted after at most all of its ports.
+			assert.ErrorIs(t, err, syscall.EADDRINUSE)
+			break
+		}
+		t.Cleanup(func() { conn.Close() })
+		port := conn.LocalAddr().(*net.UDPAddr).Port
+		assert.GreaterOrEqual(t, port, start)
+		assert.LessOrEqual(t, port, end)
+		assert.False(t, ports[port], "port %d opened twice", port)
+		ports[port] = true
+		require.LessOrEqual(t, len(ports), end-start+1)
+	}
+
+	t.Run("well-known ports", func(t *testing.T) {
+		n := &snet.SCIONNetwork{
+			Topology: snet.Topology{
+				PortRange: snet.TopologyPortRange{Start: 1, End: 1023},
+			},
+		}
+		_, err := n.OpenRaw(context.Background(), &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		assert.Error(t, err)
+	})
+}
+
+func TestListenUnspecified(t *testing.T) {
+	const serverPort = 40110
+	ia := addr.MustParseIA("1-ff00:0:110")
+	n := &snet.SCIONNetwork{
+		Topology: snet.Topology{
+			LocalIA:   ia,
+			PortRange: snet.TopologyPortRange{Start: 40110, End: 40119},
+		},
+	}
+	ctx := context.Background()
+	server, err := n.Listen(ctx, "udp", &net.UDPAddr{IP: net.IPv6unspecified, Port: serverPort})
+	if runtime.GOOS == "windows" {
+		assert.ErrorContains(t, err, "unspecified address is not supported")
+		return
+	}
+	require.NoError(t, err)
+	defer server.Close()
+
+	for name, ip := range map[string]netip.Addr{
+		"IPv4": netip.MustParseAddr("127.0.0.1"),
+		"IPv6": netip.MustParseAddr("::1"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, err := n.Listen(ctx, "udp", &net.UDPAddr{IP: ip.AsSlice()})
+			require.NoError(t, err)
+			defer client.Close()
+			require.NoError(t, client.SetDeadline(time.Now().Add(time.Second)))
+			require.NoError(t, server.SetDeadline(time.Now().Add(time.Second)))
+
+			serverAddr := &snet.UDPAddr{
+				IA:   ia,
+				Host: &net.UDPAddr{IP: ip.AsSlice(), Port: serverPort},
+				Path: path.Empty{},
+			}
+			_, err = client.WriteTo([]byte("ping"), serverAddr)
+			require.NoError(t, err)
+
+			buf := make([]byte, 16)
+			m, remote, err := server.ReadFrom(buf)
+			require.NoError(t, err)
+			assert.Equal(t, "ping", string(buf[:m]))
+			assert.Equal(t, client.LocalAddr().(*snet.UDPAddr).Host.String(),
+				remote.(*snet.UDPAddr).Host.String())
+
+			// The reply is sent from the address the client can reach.
+			_, err = server.WriteTo([]byte("pong"), remote)
+			require.NoError(t, err)
+			m, remote, err = client.ReadFrom(buf)
+			require.NoError(t, err)
+			assert.Equal(t, "pong", string(buf[:m]))
+			assert.Equal(t, serverAddr.Host.String(), remote.(*snet.UDPAddr).Host.String())
+		})
+	}
+
+	t.Run("underlay destination mismatch", func(t *testing.T) {
+		client, err := n.OpenRaw(ctx, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+		defer client.Close()
+		require.NoError(t, server.SetDeadline(time.Now().Add(time.Second)))
+
+		send := func(dst string, payload string) {
+			pkt := &snet.Packet{
+				PacketInfo: snet.PacketInfo{
+					Source: snet.SCIONAddress{
+						IA:   ia,
+						Host: addr.MustParseHost("127.0.0.1"),
+					},
+					Destination: snet.SCIONAddress{
+						IA:   ia,
+						Host: addr.MustParseHost(dst),
+					},
+					Payload: snet.UDPPayload{
+						SrcPort: uint16(client.LocalAddr().(*net.UDPAddr).Port),
+						DstPort: serverPort,
+						Payload: []byte(payload),
+					},
+					Path: path.Empty{},
+				},
+			}
+			err := client.WriteTo(pkt, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: serverPort})
+			require.NoError(t, err)
+		}
+		// The first packet claims to be destined to another host and is
+		// discarded.
+		send("127.0.0.2", "reflected")
+		send("127.0.0.1", "valid")
+
+		buf := make([]byte, 16)
+		m, _, err := server.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "valid", string(buf[:m]))
+	})
+}
+
+func TestDialPublicAddress(t *testing.T) {
+	localIA := addr.MustParseIA("1-ff00:0:110")
+	remoteIA := addr.MustParseIA("1-ff00:0:111")
+	public := netip.MustParseAddrPort("203.0.113.5:50123")
+
+	// The border router answers STUN requests as if the host was behind a NAT
+	// that maps it to public, and passes the other packets to the test.
+	router, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer router.Close()
+	packets := make(chan []byte, 4)
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, src, err := router.ReadFromUDPAddrPort(buf)
+			if err != nil {
+				close(packets)
+				return
+			}
+			if txID, err := stun.ParseBindingRequest(buf[:n]); err == nil {
+				_, _ = router.WriteToUDPAddrPort(stun.AppendResponse(nil, txID, public), src)
+				continue
+			}
+			packets <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+	remote := &snet.UDPAddr{
+		IA:      remoteIA,
+		Host:    &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 40000},
+		Path:    path.Empty{},
+		NextHop: router.LocalAddr().(*net.UDPAddr),
+	}
+	received := func(t *testing.T) *snet.Packet {
+		select {
+		case b := <-packets:
+			pkt := &snet.Packet{Bytes: b}
+			require.NoError(t, pkt.Decode())
+			return pkt
+		case <-time.After(time.Second):
+			t.Fatal("no packet received")
+			return nil
+		}
+	}
+
+	testCases := map[string]struct {
+		Network  *snet.SCIONNetwork
+		Expected func(local *net.UDPAddr) netip.AddrPort
+	}{
+		"STUN": {
+			Network: &snet.SCIONNetwork{DiscoverPublicAddress: true},
+			Expected: func(*net.UDPAddr) netip.AddrPort {
+				return public
+			},
+		},
+		"static": {
+			Network: &snet.SCIONNetwork{PublicIP: public.Addr()},
+			Expected: func(local *net.UDPAddr) netip.AddrPort {
+				return netip.AddrPortFrom(public.Addr(), uint16(local.Port))
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tc.Network.Topology = snet.Topology{
+				LocalIA:   localIA,
+				PortRange: snet.TopologyPortRange{Start: 40120, End: 40129},
+			}
+			conn, err := tc.Network.Dial(context.Background(), "udp",
+				&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}, remote)
+			require.NoError(t, err)
+			defer conn.Close()
+			require.NoError(t, conn.SetDeadline(time.Now().Add(time.Second)))
+			local := conn.LocalAddr().(*snet.UDPAddr).Host
+			expected := tc.Expected(local)
+
+			_, err = conn.Write([]byte("ping"))
+			require.NoError(t, err)
+			pkt := received(t)
+			assert.Equal(t, addr.HostIP(expected.Addr()), pkt.Source.Host)
+			assert.Equal(t, expected.Port(), pkt.Payload.(snet.UDPPayload).SrcPort)
+
+			// The reply is addressed to the public address, and the NAT
+			// forwards it to the local address.
+			reply := &snet.Packet{
+				PacketInfo: snet.PacketInfo{
+					Source:      pkt.Destination,
+					Destination: pkt.Source,
+					Path:        path.Empty{},
+					Payload: snet.UDPPayload{
+						SrcPort: 40000,
+						DstPort: expected.Port(),
+						Payload: []byte("pong"),
+					},
+				},
+			}
+			require.NoError(t, reply.Serialize())
+			_, err = router.WriteToUDP(reply.Bytes, local)
+			require.NoError(t, err)
+			buf := make([]byte, 16)
+			m, err := conn.Read(buf)
+			require.NoError(t, err)
+			assert.Equal(t, "pong", string(buf[:m]))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package snet
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// isAddrInUse returns whether err reports that the address is already in use.
+func isAddrInUse(err error) bool {
+	return errors.Is(err, syscall.EADDRINUSE)
+}
+
+// wildcardSupported is whether sockets can be bound to an unspecified address.
+const wildcardSupported = true
+
+// configureConn prepares a freshly opened underlay socket. No preparation is
+// needed outside of Windows.
+func configureConn(conn *net.UDPConn) error {
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package snet
+
+import (
+	"errors"
+	"net"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// isAddrInUse returns whether err reports that the address is already in use.
+// Windows reports WSAEADDRINUSE, which is not the same as syscall.EADDRINUSE.
+func isAddrInUse(err error) bool {
+	return errors.Is(err, windows.WSAEADDRINUSE) || errors.Is(err, syscall.EADDRINUSE)
+}
+
+// wildcardSupported is whether sockets can be bound to an unspecified address.
+// The destination address of the received packets, which is checked for such
+// sockets, cannot be retrieved on Windows, as golang.org/x/net does not
+// implement IP_PKTINFO there.
+const wildcardSupported = false
+
+// configureConn prepares a freshly opened underlay socket.
+//
+// Windows reports an ICMP port unreachable message received for a sent datagram
+// as WSAECONNRESET on the next read of the UDP socket. A SCION socket talks to
+// many destinations, so a single unreachable underlay next hop would break
+// reading. The behavior is disabled with SIO_UDP_CONNRESET.
+func configureConn(conn *net.UDPConn) error {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var ioctlErr error
+	err = rawConn.Control(func(fd uintptr) {
+		enable := uint32(0)
+		var ret uint32
+		ioctlErr = windows.WSAIoctl(windows.Handle(fd), windows.SIO_UDP_CONNRESET,
+			(*byte)(unsafe.Pointer(&enable)), uint32(unsafe.Sizeof(enable)),
+			nil, 0, &ret, nil, 0)
+	})
+	if err != nil {
+		return err
+	}
+	if ioctlErr != nil {
+		return serrors.Wrap("disabling SIO_UDP_CONNRESET", ioctlErr)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package squic
+
+import (
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+const (
+	// defaultConnIDLength is the length of the connection IDs of quic-go if
+	// the transport does not configure it.
+	defaultConnIDLength = 4
+	// migrationIdleTimeout is the time after the last packet of a connection
+	// ID, or from an address, after which it is forgotten.
+	migrationIdleTimeout = 5 * time.Minute
+)
+
+// MigratingConn is the packet connection of a QUIC transport that lets the
+// QUIC connections follow their peers across SCION path changes and address
+// changes, such as a path failover of the peer, see snet.FailoverConn, or the
+// rebinding of a NAT in front of the peer. The QUIC connections keep sending
+// to the address of the first packet of the peer; MigratingConn sends their
+// packets to the address, and on the reversed path, of the latest packet
+// received with the same destination connection ID from that peer.
+//
+// Unlike QUIC connection migration, the new address is not validated, so a
+// packet with a known connection ID redirects the connection. The connection
+// IDs of quic-go are random, so an attacker needs to observe the traffic of
+// the connection for this.
+type MigratingConn struct {
+	net.PacketConn
+	connIDLength int
+
+	mtx sync.Mutex
+	// origins maps the connection IDs to the address of the first packet
+	// received with them.
+	origins map[string]*migrationOrigin
+	// peers maps the address of the first packet of a connection ID to the
+	// address of the latest packet with it.
+	peers       map[peerKey]*migrationPeer
+	lastCleanup time.Time
+}
+
+// peerKey is the SCION address of a peer, without the path.
+type peerKey struct {
+	ia   addr.IA
+	host netip.AddrPort
+}
+
+type migrationOrigin struct {
+	peer     peerKey
+	lastSeen time.Time
+}
+
+type migrationPeer struct {
+	addr     *snet.UDPAddr
+	lastSeen time.Time
+}
+
+// NewMigratingConn returns a MigratingConn on conn, for a QUIC transport with
+// the connection ID length connIDLength. If connIDLength is zero, the default
+// length of quic-go is used.
+func NewMigratingConn(conn net.PacketConn, connIDLength int) *MigratingConn {
+	if connIDLength == 0 {
+		connIDLength = defaultConnIDLength
+	}
+	return &MigratingConn{
+		PacketConn:   conn,
+		connIDLength: connIDLength,
+		origins:      make(map[string]*migrationOrigin),
+		peers:        make(map[peerKey]*migrationPeer),
+		lastCleanup:  time.Now(),
+	}
+}
+
+func (c *MigratingConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	n, a, err := c.PacketConn.ReadFrom(b)
+	if err != nil {
+		return n, a, err
+	}
+	if remote, ok := a.(*snet.UDPAddr); ok && remote.Host != nil {
+		if connID, ok := c.destinationConnID(b[:n]); ok {
+			c.received(connID, remote)
+		}
+	}
+	return n, a, nil
+}
+
+// WriteTo sends b to the latest address of the peer at raddr, on the
+// reversed path of its latest packet.
+func (c *MigratingConn) WriteTo(b []byte, raddr net.Addr) (int, error) {
+	if remote, ok := raddr.(*snet.UDPAddr); ok && remote.Host != nil {
+		if latest := c.latest(remote); latest != nil {
+			raddr = latest
+		}
+	}
+	return c.PacketConn.WriteTo(b, raddr)
+}
+
+// destinationConnID returns the destination connection ID of the QUIC
+// packet b, following the invariants of RFC 8999.
+func (c *MigratingConn) destinationConnID(b []byte) ([]byte, bool) {
+	if len(b) == 0 {
+		return nil, false
+	}
+	if b[0]&0x80 == 0 {
+		// Short header, the length of the connection ID is known.
+		if len(b) < 1+c.connIDLength {
+			return nil, false
+		}
+		return b[1 : 1+c.connIDLength], true
+	}
+	// Long header: flags, version, and the length of the connection ID.
+	if len(b) < 6 || len(b) < 6+int(b[5]) {
+		return nil, false
+	}
+	return b[6 : 6+int(b[5])], true
+}
+
+func (c *MigratingConn) received(connID []byte, remote *snet.UDPAddr) {
+	now := time.Now()
+	key := keyOf(remote)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.cleanup(now)
+	o, ok := c.origins[string(connID)]
+	if !ok {
+		o = &migrationOrigin{peer: key}
+		c.origins[string(connID)] = o
+	}
+	o.lastSeen = now
+	c.peers[o.peer] = &migrationPeer{addr: remote, lastSeen: now}
+}
+
+func (c *MigratingConn) latest(remote *snet.UDPAddr) *snet.UDPAddr {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	p, ok := c.peers[keyOf(remote)]
+	if !ok || time.Since(p.lastSeen) > migrationIdleTimeout {
+		return nil
+	}
+	return p.addr
+}
+
+// cleanup forgets the connection IDs and peers that were idle for
+// migrationIdleTimeout, at most once per minute. The caller must hold mtx.
+func (c *MigratingConn) cleanup(now time.Time) {
+	if now.Sub(c.lastCleanup) < time.Minute {
+		return
+	}
+	c.lastCleanup = now
+	for id, o := range c.origins {
+		if now.Sub(o.lastSeen) > migrationIdleTimeout {
+			delete(c.origins, id)
+		}
+	}
+	for k, p := range c.peers {
+		if now.Sub(p.lastSeen) > migrationIdleTimeout {
+			delete(c.peers, k)
+		}
+	}
+}
+
+func keyOf(a *snet.UDPAddr) peerKey {
+	ap := a.Host.AddrPort()
+	return peerKey{
+		ia:   a.IA,
+		host: netip.AddrPortFrom(ap.Addr().Unmap().WithZone(""), ap.Port()),
+	}
+}
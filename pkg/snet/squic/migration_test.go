@@ -0,0 +1,134 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package squic_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/snet"
+	snetpath "github.com/scionproto/scion/pkg/snet/path"
+	"github.com/scionproto/scion/pkg/snet/squic"
+)
+
+func TestMigratingConn(t *testing.T) {
+	ia := addr.MustParseIA("1-ff00:0:110")
+	peer := func(ip string, port int, path byte) *snet.UDPAddr {
+		return &snet.UDPAddr{
+			IA:   ia,
+			Host: &net.UDPAddr{IP: net.ParseIP(ip), Port: port},
+			Path: snetpath.SCION{Raw: []byte{path}},
+		}
+	}
+	initial := []byte{0xc0, 0, 0, 0, 1, 4, 'a', 'b', 'c', 'd', 0}
+	short := []byte{0x40, 'a', 'b', 'c', 'd', 0}
+	other := []byte{0x40, 'w', 'x', 'y', 'z', 0}
+
+	testCases := map[string]struct {
+		reads []received
+		write *snet.UDPAddr
+		want  *snet.UDPAddr
+	}{
+		"unknown peer": {
+			write: peer("10.0.0.1", 30000, 1),
+			want:  peer("10.0.0.1", 30000, 1),
+		},
+		"same address": {
+			reads: []received{
+				{b: initial, from: peer("10.0.0.1", 30000, 1)},
+				{b: short, from: peer("10.0.0.1", 30000, 1)},
+			},
+			write: peer("10.0.0.1", 30000, 1),
+			want:  peer("10.0.0.1", 30000, 1),
+		},
+		"path change": {
+			reads: []received{
+				{b: initial, from: peer("10.0.0.1", 30000, 1)},
+				{b: short, from: peer("10.0.0.1", 30000, 2)},
+			},
+			write: peer("10.0.0.1", 30000, 1),
+			want:  peer("10.0.0.1", 30000, 2),
+		},
+		"rebinding": {
+			reads: []received{
+				{b: initial, from: peer("10.0.0.1", 30000, 1)},
+				{b: short, from: peer("10.0.0.2", 40000, 2)},
+			},
+			write: peer("10.0.0.1", 30000, 1),
+			want:  peer("10.0.0.2", 40000, 2),
+		},
+		"other connection ID": {
+			reads: []received{
+				{b: initial, from: peer("10.0.0.1", 30000, 1)},
+				{b: other, from: peer("10.0.0.2", 40000, 2)},
+			},
+			write: peer("10.0.0.1", 30000, 1),
+			want:  peer("10.0.0.1", 30000, 1),
+		},
+		"truncated": {
+			reads: []received{
+				{b: initial, from: peer("10.0.0.1", 30000, 1)},
+				{b: short[:3], from: peer("10.0.0.2", 40000, 2)},
+			},
+			write: peer("10.0.0.1", 30000, 1),
+			want:  peer("10.0.0.1", 30000, 1),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pconn := &fakePacketConn{reads: tc.reads}
+			conn := squic.NewMigratingConn(pconn, 0)
+			b := make([]byte, 100)
+			for _, r := range tc.reads {
+				n, from, err := conn.ReadFrom(b)
+				require.NoError(t, err)
+				assert.Equal(t, r.b, b[:n])
+				assert.Equal(t, r.from, from)
+			}
+			_, err := conn.WriteTo([]byte("reply"), tc.write)
+			require.NoError(t, err)
+			require.Len(t, pconn.writes, 1)
+			assert.Equal(t, tc.want, pconn.writes[0])
+		})
+	}
+}
+
+type received struct {
+	b    []byte
+	from *snet.UDPAddr
+}
+
+// fakePacketConn returns the packets in reads and records the destinations
+// of the written packets.
+type fakePacketConn struct {
+	net.PacketConn
+	reads  []received
+	writes []net.Addr
+}
+
+func (c *fakePacketConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	r := c.reads[0]
+	c.reads = c.reads[1:]
+	return copy(b, r.b), r.from, nil
+}
+
+func (c *fakePacketConn) WriteTo(b []byte, raddr net.Addr) (int, error) {
+	c.writes = append(c.writes, raddr)
+	return len(b), nil
+}
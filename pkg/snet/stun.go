@@ -0,0 +1,189 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/common"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/stun"
+)
+
+const (
+	// stunRefreshInterval is the interval at which the public address toward a
+	// border router is requested again while the connection sends packets via
+	// the router. It is shorter than the time for which the routers remember
+	// the address.
+	stunRefreshInterval = time.Minute
+	// stunRetryInterval is the minimum interval between the STUN requests to a
+	// border router that did not answer yet.
+	stunRetryInterval = time.Second
+	// stunTimeout is the time Dial waits for the public address if the
+	// context has no deadline.
+	stunTimeout = time.Second
+)
+
+// publicAddresses are the addresses under which the border routers of the
+// local AS see a connection of a host behind a NAT. They are either learned
+// per border router with STUN binding requests, or given by a static NAT
+// mapping.
+type publicAddresses struct {
+	conn *net.UDPConn
+	// static is the public IP address of a static NAT mapping that preserves
+	// the ports. If it is set, STUN is not used.
+	static netip.Addr
+
+	mtx  sync.Mutex
+	hops map[netip.AddrPort]*stunHop
+}
+
+// stunHop is the state of the STUN exchange with a border router.
+type stunHop struct {
+	public    netip.AddrPort
+	updated   time.Time
+	txID      stun.TxID
+	requested time.Time
+}
+
+func newPublicAddresses(conn *net.UDPConn, static netip.Addr) *publicAddresses {
+	return &publicAddresses{
+		conn:   conn,
+		static: static.Unmap(),
+		hops:   make(map[netip.AddrPort]*stunHop),
+	}
+}
+
+// lookup returns the public address of the connection toward the border router
+// at nextHop, given the local address of the connection. If the address is
+// unknown or due for a refresh, a STUN request is sent to the router and the
+// answer is processed by handle.
+func (a *publicAddresses) lookup(local netip.AddrPort, nextHop *net.UDPAddr) (
+	netip.AddrPort, bool) {
+
+	if a == nil {
+		return netip.AddrPort{}, false
+	}
+	if a.static.IsValid() {
+		return netip.AddrPortFrom(a.static, local.Port()), true
+	}
+	key := unmapAddrPort(nextHop.AddrPort())
+	now := time.Now()
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	h, ok := a.hops[key]
+	if !ok {
+		h = &stunHop{}
+		a.hops[key] = h
+	}
+	if now.Sub(h.updated) >= stunRefreshInterval && now.Sub(h.requested) >= stunRetryInterval {
+		h.txID, h.requested = stun.NewTxID(), now
+		if _, err := a.conn.WriteToUDPAddrPort(stun.Request(h.txID), key); err != nil {
+			log.Debug("Sending STUN request", "next_hop", key, "err", err)
+		}
+	}
+	return h.public, h.public.IsValid()
+}
+
+// handle processes b if it is the answer of a border router to a STUN request,
+// and returns whether it was a STUN message.
+func (a *publicAddresses) handle(b []byte, src netip.AddrPort) bool {
+	if a == nil || !stun.Is(b) {
+		return false
+	}
+	txID, public, err := stun.ParseResponse(b)
+	if err != nil {
+		log.Debug("Parsing STUN response", "src", src, "err", err)
+		return true
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	h, ok := a.hops[unmapAddrPort(src)]
+	if !ok || h.txID != txID {
+		return true
+	}
+	public = unmapAddrPort(public)
+	if h.public != public {
+		log.Debug("Public address learned with STUN", "next_hop", src, "public", public)
+	}
+	h.public, h.updated = public, time.Now()
+	return true
+}
+
+// contains returns whether addr is a public address of the connection. If port
+// is zero, only the IP address is compared.
+func (a *publicAddresses) contains(addr netip.AddrPort) bool {
+	if a == nil {
+		return false
+	}
+	addr = unmapAddrPort(addr)
+	if a.static.IsValid() {
+		return addr.Addr() == a.static
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	for _, h := range a.hops {
+		if h.public.Addr() == addr.Addr() && (addr.Port() == 0 || h.public.Port() == addr.Port()) {
+			return true
+		}
+	}
+	return false
+}
+
+// discover learns the public address toward the border router at nextHop. It
+// reads from the connection, so it must be called before the connection is
+// used.
+func (a *publicAddresses) discover(ctx context.Context, nextHop *net.UDPAddr) error {
+	if a == nil || a.static.IsValid() {
+		return nil
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(stunTimeout)
+	}
+	if err := a.conn.SetReadDeadline(deadline); err != nil {
+		return err
+	}
+	defer func() { _ = a.conn.SetReadDeadline(time.Time{}) }()
+	if _, ok := a.lookup(netip.AddrPort{}, nextHop); ok {
+		return nil
+	}
+	buf := make([]byte, common.SupportedMTU)
+	for {
+		n, src, err := a.conn.ReadFromUDPAddrPort(buf)
+		if err != nil {
+			return serrors.Wrap("waiting for STUN response", err, "next_hop", nextHop)
+		}
+		if !a.handle(buf[:n], src) {
+			continue
+		}
+		a.mtx.Lock()
+		h := a.hops[unmapAddrPort(nextHop.AddrPort())]
+		known := h.public.IsValid()
+		a.mtx.Unlock()
+		if known {
+			return nil
+		}
+	}
+}
+
+func unmapAddrPort(a netip.AddrPort) netip.AddrPort {
+	return netip.AddrPortFrom(a.Addr().Unmap(), a.Port())
+}
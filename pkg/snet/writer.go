@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/private/topology"
 )
@@ -36,8 +37,27 @@ type scionConnWriter struct {
 
 	mtx    sync.Mutex
 	buffer []byte
+	// sources caches the source address per underlay next hop if local is
+	// unspecified.
+	sources map[netip.Addr]cachedSource
+	// keepalive sends NAT keepalives toward the border routers used by the
+	// connection. It is nil if keepalives are disabled.
+	keepalive *natKeepalive
+	// public are the public addresses of the connection if the host is behind
+	// a NAT, see SCIONPacketConn. It is nil if they are not used.
+	public *publicAddresses
 }
 
+// cachedSource is a source address resolved for an underlay next hop.
+type cachedSource struct {
+	addr    netip.Addr
+	expires time.Time
+}
+
+// sourceCacheTTL is the time for which a resolved source address is used
+// before the routing table is consulted again.
+const sourceCacheTTL = time.Minute
+
 // WriteTo sends b to raddr.
 func (c *scionConnWriter) WriteTo(b []byte, raddr net.Addr) (int, error) {
 	var (
@@ -55,7 +75,7 @@ func (c *scionConnWriter) WriteTo(b []byte, raddr net.Addr) (int, error) {
 		if !ok {
 			return 0, serrors.New("invalid destination host IP", "ip", a.Host.IP)
 		}
-		dst = SCIONAddress{IA: a.IA, Host: addr.HostIP(hostIP)}
+		dst = SCIONAddress{IA: a.IA, Host: addr.HostIP(hostIP.Unmap())}
 		port, path = a.Host.Port, a.Path
 		nextHop = a.NextHop
 		if nextHop == nil && c.local.IA.Equal(a.IA) {
@@ -78,9 +98,11 @@ func (c *scionConnWriter) WriteTo(b []byte, raddr net.Addr) (int, error) {
 			"addr", fmt.Sprintf("%v(%T)", a, a))
 	}
 
-	listenHostIP, ok := netip.AddrFromSlice(c.local.Host.IP)
-	if !ok {
-		return 0, serrors.New("invalid listen host IP", "ip", c.local.Host.IP)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	src, natted, err := c.sourceVia(nextHop, dst)
+	if err != nil {
+		return 0, err
 	}
 
 	pkt := &Packet{
@@ -89,25 +111,72 @@ func (c *scionConnWriter) WriteTo(b []byte, raddr net.Addr) (int, error) {
 			Destination: dst,
 			Source: SCIONAddress{
 				IA:   c.local.IA,
-				Host: addr.HostIP(listenHostIP),
+				Host: addr.HostIP(src.Addr()),
 			},
 			Path: path,
 			Payload: UDPPayload{
-				SrcPort: uint16(c.local.Host.Port),
+				SrcPort: src.Port(),
 				DstPort: uint16(port),
 				Payload: b,
 			},
 		},
 	}
 
-	c.mtx.Lock()
-	defer c.mtx.Unlock()
 	if err := c.conn.WriteTo(pkt, nextHop); err != nil {
 		return 0, err
 	}
+	if c.keepalive != nil && nextHop != nil && !dst.IA.Equal(c.local.IA) &&
+		(natted || behindNAT(src.Addr(), nextHop.AddrPort().Addr())) {
+		c.keepalive.sent(nextHop, dst, path)
+	}
 	return len(b), nil
 }
 
+// sendKeepalive sends a NAT keepalive toward the border router of h, see
+// natKeepalive.
+func (c *scionConnWriter) sendKeepalive(h keepaliveHop, seq uint16) {
+	if err := c.writeTraceroute(h.nextHop, h.dst, h.path, false, seq); err != nil {
+		log.Debug("Sending NAT keepalive", "next_hop", h.nextHop, "err", err)
+	}
+}
+
+// writeTraceroute sends an SCMP traceroute request to dst via nextHop on
+// path, with the router alert set on the first or the last hop. The
+// identifier of the request is the source port, so that the reply is
+// delivered to the connection.
+func (c *scionConnWriter) writeTraceroute(nextHop *net.UDPAddr, dst SCIONAddress,
+	path DataplanePath, lastHop bool, seq uint16) error {
+
+	// The raw path may be shared with the packets written by the application,
+	// which update it while serializing, so it is copied under the lock.
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	path, err := routerAlertPath(path, lastHop)
+	if err != nil {
+		return err
+	}
+	src, _, err := c.sourceVia(nextHop, dst)
+	if err != nil {
+		return err
+	}
+	pkt := &Packet{
+		Bytes: Bytes(c.buffer),
+		PacketInfo: PacketInfo{
+			Destination: dst,
+			Source: SCIONAddress{
+				IA:   c.local.IA,
+				Host: addr.HostIP(src.Addr()),
+			},
+			Path: path,
+			Payload: SCMPTracerouteRequest{
+				Identifier: src.Port(),
+				Sequence:   seq,
+			},
+		},
+	}
+	return c.conn.WriteTo(pkt, nextHop)
+}
+
 // Write sends b through a connection with fixed remote address. If the remote
 // address for the connection is unknown, Write returns an error.
 func (c *scionConnWriter) Write(b []byte) (int, error) {
@@ -118,6 +187,58 @@ func (c *scionConnWriter) SetWriteDeadline(t time.Time) error {
 	return c.conn.SetWriteDeadline(t)
 }
 
+// source returns the SCION source address for a packet sent to nextHop. This
+// is the listen address, unless it is unspecified. In that case, the address
+// the host uses to reach nextHop is used. The caller must hold mtx.
+func (c *scionConnWriter) source(nextHop *net.UDPAddr) (netip.Addr, error) {
+	if !isWildcard(c.local.Host) {
+		listenHostIP, ok := netip.AddrFromSlice(c.local.Host.IP)
+		if !ok {
+			return netip.Addr{}, serrors.New("invalid listen host IP", "ip", c.local.Host.IP)
+		}
+		return listenHostIP.Unmap(), nil
+	}
+	if nextHop == nil {
+		return netip.Addr{}, serrors.New("unspecified listen address requires a next hop")
+	}
+	key := nextHop.AddrPort().Addr()
+	now := time.Now()
+	if cached, ok := c.sources[key]; ok && now.Before(cached.expires) {
+		return cached.addr, nil
+	}
+	src, err := resolveSource(nextHop)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if c.sources == nil {
+		c.sources = make(map[netip.Addr]cachedSource)
+	}
+	c.sources[key] = cachedSource{addr: src, expires: now.Add(sourceCacheTTL)}
+	return src, nil
+}
+
+// sourceVia returns the SCION source address and port of a packet sent to dst
+// via nextHop. If the host is behind a NAT and dst is in another AS, this is
+// the public address of the connection toward the border router at nextHop,
+// as far as it is known, and the second return value is true. The caller must
+// hold mtx.
+func (c *scionConnWriter) sourceVia(nextHop *net.UDPAddr, dst SCIONAddress) (
+	netip.AddrPort, bool, error) {
+
+	ip, err := c.source(nextHop)
+	if err != nil {
+		return netip.AddrPort{}, false, err
+	}
+	local := netip.AddrPortFrom(ip, uint16(c.local.Host.Port))
+	if nextHop == nil || dst.IA.Equal(c.local.IA) {
+		return local, false, nil
+	}
+	if public, ok := c.public.lookup(local, nextHop); ok {
+		return public, true, nil
+	}
+	return local, false, nil
+}
+
 func (c *scionConnWriter) isWithinRange(port int) bool {
 	return port >= int(c.dispatchedPortStart) && port <= int(c.dispatchedPortEnd)
 }
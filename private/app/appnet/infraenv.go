@@ -224,8 +224,10 @@ func (nc *NetworkConfig) initQUICSockets() (net.PacketConn, net.PacketConn, erro
 		Topology: nc.Topology,
 		// XXX(roosd): This is essential, the server must not read SCMP
 		// errors. Otherwise, the accept loop will always return that error
-		// on every subsequent call to accept.
-		SCMPHandler:       ignoreSCMP{},
+		// on every subsequent call to accept. The notifications sent to the
+		// server address, e.g., by the routers of the local AS, are still
+		// handled.
+		SCMPHandler:       ignoreSCMP{Handler: nc.SCMPHandler},
 		PacketConnMetrics: nc.SCIONPacketConnMetrics,
 	}
 	pconn, err := serverNet.OpenRaw(context.Background(), nc.Public)
@@ -301,13 +303,23 @@ func NewRouter(localIA addr.IA, sd env.Daemon) (snet.Router, error) {
 	return router, nil
 }
 
-// ignoreSCMP ignores all received SCMP packets.
+// ignoreSCMP passes the received SCMP packets to Handler, if it is set, and
+// ignores the errors it returns.
 //
 // XXX(roosd): This is needed such that the QUIC server does not shut down when
 // receiving a SCMP error. DO NOT REMOVE!
-type ignoreSCMP struct{}
+type ignoreSCMP struct {
+	Handler snet.SCMPHandler
+}
+
+func (h ignoreSCMP) Handle(pkt *snet.Packet) error {
+	return h.HandleUnderlay(pkt, nil)
+}
 
-func (ignoreSCMP) Handle(pkt *snet.Packet) error {
+func (h ignoreSCMP) HandleUnderlay(pkt *snet.Packet, underlay *net.UDPAddr) error {
+	if h.Handler != nil {
+		_ = snet.HandleSCMPUnderlay(h.Handler, pkt, underlay)
+	}
 	// Always reattempt reads from the socket.
 	return nil
 }
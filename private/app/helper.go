@@ -68,16 +68,28 @@ func WithSignal(ctx context.Context, sig ...os.Signal) context.Context {
 // sent to the process. The context is used for clean up, it deregisters the
 // SIGHUP channel and terminates the backgroun go routine on cancellation.
 func SIGHUPChannel(ctx context.Context) chan struct{} {
-	sighupC := make(chan os.Signal, 1)
-	signal.Notify(sighupC, syscall.SIGHUP)
+	return SignalChannel(ctx, syscall.SIGHUP)
+}
+
+// SignalChannel returns a channel that is triggered whenever one of the given
+// signals is sent to the process. At least one signal must be given. The
+// context is used for clean up, it deregisters the signals and terminates the
+// background go routine on cancellation.
+func SignalChannel(ctx context.Context, sig ...os.Signal) chan struct{} {
+	sigC := make(chan os.Signal, 1)
+	signal.Notify(sigC, sig...)
 	ch := make(chan struct{})
 	go func() {
 		defer log.HandlePanic()
-		defer signal.Stop(sighupC)
+		defer signal.Stop(sigC)
 		for {
 			select {
-			case <-sighupC:
-				ch <- struct{}{}
+			case <-sigC:
+				select {
+				case ch <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
 			case <-ctx.Done():
 				return
 			}
@@ -25,6 +25,7 @@ import (
 	"os"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -57,6 +58,8 @@ type Status struct {
 	Status         StatusName
 	LocalIP        netip.Addr
 	AdditionalInfo string
+	// RTT is the round trip time of the probe. It is only set if the path is alive.
+	RTT time.Duration
 }
 
 func (s Status) String() string {
@@ -206,6 +209,7 @@ func (p Prober) GetStatuses(ctx context.Context, paths []snet.Path,
 			}
 
 			// Send probe for each path.
+			sent := make(map[string]time.Time, len(paths))
 			for _, path := range paths {
 				originalPath, ok := path.Dataplane().(snetpath.SCION)
 				if !ok {
@@ -247,6 +251,7 @@ func (p Prober) GetStatuses(ctx context.Context, paths []snet.Path,
 						},
 					},
 				}
+				sent[PathKey(path)] = time.Now()
 				if err := conn.WriteTo(pkt, path.UnderlayNextHop()); err != nil {
 					return err
 				}
@@ -259,6 +264,9 @@ func (p Prober) GetStatuses(ctx context.Context, paths []snet.Path,
 				if err := conn.ReadFrom(&pkt, &ov); err != nil {
 					var r reply
 					if errors.As(err, &r) {
+						if start, ok := sent[r.PathKey]; ok && r.Status.Status == StatusAlive {
+							r.Status.RTT = time.Since(start)
+						}
 						addStatus(r.PathKey, r.Status)
 						continue
 					}
@@ -64,11 +64,15 @@ type PolicyGen interface {
 
 // ChainBuilder creates a certificate chain with the generated policy.
 type ChainBuilder struct {
-	PolicyGen    PolicyGen
-	SignedChains func(string) metrics.Counter
+	PolicyGen PolicyGen
+	// IssuancePolicy constrains the issued certificate chains. If it is nil, a chain is issued
+	// for every CSR.
+	IssuancePolicy *IssuancePolicy
+	SignedChains   func(string) metrics.Counter
 }
 
-// CreateChain creates a certificate chain with the latest available CA policy.
+// CreateChain creates a certificate chain with the latest available CA policy, if the CSR is
+// admitted by the issuance policy.
 func (c ChainBuilder) CreateChain(ctx context.Context,
 	csr *x509.CertificateRequest) ([]*x509.Certificate, error) {
 
@@ -77,6 +81,14 @@ func (c ChainBuilder) CreateChain(ctx context.Context,
 		c.incSignedChains("err_inactive")
 		return nil, err
 	}
+	maxValidity, err := c.IssuancePolicy.Admit(csr, time.Now())
+	if err != nil {
+		c.incSignedChains("err_policy")
+		return nil, err
+	}
+	if maxValidity != 0 && maxValidity < policy.Validity {
+		policy.Validity = maxValidity
+	}
 	chain, err := policy.CreateChain(csr)
 	if err != nil {
 		c.incSignedChains("err_internal")
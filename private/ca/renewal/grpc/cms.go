@@ -17,6 +17,7 @@ package grpc
 import (
 	"context"
 	"crypto/x509"
+	"errors"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -26,6 +27,7 @@ import (
 	"github.com/scionproto/scion/pkg/metrics"
 	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
 	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/ca/renewal"
 )
 
 // ChainBuilder creates a chain for the given CSR.
@@ -47,6 +49,7 @@ type CMSHandlerMetrics struct {
 	NotFoundError metrics.Counter
 	ParseError    metrics.Counter
 	VerifyError   metrics.Counter
+	PolicyError   metrics.Counter
 }
 
 // CMS handles CMS requests.
@@ -86,6 +89,11 @@ func (s CMS) HandleCMSRequest(
 	}
 
 	newClientChain, err := s.ChainBuilder.CreateChain(ctx, csr)
+	if errors.Is(err, renewal.ErrIssuancePolicy) {
+		logger.Info("Certificate chain renewal request rejected", "err", err)
+		metrics.CounterInc(s.Metrics.PolicyError)
+		return nil, status.Error(codes.PermissionDenied, "rejected by issuance policy")
+	}
 	if err != nil {
 		logger.Info("Failed to create renewed certificate chain", "err", err)
 		metrics.CounterInc(s.Metrics.InternalError)
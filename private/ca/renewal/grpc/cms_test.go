@@ -175,6 +175,30 @@ func TestCMSHandleCMSRequest(t *testing.T) {
 			Code:      codes.Unavailable,
 			Metric:    "err_internal",
 		},
+		"rejected by policy": {
+			Request: func(t *testing.T) *cppb.ChainRenewalRequest {
+				return signedReq
+			},
+			Verifier: func(ctrl *gomock.Controller) grpc.RenewalRequestVerifier {
+				v := mock_grpc.NewMockRenewalRequestVerifier(ctrl)
+				v.EXPECT().VerifyCMSSignedRenewalRequest(context.Background(),
+					signedReq.CmsSignedRequest).Return(mockCSR, nil)
+				return v
+			},
+			ChainBuilder: func(ctrl *gomock.Controller) grpc.ChainBuilder {
+				cb := mock_grpc.NewMockChainBuilder(ctrl)
+				cb.EXPECT().CreateChain(gomock.Any(), gomock.Any()).Return(nil,
+					serrors.JoinNoStack(renewal.ErrIssuancePolicy, nil))
+				return cb
+			},
+			CMSSigner: func(ctrl *gomock.Controller) grpc.CMSSigner {
+				return mock_grpc.NewMockCMSSigner(ctrl)
+			},
+			IA:        addr.MustParseIA("1-ff00:0:110"),
+			Assertion: assert.Error,
+			Code:      codes.PermissionDenied,
+			Metric:    "err_policy",
+		},
 		"valid": {
 			Request: func(t *testing.T) *cppb.ChainRenewalRequest {
 				return signedReq
@@ -218,6 +242,7 @@ func TestCMSHandleCMSRequest(t *testing.T) {
 					NotFoundError: ctr.With("result", "err_notfound"),
 					ParseError:    ctr.With("result", "err_parse"),
 					VerifyError:   ctr.With("result", "err_verify"),
+					PolicyError:   ctr.With("result", "err_policy"),
 					Success:       ctr.With("result", "ok_success"),
 				},
 			}
@@ -231,6 +256,7 @@ func TestCMSHandleCMSRequest(t *testing.T) {
 				"err_notfound",
 				"err_parse",
 				"err_verify",
+				"err_policy",
 				"ok_success",
 			} {
 				expected := float64(0)
@@ -0,0 +1,89 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/grpc"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	cppb "github.com/scionproto/scion/pkg/proto/control_plane"
+	"github.com/scionproto/scion/pkg/scrypto/cms/protocol"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/pkg/snet"
+)
+
+// Requester requests renewed certificate chains from the control service of a CA AS.
+type Requester struct {
+	// Dialer dials a new gRPC connection.
+	Dialer grpc.Dialer
+}
+
+// RequestChain sends the renewal request to the control service of the CA AS and returns the
+// issued certificate chain.
+//
+// The signature on the response is not verified, since this requires the certificate chain of
+// the CA AS. The caller must verify the returned chain against the TRCs instead.
+func (r Requester) RequestChain(
+	ctx context.Context,
+	ca addr.IA,
+	req *cppb.ChainRenewalRequest,
+) ([]*x509.Certificate, error) {
+
+	remote := &snet.SVCAddr{IA: ca, SVC: addr.SvcCS}
+	conn, err := r.Dialer.Dial(ctx, remote)
+	if err != nil {
+		return nil, serrors.Wrap("dialing", err, "remote", remote)
+	}
+	defer conn.Close()
+	client := cppb.NewChainRenewalServiceClient(conn)
+	rep, err := client.ChainRenewal(ctx, req, grpc.RetryProfile...)
+	if err != nil {
+		return nil, serrors.Wrap("requesting certificate chain", err, "remote", remote)
+	}
+	chain, err := chainFromResponse(rep)
+	if err != nil {
+		return nil, serrors.Wrap("extracting certificate chain from response", err)
+	}
+	return chain, nil
+}
+
+func chainFromResponse(rep *cppb.ChainRenewalResponse) ([]*x509.Certificate, error) {
+	if len(rep.CmsSignedResponse) == 0 {
+		return nil, serrors.New("CMS signed response missing")
+	}
+	ci, err := protocol.ParseContentInfo(rep.CmsSignedResponse)
+	if err != nil {
+		return nil, serrors.Wrap("parsing ContentInfo", err)
+	}
+	sd, err := ci.SignedDataContent()
+	if err != nil {
+		return nil, serrors.Wrap("parsing SignedData", err)
+	}
+	raw, err := sd.EncapContentInfo.DataEContent()
+	if err != nil {
+		return nil, serrors.Wrap("reading payload", err)
+	}
+	chain, err := x509.ParseCertificates(raw)
+	if err != nil {
+		return nil, serrors.Wrap("parsing certificate chain", err)
+	}
+	if err := cppki.ValidateChain(chain); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+)
+
+// ErrIssuancePolicy indicates that a CSR is rejected by the issuance policy.
+var ErrIssuancePolicy = serrors.New("rejected by issuance policy")
+
+// keyTypes are the key types that can be required by an issuance rule.
+var keyTypes = []string{"P-256", "P-384", "P-521"}
+
+// IssuancePolicy constrains the AS certificates that the CA issues. The rules are evaluated in
+// order for every CSR, and the first rule that matches the ISD-AS of the subject applies. CSRs
+// of subjects that no rule matches are rejected.
+type IssuancePolicy struct {
+	// Rules are the issuance rules.
+	Rules []IssuanceRule `yaml:"Rules"`
+
+	mtx sync.Mutex
+	// issued is the time the last certificate was issued to each subject.
+	issued map[addr.IA]time.Time
+}
+
+// IssuanceRule constrains the AS certificates issued to a set of subjects.
+type IssuanceRule struct {
+	// Subjects are the ISD-ASes the rule applies to. A wildcard ISD or AS number matches any
+	// ISD or AS number.
+	Subjects []addr.IA `yaml:"Subjects"`
+	// MaxValidity is the maximum validity of the issued AS certificates. If it is zero, the
+	// validity is only limited by the CA configuration.
+	MaxValidity time.Duration `yaml:"MaxValidity"`
+	// KeyTypes are the allowed key types of the subject key, i.e., the names of the elliptic
+	// curves. If empty, all key types are allowed.
+	KeyTypes []string `yaml:"KeyTypes"`
+	// MinRenewalInterval is the minimum time between two certificates issued to the same
+	// subject. If it is zero, the renewal frequency is not limited.
+	MinRenewalInterval time.Duration `yaml:"MinRenewalInterval"`
+}
+
+// ParseIssuancePolicyYaml parses the issuance policy in yaml format and validates it.
+func ParseIssuancePolicyYaml(b []byte) (*IssuancePolicy, error) {
+	p := &IssuancePolicy{}
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, serrors.Wrap("parsing issuance policy", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// LoadIssuancePolicyFromYaml loads the issuance policy from a yaml file and validates it.
+func LoadIssuancePolicyFromYaml(path string) (*IssuancePolicy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, serrors.Wrap("reading issuance policy file", err, "path", path)
+	}
+	return ParseIssuancePolicyYaml(b)
+}
+
+// Validate validates the rules of the issuance policy.
+func (p *IssuancePolicy) Validate() error {
+	for i, r := range p.Rules {
+		if len(r.Subjects) == 0 {
+			return serrors.New("issuance rule without subjects", "rule", i)
+		}
+		if r.MaxValidity < 0 || r.MinRenewalInterval < 0 {
+			return serrors.New("negative duration in issuance rule", "rule", i)
+		}
+		for _, k := range r.KeyTypes {
+			if !slices.Contains(keyTypes, k) {
+				return serrors.New("unknown key type in issuance rule", "rule", i, "key_type", k,
+					"supported", keyTypes)
+			}
+		}
+	}
+	return nil
+}
+
+// Admit evaluates the policy for the CSR at the given time. If the CSR is admitted, it returns
+// the maximum validity of the certificate, or zero if it is not limited by the policy, and
+// records the issuance for the renewal frequency limit. Otherwise, it returns an error that
+// wraps ErrIssuancePolicy. The renewal times are only kept in memory, a restart resets them.
+//
+// A nil policy admits all CSRs.
+func (p *IssuancePolicy) Admit(csr *x509.CertificateRequest, now time.Time) (time.Duration, error) {
+	if p == nil {
+		return 0, nil
+	}
+	subject, err := cppki.ExtractIA(csr.Subject)
+	if err != nil {
+		return 0, serrors.Wrap("extracting subject ISD-AS", err)
+	}
+	i := slices.IndexFunc(p.Rules, func(r IssuanceRule) bool {
+		return slices.ContainsFunc(r.Subjects, func(ia addr.IA) bool {
+			return matchIA(ia, subject)
+		})
+	})
+	if i < 0 {
+		return 0, serrors.JoinNoStack(ErrIssuancePolicy, nil, "isd_as", subject,
+			"reason", "subject not allowed")
+	}
+	r := p.Rules[i]
+	if len(r.KeyTypes) > 0 {
+		if k := keyType(csr); !slices.Contains(r.KeyTypes, k) {
+			return 0, serrors.JoinNoStack(ErrIssuancePolicy, nil, "isd_as", subject,
+				"reason", "key type not allowed", "key_type", k)
+		}
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if last, ok := p.issued[subject]; ok && now.Sub(last) < r.MinRenewalInterval {
+		return 0, serrors.JoinNoStack(ErrIssuancePolicy, nil, "isd_as", subject,
+			"reason", "renewed too frequently", "last_issued", last,
+			"min_interval", r.MinRenewalInterval)
+	}
+	if p.issued == nil {
+		p.issued = make(map[addr.IA]time.Time)
+	}
+	p.issued[subject] = now
+	return r.MaxValidity, nil
+}
+
+// matchIA returns whether the ISD-AS matches the pattern, which may contain wildcards.
+func matchIA(pattern, ia addr.IA) bool {
+	return (pattern.ISD() == 0 || pattern.ISD() == ia.ISD()) &&
+		(pattern.AS() == 0 || pattern.AS() == ia.AS())
+}
+
+// keyType returns the key type of the subject key of the CSR.
+func keyType(csr *x509.CertificateRequest) string {
+	if pub, ok := csr.PublicKey.(*ecdsa.PublicKey); ok {
+		return pub.Curve.Params().Name
+	}
+	return csr.PublicKeyAlgorithm.String()
+}
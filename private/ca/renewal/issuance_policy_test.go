@@ -0,0 +1,123 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/ca/renewal"
+)
+
+func TestParseIssuancePolicyYaml(t *testing.T) {
+	p, err := renewal.ParseIssuancePolicyYaml([]byte(`
+Rules:
+  - Subjects: ["1-ff00:0:110"]
+    MaxValidity: 24h
+    KeyTypes: ["P-384"]
+    MinRenewalInterval: 1h
+  - Subjects: ["1-0"]
+`))
+	require.NoError(t, err)
+	require.Len(t, p.Rules, 2)
+	assert.Equal(t, 24*time.Hour, p.Rules[0].MaxValidity)
+	assert.Equal(t, []string{"P-384"}, p.Rules[0].KeyTypes)
+	assert.Equal(t, time.Hour, p.Rules[0].MinRenewalInterval)
+
+	for name, raw := range map[string]string{
+		"unknown field":    `Rule: []`,
+		"no subjects":      `Rules: [{MaxValidity: 1h}]`,
+		"unknown key type": `Rules: [{Subjects: ["1-0"], KeyTypes: ["RSA"]}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := renewal.ParseIssuancePolicyYaml([]byte(raw))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIssuancePolicyAdmit(t *testing.T) {
+	p := &renewal.IssuancePolicy{
+		Rules: []renewal.IssuanceRule{
+			{
+				Subjects:           []addr.IA{addr.MustParseIA("1-ff00:0:110")},
+				MaxValidity:        24 * time.Hour,
+				KeyTypes:           []string{"P-384"},
+				MinRenewalInterval: time.Hour,
+			},
+			{
+				Subjects: []addr.IA{addr.MustParseIA("1-0")},
+			},
+		},
+	}
+	now := time.Now()
+
+	// The first matching rule applies.
+	_, err := p.Admit(newCSR(t, "1-ff00:0:110", elliptic.P256()), now)
+	assert.ErrorIs(t, err, renewal.ErrIssuancePolicy)
+	validity, err := p.Admit(newCSR(t, "1-ff00:0:110", elliptic.P384()), now)
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, validity)
+
+	// The renewal frequency is limited.
+	_, err = p.Admit(newCSR(t, "1-ff00:0:110", elliptic.P384()), now.Add(time.Minute))
+	assert.ErrorIs(t, err, renewal.ErrIssuancePolicy)
+	_, err = p.Admit(newCSR(t, "1-ff00:0:110", elliptic.P384()), now.Add(time.Hour))
+	assert.NoError(t, err)
+
+	// Subjects are matched with wildcards.
+	validity, err = p.Admit(newCSR(t, "1-ff00:0:111", elliptic.P256()), now)
+	require.NoError(t, err)
+	assert.Zero(t, validity)
+	_, err = p.Admit(newCSR(t, "2-ff00:0:210", elliptic.P256()), now)
+	assert.ErrorIs(t, err, renewal.ErrIssuancePolicy)
+
+	// A nil policy admits all CSRs.
+	var nilPolicy *renewal.IssuancePolicy
+	_, err = nilPolicy.Admit(newCSR(t, "2-ff00:0:210", elliptic.P256()), now)
+	assert.NoError(t, err)
+}
+
+func newCSR(t *testing.T, ia string, curve elliptic.Curve) *x509.CertificateRequest {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName: ia + " AS Certificate",
+			ExtraNames: []pkix.AttributeTypeAndValue{
+				{
+					Type:  cppki.OIDNameIA,
+					Value: ia,
+				},
+			},
+		},
+	}
+	raw, err := x509.CreateCertificateRequest(rand.Reader, tmpl, key)
+	require.NoError(t, err)
+	csr, err := x509.ParseCertificateRequest(raw)
+	require.NoError(t, err)
+	return csr
+}
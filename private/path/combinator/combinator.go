@@ -25,6 +25,7 @@ import (
 	"encoding/binary"
 	"hash"
 	"slices"
+	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	seg "github.com/scionproto/scion/pkg/segment"
@@ -33,8 +34,8 @@ import (
 )
 
 // Combine constructs paths between src and dst using the supplied
-// segments. All possible paths are first computed, and then filtered according
-// to filterLongPaths.
+// segments. All possible paths are first computed, and then the paths with
+// useless loops are filtered out, see isLongPath.
 //
 // Normally, with findAllIdentical=false, Combine returns one path for each
 // unique sequence of path interfaces found. If there are multiple ways to
@@ -55,20 +56,41 @@ import (
 // weight (on equal weight, see pathSolutionList.Less for the tie-breaking
 // algorithm).
 //
+// The number of combinations grows with the product of the number of up, core
+// and down segments. To keep the cost in check for destinations with many
+// candidate segments, the interface sequence and the expiration time of each
+// combination are first computed from the forwarding segments of its edges,
+// which are shared by all combinations. The full forwarding paths and their
+// metadata are only built for the combinations that remain after filtering.
+//
 // If Combine cannot extract a hop field or info field from the segments, it
 // panics.
 func Combine(src, dst addr.IA, ups, cores, downs []*seg.PathSegment,
 	findAllIdentical bool) []Path {
 
 	solutions := newDMG(ups, cores, downs).GetPaths(vertexFromIA(src), vertexFromIA(dst))
-	paths := make([]Path, len(solutions))
-	st := newHashState()
-	for i, solution := range solutions {
-		paths[i] = solution.Path(st)
+	b := newPathBuilder()
+	candidates := make([]candidate, 0, len(solutions))
+	for _, solution := range solutions {
+		c := b.Candidate(solution)
+		if isLongPath(c.interfaces) {
+			continue
+		}
+		candidates = append(candidates, c)
 	}
-	paths = filterLongPaths(paths)
 	if !findAllIdentical {
-		paths = filterDuplicates(paths)
+		keep := latestUnique(len(candidates), func(i int) (snet.PathFingerprint, time.Time) {
+			return candidates[i].fingerprint, candidates[i].expiry
+		})
+		unique := make([]candidate, 0, len(keep))
+		for _, i := range keep {
+			unique = append(unique, candidates[i])
+		}
+		candidates = unique
+	}
+	paths := make([]Path, 0, len(candidates))
+	for _, c := range candidates {
+		paths = append(paths, b.Path(c))
 	}
 	return paths
 }
@@ -81,26 +103,17 @@ type Path struct {
 	Fingerprint snet.PathFingerprint
 }
 
-// filterLongPaths returns a new slice containing only those paths that do not
-// go more than twice through interfaces belonging to the same AS (thus
-// filtering paths containing useless loops).
-func filterLongPaths(paths []Path) []Path {
-	var newPaths []Path
-	for _, path := range paths {
-		long := false
-		iaCounts := make(map[addr.IA]int)
-		for _, iface := range path.Metadata.Interfaces {
-			iaCounts[iface.IA]++
-			if iaCounts[iface.IA] > 2 {
-				long = true
-				break
-			}
-		}
-		if !long {
-			newPaths = append(newPaths, path)
+// isLongPath returns whether the path goes more than twice through interfaces
+// belonging to the same AS, i.e., whether the path contains a useless loop.
+func isLongPath(interfaces []snet.PathInterface) bool {
+	iaCounts := make(map[addr.IA]int)
+	for _, iface := range interfaces {
+		iaCounts[iface.IA]++
+		if iaCounts[iface.IA] > 2 {
+			return true
 		}
 	}
-	return newPaths
+	return false
 }
 
 // filterDuplicates removes paths with identical sequences of path interfaces,
@@ -111,13 +124,30 @@ func filterLongPaths(paths []Path) []Path {
 // available options in the graph, as we could potentially create a large
 // number of duplicates in wide network topologies.
 func filterDuplicates(paths []Path) []Path {
+	toKeep := latestUnique(len(paths), func(i int) (snet.PathFingerprint, time.Time) {
+		return paths[i].Fingerprint, paths[i].Metadata.Expiry
+	})
+	filtered := make([]Path, 0, len(toKeep))
+	for _, i := range toKeep {
+		filtered = append(filtered, paths[i])
+	}
+	return filtered
+}
+
+// latestUnique returns the indices of the n elements to keep, in ascending
+// order: the element with the latest expiry for every unique path interface
+// sequence (== fingerprint).
+func latestUnique(n int, get func(i int) (snet.PathFingerprint, time.Time)) []int {
 	// uniquePaths stores the index of the path with the latest expiry for every
-	// unique path interface sequence (== fingerprint).
+	// unique path interface sequence.
 	uniquePaths := make(map[snet.PathFingerprint]int)
-	for i, p := range paths {
-		prev, dupe := uniquePaths[p.Fingerprint]
-		if !dupe || p.Metadata.Expiry.After(paths[prev].Metadata.Expiry) {
-			uniquePaths[p.Fingerprint] = i
+	expiries := make(map[snet.PathFingerprint]time.Time)
+	for i := range n {
+		fp, expiry := get(i)
+		_, dupe := uniquePaths[fp]
+		if !dupe || expiry.After(expiries[fp]) {
+			uniquePaths[fp] = i
+			expiries[fp] = expiry
 		}
 	}
 
@@ -126,11 +156,7 @@ func filterDuplicates(paths []Path) []Path {
 		toKeep = append(toKeep, idx)
 	}
 	slices.Sort(toKeep)
-	filtered := make([]Path, 0, len(toKeep))
-	for _, i := range toKeep {
-		filtered = append(filtered, paths[i])
-	}
-	return filtered
+	return toKeep
 }
 
 // fingerprint uniquely identifies the path based on the sequence of
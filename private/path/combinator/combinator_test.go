@@ -563,6 +563,58 @@ func TestComputePath(t *testing.T) {
 		})
 	}
 }
+
+// manySegments returns the segments for paths from 1-ff00:0:132 to 1-ff00:0:112, with n copies
+// of each segment, as with segments that were originated repeatedly. The core segment to
+// 1-ff00:0:110 does not lead to the destination.
+func manySegments(g *graph.Graph, n int) (ups, cores, downs []*seg.PathSegment) {
+	for range n {
+		ups = append(ups, g.Beacon([]uint16{graph.If_130_A_131_X, graph.If_131_X_132_X}))
+		cores = append(cores,
+			g.Beacon([]uint16{graph.If_110_X_130_A}),
+			g.Beacon([]uint16{graph.If_120_A_130_B}),
+		)
+		downs = append(downs, g.Beacon([]uint16{graph.If_120_X_111_B, graph.If_111_A_112_X}))
+	}
+	return ups, cores, downs
+}
+
+func TestCombineManySegments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	src, dst := addr.MustParseIA("1-ff00:0:132"), addr.MustParseIA("1-ff00:0:112")
+
+	fingerprints := func(paths []combinator.Path) []snet.PathFingerprint {
+		var fps []snet.PathFingerprint
+		for _, p := range paths {
+			fps = append(fps, p.Fingerprint)
+		}
+		return fps
+	}
+	ups, cores, downs := manySegments(g, 1)
+	expected := combinator.Combine(src, dst, ups, cores, downs, false)
+	require.NotEmpty(t, expected)
+
+	ups, cores, downs = manySegments(g, 10)
+	result := combinator.Combine(src, dst, ups, cores, downs, false)
+	assert.ElementsMatch(t, fingerprints(expected), fingerprints(result))
+	all := combinator.Combine(src, dst, ups, cores, downs, true)
+	assert.Len(t, all, 10*10*10*len(expected))
+}
+
+func BenchmarkCombine(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	src, dst := addr.MustParseIA("1-ff00:0:132"), addr.MustParseIA("1-ff00:0:112")
+	ups, cores, downs := manySegments(g, 20)
+	b.ResetTimer()
+	for range b.N {
+		combinator.Combine(src, dst, ups, cores, downs, false)
+	}
+}
+
 func TestFilterDuplicates(t *testing.T) {
 	// Define three different path interface sequences for the test cases below.
 	// These look somewhat valid, but that doesn't matter at all -- we only look
@@ -194,7 +194,12 @@ func (g *dmg) AddEdge(src, dst vertex, segment *inputSegment, e *edge) {
 }
 
 // GetPaths returns all the paths from src to dst, sorted according to weight.
+//
+// The exploration is pruned to the vertices from which dst can be reached, so
+// that, e.g., the core segments towards core ASes that have no down segment to
+// dst are not explored.
 func (g *dmg) GetPaths(src, dst vertex) []*pathSolution {
+	reachesDst := g.reaching(dst)
 	var solutions []*pathSolution
 	queue := []*pathSolution{{currentVertex: src}}
 	for len(queue) > 0 {
@@ -202,11 +207,19 @@ func (g *dmg) GetPaths(src, dst vertex) []*pathSolution {
 		queue = queue[1:]
 
 		for nextVertex, edgeList := range g.Adjacencies[currentPathSolution.currentVertex] {
+			if _, ok := reachesDst[nextVertex]; !ok {
+				continue
+			}
 			for segment, e := range edgeList {
 				// Makes sure the the segment would be valid in a path.
 				if !validNextSeg(currentPathSolution.currentSeg, segment) {
 					continue
 				}
+				// No segment can follow a down segment, so a solution that
+				// does not end at dst with it is a dead end.
+				if segment.IsDownSeg() && nextVertex != dst {
+					continue
+				}
 				// Create a copy of the old solution s.t. trail slices do not
 				// get mixed during appends.
 				newSolution := &pathSolution{
@@ -261,6 +274,30 @@ func (g *dmg) GetPaths(src, dst vertex) []*pathSolution {
 	return solutions
 }
 
+// reaching returns the vertices from which dst can be reached, including dst
+// itself.
+func (g *dmg) reaching(dst vertex) map[vertex]struct{} {
+	predecessors := make(map[vertex][]vertex)
+	for src, neighbors := range g.Adjacencies {
+		for dst := range neighbors {
+			predecessors[dst] = append(predecessors[dst], src)
+		}
+	}
+	reached := map[vertex]struct{}{dst: {}}
+	queue := []vertex{dst}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, p := range predecessors[v] {
+			if _, ok := reached[p]; !ok {
+				reached[p] = struct{}{}
+				queue = append(queue, p)
+			}
+		}
+	}
+	return reached
+}
+
 // inputSegment is a local representation of a path segment that includes the
 // segment's type. The type (up down or core) indicates the role that this
 // segment holds in a path solution. That is, in which order the hops would
@@ -339,118 +376,91 @@ type pathSolution struct {
 	cost int
 }
 
-// Path builds the forwarding path with metadata by extracting it from a path
-// between source and destination in the DMG.
-func (solution *pathSolution) Path(hashState hashState) Path {
-	mtu := ^uint16(0)
-	var segments segmentList
-	var epicPathAuths [][]byte
-	for _, solEdge := range solution.edges {
-		var hops []path.HopField
-		var intfs []snet.PathInterface
-		var pathASEntries []seg.ASEntry // ASEntries that on the path, eventually in path order.
-		var epicSegAuths [][]byte
-
-		// Segments are in construction order, regardless of whether they're
-		// up or down segments. We traverse them FROM THE END. So, in reverse
-		// forwarding order for down segments and in forwarding order for
-		// up segments.
-		// We go through each ASEntry, starting from the last one until we
-		// find a shortcut (which can be 0, meaning the end of the segment).
-		asEntries := solEdge.segment.ASEntries
-		for asEntryIdx := len(asEntries) - 1; asEntryIdx >= solEdge.edge.Shortcut; asEntryIdx-- {
-			isShortcut := asEntryIdx == solEdge.edge.Shortcut && solEdge.edge.Shortcut != 0
-			isPeer := asEntryIdx == solEdge.edge.Shortcut && solEdge.edge.Peer != 0
-			asEntry := asEntries[asEntryIdx]
-
-			var hopField path.HopField
-			var forwardingLinkMtu int
-			var epicAuth []byte
-			if !isPeer {
-				// Regular hop field.
-				entry := asEntry.HopEntry
-				hopField = path.HopField{
-					ExpTime:     entry.HopField.ExpTime,
-					ConsIngress: entry.HopField.ConsIngress,
-					ConsEgress:  entry.HopField.ConsEgress,
-					Mac:         entry.HopField.MAC,
-				}
-				forwardingLinkMtu = entry.IngressMTU
-				epicAuth = getAuth(&asEntry)
-			} else {
-				// We've reached the ASEntry where we want to switch
-				// segments on a peering link.
-				peer := asEntry.PeerEntries[solEdge.edge.Peer-1]
-				hopField = path.HopField{
-					ExpTime:     peer.HopField.ExpTime,
-					ConsIngress: peer.HopField.ConsIngress,
-					ConsEgress:  peer.HopField.ConsEgress,
-					Mac:         peer.HopField.MAC,
-				}
-				forwardingLinkMtu = peer.PeerMTU
-				epicAuth = getAuthPeer(&asEntry, solEdge.edge.Peer-1)
-			}
+// pathBuilder builds the forwarding paths of the solutions in the DMG. The
+// forwarding segment of each solution edge is only computed once, and shared by
+// all the solutions that contain the edge. With many candidate segments, the
+// same edges appear in a large number of solutions.
+type pathBuilder struct {
+	hashState hashState
+	segments  map[segmentKey]*forwardingSegment
+}
 
-			// Segment is traversed in reverse construction direction.
-			// Only include non-zero interfaces.
-			if hopField.ConsEgress != 0 {
-				intfs = append(intfs, snet.PathInterface{
-					IA: asEntry.Local,
-					ID: iface.ID(hopField.ConsEgress),
-				})
-			}
-			// In a non-peer shortcut the AS is not traversed completely.
-			if hopField.ConsIngress != 0 && (!isShortcut || isPeer) {
-				intfs = append(intfs, snet.PathInterface{
-					IA: asEntry.Local,
-					ID: iface.ID(hopField.ConsIngress),
-				})
-			}
-			hops = append(hops, hopField)
-			pathASEntries = append(pathASEntries, asEntry)
-			epicSegAuths = append(epicSegAuths, epicAuth)
-
-			mtu = minUint16(mtu, uint16(asEntry.MTU))
-			if forwardingLinkMtu != 0 {
-				// The first HE in a segment has MTU 0, so we ignore those
-				mtu = minUint16(mtu, uint16(forwardingLinkMtu))
-			}
-		}
+func newPathBuilder() *pathBuilder {
+	return &pathBuilder{
+		hashState: newHashState(),
+		segments:  make(map[segmentKey]*forwardingSegment),
+	}
+}
 
-		// Put the hops in forwarding order. Needed for down segments
-		// since we collected hops from the end, just like for up
-		// segments.
-		if solEdge.segment.Type == proto.PathSegType_down {
-			reverseHops(hops)
-			reverseIntfs(intfs)
-			reverseASEntries(pathASEntries)
-			reverseEpicAuths(epicSegAuths)
+// segmentKey identifies the part of a path segment that is used by a solution
+// edge.
+type segmentKey struct {
+	segment  *inputSegment
+	shortcut int
+	peer     int
+}
+
+// forwardingSegment is the forwarding information of a solution edge.
+type forwardingSegment struct {
+	segment
+	// mtu is the minimum MTU of the ASes and links of the segment.
+	mtu uint16
+	// epicAuths are the EPIC authenticators of the hops, in forwarding order.
+	epicAuths [][]byte
+	// expiry is the expiration time of the segment.
+	expiry time.Time
+}
+
+// candidate is a solution whose path interfaces, expiration time and
+// fingerprint are known, but whose forwarding path is not built yet.
+type candidate struct {
+	solution    *pathSolution
+	segments    []*forwardingSegment
+	interfaces  []snet.PathInterface
+	expiry      time.Time
+	fingerprint snet.PathFingerprint
+}
+
+// Candidate computes the path interfaces, the expiration time and the
+// fingerprint of the solution.
+func (b *pathBuilder) Candidate(solution *pathSolution) candidate {
+	c := candidate{
+		solution: solution,
+		segments: make([]*forwardingSegment, 0, len(solution.edges)),
+		expiry:   maxExpirationTime,
+	}
+	for _, solEdge := range solution.edges {
+		fs := b.forwardingSegment(solEdge)
+		c.segments = append(c.segments, fs)
+		c.interfaces = append(c.interfaces, fs.Interfaces...)
+		if c.expiry.After(fs.expiry) {
+			c.expiry = fs.expiry
 		}
+	}
+	c.fingerprint = fingerprint(c.interfaces, b.hashState)
+	return c
+}
 
-		segments = append(segments, segment{
-			InfoField: path.InfoField{
-				Timestamp: util.TimeToSecs(solEdge.segment.Info.Timestamp),
-				SegID:     calculateBeta(solEdge),
-				ConsDir:   solEdge.segment.IsDownSeg(),
-				Peer:      solEdge.edge.Peer != 0,
-			},
-			HopFields:  hops,
-			Interfaces: intfs,
-			ASEntries:  pathASEntries,
-		})
-		epicPathAuths = append(epicPathAuths, epicSegAuths...)
+// Path builds the forwarding path with metadata of the candidate.
+func (b *pathBuilder) Path(c candidate) Path {
+	mtu := ^uint16(0)
+	segments := make(segmentList, 0, len(c.segments))
+	var epicPathAuths [][]byte
+	for _, fs := range c.segments {
+		segments = append(segments, fs.segment)
+		mtu = minUint16(mtu, fs.mtu)
+		epicPathAuths = append(epicPathAuths, fs.epicAuths...)
 	}
 
-	interfaces := segments.Interfaces()
 	asEntries := segments.ASEntries()
-	staticInfo := collectMetadata(interfaces, asEntries)
+	staticInfo := collectMetadata(c.interfaces, asEntries)
 
 	path := Path{
 		SCIONPath: segments.ScionPath(),
 		Metadata: snet.PathMetadata{
-			Interfaces:   interfaces,
+			Interfaces:   c.interfaces,
 			MTU:          mtu,
-			Expiry:       segments.ComputeExpTime(),
+			Expiry:       c.expiry,
 			Latency:      staticInfo.Latency,
 			Bandwidth:    staticInfo.Bandwidth,
 			Geo:          staticInfo.Geo,
@@ -458,8 +468,8 @@ func (solution *pathSolution) Path(hashState hashState) Path {
 			InternalHops: staticInfo.InternalHops,
 			Notes:        staticInfo.Notes,
 		},
-		Weight:      solution.cost,
-		Fingerprint: fingerprint(interfaces, hashState),
+		Weight:      c.solution.cost,
+		Fingerprint: c.fingerprint,
 	}
 
 	if authPHVF, authLHVF, ok := isEpicAvailable(epicPathAuths); ok {
@@ -472,6 +482,126 @@ func (solution *pathSolution) Path(hashState hashState) Path {
 	return path
 }
 
+// forwardingSegment returns the forwarding segment of the solution edge,
+// extracting it from the path segment if it is not known yet.
+func (b *pathBuilder) forwardingSegment(solEdge *solutionEdge) *forwardingSegment {
+	key := segmentKey{
+		segment:  solEdge.segment,
+		shortcut: solEdge.edge.Shortcut,
+		peer:     solEdge.edge.Peer,
+	}
+	if fs, ok := b.segments[key]; ok {
+		return fs
+	}
+	fs := newForwardingSegment(solEdge)
+	b.segments[key] = fs
+	return fs
+}
+
+// newForwardingSegment extracts the forwarding information of the solution
+// edge from its path segment.
+func newForwardingSegment(solEdge *solutionEdge) *forwardingSegment {
+	mtu := ^uint16(0)
+	var hops []path.HopField
+	var intfs []snet.PathInterface
+	var pathASEntries []seg.ASEntry // ASEntries that on the path, eventually in path order.
+	var epicSegAuths [][]byte
+
+	// Segments are in construction order, regardless of whether they're
+	// up or down segments. We traverse them FROM THE END. So, in reverse
+	// forwarding order for down segments and in forwarding order for
+	// up segments.
+	// We go through each ASEntry, starting from the last one until we
+	// find a shortcut (which can be 0, meaning the end of the segment).
+	asEntries := solEdge.segment.ASEntries
+	for asEntryIdx := len(asEntries) - 1; asEntryIdx >= solEdge.edge.Shortcut; asEntryIdx-- {
+		isShortcut := asEntryIdx == solEdge.edge.Shortcut && solEdge.edge.Shortcut != 0
+		isPeer := asEntryIdx == solEdge.edge.Shortcut && solEdge.edge.Peer != 0
+		asEntry := asEntries[asEntryIdx]
+
+		var hopField path.HopField
+		var forwardingLinkMtu int
+		var epicAuth []byte
+		if !isPeer {
+			// Regular hop field.
+			entry := asEntry.HopEntry
+			hopField = path.HopField{
+				ExpTime:     entry.HopField.ExpTime,
+				ConsIngress: entry.HopField.ConsIngress,
+				ConsEgress:  entry.HopField.ConsEgress,
+				Mac:         entry.HopField.MAC,
+			}
+			forwardingLinkMtu = entry.IngressMTU
+			epicAuth = getAuth(&asEntry)
+		} else {
+			// We've reached the ASEntry where we want to switch
+			// segments on a peering link.
+			peer := asEntry.PeerEntries[solEdge.edge.Peer-1]
+			hopField = path.HopField{
+				ExpTime:     peer.HopField.ExpTime,
+				ConsIngress: peer.HopField.ConsIngress,
+				ConsEgress:  peer.HopField.ConsEgress,
+				Mac:         peer.HopField.MAC,
+			}
+			forwardingLinkMtu = peer.PeerMTU
+			epicAuth = getAuthPeer(&asEntry, solEdge.edge.Peer-1)
+		}
+
+		// Segment is traversed in reverse construction direction.
+		// Only include non-zero interfaces.
+		if hopField.ConsEgress != 0 {
+			intfs = append(intfs, snet.PathInterface{
+				IA: asEntry.Local,
+				ID: iface.ID(hopField.ConsEgress),
+			})
+		}
+		// In a non-peer shortcut the AS is not traversed completely.
+		if hopField.ConsIngress != 0 && (!isShortcut || isPeer) {
+			intfs = append(intfs, snet.PathInterface{
+				IA: asEntry.Local,
+				ID: iface.ID(hopField.ConsIngress),
+			})
+		}
+		hops = append(hops, hopField)
+		pathASEntries = append(pathASEntries, asEntry)
+		epicSegAuths = append(epicSegAuths, epicAuth)
+
+		mtu = minUint16(mtu, uint16(asEntry.MTU))
+		if forwardingLinkMtu != 0 {
+			// The first HE in a segment has MTU 0, so we ignore those
+			mtu = minUint16(mtu, uint16(forwardingLinkMtu))
+		}
+	}
+
+	// Put the hops in forwarding order. Needed for down segments
+	// since we collected hops from the end, just like for up
+	// segments.
+	if solEdge.segment.Type == proto.PathSegType_down {
+		reverseHops(hops)
+		reverseIntfs(intfs)
+		reverseASEntries(pathASEntries)
+		reverseEpicAuths(epicSegAuths)
+	}
+
+	fs := &forwardingSegment{
+		segment: segment{
+			InfoField: path.InfoField{
+				Timestamp: util.TimeToSecs(solEdge.segment.Info.Timestamp),
+				SegID:     calculateBeta(solEdge),
+				ConsDir:   solEdge.segment.IsDownSeg(),
+				Peer:      solEdge.edge.Peer != 0,
+			},
+			HopFields:  hops,
+			Interfaces: intfs,
+			ASEntries:  pathASEntries,
+		},
+		mtu:       mtu,
+		epicAuths: epicSegAuths,
+	}
+	fs.expiry = fs.ComputeExpTime()
+	return fs
+}
+
 func getAuth(a *seg.ASEntry) []byte {
 	if a.UnsignedExtensions.EpicDetached == nil {
 		return nil
@@ -630,16 +760,6 @@ func (segment *segment) computeHopFieldsTTL() time.Duration {
 // information.
 type segmentList []segment
 
-// Interfaces returns the concatenated lists of interfaces from the individual
-// segments
-func (s segmentList) Interfaces() []snet.PathInterface {
-	var intfs []snet.PathInterface
-	for _, seg := range s {
-		intfs = append(intfs, seg.Interfaces...)
-	}
-	return intfs
-}
-
 // ASEntries returns the concatenated lists of AS entries from the
 // individual segments, in the order of appearance on the path.
 func (s segmentList) ASEntries() []seg.ASEntry {
@@ -650,17 +770,6 @@ func (s segmentList) ASEntries() []seg.ASEntry {
 	return asEntries
 }
 
-func (s segmentList) ComputeExpTime() time.Time {
-	minTimestamp := maxExpirationTime
-	for _, segment := range s {
-		expTime := segment.ComputeExpTime()
-		if minTimestamp.After(expTime) {
-			minTimestamp = expTime
-		}
-	}
-	return minTimestamp
-}
-
 func (s segmentList) ScionPath() snetpath.SCION {
 	var meta scion.MetaHdr
 	var infos []path.InfoField
@@ -17,6 +17,7 @@ package revcache
 import (
 	"context"
 
+	"github.com/scionproto/scion/pkg/private/ctrl/path_mgmt"
 	seg "github.com/scionproto/scion/pkg/segment"
 	"github.com/scionproto/scion/pkg/segment/iface"
 	"github.com/scionproto/scion/private/storage/cleaner"
@@ -48,3 +49,23 @@ func NoRevokedHopIntf(ctx context.Context, revCache RevCache,
 	}
 	return true, nil
 }
+
+// WithNotify returns a revocation cache that inserts the revocations into rc, and calls notify
+// with every revocation that rc inserted, i.e., that was not cached yet or that is newer than the
+// cached one.
+func WithNotify(rc RevCache, notify func(*path_mgmt.RevInfo)) RevCache {
+	return notifyingRevCache{RevCache: rc, notify: notify}
+}
+
+type notifyingRevCache struct {
+	RevCache
+	notify func(*path_mgmt.RevInfo)
+}
+
+func (c notifyingRevCache) Insert(ctx context.Context, rev *path_mgmt.RevInfo) (bool, error) {
+	inserted, err := c.RevCache.Insert(ctx, rev)
+	if err == nil && inserted {
+		c.notify(rev)
+	}
+	return inserted, err
+}
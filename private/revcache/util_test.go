@@ -81,6 +81,39 @@ func TestNoRevokedHopIntf(t *testing.T) {
 	})
 }
 
+func TestWithNotify(t *testing.T) {
+	rev := defaultRevInfo(ia211, graph.If_211_A_210_X, time.Now())
+	tests := map[string]struct {
+		inserted bool
+		err      error
+		notified bool
+	}{
+		"inserted":     {inserted: true, notified: true},
+		"not inserted": {inserted: false, notified: false},
+		"error":        {err: serrors.New("TestError"), notified: false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cache := mock_revcache.NewMockRevCache(ctrl)
+			cache.EXPECT().Insert(gomock.Any(), rev).Return(tc.inserted, tc.err)
+			var notified []*path_mgmt.RevInfo
+			revCache := revcache.WithNotify(cache, func(r *path_mgmt.RevInfo) {
+				notified = append(notified, r)
+			})
+			inserted, err := revCache.Insert(context.Background(), rev)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.inserted, inserted)
+			if tc.notified {
+				assert.Equal(t, []*path_mgmt.RevInfo{rev}, notified)
+			} else {
+				assert.Empty(t, notified)
+			}
+		})
+	}
+}
+
 func defaultRevInfo(ia addr.IA, ifID uint16, ts time.Time) *path_mgmt.RevInfo {
 	return &path_mgmt.RevInfo{
 		IfID:         iface.ID(ifID),
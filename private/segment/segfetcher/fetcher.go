@@ -20,6 +20,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	seg "github.com/scionproto/scion/pkg/segment"
@@ -61,25 +62,36 @@ type Fetcher struct {
 	// QueryInterval specifies after how much time segments should be
 	// refetched at the remote server.
 	QueryInterval time.Duration
-	Metrics       metrics.Fetcher
+	// QueryIntervalFor optionally returns the query interval for the segment
+	// requests to dst, instead of QueryInterval.
+	QueryIntervalFor func(dst addr.IA) time.Duration
+	Metrics          metrics.Fetcher
 }
 
 // Fetch loads the requested segments from the path DB or requests them from a remote path server.
 func (f *Fetcher) Fetch(ctx context.Context, reqs Requests, refresh bool) (Segments, error) {
+	segs, _, err := f.fetch(ctx, reqs, refresh)
+	return segs, err
+}
+
+// fetch is Fetch, it moreover indicates whether the segments were all loaded from the DB.
+func (f *Fetcher) fetch(ctx context.Context, reqs Requests,
+	refresh bool) (Segments, bool, error) {
+
 	// Load local and cached segments from DB
 	loadedSegs, fetchReqs, err := f.Resolver.Resolve(ctx, reqs, refresh)
 	if err != nil {
-		return Segments{}, serrors.JoinNoStack(errDB, err)
+		return Segments{}, false, serrors.JoinNoStack(errDB, err)
 	}
 	if len(fetchReqs) == 0 {
-		return loadedSegs, nil
+		return loadedSegs, true, nil
 	}
 	// Forward and cache any requests that were not local / cached
 	fetchedSegs, err := f.Request(ctx, fetchReqs)
 	if err != nil {
 		err = serrors.JoinNoStack(errFetch, err)
 	}
-	return append(loadedSegs, fetchedSegs...), err
+	return append(loadedSegs, fetchedSegs...), false, err
 }
 
 func (f *Fetcher) Request(ctx context.Context, reqs Requests) (Segments, error) {
@@ -122,7 +134,7 @@ func (f *Fetcher) waitOnProcessed(ctx context.Context,
 				"errors", r.VerificationErrors().ToError())
 		}
 		segs = append(segs, Segments(r.Stats().VerifiedSegs)...)
-		nextQuery := f.nextQuery(segs)
+		nextQuery := f.nextQuery(reply.Req.Dst, segs)
 		_, err := f.PathDB.InsertNextQuery(ctx, reply.Req.Src, reply.Req.Dst, nextQuery)
 		if err != nil {
 			logger.Info("NextQuery insertion failed", "err", err)
@@ -132,18 +144,25 @@ func (f *Fetcher) waitOnProcessed(ctx context.Context,
 	return segs, nil
 }
 
-// nextQuery decides the next time a query should be issued based on the
+// nextQuery decides the next time a query to dst should be issued based on the
 // received segments.
-func (f *Fetcher) nextQuery(segs Segments) time.Time {
+func (f *Fetcher) nextQuery(dst addr.IA, segs Segments) time.Time {
 	// Determine the lead time for the latest segment expiration.
 	// We want to request new segments before the last one has expired.
 	expirationLead := maxSegmentExpiry(segs).Add(-expirationLeadTime)
-	return f.nearestNextQueryTime(time.Now(), expirationLead)
+	return nearestNextQueryTime(time.Now(), expirationLead, f.queryInterval(dst))
+}
+
+func (f *Fetcher) queryInterval(dst addr.IA) time.Duration {
+	if f.QueryIntervalFor != nil {
+		return f.QueryIntervalFor(dst)
+	}
+	return f.QueryInterval
 }
 
 // nearestNextQueryTime finds the nearest next query time in the interval spanned
-// by the minimum and the configured query interval.
-func (f *Fetcher) nearestNextQueryTime(now, nextQuery time.Time) time.Time {
+// by the minimum and the query interval.
+func nearestNextQueryTime(now, nextQuery time.Time, queryInterval time.Duration) time.Time {
 	// Adding +-10% random jitter
 	jitterPercent := time.Duration(rand.IntN(20) - 10)
 
@@ -152,8 +171,8 @@ func (f *Fetcher) nearestNextQueryTime(now, nextQuery time.Time) time.Time {
 		return earliest.Add(jitter)
 	}
 
-	if latest := now.Add(f.QueryInterval); nextQuery.After(latest) {
-		jitter := f.QueryInterval * jitterPercent / 100
+	if latest := now.Add(queryInterval); nextQuery.After(latest) {
+		jitter := queryInterval * jitterPercent / 100
 		return latest.Add(jitter)
 	}
 	return nextQuery
@@ -16,7 +16,10 @@ package segfetcher
 
 import (
 	"errors"
+	"time"
 
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/metrics"
 	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/private/segment/seghandler"
@@ -38,3 +41,54 @@ func ErrToMetricsLabel(err error) string {
 		return prom.ErrNotClassified
 	}
 }
+
+// Labels of the path lookup metrics in PatherMetrics.
+var (
+	PathLookupsLabels       = []string{prom.LabelDst, LabelCache, prom.LabelResult}
+	PathLookupLatencyLabels = []string{prom.LabelDst, LabelCache}
+)
+
+// LabelCache is the label for whether a path lookup was answered from the path database alone.
+// Its values are CacheHit and CacheMiss.
+const LabelCache = "cache"
+
+// Values of the cache label.
+const (
+	// CacheHit is a path lookup that did not fetch any segments from remote path servers.
+	CacheHit = "hit"
+	// CacheMiss is a path lookup that fetched segments from remote path servers.
+	CacheMiss = "miss"
+)
+
+// PatherMetrics are the metrics of the path lookups of the Pather, per destination AS. Each
+// field may be set individually.
+type PatherMetrics struct {
+	// Lookups counts the path lookups, with the labels PathLookupsLabels. Lookups that do not
+	// find any paths have the result prom.ErrNotFound.
+	Lookups metrics.Counter
+	// LookupLatency observes the duration of the path lookups in seconds, with the labels
+	// PathLookupLatencyLabels.
+	LookupLatency metrics.Histogram
+}
+
+func (m PatherMetrics) observe(dst addr.IA, cached bool, numPaths int, err error,
+	latency time.Duration) {
+
+	cache := CacheMiss
+	if cached {
+		cache = CacheHit
+	}
+	result := prom.Success
+	switch {
+	case errors.Is(err, ErrBadDst):
+		result = prom.ErrInvalidReq
+	case err != nil:
+		result = ErrToMetricsLabel(err)
+	case numPaths == 0:
+		result = prom.ErrNotFound
+	}
+	metrics.CounterInc(metrics.CounterWith(m.Lookups,
+		prom.LabelDst, dst.String(), LabelCache, cache, prom.LabelResult, result))
+	metrics.HistogramObserve(metrics.HistogramWith(m.LookupLatency,
+		prom.LabelDst, dst.String(), LabelCache, cache), latency.Seconds())
+}
@@ -49,6 +49,8 @@ type Pather struct {
 	RevCache revcache.RevCache
 	Fetcher  *Fetcher
 	Splitter Splitter
+	// Metrics are the metrics of the path lookups. The zero value records no metrics.
+	Metrics PatherMetrics
 }
 
 // GetPaths returns all non-revoked and non-expired paths to the destination.
@@ -58,9 +60,20 @@ type Pather struct {
 func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 	refresh bool) ([]snet.Path, error) {
 
+	start := time.Now()
+	paths, cached, err := p.getPaths(ctx, dst, refresh)
+	p.Metrics.observe(dst, cached, len(paths), err, time.Since(start))
+	return paths, err
+}
+
+// getPaths is GetPaths, it moreover indicates whether the paths were built from the segments
+// in the path DB without fetching any.
+func (p *Pather) getPaths(ctx context.Context, dst addr.IA,
+	refresh bool) ([]snet.Path, bool, error) {
+
 	logger := log.FromCtx(ctx)
 	if dst.ISD() == 0 {
-		return nil, serrors.JoinNoStack(ErrBadDst, nil, "dst", dst)
+		return nil, false, serrors.JoinNoStack(ErrBadDst, nil, "dst", dst)
 	}
 	src := p.IA
 	if dst.Equal(src) {
@@ -72,13 +85,13 @@ func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 				MTU:    p.MTU,
 				Expiry: time.Now().Add(rawpath.MaxTTL),
 			},
-		}}, nil
+		}}, true, nil
 	}
 	reqs, err := p.Splitter.Split(ctx, dst)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	segs, fetchErr := p.Fetcher.Fetch(ctx, reqs, refresh)
+	segs, cached, fetchErr := p.Fetcher.fetch(ctx, reqs, refresh)
 	// Even if fetching failed, attempt to create paths.
 	if fetchErr != nil {
 		logger.Debug("Fetching failed, attempting to build paths anyway", "err", fetchErr)
@@ -87,11 +100,12 @@ func (p *Pather) GetPaths(ctx context.Context, dst addr.IA,
 	paths = p.filterRevoked(ctx, paths)
 	if len(paths) == 0 {
 		if fetchErr != nil {
-			return nil, fetchErr
+			return nil, cached, fetchErr
 		}
-		return nil, nil
+		return nil, cached, nil
 	}
-	return p.translatePaths(paths)
+	snetPaths, err := p.translatePaths(paths)
+	return snetPaths, cached, err
 }
 
 func (p *Pather) buildAllPaths(src, dst addr.IA, segs Segments) []combinator.Path {
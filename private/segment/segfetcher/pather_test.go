@@ -15,13 +15,22 @@
 package segfetcher_test
 
 import (
+	"context"
+	"errors"
+	"net"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
 	"github.com/scionproto/scion/pkg/snet"
+	"github.com/scionproto/scion/private/revcache/mock_revcache"
 	"github.com/scionproto/scion/private/segment/segfetcher"
+	"github.com/scionproto/scion/private/segment/segfetcher/mock_segfetcher"
 )
 
 func TestRevocationsString(t *testing.T) {
@@ -60,3 +69,95 @@ func TestRevocationsString(t *testing.T) {
 		})
 	}
 }
+
+func TestPatherMetrics(t *testing.T) {
+	rootCtrl := gomock.NewController(t)
+	tg := newTestGraph(rootCtrl)
+	reqs := segfetcher.Requests{
+		segfetcher.Request{SegType: Up, Src: non_core_111, Dst: core_130},
+	}
+
+	tests := map[string]struct {
+		Dst            addr.IA
+		PrepareFetcher func(*TestableFetcher)
+		Cache          string
+		Result         string
+	}{
+		"cached": {
+			Dst: core_130,
+			PrepareFetcher: func(f *TestableFetcher) {
+				f.Resolver.EXPECT().Resolve(gomock.Any(), reqs, false).
+					Return(segfetcher.Segments{tg.seg130_111_up}, segfetcher.Requests{}, nil)
+			},
+			Cache:  segfetcher.CacheHit,
+			Result: prom.Success,
+		},
+		"fetched without paths": {
+			Dst: core_130,
+			PrepareFetcher: func(f *TestableFetcher) {
+				f.Resolver.EXPECT().Resolve(gomock.Any(), reqs, false).
+					Return(segfetcher.Segments{}, reqs, nil)
+				replies := make(chan segfetcher.ReplyOrErr)
+				close(replies)
+				f.Requester.EXPECT().Request(gomock.Any(), reqs).Return(replies)
+			},
+			Cache:  segfetcher.CacheMiss,
+			Result: prom.ErrNotFound,
+		},
+		"db error": {
+			Dst: core_130,
+			PrepareFetcher: func(f *TestableFetcher) {
+				f.Resolver.EXPECT().Resolve(gomock.Any(), reqs, false).
+					Return(nil, nil, errors.New("test err"))
+			},
+			Cache:  segfetcher.CacheMiss,
+			Result: prom.ErrDB,
+		},
+		"local AS": {
+			Dst:            non_core_111,
+			PrepareFetcher: func(*TestableFetcher) {},
+			Cache:          segfetcher.CacheHit,
+			Result:         prom.Success,
+		},
+		"bad destination": {
+			Dst:            addr.MustParseIA("0-0"),
+			PrepareFetcher: func(*TestableFetcher) {},
+			Cache:          segfetcher.CacheMiss,
+			Result:         prom.ErrInvalidReq,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+			defer cancelF()
+			f := NewTestFetcher(ctrl)
+			test.PrepareFetcher(f)
+			splitter := mock_segfetcher.NewMockSplitter(ctrl)
+			splitter.EXPECT().Split(gomock.Any(), test.Dst).Return(reqs, nil).AnyTimes()
+			revCache := mock_revcache.NewMockRevCache(ctrl)
+			revCache.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes()
+			lookups := metrics.NewTestCounter()
+			p := segfetcher.Pather{
+				IA:         non_core_111,
+				NextHopper: nextHopper{},
+				RevCache:   revCache,
+				Fetcher:    f.Fetcher(),
+				Splitter:   splitter,
+				Metrics:    segfetcher.PatherMetrics{Lookups: lookups},
+			}
+			_, _ = p.GetPaths(ctx, test.Dst, false)
+			assert.Equal(t, 1.0, metrics.CounterValue(lookups.With(
+				prom.LabelDst, test.Dst.String(),
+				segfetcher.LabelCache, test.Cache,
+				prom.LabelResult, test.Result,
+			)))
+		})
+	}
+}
+
+type nextHopper struct{}
+
+func (nextHopper) UnderlayNextHop(uint16) *net.UDPAddr {
+	return &net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: 31002}
+}
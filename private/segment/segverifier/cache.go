@@ -0,0 +1,185 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segverifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"net"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/metrics"
+	"github.com/scionproto/scion/pkg/private/prom"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	seg "github.com/scionproto/scion/pkg/segment"
+	infra "github.com/scionproto/scion/private/segment/verifier"
+)
+
+// DefaultMaxCacheExpiration is the default maximum duration a verified prefix of a segment is
+// cached.
+const DefaultMaxCacheExpiration = time.Minute
+
+// CachingVerifier verifies path segments. It verifies the AS entries of a segment
+// concurrently, and caches the verified prefixes of the segments. The AS entries of a segment
+// that extends an already verified one, e.g., a beacon that is propagated again in the next
+// interval or that was received from the same upstream AS over another interface, are not
+// verified again.
+//
+// A prefix is identified by the info field and the signed AS entries it consists of. A cached
+// prefix is thus only used for segments that start with exactly the same signed content.
+type CachingVerifier struct {
+	// Verifier verifies the signatures of the AS entries.
+	Verifier infra.Verifier
+	// Cache caches the verified prefixes. If nil, no prefixes are cached.
+	Cache *cache.Cache
+	// MaxCacheExpiration is the maximum duration a verified prefix is cached. It is further
+	// bounded by the validity of the hop fields of the prefix. If zero,
+	// DefaultMaxCacheExpiration is used.
+	MaxCacheExpiration time.Duration
+	// Workers is the maximum number of AS entries that are verified concurrently across all
+	// segments. If zero, GOMAXPROCS is used. It must not be changed after the first segment was
+	// verified.
+	Workers int
+	// CacheHits counts the cache lookups. The result is "hit" if the whole segment was cached,
+	// "partial" if only a prefix of it was, and "miss" otherwise.
+	CacheHits metrics.Counter
+
+	initOnce sync.Once
+	workers  chan struct{}
+}
+
+// VerifySegment verifies the AS entries of the segment that are not part of a cached verified
+// prefix. Missing certificates are fetched from the server.
+func (v *CachingVerifier) VerifySegment(ctx context.Context, server net.Addr,
+	segment *seg.PathSegment) error {
+
+	v.initOnce.Do(func() {
+		workers := v.Workers
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		v.workers = make(chan struct{}, workers)
+	})
+
+	keys := prefixKeys(segment)
+	start := v.verifiedPrefix(keys)
+	errs := make([]error, len(segment.ASEntries))
+	var wg sync.WaitGroup
+	for i := start; i < len(segment.ASEntries); i++ {
+		select {
+		case v.workers <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return serrors.JoinNoStack(ErrSegment, ctx.Err(), "seg", segment)
+		}
+		wg.Add(1)
+		go func() {
+			defer log.HandlePanic()
+			defer func() {
+				<-v.workers
+				wg.Done()
+			}()
+			errs[i] = verifyASEntry(ctx, v.Verifier, server, segment, i)
+		}()
+	}
+	wg.Wait()
+	// Report the error of the first AS entry that failed, like the serial verification does.
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	v.cachePrefixes(segment, keys, start)
+	return nil
+}
+
+// verifiedPrefix returns the length of the longest cached verified prefix.
+func (v *CachingVerifier) verifiedPrefix(keys []string) int {
+	if v.Cache == nil {
+		return 0
+	}
+	n := 0
+	for i := len(keys) - 1; i >= 0; i-- {
+		if _, ok := v.Cache.Get(keys[i]); ok {
+			n = i + 1
+			break
+		}
+	}
+	result := "miss"
+	switch {
+	case n > 0 && n == len(keys):
+		result = "hit"
+	case n > 0:
+		result = "partial"
+	}
+	metrics.CounterInc(metrics.CounterWith(v.CacheHits, prom.LabelResult, result))
+	return n
+}
+
+// cachePrefixes caches the prefixes of the verified segment that are longer than start.
+func (v *CachingVerifier) cachePrefixes(segment *seg.PathSegment, keys []string, start int) {
+	if v.Cache == nil {
+		return
+	}
+	maxExpiration := v.MaxCacheExpiration
+	if maxExpiration == 0 {
+		maxExpiration = DefaultMaxCacheExpiration
+	}
+	var notAfter time.Time
+	for i := range keys {
+		validity := hopValidity(segment, i)
+		if notAfter.IsZero() || validity.NotAfter.Before(notAfter) {
+			notAfter = validity.NotAfter
+		}
+		if i < start {
+			continue
+		}
+		expiration := min(time.Until(notAfter), maxExpiration)
+		if expiration <= 0 {
+			return
+		}
+		v.Cache.Set(keys[i], struct{}{}, expiration)
+	}
+}
+
+// prefixKeys returns the cache keys of the prefixes of the segment. The key of the prefix that
+// ends at the AS entry with index i covers the info field and the signed content of the AS
+// entries up to and including i, which is everything its verification depends on.
+func prefixKeys(segment *seg.PathSegment) []string {
+	keys := make([]string, 0, len(segment.ASEntries))
+	prev := sha256.Sum256(segment.Info.Raw)
+	for _, asEntry := range segment.ASEntries {
+		h := sha256.New()
+		h.Write(prev[:])
+		writeField(h, asEntry.Signed.HeaderAndBody)
+		writeField(h, asEntry.Signed.Signature)
+		h.Sum(prev[:0])
+		keys = append(keys, string(prev[:]))
+	}
+	return keys
+}
+
+// writeField writes the length-prefixed field to the hash.
+func writeField(h hash.Hash, field []byte) {
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(field))))
+	h.Write(field)
+}
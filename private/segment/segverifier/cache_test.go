@@ -0,0 +1,153 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segverifier_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest/graph"
+	cryptopb "github.com/scionproto/scion/pkg/proto/crypto"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/pkg/scrypto/signed"
+	seg "github.com/scionproto/scion/pkg/segment"
+	"github.com/scionproto/scion/private/segment/segverifier"
+	infra "github.com/scionproto/scion/private/segment/verifier"
+)
+
+// countingVerifier records the ISD-AS of every verified AS entry and rejects the AS entries of
+// the ISD-AS in fail.
+type countingVerifier struct {
+	state *verifierState
+	ia    addr.IA
+}
+
+type verifierState struct {
+	mu       sync.Mutex
+	verified []addr.IA
+	fail     addr.IA
+}
+
+func (v countingVerifier) Verify(context.Context, *cryptopb.SignedMessage,
+	...[]byte) (*signed.Message, error) {
+
+	v.state.mu.Lock()
+	defer v.state.mu.Unlock()
+	v.state.verified = append(v.state.verified, v.ia)
+	if v.ia == v.state.fail {
+		return nil, serrors.New("invalid signature")
+	}
+	return &signed.Message{}, nil
+}
+
+func (v countingVerifier) WithServer(net.Addr) infra.Verifier { return v }
+
+func (v countingVerifier) WithIA(ia addr.IA) infra.Verifier {
+	v.ia = ia
+	return v
+}
+
+func (v countingVerifier) WithValidity(cppki.Validity) infra.Verifier { return v }
+
+func (s *verifierState) take() []addr.IA {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	verified := s.verified
+	s.verified = nil
+	return verified
+}
+
+func TestCachingVerifier(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	g := graph.NewDefaultGraph(mctrl)
+	ctx := context.Background()
+
+	extended := g.Beacon([]uint16{graph.If_120_X_111_B, graph.If_111_A_112_X})
+	prefix := *extended
+	prefix.ASEntries = extended.ASEntries[:2]
+	other := g.Beacon([]uint16{graph.If_120_X_111_B, graph.If_111_A_112_X})
+	ias := func(s *seg.PathSegment) []addr.IA {
+		var ias []addr.IA
+		for _, asEntry := range s.ASEntries {
+			ias = append(ias, asEntry.Local)
+		}
+		return ias
+	}
+
+	t.Run("verified prefixes are cached", func(t *testing.T) {
+		state := &verifierState{}
+		v := &segverifier.CachingVerifier{
+			Verifier: countingVerifier{state: state},
+			Cache:    cache.New(time.Minute, time.Minute),
+			Workers:  2,
+		}
+		require.NoError(t, v.VerifySegment(ctx, nil, &prefix))
+		assert.ElementsMatch(t, ias(&prefix), state.take())
+
+		// Only the AS entry that extends the cached prefix is verified.
+		require.NoError(t, v.VerifySegment(ctx, nil, extended))
+		assert.Equal(t, ias(extended)[2:], state.take())
+
+		// The whole segment is cached.
+		require.NoError(t, v.VerifySegment(ctx, nil, extended))
+		assert.Empty(t, state.take())
+	})
+	t.Run("different content is not cached", func(t *testing.T) {
+		state := &verifierState{}
+		v := &segverifier.CachingVerifier{
+			Verifier: countingVerifier{state: state},
+			Cache:    cache.New(time.Minute, time.Minute),
+		}
+		require.NoError(t, v.VerifySegment(ctx, nil, extended))
+		assert.ElementsMatch(t, ias(extended), state.take())
+
+		// The other segment has a different info field and thus shares no prefix.
+		require.NoError(t, v.VerifySegment(ctx, nil, other))
+		assert.ElementsMatch(t, ias(other), state.take())
+	})
+	t.Run("invalid segments are not cached", func(t *testing.T) {
+		state := &verifierState{fail: extended.ASEntries[1].Local}
+		v := &segverifier.CachingVerifier{
+			Verifier: countingVerifier{state: state},
+			Cache:    cache.New(time.Minute, time.Minute),
+		}
+		err := v.VerifySegment(ctx, nil, extended)
+		assert.ErrorIs(t, err, segverifier.ErrSegment)
+		assert.ElementsMatch(t, ias(extended), state.take())
+
+		state.fail = 0
+		require.NoError(t, v.VerifySegment(ctx, nil, extended))
+		assert.ElementsMatch(t, ias(extended), state.take())
+	})
+	t.Run("without cache", func(t *testing.T) {
+		state := &verifierState{}
+		v := &segverifier.CachingVerifier{
+			Verifier: countingVerifier{state: state},
+		}
+		require.NoError(t, v.VerifySegment(ctx, nil, extended))
+		require.NoError(t, v.VerifySegment(ctx, nil, extended))
+		assert.Len(t, state.take(), 2*len(extended.ASEntries))
+	})
+}
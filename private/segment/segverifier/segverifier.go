@@ -145,22 +145,38 @@ func verifySegment(ctx context.Context, verifier infra.Verifier, server net.Addr
 func VerifySegment(ctx context.Context, verifier infra.Verifier, server net.Addr,
 	segment *seg.PathSegment) error {
 
-	for i, asEntry := range segment.ASEntries {
-		// Bind the verifier to the values specified in the AS Entry since
-		// the sign meta does not carry this information.
-		// Validity is set to include the validity of the hop field contained in
-		// the AS Entry.
-		validity := cppki.Validity{
-			NotBefore: segment.Info.Timestamp,
-			NotAfter: segment.Info.Timestamp.Add(
-				path.ExpTimeToDuration(asEntry.HopEntry.HopField.ExpTime),
-			),
-		}
-		verifier := verifier.WithServer(server).WithIA(asEntry.Local).WithValidity(validity)
-		if err := segment.VerifyASEntry(ctx, verifier, i); err != nil {
-			return serrors.JoinNoStack(ErrSegment, err,
-				"seg", segment, "as", asEntry.Local)
+	for i := range segment.ASEntries {
+		if err := verifyASEntry(ctx, verifier, server, segment, i); err != nil {
+			return err
 		}
 	}
 	return nil
 }
+
+// verifyASEntry verifies the AS entry with the given index.
+func verifyASEntry(ctx context.Context, verifier infra.Verifier, server net.Addr,
+	segment *seg.PathSegment, idx int) error {
+
+	// Bind the verifier to the values specified in the AS Entry since
+	// the sign meta does not carry this information.
+	// Validity is set to include the validity of the hop field contained in
+	// the AS Entry.
+	asEntry := segment.ASEntries[idx]
+	verifier = verifier.WithServer(server).WithIA(asEntry.Local).
+		WithValidity(hopValidity(segment, idx))
+	if err := segment.VerifyASEntry(ctx, verifier, idx); err != nil {
+		return serrors.JoinNoStack(ErrSegment, err,
+			"seg", segment, "as", asEntry.Local)
+	}
+	return nil
+}
+
+// hopValidity returns the validity of the hop field of the AS entry with the given index.
+func hopValidity(segment *seg.PathSegment, idx int) cppki.Validity {
+	return cppki.Validity{
+		NotBefore: segment.Info.Timestamp,
+		NotAfter: segment.Info.Timestamp.Add(
+			path.ExpTimeToDuration(segment.ASEntries[idx].HopEntry.HopField.ExpTime),
+		),
+	}
+}
@@ -31,6 +31,18 @@ type Cleanable interface {
 	// before the passed time value.
 	// The return value indicates the number of beacons that were removed.
 	DeleteExpiredBeacons(ctx context.Context, now time.Time) (int, error)
+	// DeleteBeaconsUpdatedBefore removes all beacons that were last updated
+	// before the passed time value.
+	// The return value indicates the number of beacons that were removed.
+	DeleteBeaconsUpdatedBefore(ctx context.Context, t time.Time) (int, error)
+	// DeleteUnusableBeaconsUpdatedBefore removes all beacons that are not
+	// usable for any purpose and were last updated before the passed time value.
+	// The return value indicates the number of beacons that were removed.
+	DeleteUnusableBeaconsUpdatedBefore(ctx context.Context, t time.Time) (int, error)
+	// TrimBeaconsPerOrigin removes the least recently updated beacons of every
+	// origin AS, such that at most maxBeacons beacons remain per origin AS.
+	// The return value indicates the number of beacons that were removed.
+	TrimBeaconsPerOrigin(ctx context.Context, maxBeacons int) (int, error)
 }
 
 type QueryParams struct {
@@ -54,6 +66,9 @@ type QueryParams struct {
 	// ValidAt specifies the time that beacons need to be valid at to be matched.
 	// Beacons are returned irrespective of their validity if ValidAt is the zero time.
 	ValidAt time.Time
+	// CreatedAfter specifies the time that beacons need to be created at or after to be matched.
+	// Beacons are returned irrespective of their creation time if CreatedAfter is the zero time.
+	CreatedAfter time.Time
 }
 
 type Beacon struct {
@@ -67,4 +82,8 @@ type BeaconAPI interface {
 	GetBeacons(context.Context, *QueryParams) ([]Beacon, error)
 	// DeleteBeacon removes all beacons that have the prefix of the specified segment ID.
 	DeleteBeacon(ctx context.Context, partialID string) error
+	// MarkBeaconUnusable removes the usages of all beacons that have the prefix of the specified
+	// segment ID, so that they are neither propagated nor registered. The beacons are kept until
+	// they expire, or until they are received again with a newer timestamp.
+	MarkBeaconUnusable(ctx context.Context, partialID string) error
 }
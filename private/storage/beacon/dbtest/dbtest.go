@@ -15,6 +15,7 @@
 package dbtest
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"testing"
@@ -57,6 +58,7 @@ func Run(t *testing.T, db TestableDB) {
 
 func run(t *testing.T, db TestableDB) {
 	t.Run("GetBeacons", func(t *testing.T) { testGetBeacons(t, db) })
+	t.Run("MarkBeaconUnusable", func(t *testing.T) { testMarkBeaconUnusable(t, db) })
 	t.Run("DeleteExpired should delete expired segments", func(t *testing.T) {
 		if _, ok := db.(interface{ IgnoreCleanable() }); ok {
 			t.Skip("Ignoring beacon cleaning test")
@@ -85,6 +87,12 @@ func run(t *testing.T, db TestableDB) {
 		require.NoError(t, err)
 		assert.Equal(t, 1, deleted, "Deleted")
 	})
+	t.Run("GarbageCollection", func(t *testing.T) {
+		if _, ok := db.(interface{ IgnoreCleanable() }); ok {
+			t.Skip("Ignoring beacon cleaning test")
+		}
+		testGarbageCollection(t, db)
+	})
 }
 
 func testGetBeacons(t *testing.T, db TestableDB) {
@@ -279,6 +287,15 @@ func testGetBeacons(t *testing.T, db TestableDB) {
 			},
 			Expected: results[:2],
 		},
+		"Filter by CreatedAfter": {
+			PrepareDB: func(t *testing.T, ctx context.Context, db beaconlib.DB) {
+				insertBeacons(t, db)
+			},
+			Params: beacon.QueryParams{
+				CreatedAfter: time.Unix(2, 0),
+			},
+			Expected: results[1:],
+		},
 		"ValidAt ignored if Zero": {
 			PrepareDB: func(t *testing.T, ctx context.Context, db beaconlib.DB) {
 				insertBeacons(t, db)
@@ -310,3 +327,86 @@ func testGetBeacons(t *testing.T, db TestableDB) {
 		})
 	}
 }
+
+func testMarkBeaconUnusable(t *testing.T, db TestableDB) {
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+	db.Prepare(t, ctx)
+
+	b1 := dbtest.InsertBeacon(t, db, dbtest.Info3, 12, 10, beaconlib.UsageProp)
+	dbtest.InsertBeacon(t, db, dbtest.Info2, 13, 10, beaconlib.UsageProp)
+	require.NoError(t, db.MarkBeaconUnusable(ctx, fmt.Sprintf("%x", b1.Segment.ID()[:6])))
+
+	results, err := db.GetBeacons(ctx, &beacon.QueryParams{})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, r := range results {
+		if bytes.Equal(r.Beacon.Segment.ID(), b1.Segment.ID()) {
+			assert.Equal(t, beaconlib.Usage(0), r.Usage)
+		} else {
+			assert.Equal(t, beaconlib.UsageProp, r.Usage)
+		}
+	}
+	// Unusable beacons are not candidates for propagation.
+	candidates, err := db.CandidateBeacons(ctx, 10, beaconlib.UsageProp, addr.IA(0))
+	require.NoError(t, err)
+	assert.Len(t, candidates, 1)
+}
+
+func testGarbageCollection(t *testing.T, db TestableDB) {
+	t.Run("DeleteBeaconsUpdatedBefore deletes old beacons", func(t *testing.T) {
+		ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+		defer cancelF()
+		db.Prepare(t, ctx)
+
+		dbtest.InsertBeacon(t, db, dbtest.Info3, 12, 10, beaconlib.UsageProp)
+		between := time.Now()
+		dbtest.InsertBeacon(t, db, dbtest.Info2, 13, 10, beaconlib.UsageProp)
+
+		deleted, err := db.DeleteBeaconsUpdatedBefore(ctx, between)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted, "Deleted")
+		deleted, err = db.DeleteBeaconsUpdatedBefore(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted, "Deleted")
+	})
+	t.Run("DeleteUnusableBeaconsUpdatedBefore deletes unusable beacons", func(t *testing.T) {
+		ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+		defer cancelF()
+		db.Prepare(t, ctx)
+
+		b1 := dbtest.InsertBeacon(t, db, dbtest.Info3, 12, 10, beaconlib.UsageProp)
+		dbtest.InsertBeacon(t, db, dbtest.Info2, 13, 10, beaconlib.UsageProp)
+		require.NoError(t, db.MarkBeaconUnusable(ctx, fmt.Sprintf("%x", b1.Segment.ID())))
+
+		deleted, err := db.DeleteUnusableBeaconsUpdatedBefore(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted, "Deleted")
+		results, err := db.GetBeacons(ctx, &beacon.QueryParams{})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, beaconlib.UsageProp, results[0].Usage)
+	})
+	t.Run("TrimBeaconsPerOrigin keeps the most recent beacons", func(t *testing.T) {
+		ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+		defer cancelF()
+		db.Prepare(t, ctx)
+
+		// Info2 and Info3 originate in the same AS.
+		dbtest.InsertBeacon(t, db, dbtest.Info2, 13, 10, beaconlib.UsageProp)
+		b2 := dbtest.InsertBeacon(t, db, dbtest.Info3, 12, 10, beaconlib.UsageProp)
+		b3 := dbtest.InsertBeacon(t, db, dbtest.Info1, 14, 10, beaconlib.UsageProp)
+
+		deleted, err := db.TrimBeaconsPerOrigin(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted, "Deleted")
+		results, err := db.GetBeacons(ctx, &beacon.QueryParams{})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		var ids [][]byte
+		for _, r := range results {
+			ids = append(ids, r.Beacon.Segment.ID())
+		}
+		assert.ElementsMatch(t, [][]byte{b2.Segment.ID(), b3.Segment.ID()}, ids)
+	})
+}
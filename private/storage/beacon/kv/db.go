@@ -0,0 +1,654 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kv implements the beacon database on top of an embedded
+// log-structured key-value store.
+//
+// The beacons are appended to a log file, keyed by their segment ID. Only the
+// index, i.e., the metadata of the beacons that the queries filter on, is kept
+// in memory; the beacons themselves are read from the log when they are
+// returned. Writes therefore only append to a file, which scales to the beacon
+// volumes that core ASes receive better than the SQLite backend. The log is
+// compacted in the background when it holds more stale records than live ones.
+//
+// The semantics are identical to the ones of the SQL backends.
+package kv
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	seg "github.com/scionproto/scion/pkg/segment"
+	storagebeacon "github.com/scionproto/scion/private/storage/beacon"
+	"github.com/scionproto/scion/private/storage/db"
+)
+
+const (
+	// Version is the version of the file format understood by this backend.
+	// Whenever changes to the format are made, this version number should be
+	// increased to prevent data corruption between incompatible formats.
+	Version = 1
+
+	// minCompactRecords is the number of stale records below which the log is
+	// not compacted.
+	minCompactRecords = 1024
+)
+
+var _ beacon.DB = (*Backend)(nil)
+
+type Backend struct {
+	mu   sync.RWMutex
+	path string
+	ia   addr.IA
+	f    *os.File
+	// size is the length of the log.
+	size int64
+	// records is the number of records in the log.
+	records int
+	index   map[string]*entry
+	nextSeq uint64
+	// compacting is set while the log is compacted.
+	compacting  bool
+	closed      bool
+	compactions sync.WaitGroup
+}
+
+// New returns a new key-value backend opening a database at the given path.
+// If no database exists a new database is created. If the version of the
+// stored database is different from Version, an error is returned.
+func New(path string, ia addr.IA) (*Backend, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, serrors.Wrap("opening database", err, "path", path)
+	}
+	b := &Backend{
+		path:  path,
+		ia:    ia,
+		f:     f,
+		index: make(map[string]*entry),
+	}
+	if err := b.load(); err != nil {
+		f.Close()
+		return nil, serrors.Wrap("loading database", err, "path", path)
+	}
+	return b, nil
+}
+
+// load reads the index from the log, or initializes an empty log.
+func (b *Backend) load() error {
+	info, err := b.f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		if _, err := b.f.WriteAt(encodeHeader(), 0); err != nil {
+			return err
+		}
+		b.size = int64(headerLen)
+		return b.f.Sync()
+	}
+	h := make([]byte, headerLen)
+	if _, err := io.ReadFull(b.f, h); err != nil {
+		return serrors.Wrap("reading header", err)
+	}
+	if err := checkHeader(h); err != nil {
+		return err
+	}
+	records, size, err := replay(b.f, b.index)
+	if err != nil {
+		return err
+	}
+	// Drop the tail that was not completely written.
+	if size < info.Size() {
+		if err := b.f.Truncate(size); err != nil {
+			return err
+		}
+	}
+	b.records, b.size = records, size
+	for _, e := range b.index {
+		b.nextSeq = max(b.nextSeq, e.seq+1)
+	}
+	return nil
+}
+
+// SetMaxOpenConns is a no-op, the database is a single file.
+func (b *Backend) SetMaxOpenConns(_ int) {}
+
+// SetMaxIdleConns is a no-op, the database is a single file.
+func (b *Backend) SetMaxIdleConns(_ int) {}
+
+// Close closes the database. It waits for a running compaction to finish.
+func (b *Backend) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	b.compactions.Wait()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.f.Sync(); err != nil {
+		b.f.Close()
+		return err
+	}
+	return b.f.Close()
+}
+
+func (b *Backend) BeaconSources(ctx context.Context) ([]addr.IA, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	seen := make(map[addr.IA]struct{})
+	var ias []addr.IA
+	for _, e := range b.index {
+		if _, ok := seen[e.start]; ok {
+			continue
+		}
+		seen[e.start] = struct{}{}
+		ias = append(ias, e.start)
+	}
+	return ias, nil
+}
+
+func (b *Backend) CandidateBeacons(
+	ctx context.Context,
+	setSize int,
+	usage beacon.Usage,
+	src addr.IA,
+) ([]beacon.Beacon, error) {
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var candidates []*entry
+	for _, e := range b.index {
+		if e.usage&usage != usage || (!src.IsZero() && e.start != src) {
+			continue
+		}
+		candidates = append(candidates, e)
+	}
+	slices.SortFunc(candidates, func(x, y *entry) int {
+		if x.hops != y.hops {
+			return x.hops - y.hops
+		}
+		return compareSeq(x, y)
+	})
+	if len(candidates) > setSize {
+		candidates = candidates[:max(setSize, 0)]
+	}
+	beacons := make([]beacon.Beacon, 0, len(candidates))
+	for _, e := range candidates {
+		s, err := b.readSegment(e)
+		if err != nil {
+			return nil, err
+		}
+		beacons = append(beacons, beacon.Beacon{Segment: s, InIfID: e.inIfID})
+	}
+	return beacons, nil
+}
+
+// InsertBeacon inserts the beacon if it is new or updates the changed
+// information.
+func (b *Backend) InsertBeacon(
+	ctx context.Context,
+	bcn beacon.Beacon,
+	usage beacon.Usage,
+) (beacon.InsertStats, error) {
+
+	ret := beacon.InsertStats{}
+	// Compute ids and pack outside of the lock.
+	segID := bcn.Segment.ID()
+	packed, err := beacon.PackBeacon(bcn.Segment)
+	if err != nil {
+		return ret, db.NewInputDataError("pack segment", err)
+	}
+	e := &entry{
+		segID:    segID,
+		start:    bcn.Segment.FirstIA(),
+		inIfID:   bcn.InIfID,
+		hops:     len(bcn.Segment.ASEntries),
+		infoTime: bcn.Segment.Info.Timestamp.Unix(),
+		expiry:   bcn.Segment.MaxExpiry().Unix(),
+		usage:    usage,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	existing, ok := b.index[string(segID)]
+	if ok {
+		// Update the beacon data if it is newer.
+		if e.infoTime <= existing.infoTime {
+			return ret, nil
+		}
+		e.seq = existing.seq
+	} else {
+		e.seq = b.nextSeq
+	}
+	e.lastUpdated = time.Now().UnixNano()
+	if err := b.put([]*entry{e}, [][]byte{packed}); err != nil {
+		return ret, db.NewWriteError("insert beacon", err)
+	}
+	if ok {
+		ret.Updated = 1
+	} else {
+		b.nextSeq++
+		ret.Inserted = 1
+	}
+	return ret, nil
+}
+
+func (b *Backend) GetBeacons(
+	ctx context.Context,
+	params *storagebeacon.QueryParams,
+) ([]storagebeacon.Beacon, error) {
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var matches []*entry
+	for _, e := range b.index {
+		if matchesQuery(e, params) {
+			matches = append(matches, e)
+		}
+	}
+	slices.SortFunc(matches, func(x, y *entry) int {
+		return -compareUpdated(x, y)
+	})
+	var res []storagebeacon.Beacon
+	for _, e := range matches {
+		seg, err := b.readSegment(e)
+		if err != nil {
+			return nil, serrors.Wrap("parsing beacon", err)
+		}
+		res = append(res, storagebeacon.Beacon{
+			Beacon: beacon.Beacon{
+				Segment: seg,
+				InIfID:  e.inIfID,
+			},
+			Usage:       e.usage,
+			LastUpdated: time.Unix(0, e.lastUpdated),
+		})
+	}
+	return res, nil
+}
+
+func (b *Backend) DeleteBeacon(ctx context.Context, partialID string) error {
+	_, err := b.deleteWhere(func(e *entry) bool {
+		return hasHexPrefix(e.segID, partialID)
+	})
+	return err
+}
+
+func (b *Backend) MarkBeaconUnusable(ctx context.Context, partialID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var updated []*entry
+	var values [][]byte
+	for _, e := range b.index {
+		if !hasHexPrefix(e.segID, partialID) {
+			continue
+		}
+		value, err := b.readValue(e)
+		if err != nil {
+			return db.NewReadError("reading beacon", err)
+		}
+		u := *e
+		u.usage = 0
+		updated = append(updated, &u)
+		values = append(values, value)
+	}
+	if err := b.put(updated, values); err != nil {
+		return db.NewWriteError("mark beacon unusable", err)
+	}
+	return nil
+}
+
+func (b *Backend) DeleteExpiredBeacons(ctx context.Context, now time.Time) (int, error) {
+	t := now.Unix()
+	return b.deleteWhere(func(e *entry) bool {
+		return e.expiry < t
+	})
+}
+
+func (b *Backend) DeleteBeaconsUpdatedBefore(ctx context.Context, t time.Time) (int, error) {
+	ns := t.UnixNano()
+	return b.deleteWhere(func(e *entry) bool {
+		return e.lastUpdated < ns
+	})
+}
+
+func (b *Backend) DeleteUnusableBeaconsUpdatedBefore(
+	ctx context.Context,
+	t time.Time,
+) (int, error) {
+
+	ns := t.UnixNano()
+	return b.deleteWhere(func(e *entry) bool {
+		return e.usage == 0 && e.lastUpdated < ns
+	})
+}
+
+func (b *Backend) TrimBeaconsPerOrigin(ctx context.Context, maxBeacons int) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	perOrigin := make(map[addr.IA][]*entry)
+	for _, e := range b.index {
+		perOrigin[e.start] = append(perOrigin[e.start], e)
+	}
+	trimmed := make(map[*entry]struct{})
+	for _, entries := range perOrigin {
+		if len(entries) <= maxBeacons {
+			continue
+		}
+		// Keep the most recently updated beacons, like the SQL backends.
+		slices.SortFunc(entries, func(x, y *entry) int {
+			return -compareUpdated(x, y)
+		})
+		for _, e := range entries[max(maxBeacons, 0):] {
+			trimmed[e] = struct{}{}
+		}
+	}
+	return b.deleteLocked(func(e *entry) bool {
+		_, ok := trimmed[e]
+		return ok
+	})
+}
+
+// deleteWhere deletes the beacons that match the filter. It returns the
+// number of deleted beacons.
+func (b *Backend) deleteWhere(filter func(e *entry) bool) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.deleteLocked(filter)
+}
+
+// deleteLocked is like deleteWhere, but must be called with the write lock
+// held.
+func (b *Backend) deleteLocked(filter func(e *entry) bool) (int, error) {
+	var buf []byte
+	var deleted []string
+	for key, e := range b.index {
+		if filter(e) {
+			buf = encodeDelete(buf, e.segID)
+			deleted = append(deleted, key)
+		}
+	}
+	if len(deleted) == 0 {
+		return 0, nil
+	}
+	if err := b.append(buf, len(deleted)); err != nil {
+		return 0, db.NewWriteError("delete beacons", err)
+	}
+	for _, key := range deleted {
+		delete(b.index, key)
+	}
+	b.maybeCompact()
+	return len(deleted), nil
+}
+
+// put writes the entries and their beacons to the log and updates the index.
+// It must be called with the write lock held.
+func (b *Backend) put(entries []*entry, values [][]byte) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var buf []byte
+	offsets := make([]int64, len(entries))
+	for i, e := range entries {
+		recordOff := int64(len(buf))
+		var valueOff int
+		buf, valueOff = encodePut(buf, e, values[i])
+		offsets[i] = recordOff + int64(valueOff)
+	}
+	start := b.size
+	if err := b.append(buf, len(entries)); err != nil {
+		return err
+	}
+	for i, e := range entries {
+		e.valueOff = start + offsets[i]
+		e.valueLen = len(values[i])
+		b.index[string(e.segID)] = e
+	}
+	b.maybeCompact()
+	return nil
+}
+
+// append appends the encoded records to the log. If the write fails, the log
+// is truncated to its previous length.
+func (b *Backend) append(buf []byte, records int) error {
+	if _, err := b.f.WriteAt(buf, b.size); err != nil {
+		// Best effort, a torn tail is also dropped when the log is loaded.
+		_ = b.f.Truncate(b.size)
+		return err
+	}
+	b.size += int64(len(buf))
+	b.records += records
+	return nil
+}
+
+// maybeCompact starts to compact the log in the background if it holds more
+// stale records than live ones. It must be called with the write lock held.
+func (b *Backend) maybeCompact() {
+	stale := b.records - len(b.index)
+	if stale < minCompactRecords || stale <= len(b.index) || b.compacting || b.closed {
+		return
+	}
+	b.compacting = true
+	b.compactions.Add(1)
+	go func() {
+		defer log.HandlePanic()
+		if err := b.compact(); err != nil {
+			log.Error("Compacting beacon database failed", "path", b.path, "err", err)
+		}
+	}()
+}
+
+// compact rewrites the log with only the live records. It must be called
+// without holding the lock, after compacting was set and compactions was
+// incremented.
+func (b *Backend) compact() error {
+	defer b.compactions.Done()
+	err := b.rewrite()
+	b.mu.Lock()
+	b.compacting = false
+	b.mu.Unlock()
+	if err != nil {
+		return serrors.Wrap("compacting database", err)
+	}
+	return nil
+}
+
+// rewrite streams the live records to a new log, which then replaces the
+// current one. The records are copied from a snapshot of the index without
+// holding the lock, such that the database can be used meanwhile. Only the
+// records appended during the copy are copied with the write lock held.
+//
+// The entries are never modified once they are in the index, except for
+// their offsets here, so they can be read without holding the lock.
+func (b *Backend) rewrite() error {
+	b.mu.RLock()
+	snapshot := make([]*entry, 0, len(b.index))
+	for _, e := range b.index {
+		snapshot = append(snapshot, e)
+	}
+	snapSize, snapRecords := b.size, b.records
+	b.mu.RUnlock()
+
+	tmpPath := b.path + ".compact"
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return serrors.Wrap("creating compacted database", err)
+	}
+	cleanup := func(err error) error {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	if _, err := w.Write(encodeHeader()); err != nil {
+		return cleanup(err)
+	}
+	size := int64(headerLen)
+	offsets := make(map[*entry]int64, len(snapshot))
+	var buf []byte
+	for _, e := range snapshot {
+		value, err := b.readValue(e)
+		if err != nil {
+			return cleanup(err)
+		}
+		var valueOff int
+		buf, valueOff = encodePut(buf[:0], e, value)
+		if _, err := w.Write(buf); err != nil {
+			return cleanup(err)
+		}
+		offsets[e] = size + int64(valueOff)
+		size += int64(len(buf))
+	}
+	if err := w.Flush(); err != nil {
+		return cleanup(err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, err := io.Copy(tmp, io.NewSectionReader(b.f, snapSize, b.size-snapSize)); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return cleanup(err)
+	}
+	if err := os.Rename(tmpPath, b.path); err != nil {
+		return cleanup(err)
+	}
+	// The records appended during the copy are moved by the length of the
+	// dropped stale records.
+	shift := size - snapSize
+	for _, e := range b.index {
+		if off, ok := offsets[e]; ok {
+			e.valueOff = off
+		} else {
+			e.valueOff += shift
+		}
+	}
+	b.f.Close()
+	b.f = tmp
+	b.size += shift
+	b.records += len(snapshot) - snapRecords
+	return nil
+}
+
+func (b *Backend) readValue(e *entry) ([]byte, error) {
+	value := make([]byte, e.valueLen)
+	if _, err := b.f.ReadAt(value, e.valueOff); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (b *Backend) readSegment(e *entry) (*seg.PathSegment, error) {
+	value, err := b.readValue(e)
+	if err != nil {
+		return nil, db.NewReadError("reading beacon", err)
+	}
+	s, err := beacon.UnpackBeacon(value)
+	if err != nil {
+		return nil, db.NewDataError("failed to parse entry", err)
+	}
+	return s, nil
+}
+
+func matchesQuery(e *entry, params *storagebeacon.QueryParams) bool {
+	if params == nil {
+		return true
+	}
+	if len(params.SegIDs) > 0 && !slices.ContainsFunc(params.SegIDs, func(id []byte) bool {
+		return bytes.HasPrefix(e.segID, id)
+	}) {
+		return false
+	}
+	if len(params.StartsAt) > 0 {
+		matched, filtered := false, false
+		for _, ia := range params.StartsAt {
+			if !ia.IsZero() {
+				filtered = true
+				matched = matched || ((ia.ISD() == 0 || ia.ISD() == e.start.ISD()) &&
+					(ia.AS() == 0 || ia.AS() == e.start.AS()))
+			}
+		}
+		if filtered && !matched {
+			return false
+		}
+	}
+	if len(params.IngressInterfaces) > 0 &&
+		!slices.Contains(params.IngressInterfaces, e.inIfID) {
+		return false
+	}
+	if len(params.Usages) > 0 {
+		matched, filtered := false, false
+		for _, u := range params.Usages {
+			if u > 0 {
+				filtered = true
+				matched = matched || e.usage&u == u
+			}
+		}
+		if filtered && !matched {
+			return false
+		}
+	}
+	if !params.ValidAt.IsZero() {
+		t := params.ValidAt.Unix()
+		if e.infoTime > t || t > e.expiry {
+			return false
+		}
+	}
+	if !params.CreatedAfter.IsZero() && e.infoTime < params.CreatedAfter.Unix() {
+		return false
+	}
+	return true
+}
+
+// hasHexPrefix returns whether the hex encoding of the segment ID starts with
+// the prefix, ignoring the case.
+func hasHexPrefix(segID []byte, prefix string) bool {
+	return strings.HasPrefix(hex.EncodeToString(segID), strings.ToLower(prefix))
+}
+
+// compareUpdated orders the entries by the time they were last updated, and
+// then by insertion.
+func compareUpdated(x, y *entry) int {
+	switch {
+	case x.lastUpdated < y.lastUpdated:
+		return -1
+	case x.lastUpdated > y.lastUpdated:
+		return 1
+	}
+	return compareSeq(x, y)
+}
+
+func compareSeq(x, y *entry) int {
+	switch {
+	case x.seq < y.seq:
+		return -1
+	case x.seq > y.seq:
+		return 1
+	}
+	return 0
+}
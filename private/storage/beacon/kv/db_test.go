@@ -0,0 +1,201 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv_test
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/beacon/beacondbtest"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/private/storage/beacon/dbtest"
+	"github.com/scionproto/scion/private/storage/beacon/kv"
+)
+
+var testIA = addr.MustParseIA("1-ff00:0:333")
+
+type TestBackend struct {
+	*kv.Backend
+	dir string
+	n   int
+}
+
+func (b *TestBackend) Prepare(t *testing.T, _ context.Context) {
+	if b.Backend != nil {
+		b.Backend.Close()
+	}
+	b.n++
+	db, err := kv.New(filepath.Join(b.dir, fmt.Sprintf("beacon%d.db", b.n)), testIA)
+	require.NoError(t, err)
+	b.Backend = db
+}
+
+func TestBeaconDBSuite(t *testing.T) {
+	tdb := &TestBackend{dir: t.TempDir()}
+	defer func() {
+		if tdb.Backend != nil {
+			tdb.Backend.Close()
+		}
+	}()
+	dbtest.Run(t, tdb)
+}
+
+// TestOpenExisting tests that New restores the beacons of an existing
+// database.
+func TestOpenExisting(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	path := filepath.Join(t.TempDir(), "beacon.db")
+	db, err := kv.New(path, testIA)
+	require.NoError(t, err)
+	b1 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info1, 2, 10, beacon.UsageProp)
+	b2 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info2, 3, 11, beacon.UsageProp)
+	b3 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info3, 4, 12, beacon.UsageProp)
+	require.NoError(t, db.DeleteBeacon(ctx, hex.EncodeToString(b2.Segment.ID())))
+	require.NoError(t, db.MarkBeaconUnusable(ctx, hex.EncodeToString(b3.Segment.ID())))
+	require.NoError(t, db.Close())
+
+	db, err = kv.New(path, testIA)
+	require.NoError(t, err)
+	defer db.Close()
+	res, err := db.CandidateBeacons(ctx, 10, beacon.UsageProp, addr.IA(0))
+	require.NoError(t, err)
+	beacondbtest.CheckResult(t, res, b1)
+	all, err := db.GetBeacons(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	// New beacons do not reuse the insertion order of existing ones.
+	b4 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info2, 5, 13, beacon.UsageProp)
+	res, err = db.CandidateBeacons(ctx, 10, beacon.UsageProp, addr.IA(0))
+	require.NoError(t, err)
+	beacondbtest.CheckResults(t, res, []beacon.Beacon{b1, b4})
+}
+
+// TestOpenNewer tests that New does not overwrite an existing database if it's
+// of a newer version.
+func TestOpenNewer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beacon.db")
+	db, err := kv.New(path, testIA)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	// Write a newer version.
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	raw[len(raw)-1] = kv.Version + 1
+	require.NoError(t, os.WriteFile(path, raw, 0o644))
+
+	db, err = kv.New(path, testIA)
+	assert.Error(t, err)
+	assert.Nil(t, db)
+}
+
+// TestOpenTornTail tests that a record that was not completely written, e.g.,
+// because of a crash, is dropped.
+func TestOpenTornTail(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	path := filepath.Join(t.TempDir(), "beacon.db")
+	db, err := kv.New(path, testIA)
+	require.NoError(t, err)
+	b1 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info1, 2, 10, beacon.UsageProp)
+	beacondbtest.InsertBeacon(t, db, beacondbtest.Info2, 3, 11, beacon.UsageProp)
+	require.NoError(t, db.Close())
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-5))
+
+	db, err = kv.New(path, testIA)
+	require.NoError(t, err)
+	defer db.Close()
+	res, err := db.CandidateBeacons(ctx, 10, beacon.UsageProp, addr.IA(0))
+	require.NoError(t, err)
+	beacondbtest.CheckResult(t, res, b1)
+	// The database is writable after the tail was dropped.
+	b3 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info3, 4, 12, beacon.UsageProp)
+	res, err = db.CandidateBeacons(ctx, 10, beacon.UsageProp, addr.IA(0))
+	require.NoError(t, err)
+	beacondbtest.CheckResults(t, res, []beacon.Beacon{b1, b3})
+}
+
+// TestCompact tests that compacting the log drops the stale records and keeps
+// the live beacons.
+func TestCompact(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	path := filepath.Join(t.TempDir(), "beacon.db")
+	db, err := kv.New(path, testIA)
+	require.NoError(t, err)
+	b1 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info1, 2, 10, beacon.UsageProp)
+	b2 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info2, 3, 11, beacon.UsageProp)
+	require.NoError(t, db.DeleteBeacon(ctx, hex.EncodeToString(b2.Segment.ID())))
+	before := db.Size()
+	require.NoError(t, db.Compact())
+	assert.Less(t, db.Size(), before)
+
+	res, err := db.CandidateBeacons(ctx, 10, beacon.UsageProp, addr.IA(0))
+	require.NoError(t, err)
+	beacondbtest.CheckResult(t, res, b1)
+	b3 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info3, 4, 12, beacon.UsageProp)
+	require.NoError(t, db.Close())
+
+	db, err = kv.New(path, testIA)
+	require.NoError(t, err)
+	defer db.Close()
+	res, err = db.CandidateBeacons(ctx, 10, beacon.UsageProp, addr.IA(0))
+	require.NoError(t, err)
+	beacondbtest.CheckResults(t, res, []beacon.Beacon{b1, b3})
+}
+
+// TestCompactConcurrent tests that the beacons written while the log is
+// compacted are kept.
+func TestCompactConcurrent(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	path := filepath.Join(t.TempDir(), "beacon.db")
+	db, err := kv.New(path, testIA)
+	require.NoError(t, err)
+	b1 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info1, 2, 10, beacon.UsageProp)
+	b2 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info2, 3, 11, beacon.UsageProp)
+
+	done := make(chan error)
+	go func() {
+		done <- db.Compact()
+	}()
+	require.NoError(t, db.DeleteBeacon(ctx, hex.EncodeToString(b2.Segment.ID())))
+	b3 := beacondbtest.InsertBeacon(t, db, beacondbtest.Info3, 4, 12, beacon.UsageProp)
+	require.NoError(t, <-done)
+
+	res, err := db.CandidateBeacons(ctx, 10, beacon.UsageProp, addr.IA(0))
+	require.NoError(t, err)
+	beacondbtest.CheckResults(t, res, []beacon.Beacon{b1, b3})
+	require.NoError(t, db.Close())
+
+	db, err = kv.New(path, testIA)
+	require.NoError(t, err)
+	defer db.Close()
+	res, err = db.CandidateBeacons(ctx, 10, beacon.UsageProp, addr.IA(0))
+	require.NoError(t, err)
+	beacondbtest.CheckResults(t, res, []beacon.Beacon{b1, b3})
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import "github.com/scionproto/scion/pkg/private/serrors"
+
+// Compact compacts the log, regardless of the number of stale records. It
+// returns when the compaction is done.
+func (b *Backend) Compact() error {
+	b.mu.Lock()
+	if b.compacting {
+		b.mu.Unlock()
+		return serrors.New("already compacting")
+	}
+	b.compacting = true
+	b.compactions.Add(1)
+	b.mu.Unlock()
+	return b.compact()
+}
+
+// Size returns the length of the log.
+func (b *Backend) Size() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.size
+}
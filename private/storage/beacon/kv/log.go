@@ -0,0 +1,239 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// The log starts with a header, the magic followed by the version. It is
+// followed by records, each framed by the length of its payload and the CRC32
+// checksum of the payload. The payload of a put record is:
+//
+//	op | seq | lastUpdated | infoTime | expiry | start | inIfID | hops | usage |
+//	len(segID) | segID | len(beacon) | beacon
+//
+// The payload of a delete record is:
+//
+//	op | len(segID) | segID
+const (
+	magic = "SCIONBKV"
+
+	opPut    byte = 1
+	opDelete byte = 2
+
+	headerLen       = len(magic) + 4
+	recordHeaderLen = 8
+	// putFixedLen is the length of the fixed-size fields of a put record,
+	// including the op.
+	putFixedLen = 1 + 5*8 + 2 + 2 + 4
+	// maxRecordLen bounds the length of a record, to detect corrupted lengths.
+	maxRecordLen = 1 << 24
+)
+
+var errCorrupted = errors.New("corrupted record")
+
+// entry is the index entry of a beacon. The beacon itself stays in the log,
+// at valueOff.
+type entry struct {
+	// seq orders the entries by insertion, like the row ID of the SQL
+	// backends. It is kept when the beacon is updated.
+	seq         uint64
+	segID       []byte
+	start       addr.IA
+	inIfID      uint16
+	hops        int
+	infoTime    int64
+	expiry      int64
+	lastUpdated int64
+	usage       beacon.Usage
+	valueOff    int64
+	valueLen    int
+}
+
+func encodeHeader() []byte {
+	h := make([]byte, headerLen)
+	copy(h, magic)
+	binary.BigEndian.PutUint32(h[len(magic):], Version)
+	return h
+}
+
+func checkHeader(h []byte) error {
+	if string(h[:len(magic)]) != magic {
+		return serrors.New("not a beacon database")
+	}
+	if v := binary.BigEndian.Uint32(h[len(magic):]); v != Version {
+		return serrors.New("Database version mismatch", "expected", Version, "have", v)
+	}
+	return nil
+}
+
+// encodePut appends the put record of the entry and its beacon to dst. It
+// returns the extended slice and the offset of the beacon in the record.
+func encodePut(dst []byte, e *entry, packed []byte) ([]byte, int) {
+	payloadLen := putFixedLen + 4 + len(e.segID) + 4 + len(packed)
+	dst = appendRecordHeader(dst, payloadLen)
+	start := len(dst)
+	dst = append(dst, opPut)
+	dst = binary.BigEndian.AppendUint64(dst, e.seq)
+	dst = binary.BigEndian.AppendUint64(dst, uint64(e.lastUpdated))
+	dst = binary.BigEndian.AppendUint64(dst, uint64(e.infoTime))
+	dst = binary.BigEndian.AppendUint64(dst, uint64(e.expiry))
+	dst = binary.BigEndian.AppendUint64(dst, uint64(e.start))
+	dst = binary.BigEndian.AppendUint16(dst, e.inIfID)
+	dst = binary.BigEndian.AppendUint16(dst, uint16(e.hops))
+	dst = binary.BigEndian.AppendUint32(dst, uint32(e.usage))
+	dst = appendBytes(dst, e.segID)
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(packed)))
+	valueOff := len(dst) - start + recordHeaderLen
+	dst = append(dst, packed...)
+	return sealRecord(dst, start), valueOff
+}
+
+// encodeDelete appends the delete record of the segment ID to dst.
+func encodeDelete(dst []byte, segID []byte) []byte {
+	dst = appendRecordHeader(dst, 1+4+len(segID))
+	start := len(dst)
+	dst = append(dst, opDelete)
+	dst = appendBytes(dst, segID)
+	return sealRecord(dst, start)
+}
+
+func appendRecordHeader(dst []byte, payloadLen int) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, uint32(payloadLen))
+	// The checksum is filled in by sealRecord.
+	return binary.BigEndian.AppendUint32(dst, 0)
+}
+
+func sealRecord(dst []byte, payloadStart int) []byte {
+	sum := crc32.ChecksumIEEE(dst[payloadStart:])
+	binary.BigEndian.PutUint32(dst[payloadStart-4:payloadStart], sum)
+	return dst
+}
+
+func appendBytes(dst []byte, p []byte) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(p)))
+	return append(dst, p...)
+}
+
+// replay reads the records of the log from r, positioned after the header,
+// and applies them to the index. It returns the number of records and the
+// length of the log up to the last valid record. A truncated or corrupted
+// tail, e.g., after a crash, ends the replay.
+func replay(r io.Reader, index map[string]*entry) (int, int64, error) {
+	br := bufio.NewReader(r)
+	offset := int64(headerLen)
+	records := 0
+	for {
+		payload, err := readRecord(br)
+		switch {
+		case errors.Is(err, io.EOF), errors.Is(err, errCorrupted):
+			return records, offset, nil
+		case err != nil:
+			return 0, 0, err
+		}
+		if err := apply(payload, offset, index); err != nil {
+			return records, offset, nil
+		}
+		records++
+		offset += int64(recordHeaderLen + len(payload))
+	}
+}
+
+func readRecord(r io.Reader) ([]byte, error) {
+	var h [recordHeaderLen]byte
+	if _, err := io.ReadFull(r, h[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errCorrupted
+		}
+		return nil, err
+	}
+	l := binary.BigEndian.Uint32(h[:4])
+	if l == 0 || l > maxRecordLen {
+		return nil, errCorrupted
+	}
+	payload := make([]byte, l)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errCorrupted
+		}
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(h[4:]) {
+		return nil, errCorrupted
+	}
+	return payload, nil
+}
+
+// apply applies the record at the given offset of the log to the index.
+func apply(payload []byte, offset int64, index map[string]*entry) error {
+	switch payload[0] {
+	case opPut:
+		if len(payload) < putFixedLen {
+			return errCorrupted
+		}
+		p := payload[1:]
+		e := &entry{
+			seq:         binary.BigEndian.Uint64(p[0:]),
+			lastUpdated: int64(binary.BigEndian.Uint64(p[8:])),
+			infoTime:    int64(binary.BigEndian.Uint64(p[16:])),
+			expiry:      int64(binary.BigEndian.Uint64(p[24:])),
+			start:       addr.IA(binary.BigEndian.Uint64(p[32:])),
+			inIfID:      binary.BigEndian.Uint16(p[40:]),
+			hops:        int(binary.BigEndian.Uint16(p[42:])),
+			usage:       beacon.Usage(binary.BigEndian.Uint32(p[44:])),
+		}
+		segID, rest, err := readBytes(payload[putFixedLen:])
+		if err != nil {
+			return err
+		}
+		value, _, err := readBytes(rest)
+		if err != nil {
+			return err
+		}
+		e.segID = segID
+		e.valueLen = len(value)
+		e.valueOff = offset + int64(recordHeaderLen+len(payload)-len(value))
+		index[string(segID)] = e
+	case opDelete:
+		segID, _, err := readBytes(payload[1:])
+		if err != nil {
+			return err
+		}
+		delete(index, string(segID))
+	default:
+		return errCorrupted
+	}
+	return nil
+}
+
+func readBytes(p []byte) ([]byte, []byte, error) {
+	if len(p) < 4 {
+		return nil, nil, errCorrupted
+	}
+	l := binary.BigEndian.Uint32(p)
+	if uint64(l) > uint64(len(p)-4) {
+		return nil, nil, errCorrupted
+	}
+	return append([]byte(nil), p[4:4+l]...), p[4+l:], nil
+}
@@ -136,6 +136,15 @@ func (d *db) DeleteBeacon(ctx context.Context, partialID string) error {
 	return err
 }
 
+func (d *db) MarkBeaconUnusable(ctx context.Context, partialID string) error {
+	var err error
+	d.metrics.Observe(ctx, "mark_beacon_unusable", func(ctx context.Context) (string, error) {
+		err = d.db.MarkBeaconUnusable(ctx, partialID)
+		return dblib.ErrToMetricLabel(err), err
+	})
+	return err
+}
+
 func (d *db) Close() error {
 	return d.db.Close()
 }
@@ -0,0 +1,357 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postgres implements the beacon database on PostgreSQL.
+//
+// Unlike the SQLite backend, it does not serialize the accesses in the process. All the updates
+// are single statements, so that several control service instances can share the database.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/segment/iface"
+	storagebeacon "github.com/scionproto/scion/private/storage/beacon"
+	"github.com/scionproto/scion/private/storage/db"
+)
+
+var _ beacon.DB = (*Backend)(nil)
+
+type Backend struct {
+	db *sql.DB
+	ia addr.IA
+}
+
+// New returns a new PostgreSQL backend connecting to the database with the given connection
+// string. If the schema is not set up in the database yet, it is created. If the version of the
+// existing schema is different from the one in schema.go, an error is returned.
+func New(conn string, ia addr.IA) (*Backend, error) {
+	db, err := db.NewPostgres(conn, SchemaName, Schema, SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{
+		db: db,
+		ia: ia,
+	}, nil
+}
+
+// SetMaxOpenConns sets the maximum number of open connections.
+func (b *Backend) SetMaxOpenConns(maxOpenConns int) {
+	b.db.SetMaxOpenConns(maxOpenConns)
+}
+
+// SetMaxIdleConns sets the maximum number of idle connections.
+func (b *Backend) SetMaxIdleConns(maxIdleConns int) {
+	b.db.SetMaxIdleConns(maxIdleConns)
+}
+
+// Close closes the database.
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+func (b *Backend) BeaconSources(ctx context.Context) ([]addr.IA, error) {
+	query := `SELECT DISTINCT StartIsd, StartAs FROM Beacons`
+	rows, err := b.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, db.NewReadError("Error selecting source IAs", err)
+	}
+	defer rows.Close()
+	var ias []addr.IA
+	for rows.Next() {
+		var isd addr.ISD
+		var as addr.AS
+		if err := rows.Scan(&isd, &as); err != nil {
+			return nil, err
+		}
+		ia, err := addr.IAFrom(isd, as)
+		if err != nil {
+			return nil, err
+		}
+		ias = append(ias, ia)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ias, nil
+}
+
+func (b *Backend) CandidateBeacons(
+	ctx context.Context,
+	setSize int,
+	usage beacon.Usage,
+	src addr.IA,
+) ([]beacon.Beacon, error) {
+
+	args := []any{usage, setSize}
+	srcCond := ``
+	if !src.IsZero() {
+		srcCond = `AND StartIsd = $3 AND StartAs = $4`
+		args = append(args, src.ISD(), src.AS())
+	}
+	query := fmt.Sprintf(`
+		SELECT b.Beacon, b.InIntfID
+		FROM Beacons b
+		WHERE ( b.Usage & $1 ) = $1 %s
+		ORDER BY b.HopsLength ASC
+		LIMIT $2
+	`, srcCond)
+	rows, err := b.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, db.NewReadError("Error selecting beacons", err)
+	}
+	defer rows.Close()
+
+	beacons := make([]beacon.Beacon, 0, setSize)
+	for rows.Next() {
+		var rawBeacon sql.RawBytes
+		var inIfID iface.ID
+		if err = rows.Scan(&rawBeacon, &inIfID); err != nil {
+			return nil, db.NewReadError("failed to read rows", err)
+		}
+		s, err := beacon.UnpackBeacon(rawBeacon)
+		if err != nil {
+			return nil, db.NewDataError("failed to parse entry", err)
+		}
+		beacons = append(beacons, beacon.Beacon{Segment: s, InIfID: uint16(inIfID)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return beacons, nil
+}
+
+// InsertBeacon inserts the beacon if it is new or updates the changed
+// information if it is newer than the stored one.
+func (b *Backend) InsertBeacon(
+	ctx context.Context,
+	bcn beacon.Beacon,
+	usage beacon.Usage,
+) (beacon.InsertStats, error) {
+
+	ret := beacon.InsertStats{}
+	packed, err := beacon.PackBeacon(bcn.Segment)
+	if err != nil {
+		return ret, db.NewInputDataError("pack segment", err)
+	}
+	start := bcn.Segment.FirstIA()
+	// The update only applies to beacons with a newer info timestamp. xmax is only set on
+	// updated rows, so that inserts and updates can be told apart.
+	inst := `
+	INSERT INTO Beacons (SegID, FullID, StartIsd, StartAs, InIntfID, HopsLength, InfoTime,
+		ExpirationTime, LastUpdated, Usage, Beacon)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT (SegID) DO UPDATE SET FullID = EXCLUDED.FullID,
+		InIntfID = EXCLUDED.InIntfID, HopsLength = EXCLUDED.HopsLength,
+		InfoTime = EXCLUDED.InfoTime, ExpirationTime = EXCLUDED.ExpirationTime,
+		LastUpdated = EXCLUDED.LastUpdated, Usage = EXCLUDED.Usage, Beacon = EXCLUDED.Beacon
+	WHERE Beacons.InfoTime < EXCLUDED.InfoTime
+	RETURNING (xmax = 0)
+	`
+	var inserted bool
+	err = b.db.QueryRowContext(ctx, inst, bcn.Segment.ID(), bcn.Segment.FullID(), start.ISD(),
+		start.AS(), bcn.InIfID, len(bcn.Segment.ASEntries), bcn.Segment.Info.Timestamp.Unix(),
+		bcn.Segment.MaxExpiry().Unix(), time.Now().UnixNano(), usage, packed).Scan(&inserted)
+	switch {
+	case err == sql.ErrNoRows:
+		// The stored beacon is at least as recent.
+		return ret, nil
+	case err != nil:
+		return ret, db.NewWriteError("insert beacon", err)
+	case inserted:
+		ret.Inserted = 1
+	default:
+		ret.Updated = 1
+	}
+	return ret, nil
+}
+
+func (b *Backend) GetBeacons(
+	ctx context.Context,
+	params *storagebeacon.QueryParams,
+) ([]storagebeacon.Beacon, error) {
+
+	stmt, args := b.buildQuery(params)
+	rows, err := b.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, serrors.Wrap("looking up beacons", err, "query", stmt)
+	}
+	defer rows.Close()
+	var res []storagebeacon.Beacon
+	for rows.Next() {
+		var RowID int64
+		var lastUpdated int64
+		var usage int
+		var rawBeacon sql.RawBytes
+		var InIfID uint16
+		err = rows.Scan(&RowID, &lastUpdated, &usage, &rawBeacon, &InIfID)
+		if err != nil {
+			return nil, serrors.Wrap("reading row", err)
+		}
+		seg, err := beacon.UnpackBeacon(rawBeacon)
+		if err != nil {
+			return nil, serrors.Wrap("parsing beacon", err)
+		}
+		res = append(res, storagebeacon.Beacon{
+			Beacon: beacon.Beacon{
+				Segment: seg,
+				InIfID:  InIfID,
+			},
+			Usage:       beacon.Usage(usage),
+			LastUpdated: time.Unix(0, lastUpdated),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (b *Backend) DeleteBeacon(ctx context.Context, partialID string) error {
+	_, err := db.DeleteInTx(ctx, b.db, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `DELETE FROM Beacons WHERE encode(SegID, 'hex') ILIKE $1`
+		return tx.ExecContext(ctx, delStmt, partialID+"%")
+	})
+	return err
+}
+
+func (b *Backend) MarkBeaconUnusable(ctx context.Context, partialID string) error {
+	updStmt := `UPDATE Beacons SET Usage = 0 WHERE encode(SegID, 'hex') ILIKE $1`
+	if _, err := b.db.ExecContext(ctx, updStmt, partialID+"%"); err != nil {
+		return db.NewWriteError("mark beacon unusable", err)
+	}
+	return nil
+}
+
+func (b *Backend) buildQuery(params *storagebeacon.QueryParams) (string, []any) {
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	query := "SELECT DISTINCT RowID, LastUpdated, Usage, Beacon, InIntfID FROM Beacons"
+	if params == nil {
+		return query, args
+	}
+	where := []string{}
+	if len(params.SegIDs) > 0 {
+		subQ := make([]string, 0, len(params.SegIDs))
+		for _, segID := range params.SegIDs {
+			subQ = append(subQ, fmt.Sprintf(
+				"encode(SegID, 'hex') LIKE (encode(%s::BYTEA, 'hex') || '%%')", arg(segID)))
+		}
+		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+	}
+	if len(params.StartsAt) > 0 {
+		subQ := []string{}
+		for _, as := range params.StartsAt {
+			switch {
+			case as.IsZero():
+				continue
+			case as.ISD() == 0:
+				subQ = append(subQ, "StartAs="+arg(as.AS()))
+			case as.AS() == 0:
+				subQ = append(subQ, "StartIsd="+arg(as.ISD()))
+			case as.ISD() != 0 && as.AS() != 0:
+				subQ = append(subQ, fmt.Sprintf("(StartIsd=%s AND StartAs=%s)",
+					arg(as.ISD()), arg(as.AS())))
+			}
+		}
+		if len(subQ) > 0 {
+			where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+		}
+	}
+	if len(params.IngressInterfaces) > 0 {
+		subQ := make([]string, 0, len(params.IngressInterfaces))
+		for _, intf := range params.IngressInterfaces {
+			subQ = append(subQ, "InIntfID="+arg(intf))
+		}
+		where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+	}
+
+	if len(params.Usages) > 0 {
+		subQ := []string{}
+		for _, u := range params.Usages {
+			if u > 0 {
+				p := arg(int64(u))
+				subQ = append(subQ, fmt.Sprintf("(Usage & %s = %s)", p, p))
+			}
+		}
+		if len(subQ) > 0 {
+			where = append(where, fmt.Sprintf("(%s)", strings.Join(subQ, " OR ")))
+		}
+	}
+
+	if !params.ValidAt.IsZero() {
+		p := arg(params.ValidAt.Unix())
+		where = append(where, fmt.Sprintf("(InfoTime <= %s AND %s <= ExpirationTime)", p, p))
+	}
+	if !params.CreatedAfter.IsZero() {
+		where = append(where, "InfoTime >= "+arg(params.CreatedAfter.Unix()))
+	}
+	// Assemble the query.
+	if len(where) > 0 {
+		query += "\n" + fmt.Sprintf("WHERE %s", strings.Join(where, " AND\n"))
+	}
+	query += "\n" + "ORDER BY LastUpdated DESC"
+	return query, args
+}
+
+func (b *Backend) DeleteExpiredBeacons(ctx context.Context, now time.Time) (int, error) {
+	return db.DeleteInTx(ctx, b.db, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `DELETE FROM Beacons WHERE ExpirationTime < $1`
+		return tx.ExecContext(ctx, delStmt, now.Unix())
+	})
+}
+
+func (b *Backend) DeleteBeaconsUpdatedBefore(ctx context.Context, t time.Time) (int, error) {
+	return db.DeleteInTx(ctx, b.db, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `DELETE FROM Beacons WHERE LastUpdated < $1`
+		return tx.ExecContext(ctx, delStmt, t.UnixNano())
+	})
+}
+
+func (b *Backend) DeleteUnusableBeaconsUpdatedBefore(
+	ctx context.Context,
+	t time.Time,
+) (int, error) {
+
+	return db.DeleteInTx(ctx, b.db, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `DELETE FROM Beacons WHERE Usage = 0 AND LastUpdated < $1`
+		return tx.ExecContext(ctx, delStmt, t.UnixNano())
+	})
+}
+
+func (b *Backend) TrimBeaconsPerOrigin(ctx context.Context, maxBeacons int) (int, error) {
+	return db.DeleteInTx(ctx, b.db, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `
+		DELETE FROM Beacons WHERE RowID IN (
+			SELECT RowID FROM (
+				SELECT RowID, ROW_NUMBER() OVER (
+					PARTITION BY StartIsd, StartAs ORDER BY LastUpdated DESC, RowID DESC
+				) AS RowNumber FROM Beacons
+			) AS Ranked WHERE RowNumber > $1
+		)
+		`
+		return tx.ExecContext(ctx, delStmt, maxBeacons)
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/beacon/beacondbtest"
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/private/storage/beacon/dbtest"
+	"github.com/scionproto/scion/private/storage/beacon/postgres"
+	"github.com/scionproto/scion/private/storage/db/postgrestest"
+)
+
+var testIA = addr.MustParseIA("1-ff00:0:333")
+
+type TestBackend struct {
+	*postgres.Backend
+}
+
+func (b *TestBackend) Prepare(t *testing.T, _ context.Context) {
+	db, err := postgres.New(postgrestest.Connection(t), testIA)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	b.Backend = db
+}
+
+func TestBeaconDBSuite(t *testing.T) {
+	postgrestest.SkipIfNotConfigured(t)
+	tdb := &TestBackend{}
+	dbtest.Run(t, tdb)
+}
+
+// TestSharedDatabase tests that backends sharing a database see the beacons
+// inserted by each other.
+func TestSharedDatabase(t *testing.T) {
+	conn := postgrestest.Connection(t)
+	first, err := postgres.New(conn, testIA)
+	require.NoError(t, err)
+	defer first.Close()
+	second, err := postgres.New(conn, testIA)
+	require.NoError(t, err)
+	defer second.Close()
+
+	b := beacondbtest.InsertBeacon(t, first, beacondbtest.Info1, 2, 10, beacon.UsageProp)
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	res, err := second.CandidateBeacons(ctx, 10, beacon.UsageProp, 0)
+	require.NoError(t, err)
+	beacondbtest.CheckResult(t, res, b)
+
+	// Inserting the same beacon again through the other backend changes nothing.
+	stats, err := second.InsertBeacon(ctx, b, beacon.UsageProp)
+	require.NoError(t, err)
+	assert.Equal(t, beacon.InsertStats{}, stats)
+}
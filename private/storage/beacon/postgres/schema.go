@@ -0,0 +1,44 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+const (
+	// SchemaName is the name under which the version of the schema is recorded.
+	SchemaName = "beacon"
+	// SchemaVersion is the version of the PostgreSQL schema understood by this backend.
+	// Whenever changes to the schema are made, this version number should be increased
+	// to prevent data corruption between incompatible database schemas.
+	SchemaVersion = 1
+	// Schema is the PostgreSQL database layout.
+	Schema = `CREATE TABLE Beacons(
+		RowID BIGSERIAL PRIMARY KEY,
+		SegID BYTEA UNIQUE NOT NULL,
+		FullID BYTEA UNIQUE NOT NULL,
+		StartIsd INTEGER NOT NULL,
+		StartAs BIGINT NOT NULL,
+		InIntfID INTEGER NOT NULL,
+		HopsLength INTEGER NOT NULL,
+		InfoTime BIGINT NOT NULL,
+		ExpirationTime BIGINT NOT NULL,
+		LastUpdated BIGINT NOT NULL,
+		Usage INTEGER NOT NULL,
+		Beacon BYTEA NOT NULL
+	);
+	CREATE INDEX BeaconsStartIdx ON Beacons(StartIsd, StartAs);
+	CREATE INDEX BeaconsHopsLengthIdx ON Beacons(HopsLength);
+	CREATE INDEX BeaconsExpirationIdx ON Beacons(ExpirationTime);
+	`
+	BeaconsTable = "Beacons"
+)
@@ -249,6 +249,16 @@ func (e *executor) DeleteBeacon(ctx context.Context, partialID string) error {
 	return err
 }
 
+func (e *executor) MarkBeaconUnusable(ctx context.Context, partialID string) error {
+	e.Lock()
+	defer e.Unlock()
+	updStmt := `UPDATE Beacons SET Usage = 0 WHERE hex(SegID) LIKE ?`
+	if _, err := e.db.ExecContext(ctx, updStmt, partialID+"%"); err != nil {
+		return db.NewWriteError("mark beacon unusable", err)
+	}
+	return nil
+}
+
 func (e *executor) buildQuery(params *storagebeacon.QueryParams) (string, []any) {
 	var args []any
 	query := "SELECT DISTINCT RowID, LastUpdated, Usage, Beacon, InIntfID FROM Beacons"
@@ -312,6 +322,10 @@ func (e *executor) buildQuery(params *storagebeacon.QueryParams) (string, []any)
 		args = append(args, params.ValidAt.Unix())
 		args = append(args, params.ValidAt.Unix())
 	}
+	if !params.CreatedAfter.IsZero() {
+		where = append(where, "InfoTime >= ?")
+		args = append(args, params.CreatedAfter.Unix())
+	}
 	// Assemble the query.
 	if len(where) > 0 {
 		query += "\n" + fmt.Sprintf("WHERE %s", strings.Join(where, " AND\n"))
@@ -408,6 +422,39 @@ func (e *executor) DeleteExpiredBeacons(ctx context.Context, now time.Time) (int
 	})
 }
 
+func (e *executor) DeleteBeaconsUpdatedBefore(ctx context.Context, t time.Time) (int, error) {
+	return e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `DELETE FROM Beacons WHERE LastUpdated < ?`
+		return tx.ExecContext(ctx, delStmt, t.UnixNano())
+	})
+}
+
+func (e *executor) DeleteUnusableBeaconsUpdatedBefore(
+	ctx context.Context,
+	t time.Time,
+) (int, error) {
+
+	return e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `DELETE FROM Beacons WHERE Usage = 0 AND LastUpdated < ?`
+		return tx.ExecContext(ctx, delStmt, t.UnixNano())
+	})
+}
+
+func (e *executor) TrimBeaconsPerOrigin(ctx context.Context, maxBeacons int) (int, error) {
+	return e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `
+		DELETE FROM Beacons WHERE RowID IN (
+			SELECT RowID FROM (
+				SELECT RowID, ROW_NUMBER() OVER (
+					PARTITION BY StartIsd, StartAs ORDER BY LastUpdated DESC, RowID DESC
+				) AS RowNumber FROM Beacons
+			) WHERE RowNumber > ?
+		)
+		`
+		return tx.ExecContext(ctx, delStmt, maxBeacons)
+	})
+}
+
 func (e *executor) deleteInTx(
 	ctx context.Context,
 	delFunc func(tx *sql.Tx) (sql.Result, error),
@@ -0,0 +1,116 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"regexp"
+
+	// Registers the postgres driver.
+	_ "github.com/lib/pq"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// schemaLockID is the key of the advisory lock that is held while the schema of a PostgreSQL
+// database is checked and set up, so that services that start at the same time against the same
+// database do not set it up concurrently.
+const schemaLockID = 0x5c10_4db5
+
+var passwordParam = regexp.MustCompile(`password\s*=\s*('(\\.|[^'])*'|\S+)`)
+
+// NewPostgres returns a new PostgreSQL backend connecting to the database with the given
+// connection string. The version of the named schema is recorded in the SchemaVersions table. If
+// the schema is not set up yet, it is created. If its version is different from schemaVersion, an
+// error is returned.
+//
+// Several services can share the same database, as long as they use different schemas, or the
+// same schema for the same purpose.
+func NewPostgres(conn string, name string, schema string, schemaVersion int) (*sql.DB, error) {
+	var err error
+	if conn == "" {
+		return nil, serrors.New("Empty connection string not allowed for postgres")
+	}
+	db, err := sql.Open("postgres", conn)
+	if err != nil {
+		return nil, serrors.Wrap("Couldn't open PostgreSQL database", err,
+			"connection", RedactConnection(conn))
+	}
+	// On future errors, close the sql database before exiting
+	defer func() {
+		if err != nil {
+			db.Close()
+		}
+	}()
+	// Make sure DB is reachable
+	if err = db.Ping(); err != nil {
+		return nil, serrors.Wrap("Initial DB ping failed, connection broken?", err,
+			"connection", RedactConnection(conn))
+	}
+	err = DoInTx(context.Background(), db, func(ctx context.Context, tx *sql.Tx) error {
+		return setupPostgres(ctx, tx, name, schema, schemaVersion)
+	})
+	if err != nil {
+		return nil, serrors.Wrap("Failed to set up PostgreSQL database", err,
+			"connection", RedactConnection(conn), "schema", name)
+	}
+	return db, nil
+}
+
+func setupPostgres(ctx context.Context, tx *sql.Tx, name, schema string, version int) error {
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", schemaLockID); err != nil {
+		return serrors.Wrap("acquiring schema lock", err)
+	}
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS SchemaVersions(
+		Name TEXT PRIMARY KEY,
+		Version INTEGER NOT NULL
+	)`)
+	if err != nil {
+		return serrors.Wrap("creating schema versions table", err)
+	}
+	var existingVersion int
+	err = tx.QueryRowContext(ctx, "SELECT Version FROM SchemaVersions WHERE Name = $1",
+		name).Scan(&existingVersion)
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		return serrors.Wrap("checking schema version", err)
+	case existingVersion != version:
+		return serrors.New("Database schema version mismatch",
+			"expected", version, "have", existingVersion)
+	default:
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, schema); err != nil {
+		return serrors.Wrap("creating schema", err)
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO SchemaVersions (Name, Version) VALUES ($1, $2)",
+		name, version)
+	if err != nil {
+		return serrors.Wrap("writing schema version", err)
+	}
+	return nil
+}
+
+// RedactConnection returns the PostgreSQL connection string without its password, so that it
+// can be logged.
+func RedactConnection(conn string) string {
+	if u, err := url.Parse(conn); err == nil && u.Scheme != "" {
+		return u.Redacted()
+	}
+	return passwordParam.ReplaceAllString(conn, "password=xxxxx")
+}
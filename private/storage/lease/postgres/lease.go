@@ -0,0 +1,91 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postgres stores leases in PostgreSQL, so that the instances of a service that share a
+// database can elect a leader.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/scionproto/scion/private/storage/db"
+)
+
+type Backend struct {
+	db *sql.DB
+}
+
+// New returns a new PostgreSQL backend connecting to the database with the given connection
+// string. If the schema is not set up in the database yet, it is created. If the version of the
+// existing schema is different from the one in schema.go, an error is returned.
+func New(conn string) (*Backend, error) {
+	db, err := db.NewPostgres(conn, SchemaName, Schema, SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{db: db}, nil
+}
+
+// SetMaxOpenConns sets the maximum number of open connections.
+func (b *Backend) SetMaxOpenConns(maxOpenConns int) {
+	b.db.SetMaxOpenConns(maxOpenConns)
+}
+
+// SetMaxIdleConns sets the maximum number of idle connections.
+func (b *Backend) SetMaxIdleConns(maxIdleConns int) {
+	b.db.SetMaxIdleConns(maxIdleConns)
+}
+
+// Close closes the database.
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+// AcquireLease acquires or renews the named lease for the holder for the given duration. It
+// returns false if another holder has the lease. The expiry is computed with the clock of the
+// database, so that the clocks of the holders do not matter.
+func (b *Backend) AcquireLease(
+	ctx context.Context,
+	name string,
+	holder string,
+	duration time.Duration,
+) (bool, error) {
+
+	query := `
+	INSERT INTO Leases (Name, Holder, Expiry)
+	VALUES ($1, $2, now() + $3::DOUBLE PRECISION * INTERVAL '1 microsecond')
+	ON CONFLICT (Name) DO UPDATE SET Holder = EXCLUDED.Holder, Expiry = EXCLUDED.Expiry
+	WHERE Leases.Holder = EXCLUDED.Holder OR Leases.Expiry < now()
+	`
+	r, err := b.db.ExecContext(ctx, query, name, holder, duration.Microseconds())
+	if err != nil {
+		return false, db.NewWriteError("acquire lease", err)
+	}
+	n, err := r.RowsAffected()
+	if err != nil {
+		return false, db.NewWriteError("acquire lease", err)
+	}
+	return n > 0, nil
+}
+
+// ReleaseLease releases the named lease if the holder has it.
+func (b *Backend) ReleaseLease(ctx context.Context, name string, holder string) error {
+	query := `DELETE FROM Leases WHERE Name = $1 AND Holder = $2`
+	if _, err := b.db.ExecContext(ctx, query, name, holder); err != nil {
+		return db.NewWriteError("release lease", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/storage/db/postgrestest"
+	"github.com/scionproto/scion/private/storage/lease/postgres"
+)
+
+func TestAcquireLease(t *testing.T) {
+	b, err := postgres.New(postgrestest.Connection(t))
+	require.NoError(t, err)
+	defer b.Close()
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+
+	acquire := func(holder string, d time.Duration) bool {
+		t.Helper()
+		ok, err := b.AcquireLease(ctx, "beaconing", holder, d)
+		require.NoError(t, err)
+		return ok
+	}
+	assert.True(t, acquire("a", 200*time.Millisecond))
+	assert.False(t, acquire("b", 200*time.Millisecond))
+	// The holder renews its lease.
+	assert.True(t, acquire("a", 200*time.Millisecond))
+	// Another lease is independent.
+	ok, err := b.AcquireLease(ctx, "other", "b", time.Second)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Expired leases are taken over.
+	time.Sleep(300 * time.Millisecond)
+	assert.True(t, acquire("b", time.Minute))
+	assert.False(t, acquire("a", time.Minute))
+
+	// Released leases are taken over.
+	require.NoError(t, b.ReleaseLease(ctx, "beaconing", "a"))
+	assert.False(t, acquire("a", time.Minute))
+	require.NoError(t, b.ReleaseLease(ctx, "beaconing", "b"))
+	assert.True(t, acquire("a", time.Minute))
+}
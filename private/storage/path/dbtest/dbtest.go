@@ -33,6 +33,7 @@ import (
 	"github.com/scionproto/scion/pkg/slayers/path"
 	"github.com/scionproto/scion/private/pathdb"
 	"github.com/scionproto/scion/private/pathdb/query"
+	pathstorage "github.com/scionproto/scion/private/storage/path"
 )
 
 var (
@@ -147,6 +148,51 @@ func TestPathDB(t *testing.T, db TestablePathDB) {
 			testRollback(t, db)
 		})
 	})
+	if cleanable, ok := db.(pathstorage.Cleanable); ok {
+		t.Run("GarbageCollection", func(t *testing.T) {
+			testGarbageCollection(t, db, cleanable)
+		})
+	}
+}
+
+func testGarbageCollection(t *testing.T, db TestablePathDB, cleanable pathstorage.Cleanable) {
+	t.Run("DeleteUpdatedBefore deletes old segments", func(t *testing.T) {
+		ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+		defer cancelF()
+		db.Prepare(t, ctx)
+
+		pseg1, _ := AllocPathSegment(t, ifs1, 10)
+		pseg2, _ := AllocPathSegment(t, ifs2, 20)
+		InsertSeg(t, ctx, db, pseg1, hpGroupIDs)
+		between := time.Now()
+		InsertSeg(t, ctx, db, pseg2, hpGroupIDs)
+
+		deleted, err := cleanable.DeleteUpdatedBefore(ctx, between)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted, "Deleted")
+		deleted, err = cleanable.DeleteUpdatedBefore(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted, "Deleted")
+	})
+	t.Run("TrimPerOrigin keeps the most recent segments", func(t *testing.T) {
+		ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+		defer cancelF()
+		db.Prepare(t, ctx)
+
+		// Both segments originate in the same AS.
+		pseg1, _ := AllocPathSegment(t, ifs1, 10)
+		pseg2, _ := AllocPathSegment(t, ifs2, 20)
+		InsertSeg(t, ctx, db, pseg1, hpGroupIDs)
+		InsertSeg(t, ctx, db, pseg2, hpGroupIDs)
+
+		deleted, err := cleanable.TrimPerOrigin(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted, "Deleted")
+		res, err := db.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, pseg2.ID(), res[0].Seg.ID())
+	})
 }
 
 func testInsertWithHPGroupIDsFull(t *testing.T, pathDB pathdb.ReadWrite) {
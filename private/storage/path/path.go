@@ -15,12 +15,27 @@
 package path
 
 import (
+	"context"
 	"strconv"
 	"strings"
+	"time"
 
 	seg "github.com/scionproto/scion/pkg/segment"
 )
 
+// Cleanable is a path database that supports garbage collection of segments that have not
+// expired yet.
+type Cleanable interface {
+	// DeleteUpdatedBefore removes all path segments that were last updated before the passed
+	// time value.
+	// The return value indicates the number of path segments that were removed.
+	DeleteUpdatedBefore(ctx context.Context, t time.Time) (int, error)
+	// TrimPerOrigin removes the least recently updated path segments of every origin AS, i.e.,
+	// the first AS of the segment, such that at most maxSegments segments remain per origin AS.
+	// The return value indicates the number of path segments that were removed.
+	TrimPerOrigin(ctx context.Context, maxSegments int) (int, error)
+}
+
 type GroupIDs []uint64
 
 func (g *GroupIDs) Scan(src any) error {
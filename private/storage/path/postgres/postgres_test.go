@@ -0,0 +1,91 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/storage/db/postgrestest"
+	pathdbtest "github.com/scionproto/scion/private/storage/path/dbtest"
+)
+
+var (
+	ifs1       = []uint64{0, 5, 2, 3, 6, 3, 1, 0}
+	hpGroupIDs = []uint64{
+		0,
+		0xffffffffffffffff,
+	}
+	timeout = time.Second
+)
+
+var _ pathdbtest.TestablePathDB = (*TestPathDB)(nil)
+
+type TestPathDB struct {
+	*Backend
+}
+
+func (b *TestPathDB) Prepare(t *testing.T, _ context.Context) {
+	db, err := New(postgrestest.Connection(t))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	b.Backend = db
+}
+
+func TestPathDBSuite(t *testing.T) {
+	postgrestest.SkipIfNotConfigured(t)
+	tdb := &TestPathDB{}
+	pathdbtest.TestPathDB(t, tdb)
+}
+
+// TestOpenExisting tests that New does not overwrite an existing schema if
+// versions match.
+func TestOpenExisting(t *testing.T) {
+	conn := postgrestest.Connection(t)
+	b, err := New(conn)
+	require.NoError(t, err)
+	pseg1, _ := pathdbtest.AllocPathSegment(t, ifs1, uint32(10))
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+	pathdbtest.InsertSeg(t, ctx, b, pseg1, hpGroupIDs)
+	b.Close()
+
+	b, err = New(conn)
+	require.NoError(t, err)
+	defer b.Close()
+	res, err := b.Get(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(res), "Segment still exists")
+}
+
+// TestOpenNewer tests that New does not overwrite an existing schema if it's
+// of a newer version.
+func TestOpenNewer(t *testing.T) {
+	conn := postgrestest.Connection(t)
+	b, err := New(conn)
+	require.NoError(t, err)
+	_, err = b.db.Exec("UPDATE SchemaVersions SET Version = $1 WHERE Name = $2",
+		SchemaVersion+1, SchemaName)
+	require.NoError(t, err)
+	b.Close()
+
+	b, err = New(conn)
+	assert.Error(t, err)
+	assert.Nil(t, b)
+}
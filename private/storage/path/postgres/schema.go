@@ -0,0 +1,73 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+const (
+	// SchemaName is the name under which the version of the schema is recorded.
+	SchemaName = "path"
+	// SchemaVersion is the version of the PostgreSQL schema understood by this backend.
+	// Whenever changes to the schema are made, this version number should be increased
+	// to prevent data corruption between incompatible database schemas.
+	SchemaVersion = 1
+	// Schema is the PostgreSQL database layout.
+	Schema = `CREATE TABLE Segments(
+		RowID BIGSERIAL PRIMARY KEY,
+		SegID BYTEA UNIQUE NOT NULL,
+		FullID BYTEA UNIQUE NOT NULL,
+		LastUpdated BIGINT NOT NULL,
+		InfoTs BIGINT NOT NULL,
+		Segment BYTEA NOT NULL,
+		MaxExpiry BIGINT NOT NULL,
+		StartIsdID INTEGER NOT NULL,
+		StartAsID BIGINT NOT NULL,
+		EndIsdID INTEGER NOT NULL,
+		EndAsID BIGINT NOT NULL
+	);
+	CREATE INDEX SegmentsStartIdx ON Segments(StartIsdID, StartAsID);
+	CREATE INDEX SegmentsEndIdx ON Segments(EndIsdID, EndAsID);
+	CREATE INDEX SegmentsExpiryIdx ON Segments(MaxExpiry);
+	CREATE TABLE IntfToSeg(
+		IsdID INTEGER NOT NULL,
+		AsID BIGINT NOT NULL,
+		IntfID BIGINT NOT NULL,
+		SegRowID BIGINT NOT NULL REFERENCES Segments(RowID) ON DELETE CASCADE
+	);
+	CREATE INDEX RowIdIndex ON IntfToSeg(SegRowID);
+	CREATE INDEX IntfIndex ON IntfToSeg(IsdID, AsID, IntfID);
+	CREATE TABLE SegTypes(
+		SegRowID BIGINT NOT NULL REFERENCES Segments(RowID) ON DELETE CASCADE,
+		Type INTEGER NOT NULL,
+		PRIMARY KEY (SegRowID, Type)
+	);
+	CREATE TABLE HPGroupIDs(
+		SegRowID BIGINT NOT NULL REFERENCES Segments(RowID) ON DELETE CASCADE,
+		GroupID BIGINT NOT NULL,
+		PRIMARY KEY (SegRowID, GroupID)
+	);
+	CREATE TABLE NextQuery(
+		RowID BIGSERIAL PRIMARY KEY,
+		SrcIsdID INTEGER NOT NULL,
+		SrcAsID BIGINT NOT NULL,
+		DstIsdID INTEGER NOT NULL,
+		DstAsID BIGINT NOT NULL,
+		NextQuery BIGINT NOT NULL,
+		UNIQUE(SrcIsdID, SrcAsID, DstIsdID, DstAsID)
+	);`
+	SegmentsTable   = "Segments"
+	IntfToSegTable  = "IntfToSeg"
+	SegTypesTable   = "SegTypes"
+	HPGroupIDsTable = "HPGroupIDs"
+	NextQueryTable  = "NextQuery"
+)
@@ -385,6 +385,28 @@ func (e *executor) DeleteExpired(ctx context.Context, now time.Time) (int, error
 	})
 }
 
+func (e *executor) DeleteUpdatedBefore(ctx context.Context, t time.Time) (int, error) {
+	return e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `DELETE FROM Segments WHERE LastUpdated < ?`
+		return tx.ExecContext(ctx, delStmt, t.UnixNano())
+	})
+}
+
+func (e *executor) TrimPerOrigin(ctx context.Context, maxSegments int) (int, error) {
+	return e.deleteInTx(ctx, func(tx *sql.Tx) (sql.Result, error) {
+		delStmt := `
+		DELETE FROM Segments WHERE RowID IN (
+			SELECT RowID FROM (
+				SELECT RowID, ROW_NUMBER() OVER (
+					PARTITION BY StartIsdID, StartAsID ORDER BY LastUpdated DESC, RowID DESC
+				) AS RowNumber FROM Segments
+			) WHERE RowNumber > ?
+		)
+		`
+		return tx.ExecContext(ctx, delStmt, maxSegments)
+	})
+}
+
 func (e *executor) deleteInTx(ctx context.Context,
 	delFunc func(tx *sql.Tx) (sql.Result, error)) (int, error) {
 
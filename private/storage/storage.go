@@ -22,23 +22,32 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/control/beacon"
+	"github.com/scionproto/scion/control/leader"
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/drkey"
 	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/private/config"
 	"github.com/scionproto/scion/private/pathdb"
 	"github.com/scionproto/scion/private/periodic"
 	"github.com/scionproto/scion/private/revcache"
 	"github.com/scionproto/scion/private/revcache/memrevcache"
 	beaconstorage "github.com/scionproto/scion/private/storage/beacon"
+	kvbeacondb "github.com/scionproto/scion/private/storage/beacon/kv"
+	postgresbeacondb "github.com/scionproto/scion/private/storage/beacon/postgres"
 	sqlitebeacondb "github.com/scionproto/scion/private/storage/beacon/sqlite"
 	"github.com/scionproto/scion/private/storage/cleaner"
 	"github.com/scionproto/scion/private/storage/db"
 	sqlitelevel1 "github.com/scionproto/scion/private/storage/drkey/level1/sqlite"
 	sqlitelevel2 "github.com/scionproto/scion/private/storage/drkey/level2/sqlite"
 	sqlitesecret "github.com/scionproto/scion/private/storage/drkey/secret/sqlite"
+	postgresleasedb "github.com/scionproto/scion/private/storage/lease/postgres"
+	pathstorage "github.com/scionproto/scion/private/storage/path"
+	postgrespathdb "github.com/scionproto/scion/private/storage/path/postgres"
 	sqlitepathdb "github.com/scionproto/scion/private/storage/path/sqlite"
 	truststorage "github.com/scionproto/scion/private/storage/trust"
+	postgrestrustdb "github.com/scionproto/scion/private/storage/trust/postgres"
 	sqlitetrustdb "github.com/scionproto/scion/private/storage/trust/sqlite"
 	"github.com/scionproto/scion/private/trust"
 )
@@ -49,6 +58,11 @@ type Backend string
 const (
 	// BackendSqlite indicates an sqlite backend.
 	BackendSqlite Backend = "sqlite"
+	// BackendPostgres indicates a PostgreSQL backend.
+	BackendPostgres Backend = "postgres"
+	// BackendKV indicates an embedded key-value store backend. It is only
+	// supported by the beacon database.
+	BackendKV Backend = "kv"
 	// DefaultPath indicates the default connection string for a generic database.
 	DefaultPath              = "/share/scion.db"
 	DefaultTrustDBPath       = "/share/data/%s.trust.db"
@@ -61,21 +75,27 @@ const (
 // Default samples for various databases.
 var (
 	SampleBeaconDB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: "/share/cache/%s.beacon.db",
 	}
 	SamplePathDB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: DefaultPathDBPath,
 	}
 	SampleTrustDB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: DefaultTrustDBPath,
 	}
 	SampleDRKeyLevel1DB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: DefaultDRKeyLevel1DBPath,
 	}
 	SampleDRKeyLevel2DB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: DefaultDRKeyLevel2DBPath,
 	}
 	SampleDRKeySecretValueDB = DBConfig{
+		Backend:    BackendSqlite,
 		Connection: DefaultDRKeySVDBPath,
 	}
 )
@@ -105,13 +125,24 @@ type PathDB interface {
 	pathdb.DB
 }
 
+// LeaseDB stores the leases of the leader election between service instances.
+type LeaseDB interface {
+	io.Closer
+	leader.DB
+}
+
 var _ (config.Config) = (*DBConfig)(nil)
 
 // DBConfig is the configuration for the connection to a database.
 type DBConfig struct {
-	Connection   string `toml:"connection,omitempty"`
-	MaxOpenConns int    `toml:"max_open_conns,omitempty"`
-	MaxIdleConns int    `toml:"max_idle_conns,omitempty"`
+	// Backend is the database backend. If empty, the sqlite backend is used.
+	Backend      Backend `toml:"backend,omitempty"`
+	Connection   string  `toml:"connection,omitempty"`
+	MaxOpenConns int     `toml:"max_open_conns,omitempty"`
+	MaxIdleConns int     `toml:"max_idle_conns,omitempty"`
+	// GC configures the garbage collection of the beacon and path databases. It is ignored by
+	// the other databases.
+	GC GCConfig `toml:"gc,omitempty"`
 }
 
 type writeDefault struct {
@@ -147,12 +178,33 @@ func (cfg *DBConfig) InitDefaults() {
 }
 
 func (cfg *DBConfig) Validate() error {
-	return nil
+	switch cfg.Backend {
+	case "", BackendSqlite, BackendPostgres, BackendKV:
+		return cfg.GC.Validate()
+	default:
+		return serrors.New("unknown database backend", "backend", cfg.Backend)
+	}
+}
+
+func (cfg *DBConfig) backend() Backend {
+	if cfg.Backend == "" {
+		return BackendSqlite
+	}
+	return cfg.Backend
+}
+
+// connection returns the connection string to be logged.
+func (cfg *DBConfig) connection() string {
+	if cfg.backend() == BackendPostgres {
+		return db.RedactConnection(cfg.Connection)
+	}
+	return cfg.Connection
 }
 
 // Sample writes a config sample to the writer.
 func (cfg *DBConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, sample)
+	config.WriteSample(dst, path, ctx, &cfg.GC)
 }
 
 // ConfigName is the key in the toml file.
@@ -160,88 +212,217 @@ func (cfg *DBConfig) ConfigName() string {
 	return "db"
 }
 
+var _ (config.Config) = (*GCConfig)(nil)
+
+// GCConfig configures the garbage collection of the beacon and path databases. Expired entries
+// are always deleted. The zero value of each option disables it.
+type GCConfig struct {
+	config.NoDefaulter
+	// MaxAge is the time after their last update after which entries are deleted, even if they
+	// have not expired yet.
+	MaxAge util.DurWrap `toml:"max_age,omitempty"`
+	// MaxEntriesPerOrigin is the maximum number of entries kept per origin AS. The least
+	// recently updated entries are deleted first.
+	MaxEntriesPerOrigin int `toml:"max_entries_per_origin,omitempty"`
+	// UnusableRetention is the time after their last update after which beacons that are not
+	// usable for any purpose are deleted. It is ignored by the path database.
+	UnusableRetention util.DurWrap `toml:"unusable_retention,omitempty"`
+}
+
+func (cfg *GCConfig) Validate() error {
+	if cfg.MaxAge.Duration < 0 {
+		return serrors.New("max_age must not be negative", "max_age", cfg.MaxAge)
+	}
+	if cfg.MaxEntriesPerOrigin < 0 {
+		return serrors.New("max_entries_per_origin must not be negative",
+			"max_entries_per_origin", cfg.MaxEntriesPerOrigin)
+	}
+	if cfg.UnusableRetention.Duration < 0 {
+		return serrors.New("unusable_retention must not be negative",
+			"unusable_retention", cfg.UnusableRetention)
+	}
+	return nil
+}
+
+// Sample writes a config sample to the writer.
+func (cfg *GCConfig) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, gcSample)
+}
+
+// ConfigName is the key in the toml file.
+func (cfg *GCConfig) ConfigName() string {
+	return "gc"
+}
+
 func NewBeaconStorage(c DBConfig, ia addr.IA) (BeaconDB, error) {
-	log.Info("Connecting BeaconDB", "backend", BackendSqlite, "connection", c.Connection)
-	db, err := sqlitebeacondb.New(c.Connection, ia)
+	log.Info("Connecting BeaconDB", "backend", c.backend(), "connection", c.connection())
+	var db interface {
+		BeaconDB
+		beaconstorage.Cleanable
+		db.LimitSetter
+	}
+	var err error
+	switch c.backend() {
+	case BackendPostgres:
+		db, err = postgresbeacondb.New(c.Connection, ia)
+	case BackendKV:
+		db, err = kvbeacondb.New(c.Connection, ia)
+	default:
+		db, err = sqlitebeacondb.New(c.Connection, ia)
+	}
 	if err != nil {
 		return nil, err
 	}
 	SetConnLimits(db, c)
 
-	// Start a periodic task that cleans up the expired beacons.
-	cleaner := periodic.Start(
-		cleaner.New(
+	// Start a periodic task that cleans up the expired beacons, and one per
+	// configured garbage collection policy.
+	cleaners := []*periodic.Runner{
+		startCleaner(
 			func(ctx context.Context) (int, error) {
 				return db.DeleteExpiredBeacons(ctx, time.Now())
 			},
 			"control_beaconstorage_cleaner",
 		),
-		30*time.Second,
-		30*time.Second,
-	)
+	}
+	if maxAge := c.GC.MaxAge.Duration; maxAge > 0 {
+		cleaners = append(cleaners, startCleaner(
+			func(ctx context.Context) (int, error) {
+				return db.DeleteBeaconsUpdatedBefore(ctx, time.Now().Add(-maxAge))
+			},
+			"control_beaconstorage_max_age",
+		))
+	}
+	if maxEntries := c.GC.MaxEntriesPerOrigin; maxEntries > 0 {
+		cleaners = append(cleaners, startCleaner(
+			func(ctx context.Context) (int, error) {
+				return db.TrimBeaconsPerOrigin(ctx, maxEntries)
+			},
+			"control_beaconstorage_max_entries",
+		))
+	}
+	if retention := c.GC.UnusableRetention.Duration; retention > 0 {
+		cleaners = append(cleaners, startCleaner(
+			func(ctx context.Context) (int, error) {
+				return db.DeleteUnusableBeaconsUpdatedBefore(ctx, time.Now().Add(-retention))
+			},
+			"control_beaconstorage_unusable",
+		))
+	}
 	return beaconDBWithCleaner{
 		BeaconDB: db,
-		cleaner:  cleaner,
+		cleaners: cleaners,
 	}, nil
 }
 
 // beaconDBWithCleaner implements the BeaconDB interface and stops both the
-// database and the cleanup task on Close.
+// database and the cleanup tasks on Close.
 type beaconDBWithCleaner struct {
 	BeaconDB
-	cleaner *periodic.Runner
+	cleaners []*periodic.Runner
 }
 
 func (b beaconDBWithCleaner) Close() error {
-	b.cleaner.Kill()
+	for _, c := range b.cleaners {
+		c.Kill()
+	}
 	return b.BeaconDB.Close()
 }
 
 func NewPathStorage(c DBConfig) (PathDB, error) {
-	log.Info("Connecting PathDB", "backend", BackendSqlite, "connection", c.Connection)
-	db, err := sqlitepathdb.New(c.Connection)
+	log.Info("Connecting PathDB", "backend", c.backend(), "connection", c.connection())
+	var db interface {
+		PathDB
+		pathstorage.Cleanable
+		db.LimitSetter
+	}
+	var err error
+	switch c.backend() {
+	case BackendPostgres:
+		db, err = postgrespathdb.New(c.Connection)
+	case BackendKV:
+		return nil, serrors.New("backend not supported for path databases",
+			"backend", c.backend())
+	default:
+		db, err = sqlitepathdb.New(c.Connection)
+	}
 	if err != nil {
 		return nil, err
 	}
 	SetConnLimits(db, c)
 
-	// Start a periodic task that cleans up the expired path segments.
-	cleaner := periodic.Start(
-		cleaner.New(
+	// Start a periodic task that cleans up the expired path segments, and one
+	// per configured garbage collection policy.
+	cleaners := []*periodic.Runner{
+		startCleaner(
 			func(ctx context.Context) (int, error) {
 				return db.DeleteExpired(ctx, time.Now())
 			},
 			"control_pathstorage_cleaner",
 		),
-		30*time.Second,
-		30*time.Second,
-	)
+	}
+	if maxAge := c.GC.MaxAge.Duration; maxAge > 0 {
+		cleaners = append(cleaners, startCleaner(
+			func(ctx context.Context) (int, error) {
+				return db.DeleteUpdatedBefore(ctx, time.Now().Add(-maxAge))
+			},
+			"control_pathstorage_max_age",
+		))
+	}
+	if maxEntries := c.GC.MaxEntriesPerOrigin; maxEntries > 0 {
+		cleaners = append(cleaners, startCleaner(
+			func(ctx context.Context) (int, error) {
+				return db.TrimPerOrigin(ctx, maxEntries)
+			},
+			"control_pathstorage_max_entries",
+		))
+	}
 	return pathDBWithCleaner{
 		DB:       db,
-		cleaner:  cleaner,
+		cleaners: cleaners,
 		dbCloser: db,
 	}, nil
 }
 
 // pathDBWithCleaner implements the path DB interface and stops both the
-// database and the cleanup task on Close.
+// database and the cleanup tasks on Close.
 type pathDBWithCleaner struct {
 	pathdb.DB
-	cleaner  *periodic.Runner
+	cleaners []*periodic.Runner
 	dbCloser io.Closer
 }
 
 func (b pathDBWithCleaner) Close() error {
-	b.cleaner.Kill()
+	for _, c := range b.cleaners {
+		c.Kill()
+	}
 	return b.dbCloser.Close()
 }
 
+// startCleaner starts a periodic task that deletes entries using deleter. The
+// metrics of the task are published in the given namespace.
+func startCleaner(deleter cleaner.ExpiredDeleter, namespace string) *periodic.Runner {
+	return periodic.Start(cleaner.New(deleter, namespace), 30*time.Second, 30*time.Second)
+}
+
 func NewRevocationStorage() revcache.RevCache {
 	return memrevcache.New()
 }
 
 func NewTrustStorage(c DBConfig) (TrustDB, error) {
-	log.Info("Connecting TrustDB", "backend", BackendSqlite, "connection", c.Connection)
+	log.Info("Connecting TrustDB", "backend", c.backend(), "connection", c.connection())
+	if c.backend() == BackendKV {
+		return nil, serrors.New("backend not supported for trust databases",
+			"backend", c.backend())
+	}
+	if c.backend() == BackendPostgres {
+		db, err := postgrestrustdb.New(c.Connection)
+		if err != nil {
+			return nil, err
+		}
+		SetConnLimits(db, c)
+		return db, nil
+	}
 	db, err := sqlitetrustdb.New(c.Connection)
 	if err != nil {
 		return nil, err
@@ -250,8 +431,29 @@ func NewTrustStorage(c DBConfig) (TrustDB, error) {
 	return db, nil
 }
 
+// NewLeaseStorage returns the database for the leader election between the instances of a
+// service. Only the postgres backend can be shared between instances, so it is the only one
+// supported.
+func NewLeaseStorage(c DBConfig) (LeaseDB, error) {
+	log.Info("Connecting LeaseDB", "backend", c.backend(), "connection", c.connection())
+	if c.backend() != BackendPostgres {
+		return nil, serrors.New("backend not supported for leader election",
+			"backend", c.backend())
+	}
+	db, err := postgresleasedb.New(c.Connection)
+	if err != nil {
+		return nil, err
+	}
+	SetConnLimits(db, c)
+	return db, nil
+}
+
 func NewDRKeySecretValueStorage(c DBConfig) (drkey.SecretValueDB, error) {
 	log.Info("Connecting DRKeySecretValueDB", "	", BackendSqlite, "connection", c.Connection)
+	if c.backend() != BackendSqlite {
+		return nil, serrors.New("backend not supported for DRKey databases",
+			"backend", c.backend())
+	}
 	db, err := sqlitesecret.NewBackend(c.Connection)
 	if err != nil {
 		return nil, err
@@ -262,6 +464,10 @@ func NewDRKeySecretValueStorage(c DBConfig) (drkey.SecretValueDB, error) {
 
 func NewDRKeyLevel1Storage(c DBConfig) (drkey.Level1DB, error) {
 	log.Info("Connecting DRKeyLevel1DB", "	", BackendSqlite, "connection", c.Connection)
+	if c.backend() != BackendSqlite {
+		return nil, serrors.New("backend not supported for DRKey databases",
+			"backend", c.backend())
+	}
 	db, err := sqlitelevel1.NewBackend(c.Connection)
 	if err != nil {
 		return nil, err
@@ -272,6 +478,10 @@ func NewDRKeyLevel1Storage(c DBConfig) (drkey.Level1DB, error) {
 
 func NewDRKeyLevel2Storage(c DBConfig) (drkey.Level2DB, error) {
 	log.Info("Connecting DRKeyDB", "	", BackendSqlite, "connection", c.Connection)
+	if c.backend() != BackendSqlite {
+		return nil, serrors.New("backend not supported for DRKey databases",
+			"backend", c.backend())
+	}
 	db, err := sqlitelevel2.NewBackend(c.Connection)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,297 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postgres implements the trust DB on PostgreSQL.
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/storage/db"
+	truststorage "github.com/scionproto/scion/private/storage/trust"
+	"github.com/scionproto/scion/private/trust"
+)
+
+// DB implements the trust DB with a PostgreSQL backend.
+type DB struct {
+	db *sql.DB
+}
+
+// New returns a new PostgreSQL backend connecting to the database with the given connection
+// string. If the schema is not set up in the database yet, it is created. If the version of the
+// existing schema is different from the one in schema.go, an error is returned.
+func New(conn string) (DB, error) {
+	db, err := db.NewPostgres(conn, SchemaName, Schema, SchemaVersion)
+	if err != nil {
+		return DB{}, err
+	}
+	return DB{db: db}, nil
+}
+
+// SetMaxOpenConns sets the maximum number of open connections.
+func (d DB) SetMaxOpenConns(maxOpenConns int) {
+	d.db.SetMaxOpenConns(maxOpenConns)
+}
+
+// SetMaxIdleConns sets the maximum number of idle connections.
+func (d DB) SetMaxIdleConns(maxIdleConns int) {
+	d.db.SetMaxIdleConns(maxIdleConns)
+}
+
+// Close closes the database.
+func (d DB) Close() error {
+	return d.db.Close()
+}
+
+func (d DB) SignedTRC(ctx context.Context, id cppki.TRCID) (cppki.SignedTRC, error) {
+	if id.Base.IsLatest() != id.Serial.IsLatest() {
+		return cppki.SignedTRC{}, serrors.New("unsupported TRC ID for query", "id", id)
+	}
+
+	sqlQuery := `SELECT trc FROM trcs WHERE isd_id=$1
+				ORDER BY base DESC, serial DESC
+				LIMIT 1`
+	args := []any{id.ISD}
+	if !id.Base.IsLatest() {
+		sqlQuery = `SELECT trc FROM trcs WHERE isd_id=$1 AND base=$2 AND serial=$3`
+		args = append(args, id.Base, id.Serial)
+	}
+	r := d.db.QueryRowContext(ctx, sqlQuery, args...)
+	var rawTRC []byte
+	err := r.Scan(&rawTRC)
+	if err == sql.ErrNoRows {
+		return cppki.SignedTRC{}, nil
+	}
+	if err != nil {
+		return cppki.SignedTRC{}, serrors.JoinNoStack(db.ErrReadFailed, err)
+	}
+	trc, err := cppki.DecodeSignedTRC(rawTRC)
+	if err != nil {
+		return cppki.SignedTRC{}, serrors.JoinNoStack(db.ErrDataInvalid, err)
+	}
+	return trc, nil
+}
+
+func (d DB) InsertTRC(ctx context.Context, trc cppki.SignedTRC) (bool, error) {
+	insert := `INSERT INTO trcs (isd_id, base, serial, fingerprint, trc)
+			   VALUES ($1, $2, $3, $4, $5)
+			   ON CONFLICT DO NOTHING`
+	existing := `SELECT fingerprint FROM trcs WHERE isd_id=$1 AND base=$2 AND serial=$3`
+	fingerprint := trcFingerprint(trc)
+	var inserted bool
+	err := db.DoInTx(ctx, d.db, func(ctx context.Context, tx *sql.Tx) error {
+		r, err := tx.ExecContext(ctx, insert,
+			trc.TRC.ID.ISD,
+			trc.TRC.ID.Base,
+			trc.TRC.ID.Serial,
+			fingerprint,
+			trc.Raw,
+		)
+		if err != nil {
+			return serrors.JoinNoStack(db.ErrWriteFailed, err)
+		}
+		ar, err := r.RowsAffected()
+		if err != nil {
+			return serrors.JoinNoStack(db.ErrWriteFailed, err)
+		}
+		if inserted = ar > 0; inserted {
+			return nil
+		}
+		// A TRC with the same ID exists, it must be the same one.
+		var stored []byte
+		err = tx.QueryRowContext(ctx, existing, trc.TRC.ID.ISD, trc.TRC.ID.Base,
+			trc.TRC.ID.Serial).Scan(&stored)
+		if err != nil {
+			return serrors.JoinNoStack(db.ErrReadFailed, err)
+		}
+		if !bytes.Equal(stored, fingerprint) {
+			return serrors.JoinNoStack(db.ErrWriteFailed, nil,
+				"msg", "conflicting TRC with the same ID", "id", trc.TRC.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return inserted, nil
+}
+
+func (d DB) Chains(ctx context.Context,
+	query trust.ChainQuery) ([][]*x509.Certificate, error) {
+
+	sqlQuery := []string{"SELECT as_cert, ca_cert FROM chains"}
+	var args []any
+	var filters []string
+
+	if len(query.SubjectKeyID) != 0 {
+		args = append(args, query.SubjectKeyID)
+		filters = append(filters, fmt.Sprintf("key_id=$%d", len(args)))
+	}
+	if !query.Validity.IsZero() {
+		args = append(args, query.Validity.NotBefore.UTC())
+		args = append(args, query.Validity.NotAfter.UTC())
+		filters = append(filters, fmt.Sprintf("not_before<=$%d AND not_after>=$%d",
+			len(args)-1, len(args)))
+	}
+	if query.IA.ISD() != 0 {
+		args = append(args, query.IA.ISD())
+		filters = append(filters, fmt.Sprintf("isd_id=$%d", len(args)))
+	}
+	if query.IA.AS() != 0 {
+		args = append(args, query.IA.AS())
+		filters = append(filters, fmt.Sprintf("as_id=$%d", len(args)))
+	}
+	if len(filters) != 0 {
+		sqlQuery = append(sqlQuery, "WHERE")
+	}
+	sqlQuery = append(sqlQuery, strings.Join(filters, " AND "))
+	rows, err := d.db.QueryContext(ctx, strings.Join(sqlQuery, "\n"), args...)
+	if err != nil {
+		return nil, serrors.JoinNoStack(db.ErrReadFailed, err)
+	}
+	defer rows.Close()
+	var chains [][]*x509.Certificate
+	for rows.Next() {
+		var rawAS, rawCA []byte
+		if err := rows.Scan(&rawAS, &rawCA); err != nil {
+			return nil, serrors.JoinNoStack(db.ErrReadFailed, err)
+		}
+		as, err := x509.ParseCertificate(rawAS)
+		if err != nil {
+			return nil, serrors.JoinNoStack(db.ErrDataInvalid, err)
+		}
+		ca, err := x509.ParseCertificate(rawCA)
+		if err != nil {
+			return nil, serrors.JoinNoStack(db.ErrDataInvalid, err)
+		}
+		chains = append(chains, []*x509.Certificate{as, ca})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, serrors.JoinNoStack(db.ErrReadFailed, err)
+	}
+	return chains, nil
+}
+
+func (d DB) Chain(ctx context.Context,
+	chainID []byte) ([]*x509.Certificate, error) {
+
+	sqlQuery := "SELECT as_cert, ca_cert FROM chains WHERE chain_fingerprint=$1"
+	r := d.db.QueryRowContext(ctx, sqlQuery, chainID)
+	var rawAS, rawCA []byte
+	if err := r.Scan(&rawAS, &rawCA); err != nil {
+		return nil, serrors.JoinNoStack(db.ErrReadFailed, err)
+	}
+	as, err := x509.ParseCertificate(rawAS)
+	if err != nil {
+		return nil, serrors.JoinNoStack(db.ErrDataInvalid, err)
+	}
+	ca, err := x509.ParseCertificate(rawCA)
+	if err != nil {
+		return nil, serrors.JoinNoStack(db.ErrDataInvalid, err)
+	}
+	return []*x509.Certificate{as, ca}, nil
+}
+
+func (d DB) InsertChain(ctx context.Context, chain []*x509.Certificate) (bool, error) {
+	if len(chain) != 2 {
+		return false, serrors.JoinNoStack(db.ErrInvalidInputData, nil,
+			"msg", "invalid chain length",
+			"expected", 2, "actual", len(chain))
+
+	}
+	ia, err := cppki.ExtractIA(chain[0].Subject)
+	if err != nil {
+		return false, serrors.JoinNoStack(db.ErrInvalidInputData, err,
+			"msg", "invalid AS cert, invalid ISD-AS")
+
+	}
+	query := `INSERT INTO chains (isd_id, as_id, key_id, not_before, not_after,
+								  chain_fingerprint, as_cert, ca_cert)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  ON CONFLICT DO NOTHING`
+	r, err := d.db.ExecContext(ctx, query, ia.ISD(), ia.AS(), chain[0].SubjectKeyId,
+		chain[0].NotBefore.UTC(), chain[0].NotAfter.UTC(),
+		truststorage.ChainID(chain), chain[0].Raw, chain[1].Raw)
+	if err != nil {
+		return false, serrors.JoinNoStack(db.ErrWriteFailed, err)
+	}
+	ar, err := r.RowsAffected()
+	if err != nil {
+		return false, serrors.JoinNoStack(db.ErrWriteFailed, err)
+	}
+	return ar > 0, nil
+}
+
+// SignedTRCs returns the TRC from each ISD in the trust database according to the query.
+func (d DB) SignedTRCs(ctx context.Context,
+	query truststorage.TRCsQuery) (cppki.SignedTRCs, error) {
+
+	var filter string
+	var args []any
+	if len(query.ISD) > 0 {
+		subQ := make([]string, 0, len(query.ISD))
+		for _, ISD := range query.ISD {
+			subQ = append(subQ, fmt.Sprintf("isd_id=$%d", len(args)+1))
+			args = append(args, uint16(ISD))
+		}
+		filter = fmt.Sprintf("WHERE (%s)", strings.Join(subQ, " OR "))
+	}
+
+	var sqlQuery string
+	if !query.Latest {
+		sqlQuery = fmt.Sprintf("SELECT trc FROM trcs %s", filter)
+	} else {
+		sqlQuery = fmt.Sprintf(`
+			SELECT DISTINCT ON (isd_id) trc
+			FROM trcs
+			%s
+			ORDER BY isd_id, base DESC, serial DESC
+		`, filter)
+	}
+	rows, err := d.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, serrors.JoinNoStack(db.ErrReadFailed, err)
+	}
+	defer rows.Close()
+	var res cppki.SignedTRCs
+	for rows.Next() {
+		var rawTRC []byte
+		if err := rows.Scan(&rawTRC); err != nil {
+			return nil, serrors.JoinNoStack(db.ErrReadFailed, err)
+		}
+		curRes, err := cppki.DecodeSignedTRC(rawTRC)
+		if err != nil {
+			return nil, serrors.JoinNoStack(db.ErrDataInvalid, err)
+		}
+		res = append(res, curRes)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func trcFingerprint(trc cppki.SignedTRC) []byte {
+	h := sha256.New()
+	h.Write(trc.TRC.Raw)
+	return h.Sum(nil)
+}
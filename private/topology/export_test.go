@@ -16,7 +16,8 @@
 package topology
 
 var (
-	RawBRIntfLocalAddr = rawBRIntfLocalAddr
+	RawBRIntfLocalAddr    = rawBRIntfLocalAddr
+	CheckUnderlayFamilies = checkUnderlayFamilies
 )
 
 // SetFile allows to change the file for testing. This is helpful because we
@@ -0,0 +1,56 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// UnderlayFamily is the IP address family of underlay addresses.
+type UnderlayFamily string
+
+const (
+	// AnyFamily matches the addresses of both families.
+	AnyFamily UnderlayFamily = ""
+	// IPv4 matches IPv4 addresses, including IPv4-mapped IPv6 addresses.
+	IPv4 UnderlayFamily = "ipv4"
+	// IPv6 matches IPv6 addresses that are not IPv4-mapped.
+	IPv6 UnderlayFamily = "ipv6"
+)
+
+// ParseUnderlayFamily parses an underlay family. The matching is case-insensitive.
+func ParseUnderlayFamily(s string) (UnderlayFamily, error) {
+	switch f := UnderlayFamily(strings.ToLower(s)); f {
+	case AnyFamily, IPv4, IPv6:
+		return f, nil
+	default:
+		return AnyFamily, serrors.New("invalid underlay family", "family", s)
+	}
+}
+
+// Contains returns whether the address is of the family.
+func (f UnderlayFamily) Contains(a netip.Addr) bool {
+	switch f {
+	case IPv4:
+		return a.Unmap().Is4()
+	case IPv6:
+		return a.Is6() && !a.Is4In6()
+	default:
+		return true
+	}
+}
@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
@@ -56,6 +58,10 @@ type LoaderCfg struct {
 	File string
 	// Reload is the channel on which reloads can be triggered.
 	Reload <-chan struct{}
+	// WatchInterval is the interval at which the file is checked for changes. The topology is
+	// reloaded when the modification time or the size of the file changed. If zero, the
+	// topology is only reloaded when triggered on the Reload channel.
+	WatchInterval time.Duration
 	// Validator is used to validate topology updates. If this field is not set,
 	// update is permissible. If the validation is error a reload is discarded
 	Validator Validator
@@ -73,6 +79,11 @@ type Loader struct {
 	mtx         sync.Mutex
 	subscribers map[*Subscription]chan struct{}
 	topo        Topology
+
+	// modTime and size are the modification time and the size of the file when it was last
+	// loaded. They are only accessed by the reloads, which are never concurrent.
+	modTime time.Time
+	size    int64
 }
 
 // NewLoader creates a topology loader from the given configuration. This method
@@ -90,17 +101,23 @@ func NewLoader(cfg LoaderCfg) (*Loader, error) {
 }
 
 // Run runs the topology reloader. It makes sure that the topology is reloaded
-// when the configured signal channel is filled. A topology that can't be parsed
-// or doesn't validate will be ignored.
+// when the configured signal channel is filled, or when the file changes if a
+// watch interval is configured. A topology that can't be parsed or doesn't
+// validate will be ignored.
 func (l *Loader) Run(ctx context.Context) error {
+	var watch <-chan time.Time
+	if l.cfg.WatchInterval > 0 {
+		ticker := time.NewTicker(l.cfg.WatchInterval)
+		defer ticker.Stop()
+		watch = ticker.C
+	}
 	for {
 		select {
 		case <-l.cfg.Reload:
-			if err := l.reload(); err != nil {
-				log.FromCtx(ctx).Error("Failed to reload topology file",
-					"file", l.cfg.File, "err", err)
-			} else {
-				log.FromCtx(ctx).Info("Reloaded topology")
+			l.reloadAndLog(ctx)
+		case <-watch:
+			if l.fileChanged() {
+				l.reloadAndLog(ctx)
 			}
 		case <-ctx.Done():
 			return nil
@@ -108,6 +125,26 @@ func (l *Loader) Run(ctx context.Context) error {
 	}
 }
 
+func (l *Loader) reloadAndLog(ctx context.Context) {
+	if err := l.reload(); err != nil {
+		log.FromCtx(ctx).Error("Failed to reload topology file",
+			"file", l.cfg.File, "err", err)
+	} else {
+		log.FromCtx(ctx).Info("Reloaded topology")
+	}
+}
+
+// fileChanged returns whether the modification time or the size of the file changed since it
+// was last loaded. A missing file does not count as changed, the current topology is kept until
+// the file is back.
+func (l *Loader) fileChanged() bool {
+	info, err := os.Stat(l.cfg.File)
+	if err != nil {
+		return false
+	}
+	return !info.ModTime().Equal(l.modTime) || info.Size() != l.size
+}
+
 func (l *Loader) IA() addr.IA {
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
@@ -283,6 +320,11 @@ func (l *Loader) notifyAllLocked() {
 }
 
 func (l *Loader) reload() error {
+	// The file is stat'ed before it is read, so that a change that happens while it is read
+	// triggers another reload. A file that fails to load is not loaded again until it changes.
+	if info, err := os.Stat(l.cfg.File); err == nil {
+		l.modTime, l.size = info.ModTime(), info.Size()
+	}
 	newTopo, err := l.load()
 	if err != nil {
 		metrics.CounterInc(l.cfg.Metrics.ReadErrors)
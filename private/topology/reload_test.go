@@ -198,6 +198,43 @@ func TestLoader(t *testing.T) {
 		testBasicTopo(t, l)
 		assert.Equal(t, xtest.MustParseUDPAddr(t, "10.0.0.1:42"), l.UnderlayNextHop(11))
 	})
+	t.Run("changed file is reloaded when watched", func(t *testing.T) {
+		file := modifiedTopo(t, func(topo *jsontopo.Topology) {})
+		l, err := topology.NewLoader(topology.LoaderCfg{
+			File:          file,
+			WatchInterval: 10 * time.Millisecond,
+		})
+		assert.NoError(t, err)
+		ctx, cancelF := context.WithCancel(context.Background())
+		t.Cleanup(cancelF)
+		g, errCtx := errgroup.WithContext(ctx)
+		g.Go(func() error {
+			return l.Run(errCtx)
+		})
+		sub := l.Subscribe()
+		defer sub.Close()
+
+		modified := modifiedTopo(t, func(topo *jsontopo.Topology) {
+			topo.BorderRouters["br1-ff00:0:311-2"].InternalAddr = "10.0.0.1:42"
+		})
+		raw, err := os.ReadFile(modified)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(file, raw, 0644))
+		// Make sure the modification time differs, even on file systems with a coarse
+		// resolution.
+		mtime := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(file, mtime, mtime))
+		xtest.AssertReadReturnsBefore(t, sub.Updates, time.Second)
+		testBasicTopo(t, l)
+		assert.Equal(t, xtest.MustParseUDPAddr(t, "10.0.0.1:42"), l.UnderlayNextHop(11))
+
+		// An unchanged file is not reloaded.
+		select {
+		case <-sub.Updates:
+			t.Fatal("unchanged file reloaded")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
 	t.Run("test subscription", func(t *testing.T) {
 		reloadCh := make(chan struct{})
 		l, err := topology.NewLoader(topology.LoaderCfg{
@@ -22,6 +22,7 @@ import (
 	"net"
 	"net/netip"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -113,11 +114,29 @@ type (
 		InternalAddr netip.AddrPort
 		Local        netip.AddrPort
 		Remote       netip.AddrPort
-		RemoteIfID   iface.ID
-		IA           addr.IA
-		LinkType     LinkType
-		MTU          int
-		BFD          BFD
+		// AdditionalUnderlays are further pairs of local and remote underlay addresses for the
+		// link. The border router spreads the traffic of the link across all of them.
+		AdditionalUnderlays []Underlay
+		// PreferredFamily is the address family of the underlays that carry the traffic of the
+		// link as long as one of them is up. If AnyFamily, all the underlays are used.
+		PreferredFamily UnderlayFamily
+		RemoteIfID      iface.ID
+		IA              addr.IA
+		LinkType        LinkType
+		MTU             int
+		BFD             BFD
+		// SharedWith is the name of the other border router that serves the interface, if the
+		// interface is shared by two border routers. Each of them has its own underlay to the
+		// neighbor, and the traffic of the interface is shared between them by flow. The
+		// IFInfoMap contains the entry of the border router whose name comes first, to which
+		// the traffic of the interface is sent within the AS.
+		SharedWith string
+	}
+
+	// Underlay is a pair of local and remote underlay addresses of a link.
+	Underlay struct {
+		Local  netip.AddrPort
+		Remote netip.AddrPort
 	}
 
 	// IDAddrMap maps process IDs to their topology addresses.
@@ -256,6 +275,10 @@ func validatePortRange(portRange string) (uint16, uint16, error) {
 }
 
 func (t *RWTopology) populateBR(raw *jsontopo.Topology) error {
+	shared, err := sharedInterfaces(raw)
+	if err != nil {
+		return err
+	}
 	for name, rawBr := range raw.BorderRouters {
 		if rawBr.InternalAddr == "" {
 			return serrors.New("Missing Internal Address", "br", name)
@@ -271,8 +294,9 @@ func (t *RWTopology) populateBR(raw *jsontopo.Topology) error {
 		}
 		for ifID, rawIntf := range rawBr.Interfaces {
 			var err error
-			// Check that ifID is unique
-			if _, ok := t.IFInfoMap[ifID]; ok {
+			// Check that ifID is unique, unless it is shared by two border routers.
+			sharing := shared[ifID]
+			if _, ok := t.IFInfoMap[ifID]; (ok || len(sharing) != 0) && !rawIntf.Shared {
 				return serrors.New("IfID already exists", "ID", ifID)
 			}
 			brInfo.IfIDs = append(brInfo.IfIDs, ifID)
@@ -282,6 +306,20 @@ func (t *RWTopology) populateBR(raw *jsontopo.Topology) error {
 				InternalAddr: intAddr,
 				MTU:          rawIntf.MTU,
 			}
+			if rawIntf.Shared {
+				ifinfo.SharedWith = sharing[0]
+				if ifinfo.SharedWith == name {
+					ifinfo.SharedWith = sharing[1]
+				}
+			}
+			// addIntf records the interface. Of a shared interface, the IFInfoMap keeps the
+			// entry of the first border router.
+			addIntf := func() {
+				brInfo.IFs[ifID] = &ifinfo
+				if !rawIntf.Shared || sharing[0] == name {
+					t.IFInfoMap[ifID] = ifinfo
+				}
+			}
 			if ifinfo.IA, err = addr.ParseIA(rawIntf.IA); err != nil {
 				return err
 			}
@@ -305,8 +343,7 @@ func (t *RWTopology) populateBR(raw *jsontopo.Topology) error {
 			// These fields are only necessary for the border router.
 			// Parsing should not fail if all fields are empty.
 			if rawIntf.Underlay == (jsontopo.Underlay{}) {
-				brInfo.IFs[ifID] = &ifinfo
-				t.IFInfoMap[ifID] = ifinfo
+				addIntf()
 				continue
 			}
 			if ifinfo.Local, err = rawBRIntfLocalAddr(&rawIntf.Underlay); err != nil {
@@ -319,14 +356,80 @@ func (t *RWTopology) populateBR(raw *jsontopo.Topology) error {
 					"underlay external data-plane remote address", err)
 
 			}
-			brInfo.IFs[ifID] = &ifinfo
-			t.IFInfoMap[ifID] = ifinfo
+			if err := checkUnderlayFamilies(ifinfo.Local, ifinfo.Remote); err != nil {
+				return serrors.Wrap("invalid underlay", err, "if_id", ifID)
+			}
+			if ifinfo.PreferredFamily, err = ParseUnderlayFamily(
+				rawIntf.PreferredFamily); err != nil {
+
+				return serrors.Wrap("invalid preferred underlay family", err, "if_id", ifID)
+			}
+			for i := range rawIntf.AdditionalUnderlays {
+				var u Underlay
+				if u.Local, err = rawBRIntfLocalAddr(&rawIntf.AdditionalUnderlays[i]); err != nil {
+					return serrors.Wrap("unable to extract additional "+
+						"underlay external data-plane local address", err, "index", i)
+				}
+				u.Remote, err = resolveAddrPort(rawIntf.AdditionalUnderlays[i].Remote)
+				if err != nil {
+					return serrors.Wrap("unable to extract additional "+
+						"underlay external data-plane remote address", err, "index", i)
+				}
+				if err := checkUnderlayFamilies(u.Local, u.Remote); err != nil {
+					return serrors.Wrap("invalid additional underlay", err,
+						"if_id", ifID, "index", i)
+				}
+				if u.Remote == ifinfo.Remote || slices.ContainsFunc(ifinfo.AdditionalUnderlays,
+					func(o Underlay) bool { return o.Remote == u.Remote }) {
+
+					return serrors.New("duplicate underlay remote address",
+						"if_id", ifID, "remote", u.Remote)
+				}
+				ifinfo.AdditionalUnderlays = append(ifinfo.AdditionalUnderlays, u)
+			}
+			addIntf()
 		}
 		sort.Slice(brInfo.IfIDs, func(i, j int) bool {
 			return brInfo.IfIDs[i] < brInfo.IfIDs[j]
 		})
 		t.BR[name] = brInfo
 	}
+	return t.checkSharedInterfaces(shared)
+}
+
+// sharedInterfaces returns the names of the two border routers that share each of the shared
+// interfaces, in increasing order.
+func sharedInterfaces(raw *jsontopo.Topology) (map[iface.ID][]string, error) {
+	shared := make(map[iface.ID][]string)
+	for name, rawBr := range raw.BorderRouters {
+		for ifID, rawIntf := range rawBr.Interfaces {
+			if rawIntf.Shared {
+				shared[ifID] = append(shared[ifID], name)
+			}
+		}
+	}
+	for ifID, names := range shared {
+		if len(names) != 2 {
+			return nil, serrors.New("shared interface must be configured on two border routers",
+				"ID", ifID, "border_routers", names)
+		}
+		slices.Sort(names)
+	}
+	return shared, nil
+}
+
+// checkSharedInterfaces checks that the two border routers that share an interface agree on
+// the link to the neighbor.
+func (t *RWTopology) checkSharedInterfaces(shared map[iface.ID][]string) error {
+	for ifID, names := range shared {
+		a, b := t.BR[names[0]].IFs[ifID], t.BR[names[1]].IFs[ifID]
+		if a.IA != b.IA || a.LinkType != b.LinkType || a.MTU != b.MTU ||
+			a.RemoteIfID != b.RemoteIfID {
+
+			return serrors.New("border routers sharing an interface disagree on the link",
+				"ID", ifID, "border_routers", names)
+		}
+	}
 	return nil
 }
 
@@ -589,6 +692,20 @@ func (m IDAddrMap) copy() IDAddrMap {
 	return newM
 }
 
+// checkUnderlayFamilies checks that the local and remote addresses of an underlay are of the same
+// address family. A local address without an IP, which binds to all addresses, matches either.
+func checkUnderlayFamilies(local, remote netip.AddrPort) error {
+	l, r := local.Addr(), remote.Addr()
+	if !l.IsValid() || l.IsUnspecified() {
+		return nil
+	}
+	if l.Unmap().Is4() != r.Unmap().Is4() {
+		return serrors.New("local and remote underlay addresses are of different families",
+			"local", local, "remote", remote)
+	}
+	return nil
+}
+
 // CheckLinks checks whether the link types are compatible with whether the AS is core or not.
 func (i IFInfo) CheckLinks(isCore bool, brName string) error {
 	if isCore {
@@ -290,9 +290,20 @@ func TestIFInfoMapCoreAS(t *testing.T) {
 			InternalAddr: netip.MustParseAddrPort("10.1.0.1:0"),
 			Local:        netip.MustParseAddrPort("192.0.2.1:4997"),
 			Remote:       netip.MustParseAddrPort("192.0.2.2:4998"),
-			IA:           addr.MustParseIA("6-ff00:0:363"),
-			LinkType:     Core,
-			MTU:          1472,
+			AdditionalUnderlays: []Underlay{
+				{
+					Local:  netip.MustParseAddrPort("192.0.2.5:4997"),
+					Remote: netip.MustParseAddrPort("192.0.2.6:4998"),
+				},
+				{
+					Local:  netip.MustParseAddrPort("[2001:db8:a0b:12f0::5]:4997"),
+					Remote: netip.MustParseAddrPort("[2001:db8:a0b:12f0::6]:4998"),
+				},
+			},
+			PreferredFamily: IPv6,
+			IA:              addr.MustParseIA("6-ff00:0:363"),
+			LinkType:        Core,
+			MTU:             1472,
 		},
 		32: IFInfo{
 			ID:           32,
@@ -337,6 +348,63 @@ func TestCopy(t *testing.T) {
 	assert.Equal(t, topo, newTopo)
 }
 
+func TestSharedInterface(t *testing.T) {
+	intf := func(local, remote string, shared bool) *jsontopo.BRInterface {
+		return &jsontopo.BRInterface{
+			Underlay: jsontopo.Underlay{Local: local, Remote: remote},
+			IA:       "1-ff00:0:312",
+			LinkTo:   "PARENT",
+			MTU:      1472,
+			Shared:   shared,
+		}
+	}
+	raw := func(b1, b2 *jsontopo.BRInterface) *jsontopo.Topology {
+		return &jsontopo.Topology{
+			IA:               "1-ff00:0:311",
+			MTU:              1472,
+			EndhostPortRange: "1024-65535",
+			BorderRouters: map[string]*jsontopo.BRInfo{
+				"br1": {
+					InternalAddr: "10.1.0.1:30042",
+					Interfaces:   map[iface.ID]*jsontopo.BRInterface{1: b1},
+				},
+				"br2": {
+					InternalAddr: "10.1.0.2:30042",
+					Interfaces:   map[iface.ID]*jsontopo.BRInterface{1: b2},
+				},
+			},
+		}
+	}
+
+	t.Run("shared by two routers", func(t *testing.T) {
+		topo, err := RWTopologyFromJSONTopology(raw(
+			intf("192.0.2.1:50000", "192.0.2.2:50000", true),
+			intf("192.0.2.3:50000", "192.0.2.4:50000", true),
+		))
+		require.NoError(t, err)
+		br1, br2 := topo.BR["br1"].IFs[1], topo.BR["br2"].IFs[1]
+		assert.Equal(t, "br2", br1.SharedWith)
+		assert.Equal(t, netip.MustParseAddrPort("192.0.2.2:50000"), br1.Remote)
+		assert.Equal(t, "br1", br2.SharedWith)
+		assert.Equal(t, netip.MustParseAddrPort("192.0.2.4:50000"), br2.Remote)
+		assert.Equal(t, *br1, topo.IFInfoMap[1])
+	})
+	t.Run("not shared by both", func(t *testing.T) {
+		_, err := RWTopologyFromJSONTopology(raw(
+			intf("192.0.2.1:50000", "192.0.2.2:50000", true),
+			intf("192.0.2.3:50000", "192.0.2.4:50000", false),
+		))
+		assert.Error(t, err)
+	})
+	t.Run("different links", func(t *testing.T) {
+		b2 := intf("192.0.2.3:50000", "192.0.2.4:50000", true)
+		b2.MTU = 1280
+		_, err := RWTopologyFromJSONTopology(raw(
+			intf("192.0.2.1:50000", "192.0.2.2:50000", true), b2))
+		assert.Error(t, err)
+	})
+}
+
 func TestExternalDataPlanePort(t *testing.T) {
 	testCases := []struct {
 		Name            string
@@ -465,6 +533,51 @@ func TestExternalDataPlanePort(t *testing.T) {
 	}
 }
 
+func TestCheckUnderlayFamilies(t *testing.T) {
+	testCases := map[string]struct {
+		Local, Remote string
+		ExpectedError assert.ErrorAssertionFunc
+	}{
+		"IPv4":                 {"192.0.2.1:4997", "192.0.2.2:4998", assert.NoError},
+		"IPv6":                 {"[2001:db8::1]:4997", "[2001:db8::2]:4998", assert.NoError},
+		"IPv4-mapped and IPv4": {"[::ffff:192.0.2.1]:4997", "192.0.2.2:4998", assert.NoError},
+		"port only":            {":4997", "[2001:db8::2]:4998", assert.NoError},
+		"unspecified":          {"0.0.0.0:4997", "[2001:db8::2]:4998", assert.NoError},
+		"IPv4 and IPv6":        {"192.0.2.1:4997", "[2001:db8::2]:4998", assert.Error},
+		"IPv6 and IPv4":        {"[2001:db8::1]:4997", "192.0.2.2:4998", assert.Error},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var local netip.AddrPort
+			if tc.Local[0] == ':' {
+				local = netip.AddrPortFrom(netip.Addr{}, 4997)
+			} else {
+				local = netip.MustParseAddrPort(tc.Local)
+			}
+			remote := netip.MustParseAddrPort(tc.Remote)
+			tc.ExpectedError(t, CheckUnderlayFamilies(local, remote))
+		})
+	}
+}
+
+func TestUnderlayFamily(t *testing.T) {
+	f, err := ParseUnderlayFamily("IPv6")
+	require.NoError(t, err)
+	assert.Equal(t, IPv6, f)
+	_, err = ParseUnderlayFamily("ipx")
+	assert.Error(t, err)
+
+	v4, v6 := netip.MustParseAddr("192.0.2.1"), netip.MustParseAddr("2001:db8::1")
+	mapped := netip.MustParseAddr("::ffff:192.0.2.1")
+	assert.True(t, IPv4.Contains(v4))
+	assert.True(t, IPv4.Contains(mapped))
+	assert.False(t, IPv4.Contains(v6))
+	assert.True(t, IPv6.Contains(v6))
+	assert.False(t, IPv6.Contains(mapped))
+	assert.True(t, AnyFamily.Contains(v4))
+	assert.True(t, AnyFamily.Contains(v6))
+}
+
 func TestRawAddrMap_ToTopoAddr(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -28,7 +28,10 @@ const defaultExpiration = time.Minute
 
 type Config struct {
 	config.NoValidator
-	Cache Cache `toml:"cache"`
+	// GracePeriodRefusals is the file in which the refused predecessor TRCs are persisted. If
+	// empty, refusals are kept in memory only.
+	GracePeriodRefusals string `toml:"grace_period_refusals,omitempty"`
+	Cache               Cache  `toml:"cache"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -38,6 +41,12 @@ func (cfg *Config) InitDefaults() {
 }
 
 func (cfg *Config) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, `
+# The file in which the predecessor TRCs that are refused during the grace
+# period of their successor are persisted. If empty, the refusals are kept in
+# memory only. (default "")
+grace_period_refusals = ""
+`)
 	config.WriteSample(dst, path, ctx,
 		&cfg.Cache,
 	)
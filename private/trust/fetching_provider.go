@@ -53,6 +53,9 @@ type FetchingProvider struct {
 	Recurser Recurser
 	Fetcher  Fetcher
 	Router   Router
+	// GracePeriod decides whether the predecessor TRCs are accepted during the grace period.
+	// If it is nil, they are accepted during the whole grace period.
+	GracePeriod *GracePeriod
 }
 
 // GetChains returns certificate chains that match the chain query. If no chain
@@ -111,8 +114,9 @@ func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 		setProviderMetric(span, l.WithResult(result), err)
 		return nil, serrors.Wrap("fetching active TRCs from database", err)
 	}
+	trcs, refused := p.GracePeriod.split(trcs)
 
-	chains = filterVerifiableChains(chains, trcs)
+	chains = filterVerifiableChains(ctx, chains, trcs, refused)
 	if len(chains) > 0 {
 		setProviderMetric(span, l.WithResult(metrics.Success), nil)
 		return chains, nil
@@ -136,7 +140,7 @@ func (p FetchingProvider) GetChains(ctx context.Context, query ChainQuery,
 	}
 
 	// For simplicity, we ignore non-verifiable chains.
-	chains = filterVerifiableChains(chains, trcs)
+	chains = filterVerifiableChains(ctx, chains, trcs, refused)
 	if len(chains) > 0 {
 		// FIXME(roosd): Should probably be a transaction.
 		for _, chain := range chains {
@@ -268,17 +272,13 @@ func activeTRCs(ctx context.Context, db DB, isd addr.ISD) ([]cppki.SignedTRC, st
 	return []cppki.SignedTRC{trc, grace}, metrics.Success, nil
 }
 
-func filterVerifiableChains(chains [][]*x509.Certificate,
-	trcs []cppki.SignedTRC) [][]*x509.Certificate {
+func filterVerifiableChains(ctx context.Context, chains [][]*x509.Certificate,
+	trcs, refused []cppki.SignedTRC) [][]*x509.Certificate {
 
 	verified := make([][]*x509.Certificate, 0, len(chains))
 	for _, chain := range chains {
-		for _, trc := range trcs {
-			verifyOptions := cppki.VerifyOptions{TRC: []*cppki.TRC{&trc.TRC}}
-			if err := cppki.VerifyChain(chain, verifyOptions); err == nil {
-				verified = append(verified, chain)
-				break
-			}
+		if err := verifyChainGrace(ctx, chain, trcs, refused); err == nil {
+			verified = append(verified, chain)
 		}
 	}
 	return verified
@@ -0,0 +1,212 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/trust/internal/metrics"
+)
+
+// GracePeriod decides whether the predecessor of the latest TRC of an ISD is accepted during
+// the grace period of the latest TRC. Operators can refuse the predecessor before the grace
+// period ends, e.g., when its root keys are compromised. The refusals are persisted in a file,
+// such that they survive a restart. It is safe for concurrent use. A nil GracePeriod accepts
+// the predecessor TRCs during the whole grace period.
+type GracePeriod struct {
+	path string
+
+	mu      sync.RWMutex
+	refused map[cppki.TRCID]struct{}
+}
+
+// NewGracePeriod creates a grace period policy. The refusals are loaded from and persisted in
+// the file at the given path. If the path is empty, the refusals are not persisted.
+func NewGracePeriod(path string) (*GracePeriod, error) {
+	g := &GracePeriod{
+		path:    path,
+		refused: make(map[cppki.TRCID]struct{}),
+	}
+	if path == "" {
+		return g, nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return g, nil
+	}
+	if err != nil {
+		return nil, serrors.Wrap("reading grace period refusals", err, "file", path)
+	}
+	var file gracePeriodFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, serrors.Wrap("parsing grace period refusals", err, "file", path)
+	}
+	for _, rawID := range file.Refused {
+		id, err := cppki.TRCIDFromString(rawID)
+		if err != nil {
+			return nil, serrors.Wrap("parsing TRC ID", err, "file", path)
+		}
+		g.refused[id] = struct{}{}
+	}
+	return g, nil
+}
+
+// Refuse refuses the TRC as predecessor during the grace period of its successor, and
+// persists the refusal.
+func (g *GracePeriod) Refuse(id cppki.TRCID) error {
+	if err := id.Validate(); err != nil {
+		return serrors.Wrap("validating TRC ID", err)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.refused[id]; ok {
+		return nil
+	}
+	g.refused[id] = struct{}{}
+	if err := g.persist(); err != nil {
+		delete(g.refused, id)
+		return err
+	}
+	return nil
+}
+
+// Accept withdraws the refusal of the TRC, such that it is accepted as predecessor again until
+// the grace period of its successor ends.
+func (g *GracePeriod) Accept(id cppki.TRCID) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.refused[id]; !ok {
+		return nil
+	}
+	delete(g.refused, id)
+	if err := g.persist(); err != nil {
+		g.refused[id] = struct{}{}
+		return err
+	}
+	return nil
+}
+
+// Refused indicates whether the TRC is refused as predecessor.
+func (g *GracePeriod) Refused(id cppki.TRCID) bool {
+	if g == nil {
+		return false
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	_, ok := g.refused[id]
+	return ok
+}
+
+// Refusals returns the refused TRCs, sorted by ISD, base and serial number.
+func (g *GracePeriod) Refusals() []cppki.TRCID {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	ids := make([]cppki.TRCID, 0, len(g.refused))
+	for id := range g.refused {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].ISD != ids[j].ISD {
+			return ids[i].ISD < ids[j].ISD
+		}
+		if ids[i].Base != ids[j].Base {
+			return ids[i].Base < ids[j].Base
+		}
+		return ids[i].Serial < ids[j].Serial
+	})
+	return ids
+}
+
+// split splits the active TRCs into the TRCs that are accepted and the refused predecessor.
+func (g *GracePeriod) split(trcs []cppki.SignedTRC) ([]cppki.SignedTRC, []cppki.SignedTRC) {
+	if len(trcs) < 2 || !g.Refused(trcs[1].TRC.ID) {
+		return trcs, nil
+	}
+	return trcs[:1], trcs[1:]
+}
+
+// persist writes the refusals to the file. The caller must hold the lock.
+func (g *GracePeriod) persist() error {
+	if g.path == "" {
+		return nil
+	}
+	file := gracePeriodFile{Refused: make([]string, 0, len(g.refused))}
+	for id := range g.refused {
+		file.Refused = append(file.Refused, id.String())
+	}
+	sort.Strings(file.Refused)
+	raw, err := json.MarshalIndent(file, "", "    ")
+	if err != nil {
+		return serrors.Wrap("marshaling grace period refusals", err)
+	}
+	// Write the file atomically, such that it is never loaded partially.
+	tmp := g.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0644); err != nil {
+		return serrors.Wrap("writing grace period refusals", err, "file", g.path)
+	}
+	if err := os.Rename(tmp, g.path); err != nil {
+		return serrors.Wrap("writing grace period refusals", err, "file", g.path)
+	}
+	return nil
+}
+
+type gracePeriodFile struct {
+	Refused []string `json:"refused"`
+}
+
+// verifyChainGrace verifies the chain against the accepted TRCs. If the chain is only
+// verifiable against the predecessor TRC during the grace period, or only against the refused
+// predecessor TRC, this is logged and counted.
+func verifyChainGrace(ctx context.Context, chain []*x509.Certificate,
+	trcs, refused []cppki.SignedTRC) error {
+
+	var errs serrors.List
+	for i, trc := range trcs {
+		verifyOptions := cppki.VerifyOptions{TRC: []*cppki.TRC{&trc.TRC}}
+		if err := cppki.VerifyChain(chain, verifyOptions); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if i > 0 {
+			l := metrics.GraceLabels{ISD: trc.TRC.ID.ISD, Result: metrics.OkGrace}
+			metrics.Grace.Verify(l).Inc()
+			log.FromCtx(ctx).Debug("Certificate chain verified with predecessor TRC",
+				"subject", chain[0].Subject, "trc", trc.TRC.ID, "latest", trcs[0].TRC.ID)
+		}
+		return nil
+	}
+	for _, trc := range refused {
+		verifyOptions := cppki.VerifyOptions{TRC: []*cppki.TRC{&trc.TRC}}
+		if err := cppki.VerifyChain(chain, verifyOptions); err != nil {
+			continue
+		}
+		l := metrics.GraceLabels{ISD: trc.TRC.ID.ISD, Result: metrics.ErrRefused}
+		metrics.Grace.Verify(l).Inc()
+		log.FromCtx(ctx).Info("Certificate chain only verifiable with refused predecessor TRC",
+			"subject", chain[0].Subject, "trc", trc.TRC.ID)
+		errs = append(errs, serrors.New("predecessor TRC refused", "trc", trc.TRC.ID))
+	}
+	return errs.ToError()
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trust_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/pkg/scrypto/cppki"
+	"github.com/scionproto/scion/private/trust"
+	"github.com/scionproto/scion/private/trust/mock_trust"
+)
+
+func TestGracePeriod(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "refusals.json")
+	s1 := cppki.TRCID{ISD: 1, Base: 1, Serial: 1}
+	s2 := cppki.TRCID{ISD: 2, Base: 1, Serial: 2}
+
+	g, err := trust.NewGracePeriod(file)
+	require.NoError(t, err)
+	assert.Empty(t, g.Refusals())
+	assert.Error(t, g.Refuse(cppki.TRCID{ISD: 1}))
+
+	require.NoError(t, g.Refuse(s2))
+	require.NoError(t, g.Refuse(s1))
+	assert.True(t, g.Refused(s1))
+	assert.Equal(t, []cppki.TRCID{s1, s2}, g.Refusals())
+
+	// The refusals are persisted.
+	reloaded, err := trust.NewGracePeriod(file)
+	require.NoError(t, err)
+	assert.Equal(t, []cppki.TRCID{s1, s2}, reloaded.Refusals())
+
+	require.NoError(t, reloaded.Accept(s1))
+	assert.False(t, reloaded.Refused(s1))
+	reloaded, err = trust.NewGracePeriod(file)
+	require.NoError(t, err)
+	assert.Equal(t, []cppki.TRCID{s2}, reloaded.Refusals())
+
+	// A nil grace period accepts all TRCs.
+	var nilGrace *trust.GracePeriod
+	assert.False(t, nilGrace.Refused(s1))
+}
+
+func TestGracePeriodVerification(t *testing.T) {
+	dir := genCrypto(t)
+	trc := xtest.LoadTRC(t, filepath.Join(dir, "ISD1/trcs/ISD1-B1-S1.trc"))
+	chain := xtest.LoadChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_110.pem"))
+	rawChain := loadRawChain(t, filepath.Join(dir, "certs/ISD1-ASff00_0_110.pem"))
+
+	// The latest TRC is in its grace period and has new root keys, such that the chain is only
+	// verifiable with its predecessor.
+	latest := xtest.LoadTRC(t, filepath.Join(dir, "ISD1/trcs/ISD1-B1-S1.trc"))
+	latest.TRC.ID.Serial = 2
+	latest.TRC.Validity.NotBefore = time.Now().Add(-time.Minute)
+	latest.TRC.GracePeriod = time.Hour
+	roots, err := latest.TRC.RootCerts()
+	require.NoError(t, err)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	for _, root := range roots {
+		root.PublicKey = key.Public()
+	}
+	newDB := func(ctrl *gomock.Controller) trust.DB {
+		db := mock_trust.NewMockDB(ctrl)
+		db.EXPECT().Chains(gomock.Any(), gomock.Any()).Return(
+			[][]*x509.Certificate{chain}, nil).AnyTimes()
+		db.EXPECT().SignedTRC(gomock.Any(), cppki.TRCID{ISD: 1}).Return(
+			latest, nil).AnyTimes()
+		db.EXPECT().SignedTRC(gomock.Any(), trc.TRC.ID).Return(trc, nil).AnyTimes()
+		return db
+	}
+	query := trust.ChainQuery{
+		IA:           addr.MustParseIA("1-ff00:0:110"),
+		Validity:     cppki.Validity{NotBefore: time.Now(), NotAfter: time.Now()},
+		SubjectKeyID: chain[0].SubjectKeyId,
+	}
+
+	testCases := map[string]struct {
+		refused   bool
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"predecessor accepted": {
+			assertErr: assert.NoError,
+		},
+		"predecessor refused": {
+			refused:   true,
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			grace, err := trust.NewGracePeriod("")
+			require.NoError(t, err)
+			if tc.refused {
+				require.NoError(t, grace.Refuse(trc.TRC.ID))
+			}
+			recurser := mock_trust.NewMockRecurser(ctrl)
+			recurser.EXPECT().AllowRecursion(gomock.Any()).Return(
+				serrors.New("not allowed")).AnyTimes()
+			p := trust.FetchingProvider{
+				DB:          newDB(ctrl),
+				Recurser:    recurser,
+				GracePeriod: grace,
+			}
+			_, err = p.GetChains(context.Background(), query)
+			tc.assertErr(t, err)
+
+			v := trust.TLSCryptoVerifier{
+				DB:          newDB(ctrl),
+				Timeout:     5 * time.Second,
+				GracePeriod: grace,
+			}
+			tc.assertErr(t, v.VerifyServerCertificate(rawChain, nil))
+		})
+	}
+}
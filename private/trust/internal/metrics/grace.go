@@ -0,0 +1,63 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/prom"
+)
+
+// GraceLabels defines the labels of the certificate chain verifications that depend on the
+// predecessor TRC during the grace period of the latest TRC.
+type GraceLabels struct {
+	ISD    addr.ISD
+	Result string
+}
+
+// Labels returns the list of labels.
+func (l GraceLabels) Labels() []string {
+	return []string{"isd", prom.LabelResult}
+}
+
+// Values returns the label values in the order defined by Labels.
+func (l GraceLabels) Values() []string {
+	return []string{l.ISD.String(), l.Result}
+}
+
+// WithResult returns the lookup labels with the modified result.
+func (l GraceLabels) WithResult(result string) GraceLabels {
+	l.Result = result
+	return l
+}
+
+type grace struct {
+	verifications prometheus.CounterVec
+}
+
+func newGrace() grace {
+	return grace{
+		verifications: *prom.NewCounterVecWithLabels(Namespace, "",
+			"grace_period_verifications_total",
+			"Number of certificate chain verifications that fell back to the predecessor TRC "+
+				"during the grace period, or failed because the predecessor TRC is refused",
+			GraceLabels{}),
+	}
+}
+
+func (g *grace) Verify(l GraceLabels) prometheus.Counter {
+	return g.verifications.WithLabelValues(l.Values()...)
+}
@@ -52,6 +52,9 @@ const (
 	OkExists   = "ok_exists"
 	OkInserted = "ok_inserted"
 	OkIgnored  = "ok_ignored"
+	// OkGrace indicates that a chain was verified with the predecessor TRC during the grace
+	// period of the latest TRC.
+	OkGrace = "ok_grace"
 
 	ErrMismatch   = "err_content_mismatch"
 	ErrDB         = prom.ErrDB
@@ -61,6 +64,7 @@ const (
 	ErrNotAllowed = "err_not_allowed"
 	ErrNotFound   = "err_not_found"
 	ErrParse      = prom.ErrParse
+	ErrRefused    = "err_refused"
 	ErrTransmit   = "err_transmit"
 	ErrValidate   = prom.ErrValidate
 	ErrVerify     = prom.ErrVerify
@@ -75,6 +79,8 @@ var (
 	Signer = newSigner()
 	// Verifier exposes the verifier metrics.
 	Verifier = newVerifier()
+	// Grace exposes the grace period metrics.
+	Grace = newGrace()
 )
 
 // PeerToLabel converts the peer address to a peer metric label.
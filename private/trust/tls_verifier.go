@@ -32,6 +32,9 @@ const defaultTimeout = 5 * time.Second
 type TLSCryptoVerifier struct {
 	DB      DB
 	Timeout time.Duration
+	// GracePeriod decides whether the predecessor TRCs are accepted during the grace period.
+	// If it is nil, they are accepted during the whole grace period.
+	GracePeriod *GracePeriod
 }
 
 // NewTLSCryptoVerifier returns a new instance with the defaultTimeout.
@@ -134,25 +137,13 @@ func (v *TLSCryptoVerifier) verifyParsedPeerCertificate(
 	if err != nil {
 		return 0, serrors.Wrap("loading TRCs", err)
 	}
-	if err := verifyChain(chain, trcs); err != nil {
+	trcs, refused := v.GracePeriod.split(trcs)
+	if err := verifyChainGrace(ctx, chain, trcs, refused); err != nil {
 		return 0, serrors.Wrap("verifying chains", err)
 	}
 	return ia, nil
 }
 
-func verifyChain(chain []*x509.Certificate, trcs []cppki.SignedTRC) error {
-	var errs serrors.List
-	for _, trc := range trcs {
-		verifyOptions := cppki.VerifyOptions{TRC: []*cppki.TRC{&trc.TRC}}
-		if err := cppki.VerifyChain(chain, verifyOptions); err != nil {
-			errs = append(errs, err)
-			continue
-		}
-		return nil
-	}
-	return errs.ToError()
-}
-
 // verifyExtendedKeyUsage return an error if the certifcate extended key usages do not
 // include any requested extended key usage.
 func verifyExtendedKeyUsage(cert *x509.Certificate, expectedKeyUsage x509.ExtKeyUsage) error {
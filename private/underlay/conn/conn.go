@@ -58,6 +58,21 @@ type Config struct {
 	// ReceiveBufferSize is the size of the operating system receive buffer, in
 	// bytes.
 	ReceiveBufferSize int
+	// UDPOffload enables UDP generic segmentation offload on send and generic receive offload
+	// on receive, where the operating system supports them. Otherwise, it has no effect.
+	UDPOffload bool
+	// PathMTUDiscovery sets the don't fragment flag of the datagrams sent over a connected
+	// socket, so that the operating system discovers the path MTU to the remote address, as
+	// returned by PathMTU. Only supported on Linux.
+	PathMTUDiscovery bool
+	// BusyPoll is the time during which the operating system busy polls the network device when
+	// a read finds no datagram. If zero, the operating system default is used. Only supported on
+	// Linux.
+	BusyPoll time.Duration
+	// TrafficClass is the TOS byte of the IPv4 header, or the traffic class of the IPv6 header,
+	// of the datagrams sent, unless they set one of their own. If zero, the operating system
+	// default is used. Only supported on Linux.
+	TrafficClass uint8
 }
 
 // New opens a new underlay socket on the specified addresses.
@@ -77,9 +92,17 @@ func New(listen, remote netip.AddrPort, cfg *Config) (Conn, error) {
 	return newConnUDPIPv6(listen, remote, cfg)
 }
 
+// batchConn is the batch I/O of ipv4.PacketConn and ipv6.PacketConn.
+type batchConn interface {
+	ReadBatch(ms []ipv4.Message, flags int) (int, error)
+	WriteBatch(ms []ipv4.Message, flags int) (int, error)
+}
+
 type connUDPIPv4 struct {
 	connUDPBase
 	pconn *ipv4.PacketConn
+	// rconn is the batch I/O that datagrams are read with.
+	rconn batchConn
 }
 
 func newConnUDPIPv4(listen, remote netip.AddrPort, cfg *Config) (*connUDPIPv4, error) {
@@ -88,17 +111,25 @@ func newConnUDPIPv4(listen, remote netip.AddrPort, cfg *Config) (*connUDPIPv4, e
 		return nil, err
 	}
 	cc.pconn = ipv4.NewPacketConn(cc.conn)
+	cc.rconn = newMmsgConn(cc.conn, cc.pconn)
 	return cc, nil
 }
 
-// ReadBatch reads up to len(msgs) packets, and stores them in msgs.
+// ReadBatch reads up to len(msgs) packets, and stores them in msgs. The source address of a
+// packet is stored in the *net.UDPAddr of its message, if it has one.
 // It returns the number of packets read, and an error if any.
 func (c *connUDPIPv4) ReadBatch(msgs Messages) (int, error) {
-	n, err := c.pconn.ReadBatch(msgs, syscallMSG_WAITFORONE)
+	if c.offload != nil {
+		return c.offload.readBatch(c.rconn, msgs, syscallMSG_WAITFORONE)
+	}
+	n, err := c.rconn.ReadBatch(msgs, syscallMSG_WAITFORONE)
 	return n, err
 }
 
 func (c *connUDPIPv4) WriteBatch(msgs Messages, flags int) (int, error) {
+	if c.offload != nil {
+		return c.offload.writeBatch(c.pconn, msgs, flags)
+	}
 	return c.pconn.WriteBatch(msgs, flags)
 }
 
@@ -118,6 +149,8 @@ func (c *connUDPIPv4) SetDeadline(t time.Time) error {
 type connUDPIPv6 struct {
 	connUDPBase
 	pconn *ipv6.PacketConn
+	// rconn is the batch I/O that datagrams are read with.
+	rconn batchConn
 }
 
 func newConnUDPIPv6(listen, remote netip.AddrPort, cfg *Config) (*connUDPIPv6, error) {
@@ -126,17 +159,25 @@ func newConnUDPIPv6(listen, remote netip.AddrPort, cfg *Config) (*connUDPIPv6, e
 		return nil, err
 	}
 	cc.pconn = ipv6.NewPacketConn(cc.conn)
+	cc.rconn = newMmsgConn(cc.conn, cc.pconn)
 	return cc, nil
 }
 
-// ReadBatch reads up to len(msgs) packets, and stores them in msgs.
+// ReadBatch reads up to len(msgs) packets, and stores them in msgs. The source address of a
+// packet is stored in the *net.UDPAddr of its message, if it has one.
 // It returns the number of packets read, and an error if any.
 func (c *connUDPIPv6) ReadBatch(msgs Messages) (int, error) {
-	n, err := c.pconn.ReadBatch(msgs, syscallMSG_WAITFORONE)
+	if c.offload != nil {
+		return c.offload.readBatch(c.rconn, msgs, syscallMSG_WAITFORONE)
+	}
+	n, err := c.rconn.ReadBatch(msgs, syscallMSG_WAITFORONE)
 	return n, err
 }
 
 func (c *connUDPIPv6) WriteBatch(msgs Messages, flags int) (int, error) {
+	if c.offload != nil {
+		return c.offload.writeBatch(c.pconn, msgs, flags)
+	}
 	return c.pconn.WriteBatch(msgs, flags)
 }
 
@@ -158,6 +199,11 @@ type connUDPBase struct {
 	Listen netip.AddrPort
 	Remote netip.AddrPort
 	closed bool
+	ipv6   bool
+	// offload is nil if UDP segmentation offload is not used.
+	offload *udpOffload
+	// pmtud indicates whether the path MTU to the remote address is discovered.
+	pmtud bool
 }
 
 func (cc *connUDPBase) initConnUDP(
@@ -255,9 +301,44 @@ func (cc *connUDPBase) initConnUDP(
 		}
 	}
 
+	if cfg.UDPOffload {
+		cc.offload = newUDPOffload(c)
+		gso, gro := cc.offload.enabled()
+		log.Debug("UDP segmentation offload", "listen", laddr, "remote", raddr,
+			"gso", gso, "gro", gro)
+	}
+
+	ipv6 := network == "udp6"
+	if cfg.BusyPoll != 0 {
+		if err := setBusyPoll(c, cfg.BusyPoll); err != nil {
+			return serrors.Wrap("Error setting busy poll", err,
+				"listen", laddr,
+				"remote", raddr,
+			)
+		}
+	}
+	if cfg.TrafficClass != 0 {
+		if err := setTrafficClass(c, ipv6, cfg.TrafficClass); err != nil {
+			return serrors.Wrap("Error setting traffic class", err,
+				"listen", laddr,
+				"remote", raddr,
+			)
+		}
+	}
+	if cfg.PathMTUDiscovery && raddr.IsValid() {
+		if err := setPathMTUDiscovery(c, ipv6); err != nil {
+			return serrors.Wrap("Error enabling path MTU discovery", err,
+				"listen", laddr,
+				"remote", raddr,
+			)
+		}
+		cc.pmtud = true
+	}
+
 	cc.conn = c
 	cc.Listen = laddr
 	cc.Remote = raddr
+	cc.ipv6 = ipv6
 	return nil
 }
 
@@ -272,6 +353,45 @@ func (c *connUDPBase) WriteTo(b []byte, dst netip.AddrPort) (int, error) {
 	return c.conn.WriteToUDPAddrPort(b, dst)
 }
 
+// PathMTU returns the largest datagram payload that can currently be sent to the remote address
+// without fragmentation, as discovered by the operating system. It returns an error unless path
+// MTU discovery is enabled for the connection.
+func (c *connUDPBase) PathMTU() (int, error) {
+	if !c.pmtud {
+		return 0, serrors.New("path MTU discovery not enabled")
+	}
+	return pathMTU(c.conn, c.ipv6)
+}
+
+// IncomingCPU returns the CPU on which the operating system processed the last datagram received
+// on the connection. With receive side scaling, this is the CPU that serves the receive queue of
+// the network device that the datagrams of the connection are steered to.
+func (c *connUDPBase) IncomingCPU() (int, error) {
+	return incomingCPU(c.conn)
+}
+
+// ReceiveDrops returns the number of datagrams that the operating system dropped because the
+// receive buffer of the connection was full. Only supported on Linux.
+func (c *connUDPBase) ReceiveDrops() (uint64, error) {
+	return receiveDrops(c.conn, c.ipv6)
+}
+
+// ReceiveBufferSize returns the size of the operating system receive buffer, in bytes.
+func (c *connUDPBase) ReceiveBufferSize() (int, error) {
+	size, err := sockctrl.GetsockoptInt(c.conn, syscall.SOL_SOCKET, syscall.SO_RCVBUF)
+	// Note: the kernel reports the doubled value of the size that was set.
+	return size / 2, err
+}
+
+// SetReceiveBufferSize changes the size of the operating system receive buffer, and returns the
+// size that is in effect, which the operating system may cap.
+func (c *connUDPBase) SetReceiveBufferSize(size int) (int, error) {
+	if err := c.conn.SetReadBuffer(size); err != nil {
+		return 0, err
+	}
+	return c.ReceiveBufferSize()
+}
+
 func (c *connUDPBase) LocalAddr() netip.AddrPort {
 	return c.Listen
 }
@@ -298,3 +418,9 @@ func NewReadMessages(n int) Messages {
 	}
 	return m
 }
+
+// Truncated returns whether the datagram read into the message was larger than its buffer, and
+// was cut short.
+func Truncated(m *ipv4.Message) bool {
+	return m.Flags&syscallMSG_TRUNC != 0
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package conn
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/private/underlay/sockctrl"
+)
+
+// incomingCPU returns the CPU on which the kernel processed the last datagram received on the
+// socket.
+func incomingCPU(c *net.UDPConn) (int, error) {
+	return sockctrl.GetsockoptInt(c, unix.SOL_SOCKET, unix.SO_INCOMING_CPU)
+}
@@ -17,4 +17,7 @@
 
 package conn
 
-const syscallMSG_WAITFORONE = 0
+const (
+	syscallMSG_WAITFORONE = 0
+	syscallMSG_TRUNC      = 0
+)
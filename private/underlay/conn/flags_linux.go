@@ -19,4 +19,7 @@ package conn
 
 import "syscall"
 
-const syscallMSG_WAITFORONE = syscall.MSG_WAITFORONE
+const (
+	syscallMSG_WAITFORONE = syscall.MSG_WAITFORONE
+	syscallMSG_TRUNC      = syscall.MSG_TRUNC
+)
@@ -0,0 +1,306 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package conn
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync/atomic"
+	"unsafe"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/private/underlay/sockctrl"
+)
+
+const (
+	// maxGSOSegments is the largest number of segments the kernel accepts in a single
+	// UDP_SEGMENT send (UDP_MAX_SEGMENTS in older kernels).
+	maxGSOSegments = 64
+	// maxGSOBytes bounds the payload of a single UDP_SEGMENT send, so that it fits in one
+	// UDP datagram.
+	maxGSOBytes = 65000
+	// groBufSize is the size of the buffers that coalesced datagrams are read into. It holds the
+	// largest datagram that GRO can produce.
+	groBufSize = 1 << 16
+	// maxGROBuffers bounds the number of GRO buffers of a socket, and with it their memory.
+	maxGROBuffers = 64
+	// maxCoalescedOOB is the largest control data of messages that can still be coalesced.
+	maxCoalescedOOB = 64
+)
+
+// udpOffload implements UDP generic segmentation offload (GSO) on send and generic receive
+// offload (GRO) on receive for a socket.
+//
+// With GSO, consecutive messages of a batch that go to the same destination and have the same
+// size and control data are sent as a single large datagram which the kernel, or the network
+// device, splits. With GRO, the kernel coalesces the datagrams of a flow into a single large
+// datagram, which is split again here. Both reduce the per-packet cost of the system calls and
+// of the network stack, which is significant for the mostly point-to-point flows between border
+// routers.
+type udpOffload struct {
+	gso atomic.Bool
+	gro bool
+
+	// Scratch space of writeBatch.
+	wmsgs   Messages
+	wcounts []int
+	wbufs   [][]byte
+	woob    [][]byte
+
+	// Scratch space of readBatch. The segments of rmsgs[rnext:rcount], starting at offset roff
+	// of rmsgs[rnext], have not been returned yet.
+	rmsgs  Messages
+	rsegs  []int
+	rnext  int
+	rcount int
+	roff   int
+}
+
+// newUDPOffload enables the segmentation offloads that the kernel supports on the socket. It
+// returns nil if it supports none.
+func newUDPOffload(c *net.UDPConn) *udpOffload {
+	o := &udpOffload{}
+	if _, err := sockctrl.GetsockoptInt(c, unix.SOL_UDP, unix.UDP_SEGMENT); err == nil {
+		o.gso.Store(true)
+	}
+	if err := sockctrl.SetsockoptInt(c, unix.SOL_UDP, unix.UDP_GRO, 1); err == nil {
+		o.gro = true
+	}
+	if !o.gso.Load() && !o.gro {
+		return nil
+	}
+	return o
+}
+
+// enabled returns whether GSO and GRO are in use.
+func (o *udpOffload) enabled() (gso, gro bool) {
+	if o == nil {
+		return false, false
+	}
+	return o.gso.Load(), o.gro
+}
+
+// readBatch reads up to len(msgs) packets from c, and stores them in msgs. The packets of the
+// datagrams coalesced by GRO are copied into msgs one by one; those that do not fit are returned
+// by the next call.
+func (o *udpOffload) readBatch(c batchConn, msgs Messages, flags int) (int, error) {
+	if !o.gro {
+		return c.ReadBatch(msgs, flags)
+	}
+	if o.rnext == o.rcount {
+		if o.rmsgs == nil {
+			o.initRead(min(len(msgs), maxGROBuffers))
+		}
+		n, err := c.ReadBatch(o.rmsgs[:min(len(msgs), len(o.rmsgs))], flags)
+		if err != nil {
+			return 0, err
+		}
+		for i := range o.rmsgs[:n] {
+			o.rsegs[i] = groSegmentSize(&o.rmsgs[i])
+		}
+		o.rnext, o.rcount, o.roff = 0, n, 0
+	}
+	n := 0
+	for n < len(msgs) && o.rnext < o.rcount {
+		src := &o.rmsgs[o.rnext]
+		end := min(o.roff+o.rsegs[o.rnext], src.N)
+		dst := &msgs[n]
+		dst.N = copy(dst.Buffers[0], src.Buffers[0][o.roff:end])
+		dst.NN = 0
+		dst.Flags = 0
+		if dst.N < end-o.roff {
+			dst.Flags = syscallMSG_TRUNC
+		}
+		dst.Addr = copyAddr(dst.Addr, src.Addr)
+		n++
+		o.roff = end
+		if o.roff >= src.N {
+			o.rnext++
+			o.roff = 0
+		}
+	}
+	return n, nil
+}
+
+func (o *udpOffload) initRead(n int) {
+	o.rmsgs = make(Messages, n)
+	o.rsegs = make([]int, n)
+	bufs := make([]byte, n*groBufSize)
+	oob := make([]byte, n*unix.CmsgSpace(4))
+	for i := range o.rmsgs {
+		o.rmsgs[i].Buffers = [][]byte{bufs[i*groBufSize : (i+1)*groBufSize]}
+		o.rmsgs[i].OOB = oob[i*unix.CmsgSpace(4) : (i+1)*unix.CmsgSpace(4)]
+	}
+}
+
+// copyAddr returns a copy of the address src, which is stored in dst if both are UDP addresses.
+// The addresses of rmsgs are reused by the next read, so they must not be handed out.
+func copyAddr(dst, src net.Addr) net.Addr {
+	s, ok := src.(*net.UDPAddr)
+	if !ok || s == nil {
+		return src
+	}
+	d, ok := dst.(*net.UDPAddr)
+	if !ok || d == nil {
+		d = &net.UDPAddr{}
+	}
+	d.IP = append(d.IP[:0], s.IP...)
+	d.Port = s.Port
+	d.Zone = s.Zone
+	return d
+}
+
+// groSegmentSize returns the size of the segments of a datagram read with GRO enabled.
+func groSegmentSize(m *ipv4.Message) int {
+	oob := m.OOB[:m.NN]
+	for len(oob) > 0 {
+		hdr, data, rest, err := unix.ParseOneSocketControlMessage(oob)
+		if err != nil {
+			break
+		}
+		if hdr.Level == unix.SOL_UDP && hdr.Type == unix.UDP_GRO && len(data) >= 4 {
+			if size := int(binary.NativeEndian.Uint32(data)); size > 0 {
+				return size
+			}
+		}
+		oob = rest
+	}
+	// Not coalesced.
+	return m.N
+}
+
+// writeBatch writes msgs to c. Runs of messages with the same destination, size and control data
+// are sent with GSO. If the kernel rejects such a send, the messages are sent again without GSO;
+// if the network device does not support GSO, it is disabled for good.
+func (o *udpOffload) writeBatch(c batchConn, msgs Messages, flags int) (int, error) {
+	if !o.gso.Load() || len(msgs) < 2 {
+		return c.WriteBatch(msgs, flags)
+	}
+	k := o.coalesce(msgs)
+	if k == len(msgs) {
+		return c.WriteBatch(msgs, flags)
+	}
+	n, err := c.WriteBatch(o.wmsgs[:k], flags)
+	written := 0
+	for _, count := range o.wcounts[:max(n, 0)] {
+		written += count
+	}
+	if err == nil {
+		return written, nil
+	}
+	if errors.Is(err, unix.EIO) {
+		log.Info("UDP GSO not supported by the network device, disabling it", "err", err)
+		o.gso.Store(false)
+	}
+	n, err = c.WriteBatch(msgs[written:], flags)
+	return written + max(n, 0), err
+}
+
+// coalesce fills wmsgs with msgs, merging the runs of messages that can be sent with GSO into
+// single messages. It returns the number of messages in wmsgs.
+func (o *udpOffload) coalesce(msgs Messages) int {
+	if len(o.wmsgs) < len(msgs) {
+		o.initWrite(len(msgs))
+	}
+	k := 0
+	for i := 0; i < len(msgs); {
+		first := &msgs[i]
+		j := i + 1
+		if len(first.Buffers) == 1 && len(first.OOB) <= maxCoalescedOOB {
+			size := len(first.Buffers[0])
+			total := size
+			for j < len(msgs) && j-i < maxGSOSegments {
+				m := &msgs[j]
+				if len(m.Buffers) != 1 || !bytes.Equal(m.OOB, first.OOB) ||
+					!sameAddr(first.Addr, m.Addr) {
+					break
+				}
+				l := len(m.Buffers[0])
+				if l == 0 || l > size || total+l > maxGSOBytes {
+					break
+				}
+				total += l
+				j++
+				if l < size {
+					// Only the last segment may be shorter.
+					break
+				}
+			}
+		}
+		w := &o.wmsgs[k]
+		w.Addr = first.Addr
+		if j-i == 1 {
+			w.Buffers = first.Buffers
+			w.OOB = first.OOB
+		} else {
+			for x := i; x < j; x++ {
+				o.wbufs[x] = msgs[x].Buffers[0]
+			}
+			w.Buffers = o.wbufs[i:j:j]
+			w.OOB = append(gsoControlMessage(o.woob[k], len(first.Buffers[0])), first.OOB...)
+		}
+		o.wcounts[k] = j - i
+		k++
+		i = j
+	}
+	return k
+}
+
+func (o *udpOffload) initWrite(n int) {
+	o.wmsgs = make(Messages, n)
+	o.wcounts = make([]int, n)
+	o.wbufs = make([][]byte, n)
+	o.woob = make([][]byte, n)
+	size := unix.CmsgSpace(2) + maxCoalescedOOB
+	oob := make([]byte, n*size)
+	for i := range o.woob {
+		o.woob[i] = oob[i*size : (i+1)*size : (i+1)*size]
+	}
+}
+
+// gsoControlMessage writes the UDP_SEGMENT control message for the given segment size to b, and
+// returns it.
+func gsoControlMessage(b []byte, segmentSize int) []byte {
+	hdr := (*unix.Cmsghdr)(unsafe.Pointer(&b[0]))
+	hdr.Level = unix.SOL_UDP
+	hdr.Type = unix.UDP_SEGMENT
+	hdr.SetLen(unix.CmsgLen(2))
+	binary.NativeEndian.PutUint16(b[unix.CmsgLen(0):], uint16(segmentSize))
+	return b[:unix.CmsgSpace(2)]
+}
+
+// sameAddr returns whether two message addresses are the same destination. A nil address is the
+// remote address of a connected socket.
+func sameAddr(a, b net.Addr) bool {
+	if a == b {
+		return true
+	}
+	ua, ok := a.(*net.UDPAddr)
+	if !ok {
+		return false
+	}
+	ub, ok := b.(*net.UDPAddr)
+	if !ok {
+		return false
+	}
+	return ua.Port == ub.Port && ua.IP.Equal(ub.IP) && ua.Zone == ub.Zone
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package conn
+
+import (
+	"bytes"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/ipv4"
+)
+
+func TestUDPOffloadCoalesce(t *testing.T) {
+	a := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 50000}
+	b := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 2), Port: 50000}
+	msg := func(size int, addr net.Addr) ipv4.Message {
+		return ipv4.Message{Buffers: [][]byte{make([]byte, size)}, Addr: addr}
+	}
+	withTC := func(m ipv4.Message, tc uint8) ipv4.Message {
+		m.OOB = TrafficClassControlMessage(make([]byte, TrafficClassLen), false, tc)
+		return m
+	}
+	testCases := map[string]struct {
+		msgs   Messages
+		counts []int
+	}{
+		"same size and destination": {
+			msgs:   Messages{msg(100, a), msg(100, a), msg(100, a)},
+			counts: []int{3},
+		},
+		"connected": {
+			msgs:   Messages{msg(100, nil), msg(100, nil)},
+			counts: []int{2},
+		},
+		"shorter last segment": {
+			msgs:   Messages{msg(100, a), msg(100, a), msg(50, a), msg(50, a)},
+			counts: []int{3, 1},
+		},
+		"longer segment": {
+			msgs:   Messages{msg(100, a), msg(200, a), msg(200, a)},
+			counts: []int{1, 2},
+		},
+		"different destinations": {
+			msgs:   Messages{msg(100, a), msg(100, b), msg(100, b), msg(100, a)},
+			counts: []int{1, 2, 1},
+		},
+		"traffic classes": {
+			msgs: Messages{msg(100, a), withTC(msg(100, a), 184), withTC(msg(100, a), 184),
+				withTC(msg(100, a), 32)},
+			counts: []int{1, 2, 1},
+		},
+		"size limit": {
+			msgs:   Messages{msg(40000, a), msg(40000, a)},
+			counts: []int{1, 1},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			o := &udpOffload{}
+			k := o.coalesce(tc.msgs)
+			assert.Equal(t, tc.counts, o.wcounts[:k])
+			i := 0
+			for j, count := range o.wcounts[:k] {
+				assert.Len(t, o.wmsgs[j].Buffers, count)
+				assert.Equal(t, count > 1, len(o.wmsgs[j].OOB) > len(tc.msgs[i].OOB))
+				assert.Equal(t, tc.msgs[i].Addr, o.wmsgs[j].Addr)
+				i += count
+			}
+		})
+	}
+}
+
+func TestUDPOffloadLoopback(t *testing.T) {
+	cfg := &Config{UDPOffload: true}
+	rx, err := New(netip.MustParseAddrPort("127.0.0.1:0"), netip.AddrPort{}, cfg)
+	require.NoError(t, err)
+	defer rx.Close()
+	rxAddr := rx.(*connUDPIPv4).conn.LocalAddr().(*net.UDPAddr).AddrPort()
+	tx, err := New(netip.MustParseAddrPort("127.0.0.1:0"), rxAddr, cfg)
+	require.NoError(t, err)
+	defer tx.Close()
+	txAddr := tx.(*connUDPIPv4).conn.LocalAddr().(*net.UDPAddr).AddrPort()
+	if gso, gro := tx.(*connUDPIPv4).offload.enabled(); !gso || !gro {
+		t.Skip("UDP segmentation offload not supported")
+	}
+	require.NoError(t, rx.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	// Ten segments of the same size and a shorter one, which are sent as one datagram.
+	var sent [][]byte
+	out := make(Messages, 11)
+	for i := range out {
+		size := 1000
+		if i == len(out)-1 {
+			size = 300
+		}
+		sent = append(sent, bytes.Repeat([]byte{byte(i)}, size))
+		out[i].Buffers = [][]byte{sent[i]}
+	}
+	n, err := tx.WriteBatch(out, 0)
+	require.NoError(t, err)
+	require.Equal(t, len(out), n)
+
+	// The segments are returned one by one, even if they were coalesced, across calls when the
+	// batch is too small.
+	in := NewReadMessages(4)
+	for i := range in {
+		in[i].Buffers[0] = make([]byte, 2000)
+	}
+	var received [][]byte
+	for len(received) < len(sent) {
+		n, err := rx.ReadBatch(in)
+		require.NoError(t, err)
+		for _, m := range in[:n] {
+			received = append(received, bytes.Clone(m.Buffers[0][:m.N]))
+			assert.Equal(t, txAddr, m.Addr.(*net.UDPAddr).AddrPort())
+		}
+	}
+	assert.Equal(t, sent, received)
+}
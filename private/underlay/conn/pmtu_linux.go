@@ -0,0 +1,52 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package conn
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/private/underlay/sockctrl"
+)
+
+const (
+	ipv4HdrLen = 20
+	ipv6HdrLen = 40
+	udpHdrLen  = 8
+)
+
+// setPathMTUDiscovery makes the kernel set the don't fragment flag of the datagrams sent over the
+// socket, and track the path MTU to their destination from the ICMP errors that it receives.
+func setPathMTUDiscovery(c *net.UDPConn, ipv6 bool) error {
+	if ipv6 {
+		return sockctrl.SetsockoptInt(c, unix.IPPROTO_IPV6, unix.IPV6_MTU_DISCOVER,
+			unix.IPV6_PMTUDISC_DO)
+	}
+	return sockctrl.SetsockoptInt(c, unix.IPPROTO_IP, unix.IP_MTU_DISCOVER, unix.IP_PMTUDISC_DO)
+}
+
+// pathMTU returns the largest UDP payload that the kernel can currently send to the remote address
+// of the connected socket without fragmentation.
+func pathMTU(c *net.UDPConn, ipv6 bool) (int, error) {
+	if ipv6 {
+		mtu, err := sockctrl.GetsockoptInt(c, unix.IPPROTO_IPV6, unix.IPV6_MTU)
+		return mtu - ipv6HdrLen - udpHdrLen, err
+	}
+	mtu, err := sockctrl.GetsockoptInt(c, unix.IPPROTO_IP, unix.IP_MTU)
+	return mtu - ipv4HdrLen - udpHdrLen, err
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package conn
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathMTU(t *testing.T) {
+	lo, err := net.InterfaceByName("lo")
+	if err != nil {
+		t.Skip("no loopback interface:", err)
+	}
+	local := netip.MustParseAddrPort("127.0.0.1:0")
+	remote := netip.MustParseAddrPort("127.0.0.1:9")
+
+	c, err := New(local, remote, &Config{PathMTUDiscovery: true})
+	require.NoError(t, err)
+	defer c.Close()
+	mtu, err := c.(*connUDPIPv4).PathMTU()
+	require.NoError(t, err)
+	// The MTU of an IPv4 route is at most the largest IPv4 packet.
+	assert.Equal(t, min(lo.MTU, 0xffff)-ipv4HdrLen-udpHdrLen, mtu)
+
+	c, err = New(local, remote, &Config{})
+	require.NoError(t, err)
+	defer c.Close()
+	_, err = c.(*connUDPIPv4).PathMTU()
+	assert.Error(t, err)
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package conn
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/sys/unix"
+)
+
+// mmsghdr is the struct mmsghdr of recvmmsg(2).
+type mmsghdr struct {
+	hdr unix.Msghdr
+	len uint32
+}
+
+// mmsgConn reads batches of datagrams without allocating memory: unlike the ReadBatch of
+// ipv4.PacketConn and ipv6.PacketConn, which allocate the source address of each datagram, it
+// stores the source address in the *net.UDPAddr that the message already has, if any. The caller
+// can so give each message an address of its own, along with its buffer, and reuse both.
+// This only covers the receive path; writing datagrams is unchanged.
+//
+// ReadBatch must not be called concurrently.
+type mmsgConn struct {
+	batchConn
+	raw syscall.RawConn
+
+	// Scratch space of ReadBatch.
+	hdrs  []mmsghdr
+	iovs  []unix.Iovec
+	names []unix.RawSockaddrInet6
+	// The arguments and results of the recvmmsg call in progress. They are kept here, rather
+	// than captured by recvFn, so that recvFn is only allocated once.
+	n, flags int
+	count    int
+	errno    syscall.Errno
+	recvFn   func(fd uintptr) bool
+}
+
+func newMmsgConn(c *net.UDPConn, bc batchConn) batchConn {
+	raw, err := c.SyscallConn()
+	if err != nil {
+		return bc
+	}
+	m := &mmsgConn{batchConn: bc, raw: raw}
+	m.recvFn = m.recv
+	return m
+}
+
+func (m *mmsgConn) ReadBatch(ms []ipv4.Message, flags int) (int, error) {
+	if len(ms) == 0 {
+		return 0, nil
+	}
+	if len(m.hdrs) < len(ms) {
+		m.hdrs = make([]mmsghdr, len(ms))
+		m.iovs = make([]unix.Iovec, len(ms))
+		m.names = make([]unix.RawSockaddrInet6, len(ms))
+	}
+	for i := range ms {
+		if len(ms[i].Buffers) != 1 || len(ms[i].Buffers[0]) == 0 {
+			// Scatter reads are not needed by the router; leave them to the general case.
+			return m.batchConn.ReadBatch(ms, flags)
+		}
+		buf := ms[i].Buffers[0]
+		m.iovs[i].Base = &buf[0]
+		m.iovs[i].SetLen(len(buf))
+		h := &m.hdrs[i].hdr
+		*h = unix.Msghdr{
+			Name:    (*byte)(unsafe.Pointer(&m.names[i])),
+			Namelen: unix.SizeofSockaddrInet6,
+			Iov:     &m.iovs[i],
+		}
+		h.SetIovlen(1)
+		if len(ms[i].OOB) != 0 {
+			h.Control = &ms[i].OOB[0]
+			h.SetControllen(len(ms[i].OOB))
+		}
+	}
+	m.n, m.flags = len(ms), flags
+	if err := m.raw.Read(m.recvFn); err != nil {
+		return 0, err
+	}
+	if m.errno != 0 {
+		return 0, os.NewSyscallError("recvmmsg", m.errno)
+	}
+	for i := range ms[:m.count] {
+		h := &m.hdrs[i]
+		ms[i].N = int(h.len)
+		ms[i].NN = int(h.hdr.Controllen)
+		ms[i].Flags = int(h.hdr.Flags)
+		ms[i].Addr = sockaddrToUDPAddr(&m.names[i], ms[i].Addr)
+	}
+	return m.count, nil
+}
+
+// recv makes the recvmmsg call. It returns false if the socket has no datagram to read, so that
+// the runtime waits for one.
+func (m *mmsgConn) recv(fd uintptr) bool {
+	for {
+		n, _, errno := unix.Syscall6(unix.SYS_RECVMMSG, fd, uintptr(unsafe.Pointer(&m.hdrs[0])),
+			uintptr(m.n), uintptr(m.flags), 0, 0)
+		switch errno {
+		case unix.EINTR:
+			continue
+		case unix.EAGAIN:
+			return false
+		}
+		m.count, m.errno = int(n), errno
+		return true
+	}
+}
+
+// sockaddrToUDPAddr returns the UDP address of the given socket address. It is stored in dst, if
+// that is a *net.UDPAddr, so that no memory is allocated unless the address has a zone. The IP
+// of the address is empty if the socket address is not an IP one.
+func sockaddrToUDPAddr(sa *unix.RawSockaddrInet6, dst net.Addr) net.Addr {
+	a, ok := dst.(*net.UDPAddr)
+	if !ok || a == nil {
+		a = &net.UDPAddr{}
+	}
+	// The port is in network byte order in both families.
+	port := (*[2]byte)(unsafe.Pointer(&sa.Port))
+	a.Port = int(port[0])<<8 | int(port[1])
+	a.Zone = ""
+	switch sa.Family {
+	case unix.AF_INET:
+		sa4 := (*unix.RawSockaddrInet4)(unsafe.Pointer(sa))
+		a.IP = append(a.IP[:0], sa4.Addr[:]...)
+	case unix.AF_INET6:
+		a.IP = append(a.IP[:0], sa.Addr[:]...)
+		if sa.Scope_id != 0 {
+			a.Zone = zoneName(int(sa.Scope_id))
+		}
+	default:
+		a.IP = a.IP[:0]
+	}
+	return a
+}
+
+// zoneName returns the name of the interface with the given index, or the index itself if the
+// interface is not known.
+func zoneName(index int) string {
+	if ifi, err := net.InterfaceByIndex(index); err == nil {
+		return ifi.Name
+	}
+	return strconv.Itoa(index)
+}
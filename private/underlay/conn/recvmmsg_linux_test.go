@@ -0,0 +1,87 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The race detector allocates memory of its own, which the test would count.
+
+//go:build linux && !race
+
+package conn
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBatchInPlace(t *testing.T) {
+	for _, local := range []string{"127.0.0.1:0", "[::1]:0"} {
+		t.Run(local, func(t *testing.T) {
+			rc, err := New(netip.MustParseAddrPort(local), netip.AddrPort{}, &Config{})
+			if err != nil {
+				t.Skip("cannot listen:", err)
+			}
+			defer rc.Close()
+			sc, err := New(netip.MustParseAddrPort(local), boundAddr(rc), &Config{})
+			require.NoError(t, err)
+			defer sc.Close()
+
+			const n = 4
+			w := make(Messages, n)
+			for i := range w {
+				w[i].Buffers = [][]byte{{byte(i), 1, 2, 3}}
+			}
+			r := NewReadMessages(n)
+			addrs := make([]*net.UDPAddr, n)
+			for i := range r {
+				r[i].Buffers[0] = make([]byte, 64)
+				addrs[i] = &net.UDPAddr{IP: make(net.IP, 0, net.IPv6len)}
+				r[i].Addr = addrs[i]
+			}
+			exchange := func() {
+				written, err := sc.WriteBatch(w, 0)
+				require.NoError(t, err)
+				require.Equal(t, n, written)
+				for read := 0; read < n; {
+					k, err := rc.ReadBatch(r[read:])
+					require.NoError(t, err)
+					read += k
+				}
+			}
+
+			exchange()
+			for i := range r {
+				assert.Same(t, addrs[i], r[i].Addr)
+				assert.Equal(t, boundAddr(sc), addrs[i].AddrPort())
+				assert.Equal(t, []byte{byte(i), 1, 2, 3}, r[i].Buffers[0][:r[i].N])
+			}
+			// The buffers and addresses of the messages are reused; nothing is allocated.
+			assert.Zero(t, testing.AllocsPerRun(20, exchange))
+		})
+	}
+}
+
+// boundAddr returns the address that the socket of the connection is bound to.
+func boundAddr(c Conn) netip.AddrPort {
+	var u *net.UDPConn
+	switch c := c.(type) {
+	case *connUDPIPv4:
+		u = c.conn
+	case *connUDPIPv6:
+		u = c.conn
+	}
+	return u.LocalAddr().(*net.UDPAddr).AddrPort()
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package conn
+
+// TrafficClassLen is the size of the buffer needed by TrafficClassControlMessage.
+var TrafficClassLen = 0
+
+// TrafficClassControlMessage returns nil: setting the traffic class of individual messages is
+// only supported on Linux.
+func TrafficClassControlMessage(b []byte, ipv6 bool, tc uint8) []byte {
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package conn
+
+import (
+	"encoding/binary"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// TrafficClassLen is the size of the buffer needed by TrafficClassControlMessage.
+var TrafficClassLen = unix.CmsgSpace(4)
+
+// TrafficClassControlMessage writes to b the control message that sets the traffic class (the
+// type of service byte for IPv4) of a message sent over an IPv4 or IPv6 socket, and returns it.
+// The message can be used as the OOB of the messages passed to WriteBatch. b must be at least
+// TrafficClassLen bytes long.
+func TrafficClassControlMessage(b []byte, ipv6 bool, tc uint8) []byte {
+	hdr := (*unix.Cmsghdr)(unsafe.Pointer(&b[0]))
+	if ipv6 {
+		hdr.Level = unix.IPPROTO_IPV6
+		hdr.Type = unix.IPV6_TCLASS
+	} else {
+		hdr.Level = unix.IPPROTO_IP
+		hdr.Type = unix.IP_TOS
+	}
+	hdr.SetLen(unix.CmsgLen(4))
+	binary.NativeEndian.PutUint32(b[unix.CmsgLen(0):], uint32(tc))
+	return b[:unix.CmsgSpace(4)]
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package conn
+
+import (
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+var errTuningUnsupported = serrors.New("socket option is only supported on Linux")
+
+func setBusyPoll(c *net.UDPConn, d time.Duration) error {
+	return errTuningUnsupported
+}
+
+func setTrafficClass(c *net.UDPConn, ipv6 bool, tc uint8) error {
+	return errTuningUnsupported
+}
+
+func receiveDrops(c *net.UDPConn, ipv6 bool) (uint64, error) {
+	return 0, errTuningUnsupported
+}
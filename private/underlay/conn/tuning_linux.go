@@ -0,0 +1,93 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package conn
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/underlay/sockctrl"
+)
+
+// setBusyPoll makes the kernel busy poll the network device for the given time when a read on
+// the socket finds no datagram, instead of waiting for an interrupt. This lowers the latency at
+// the cost of CPU time. Raising it above the system default requires CAP_NET_ADMIN.
+func setBusyPoll(c *net.UDPConn, d time.Duration) error {
+	return sockctrl.SetsockoptInt(c, unix.SOL_SOCKET, unix.SO_BUSY_POLL, int(d/time.Microsecond))
+}
+
+// setTrafficClass sets the TOS byte of the IPv4 header, or the traffic class of the IPv6 header,
+// of the datagrams sent over the socket, unless a control message overrides it.
+func setTrafficClass(c *net.UDPConn, ipv6 bool, tc uint8) error {
+	if ipv6 {
+		return sockctrl.SetsockoptInt(c, unix.IPPROTO_IPV6, unix.IPV6_TCLASS, int(tc))
+	}
+	return sockctrl.SetsockoptInt(c, unix.IPPROTO_IP, unix.IP_TOS, int(tc))
+}
+
+// receiveDrops returns the number of datagrams that the kernel dropped because the receive buffer
+// of the socket was full, as reported in /proc/net/udp or /proc/net/udp6.
+func receiveDrops(c *net.UDPConn, ipv6 bool) (uint64, error) {
+	var inode uint64
+	err := sockctrl.SockControl(c, func(fd int) error {
+		var st unix.Stat_t
+		if err := unix.Fstat(fd, &st); err != nil {
+			return err
+		}
+		inode = st.Ino
+		return nil
+	})
+	if err != nil {
+		return 0, serrors.Wrap("getting socket inode", err)
+	}
+	path := "/proc/net/udp"
+	if ipv6 {
+		path = "/proc/net/udp6"
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return parseReceiveDrops(f, inode)
+}
+
+// parseReceiveDrops returns the drops of the socket with the given inode from the content of
+// /proc/net/udp. The inode and the drops are the 10th and the 13th fields of each line.
+func parseReceiveDrops(r io.Reader, inode uint64) (uint64, error) {
+	want := strconv.FormatUint(inode, 10)
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 13 || fields[9] != want {
+			continue
+		}
+		return strconv.ParseUint(fields[12], 10, 64)
+	}
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+	return 0, serrors.New("socket not found", "inode", inode)
+}
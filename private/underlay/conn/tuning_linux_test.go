@@ -0,0 +1,83 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package conn
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/private/underlay/sockctrl"
+)
+
+func TestSocketTuning(t *testing.T) {
+	if _, err := net.InterfaceByName("lo"); err != nil {
+		t.Skip("no loopback interface:", err)
+	}
+	c, err := New(netip.MustParseAddrPort("127.0.0.1:0"), netip.AddrPort{},
+		&Config{ReceiveBufferSize: 4096, TrafficClass: 0xb8})
+	require.NoError(t, err)
+	defer c.Close()
+	base := &c.(*connUDPIPv4).connUDPBase
+	tos, err := sockctrl.GetsockoptInt(base.conn, unix.IPPROTO_IP, unix.IP_TOS)
+	require.NoError(t, err)
+	assert.Equal(t, 0xb8, tos)
+
+	drops, err := base.ReceiveDrops()
+	require.NoError(t, err)
+	assert.Zero(t, drops)
+
+	// Overflow the receive buffer.
+	sender, err := net.DialUDP("udp4", nil, base.conn.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+	defer sender.Close()
+	for range 100 {
+		_, err := sender.Write(make([]byte, 1000))
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		drops, err = base.ReceiveDrops()
+		return err == nil && drops > 0
+	}, time.Second, 10*time.Millisecond)
+
+	before, err := base.ReceiveBufferSize()
+	require.NoError(t, err)
+	after, err := base.SetReceiveBufferSize(2 * before)
+	require.NoError(t, err)
+	assert.Greater(t, after, before)
+}
+
+func TestParseReceiveDrops(t *testing.T) {
+	// The header and two sockets of /proc/net/udp.
+	content := "   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt" +
+		"   uid  timeout inode ref pointer drops\n" +
+		"  120: 00000000:0044 00000000:0000 07 00000000:00000000 00:00000000 00000000" +
+		"     0        0 18057 2 0000000000000000 0\n" +
+		"  846: 0100007F:9C40 00000000:0000 07 00000000:00000900 00:00000000 00000000" +
+		"  1000        0 41234 2 0000000000000000 17\n"
+	drops, err := parseReceiveDrops(strings.NewReader(content), 41234)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(17), drops)
+	_, err = parseReceiveDrops(strings.NewReader(content), 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,324 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package xdp
+
+import (
+	"encoding/binary"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// maxQueues bounds the number of NIC queues that can be redirected to XDP sockets.
+	maxQueues = 64
+	// maxPorts bounds the number of UDP ports that can be redirected on one device.
+	maxPorts = 1024
+
+	xdpPass = 2
+
+	bpfFuncMapLookupElem = 1
+	bpfFuncRedirectMap   = 51
+)
+
+// bpfInsn is the kernel's struct bpf_insn.
+type bpfInsn struct {
+	code uint8
+	regs uint8 // dst in the low nibble, src in the high nibble.
+	off  int16
+	imm  int32
+}
+
+type bpfMapCreateAttr struct {
+	mapType    uint32
+	keySize    uint32
+	valueSize  uint32
+	maxEntries uint32
+	mapFlags   uint32
+}
+
+type bpfMapElemAttr struct {
+	mapFd uint32
+	_     uint32
+	key   uint64
+	value uint64
+	flags uint64
+}
+
+type bpfProgLoadAttr struct {
+	progType           uint32
+	insnCnt            uint32
+	insns              uint64
+	license            uint64
+	logLevel           uint32
+	logSize            uint32
+	logBuf             uint64
+	kernVersion        uint32
+	progFlags          uint32
+	progName           [unix.BPF_OBJ_NAME_LEN]byte
+	progIfindex        uint32
+	expectedAttachType uint32
+}
+
+func bpf(cmd int, attr unsafe.Pointer, size uintptr) (int, error) {
+	r, _, errno := unix.Syscall(unix.SYS_BPF, uintptr(cmd), uintptr(attr), size)
+	if errno != 0 {
+		return -1, errno
+	}
+	return int(r), nil
+}
+
+func newBPFMap(mapType, keySize, valueSize, maxEntries uint32) (int, error) {
+	attr := bpfMapCreateAttr{
+		mapType:    mapType,
+		keySize:    keySize,
+		valueSize:  valueSize,
+		maxEntries: maxEntries,
+	}
+	fd, err := bpf(unix.BPF_MAP_CREATE, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	if err != nil {
+		return -1, serrors.Wrap("creating BPF map", err, "type", mapType)
+	}
+	return fd, nil
+}
+
+func bpfMapUpdate(fd int, key, value unsafe.Pointer) error {
+	attr := bpfMapElemAttr{
+		mapFd: uint32(fd),
+		key:   uint64(uintptr(key)),
+		value: uint64(uintptr(value)),
+	}
+	_, err := bpf(unix.BPF_MAP_UPDATE_ELEM, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	return err
+}
+
+func bpfMapDelete(fd int, key unsafe.Pointer) error {
+	attr := bpfMapElemAttr{
+		mapFd: uint32(fd),
+		key:   uint64(uintptr(key)),
+	}
+	_, err := bpf(unix.BPF_MAP_DELETE_ELEM, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	return err
+}
+
+// program is the XDP program attached to one network device, together with its maps.
+type program struct {
+	fd      int
+	xsksMap int // queue index -> XDP socket
+	portMap int // UDP destination port (network byte order) -> present
+}
+
+// newProgram creates the maps and loads the redirect program.
+func newProgram() (*program, error) {
+	xsks, err := newBPFMap(unix.BPF_MAP_TYPE_XSKMAP, 4, 4, maxQueues)
+	if err != nil {
+		return nil, err
+	}
+	ports, err := newBPFMap(unix.BPF_MAP_TYPE_HASH, 2, 1, maxPorts)
+	if err != nil {
+		unix.Close(xsks)
+		return nil, err
+	}
+	insns := redirectProgram(xsks, ports)
+	license := []byte("Apache-2.0\x00")
+	logBuf := make([]byte, 4096)
+	attr := bpfProgLoadAttr{
+		progType: unix.BPF_PROG_TYPE_XDP,
+		insnCnt:  uint32(len(insns)),
+		insns:    uint64(uintptr(unsafe.Pointer(&insns[0]))),
+		license:  uint64(uintptr(unsafe.Pointer(&license[0]))),
+		logLevel: 1,
+		logSize:  uint32(len(logBuf)),
+		logBuf:   uint64(uintptr(unsafe.Pointer(&logBuf[0]))),
+	}
+	copy(attr.progName[:], "scion_xsk")
+	fd, err := bpf(unix.BPF_PROG_LOAD, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	if err != nil {
+		unix.Close(xsks)
+		unix.Close(ports)
+		return nil, serrors.Wrap("loading XDP program", err,
+			"verifier_log", unix.ByteSliceToString(logBuf))
+	}
+	return &program{fd: fd, xsksMap: xsks, portMap: ports}, nil
+}
+
+func (p *program) setSocket(queue int, sockFd int) error {
+	k, v := uint32(queue), uint32(sockFd)
+	return bpfMapUpdate(p.xsksMap, unsafe.Pointer(&k), unsafe.Pointer(&v))
+}
+
+func (p *program) addPort(port uint16) error {
+	var k [2]byte
+	binary.BigEndian.PutUint16(k[:], port)
+	v := uint8(1)
+	return bpfMapUpdate(p.portMap, unsafe.Pointer(&k), unsafe.Pointer(&v))
+}
+
+func (p *program) removePort(port uint16) error {
+	var k [2]byte
+	binary.BigEndian.PutUint16(k[:], port)
+	return bpfMapDelete(p.portMap, unsafe.Pointer(&k))
+}
+
+func (p *program) close() {
+	unix.Close(p.fd)
+	unix.Close(p.xsksMap)
+	unix.Close(p.portMap)
+}
+
+// redirectProgram assembles the XDP program. In C, it reads roughly as follows:
+//
+//	if (is_udp_ipv4_unfragmented(pkt) || is_udp_ipv6(pkt)) {
+//		if (bpf_map_lookup_elem(&ports, &udp->dest))
+//			return bpf_redirect_map(&xsks, ctx->rx_queue_index, XDP_PASS);
+//	}
+//	return XDP_PASS;
+//
+// The packet fields are loaded in host byte order, so the constants they are compared against
+// are converted accordingly.
+func redirectProgram(xsksMap, portMap int) []bpfInsn {
+	const (
+		r0, r1, r2, r3, r4, r5, r6, r10 = 0, 1, 2, 3, 4, 5, 6, 10
+	)
+	native16 := func(v uint16) int32 {
+		var b [2]byte
+		binary.BigEndian.PutUint16(b[:], v)
+		return int32(binary.NativeEndian.Uint16(b[:]))
+	}
+	a := &assembler{labels: map[string]int{}}
+	a.movReg(r6, r1)
+	a.load(unix.BPF_W, r2, r6, 0) // data
+	a.load(unix.BPF_W, r3, r6, 4) // data_end
+	a.movReg(r4, r2)
+	a.addImm(r4, ethHdrLen+ipv4HdrLen+udpHdrLen)
+	a.jmpReg(unix.BPF_JGT, r4, r3, "pass")
+	a.load(unix.BPF_H, r5, r2, 12) // ether type
+	a.jmpImm(unix.BPF_JNE, r5, native16(etherTypeIPv4), "ipv6")
+	a.load(unix.BPF_B, r5, r2, ethHdrLen) // version and IHL
+	a.jmpImm(unix.BPF_JNE, r5, 0x45, "pass")
+	a.load(unix.BPF_B, r5, r2, ethHdrLen+9) // protocol
+	a.jmpImm(unix.BPF_JNE, r5, protoUDP, "pass")
+	a.load(unix.BPF_H, r5, r2, ethHdrLen+6) // flags and fragment offset
+	a.jmpImm(unix.BPF_JSET, r5, native16(0x3fff), "pass")
+	a.load(unix.BPF_H, r5, r2, ethHdrLen+ipv4HdrLen+2) // UDP destination port
+	a.jmp("lookup")
+	a.label("ipv6")
+	a.jmpImm(unix.BPF_JNE, r5, native16(etherTypeIPv6), "pass")
+	a.movReg(r4, r2)
+	a.addImm(r4, ethHdrLen+ipv6HdrLen+udpHdrLen)
+	a.jmpReg(unix.BPF_JGT, r4, r3, "pass")
+	a.load(unix.BPF_B, r5, r2, ethHdrLen+6) // next header
+	a.jmpImm(unix.BPF_JNE, r5, protoUDP, "pass")
+	a.load(unix.BPF_H, r5, r2, ethHdrLen+ipv6HdrLen+2) // UDP destination port
+	a.label("lookup")
+	a.store(unix.BPF_H, r10, r5, -4)
+	a.movReg(r2, r10)
+	a.addImm(r2, -4)
+	a.loadMapFd(r1, portMap)
+	a.call(bpfFuncMapLookupElem)
+	a.jmpImm(unix.BPF_JEQ, r0, 0, "pass")
+	a.load(unix.BPF_W, r2, r6, 16) // rx_queue_index
+	a.loadMapFd(r1, xsksMap)
+	a.movImm(r3, xdpPass)
+	a.call(bpfFuncRedirectMap)
+	a.exit()
+	a.label("pass")
+	a.movImm(r0, xdpPass)
+	a.exit()
+	return a.assemble()
+}
+
+// assembler is a minimal eBPF assembler with support for forward jumps to labels.
+type assembler struct {
+	insns  []bpfInsn
+	labels map[string]int
+	fixups []fixup
+}
+
+type fixup struct {
+	pc    int
+	label string
+}
+
+func (a *assembler) emit(code uint8, dst, src uint8, off int16, imm int32) {
+	a.insns = append(a.insns, bpfInsn{code: code, regs: dst | src<<4, off: off, imm: imm})
+}
+
+func (a *assembler) label(name string) {
+	a.labels[name] = len(a.insns)
+}
+
+func (a *assembler) movReg(dst, src uint8) {
+	a.emit(unix.BPF_ALU64|unix.BPF_MOV|unix.BPF_X, dst, src, 0, 0)
+}
+
+func (a *assembler) movImm(dst uint8, imm int32) {
+	a.emit(unix.BPF_ALU64|unix.BPF_MOV|unix.BPF_K, dst, 0, 0, imm)
+}
+
+func (a *assembler) addImm(dst uint8, imm int32) {
+	a.emit(unix.BPF_ALU64|unix.BPF_ADD|unix.BPF_K, dst, 0, 0, imm)
+}
+
+func (a *assembler) load(size uint8, dst, src uint8, off int16) {
+	a.emit(unix.BPF_LDX|unix.BPF_MEM|size, dst, src, off, 0)
+}
+
+func (a *assembler) store(size uint8, dst, src uint8, off int16) {
+	a.emit(unix.BPF_STX|unix.BPF_MEM|size, dst, src, off, 0)
+}
+
+func (a *assembler) loadMapFd(dst uint8, fd int) {
+	a.emit(unix.BPF_LD|unix.BPF_DW|unix.BPF_IMM, dst, unix.BPF_PSEUDO_MAP_FD, 0, int32(fd))
+	a.emit(0, 0, 0, 0, 0)
+}
+
+func (a *assembler) jmpImm(op uint8, dst uint8, imm int32, target string) {
+	a.fixups = append(a.fixups, fixup{pc: len(a.insns), label: target})
+	a.emit(unix.BPF_JMP|op|unix.BPF_K, dst, 0, 0, imm)
+}
+
+func (a *assembler) jmpReg(op uint8, dst, src uint8, target string) {
+	a.fixups = append(a.fixups, fixup{pc: len(a.insns), label: target})
+	a.emit(unix.BPF_JMP|op|unix.BPF_X, dst, src, 0, 0)
+}
+
+func (a *assembler) jmp(target string) {
+	a.fixups = append(a.fixups, fixup{pc: len(a.insns), label: target})
+	a.emit(unix.BPF_JMP|unix.BPF_JA, 0, 0, 0, 0)
+}
+
+func (a *assembler) call(fn int32) {
+	a.emit(unix.BPF_JMP|unix.BPF_CALL, 0, 0, 0, fn)
+}
+
+func (a *assembler) exit() {
+	a.emit(unix.BPF_JMP|unix.BPF_EXIT, 0, 0, 0, 0)
+}
+
+func (a *assembler) assemble() []bpfInsn {
+	for _, f := range a.fixups {
+		target, ok := a.labels[f.label]
+		if !ok {
+			panic("undefined label: " + f.label)
+		}
+		a.insns[f.pc].off = int16(target - f.pc - 1)
+	}
+	return a.insns
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xdp
+
+import (
+	"github.com/scionproto/scion/private/underlay/conn"
+)
+
+// Attach modes of the XDP program.
+const (
+	// ModeNative runs the program in the network driver. It requires driver support.
+	ModeNative = "native"
+	// ModeGeneric runs the program after the kernel has allocated the socket buffer. It works
+	// with every driver, but is much slower than native mode.
+	ModeGeneric = "generic"
+)
+
+// Config customizes the behavior of an AF_XDP connection.
+type Config struct {
+	// Queue is the NIC receive queue the XDP socket is bound to. Only traffic steered to that
+	// queue reaches the socket; the NIC must be configured accordingly (e.g., with ethtool).
+	Queue int
+	// Mode is the attach mode of the XDP program, either ModeNative or ModeGeneric. If empty,
+	// native mode is tried first, then generic mode.
+	Mode string
+	// ZeroCopy requests zero-copy operation of the XDP socket. It requires driver support.
+	ZeroCopy bool
+	// MTU is the largest UDP payload that is sent or received. New fails if frames of that size
+	// do not fit in a UMEM chunk. If 0, the largest payload that fits is assumed.
+	MTU int
+	// Socket configures the regular UDP socket that is kept bound to the local address.
+	Socket conn.Config
+}
@@ -0,0 +1,582 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package xdp
+
+import (
+	"net"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/underlay/conn"
+)
+
+const (
+	// pollTimeout bounds the time the receive loop waits before checking for shutdown.
+	pollTimeout = 100 // milliseconds
+	// resolveTimeout is how long New waits for the kernel to resolve the next hop.
+	resolveTimeout = time.Second
+	// refreshInterval is the interval at which the next hop's Ethernet address is refreshed.
+	refreshInterval = 10 * time.Second
+	// connQueueSize is the number of received frames that can be queued for one connection.
+	connQueueSize = 512
+)
+
+var errNoDeadlines = serrors.New("deadlines are not supported on AF_XDP connections")
+
+// rxFrame identifies a received frame and the location of its UDP payload.
+type rxFrame struct {
+	addr uint64
+	off  uint16
+	n    uint16
+}
+
+type deviceKey struct {
+	ifindex int
+	queue   int
+}
+
+var (
+	devicesMtx sync.Mutex
+	devices    = map[deviceKey]*device{}
+)
+
+// device is the XDP socket bound to one queue of one network device. It is shared by all the
+// connections whose local address is on that device.
+type device struct {
+	key  deviceKey
+	link netlink.Link
+	prog *program
+	sock *socket
+
+	// conns maps the local address of each connection to the connection. It is updated under
+	// devicesMtx and read by the receive loop.
+	conns atomic.Pointer[map[netip.AddrPort]*Conn]
+
+	fillMtx sync.Mutex
+
+	txMtx  sync.Mutex
+	txFree []uint64
+
+	done chan struct{}
+}
+
+func openDevice(key deviceKey, cfg Config) (*device, error) {
+	link, err := netlink.LinkByIndex(key.ifindex)
+	if err != nil {
+		return nil, serrors.Wrap("looking up network device", err, "ifindex", key.ifindex)
+	}
+	prog, err := newProgram()
+	if err != nil {
+		return nil, err
+	}
+	sock, err := newSocket(key.ifindex, key.queue, cfg.ZeroCopy)
+	if err != nil {
+		prog.close()
+		return nil, err
+	}
+	if err := prog.setSocket(key.queue, sock.fd); err != nil {
+		sock.close()
+		prog.close()
+		return nil, serrors.Wrap("registering XDP socket", err)
+	}
+	if err := attach(link, prog.fd, cfg.Mode); err != nil {
+		sock.close()
+		prog.close()
+		return nil, err
+	}
+	d := &device{
+		key:    key,
+		link:   link,
+		prog:   prog,
+		sock:   sock,
+		txFree: make([]uint64, 0, numFrames-ringSize),
+		done:   make(chan struct{}),
+	}
+	for i := ringSize; i < numFrames; i++ {
+		d.txFree = append(d.txFree, uint64(i)*frameSize)
+	}
+	d.conns.Store(&map[netip.AddrPort]*Conn{})
+	go func() {
+		defer log.HandlePanic()
+		d.receive()
+	}()
+	log.Info("AF_XDP socket opened", "device", link.Attrs().Name, "queue", key.queue,
+		"mode", cfg.Mode, "zero_copy", cfg.ZeroCopy)
+	return d, nil
+}
+
+func attach(link netlink.Link, progFd int, mode string) error {
+	var modes []int
+	switch mode {
+	case ModeNative:
+		modes = []int{unix.XDP_FLAGS_DRV_MODE}
+	case ModeGeneric:
+		modes = []int{unix.XDP_FLAGS_SKB_MODE}
+	case "":
+		modes = []int{unix.XDP_FLAGS_DRV_MODE, unix.XDP_FLAGS_SKB_MODE}
+	default:
+		return serrors.New("unknown XDP attach mode", "mode", mode)
+	}
+	var err error
+	for _, m := range modes {
+		err = netlink.LinkSetXdpFdWithFlags(link, progFd, m|unix.XDP_FLAGS_UPDATE_IF_NOEXIST)
+		if err == nil {
+			return nil
+		}
+	}
+	return serrors.Wrap("attaching XDP program", err, "device", link.Attrs().Name)
+}
+
+// addConn registers c with the device. Must be called with devicesMtx held.
+func (d *device) addConn(c *Conn) error {
+	old := *d.conns.Load()
+	if _, exists := old[c.local]; exists {
+		return serrors.New("local address already in use", "local", c.local)
+	}
+	if err := d.prog.addPort(c.local.Port()); err != nil {
+		return serrors.Wrap("registering UDP port", err, "port", c.local.Port())
+	}
+	m := make(map[netip.AddrPort]*Conn, len(old)+1)
+	for k, v := range old {
+		m[k] = v
+	}
+	m[c.local] = c
+	d.conns.Store(&m)
+	return nil
+}
+
+// removeConn unregisters c from the device and returns the number of remaining connections.
+// Must be called with devicesMtx held.
+func (d *device) removeConn(c *Conn) int {
+	old := *d.conns.Load()
+	m := make(map[netip.AddrPort]*Conn, len(old))
+	portInUse := false
+	for k, v := range old {
+		if v == c {
+			continue
+		}
+		m[k] = v
+		portInUse = portInUse || k.Port() == c.local.Port()
+	}
+	if !portInUse {
+		_ = d.prog.removePort(c.local.Port())
+	}
+	d.conns.Store(&m)
+	return len(m)
+}
+
+func (d *device) close() {
+	close(d.done)
+	if err := netlink.LinkSetXdpFd(d.link, -1); err != nil {
+		log.Error("Detaching XDP program", "device", d.link.Attrs().Name, "err", err)
+	}
+	d.prog.close()
+	// The receive loop may still be polling; it notices d.done within pollTimeout. The socket
+	// is closed by the loop itself, so that it never touches unmapped rings.
+}
+
+// receive is the receive loop. It demultiplexes incoming frames to the connections by local
+// address and returns the frames it cannot deliver to the kernel right away.
+func (d *device) receive() {
+	s := d.sock
+	fds := []unix.PollFd{{Fd: int32(s.fd), Events: unix.POLLIN}}
+	recycle := make([]uint64, 0, ringSize)
+	for {
+		select {
+		case <-d.done:
+			d.fillMtx.Lock()
+			s.close()
+			d.sock = nil
+			d.fillMtx.Unlock()
+			return
+		default:
+		}
+		cons, n := s.rx.available()
+		if n == 0 {
+			if _, err := unix.Poll(fds, pollTimeout); err != nil && err != unix.EINTR {
+				log.Error("Polling XDP socket", "err", err)
+			}
+			continue
+		}
+		conns := *d.conns.Load()
+		for i := uint32(0); i < n; i++ {
+			desc := s.rx.desc(cons + i)
+			frame := s.frame(desc.Addr, desc.Len)
+			src, dst, payload, err := parseUDPFrame(frame)
+			if err != nil {
+				recycle = append(recycle, desc.Addr)
+				continue
+			}
+			c, ok := conns[dst]
+			if !ok || c.remote != src {
+				recycle = append(recycle, desc.Addr)
+				continue
+			}
+			f := rxFrame{
+				addr: desc.Addr,
+				off:  uint16(cap(frame) - cap(payload)),
+				n:    uint16(len(payload)),
+			}
+			select {
+			case c.rxQ <- f:
+			default:
+				recycle = append(recycle, desc.Addr)
+			}
+		}
+		atomic.StoreUint32(s.rx.consumer, cons+n)
+		d.refill(recycle)
+		recycle = recycle[:0]
+	}
+}
+
+// refill returns the given frames to the kernel.
+func (d *device) refill(addrs []uint64) {
+	if len(addrs) == 0 {
+		return
+	}
+	d.fillMtx.Lock()
+	defer d.fillMtx.Unlock()
+	s := d.sock
+	if s == nil {
+		return
+	}
+	prod, free := s.fill.free()
+	// The fill ring has room for all the reception frames, so free is never short.
+	n := min(uint32(len(addrs)), free)
+	for i := uint32(0); i < n; i++ {
+		*s.fill.addr(prod + i) = addrs[i]
+	}
+	atomic.StoreUint32(s.fill.producer, prod+n)
+	s.wakeFill()
+}
+
+// reclaim moves completed transmission frames back to the free list. Must be called with txMtx
+// held.
+func (d *device) reclaim() {
+	s := d.sock
+	cons, n := s.comp.available()
+	for i := uint32(0); i < n; i++ {
+		d.txFree = append(d.txFree, *s.comp.addr(cons + i))
+	}
+	atomic.StoreUint32(s.comp.consumer, cons+n)
+}
+
+// Conn is a point-to-point UDP connection over an XDP socket. It implements the batch read and
+// write operations used by the router.
+type Conn struct {
+	dev    *device
+	kernel conn.Conn
+	local  netip.AddrPort
+	remote netip.AddrPort
+	// remoteAddr is returned as the source of every message. It is never modified.
+	remoteAddr *net.UDPAddr
+	hdr        atomic.Pointer[headerTemplate]
+	rxQ        chan rxFrame
+	closeOnce  sync.Once
+	closed     chan struct{}
+	// recycle is the scratch space of ReadBatch for the frames to return to the fill ring.
+	recycle []uint64
+}
+
+// New opens an AF_XDP connection between the given local and remote addresses. It fails if
+// AF_XDP is not usable for that link, in which case the caller is expected to fall back to a
+// regular UDP socket.
+func New(local, remote netip.AddrPort, cfg Config) (conn.Conn, error) {
+	if !local.IsValid() || !remote.IsValid() {
+		return nil, serrors.New("both local and remote addresses are required",
+			"local", local, "remote", remote)
+	}
+	if local.Addr().Is4() != remote.Addr().Is4() {
+		return nil, serrors.New("address families do not match", "local", local, "remote", remote)
+	}
+	hdrLen := ethHdrLen + ipv4HdrLen + udpHdrLen
+	if local.Addr().Is6() {
+		hdrLen = ethHdrLen + ipv6HdrLen + udpHdrLen
+	}
+	if cfg.MTU > frameSize-hdrLen {
+		return nil, serrors.New("MTU does not fit in an AF_XDP frame",
+			"mtu", cfg.MTU, "max", frameSize-hdrLen)
+	}
+	link, nextHop, err := routeTo(local, remote)
+	if err != nil {
+		return nil, err
+	}
+	// The kernel socket reserves the port and is used to prompt neighbor resolution.
+	kernel, err := conn.New(local, remote, &cfg.Socket)
+	if err != nil {
+		return nil, err
+	}
+	dstMAC, err := resolve(kernel, link, nextHop, resolveTimeout)
+	if err != nil {
+		kernel.Close()
+		return nil, err
+	}
+	c := &Conn{
+		kernel:     kernel,
+		local:      local,
+		remote:     remote,
+		remoteAddr: net.UDPAddrFromAddrPort(remote),
+		rxQ:        make(chan rxFrame, connQueueSize),
+		closed:     make(chan struct{}),
+	}
+	c.hdr.Store(newHeaderTemplate(link.Attrs().HardwareAddr, dstMAC, local, remote))
+
+	devicesMtx.Lock()
+	defer devicesMtx.Unlock()
+	key := deviceKey{ifindex: link.Attrs().Index, queue: cfg.Queue}
+	d, ok := devices[key]
+	if !ok {
+		if d, err = openDevice(key, cfg); err != nil {
+			kernel.Close()
+			return nil, err
+		}
+		devices[key] = d
+	}
+	if err := d.addConn(c); err != nil {
+		if len(*d.conns.Load()) == 0 {
+			d.close()
+			delete(devices, key)
+		}
+		kernel.Close()
+		return nil, err
+	}
+	c.dev = d
+	go func() {
+		defer log.HandlePanic()
+		c.refresh(link, nextHop)
+	}()
+	return c, nil
+}
+
+// routeTo returns the network device through which remote is reached from local, and the
+// address of the next hop on that device.
+func routeTo(local, remote netip.AddrPort) (netlink.Link, netip.Addr, error) {
+	routes, err := netlink.RouteGetWithOptions(remote.Addr().AsSlice(),
+		&netlink.RouteGetOptions{SrcAddr: local.Addr().AsSlice()})
+	if err != nil || len(routes) == 0 {
+		return nil, netip.Addr{}, serrors.Wrap("looking up route", err, "remote", remote)
+	}
+	r := routes[0]
+	link, err := netlink.LinkByIndex(r.LinkIndex)
+	if err != nil {
+		return nil, netip.Addr{}, serrors.Wrap("looking up network device", err,
+			"ifindex", r.LinkIndex)
+	}
+	if len(link.Attrs().HardwareAddr) != 6 {
+		return nil, netip.Addr{}, serrors.New("network device is not an Ethernet device",
+			"device", link.Attrs().Name)
+	}
+	nextHop := remote.Addr()
+	if gw, ok := netip.AddrFromSlice(r.Gw); ok && r.Gw != nil {
+		nextHop = gw.Unmap()
+	}
+	return link, nextHop, nil
+}
+
+// resolve returns the Ethernet address of the next hop. If the kernel does not know it yet, an
+// empty datagram is sent through the kernel socket to trigger address resolution.
+func resolve(kernel conn.Conn, link netlink.Link, nextHop netip.Addr,
+	timeout time.Duration) (net.HardwareAddr, error) {
+
+	if mac, ok := lookupNeighbor(link, nextHop); ok {
+		return mac, nil
+	}
+	if _, err := kernel.WriteTo(nil, netip.AddrPort{}); err != nil {
+		return nil, serrors.Wrap("prompting neighbor resolution", err)
+	}
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		if mac, ok := lookupNeighbor(link, nextHop); ok {
+			return mac, nil
+		}
+	}
+	return nil, serrors.New("next hop not resolved", "next_hop", nextHop,
+		"device", link.Attrs().Name)
+}
+
+func lookupNeighbor(link netlink.Link, ip netip.Addr) (net.HardwareAddr, bool) {
+	family := netlink.FAMILY_V6
+	if ip.Is4() {
+		family = netlink.FAMILY_V4
+	}
+	neighs, err := netlink.NeighList(link.Attrs().Index, family)
+	if err != nil {
+		return nil, false
+	}
+	usable := netlink.NUD_REACHABLE | netlink.NUD_STALE | netlink.NUD_DELAY |
+		netlink.NUD_PROBE | netlink.NUD_PERMANENT
+	for _, n := range neighs {
+		nip, ok := netip.AddrFromSlice(n.IP)
+		if !ok || nip.Unmap() != ip || n.State&usable == 0 || len(n.HardwareAddr) != 6 {
+			continue
+		}
+		return n.HardwareAddr, true
+	}
+	return nil, false
+}
+
+// refresh keeps the Ethernet address of the next hop up to date.
+func (c *Conn) refresh(link netlink.Link, nextHop netip.Addr) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.closed:
+			return
+		case <-ticker.C:
+		}
+		mac, ok := lookupNeighbor(link, nextHop)
+		if !ok {
+			continue
+		}
+		if string(c.hdr.Load().hdr[0:6]) != string(mac) {
+			log.Info("Next hop Ethernet address changed", "next_hop", nextHop, "mac", mac)
+			c.hdr.Store(newHeaderTemplate(link.Attrs().HardwareAddr, mac, c.local, c.remote))
+		}
+	}
+}
+
+// ReadBatch reads up to len(msgs) datagrams. It blocks until at least one is available.
+// ReadBatch must not be called concurrently.
+func (c *Conn) ReadBatch(msgs conn.Messages) (int, error) {
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+	d := c.dev
+	var f rxFrame
+	select {
+	case f = <-c.rxQ:
+	case <-c.closed:
+		return 0, net.ErrClosed
+	}
+	c.recycle = c.recycle[:0]
+	i := 0
+loop:
+	for ; i < len(msgs); i++ {
+		if i > 0 {
+			select {
+			case f = <-c.rxQ:
+			default:
+				break loop
+			}
+		}
+		payload := d.sock.umem[f.addr+uint64(f.off) : f.addr+uint64(f.off)+uint64(f.n)]
+		msgs[i].N = copy(msgs[i].Buffers[0], payload)
+		msgs[i].Addr = c.remoteAddr
+		c.recycle = append(c.recycle, f.addr)
+	}
+	d.refill(c.recycle)
+	return i, nil
+}
+
+// WriteBatch sends the given datagrams to the remote address. It returns the number of
+// datagrams that were queued for transmission. Flags are ignored.
+func (c *Conn) WriteBatch(msgs conn.Messages, _ int) (int, error) {
+	d := c.dev
+	hdr := c.hdr.Load()
+	d.txMtx.Lock()
+	defer d.txMtx.Unlock()
+	s := d.sock
+	d.reclaim()
+	if len(d.txFree) < len(msgs) {
+		// Let the kernel catch up, then try again once.
+		s.kick()
+		d.reclaim()
+	}
+	prod, free := s.tx.free()
+	n := min(len(msgs), len(d.txFree), int(free))
+	var err error
+	i := 0
+	for ; i < n; i++ {
+		addr := d.txFree[len(d.txFree)-1]
+		l, werr := hdr.writeFrame(s.umem[addr:addr+frameSize], msgs[i].Buffers[0])
+		if werr != nil {
+			err = werr
+			break
+		}
+		d.txFree = d.txFree[:len(d.txFree)-1]
+		desc := s.tx.desc(prod + uint32(i))
+		desc.Addr = addr
+		desc.Len = uint32(l)
+		desc.Options = 0
+		msgs[i].N = len(msgs[i].Buffers[0])
+	}
+	if i > 0 {
+		atomic.StoreUint32(s.tx.producer, prod+uint32(i))
+		s.kick()
+	}
+	return i, err
+}
+
+// WriteTo sends a single datagram to the remote address. The destination is ignored, as for a
+// connected socket.
+func (c *Conn) WriteTo(b []byte, _ netip.AddrPort) (int, error) {
+	msgs := conn.Messages{{Buffers: [][]byte{b}}}
+	if _, err := c.WriteBatch(msgs, 0); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// LocalAddr returns the local address of the connection.
+func (c *Conn) LocalAddr() netip.AddrPort {
+	return c.local
+}
+
+// RemoteAddr returns the remote address of the connection.
+func (c *Conn) RemoteAddr() netip.AddrPort {
+	return c.remote
+}
+
+// SetReadDeadline is not supported and always returns an error.
+func (c *Conn) SetReadDeadline(time.Time) error {
+	return errNoDeadlines
+}
+
+// SetWriteDeadline is not supported and always returns an error.
+func (c *Conn) SetWriteDeadline(time.Time) error {
+	return errNoDeadlines
+}
+
+// SetDeadline is not supported and always returns an error.
+func (c *Conn) SetDeadline(time.Time) error {
+	return errNoDeadlines
+}
+
+// Close closes the connection. The XDP socket is closed, and the XDP program detached, once the
+// last connection on the device is closed.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		devicesMtx.Lock()
+		defer devicesMtx.Unlock()
+		if c.dev.removeConn(c) == 0 {
+			c.dev.close()
+			delete(devices, c.dev.key)
+		}
+		c.kernel.Close()
+	})
+	return nil
+}
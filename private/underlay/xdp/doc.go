@@ -0,0 +1,37 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package xdp implements an AF_XDP based underlay connection for point-to-point UDP/IP links.
+//
+// An XDP socket receives Ethernet frames directly from a NIC receive queue, bypassing the
+// kernel's network stack. A small XDP program is attached to the network device; it redirects
+// UDP datagrams addressed to one of the ports registered by this package to the XDP socket and
+// lets everything else (ARP, neighbor discovery, management traffic, ...) pass to the kernel.
+//
+// A Conn takes the place of a connected UDP socket: it only accepts datagrams from the configured
+// remote address, and it sends datagrams to that address, framing them itself. The Ethernet
+// address of the next hop is obtained from the kernel's neighbor table. A regular UDP socket is
+// kept bound to the local address, so that the port remains reserved and the kernel can be
+// prompted to resolve the next hop.
+//
+// Limitations:
+//   - Linux only. Other platforms get an error from New.
+//   - Frames must fit in a single UMEM chunk (see frameSize). Jumbo frames are not supported;
+//     New fails for links with an MTU that does not fit.
+//   - IPv4 options, IPv6 extension headers, VLAN tags and IP fragments are not supported. Such
+//     packets are passed to the kernel and are not seen by the Conn.
+//   - All connections bound to the same network device and queue share a single XDP socket.
+//   - Only the configured NIC queue is read. Datagrams that the NIC steers to other queues reach
+//     the regular UDP socket of the Conn, which is never read, and are lost.
+package xdp
@@ -0,0 +1,208 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xdp
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+)
+
+const (
+	ethHdrLen  = 14
+	ipv4HdrLen = 20
+	ipv6HdrLen = 40
+	udpHdrLen  = 8
+
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86DD
+	protoUDP      = 17
+
+	defaultTTL = 64
+)
+
+var (
+	errNotUDP        = errors.New("not a UDP/IP frame")
+	errTruncated     = errors.New("truncated frame")
+	errFragmented    = errors.New("fragmented IP packet")
+	errFrameTooLarge = errors.New("payload does not fit in frame")
+)
+
+// parseUDPFrame extracts the UDP addressing and the payload from an Ethernet frame. Only plain
+// UDP over IPv4 (without options) or IPv6 (without extension headers) is accepted. The returned
+// payload aliases the frame.
+func parseUDPFrame(frame []byte) (src, dst netip.AddrPort, payload []byte, err error) {
+	if len(frame) < ethHdrLen {
+		return src, dst, nil, errTruncated
+	}
+	var srcIP, dstIP netip.Addr
+	var l4 []byte
+	switch binary.BigEndian.Uint16(frame[12:14]) {
+	case etherTypeIPv4:
+		ip := frame[ethHdrLen:]
+		if len(ip) < ipv4HdrLen {
+			return src, dst, nil, errTruncated
+		}
+		if ip[0] != 0x45 || ip[9] != protoUDP {
+			return src, dst, nil, errNotUDP
+		}
+		if binary.BigEndian.Uint16(ip[6:8])&0x3fff != 0 {
+			return src, dst, nil, errFragmented
+		}
+		totalLen := int(binary.BigEndian.Uint16(ip[2:4]))
+		if totalLen < ipv4HdrLen || totalLen > len(ip) {
+			return src, dst, nil, errTruncated
+		}
+		srcIP = netip.AddrFrom4([4]byte(ip[12:16]))
+		dstIP = netip.AddrFrom4([4]byte(ip[16:20]))
+		l4 = ip[ipv4HdrLen:totalLen]
+	case etherTypeIPv6:
+		ip := frame[ethHdrLen:]
+		if len(ip) < ipv6HdrLen {
+			return src, dst, nil, errTruncated
+		}
+		if ip[0]>>4 != 6 || ip[6] != protoUDP {
+			return src, dst, nil, errNotUDP
+		}
+		payloadLen := int(binary.BigEndian.Uint16(ip[4:6]))
+		if ipv6HdrLen+payloadLen > len(ip) {
+			return src, dst, nil, errTruncated
+		}
+		srcIP = netip.AddrFrom16([16]byte(ip[8:24]))
+		dstIP = netip.AddrFrom16([16]byte(ip[24:40]))
+		l4 = ip[ipv6HdrLen : ipv6HdrLen+payloadLen]
+	default:
+		return src, dst, nil, errNotUDP
+	}
+	if len(l4) < udpHdrLen {
+		return src, dst, nil, errTruncated
+	}
+	udpLen := int(binary.BigEndian.Uint16(l4[4:6]))
+	if udpLen < udpHdrLen || udpLen > len(l4) {
+		return src, dst, nil, errTruncated
+	}
+	src = netip.AddrPortFrom(srcIP, binary.BigEndian.Uint16(l4[0:2]))
+	dst = netip.AddrPortFrom(dstIP, binary.BigEndian.Uint16(l4[2:4]))
+	return src, dst, l4[udpHdrLen:udpLen], nil
+}
+
+// headerTemplate contains the pre-serialized Ethernet, IP and UDP headers of the frames sent over
+// one link. Only the length fields and checksums change from one frame to the next.
+type headerTemplate struct {
+	hdr []byte
+	v4  bool
+	// pseudoSum is the partial one's complement sum of the UDP pseudo header (without the length)
+	// and of the UDP ports. It is used for IPv6, where the UDP checksum is mandatory.
+	pseudoSum uint32
+}
+
+func newHeaderTemplate(srcMAC, dstMAC net.HardwareAddr,
+	src, dst netip.AddrPort) *headerTemplate {
+
+	v4 := src.Addr().Is4()
+	ipLen := ipv6HdrLen
+	etherType := uint16(etherTypeIPv6)
+	if v4 {
+		ipLen = ipv4HdrLen
+		etherType = etherTypeIPv4
+	}
+	hdr := make([]byte, ethHdrLen+ipLen+udpHdrLen)
+	copy(hdr[0:6], dstMAC)
+	copy(hdr[6:12], srcMAC)
+	binary.BigEndian.PutUint16(hdr[12:14], etherType)
+
+	ip := hdr[ethHdrLen : ethHdrLen+ipLen]
+	if v4 {
+		ip[0] = 0x45
+		binary.BigEndian.PutUint16(ip[6:8], 0x4000) // Don't fragment.
+		ip[8] = defaultTTL
+		ip[9] = protoUDP
+		s, d := src.Addr().As4(), dst.Addr().As4()
+		copy(ip[12:16], s[:])
+		copy(ip[16:20], d[:])
+	} else {
+		ip[0] = 0x60
+		ip[6] = protoUDP
+		ip[7] = defaultTTL
+		s, d := src.Addr().As16(), dst.Addr().As16()
+		copy(ip[8:24], s[:])
+		copy(ip[24:40], d[:])
+	}
+	udp := hdr[ethHdrLen+ipLen:]
+	binary.BigEndian.PutUint16(udp[0:2], src.Port())
+	binary.BigEndian.PutUint16(udp[2:4], dst.Port())
+
+	t := &headerTemplate{hdr: hdr, v4: v4}
+	if !v4 {
+		t.pseudoSum = sum16(ip[8:40], 0) + protoUDP + uint32(src.Port()) + uint32(dst.Port())
+	}
+	return t
+}
+
+// writeFrame writes a complete frame carrying the given payload to buf and returns the
+// length of the frame.
+func (t *headerTemplate) writeFrame(buf, payload []byte) (int, error) {
+	n := len(t.hdr) + len(payload)
+	if n > len(buf) || len(payload)+udpHdrLen > 0xffff {
+		return 0, errFrameTooLarge
+	}
+	copy(buf, t.hdr)
+	copy(buf[len(t.hdr):], payload)
+	udpLen := uint16(udpHdrLen + len(payload))
+	if t.v4 {
+		ip := buf[ethHdrLen : ethHdrLen+ipv4HdrLen]
+		binary.BigEndian.PutUint16(ip[2:4], uint16(ipv4HdrLen)+udpLen)
+		binary.BigEndian.PutUint16(ip[10:12], 0)
+		binary.BigEndian.PutUint16(ip[10:12], ^fold(sum16(ip, 0)))
+		udp := buf[ethHdrLen+ipv4HdrLen:]
+		binary.BigEndian.PutUint16(udp[4:6], udpLen)
+		// The UDP checksum is optional over IPv4.
+		binary.BigEndian.PutUint16(udp[6:8], 0)
+		return n, nil
+	}
+	ip := buf[ethHdrLen : ethHdrLen+ipv6HdrLen]
+	binary.BigEndian.PutUint16(ip[4:6], udpLen)
+	udp := buf[ethHdrLen+ipv6HdrLen : n]
+	binary.BigEndian.PutUint16(udp[4:6], udpLen)
+	binary.BigEndian.PutUint16(udp[6:8], 0)
+	// The pseudo header carries the UDP length once, the UDP header carries it a second time.
+	csum := ^fold(sum16(udp[udpHdrLen:], t.pseudoSum+2*uint32(udpLen)))
+	if csum == 0 {
+		csum = 0xffff
+	}
+	binary.BigEndian.PutUint16(udp[6:8], csum)
+	return n, nil
+}
+
+// sum16 adds the big-endian 16 bit words of b to the given partial sum.
+func sum16(b []byte, sum uint32) uint32 {
+	for len(b) >= 2 {
+		sum += uint32(b[0])<<8 | uint32(b[1])
+		b = b[2:]
+	}
+	if len(b) == 1 {
+		sum += uint32(b[0]) << 8
+	}
+	return sum
+}
+
+// fold folds a partial sum into a 16 bit one's complement sum.
+func fold(sum uint32) uint16 {
+	for sum > 0xffff {
+		sum = sum>>16 + sum&0xffff
+	}
+	return uint16(sum)
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xdp
+
+import (
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameRoundTrip(t *testing.T) {
+	srcMAC := net.HardwareAddr{0x02, 0, 0, 0, 0, 0x01}
+	dstMAC := net.HardwareAddr{0x02, 0, 0, 0, 0, 0x02}
+	testCases := map[string]struct {
+		src, dst netip.AddrPort
+		ipLen    int
+	}{
+		"IPv4": {
+			src:   netip.MustParseAddrPort("192.0.2.1:50000"),
+			dst:   netip.MustParseAddrPort("192.0.2.2:50001"),
+			ipLen: ipv4HdrLen,
+		},
+		"IPv6": {
+			src:   netip.MustParseAddrPort("[2001:db8::1]:50000"),
+			dst:   netip.MustParseAddrPort("[2001:db8::2]:50001"),
+			ipLen: ipv6HdrLen,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			payload := []byte("some SCION packet, odd length")
+			buf := make([]byte, 256)
+			tmpl := newHeaderTemplate(srcMAC, dstMAC, tc.src, tc.dst)
+			n, err := tmpl.writeFrame(buf, payload)
+			require.NoError(t, err)
+			assert.Equal(t, ethHdrLen+tc.ipLen+udpHdrLen+len(payload), n)
+			assert.Equal(t, []byte(dstMAC), buf[0:6])
+			assert.Equal(t, []byte(srcMAC), buf[6:12])
+
+			src, dst, p, err := parseUDPFrame(buf[:n])
+			require.NoError(t, err)
+			assert.Equal(t, tc.src, src)
+			assert.Equal(t, tc.dst, dst)
+			assert.Equal(t, payload, p)
+
+			ip := buf[ethHdrLen : ethHdrLen+tc.ipLen]
+			udp := buf[ethHdrLen+tc.ipLen : n]
+			if tc.src.Addr().Is4() {
+				assert.Equal(t, uint16(0xffff), fold(sum16(ip, 0)), "IPv4 header checksum")
+				return
+			}
+			// Verify the UDP checksum over the pseudo header and the datagram.
+			sum := sum16(ip[8:40], 0) + protoUDP + uint32(len(udp))
+			assert.Equal(t, uint16(0xffff), fold(sum16(udp, sum)), "UDP checksum")
+		})
+	}
+}
+
+func TestWriteFrameTooLarge(t *testing.T) {
+	tmpl := newHeaderTemplate(make(net.HardwareAddr, 6), make(net.HardwareAddr, 6),
+		netip.MustParseAddrPort("192.0.2.1:1"), netip.MustParseAddrPort("192.0.2.2:2"))
+	_, err := tmpl.writeFrame(make([]byte, 64), make([]byte, 64))
+	assert.ErrorIs(t, err, errFrameTooLarge)
+}
+
+func TestParseUDPFrameRejects(t *testing.T) {
+	tmpl := newHeaderTemplate(make(net.HardwareAddr, 6), make(net.HardwareAddr, 6),
+		netip.MustParseAddrPort("192.0.2.1:1"), netip.MustParseAddrPort("192.0.2.2:2"))
+	valid := make([]byte, 128)
+	n, err := tmpl.writeFrame(valid, []byte("payload"))
+	require.NoError(t, err)
+	valid = valid[:n]
+
+	testCases := map[string]struct {
+		modify func(b []byte) []byte
+		err    error
+	}{
+		"ARP": {
+			modify: func(b []byte) []byte {
+				binary.BigEndian.PutUint16(b[12:14], 0x0806)
+				return b
+			},
+			err: errNotUDP,
+		},
+		"IPv4 options": {
+			modify: func(b []byte) []byte {
+				b[ethHdrLen] = 0x46
+				return b
+			},
+			err: errNotUDP,
+		},
+		"TCP": {
+			modify: func(b []byte) []byte {
+				b[ethHdrLen+9] = 6
+				return b
+			},
+			err: errNotUDP,
+		},
+		"fragment": {
+			modify: func(b []byte) []byte {
+				binary.BigEndian.PutUint16(b[ethHdrLen+6:ethHdrLen+8], 0x2000)
+				return b
+			},
+			err: errFragmented,
+		},
+		"truncated": {
+			modify: func(b []byte) []byte { return b[:len(b)-1] },
+			err:    errTruncated,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := tc.modify(append([]byte(nil), valid...))
+			_, _, _, err := parseUDPFrame(b)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
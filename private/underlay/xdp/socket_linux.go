@@ -0,0 +1,222 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package xdp
+
+import (
+	"sync/atomic"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+const (
+	// frameSize is the size of one UMEM chunk. It bounds the size of a frame.
+	frameSize = 4096
+	// numFrames is the number of UMEM chunks. The first half is used for reception and the
+	// second half for transmission.
+	numFrames = 4096
+	// ringSize is the number of entries of each of the four rings. It must be a power of two.
+	ringSize = numFrames / 2
+)
+
+// ring is a single-producer/single-consumer ring shared with the kernel.
+type ring struct {
+	mem      []byte
+	producer *uint32
+	consumer *uint32
+	flags    *uint32
+	descs    unsafe.Pointer
+	mask     uint32
+}
+
+func newRing(fd int, pgoff int64, off unix.XDPRingOffset, entrySize uintptr) (ring, error) {
+	length := int(off.Desc) + ringSize*int(entrySize)
+	mem, err := unix.Mmap(fd, pgoff, length,
+		unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_POPULATE)
+	if err != nil {
+		return ring{}, serrors.Wrap("mapping XDP ring", err, "pgoff", pgoff)
+	}
+	return ring{
+		mem:      mem,
+		producer: (*uint32)(unsafe.Pointer(&mem[off.Producer])),
+		consumer: (*uint32)(unsafe.Pointer(&mem[off.Consumer])),
+		flags:    (*uint32)(unsafe.Pointer(&mem[off.Flags])),
+		descs:    unsafe.Pointer(&mem[off.Desc]),
+		mask:     ringSize - 1,
+	}, nil
+}
+
+func (r *ring) needsWakeup() bool {
+	return atomic.LoadUint32(r.flags)&unix.XDP_RING_NEED_WAKEUP != 0
+}
+
+// addr returns a pointer to the i-th entry of a fill or completion ring.
+func (r *ring) addr(i uint32) *uint64 {
+	return (*uint64)(unsafe.Add(r.descs, uintptr(i&r.mask)*8))
+}
+
+// desc returns a pointer to the i-th entry of an rx or tx ring.
+func (r *ring) desc(i uint32) *unix.XDPDesc {
+	return (*unix.XDPDesc)(unsafe.Add(r.descs, uintptr(i&r.mask)*unsafe.Sizeof(unix.XDPDesc{})))
+}
+
+// available returns the number of entries that can be consumed from a ring that the kernel
+// produces into.
+func (r *ring) available() (uint32, uint32) {
+	cons := atomic.LoadUint32(r.consumer)
+	return cons, atomic.LoadUint32(r.producer) - cons
+}
+
+// free returns the number of entries that can be produced into a ring that the kernel consumes.
+func (r *ring) free() (uint32, uint32) {
+	prod := atomic.LoadUint32(r.producer)
+	return prod, ringSize - (prod - atomic.LoadUint32(r.consumer))
+}
+
+func (r *ring) unmap() {
+	if r.mem != nil {
+		_ = unix.Munmap(r.mem)
+	}
+}
+
+// socket is an XDP socket together with its UMEM and rings.
+type socket struct {
+	fd   int
+	umem []byte
+	fill ring
+	comp ring
+	rx   ring
+	tx   ring
+}
+
+func newSocket(ifindex, queue int, zeroCopy bool) (*socket, error) {
+	fd, err := unix.Socket(unix.AF_XDP, unix.SOCK_RAW, 0)
+	if err != nil {
+		return nil, serrors.Wrap("opening XDP socket", err)
+	}
+	s := &socket{fd: fd}
+	if err := s.init(ifindex, queue, zeroCopy); err != nil {
+		s.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *socket) init(ifindex, queue int, zeroCopy bool) error {
+	var err error
+	s.umem, err = unix.Mmap(-1, 0, numFrames*frameSize,
+		unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANONYMOUS|unix.MAP_POPULATE)
+	if err != nil {
+		return serrors.Wrap("allocating UMEM", err)
+	}
+	reg := unix.XDPUmemReg{
+		Addr: uint64(uintptr(unsafe.Pointer(&s.umem[0]))),
+		Len:  uint64(len(s.umem)),
+		Size: frameSize,
+	}
+	if err := setsockopt(s.fd, unix.XDP_UMEM_REG, unsafe.Pointer(&reg),
+		unsafe.Sizeof(reg)); err != nil {
+		return serrors.Wrap("registering UMEM", err)
+	}
+	for _, opt := range []int{unix.XDP_UMEM_FILL_RING, unix.XDP_UMEM_COMPLETION_RING,
+		unix.XDP_RX_RING, unix.XDP_TX_RING} {
+
+		if err := unix.SetsockoptInt(s.fd, unix.SOL_XDP, opt, ringSize); err != nil {
+			return serrors.Wrap("sizing XDP ring", err, "option", opt)
+		}
+	}
+	var off unix.XDPMmapOffsets
+	size := uint32(unsafe.Sizeof(off))
+	_, _, errno := unix.Syscall6(unix.SYS_GETSOCKOPT, uintptr(s.fd), unix.SOL_XDP,
+		unix.XDP_MMAP_OFFSETS, uintptr(unsafe.Pointer(&off)), uintptr(unsafe.Pointer(&size)), 0)
+	if errno != 0 {
+		return serrors.Wrap("getting XDP ring offsets", errno)
+	}
+	if s.fill, err = newRing(s.fd, unix.XDP_UMEM_PGOFF_FILL_RING, off.Fr, 8); err != nil {
+		return err
+	}
+	if s.comp, err = newRing(s.fd, unix.XDP_UMEM_PGOFF_COMPLETION_RING, off.Cr, 8); err != nil {
+		return err
+	}
+	descSize := unsafe.Sizeof(unix.XDPDesc{})
+	if s.rx, err = newRing(s.fd, unix.XDP_PGOFF_RX_RING, off.Rx, descSize); err != nil {
+		return err
+	}
+	if s.tx, err = newRing(s.fd, unix.XDP_PGOFF_TX_RING, off.Tx, descSize); err != nil {
+		return err
+	}
+
+	// Hand all the reception frames to the kernel.
+	prod, _ := s.fill.free()
+	for i := uint32(0); i < ringSize; i++ {
+		*s.fill.addr(prod + i) = uint64(i) * frameSize
+	}
+	atomic.StoreUint32(s.fill.producer, prod+ringSize)
+
+	flags := uint16(unix.XDP_USE_NEED_WAKEUP | unix.XDP_COPY)
+	if zeroCopy {
+		flags = unix.XDP_USE_NEED_WAKEUP | unix.XDP_ZEROCOPY
+	}
+	sa := &unix.SockaddrXDP{Flags: flags, Ifindex: uint32(ifindex), QueueID: uint32(queue)}
+	if err := unix.Bind(s.fd, sa); err != nil {
+		return serrors.Wrap("binding XDP socket", err, "ifindex", ifindex, "queue", queue)
+	}
+	return nil
+}
+
+// frame returns the UMEM chunk at the given address, limited to n bytes.
+func (s *socket) frame(addr uint64, n uint32) []byte {
+	return s.umem[addr : addr+uint64(n)]
+}
+
+// kick wakes the kernel up to process the tx ring, if it asked for it.
+func (s *socket) kick() {
+	if !s.tx.needsWakeup() {
+		return
+	}
+	_, _, _ = unix.Syscall6(unix.SYS_SENDTO, uintptr(s.fd), 0, 0, unix.MSG_DONTWAIT, 0, 0)
+}
+
+// wakeFill wakes the kernel up to pick up new entries of the fill ring, if it asked for it.
+func (s *socket) wakeFill() {
+	if !s.fill.needsWakeup() {
+		return
+	}
+	_, _, _ = unix.Syscall6(unix.SYS_RECVFROM, uintptr(s.fd), 0, 0, unix.MSG_DONTWAIT, 0, 0)
+}
+
+func (s *socket) close() {
+	s.rx.unmap()
+	s.tx.unmap()
+	s.fill.unmap()
+	s.comp.unmap()
+	unix.Close(s.fd)
+	if s.umem != nil {
+		_ = unix.Munmap(s.umem)
+	}
+}
+
+func setsockopt(fd, opt int, val unsafe.Pointer, size uintptr) error {
+	_, _, errno := unix.Syscall6(unix.SYS_SETSOCKOPT, uintptr(fd), unix.SOL_XDP, uintptr(opt),
+		uintptr(val), size, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
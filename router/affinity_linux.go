@@ -0,0 +1,81 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package router
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// usableCPUs returns the CPUs that the router may run on, with their NUMA nodes.
+func usableCPUs() ([]cpuInfo, error) {
+	var set unix.CPUSet
+	if err := unix.SchedGetaffinity(0, &set); err != nil {
+		return nil, serrors.Wrap("getting CPU affinity", err)
+	}
+	var cpus []cpuInfo
+	for id := 0; id < 8*int(unsafe.Sizeof(set)); id++ {
+		if set.IsSet(id) {
+			cpus = append(cpus, cpuInfo{id: id, node: cpuNode(id)})
+		}
+	}
+	if len(cpus) == 0 {
+		return nil, serrors.New("no usable CPU")
+	}
+	return cpus, nil
+}
+
+// cpuNode returns the NUMA node of the given CPU. It is 0 on machines without NUMA.
+func cpuNode(cpu int) int {
+	entries, err := os.ReadDir(fmt.Sprintf("/sys/devices/system/cpu/cpu%d", cpu))
+	if err != nil {
+		return 0
+	}
+	for _, e := range entries {
+		if n, ok := strings.CutPrefix(e.Name(), "node"); ok {
+			if node, err := strconv.Atoi(n); err == nil {
+				return node
+			}
+		}
+	}
+	return 0
+}
+
+// pinThread locks the calling goroutine to its thread, and restricts the thread to the given
+// CPUs. The goroutine keeps the thread until it exits.
+func pinThread(cpus ...int) error {
+	if len(cpus) == 0 {
+		return nil
+	}
+	var set unix.CPUSet
+	for _, cpu := range cpus {
+		set.Set(cpu)
+	}
+	runtime.LockOSThread()
+	if err := unix.SchedSetaffinity(0, &set); err != nil {
+		return serrors.Wrap("setting CPU affinity", err, "cpus", cpus)
+	}
+	return nil
+}
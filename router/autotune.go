@@ -0,0 +1,126 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// autotuneInterval is the interval at which the receive drops of the connections are checked.
+const autotuneInterval = 5 * time.Second
+
+// tunableConn is a connection whose receive buffer can be grown when it overflows.
+type tunableConn interface {
+	ReceiveDrops() (uint64, error)
+	ReceiveBufferSize() (int, error)
+	SetReceiveBufferSize(int) (int, error)
+}
+
+// receiveBufferTuner doubles the receive buffers of the connections that dropped datagrams
+// since the last check, up to a maximum size.
+type receiveBufferTuner struct {
+	maxSize int
+	// drops is the number of drops of each connection at the last check.
+	drops map[tunableConn]uint64
+	// capped holds the connections whose buffer cannot be grown further.
+	capped map[tunableConn]bool
+}
+
+func newReceiveBufferTuner(maxSize int) *receiveBufferTuner {
+	return &receiveBufferTuner{
+		maxSize: maxSize,
+		drops:   make(map[tunableConn]uint64),
+		capped:  make(map[tunableConn]bool),
+	}
+}
+
+// check grows the receive buffers of the given connections that dropped datagrams. The state
+// of the connections that are gone is forgotten.
+func (t *receiveBufferTuner) check(conns []tunableConn) {
+	seen := make(map[tunableConn]bool, len(conns))
+	for _, c := range conns {
+		seen[c] = true
+		drops, err := c.ReceiveDrops()
+		if err != nil {
+			continue
+		}
+		prev, known := t.drops[c]
+		t.drops[c] = drops
+		if !known || drops <= prev || t.capped[c] {
+			continue
+		}
+		size, err := c.ReceiveBufferSize()
+		if err != nil {
+			continue
+		}
+		target := min(2*size, t.maxSize)
+		if target <= size {
+			t.capped[c] = true
+			log.Info("Receive buffer dropping datagrams at maximum size", "size", size,
+				"drops", drops-prev)
+			continue
+		}
+		actual, err := c.SetReceiveBufferSize(target)
+		if err != nil {
+			log.Info("Error growing receive buffer", "size", target, "err", err)
+			t.capped[c] = true
+			continue
+		}
+		log.Info("Grew receive buffer after drops", "drops", drops-prev, "previous", size,
+			"size", actual)
+		if actual <= size {
+			// The operating system caps the size (e.g., at net.core.rmem_max on Linux).
+			t.capped[c] = true
+		}
+	}
+	for c := range t.drops {
+		if !seen[c] {
+			delete(t.drops, c)
+			delete(t.capped, c)
+		}
+	}
+}
+
+// runReceiveBufferAutotuning periodically grows the receive buffers of the connections that
+// overflow, until the context is done.
+func (d *DataPlane) runReceiveBufferAutotuning(ctx context.Context) {
+	tuner := newReceiveBufferTuner(d.RunConfig.ReceiveBufferMaxSize)
+	ticker := time.NewTicker(autotuneInterval)
+	defer ticker.Stop()
+	for {
+		tuner.check(d.tunableConns())
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// tunableConns returns the connections of the underlay whose receive buffer can be tuned.
+func (d *DataPlane) tunableConns() []tunableConn {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	var conns []tunableConn
+	for _, u := range d.underlay.Connections() {
+		if c, ok := u.Conn().(tunableConn); ok {
+			conns = append(conns, c)
+		}
+	}
+	return conns
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testTunableConn is a connection with a receive buffer capped at maxSize.
+type testTunableConn struct {
+	drops   uint64
+	size    int
+	maxSize int
+}
+
+func (c *testTunableConn) ReceiveDrops() (uint64, error)   { return c.drops, nil }
+func (c *testTunableConn) ReceiveBufferSize() (int, error) { return c.size, nil }
+
+func (c *testTunableConn) SetReceiveBufferSize(size int) (int, error) {
+	c.size = min(size, c.maxSize)
+	return c.size, nil
+}
+
+func TestReceiveBufferTuner(t *testing.T) {
+	t.Run("buffers grow on drops up to the maximum", func(t *testing.T) {
+		c := &testTunableConn{drops: 10, size: 1000, maxSize: 1 << 20}
+		tuner := newReceiveBufferTuner(3000)
+		conns := []tunableConn{c}
+
+		// The drops before the first check do not count.
+		tuner.check(conns)
+		assert.Equal(t, 1000, c.size)
+		tuner.check(conns)
+		assert.Equal(t, 1000, c.size)
+
+		c.drops++
+		tuner.check(conns)
+		assert.Equal(t, 2000, c.size)
+		c.drops++
+		tuner.check(conns)
+		assert.Equal(t, 3000, c.size)
+		c.drops++
+		tuner.check(conns)
+		assert.Equal(t, 3000, c.size)
+		assert.True(t, tuner.capped[c])
+	})
+	t.Run("buffers capped by the system stop growing", func(t *testing.T) {
+		c := &testTunableConn{size: 1000, maxSize: 1000}
+		tuner := newReceiveBufferTuner(1 << 20)
+		conns := []tunableConn{c}
+		tuner.check(conns)
+		c.drops++
+		tuner.check(conns)
+		assert.Equal(t, 1000, c.size)
+		assert.True(t, tuner.capped[c])
+	})
+	t.Run("connections that are gone are forgotten", func(t *testing.T) {
+		c := &testTunableConn{size: 1000, maxSize: 1000}
+		tuner := newReceiveBufferTuner(1 << 20)
+		tuner.check([]tunableConn{c})
+		tuner.check(nil)
+		assert.Empty(t, tuner.drops)
+	})
+}
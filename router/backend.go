@@ -0,0 +1,124 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/netip"
+	"slices"
+	"sync"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/underlay/conn"
+	"github.com/scionproto/scion/router/config"
+)
+
+// DefaultBackend is the name of the backend that opens regular UDP sockets. It is always
+// available.
+const DefaultBackend = "udp"
+
+// Backend opens the underlay connections of the router. The default backend opens regular UDP
+// sockets; alternative dataplanes, such as AF_XDP, are implemented as further backends and
+// registered with RegisterBackend. One of them is selected by name at startup.
+type Backend interface {
+	// OpenInternal opens the connection of the internal interface, bound to the local address and
+	// not connected.
+	OpenInternal(local netip.AddrPort) (conn.Conn, error)
+	// OpenExternal opens the connection of an external interface between the local and the
+	// remote address, that carries packets of up to mtu bytes.
+	OpenExternal(local, remote netip.AddrPort, mtu int) (conn.Conn, error)
+}
+
+// BackendConfig is the configuration from which a backend is created.
+type BackendConfig struct {
+	// Socket configures the UDP sockets, of the default backend and of those that fall back to
+	// it.
+	Socket conn.Config
+	XDP    config.XDP
+}
+
+var (
+	backendsMtx sync.Mutex
+	backends    = map[string]func(BackendConfig) (Backend, error){
+		DefaultBackend: func(cfg BackendConfig) (Backend, error) {
+			return NewSocketBackend(cfg), nil
+		},
+	}
+)
+
+// RegisterBackend makes a backend available under the given name. It is meant to be called
+// from the init function of the package that implements the backend, and panics if the name is
+// already taken.
+func RegisterBackend(name string, newBackend func(BackendConfig) (Backend, error)) {
+	backendsMtx.Lock()
+	defer backendsMtx.Unlock()
+	if _, ok := backends[name]; ok {
+		panic("backend registered twice: " + name)
+	}
+	backends[name] = newBackend
+}
+
+// NewBackend creates the backend with the given name. If the name is empty, the default
+// backend is created.
+func NewBackend(name string, cfg BackendConfig) (Backend, error) {
+	if name == "" {
+		name = DefaultBackend
+	}
+	backendsMtx.Lock()
+	newBackend, ok := backends[name]
+	backendsMtx.Unlock()
+	if !ok {
+		return nil, serrors.New("unknown backend", "backend", name, "available", Backends())
+	}
+	b, err := newBackend(cfg)
+	if err != nil {
+		return nil, serrors.Wrap("creating backend", err, "backend", name)
+	}
+	return b, nil
+}
+
+// Backends returns the names of the registered backends, sorted.
+func Backends() []string {
+	backendsMtx.Lock()
+	defer backendsMtx.Unlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// socketBackend opens regular UDP sockets.
+type socketBackend struct {
+	cfg conn.Config
+}
+
+// NewSocketBackend returns the default backend, which opens regular UDP sockets. Other backends
+// can use it for the connections they do not handle themselves.
+func NewSocketBackend(cfg BackendConfig) Backend {
+	return socketBackend{cfg: cfg.Socket}
+}
+
+func (b socketBackend) OpenInternal(local netip.AddrPort) (conn.Conn, error) {
+	cfg := b.cfg
+	// Path MTU discovery only applies to the links to the neighbors.
+	cfg.PathMTUDiscovery = false
+	return conn.New(local, netip.AddrPort{}, &cfg)
+}
+
+func (b socketBackend) OpenExternal(local, remote netip.AddrPort, _ int) (conn.Conn, error) {
+	cfg := b.cfg
+	return conn.New(local, remote, &cfg)
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/underlay/conn"
+)
+
+// testBackend records the connections it is asked to open.
+type testBackend struct {
+	cfg      BackendConfig
+	internal []netip.AddrPort
+	external []netip.AddrPort
+}
+
+func (b *testBackend) OpenInternal(local netip.AddrPort) (conn.Conn, error) {
+	b.internal = append(b.internal, local)
+	return nil, nil
+}
+
+func (b *testBackend) OpenExternal(local, remote netip.AddrPort, mtu int) (conn.Conn, error) {
+	b.external = append(b.external, remote)
+	return nil, nil
+}
+
+func TestBackends(t *testing.T) {
+	var created *testBackend
+	RegisterBackend("test", func(cfg BackendConfig) (Backend, error) {
+		created = &testBackend{cfg: cfg}
+		return created, nil
+	})
+	t.Cleanup(func() {
+		backendsMtx.Lock()
+		defer backendsMtx.Unlock()
+		delete(backends, "test")
+	})
+
+	t.Run("registered backends are selected by name", func(t *testing.T) {
+		cfg := BackendConfig{Socket: conn.Config{ReceiveBufferSize: 1 << 20}}
+		b, err := NewBackend("test", cfg)
+		require.NoError(t, err)
+		assert.Same(t, created, b)
+		assert.Equal(t, cfg, created.cfg)
+		assert.Contains(t, Backends(), "test")
+	})
+	t.Run("the default backend is used without a name", func(t *testing.T) {
+		b, err := NewBackend("", BackendConfig{})
+		require.NoError(t, err)
+		assert.IsType(t, socketBackend{}, b)
+	})
+	t.Run("unknown backends are rejected", func(t *testing.T) {
+		_, err := NewBackend("dpdk", BackendConfig{})
+		assert.ErrorContains(t, err, "unknown backend")
+	})
+	t.Run("names are registered once", func(t *testing.T) {
+		assert.Panics(t, func() {
+			RegisterBackend(DefaultBackend, func(BackendConfig) (Backend, error) {
+				return nil, nil
+			})
+		})
+	})
+	t.Run("the connector opens the connections through the backend", func(t *testing.T) {
+		b := &testBackend{}
+		c := &Connector{Backend: b}
+		local := netip.MustParseAddrPort("192.0.2.1:50000")
+		remote := netip.MustParseAddrPort("192.0.2.2:50000")
+		_, err := c.newExternalConn(local, remote, 1472)
+		require.NoError(t, err)
+		assert.Equal(t, []netip.AddrPort{remote}, b.external)
+	})
+	t.Run("the default backend opens UDP sockets", func(t *testing.T) {
+		b := NewSocketBackend(BackendConfig{Socket: conn.Config{PathMTUDiscovery: true}})
+		c, err := b.OpenInternal(netip.MustParseAddrPort("127.0.0.1:0"))
+		require.NoError(t, err)
+		defer c.Close()
+		assert.True(t, c.LocalAddr().IsValid())
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backends contains the underlay backends of the router other than the default one,
+// which opens regular UDP sockets. Each backend registers itself when the package is imported.
+package backends
+
+import (
+	"net/netip"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/private/underlay/conn"
+	"github.com/scionproto/scion/private/underlay/xdp"
+	"github.com/scionproto/scion/router"
+)
+
+// XDP is the name of the AF_XDP backend.
+const XDP = "xdp"
+
+func init() {
+	router.RegisterBackend(XDP, newXDPBackend)
+}
+
+// xdpBackend exchanges the packets of the external interfaces through AF_XDP sockets. The
+// internal interface, and the external interfaces for which AF_XDP cannot be set up, use
+// regular UDP sockets.
+type xdpBackend struct {
+	cfg      router.BackendConfig
+	fallback router.Backend
+}
+
+func newXDPBackend(cfg router.BackendConfig) (router.Backend, error) {
+	return &xdpBackend{cfg: cfg, fallback: router.NewSocketBackend(cfg)}, nil
+}
+
+func (b *xdpBackend) OpenInternal(local netip.AddrPort) (conn.Conn, error) {
+	return b.fallback.OpenInternal(local)
+}
+
+func (b *xdpBackend) OpenExternal(local, remote netip.AddrPort, mtu int) (conn.Conn, error) {
+	connection, err := xdp.New(local, remote, xdp.Config{
+		Queue:    b.cfg.XDP.QueueID,
+		Mode:     b.cfg.XDP.Mode,
+		ZeroCopy: b.cfg.XDP.ZeroCopy,
+		MTU:      mtu,
+		Socket:   b.cfg.Socket,
+	})
+	if err == nil {
+		log.Info("Using AF_XDP for external interface", "local", local, "remote", remote)
+		return connection, nil
+	}
+	log.Info("AF_XDP not available, falling back to UDP socket",
+		"local", local, "remote", remote, "err", err)
+	return b.fallback.OpenExternal(local, remote, mtu)
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/binary"
+	"sync"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/router/control"
+)
+
+// authenticator signs the sent and verifies the received BFD control packets of a session, with
+// Keyed SHA1 or Meticulous Keyed SHA1 authentication (RFC 5880, Section 6.7.4).
+type authenticator struct {
+	authType layers.BFDAuthType
+	keyID    layers.BFDAuthKeyID
+	// key is the shared secret, padded with zeros to the size of a digest.
+	key [sha1.Size]byte
+	// window is the number of sequence numbers, beyond the last one received, that are
+	// accepted: 3 * Detect Mult.
+	window uint32
+
+	// xmitSeq is the sequence number of the next sent packet. It, as well as the fields
+	// below, is only accessed by Run.
+	xmitSeq layers.BFDAuthSequenceNumber
+	header  layers.BFDAuthHeader
+	digest  [sha1.Size]byte
+	xmitBuf gopacket.SerializeBuffer
+
+	// mtx protects the receive state, which is accessed by the callers of ReceiveMessage and by
+	// Run.
+	mtx sync.Mutex
+	// rcvSeq is the last sequence number received, if rcvSeqKnown.
+	rcvSeq      uint32
+	rcvSeqKnown bool
+	rcvBuf      gopacket.SerializeBuffer
+}
+
+// validateAuth returns an error if the authentication cannot be used.
+func validateAuth(cfg control.BFDAuth) error {
+	switch cfg.Type {
+	case control.BFDAuthNone:
+		return nil
+	case control.BFDAuthKeyedSHA1, control.BFDAuthMeticulousKeyedSHA1:
+	default:
+		return serrors.New("unsupported authentication type", "type", cfg.Type)
+	}
+	if len(cfg.Key) == 0 || len(cfg.Key) > sha1.Size {
+		return serrors.New("authentication key must be 1 to 20 bytes long",
+			"length", len(cfg.Key))
+	}
+	return nil
+}
+
+// newAuthenticator returns the authenticator for the given configuration, or nil if the
+// packets are not authenticated. The configuration must be valid.
+func newAuthenticator(cfg control.BFDAuth, detectMult layers.BFDDetectMultiplier) *authenticator {
+	if cfg.Type == control.BFDAuthNone {
+		return nil
+	}
+	a := &authenticator{
+		authType: layers.BFDAuthTypeKeyedSHA1,
+		keyID:    layers.BFDAuthKeyID(cfg.KeyID),
+		window:   3 * uint32(detectMult),
+		xmitBuf:  gopacket.NewSerializeBuffer(),
+		rcvBuf:   gopacket.NewSerializeBuffer(),
+	}
+	if cfg.Type == control.BFDAuthMeticulousKeyedSHA1 {
+		a.authType = layers.BFDAuthTypeMeticulousKeyedSHA1
+	}
+	copy(a.key[:], cfg.Key)
+	// The initial sequence number is random, so that the packets of a restarted session are not
+	// taken for replayed ones.
+	var seq [4]byte
+	if _, err := rand.Read(seq[:]); err == nil {
+		a.xmitSeq = layers.BFDAuthSequenceNumber(binary.BigEndian.Uint32(seq[:]))
+	}
+	return a
+}
+
+// sign adds the authentication section to the packet. The packet must not be used after the
+// next call to sign.
+func (a *authenticator) sign(pkt *layers.BFD) error {
+	// The digest is computed over the packet with the key in place of the digest.
+	a.header = layers.BFDAuthHeader{
+		AuthType:       a.authType,
+		KeyID:          a.keyID,
+		SequenceNumber: a.xmitSeq,
+		Data:           a.key[:],
+	}
+	pkt.AuthPresent = true
+	pkt.AuthHeader = &a.header
+	digest, err := computeDigest(a.xmitBuf, pkt)
+	if err != nil {
+		return err
+	}
+	a.digest = digest
+	a.header.Data = a.digest[:]
+	// The sequence number of Keyed SHA1 need only be incremented now and then; doing it for
+	// every packet is simpler and allowed.
+	a.xmitSeq++
+	return nil
+}
+
+// verify returns whether the packet is correctly authenticated and is not a replay. If it is,
+// the sequence number of the packet is recorded.
+func (a *authenticator) verify(pkt *layers.BFD) bool {
+	h := pkt.AuthHeader
+	if !pkt.AuthPresent || h == nil || h.AuthType != a.authType || h.KeyID != a.keyID ||
+		len(h.Data) != sha1.Size {
+
+		return false
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	seq := uint32(h.SequenceNumber)
+	if a.rcvSeqKnown {
+		// The sequence number must be in [first, rcvSeq+window], in the circular number space.
+		first := a.rcvSeq
+		if a.authType == layers.BFDAuthTypeMeticulousKeyedSHA1 {
+			first++
+		}
+		if seq-first > a.rcvSeq+a.window-first {
+			return false
+		}
+	}
+	cp, hdr := *pkt, *h
+	hdr.Data = a.key[:]
+	cp.AuthHeader = &hdr
+	digest, err := computeDigest(a.rcvBuf, &cp)
+	if err != nil || subtle.ConstantTimeCompare(digest[:], h.Data) != 1 {
+		return false
+	}
+	a.rcvSeq, a.rcvSeqKnown = seq, true
+	return true
+}
+
+// reset forgets the last received sequence number. It is called when the session goes down, so
+// that the sequence numbers of a restarted remote session are accepted.
+func (a *authenticator) reset() {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.rcvSeqKnown = false
+}
+
+// computeDigest returns the SHA1 hash of the serialized packet.
+func computeDigest(buf gopacket.SerializeBuffer, pkt *layers.BFD) ([sha1.Size]byte, error) {
+	if err := buf.Clear(); err != nil {
+		return [sha1.Size]byte{}, err
+	}
+	if err := pkt.SerializeTo(buf, gopacket.SerializeOptions{}); err != nil {
+		return [sha1.Size]byte{}, err
+	}
+	return sha1.Sum(buf.Bytes()), nil
+}
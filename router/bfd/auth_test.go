@@ -0,0 +1,140 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gopacket/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/router/bfd"
+	"github.com/scionproto/scion/router/control"
+)
+
+func TestAuthenticator(t *testing.T) {
+	packet := func() *layers.BFD {
+		return &layers.BFD{
+			Version:               1,
+			State:                 layers.BFDStateUp,
+			DetectMultiplier:      3,
+			MyDiscriminator:       1,
+			YourDiscriminator:     2,
+			DesiredMinTxInterval:  200000,
+			RequiredMinRxInterval: 200000,
+		}
+	}
+	// signed returns a copy of a packet signed by a, as the receiver would decode it.
+	signed := func(t *testing.T, a *bfd.Authenticator) *layers.BFD {
+		pkt := packet()
+		require.NoError(t, a.Sign(pkt))
+		h := *pkt.AuthHeader
+		h.Data = append(layers.BFDAuthData(nil), h.Data...)
+		pkt.AuthHeader = &h
+		return pkt
+	}
+
+	t.Run("signed packets are accepted", func(t *testing.T) {
+		for _, authType := range []control.BFDAuthType{
+			control.BFDAuthKeyedSHA1,
+			control.BFDAuthMeticulousKeyedSHA1,
+		} {
+			sender := bfd.NewAuthenticator(testAuth(authType, "secret"), 3)
+			receiver := bfd.NewAuthenticator(testAuth(authType, "secret"), 3)
+			for i := 0; i < 10; i++ {
+				pkt := signed(t, sender)
+				assert.Equal(t, 28, pkt.AuthHeader.Length())
+				assert.True(t, receiver.Verify(pkt), authType)
+			}
+		}
+	})
+	t.Run("modified packets are rejected", func(t *testing.T) {
+		sender := bfd.NewAuthenticator(testAuth(control.BFDAuthKeyedSHA1, "secret"), 3)
+		receiver := bfd.NewAuthenticator(testAuth(control.BFDAuthKeyedSHA1, "secret"), 3)
+		pkt := signed(t, sender)
+		pkt.State = layers.BFDStateDown
+		assert.False(t, receiver.Verify(pkt))
+
+		pkt = signed(t, sender)
+		pkt.AuthHeader.KeyID = 2
+		assert.False(t, receiver.Verify(pkt))
+
+		pkt = signed(t, sender)
+		pkt.AuthHeader.AuthType = layers.BFDAuthTypeMeticulousKeyedSHA1
+		assert.False(t, receiver.Verify(pkt))
+
+		other := bfd.NewAuthenticator(testAuth(control.BFDAuthKeyedSHA1, "other secret"), 3)
+		assert.False(t, receiver.Verify(signed(t, other)))
+	})
+	t.Run("replayed packets are rejected", func(t *testing.T) {
+		sender := bfd.NewAuthenticator(testAuth(control.BFDAuthMeticulousKeyedSHA1, "s"), 3)
+		receiver := bfd.NewAuthenticator(testAuth(control.BFDAuthMeticulousKeyedSHA1, "s"), 3)
+		old := signed(t, sender)
+		require.True(t, receiver.Verify(old))
+		assert.False(t, receiver.Verify(old))
+
+		// Sequence numbers too far ahead are rejected as well.
+		for i := 0; i < 9; i++ {
+			signed(t, sender)
+		}
+		assert.False(t, receiver.Verify(signed(t, sender)))
+
+		// Until the session goes down.
+		receiver.Reset()
+		assert.True(t, receiver.Verify(old))
+	})
+	t.Run("keyed SHA1 accepts a repeated sequence number", func(t *testing.T) {
+		sender := bfd.NewAuthenticator(testAuth(control.BFDAuthKeyedSHA1, "secret"), 3)
+		receiver := bfd.NewAuthenticator(testAuth(control.BFDAuthKeyedSHA1, "secret"), 3)
+		pkt := signed(t, sender)
+		require.True(t, receiver.Verify(pkt))
+		assert.True(t, receiver.Verify(pkt))
+	})
+	t.Run("unauthenticated packets are rejected", func(t *testing.T) {
+		receiver := bfd.NewAuthenticator(testAuth(control.BFDAuthKeyedSHA1, "secret"), 3)
+		assert.False(t, receiver.Verify(packet()))
+	})
+}
+
+func TestNewSessionAuth(t *testing.T) {
+	cfg := control.BFD{
+		DetectMult:            3,
+		DesiredMinTxInterval:  200 * time.Millisecond,
+		RequiredMinRxInterval: 200 * time.Millisecond,
+	}
+	testCases := map[string]struct {
+		auth      control.BFDAuth
+		assertErr assert.ErrorAssertionFunc
+	}{
+		"none":         {assertErr: assert.NoError},
+		"keyed sha1":   {auth: testAuth(control.BFDAuthKeyedSHA1, "k"), assertErr: assert.NoError},
+		"unknown type": {auth: testAuth("keyed_md5", "k"), assertErr: assert.Error},
+		"empty key":    {auth: testAuth(control.BFDAuthKeyedSHA1, ""), assertErr: assert.Error},
+		"long key": {
+			auth:      testAuth(control.BFDAuthKeyedSHA1, "012345678901234567890"),
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := cfg
+			cfg.Auth = tc.auth
+			_, err := bfd.NewSession(&redirectSender{}, cfg, bfd.Metrics{})
+			tc.assertErr(t, err)
+		})
+	}
+}
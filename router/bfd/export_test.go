@@ -14,7 +14,12 @@
 
 package bfd
 
-import "github.com/scionproto/scion/pkg/log"
+import (
+	"github.com/gopacket/gopacket/layers"
+
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/router/control"
+)
 
 const (
 	MinJitter            = minJitter
@@ -52,3 +57,21 @@ type (
 func (s *Session) SetLogger(logger log.Logger) {
 	s.testLogger = logger
 }
+
+type Authenticator = authenticator
+
+func NewAuthenticator(cfg control.BFDAuth, detectMult layers.BFDDetectMultiplier) *Authenticator {
+	return newAuthenticator(cfg, detectMult)
+}
+
+func (a *authenticator) Sign(pkt *layers.BFD) error {
+	return a.sign(pkt)
+}
+
+func (a *authenticator) Verify(pkt *layers.BFD) bool {
+	return a.verify(pkt)
+}
+
+func (a *authenticator) Reset() {
+	a.reset()
+}
@@ -31,4 +31,7 @@ type Metrics struct {
 	Up prometheus.Gauge
 	// StateChanges reports the total number of state changes of the session.
 	StateChanges prometheus.Counter
+	// RTT reports the smoothed round trip time to the remote in seconds, if the session
+	// measures it.
+	RTT prometheus.Gauge
 }
@@ -34,6 +34,13 @@ func TestMetrics(t *testing.T) {
 	packetsReceived := prometheus.NewCounter(prometheus.CounterOpts{Name: "packets_received"})
 	up := prometheus.NewGauge(prometheus.GaugeOpts{Name: "up"})
 	stateChanges := prometheus.NewCounter(prometheus.CounterOpts{Name: "state_changes"})
+	var mtx sync.Mutex
+	var changed []bool
+	upChanges := func() []bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return append([]bool(nil), changed...)
+	}
 
 	sessionA := &bfd.Session{
 		DetectMult:            1,
@@ -47,6 +54,11 @@ func TestMetrics(t *testing.T) {
 			Up:              up,
 			StateChanges:    stateChanges,
 		},
+		StateChanged: func(up bool) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			changed = append(changed, up)
+		},
 	}
 
 	sessionB := &bfd.Session{
@@ -91,6 +103,7 @@ func TestMetrics(t *testing.T) {
 	betweenOrEqual(t, 5.0, 27.0, promtest.ToFloat64(packetsReceived))
 	assert.Equal(t, 1.0, promtest.ToFloat64(up))
 	assert.Greater(t, promtest.ToFloat64(stateChanges), 0.0)
+	assert.Equal(t, []bool{true}, upChanges())
 
 	changes := promtest.ToFloat64(stateChanges)
 	linkAToB.Sending(false)
@@ -99,6 +112,7 @@ func TestMetrics(t *testing.T) {
 
 	assert.Equal(t, 0.0, promtest.ToFloat64(up))
 	assert.Greater(t, promtest.ToFloat64(stateChanges), changes)
+	assert.Equal(t, []bool{true, false}, upChanges())
 
 	linkAToB.Close()
 	linkBToA.Close()
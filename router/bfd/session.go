@@ -21,6 +21,7 @@ import (
 	"math"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gopacket/gopacket/layers"
@@ -59,10 +60,19 @@ var (
 //
 // The Control Plane Independent bit is cleared.
 //
-// Authentication is not supported. The Authentication Present bit of BFD packets is cleared.
+// Authentication is optional; only Keyed SHA1 and Meticulous Keyed SHA1 are supported. If it is
+// configured, the sent packets are authenticated and the received packets that are not correctly
+// authenticated are discarded. Otherwise, the Authentication Present bit of sent packets is
+// cleared and the received authenticated packets are discarded.
 //
 // Session does not support the BFD Echo function. Therefore, the Required Min Echo RX field is
 // always set to 0.
+//
+// Poll sequences are only used to measure the round trip time to the remote: if
+// LatencyProbeInterval is set, the Session sends an additional packet with the Poll bit set at
+// that interval, and times the packet with the Final bit set that the remote answers with. The
+// Session answers the Poll packets of the remote in the same way, but it does not change its
+// timers on polls.
 type Session struct {
 	// Sender is used by the Session to send BFD messages to the other end of the point to point
 	// link.
@@ -121,6 +131,22 @@ type Session struct {
 	// must be non-zero.
 	DetectMult layers.BFDDetectMultiplier
 
+	// Auth is the authentication of the BFD control packets. If the type is none, the packets
+	// are not authenticated. Run will return an error if the configuration is not valid.
+	Auth control.BFDAuth
+
+	// LatencyProbeInterval is the interval at which the round trip time to the remote is
+	// measured while the session is up. If it is 0, the round trip time is not measured.
+	LatencyProbeInterval time.Duration
+
+	// rtt is the smoothed round trip time to the remote, in nanoseconds. It is 0 if it is not
+	// known.
+	rtt atomic.Int64
+
+	authOnce sync.Once
+	// auth signs and verifies the packets, if they are authenticated.
+	auth *authenticator
+
 	// ReceiveQueueSize is the size of the Session's receive messages queue. The default is 0,
 	// but this is often not desirable as writing to the Session's message queue will block
 	// until the session is ready to read it.
@@ -129,6 +155,9 @@ type Session struct {
 	messagesOnce sync.Once
 	// messages is the channel on which the session receives BFD packets.
 	messages chan bfdMessage
+	// stopped is closed when Run returns, so that ReceiveMessage does not block forever on a
+	// session that is no longer running.
+	stopped chan struct{}
 
 	// localStateLock protects access to the local state.
 	localStateLock sync.RWMutex
@@ -158,6 +187,12 @@ type Session struct {
 	// If a metric is not initialized, it is not reported.
 	Metrics Metrics
 
+	// StateChanged is called when the session goes up, and when it goes down from up. It is called
+	// from the goroutine running the session, so it must not block.
+	//
+	// If it is nil, it is not called.
+	StateChanged func(up bool)
+
 	// testLogger is set if more logs should be generated, specifically, logs about
 	// periodic events that would in production environment clog the logs. Use
 	// this field only in tests.
@@ -180,11 +215,16 @@ func NewSession(s Sender, cfg control.BFD, metrics Metrics) (*Session, error) {
 		return nil, err
 	}
 	disc := layers.BFDDiscriminator(uint32(discInt.Uint64()) + 1)
+	if err := validateAuth(cfg.Auth); err != nil {
+		return nil, err
+	}
 	return &Session{
 		Sender:                s,
 		DetectMult:            layers.BFDDetectMultiplier(cfg.DetectMult),
 		DesiredMinTxInterval:  cfg.DesiredMinTxInterval,
 		RequiredMinRxInterval: cfg.RequiredMinRxInterval,
+		Auth:                  cfg.Auth,
+		LatencyProbeInterval:  cfg.LatencyProbeInterval,
 		LocalDiscriminator:    disc,
 		ReceiveQueueSize:      10,
 		Metrics:               metrics,
@@ -197,7 +237,8 @@ func (s *Session) String() string {
 }
 
 // Run initializes the Session's timers and state machine, and starts sending out BFD control
-// packets on the point to point link.
+// packets on the point to point link. Run returns when the session is closed or when the
+// context is done.
 //
 // Run must only be called once.
 func (s *Session) Run(ctx context.Context) error {
@@ -212,6 +253,7 @@ func (s *Session) Run(ctx context.Context) error {
 		s.setRemoteDiscriminator(s.RemoteDiscriminator)
 	}
 	s.initMessages()
+	defer close(s.stopped)
 	s.initMetrics()
 
 	// detectionTimer tracks the period of time without receiving BFD packets after which the
@@ -226,10 +268,23 @@ func (s *Session) Run(ctx context.Context) error {
 	s.desiredMinTXInterval = defaultTransmissionInterval
 	sendTimer := time.NewTimer(s.desiredMinTXInterval)
 
+	// probe triggers the latency probes. It is nil if the round trip time is not measured.
+	var probe <-chan time.Time
+	if s.LatencyProbeInterval > 0 {
+		probeTicker := time.NewTicker(s.LatencyProbeInterval)
+		defer probeTicker.Stop()
+		probe = probeTicker.C
+	}
+	// pollSent is the time at which the pending Poll packet was sent. It is zero if no Poll
+	// packet is waiting for its Final packet.
+	var pollSent time.Time
+
 	pkt := &layers.BFD{}
 MainLoop:
 	for {
 		select {
+		case <-ctx.Done():
+			break MainLoop
 		case msg, ok := <-s.messages:
 			if !ok {
 				break MainLoop
@@ -274,36 +329,27 @@ MainLoop:
 				}
 				sendTimer.Reset(s.computeNextSendInterval())
 			}
+
+			if msg.Final && !pollSent.IsZero() {
+				s.updateRTT(msg.received.Sub(pollSent))
+				pollSent = time.Time{}
+			}
+			if msg.Poll {
+				// Answer right away, so that the remote measures the round trip time.
+				s.send(logger, pkt, false, true)
+			}
 		case <-sendTimer.C:
 			// Send timer guaranteed to be expired, so we can reset.
 			sendTimer.Reset(s.computeNextSendInterval())
-
-			// These conversions are guaranteed to not return an error, because the input has been
-			// sanitized.
-			desiredMinTxInterval, _ := durationToBFDInterval(s.desiredMinTXInterval)
-			requiredMinRxInterval, _ := durationToBFDInterval(s.RequiredMinRxInterval)
-
-			*pkt = layers.BFD{
-				Version:               1,
-				State:                 layers.BFDState(s.getLocalState()),
-				DetectMultiplier:      s.DetectMult,
-				MyDiscriminator:       s.LocalDiscriminator,
-				YourDiscriminator:     s.remoteDiscriminator,
-				DesiredMinTxInterval:  desiredMinTxInterval,
-				RequiredMinRxInterval: requiredMinRxInterval,
-			}
-
-			if err := s.Sender.Send(pkt); err != nil {
-				logger.Debug("error sending message", "err", err)
+			s.send(logger, pkt, false, false)
+		case <-probe:
+			if s.getLocalState() != stateUp {
+				pollSent = time.Time{}
 				continue
 			}
-			if s.testLogger != nil {
-				s.testLogger.Debug("heartbeat sent", "desired_min_tx_interval",
-					pkt.DesiredMinTxInterval, "required_min_rx_interval", pkt.RequiredMinRxInterval)
-			}
-			if s.Metrics.PacketsSent != nil {
-				s.Metrics.PacketsSent.Add(1)
-			}
+			// A Poll packet that was not answered until now is considered lost.
+			pollSent = time.Now()
+			s.send(logger, pkt, true, false)
 		case <-detectionTimer.C:
 			// detection timer guaranteed to be expired, so we can reset. We reset s.t. if some
 			// other branch wants to stop this timer, it can assume it hasn't been drained.
@@ -315,12 +361,80 @@ MainLoop:
 				// Change the desired interval back to the default transmission interval, to
 				// avoid flooding the network while the session is down.
 				s.desiredMinTXInterval = defaultTransmissionInterval
+				if auth := s.authenticator(); auth != nil {
+					auth.reset()
+				}
+				s.rtt.Store(0)
+				pollSent = time.Time{}
 			}
 		}
 	}
 	return nil
 }
 
+// send sends a control packet with the current state of the session and with the given Poll and
+// Final bits.
+func (s *Session) send(logger log.Logger, pkt *layers.BFD, poll, final bool) {
+	// These conversions are guaranteed to not return an error, because the input has been
+	// sanitized.
+	desiredMinTxInterval, _ := durationToBFDInterval(s.desiredMinTXInterval)
+	requiredMinRxInterval, _ := durationToBFDInterval(s.RequiredMinRxInterval)
+
+	*pkt = layers.BFD{
+		Version:               1,
+		State:                 layers.BFDState(s.getLocalState()),
+		Poll:                  poll,
+		Final:                 final,
+		DetectMultiplier:      s.DetectMult,
+		MyDiscriminator:       s.LocalDiscriminator,
+		YourDiscriminator:     s.remoteDiscriminator,
+		DesiredMinTxInterval:  desiredMinTxInterval,
+		RequiredMinRxInterval: requiredMinRxInterval,
+	}
+	if auth := s.authenticator(); auth != nil {
+		if err := auth.sign(pkt); err != nil {
+			logger.Debug("error authenticating message", "err", err)
+			return
+		}
+	}
+
+	if err := s.Sender.Send(pkt); err != nil {
+		logger.Debug("error sending message", "err", err)
+		return
+	}
+	if s.testLogger != nil {
+		s.testLogger.Debug("heartbeat sent", "desired_min_tx_interval",
+			pkt.DesiredMinTxInterval, "required_min_rx_interval", pkt.RequiredMinRxInterval)
+	}
+	if s.Metrics.PacketsSent != nil {
+		s.Metrics.PacketsSent.Add(1)
+	}
+}
+
+// updateRTT adds a sample to the smoothed round trip time, with the weight of the samples of
+// TCP (RFC 6298).
+func (s *Session) updateRTT(sample time.Duration) {
+	rtt := time.Duration(s.rtt.Load())
+	if rtt == 0 {
+		rtt = sample
+	} else {
+		rtt += (sample - rtt) / 8
+	}
+	// A zero round trip time would read as unknown.
+	rtt = max(rtt, time.Nanosecond)
+	s.rtt.Store(int64(rtt))
+	if s.Metrics.RTT != nil {
+		s.Metrics.RTT.Set(rtt.Seconds())
+	}
+}
+
+// RTT returns the smoothed round trip time to the remote, as measured with Poll sequences. It is
+// 0 while it is not known, and when the session is down. It is safe to call RTT while Run is
+// executed.
+func (s *Session) RTT() time.Duration {
+	return time.Duration(s.rtt.Load())
+}
+
 func (s *Session) Close() error {
 	s.initMessages()
 	close(s.messages)
@@ -361,6 +475,12 @@ func (s *Session) validateParameters() error {
 	if s.Sender == nil {
 		return serrors.New("sender must not be nil")
 	}
+	if s.LatencyProbeInterval < 0 {
+		return serrors.New("latency probe interval must not be negative")
+	}
+	if err := validateAuth(s.Auth); err != nil {
+		return serrors.Wrap("bad authentication", err)
+	}
 	return nil
 }
 
@@ -424,15 +544,36 @@ func (s *Session) ReceiveMessage(msg *layers.BFD) {
 		}
 		return
 	}
+	if auth := s.authenticator(); auth != nil {
+		if !auth.verify(msg) {
+			if s.testLogger != nil {
+				s.testLogger.Debug("Received packet that is not correctly authenticated.")
+			}
+			return
+		}
+	} else if msg.AuthPresent {
+		if s.testLogger != nil {
+			s.testLogger.Debug("Received authenticated packet, but authentication is not " +
+				"configured. Packet will be discarded.")
+		}
+		return
+	}
 
-	s.messages <- bfdMessage{
+	m := bfdMessage{
+		received:              time.Now(),
 		State:                 msg.State,
+		Poll:                  msg.Poll,
+		Final:                 msg.Final,
 		DetectMultiplier:      msg.DetectMultiplier,
 		MyDiscriminator:       msg.MyDiscriminator,
 		YourDiscriminator:     msg.YourDiscriminator,
 		DesiredMinTxInterval:  msg.DesiredMinTxInterval,
 		RequiredMinRxInterval: msg.RequiredMinRxInterval,
 	}
+	select {
+	case s.messages <- m:
+	case <-s.stopped:
+	}
 }
 
 // initMetrics initializes the metrics to a zero value.
@@ -451,9 +592,21 @@ func (s *Session) initMetrics() {
 	}
 }
 
+// authenticator returns the authenticator of the session, or nil if the packets are not
+// authenticated.
+func (s *Session) authenticator() *authenticator {
+	s.authOnce.Do(func() {
+		if validateAuth(s.Auth) == nil {
+			s.auth = newAuthenticator(s.Auth, s.DetectMult)
+		}
+	})
+	return s.auth
+}
+
 func (s *Session) initMessages() {
 	s.messagesOnce.Do(func() {
 		s.messages = make(chan bfdMessage, s.ReceiveQueueSize)
+		s.stopped = make(chan struct{})
 	})
 }
 
@@ -462,10 +615,11 @@ func (s *Session) transition(ctx context.Context, e event) {
 	// about making the state transition a transaction.
 
 	logger := log.FromCtx(ctx)
-	newState := transition(s.getLocalState(), e)
-	if newState != s.localState {
+	oldState := s.getLocalState()
+	newState := transition(oldState, e)
+	if newState != oldState {
 		logger.Debug(fmt.Sprintf("Transitioned from state %v to state %v on event %v",
-			s.localState, newState, e))
+			oldState, newState, e))
 		s.setLocalState(newState)
 		if s.Metrics.Up != nil {
 			if newState == stateUp {
@@ -477,6 +631,9 @@ func (s *Session) transition(ctx context.Context, e event) {
 		if s.Metrics.StateChanges != nil {
 			s.Metrics.StateChanges.Add(1)
 		}
+		if s.StateChanged != nil && (newState == stateUp || oldState == stateUp) {
+			s.StateChanged(newState == stateUp)
+		}
 	}
 }
 
@@ -548,20 +705,18 @@ func shouldDiscard(pkt *layers.BFD) (bool, string) {
 		}
 	}
 
-	// Authentication is not supported (see Anapaya/scion#3280). We currently discard
-	// such packets.
-	if pkt.AuthPresent {
+	// Only the SHA1 authentication types are supported. We currently discard the packets
+	// authenticated otherwise. Whether the authentication is valid is checked by the session.
+	if pkt.AuthPresent && pkt.AuthHeader.AuthType != layers.BFDAuthTypeKeyedSHA1 &&
+		pkt.AuthHeader.AuthType != layers.BFDAuthTypeMeticulousKeyedSHA1 {
+
 		return true,
-			"Received authenticated packet, but authentication is not supported. " +
+			"Received authenticated packet, but the authentication type is not supported. " +
 				"Packet will be discarded."
 	}
 
-	// Poll/final sequences are not supported (see Anapaya/scion#3281).
-	if pkt.Poll {
-		return true, "Received Poll packet, but poll mechanism is not supported."
-	}
-	if pkt.Final {
-		return true, "Received Final packet, but poll mechanism is not supported."
+	if pkt.Poll && pkt.Final {
+		return true, "Received packet with both the Poll and the Final bit set."
 	}
 
 	// Echo function is not supported. We discard such packets to ensure that the
@@ -600,7 +755,11 @@ func bfdIntervalToDuration(x layers.BFDTimeInterval) time.Duration {
 // bfdMessage contains the relevant values to (asynchronously) process a BFD message
 // received from the network. This is a subset of the fields of layers.BFD.
 type bfdMessage struct {
+	// received is the time at which the message was received.
+	received              time.Time
 	State                 layers.BFDState
+	Poll                  bool
+	Final                 bool
 	DetectMultiplier      layers.BFDDetectMultiplier
 	MyDiscriminator       layers.BFDDiscriminator
 	YourDiscriminator     layers.BFDDiscriminator
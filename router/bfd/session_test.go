@@ -29,6 +29,7 @@ import (
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/log/testlog"
 	"github.com/scionproto/scion/router/bfd"
+	"github.com/scionproto/scion/router/control"
 )
 
 // redirectSender sends a BFD message directly into a destination Session's receive queue.
@@ -265,6 +266,80 @@ func TestSession(t *testing.T) {
 				time.Sleep(2 * time.Second)
 			},
 		},
+		"authenticated": {
+			sessionA: &bfd.Session{
+				DetectMult:            1,
+				DesiredMinTxInterval:  200 * time.Millisecond,
+				RequiredMinRxInterval: 100 * time.Millisecond,
+				LocalDiscriminator:    1,
+				ReceiveQueueSize:      10,
+				Auth:                  testAuth(control.BFDAuthMeticulousKeyedSHA1, "secret"),
+			},
+			sessionB: &bfd.Session{
+				DetectMult:            1,
+				DesiredMinTxInterval:  200 * time.Millisecond,
+				RequiredMinRxInterval: 100 * time.Millisecond,
+				LocalDiscriminator:    2,
+				ReceiveQueueSize:      10,
+				Auth:                  testAuth(control.BFDAuthMeticulousKeyedSHA1, "secret"),
+			},
+			expectedUpA: true,
+			expectedUpB: true,
+			testBehavior: func(linkAToB, linkBToA *redirectSender) {
+				linkAToB.Sending(true)
+				linkBToA.Sending(true)
+				time.Sleep(2 * time.Second)
+			},
+		},
+		"authentication key mismatch": {
+			sessionA: &bfd.Session{
+				DetectMult:            1,
+				DesiredMinTxInterval:  200 * time.Millisecond,
+				RequiredMinRxInterval: 100 * time.Millisecond,
+				LocalDiscriminator:    1,
+				ReceiveQueueSize:      10,
+				Auth:                  testAuth(control.BFDAuthKeyedSHA1, "secret"),
+			},
+			sessionB: &bfd.Session{
+				DetectMult:            1,
+				DesiredMinTxInterval:  200 * time.Millisecond,
+				RequiredMinRxInterval: 100 * time.Millisecond,
+				LocalDiscriminator:    2,
+				ReceiveQueueSize:      10,
+				Auth:                  testAuth(control.BFDAuthKeyedSHA1, "other secret"),
+			},
+			expectedUpA: false,
+			expectedUpB: false,
+			testBehavior: func(linkAToB, linkBToA *redirectSender) {
+				linkAToB.Sending(true)
+				linkBToA.Sending(true)
+				time.Sleep(2 * time.Second)
+			},
+		},
+		"authentication on one side only": {
+			sessionA: &bfd.Session{
+				DetectMult:            1,
+				DesiredMinTxInterval:  200 * time.Millisecond,
+				RequiredMinRxInterval: 100 * time.Millisecond,
+				LocalDiscriminator:    1,
+				ReceiveQueueSize:      10,
+				Auth:                  testAuth(control.BFDAuthKeyedSHA1, "secret"),
+			},
+			sessionB: &bfd.Session{
+				DetectMult:            1,
+				DesiredMinTxInterval:  200 * time.Millisecond,
+				RequiredMinRxInterval: 100 * time.Millisecond,
+				LocalDiscriminator:    2,
+				ReceiveQueueSize:      10,
+			},
+			expectedUpA: false,
+			expectedUpB: false,
+			testBehavior: func(linkAToB, linkBToA *redirectSender) {
+				linkAToB.Sending(true)
+				linkBToA.Sending(true)
+				time.Sleep(2 * time.Second)
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -272,6 +347,10 @@ func TestSession(t *testing.T) {
 	}
 }
 
+func testAuth(authType control.BFDAuthType, key string) control.BFDAuth {
+	return control.BFDAuth{Type: authType, KeyID: 1, Key: []byte(key)}
+}
+
 // sessionSubtest is used to capture the test case data and name for safe parallel execution.
 func sessionSubtest(name string, tc *sessionTestCase) func(t *testing.T) {
 	return func(t *testing.T) {
@@ -404,6 +483,72 @@ func TestSessionDebootstrap(t *testing.T) {
 	wg.Wait()
 }
 
+// delayedSender delays the messages it sends.
+type delayedSender struct {
+	bfd.Sender
+	delay time.Duration
+}
+
+func (d delayedSender) Send(bfd *layers.BFD) error {
+	time.Sleep(d.delay)
+	return d.Sender.Send(bfd)
+}
+
+func TestSessionRTT(t *testing.T) {
+	sessionA := &bfd.Session{
+		DetectMult:            3,
+		DesiredMinTxInterval:  100 * time.Millisecond,
+		RequiredMinRxInterval: 100 * time.Millisecond,
+		LocalDiscriminator:    1,
+		ReceiveQueueSize:      10,
+		LatencyProbeInterval:  50 * time.Millisecond,
+	}
+	sessionB := &bfd.Session{
+		DetectMult:            3,
+		DesiredMinTxInterval:  100 * time.Millisecond,
+		RequiredMinRxInterval: 100 * time.Millisecond,
+		LocalDiscriminator:    2,
+		ReceiveQueueSize:      10,
+	}
+	loggerA := testlog.NewLogger(t).New("session", "A")
+	loggerB := testlog.NewLogger(t).New("session", "B")
+	sessionA.SetLogger(loggerA)
+	sessionB.SetLogger(loggerB)
+	linkAToB := &redirectSender{Destination: sessionB}
+	linkBToA := &redirectSender{Destination: sessionA}
+	sessionA.Sender = linkAToB
+	sessionB.Sender = delayedSender{Sender: linkBToA, delay: 10 * time.Millisecond}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, sessionA.Run(log.CtxWith(context.Background(), loggerA)))
+	}()
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, sessionB.Run(log.CtxWith(context.Background(), loggerB)))
+	}()
+	linkAToB.Sending(true)
+	linkBToA.Sending(true)
+	time.Sleep(2 * time.Second)
+
+	assert.True(t, sessionA.IsUp())
+	assert.GreaterOrEqual(t, sessionA.RTT(), 10*time.Millisecond)
+	assert.Less(t, sessionA.RTT(), 100*time.Millisecond)
+	assert.Zero(t, sessionB.RTT(), "B does not measure the round trip time")
+
+	// The round trip time is no longer known once the session is down.
+	linkBToA.Sending(false)
+	time.Sleep(time.Second)
+	assert.False(t, sessionA.IsUp())
+	assert.Zero(t, sessionA.RTT())
+
+	linkAToB.Close()
+	linkBToA.Close()
+	wg.Wait()
+}
+
 func TestSessionRun(t *testing.T) {
 	testCases := map[string]struct {
 		session *bfd.Session
@@ -696,6 +841,19 @@ func TestShouldDiscard(t *testing.T) {
 			// implementation doesn't support it yet.
 			hasReason: assert.NotEmpty,
 		},
+		"auth set, auth type sha1": {
+			packetEdit: func(pkt layers.BFD) layers.BFD {
+				pkt.AuthPresent = true
+				pkt.AuthHeader = &layers.BFDAuthHeader{
+					AuthType: layers.BFDAuthTypeKeyedSHA1,
+					Data:     make(layers.BFDAuthData, 20),
+				}
+				return pkt
+			},
+			// The authentication itself is checked by the session.
+			shouldDiscard: false,
+			hasReason:     assert.Empty,
+		},
 		"auth clear, no auth header": {
 			packetEdit: func(pkt layers.BFD) layers.BFD {
 				pkt.AuthPresent = false
@@ -732,14 +890,23 @@ func TestShouldDiscard(t *testing.T) {
 				pkt.Poll = true
 				return pkt
 			},
-			shouldDiscard: true,
-			hasReason:     assert.NotEmpty,
+			shouldDiscard: false,
+			hasReason:     assert.Empty,
 		},
 		"final bit set": {
 			packetEdit: func(pkt layers.BFD) layers.BFD {
 				pkt.Final = true
 				return pkt
 			},
+			shouldDiscard: false,
+			hasReason:     assert.Empty,
+		},
+		"poll and final bits set": {
+			packetEdit: func(pkt layers.BFD) layers.BFD {
+				pkt.Poll = true
+				pkt.Final = true
+				return pkt
+			},
 			shouldDiscard: true,
 			hasReason:     assert.NotEmpty,
 		},
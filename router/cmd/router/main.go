@@ -31,9 +31,12 @@ import (
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/private/app"
 	"github.com/scionproto/scion/private/app/launcher"
+	caconfig "github.com/scionproto/scion/private/ca/config"
+	"github.com/scionproto/scion/private/mgmtapi/jwtauth"
 	"github.com/scionproto/scion/private/service"
-	"github.com/scionproto/scion/private/topology"
+	"github.com/scionproto/scion/private/underlay/conn"
 	"github.com/scionproto/scion/router"
+	_ "github.com/scionproto/scion/router/backends"
 	"github.com/scionproto/scion/router/config"
 	"github.com/scionproto/scion/router/control"
 	api "github.com/scionproto/scion/router/mgmtapi"
@@ -58,9 +61,30 @@ func realMain(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// The router shuts down when the grace period of draining is over, as on a signal.
+	ctx, shutdown := context.WithCancel(ctx)
+	defer shutdown()
 	g, errCtx := errgroup.WithContext(ctx)
 	metrics := router.NewMetrics()
+	rssKey, err := globalCfg.Router.RSSKeyBytes()
+	if err != nil {
+		return err
+	}
 
+	backend, err := router.NewBackend(globalCfg.Router.Backend, router.BackendConfig{
+		Socket: conn.Config{
+			ReceiveBufferSize: globalCfg.Router.ReceiveBufferSize,
+			SendBufferSize:    globalCfg.Router.SendBufferSize,
+			UDPOffload:        globalCfg.Router.UDPOffload,
+			PathMTUDiscovery:  globalCfg.Router.PathMTUDiscovery,
+			BusyPoll:          globalCfg.Router.BusyPoll.Duration,
+			TrafficClass:      globalCfg.Router.TrafficClass,
+		},
+		XDP: globalCfg.Router.XDP,
+	})
+	if err != nil {
+		return serrors.Wrap("creating underlay backend", err)
+	}
 	dp := &router.Connector{
 		DataPlane: router.DataPlane{
 			Metrics:                        metrics,
@@ -69,11 +93,33 @@ func realMain(ctx context.Context) error {
 				NumProcessors:         globalCfg.Router.NumProcessors,
 				NumSlowPathProcessors: globalCfg.Router.NumSlowPathProcessors,
 				BatchSize:             globalCfg.Router.BatchSize,
+				SCMPRate:              globalCfg.Router.SCMPRateLimit,
+				SCMPBurst:             globalCfg.Router.SCMPBurst,
+				SCMPDiagnostics:       globalCfg.Router.SCMPDiagnostics,
+				ReceiveBufferAutotune: globalCfg.Router.ReceiveBufferAutotune,
+				ReceiveBufferMaxSize:  globalCfg.Router.ReceiveBufferMaxSize,
+				AntiSpoofing:          globalCfg.Router.AntiSpoofing,
+				STUN:                  globalCfg.Router.STUN,
+				DrainGracePeriod:      globalCfg.Router.DrainGracePeriod.Duration,
+				PathMTUDiscovery:      globalCfg.Router.PathMTUDiscovery,
+				CPUAffinity:           globalCfg.Router.CPUAffinity,
+				RSSSharding:           globalCfg.Router.RSSSharding,
+				RSSKey:                rssKey,
+				ReplayWindow:          globalCfg.Router.ReplayWindow.Duration,
+				ReplayWindowPackets:   globalCfg.Router.ReplayWindowPackets,
+				DropTraceSize:         globalCfg.Router.DropTrace.Size,
+				DropTraceSnapLen:      globalCfg.Router.DropTrace.SnapLen,
 			},
 		},
-		ReceiveBufferSize:   globalCfg.Router.ReceiveBufferSize,
-		SendBufferSize:      globalCfg.Router.SendBufferSize,
+		Backend:             backend,
 		BFD:                 globalCfg.Router.BFD,
+		RateLimits:          globalCfg.Router.RateLimits,
+		QoS:                 globalCfg.Router.QoS,
+		Filter:              globalCfg.Router.Filter,
+		EPICHP:              globalCfg.Router.EPICHP,
+		Fabrid:              globalCfg.Router.Fabrid,
+		Telemetry:           globalCfg.Router.Telemetry,
+		EnforceMTU:          globalCfg.Router.EnforceMTU,
 		DispatchedPortStart: globalCfg.Router.DispatchedPortStart,
 		DispatchedPortEnd:   globalCfg.Router.DispatchedPortEnd,
 	}
@@ -84,11 +130,25 @@ func realMain(ctx context.Context) error {
 	if err := iaCtx.Configure(); err != nil {
 		return serrors.Wrap("configuring dataplane", err)
 	}
+	if m := globalCfg.Router.Mirror; m.PcapFile != "" || m.Socket != "" {
+		err := dp.StartMirror(control.MirrorConfig{
+			SampleRate: m.SampleRate,
+			Interfaces: m.Interfaces,
+			SrcIA:      m.SrcIA,
+			DstIA:      m.DstIA,
+			MaxPackets: m.MaxPackets,
+			PcapFile:   m.PcapFile,
+			Socket:     m.Socket,
+		})
+		if err != nil {
+			return serrors.Wrap("starting packet mirroring", err)
+		}
+	}
 	statusPages := service.StatusPages{
 		"info":      service.NewInfoStatusPage(),
 		"config":    service.NewConfigStatusPage(globalCfg),
 		"log/level": service.NewLogLevelStatusPage(),
-		"topology":  topologyHandler(iaCtx.Config.Topo),
+		"topology":  topologyHandler(iaCtx),
 	}
 	if err := statusPages.Register(http.DefaultServeMux, globalCfg.General.ID); err != nil {
 		return err
@@ -114,6 +174,17 @@ func realMain(ctx context.Context) error {
 			Info:      service.NewInfoStatusPage().Handler,
 			LogLevel:  service.NewLogLevelStatusPage().Handler,
 			Dataplane: dp,
+			Mirror:    dp,
+			Drainer:   dp,
+			DropTrace: dp,
+			MirrorDir: globalCfg.Router.Mirror.Dir,
+		}
+		if path := globalCfg.Router.APISharedSecret; path != "" {
+			verifier := &jwtauth.HTTPVerifier{
+				Generator: caconfig.NewPEMSymmetricKey(path).Get,
+				Logger:    log.Root(),
+			}
+			server.AdminAuth = verifier.AddAuthorization
 		}
 		log.Info("Exposing API", "addr", globalCfg.API.Addr)
 		h := api.HandlerFromMuxWithBaseURL(&server, r, "/api/v1")
@@ -142,6 +213,36 @@ func realMain(ctx context.Context) error {
 		}
 		return nil
 	})
+	g.Go(func() error {
+		defer log.HandlePanic()
+		select {
+		case <-dp.Drained():
+			log.Info("Router drained, shutting down")
+			shutdown()
+		case <-errCtx.Done():
+		}
+		return nil
+	})
+	g.Go(func() error {
+		defer log.HandlePanic()
+		reload := app.SIGHUPChannel(errCtx)
+		for {
+			select {
+			case <-reload:
+				log.Info("Reloading topology")
+				newConf, err := loadControlConfig()
+				if err != nil {
+					log.Error("Failed to reload topology", "err", err)
+					continue
+				}
+				if err := iaCtx.Reload(newConf); err != nil {
+					log.Error("Failed to apply reloaded topology", "err", err)
+				}
+			case <-errCtx.Done():
+				return nil
+			}
+		}
+	})
 
 	return g.Wait()
 }
@@ -154,11 +255,13 @@ func loadControlConfig() (*control.Config, error) {
 	return newConf, nil
 }
 
-func topologyHandler(topo topology.Topology) service.StatusPage {
+// topologyHandler serves the topology that is currently applied, so that the
+// page reflects the reloads.
+func topologyHandler(iaCtx *control.IACtx) service.StatusPage {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
-		bytes, err := json.MarshalIndent(topo, "", "    ")
+		bytes, err := json.MarshalIndent(iaCtx.CurrentConfig().Topo, "", "    ")
 		if err != nil {
 			http.Error(w, "Unable to marshal topology", http.StatusInternalServerError)
 			return
@@ -18,10 +18,16 @@
 package config
 
 import (
+	"encoding/hex"
+	"fmt"
 	"io"
+	"net/netip"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
@@ -42,12 +48,42 @@ type Config struct {
 }
 
 type RouterConfig struct {
-	ReceiveBufferSize     int `toml:"receive_buffer_size,omitempty"`
-	SendBufferSize        int `toml:"send_buffer_size,omitempty"`
-	NumProcessors         int `toml:"num_processors,omitempty"`
-	NumSlowPathProcessors int `toml:"num_slow_processors,omitempty"`
-	BatchSize             int `toml:"batch_size,omitempty"`
-	BFD                   BFD `toml:"bfd,omitempty"`
+	// Backend is the name of the backend that opens the underlay connections.
+	Backend               string       `toml:"backend,omitempty"`
+	ReceiveBufferSize     int          `toml:"receive_buffer_size,omitempty"`
+	SendBufferSize        int          `toml:"send_buffer_size,omitempty"`
+	UDPOffload            bool         `toml:"udp_offload,omitempty"`
+	BusyPoll              util.DurWrap `toml:"busy_poll,omitempty"`
+	TrafficClass          uint8        `toml:"traffic_class,omitempty"`
+	ReceiveBufferAutotune bool         `toml:"receive_buffer_autotune,omitempty"`
+	ReceiveBufferMaxSize  int          `toml:"receive_buffer_max_size,omitempty"`
+	NumProcessors         int          `toml:"num_processors,omitempty"`
+	NumSlowPathProcessors int          `toml:"num_slow_processors,omitempty"`
+	BatchSize             int          `toml:"batch_size,omitempty"`
+	SCMPRateLimit         float64      `toml:"scmp_rate_limit,omitempty"`
+	SCMPBurst             int          `toml:"scmp_burst,omitempty"`
+	SCMPDiagnostics       bool         `toml:"scmp_diagnostics,omitempty"`
+	AntiSpoofing          bool         `toml:"anti_spoofing,omitempty"`
+	STUN                  bool         `toml:"stun,omitempty"`
+	DrainGracePeriod      util.DurWrap `toml:"drain_grace_period,omitempty"`
+	APISharedSecret       string       `toml:"api_shared_secret,omitempty"`
+	EnforceMTU            bool         `toml:"enforce_mtu,omitempty"`
+	PathMTUDiscovery      bool         `toml:"path_mtu_discovery,omitempty"`
+	CPUAffinity           bool         `toml:"cpu_affinity,omitempty"`
+	RSSSharding           bool         `toml:"rss_sharding,omitempty"`
+	RSSKey                string       `toml:"rss_key,omitempty"`
+	ReplayWindow          util.DurWrap `toml:"replay_window,omitempty"`
+	ReplayWindowPackets   int          `toml:"replay_window_packets,omitempty"`
+	BFD                   BFD          `toml:"bfd,omitempty"`
+	XDP                   XDP          `toml:"xdp,omitempty"`
+	RateLimits            []RateLimit  `toml:"rate_limit,omitempty"`
+	QoS                   QoS          `toml:"qos,omitempty"`
+	Mirror                Mirror       `toml:"mirror,omitempty"`
+	Filter                Filter       `toml:"filter,omitempty"`
+	EPICHP                []EPICHP     `toml:"epic_hp,omitempty"`
+	Fabrid                []Fabrid     `toml:"fabrid,omitempty"`
+	Telemetry             Telemetry    `toml:"telemetry,omitempty"`
+	DropTrace             DropTrace    `toml:"drop_trace,omitempty"`
 	// TODO: These two values were introduced to override the port range for
 	// configured router in the context of acceptance tests. However, this
 	// introduces two sources for the port configuration. We should remove this
@@ -63,6 +99,197 @@ type BFD struct {
 	DetectMult            uint8        `toml:"detect_mult,omitempty"`
 	DesiredMinTxInterval  util.DurWrap `toml:"desired_min_tx_interval,omitempty"`
 	RequiredMinRxInterval util.DurWrap `toml:"required_min_rx_interval,omitempty"`
+	// LatencyProbeInterval is the interval at which the sessions with the neighbors measure the
+	// round trip time. If 0, it is not measured.
+	LatencyProbeInterval util.DurWrap `toml:"latency_probe_interval,omitempty"`
+	// AuthType is the authentication of the BFD control packets: "none", "keyed_sha1", or
+	// "meticulous_keyed_sha1". If empty, none.
+	AuthType  string `toml:"auth_type,omitempty"`
+	AuthKeyID uint8  `toml:"auth_key_id,omitempty"`
+	// AuthKeyFile is the file that contains the base64 encoded authentication key, of at
+	// most 20 bytes.
+	AuthKeyFile string `toml:"auth_key_file,omitempty"`
+	// Neighbors override the settings above for the sessions with specific neighbors.
+	Neighbors []BFDNeighbor `toml:"neighbor,omitempty"`
+}
+
+// BFDNeighbor configures the BFD sessions of an external interface, or of all the external
+// interfaces to a neighboring AS. Exactly one of Interface or NeighborIA must be set. The
+// settings that are not set are taken from the global BFD configuration; if AuthType is set, the
+// other authentication settings are taken from this entry only.
+type BFDNeighbor struct {
+	Interface             uint16       `toml:"interface,omitempty"`
+	NeighborIA            addr.IA      `toml:"neighbor_isd_as,omitempty"`
+	DetectMult            uint8        `toml:"detect_mult,omitempty"`
+	DesiredMinTxInterval  util.DurWrap `toml:"desired_min_tx_interval,omitempty"`
+	RequiredMinRxInterval util.DurWrap `toml:"required_min_rx_interval,omitempty"`
+	AuthType              string       `toml:"auth_type,omitempty"`
+	AuthKeyID             uint8        `toml:"auth_key_id,omitempty"`
+	AuthKeyFile           string       `toml:"auth_key_file,omitempty"`
+}
+
+// XDP configures the AF_XDP fast path of the external interfaces.
+type XDP struct {
+	Enable   bool   `toml:"enable,omitempty"`
+	QueueID  int    `toml:"queue_id,omitempty"`
+	Mode     string `toml:"mode,omitempty"`
+	ZeroCopy bool   `toml:"zero_copy,omitempty"`
+}
+
+// RateLimit configures a token bucket policing the traffic leaving through an external
+// interface, or through all the external interfaces to a neighboring AS: the packets over the
+// limit are dropped, not delayed. Exactly one of Interface or NeighborIA must be set.
+type RateLimit struct {
+	Interface  uint16  `toml:"interface,omitempty"`
+	NeighborIA addr.IA `toml:"neighbor_isd_as,omitempty"`
+	// Rate is the sustained rate, in bits per second.
+	Rate int64 `toml:"rate,omitempty"`
+	// Burst is the bucket size, in bytes. If zero, 10ms worth of traffic at Rate.
+	Burst int `toml:"burst,omitempty"`
+}
+
+// QoS configures the prioritization of traffic according to the traffic class of the SCION
+// header.
+type QoS struct {
+	Classes []TrafficClass `toml:"class,omitempty"`
+}
+
+// TrafficClass configures the handling of the packets whose SCION traffic class carries any of
+// the given DSCP values.
+type TrafficClass struct {
+	DSCP []uint8 `toml:"dscp,omitempty"`
+	// Priority is one of "high", "normal", or "low". If empty, normal.
+	Priority string `toml:"priority,omitempty"`
+	// UnderlayDSCP is the DSCP written into the underlay IP header of the packets.
+	UnderlayDSCP uint8 `toml:"underlay_dscp,omitempty"`
+}
+
+// Mirror configures the mirroring of forwarded packets from startup. Packets are only mirrored
+// if one of PcapFile or Socket is set. Mirroring can also be controlled through the management
+// API.
+type Mirror struct {
+	SampleRate uint32   `toml:"sample_rate,omitempty"`
+	Interfaces []uint16 `toml:"interfaces,omitempty"`
+	SrcIA      addr.IA  `toml:"src_isd_as,omitempty"`
+	DstIA      addr.IA  `toml:"dst_isd_as,omitempty"`
+	MaxPackets uint64   `toml:"max_packets,omitempty"`
+	PcapFile   string   `toml:"pcap_file,omitempty"`
+	Socket     string   `toml:"socket,omitempty"`
+	// Dir is the directory of the pcap files and sockets of the mirroring requests of the
+	// management API. The paths of the requests are relative to it. If empty, the management API
+	// cannot start mirroring.
+	Dir string `toml:"dir,omitempty"`
+}
+
+// Filter configures the filtering of the packets received by the router. The rules are evaluated
+// in order, and the first one that matches a packet applies.
+type Filter struct {
+	// DefaultAction is applied to the packets that match no rule: "permit" or "deny". If empty,
+	// permit.
+	DefaultAction string       `toml:"default_action,omitempty"`
+	Rules         []FilterRule `toml:"rule,omitempty"`
+}
+
+// FilterRule selects received packets and decides what is done with them. The fields that are
+// not set match all packets.
+type FilterRule struct {
+	// Name identifies the rule in the metrics. If empty, the index of the rule.
+	Name string `toml:"name,omitempty"`
+	// Action is one of "permit", "deny", or "rate_limit".
+	Action     string   `toml:"action,omitempty"`
+	Interfaces []uint16 `toml:"interfaces,omitempty"`
+	// SrcIA and DstIA may contain wildcards, e.g. "1-0" for all the ASes of ISD 1.
+	SrcIA     addr.IA      `toml:"src_isd_as,omitempty"`
+	DstIA     addr.IA      `toml:"dst_isd_as,omitempty"`
+	SrcPrefix netip.Prefix `toml:"src_prefix,omitempty"`
+	DstPrefix netip.Prefix `toml:"dst_prefix,omitempty"`
+	SrcPorts  PortRange    `toml:"src_ports,omitempty"`
+	DstPorts  PortRange    `toml:"dst_ports,omitempty"`
+	// PathTypes are any of "empty", "scion", "onehop", and "epic".
+	PathTypes []string `toml:"path_types,omitempty"`
+	// Rate is the rate of a rate_limit rule, in bits per second.
+	Rate int64 `toml:"rate,omitempty"`
+	// Burst is the bucket size of a rate_limit rule, in bytes. If zero, 10ms worth of traffic
+	// at Rate.
+	Burst int `toml:"burst,omitempty"`
+}
+
+// EPICHP protects the traffic from one interface to another with EPIC-HP.
+type EPICHP struct {
+	// Ingress and Egress are the interfaces through which the packets enter and leave the local
+	// AS. 0 is the local AS: the packets that originate from, or are delivered to, local hosts.
+	Ingress uint16 `toml:"ingress,omitempty"`
+	Egress  uint16 `toml:"egress,omitempty"`
+	// Mode is "only" or "prioritize". If empty, only.
+	Mode string `toml:"mode,omitempty"`
+}
+
+// Fabrid is an intra-AS policy that packets can request for their hop through the local AS
+// with the experimental, authenticated FABRID hop-by-hop option.
+type Fabrid struct {
+	// PolicyID identifies the policy in the FABRID option.
+	PolicyID uint8 `toml:"policy_id,omitempty"`
+	// Ingress and Egress are the interfaces between which the policy is supported. 0 is the
+	// local AS.
+	Ingress uint16 `toml:"ingress,omitempty"`
+	Egress  uint16 `toml:"egress,omitempty"`
+	// SrcIAs, if not empty, are the only ASes whose hosts can request the policy.
+	SrcIAs []addr.IA `toml:"src_isd_as,omitempty"`
+	// NextHop is the internal address, e.g. "10.0.0.5:30042", that the packets using the policy
+	// are sent to when they leave the router through the internal network. If empty, they go to
+	// the sibling router or to the destination host, as other packets.
+	NextHop netip.AddrPort `toml:"next_hop,omitempty"`
+}
+
+// Telemetry configures the in-band telemetry records that the router appends to transit
+// packets.
+type Telemetry struct {
+	// SampleRate is the rate at which the packets are sampled: one in every SampleRate
+	// packets. 0 disables telemetry.
+	SampleRate uint32 `toml:"sample_rate,omitempty"`
+}
+
+// DropTrace configures the ring buffer of the last packets dropped by the router, which is
+// exposed through the management API. The API requests for the drop trace must be authorized
+// with a JWT bearer token signed with the API shared secret.
+type DropTrace struct {
+	// Size is the number of dropped packets kept. 0 disables the drop trace.
+	Size int `toml:"size,omitempty"`
+	// SnapLen is the number of bytes kept from the start of each dropped packet.
+	SnapLen int `toml:"snap_len,omitempty"`
+}
+
+// PortRange is an inclusive range of ports, written as a single port, e.g. "53", or as two
+// ports separated by a dash, e.g. "1024-65535". The zero value matches all ports.
+type PortRange struct {
+	Min, Max uint16
+}
+
+func (r *PortRange) UnmarshalText(text []byte) error {
+	lo, hi, found := strings.Cut(string(text), "-")
+	if !found {
+		hi = lo
+	}
+	minPort, err := strconv.ParseUint(strings.TrimSpace(lo), 10, 16)
+	if err != nil {
+		return serrors.Wrap("parsing port range", err, "range", string(text))
+	}
+	maxPort, err := strconv.ParseUint(strings.TrimSpace(hi), 10, 16)
+	if err != nil {
+		return serrors.Wrap("parsing port range", err, "range", string(text))
+	}
+	if minPort > maxPort {
+		return serrors.New("invalid port range", "range", string(text))
+	}
+	r.Min, r.Max = uint16(minPort), uint16(maxPort)
+	return nil
+}
+
+func (r PortRange) MarshalText() ([]byte, error) {
+	if r.Min == r.Max {
+		return []byte(strconv.Itoa(int(r.Min))), nil
+	}
+	return []byte(fmt.Sprintf("%d-%d", r.Min, r.Max)), nil
 }
 
 func (cfg *RouterConfig) ConfigName() string {
@@ -76,6 +303,13 @@ func (cfg *RouterConfig) Validate() error {
 	if cfg.SendBufferSize < 0 {
 		return serrors.New("Provided router config is invalid. SendBufferSize < 0")
 	}
+	if cfg.BusyPoll.Duration < 0 {
+		return serrors.New("Provided router config is invalid. BusyPoll < 0")
+	}
+	if cfg.ReceiveBufferAutotune && cfg.ReceiveBufferMaxSize < cfg.ReceiveBufferSize {
+		return serrors.New("Provided router config is invalid. " +
+			"ReceiveBufferMaxSize < ReceiveBufferSize")
+	}
 	if cfg.BatchSize < 1 {
 		return serrors.New("Provided router config is invalid. BatchSize < 1")
 	}
@@ -85,6 +319,126 @@ func (cfg *RouterConfig) Validate() error {
 	if cfg.NumSlowPathProcessors < 1 {
 		return serrors.New("Provided router config is invalid. NumSlowPathProcessors < 1")
 	}
+	if cfg.SCMPRateLimit < 0 {
+		return serrors.New("Provided router config is invalid. SCMPRateLimit < 0")
+	}
+	if cfg.SCMPBurst < 0 {
+		return serrors.New("Provided router config is invalid. SCMPBurst < 0")
+	}
+	if cfg.ReplayWindow.Duration < 0 {
+		return serrors.New("Provided router config is invalid. ReplayWindow < 0")
+	}
+	if cfg.ReplayWindowPackets < 0 {
+		return serrors.New("Provided router config is invalid. ReplayWindowPackets < 0")
+	}
+	if _, err := cfg.RSSKeyBytes(); err != nil {
+		return err
+	}
+	if cfg.XDP.Enable && cfg.Backend != "xdp" {
+		return serrors.New("Provided router config is invalid. XDP.Enable requires the xdp backend",
+			"backend", cfg.Backend)
+	}
+	if cfg.XDP.QueueID < 0 {
+		return serrors.New("Provided router config is invalid. XDP.QueueID < 0")
+	}
+	switch cfg.XDP.Mode {
+	case "", "native", "generic":
+	default:
+		return serrors.New("Provided router config is invalid. Unknown XDP.Mode",
+			"mode", cfg.XDP.Mode)
+	}
+	for _, rl := range cfg.RateLimits {
+		if (rl.Interface == 0) == rl.NeighborIA.IsZero() {
+			return serrors.New("Provided router config is invalid. " +
+				"Exactly one of RateLimit.Interface and RateLimit.NeighborIA must be set")
+		}
+		if rl.Rate <= 0 {
+			return serrors.New("Provided router config is invalid. RateLimit.Rate <= 0",
+				"interface", rl.Interface, "neighbor_isd_as", rl.NeighborIA)
+		}
+		if rl.Burst < 0 {
+			return serrors.New("Provided router config is invalid. RateLimit.Burst < 0",
+				"interface", rl.Interface, "neighbor_isd_as", rl.NeighborIA)
+		}
+	}
+	if cfg.BFD.LatencyProbeInterval.Duration < 0 {
+		return serrors.New("Provided router config is invalid. BFD.LatencyProbeInterval < 0")
+	}
+	if err := validateBFDAuth(cfg.BFD.AuthType, cfg.BFD.AuthKeyFile); err != nil {
+		return err
+	}
+	for _, n := range cfg.BFD.Neighbors {
+		if (n.Interface == 0) == n.NeighborIA.IsZero() {
+			return serrors.New("Provided router config is invalid. " +
+				"Exactly one of BFD.Neighbor.Interface and BFD.Neighbor.NeighborIA must be set")
+		}
+		if err := validateBFDAuth(n.AuthType, n.AuthKeyFile); err != nil {
+			return serrors.Wrap("invalid BFD neighbor", err,
+				"interface", n.Interface, "neighbor_isd_as", n.NeighborIA)
+		}
+	}
+	classified := make(map[uint8]bool)
+	for _, tc := range cfg.QoS.Classes {
+		if len(tc.DSCP) == 0 {
+			return serrors.New("Provided router config is invalid. QoS.Class.DSCP is empty")
+		}
+		for _, dscp := range tc.DSCP {
+			if dscp > 63 {
+				return serrors.New("Provided router config is invalid. QoS.Class.DSCP > 63",
+					"dscp", dscp)
+			}
+			if classified[dscp] {
+				return serrors.New("Provided router config is invalid. "+
+					"DSCP in several QoS classes", "dscp", dscp)
+			}
+			classified[dscp] = true
+		}
+		switch tc.Priority {
+		case "", "high", "normal", "low":
+		default:
+			return serrors.New("Provided router config is invalid. Unknown QoS.Class.Priority",
+				"priority", tc.Priority)
+		}
+		if tc.UnderlayDSCP > 63 {
+			return serrors.New("Provided router config is invalid. QoS.Class.UnderlayDSCP > 63",
+				"underlay_dscp", tc.UnderlayDSCP)
+		}
+	}
+	if cfg.DropTrace.Size < 0 {
+		return serrors.New("Provided router config is invalid. DropTrace.Size < 0")
+	}
+	if cfg.DropTrace.SnapLen < 0 {
+		return serrors.New("Provided router config is invalid. DropTrace.SnapLen < 0")
+	}
+	if cfg.DropTrace.Size > 0 && cfg.APISharedSecret == "" {
+		return serrors.New("Provided router config is invalid. " +
+			"DropTrace.Size is set, but APISharedSecret is not set")
+	}
+	if cfg.Mirror.PcapFile != "" && cfg.Mirror.Socket != "" {
+		return serrors.New("Provided router config is invalid. " +
+			"At most one of Mirror.PcapFile and Mirror.Socket may be set")
+	}
+	if err := cfg.Filter.validate(); err != nil {
+		return err
+	}
+	for _, hp := range cfg.EPICHP {
+		if hp.Ingress == hp.Egress {
+			return serrors.New("Provided router config is invalid. "+
+				"EPICHP.Ingress and EPICHP.Egress must differ", "interface", hp.Ingress)
+		}
+		switch hp.Mode {
+		case "", "only", "prioritize":
+		default:
+			return serrors.New("Provided router config is invalid. Unknown EPICHP.Mode",
+				"mode", hp.Mode)
+		}
+	}
+	for _, f := range cfg.Fabrid {
+		if f.Ingress == f.Egress {
+			return serrors.New("Provided router config is invalid. "+
+				"Fabrid.Ingress and Fabrid.Egress must differ", "interface", f.Ingress)
+		}
+	}
 	if cfg.DispatchedPortStart != nil {
 		if cfg.DispatchedPortEnd == nil {
 			return serrors.New("provided router config is invalid. " +
@@ -109,6 +463,78 @@ func (cfg *RouterConfig) Validate() error {
 	return nil
 }
 
+func (cfg *Filter) validate() error {
+	switch cfg.DefaultAction {
+	case "", "permit", "deny":
+	default:
+		return serrors.New("Provided router config is invalid. Unknown Filter.DefaultAction",
+			"default_action", cfg.DefaultAction)
+	}
+	for i, r := range cfg.Rules {
+		switch r.Action {
+		case "permit", "deny":
+		case "rate_limit":
+			if r.Rate <= 0 {
+				return serrors.New("Provided router config is invalid. Filter.Rule.Rate <= 0",
+					"rule", i, "name", r.Name)
+			}
+		default:
+			return serrors.New("Provided router config is invalid. Unknown Filter.Rule.Action",
+				"rule", i, "name", r.Name, "action", r.Action)
+		}
+		if r.Burst < 0 {
+			return serrors.New("Provided router config is invalid. Filter.Rule.Burst < 0",
+				"rule", i, "name", r.Name)
+		}
+		for _, pt := range r.PathTypes {
+			switch pt {
+			case "empty", "scion", "onehop", "epic":
+			default:
+				return serrors.New("Provided router config is invalid. "+
+					"Unknown Filter.Rule.PathTypes", "rule", i, "name", r.Name, "path_type", pt)
+			}
+		}
+	}
+	return nil
+}
+
+func validateBFDAuth(authType, keyFile string) error {
+	switch authType {
+	case "", "none":
+		return nil
+	case "keyed_sha1", "meticulous_keyed_sha1":
+	default:
+		return serrors.New("Provided router config is invalid. Unknown BFD.AuthType",
+			"auth_type", authType)
+	}
+	if keyFile == "" {
+		return serrors.New("Provided router config is invalid. BFD.AuthKeyFile is not set",
+			"auth_type", authType)
+	}
+	return nil
+}
+
+// rssKeyLen is the length of an RSS key.
+const rssKeyLen = 40
+
+// RSSKeyBytes returns the RSS key, or nil if none is set. The key is configured as the
+// hexadecimal bytes separated by colons that "ethtool -x" shows.
+func (cfg *RouterConfig) RSSKeyBytes() ([]byte, error) {
+	if cfg.RSSKey == "" {
+		return nil, nil
+	}
+	key, err := hex.DecodeString(strings.ReplaceAll(cfg.RSSKey, ":", ""))
+	if err != nil {
+		return nil, serrors.Wrap("Provided router config is invalid. RSSKey is not hexadecimal",
+			err)
+	}
+	if len(key) != rssKeyLen {
+		return nil, serrors.New("Provided router config is invalid. RSSKey has the wrong length",
+			"length", len(key), "expected", rssKeyLen)
+	}
+	return key, nil
+}
+
 func (cfg *RouterConfig) InitDefaults() {
 
 	// NumProcessors is the number of goroutines used to handle the processing queue.
@@ -132,12 +558,34 @@ func (cfg *RouterConfig) InitDefaults() {
 		}
 	}
 
+	if cfg.Backend == "" {
+		// Enabling AF_XDP selects its backend, as it did before backends could be selected.
+		cfg.Backend = "udp"
+		if cfg.XDP.Enable {
+			cfg.Backend = "xdp"
+		}
+	}
+	if cfg.ReceiveBufferMaxSize == 0 {
+		cfg.ReceiveBufferMaxSize = 16 << 20
+	}
 	if cfg.NumSlowPathProcessors == 0 {
 		cfg.NumSlowPathProcessors = 1
 	}
 	if cfg.BatchSize == 0 {
 		cfg.BatchSize = 256
 	}
+	if cfg.SCMPBurst == 0 {
+		cfg.SCMPBurst = 10
+	}
+	if cfg.DrainGracePeriod.Duration == 0 {
+		cfg.DrainGracePeriod = util.DurWrap{Duration: 30 * time.Second}
+	}
+	if cfg.ReplayWindowPackets == 0 {
+		cfg.ReplayWindowPackets = 64
+	}
+	if cfg.DropTrace.SnapLen == 0 {
+		cfg.DropTrace.SnapLen = 128
+	}
 	if cfg.BFD.DetectMult == 0 {
 		cfg.BFD.DetectMult = 3
 	}
@@ -15,6 +15,11 @@
 package config
 
 const routerConfigSample = `
+# The backend that opens the underlay connections: "udp" for regular UDP
+# sockets, or "xdp" for the AF_XDP fast path on the external interfaces.
+# (default "udp", or "xdp" if xdp.enable is set)
+backend = "udp"
+
 # The receive buffer size in bytes. 0 means use system default.
 # (default 0)
 receive_buffer_size = 0
@@ -23,6 +28,35 @@ receive_buffer_size = 0
 # (default 0)
 send_buffer_size = 0
 
+# Whether to use UDP generic segmentation and receive offload (GSO/GRO) on the
+# underlay sockets, where the operating system supports them.
+# (default false)
+udp_offload = false
+
+# The time during which the operating system busy polls the network device when
+# a read on an underlay socket finds no packet, instead of waiting for an
+# interrupt. Only supported on Linux. 0 means use system default.
+# (default 0s)
+busy_poll = "0s"
+
+# The TOS byte of the IPv4 header, or the traffic class of the IPv6 header, of
+# the underlay packets that do not set one of their own. Only supported on
+# Linux. 0 means use system default.
+# (default 0)
+traffic_class = 0
+
+# Whether to double the receive buffers of the underlay sockets that drop
+# packets because they are full, up to receive_buffer_max_size. Drops are
+# only detected on Linux.
+# (default false)
+receive_buffer_autotune = false
+
+# The largest receive buffer size in bytes that autotuning grows the
+# buffers to. The operating system may cap it further (e.g.,
+# net.core.rmem_max on Linux).
+# (default 16777216)
+receive_buffer_max_size = 16777216
+
 # The number of fast-path processors.
 # (default GOMAXPROCS)
 num_processors = 8
@@ -35,4 +69,175 @@ num_slow_processors = 1
 # read or write from / to the network socket.
 # (default 256)
 batch_size = 256
+
+# The number of SCMP error messages per second that may be sent in response to
+# the packets of any single source AS. 0 means unlimited.
+# (default 0)
+scmp_rate_limit = 0
+
+# The number of SCMP error messages that may be sent in a burst in response to
+# the packets of any single source AS.
+# (default 10)
+scmp_burst = 10
+
+# Add a diagnostic option to the SCMP error messages, with the interfaces and
+# the hop field at which the offending packet was rejected.
+# (default false)
+scmp_diagnostics = false
+
+# Drop the packets received from a neighbor that claim a source in the local AS,
+# or that claim to come from the neighbor but whose first hop field does not leave
+# the neighbor through the interface they arrived on.
+# (default false)
+anti_spoofing = false
+
+# Answer the STUN binding requests of the end hosts on the internal interface,
+# and deliver the packets for the addresses learned this way to their port, even
+# if it is outside the dispatched port range. This lets end hosts behind a NAT
+# learn their public address.
+# (default false)
+stun = false
+
+# The time during which a draining router keeps forwarding traffic before it
+# shuts down, unless the drain request through the HTTP API sets another one.
+# (default 30s)
+drain_grace_period = "30s"
+
+# The PEM file of the shared secret that authorizes the administrative requests
+# of the HTTP API: inspecting the dropped packets, mirroring and draining. The
+# requests must carry a JWT bearer token signed (HS256) with it. Without it,
+# these requests are refused.
+# (default "")
+# api_shared_secret = "/etc/scion/br-api.key"
+
+# Drop the packets that are larger than the MTU in the topology of the external
+# interface they would leave through. Check that the MTUs in the topology are not
+# below the ones of the links before enabling this.
+# (default false)
+enforce_mtu = false
+
+# Discover the path MTU toward the neighbors: the datagrams of the external
+# interfaces are sent with the don't fragment flag, and the path MTUs that the
+# operating system learns from ICMP errors limit the MTUs of the interfaces.
+# Only supported on Linux.
+# (default false)
+path_mtu_discovery = false
+
+# Pin the packet processors to the CPUs that the router may run on, spread over
+# the NUMA nodes, and hand the packets of each interface only to the processors on
+# the NUMA node where the operating system receives them. Only supported on Linux.
+# (default false)
+cpu_affinity = false
+
+# Shard the packets across the processors by the receive side scaling (RSS) hash
+# of their underlay addresses and ports, as the network device does across its
+# receive queues, instead of by their SCION flow. This only spreads the load if
+# the underlay carries many distinct UDP flows.
+# (default false)
+rss_sharding = false
+
+# The 40 byte key of the RSS hash, as shown by "ethtool -x". If not set, the
+# default key of the Microsoft RSS specification is used.
+# rss_key = "6d:5a:56:da:25:5b:0e:c2:41:67:..."
+
+# Drop the copies of a packet that are received within this time of the packet,
+# e.g. because a device on a link duplicates the traffic. 0 disables it.
+# (default 0)
+replay_window = "0s"
+
+# The number of packets remembered to detect their copies, for each of the 4096
+# groups into which the flows, by source AS and path, are hashed.
+# (default 64)
+replay_window_packets = 64
+
+# Authenticate the BFD control packets with a key shared with the remote routers,
+# measure the round trip time to the neighbors, and tune the failure detection of
+# the sessions with specific neighbors.
+# [router.bfd]
+# latency_probe_interval = "10s"
+# auth_type = "meticulous_keyed_sha1"
+# auth_key_id = 1
+# auth_key_file = "/etc/scion/bfd.key"
+#
+# [[router.bfd.neighbor]]
+# interface = 1
+# detect_mult = 5
+# desired_min_tx_interval = "50ms"
+# required_min_rx_interval = "50ms"
+
+# The traffic classes that are forwarded with a priority other than normal.
+# Packets are classified by the DSCP value in the traffic class field of their
+# SCION header. Each class sets the priority, one of "high", "normal" or "low",
+# and the DSCP value written into the underlay IP header of its packets.
+# [[router.qos.class]]
+# dscp = [46]
+# priority = "high"
+# underlay_dscp = 46
+
+# Filter the received packets. The first rule that matches a packet decides
+# whether it is permitted, denied, or rate limited. The packets that match no
+# rule are subject to the default action, "permit" or "deny".
+# [router.filter]
+# default_action = "permit"
+#
+# [[router.filter.rule]]
+# name = "block-scanner"
+# action = "deny"
+# src_isd_as = "1-ff00:0:110"
+# src_prefix = "192.0.2.0/24"
+#
+# [[router.filter.rule]]
+# name = "limit-dns"
+# action = "rate_limit"
+# dst_ports = "53"
+# rate = 10_000_000
+
+# Protect the hidden paths from interface 1 to the local AS with EPIC-HP: only
+# the EPIC packets whose hop validation field verifies are forwarded. With mode
+# "prioritize", they are forwarded with high priority and all others with low
+# priority instead.
+# [[router.epic_hp]]
+# ingress = 1
+# egress = 0
+# mode = "only"
+
+# Experimental: support the policy 5 for the packets from interface 1 to
+# interface 2, which is on a sibling router, as requested by the authenticated
+# FABRID option: the packets that request it are sent to the given internal
+# address, e.g. of a device that steers them along the path of the policy,
+# instead of to the sibling router. The packets that request a policy that is
+# not supported between their interfaces, or whose request does not verify with
+# the DRKey of their source host, are dropped. With src_isd_as, only the hosts
+# of the given ASes can request the policy.
+# [[router.fabrid]]
+# policy_id = 5
+# ingress = 1
+# egress = 2
+# src_isd_as = []
+# next_hop = "10.0.0.5:30042"
+
+# Append a telemetry record, with the time the router received the packet, the
+# time it spent in the router and the depth of its egress queue, to one in every
+# sample_rate of the packets, if they are transit packets. 0 disables telemetry.
+# [router.telemetry]
+# sample_rate = 10000
+
+# Mirror a sample of the forwarded packets, with synthesized IP and UDP headers
+# carrying their underlay addresses, to a pcap file or to a Unix datagram socket.
+# Mirroring can also be controlled at runtime through the HTTP API; the files and
+# sockets of these requests are relative to dir.
+# [router.mirror]
+# sample_rate = 100
+# interfaces = [1]
+# src_isd_as = "1-ff00:0:110"
+# max_packets = 10000
+# pcap_file = "/var/tmp/br1-mirror.pcap"
+# dir = "/var/tmp/br1-mirror"
+
+# Keep the last dropped packets, with the reason why they were dropped, for
+# inspection through the /drops resource of the HTTP API. The requests must be
+# authorized with a token signed with the api_shared_secret.
+# [router.drop_trace]
+# size = 1024
+# snap_len = 128
 `
@@ -15,13 +15,25 @@
 package router
 
 import (
+	"encoding/base64"
 	"net/netip"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/segment/iface"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/empty"
+	"github.com/scionproto/scion/pkg/slayers/path/epic"
+	"github.com/scionproto/scion/pkg/slayers/path/onehop"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/private/underlay/conn"
 	"github.com/scionproto/scion/router/config"
 	"github.com/scionproto/scion/router/control"
@@ -37,10 +49,21 @@ type Connector struct {
 	internalInterfaces []control.InternalInterface
 	externalInterfaces map[uint16]control.ExternalInterface
 	siblingInterfaces  map[uint16]control.SiblingInterface
+	// rateLimiters holds the limiter created for each entry of RateLimits, so that the
+	// interfaces to the same neighbor share one limiter.
+	rateLimiters map[int]*rateLimiter
 
-	ReceiveBufferSize   int
-	SendBufferSize      int
+	// Backend opens the underlay connections. If nil, the default backend with the default
+	// socket settings is used.
+	Backend             Backend
 	BFD                 config.BFD
+	RateLimits          []config.RateLimit
+	QoS                 config.QoS
+	Filter              config.Filter
+	EPICHP              []config.EPICHP
+	Fabrid              []config.Fabrid
+	Telemetry           config.Telemetry
+	EnforceMTU          bool
 	DispatchedPortStart *int
 	DispatchedPortEnd   *int
 }
@@ -56,7 +79,136 @@ func (c *Connector) CreateIACtx(ia addr.IA) error {
 		return serrors.JoinNoStack(errMultiIA, nil, "current", c.ia, "new", ia)
 	}
 	c.ia = ia
-	return c.DataPlane.SetIA(ia)
+	if err := c.DataPlane.SetIA(ia); err != nil {
+		return err
+	}
+	if err := c.configureQoS(); err != nil {
+		return err
+	}
+	if err := c.configureFilter(); err != nil {
+		return err
+	}
+	if err := c.configureEPICHP(); err != nil {
+		return err
+	}
+	if err := c.configureFabrid(); err != nil {
+		return err
+	}
+	return c.DataPlane.SetTelemetrySampleRate(c.Telemetry.SampleRate)
+}
+
+// configureQoS adds the configured traffic classes to the dataplane.
+func (c *Connector) configureQoS() error {
+	priorities := map[string]Priority{
+		"":       PriorityNormal,
+		"normal": PriorityNormal,
+		"high":   PriorityHigh,
+		"low":    PriorityLow,
+	}
+	for _, tc := range c.QoS.Classes {
+		prio, ok := priorities[tc.Priority]
+		if !ok {
+			return serrors.New("unknown priority", "priority", tc.Priority)
+		}
+		if err := c.DataPlane.AddTrafficClass(tc.DSCP, prio, tc.UnderlayDSCP); err != nil {
+			return serrors.Wrap("adding traffic class", err, "dscp", tc.DSCP)
+		}
+	}
+	return nil
+}
+
+// configureEPICHP adds the interface pairs protected with EPIC-HP to the dataplane.
+func (c *Connector) configureEPICHP() error {
+	modes := map[string]EPICHPMode{
+		"":           EPICHPOnly,
+		"only":       EPICHPOnly,
+		"prioritize": EPICHPPrioritize,
+	}
+	for _, hp := range c.EPICHP {
+		mode, ok := modes[hp.Mode]
+		if !ok {
+			return serrors.New("unknown EPIC-HP mode", "mode", hp.Mode)
+		}
+		if err := c.DataPlane.AddEPICHPInterfacePair(hp.Ingress, hp.Egress, mode); err != nil {
+			return serrors.Wrap("adding EPIC-HP interface pair", err,
+				"ingress", hp.Ingress, "egress", hp.Egress)
+		}
+	}
+	return nil
+}
+
+// configureFabrid adds the FABRID policies that the packets can request to the dataplane.
+func (c *Connector) configureFabrid() error {
+	for _, f := range c.Fabrid {
+		policy := FabridPolicy{
+			ID:      f.PolicyID,
+			Ingress: f.Ingress,
+			Egress:  f.Egress,
+			SrcIAs:  f.SrcIAs,
+			NextHop: f.NextHop,
+		}
+		if err := c.DataPlane.AddFabridPolicy(policy); err != nil {
+			return serrors.Wrap("adding FABRID policy", err, "policy_id", f.PolicyID)
+		}
+	}
+	return nil
+}
+
+// configureFilter adds the configured filter rules to the dataplane.
+func (c *Connector) configureFilter() error {
+	actions := map[string]FilterAction{
+		"":           FilterPermit,
+		"permit":     FilterPermit,
+		"deny":       FilterDeny,
+		"rate_limit": FilterRateLimit,
+	}
+	pathTypes := map[string]path.Type{
+		"empty":  empty.PathType,
+		"scion":  scion.PathType,
+		"onehop": onehop.PathType,
+		"epic":   epic.PathType,
+	}
+	if len(c.Filter.Rules) == 0 && c.Filter.DefaultAction == "" {
+		return nil
+	}
+	action, ok := actions[c.Filter.DefaultAction]
+	if !ok {
+		return serrors.New("unknown default filter action", "action", c.Filter.DefaultAction)
+	}
+	if err := c.DataPlane.SetFilterDefaultAction(action); err != nil {
+		return err
+	}
+	for i, r := range c.Filter.Rules {
+		rule := FilterRule{
+			Name:       r.Name,
+			Interfaces: r.Interfaces,
+			SrcIA:      r.SrcIA,
+			DstIA:      r.DstIA,
+			SrcPrefix:  r.SrcPrefix,
+			DstPrefix:  r.DstPrefix,
+			SrcPorts:   PortRange(r.SrcPorts),
+			DstPorts:   PortRange(r.DstPorts),
+			Rate:       r.Rate,
+			Burst:      r.Burst,
+		}
+		if rule.Name == "" {
+			rule.Name = strconv.Itoa(i)
+		}
+		if rule.Action, ok = actions[r.Action]; !ok {
+			return serrors.New("unknown filter action", "rule", rule.Name, "action", r.Action)
+		}
+		for _, pt := range r.PathTypes {
+			t, ok := pathTypes[pt]
+			if !ok {
+				return serrors.New("unknown path type", "rule", rule.Name, "path_type", pt)
+			}
+			rule.PathTypes = append(rule.PathTypes, t)
+		}
+		if err := c.DataPlane.AddFilterRule(rule); err != nil {
+			return serrors.Wrap("adding filter rule", err, "rule", rule.Name)
+		}
+	}
+	return nil
 }
 
 // AddInternalInterface adds the internal interface.
@@ -67,8 +219,7 @@ func (c *Connector) AddInternalInterface(ia addr.IA, local netip.AddrPort) error
 	if !c.ia.Equal(ia) {
 		return serrors.JoinNoStack(errMultiIA, nil, "current", c.ia, "new", ia)
 	}
-	connection, err := conn.New(local, netip.AddrPort{},
-		&conn.Config{ReceiveBufferSize: c.ReceiveBufferSize, SendBufferSize: c.SendBufferSize})
+	connection, err := c.backend().OpenInternal(local)
 	if err != nil {
 		return err
 	}
@@ -89,7 +240,10 @@ func (c *Connector) AddExternalInterface(localIfID iface.ID, link control.LinkIn
 	log.Debug("Adding external interface", "interface", localIfID,
 		"local_isd_as", link.Local.IA, "local_addr", link.Local.Addr,
 		"remote_isd_as", link.Remote.IA, "remote_addr", link.Remote.Addr,
+		"additional_underlays", len(link.AdditionalUnderlays),
+		"preferred_family", link.PreferredFamily,
 		"owned", owned,
+		"shared_with", link.SharedWith,
 		"link_bfd_configured", link.BFD.Disable != nil,
 		"link_bfd_enabled", link.BFD.Disable == nil || !*link.BFD.Disable,
 		"dataplane_bfd_enabled", !c.BFD.Disable)
@@ -104,7 +258,11 @@ func (c *Connector) AddExternalInterface(localIfID iface.ID, link control.LinkIn
 		return serrors.Wrap("adding neighboring IA", err, "if_id", localIfID)
 	}
 
-	link.BFD = c.applyBFDDefaults(link.BFD)
+	bfdCfg, err := c.applyBFDDefaults(link.BFD, intf, link.Remote.IA, owned)
+	if err != nil {
+		return serrors.Wrap("configuring BFD", err, "if_id", localIfID)
+	}
+	link.BFD = bfdCfg
 	if owned {
 		if len(c.externalInterfaces) == 0 {
 			c.externalInterfaces = make(map[uint16]control.ExternalInterface)
@@ -130,13 +288,103 @@ func (c *Connector) AddExternalInterface(localIfID iface.ID, link control.LinkIn
 			link.BFD, link.Instance)
 	}
 
-	connection, err := conn.New(link.Local.Addr, link.Remote.Addr,
-		&conn.Config{ReceiveBufferSize: c.ReceiveBufferSize, SendBufferSize: c.SendBufferSize})
+	if err := c.addEgressRateLimiters(intf, link.Remote.IA); err != nil {
+		return serrors.Wrap("adding rate limiters", err, "if_id", localIfID)
+	}
+	if c.EnforceMTU || c.DataPlane.RunConfig.PathMTUDiscovery {
+		if err := c.DataPlane.SetInterfaceMTU(intf, link.MTU); err != nil {
+			return err
+		}
+	}
+	connection, err := c.newExternalConn(link.Local.Addr, link.Remote.Addr, link.MTU)
+	if err != nil {
+		return err
+	}
+
+	err = c.DataPlane.AddExternalInterface(intf, connection, link.Local, link.Remote, link.BFD)
 	if err != nil {
 		return err
 	}
+	for _, u := range link.AdditionalUnderlays {
+		connection, err := c.newExternalConn(u.Local, u.Remote, link.MTU)
+		if err != nil {
+			return err
+		}
+		local := control.LinkEnd{IA: link.Local.IA, Addr: u.Local, IfID: link.Local.IfID}
+		remote := control.LinkEnd{IA: link.Remote.IA, Addr: u.Remote, IfID: link.Remote.IfID}
+		if err := c.DataPlane.AddExternalUnderlay(intf, connection, local, remote,
+			link.BFD); err != nil {
 
-	return c.DataPlane.AddExternalInterface(intf, connection, link.Local, link.Remote, link.BFD)
+			return serrors.Wrap("adding underlay", err, "if_id", localIfID, "remote", u.Remote)
+		}
+	}
+	if link.PreferredFamily != topology.AnyFamily {
+		if err := c.DataPlane.SetPreferredFamily(intf, link.PreferredFamily); err != nil {
+			return err
+		}
+	}
+	if link.SharedWith == "" {
+		return nil
+	}
+	if len(c.internalInterfaces) == 0 {
+		return serrors.New("no internal interface to reach the sibling router",
+			"if_id", localIfID, "sibling", link.SharedWith)
+	}
+	// For internal BFD always use the default configuration.
+	siblingBFD, err := c.applyBFDDefaults(control.BFD{}, intf, link.Remote.IA, false)
+	if err != nil {
+		return serrors.Wrap("configuring sibling BFD", err, "if_id", localIfID)
+	}
+	return c.DataPlane.ShareExternalInterface(intf, c.internalInterfaces[0].Addr,
+		link.SharedWithAddr, siblingBFD, link.SharedWith)
+}
+
+// addEgressRateLimiters adds the configured rate limiters that apply to the given interface.
+func (c *Connector) addEgressRateLimiters(intf uint16, neighbor addr.IA) error {
+	for i, rl := range c.RateLimits {
+		if rl.Interface != intf && !(rl.Interface == 0 && rl.NeighborIA.Equal(neighbor)) {
+			continue
+		}
+		l, ok := c.rateLimiters[i]
+		if !ok {
+			l = newEgressRateLimiter(rl.Rate, rl.Burst)
+			if c.rateLimiters == nil {
+				c.rateLimiters = make(map[int]*rateLimiter)
+			}
+			c.rateLimiters[i] = l
+		}
+		log.Debug("Adding egress rate limit", "interface", intf, "neighbor_isd_as", neighbor,
+			"rate", rl.Rate, "burst", rl.Burst)
+		if err := c.DataPlane.addEgressRateLimiter(intf, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveExternalInterface removes the given interface, whether owned or via a sibling router.
+func (c *Connector) RemoveExternalInterface(localIfID iface.ID) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	intf := uint16(localIfID)
+	log.Debug("Removing external interface", "interface", localIfID)
+
+	delete(c.externalInterfaces, intf)
+	delete(c.siblingInterfaces, intf)
+	return c.DataPlane.RemoveInterface(intf)
+}
+
+// newExternalConn opens the connection of an external interface with the given MTU.
+func (c *Connector) newExternalConn(local, remote netip.AddrPort, mtu int) (conn.Conn, error) {
+	return c.backend().OpenExternal(local, remote, mtu)
+}
+
+// backend returns the backend that opens the underlay connections.
+func (c *Connector) backend() Backend {
+	if c.Backend == nil {
+		c.Backend = NewSocketBackend(BackendConfig{})
+	}
+	return c.Backend
 }
 
 // AddSvc adds the service address for the given ISD-AS.
@@ -176,6 +424,21 @@ func (c *Connector) SetKey(ia addr.IA, index int, key []byte) error {
 	return c.DataPlane.SetKey(key)
 }
 
+// SetMasterKey sets the master key of the given ISD-AS, from which the keys of the FABRID option
+// are derived. The key is only kept if FABRID policies are configured.
+func (c *Connector) SetMasterKey(ia addr.IA, key []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	log.Debug("Setting master key", "isd_as", ia)
+	if !c.ia.Equal(ia) {
+		return serrors.JoinNoStack(errMultiIA, nil, "current", c.ia, "new", ia)
+	}
+	if len(c.Fabrid) == 0 {
+		return nil
+	}
+	return c.DataPlane.SetMasterKey(key)
+}
+
 func (c *Connector) ListInternalInterfaces() ([]control.InternalInterface, error) {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
@@ -193,6 +456,7 @@ func (c *Connector) ListExternalInterfaces() ([]control.ExternalInterface, error
 	externalInterfaceList := make([]control.ExternalInterface, 0, len(c.externalInterfaces))
 	for _, externalInterface := range c.externalInterfaces {
 		externalInterface.State = c.DataPlane.getInterfaceState(externalInterface.IfID)
+		externalInterface.RTT = c.DataPlane.getInterfaceRTT(externalInterface.IfID)
 		externalInterfaceList = append(externalInterfaceList, externalInterface)
 	}
 	return externalInterfaceList, nil
@@ -210,29 +474,94 @@ func (c *Connector) ListSiblingInterfaces() ([]control.SiblingInterface, error)
 	return siblingInterfaceList, nil
 }
 
-// applyBFDDefaults updates the given cfg object with the global default BFD settings.
-// Link-specific settings, if configured, remain unchanged.  IMPORTANT: cfg.Disable isn't a boolean
-// but a pointer to boolean, allowing a simple representation of the unconfigured state: nil. This
-// means that using a cfg object that hasn't been processed by this function may lead to a NPE.
-// In particular, "control.BFD{}" is invalid.
-func (c *Connector) applyBFDDefaults(cfg control.BFD) control.BFD {
+// applyBFDDefaults updates the given cfg object with the BFD settings configured for the neighbor
+// of an owned interface, and then with the global default BFD settings. Link-specific settings, if
+// configured, remain unchanged. The authentication is always taken from the router configuration.
+// IMPORTANT: cfg.Disable isn't a boolean but a pointer to boolean, allowing a simple
+// representation of the unconfigured state: nil. This means that using a cfg object that hasn't
+// been processed by this function may lead to a NPE. In particular, "control.BFD{}" is invalid.
+func (c *Connector) applyBFDDefaults(cfg control.BFD, intf uint16, neighbor addr.IA,
+	owned bool) (control.BFD, error) {
 
+	defaults := c.BFD
+	if owned {
+		defaults = c.neighborBFD(intf, neighbor)
+	}
 	if cfg.Disable == nil {
-		disable := c.BFD.Disable
+		disable := defaults.Disable
 		cfg.Disable = &disable
 	}
 	if cfg.DetectMult == 0 {
-		cfg.DetectMult = c.BFD.DetectMult
+		cfg.DetectMult = defaults.DetectMult
 	}
 	if cfg.DesiredMinTxInterval == 0 {
-		cfg.DesiredMinTxInterval = c.BFD.DesiredMinTxInterval.Duration
+		cfg.DesiredMinTxInterval = defaults.DesiredMinTxInterval.Duration
 	}
 	if cfg.RequiredMinRxInterval == 0 {
-		cfg.RequiredMinRxInterval = c.BFD.RequiredMinRxInterval.Duration
+		cfg.RequiredMinRxInterval = defaults.RequiredMinRxInterval.Duration
+	}
+	cfg.LatencyProbeInterval = defaults.LatencyProbeInterval.Duration
+	cfg.Auth = control.BFDAuth{}
+	switch defaults.AuthType {
+	case "", "none":
+	default:
+		key, err := loadBFDKey(defaults.AuthKeyFile)
+		if err != nil {
+			return control.BFD{}, err
+		}
+		cfg.Auth = control.BFDAuth{
+			Type:  control.BFDAuthType(defaults.AuthType),
+			KeyID: defaults.AuthKeyID,
+			Key:   key,
+		}
+	}
+	return cfg, nil
+}
+
+// neighborBFD returns the global BFD settings, overridden by the settings configured for the
+// given interface or, failing that, for the given neighboring AS.
+func (c *Connector) neighborBFD(intf uint16, neighbor addr.IA) config.BFD {
+	cfg := c.BFD
+	idx := slices.IndexFunc(c.BFD.Neighbors, func(n config.BFDNeighbor) bool {
+		return n.Interface == intf
+	})
+	if idx < 0 {
+		idx = slices.IndexFunc(c.BFD.Neighbors, func(n config.BFDNeighbor) bool {
+			return n.Interface == 0 && n.NeighborIA.Equal(neighbor)
+		})
+	}
+	if idx < 0 {
+		return cfg
+	}
+	n := c.BFD.Neighbors[idx]
+	if n.DetectMult != 0 {
+		cfg.DetectMult = n.DetectMult
+	}
+	if n.DesiredMinTxInterval.Duration != 0 {
+		cfg.DesiredMinTxInterval = n.DesiredMinTxInterval
+	}
+	if n.RequiredMinRxInterval.Duration != 0 {
+		cfg.RequiredMinRxInterval = n.RequiredMinRxInterval
+	}
+	if n.AuthType != "" {
+		cfg.AuthType, cfg.AuthKeyID, cfg.AuthKeyFile = n.AuthType, n.AuthKeyID, n.AuthKeyFile
 	}
 	return cfg
 }
 
+// loadBFDKey reads the base64 encoded BFD authentication key from the given file.
+func loadBFDKey(file string) ([]byte, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, serrors.Wrap("reading BFD authentication key", err, "file", file)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, serrors.Wrap("decoding BFD authentication key", err, "file", file)
+	}
+	return key, nil
+}
+
 func (c *Connector) SetPortRange(start, end uint16) {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
@@ -245,3 +574,38 @@ func (c *Connector) SetPortRange(start, end uint16) {
 	log.Debug("Endhost port range configuration", "startPort", start, "endPort", end)
 	c.DataPlane.SetPortRange(start, end)
 }
+
+// StartMirror starts mirroring the forwarded packets selected by the given configuration.
+func (c *Connector) StartMirror(cfg control.MirrorConfig) error {
+	return c.DataPlane.StartMirror(cfg)
+}
+
+// StopMirror stops mirroring packets.
+func (c *Connector) StopMirror() error {
+	return c.DataPlane.StopMirror()
+}
+
+// MirrorStatus returns the state of the packet mirroring.
+func (c *Connector) MirrorStatus() control.MirrorStatus {
+	return c.DataPlane.MirrorStatus()
+}
+
+// Drain puts the router into drain mode, with the given grace period.
+func (c *Connector) Drain(grace time.Duration) error {
+	return c.DataPlane.Drain(grace)
+}
+
+// DrainStatus returns the state of the draining of the router.
+func (c *Connector) DrainStatus() control.DrainStatus {
+	return c.DataPlane.DrainStatus()
+}
+
+// DroppedPackets returns the last packets dropped by the router.
+func (c *Connector) DroppedPackets() ([]control.DroppedPacket, error) {
+	return c.DataPlane.DroppedPackets()
+}
+
+// Drained returns a channel that is closed when the router can be shut down after draining.
+func (c *Connector) Drained() <-chan struct{} {
+	return c.DataPlane.Drained()
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ptr"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/epic"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/router/config"
+	"github.com/scionproto/scion/router/control"
+)
+
+func TestApplyBFDDefaults(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "bfd.key")
+	err := os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString([]byte("key"))+"\n"),
+		0600)
+	require.NoError(t, err)
+	neighbor := addr.MustParseIA("1-ff00:0:110")
+	c := &Connector{
+		BFD: config.BFD{
+			DetectMult:            3,
+			DesiredMinTxInterval:  util.DurWrap{Duration: 200 * time.Millisecond},
+			RequiredMinRxInterval: util.DurWrap{Duration: 200 * time.Millisecond},
+			AuthType:              "keyed_sha1",
+			AuthKeyID:             1,
+			AuthKeyFile:           keyFile,
+			Neighbors: []config.BFDNeighbor{
+				{
+					NeighborIA:           neighbor,
+					DetectMult:           4,
+					DesiredMinTxInterval: util.DurWrap{Duration: 100 * time.Millisecond},
+				},
+				{
+					Interface:  2,
+					DetectMult: 5,
+					AuthType:   "none",
+				},
+			},
+		},
+	}
+	auth := control.BFDAuth{Type: control.BFDAuthKeyedSHA1, KeyID: 1, Key: []byte("key")}
+
+	t.Run("global defaults", func(t *testing.T) {
+		cfg, err := c.applyBFDDefaults(control.BFD{}, 1, addr.MustParseIA("1-ff00:0:111"), true)
+		require.NoError(t, err)
+		assert.Equal(t, control.BFD{
+			Disable:               ptr.To(false),
+			DetectMult:            3,
+			DesiredMinTxInterval:  200 * time.Millisecond,
+			RequiredMinRxInterval: 200 * time.Millisecond,
+			Auth:                  auth,
+		}, cfg)
+	})
+	t.Run("neighbor AS and link settings", func(t *testing.T) {
+		cfg, err := c.applyBFDDefaults(control.BFD{RequiredMinRxInterval: time.Second},
+			1, neighbor, true)
+		require.NoError(t, err)
+		assert.Equal(t, control.BFD{
+			Disable:               ptr.To(false),
+			DetectMult:            4,
+			DesiredMinTxInterval:  100 * time.Millisecond,
+			RequiredMinRxInterval: time.Second,
+			Auth:                  auth,
+		}, cfg)
+	})
+	t.Run("interface before neighbor AS", func(t *testing.T) {
+		cfg, err := c.applyBFDDefaults(control.BFD{}, 2, neighbor, true)
+		require.NoError(t, err)
+		assert.Equal(t, uint8(5), cfg.DetectMult)
+		assert.Equal(t, 200*time.Millisecond, cfg.DesiredMinTxInterval)
+		assert.Equal(t, control.BFDAuth{}, cfg.Auth)
+	})
+	t.Run("sibling links only use the global settings", func(t *testing.T) {
+		cfg, err := c.applyBFDDefaults(control.BFD{}, 2, neighbor, false)
+		require.NoError(t, err)
+		assert.Equal(t, uint8(3), cfg.DetectMult)
+		assert.Equal(t, auth, cfg.Auth)
+	})
+	t.Run("missing key file", func(t *testing.T) {
+		c := &Connector{BFD: config.BFD{AuthType: "keyed_sha1", AuthKeyFile: keyFile + ".x"}}
+		_, err := c.applyBFDDefaults(control.BFD{}, 1, neighbor, true)
+		assert.Error(t, err)
+	})
+}
+
+func TestConfigureFilter(t *testing.T) {
+	c := &Connector{
+		Filter: config.Filter{
+			DefaultAction: "deny",
+			Rules: []config.FilterRule{
+				{
+					Name:      "dns",
+					Action:    "rate_limit",
+					DstPorts:  config.PortRange{Min: 53, Max: 53},
+					PathTypes: []string{"scion", "epic"},
+					Rate:      1_000_000,
+				},
+				{
+					Action: "permit",
+					SrcIA:  addr.MustParseIA("1-0"),
+				},
+			},
+		},
+	}
+	require.NoError(t, c.configureFilter())
+	f := c.DataPlane.filter
+	require.NotNil(t, f)
+	assert.Equal(t, FilterDeny, f.defaultAction)
+	require.Len(t, f.rules, 2)
+	assert.Equal(t, "dns", f.rules[0].Name)
+	assert.Equal(t, FilterRateLimit, f.rules[0].Action)
+	assert.Equal(t, PortRange{Min: 53, Max: 53}, f.rules[0].DstPorts)
+	assert.Equal(t, []path.Type{scion.PathType, epic.PathType}, f.rules[0].PathTypes)
+	assert.NotNil(t, f.rules[0].limiter)
+	assert.Equal(t, "1", f.rules[1].Name)
+	assert.Equal(t, FilterPermit, f.rules[1].Action)
+
+	c = &Connector{Filter: config.Filter{Rules: []config.FilterRule{{Action: "drop"}}}}
+	assert.Error(t, c.configureFilter())
+}
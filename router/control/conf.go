@@ -20,6 +20,7 @@ import (
 	"crypto/sha256"
 	"net/netip"
 	"sort"
+	"time"
 
 	"golang.org/x/crypto/pbkdf2"
 
@@ -35,14 +36,65 @@ type Dataplane interface {
 	CreateIACtx(ia addr.IA) error
 	AddInternalInterface(ia addr.IA, local netip.AddrPort) error
 	AddExternalInterface(localIfID iface.ID, info LinkInfo, owned bool) error
+	RemoveExternalInterface(localIfID iface.ID) error
 	AddSvc(ia addr.IA, svc addr.SVC, a netip.AddrPort) error
 	DelSvc(ia addr.IA, svc addr.SVC, a netip.AddrPort) error
 	SetKey(ia addr.IA, index int, key []byte) error
 	SetPortRange(start, end uint16)
 }
 
+// MasterKeySetter is implemented by the dataplanes that derive keys other than the hop field MAC
+// key, e.g. DRKeys, from the master key of the AS.
+type MasterKeySetter interface {
+	SetMasterKey(ia addr.IA, key []byte) error
+}
+
 // BFD is the configuration for the BFD sessions.
-type BFD topology.BFD
+// Disable is a pointer to boolean; nil means unspecified (see topology.BFD).
+type BFD struct {
+	Disable               *bool
+	DetectMult            uint8
+	DesiredMinTxInterval  time.Duration
+	RequiredMinRxInterval time.Duration
+	// Auth is the authentication of the BFD control packets. It is not part of the topology;
+	// the router sets it from its own configuration.
+	Auth BFDAuth
+	// LatencyProbeInterval is the interval at which the round trip time to the remote is
+	// measured. 0 means that it is not measured. Like Auth, it is set from the router
+	// configuration.
+	LatencyProbeInterval time.Duration
+}
+
+// BFDAuthType is the authentication type of BFD control packets.
+type BFDAuthType string
+
+const (
+	// BFDAuthNone means that the BFD control packets are not authenticated.
+	BFDAuthNone BFDAuthType = ""
+	// BFDAuthKeyedSHA1 is Keyed SHA1 authentication (RFC 5880, Section 6.7.4).
+	BFDAuthKeyedSHA1 BFDAuthType = "keyed_sha1"
+	// BFDAuthMeticulousKeyedSHA1 is Meticulous Keyed SHA1 authentication (RFC 5880,
+	// Section 6.7.4), where the sequence number of every packet is incremented.
+	BFDAuthMeticulousKeyedSHA1 BFDAuthType = "meticulous_keyed_sha1"
+)
+
+// BFDAuth is the authentication of BFD control packets.
+type BFDAuth struct {
+	Type  BFDAuthType
+	KeyID uint8
+	// Key is the shared secret, of at most 20 bytes.
+	Key []byte
+}
+
+// linkBFD returns the BFD configuration of a link, as found in the topology.
+func linkBFD(cfg topology.BFD) BFD {
+	return BFD{
+		Disable:               cfg.Disable,
+		DetectMult:            cfg.DetectMult,
+		DesiredMinTxInterval:  cfg.DesiredMinTxInterval,
+		RequiredMinRxInterval: cfg.RequiredMinRxInterval,
+	}
+}
 
 // LinkInfo contains the information about a link between an internal and
 // external router.
@@ -53,6 +105,17 @@ type LinkInfo struct {
 	LinkTo   topology.LinkType
 	BFD      BFD
 	MTU      int
+	// AdditionalUnderlays are further pairs of underlay addresses of the link, over which
+	// the traffic is spread.
+	AdditionalUnderlays []topology.Underlay
+	// PreferredFamily is the address family of the underlays that carry the traffic while one
+	// of them is up.
+	PreferredFamily topology.UnderlayFamily
+	// SharedWith is the name of the sibling router that serves the interface as well, if the
+	// interface is shared, and SharedWithAddr is its internal address. The traffic of the
+	// interface is shared with the sibling router by flow.
+	SharedWith     string
+	SharedWithAddr netip.AddrPort
 }
 
 // LinkEnd represents one end of a link.
@@ -68,6 +131,89 @@ type ObservableDataplane interface {
 	ListSiblingInterfaces() ([]SiblingInterface, error)
 }
 
+// PacketMirror is the interface that the http status handler expects from the dataplane to
+// mirror forwarded packets.
+type PacketMirror interface {
+	StartMirror(cfg MirrorConfig) error
+	StopMirror() error
+	MirrorStatus() MirrorStatus
+}
+
+// MirrorConfig selects the forwarded packets to mirror and where to mirror them to.
+type MirrorConfig struct {
+	// SampleRate is the fraction, 1/SampleRate, of the matching packets that are mirrored. 0 and
+	// 1 mirror all of them.
+	SampleRate uint32
+	// Interfaces restricts the mirroring to the packets entering or leaving through one of the
+	// given interfaces. If empty, the packets of all interfaces are mirrored.
+	Interfaces []uint16
+	// SrcIA and DstIA restrict the mirroring to the packets from, respectively to, the given AS.
+	// The zero value matches any AS.
+	SrcIA addr.IA
+	DstIA addr.IA
+	// MaxPackets stops the mirroring after the given number of packets. 0 means no limit.
+	MaxPackets uint64
+	// PcapFile is the path of the pcap file to write the packets to.
+	PcapFile string
+	// Socket is the path of the Unix datagram socket to send the packets to. Exactly one of
+	// PcapFile and Socket must be set.
+	Socket string
+}
+
+// MirrorStatus is the state of the mirroring of packets.
+type MirrorStatus struct {
+	// Active indicates whether packets are being mirrored.
+	Active bool
+	// Config is the configuration of the active mirroring, or of the last one if it stopped
+	// after MaxPackets packets. Otherwise, it is nil.
+	Config *MirrorConfig
+	// Mirrored is the number of packets mirrored.
+	Mirrored uint64
+	// Dropped is the number of sampled packets that could not be mirrored.
+	Dropped uint64
+}
+
+// Drainer is the interface that the http status handler expects from the dataplane to drain
+// the router before maintenance.
+type Drainer interface {
+	Drain(grace time.Duration) error
+	DrainStatus() DrainStatus
+}
+
+// DrainStatus is the state of the draining of the router.
+type DrainStatus struct {
+	// Draining indicates whether the router is draining.
+	Draining bool
+	// Deadline is the end of the grace period, after which the router shuts down. It is zero
+	// if the router is not draining.
+	Deadline time.Time
+}
+
+// DropTracer is the interface that the http status handler expects from the dataplane to list
+// the packets that it dropped last.
+type DropTracer interface {
+	DroppedPackets() ([]DroppedPacket, error)
+}
+
+// DroppedPacket is a packet that the router dropped, as kept in the drop trace.
+type DroppedPacket struct {
+	// Time is when the packet was dropped.
+	Time time.Time
+	// Reason is the reason why the packet was dropped, as in the reason label of the dropped
+	// packets metric.
+	Reason string
+	// Ingress is the interface the packet was received from. 0 is the internal interface.
+	Ingress uint16
+	// Egress is the interface the packet would have been sent through, if it was already known.
+	Egress uint16
+	// Src is the underlay address the packet was received from, if it is known.
+	Src netip.AddrPort
+	// Length is the length of the packet.
+	Length int
+	// Header is the start of the packet, with its headers, cut at the snap length of the trace.
+	Header []byte
+}
+
 // InternalInterface represents the internal underlay interface of a router.
 type InternalInterface struct {
 	IA   addr.IA
@@ -82,6 +228,8 @@ type ExternalInterface struct {
 	Link LinkInfo
 	// State indicates the interface state.
 	State InterfaceState
+	// RTT is the round trip time to the neighbor measured by BFD. It is 0 if it is not known.
+	RTT time.Duration
 }
 
 // SiblingInterface represents a sibling underlay interface of a router. A sibling interface
@@ -130,6 +278,11 @@ func ConfigDataplane(dp Dataplane, cfg *Config) error {
 		if err := dp.SetKey(cfg.IA, 0, key0); err != nil {
 			return err
 		}
+		if s, ok := dp.(MasterKeySetter); ok {
+			if err := s.SetMasterKey(cfg.IA, cfg.MasterKeys.Key0); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Add internal interfaces
@@ -166,20 +319,31 @@ func DeriveHFMacKey(k []byte) []byte {
 }
 
 func confExternalInterfaces(dp Dataplane, cfg *Config) error {
-	// Sort out keys/ifIDs to get deterministic order for unit testing
-	infoMap := cfg.Topo.IFInfoMap()
-	if len(infoMap) == 0 {
-		// nothing to do
-		return nil
-	}
-	ifIDs := []iface.ID{}
-	for k := range infoMap {
-		ifIDs = append(ifIDs, k)
+	links := externalLinks(cfg)
+	for _, ifID := range sortedIfIDs(links) {
+		if err := dp.AddExternalInterface(ifID, links[ifID].info, links[ifID].owned); err != nil {
+			return err
+		}
 	}
-	sort.Slice(ifIDs, func(i, j int) bool { return ifIDs[i] < ifIDs[j] })
-	// External interfaces
-	for _, ifID := range ifIDs {
-		iface := infoMap[ifID]
+	return nil
+}
+
+// externalLink is the configuration of an external interface as given to the dataplane.
+type externalLink struct {
+	info  LinkInfo
+	owned bool
+}
+
+// externalLinks returns the configuration of all the external interfaces of the AS.
+func externalLinks(cfg *Config) map[iface.ID]externalLink {
+	infoMap := cfg.Topo.IFInfoMap()
+	links := make(map[iface.ID]externalLink, len(infoMap))
+	for ifID, iface := range infoMap {
+		own, owned := cfg.BR.IFs[ifID]
+		if owned {
+			// A shared interface has an underlay of its own on each router.
+			iface = *own
+		}
 		linkInfo := LinkInfo{
 			Local: LinkEnd{
 				IA:   cfg.IA,
@@ -191,13 +355,17 @@ func confExternalInterfaces(dp Dataplane, cfg *Config) error {
 				Addr: iface.Remote,
 				IfID: iface.RemoteIfID,
 			},
-			Instance: iface.BRName,
-			BFD:      BFD(iface.BFD),
-			LinkTo:   iface.LinkType,
-			MTU:      iface.MTU,
+			Instance:            iface.BRName,
+			BFD:                 linkBFD(iface.BFD),
+			LinkTo:              iface.LinkType,
+			MTU:                 iface.MTU,
+			AdditionalUnderlays: iface.AdditionalUnderlays,
+			PreferredFamily:     iface.PreferredFamily,
+		}
+		if sibling, ok := cfg.Topo.BR(iface.SharedWith); owned && ok {
+			linkInfo.SharedWith = sibling.Name
+			linkInfo.SharedWithAddr = sibling.InternalAddr
 		}
-
-		_, owned := cfg.BR.IFs[ifID]
 		if !owned {
 			// The current implementation effectively uses IP/UDP tunnels to create the SCION
 			// network as an overlay, with forwarding to local hosts being a special case. When
@@ -209,13 +377,24 @@ func confExternalInterfaces(dp Dataplane, cfg *Config) error {
 			linkInfo.Remote.Addr = iface.InternalAddr // i.e. via sibling router.
 			// For internal BFD always use the default configuration.
 			linkInfo.BFD = BFD{}
+			// The sibling router spreads the traffic across the underlays.
+			linkInfo.AdditionalUnderlays = nil
+			linkInfo.PreferredFamily = topology.AnyFamily
 		}
+		links[ifID] = externalLink{info: linkInfo, owned: owned}
+	}
+	return links
+}
 
-		if err := dp.AddExternalInterface(ifID, linkInfo, owned); err != nil {
-			return err
-		}
+// sortedIfIDs returns the interface IDs of the given links in increasing order, which gives a
+// deterministic configuration order for unit testing.
+func sortedIfIDs(links map[iface.ID]externalLink) []iface.ID {
+	ifIDs := make([]iface.ID, 0, len(links))
+	for k := range links {
+		ifIDs = append(ifIDs, k)
 	}
-	return nil
+	sort.Slice(ifIDs, func(i, j int) bool { return ifIDs[i] < ifIDs[j] })
+	return ifIDs
 }
 
 var svcTypes = []addr.SVC{
@@ -229,20 +408,29 @@ func confServices(dp Dataplane, cfg *Config) error {
 		return nil
 	}
 	for _, svc := range svcTypes {
-		addrs, err := cfg.Topo.Multicast(svc)
-		if err != nil {
-			// XXX assumption is that any error means there are no addresses for the SVC type
-			continue
-		}
-		// Sort to get deterministic unit test, shouldn't matter for SVC resolution
-		sort.Slice(addrs, func(i, j int) bool {
-			return addrs[i].IP.String() < addrs[j].IP.String()
-		})
-		for _, a := range addrs {
-			if err := dp.AddSvc(cfg.IA, svc, a.AddrPort()); err != nil {
+		for _, a := range serviceAddrs(cfg, svc) {
+			if err := dp.AddSvc(cfg.IA, svc, a); err != nil {
 				return err
 			}
 		}
 	}
 	return nil
 }
+
+// serviceAddrs returns the addresses of the given service in the topology.
+func serviceAddrs(cfg *Config, svc addr.SVC) []netip.AddrPort {
+	addrs, err := cfg.Topo.Multicast(svc)
+	if err != nil {
+		// XXX assumption is that any error means there are no addresses for the SVC type
+		return nil
+	}
+	// Sort to get deterministic unit test, shouldn't matter for SVC resolution
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].IP.String() < addrs[j].IP.String()
+	})
+	result := make([]netip.AddrPort, 0, len(addrs))
+	for _, a := range addrs {
+		result = append(result, a.AddrPort())
+	}
+	return result
+}
@@ -15,11 +15,15 @@
 package control_test
 
 import (
+	"fmt"
+	"net/netip"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/segment/iface"
 	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/router/control"
 )
@@ -46,3 +50,92 @@ func TestLoad(t *testing.T) {
 		})
 	}
 }
+
+func TestReload(t *testing.T) {
+	const id = "br1-ff00_0_110-2"
+	loadConfig := func(t *testing.T, file string) *control.Config {
+		cfg, err := control.LoadConfig(id, "testdata")
+		require.NoError(t, err)
+		topo, err := topology.FromJSONFile(file)
+		require.NoError(t, err)
+		br, ok := topo.BR(id)
+		require.True(t, ok)
+		cfg.Topo, cfg.IA, cfg.BR = topo, topo.IA(), &br
+		return cfg
+	}
+
+	t.Run("changes are applied", func(t *testing.T) {
+		dp := &recordingDataplane{}
+		iac := &control.IACtx{Config: loadConfig(t, "testdata/topology.json"), DP: dp}
+		cfg := loadConfig(t, "testdata/topology_reload.json")
+		require.NoError(t, iac.Reload(cfg))
+		assert.Equal(t, []string{
+			"remove 1",
+			"remove 2",
+			"add 2 owned=true remote=127.0.0.3:50000",
+			"add 3 owned=true remote=127.0.0.5:50000",
+			"add 4 owned=false remote=127.0.0.1:50000",
+			"del CS 127.0.0.1:60004",
+			"add CS 127.0.0.1:60005",
+		}, dp.calls)
+		assert.Equal(t, cfg, iac.Config)
+		assert.Equal(t, cfg, iac.CurrentConfig())
+
+		// Reloading the same configuration again changes nothing.
+		dp.calls = nil
+		require.NoError(t, iac.Reload(loadConfig(t, "testdata/topology_reload.json")))
+		assert.Empty(t, dp.calls)
+	})
+	t.Run("internal address change is rejected", func(t *testing.T) {
+		dp := &recordingDataplane{}
+		iac := &control.IACtx{Config: loadConfig(t, "testdata/topology.json"), DP: dp}
+		cfg := loadConfig(t, "testdata/topology_reload.json")
+		cfg.BR.InternalAddr = netip.MustParseAddrPort("127.0.0.2:50001")
+		assert.Error(t, iac.Reload(cfg))
+		assert.Empty(t, dp.calls)
+	})
+	t.Run("master key change is rejected", func(t *testing.T) {
+		dp := &recordingDataplane{}
+		iac := &control.IACtx{Config: loadConfig(t, "testdata/topology.json"), DP: dp}
+		cfg := loadConfig(t, "testdata/topology_reload.json")
+		cfg.MasterKeys.Key0 = []byte("another key")
+		assert.Error(t, iac.Reload(cfg))
+		assert.Empty(t, dp.calls)
+	})
+}
+
+// recordingDataplane records the reconfiguration calls made by a reload.
+type recordingDataplane struct {
+	calls []string
+}
+
+func (d *recordingDataplane) CreateIACtx(addr.IA) error { return nil }
+
+func (d *recordingDataplane) AddInternalInterface(addr.IA, netip.AddrPort) error { return nil }
+
+func (d *recordingDataplane) AddExternalInterface(ifID iface.ID, info control.LinkInfo,
+	owned bool) error {
+
+	d.calls = append(d.calls, fmt.Sprintf("add %d owned=%t remote=%s", ifID, owned,
+		info.Remote.Addr))
+	return nil
+}
+
+func (d *recordingDataplane) RemoveExternalInterface(ifID iface.ID) error {
+	d.calls = append(d.calls, fmt.Sprintf("remove %d", ifID))
+	return nil
+}
+
+func (d *recordingDataplane) AddSvc(_ addr.IA, svc addr.SVC, a netip.AddrPort) error {
+	d.calls = append(d.calls, fmt.Sprintf("add %s %s", svc, a))
+	return nil
+}
+
+func (d *recordingDataplane) DelSvc(_ addr.IA, svc addr.SVC, a netip.AddrPort) error {
+	d.calls = append(d.calls, fmt.Sprintf("del %s %s", svc, a))
+	return nil
+}
+
+func (d *recordingDataplane) SetKey(addr.IA, int, []byte) error { return nil }
+
+func (d *recordingDataplane) SetPortRange(uint16, uint16) {}
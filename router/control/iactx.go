@@ -15,9 +15,13 @@
 package control
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"sync/atomic"
 
 	"github.com/scionproto/scion/pkg/addr"
 	"github.com/scionproto/scion/pkg/log"
@@ -98,6 +102,19 @@ type IACtx struct {
 	Config *Config
 	// DP is the underlying data plane.
 	DP Dataplane
+
+	// current is the configuration applied last, see CurrentConfig.
+	current atomic.Pointer[Config]
+}
+
+// CurrentConfig returns the configuration that was applied to the data plane
+// last, by Configure or Reload. Unlike Config, it may be read while the
+// configuration is reloaded.
+func (iac *IACtx) CurrentConfig() *Config {
+	if cfg := iac.current.Load(); cfg != nil {
+		return cfg
+	}
+	return iac.Config
 }
 
 // Configure configures the dataplane for the given context.
@@ -116,10 +133,93 @@ func (iac *IACtx) Configure() error {
 		}
 		return serrors.Wrap("config setup", err, "config", brConfDump)
 	}
+	iac.current.Store(cfg)
 	log.Debug("Dataplane configured successfully", "config", cfg)
 	return nil
 }
 
+// Reload reconfigures the running dataplane according to the given configuration, which
+// replaces the current one. The external interfaces that were removed or changed are removed
+// from the dataplane, and those that were added or changed are added to it; the traffic of the
+// other interfaces is not affected. The service addresses are updated likewise.
+//
+// Changes of the ISD-AS, of the internal address of the router, of the master keys, and of the
+// dispatched port range cannot be applied to a running dataplane and are rejected.
+// If an error occurs while reconfiguring, the dataplane may be left partially reconfigured.
+func (iac *IACtx) Reload(cfg *Config) error {
+	if cfg == nil || cfg.BR == nil {
+		return serrors.New("empty configuration")
+	}
+	cur := iac.Config
+	if !cfg.IA.Equal(cur.IA) {
+		return serrors.JoinNoStack(errRestartRequired, nil, "isd_as", cfg.IA)
+	}
+	if cfg.BR.InternalAddr != cur.BR.InternalAddr {
+		return serrors.JoinNoStack(errRestartRequired, nil, "internal_addr", cfg.BR.InternalAddr)
+	}
+	if !bytes.Equal(cfg.MasterKeys.Key0, cur.MasterKeys.Key0) ||
+		!bytes.Equal(cfg.MasterKeys.Key1, cur.MasterKeys.Key1) {
+
+		return serrors.JoinNoStack(errRestartRequired, nil, "changed", "master keys")
+	}
+	curStart, curEnd := cur.Topo.PortRange()
+	start, end := cfg.Topo.PortRange()
+	if start != curStart || end != curEnd {
+		return serrors.JoinNoStack(errRestartRequired, nil, "dispatched_ports",
+			fmt.Sprintf("%d-%d", start, end))
+	}
+
+	curLinks, links := externalLinks(cur), externalLinks(cfg)
+	for _, ifID := range sortedIfIDs(curLinks) {
+		if l, ok := links[ifID]; ok && reflect.DeepEqual(l, curLinks[ifID]) {
+			continue
+		}
+		log.Info("Removing external interface", "interface", ifID)
+		if err := iac.DP.RemoveExternalInterface(ifID); err != nil {
+			return serrors.Wrap("removing external interface", err, "if_id", ifID)
+		}
+	}
+	for _, ifID := range sortedIfIDs(links) {
+		if l, ok := curLinks[ifID]; ok && reflect.DeepEqual(l, links[ifID]) {
+			continue
+		}
+		log.Info("Adding external interface", "interface", ifID)
+		if err := iac.DP.AddExternalInterface(ifID, links[ifID].info,
+			links[ifID].owned); err != nil {
+
+			// Do not leave the interface half configured, so that the next reload can retry.
+			_ = iac.DP.RemoveExternalInterface(ifID)
+			return serrors.Wrap("adding external interface", err, "if_id", ifID)
+		}
+	}
+
+	for _, svc := range svcTypes {
+		curAddrs, addrs := serviceAddrs(cur, svc), serviceAddrs(cfg, svc)
+		for _, a := range curAddrs {
+			if slices.Contains(addrs, a) {
+				continue
+			}
+			if err := iac.DP.DelSvc(cfg.IA, svc, a); err != nil {
+				return serrors.Wrap("removing service", err, "svc", svc, "address", a)
+			}
+		}
+		for _, a := range addrs {
+			if slices.Contains(curAddrs, a) {
+				continue
+			}
+			if err := iac.DP.AddSvc(cfg.IA, svc, a); err != nil {
+				return serrors.Wrap("adding service", err, "svc", svc, "address", a)
+			}
+		}
+	}
+	iac.Config = cfg
+	iac.current.Store(cfg)
+	log.Info("Dataplane reconfigured successfully", "config", cfg)
+	return nil
+}
+
+var errRestartRequired = serrors.New("configuration change requires a restart")
+
 func dumpConfig(cfg *Config) (string, error) {
 	if cfg == nil {
 		return "", serrors.New("empty configuration")
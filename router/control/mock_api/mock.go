@@ -1,16 +1,106 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/router/control (interfaces: ObservableDataplane)
+// Source: github.com/scionproto/scion/router/control (interfaces: Drainer,DropTracer,ObservableDataplane,PacketMirror)
 
 // Package mock_api is a generated GoMock package.
 package mock_api
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	control "github.com/scionproto/scion/router/control"
 )
 
+// MockDrainer is a mock of Drainer interface.
+type MockDrainer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDrainerMockRecorder
+}
+
+// MockDrainerMockRecorder is the mock recorder for MockDrainer.
+type MockDrainerMockRecorder struct {
+	mock *MockDrainer
+}
+
+// NewMockDrainer creates a new mock instance.
+func NewMockDrainer(ctrl *gomock.Controller) *MockDrainer {
+	mock := &MockDrainer{ctrl: ctrl}
+	mock.recorder = &MockDrainerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDrainer) EXPECT() *MockDrainerMockRecorder {
+	return m.recorder
+}
+
+// Drain mocks base method.
+func (m *MockDrainer) Drain(arg0 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drain", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockDrainerMockRecorder) Drain(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockDrainer)(nil).Drain), arg0)
+}
+
+// DrainStatus mocks base method.
+func (m *MockDrainer) DrainStatus() control.DrainStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DrainStatus")
+	ret0, _ := ret[0].(control.DrainStatus)
+	return ret0
+}
+
+// DrainStatus indicates an expected call of DrainStatus.
+func (mr *MockDrainerMockRecorder) DrainStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrainStatus", reflect.TypeOf((*MockDrainer)(nil).DrainStatus))
+}
+
+// MockDropTracer is a mock of DropTracer interface.
+type MockDropTracer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDropTracerMockRecorder
+}
+
+// MockDropTracerMockRecorder is the mock recorder for MockDropTracer.
+type MockDropTracerMockRecorder struct {
+	mock *MockDropTracer
+}
+
+// NewMockDropTracer creates a new mock instance.
+func NewMockDropTracer(ctrl *gomock.Controller) *MockDropTracer {
+	mock := &MockDropTracer{ctrl: ctrl}
+	mock.recorder = &MockDropTracerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDropTracer) EXPECT() *MockDropTracerMockRecorder {
+	return m.recorder
+}
+
+// DroppedPackets mocks base method.
+func (m *MockDropTracer) DroppedPackets() ([]control.DroppedPacket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DroppedPackets")
+	ret0, _ := ret[0].([]control.DroppedPacket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DroppedPackets indicates an expected call of DroppedPackets.
+func (mr *MockDropTracerMockRecorder) DroppedPackets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DroppedPackets", reflect.TypeOf((*MockDropTracer)(nil).DroppedPackets))
+}
+
 // MockObservableDataplane is a mock of ObservableDataplane interface.
 type MockObservableDataplane struct {
 	ctrl     *gomock.Controller
@@ -78,3 +168,68 @@ func (mr *MockObservableDataplaneMockRecorder) ListSiblingInterfaces() *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSiblingInterfaces", reflect.TypeOf((*MockObservableDataplane)(nil).ListSiblingInterfaces))
 }
+
+// MockPacketMirror is a mock of PacketMirror interface.
+type MockPacketMirror struct {
+	ctrl     *gomock.Controller
+	recorder *MockPacketMirrorMockRecorder
+}
+
+// MockPacketMirrorMockRecorder is the mock recorder for MockPacketMirror.
+type MockPacketMirrorMockRecorder struct {
+	mock *MockPacketMirror
+}
+
+// NewMockPacketMirror creates a new mock instance.
+func NewMockPacketMirror(ctrl *gomock.Controller) *MockPacketMirror {
+	mock := &MockPacketMirror{ctrl: ctrl}
+	mock.recorder = &MockPacketMirrorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPacketMirror) EXPECT() *MockPacketMirrorMockRecorder {
+	return m.recorder
+}
+
+// MirrorStatus mocks base method.
+func (m *MockPacketMirror) MirrorStatus() control.MirrorStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MirrorStatus")
+	ret0, _ := ret[0].(control.MirrorStatus)
+	return ret0
+}
+
+// MirrorStatus indicates an expected call of MirrorStatus.
+func (mr *MockPacketMirrorMockRecorder) MirrorStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MirrorStatus", reflect.TypeOf((*MockPacketMirror)(nil).MirrorStatus))
+}
+
+// StartMirror mocks base method.
+func (m *MockPacketMirror) StartMirror(arg0 control.MirrorConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartMirror", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartMirror indicates an expected call of StartMirror.
+func (mr *MockPacketMirrorMockRecorder) StartMirror(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartMirror", reflect.TypeOf((*MockPacketMirror)(nil).StartMirror), arg0)
+}
+
+// StopMirror mocks base method.
+func (m *MockPacketMirror) StopMirror() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopMirror")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopMirror indicates an expected call of StopMirror.
+func (mr *MockPacketMirrorMockRecorder) StopMirror() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopMirror", reflect.TypeOf((*MockPacketMirror)(nil).StopMirror))
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/scionproto/scion/pkg/log"
 	"github.com/scionproto/scion/pkg/private/processmetrics"
 	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/stun"
 	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/pkg/slayers"
@@ -72,6 +73,11 @@ const (
 	// needs to be authenticated: 16B (e2e.option.Len()) + 16B (CMAC_tag.Len()).
 	e2eAuthHdrLen = 32
 
+	// e2eDiagnosticHdrLen is the length in bytes of added information when a SCMP error carries
+	// a diagnostic: 2B (option header) + 16B (diagnostic) + 2B (padding, or e2e header if the
+	// message is not authenticated).
+	e2eDiagnosticHdrLen = 20
+
 	// Needed to compute required padding
 	ptrSize = unsafe.Sizeof(&struct{ int }{})
 	is32bit = 1 - (ptrSize-4)/4
@@ -81,6 +87,8 @@ type BFDSession interface {
 	Run(ctx context.Context) error
 	ReceiveMessage(*layers.BFD)
 	IsUp() bool
+	// RTT returns the round trip time to the remote, or 0 if it is not known.
+	RTT() time.Duration
 }
 
 // BatchConn is a connection that supports batch reads and writes.
@@ -126,9 +134,9 @@ type Packet struct {
 	rawPacket []byte
 	// The entire packet buffer. We don't need it as a slice; we know its size.
 	buffer *[bufSize]byte
-	// The source address. Will be set by the receiver from smsg.Addr. We could update it in-place,
-	// but the IP address bytes in it are allocated by readbatch, so if we copy into a recyclable
-	// location, it's the original we throw away. No gain (may be a tiny bit?).
+	// The source address. It is owned by the packet, like DstAddr, and the receiver has it
+	// updated in-place by readbatch, so that receiving a packet allocates nothing on Linux. A
+	// cleared source address has a zero-length IP.
 	srcAddr *net.UDPAddr
 	// The address to where we are forwarding the packet.
 	// Will be set by the processing routine; it is updated in-place.
@@ -142,8 +150,18 @@ type Packet struct {
 	// The type of traffic. This is used for metrics at the forwarding stage, but is most
 	// economically determined at the processing stage. So store it here. It's 2 bytes long.
 	trafficType trafficType
-	// Pad to 64 bytes. For 64bit arch, add 12 bytes. For 32bit arch, add 32 bytes.
-	_ [4 + is32bit*24]byte
+	// The forwarding priority, and the traffic class of the underlay IP header. These are set by
+	// the processing routine from the traffic class of the packet.
+	priority   Priority
+	underlayTC uint8
+	// The reason why the packet is dropped, if it is. This is set by the processing routine.
+	dropReason dropReason
+	// Pad to 64 bytes. For 32bit arch, add 24 bytes. The padding is not last, as a last field of
+	// size zero would be padded itself.
+	_ [is32bit * 24]byte
+	// Whether the packet is handed over to the sibling router that shares the egress interface,
+	// rather than sent by this router. This is set by the processing routine.
+	viaSibling bool
 }
 
 // Keep this 6 bytes long. See comment for packet.
@@ -164,16 +182,22 @@ func (p *Packet) init(buffer *[bufSize]byte) *Packet {
 	p.buffer = buffer
 	p.rawPacket = p.buffer[:]
 	p.DstAddr = &net.UDPAddr{IP: make(net.IP, net.IPv6len)}
+	p.srcAddr = &net.UDPAddr{IP: make(net.IP, 0, net.IPv6len)}
 	return p
 }
 
 // reset() makes the packet ready to receive a new underlay message.
-// A cleared dstAddr is represented with a zero-length IP so we keep reusing the IP storage bytes.
+// A cleared dstAddr or srcAddr is represented with a zero-length IP so we keep reusing the IP
+// storage bytes.
 func (p *Packet) reset() {
 	p.DstAddr.IP = p.DstAddr.IP[0:0] // We're keeping the object, just blank it.
+	p.srcAddr.IP = p.srcAddr.IP[0:0]
+	p.srcAddr.Port = 0
+	p.srcAddr.Zone = ""
 	*p = Packet{
 		buffer:    p.buffer,    // keep the buffer
 		rawPacket: p.buffer[:], // restore the full packet capacity
+		srcAddr:   p.srcAddr,   // keep the srcAddr and so the IP slice and bytes
 		DstAddr:   p.DstAddr,   // keep the dstAddr and so the IP slice and bytes
 	}
 	// Everything else is reset to zero value.
@@ -187,6 +211,7 @@ type DataPlane struct {
 	interfaces          map[uint16]Link
 	linkTypes           map[uint16]topology.LinkType
 	neighborIAs         map[uint16]addr.IA
+	remoteIfIDs         map[uint16]uint16
 	internalIP          netip.Addr
 	svc                 *services
 	macFactory          func() hash.Hash
@@ -197,6 +222,63 @@ type DataPlane struct {
 	forwardingMetrics   map[uint16]interfaceMetrics
 	dispatchedPortStart uint16
 	dispatchedPortEnd   uint16
+	// egressLimiters holds the rate limiters applied to the packets leaving through each
+	// interface. A limiter may be shared by several interfaces.
+	egressLimiters map[uint16][]*rateLimiter
+	// mtus holds the largest SCION packet that may leave through each interface. Interfaces
+	// without an entry are only limited by the size of the packet buffers.
+	mtus map[uint16]int
+	// pathMTUs holds the path MTUs of the external interfaces discovered by the underlay. The
+	// forwarding tables use the smaller of the configured and the discovered MTU.
+	pathMTUs map[uint16]int
+	// scmpLimiter limits the rate of SCMP error messages per source AS. Nil if unlimited.
+	scmpLimiter *perIALimiter
+	// replay detects the duplicated packets. Nil if they are not suppressed.
+	replay *replayFilter
+	// natBindings holds the addresses learned from the STUN requests of end hosts, see STUN in
+	// RunConfig.
+	natBindings natBindings
+	// qos holds the handling of the configured traffic classes. Nil if there are none.
+	qos *qosClasses
+	// filter is the filter applied to the received packets. Nil if there is none.
+	filter *packetFilter
+	// epicHP holds the interface pairs protected with EPIC-HP. Nil if there are none.
+	epicHP map[interfacePair]EPICHPMode
+	// fabridPolicies holds the policies that the packets can request with the FABRID option.
+	// Nil if there are none.
+	fabridPolicies map[fabridKey]*fabridPolicy
+	// masterKey is the master key of the local AS, from which the keys of the FABRID option are
+	// derived. Nil if it is not set.
+	masterKey []byte
+	// telemetrySampleRate is the rate at which telemetry records are added to transit packets.
+	// 0 if telemetry is disabled.
+	telemetrySampleRate uint32
+	// mirror is the current packet mirror. Nil if packets are not mirrored.
+	mirror atomic.Pointer[mirror]
+	// dropTrace keeps the last dropped packets. It is set by Run, and nil if the drop trace is
+	// disabled.
+	dropTrace atomic.Pointer[dropTrace]
+	// drain is the state of the draining of the router. Nil unless it is draining. drained is
+	// closed when the grace period of the draining is over.
+	drain   atomic.Pointer[drainState]
+	drained chan struct{}
+	// downInterfaces are the external interfaces that went down after being up, and whose
+	// control services are notified until they are up again. It is only accessed under mtx.
+	downInterfaces map[uint16]struct{}
+	// tables is the snapshot of the forwarding tables used by the packet processing goroutines.
+	// The maps above are the master copies and are only accessed under mtx. Whenever they change,
+	// a new snapshot is published.
+	tables atomic.Pointer[forwardingTables]
+	// runCtx, procQs, and bfdStops are set by Run. They are used to start and stop the
+	// goroutines of the interfaces that are added or removed while running.
+	runCtx   context.Context
+	procQs   []chan *Packet
+	bfdStops map[netip.AddrPort]context.CancelFunc
+	// placement is the placement of the processors on the CPUs. It is set by Run, unless
+	// RunConfig.CPUAffinity is false or CPU affinity is not available.
+	placement *cpuPlacement
+	// poolAllocated is the number of packets allocated for the packet pool.
+	poolAllocated int
 
 	ExperimentalSCMPAuthentication bool
 	RunConfig                      RunConfig
@@ -235,8 +317,18 @@ var (
 	errBFDSessionDown             = errors.New("bfd session down")
 	expiredHop                    = errors.New("expired hop")
 	ingressInterfaceInvalid       = errors.New("ingress interface invalid")
+	firstHopInvalid               = errors.New("first hop inconsistent with ingress interface")
+	epicHPRequired                = errors.New("interface pair requires EPIC-HP")
+	invalidFabrid                 = errors.New("FABRID option does not match the path")
+	invalidFabridHVF              = errors.New("FABRID hop validation field verification failed")
+	fabridOutsideWindow           = errors.New("FABRID timestamp outside the acceptance window")
+	fabridPolicyUnsupported       = errors.New("requested FABRID policy not supported")
+	noFabridKey                   = errors.New("no master key to verify the FABRID option")
 	macVerificationFailed         = errors.New("MAC verification failed")
 	badPacketSize                 = errors.New("bad packet size")
+	errSCMPRateLimited            = errors.New("SCMP rate limit exceeded for source AS")
+	errPacketTooBig               = errors.New("packet larger than egress MTU")
+	duplicatePacket               = errors.New("duplicate packet")
 
 	// zeroBuffer will be used to reset the Authenticator option in the
 	// scionPacketProcessor.OptAuth
@@ -358,23 +450,20 @@ func (d *DataPlane) AddInternalInterface(conn BatchConn, ip netip.Addr) error {
 
 // AddExternalInterface adds the inter AS connection for the given interface ID.
 // If a connection for the given ID is already set this method will return an
-// error. This can only be called on a not yet running dataplane.
+// error. If the dataplane is running, the connection is served right away.
 func (d *DataPlane) AddExternalInterface(ifID uint16, conn BatchConn,
 	src, dst control.LinkEnd, cfg control.BFD) error {
 
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 
-	if d.IsRunning() {
-		return modifyExisting
-	}
 	if conn == nil || !src.Addr.IsValid() || !dst.Addr.IsValid() {
 		return emptyValue
 	}
 	if d.underlay == nil {
 		d.underlay = newUnderlay()
 	}
-	bfd, err := d.newExternalInterfaceBFD(ifID, src, dst, cfg)
+	bfd, err := d.newExternalInterfaceBFD(ifID, src, dst, cfg, true)
 	if err != nil {
 		return serrors.Wrap("adding external BFD", err, "if_id", ifID)
 	}
@@ -384,20 +473,113 @@ func (d *DataPlane) AddExternalInterface(ifID uint16, conn BatchConn,
 	if _, exists := d.interfaces[ifID]; exists {
 		return serrors.JoinNoStack(alreadySet, nil, "ifID", ifID)
 	}
-	d.interfaces[ifID] = d.underlay.NewExternalLink(
-		conn, d.RunConfig.BatchSize, bfd, dst.Addr, ifID)
+	link := d.underlay.NewExternalLink(conn, d.RunConfig.BatchSize, bfd, dst.Addr, ifID)
+	d.interfaces[ifID] = link
+	if dst.IfID != 0 {
+		if d.remoteIfIDs == nil {
+			d.remoteIfIDs = make(map[uint16]uint16)
+		}
+		d.remoteIfIDs[ifID] = uint16(dst.IfID)
+	}
+	d.startLink(ifID, link)
+	return nil
+}
+
+// AddExternalUnderlay adds a further inter AS connection to the given interface, which must
+// already have been added with AddExternalInterface. The traffic of the interface is spread
+// across all its connections by flow. Each connection has its own BFD session, and only
+// the connections that are up are used. If the dataplane is running, the connection is served
+// right away.
+func (d *DataPlane) AddExternalUnderlay(ifID uint16, conn BatchConn,
+	src, dst control.LinkEnd, cfg control.BFD) error {
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if conn == nil || !src.Addr.IsValid() || !dst.Addr.IsValid() {
+		return emptyValue
+	}
+	existing, exists := d.interfaces[ifID]
+	if !exists || existing.Scope() != External {
+		return serrors.New("no external interface to add the connection to", "ifID", ifID)
+	}
+	for _, m := range underlayLinks(existing) {
+		if m.Remote() == dst.Addr {
+			return serrors.JoinNoStack(alreadySet, nil, "ifID", ifID, "remote", dst.Addr)
+		}
+	}
+	bfd, err := d.newExternalInterfaceBFD(ifID, src, dst, cfg, false)
+	if err != nil {
+		return serrors.Wrap("adding external BFD", err, "if_id", ifID)
+	}
+	link := d.underlay.NewExternalLink(conn, d.RunConfig.BatchSize, bfd, dst.Addr, ifID)
+	if e, ok := existing.(*ecmpLink); ok {
+		d.interfaces[ifID] = e.withMember(link)
+	} else {
+		d.interfaces[ifID] = newECMPLink(existing, link)
+	}
+	d.startLink(ifID, link)
+	return nil
+}
+
+// SetPreferredFamily sets the address family of the underlays that carry the traffic of the
+// given external interface, as long as one of them is up. It only matters for interfaces with
+// several underlays.
+func (d *DataPlane) SetPreferredFamily(ifID uint16, family topology.UnderlayFamily) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	existing, exists := d.interfaces[ifID]
+	if !exists || existing.Scope() != External {
+		return serrors.New("no external interface to set the family of", "ifID", ifID)
+	}
+	if e, ok := existing.(*ecmpLink); ok {
+		d.interfaces[ifID] = e.withFamily(family)
+		d.publishTables()
+	}
+	return nil
+}
+
+// ShareExternalInterface shares the given external interface with the sibling router at the
+// given internal address, which serves the interface as well over an underlay of its own. The
+// packets that leave through the interface are shared between the two routers by flow. The
+// interface must have been added with AddExternalInterface, and its further underlays with
+// AddExternalUnderlay, before. The sibling router is reached from the given internal address,
+// and monitored with a BFD session, which is shared with the interfaces of the sibling router.
+func (d *DataPlane) ShareExternalInterface(ifID uint16, src, dst netip.AddrPort,
+	cfg control.BFD, sibling string) error {
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if !dst.IsValid() || !src.IsValid() {
+		return emptyValue
+	}
+	existing, exists := d.interfaces[ifID]
+	if !exists || existing.Scope() != External {
+		return serrors.New("no external interface to share", "ifID", ifID)
+	}
+	if _, ok := existing.(*sharedLink); ok {
+		return serrors.JoinNoStack(alreadySet, nil, "ifID", ifID)
+	}
+	bfd, err := d.newNextHopBFD(ifID, src, dst, cfg, sibling)
+	if err != nil {
+		return serrors.Wrap("adding sibling BFD", err, "if_id", ifID)
+	}
+	siblingLink := d.underlay.NewSiblingLink(d.RunConfig.BatchSize, bfd, dst)
+	link := newSharedLink(existing, siblingLink, src, dst)
+	d.interfaces[ifID] = link
+	d.startLink(ifID, link)
+	if _, running := d.bfdStops[dst]; d.runCtx != nil && !running {
+		d.startBFD(dst, siblingLink)
+	}
 	return nil
 }
 
 // AddNeighborIA adds the neighboring IA for a given interface ID. If an IA for
-// the given ID is already set, this method will return an error. This can only
-// be called on a not yet running dataplane.
+// the given ID is already set, this method will return an error.
 func (d *DataPlane) AddNeighborIA(ifID uint16, remote addr.IA) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.IsRunning() {
-		return modifyExisting
-	}
 	if remote.IsZero() {
 		return emptyValue
 	}
@@ -408,18 +590,49 @@ func (d *DataPlane) AddNeighborIA(ifID uint16, remote addr.IA) error {
 		d.neighborIAs = make(map[uint16]addr.IA)
 	}
 	d.neighborIAs[ifID] = remote
+	d.publishTables()
+	return nil
+}
+
+// SetInterfaceMTU sets the largest SCION packet, in bytes, that may be forwarded through the given
+// interface. Larger packets are dropped. 0 removes the limit.
+func (d *DataPlane) SetInterfaceMTU(ifID uint16, mtu int) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if mtu < 0 || mtu > bufSize {
+		return serrors.New("unsupported MTU", "if_id", ifID, "mtu", mtu, "max", bufSize)
+	}
+	if mtu == 0 {
+		delete(d.mtus, ifID)
+	} else {
+		if d.mtus == nil {
+			d.mtus = make(map[uint16]int)
+		}
+		d.mtus[ifID] = mtu
+	}
+	d.publishTables()
+	return nil
+}
+
+// addEgressRateLimiter adds a rate limiter to the packets leaving through the given interface.
+// The same limiter can be added to several interfaces, in which case their traffic is limited
+// jointly.
+func (d *DataPlane) addEgressRateLimiter(ifID uint16, l *rateLimiter) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.egressLimiters == nil {
+		d.egressLimiters = make(map[uint16][]*rateLimiter)
+	}
+	d.egressLimiters[ifID] = append(d.egressLimiters[ifID], l)
+	d.publishTables()
 	return nil
 }
 
 // AddLinkType adds the link type for a given interface ID. If a link type for
-// the given ID is already set, this method will return an error. This can only
-// be called on a not yet running dataplane.
+// the given ID is already set, this method will return an error.
 func (d *DataPlane) AddLinkType(ifID uint16, linkTo topology.LinkType) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.IsRunning() {
-		return modifyExisting
-	}
 	if _, exists := d.linkTypes[ifID]; exists {
 		return serrors.JoinNoStack(alreadySet, nil, "ifID", ifID)
 	}
@@ -427,18 +640,20 @@ func (d *DataPlane) AddLinkType(ifID uint16, linkTo topology.LinkType) error {
 		d.linkTypes = make(map[uint16]topology.LinkType)
 	}
 	d.linkTypes[ifID] = linkTo
+	d.publishTables()
 	return nil
 }
 
-// AddExternalInterfaceBFD adds the inter AS connection BFD session.
+// AddExternalInterfaceBFD adds the inter AS connection BFD session. The metrics are per
+// interface, so only the session of the primary connection of an interface reports them.
 func (d *DataPlane) newExternalInterfaceBFD(ifID uint16,
-	src, dst control.LinkEnd, cfg control.BFD) (BFDSession, error) {
+	src, dst control.LinkEnd, cfg control.BFD, primary bool) (BFDSession, error) {
 
 	if *cfg.Disable {
 		return nil, nil
 	}
 	var m bfd.Metrics
-	if d.Metrics != nil {
+	if d.Metrics != nil && primary {
 		labels := prometheus.Labels{
 			"interface":       fmt.Sprint(ifID),
 			"isd_as":          d.localIA.String(),
@@ -449,25 +664,56 @@ func (d *DataPlane) newExternalInterfaceBFD(ifID uint16,
 			StateChanges:    d.Metrics.BFDInterfaceStateChanges.With(labels),
 			PacketsSent:     d.Metrics.BFDPacketsSent.With(labels),
 			PacketsReceived: d.Metrics.BFDPacketsReceived.With(labels),
+			RTT:             d.Metrics.BFDRoundTripTime.With(labels),
 		}
 	}
 	s, err := newBFDSend(d, src.IA, dst.IA, src.Addr, dst.Addr, ifID, d.macFactory())
 	if err != nil {
 		return nil, err
 	}
-	return bfd.NewSession(s, cfg, m)
+	session, err := bfd.NewSession(s, cfg, m)
+	if err != nil {
+		return nil, err
+	}
+	session.StateChanged = func(bool) {
+		go func() {
+			defer log.HandlePanic()
+			d.interfaceStateChanged(ifID)
+		}()
+	}
+	return session, nil
 }
 
 // getInterfaceState checks if there is a bfd session for the input interfaceID and
 // returns InterfaceUp if the relevant BFDSession state is up, or if there is no BFD
 // session. Otherwise, it returns InterfaceDown.
 func (d *DataPlane) getInterfaceState(ifID uint16) control.InterfaceState {
-	if link := d.interfaces[ifID]; link != nil && !link.IsUp() {
+	if link := d.tables.Load().interfaces[ifID]; link != nil && !link.IsUp() {
 		return control.InterfaceDown
 	}
 	return control.InterfaceUp
 }
 
+// getInterfaceRTT returns the smallest round trip time measured by the BFD sessions of the
+// interface, or 0 if none is known.
+func (d *DataPlane) getInterfaceRTT(ifID uint16) time.Duration {
+	link := d.tables.Load().interfaces[ifID]
+	if link == nil {
+		return 0
+	}
+	var rtt time.Duration
+	for _, l := range underlayLinks(link) {
+		s := l.BFDSession()
+		if s == nil {
+			continue
+		}
+		if r := s.RTT(); r != 0 && (rtt == 0 || r < rtt) {
+			rtt = r
+		}
+	}
+	return rtt
+}
+
 // AddSvc adds the address for the given service. This can be called multiple
 // times for the same service, with the address added to the list of addresses
 // that provide the service.
@@ -509,17 +755,15 @@ func (d *DataPlane) DelSvc(svc addr.SVC, a netip.AddrPort) error {
 }
 
 // AddNextHop sets the next hop address for the given interface ID. If the
-// interface ID already has an address associated this operation fails. This can
-// only be called on a not yet running dataplane.
+// interface ID already has an address associated this operation fails. If the
+// dataplane is running, the BFD session to the next hop is started right away,
+// unless it is shared with other interfaces and already running.
 func (d *DataPlane) AddNextHop(ifID uint16, src, dst netip.AddrPort, cfg control.BFD,
 	sibling string) error {
 
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
 
-	if d.IsRunning() {
-		return modifyExisting
-	}
 	if !dst.IsValid() || !src.IsValid() {
 		return emptyValue
 	}
@@ -537,7 +781,9 @@ func (d *DataPlane) AddNextHop(ifID uint16, src, dst netip.AddrPort, cfg control
 		return serrors.JoinNoStack(alreadySet, nil, "ifID", ifID)
 	}
 
-	d.interfaces[ifID] = d.underlay.NewSiblingLink(d.RunConfig.BatchSize, bfd, dst)
+	link := d.underlay.NewSiblingLink(d.RunConfig.BatchSize, bfd, dst)
+	d.interfaces[ifID] = link
+	d.startLink(ifID, link)
 	return nil
 }
 
@@ -579,6 +825,57 @@ type RunConfig struct {
 	NumProcessors         int
 	NumSlowPathProcessors int
 	BatchSize             int
+	// SCMPRate is the number of SCMP error messages per second that may be sent in response to
+	// the packets from any single source AS. Zero means unlimited.
+	SCMPRate float64
+	// SCMPBurst is the number of SCMP error messages that may be sent in a burst to any single
+	// source AS.
+	SCMPBurst int
+	// AntiSpoofing enables the ingress anti-spoofing checks: the packets received from a
+	// neighbor must not claim a source in the local AS, and those that claim to come from the
+	// neighbor itself must have a first hop field that leaves the neighbor through the interface
+	// they arrived on. Packets that fail the checks are dropped.
+	AntiSpoofing bool
+	// DrainGracePeriod is the time during which a draining router keeps forwarding traffic
+	// before it shuts down, if Drain is not given one.
+	DrainGracePeriod time.Duration
+	// PathMTUDiscovery makes the router track the path MTUs toward its neighbors discovered by
+	// the underlay, and apply them as the MTUs of the external interfaces.
+	PathMTUDiscovery bool
+	// CPUAffinity pins the processors to the CPUs that the router may run on, spread over the
+	// NUMA nodes, and makes the receivers hand packets only to the processors on the NUMA node
+	// where the kernel receives them.
+	CPUAffinity bool
+	// RSSSharding makes the receivers shard the packets across the processors by the RSS hash of
+	// their underlay addresses and ports, instead of by their SCION flow.
+	RSSSharding bool
+	// RSSKey is the key of the RSS hash. If empty, the default key of the Microsoft RSS
+	// specification is used.
+	RSSKey []byte
+	// ReplayWindow is the time during which the duplicates of a SCION packet are detected and
+	// dropped. Zero disables the suppression of duplicates.
+	ReplayWindow time.Duration
+	// ReplayWindowPackets is the number of packets remembered for each group of flows, a flow
+	// being the packets of a source AS over a path.
+	ReplayWindowPackets int
+	// DropTraceSize is the number of the last dropped packets that are kept for inspection.
+	// Zero disables the drop trace.
+	DropTraceSize int
+	// DropTraceSnapLen is the number of bytes kept from the start of each dropped packet. If
+	// zero, enough for the headers of most packets.
+	DropTraceSnapLen int
+	// SCMPDiagnostics makes the router add a diagnostic option to the SCMP errors that it
+	// sends, with the interfaces and the hop field at which it rejected the offending packet.
+	SCMPDiagnostics bool
+	// ReceiveBufferAutotune makes the router double the receive buffers of the connections that
+	// drop datagrams because they are full, up to ReceiveBufferMaxSize bytes.
+	ReceiveBufferAutotune bool
+	ReceiveBufferMaxSize  int
+	// STUN makes the router answer the STUN binding requests of the end hosts on the internal
+	// interface, so that hosts behind a NAT learn their public address, and deliver the packets
+	// to the addresses learned this way on their port, even if it is outside the dispatched
+	// port range.
+	STUN bool
 }
 
 func (d *DataPlane) Run(ctx context.Context) error {
@@ -594,22 +891,41 @@ func (d *DataPlane) Run(ctx context.Context) error {
 
 	d.initPacketPool(processorQueueSize)
 	procQs, slowQs := d.initQueues(processorQueueSize)
+	d.runCtx = ctx
+	d.procQs = procQs
+	if d.RunConfig.CPUAffinity {
+		placement, err := newCPUPlacement(d.RunConfig.NumProcessors)
+		if err != nil {
+			log.Info("CPU affinity not available, processors are not pinned", "err", err)
+		} else {
+			d.placement = placement
+		}
+	}
+	if d.RunConfig.SCMPRate > 0 {
+		d.scmpLimiter = newPerIALimiter(d.RunConfig.SCMPRate,
+			float64(max(d.RunConfig.SCMPBurst, 1)))
+	}
+	if d.RunConfig.ReplayWindow > 0 {
+		d.replay = newReplayFilter(d.RunConfig.ReplayWindow,
+			max(d.RunConfig.ReplayWindowPackets, 1))
+	}
+	if d.RunConfig.DropTraceSize > 0 {
+		d.dropTrace.Store(newDropTrace(d.RunConfig.DropTraceSize, d.RunConfig.DropTraceSnapLen))
+	}
 
 	d.setRunning()
 	for _, c := range underlayConnections {
-		go func(c UnderlayConn) {
-			defer log.HandlePanic()
-			d.runReceiver(c, procQs)
-		}(c)
-		go func(c UnderlayConn) {
-			defer log.HandlePanic()
-			d.runForwarder(c)
-		}(c)
+		d.startConnection(c)
 	}
 
 	for i := 0; i < d.RunConfig.NumProcessors; i++ {
 		go func(i int) {
 			defer log.HandlePanic()
+			if d.placement != nil {
+				if err := pinThread(d.placement.procCPUs[i]); err != nil {
+					log.Info("Failed to pin processor", "processor", i, "err", err)
+				}
+			}
 			d.runProcessor(i, procQs[i], slowQs[i%d.RunConfig.NumSlowPathProcessors])
 		}(i)
 	}
@@ -621,17 +937,24 @@ func (d *DataPlane) Run(ctx context.Context) error {
 	}
 
 	for addr, link := range d.underlay.Links() {
-		s := link.BFDSession()
-		if s == nil {
-			continue
-		}
-		go func(addr netip.AddrPort) {
+		d.startBFD(addr, link)
+	}
+	if d.RunConfig.PathMTUDiscovery {
+		go func() {
 			defer log.HandlePanic()
-			if err := s.Run(ctx); err != nil && err != bfd.AlreadyRunning {
-				log.Error("BFD session failed to start", "remote address", addr, "err", err)
-			}
-		}(addr)
+			d.runPathMTUDiscovery(ctx)
+		}()
+	}
+	if d.RunConfig.ReceiveBufferAutotune {
+		go func() {
+			defer log.HandlePanic()
+			d.runReceiveBufferAutotuning(ctx)
+		}()
 	}
+	go func() {
+		defer log.HandlePanic()
+		d.runInterfaceDownNotification(ctx)
+	}()
 
 	d.mtx.Unlock()
 	<-ctx.Done()
@@ -639,19 +962,22 @@ func (d *DataPlane) Run(ctx context.Context) error {
 }
 
 // initializePacketPool calculates the size of the packet pool based on the
-// current dataplane settings and allocates all the buffers
+// current dataplane settings and allocates all the buffers. Room is left in the
+// pool for the buffers of interfaces added while running.
 func (d *DataPlane) initPacketPool(processorQueueSize int) {
 	poolSize := len(d.interfaces)*d.RunConfig.BatchSize +
 		(d.RunConfig.NumProcessors+d.RunConfig.NumSlowPathProcessors)*(processorQueueSize+1) +
 		len(d.interfaces)*(2*d.RunConfig.BatchSize)
+	poolCap := poolSize + reloadPoolInterfaces*3*d.RunConfig.BatchSize
 
 	log.Debug("Initialize packet pool of size", "poolSize", poolSize)
-	d.packetPool = make(chan *Packet, poolSize)
+	d.packetPool = make(chan *Packet, poolCap)
 	pktBuffers := make([][bufSize]byte, poolSize)
 	pktStructs := make([]Packet, poolSize)
 	for i := 0; i < poolSize; i++ {
 		d.packetPool <- pktStructs[i].init(&pktBuffers[i])
 	}
+	d.poolAllocated = poolSize
 }
 
 // initializes the processing routines and queues
@@ -686,6 +1012,10 @@ func (d *DataPlane) runReceiver(u UnderlayConn, procQs []chan *Packet) {
 	for _, c := range randomBytes {
 		hashSeed = hashFNV1a(hashSeed, c)
 	}
+	conn := u.Conn()
+	sharder := d.newFlowSharder(conn, hashSeed)
+	// localized is set once the receiver is placed on the NUMA node of its connection.
+	localized := d.placement == nil
 
 	// A collection of socket messages, as the readBatch API expects them. We keep using the same
 	// collection, call after call; only replacing the buffer.
@@ -697,36 +1027,41 @@ func (d *DataPlane) runReceiver(u UnderlayConn, procQs []chan *Packet) {
 
 	numReusable := 0 // unused buffers from previous loop
 	ifID := u.IfID()
-	metrics := d.forwardingMetrics[ifID] // If receiver exists, fw metrics exist too.
+	// If receiver exists, fw metrics exist too.
+	metrics := d.tables.Load().forwardingMetrics[ifID]
 
 	enqueueForProcessing := func(size int, srcAddr *net.UDPAddr, pkt *Packet) {
 		sc := classOfSize(size)
 		metrics[sc].InputPacketsTotal.Inc()
 		metrics[sc].InputBytesTotal.Add(float64(size))
 
-		procID, err := computeProcID(pkt.rawPacket, d.RunConfig.NumProcessors, hashSeed)
-		if err != nil {
-			log.Debug("Error while computing procID", "err", err)
-			d.returnPacketToPool(pkt)
-			metrics[sc].DroppedPacketsInvalid.Inc()
-			return
-		}
+		procID, err := sharder.procID(pkt.rawPacket, srcAddr)
 
 		pkt.rawPacket = pkt.rawPacket[:size] // Update size; readBatch does not.
 		// TODO(multi_underlay): We should begin with finding the link and get the ifID
 		// from there. We will do that once we actually move these pre-processing tasks directly
 		// into the underlay.
 		pkt.ingress = ifID
-		pkt.srcAddr = srcAddr
+		if srcAddr != pkt.srcAddr {
+			// Not read in-place.
+			updateNetAddrFromNetAddr(pkt.srcAddr, srcAddr)
+		}
+		if err != nil {
+			log.Debug("Error while computing procID", "err", err)
+			d.traceDrop(pkt, dropParseError)
+			d.returnPacketToPool(pkt)
+			metrics[sc].DroppedPackets[dropParseError].Inc()
+			return
+		}
 		select {
 		case procQs[procID] <- pkt:
 		default:
+			d.traceDrop(pkt, dropBusyProcessor)
 			d.returnPacketToPool(pkt)
-			metrics[sc].DroppedPacketsBusyProcessor.Inc()
+			metrics[sc].DroppedPackets[dropBusyProcessor].Inc()
 		}
 	}
 
-	conn := u.Conn()
 	for d.IsRunning() {
 		// collect packets.
 
@@ -736,22 +1071,69 @@ func (d *DataPlane) runReceiver(u UnderlayConn, procQs []chan *Packet) {
 			p.reset()
 			packets[i] = p
 			msgs[i].Buffers[0] = p.rawPacket
+			msgs[i].Addr = p.srcAddr
 		}
 
 		// Fill the packets
 		numPkts, err := conn.ReadBatch(msgs)
 		numReusable = len(msgs) - numPkts
+		if errors.Is(err, net.ErrClosed) {
+			// The interface has been removed.
+			for _, p := range packets[:len(msgs)] {
+				d.returnPacketToPool(p)
+			}
+			log.Debug("Receiver stopped", "connection", u.Name())
+			return
+		}
 		if err != nil {
 			log.Debug("Error while reading batch", "interfaceID", ifID, "err", err)
 			continue
 		}
+		if !localized && numPkts > 0 {
+			localized = sharder.localize(d.placement, conn)
+		}
 		for i, msg := range msgs[:numPkts] {
+			if underlayconn.Truncated(&msgs[i]) {
+				// Larger than the packet buffers; what was read is not usable.
+				metrics[classOfSize(msg.N)].DroppedPackets[dropTooBig].Inc()
+				d.returnPacketToPool(packets[i])
+				continue
+			}
 			enqueueForProcessing(msg.N, msg.Addr.(*net.UDPAddr), packets[i])
 		}
 	}
 }
 
+// newFlowSharder returns the sharder of the packets read from the given connection across the
+// processors.
+func (d *DataPlane) newFlowSharder(conn BatchConn, hashSeed uint32) *flowSharder {
+	s := &flowSharder{
+		numProcs: d.RunConfig.NumProcessors,
+		hashSeed: hashSeed,
+	}
+	if d.RunConfig.RSSSharding {
+		s.rssKey = d.RunConfig.RSSKey
+		if len(s.rssKey) == 0 {
+			s.rssKey = defaultRSSKey
+		}
+		if c, ok := conn.(localAddrConn); ok {
+			s.local = c.LocalAddr()
+		}
+	}
+	return s
+}
+
 func computeProcID(data []byte, numProcRoutines int, hashSeed uint32) (uint32, error) {
+	s, err := flowHash(data, hashSeed)
+	if err != nil {
+		return 0, err
+	}
+	return s % uint32(numProcRoutines), nil
+}
+
+// flowHash returns the hash of the flow of the given packet: of its flow ID and of its source
+// and destination addresses.
+func flowHash(data []byte, hashSeed uint32) (uint32, error) {
 	if len(data) < slayers.CmnHdrLen {
 		return 0, errShortPacket
 	}
@@ -774,8 +1156,7 @@ func computeProcID(data []byte, numProcRoutines int, hashSeed uint32) (uint32, e
 	for _, c := range data[slayers.CmnHdrLen : slayers.CmnHdrLen+addrHdrLen] {
 		s = hashFNV1a(s, c)
 	}
-
-	return s % uint32(numProcRoutines), nil
+	return s, nil
 }
 
 func (d *DataPlane) getPacketFromPool() *Packet {
@@ -795,10 +1176,20 @@ func (d *DataPlane) runProcessor(id int, q <-chan *Packet, slowQ chan<- *Packet)
 		if !ok {
 			continue
 		}
+		// Classified first, so that the processing can still change the priority.
+		if d.qos != nil {
+			d.qos.classify(p)
+		}
+		var received time.Time
+		sampled := processor.sampleTelemetry()
+		if sampled {
+			received = time.Now()
+		}
 		disp := processor.processPkt(p)
+		t := processor.tables
 
 		sc := classOfSize(len(p.rawPacket))
-		metrics := d.forwardingMetrics[p.ingress][sc]
+		metrics := t.forwardingMetrics[p.ingress][sc]
 		metrics.ProcessedPackets.Inc()
 
 		switch disp {
@@ -809,7 +1200,8 @@ func (d *DataPlane) runProcessor(id int, q <-chan *Packet, slowQ chan<- *Packet)
 			select {
 			case slowQ <- p:
 			default:
-				metrics.DroppedPacketsBusySlowPath.Inc()
+				metrics.DroppedPackets[dropBusySlowPath].Inc()
+				d.traceDrop(p, dropBusySlowPath)
 				d.returnPacketToPool(p)
 			}
 			continue
@@ -817,7 +1209,8 @@ func (d *DataPlane) runProcessor(id int, q <-chan *Packet, slowQ chan<- *Packet)
 			d.returnPacketToPool(p)
 			continue
 		case pDiscard: // Everything else
-			metrics.DroppedPacketsInvalid.Inc()
+			metrics.DroppedPackets[p.dropReason].Inc()
+			d.traceDrop(p, p.dropReason)
 			d.returnPacketToPool(p)
 			continue
 		default: // Newly added dispositions need to be handled.
@@ -825,20 +1218,49 @@ func (d *DataPlane) runProcessor(id int, q <-chan *Packet, slowQ chan<- *Packet)
 			d.returnPacketToPool(p)
 			continue
 		}
-		fwLink, ok := d.interfaces[p.egress]
+		fwLink, ok := t.interfaces[p.egress]
 		if !ok {
 			log.Debug("Error determining forwarder. Egress is invalid", "egress", p.egress)
-			metrics.DroppedPacketsInvalid.Inc()
+			metrics.DroppedPackets[dropUnknownInterface].Inc()
+			d.traceDrop(p, dropUnknownInterface)
+			d.returnPacketToPool(p)
+			continue
+		}
+		if sampled && p.trafficType.isTransit() {
+			t.addTelemetry(p, d.localIA, received, fwLink)
+		}
+		if !t.withinEgressMTU(p) {
+			t.forwardingMetrics[p.egress][sc].DroppedPackets[dropTooBig].Inc()
+			d.traceDrop(p, dropTooBig)
 			d.returnPacketToPool(p)
 			continue
 		}
+		if !t.withinEgressRate(p) {
+			t.forwardingMetrics[p.egress][sc].DroppedPackets[dropRateLimited].Inc()
+			d.traceDrop(p, dropRateLimited)
+			d.returnPacketToPool(p)
+			continue
+		}
+		d.mirrorPacket(p, fwLink)
 		if !fwLink.Send(p) {
+			d.traceDrop(p, dropBusyForwarder)
 			d.returnPacketToPool(p)
-			metrics.DroppedPacketsBusyForwarder.Inc()
+			metrics.DroppedPackets[dropBusyForwarder].Inc()
 		}
 	}
 }
 
+// withinEgressMTU returns true if the packet is not larger than the MTU of its egress interface.
+func (t *forwardingTables) withinEgressMTU(p *Packet) bool {
+	mtu, ok := t.mtus[p.egress]
+	return !ok || len(p.rawPacket) <= mtu
+}
+
+// withinEgressRate returns true if the packet is within the rate limits of its egress interface.
+func (t *forwardingTables) withinEgressRate(p *Packet) bool {
+	return allowAll(t.egressLimiters[p.egress], len(p.rawPacket))
+}
+
 func (d *DataPlane) runSlowPathProcessor(id int, q <-chan *Packet) {
 
 	log.Debug("Initialize slow-path processor with", "id", id)
@@ -848,21 +1270,45 @@ func (d *DataPlane) runSlowPathProcessor(id int, q <-chan *Packet) {
 		if !ok {
 			continue
 		}
+		// A packet that triggers an SCMP error is dropped, and the error is sent in its place.
+		// It is counted with the reason determined by the fast path.
+		isError := p.slowPathRequest.typ == slowPathSCMP
+		reason, origSC := p.dropReason, classOfSize(len(p.rawPacket))
+		if isError {
+			// Traced before the packet is replaced with the error.
+			d.traceDrop(p, reason)
+		}
 		err := processor.processPacket(p)
+		t := processor.tables
 		sc := classOfSize(len(p.rawPacket))
-		metrics := d.forwardingMetrics[p.ingress][sc]
+		metrics := t.forwardingMetrics[p.ingress][sc]
+		if errors.Is(err, errSCMPRateLimited) {
+			metrics.DroppedPackets[dropSCMPRateLimited].Inc()
+			d.returnPacketToPool(p)
+			continue
+		}
 		if err != nil {
 			log.Debug("Error processing packet", "err", err)
-			metrics.DroppedPacketsInvalid.Inc()
+			metrics.DroppedPackets[reason].Inc()
+			if !isError {
+				d.traceDrop(p, reason)
+			}
 			d.returnPacketToPool(p)
 			continue
 		}
-		fwLink, ok := d.interfaces[p.egress]
+		if isError {
+			t.forwardingMetrics[p.ingress][origSC].DroppedPackets[reason].Inc()
+		}
+		fwLink, ok := t.interfaces[p.egress]
 		if !ok {
 			log.Debug("Error determining forwarder. Egress is invalid", "egress", p.egress)
 			d.returnPacketToPool(p)
 			continue
 		}
+		if d.qos != nil {
+			d.qos.classify(p)
+		}
+		d.mirrorPacket(p, fwLink)
 		if !fwLink.Send(p) {
 			d.returnPacketToPool(p)
 		}
@@ -885,8 +1331,9 @@ func newSlowPathProcessor(d *DataPlane) *slowPathPacketProcessor {
 }
 
 type slowPathPacketProcessor struct {
-	d   *DataPlane
-	pkt *Packet
+	d      *DataPlane
+	pkt    *Packet
+	tables *forwardingTables
 
 	scionLayer slayers.SCION
 	hbhLayer   slayers.HopByHopExtnSkipper
@@ -917,6 +1364,9 @@ func (p *slowPathPacketProcessor) processPacket(pkt *Packet) error {
 	var err error
 	p.reset()
 	p.pkt = pkt
+	p.tables = p.d.tables.Load()
+	// The packets of the slow path are sent by this router.
+	pkt.viaSibling = false
 
 	p.lastLayer, err = decodeLayers(pkt.rawPacket, &p.scionLayer, &p.hbhLayer, &p.e2eLayer)
 	if err != nil {
@@ -947,6 +1397,9 @@ func (p *slowPathPacketProcessor) processPacket(pkt *Packet) error {
 	s := pkt.slowPathRequest
 	switch s.typ {
 	case slowPathSCMP: //SCMP
+		if p.d.scmpLimiter != nil && !p.d.scmpLimiter.allow(p.scionLayer.SrcIA) {
+			return errSCMPRateLimited
+		}
 		var layer gopacket.SerializableLayer
 		switch s.scmpType {
 		case slayers.SCMPTypeParameterProblem:
@@ -959,6 +1412,8 @@ func (p *slowPathPacketProcessor) processPacket(pkt *Packet) error {
 		case slayers.SCMPTypeInternalConnectivityDown:
 			layer = &slayers.SCMPInternalConnectivityDown{IA: p.d.localIA,
 				Ingress: uint64(p.pkt.ingress), Egress: uint64(p.pkt.egress)}
+		case slayers.SCMPTypePacketTooBig:
+			layer = &slayers.SCMPPacketTooBig{MTU: uint16(p.tables.mtus[p.pkt.egress])}
 		}
 		return p.packSCMP(s.scmpType, s.code, layer, true)
 
@@ -1024,14 +1479,38 @@ func (d *DataPlane) runForwarder(u UnderlayConn) {
 	for i := range msgs {
 		msgs[i].Buffers = make([][]byte, 1)
 	}
+	// The control messages that set the underlay traffic class of the packets.
+	oob := make([]byte, d.RunConfig.BatchSize*underlayconn.TrafficClassLen)
+	ipv6 := underlayIPv6(u.Conn())
 
-	c := u.Queue()
+	var qs [NumPriorities]<-chan *Packet
+	for i, prio := range prioritiesInOrder {
+		qs[i] = u.Queue(prio)
+	}
+	done := u.Done()
 	conn := u.Conn()
-	metrics := d.forwardingMetrics[u.IfID()]
+	metrics := d.tables.Load().forwardingMetrics[u.IfID()]
 	toWrite := 0
 	for d.IsRunning() {
-		// Top-up our batch.
-		toWrite += readUpTo(c, d.RunConfig.BatchSize-toWrite, toWrite == 0, pkts[toWrite:])
+		if toWrite == 0 {
+			// Wait for a packet, unless the connection is removed in the meantime.
+			select {
+			case pkts[0] = <-qs[0]:
+				toWrite = 1
+			case pkts[0] = <-qs[1]:
+				toWrite = 1
+			case pkts[0] = <-qs[2]:
+				toWrite = 1
+			case <-done:
+				d.drainQueue(qs)
+				log.Debug("Forwarder stopped", "connection", u.Name())
+				return
+			}
+		}
+		// Top-up our batch, from the highest priority to the lowest.
+		for _, c := range qs {
+			toWrite += readUpTo(c, d.RunConfig.BatchSize-toWrite, false, pkts[toWrite:])
+		}
 
 		// Turn the packets into underlay messages that WriteBatch can send.
 		for i, p := range pkts[:toWrite] {
@@ -1040,6 +1519,12 @@ func (d *DataPlane) runForwarder(u UnderlayConn) {
 			if len(p.DstAddr.IP) != 0 {
 				msgs[i].Addr = p.DstAddr
 			}
+			msgs[i].OOB = nil
+			if p.underlayTC != 0 {
+				l := underlayconn.TrafficClassLen
+				msgs[i].OOB = underlayconn.TrafficClassControlMessage(
+					oob[i*l:(i+1)*l], ipv6, p.underlayTC)
+			}
 		}
 		written, _ := conn.WriteBatch(msgs[:toWrite], 0)
 		if written < 0 {
@@ -1057,7 +1542,8 @@ func (d *DataPlane) runForwarder(u UnderlayConn) {
 		if written != toWrite {
 			// Only one is dropped at this time. We'll retry the rest.
 			sc := classOfSize(len(pkts[written].rawPacket))
-			metrics[sc].DroppedPacketsInvalid.Inc()
+			metrics[sc].DroppedPackets[dropSendError].Inc()
+			d.traceDrop(pkts[written], dropSendError)
 			d.returnPacketToPool(pkts[written])
 			toWrite -= (written + 1)
 			// Shift the leftovers to the head of the buffers.
@@ -1102,6 +1588,10 @@ func newPacketProcessor(d *DataPlane) *scionPacketProcessor {
 		d:              d,
 		mac:            d.macFactory(),
 		macInputBuffer: make([]byte, max(path.MACBufferSize, libepic.MACBufferSize)),
+		fabridKeys: fabridKeys{
+			epochDuration:    drkeyutil.LoadEpochDuration(),
+			acceptanceWindow: drkeyutil.LoadAcceptanceWindow(),
+		},
 	}
 	p.scionLayer.RecyclePaths()
 	return p
@@ -1115,6 +1605,8 @@ func (p *scionPacketProcessor) reset() error {
 	p.infoField = path.InfoField{}
 	p.effectiveXover = false
 	p.peering = false
+	p.verifyHVF = false
+	p.fabridNextHop = netip.AddrPort{}
 	p.mac.Reset()
 	p.cachedMac = nil
 	// Reset hbh layer
@@ -1131,34 +1623,64 @@ func errorDiscard(ctx ...any) disposition {
 	return pDiscard
 }
 
+// discard is like errorDiscard, but also records the reason why the packet is dropped, for the
+// metrics. Packets discarded with errorDiscard are counted as invalid.
+func (p *scionPacketProcessor) discard(reason dropReason, ctx ...any) disposition {
+	p.pkt.dropReason = reason
+	log.Debug("Discarding packet", ctx...)
+	return pDiscard
+}
+
 func (p *scionPacketProcessor) processPkt(pkt *Packet) disposition {
 	if err := p.reset(); err != nil {
 		return errorDiscard("error", err)
 	}
 	p.pkt = pkt
+	p.tables = p.d.tables.Load()
+
+	if pkt.ingress == 0 && p.d.RunConfig.STUN && stun.Is(pkt.rawPacket) {
+		return p.processSTUN()
+	}
 
 	// parse SCION header and skip extensions;
 	var err error
 	p.lastLayer, err = decodeLayers(pkt.rawPacket, &p.scionLayer, &p.hbhLayer, &p.e2eLayer)
 	if err != nil {
-		return errorDiscard("error", err)
+		return p.discard(dropParseError, "error", err)
 	}
 
 	pld := p.lastLayer.LayerPayload()
+	l4 := p.lastLayer.NextLayerType()
+	if p.d.filter != nil && l4 != layers.LayerTypeBFD && !p.d.filter.permit(filteredPacket{
+		ingress: pkt.ingress,
+		scion:   &p.scionLayer,
+		l4:      l4,
+		payload: pld,
+		size:    len(pkt.rawPacket),
+	}) {
+		pkt.dropReason = dropFiltered
+		return pDiscard
+	}
 
 	pathType := p.scionLayer.PathType
+	if p.d.replay != nil && (pathType == scion.PathType || pathType == epic.PathType) {
+		path := p.scionLayer.Contents[slayers.CmnHdrLen+p.scionLayer.AddrHdrLen():]
+		if p.d.replay.duplicate(p.scionLayer.SrcIA, path, pkt.rawPacket) {
+			return p.discard(dropDuplicate, "error", duplicatePacket)
+		}
+	}
 	switch pathType {
 	case empty.PathType:
 		if p.lastLayer.NextLayerType() == layers.LayerTypeBFD {
 			return p.processIntraBFD(pld)
 		}
-		return errorDiscard("error", unsupportedPathTypeNextHeader)
+		return p.discard(dropUnsupportedPath, "error", unsupportedPathTypeNextHeader)
 
 	case onehop.PathType:
 		if p.lastLayer.NextLayerType() == layers.LayerTypeBFD {
 			ohp, ok := p.scionLayer.Path.(*onehop.Path)
 			if !ok {
-				return errorDiscard("error", malformedPath)
+				return p.discard(dropInvalidPath, "error", malformedPath)
 			}
 			return p.processInterBFD(ohp, pld)
 		}
@@ -1168,7 +1690,7 @@ func (p *scionPacketProcessor) processPkt(pkt *Packet) disposition {
 	case epic.PathType:
 		return p.processEPIC()
 	default:
-		return errorDiscard("error", unsupportedPathType)
+		return p.discard(dropUnsupportedPath, "error", unsupportedPathType)
 	}
 }
 
@@ -1176,17 +1698,26 @@ func (p *scionPacketProcessor) processInterBFD(oh *onehop.Path, data []byte) dis
 
 	// If this is an inter-AS BFD, it can via an interface we own. So the ifID matches one link
 	// and the ifID better be valid. In the future that will be checked upstream from here.
-	link, exists := p.d.interfaces[p.pkt.ingress]
+	link, exists := p.tables.interfaces[p.pkt.ingress]
 	if !exists {
-		return errorDiscard("error", noBFDSessionFound)
+		return p.discard(dropNoBFDSession, "error", noBFDSessionFound)
+	}
+	link = localLink(link)
+	if e, ok := link.(*ecmpLink); ok {
+		// Each of the connections has its own session.
+		if link = e.member(p.pkt.srcAddr.AddrPort()); link == nil {
+			return p.discard(dropNoBFDSession, "error", noBFDSessionFound)
+		}
 	}
 	session := link.BFDSession()
 	if session == nil {
-		return errorDiscard("error", noBFDSessionFound)
+		return p.discard(dropNoBFDSession, "error", noBFDSessionFound)
 	}
 	bfd := &p.bfdLayer
+	// The authentication header of a previous packet is not cleared by DecodeFromBytes.
+	bfd.AuthHeader = nil
 	if err := bfd.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
-		return errorDiscard("error", err)
+		return p.discard(dropParseError, "error", err)
 	}
 	session.ReceiveMessage(bfd)
 	return pDiscard // All's fine. That packet's journey ends here.
@@ -1199,12 +1730,14 @@ func (p *scionPacketProcessor) processIntraBFD(data []byte) disposition {
 	src := p.pkt.srcAddr.AddrPort() // POSSIBLY EXPENSIVE CONVERSION
 	session := p.d.underlay.Link(src).BFDSession()
 	if session == nil {
-		return errorDiscard("error", noBFDSessionFound)
+		return p.discard(dropNoBFDSession, "error", noBFDSessionFound)
 	}
 
 	bfd := &p.bfdLayer
+	// The authentication header of a previous packet is not cleared by DecodeFromBytes.
+	bfd.AuthHeader = nil
 	if err := bfd.DecodeFromBytes(data, gopacket.NilDecodeFeedback); err != nil {
-		return errorDiscard("error", err)
+		return p.discard(dropParseError, "error", err)
 	}
 
 	session.ReceiveMessage(bfd)
@@ -1217,7 +1750,7 @@ func (p *scionPacketProcessor) processSCION() disposition {
 	p.path, ok = p.scionLayer.Path.(*scion.Raw)
 	if !ok {
 		// TODO(lukedirtwalker) parameter problem invalid path?
-		return errorDiscard("error", malformedPath)
+		return p.discard(dropInvalidPath, "error", malformedPath)
 	}
 	return p.process()
 }
@@ -1226,33 +1759,34 @@ func (p *scionPacketProcessor) processEPIC() disposition {
 
 	epicPath, ok := p.scionLayer.Path.(*epic.Path)
 	if !ok {
-		return errorDiscard("error", malformedPath)
+		return p.discard(dropInvalidPath, "error", malformedPath)
 	}
 
 	p.path = epicPath.ScionPath
 	if p.path == nil {
-		return errorDiscard("error", malformedPath)
+		return p.discard(dropInvalidPath, "error", malformedPath)
 	}
 
 	isPenultimate := p.path.IsPenultimateHop()
 	isLast := p.path.IsLastHop()
+	p.verifyHVF = isPenultimate || isLast
 
 	disp := p.process()
 	if disp != pForward {
 		return disp
 	}
 
-	if isPenultimate || isLast {
+	if p.verifyHVF {
 		firstInfo, err := p.path.GetInfoField(0)
 		if err != nil {
-			return errorDiscard("error", err)
+			return p.discard(dropInvalidPath, "error", err)
 		}
 
 		timestamp := time.Unix(int64(firstInfo.Timestamp), 0)
 		err = libepic.VerifyTimestamp(timestamp, epicPath.PktID.Timestamp, time.Now())
 		if err != nil {
 			// TODO(mawyss): Send back SCMP packet
-			return errorDiscard("error", err)
+			return p.discard(dropExpiredHop, "error", err)
 		}
 
 		HVF := epicPath.PHVF
@@ -1263,7 +1797,7 @@ func (p *scionPacketProcessor) processEPIC() disposition {
 			&p.scionLayer, firstInfo.Timestamp, HVF, p.macInputBuffer[:libepic.MACBufferSize])
 		if err != nil {
 			// TODO(mawyss): Send back SCMP packet
-			return errorDiscard("error", err)
+			return p.discard(dropBadMAC, "error", err)
 		}
 	}
 
@@ -1299,6 +1833,17 @@ type scionPacketProcessor struct {
 
 	// peering indicates that the hop field being processed is a peering hop field.
 	peering bool
+	// verifyHVF indicates that the hop validation field of the EPIC packet being processed is
+	// verified at this hop.
+	verifyHVF bool
+	// fabridNextHop is the next hop of the FABRID policy of the packet being processed, if any.
+	fabridNextHop netip.AddrPort
+	// fabridKeys caches the keys of the FABRID options.
+	fabridKeys fabridKeys
+	// telemetrySeen counts the packets since the last one sampled for telemetry.
+	telemetrySeen uint32
+	// extPacket is the packet given to the extension handlers, kept here to avoid allocations.
+	extPacket ExtensionPacket
 
 	// cachedMac contains the full 16 bytes of the MAC. Will be set during processing.
 	// For a hop performing an Xover, it is the MAC corresponding to the down segment.
@@ -1308,6 +1853,9 @@ type scionPacketProcessor struct {
 
 	// bfdLayer is reusable buffer for parsing BFD messages
 	bfdLayer layers.BFD
+
+	// tables is the snapshot of the forwarding tables used to process the current packet.
+	tables *forwardingTables
 }
 
 type slowPathType uint8
@@ -1354,12 +1902,12 @@ func (p *scionPacketProcessor) parsePath() disposition {
 	p.hopField, err = p.path.GetCurrentHopField()
 	if err != nil {
 		// TODO(lukedirtwalker) parameter problem invalid path?
-		return errorDiscard("error", err)
+		return p.discard(dropInvalidPath, "error", err)
 	}
 	p.infoField, err = p.path.GetCurrentInfoField()
 	if err != nil {
 		// TODO(lukedirtwalker) parameter problem invalid path?
-		return errorDiscard("error", err)
+		return p.discard(dropInvalidPath, "error", err)
 	}
 	// Segments without the Peering flag must consist of at least two HFs:
 	// https://github.com/scionproto/scion/issues/4524
@@ -1367,10 +1915,10 @@ func (p *scionPacketProcessor) parsePath() disposition {
 		p.path.PathMeta.SegLen[1] == 1 ||
 		p.path.PathMeta.SegLen[2] == 1
 	if !p.infoField.Peer && hasSingletonSegment {
-		return errorDiscard("error", malformedPath)
+		return p.discard(dropInvalidPath, "error", malformedPath)
 	}
 	if !p.path.CurrINFMatchesCurrHF() {
-		return errorDiscard("error", malformedPath)
+		return p.discard(dropInvalidPath, "error", malformedPath)
 	}
 	return pForward
 }
@@ -1404,7 +1952,7 @@ func (p *scionPacketProcessor) determinePeer() disposition {
 	peer, err := determinePeer(p.path.PathMeta, p.infoField)
 	p.peering = peer
 	if err != nil {
-		return errorDiscard("error", err)
+		return p.discard(dropInvalidPath, "error", err)
 	}
 	return pForward
 }
@@ -1419,6 +1967,7 @@ func (p *scionPacketProcessor) validateHopExpiry() disposition {
 	log.Debug("SCMP response", "cause", expiredHop,
 		"cons_dir", p.infoField.ConsDir, "if_id", p.pkt.ingress,
 		"curr_inf", p.path.PathMeta.CurrINF, "curr_hf", p.path.PathMeta.CurrHF)
+	p.pkt.dropReason = dropExpiredHop
 	p.pkt.slowPathRequest = slowPathRequest{
 		scmpType: slayers.SCMPTypeParameterProblem,
 		code:     slayers.SCMPCodePathExpired,
@@ -1437,6 +1986,7 @@ func (p *scionPacketProcessor) validateIngressID() disposition {
 	if p.pkt.ingress != 0 && p.pkt.ingress != hdrIngressID {
 		log.Debug("SCMP response", "cause", ingressInterfaceInvalid,
 			"pkt_ingress", hdrIngressID, "router_ingress", p.pkt.ingress)
+		p.pkt.dropReason = dropUnknownInterface
 		p.pkt.slowPathRequest = slowPathRequest{
 			scmpType: slayers.SCMPTypeParameterProblem,
 			code:     errCode,
@@ -1447,6 +1997,49 @@ func (p *scionPacketProcessor) validateIngressID() disposition {
 	return pForward
 }
 
+// validateIngressSrc applies the anti-spoofing checks, if enabled, to the packets received from
+// a neighbor. The source AS must not be the local one, and if the packet claims to come from the
+// neighbor, i.e. the current hop is the second of the path, the neighbor must be the source AS
+// and the first hop must leave it through the remote end of the ingress interface. The MAC of
+// the first hop cannot be verified here, so this only catches hop fields that do not fit the link.
+func (p *scionPacketProcessor) validateIngressSrc() disposition {
+	if !p.d.RunConfig.AntiSpoofing || p.pkt.ingress == 0 {
+		return pForward
+	}
+	srcIA := p.scionLayer.SrcIA
+	if srcIA == p.d.localIA {
+		return p.discard(dropSpoofed, "cause", invalidSrcIA, "src_isd_as", srcIA)
+	}
+	if p.path.PathMeta.CurrHF != 1 {
+		return pForward
+	}
+	if neighborIA := p.tables.neighborIAs[p.pkt.ingress]; srcIA != neighborIA {
+		return p.discard(dropSpoofed, "cause", invalidSrcIA, "src_isd_as", srcIA,
+			"neighbor_isd_as", neighborIA)
+	}
+	remoteIfID, ok := p.tables.remoteIfIDs[p.pkt.ingress]
+	if !ok {
+		return pForward
+	}
+	info, err := p.path.GetInfoField(0)
+	if err != nil {
+		return p.discard(dropInvalidPath, "error", err)
+	}
+	hop, err := p.path.GetHopField(0)
+	if err != nil {
+		return p.discard(dropInvalidPath, "error", err)
+	}
+	firstEgress := hop.ConsEgress
+	if !info.ConsDir {
+		firstEgress = hop.ConsIngress
+	}
+	if firstEgress != remoteIfID {
+		return p.discard(dropSpoofed, "cause", firstHopInvalid, "first_egress", firstEgress,
+			"remote_if_id", remoteIfID)
+	}
+	return pForward
+}
+
 func (p *scionPacketProcessor) validateSrcDstIA() disposition {
 	srcIsLocal := (p.scionLayer.SrcIA == p.d.localIA)
 	dstIsLocal := (p.scionLayer.DstIA == p.d.localIA)
@@ -1476,6 +2069,7 @@ func (p *scionPacketProcessor) validateSrcDstIA() disposition {
 // invalidSrcIA is a helper to return an SCMP error for an invalid SrcIA.
 func (p *scionPacketProcessor) respInvalidSrcIA() disposition {
 	log.Debug("SCMP response", "cause", invalidSrcIA)
+	p.pkt.dropReason = dropInvalidAddress
 	p.pkt.slowPathRequest = slowPathRequest{
 		scmpType: slayers.SCMPTypeParameterProblem,
 		code:     slayers.SCMPCodeInvalidSourceAddress,
@@ -1487,6 +2081,7 @@ func (p *scionPacketProcessor) respInvalidSrcIA() disposition {
 // invalidDstIA is a helper to return an SCMP error for an invalid DstIA.
 func (p *scionPacketProcessor) respInvalidDstIA() disposition {
 	log.Debug("SCMP response", "cause", invalidDstIA)
+	p.pkt.dropReason = dropInvalidAddress
 	p.pkt.slowPathRequest = slowPathRequest{
 		scmpType: slayers.SCMPTypeParameterProblem,
 		code:     slayers.SCMPCodeInvalidDestinationAddress,
@@ -1508,15 +2103,19 @@ func (p *scionPacketProcessor) validateTransitUnderlaySrc() disposition {
 		return pForward
 	}
 	pktIngressID := p.ingressInterface()
-	ingressLink := p.d.interfaces[pktIngressID]
-	if ingressLink.Scope() != Sibling {
+	ingressLink := p.tables.interfaces[pktIngressID]
+	if s, ok := ingressLink.(*sharedLink); ok {
+		// The packet entered the AS through the sibling router that shares the interface.
+		ingressLink = s.sibling
+	}
+	if ingressLink == nil || ingressLink.Scope() != Sibling {
 		// Drop
-		return errorDiscard("error", invalidSrcAddrForTransit)
+		return p.discard(dropInvalidAddress, "error", invalidSrcAddrForTransit)
 	}
 	src, okS := netip.AddrFromSlice(p.pkt.srcAddr.IP)
 	if !(okS && ingressLink.Remote().Addr() == src) {
 		// Drop
-		return errorDiscard("error", invalidSrcAddrForTransit)
+		return p.discard(dropInvalidAddress, "error", invalidSrcAddrForTransit)
 	}
 	return pForward
 }
@@ -1530,7 +2129,7 @@ func (p *scionPacketProcessor) validateTransitUnderlaySrc() disposition {
 // to another AS directly, or via a sibling router.
 func (p *scionPacketProcessor) validateEgressID() disposition {
 	egressID := p.pkt.egress
-	link, found := p.d.interfaces[egressID]
+	link, found := p.tables.interfaces[egressID]
 
 	// egress interface must be a known interface
 	// egress is never the internalInterface
@@ -1542,6 +2141,7 @@ func (p *scionPacketProcessor) validateEgressID() disposition {
 			errCode = slayers.SCMPCodeUnknownHopFieldIngress
 		}
 		log.Debug("SCMP response", "cause", cannotRoute)
+		p.pkt.dropReason = dropUnknownInterface
 		p.pkt.slowPathRequest = slowPathRequest{
 			scmpType: slayers.SCMPTypeParameterProblem,
 			code:     errCode,
@@ -1550,7 +2150,7 @@ func (p *scionPacketProcessor) validateEgressID() disposition {
 		return pSlowPath
 	}
 
-	ingressLT, egressLT := p.d.linkTypes[p.pkt.ingress], p.d.linkTypes[egressID]
+	ingressLT, egressLT := p.tables.linkTypes[p.pkt.ingress], p.tables.linkTypes[egressID]
 	if !p.effectiveXover {
 		// Check that the interface pair is valid within a single segment.
 		// No check required if the packet is received from an internal interface.
@@ -1573,6 +2173,7 @@ func (p *scionPacketProcessor) validateEgressID() disposition {
 			log.Debug("SCMP response", "cause", cannotRoute,
 				"ingress_id", p.pkt.ingress, "ingress_type", ingressLT,
 				"egress_id", egressID, "egress_type", egressLT)
+			p.pkt.dropReason = dropInvalidPath
 			p.pkt.slowPathRequest = slowPathRequest{
 				scmpType: slayers.SCMPTypeParameterProblem,
 				code:     slayers.SCMPCodeInvalidPath, // XXX(matzf) new code InvalidHop?,
@@ -1598,6 +2199,7 @@ func (p *scionPacketProcessor) validateEgressID() disposition {
 		log.Debug("SCMP response", "cause", cannotRoute,
 			"ingress_id", p.pkt.ingress, "ingress_type", ingressLT,
 			"egress_id", egressID, "egress_type", egressLT)
+		p.pkt.dropReason = dropInvalidPath
 		p.pkt.slowPathRequest = slowPathRequest{
 			scmpType: slayers.SCMPTypeParameterProblem,
 			code:     slayers.SCMPCodeInvalidSegmentChange,
@@ -1614,7 +2216,7 @@ func (p *scionPacketProcessor) updateNonConsDirIngressSegID() disposition {
 	if !p.infoField.ConsDir && p.pkt.ingress != 0 && !p.peering {
 		p.infoField.UpdateSegID(p.hopField.Mac)
 		if err := p.path.SetInfoField(p.infoField, int(p.path.PathMeta.CurrINF)); err != nil {
-			return errorDiscard("error", err)
+			return p.discard(dropInvalidPath, "error", err)
 		}
 	}
 	return pForward
@@ -1639,6 +2241,7 @@ func (p *scionPacketProcessor) verifyCurrentMAC() disposition {
 			"cons_dir", p.infoField.ConsDir,
 			"if_id", p.pkt.ingress, "curr_inf", p.path.PathMeta.CurrINF,
 			"curr_hf", p.path.PathMeta.CurrHF, "seg_id", p.infoField.SegID)
+		p.pkt.dropReason = dropBadMAC
 		p.pkt.slowPathRequest = slowPathRequest{
 			scmpType: slayers.SCMPTypeParameterProblem,
 			code:     slayers.SCMPCodeInvalidHopFieldMAC,
@@ -1661,6 +2264,7 @@ func (p *scionPacketProcessor) resolveInbound() disposition {
 		return pForward
 	case noSVCBackend:
 		log.Debug("SCMP response", "cause", err)
+		p.pkt.dropReason = dropNoRoute
 		p.pkt.slowPathRequest = slowPathRequest{
 			scmpType: slayers.SCMPTypeDestinationUnreachable,
 			code:     slayers.SCMPCodeNoRoute,
@@ -1668,6 +2272,7 @@ func (p *scionPacketProcessor) resolveInbound() disposition {
 		return pSlowPath
 	case invalidDstAddr, unsupportedV4MappedV6Address, unsupportedUnspecifiedAddress:
 		log.Debug("SCMP response", "cause", err)
+		p.pkt.dropReason = dropInvalidAddress
 		p.pkt.slowPathRequest = slowPathRequest{
 			scmpType: slayers.SCMPTypeParameterProblem,
 			code:     slayers.SCMPCodeInvalidDestinationAddress,
@@ -1688,12 +2293,12 @@ func (p *scionPacketProcessor) processEgress() disposition {
 		p.infoField.UpdateSegID(p.hopField.Mac)
 		if err := p.path.SetInfoField(p.infoField, int(p.path.PathMeta.CurrINF)); err != nil {
 			// TODO parameter problem invalid path
-			return errorDiscard("error", err)
+			return p.discard(dropInvalidPath, "error", err)
 		}
 	}
 	if err := p.path.IncPath(); err != nil {
 		// TODO parameter problem invalid path
-		return errorDiscard("error", err)
+		return p.discard(dropInvalidPath, "error", err)
 	}
 	return pForward
 }
@@ -1702,16 +2307,16 @@ func (p *scionPacketProcessor) doXover() disposition {
 	p.effectiveXover = true
 	if err := p.path.IncPath(); err != nil {
 		// TODO parameter problem invalid path
-		return errorDiscard("error", err)
+		return p.discard(dropInvalidPath, "error", err)
 	}
 	var err error
 	if p.hopField, err = p.path.GetCurrentHopField(); err != nil {
 		// TODO parameter problem invalid path
-		return errorDiscard("error", err)
+		return p.discard(dropInvalidPath, "error", err)
 	}
 	if p.infoField, err = p.path.GetCurrentInfoField(); err != nil {
 		// TODO parameter problem invalid path
-		return errorDiscard("error", err)
+		return p.discard(dropInvalidPath, "error", err)
 	}
 	return pForward
 }
@@ -1745,9 +2350,10 @@ func (p *scionPacketProcessor) egressInterface() uint16 {
 
 func (p *scionPacketProcessor) validateEgressUp() disposition {
 	egressID := p.pkt.egress
-	egressLink := p.d.interfaces[egressID]
+	egressLink := p.tables.interfaces[egressID]
 	if !egressLink.IsUp() {
 		log.Debug("SCMP response", "cause", errBFDSessionDown)
+		p.pkt.dropReason = dropInterfaceDown
 		if egressLink.Scope() != External {
 			p.pkt.slowPathRequest = slowPathRequest{
 				scmpType: slayers.SCMPTypeInternalConnectivityDown,
@@ -1764,6 +2370,24 @@ func (p *scionPacketProcessor) validateEgressUp() disposition {
 	return pForward
 }
 
+// validateEgressMTU checks that the packet fits the MTU of the egress interface. Larger packets
+// are answered with an SCMP packet too big error that carries the MTU, so that the source can
+// adapt the size of its packets.
+func (p *scionPacketProcessor) validateEgressMTU() disposition {
+	mtu, ok := p.tables.mtus[p.pkt.egress]
+	if !ok || len(p.pkt.rawPacket) <= mtu {
+		return pForward
+	}
+	log.Debug("SCMP response", "cause", errPacketTooBig, "size", len(p.pkt.rawPacket),
+		"mtu", mtu)
+	p.pkt.dropReason = dropTooBig
+	p.pkt.slowPathRequest = slowPathRequest{
+		scmpType: slayers.SCMPTypePacketTooBig,
+		code:     0,
+	}
+	return pSlowPath
+}
+
 func (p *scionPacketProcessor) handleIngressRouterAlert() disposition {
 	if p.pkt.ingress == 0 {
 		return pForward
@@ -1794,7 +2418,7 @@ func (p *scionPacketProcessor) handleEgressRouterAlert() disposition {
 	if !*alert {
 		return pForward
 	}
-	if p.d.interfaces[p.pkt.egress].Scope() != External {
+	if !p.egressIsLocal() {
 		// the egress router is not this one.
 		return pForward
 	}
@@ -1808,6 +2432,12 @@ func (p *scionPacketProcessor) handleEgressRouterAlert() disposition {
 	return pSlowPath
 }
 
+// egressIsLocal returns true if this router sends the packet out of the egress interface, as
+// opposed to a sibling router.
+func (p *scionPacketProcessor) egressIsLocal() bool {
+	return p.tables.interfaces[p.pkt.egress].Scope() == External && !p.pkt.viaSibling
+}
+
 func (p *scionPacketProcessor) egressRouterAlertFlag() *bool {
 	if !p.infoField.ConsDir {
 		return &p.hopField.IngressRouterAlert
@@ -1852,6 +2482,7 @@ func (p *scionPacketProcessor) validatePktLen() disposition {
 	}
 	log.Debug("SCMP response", "cause", badPacketSize, "header", p.scionLayer.PayloadLen,
 		"actual", len(p.scionLayer.Payload))
+	p.pkt.dropReason = dropBadPacketSize
 	p.pkt.slowPathRequest = slowPathRequest{
 		scmpType: slayers.SCMPTypeParameterProblem,
 		code:     slayers.SCMPCodeInvalidPacketSize,
@@ -1874,6 +2505,7 @@ func (p *scionPacketProcessor) validateSrcHost() disposition {
 	}
 
 	log.Debug("SCMP response", "cause", err)
+	p.pkt.dropReason = dropInvalidAddress
 	p.pkt.slowPathRequest = slowPathRequest{
 		scmpType: slayers.SCMPTypeParameterProblem,
 		code:     slayers.SCMPCodeInvalidSourceAddress,
@@ -1894,6 +2526,9 @@ func (p *scionPacketProcessor) process() disposition {
 	if disp := p.validateIngressID(); disp != pForward {
 		return disp
 	}
+	if disp := p.validateIngressSrc(); disp != pForward {
+		return disp
+	}
 	if disp := p.validatePktLen(); disp != pForward {
 		return disp
 	}
@@ -1921,6 +2556,16 @@ func (p *scionPacketProcessor) process() disposition {
 		if disp != pForward {
 			return disp
 		}
+		if disp := p.enforceEPICHP(p.ingressInterface(), 0); disp != pForward {
+			return disp
+		}
+		if disp := p.enforceFabrid(p.ingressInterface(), 0); disp != pForward {
+			return disp
+		}
+		if disp := p.handleExtensions(p.ingressInterface(), 0); disp != pForward {
+			return disp
+		}
+		p.steerFabrid()
 		p.pkt.trafficType = ttIn
 		return pForward
 	}
@@ -1955,6 +2600,18 @@ func (p *scionPacketProcessor) process() disposition {
 	if disp := p.validateEgressID(); disp != pForward {
 		return disp
 	}
+	if s, ok := p.tables.interfaces[egressID].(*sharedLink); ok {
+		p.pkt.viaSibling = s.handOver(p.pkt)
+	}
+	if disp := p.enforceEPICHP(p.ingressInterface(), egressID); disp != pForward {
+		return disp
+	}
+	if disp := p.enforceFabrid(p.ingressInterface(), egressID); disp != pForward {
+		return disp
+	}
+	if disp := p.handleExtensions(p.ingressInterface(), egressID); disp != pForward {
+		return disp
+	}
 
 	// handle egress router alert before we check if it's up because we want to
 	// send the reply anyway, so that trace route can pinpoint the exact link
@@ -1965,7 +2622,10 @@ func (p *scionPacketProcessor) process() disposition {
 	if disp := p.validateEgressUp(); disp != pForward {
 		return disp
 	}
-	if p.d.interfaces[egressID].Scope() == External {
+	if disp := p.validateEgressMTU(); disp != pForward {
+		return disp
+	}
+	if p.egressIsLocal() {
 		// Not ASTransit in
 		if disp := p.processEgress(); disp != pForward {
 			return disp
@@ -1988,6 +2648,7 @@ func (p *scionPacketProcessor) process() disposition {
 
 	// ASTransit in: pkt leaving this AS through another BR.
 	// We already know the egressID is valid. The packet can go straight to forwarding.
+	p.steerFabrid()
 	p.pkt.trafficType = ttInTransit
 	return pForward
 }
@@ -1997,31 +2658,36 @@ func (p *scionPacketProcessor) processOHP() disposition {
 	ohp, ok := s.Path.(*onehop.Path)
 	if !ok {
 		// TODO parameter problem -> invalid path
-		return errorDiscard("error", malformedPath)
+		return p.discard(dropInvalidPath, "error", malformedPath)
 	}
 	if !ohp.Info.ConsDir {
 		// TODO parameter problem -> invalid path
-		return errorDiscard("error", malformedPath)
+		return p.discard(dropInvalidPath, "error", malformedPath)
+	}
+	if p.d.drain.Load() != nil {
+		// Beacons are neither sent nor received while draining, so that the neighbors stop
+		// using the paths through this router.
+		return p.discard(dropDraining)
 	}
 
 	// OHP leaving our IA
 	if p.pkt.ingress == 0 {
 		if !p.d.localIA.Equal(s.SrcIA) {
 			// TODO parameter problem -> invalid path
-			return errorDiscard("error", cannotRoute)
+			return p.discard(dropInvalidAddress, "error", cannotRoute)
 		}
-		neighborIA, ok := p.d.neighborIAs[ohp.FirstHop.ConsEgress]
+		neighborIA, ok := p.tables.neighborIAs[ohp.FirstHop.ConsEgress]
 		if !ok {
 			// TODO parameter problem invalid interface
-			return errorDiscard("error", cannotRoute)
+			return p.discard(dropUnknownInterface, "error", cannotRoute)
 		}
 		if !neighborIA.Equal(s.DstIA) {
-			return errorDiscard("error", cannotRoute)
+			return p.discard(dropInvalidAddress, "error", cannotRoute)
 		}
 		mac := path.MAC(p.mac, ohp.Info, ohp.FirstHop, p.macInputBuffer[:path.MACBufferSize])
 		if subtle.ConstantTimeCompare(ohp.FirstHop.Mac[:], mac[:]) == 0 {
 			// TODO parameter problem -> invalid MAC
-			return errorDiscard("error", macVerificationFailed)
+			return p.discard(dropBadMAC, "error", macVerificationFailed)
 		}
 		ohp.Info.UpdateSegID(ohp.FirstHop.Mac)
 
@@ -2034,11 +2700,18 @@ func (p *scionPacketProcessor) processOHP() disposition {
 
 	// OHP entering our IA
 	if !p.d.localIA.Equal(s.DstIA) {
-		return errorDiscard("error", cannotRoute)
+		return p.discard(dropInvalidAddress, "error", cannotRoute)
 	}
-	neighborIA := p.d.neighborIAs[p.pkt.ingress]
+	neighborIA := p.tables.neighborIAs[p.pkt.ingress]
 	if !neighborIA.Equal(s.SrcIA) {
-		return errorDiscard("error", cannotRoute)
+		return p.discard(dropInvalidAddress, "error", cannotRoute)
+	}
+	if p.d.RunConfig.AntiSpoofing {
+		remoteIfID, ok := p.tables.remoteIfIDs[p.pkt.ingress]
+		if ok && ohp.FirstHop.ConsEgress != remoteIfID {
+			return p.discard(dropSpoofed, "cause", firstHopInvalid,
+				"first_egress", ohp.FirstHop.ConsEgress, "remote_if_id", remoteIfID)
+		}
 	}
 
 	ohp.SecondHop = path.HopField{
@@ -2123,20 +2796,14 @@ func (d *DataPlane) addEndhostPort(
 			return serrors.New("SCION/UDP header len too small", "length",
 				len(lastLayer.LayerPayload()))
 		}
-		port = binary.BigEndian.Uint16(lastLayer.LayerPayload()[2:])
-		if port < d.dispatchedPortStart || port > d.dispatchedPortEnd {
-			port = topology.EndhostPort
-		}
+		port = d.endhostPort(dst, binary.BigEndian.Uint16(lastLayer.LayerPayload()[2:]))
 	case slayers.L4TCP:
 		if len(lastLayer.LayerPayload()) < 20 {
 			// TODO: Treat this as a parameter problem
 			return serrors.New("SCION/TCP header len too small", "length",
 				len(lastLayer.LayerPayload()))
 		}
-		port = binary.BigEndian.Uint16(lastLayer.LayerPayload()[2:])
-		if port < d.dispatchedPortStart || port > d.dispatchedPortEnd {
-			port = topology.EndhostPort
-		}
+		port = d.endhostPort(dst, binary.BigEndian.Uint16(lastLayer.LayerPayload()[2:]))
 	case slayers.L4SCMP:
 		var scmpLayer slayers.SCMP
 		err := scmpLayer.DecodeFromBytes(lastLayer.LayerPayload(), gopacket.NilDecodeFeedback)
@@ -2150,9 +2817,7 @@ func (d *DataPlane) addEndhostPort(
 			return serrors.Wrap("getting dst port from SCMP message", err)
 		}
 		// if the SCMP dst port is outside the range, we send it to the EndhostPort
-		if port < d.dispatchedPortStart || port > d.dispatchedPortEnd {
-			port = topology.EndhostPort
-		}
+		port = d.endhostPort(dst, port)
 	default:
 		log.Debug("msg", "protocol", l4Type)
 	}
@@ -2386,13 +3051,22 @@ func (b *bfdSend) Send(bfd *layers.BFD) error {
 
 	// BfdControllers and fwQs are initialized from the same set of ifIDs. So not finding
 	// the forwarding queue is an serious internal error. Let that panic.
-	fwLink := b.dataPlane.interfaces[b.ifID]
+	fwLink := localLink(b.dataPlane.tables.Load().interfaces[b.ifID])
+	if e, ok := fwLink.(*ecmpLink); ok {
+		// The session monitors one specific connection of the interface.
+		if fwLink = e.member(b.dstAddr); fwLink == nil {
+			b.dataPlane.returnPacketToPool(p)
+			return nil
+		}
+	}
 
 	if b.ifID == 0 {
 		// Using the internal interface: must specify the destination address
 		UpdateNetAddrFromAddrPort(p.DstAddr, b.dstAddr)
 	}
 	// No need to specify pkt.egress. It isn't used downstream from here.
+	// BFD packets have high priority, so that congestion does not bring the link down.
+	p.priority = PriorityHigh
 	if !fwLink.Send(p) {
 		// We do not care if some BFD packets get bounced under high load. If it becomes a problem,
 		// the solution is do use BFD's demand-mode. To be considered in a future refactoring.
@@ -2461,7 +3135,7 @@ func (p *slowPathPacketProcessor) prepareSCMP(
 	// If the packet is sent to an external router, we need to increment the
 	// path to prepare it for the next hop.
 	// This is an SCMP response to pkt, so egress will be pkt.ingress.
-	if p.d.interfaces[p.pkt.ingress].Scope() == External {
+	if p.tables.interfaces[p.pkt.ingress].Scope() == External {
 		infoField := &revPath.InfoFields[revPath.PathMeta.CurrINF]
 		if infoField.ConsDir && !peering {
 			hopField := revPath.HopFields[revPath.PathMeta.CurrHF]
@@ -2504,6 +3178,18 @@ func (p *slowPathPacketProcessor) prepareSCMP(
 				p.hasValidAuth(time.Now()))
 	}
 
+	// The diagnostic describes the offending packet, whose path is overwritten by the quote.
+	var diagnostic *slayers.EndToEndOption
+	if isError && p.d.RunConfig.SCMPDiagnostics {
+		diagnostic = slayers.NewSCMPDiagnosticOption(slayers.SCMPDiagnostic{
+			Ingress:   p.pkt.ingress,
+			Egress:    p.pkt.egress,
+			CurrINF:   path.PathMeta.CurrINF,
+			CurrHF:    path.PathMeta.CurrHF,
+			Timestamp: uint64(time.Now().UnixNano()),
+		})
+	}
+
 	var quote []byte
 	if isError {
 		// add quote for errors.
@@ -2511,6 +3197,9 @@ func (p *slowPathPacketProcessor) prepareSCMP(
 		if needsAuth {
 			hdrLen += e2eAuthHdrLen
 		}
+		if diagnostic != nil {
+			hdrLen += e2eDiagnosticHdrLen
+		}
 		switch scmpH.TypeCode.Type() {
 		case slayers.SCMPTypeExternalInterfaceDown:
 			hdrLen += 20
@@ -2541,10 +3230,8 @@ func (p *slowPathPacketProcessor) prepareSCMP(
 		return serrors.JoinNoStack(cannotRoute, err, "details", "serializing SCMP message")
 	}
 
+	var e2e slayers.EndToEndExtn
 	if needsAuth {
-		var e2e slayers.EndToEndExtn
-		scionL.NextHdr = slayers.End2EndClass
-
 		now := time.Now()
 		dstA, err := scionL.DstAddr()
 		if err != nil {
@@ -2559,8 +3246,8 @@ func (p *slowPathPacketProcessor) prepareSCMP(
 			return serrors.JoinNoStack(cannotRoute, err, "details", "resetting SPAO header")
 		}
 
-		e2e.Options = []*slayers.EndToEndOption{p.optAuth.EndToEndOption}
-		e2e.NextHdr = slayers.L4SCMP
+		e2e.Options = append(e2e.Options, p.optAuth.EndToEndOption)
+		scionL.NextHdr = slayers.End2EndClass
 		_, err = spao.ComputeAuthCMAC(
 			spao.MACInput{
 				Key:        key.Key[:],
@@ -2575,10 +3262,17 @@ func (p *slowPathPacketProcessor) prepareSCMP(
 		if err != nil {
 			return serrors.JoinNoStack(cannotRoute, err, "details", "computing CMAC")
 		}
+	}
+	if diagnostic != nil {
+		e2e.Options = append(e2e.Options, diagnostic)
+	}
+	if len(e2e.Options) != 0 {
+		e2e.NextHdr = slayers.L4SCMP
 		if err := e2e.SerializeTo(&serBuf, sopts); err != nil {
 			return serrors.JoinNoStack(cannotRoute, err,
 				"details", "serializing SCION E2E headers")
 		}
+		scionL.NextHdr = slayers.End2EndClass
 	} else {
 		scionL.NextHdr = slayers.L4SCMP
 	}
@@ -2717,6 +3411,7 @@ func (d *DataPlane) initMetrics() {
 		d.forwardingMetrics[ifID] = newInterfaceMetrics(
 			d.Metrics, ifID, d.localIA, link.Scope(), d.neighborIAs)
 	}
+	d.publishTables()
 
 	// Start our custom /proc/pid/stat collector to export iowait time and (in the future) other
 	// process-wide metrics that prometheus does not.
@@ -16,6 +16,7 @@ package router
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/binary"
 	"hash/fnv"
@@ -32,13 +33,16 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/ptr"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/pkg/scrypto"
 	"github.com/scionproto/scion/pkg/slayers"
 	"github.com/scionproto/scion/pkg/slayers/path"
 	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/private/topology"
 	underlayconn "github.com/scionproto/scion/private/underlay/conn"
+	"github.com/scionproto/scion/router/control"
 	"github.com/scionproto/scion/router/mock_router"
 )
 
@@ -225,6 +229,93 @@ func TestForwarder(t *testing.T) {
 	}
 }
 
+// TestInterfaceReload runs the dataplane, removes an external interface and adds another one.
+// We verify that the connection of the removed interface is closed and its receiver stops, and
+// that the receiver of the new interface is started.
+func TestInterfaceReload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// blockingConn returns a connection that receives nothing until it is closed. The returned
+	// channel is signalled when the receiver reads from the connection.
+	blockingConn := func() (*mock_router.MockBatchConn, chan struct{}) {
+		c := mock_router.NewMockBatchConn(ctrl)
+		closed := make(chan struct{})
+		reading := make(chan struct{}, 1)
+		c.EXPECT().ReadBatch(gomock.Any()).DoAndReturn(
+			func(underlayconn.Messages) (int, error) {
+				select {
+				case reading <- struct{}{}:
+				default:
+				}
+				<-closed
+				return 0, net.ErrClosed
+			},
+		).AnyTimes()
+		c.EXPECT().Close().DoAndReturn(func() error {
+			close(closed)
+			return nil
+		}).MaxTimes(1)
+		return c, reading
+	}
+
+	dp := &DataPlane{
+		Metrics: metrics,
+		RunConfig: RunConfig{
+			NumProcessors:         1,
+			NumSlowPathProcessors: 1,
+			BatchSize:             8,
+		},
+	}
+	require.NoError(t, dp.SetKey(testKey))
+	internal, _ := blockingConn()
+	defer func() { _ = internal.Close() }()
+	require.NoError(t, dp.AddInternalInterface(internal, netip.Addr{}))
+
+	l := control.LinkEnd{
+		IA:   addr.MustParseIA("1-ff00:0:1"),
+		Addr: netip.MustParseAddrPort("10.0.0.100:0"),
+	}
+	r := control.LinkEnd{
+		IA:   addr.MustParseIA("1-ff00:0:3"),
+		Addr: netip.MustParseAddrPort("10.0.0.200:0"),
+	}
+	nobfd := control.BFD{Disable: ptr.To(true)}
+	removed, removedReading := blockingConn()
+	require.NoError(t, dp.AddLinkType(42, topology.Child))
+	require.NoError(t, dp.AddNeighborIA(42, r.IA))
+	require.NoError(t, dp.AddExternalInterface(42, removed, l, r, nobfd))
+
+	go func() {
+		_ = dp.Run(ctx)
+	}()
+	select {
+	case <-removedReading:
+	case <-time.After(time.Second):
+		require.Fail(t, "receiver of interface 42 not started")
+	}
+
+	require.NoError(t, dp.RemoveInterface(42))
+	assert.NotContains(t, dp.tables.Load().interfaces, uint16(42))
+	assert.NotContains(t, dp.underlay.Connections(), r.Addr)
+
+	added, addedReading := blockingConn()
+	defer func() { _ = added.Close() }()
+	r.Addr = netip.MustParseAddrPort("10.0.0.201:0")
+	require.NoError(t, dp.AddLinkType(43, topology.Child))
+	require.NoError(t, dp.AddNeighborIA(43, r.IA))
+	require.NoError(t, dp.AddExternalInterface(43, added, l, r, nobfd))
+	select {
+	case <-addedReading:
+	case <-time.After(time.Second):
+		require.Fail(t, "receiver of interface 43 not started")
+	}
+	assert.Contains(t, dp.tables.Load().interfaces, uint16(43))
+	assert.Contains(t, dp.tables.Load().forwardingMetrics, uint16(43))
+}
+
 func TestComputeProcId(t *testing.T) {
 	randomValueBytes := []byte{1, 2, 3, 4}
 	numProcs := 10000
@@ -452,6 +543,8 @@ func TestSlowPathProcessing(t *testing.T) {
 		expectedSlowPathRequest slowPathRequest
 		srcInterface            uint16
 		expectedLayerType       gopacket.LayerType
+		// expectedMTU is the MTU expected in a packet too big message.
+		expectedMTU uint16
 	}{
 		"svc nobackend": {
 			prepareDP: func(ctrl *gomock.Controller) *DataPlane {
@@ -620,6 +713,33 @@ func TestSlowPathProcessing(t *testing.T) {
 			},
 			expectedLayerType: slayers.LayerTypeSCMPParameterProblem,
 		},
+		"packet too big": {
+			prepareDP: func(ctrl *gomock.Controller) *DataPlane {
+				dp := NewDP(fakeExternalInterfaces,
+					map[uint16]topology.LinkType{1: topology.Child},
+					mock_router.NewMockBatchConn(ctrl),
+					fakeInternalNextHops,
+					fakeServices,
+					addr.MustParseIA("1-ff00:0:111"), nil, testKey)
+				require.NoError(t, dp.SetInterfaceMTU(1, 100))
+				return dp
+			},
+			mockMsg: func() []byte {
+				spkt := prepBaseMsgHop0Out(t, payload, 0)
+				spkt.SrcIA = addr.MustParseIA("1-ff00:0:111")
+				_ = spkt.SetDstAddr(addr.HostIP(netip.AddrFrom4([4]byte{10, 0, 200, 200})))
+				_ = spkt.SetSrcAddr(addr.HostIP(netip.AddrFrom4([4]byte{10, 0, 200, 100})))
+				ret := toMsg(t, spkt)
+				return ret
+			},
+			srcInterface: 0,
+			expectedSlowPathRequest: slowPathRequest{
+				typ:      slowPathSCMP,
+				scmpType: slayers.SCMPTypePacketTooBig,
+			},
+			expectedLayerType: slayers.LayerTypeSCMPPacketTooBig,
+			expectedMTU:       100,
+		},
 	}
 	for name, tc := range testCases {
 		name, tc := name, tc
@@ -654,10 +774,58 @@ func TestSlowPathProcessing(t *testing.T) {
 				tc.expectedSlowPathRequest.code)
 			assert.Equal(t, expectedTypeCode, scmp.TypeCode)
 			assert.NotNil(t, packet.Layer(tc.expectedLayerType))
+			tooBigLayer := packet.Layer(slayers.LayerTypeSCMPPacketTooBig)
+			if tooBig, ok := tooBigLayer.(*slayers.SCMPPacketTooBig); ok {
+				assert.Equal(t, tc.expectedMTU, tooBig.MTU)
+			}
 		})
 	}
 }
 
+func TestSCMPDiagnostics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dp := NewDP([]uint16{1}, nil, mock_router.NewMockBatchConn(ctrl),
+		map[uint16]netip.AddrPort{}, map[addr.SVC][]netip.AddrPort{},
+		addr.MustParseIA("1-ff00:0:110"), nil, testKey)
+	dp.RunConfig.SCMPDiagnostics = true
+	dp.initMetrics()
+
+	// A packet whose current hop field has an invalid MAC.
+	spkt := prepBaseMsg(t, []byte("actualpayloadbytes"), 0)
+	spkt.Path.(*scion.Decoded).HopFields[2].Mac = [path.MacLen]byte{}
+	rp := toMsg(t, spkt)
+	pkt := Packet{}
+	pkt.init(&[bufSize]byte{})
+	pkt.reset()
+	pkt.ingress = 1
+	pkt.srcAddr = &net.UDPAddr{}
+	pkt.rawPacket = pkt.rawPacket[:len(rp)]
+	copy(pkt.rawPacket, rp)
+
+	before := time.Now()
+	require.Equal(t, pSlowPath, newPacketProcessor(dp).processPkt(&pkt))
+	require.NoError(t, newSlowPathProcessor(dp).processPacket(&pkt))
+
+	packet := gopacket.NewPacket(pkt.rawPacket, slayers.LayerTypeSCION, gopacket.Default)
+	scmp, ok := packet.Layer(slayers.LayerTypeSCMP).(*slayers.SCMP)
+	require.True(t, ok, "no SCMP layer: %v", packet.ErrorLayer())
+	assert.Equal(t, slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+		slayers.SCMPCodeInvalidHopFieldMAC), scmp.TypeCode)
+	e2e, ok := packet.Layer(slayers.LayerTypeEndToEndExtn).(*slayers.EndToEndExtn)
+	require.True(t, ok)
+	opt, err := e2e.FindOption(slayers.OptTypeSCMPDiagnostic)
+	require.NoError(t, err)
+	diag, err := slayers.ParseSCMPDiagnosticOption(opt)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(1), diag.Ingress)
+	assert.Equal(t, uint8(0), diag.CurrINF)
+	assert.Equal(t, uint8(2), diag.CurrHF)
+	assert.GreaterOrEqual(t, int64(diag.Timestamp), before.UnixNano())
+	// The whole offending packet is still quoted.
+	assert.Equal(t, rp, scmp.Payload[4:])
+}
+
 func toMsg(t *testing.T, spkt *slayers.SCION) []byte {
 	t.Helper()
 	buffer := gopacket.NewSerializeBuffer()
@@ -762,3 +930,74 @@ func prepBaseMsgHop0Out(t *testing.T, payload []byte, flowId uint32) *slayers.SC
 	spkt.Path = dpath
 	return spkt
 }
+
+func TestProcessPktAllocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	payload := []byte("actualpayloadbytes")
+	newDP := func(local string) *DataPlane {
+		return NewDP([]uint16{1}, map[uint16]topology.LinkType{1: topology.Child},
+			mock_router.NewMockBatchConn(ctrl), nil, map[addr.SVC][]netip.AddrPort{},
+			addr.MustParseIA(local), nil, testKey)
+	}
+	withHosts := func(spkt *slayers.SCION) *slayers.SCION {
+		_ = spkt.SetDstAddr(addr.HostIP(netip.AddrFrom4([4]byte{10, 0, 200, 200})))
+		_ = spkt.SetSrcAddr(addr.HostIP(netip.AddrFrom4([4]byte{10, 0, 200, 100})))
+		return spkt
+	}
+	testCases := map[string]struct {
+		dp      *DataPlane
+		raw     []byte
+		ingress uint16
+	}{
+		"outbound": {
+			dp:      newDP("1-ff00:0:111"),
+			raw:     toMsg(t, withHosts(prepBaseMsgHop0Out(t, payload, 0))),
+			ingress: 0,
+		},
+		"inbound": {
+			dp:      newDP("1-ff00:0:110"),
+			raw:     toMsg(t, withHosts(prepBaseMsg(t, payload, 0))),
+			ingress: 1,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var p Packet
+			p.init(new([bufSize]byte))
+			processor := newPacketProcessor(tc.dp)
+			src := &net.UDPAddr{IP: net.IP{10, 0, 200, 100}, Port: 30041}
+			process := func() {
+				p.reset()
+				p.rawPacket = p.rawPacket[:copy(p.rawPacket, tc.raw)]
+				p.ingress = tc.ingress
+				updateNetAddrFromNetAddr(p.srcAddr, src)
+				require.Equal(t, pForward, processor.processPkt(&p), p.dropReason)
+			}
+			assert.Zero(t, testing.AllocsPerRun(100, process))
+			assert.Len(t, p.srcAddr.IP, net.IPv4len)
+			p.reset()
+			assert.Empty(t, p.srcAddr.IP)
+			assert.Equal(t, net.IPv6len, cap(p.srcAddr.IP))
+		})
+	}
+}
+
+func TestWithinEgressMTU(t *testing.T) {
+	d := &DataPlane{}
+	require.NoError(t, d.SetInterfaceMTU(1, 8952))
+	require.NoError(t, d.SetInterfaceMTU(2, 1472))
+	tables := d.tables.Load()
+	packet := func(egress uint16, size int) *Packet {
+		return &Packet{rawPacket: make([]byte, size), egress: egress}
+	}
+	assert.True(t, tables.withinEgressMTU(packet(1, 8952)))
+	assert.False(t, tables.withinEgressMTU(packet(1, 8953)))
+	assert.True(t, tables.withinEgressMTU(packet(2, 1472)))
+	assert.False(t, tables.withinEgressMTU(packet(2, 2000)))
+	// Interfaces without an MTU are only limited by the buffers.
+	assert.True(t, tables.withinEgressMTU(packet(3, bufSize)))
+
+	require.NoError(t, d.SetInterfaceMTU(2, 0))
+	assert.True(t, d.tables.Load().withinEgressMTU(packet(2, 2000)))
+}
@@ -18,6 +18,7 @@ package router_test
 import (
 	"bytes"
 	"context"
+	"crypto/cipher"
 	"fmt"
 	"net"
 	"net/netip"
@@ -33,6 +34,7 @@ import (
 
 	"github.com/scionproto/scion/pkg/addr"
 	libepic "github.com/scionproto/scion/pkg/experimental/epic"
+	"github.com/scionproto/scion/pkg/experimental/fabrid"
 	"github.com/scionproto/scion/pkg/private/ptr"
 	"github.com/scionproto/scion/pkg/private/util"
 	"github.com/scionproto/scion/pkg/scrypto"
@@ -42,6 +44,7 @@ import (
 	"github.com/scionproto/scion/pkg/slayers/path/epic"
 	"github.com/scionproto/scion/pkg/slayers/path/onehop"
 	"github.com/scionproto/scion/pkg/slayers/path/scion"
+	"github.com/scionproto/scion/private/drkey/drkeyutil"
 	"github.com/scionproto/scion/private/topology"
 	underlayconn "github.com/scionproto/scion/private/underlay/conn"
 	"github.com/scionproto/scion/router"
@@ -112,6 +115,15 @@ func TestDataPlaneSetKey(t *testing.T) {
 	})
 }
 
+func TestDataPlaneSetInterfaceMTU(t *testing.T) {
+	d := &router.DataPlane{}
+	assert.NoError(t, d.SetInterfaceMTU(1, 8952))
+	assert.NoError(t, d.SetInterfaceMTU(1, 1472))
+	assert.NoError(t, d.SetInterfaceMTU(1, 0))
+	assert.Error(t, d.SetInterfaceMTU(1, -1))
+	assert.Error(t, d.SetInterfaceMTU(1, 65535))
+}
+
 func TestDataPlaneAddExternalInterface(t *testing.T) {
 	l := control.LinkEnd{
 		IA:   addr.MustParseIA("1-ff00:0:1"),
@@ -122,13 +134,14 @@ func TestDataPlaneAddExternalInterface(t *testing.T) {
 		Addr: netip.MustParseAddrPort("10.0.0.200:0"),
 	}
 	nobfd := control.BFD{Disable: ptr.To(true)}
-	t.Run("fails after serve", func(t *testing.T) {
+	t.Run("succeeds after serve", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
 		d := &router.DataPlane{}
 		d.FakeStart()
-		assert.Error(t, d.AddExternalInterface(42, mock_router.NewMockBatchConn(ctrl), l, r, nobfd))
+		assert.NoError(t,
+			d.AddExternalInterface(42, mock_router.NewMockBatchConn(ctrl), l, r, nobfd))
 	})
 	t.Run("setting nil conn is not allowed", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -175,6 +188,188 @@ func TestDataPlaneAddExternalInterface(t *testing.T) {
 	})
 }
 
+func TestDataPlaneAddExternalUnderlay(t *testing.T) {
+	l := control.LinkEnd{
+		IA:   addr.MustParseIA("1-ff00:0:1"),
+		Addr: netip.MustParseAddrPort("10.0.0.100:0"),
+	}
+	r := control.LinkEnd{
+		IA:   addr.MustParseIA("1-ff00:0:3"),
+		Addr: netip.MustParseAddrPort("10.0.0.200:0"),
+	}
+	l2, r2 := l, r
+	l2.Addr = netip.MustParseAddrPort("10.0.1.100:0")
+	r2.Addr = netip.MustParseAddrPort("10.0.1.200:0")
+	nobfd := control.BFD{Disable: ptr.To(true)}
+
+	t.Run("interface must exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.Error(t,
+			d.AddExternalUnderlay(42, mock_router.NewMockBatchConn(ctrl), l2, r2, nobfd))
+	})
+	t.Run("normal add works", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.NoError(t,
+			d.AddExternalInterface(42, mock_router.NewMockBatchConn(ctrl), l, r, nobfd))
+		assert.NoError(t,
+			d.AddExternalUnderlay(42, mock_router.NewMockBatchConn(ctrl), l2, r2, nobfd))
+	})
+	t.Run("same remote twice fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.NoError(t,
+			d.AddExternalInterface(42, mock_router.NewMockBatchConn(ctrl), l, r, nobfd))
+		assert.Error(t,
+			d.AddExternalUnderlay(42, mock_router.NewMockBatchConn(ctrl), l2, r, nobfd))
+	})
+	t.Run("removal closes all connections", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		c1 := mock_router.NewMockBatchConn(ctrl)
+		c1.EXPECT().Close().Return(nil)
+		c2 := mock_router.NewMockBatchConn(ctrl)
+		c2.EXPECT().Close().Return(nil)
+		assert.NoError(t, d.AddExternalInterface(42, c1, l, r, nobfd))
+		assert.NoError(t, d.AddExternalUnderlay(42, c2, l2, r2, nobfd))
+		d.FakeStart()
+		assert.NoError(t, d.RemoveInterface(42))
+	})
+}
+
+func TestDataPlaneShareExternalInterface(t *testing.T) {
+	l := control.LinkEnd{
+		IA:   addr.MustParseIA("1-ff00:0:1"),
+		Addr: netip.MustParseAddrPort("10.0.0.100:0"),
+	}
+	r := control.LinkEnd{
+		IA:   addr.MustParseIA("1-ff00:0:3"),
+		Addr: netip.MustParseAddrPort("10.0.0.200:0"),
+	}
+	local := netip.MustParseAddrPort("10.0.0.10:30042")
+	sibling := netip.MustParseAddrPort("10.0.0.50:30042")
+	nobfd := control.BFD{Disable: ptr.To(true)}
+
+	t.Run("interface must exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.NoError(t, d.AddInternalInterface(mock_router.NewMockBatchConn(ctrl),
+			netip.Addr{}))
+		assert.Error(t, d.ShareExternalInterface(42, local, sibling, nobfd, ""))
+	})
+	t.Run("normal share works", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.NoError(t, d.AddInternalInterface(mock_router.NewMockBatchConn(ctrl),
+			netip.Addr{}))
+		assert.NoError(t,
+			d.AddExternalInterface(42, mock_router.NewMockBatchConn(ctrl), l, r, nobfd))
+		assert.NoError(t, d.ShareExternalInterface(42, local, sibling, nobfd, ""))
+	})
+	t.Run("sharing twice fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.NoError(t, d.AddInternalInterface(mock_router.NewMockBatchConn(ctrl),
+			netip.Addr{}))
+		assert.NoError(t,
+			d.AddExternalInterface(42, mock_router.NewMockBatchConn(ctrl), l, r, nobfd))
+		assert.NoError(t, d.ShareExternalInterface(42, local, sibling, nobfd, ""))
+		assert.Error(t, d.ShareExternalInterface(42, local, sibling, nobfd, ""))
+	})
+	t.Run("removal keeps the sibling link in use", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		conn := mock_router.NewMockBatchConn(ctrl)
+		conn.EXPECT().Close().Return(nil)
+		assert.NoError(t, d.AddInternalInterface(mock_router.NewMockBatchConn(ctrl),
+			netip.Addr{}))
+		assert.NoError(t, d.AddExternalInterface(42, conn, l, r, nobfd))
+		assert.NoError(t, d.ShareExternalInterface(42, local, sibling, nobfd, ""))
+		assert.NoError(t, d.AddNextHop(45, local, sibling, nobfd, ""))
+		d.FakeStart()
+		assert.NoError(t, d.RemoveInterface(42))
+		assert.Error(t, d.AddNextHop(45, local, sibling, nobfd, ""))
+		assert.NoError(t, d.RemoveInterface(45))
+	})
+}
+
+func TestDataPlaneRemoveInterface(t *testing.T) {
+	l := control.LinkEnd{
+		IA:   addr.MustParseIA("1-ff00:0:1"),
+		Addr: netip.MustParseAddrPort("10.0.0.100:0"),
+	}
+	r := control.LinkEnd{
+		IA:   addr.MustParseIA("1-ff00:0:3"),
+		Addr: netip.MustParseAddrPort("10.0.0.200:0"),
+	}
+	sibling := netip.MustParseAddrPort("10.0.0.50:30042")
+	nobfd := control.BFD{Disable: ptr.To(true)}
+
+	t.Run("internal interface cannot be removed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.NoError(t, d.AddInternalInterface(mock_router.NewMockBatchConn(ctrl),
+			netip.Addr{}))
+		d.FakeStart()
+		assert.Error(t, d.RemoveInterface(0))
+	})
+	t.Run("removing unknown interface succeeds", func(t *testing.T) {
+		d := &router.DataPlane{}
+		d.FakeStart()
+		assert.NoError(t, d.RemoveInterface(42))
+	})
+	t.Run("external interface is closed and can be added again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		conn := mock_router.NewMockBatchConn(ctrl)
+		conn.EXPECT().Close().Return(nil)
+		assert.NoError(t, d.AddLinkType(42, topology.Child))
+		assert.NoError(t, d.AddNeighborIA(42, r.IA))
+		assert.NoError(t, d.AddExternalInterface(42, conn, l, r, nobfd))
+		d.FakeStart()
+		assert.NoError(t, d.RemoveInterface(42))
+		assert.NoError(t, d.AddLinkType(42, topology.Child))
+		assert.NoError(t, d.AddNeighborIA(42, r.IA))
+		assert.NoError(t,
+			d.AddExternalInterface(42, mock_router.NewMockBatchConn(ctrl), l, r, nobfd))
+	})
+	t.Run("shared sibling link is kept", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{}
+		assert.NoError(t, d.AddInternalInterface(mock_router.NewMockBatchConn(ctrl),
+			netip.Addr{}))
+		assert.NoError(t, d.AddNextHop(45, l.Addr, sibling, nobfd, ""))
+		assert.NoError(t, d.AddNextHop(46, l.Addr, sibling, nobfd, ""))
+		d.FakeStart()
+		assert.NoError(t, d.RemoveInterface(45))
+		assert.Error(t, d.AddNextHop(46, l.Addr, sibling, nobfd, ""))
+		assert.NoError(t, d.AddNextHop(45, l.Addr, sibling, nobfd, ""))
+	})
+}
+
 func TestDataPlaneAddSVC(t *testing.T) {
 	t.Run("succeeds after serve", func(t *testing.T) {
 		d := &router.DataPlane{}
@@ -205,13 +400,13 @@ func TestDataPlaneAddNextHop(t *testing.T) {
 
 	nobfd := control.BFD{Disable: ptr.To(true)}
 
-	t.Run("fails after serve", func(t *testing.T) {
+	t.Run("succeeds after serve", func(t *testing.T) {
 		d := &router.DataPlane{}
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		assert.NoError(t, d.AddInternalInterface(mock_router.NewMockBatchConn(ctrl), nilAddr))
 		d.FakeStart()
-		assert.Error(t, d.AddNextHop(45, l, r, nobfd, ""))
+		assert.NoError(t, d.AddNextHop(45, l, r, nobfd, ""))
 	})
 	t.Run("setting nil dst is not allowed", func(t *testing.T) {
 		d := &router.DataPlane{}
@@ -1578,6 +1773,413 @@ func TestProcessPkt(t *testing.T) {
 	}
 }
 
+func TestAntiSpoofing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	now := time.Now()
+	localIA := addr.MustParseIA("1-ff00:0:110")
+	neighborIA := addr.MustParseIA("1-ff00:0:111")
+
+	newDP := func(enabled bool) *router.DataPlane {
+		dp := router.NewDP([]uint16{1, 2}, nil, mock_router.NewMockBatchConn(ctrl),
+			map[uint16]netip.AddrPort{}, nil, localIA,
+			map[uint16]addr.IA{1: neighborIA, 2: addr.MustParseIA("1-ff00:0:112")}, key)
+		dp.RunConfig.AntiSpoofing = enabled
+		dp.SetRemoteIfID(1, 5)
+		return dp
+	}
+	// scionMsg returns a packet from the neighbor of interface 1 to the local AS, with the given
+	// first hop field.
+	scionMsg := func(srcIA addr.IA, consDir bool, first path.HopField) *router.Packet {
+		spkt, dpath := prepBaseMsg(now)
+		spkt.SrcIA = srcIA
+		spkt.DstIA = localIA
+		require.NoError(t, spkt.SetDstAddr(addr.MustParseHost("10.0.100.100")))
+		dpath.InfoFields[0].ConsDir = consDir
+		dpath.Base.PathMeta.SegLen = [3]uint8{2, 0, 0}
+		dpath.Base.NumHops = 2
+		last := path.HopField{ConsIngress: 1}
+		if !consDir {
+			last = path.HopField{ConsEgress: 1}
+		}
+		dpath.HopFields = []path.HopField{first, last}
+		dpath.HopFields[1].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[1])
+		return router.NewPacket(toBytes(t, spkt, dpath), nil, nil, 1, 0)
+	}
+	ohpMsg := func(firstEgress uint16) *router.Packet {
+		spkt, _ := prepBaseMsg(now)
+		spkt.PathType = onehop.PathType
+		spkt.SrcIA = neighborIA
+		spkt.DstIA = localIA
+		require.NoError(t, spkt.SetDstAddr(addr.MustParseHost("10.0.100.100")))
+		dpath := &onehop.Path{
+			Info:     path.InfoField{ConsDir: true, SegID: 0x222, Timestamp: 0x100},
+			FirstHop: path.HopField{ExpTime: 63, ConsEgress: firstEgress},
+		}
+		return router.NewPacket(toBytes(t, spkt, dpath), nil, nil, 1, 0)
+	}
+
+	testCases := map[string]struct {
+		disabled bool
+		pkt      *router.Packet
+		spoofed  bool
+	}{
+		"first hop from neighbor": {
+			pkt: scionMsg(neighborIA, true, path.HopField{ConsEgress: 5}),
+		},
+		"first hop against construction direction": {
+			pkt: scionMsg(neighborIA, false, path.HopField{ConsIngress: 5}),
+		},
+		"first hop leaves through other interface": {
+			pkt:     scionMsg(neighborIA, true, path.HopField{ConsEgress: 6}),
+			spoofed: true,
+		},
+		"source is not the neighbor": {
+			pkt:     scionMsg(addr.MustParseIA("1-ff00:0:112"), true, path.HopField{ConsEgress: 5}),
+			spoofed: true,
+		},
+		"source is the local AS": {
+			pkt:     scionMsg(localIA, true, path.HopField{ConsEgress: 5}),
+			spoofed: true,
+		},
+		"disabled": {
+			disabled: true,
+			pkt:      scionMsg(neighborIA, true, path.HopField{ConsEgress: 6}),
+		},
+		"one hop path from neighbor": {
+			pkt: ohpMsg(5),
+		},
+		"one hop path leaves through other interface": {
+			pkt:     ohpMsg(6),
+			spoofed: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			disp := newDP(!tc.disabled).ProcessPkt(tc.pkt)
+			if tc.spoofed {
+				assert.Equal(t, router.PDiscard, disp)
+				assert.Equal(t, "spoofed", tc.pkt.DropReason())
+				return
+			}
+			assert.NotEqual(t, router.PDiscard, disp)
+		})
+	}
+
+	t.Run("transit packets are not checked against the neighbor", func(t *testing.T) {
+		spkt, dpath := prepBaseMsg(now)
+		spkt.DstIA = localIA
+		require.NoError(t, spkt.SetDstAddr(addr.MustParseHost("10.0.100.100")))
+		dpath.HopFields = []path.HopField{
+			{ConsIngress: 41, ConsEgress: 40},
+			{ConsIngress: 31, ConsEgress: 30},
+			{ConsIngress: 1, ConsEgress: 0},
+		}
+		dpath.Base.PathMeta.CurrHF = 2
+		dpath.HopFields[2].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[2])
+		pkt := router.NewPacket(toBytes(t, spkt, dpath), nil, nil, 1, 0)
+		assert.NotEqual(t, router.PDiscard, newDP(true).ProcessPkt(pkt))
+	})
+}
+
+func TestDropReasons(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	now := time.Now()
+	localIA := addr.MustParseIA("1-ff00:0:110")
+
+	// inbound returns a packet to the local AS, received on interface 1, after applying modify
+	// to its path. The MAC of the current hop is computed after modify, unless it sets one.
+	inbound := func(modify func(*scion.Decoded)) []byte {
+		spkt, dpath := prepBaseMsg(now)
+		spkt.DstIA = localIA
+		require.NoError(t, spkt.SetDstAddr(addr.MustParseHost("10.0.100.100")))
+		dpath.HopFields = []path.HopField{
+			{ConsIngress: 41, ConsEgress: 40},
+			{ConsIngress: 31, ConsEgress: 30},
+			{ConsIngress: 1, ConsEgress: 0},
+		}
+		dpath.Base.PathMeta.CurrHF = 2
+		modify(dpath)
+		if dpath.HopFields[2].Mac == [path.MacLen]byte{} {
+			dpath.HopFields[2].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[2])
+		}
+		return toBytes(t, spkt, dpath)
+	}
+
+	testCases := map[string]struct {
+		raw    []byte
+		reason string
+	}{
+		"bad MAC": {
+			raw: inbound(func(dpath *scion.Decoded) {
+				dpath.HopFields[2].Mac = [path.MacLen]byte{1, 2, 3, 4, 5, 6}
+			}),
+			reason: "bad_mac",
+		},
+		"expired hop": {
+			raw: inbound(func(dpath *scion.Decoded) {
+				dpath.InfoFields[0].Timestamp = util.TimeToSecs(now.Add(-24 * time.Hour))
+			}),
+			reason: "expired_hop",
+		},
+		"unknown ingress interface": {
+			raw: inbound(func(dpath *scion.Decoded) {
+				dpath.HopFields[2].ConsIngress = 2
+			}),
+			reason: "unknown_interface",
+		},
+		"malformed path": {
+			raw: inbound(func(dpath *scion.Decoded) {
+				dpath.Base.PathMeta.SegLen = [3]uint8{2, 1, 0}
+				dpath.Base.NumINF = 2
+				dpath.InfoFields = append(dpath.InfoFields, dpath.InfoFields[0])
+			}),
+			reason: "invalid_path",
+		},
+		"truncated packet": {
+			raw:    inbound(func(*scion.Decoded) {})[:slayers.CmnHdrLen+4],
+			reason: "parse_error",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dp := router.NewDP([]uint16{1, 2}, nil, mock_router.NewMockBatchConn(ctrl),
+				map[uint16]netip.AddrPort{}, nil, localIA, nil, key)
+			pkt := router.NewPacket(tc.raw, nil, nil, 1, 0)
+			dp.ProcessPkt(pkt)
+			assert.Equal(t, tc.reason, pkt.DropReason())
+		})
+	}
+}
+
+func TestEPICHP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	now := time.Now()
+	epicTS, err := libepic.CreateTimestamp(now, now)
+	require.NoError(t, err)
+	localIA := addr.MustParseIA("1-ff00:0:110")
+
+	newDP := func(mode router.EPICHPMode) *router.DataPlane {
+		dp := router.NewDP([]uint16{1, 2}, nil, mock_router.NewMockBatchConn(ctrl),
+			map[uint16]netip.AddrPort{}, nil, localIA, nil, key)
+		require.NoError(t, dp.AddEPICHPInterfacePair(1, 0, mode))
+		return dp
+	}
+	// Both packets are received on the protected interface 1 and delivered locally.
+	epicMsg := func(validHVF bool) *router.Packet {
+		spkt, epicpath, dpath := prepEpicMsg(t, false, key, epicTS, now)
+		prepareEpicCrypto(t, spkt, epicpath, dpath, key)
+		if !validHVF {
+			epicpath.LHVF = []byte{1, 2, 3, 4}
+		}
+		return toIP(t, spkt, epicpath, false, 1, 0)
+	}
+	scionMsg := func(ingress uint16) *router.Packet {
+		spkt, dpath := prepBaseMsg(now)
+		spkt.DstIA = localIA
+		require.NoError(t, spkt.SetDstAddr(addr.MustParseHost("10.0.100.100")))
+		dpath.HopFields = []path.HopField{
+			{ConsIngress: 41, ConsEgress: 40},
+			{ConsIngress: 31, ConsEgress: 30},
+			{ConsIngress: ingress, ConsEgress: 0},
+		}
+		dpath.Base.PathMeta.CurrHF = 2
+		dpath.HopFields[2].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[2])
+		return router.NewPacket(toBytes(t, spkt, dpath), nil, nil, ingress, 0)
+	}
+
+	t.Run("only", func(t *testing.T) {
+		dp := newDP(router.EPICHPOnly)
+		assert.Equal(t, router.PForward, dp.ProcessPkt(epicMsg(true)))
+
+		pkt := scionMsg(1)
+		assert.Equal(t, router.PDiscard, dp.ProcessPkt(pkt))
+		assert.Equal(t, "epic_hp_required", pkt.DropReason())
+
+		pkt = epicMsg(false)
+		assert.Equal(t, router.PDiscard, dp.ProcessPkt(pkt))
+		assert.Equal(t, "bad_mac", pkt.DropReason())
+
+		// Other interface pairs are not affected.
+		assert.Equal(t, router.PForward, dp.ProcessPkt(scionMsg(2)))
+	})
+	t.Run("prioritize", func(t *testing.T) {
+		dp := newDP(router.EPICHPPrioritize)
+		pkt := epicMsg(true)
+		assert.Equal(t, router.PForward, dp.ProcessPkt(pkt))
+		assert.Equal(t, router.PriorityHigh, pkt.Priority())
+
+		pkt = scionMsg(1)
+		assert.Equal(t, router.PForward, dp.ProcessPkt(pkt))
+		assert.Equal(t, router.PriorityLow, pkt.Priority())
+
+		pkt = scionMsg(2)
+		assert.Equal(t, router.PForward, dp.ProcessPkt(pkt))
+		assert.Equal(t, router.PriorityNormal, pkt.Priority())
+	})
+	t.Run("invalid pairs", func(t *testing.T) {
+		dp := newDP(router.EPICHPOnly)
+		assert.Error(t, dp.AddEPICHPInterfacePair(1, 0, router.EPICHPOnly))
+		assert.Error(t, dp.AddEPICHPInterfacePair(2, 2, router.EPICHPOnly))
+		dp.FakeStart()
+		assert.Error(t, dp.AddEPICHPInterfacePair(2, 0, router.EPICHPOnly))
+	})
+}
+
+func TestFabrid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	masterKey := []byte("testmasterkey_xx")
+	now := time.Now()
+	steerTo := netip.MustParseAddrPort("10.0.0.5:30042")
+	srcHost := addr.MustParseHost("10.0.0.1")
+
+	dp := router.NewDP([]uint16{1, 2},
+		map[uint16]topology.LinkType{1: topology.Core, 2: topology.Core, 3: topology.Core},
+		mock_router.NewMockBatchConn(ctrl),
+		map[uint16]netip.AddrPort{
+			uint16(3): netip.MustParseAddrPort("10.0.200.200:30043"),
+		}, nil, addr.MustParseIA("1-ff00:0:110"), nil, key)
+	require.NoError(t, dp.SetMasterKey(masterKey))
+	assert.Error(t, dp.SetMasterKey(masterKey))
+	require.NoError(t, dp.AddFabridPolicy(router.FabridPolicy{ID: 5, Ingress: 1, Egress: 2}))
+	require.NoError(t, dp.AddFabridPolicy(router.FabridPolicy{
+		ID: 5, Ingress: 1, Egress: 3, NextHop: steerTo,
+	}))
+	require.NoError(t, dp.AddFabridPolicy(router.FabridPolicy{
+		ID: 7, Ingress: 1, Egress: 2, SrcIAs: []addr.IA{addr.MustParseIA("1-ff00:0:111")},
+	}))
+	assert.Error(t, dp.AddFabridPolicy(router.FabridPolicy{ID: 5, Ingress: 1, Egress: 2}))
+	assert.Error(t, dp.AddFabridPolicy(router.FabridPolicy{ID: 6, Ingress: 2, Egress: 2}))
+
+	// hostKey is the key that the source host obtains from its control service for the local
+	// AS.
+	hostKey := func(t *testing.T, mk []byte, ts time.Time) cipher.Block {
+		sv, err := fabrid.SecretValue(mk, ts, drkeyutil.LoadEpochDuration())
+		require.NoError(t, err)
+		k, err := fabrid.DeriveKey(sv.Key, addr.MustParseIA("2-ff00:0:222"), srcHost)
+		require.NoError(t, err)
+		block, err := fabrid.NewCipher(k)
+		require.NoError(t, err)
+		return block
+	}
+	// The packets are received on interface 1, and request a policy, if any, for the current hop
+	// field.
+	prepMsg := func(
+		egress uint16,
+		ts time.Time,
+		hops func(fabrid.Input) []slayers.FabridHop,
+	) *router.Packet {
+
+		spkt, dpath := prepBaseMsg(now)
+		require.NoError(t, spkt.SetSrcAddr(srcHost))
+		require.NoError(t, spkt.SetDstAddr(addr.MustParseHost("10.0.0.2")))
+		dpath.HopFields = []path.HopField{
+			{ConsIngress: 31, ConsEgress: 30},
+			{ConsIngress: 1, ConsEgress: egress},
+			{ConsIngress: 50, ConsEgress: 51},
+		}
+		dpath.HopFields[1].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[1])
+		spkt.Path = dpath
+		scionudpLayer := &slayers.UDP{SrcPort: uint16(srcUDPPort), DstPort: uint16(dstUDPPort)}
+		scionudpLayer.SetNetworkLayerForChecksum(spkt)
+		layers := []gopacket.SerializableLayer{spkt}
+		if hops != nil {
+			in := fabrid.Input{
+				Timestamp: uint32(ts.Unix()),
+				PacketID:  42,
+				Ingress:   1,
+				Egress:    egress,
+			}
+			spkt.NextHdr = slayers.HopByHopClass
+			hbh := &slayers.HopByHopExtn{}
+			hbh.NextHdr = slayers.L4UDP
+			hbh.Options = []*slayers.HopByHopOption{
+				slayers.NewFabridOption(in.Timestamp, in.PacketID, hops(in)).HopByHopOption,
+			}
+			layers = append(layers, hbh)
+		}
+		layers = append(layers, scionudpLayer, gopacket.Payload("actualpayloadbytes"))
+		buffer := gopacket.NewSerializeBuffer()
+		require.NoError(t, gopacket.SerializeLayers(buffer,
+			gopacket.SerializeOptions{FixLengths: true}, layers...))
+		return router.NewPacket(buffer.Bytes(), nil, nil, 1, 0)
+	}
+	policy := func(block cipher.Block, id uint8) func(fabrid.Input) []slayers.FabridHop {
+		return func(in fabrid.Input) []slayers.FabridHop {
+			return []slayers.FabridHop{{}, fabrid.ComputeHop(block, in, id, nil), {}}
+		}
+	}
+	block := hostKey(t, masterKey, now)
+
+	t.Run("supported policy", func(t *testing.T) {
+		pkt := prepMsg(2, now, policy(block, 5))
+		assert.Equal(t, router.PForward, dp.ProcessPkt(pkt))
+		assert.Equal(t, uint16(2), pkt.Egress())
+	})
+	t.Run("steered to the next hop of the policy", func(t *testing.T) {
+		pkt := prepMsg(3, now, policy(block, 5))
+		assert.Equal(t, router.PForward, dp.ProcessPkt(pkt))
+		assert.Equal(t, uint16(0), pkt.Egress())
+		assert.Equal(t, steerTo, pkt.DstAddr.AddrPort())
+	})
+	t.Run("unsupported policy", func(t *testing.T) {
+		pkt := prepMsg(2, now, policy(block, 6))
+		assert.Equal(t, router.PDiscard, dp.ProcessPkt(pkt))
+		assert.Equal(t, "fabrid", pkt.DropReason())
+	})
+	t.Run("policy restricted to other source ASes", func(t *testing.T) {
+		pkt := prepMsg(2, now, policy(block, 7))
+		assert.Equal(t, router.PDiscard, dp.ProcessPkt(pkt))
+		assert.Equal(t, "fabrid", pkt.DropReason())
+	})
+	t.Run("invalid hop validation field", func(t *testing.T) {
+		pkt := prepMsg(2, now, policy(hostKey(t, []byte("othermasterkeyxx"), now), 5))
+		assert.Equal(t, router.PDiscard, dp.ProcessPkt(pkt))
+		assert.Equal(t, "fabrid", pkt.DropReason())
+	})
+	t.Run("modified policy ID", func(t *testing.T) {
+		pkt := prepMsg(2, now, func(in fabrid.Input) []slayers.FabridHop {
+			hops := policy(block, 5)(in)
+			hops[1].EncPolicyID ^= 5 ^ 7
+			return hops
+		})
+		assert.Equal(t, router.PDiscard, dp.ProcessPkt(pkt))
+		assert.Equal(t, "fabrid", pkt.DropReason())
+	})
+	t.Run("timestamp outside the acceptance window", func(t *testing.T) {
+		old := now.Add(-time.Hour)
+		pkt := prepMsg(2, old, policy(hostKey(t, masterKey, old), 5))
+		assert.Equal(t, router.PDiscard, dp.ProcessPkt(pkt))
+		assert.Equal(t, "fabrid", pkt.DropReason())
+	})
+	t.Run("option does not match the path", func(t *testing.T) {
+		pkt := prepMsg(2, now, func(in fabrid.Input) []slayers.FabridHop {
+			return policy(block, 5)(in)[:2]
+		})
+		assert.Equal(t, router.PDiscard, dp.ProcessPkt(pkt))
+		assert.Equal(t, "fabrid", pkt.DropReason())
+	})
+	t.Run("no policy requested", func(t *testing.T) {
+		assert.Equal(t, router.PForward, dp.ProcessPkt(prepMsg(2, now, nil)))
+		pkt := prepMsg(3, now, func(fabrid.Input) []slayers.FabridHop {
+			return make([]slayers.FabridHop, 3)
+		})
+		assert.Equal(t, router.PForward, dp.ProcessPkt(pkt))
+		assert.Equal(t, uint16(3), pkt.Egress())
+	})
+}
+
 func toBytes(t *testing.T, spkt *slayers.SCION, dpath path.Path) []byte {
 	t.Helper()
 	spkt.Path = dpath
@@ -17,6 +17,7 @@
 // The code in this package is organized as follows:
 //   - connector.go: implementation of the management API.
 //   - dataplane.go: forwards packets between underlay connections.
+//   - extension.go: registration of the handlers of experimental extension options.
 //   - fnv1aCheap.go: a domain-specific implementation of the fnv1a hash function.
 //   - metrics.go: manages the monitoring sensors.
 //   - serialize_proxy.go: a domain-specific implementation of gopacket.SerializeBuffer.
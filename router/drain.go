@@ -0,0 +1,180 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/netip"
+	"time"
+
+	"github.com/gopacket/gopacket"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path/empty"
+	"github.com/scionproto/scion/router/control"
+)
+
+// downNotifyInterval is the interval at which a router notifies the control services that its
+// interfaces are down. It is well below the 10s lifetime of the revocations that the control
+// services that handle the notifications derive from them.
+const downNotifyInterval = 4 * time.Second
+
+var errAlreadyDraining = serrors.New("router is already draining")
+
+// drainState is the state of a draining router.
+type drainState struct {
+	deadline time.Time
+}
+
+// Drain puts the router into drain mode before maintenance: the control services of the local AS
+// are repeatedly notified that all the external interfaces of the router are down, and beacons
+// are no longer forwarded, while all other traffic still is. After the grace period, Drained is
+// closed. If grace is zero, RunConfig.DrainGracePeriod is used. Draining cannot be undone.
+func (d *DataPlane) Drain(grace time.Duration) error {
+	if grace < 0 {
+		return serrors.New("invalid grace period", "grace_period", grace)
+	}
+	if grace == 0 {
+		grace = d.RunConfig.DrainGracePeriod
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if !d.IsRunning() {
+		return serrors.New("dataplane is not running")
+	}
+	s := &drainState{deadline: time.Now().Add(grace)}
+	if !d.drain.CompareAndSwap(nil, s) {
+		return errAlreadyDraining
+	}
+	if d.drained == nil {
+		d.drained = make(chan struct{})
+	}
+	log.Info("Draining router", "grace_period", grace)
+	go func(drained chan struct{}) {
+		defer log.HandlePanic()
+		d.runDrain(s, drained)
+	}(d.drained)
+	return nil
+}
+
+// DrainStatus returns whether the router is draining, and until when.
+func (d *DataPlane) DrainStatus() control.DrainStatus {
+	s := d.drain.Load()
+	if s == nil {
+		return control.DrainStatus{}
+	}
+	return control.DrainStatus{Draining: true, Deadline: s.deadline}
+}
+
+// Drained returns a channel that is closed when the grace period of the draining is over, and the
+// router can be shut down.
+func (d *DataPlane) Drained() <-chan struct{} {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.drained == nil {
+		d.drained = make(chan struct{})
+	}
+	return d.drained
+}
+
+// runDrain notifies the control services that the interfaces are down until the grace period is
+// over, and then closes drained.
+func (d *DataPlane) runDrain(s *drainState, drained chan struct{}) {
+	timer := time.NewTimer(time.Until(s.deadline))
+	defer timer.Stop()
+	ticker := time.NewTicker(downNotifyInterval)
+	defer ticker.Stop()
+	for {
+		d.notifyInterfacesDown(func(uint16, Link) bool { return true })
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			log.Info("Drain grace period is over")
+			close(drained)
+			return
+		}
+	}
+}
+
+// notifyInterfacesDown sends an SCMP external interface down message for each external interface
+// of the router for which down returns true to each of the control services of the local AS.
+func (d *DataPlane) notifyInterfacesDown(down func(ifID uint16, link Link) bool) {
+	d.mtx.Lock()
+	var csAddrs []netip.AddrPort
+	if d.svc != nil {
+		csAddrs = d.svc.All(addr.SvcCS)
+	}
+	src := d.internalIP
+	d.mtx.Unlock()
+
+	t := d.tables.Load()
+	internal := t.interfaces[0]
+	for ifID, link := range t.interfaces {
+		if ifID == 0 || link.Scope() != External || !down(ifID, link) {
+			continue
+		}
+		for _, cs := range csAddrs {
+			if err := d.sendInterfaceDown(internal, ifID, src, cs); err != nil {
+				log.Info("Error notifying interface down", "interface", ifID, "cs", cs,
+					"err", err)
+			}
+		}
+	}
+}
+
+// sendInterfaceDown sends an SCMP external interface down message for the given interface to the
+// given control service, through the internal link.
+func (d *DataPlane) sendInterfaceDown(internal Link, ifID uint16, src netip.Addr,
+	dst netip.AddrPort) error {
+
+	scn := &slayers.SCION{
+		Version:      0,
+		TrafficClass: 0xb8,
+		NextHdr:      slayers.L4SCMP,
+		PathType:     empty.PathType,
+		Path:         &empty.Path{},
+		SrcIA:        d.localIA,
+		DstIA:        d.localIA,
+	}
+	if err := scn.SetSrcAddr(addr.HostIP(src)); err != nil {
+		return err
+	}
+	if err := scn.SetDstAddr(addr.HostIP(dst.Addr())); err != nil {
+		return err
+	}
+	scmpH := &slayers.SCMP{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeExternalInterfaceDown, 0),
+	}
+	scmpH.SetNetworkLayerForChecksum(scn)
+	msg := &slayers.SCMPExternalInterfaceDown{IA: d.localIA, IfID: uint64(ifID)}
+
+	p := d.getPacketFromPool()
+	p.reset()
+	serBuf := newSerializeProxy(p.rawPacket)
+	sopts := gopacket.SerializeOptions{ComputeChecksums: true, FixLengths: true}
+	if err := gopacket.SerializeLayers(&serBuf, sopts, scn, scmpH, msg); err != nil {
+		d.returnPacketToPool(p)
+		return err
+	}
+	p.rawPacket = serBuf.Bytes()
+	UpdateNetAddrFromAddrPort(p.DstAddr, dst)
+	p.priority = PriorityHigh
+	if !internal.Send(p) {
+		d.returnPacketToPool(p)
+	}
+	return nil
+}
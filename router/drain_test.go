@@ -0,0 +1,182 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"bytes"
+	"hash"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/util"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/onehop"
+)
+
+// capturingLink is an internal link that keeps a copy of the packets sent over it.
+type capturingLink struct {
+	testLink
+	mtx  sync.Mutex
+	pkts []capturedPacket
+}
+
+type capturedPacket struct {
+	raw []byte
+	dst netip.AddrPort
+}
+
+func (l *capturingLink) Scope() LinkScope { return Internal }
+
+func (l *capturingLink) Send(p *Packet) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.pkts = append(l.pkts, capturedPacket{
+		raw: bytes.Clone(p.rawPacket),
+		dst: p.DstAddr.AddrPort(),
+	})
+	return false
+}
+
+func (l *capturingLink) sent() []capturedPacket {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.pkts
+}
+
+func TestDrain(t *testing.T) {
+	local := addr.MustParseIA("1-ff00:0:110")
+	cs := []netip.AddrPort{
+		netip.MustParseAddrPort("10.0.200.100:30254"),
+		netip.MustParseAddrPort("10.0.200.101:30254"),
+	}
+	newDP := func(internal Link) *DataPlane {
+		d := &DataPlane{
+			localIA:    local,
+			internalIP: netip.MustParseAddr("10.0.200.1"),
+			svc:        newServices(),
+			interfaces: map[uint16]Link{
+				0: internal,
+				1: &testLink{},
+				2: &testLink{},
+			},
+			neighborIAs: map[uint16]addr.IA{
+				1: addr.MustParseIA("1-ff00:0:111"),
+				2: addr.MustParseIA("1-ff00:0:112"),
+			},
+			macFactory: func() hash.Hash {
+				mac, err := scrypto.InitMac(testKey)
+				require.NoError(t, err)
+				return mac
+			},
+			packetPool: make(chan *Packet, 8),
+		}
+		for i := 0; i < cap(d.packetPool); i++ {
+			d.packetPool <- new(Packet).init(&[bufSize]byte{})
+		}
+		for _, a := range cs {
+			d.svc.AddSvc(addr.SvcCS, a)
+		}
+		d.publishTables()
+		return d
+	}
+
+	t.Run("not running", func(t *testing.T) {
+		d := newDP(&capturingLink{})
+		assert.Error(t, d.Drain(time.Second))
+		assert.False(t, d.DrainStatus().Draining)
+	})
+	t.Run("invalid grace period", func(t *testing.T) {
+		d := newDP(&capturingLink{})
+		d.setRunning()
+		assert.Error(t, d.Drain(-time.Second))
+		assert.False(t, d.DrainStatus().Draining)
+	})
+	t.Run("control services are notified until the grace period is over", func(t *testing.T) {
+		internal := &capturingLink{}
+		d := newDP(internal)
+		d.setRunning()
+		drained := d.Drained()
+		before := time.Now()
+		require.NoError(t, d.Drain(100*time.Millisecond))
+		status := d.DrainStatus()
+		assert.True(t, status.Draining)
+		assert.WithinRange(t, status.Deadline, before.Add(100*time.Millisecond),
+			time.Now().Add(100*time.Millisecond))
+		assert.ErrorIs(t, d.Drain(time.Second), errAlreadyDraining)
+
+		select {
+		case <-drained:
+		case <-time.After(5 * time.Second):
+			t.Fatal("grace period not over")
+		}
+		notified := make(map[netip.AddrPort][]uint64)
+		for _, p := range internal.sent() {
+			var s slayers.SCION
+			var scmp slayers.SCMP
+			var msg slayers.SCMPExternalInterfaceDown
+			require.NoError(t, s.DecodeFromBytes(p.raw, gopacket.NilDecodeFeedback))
+			require.NoError(t, scmp.DecodeFromBytes(s.Payload, gopacket.NilDecodeFeedback))
+			require.NoError(t, msg.DecodeFromBytes(scmp.Payload, gopacket.NilDecodeFeedback))
+			assert.Equal(t, local, s.SrcIA)
+			assert.Equal(t, local, s.DstIA)
+			assert.Equal(t, slayers.SCMPTypeExternalInterfaceDown, scmp.TypeCode.Type())
+			assert.Equal(t, local, msg.IA)
+			dst, err := s.DstAddr()
+			require.NoError(t, err)
+			assert.Equal(t, p.dst.Addr(), dst.IP())
+			notified[p.dst] = append(notified[p.dst], msg.IfID)
+		}
+		require.Len(t, notified, 2)
+		for _, a := range cs {
+			assert.ElementsMatch(t, []uint64{1, 2}, notified[a], a)
+		}
+	})
+	t.Run("beacons are dropped", func(t *testing.T) {
+		d := newDP(&capturingLink{})
+		d.setRunning()
+		beacon := func() *Packet {
+			s := prepBaseMsg(t, []byte("actualpayloadbytes"), 0)
+			s.SrcIA, s.DstIA = local, addr.MustParseIA("1-ff00:0:111")
+			s.PathType = onehop.PathType
+			s.Path = &onehop.Path{
+				Info:     path.InfoField{ConsDir: true, Timestamp: util.TimeToSecs(time.Now())},
+				FirstHop: path.HopField{ConsEgress: 1, ExpTime: 63},
+			}
+			ohp := s.Path.(*onehop.Path)
+			ohp.FirstHop.Mac = computeMAC(t, testKey, ohp.Info, ohp.FirstHop)
+			p := new(Packet).init(&[bufSize]byte{})
+			p.reset()
+			p.rawPacket = p.buffer[:copy(p.buffer[:], toMsg(t, s))]
+			return p
+		}
+		p := beacon()
+		assert.Equal(t, pForward, newPacketProcessor(d).processPkt(p))
+		assert.Equal(t, uint16(1), p.egress)
+
+		require.NoError(t, d.Drain(time.Hour))
+		p = beacon()
+		assert.Equal(t, pDiscard, newPacketProcessor(d).processPkt(p))
+		assert.Equal(t, dropDraining, p.dropReason)
+	})
+}
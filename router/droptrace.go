@@ -0,0 +1,146 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"bytes"
+	"cmp"
+	"net/netip"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/router/control"
+)
+
+// defaultDropTraceSnapLen is the number of bytes kept from the start of each dropped packet if
+// none is configured. It holds the common and address headers and a path of a few hops.
+const defaultDropTraceSnapLen = 128
+
+var errNoDropTrace = serrors.New("drop trace is not enabled")
+
+// dropTrace keeps the last dropped packets in a ring buffer, so that operators can see what the
+// router rejects.
+//
+// The packets are recorded by the goroutines that drop them. Each one claims the next slot of
+// the ring and copies the start of the packet into it under the lock of that slot only, so that
+// recording a drop neither allocates nor contends with the other goroutines, unless the ring
+// wraps around while they are still writing.
+type dropTrace struct {
+	// next is the number of packets recorded so far.
+	next  atomic.Uint64
+	slots []dropSlot
+}
+
+type dropSlot struct {
+	mtx sync.Mutex
+	// seq is the number of the packet in the slot, counting from 1. 0 if the slot is unused.
+	seq     uint64
+	time    time.Time
+	reason  dropReason
+	ingress uint16
+	egress  uint16
+	src     netip.AddrPort
+	length  int
+	// header has the capacity of the snap length.
+	header []byte
+}
+
+func newDropTrace(size, snapLen int) *dropTrace {
+	if snapLen <= 0 {
+		snapLen = defaultDropTraceSnapLen
+	}
+	t := &dropTrace{slots: make([]dropSlot, size)}
+	headers := make([]byte, size*snapLen)
+	for i := range t.slots {
+		t.slots[i].header = headers[i*snapLen : i*snapLen : (i+1)*snapLen]
+	}
+	return t
+}
+
+// record keeps the given packet, dropped for the given reason, in place of the oldest one.
+func (t *dropTrace) record(p *Packet, reason dropReason) {
+	seq := t.next.Add(1)
+	s := &t.slots[(seq-1)%uint64(len(t.slots))]
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.seq = seq
+	s.time = time.Now()
+	s.reason = reason
+	s.ingress = p.ingress
+	s.egress = p.egress
+	s.src = netip.AddrPort{}
+	if p.srcAddr != nil && len(p.srcAddr.IP) != 0 {
+		src := p.srcAddr.AddrPort()
+		s.src = netip.AddrPortFrom(src.Addr().Unmap(), src.Port())
+	}
+	s.length = len(p.rawPacket)
+	s.header = append(s.header[:0], p.rawPacket[:min(len(p.rawPacket), cap(s.header))]...)
+}
+
+// packets returns the packets in the ring, the most recent first.
+func (t *dropTrace) packets() []control.DroppedPacket {
+	type entry struct {
+		seq uint64
+		pkt control.DroppedPacket
+	}
+	entries := make([]entry, 0, len(t.slots))
+	for i := range t.slots {
+		s := &t.slots[i]
+		s.mtx.Lock()
+		if s.seq != 0 {
+			entries = append(entries, entry{
+				seq: s.seq,
+				pkt: control.DroppedPacket{
+					Time:    s.time,
+					Reason:  s.reason.String(),
+					Ingress: s.ingress,
+					Egress:  s.egress,
+					Src:     s.src,
+					Length:  s.length,
+					Header:  bytes.Clone(s.header),
+				},
+			})
+		}
+		s.mtx.Unlock()
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		return cmp.Compare(b.seq, a.seq)
+	})
+	pkts := make([]control.DroppedPacket, len(entries))
+	for i, e := range entries {
+		pkts[i] = e.pkt
+	}
+	return pkts
+}
+
+// traceDrop records the packet, which is dropped for the given reason, in the drop trace if it
+// is enabled.
+func (d *DataPlane) traceDrop(p *Packet, reason dropReason) {
+	if t := d.dropTrace.Load(); t != nil {
+		t.record(p, reason)
+	}
+}
+
+// DroppedPackets returns the last packets dropped by the router, the most recent first.
+func (d *DataPlane) DroppedPackets() ([]control.DroppedPacket, error) {
+	t := d.dropTrace.Load()
+	if t == nil {
+		return nil, errNoDropTrace
+	}
+	return t.packets(), nil
+}
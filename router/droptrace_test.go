@@ -0,0 +1,91 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropTrace(t *testing.T) {
+	packet := func(ingress uint16, raw ...byte) *Packet {
+		return &Packet{
+			rawPacket: raw,
+			srcAddr:   &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 50000},
+			ingress:   ingress,
+			egress:    2,
+		}
+	}
+
+	t.Run("the most recent packets are kept", func(t *testing.T) {
+		tr := newDropTrace(3, 4)
+		assert.Empty(t, tr.packets())
+		for i := uint16(1); i <= 5; i++ {
+			tr.record(packet(i, byte(i)), dropBadMAC)
+		}
+		pkts := tr.packets()
+		require.Len(t, pkts, 3)
+		for i, p := range pkts {
+			assert.Equal(t, uint16(5-i), p.Ingress)
+			assert.Equal(t, []byte{byte(5 - i)}, p.Header)
+		}
+		assert.Equal(t, "bad_mac", pkts[0].Reason)
+		assert.Equal(t, uint16(2), pkts[0].Egress)
+		assert.Equal(t, netip.MustParseAddrPort("192.0.2.1:50000"), pkts[0].Src)
+		assert.False(t, pkts[0].Time.Before(pkts[1].Time))
+	})
+	t.Run("packets are cut at the snap length", func(t *testing.T) {
+		tr := newDropTrace(2, 4)
+		tr.record(packet(1, 1, 2, 3, 4, 5, 6), dropTooBig)
+		pkts := tr.packets()
+		require.Len(t, pkts, 1)
+		assert.Equal(t, []byte{1, 2, 3, 4}, pkts[0].Header)
+		assert.Equal(t, 6, pkts[0].Length)
+	})
+	t.Run("packets are recorded concurrently", func(t *testing.T) {
+		tr := newDropTrace(16, 0)
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					tr.record(packet(1, make([]byte, 200)...), dropBusyForwarder)
+				}
+			}()
+		}
+		wg.Wait()
+		pkts := tr.packets()
+		require.Len(t, pkts, 16)
+		assert.Len(t, pkts[0].Header, defaultDropTraceSnapLen)
+	})
+	t.Run("the data plane reports a disabled trace", func(t *testing.T) {
+		d := &DataPlane{}
+		_, err := d.DroppedPackets()
+		assert.ErrorIs(t, err, errNoDropTrace)
+		d.traceDrop(packet(1, 1), dropInvalid)
+
+		d.dropTrace.Store(newDropTrace(2, 0))
+		d.traceDrop(packet(1, 1), dropInvalid)
+		pkts, err := d.DroppedPackets()
+		require.NoError(t, err)
+		assert.Len(t, pkts, 1)
+	})
+}
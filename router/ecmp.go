@@ -0,0 +1,175 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto/rand"
+	"net/netip"
+
+	"github.com/scionproto/scion/private/topology"
+)
+
+// ecmpLink is an external link made of several underlay links to the same neighbor, for
+// example over distinct physical circuits. Packets are spread across the links that are up by
+// hashing their flow, so that the packets of one flow stay on the same link and are not
+// reordered.
+//
+// Each member link has its own connection and BFD session. The ecmpLink itself has no BFD
+// session; it is up as long as any of its members is up.
+//
+// If the link has a preferred address family, for example on a dual-stack link, only the members
+// with a remote address of that family carry packets, as long as one of them is up.
+type ecmpLink struct {
+	members  []Link
+	hashSeed uint32
+	family   topology.UnderlayFamily
+}
+
+func newECMPLink(members ...Link) *ecmpLink {
+	hashSeed := fnv1aOffset32
+	randomBytes := make([]byte, 4)
+	if _, err := rand.Read(randomBytes); err != nil {
+		panic("Error while generating random value")
+	}
+	for _, c := range randomBytes {
+		hashSeed = hashFNV1a(hashSeed, c)
+	}
+	return &ecmpLink{members: members, hashSeed: hashSeed}
+}
+
+// withMember returns a copy of the link with the given member added. Links are never modified
+// in place, as the packet processors may be using them.
+func (l *ecmpLink) withMember(m Link) *ecmpLink {
+	members := append(append(make([]Link, 0, len(l.members)+1), l.members...), m)
+	return &ecmpLink{members: members, hashSeed: l.hashSeed, family: l.family}
+}
+
+// withFamily returns a copy of the link that prefers the members of the given address family.
+func (l *ecmpLink) withFamily(family topology.UnderlayFamily) *ecmpLink {
+	return &ecmpLink{members: l.members, hashSeed: l.hashSeed, family: family}
+}
+
+// member returns the member link with the given remote address, or nil.
+func (l *ecmpLink) member(remote netip.AddrPort) Link {
+	for _, m := range l.members {
+		if m.Remote() == remote {
+			return m
+		}
+	}
+	return nil
+}
+
+// pick returns the member link of the packet's flow.
+func (l *ecmpLink) pick(p *Packet) Link {
+	numUp, numPreferred := 0, 0
+	for _, m := range l.members {
+		if m.IsUp() {
+			numUp++
+			if l.family.Contains(m.Remote().Addr()) {
+				numPreferred++
+			}
+		}
+	}
+	if numUp == 0 {
+		// Nothing is known to work. Use the primary link.
+		return l.members[0]
+	}
+	usable := func(m Link) bool {
+		return m.IsUp() && (numPreferred == 0 || l.family.Contains(m.Remote().Addr()))
+	}
+	if numPreferred != 0 {
+		numUp = numPreferred
+	}
+	n, err := computeProcID(p.rawPacket, numUp, l.hashSeed)
+	if err != nil {
+		n = 0
+	}
+	for _, m := range l.members {
+		if !usable(m) {
+			continue
+		}
+		if n == 0 {
+			return m
+		}
+		n--
+	}
+	// The state of the members changed in the mean time.
+	return l.members[0]
+}
+
+func (l *ecmpLink) Scope() LinkScope {
+	return External
+}
+
+func (l *ecmpLink) BFDSession() BFDSession {
+	return nil
+}
+
+func (l *ecmpLink) IsUp() bool {
+	for _, m := range l.members {
+		if m.IsUp() {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *ecmpLink) IfID() uint16 {
+	return l.members[0].IfID()
+}
+
+// Remote returns the remote address of the primary link.
+func (l *ecmpLink) Remote() netip.AddrPort {
+	return l.members[0].Remote()
+}
+
+func (l *ecmpLink) Send(p *Packet) bool {
+	return l.pick(p).Send(p)
+}
+
+func (l *ecmpLink) SendBlocking(p *Packet) {
+	l.pick(p).SendBlocking(p)
+}
+
+// QueueLen returns the number of packets waiting to be sent over all the members.
+func (l *ecmpLink) QueueLen() int {
+	n := 0
+	for _, m := range l.members {
+		n += m.QueueLen()
+	}
+	return n
+}
+
+// PathMTU returns the smallest of the known path MTUs of the members, as the packets of an
+// interface may be sent over any of them.
+func (l *ecmpLink) PathMTU() int {
+	mtu := 0
+	for _, m := range l.members {
+		if m := m.PathMTU(); m != 0 && (mtu == 0 || m < mtu) {
+			mtu = m
+		}
+	}
+	return mtu
+}
+
+// underlayLinks returns the links of the underlay that make up the given link. Of a shared
+// interface, these are the links of this router.
+func underlayLinks(link Link) []Link {
+	link = localLink(link)
+	if e, ok := link.(*ecmpLink); ok {
+		return e.members
+	}
+	return []Link{link}
+}
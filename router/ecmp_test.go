@@ -0,0 +1,135 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/private/topology"
+)
+
+// testLink is a link that can be brought down and counts the packets sent over it.
+type testLink struct {
+	remote netip.AddrPort
+	down   bool
+	sent   int
+	mtu    int
+}
+
+func (l *testLink) Scope() LinkScope       { return External }
+func (l *testLink) BFDSession() BFDSession { return nil }
+func (l *testLink) IsUp() bool             { return !l.down }
+func (l *testLink) IfID() uint16           { return 1 }
+func (l *testLink) Remote() netip.AddrPort { return l.remote }
+func (l *testLink) Send(p *Packet) bool    { l.sent++; return true }
+func (l *testLink) SendBlocking(p *Packet) { l.sent++ }
+func (l *testLink) QueueLen() int          { return 0 }
+func (l *testLink) PathMTU() int           { return l.mtu }
+
+func TestECMPLink(t *testing.T) {
+	newMembers := func() []*testLink {
+		var members []*testLink
+		for i := 0; i < 4; i++ {
+			members = append(members, &testLink{
+				remote: netip.MustParseAddrPort(fmt.Sprintf("192.0.2.%d:50000", i+1)),
+			})
+		}
+		return members
+	}
+	newLink := func(members []*testLink) *ecmpLink {
+		l := newECMPLink(members[0])
+		for _, m := range members[1:] {
+			l = l.withMember(m)
+		}
+		return l
+	}
+	packet := func(flowID uint32) *Packet {
+		return &Packet{rawPacket: serializedBaseMsg(t, []byte("payload"), flowID)}
+	}
+
+	t.Run("flows are spread and stick to one link", func(t *testing.T) {
+		members := newMembers()
+		l := newLink(members)
+		for flowID := uint32(0); flowID < 400; flowID++ {
+			picked := l.pick(packet(flowID))
+			assert.Same(t, picked, l.pick(packet(flowID)))
+			assert.True(t, l.Send(packet(flowID)))
+		}
+		for _, m := range members {
+			assert.Greater(t, m.sent, 50, m.remote)
+		}
+	})
+	t.Run("links that are down are avoided", func(t *testing.T) {
+		members := newMembers()
+		l := newLink(members)
+		members[1].down = true
+		members[3].down = true
+		for flowID := uint32(0); flowID < 100; flowID++ {
+			l.SendBlocking(packet(flowID))
+		}
+		assert.Zero(t, members[1].sent)
+		assert.Zero(t, members[3].sent)
+		assert.Equal(t, 100, members[0].sent+members[2].sent)
+		assert.True(t, l.IsUp())
+	})
+	t.Run("primary is used when all are down", func(t *testing.T) {
+		members := newMembers()
+		l := newLink(members)
+		for _, m := range members {
+			m.down = true
+		}
+		assert.False(t, l.IsUp())
+		assert.Same(t, Link(members[0]), l.pick(packet(42)))
+	})
+	t.Run("members of the preferred family are used while up", func(t *testing.T) {
+		members := newMembers()
+		v6 := []*testLink{
+			{remote: netip.MustParseAddrPort("[2001:db8::1]:50000")},
+			{remote: netip.MustParseAddrPort("[2001:db8::2]:50000")},
+		}
+		l := newLink(append(members, v6...)).withFamily(topology.IPv6)
+		for flowID := uint32(0); flowID < 100; flowID++ {
+			l.SendBlocking(packet(flowID))
+		}
+		for _, m := range members {
+			assert.Zero(t, m.sent, m.remote)
+		}
+		assert.Equal(t, 100, v6[0].sent+v6[1].sent)
+		assert.NotZero(t, v6[0].sent)
+		assert.NotZero(t, v6[1].sent)
+
+		// When no member of the preferred family is up, the others are used.
+		v6[0].down = true
+		v6[1].down = true
+		for flowID := uint32(0); flowID < 100; flowID++ {
+			l.SendBlocking(packet(flowID))
+		}
+		assert.Equal(t, 100, members[0].sent+members[1].sent+members[2].sent+members[3].sent)
+		assert.Equal(t, topology.IPv6, l.withMember(&testLink{}).family)
+	})
+	t.Run("members are found by remote address", func(t *testing.T) {
+		members := newMembers()
+		l := newLink(members)
+		require.Len(t, underlayLinks(l), 4)
+		assert.Same(t, Link(members[2]), l.member(members[2].remote))
+		assert.Nil(t, l.member(netip.MustParseAddrPort("192.0.2.99:50000")))
+		assert.Equal(t, members[0].remote, l.Remote())
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// EPICHPMode is how the traffic between a pair of interfaces protected with EPIC-HP is treated.
+// See the EPIC-HP design document, doc/dev/design/EPIC.md.
+type EPICHPMode uint8
+
+const (
+	// EPICHPOnly only forwards the EPIC-HP packets whose hop validation field verifies. This
+	// makes the hidden paths through the pair usable by the holders of their authenticators
+	// only.
+	EPICHPOnly EPICHPMode = iota
+	// EPICHPPrioritize forwards the EPIC-HP packets whose hop validation field verifies with
+	// high priority, and all the others with low priority.
+	EPICHPPrioritize
+)
+
+func (m EPICHPMode) String() string {
+	switch m {
+	case EPICHPOnly:
+		return "only"
+	case EPICHPPrioritize:
+		return "prioritize"
+	}
+	return "unknown"
+}
+
+// interfacePair is the pair of interfaces through which a packet enters and leaves the local AS,
+// in the direction of travel. Interface 0 is the local AS itself.
+type interfacePair struct {
+	ingress, egress uint16
+}
+
+// AddEPICHPInterfacePair protects the traffic from the ingress to the egress interface with
+// EPIC-HP. Interface 0 is the local AS, i.e. the packets that originate from, or are delivered
+// to, a host in the local AS. The pair should carry hidden paths only, on which the local AS is
+// the last or the penultimate AS: these are the hops at which the hop validation fields of EPIC
+// packets are verified. This can only be called before the dataplane is running.
+func (d *DataPlane) AddEPICHPInterfacePair(ingress, egress uint16, mode EPICHPMode) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.IsRunning() {
+		return modifyExisting
+	}
+	if ingress == egress {
+		return serrors.New("ingress and egress interface must differ", "if_id", ingress)
+	}
+	if mode > EPICHPPrioritize {
+		return serrors.New("invalid EPIC-HP mode", "mode", mode)
+	}
+	pair := interfacePair{ingress: ingress, egress: egress}
+	if _, exists := d.epicHP[pair]; exists {
+		return serrors.JoinNoStack(alreadySet, nil, "ingress", ingress, "egress", egress)
+	}
+	if d.epicHP == nil {
+		d.epicHP = make(map[interfacePair]EPICHPMode)
+	}
+	d.epicHP[pair] = mode
+	return nil
+}
+
+// enforceEPICHP applies the EPIC-HP protection, if any, of the given interface pair to the
+// packet. An EPIC packet only counts as EPIC-HP if its hop validation field is verified at this
+// hop, which processEPIC does once the rest of the processing succeeds.
+func (p *scionPacketProcessor) enforceEPICHP(ingress, egress uint16) disposition {
+	mode, ok := p.d.epicHP[interfacePair{ingress: ingress, egress: egress}]
+	if !ok {
+		return pForward
+	}
+	switch {
+	case mode == EPICHPPrioritize && p.verifyHVF:
+		p.pkt.priority = PriorityHigh
+	case mode == EPICHPPrioritize:
+		p.pkt.priority = PriorityLow
+	case !p.verifyHVF:
+		return p.discard(dropEPICHPRequired, "error", epicHPRequired,
+			"ingress", ingress, "egress", egress)
+	}
+	return pForward
+}
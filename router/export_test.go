@@ -38,7 +38,21 @@ var NewServices = newServices
 
 type Disposition disposition
 
-const PDiscard = Disposition(pDiscard)
+const (
+	PDiscard = Disposition(pDiscard)
+	PForward = Disposition(pForward)
+)
+
+// DropReason returns the reason label of the dropped packets metric that the packet is counted
+// with if it is dropped.
+func (p *Packet) DropReason() string {
+	return p.dropReason.String()
+}
+
+// Egress returns the interface that the packet is forwarded through.
+func (p *Packet) Egress() uint16 {
+	return p.egress
+}
 
 func NewPacket(raw []byte, src, dst *net.UDPAddr, ingress, egress uint16) *Packet {
 	p := Packet{
@@ -102,6 +116,16 @@ func NewDP(
 	return dp
 }
 
+// SetRemoteIfID sets the interface ID of the remote end of the given interface, as
+// AddExternalInterface does.
+func (d *DataPlane) SetRemoteIfID(ifID, remote uint16) {
+	if d.remoteIfIDs == nil {
+		d.remoteIfIDs = make(map[uint16]uint16)
+	}
+	d.remoteIfIDs[ifID] = remote
+	d.publishTables()
+}
+
 func (d *DataPlane) FakeStart() {
 	d.setRunning()
 }
@@ -0,0 +1,196 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+// ExtensionType is the extension header that an option is carried in.
+type ExtensionType uint8
+
+const (
+	// HopByHop is the hop-by-hop options header, processed by every router on the path.
+	HopByHop ExtensionType = iota
+	// EndToEnd is the end-to-end options header, addressed to the destination only. Routers
+	// can still observe it.
+	EndToEnd
+)
+
+func (e ExtensionType) String() string {
+	switch e {
+	case HopByHop:
+		return "hop-by-hop"
+	case EndToEnd:
+		return "end-to-end"
+	default:
+		return fmt.Sprintf("ExtensionType(%d)", uint8(e))
+	}
+}
+
+// ExtensionVerdict is what the router does with a packet once an extension handler processed
+// it.
+type ExtensionVerdict uint8
+
+const (
+	// ExtensionContinue lets the router process and forward the packet as usual.
+	ExtensionContinue ExtensionVerdict = iota
+	// ExtensionDrop drops the packet. It is counted in the dropped packets with the reason
+	// "extension".
+	ExtensionDrop
+)
+
+// ExtensionPacket is a packet that carries the option of an extension handler. It is only valid
+// during the call of the handler.
+type ExtensionPacket struct {
+	// SrcIA and DstIA are the source and destination ISD-AS of the packet.
+	SrcIA, DstIA addr.IA
+	// Ingress and Egress are the interfaces through which the packet enters and leaves the local
+	// AS. Interface 0 is the local AS itself.
+	Ingress, Egress uint16
+	// FromSibling is set if the packet was received from a sibling router of the local AS,
+	// which processed it already.
+	FromSibling bool
+	// Option is the data of the option. The handler may modify it in place, but must not
+	// retain it.
+	Option []byte
+}
+
+// ExtensionHandler processes the packets that carry an option of an experimental extension.
+type ExtensionHandler interface {
+	// Handle is called for each packet that carries the option and that the router forwards or
+	// delivers, after the path of the packet was verified and its egress interface determined.
+	// It is called concurrently by the packet processors, so it must be safe for concurrent use,
+	// and it must neither block nor allocate memory, if possible.
+	Handle(p *ExtensionPacket) ExtensionVerdict
+}
+
+// ExtensionHandlerFunc is an ExtensionHandler implemented by a function.
+type ExtensionHandlerFunc func(p *ExtensionPacket) ExtensionVerdict
+
+func (f ExtensionHandlerFunc) Handle(p *ExtensionPacket) ExtensionVerdict {
+	return f(p)
+}
+
+type extensionHandler struct {
+	name    string
+	optType slayers.OptionType
+	handler ExtensionHandler
+}
+
+// extensionHandlers are the registered handlers of each extension. They are only modified
+// before the router starts.
+var extensionHandlers [EndToEnd + 1][]extensionHandler
+
+// reservedOptionTypes are the option types of each extension that the router handles itself.
+var reservedOptionTypes = [EndToEnd + 1][]slayers.OptionType{
+	HopByHop: {
+		slayers.OptTypePad1,
+		slayers.OptTypePadN,
+		slayers.OptTypeFabrid,
+		slayers.OptTypeTelemetry,
+	},
+	EndToEnd: {
+		slayers.OptTypePad1,
+		slayers.OptTypePadN,
+		slayers.OptTypeAuthenticator,
+		slayers.OptTypeSCMPDiagnostic,
+	},
+}
+
+// RegisterExtension registers the handler of the options of the given type in the given
+// extension header. It is meant to be called from the init function of a package that the router
+// is built with, so that extensions can be implemented out of tree: a custom router main package
+// imports the package of the extension for its side effects, like the underlay providers.
+//
+// RegisterExtension panics if the option type is one that the router handles itself, or if a
+// handler is already registered for it.
+func RegisterExtension(
+	ext ExtensionType,
+	optType slayers.OptionType,
+	name string,
+	handler ExtensionHandler,
+) {
+	if ext > EndToEnd {
+		panic(fmt.Sprintf("unknown extension type %d", ext))
+	}
+	if slices.Contains(reservedOptionTypes[ext], optType) {
+		panic(fmt.Sprintf("%s option type %d is reserved", ext, optType))
+	}
+	for _, h := range extensionHandlers[ext] {
+		if h.optType == optType {
+			panic(fmt.Sprintf("%s option type %d already registered by %s",
+				ext, optType, h.name))
+		}
+	}
+	extensionHandlers[ext] = append(extensionHandlers[ext], extensionHandler{
+		name:    name,
+		optType: optType,
+		handler: handler,
+	})
+}
+
+// handleExtensions calls the handlers of the extension options that the packet carries, for its
+// hop from the given ingress to the given egress interface.
+func (p *scionPacketProcessor) handleExtensions(ingress, egress uint16) disposition {
+	if len(p.hbhLayer.Contents) != 0 {
+		for _, h := range extensionHandlers[HopByHop] {
+			o, err := p.hbhLayer.FindOption(h.optType)
+			if err != nil {
+				continue
+			}
+			if disp := p.callExtension(h, o.OptData, ingress, egress); disp != pForward {
+				return disp
+			}
+		}
+	}
+	if len(p.e2eLayer.Contents) != 0 {
+		for _, h := range extensionHandlers[EndToEnd] {
+			o, err := p.e2eLayer.FindOption(h.optType)
+			if err != nil {
+				continue
+			}
+			if disp := p.callExtension(h, o.OptData, ingress, egress); disp != pForward {
+				return disp
+			}
+		}
+	}
+	return pForward
+}
+
+func (p *scionPacketProcessor) callExtension(
+	h extensionHandler,
+	option []byte,
+	ingress, egress uint16,
+) disposition {
+	p.extPacket = ExtensionPacket{
+		SrcIA:       p.scionLayer.SrcIA,
+		DstIA:       p.scionLayer.DstIA,
+		Ingress:     ingress,
+		Egress:      egress,
+		FromSibling: p.pkt.ingress == 0 && ingress != 0,
+		Option:      option,
+	}
+	verdict := h.handler.Handle(&p.extPacket)
+	p.extPacket.Option = nil
+	if verdict == ExtensionDrop {
+		return p.discard(dropExtension, "extension", h.name)
+	}
+	return pForward
+}
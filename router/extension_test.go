@@ -0,0 +1,125 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"bytes"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gopacket/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/private/topology"
+	"github.com/scionproto/scion/router/mock_router"
+)
+
+func TestRegisterExtension(t *testing.T) {
+	saved := extensionHandlers
+	t.Cleanup(func() { extensionHandlers = saved })
+	nop := ExtensionHandlerFunc(func(*ExtensionPacket) ExtensionVerdict {
+		return ExtensionContinue
+	})
+
+	RegisterExtension(HopByHop, 0x30, "test", nop)
+	// The same type can be used in the other extension.
+	RegisterExtension(EndToEnd, 0x30, "test", nop)
+	assert.Panics(t, func() { RegisterExtension(HopByHop, 0x30, "other", nop) })
+	assert.Panics(t, func() {
+		RegisterExtension(HopByHop, slayers.OptTypeFabrid, "other", nop)
+	})
+	assert.Panics(t, func() {
+		RegisterExtension(HopByHop, slayers.OptTypeTelemetry, "other", nop)
+	})
+	assert.Panics(t, func() {
+		RegisterExtension(EndToEnd, slayers.OptTypeSCMPDiagnostic, "other", nop)
+	})
+	assert.Panics(t, func() { RegisterExtension(EndToEnd, slayers.OptTypePadN, "other", nop) })
+	assert.Panics(t, func() { RegisterExtension(EndToEnd+1, 0x31, "other", nop) })
+}
+
+func TestHandleExtensions(t *testing.T) {
+	saved := extensionHandlers
+	t.Cleanup(func() { extensionHandlers = saved })
+	extensionHandlers = [EndToEnd + 1][]extensionHandler{}
+
+	var seen []ExtensionPacket
+	verdict := ExtensionContinue
+	handler := ExtensionHandlerFunc(func(p *ExtensionPacket) ExtensionVerdict {
+		seen = append(seen, *p)
+		// Record this hop in the option.
+		p.Option[0] = 0xee
+		return verdict
+	})
+	RegisterExtension(HopByHop, 0x30, "hop-by-hop", handler)
+	RegisterExtension(EndToEnd, 0x31, "end-to-end", handler)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dp := NewDP([]uint16{1}, map[uint16]topology.LinkType{1: topology.Child},
+		mock_router.NewMockBatchConn(ctrl), nil, map[addr.SVC][]netip.AddrPort{},
+		addr.MustParseIA("1-ff00:0:110"), nil, testKey)
+	payload := []byte("actualpayloadbytes")
+	packet := func(hbhOptions, e2eOptions []byte) *Packet {
+		s := prepBaseMsg(t, payload, 0)
+		_ = s.SetDstAddr(addr.HostIP(netip.AddrFrom4([4]byte{10, 0, 200, 200})))
+		_ = s.SetSrcAddr(addr.HostIP(netip.AddrFrom4([4]byte{10, 0, 200, 100})))
+		s.NextHdr = slayers.HopByHopClass
+		hbh := &slayers.HopByHopExtn{}
+		hbh.NextHdr = slayers.End2EndClass
+		hbh.Options = []*slayers.HopByHopOption{{OptType: 0x30, OptData: hbhOptions}}
+		e2e := &slayers.EndToEndExtn{}
+		e2e.NextHdr = slayers.L4UDP
+		e2e.Options = []*slayers.EndToEndOption{{OptType: 0x31, OptData: e2eOptions}}
+		buffer := gopacket.NewSerializeBuffer()
+		require.NoError(t, gopacket.SerializeLayers(buffer,
+			gopacket.SerializeOptions{FixLengths: true}, s, hbh, e2e, gopacket.Payload(payload)))
+		p := new(Packet).init(&[bufSize]byte{})
+		p.reset()
+		p.rawPacket = p.buffer[:copy(p.buffer[:], buffer.Bytes())]
+		p.ingress = 1
+		updateNetAddrFromNetAddr(p.srcAddr, &net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: 50000})
+		return p
+	}
+
+	t.Run("handlers see and update their options", func(t *testing.T) {
+		seen = nil
+		p := packet([]byte{1, 2, 3, 4}, []byte{5, 6})
+		assert.Equal(t, pForward, newPacketProcessor(dp).processPkt(p))
+		require.Len(t, seen, 2)
+		for _, e := range seen {
+			assert.Equal(t, addr.MustParseIA("1-ff00:0:111"), e.SrcIA)
+			assert.Equal(t, addr.MustParseIA("1-ff00:0:110"), e.DstIA)
+			assert.Equal(t, uint16(1), e.Ingress)
+			assert.Equal(t, uint16(0), e.Egress)
+			assert.False(t, e.FromSibling)
+		}
+		assert.True(t, bytes.Contains(p.rawPacket, []byte{0x30, 4, 0xee, 2, 3, 4}))
+		assert.True(t, bytes.Contains(p.rawPacket, []byte{0x31, 2, 0xee, 6}))
+	})
+	t.Run("packets are dropped by handlers", func(t *testing.T) {
+		seen = nil
+		verdict = ExtensionDrop
+		p := packet([]byte{1, 2, 3, 4}, []byte{5, 6})
+		assert.Equal(t, pDiscard, newPacketProcessor(dp).processPkt(p))
+		assert.Equal(t, dropExtension, p.dropReason)
+		assert.Len(t, seen, 1, "the end-to-end handler is not called")
+	})
+}
@@ -0,0 +1,264 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto/cipher"
+	"net/netip"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/drkey"
+	"github.com/scionproto/scion/pkg/experimental/fabrid"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+// FabridPolicy is an intra-AS policy that the packets can request, with the experimental FABRID
+// hop-by-hop option, for their hop from the ingress to the egress interface. The request is
+// authenticated with the DRKey of the source host, which the router derives from the master key
+// of the AS. See doc/protocols/fabrid-option.rst.
+type FabridPolicy struct {
+	// ID identifies the policy in the FABRID option.
+	ID uint8
+	// Ingress and Egress are the interfaces through which the packets enter and leave the local
+	// AS, in the direction of travel. Interface 0 is the local AS.
+	Ingress, Egress uint16
+	// SrcIAs, if not empty, are the only ASes whose hosts can request the policy.
+	SrcIAs []addr.IA
+	// NextHop, if valid, is the internal underlay address that the packets are sent to when
+	// they leave the router through the internal network, i.e. when their egress interface is
+	// on a sibling router or when they are delivered to the local AS. It is where the intra-AS
+	// path of the policy starts. If not valid, the packets are forwarded as any other.
+	NextHop netip.AddrPort
+}
+
+type fabridKey struct {
+	interfacePair
+	id uint8
+}
+
+type fabridPolicy struct {
+	srcIAs  []addr.IA
+	nextHop netip.AddrPort
+	// forwarded counts the packets forwarded according to the policy. Nil if there are no
+	// metrics.
+	forwarded prometheus.Counter
+}
+
+// AddFabridPolicy adds a policy that the packets can request with the FABRID option. The packets
+// that request a policy which is not supported between their ingress and egress interfaces, or
+// whose request does not verify, are dropped. This can only be called before the dataplane is
+// running.
+func (d *DataPlane) AddFabridPolicy(policy FabridPolicy) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.IsRunning() {
+		return modifyExisting
+	}
+	if policy.Ingress == policy.Egress {
+		return serrors.New("ingress and egress interface must differ", "if_id", policy.Ingress)
+	}
+	key := fabridKey{
+		interfacePair: interfacePair{ingress: policy.Ingress, egress: policy.Egress},
+		id:            policy.ID,
+	}
+	if _, exists := d.fabridPolicies[key]; exists {
+		return serrors.JoinNoStack(alreadySet, nil, "policy_id", policy.ID,
+			"ingress", policy.Ingress, "egress", policy.Egress)
+	}
+	fp := &fabridPolicy{srcIAs: slices.Clone(policy.SrcIAs), nextHop: policy.NextHop}
+	if d.Metrics != nil {
+		fp.forwarded = d.Metrics.FabridPackets.With(prometheus.Labels{
+			"isd_as":    d.localIA.String(),
+			"policy_id": strconv.Itoa(int(policy.ID)),
+		})
+		fp.forwarded.Add(0)
+	}
+	if d.fabridPolicies == nil {
+		d.fabridPolicies = make(map[fabridKey]*fabridPolicy)
+	}
+	d.fabridPolicies[key] = fp
+	return nil
+}
+
+// SetMasterKey sets the master key of the local AS, from which the keys of the FABRID option are
+// derived. This can only be called before the dataplane is running.
+func (d *DataPlane) SetMasterKey(key []byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.IsRunning() {
+		return modifyExisting
+	}
+	if len(key) == 0 {
+		return emptyValue
+	}
+	if d.masterKey != nil {
+		return alreadySet
+	}
+	d.masterKey = slices.Clone(key)
+	return nil
+}
+
+// fabridKeys caches the keys with which a packet processor verifies the FABRID options, so that
+// they are not derived for every packet.
+type fabridKeys struct {
+	epochDuration    time.Duration
+	acceptanceWindow time.Duration
+	// svs holds the secret values of the last epochs for which keys were derived, indexed by the
+	// parity of the epoch index. The acceptance window is shorter than an epoch, so the packets
+	// use the keys of at most two epochs at any time.
+	svs [2]fabridSV
+	// epoch, srcIA, srcHost and block are those of the AS-host key of the last verified source.
+	// block is nil if there is none.
+	epoch   int64
+	srcIA   addr.IA
+	srcHost addr.Host
+	block   cipher.Block
+	buf     [fabrid.BufferSize]byte
+}
+
+type fabridSV struct {
+	valid bool
+	epoch int64
+	key   drkey.Key
+}
+
+// keyBlock returns the block cipher of the AS-host key of the given source host, valid at ts.
+func (k *fabridKeys) keyBlock(
+	masterKey []byte,
+	ts time.Time,
+	srcIA addr.IA,
+	srcHost addr.Host,
+) (cipher.Block, error) {
+
+	d := int64(k.epochDuration / time.Second)
+	if d <= 0 {
+		return nil, serrors.New("invalid epoch duration", "duration", k.epochDuration)
+	}
+	epoch := ts.Unix() / d
+	if k.block != nil && k.epoch == epoch && k.srcIA == srcIA && k.srcHost == srcHost {
+		return k.block, nil
+	}
+	sv := &k.svs[epoch%2]
+	if !sv.valid || sv.epoch != epoch {
+		v, err := fabrid.SecretValue(masterKey, ts, k.epochDuration)
+		if err != nil {
+			return nil, err
+		}
+		*sv = fabridSV{valid: true, epoch: epoch, key: v.Key}
+	}
+	key, err := fabrid.DeriveKey(sv.key, srcIA, srcHost)
+	if err != nil {
+		return nil, err
+	}
+	block, err := fabrid.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	k.epoch, k.srcIA, k.srcHost, k.block = epoch, srcIA, srcHost, block
+	return block, nil
+}
+
+// enforceFabrid verifies the policy, if any, that the packet requests for the current hop field
+// with the FABRID option, and checks it against those supported between the given interfaces.
+// The packet is dropped if the request does not verify, or if the policy is not supported.
+// Otherwise, the next hop of the policy, if any, is recorded for steerFabrid.
+//
+// The policy is only checked by the router through which the packet enters the local AS, which
+// is the one that knows both interfaces.
+func (p *scionPacketProcessor) enforceFabrid(ingress, egress uint16) disposition {
+	if p.d.fabridPolicies == nil || len(p.hbhLayer.Contents) == 0 {
+		return pForward
+	}
+	if p.pkt.ingress == 0 && ingress != 0 {
+		// Received from a sibling router, which enforced the policy already.
+		return pForward
+	}
+	o, err := p.hbhLayer.FindOption(slayers.OptTypeFabrid)
+	if err != nil {
+		return pForward
+	}
+	opt, err := slayers.ParseFabridOption(&o)
+	if err != nil {
+		return p.discard(dropFabrid, "error", err)
+	}
+	if opt.NumHops() != p.path.NumHops {
+		return p.discard(dropFabrid, "error", invalidFabrid,
+			"hops", opt.NumHops(), "expected", p.path.NumHops)
+	}
+	hop := opt.Hop(int(p.path.PathMeta.CurrHF))
+	if !hop.Enabled {
+		return pForward
+	}
+	if p.d.masterKey == nil {
+		return p.discard(dropFabrid, "error", noFabridKey)
+	}
+	ts := time.Unix(int64(opt.Timestamp()), 0)
+	now, aw := time.Now(), p.fabridKeys.acceptanceWindow/2
+	if ts.Before(now.Add(-aw)) || !ts.Before(now.Add(aw)) {
+		return p.discard(dropFabrid, "error", fabridOutsideWindow, "timestamp", ts)
+	}
+	srcHost, err := p.scionLayer.SrcAddr()
+	if err != nil {
+		return p.discard(dropFabrid, "error", err)
+	}
+	block, err := p.fabridKeys.keyBlock(p.d.masterKey, ts, p.scionLayer.SrcIA, srcHost)
+	if err != nil {
+		return p.discard(dropFabrid, "error", err)
+	}
+	in := fabrid.Input{
+		Timestamp: opt.Timestamp(),
+		PacketID:  opt.PacketID(),
+		Ingress:   ingress,
+		Egress:    egress,
+	}
+	policyID, ok := fabrid.VerifyHop(block, in, hop, p.fabridKeys.buf[:])
+	if !ok {
+		return p.discard(dropFabrid, "error", invalidFabridHVF)
+	}
+	key := fabridKey{
+		interfacePair: interfacePair{ingress: ingress, egress: egress},
+		id:            policyID,
+	}
+	policy, ok := p.d.fabridPolicies[key]
+	if !ok {
+		return p.discard(dropFabrid, "error", fabridPolicyUnsupported,
+			"policy_id", policyID, "ingress", ingress, "egress", egress)
+	}
+	if len(policy.srcIAs) > 0 && !slices.Contains(policy.srcIAs, p.scionLayer.SrcIA) {
+		return p.discard(dropFabrid, "error", fabridPolicyUnsupported,
+			"policy_id", policyID, "src_isd_as", p.scionLayer.SrcIA)
+	}
+	if policy.forwarded != nil {
+		policy.forwarded.Inc()
+	}
+	p.fabridNextHop = policy.nextHop
+	return pForward
+}
+
+// steerFabrid sends the packet, which leaves the router through the internal network, to the
+// next hop of its requested policy, if any.
+func (p *scionPacketProcessor) steerFabrid() {
+	if !p.fabridNextHop.IsValid() {
+		return
+	}
+	p.pkt.egress = 0
+	UpdateNetAddrFromAddrPort(p.pkt.DstAddr, p.fabridNextHop)
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"slices"
+
+	"github.com/gopacket/gopacket"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path"
+)
+
+// FilterAction is what is done with the packets that match a filter rule.
+type FilterAction uint8
+
+const (
+	// FilterPermit lets the packets through.
+	FilterPermit FilterAction = iota
+	// FilterDeny drops the packets.
+	FilterDeny
+	// FilterRateLimit lets the packets through up to the rate of the rule, and drops the
+	// others.
+	FilterRateLimit
+)
+
+func (a FilterAction) String() string {
+	switch a {
+	case FilterPermit:
+		return "permit"
+	case FilterDeny:
+		return "deny"
+	case FilterRateLimit:
+		return "rate_limit"
+	default:
+		return "unknown"
+	}
+}
+
+// PortRange is an inclusive range of ports. The zero value matches all ports.
+type PortRange struct {
+	Min, Max uint16
+}
+
+func (r PortRange) any() bool {
+	return r == PortRange{}
+}
+
+func (r PortRange) contains(port uint16) bool {
+	return port >= r.Min && port <= r.Max
+}
+
+// FilterRule selects packets received by the router, and what is done with them. The fields that
+// are not set match all packets.
+type FilterRule struct {
+	// Name identifies the rule in the metrics.
+	Name string
+	// Interfaces are the interfaces the packets are received through. The internal interface
+	// is 0.
+	Interfaces []uint16
+	// SrcIA and DstIA are the source and destination ISD-AS of the packets. Either the ISD or
+	// the AS number may be the wildcard 0.
+	SrcIA, DstIA addr.IA
+	// SrcPrefix and DstPrefix contain the source and destination IP host addresses of the
+	// packets. Packets with other types of host addresses, such as service addresses, do not
+	// match them.
+	SrcPrefix, DstPrefix netip.Prefix
+	// SrcPorts and DstPorts contain the source and destination ports of SCION/UDP packets. The
+	// packets of other protocols do not match them.
+	SrcPorts, DstPorts PortRange
+	// PathTypes are the path types of the packets.
+	PathTypes []path.Type
+	Action    FilterAction
+	// Rate is the rate, in bits per second, of the packets that are let through by a rate_limit
+	// rule, and Burst the bucket size in bytes. If Burst is zero, 10ms worth of traffic at Rate.
+	Rate  int64
+	Burst int
+}
+
+// packetFilter applies the filter rules to the packets received by the router. The rules are
+// evaluated in order, and the first that matches a packet decides of its fate. Packets that match
+// no rule are subject to the default action.
+type packetFilter struct {
+	rules         []filterRule
+	defaultAction FilterAction
+}
+
+type filterRule struct {
+	FilterRule
+	limiter *rateLimiter
+	// matched counts the packets that matched the rule. Nil if there are no metrics.
+	matched prometheus.Counter
+}
+
+// filteredPacket is the information about a received packet that filter rules match on.
+type filteredPacket struct {
+	ingress uint16
+	scion   *slayers.SCION
+	// l4 is the type of the layer that follows the SCION header and its extensions, and
+	// payload its bytes.
+	l4      gopacket.LayerType
+	payload []byte
+	size    int
+}
+
+// permit returns whether the packet may be processed further.
+func (f *packetFilter) permit(p filteredPacket) bool {
+	for i := range f.rules {
+		r := &f.rules[i]
+		if !r.matches(p) {
+			continue
+		}
+		if r.matched != nil {
+			r.matched.Inc()
+		}
+		switch r.Action {
+		case FilterPermit:
+			return true
+		case FilterRateLimit:
+			return r.limiter.allow(p.size)
+		default:
+			return false
+		}
+	}
+	return f.defaultAction == FilterPermit
+}
+
+// matches returns whether the packet is selected by the rule.
+func (r *filterRule) matches(p filteredPacket) bool {
+	if len(r.Interfaces) != 0 && !slices.Contains(r.Interfaces, p.ingress) {
+		return false
+	}
+	if len(r.PathTypes) != 0 && !slices.Contains(r.PathTypes, p.scion.PathType) {
+		return false
+	}
+	if !matchIA(r.SrcIA, p.scion.SrcIA) || !matchIA(r.DstIA, p.scion.DstIA) {
+		return false
+	}
+	if r.SrcPrefix.IsValid() {
+		if h, err := p.scion.SrcAddr(); err != nil || !matchHost(r.SrcPrefix, h) {
+			return false
+		}
+	}
+	if r.DstPrefix.IsValid() {
+		if h, err := p.scion.DstAddr(); err != nil || !matchHost(r.DstPrefix, h) {
+			return false
+		}
+	}
+	if r.SrcPorts.any() && r.DstPorts.any() {
+		return true
+	}
+	// The ports start the SCION/UDP header.
+	if p.l4 != slayers.LayerTypeSCIONUDP || len(p.payload) < 4 {
+		return false
+	}
+	return (r.SrcPorts.any() || r.SrcPorts.contains(binary.BigEndian.Uint16(p.payload))) &&
+		(r.DstPorts.any() || r.DstPorts.contains(binary.BigEndian.Uint16(p.payload[2:])))
+}
+
+// matchIA returns whether ia matches the given ISD-AS, in which the ISD, the AS number, or both
+// may be the wildcard 0.
+func matchIA(want, ia addr.IA) bool {
+	return (want.ISD() == 0 || want.ISD() == ia.ISD()) &&
+		(want.AS() == 0 || want.AS() == ia.AS())
+}
+
+func matchHost(prefix netip.Prefix, h addr.Host) bool {
+	return h.Type() == addr.HostTypeIP && prefix.Contains(h.IP().Unmap())
+}
+
+// AddFilterRule appends a rule to the filter applied to the packets received by the router,
+// before they are processed. BFD packets are never filtered, so that the rules cannot bring the
+// links down. This can only be called before the dataplane is running.
+func (d *DataPlane) AddFilterRule(rule FilterRule) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.IsRunning() {
+		return modifyExisting
+	}
+	if rule.Action > FilterRateLimit {
+		return serrors.New("invalid filter action", "action", rule.Action)
+	}
+	if rule.Action == FilterRateLimit && rule.Rate <= 0 {
+		return serrors.New("rate limit filter rule without rate", "rule", rule.Name)
+	}
+	for _, r := range []PortRange{rule.SrcPorts, rule.DstPorts} {
+		if r.Min > r.Max {
+			return serrors.New("invalid port range", "rule", rule.Name, "min", r.Min,
+				"max", r.Max)
+		}
+	}
+	if rule.SrcPrefix.IsValid() {
+		rule.SrcPrefix = rule.SrcPrefix.Masked()
+	}
+	if rule.DstPrefix.IsValid() {
+		rule.DstPrefix = rule.DstPrefix.Masked()
+	}
+	r := filterRule{FilterRule: rule}
+	if rule.Action == FilterRateLimit {
+		r.limiter = newEgressRateLimiter(rule.Rate, rule.Burst)
+	}
+	if d.Metrics != nil {
+		r.matched = d.Metrics.FilterMatchedPackets.With(prometheus.Labels{
+			"isd_as": d.localIA.String(),
+			"rule":   rule.Name,
+			"action": rule.Action.String(),
+		})
+		r.matched.Add(0)
+	}
+	if d.filter == nil {
+		d.filter = &packetFilter{}
+	}
+	d.filter.rules = append(d.filter.rules, r)
+	return nil
+}
+
+// SetFilterDefaultAction sets what is done with the packets that match no filter rule. It is
+// FilterPermit by default. This can only be called before the dataplane is running.
+func (d *DataPlane) SetFilterDefaultAction(action FilterAction) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.IsRunning() {
+		return modifyExisting
+	}
+	if action != FilterPermit && action != FilterDeny {
+		return serrors.New("invalid default filter action", "action", action)
+	}
+	if d.filter == nil {
+		d.filter = &packetFilter{}
+	}
+	d.filter.defaultAction = action
+	return nil
+}
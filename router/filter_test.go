@@ -0,0 +1,187 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"testing"
+
+	"github.com/gopacket/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/pkg/slayers/path"
+	"github.com/scionproto/scion/pkg/slayers/path/epic"
+	"github.com/scionproto/scion/pkg/slayers/path/scion"
+)
+
+func TestPacketFilter(t *testing.T) {
+	// packet returns a SCION/UDP packet received through interface 1.
+	packet := func(src, dst string, srcPort, dstPort uint16) filteredPacket {
+		s := &slayers.SCION{
+			PathType: scion.PathType,
+			SrcIA:    addr.MustParseIA("1-ff00:0:111"),
+			DstIA:    addr.MustParseIA("2-ff00:0:222"),
+		}
+		require.NoError(t, s.SetSrcAddr(addr.HostIP(netip.MustParseAddr(src))))
+		require.NoError(t, s.SetDstAddr(addr.HostIP(netip.MustParseAddr(dst))))
+		udp := make([]byte, 8)
+		binary.BigEndian.PutUint16(udp, srcPort)
+		binary.BigEndian.PutUint16(udp[2:], dstPort)
+		return filteredPacket{
+			ingress: 1,
+			scion:   s,
+			l4:      slayers.LayerTypeSCIONUDP,
+			payload: udp,
+			size:    1000,
+		}
+	}
+	newFilter := func(t *testing.T, defaultAction FilterAction, rules ...FilterRule) *packetFilter {
+		var d DataPlane
+		for _, r := range rules {
+			require.NoError(t, d.AddFilterRule(r))
+		}
+		require.NoError(t, d.SetFilterDefaultAction(defaultAction))
+		return d.filter
+	}
+
+	testCases := map[string]struct {
+		rule     FilterRule
+		packet   filteredPacket
+		expected bool
+	}{
+		"source ISD-AS": {
+			rule:   FilterRule{SrcIA: addr.MustParseIA("1-ff00:0:111")},
+			packet: packet("10.0.0.1", "10.1.0.1", 1000, 53),
+		},
+		"wildcard destination ISD": {
+			rule:   FilterRule{DstIA: addr.MustParseIA("2-0")},
+			packet: packet("10.0.0.1", "10.1.0.1", 1000, 53),
+		},
+		"other destination AS": {
+			rule:     FilterRule{DstIA: addr.MustParseIA("0-ff00:0:223")},
+			packet:   packet("10.0.0.1", "10.1.0.1", 1000, 53),
+			expected: true,
+		},
+		"source prefix": {
+			rule:   FilterRule{SrcPrefix: netip.MustParsePrefix("10.0.0.0/24")},
+			packet: packet("10.0.0.1", "10.1.0.1", 1000, 53),
+		},
+		"other source prefix": {
+			rule:     FilterRule{SrcPrefix: netip.MustParsePrefix("10.0.1.0/24")},
+			packet:   packet("10.0.0.1", "10.1.0.1", 1000, 53),
+			expected: true,
+		},
+		"destination prefix, IPv6": {
+			rule:   FilterRule{DstPrefix: netip.MustParsePrefix("2001:db8::/32")},
+			packet: packet("10.0.0.1", "2001:db8::1", 1000, 53),
+		},
+		"destination port range": {
+			rule:   FilterRule{DstPorts: PortRange{Min: 50, Max: 60}},
+			packet: packet("10.0.0.1", "10.1.0.1", 1000, 53),
+		},
+		"other source port": {
+			rule:     FilterRule{SrcPorts: PortRange{Min: 53, Max: 53}},
+			packet:   packet("10.0.0.1", "10.1.0.1", 1000, 53),
+			expected: true,
+		},
+		"path type": {
+			rule:   FilterRule{PathTypes: []path.Type{epic.PathType, scion.PathType}},
+			packet: packet("10.0.0.1", "10.1.0.1", 1000, 53),
+		},
+		"other interface": {
+			rule:     FilterRule{Interfaces: []uint16{0, 2}},
+			packet:   packet("10.0.0.1", "10.1.0.1", 1000, 53),
+			expected: true,
+		},
+		"all fields": {
+			rule: FilterRule{
+				Interfaces: []uint16{1},
+				SrcIA:      addr.MustParseIA("1-ff00:0:111"),
+				DstIA:      addr.MustParseIA("2-ff00:0:222"),
+				SrcPrefix:  netip.MustParsePrefix("10.0.0.1/32"),
+				DstPrefix:  netip.MustParsePrefix("10.1.0.0/16"),
+				SrcPorts:   PortRange{Min: 1000, Max: 1000},
+				DstPorts:   PortRange{Min: 53, Max: 53},
+				PathTypes:  []path.Type{scion.PathType},
+			},
+			packet: packet("10.0.0.1", "10.1.0.1", 1000, 53),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tc.rule.Action = FilterDeny
+			f := newFilter(t, FilterPermit, tc.rule)
+			assert.Equal(t, tc.expected, f.permit(tc.packet))
+		})
+	}
+
+	t.Run("ports do not match other protocols", func(t *testing.T) {
+		f := newFilter(t, FilterPermit,
+			FilterRule{Action: FilterDeny, DstPorts: PortRange{Min: 53, Max: 53}})
+		p := packet("10.0.0.1", "10.1.0.1", 1000, 53)
+		p.l4 = slayers.LayerTypeSCMP
+		assert.True(t, f.permit(p))
+		p.l4 = gopacket.LayerTypePayload
+		assert.True(t, f.permit(p))
+	})
+	t.Run("first matching rule applies", func(t *testing.T) {
+		f := newFilter(t, FilterDeny,
+			FilterRule{Action: FilterPermit, DstPorts: PortRange{Min: 53, Max: 53}},
+			FilterRule{Action: FilterDeny, SrcIA: addr.MustParseIA("1-0")},
+		)
+		assert.True(t, f.permit(packet("10.0.0.1", "10.1.0.1", 1000, 53)))
+		assert.False(t, f.permit(packet("10.0.0.1", "10.1.0.1", 1000, 443)))
+	})
+	t.Run("default action", func(t *testing.T) {
+		f := newFilter(t, FilterDeny,
+			FilterRule{Action: FilterPermit, DstPorts: PortRange{Min: 53, Max: 53}})
+		assert.False(t, f.permit(packet("10.0.0.1", "10.1.0.1", 1000, 443)))
+	})
+	t.Run("rate limit", func(t *testing.T) {
+		// 10 packets of 1000 bytes per second, with a burst of 10 packets.
+		f := newFilter(t, FilterPermit,
+			FilterRule{Action: FilterRateLimit, Rate: 80_000, Burst: 10_000})
+		permitted := 0
+		for i := 0; i < 20; i++ {
+			if f.permit(packet("10.0.0.1", "10.1.0.1", 1000, 53)) {
+				permitted++
+			}
+		}
+		assert.Equal(t, 10, permitted)
+	})
+}
+
+func TestAddFilterRule(t *testing.T) {
+	testCases := map[string]FilterRule{
+		"unknown action":      {Action: FilterRateLimit + 1},
+		"rate limit no rate":  {Action: FilterRateLimit},
+		"invalid port range":  {Action: FilterDeny, SrcPorts: PortRange{Min: 10, Max: 1}},
+		"negative rate limit": {Action: FilterRateLimit, Rate: -1},
+	}
+	for name, rule := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var d DataPlane
+			assert.Error(t, d.AddFilterRule(rule))
+		})
+	}
+	t.Run("default action", func(t *testing.T) {
+		var d DataPlane
+		assert.Error(t, d.SetFilterDefaultAction(FilterRateLimit))
+	})
+}
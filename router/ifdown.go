@@ -0,0 +1,84 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// interfaceStateChanged is called when a BFD session of the external interface goes up, or goes
+// down from up. If the interface is down, the control services of the local AS are notified
+// immediately, and then repeatedly until it is up again, so that they stop using the interface
+// without waiting for the path segments to expire.
+func (d *DataPlane) interfaceStateChanged(ifID uint16) {
+	link := d.tables.Load().interfaces[ifID]
+	if link == nil {
+		return
+	}
+	d.mtx.Lock()
+	_, wasDown := d.downInterfaces[ifID]
+	if link.IsUp() {
+		delete(d.downInterfaces, ifID)
+	} else {
+		if d.downInterfaces == nil {
+			d.downInterfaces = make(map[uint16]struct{})
+		}
+		d.downInterfaces[ifID] = struct{}{}
+	}
+	d.mtx.Unlock()
+
+	switch {
+	case link.IsUp() && wasDown:
+		log.Info("External interface is up again", "interface", ifID)
+	case !link.IsUp() && !wasDown:
+		log.Info("External interface is down, notifying control services", "interface", ifID)
+		d.notifyInterfacesDown(func(id uint16, l Link) bool { return id == ifID && !l.IsUp() })
+	}
+}
+
+// runInterfaceDownNotification notifies the control services of the local AS about the external
+// interfaces that are down until the context is done. The notifications are repeated because the
+// revocations derived from them expire.
+func (d *DataPlane) runInterfaceDownNotification(ctx context.Context) {
+	ticker := time.NewTicker(downNotifyInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if d.drain.Load() != nil {
+			// The draining router notifies that all the interfaces are down.
+			continue
+		}
+		d.mtx.Lock()
+		down := make(map[uint16]struct{}, len(d.downInterfaces))
+		for ifID := range d.downInterfaces {
+			down[ifID] = struct{}{}
+		}
+		d.mtx.Unlock()
+		if len(down) == 0 {
+			continue
+		}
+		d.notifyInterfacesDown(func(ifID uint16, link Link) bool {
+			_, ok := down[ifID]
+			return ok && !link.IsUp()
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"hash"
+	"net/netip"
+	"testing"
+
+	"github.com/gopacket/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/scrypto"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+func TestInterfaceStateChanged(t *testing.T) {
+	local := addr.MustParseIA("1-ff00:0:110")
+	cs := netip.MustParseAddrPort("10.0.200.100:30254")
+	internal := &capturingLink{}
+	link := &testLink{}
+	d := &DataPlane{
+		localIA:    local,
+		internalIP: netip.MustParseAddr("10.0.200.1"),
+		svc:        newServices(),
+		interfaces: map[uint16]Link{
+			0: internal,
+			1: link,
+			2: &testLink{down: true},
+		},
+		neighborIAs: map[uint16]addr.IA{
+			1: addr.MustParseIA("1-ff00:0:111"),
+			2: addr.MustParseIA("1-ff00:0:112"),
+		},
+		macFactory: func() hash.Hash {
+			mac, err := scrypto.InitMac(testKey)
+			require.NoError(t, err)
+			return mac
+		},
+		packetPool: make(chan *Packet, 8),
+	}
+	for i := 0; i < cap(d.packetPool); i++ {
+		d.packetPool <- new(Packet).init(&[bufSize]byte{})
+	}
+	d.svc.AddSvc(addr.SvcCS, cs)
+	d.publishTables()
+
+	notified := func() []uint64 {
+		var ifIDs []uint64
+		for _, p := range internal.sent() {
+			var s slayers.SCION
+			var scmp slayers.SCMP
+			var msg slayers.SCMPExternalInterfaceDown
+			require.NoError(t, s.DecodeFromBytes(p.raw, gopacket.NilDecodeFeedback))
+			require.NoError(t, scmp.DecodeFromBytes(s.Payload, gopacket.NilDecodeFeedback))
+			require.NoError(t, msg.DecodeFromBytes(scmp.Payload, gopacket.NilDecodeFeedback))
+			assert.Equal(t, slayers.SCMPTypeExternalInterfaceDown, scmp.TypeCode.Type())
+			assert.Equal(t, local, msg.IA)
+			assert.Equal(t, cs, p.dst)
+			ifIDs = append(ifIDs, msg.IfID)
+		}
+		return ifIDs
+	}
+
+	// Only the interface that went down is notified, once.
+	link.down = true
+	d.interfaceStateChanged(1)
+	d.interfaceStateChanged(1)
+	assert.Equal(t, []uint64{1}, notified())
+	assert.Contains(t, d.downInterfaces, uint16(1))
+
+	link.down = false
+	d.interfaceStateChanged(1)
+	assert.Equal(t, []uint64{1}, notified())
+	assert.Empty(t, d.downInterfaces)
+}
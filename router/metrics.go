@@ -37,12 +37,15 @@ type Metrics struct {
 	BFDInterfaceStateChanges  *prometheus.CounterVec
 	BFDPacketsSent            *prometheus.CounterVec
 	BFDPacketsReceived        *prometheus.CounterVec
+	BFDRoundTripTime          *prometheus.GaugeVec
 	ServiceInstanceCount      *prometheus.GaugeVec
 	ServiceInstanceChanges    *prometheus.CounterVec
 	SiblingReachable          *prometheus.GaugeVec
 	SiblingBFDPacketsSent     *prometheus.CounterVec
 	SiblingBFDPacketsReceived *prometheus.CounterVec
 	SiblingBFDStateChanges    *prometheus.CounterVec
+	FilterMatchedPackets      *prometheus.CounterVec
+	FabridPackets             *prometheus.CounterVec
 }
 
 // NewMetrics initializes the metrics for the Border Router, and registers them with the default
@@ -119,6 +122,13 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"interface", "isd_as", "neighbor_isd_as"},
 		),
+		BFDRoundTripTime: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "router_bfd_rtt_seconds",
+				Help: "Smoothed round trip time to the neighbor router measured by BFD.",
+			},
+			[]string{"interface", "isd_as", "neighbor_isd_as"},
+		),
 		ServiceInstanceCount: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "router_service_instance_count",
@@ -163,6 +173,20 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"sibling", "isd_as"},
 		),
+		FilterMatchedPackets: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "router_filter_matched_pkts_total",
+				Help: "Total number of received packets that matched a filter rule.",
+			},
+			[]string{"isd_as", "rule", "action"},
+		),
+		FabridPackets: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "router_fabrid_pkts_total",
+				Help: "Total number of packets forwarded according to a FABRID policy.",
+			},
+			[]string{"isd_as", "policy_id"},
+		),
 	}
 }
 
@@ -244,6 +268,102 @@ func (sc sizeClass) String() string {
 	return strings.Join([]string{low, high}, "_")
 }
 
+// dropReason is the reason why the router dropped a packet. It is the value of the reason label
+// of the dropped packets metric. Do not change this type's length without checking the effect it
+// has on the size of router.Packet.
+type dropReason uint8
+
+const (
+	// dropInvalid is for the invalid packets that do not fit any of the more specific reasons.
+	dropInvalid dropReason = iota
+	// dropParseError is for the packets whose headers cannot be parsed.
+	dropParseError
+	// dropUnsupportedPath is for the packets with a path type that the router does not support.
+	dropUnsupportedPath
+	// dropInvalidPath is for the packets with a malformed path, or a path that is not valid
+	// through this router.
+	dropInvalidPath
+	// dropBadMAC is for the packets whose hop field MAC, or EPIC hop validation field, does not
+	// verify.
+	dropBadMAC
+	// dropExpiredHop is for the packets with an expired hop field, or EPIC timestamp.
+	dropExpiredHop
+	// dropUnknownInterface is for the packets whose hop field does not match a known interface.
+	dropUnknownInterface
+	// dropInterfaceDown is for the packets that would leave through an interface that is down.
+	dropInterfaceDown
+	// dropInvalidAddress is for the packets with an invalid source or destination.
+	dropInvalidAddress
+	// dropNoRoute is for the packets for which there is no destination to deliver them to.
+	dropNoRoute
+	// dropBadPacketSize is for the packets whose length does not match their header.
+	dropBadPacketSize
+	// dropNoBFDSession is for the BFD packets that do not belong to any session.
+	dropNoBFDSession
+	// dropSendError is for the packets that could not be written to their egress connection.
+	dropSendError
+	dropBusyProcessor
+	dropBusyForwarder
+	dropBusySlowPath
+	dropRateLimited
+	dropSCMPRateLimited
+	dropTooBig
+	// dropFiltered is for the packets denied or rate limited by the packet filter.
+	dropFiltered
+	dropSpoofed
+	// dropEPICHPRequired is for the packets between a pair of interfaces that is protected
+	// with EPIC-HP, that are not EPIC-HP packets.
+	dropEPICHPRequired
+	// dropFabrid is for the packets that request a policy with the FABRID option which is not
+	// supported between their interfaces, or whose FABRID option is malformed or does not verify.
+	dropFabrid
+	// dropDraining is for the beacons and other one-hop path packets that are not forwarded
+	// while the router is draining.
+	dropDraining
+	// dropExtension is for the packets that an extension handler decided to drop.
+	dropExtension
+	// dropDuplicate is for the packets that were already received within the replay window.
+	dropDuplicate
+	dropReasonMax
+)
+
+var dropReasonNames = [dropReasonMax]string{
+	dropInvalid:          "invalid",
+	dropParseError:       "parse_error",
+	dropUnsupportedPath:  "unsupported_path",
+	dropInvalidPath:      "invalid_path",
+	dropBadMAC:           "bad_mac",
+	dropExpiredHop:       "expired_hop",
+	dropUnknownInterface: "unknown_interface",
+	dropInterfaceDown:    "interface_down",
+	dropInvalidAddress:   "invalid_address",
+	dropNoRoute:          "no_route",
+	dropBadPacketSize:    "bad_packet_size",
+	dropNoBFDSession:     "no_bfd_session",
+	dropSendError:        "send_error",
+	dropBusyProcessor:    "busy_processor",
+	dropBusyForwarder:    "busy_forwarder",
+	dropBusySlowPath:     "busy_slow_path",
+	dropRateLimited:      "rate_limited",
+	dropSCMPRateLimited:  "scmp_rate_limited",
+	dropTooBig:           "packet_too_big",
+	dropFiltered:         "filtered",
+	dropSpoofed:          "spoofed",
+	dropEPICHPRequired:   "epic_hp_required",
+	dropFabrid:           "fabrid",
+	dropDraining:         "draining",
+	dropExtension:        "extension",
+	dropDuplicate:        "duplicate",
+}
+
+// Returns the value of the reason label for the given drop reason.
+func (r dropReason) String() string {
+	if r >= dropReasonMax {
+		return dropReasonNames[dropInvalid]
+	}
+	return dropReasonNames[r]
+}
+
 // interfaceMetrics is the set of metrics that are relevant for one given interface. It is a map
 // that associates each (traffic-type, size-class) pair with the set of metrics belonging to that
 // interface that have these label values. This set of metrics is itself a trafficMetric structure.
@@ -260,14 +380,12 @@ type interfaceMetrics map[sizeClass]trafficMetrics
 // trafficMetrics groups all the metrics instances that all share the same interface AND
 // sizeClass label values (but have different names - i.e. they count different things).
 type trafficMetrics struct {
-	InputBytesTotal             prometheus.Counter
-	InputPacketsTotal           prometheus.Counter
-	DroppedPacketsInvalid       prometheus.Counter
-	DroppedPacketsBusyProcessor prometheus.Counter
-	DroppedPacketsBusyForwarder prometheus.Counter
-	DroppedPacketsBusySlowPath  prometheus.Counter
-	ProcessedPackets            prometheus.Counter
-	Output                      [ttMax]outputMetrics
+	InputBytesTotal   prometheus.Counter
+	InputPacketsTotal prometheus.Counter
+	// DroppedPackets is indexed by drop reason.
+	DroppedPackets   [dropReasonMax]prometheus.Counter
+	ProcessedPackets prometheus.Counter
+	Output           [ttMax]outputMetrics
 }
 
 // outputMetrics groups all the metrics about traffic that has reached the output stage. Metrics
@@ -312,30 +430,14 @@ func newTrafficMetrics(
 	}
 
 	// Dropped metrics have the extra "Reason" label.
-	reasonMap := map[string]string{}
-
-	reasonMap["reason"] = "invalid"
-	c.DroppedPacketsInvalid =
-		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
-
-	reasonMap["reason"] = "busy_processor"
-	c.DroppedPacketsBusyProcessor =
-		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
-
-	reasonMap["reason"] = "busy_forwarder"
-	c.DroppedPacketsBusyForwarder =
-		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
-
-	reasonMap["reason"] = "busy_slow_path"
-	c.DroppedPacketsBusySlowPath =
-		metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels).With(reasonMap)
+	dropped := metrics.DroppedPacketsTotal.MustCurryWith(ifLabels).MustCurryWith(scLabels)
+	for r := dropInvalid; r < dropReasonMax; r++ {
+		c.DroppedPackets[r] = dropped.With(prometheus.Labels{"reason": r.String()})
+		c.DroppedPackets[r].Add(0)
+	}
 
 	c.InputBytesTotal.Add(0)
 	c.InputPacketsTotal.Add(0)
-	c.DroppedPacketsInvalid.Add(0)
-	c.DroppedPacketsBusyProcessor.Add(0)
-	c.DroppedPacketsBusyForwarder.Add(0)
-	c.DroppedPacketsBusySlowPath.Add(0)
 	c.ProcessedPackets.Add(0)
 	return c
 }
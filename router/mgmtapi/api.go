@@ -17,9 +17,16 @@ package mgmtapi
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"math"
 	"net/http"
+	"path/filepath"
+	"time"
 
 	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/private/util"
 	api "github.com/scionproto/scion/private/mgmtapi"
 	"github.com/scionproto/scion/router/control"
 )
@@ -30,6 +37,16 @@ type Server struct {
 	Info      http.HandlerFunc
 	LogLevel  http.HandlerFunc
 	Dataplane control.ObservableDataplane
+	Mirror    control.PacketMirror
+	Drainer   control.Drainer
+	DropTrace control.DropTracer
+	// AdminAuth authorizes the administrative requests, i.e. the requests for the resources that
+	// expose the contents of packets, the drop trace and packet mirroring, and the drain requests.
+	// If it is nil, these requests are not available.
+	AdminAuth func(http.Handler) http.Handler
+	// MirrorDir is the directory of the pcap files and sockets of the mirroring requests. If it
+	// is empty, mirroring cannot be started.
+	MirrorDir string
 }
 
 // GetConfig is an indirection to the http handler.
@@ -113,6 +130,9 @@ func (s *Server) GetInterfaces(w http.ResponseWriter, r *http.Request) {
 			ScionMtu:     intf.Link.MTU,
 			State:        LinkState(intf.State),
 		}
+		if intf.RTT != 0 {
+			newInterface.Rtt = api.StringRef(intf.RTT.Round(time.Microsecond).String())
+		}
 
 		intfs = append(intfs, newInterface)
 	}
@@ -149,6 +169,299 @@ func (s *Server) GetInterfaces(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetMirror gets the state of packet mirroring.
+func (s *Server) GetMirror(w http.ResponseWriter, r *http.Request) {
+	writeMirrorStatus(w, s.Mirror.MirrorStatus())
+}
+
+// StartMirror starts mirroring forwarded packets.
+func (s *Server) StartMirror(w http.ResponseWriter, r *http.Request) {
+	s.adminOnly(w, r, "mirroring", s.startMirror)
+}
+
+func (s *Server) startMirror(w http.ResponseWriter, r *http.Request) {
+	var req MirrorConfig
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "error decoding body",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	cfg, err := s.mirrorConfigFromAPI(req)
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "invalid mirror configuration",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	if err := s.Mirror.StartMirror(cfg); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "error starting packet mirroring",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	writeMirrorStatus(w, s.Mirror.MirrorStatus())
+}
+
+// StopMirror stops mirroring forwarded packets.
+func (s *Server) StopMirror(w http.ResponseWriter, r *http.Request) {
+	s.adminOnly(w, r, "mirroring", s.stopMirror)
+}
+
+func (s *Server) stopMirror(w http.ResponseWriter, r *http.Request) {
+	if err := s.Mirror.StopMirror(); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusNotFound,
+			Title:  "error stopping packet mirroring",
+			Type:   api.StringRef(api.NotFound),
+		})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) mirrorConfigFromAPI(req MirrorConfig) (control.MirrorConfig, error) {
+	var cfg control.MirrorConfig
+	if req.SampleRate != nil {
+		if *req.SampleRate < 0 || int64(*req.SampleRate) > math.MaxUint32 {
+			return cfg, serrors.New("invalid sample rate", "sample_rate", *req.SampleRate)
+		}
+		cfg.SampleRate = uint32(*req.SampleRate)
+	}
+	if req.Interfaces != nil {
+		for _, ifID := range *req.Interfaces {
+			if ifID < 0 || ifID > math.MaxUint16 {
+				return cfg, serrors.New("invalid interface", "interface", ifID)
+			}
+			cfg.Interfaces = append(cfg.Interfaces, uint16(ifID))
+		}
+	}
+	var err error
+	if req.SrcIsdAs != nil {
+		if cfg.SrcIA, err = addr.ParseIA(*req.SrcIsdAs); err != nil {
+			return cfg, err
+		}
+	}
+	if req.DstIsdAs != nil {
+		if cfg.DstIA, err = addr.ParseIA(*req.DstIsdAs); err != nil {
+			return cfg, err
+		}
+	}
+	if req.MaxPackets != nil {
+		if *req.MaxPackets < 0 {
+			return cfg, serrors.New("invalid max packets", "max_packets", *req.MaxPackets)
+		}
+		cfg.MaxPackets = uint64(*req.MaxPackets)
+	}
+	if req.PcapFile != nil {
+		if cfg.PcapFile, err = s.mirrorPath(*req.PcapFile); err != nil {
+			return cfg, err
+		}
+	}
+	if req.Socket != nil {
+		if cfg.Socket, err = s.mirrorPath(*req.Socket); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// mirrorPath resolves the path of a mirroring request within the mirroring directory.
+func (s *Server) mirrorPath(p string) (string, error) {
+	if s.MirrorDir == "" {
+		return "", serrors.New("no directory is configured for the mirroring requests")
+	}
+	if !filepath.IsLocal(p) {
+		return "", serrors.New("path must be relative and stay within the mirroring directory",
+			"path", p)
+	}
+	return filepath.Join(s.MirrorDir, p), nil
+}
+
+func writeMirrorStatus(w http.ResponseWriter, status control.MirrorStatus) {
+	rep := MirrorStatus{
+		Active:   status.Active,
+		Mirrored: int(status.Mirrored),
+		Dropped:  int(status.Dropped),
+	}
+	if c := status.Config; c != nil {
+		sampleRate := int(c.SampleRate)
+		maxPackets := int(c.MaxPackets)
+		cfg := MirrorConfig{
+			SampleRate: &sampleRate,
+			MaxPackets: &maxPackets,
+		}
+		if len(c.Interfaces) != 0 {
+			intfs := make([]int, 0, len(c.Interfaces))
+			for _, ifID := range c.Interfaces {
+				intfs = append(intfs, int(ifID))
+			}
+			cfg.Interfaces = &intfs
+		}
+		if !c.SrcIA.IsZero() {
+			cfg.SrcIsdAs = api.StringRef(c.SrcIA.String())
+		}
+		if !c.DstIA.IsZero() {
+			cfg.DstIsdAs = api.StringRef(c.DstIA.String())
+		}
+		if c.PcapFile != "" {
+			cfg.PcapFile = api.StringRef(c.PcapFile)
+		}
+		if c.Socket != "" {
+			cfg.Socket = api.StringRef(c.Socket)
+		}
+		rep.Config = &cfg
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// GetDrain gets the state of the draining of the router.
+func (s *Server) GetDrain(w http.ResponseWriter, r *http.Request) {
+	writeDrainStatus(w, s.Drainer.DrainStatus())
+}
+
+// Drain puts the router into drain mode.
+func (s *Server) Drain(w http.ResponseWriter, r *http.Request) {
+	s.adminOnly(w, r, "draining", s.drain)
+}
+
+func (s *Server) drain(w http.ResponseWriter, r *http.Request) {
+	var req DrainRequest
+	// The body is optional.
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "error decoding body",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	var grace time.Duration
+	if req.GracePeriod != nil {
+		var err error
+		if grace, err = util.ParseDuration(*req.GracePeriod); err != nil {
+			ErrorResponse(w, Problem{
+				Detail: api.StringRef(err.Error()),
+				Status: http.StatusBadRequest,
+				Title:  "invalid grace period",
+				Type:   api.StringRef(api.BadRequest),
+			})
+			return
+		}
+	}
+	if err := s.Drainer.Drain(grace); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "error draining router",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	writeDrainStatus(w, s.Drainer.DrainStatus())
+}
+
+func writeDrainStatus(w http.ResponseWriter, status control.DrainStatus) {
+	rep := DrainStatus{Draining: status.Draining}
+	if !status.Deadline.IsZero() {
+		deadline := status.Deadline.UTC()
+		rep.Deadline = &deadline
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+// GetDrops lists the last packets dropped by the router.
+func (s *Server) GetDrops(w http.ResponseWriter, r *http.Request) {
+	s.adminOnly(w, r, "drop trace", s.getDrops)
+}
+
+// adminOnly serves the request with handler if it is authorized by AdminAuth. If no AdminAuth is
+// configured, the resource is reported as not available.
+func (s *Server) adminOnly(w http.ResponseWriter, r *http.Request, resource string,
+	handler http.HandlerFunc) {
+
+	if s.AdminAuth == nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef("no authorization is configured for the " + resource),
+			Status: http.StatusNotFound,
+			Title:  resource + " not available",
+			Type:   api.StringRef(api.NotFound),
+		})
+		return
+	}
+	s.AdminAuth(handler).ServeHTTP(w, r)
+}
+
+func (s *Server) getDrops(w http.ResponseWriter, r *http.Request) {
+	pkts, err := s.DropTrace.DroppedPackets()
+	if err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusNotFound,
+			Title:  "drop trace not available",
+			Type:   api.StringRef(api.NotFound),
+		})
+		return
+	}
+	rep := DroppedPackets{Packets: make([]DroppedPacket, 0, len(pkts))}
+	for _, p := range pkts {
+		pkt := DroppedPacket{
+			Time:             p.Time.UTC(),
+			Reason:           p.Reason,
+			IngressInterface: int(p.Ingress),
+			EgressInterface:  int(p.Egress),
+			Length:           p.Length,
+			Header:           p.Header,
+		}
+		if p.Src.IsValid() {
+			pkt.UnderlaySource = api.StringRef(p.Src.String())
+		}
+		rep.Packets = append(rep.Packets, pkt)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		ErrorResponse(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
 // Error creates an detailed error response.
 func ErrorResponse(w http.ResponseWriter, p Problem) {
 	w.Header().Set("Content-Type", "application/problem+json")
@@ -19,6 +19,7 @@ import (
 	"net/http/httptest"
 	"net/netip"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/scionproto/scion/pkg/private/ptr"
 	"github.com/scionproto/scion/pkg/private/serrors"
 	"github.com/scionproto/scion/pkg/private/xtest"
+	"github.com/scionproto/scion/private/mgmtapi/jwtauth"
 	"github.com/scionproto/scion/private/topology"
 	"github.com/scionproto/scion/router/control"
 	"github.com/scionproto/scion/router/control/mock_api"
@@ -121,6 +123,127 @@ func TestAPI(t *testing.T) {
 			ResponseFile: "testdata/interfaces-sibling-error.json",
 			Status:       500,
 		},
+		"mirror": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				mirror := mock_api.NewMockPacketMirror(ctrl)
+				s := &Server{
+					Mirror: mirror,
+				}
+				mirror.EXPECT().MirrorStatus().Return(control.MirrorStatus{
+					Active: true,
+					Config: &control.MirrorConfig{
+						SampleRate: 100,
+						Interfaces: []uint16{1, 2},
+						SrcIA:      addr.MustParseIA("1-ff00:0:111"),
+						PcapFile:   "/var/tmp/br1-mirror.pcap",
+					},
+					Mirrored: 1234,
+					Dropped:  5,
+				})
+				return Handler(s)
+			},
+			RequestURL:   "/mirror",
+			ResponseFile: "testdata/mirror.json",
+			Status:       200,
+		},
+		"mirror inactive": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				mirror := mock_api.NewMockPacketMirror(ctrl)
+				s := &Server{
+					Mirror: mirror,
+				}
+				mirror.EXPECT().MirrorStatus().Return(control.MirrorStatus{})
+				return Handler(s)
+			},
+			RequestURL:   "/mirror",
+			ResponseFile: "testdata/mirror-inactive.json",
+			Status:       200,
+		},
+		"drain": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				drainer := mock_api.NewMockDrainer(ctrl)
+				s := &Server{
+					Drainer: drainer,
+				}
+				drainer.EXPECT().DrainStatus().Return(control.DrainStatus{
+					Draining: true,
+					Deadline: time.Date(2026, 10, 14, 12, 0, 30, 0, time.UTC),
+				})
+				return Handler(s)
+			},
+			RequestURL:   "/drain",
+			ResponseFile: "testdata/drain.json",
+			Status:       200,
+		},
+		"drain inactive": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				drainer := mock_api.NewMockDrainer(ctrl)
+				s := &Server{
+					Drainer: drainer,
+				}
+				drainer.EXPECT().DrainStatus().Return(control.DrainStatus{})
+				return Handler(s)
+			},
+			RequestURL:   "/drain",
+			ResponseFile: "testdata/drain-inactive.json",
+			Status:       200,
+		},
+		"drops": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				tracer := mock_api.NewMockDropTracer(ctrl)
+				s := &Server{
+					DropTrace: tracer,
+					AdminAuth: func(h http.Handler) http.Handler { return h },
+				}
+				tracer.EXPECT().DroppedPackets().Return([]control.DroppedPacket{
+					{
+						Time:    time.Date(2026, 10, 14, 12, 0, 1, 0, time.UTC),
+						Reason:  "bad_mac",
+						Ingress: 1,
+						Egress:  2,
+						Src:     netip.MustParseAddrPort("192.0.2.1:50000"),
+						Length:  1200,
+						Header:  []byte{0, 0, 0, 1},
+					},
+					{
+						Time:   time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC),
+						Reason: "busy_forwarder",
+						Length: 100,
+						Header: []byte{0, 0, 0, 2},
+					},
+				}, nil)
+				return Handler(s)
+			},
+			RequestURL:   "/drops",
+			ResponseFile: "testdata/drops.json",
+			Status:       200,
+		},
+		"drops disabled": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				tracer := mock_api.NewMockDropTracer(ctrl)
+				s := &Server{
+					DropTrace: tracer,
+					AdminAuth: func(h http.Handler) http.Handler { return h },
+				}
+				tracer.EXPECT().DroppedPackets().Return(nil,
+					serrors.New("drop trace is not enabled"))
+				return Handler(s)
+			},
+			RequestURL:         "/drops",
+			Status:             404,
+			IgnoreResponseBody: true,
+		},
+		"drops without authorization": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				s := &Server{
+					DropTrace: mock_api.NewMockDropTracer(ctrl),
+				}
+				return Handler(s)
+			},
+			RequestURL:         "/drops",
+			Status:             404,
+			IgnoreResponseBody: true,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -151,6 +274,224 @@ func TestAPI(t *testing.T) {
 	}
 }
 
+func TestStartMirror(t *testing.T) {
+	passthrough := func(h http.Handler) http.Handler { return h }
+	testCases := map[string]struct {
+		Body      string
+		AdminAuth func(http.Handler) http.Handler
+		MirrorDir string
+		Expected  *control.MirrorConfig
+		Status    int
+	}{
+		"valid": {
+			Body: `{"sample_rate": 10, "interfaces": [1], "dst_isd_as": "1-ff00:0:110",
+				"max_packets": 100, "socket": "br1-mirror.sock"}`,
+			AdminAuth: passthrough,
+			MirrorDir: "/run/scion",
+			Expected: &control.MirrorConfig{
+				SampleRate: 10,
+				Interfaces: []uint16{1},
+				DstIA:      addr.MustParseIA("1-ff00:0:110"),
+				MaxPackets: 100,
+				Socket:     "/run/scion/br1-mirror.sock",
+			},
+			Status: 200,
+		},
+		"pcap file": {
+			Body:      `{"pcap_file": "captures/br1.pcap"}`,
+			AdminAuth: passthrough,
+			MirrorDir: "/var/tmp",
+			Expected: &control.MirrorConfig{
+				PcapFile: "/var/tmp/captures/br1.pcap",
+			},
+			Status: 200,
+		},
+		"absolute path": {
+			Body:      `{"pcap_file": "/etc/passwd"}`,
+			AdminAuth: passthrough,
+			MirrorDir: "/var/tmp",
+			Status:    400,
+		},
+		"path outside of directory": {
+			Body:      `{"socket": "../run/br1-mirror.sock"}`,
+			AdminAuth: passthrough,
+			MirrorDir: "/var/tmp",
+			Status:    400,
+		},
+		"no directory": {
+			Body:      `{"socket": "br1-mirror.sock"}`,
+			AdminAuth: passthrough,
+			Status:    400,
+		},
+		"no authorization": {
+			Body:      `{"socket": "br1-mirror.sock"}`,
+			MirrorDir: "/run/scion",
+			Status:    404,
+		},
+		"invalid interface": {
+			Body:      `{"interfaces": [65536], "socket": "br1-mirror.sock"}`,
+			AdminAuth: passthrough,
+			MirrorDir: "/run/scion",
+			Status:    400,
+		},
+		"invalid isd-as": {
+			Body:      `{"src_isd_as": "1-ff00:0", "socket": "br1-mirror.sock"}`,
+			AdminAuth: passthrough,
+			MirrorDir: "/run/scion",
+			Status:    400,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mirror := mock_api.NewMockPacketMirror(ctrl)
+			if tc.Expected != nil {
+				mirror.EXPECT().StartMirror(*tc.Expected).Return(nil)
+				mirror.EXPECT().MirrorStatus().Return(control.MirrorStatus{
+					Active: true,
+					Config: tc.Expected,
+				})
+			}
+			req, err := http.NewRequest("PUT", "/mirror", strings.NewReader(tc.Body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			Handler(&Server{
+				Mirror:    mirror,
+				AdminAuth: tc.AdminAuth,
+				MirrorDir: tc.MirrorDir,
+			}).ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestStopMirror(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mirror := mock_api.NewMockPacketMirror(ctrl)
+	mirror.EXPECT().StopMirror().Return(nil)
+	mirror.EXPECT().StopMirror().Return(serrors.New("no packet mirroring is active"))
+	h := Handler(&Server{
+		Mirror:    mirror,
+		AdminAuth: func(h http.Handler) http.Handler { return h },
+	})
+	for _, status := range []int{204, 404} {
+		req, err := http.NewRequest("DELETE", "/mirror", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		assert.Equal(t, status, rr.Result().StatusCode)
+	}
+
+	// Without authorization, mirroring cannot be stopped.
+	req, err := http.NewRequest("DELETE", "/mirror", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	Handler(&Server{Mirror: mirror}).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestDrain(t *testing.T) {
+	testCases := map[string]struct {
+		Body     string
+		Expected *time.Duration
+		DrainErr error
+		Unauth   bool
+		Status   int
+	}{
+		"grace period": {
+			Body:     `{"grace_period": "2m"}`,
+			Expected: ptr.To(2 * time.Minute),
+			Status:   200,
+		},
+		"default grace period": {
+			Expected: ptr.To(time.Duration(0)),
+			Status:   200,
+		},
+		"invalid grace period": {
+			Body:   `{"grace_period": "soon"}`,
+			Status: 400,
+		},
+		"already draining": {
+			Body:     `{"grace_period": "2m"}`,
+			Expected: ptr.To(2 * time.Minute),
+			DrainErr: serrors.New("router is already draining"),
+			Status:   400,
+		},
+		"no authorization": {
+			Body:   `{"grace_period": "2m"}`,
+			Unauth: true,
+			Status: 404,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			drainer := mock_api.NewMockDrainer(ctrl)
+			if tc.Expected != nil {
+				drainer.EXPECT().Drain(*tc.Expected).Return(tc.DrainErr)
+			}
+			if tc.Expected != nil && tc.DrainErr == nil {
+				drainer.EXPECT().DrainStatus().Return(control.DrainStatus{
+					Draining: true,
+					Deadline: time.Now().Add(*tc.Expected),
+				})
+			}
+			req, err := http.NewRequest("PUT", "/drain", strings.NewReader(tc.Body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			server := &Server{Drainer: drainer}
+			if !tc.Unauth {
+				server.AdminAuth = func(h http.Handler) http.Handler { return h }
+			}
+			rr := httptest.NewRecorder()
+			Handler(server).ServeHTTP(rr, req)
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestGetDropsAuthorization(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	keyFunc := func() ([]byte, error) { return key, nil }
+	verifier := &jwtauth.HTTPVerifier{Generator: keyFunc}
+	tokens := &jwtauth.JWTTokenSource{Subject: "operator", Generator: keyFunc}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tracer := mock_api.NewMockDropTracer(ctrl)
+	tracer.EXPECT().DroppedPackets().Return(nil, nil)
+	h := Handler(&Server{DropTrace: tracer, AdminAuth: verifier.AddAuthorization})
+
+	req, err := http.NewRequest("GET", "/drops", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	assert.NotEqual(t, http.StatusOK, rr.Result().StatusCode)
+
+	token, err := tokens.Token()
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token.String())
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.JSONEq(t, `{"packets": []}`, rr.Body.String())
+}
+
 func createExternalIntfs(t *testing.T) []control.ExternalInterface {
 	return []control.ExternalInterface{
 		{
@@ -175,6 +516,7 @@ func createExternalIntfs(t *testing.T) []control.ExternalInterface {
 				MTU: 1472,
 			},
 			State: control.InterfaceUp,
+			RTT:   1500*time.Microsecond + 123*time.Nanosecond,
 		},
 		{
 			IfID: 2,
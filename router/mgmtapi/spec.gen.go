@@ -18,45 +18,73 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xZ33PbNhL+VzBoH5op9cNOem305thOq5kk1lj29KH1eSBiRaIGARYAZet8+t9vFiAp",
-	"/rLs5K7tXJ9skQD2w7ffLnbBRxrrLNcKlLN09kgN2FwrC/7HO8Yv4fcCrMNfsVYOlP+X5bkUMXNCq8lv",
-	"Vit8ZuMUMob/fW1gTWf0q8l+6Ul4aydLxxRnhp8bow3d7XYR5WBjI3JcjM7QJjGlUXxbTvRw3p/hn9zo",
-	"HIwTASMHKwzw20wokRXZrXu4FcqB2TBZvm4sfpUCKQeSahRZgbsHUMQZpmwmrBVaEb0m796fEdyz0ZLk",
-	"LL4DZ4lLmSMuBYIQmNOGBPt2TK5SYcmGyQKIsITxDWK0wInTfkYOYCKS6nvYgPFPWOwKJvdAChwtLLE5",
-	"xGItgJPVljh2J1Tix2fswSPX69IqH5WbGbmHUb0MU9wPD1j02v8wkGkHntnWRAMxiA3sQfhZYxpReGBZ",
-	"LoHO6PF0mlkaUbfN8ad1RqiEes85iJHa26yQTuRSgBkmXRXZCgyCaTGZFdaRFfrElkxxiCUzQByyaSE4",
-	"g1nC9b1CjoHURveY1zoQih6r5ghLYibjQjIXiCwhbis2W/QoSLQTfmhLBnuRbAOkPj2va2JwcAIGmQHF",
-	"VhJ4n4y54mXgoOn7FFwKxgMXlpSzvAdjrdYiKQxwolWw7cGsWdy270wBNYSV1hKYQgiVq+vIKF39mVFR",
-	"zuKHwgFdtbUOMmJTXUhObJHn2rjng6KUJcYGPhKBHWjJfe3TgYq35BsxhnHUxjoKWGrgr2rkTwJGJHEM",
-	"uUO2KyRSx0yW23iR/BsU09kvB/PQE5Gyl8kBb91E1AnngbwTXJiwDJPkvTb3zHCU81kdEpVqaoUx1ZZN",
-	"uQm9+g1ihzKZV2/7qXW15s+lc0zJu4jWJm7FgOaXp/OLT3sYRHBQDhOceT6Q/CzF5K1o4uzrl3FuwFrc",
-	"cjWFdO1WqVAXrmOaHr09Hh/944fx8fh49vpoOp0OpTsFIklX2jxHSk3pp2qC14r0TrGpyJ9b4INQd5fN",
-	"8f4c9OpxxbMnLA78eHXtJznm4CXWln5gV9Mttzb230QTeZlUpjr7HPRfQ9HBQ/O9h1TDQwfV+qnhi7Zq",
-	"SyX0ZXJ9tpjMF6RQHIxk26Zk0GgHyxfoQ1h+GyqVg+qw/MT2qQ5zoxp+g6VqrxjqXU2D4rkWylW7kELd",
-	"HY5ze1mWeH3q6mXDLweZfbHW0UZplBnDtl5+YiWFSm6/YN1lmHpg+d2eoGpHRArrkKWQzPEYLSGQBoQh",
-	"crxPZo9Nj4/W6+l0Np0dHaGzc+ZQyHRG//nrr/zb0Te/sNF6Onp783gUvdnNXj0e79qPXv0bx31N9yjn",
-	"y7PRyZLM6+w3pKFe6CMoVWSokdOLy3Ma0dOf5h/OaEQXJ5fnn67wn/PzS9TLHnw1ZHD5ZZUUqnWvFzSi",
-	"Zxc/f2ov4h/3V9DJB9iA7KtHVo/bYfdBJ4n3iX8d1VY5rIrEZ4i1xse+IWgBKN8cPnfDsjcDTl0YvZKQ",
-	"DbUMjokBpCckLTKGJQ/jvjSAh1wyFc7SsiiPQ70gLNFxXBgDan+w5MFgXWSkIPN1IXEGCrIsa6pRqM4E",
-	"S2/GNyLkvlTf4+Dc6BiAj8nPRjgHeIKTc5VIYVM/q8aHdS+oRCgAYyNS2IJJuSVKO2ILgcUsjlCYVSFO",
-	"lfAVjmN3kGrJwVi/Go728SL+Bbyd9U61UmVhgaU5c2zFLBAnMqxKCzeYBJV1TA0d0yfk+nJODKwhsBZo",
-	"qqLBenJqlp9kNyIwTsZYjzPuix9G1oYlGajGYoZoQ2yxGuXMpXUDVrlnm8OYfGRb7DyKshhtOMhoXaZT",
-	"YetJIpxMVhcmBhJr3jkgJuXASVxzNvKS/srpO1Aj1PIIHTfy7I0Ce2ttMubojBZGjGpmhmjF47Www7XP",
-	"T1dXCxIGeGQkAYXdadlAYrdqRCIUsWCw9wzt0iEJt/b23fR1RMtinM6+e/s2omWRSmdH0+lQ1VamvL4C",
-	"bKoNijPLmNn24sY75q8W/RKMj8drxTZMSLQ55JDwAHe4ZoVEH7KVLtxsJZm6o9FLtF8o8XsBctsNgiYf",
-	"RCscENTnb2AeXIO3jeDAycliPiYXea4bnVUVSaxslcnl+9PR9z9Mv4+I8NlJgfC9p4FYZxkoHuausMOu",
-	"gHrCka9QYzhNWMiRo9odXMcFBl+wo7QhidQr75Kwv7o7b7n5ZcHzGSHSORbKeKmkOHQ+1IXycENcdp+t",
-	"64BCIXeKrLYOrN9YqMfK9rLsdw3kBiyWM9W+nY619Ak0LPHN4uz6VbvwlGwLxnMtbC3qxg0GszWkc/Sb",
-	"AkdytpWacTIi8wX5CRgHQ0bk+qz60WL56M33x0Ox2qu0ni4L/5Lubl61c516PdR4f3gzV9LzN2vlBoh/",
-	"sr/rdHQBSLOJK0vs+cESu8tjX2X/fff0v+6Z2pfVPcRQPW4L1o8mGVjLkucTVV33dqzvdmVp3D9FF/M6",
-	"p4atXdb9ctUQ+QekOspOFnMa0Q0YG1aYjqfjI9ygzkGxXNAZfT2ejo9Dn5P6zU3CVRL+m4C/9A9X3kKr",
-	"Oacz+iO40zAian80OJ5OO18L8Mya5JKJzneCLjG9bwHLIo7BWqyhLyrjCPtNMDGkkxrKpPHxwn9HCDUH",
-	"ndGFEVVqvrr4+KFzZ7YWMlyUscSif/Bw1Ire4BqTyiFPMTIPHcv/Fx/vmBUxwa3hSYsc5CwB4suZuuww",
-	"WvryEeXk+xNrD7DUbPdLrjpNobCuIeD9jFAZMQO9C/Dmxd0A8Y3c8wz9X/7xauAOZcBLfm963dvauOGq",
-	"J+CUddC3nweranQHsMzVhklRf1Ebd1z/pBsarm1c3HnvSp1M6lb/qUCobwn+QG/UNv60SPkRsGtoX2f0",
-	"IiCieTFAyrJDil//nebbP4WP6hKmaT+cQM4UsPtbeWn5Ei/5Kb4dxuePtDCSzmjqXD6bTB5Tbd1u9phr",
-	"43YTlovJ5ggPUGYE9jyeIxzS7v98Qeofowa06bx+PX3z5hhZuKnh9CqHDZitSxG4L7pCR9bPIxFVLKtK",
-	"6ep6tLvYqd8qnv0EHkJruNqWi5WZvLlUyczuZvefAAAA//9UPMQGEyAAAA==",
+	"H4sIAAAAAAAC/+xb3XMbN5L/V7pm9yGpHVKUrCQbVu2DbDkbXjm2Th+Vqsv6WOBMDwerGWAWwEji+fS/",
+	"XzWAwXySkhMnTq42LzFJoNHoL3T/uvUhSmRZSYHC6Gj5IVKoKyk02g8vWXqJ/6pRG/qUSGFQ2H+yqip4",
+	"wgyX4uifWgr6Tic5loz+9WeFWbSM/nTUkj5yv+qjK8NEylT6WimposfHxzhKUSeKV0QsWtKZoPyh9Kvf",
+	"aNn57pz+VylZoTLc8Zii5grTdckFL+tybR7WXBhUd6zwP3eIX+cIfiE0q2CD5h5RgFFM6JJrzaUAmcHL",
+	"786B7qxkARVLbtFoMDkzYHIEYoEZqcCdr+dwnXMNd6yoEbgGlt4RjxpTMNLuqBBVDLm8xztU9huWmJoV",
+	"LSM1reYadIUJzzimsNmBYbdcbO36kj1YzmXmT01n/jIz8zALZJhI7XLHi8zsB4WlNGgl29uoMEF+hy0T",
+	"dtc8iiN8YGVVYLSMThaLUkdxZHYVfdRGcbGNrOYMJiTadVkXhlcFRzUtdFGXG1TETE+SZa0NbEgn2ksq",
+	"xaRgCsGQNDU6ZTANqbwXJGOEcGjLcyadQEljzR6uIWFFUhfMOEF6FneNNHviEbiVhtulPTNojWTnWBqL",
+	"50UQDC3eoiLJoGCbAtOxMFYi9Y5DR9/naHJUlnGuwe+yGkykyPi2VpiCFO5sy0zGkv75RtUYWNhIWSAT",
+	"xEKj6uAZXtUf6RV+V3rIHUhVO22wBJ3LukhB11UllXnaKbxZVoiKvuJOOtgz94xugiLZwRd8jvO4z+vM",
+	"8RIY/zJwvpdh4iRJsDIk7YaTQias8Nd4lvl3RBwtfzoYh/Z4SmsmB7T1Po4MN5aRlzzlypFhBXwn1T1T",
+	"KZnzeXCJxmqChTHRNxt/Cbn5JyaGzORcMS46Ub4fXbeKJbiuUHGZThuO4SVCWpNM4D7nSe70KmuDCm4R",
+	"Kw1Zy6lRLMt4AhvMpELgBnRem8a9VxkIaUCjiS2Vjg+kxOa6yw6pkSJmX1kvFtOqamR4wRQr0aDSNowS",
+	"VVqyTzBXhplaT706LC242ONMKNImsFiOwXEcA8tIKiMxdWQwsLyTr2fHi9nx6fXxyXKxWC4W/xXFUSZV",
+	"yUy0jFJmcEYKmAzOzd1GLP7oo06HA66DLJ4RXIam35zUsVUSHD4tYllVmF5Y5xwLGbcKtV4H+50WdvjZ",
+	"vbKWFtzbMJSzO4QCM4pRStbbfA4L4BmZHddgms2CFS2VGKTya+6ZtvZ4K+S9gB2anmhOpuJ+jizd9wZq",
+	"w5Rp7MLxGcOGafz6FFAkMsU0hqQ24FMMLVgFBYqtyZtdqZIV+dDgCYjO6L///Nvfusax2ZlJu+DiF4iV",
+	"6fZByJQs5xDW9qRI8l30eDyeEpe73TQD/Zs38uIC6GK6T/tksZgir5D55HRM3v0G9/lueMHUWWVMaQcX",
+	"YNrFBdtg0XDkPGftV6+rW6PXRhpWQIlG8aSvoQ1L1yVLphRiHXh/bJ3m7slAMT8+eXH61dfPjxe1SFEV",
+	"bLfWslb7rKJZBCxNyYoOGkfc+pp1oT7Px9+ezBfzk/nx8qvFYrF48oH1fHudTtlxPI4YwcSCa3ZilA8/",
+	"nv8nA9TEM1C1P3CDpX6q+unRix7DiUwpthvduKHeYfkN0wbSHt96ivFV17n7PG+y9Ck2qdCykcITWfOJ",
+	"x//q1erd267HpygMzziqp9PjJmA8FYQaK5NZG2OG5/bccWxix1//dX4yP1m+OJ40sjgSyLf5RqqnhBJE",
+	"+rbZYNVV2FRL57x6isAbLm4vu+tpvzF7wpOsqYxTvPJRQPo6xZ3tr2tjVIlM2xxps6Ocdw7vRLGDSqFG",
+	"YcgFO++8X6vJLanK2O+d85Ppmk8nNos19ZOVPi384frGbjLM4HPkY9OGkSP0DLGjsS43sTXs5qiBZiYt",
+	"rputWJtatTYlOkI76F9vO9bT9zNvu2P13pxfHK0uxqHUG/KAl59h0Vyna/ZkLFrp9EyPRe32xoH9jpSa",
+	"u1IiP/RCFGkluQj5TcHF7fyg5PSlh5rGogtknx9ZA9lxVI0jzTcFF9v1z6B75bYeIN8pLpobQcG1ISm5",
+	"opIczbMAHRamhGN1svzQ1fgsy+hBXx4fk7IrZsiQo2X03//4R/qX2Rc/sVm2mH37/sNxfPq4/PLDyWP/",
+	"qy//l9b9OWq5XF2dz86uYBXi9ZQNjYIVMSXqkmzk1bvL11Ecvfp+9eY8iqOLs8vXb6/pH69fX5K9tMw3",
+	"SybJXzVBoaF7cxHF0fm7H9/2idxcTFKQ2zd4h8XYeorm677bvZHbrdWJ/TkOp6a4qbc2QmSSvrbAZI8B",
+	"/8vh9MSRfT+h1B84kXxla9mJSlKb9Uc5bDxwj/4tbewv7Ymd3MwDH0gbQSookCqjzrvg6yOQonlQdacE",
+	"0LY0x7Iyu7hHlQCGopP2a2AK/fGYPr84+Ok4Pnkftx45zhiGLl2yh3Un+xqkJkZWngvSuKu6LUw1wiHt",
+	"zRZxZ3UiheGiRg21MLzwT6Q247Sb4u9k3VElrFpnvJjIai5Yp6ZJWAW0DIyEe8UN9lUmY3Cv2F14/1s2",
+	"HRQk1W6QAMHrB5aYYteoMvDiwpAk2gF31YOaNtqo45k7Y04bJxMAu3atmJm4nrN1ezYXgHeodtDZ0PBa",
+	"MpPkdIughYVl77gxXbIqt7YcinxK4O5ah6V9I/gDpMywrWJlIwgjQaPHy7tyl6L5DBWqsO2zKoSWTipE",
+	"JeuPffJD4oOFRw6JmRTp2QrYYXu/w8WGU/o+qIwlZhJ1blCoRuxMIWyQJBnix3Ng7iTE1UM378VgC45Z",
+	"d36qWeFMN+1H0cRiS0L6zoXjNYYNJqzWOLCKdjFhoVBX4ANzV9YZ48XgupNm3hz23B5LUJ6WkDE1wExe",
+	"nI7PGDxqXnedk1vRTYF93mXC9aes5ULJTYHlFKZqGJ94uM8gr0smQCFLLWKPD1XBvOh8ryxx/sg1yCSp",
+	"lULRVoaVOzBg/zkWVVYXtIPyM4O9VeQHW3Jwlt5xVwrk8p4WV0omSK/aj4obg4Ii3GuxLbjO7a7AXyYV",
+	"oNhygah0DLWuWVHsHLRdc0PoiFQgpACDSS64bTwYdou5LFJU2lKj1TZ95P8zMI3olRTCe62RNjYRimjr",
+	"xBRkbaZRP22YmKqzz+DmcgUKM3RSc2JqkkOH8AQp75VuDDjfzqkEZalF+hlkim1LFB1iNvfQ9WZWUVw2",
+	"sksAiOU5/MB25Fa17xF1FKSk9NUF12GTx+ccZAWJTAf10pFfeJQEmc1shvcnI29RzCi1m5HiLCyWzpz0",
+	"AmBWKz4LkpmMvSHojf3x++vrC3ALLGewRYGqaUcS21LxLRegUVFL2HUxD5lw725fLV7YNIi6RdHyq2+/",
+	"jSPfO9r7THp/HVuAzqUi4yxLpnaDQ71iPrfRX6Gy/ngj2B3jBZ05pRD3Bd0wY3VBOmQbWZvlpmDiNoqf",
+	"Y/u14P+qsdgNnaArD5CUZ3vrs4MRD6YjtzueYgpnF6s5vKsq2Wl4Np7EfAcbLr97Nfvmr4tvYp9oCuT2",
+	"WVSYyLJEkbq9G4QUG0atwEleruQ2EpiLkbOgjlQmNTmfO0dIBdtCbqxK3P1C07yn5uc5z0e4yOBB8f7S",
+	"mOJUuRRwo+k+tW8K97r0teAmdAbsxRw8EbJL24ZW6DGxoE4jE1nYAOpIfHFxfvNlH4cp2A6VlTXXwag7",
+	"gwVMB5Zek94EGqjYrpAshRmsLuB7CzvDDG7Omw/9d/j0m8lO0gh42I+SfBZ4duXXDOErB3n86misF8+n",
+	"xGJ/B8jmhOD3wp0DgNMx0k3KnIRaJHEydx/KcWxlvxxM/NQQYn+GbMQxNl/3DdauhhK1ZtunA1WAgQan",
+	"Pz56pGj8il6sQkx1V7sM8HGDD9ovoHnKzi5WURzdodKOwmK+mB/TBWWFglWcxhmoReZgv9xe7qitd7au",
+	"4nWTaFyKVRoto7+j8VVO3J/lO1ksBkN89GYdVQXjg/G9oWBGI3pXdZKg1pRDv2sOJ7ZPF4t9dhJYOerM",
+	"FBJln3NQra54E5qv3/3wZjDKQgWzNQa21aQfehyliN4TjSM7Y9ARSZ/dv1Nv8uDIQ2wD/H2OArjRvZkN",
+	"skrbbB6J2c6HPC3lnz8q2R1AmdLCcMJi3tHBHgb8g/6Xj2OkqdgmmFiJO1bwMLE5H+iURG/c6MN4GsRr",
+	"0n4VvSfYrJ6CcGrT05qgkoe2QClTbAaJSsbpwlThONSxGfzSztcGz9PZlYUbhDRuzjKMdeLDEKzUfTjH",
+	"7qNJHWczG2SJFNpTg0KKLapm4IlQgfvcIj0EZzkD9ANQ2nACTrXjdjQH5IxxOEBENivvUM3h3MsREiY8",
+	"ElGLVAp/e6+OACux2uRSUVIN99zkwOA/frwm5hWxRJUQaL4Vzc90LoUznTOLHmCi0IwbrX2XaP3Bnv1S",
+	"prtP6wpt2Hh8/Hxu52P478vpiIXT35KF60kL4W5eqh3bG8YDK9tBa3UQBlw8l5XeG8/fcO2ctWDaBKyr",
+	"GYrY7Dr0XbeilNqAwgSFgYwrbeLWzPtjQDu4R4WBVjNJ3Z3c4grcJIn+fK5mXx8S0a/qBr3RlwkbmJpF",
+	"+Vy22A7GNfjqz7DOvmFNTNkEUyXZO1NtcsF9ydjK9Q7/WKnYS6Z5Aly4Ip9LARXbIlgkBcz4dXXQqNZ7",
+	"E7R+x/KwVw/KAg+6M4WjkfiDDtIre341L5mYZpjyFLqbzEZX+x1mbHvV0FFtZ4TGareQ26PQdN/nCKFf",
+	"/ytqI5zxm3kKJbjFYLBg5AEhs+0L5WoglE+fNh2SRzMO0T3fFb9G1fj4/0pLV8/RElmya1y54FSgwT2j",
+	"BTYEWojejFpeny8rINZcn3PsY6dTvfE+4+2Iw2//hL+VIzm6v9Kh9mP8C570wSTI+C13v1kn3QsedP8U",
+	"hYVmufu2Fs3fkhwyCnsFmbXJhR2QsMPRXup+SKVl1bRWhOnE3MrUm7dP+5/Od3ut/kOgxMgt/gDgxFT3",
+	"emwmkyjFla0QWu0xPzrQKD0gAp0pE2CdCSCp3DcOn5iaVYmbdtMzRk7OxC7YYs+jFFYFS5rRrM8TpZjq",
+	"Guqnf/X6sx6Pj7/l2/aUf0wEXaZMCLr/hhGeGdX73nYgrNuNtr9O33+IalVEyyg3ploeHX3IpTaPyw+V",
+	"VObxiFX86O6YEHmmODVRrXXQkn5D2bqo/ZqigVSDn18sTk9P6LLvAzujVgTNxRk7AGe7OK7FO64O4kiw",
+	"sunNNePH0yN3REtm4zjTIeJlMqYQwMQ+0jkBrbbEHFwzpnWtOn+TcRCb6RGTlZ4g9srmZtZC8MG10Tc7",
+	"L6cG2O2Q8anc4/vH/xsA1X858NZAAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -3,6 +3,10 @@
 // Code generated by unknown module path version unknown version DO NOT EDIT.
 package mgmtapi
 
+import (
+	"time"
+)
+
 // Defines values for LinkRelationship.
 const (
 	CHILD  LinkRelationship = "CHILD"
@@ -39,6 +43,50 @@ type BFD struct {
 	RequiredMinimumReceive string `json:"required_minimum_receive"`
 }
 
+// DrainRequest defines model for DrainRequest.
+type DrainRequest struct {
+	// GracePeriod The time during which the router keeps forwarding traffic before it shuts down. If not set, the configured drain_grace_period is used.
+	GracePeriod *string `json:"grace_period,omitempty"`
+}
+
+// DrainStatus defines model for DrainStatus.
+type DrainStatus struct {
+	// Deadline The end of the grace period, after which the router shuts down.
+	Deadline *time.Time `json:"deadline,omitempty"`
+
+	// Draining Whether the router is draining.
+	Draining bool `json:"draining"`
+}
+
+// DroppedPacket defines model for DroppedPacket.
+type DroppedPacket struct {
+	// EgressInterface The interface the packet would have left through. 0 if it is the internal interface, or if it was not known yet.
+	EgressInterface int `json:"egress_interface"`
+
+	// Header The start of the packet, base64 encoded, cut at the snap length of the drop trace.
+	Header []byte `json:"header"`
+
+	// IngressInterface The interface the packet was received from. The internal interface is 0.
+	IngressInterface int `json:"ingress_interface"`
+
+	// Length The length of the packet, in bytes.
+	Length int `json:"length"`
+
+	// Reason The reason why the packet was dropped, as in the reason label of the router_dropped_pkts_total metric.
+	Reason string `json:"reason"`
+
+	// Time The time the packet was dropped.
+	Time time.Time `json:"time"`
+
+	// UnderlaySource The underlay address the packet was received from, if it is known.
+	UnderlaySource *string `json:"underlay_source,omitempty"`
+}
+
+// DroppedPackets defines model for DroppedPackets.
+type DroppedPackets struct {
+	Packets []DroppedPacket `json:"packets"`
+}
+
 // Interface defines model for Interface.
 type Interface struct {
 	Bfd BFD `json:"bfd"`
@@ -51,6 +99,9 @@ type Interface struct {
 	Neighbor          InterfaceNeighbor `json:"neighbor"`
 	Relationship      LinkRelationship  `json:"relationship"`
 
+	// Rtt The round trip time to the neighbor router, as measured by BFD. Only present if the router measures it and it is known.
+	Rtt *string `json:"rtt,omitempty"`
+
 	// ScionMtu The maximum transmission unit in bytes for SCION packets. This represents the protocol data unit (PDU) of the SCION layer and is usually calculated as maximum Ethernet payload - IP Header - UDP Header.
 	ScionMtu ScionMTU  `json:"scion_mtu"`
 	State    LinkState `json:"state"`
@@ -87,6 +138,40 @@ type LogLevel struct {
 // LogLevelLevel Logging level
 type LogLevelLevel string
 
+// MirrorConfig defines model for MirrorConfig.
+type MirrorConfig struct {
+	DstIsdAs *IsdAs `json:"dst_isd_as,omitempty"`
+
+	// Interfaces Only mirror the packets that enter or leave the router through one of these interfaces. If empty, the packets of all interfaces are mirrored. The internal interface is 0.
+	Interfaces *[]int `json:"interfaces,omitempty"`
+
+	// MaxPackets Stop mirroring after this number of packets. If 0, mirroring continues until it is stopped.
+	MaxPackets *int `json:"max_packets,omitempty"`
+
+	// PcapFile Path of the pcap file to write the packets to, relative to the mirroring directory of the router. Exactly one of pcap_file and socket must be set.
+	PcapFile *string `json:"pcap_file,omitempty"`
+
+	// SampleRate Mirror one in every sample_rate of the matching packets. 0 and 1 mirror all of them.
+	SampleRate *int `json:"sample_rate,omitempty"`
+
+	// Socket Path of the Unix datagram socket to send the packets to, one packet per datagram, relative to the mirroring directory of the router. Exactly one of pcap_file and socket must be set.
+	Socket   *string `json:"socket,omitempty"`
+	SrcIsdAs *IsdAs  `json:"src_isd_as,omitempty"`
+}
+
+// MirrorStatus defines model for MirrorStatus.
+type MirrorStatus struct {
+	// Active Whether packets are being mirrored.
+	Active bool          `json:"active"`
+	Config *MirrorConfig `json:"config,omitempty"`
+
+	// Dropped The number of sampled packets that could not be mirrored, because the mirroring could not keep up or the destination failed.
+	Dropped int `json:"dropped"`
+
+	// Mirrored The number of packets mirrored so far.
+	Mirrored int `json:"mirrored"`
+}
+
 // Problem defines model for Problem.
 type Problem struct {
 	// Detail A human readable explanation specific to this occurrence of the problem that is helpful to locate the problem and give advice on how to proceed. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
@@ -137,5 +222,11 @@ type StandardError struct {
 // BadRequest defines model for BadRequest.
 type BadRequest = StandardError
 
+// DrainJSONRequestBody defines body for Drain for application/json ContentType.
+type DrainJSONRequestBody = DrainRequest
+
 // SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
 type SetLogLevelJSONRequestBody = LogLevel
+
+// StartMirrorJSONRequestBody defines body for StartMirror for application/json ContentType.
+type StartMirrorJSONRequestBody = MirrorConfig
@@ -0,0 +1,375 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	"github.com/gopacket/gopacket/pcapgo"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/router/control"
+)
+
+// mirrorQueueSize is the number of mirrored packets that can wait to be written. Packets are
+// dropped from the mirror, not from forwarding, when the queue is full.
+const mirrorQueueSize = 1024
+
+var errNoMirror = serrors.New("no packet mirroring is active")
+
+// mirror copies a sample of the forwarded packets to a pcap file or a Unix datagram socket.
+//
+// The packets are copied by the packet processors and written by a goroutine of their own, so
+// that a slow destination delays neither forwarding nor the other mirrored packets; it only
+// leads to mirrored packets being dropped.
+type mirror struct {
+	cfg   control.MirrorConfig
+	out   mirrorOutput
+	queue chan mirroredPacket
+	// seen counts the matching packets, for sampling.
+	seen     atomic.Uint64
+	mirrored atomic.Uint64
+	dropped  atomic.Uint64
+	// done is set once MaxPackets packets have been mirrored.
+	done     atomic.Bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// mirroredPacket is the copy of a forwarded packet, with its underlay addresses.
+type mirroredPacket struct {
+	data     []byte
+	src, dst netip.AddrPort
+	time     time.Time
+}
+
+// mirrorOutput is where the mirrored packets are written to. Each packet is written with
+// synthesized IP and UDP headers that carry its underlay addresses.
+type mirrorOutput interface {
+	write(ts time.Time, frame []byte) error
+	// flush is called when there are no more packets waiting to be written.
+	flush() error
+	Close() error
+}
+
+func newMirror(cfg control.MirrorConfig) (*mirror, error) {
+	var out mirrorOutput
+	var err error
+	switch {
+	case (cfg.PcapFile == "") == (cfg.Socket == ""):
+		return nil, serrors.New("exactly one of pcap file and socket must be set")
+	case cfg.PcapFile != "":
+		out, err = newPcapOutput(cfg.PcapFile)
+	default:
+		out, err = newSocketOutput(cfg.Socket)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mirror{
+		cfg:     cfg,
+		out:     out,
+		queue:   make(chan mirroredPacket, mirrorQueueSize),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}, nil
+}
+
+// matches returns true if the packet is selected by the mirror's filter.
+func (m *mirror) matches(p *Packet) bool {
+	if len(m.cfg.Interfaces) != 0 &&
+		!slices.Contains(m.cfg.Interfaces, p.ingress) &&
+		!slices.Contains(m.cfg.Interfaces, p.egress) {
+		return false
+	}
+	if m.cfg.SrcIA.IsZero() && m.cfg.DstIA.IsZero() {
+		return true
+	}
+	// The destination ISD-AS followed by the source ISD-AS start the address header.
+	if len(p.rawPacket) < slayers.CmnHdrLen+2*addr.IABytes {
+		return false
+	}
+	dstIA := addr.IA(binary.BigEndian.Uint64(p.rawPacket[slayers.CmnHdrLen:]))
+	srcIA := addr.IA(binary.BigEndian.Uint64(p.rawPacket[slayers.CmnHdrLen+addr.IABytes:]))
+	return (m.cfg.SrcIA.IsZero() || m.cfg.SrcIA == srcIA) &&
+		(m.cfg.DstIA.IsZero() || m.cfg.DstIA == dstIA)
+}
+
+// offer mirrors the packet, which is about to be sent over the given link, if it is selected.
+func (m *mirror) offer(p *Packet, link Link) {
+	if m.done.Load() || !m.matches(p) {
+		return
+	}
+	if rate := uint64(m.cfg.SampleRate); rate > 1 && m.seen.Add(1)%rate != 0 {
+		return
+	}
+	mp := mirroredPacket{
+		data: bytes.Clone(p.rawPacket),
+		dst:  link.Remote(),
+		time: time.Now(),
+	}
+	if p.srcAddr != nil && len(p.srcAddr.IP) != 0 {
+		mp.src = p.srcAddr.AddrPort()
+	}
+	if p.DstAddr != nil && len(p.DstAddr.IP) != 0 {
+		mp.dst = p.DstAddr.AddrPort()
+	}
+	select {
+	case m.queue <- mp:
+	default:
+		m.dropped.Add(1)
+	}
+}
+
+// run writes the mirrored packets until the mirror is closed or MaxPackets packets have been
+// mirrored. It closes the output when it returns.
+func (m *mirror) run() {
+	defer close(m.stopped)
+	defer func() {
+		if err := m.out.Close(); err != nil {
+			log.Info("Error closing packet mirror", "err", err)
+		}
+	}()
+	buf := gopacket.NewSerializeBuffer()
+	for {
+		var mp mirroredPacket
+		select {
+		case <-m.stop:
+			return
+		case mp = <-m.queue:
+		}
+		err := mirrorFrame(buf, mp)
+		if err == nil {
+			err = m.out.write(mp.time, buf.Bytes())
+		}
+		if err == nil && len(m.queue) == 0 {
+			err = m.out.flush()
+		}
+		if err != nil {
+			log.Debug("Error mirroring packet", "err", err)
+			m.dropped.Add(1)
+			continue
+		}
+		if n := m.mirrored.Add(1); m.cfg.MaxPackets != 0 && n >= m.cfg.MaxPackets {
+			m.done.Store(true)
+			if err := m.out.flush(); err != nil {
+				log.Info("Error flushing packet mirror", "err", err)
+			}
+			return
+		}
+	}
+}
+
+// close stops the mirror and waits until its output is closed.
+func (m *mirror) close() {
+	m.stopOnce.Do(func() { close(m.stop) })
+	<-m.stopped
+}
+
+func (m *mirror) status() control.MirrorStatus {
+	cfg := m.cfg
+	return control.MirrorStatus{
+		Active:   !m.done.Load(),
+		Config:   &cfg,
+		Mirrored: m.mirrored.Load(),
+		Dropped:  m.dropped.Load(),
+	}
+}
+
+// mirrorFrame serializes the mirrored packet into buf, behind IP and UDP headers with the
+// underlay addresses of the packet. The addresses that are not known are unspecified.
+func mirrorFrame(buf gopacket.SerializeBuffer, mp mirroredPacket) error {
+	src, dst := mp.src.Addr(), mp.dst.Addr()
+	ipv6 := src.Is6() && !src.Is4In6() || dst.Is6() && !dst.Is4In6()
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(mp.src.Port()),
+		DstPort: layers.UDPPort(mp.dst.Port()),
+	}
+	var ip gopacket.SerializableLayer
+	if ipv6 {
+		ip6 := &layers.IPv6{
+			Version:    6,
+			NextHeader: layers.IPProtocolUDP,
+			HopLimit:   64,
+			SrcIP:      mirrorIP(src, true),
+			DstIP:      mirrorIP(dst, true),
+		}
+		if err := udp.SetNetworkLayerForChecksum(ip6); err != nil {
+			return err
+		}
+		ip = ip6
+	} else {
+		ip4 := &layers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    mirrorIP(src, false),
+			DstIP:    mirrorIP(dst, false),
+		}
+		if err := udp.SetNetworkLayerForChecksum(ip4); err != nil {
+			return err
+		}
+		ip = ip4
+	}
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	return gopacket.SerializeLayers(buf, opts, ip, udp, gopacket.Payload(mp.data))
+}
+
+// mirrorIP returns the address for an IPv6 or IPv4 header. IPv4 addresses are mapped in IPv6
+// headers.
+func mirrorIP(a netip.Addr, ipv6 bool) net.IP {
+	switch {
+	case !a.IsValid() && ipv6:
+		return net.IPv6unspecified
+	case !a.IsValid():
+		return net.IPv4zero.To4()
+	case ipv6:
+		b := a.As16()
+		return net.IP(b[:])
+	default:
+		return net.IP(a.Unmap().AsSlice())
+	}
+}
+
+// pcapOutput writes the mirrored packets to a pcap file.
+type pcapOutput struct {
+	f *os.File
+	b *bufio.Writer
+	w *pcapgo.Writer
+}
+
+func newPcapOutput(path string) (*pcapOutput, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, serrors.Wrap("creating pcap file", err, "file", path)
+	}
+	b := bufio.NewWriter(f)
+	w := pcapgo.NewWriter(b)
+	if err := w.WriteFileHeader(bufSize+64, layers.LinkTypeRaw); err != nil {
+		f.Close()
+		return nil, serrors.Wrap("writing pcap header", err, "file", path)
+	}
+	return &pcapOutput{f: f, b: b, w: w}, nil
+}
+
+func (o *pcapOutput) write(ts time.Time, frame []byte) error {
+	return o.w.WritePacket(gopacket.CaptureInfo{
+		Timestamp:     ts,
+		CaptureLength: len(frame),
+		Length:        len(frame),
+	}, frame)
+}
+
+func (o *pcapOutput) flush() error {
+	return o.b.Flush()
+}
+
+func (o *pcapOutput) Close() error {
+	if err := o.b.Flush(); err != nil {
+		o.f.Close()
+		return err
+	}
+	return o.f.Close()
+}
+
+// socketOutput sends the mirrored packets to a Unix datagram socket, one per datagram.
+type socketOutput struct {
+	conn *net.UnixConn
+}
+
+func newSocketOutput(path string) (*socketOutput, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, serrors.Wrap("connecting to mirror socket", err, "socket", path)
+	}
+	return &socketOutput{conn: conn}, nil
+}
+
+func (o *socketOutput) write(_ time.Time, frame []byte) error {
+	_, err := o.conn.Write(frame)
+	return err
+}
+
+func (o *socketOutput) flush() error {
+	return nil
+}
+
+func (o *socketOutput) Close() error {
+	return o.conn.Close()
+}
+
+// StartMirror starts mirroring the forwarded packets selected by the given configuration. The
+// current mirroring, if any, is stopped. This can be called whether the dataplane is running
+// or not.
+func (d *DataPlane) StartMirror(cfg control.MirrorConfig) error {
+	m, err := newMirror(cfg)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer log.HandlePanic()
+		m.run()
+	}()
+	if old := d.mirror.Swap(m); old != nil {
+		old.close()
+	}
+	log.Info("Started packet mirroring", "pcap_file", cfg.PcapFile, "socket", cfg.Socket)
+	return nil
+}
+
+// StopMirror stops mirroring packets.
+func (d *DataPlane) StopMirror() error {
+	m := d.mirror.Swap(nil)
+	if m == nil {
+		return errNoMirror
+	}
+	m.close()
+	log.Info("Stopped packet mirroring", "mirrored", m.mirrored.Load(),
+		"dropped", m.dropped.Load())
+	return nil
+}
+
+// MirrorStatus returns the state of the current packet mirroring, or of the last one if it
+// stopped after the requested number of packets.
+func (d *DataPlane) MirrorStatus() control.MirrorStatus {
+	m := d.mirror.Load()
+	if m == nil {
+		return control.MirrorStatus{}
+	}
+	return m.status()
+}
+
+// mirrorPacket offers the packet, which is about to be sent over the given link, to the current
+// packet mirror.
+func (d *DataPlane) mirrorPacket(p *Packet, link Link) {
+	if m := d.mirror.Load(); m != nil {
+		m.offer(p, link)
+	}
+}
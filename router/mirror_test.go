@@ -0,0 +1,129 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	"github.com/gopacket/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/router/control"
+)
+
+func TestMirror(t *testing.T) {
+	link := &testLink{remote: netip.MustParseAddrPort("192.0.2.2:50000")}
+	// The message carries a timestamp, so it is serialized once for all packets.
+	raw := serializedBaseMsg(t, []byte("payload"), 1)
+	packet := func(ingress uint16) *Packet {
+		p := new(Packet).init(&[bufSize]byte{})
+		p.reset()
+		p.rawPacket = p.buffer[:copy(p.buffer[:], raw)]
+		p.ingress = ingress
+		p.egress = 2
+		p.srcAddr = net.UDPAddrFromAddrPort(netip.MustParseAddrPort("192.0.2.1:50000"))
+		return p
+	}
+	waitMirrored := func(t *testing.T, d *DataPlane, n uint64) {
+		require.Eventually(t, func() bool {
+			return d.MirrorStatus().Mirrored == n
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	t.Run("pcap file with filter and sampling", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "mirror.pcap")
+		var d DataPlane
+		require.NoError(t, d.StartMirror(control.MirrorConfig{
+			SampleRate: 2,
+			Interfaces: []uint16{1},
+			SrcIA:      addr.MustParseIA("1-ff00:0:111"),
+			MaxPackets: 3,
+			PcapFile:   file,
+		}))
+		for i := 0; i < 10; i++ {
+			// Only the packets from interface 1 match, and every second is sampled.
+			d.mirrorPacket(packet(1), link)
+			d.mirrorPacket(packet(3), link)
+		}
+		waitMirrored(t, &d, 3)
+		status := d.MirrorStatus()
+		assert.False(t, status.Active)
+		assert.Zero(t, status.Dropped)
+		require.NoError(t, d.StopMirror())
+		assert.ErrorIs(t, d.StopMirror(), errNoMirror)
+
+		f, err := os.Open(file)
+		require.NoError(t, err)
+		defer f.Close()
+		r, err := pcapgo.NewReader(f)
+		require.NoError(t, err)
+		assert.Equal(t, layers.LinkTypeRaw, r.LinkType())
+		var frames int
+		for {
+			data, _, err := r.ReadPacketData()
+			if err != nil {
+				break
+			}
+			frames++
+			pkt := gopacket.NewPacket(data, layers.LayerTypeIPv4, gopacket.Default)
+			ip := pkt.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+			assert.Equal(t, "192.0.2.1", ip.SrcIP.String())
+			assert.Equal(t, "192.0.2.2", ip.DstIP.String())
+			udp := pkt.Layer(layers.LayerTypeUDP).(*layers.UDP)
+			assert.Equal(t, layers.UDPPort(50000), udp.DstPort)
+			assert.Equal(t, packet(1).rawPacket, udp.Payload)
+		}
+		assert.Equal(t, 3, frames)
+	})
+	t.Run("socket", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mirror.sock")
+		conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var d DataPlane
+		require.NoError(t, d.StartMirror(control.MirrorConfig{
+			DstIA:  addr.MustParseIA("1-ff00:0:110"),
+			Socket: path,
+		}))
+		d.mirrorPacket(packet(1), link)
+		waitMirrored(t, &d, 1)
+		assert.True(t, d.MirrorStatus().Active)
+
+		buf := make([]byte, bufSize)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		pkt := gopacket.NewPacket(buf[:n], layers.LayerTypeIPv4, gopacket.Default)
+		udp := pkt.Layer(layers.LayerTypeUDP).(*layers.UDP)
+		assert.Equal(t, packet(1).rawPacket, udp.Payload)
+		require.NoError(t, d.StopMirror())
+	})
+	t.Run("invalid output", func(t *testing.T) {
+		var d DataPlane
+		assert.Error(t, d.StartMirror(control.MirrorConfig{}))
+		assert.Error(t, d.StartMirror(control.MirrorConfig{PcapFile: "a.pcap", Socket: "a.sock"}))
+		assert.Equal(t, control.MirrorStatus{}, d.MirrorStatus())
+	})
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"net/netip"
+	"slices"
+
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// cpuInfo is a CPU that the router may run on, and the NUMA node it belongs to.
+type cpuInfo struct {
+	id   int
+	node int
+}
+
+// cpuPlacement is the placement of the packet processors on the CPUs of the machine.
+//
+// The processors are pinned to distinct CPUs when there are enough of them, spread evenly over
+// the NUMA nodes. A receiver hands packets only to the processors of the NUMA node on which the
+// kernel receives the packets of its connection, so that the packets are not processed on a node
+// other than the one where they are in the caches.
+type cpuPlacement struct {
+	// procCPUs is the CPU that each processor is pinned to.
+	procCPUs []int
+	// nodeOfCPU is the NUMA node of each CPU.
+	nodeOfCPU map[int]int
+	// nodeCPUs are the CPUs of each NUMA node.
+	nodeCPUs map[int][]int
+	// nodeProcs are the processors of each NUMA node.
+	nodeProcs map[int][]int
+}
+
+// newCPUPlacement places the given number of processors on the CPUs that the router may run on.
+func newCPUPlacement(numProcs int) (*cpuPlacement, error) {
+	cpus, err := usableCPUs()
+	if err != nil {
+		return nil, err
+	}
+	return planPlacement(cpus, numProcs), nil
+}
+
+// planPlacement places the given number of processors on the given CPUs. The CPUs are taken
+// from each NUMA node in turn, so that the processors are spread evenly over the nodes. If there
+// are more processors than CPUs, some CPUs get more than one processor.
+func planPlacement(cpus []cpuInfo, numProcs int) *cpuPlacement {
+	p := &cpuPlacement{
+		procCPUs:  make([]int, numProcs),
+		nodeOfCPU: make(map[int]int, len(cpus)),
+		nodeCPUs:  make(map[int][]int),
+		nodeProcs: make(map[int][]int),
+	}
+	for _, c := range cpus {
+		p.nodeOfCPU[c.id] = c.node
+		p.nodeCPUs[c.node] = append(p.nodeCPUs[c.node], c.id)
+	}
+	var nodes []int
+	for node, ids := range p.nodeCPUs {
+		slices.Sort(ids)
+		nodes = append(nodes, node)
+	}
+	slices.Sort(nodes)
+
+	// Interleave the CPUs of the nodes.
+	var order []int
+	for i := 0; len(order) < len(cpus); i++ {
+		for _, node := range nodes {
+			if ids := p.nodeCPUs[node]; i < len(ids) {
+				order = append(order, ids[i])
+			}
+		}
+	}
+	for i := range p.procCPUs {
+		cpu := order[i%len(order)]
+		p.procCPUs[i] = cpu
+		node := p.nodeOfCPU[cpu]
+		p.nodeProcs[node] = append(p.nodeProcs[node], i)
+	}
+	return p
+}
+
+// localProcessors returns the processors on the NUMA node of the given CPU, or nil if there are
+// none.
+func (p *cpuPlacement) localProcessors(cpu int) []int {
+	node, ok := p.nodeOfCPU[cpu]
+	if !ok {
+		return nil
+	}
+	return p.nodeProcs[node]
+}
+
+// localCPUs returns the CPUs of the NUMA node of the given CPU, or nil if it is not known.
+func (p *cpuPlacement) localCPUs(cpu int) []int {
+	node, ok := p.nodeOfCPU[cpu]
+	if !ok {
+		return nil
+	}
+	return p.nodeCPUs[node]
+}
+
+// incomingCPUConn is a connection that knows the CPU on which the kernel receives its packets.
+type incomingCPUConn interface {
+	IncomingCPU() (int, error)
+}
+
+// localAddrConn is a connection that knows its local address.
+type localAddrConn interface {
+	LocalAddr() netip.AddrPort
+}
+
+// flowSharder picks the processor of each packet read by a receiver. The packets of a flow
+// always go to the same processor, so that they are not reordered.
+type flowSharder struct {
+	numProcs int
+	hashSeed uint32
+	// rssKey is the key of the RSS hash of the underlay addresses by which the packets are
+	// sharded. If nil, they are sharded by their SCION flow.
+	rssKey []byte
+	// local is the local address of the connection, for the RSS hash.
+	local netip.AddrPort
+	// procs are the processors that the packets are sharded across. If nil, all of them are.
+	procs []int
+}
+
+// procID returns the processor of the given packet, received from the given address.
+func (s *flowSharder) procID(data []byte, src *net.UDPAddr) (int, error) {
+	n := s.numProcs
+	if s.procs != nil {
+		n = len(s.procs)
+	}
+	var id uint32
+	if s.rssKey != nil {
+		id = rssHash(s.rssKey, src.AddrPort(), s.local) % uint32(n)
+	} else {
+		var err error
+		id, err = computeProcID(data, n, s.hashSeed)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if s.procs != nil {
+		return s.procs[id], nil
+	}
+	return int(id), nil
+}
+
+// localize restricts the sharding to the processors on the NUMA node where the kernel receives
+// the packets of the connection, and moves the calling receiver to that node. It returns false
+// if that node is not known yet.
+func (s *flowSharder) localize(p *cpuPlacement, conn BatchConn) bool {
+	c, ok := conn.(incomingCPUConn)
+	if !ok {
+		return true
+	}
+	cpu, err := c.IncomingCPU()
+	if err != nil || cpu < 0 {
+		return false
+	}
+	if procs := p.localProcessors(cpu); len(procs) != 0 {
+		s.procs = procs
+	}
+	if err := pinThread(p.localCPUs(cpu)...); err != nil {
+		log.Info("Failed to pin receiver to its NUMA node", "cpu", cpu, "err", err)
+	}
+	return true
+}
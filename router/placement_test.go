@@ -0,0 +1,82 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanPlacement(t *testing.T) {
+	// Two NUMA nodes with CPUs 0-3 and 4-7.
+	var cpus []cpuInfo
+	for id := 0; id < 8; id++ {
+		cpus = append(cpus, cpuInfo{id: id, node: id / 4})
+	}
+
+	t.Run("processors are spread over the nodes", func(t *testing.T) {
+		p := planPlacement(cpus, 6)
+		assert.Equal(t, []int{0, 4, 1, 5, 2, 6}, p.procCPUs)
+		assert.Equal(t, []int{0, 2, 4}, p.localProcessors(3))
+		assert.Equal(t, []int{1, 3, 5}, p.localProcessors(7))
+		assert.Equal(t, []int{4, 5, 6, 7}, p.localCPUs(6))
+		assert.Nil(t, p.localProcessors(8))
+	})
+	t.Run("more processors than CPUs", func(t *testing.T) {
+		p := planPlacement(cpus[:2], 3)
+		assert.Equal(t, []int{0, 1, 0}, p.procCPUs)
+		assert.Equal(t, []int{0, 1, 2}, p.localProcessors(1))
+	})
+}
+
+func TestFlowSharder(t *testing.T) {
+	src := &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 50000}
+
+	t.Run("SCION flows", func(t *testing.T) {
+		s := &flowSharder{numProcs: 4, hashSeed: fnv1aOffset32}
+		seen := make(map[int]bool)
+		for flowID := uint32(0); flowID < 100; flowID++ {
+			raw := serializedBaseMsg(t, []byte("payload"), flowID)
+			id, err := s.procID(raw, src)
+			require.NoError(t, err)
+			again, err := s.procID(raw, src)
+			require.NoError(t, err)
+			assert.Equal(t, id, again)
+			seen[id] = true
+		}
+		assert.Len(t, seen, 4)
+
+		// Restricted to the processors of a NUMA node.
+		s.procs = []int{1, 3}
+		for flowID := uint32(0); flowID < 100; flowID++ {
+			id, err := s.procID(serializedBaseMsg(t, []byte("payload"), flowID), src)
+			require.NoError(t, err)
+			assert.Contains(t, s.procs, id)
+		}
+	})
+	t.Run("RSS hash", func(t *testing.T) {
+		s := &flowSharder{numProcs: 4, rssKey: defaultRSSKey}
+		for flowID := uint32(0); flowID < 10; flowID++ {
+			// All the packets of an underlay flow go to the same processor, whatever their
+			// SCION flow.
+			id, err := s.procID(serializedBaseMsg(t, []byte("payload"), flowID), src)
+			require.NoError(t, err)
+			assert.Equal(t, int(rssHash(defaultRSSKey, src.AddrPort(), s.local)%4), id)
+		}
+	})
+}
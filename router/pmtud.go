@@ -0,0 +1,89 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/pkg/log"
+)
+
+// pathMTUInterval is the interval at which the path MTUs discovered by the underlay are checked.
+const pathMTUInterval = 5 * time.Second
+
+// runPathMTUDiscovery periodically applies the path MTUs of the external interfaces, as
+// discovered by the underlay, until the context is done.
+func (d *DataPlane) runPathMTUDiscovery(ctx context.Context) {
+	ticker := time.NewTicker(pathMTUInterval)
+	defer ticker.Stop()
+	for {
+		d.updatePathMTUs()
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// updatePathMTUs records the current path MTUs of the external interfaces, and publishes new
+// forwarding tables if any of them changed.
+func (d *DataPlane) updatePathMTUs() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	changed := false
+	for ifID, link := range d.interfaces {
+		if link.Scope() != External {
+			continue
+		}
+		mtu := min(link.PathMTU(), bufSize)
+		if mtu == d.pathMTUs[ifID] {
+			continue
+		}
+		log.Info("Path MTU of interface changed", "interface", ifID, "mtu", mtu,
+			"previous", d.pathMTUs[ifID])
+		if mtu == 0 {
+			delete(d.pathMTUs, ifID)
+		} else {
+			if d.pathMTUs == nil {
+				d.pathMTUs = make(map[uint16]int)
+			}
+			d.pathMTUs[ifID] = mtu
+		}
+		changed = true
+	}
+	if changed {
+		d.publishTables()
+	}
+}
+
+// effectiveMTUs returns the MTUs of the interfaces: the smaller of the configured and the
+// discovered MTU of each interface that has either.
+func effectiveMTUs(configured, discovered map[uint16]int) map[uint16]int {
+	if len(configured) == 0 && len(discovered) == 0 {
+		return nil
+	}
+	mtus := make(map[uint16]int, len(configured))
+	for ifID, mtu := range configured {
+		mtus[ifID] = mtu
+	}
+	for ifID, mtu := range discovered {
+		if c, ok := mtus[ifID]; !ok || mtu < c {
+			mtus[ifID] = mtu
+		}
+	}
+	return mtus
+}
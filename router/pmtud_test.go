@@ -0,0 +1,48 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePathMTUs(t *testing.T) {
+	link1 := &testLink{mtu: 1400}
+	link2 := &testLink{mtu: 9000}
+	d := &DataPlane{
+		interfaces: map[uint16]Link{1: link1, 2: link2, 3: &testLink{}},
+		mtus:       map[uint16]int{1: 1472, 2: 1300},
+	}
+
+	d.updatePathMTUs()
+	mtus := d.tables.Load().mtus
+	assert.Equal(t, 1400, mtus[1], "discovered MTU is smaller")
+	assert.Equal(t, 1300, mtus[2], "configured MTU is smaller")
+	assert.NotContains(t, mtus, uint16(3))
+
+	// Unchanged MTUs do not publish new tables.
+	tables := d.tables.Load()
+	d.updatePathMTUs()
+	assert.Same(t, tables, d.tables.Load())
+
+	// MTUs beyond the packet buffers are capped, and an unknown MTU removes the discovered one.
+	link1.mtu = 0
+	d.mtus = nil
+	link2.mtu = 1 << 20
+	d.updatePathMTUs()
+	assert.Equal(t, map[uint16]int{2: bufSize}, d.tables.Load().mtus)
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/netip"
+
+	"github.com/scionproto/scion/pkg/private/serrors"
+)
+
+// Priority is the forwarding priority of a packet. Each connection has one queue per priority,
+// and the packets queued for a connection are sent in the order of the priority of their queue.
+// The packets of a low priority can therefore be delayed, or dropped when their queue is full,
+// without affecting those of a higher priority.
+type Priority uint8
+
+const (
+	// PriorityNormal is the priority of the packets of the traffic classes that are not
+	// configured. It is the zero value, so that packets are of normal priority by default.
+	PriorityNormal Priority = iota
+	PriorityHigh
+	PriorityLow
+	// NumPriorities is the number of priorities.
+	NumPriorities
+)
+
+// prioritiesInOrder lists the priorities from the highest to the lowest.
+var prioritiesInOrder = [NumPriorities]Priority{PriorityHigh, PriorityNormal, PriorityLow}
+
+// Priority returns the forwarding priority of the packet.
+func (p *Packet) Priority() Priority {
+	return p.priority
+}
+
+// qosClass describes the handling of the packets of a traffic class.
+type qosClass struct {
+	priority Priority
+	// underlayTC is the traffic class (the type of service byte for IPv4) of the underlay IP
+	// header of the packets. Zero means the default of the socket.
+	underlayTC uint8
+}
+
+// qosClasses maps the DSCP value of the traffic class of SCION packets to their handling.
+type qosClasses [64]qosClass
+
+// classify sets the priority and the underlay traffic class of the packet from the traffic class
+// of its SCION header.
+func (q *qosClasses) classify(p *Packet) {
+	if len(p.rawPacket) < 2 {
+		return
+	}
+	// The traffic class follows the 4 bits of the version.
+	tc := p.rawPacket[0]<<4 | p.rawPacket[1]>>4
+	c := q[tc>>2]
+	p.priority = c.priority
+	p.underlayTC = c.underlayTC
+}
+
+// AddTrafficClass sets the forwarding priority of the packets whose SCION traffic class carries
+// one of the given DSCP values, and the DSCP written into the underlay IP header when they are
+// forwarded. The packets of the other traffic classes have normal priority and are sent with the
+// default DSCP of the underlay sockets. This can only be called before the dataplane is running.
+func (d *DataPlane) AddTrafficClass(dscps []uint8, prio Priority, underlayDSCP uint8) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.IsRunning() {
+		return modifyExisting
+	}
+	if prio >= NumPriorities {
+		return serrors.New("invalid priority", "priority", prio)
+	}
+	if underlayDSCP >= 64 {
+		return serrors.New("invalid underlay DSCP", "dscp", underlayDSCP)
+	}
+	if d.qos == nil {
+		d.qos = &qosClasses{}
+	}
+	for _, dscp := range dscps {
+		if dscp >= 64 {
+			return serrors.New("invalid DSCP", "dscp", dscp)
+		}
+		d.qos[dscp] = qosClass{priority: prio, underlayTC: underlayDSCP << 2}
+	}
+	return nil
+}
+
+// underlayIPv6 returns whether the given connection is an IPv6 socket, which is needed to set the
+// underlay traffic class of the packets sent over it.
+func underlayIPv6(c BatchConn) bool {
+	l, ok := c.(interface{ LocalAddr() netip.AddrPort })
+	if !ok {
+		return false
+	}
+	return l.LocalAddr().Addr().Is6()
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQoSClassify(t *testing.T) {
+	d := &DataPlane{}
+	require.NoError(t, d.AddTrafficClass([]uint8{46, 34}, PriorityHigh, 46))
+	require.NoError(t, d.AddTrafficClass([]uint8{8}, PriorityLow, 0))
+	assert.Error(t, d.AddTrafficClass([]uint8{64}, PriorityLow, 0))
+	assert.Error(t, d.AddTrafficClass([]uint8{1}, NumPriorities, 0))
+	assert.Error(t, d.AddTrafficClass([]uint8{1}, PriorityLow, 64))
+
+	// packet returns a packet with the given traffic class in its SCION header.
+	packet := func(tc uint8) *Packet {
+		return &Packet{rawPacket: []byte{tc >> 4, tc<<4 | 0x0a, 0xbc, 0xde}}
+	}
+	testCases := map[string]struct {
+		tc         uint8
+		priority   Priority
+		underlayTC uint8
+	}{
+		"expedited forwarding": {tc: 46 << 2, priority: PriorityHigh, underlayTC: 46 << 2},
+		"ECN bits are ignored": {tc: 34<<2 | 0x3, priority: PriorityHigh, underlayTC: 46 << 2},
+		"low priority":         {tc: 8 << 2, priority: PriorityLow},
+		"not configured":       {tc: 10 << 2, priority: PriorityNormal},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := packet(tc.tc)
+			d.qos.classify(p)
+			assert.Equal(t, tc.priority, p.Priority())
+			assert.Equal(t, tc.underlayTC, p.underlayTC)
+		})
+	}
+
+	d.running.Store(true)
+	assert.ErrorIs(t, d.AddTrafficClass([]uint8{1}, PriorityLow, 0), modifyExisting)
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+// minEgressBurst is the smallest burst allowed by an egress rate limiter, in bytes. It ensures
+// that a packet of the largest supported size can always pass an idle limiter.
+const minEgressBurst = bufSize
+
+// maxTrackedSCMPSources bounds the number of source ASes for which an SCMP rate limiter is kept.
+const maxTrackedSCMPSources = 4096
+
+// rateLimiterEpoch is the origin of the clock used by the rate limiters. Using the monotonic
+// clock protects the limiters from wall clock adjustments.
+var rateLimiterEpoch = time.Now()
+
+func rateLimiterNow() int64 {
+	return int64(time.Since(rateLimiterEpoch))
+}
+
+// rateLimiter is a token bucket. It is implemented as a generic cell rate algorithm: instead of
+// counting tokens, it tracks the theoretical time at which the bucket is next full. This makes it
+// possible to update it with a single compare-and-swap, so it can be shared by all packet
+// processors without locking.
+type rateLimiter struct {
+	// nsPerToken is the time it takes to earn one token.
+	nsPerToken float64
+	// burstNs is the time it takes to fill the bucket from empty.
+	burstNs int64
+	// tat is the theoretical arrival time: the time, relative to rateLimiterEpoch, at which the
+	// bucket is full again.
+	tat atomic.Int64
+}
+
+// newRateLimiter returns a limiter that lets through rate tokens per second on average, with
+// bursts of up to burst tokens.
+func newRateLimiter(rate float64, burst float64) *rateLimiter {
+	nsPerToken := float64(time.Second) / rate
+	return &rateLimiter{
+		nsPerToken: nsPerToken,
+		burstNs:    int64(burst * nsPerToken),
+	}
+}
+
+// newEgressRateLimiter returns a limiter that lets through rate bits per second on average, with
+// bursts of up to burst bytes. Tokens are bytes. If burst is zero, a burst worth 10ms of traffic
+// is allowed. Bursts smaller than minEgressBurst are raised to that value.
+func newEgressRateLimiter(rate int64, burst int) *rateLimiter {
+	if burst == 0 {
+		burst = int(rate / 8 / 100)
+	}
+	burst = max(burst, minEgressBurst)
+	return newRateLimiter(float64(rate)/8, float64(burst))
+}
+
+// allow consumes n tokens and returns true if enough tokens were available. Otherwise, it returns
+// false and the bucket is left untouched.
+func (l *rateLimiter) allow(n int) bool {
+	return l.allowAt(n, rateLimiterNow())
+}
+
+func (l *rateLimiter) allowAt(n int, now int64) bool {
+	cost := l.cost(n)
+	for {
+		tat := l.tat.Load()
+		newTat := tat + cost
+		if tat < now {
+			newTat = now + cost
+		}
+		if newTat-now > l.burstNs {
+			return false
+		}
+		if l.tat.CompareAndSwap(tat, newTat) {
+			return true
+		}
+	}
+}
+
+// peekAt returns true if n tokens are available at the given time, without consuming them.
+func (l *rateLimiter) peekAt(n int, now int64) bool {
+	tat := l.tat.Load()
+	if tat < now {
+		tat = now
+	}
+	return tat+l.cost(n)-now <= l.burstNs
+}
+
+// refund gives back n tokens consumed by allow.
+func (l *rateLimiter) refund(n int) {
+	l.tat.Add(-l.cost(n))
+}
+
+func (l *rateLimiter) cost(n int) int64 {
+	return int64(float64(n) * l.nsPerToken)
+}
+
+// allowAll consumes n tokens from each of the limiters and returns true if all of them had enough
+// tokens. Otherwise, it returns false and no tokens are consumed, so that a packet refused by one
+// limiter does not use up the budget of the others.
+func allowAll(limiters []*rateLimiter, n int) bool {
+	return allowAllAt(limiters, n, rateLimiterNow())
+}
+
+func allowAllAt(limiters []*rateLimiter, n int, now int64) bool {
+	for _, l := range limiters {
+		if !l.peekAt(n, now) {
+			return false
+		}
+	}
+	for i, l := range limiters {
+		if !l.allowAt(n, now) {
+			// Another packet processor consumed the tokens since the peek.
+			for _, c := range limiters[:i] {
+				c.refund(n)
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// perIALimiter rate-limits events separately for each ISD-AS. The number of ISD-ASes tracked is
+// bounded; when the bound is reached, the limiter of the least recently seen ISD-AS is discarded
+// to make room for the new one. Events from a new ISD-AS are thus never refused outright.
+type perIALimiter struct {
+	rate  float64
+	burst float64
+	mtx   sync.Mutex
+	// limiters maps the tracked ISD-ASes to their element in lru.
+	limiters map[addr.IA]*list.Element
+	// lru holds the perIAEntry of the tracked ISD-ASes, the most recently seen first.
+	lru *list.List
+}
+
+type perIAEntry struct {
+	ia      addr.IA
+	limiter *rateLimiter
+}
+
+func newPerIALimiter(rate float64, burst float64) *perIALimiter {
+	return &perIALimiter{
+		rate:     rate,
+		burst:    burst,
+		limiters: make(map[addr.IA]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// allow returns true if one more event from the given ISD-AS is within the limits.
+func (l *perIALimiter) allow(ia addr.IA) bool {
+	return l.allowAt(ia, rateLimiterNow())
+}
+
+func (l *perIALimiter) allowAt(ia addr.IA, now int64) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if e, ok := l.limiters[ia]; ok {
+		l.lru.MoveToFront(e)
+		return e.Value.(*perIAEntry).limiter.allowAt(1, now)
+	}
+	if len(l.limiters) >= maxTrackedSCMPSources {
+		oldest := l.lru.Back()
+		l.lru.Remove(oldest)
+		delete(l.limiters, oldest.Value.(*perIAEntry).ia)
+	}
+	rl := newRateLimiter(l.rate, l.burst)
+	l.limiters[ia] = l.lru.PushFront(&perIAEntry{ia: ia, limiter: rl})
+	return rl.allowAt(1, now)
+}
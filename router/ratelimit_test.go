@@ -0,0 +1,151 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	// 8 Mbps is 1 byte/µs. 20000 bytes of burst.
+	l := newEgressRateLimiter(8_000_000, 20000)
+	now := int64(time.Hour)
+	passed := 0
+	for l.allowAt(1000, now) {
+		passed++
+	}
+	assert.Equal(t, 20, passed)
+
+	// After 1ms, 1000 bytes worth of tokens are available again.
+	now += int64(time.Millisecond)
+	assert.True(t, l.allowAt(1000, now))
+	assert.False(t, l.allowAt(1000, now))
+
+	// An idle limiter does not accumulate more than the burst.
+	now += int64(time.Second)
+	passed = 0
+	for l.allowAt(1000, now) {
+		passed++
+	}
+	assert.Equal(t, 20, passed)
+}
+
+func TestRateLimiterDefaultBurst(t *testing.T) {
+	// 10ms worth of 100 Mbps is 125000 bytes.
+	l := newEgressRateLimiter(100_000_000, 0)
+	assert.Equal(t, int64(10*time.Millisecond), l.burstNs)
+
+	// Small bursts are raised so that a maximum size packet can pass.
+	l = newEgressRateLimiter(8_000_000, 100)
+	assert.True(t, l.allowAt(bufSize, 0))
+	assert.False(t, l.allowAt(1, 0))
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	l := newEgressRateLimiter(8_000_000, 100000)
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	passed := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := 0
+			for j := 0; j < 100; j++ {
+				if l.allowAt(1000, 0) {
+					n++
+				}
+			}
+			mtx.Lock()
+			passed += n
+			mtx.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, passed)
+}
+
+func TestAllowAll(t *testing.T) {
+	// 8 Mbps is 1 byte/µs. The second limiter binds: it has a smaller burst.
+	loose := newEgressRateLimiter(8_000_000, 30000)
+	tight := newEgressRateLimiter(8_000_000, 10000)
+	limiters := []*rateLimiter{loose, tight}
+	now := int64(time.Hour)
+	passed := 0
+	for allowAllAt(limiters, 1000, now) {
+		passed++
+	}
+	assert.Equal(t, 10, passed)
+	// The refused packets did not consume the tokens of the first limiter: it still has
+	// 20000 bytes worth of tokens.
+	passed = 0
+	for loose.allowAt(1000, now) {
+		passed++
+	}
+	assert.Equal(t, 20, passed)
+
+	// The tokens given back when a concurrent packet wins the race for the second limiter are
+	// available again.
+	now += int64(time.Second)
+	assert.True(t, loose.allowAt(30000, now))
+	assert.False(t, loose.peekAt(1000, now))
+	loose.refund(30000)
+	assert.True(t, loose.peekAt(30000, now))
+	assert.True(t, allowAllAt(nil, 1000, now))
+}
+
+func TestPerIALimiter(t *testing.T) {
+	ia1 := addr.MustParseIA("1-ff00:0:110")
+	ia2 := addr.MustParseIA("1-ff00:0:111")
+	// 10 events per second, bursts of 2.
+	l := newPerIALimiter(10, 2)
+	now := int64(time.Hour)
+	assert.True(t, l.allowAt(ia1, now))
+	assert.True(t, l.allowAt(ia1, now))
+	assert.False(t, l.allowAt(ia1, now))
+	// Another source is not affected.
+	assert.True(t, l.allowAt(ia2, now))
+	// After 100ms one more event is allowed.
+	now += int64(100 * time.Millisecond)
+	assert.True(t, l.allowAt(ia1, now))
+	assert.False(t, l.allowAt(ia1, now))
+}
+
+func TestPerIALimiterBounded(t *testing.T) {
+	l := newPerIALimiter(1, 1)
+	now := int64(time.Hour)
+	for i := 0; i < maxTrackedSCMPSources; i++ {
+		assert.True(t, l.allowAt(addr.MustIAFrom(1, addr.AS(i+1)), now))
+	}
+	// The first source is seen again, so the second one is now the least recently seen.
+	first, second := addr.MustIAFrom(1, 1), addr.MustIAFrom(1, 2)
+	assert.False(t, l.allowAt(first, now))
+
+	// A new source is not refused even though all the tracked sources still have state: the
+	// least recently seen one is discarded instead.
+	newIA := addr.MustIAFrom(2, 1)
+	assert.True(t, l.allowAt(newIA, now))
+	assert.False(t, l.allowAt(newIA, now))
+	assert.Len(t, l.limiters, maxTrackedSCMPSources)
+	assert.NotContains(t, l.limiters, second)
+	assert.Contains(t, l.limiters, first)
+	assert.False(t, l.allowAt(first, now))
+}
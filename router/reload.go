@@ -0,0 +1,217 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"maps"
+	"net/netip"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/log"
+	"github.com/scionproto/scion/pkg/private/serrors"
+	"github.com/scionproto/scion/private/topology"
+	"github.com/scionproto/scion/router/bfd"
+)
+
+const (
+	// reloadPoolInterfaces is the number of external interfaces for which room is reserved
+	// in the packet pool, so that interfaces added while running get their share of packets.
+	reloadPoolInterfaces = 16
+	// drainGracePeriod is how long the forwarder of a removed connection keeps discarding
+	// packets after the last one it saw. It covers the packets that the processors had already
+	// routed to the connection before it was removed.
+	drainGracePeriod = time.Second
+)
+
+// forwardingTables is an immutable snapshot of the per-interface state needed to forward
+// packets. The packet processing goroutines load the current snapshot once per packet, so
+// that interfaces can be added and removed while running without locking the fast path.
+type forwardingTables struct {
+	interfaces        map[uint16]Link
+	linkTypes         map[uint16]topology.LinkType
+	neighborIAs       map[uint16]addr.IA
+	remoteIfIDs       map[uint16]uint16
+	forwardingMetrics map[uint16]interfaceMetrics
+	egressLimiters    map[uint16][]*rateLimiter
+	mtus              map[uint16]int
+}
+
+// publishTables publishes a new snapshot of the forwarding tables. It must be called with mtx
+// held after any change of the tables, unless the dataplane is not running yet.
+func (d *DataPlane) publishTables() {
+	d.tables.Store(&forwardingTables{
+		interfaces:        maps.Clone(d.interfaces),
+		linkTypes:         maps.Clone(d.linkTypes),
+		neighborIAs:       maps.Clone(d.neighborIAs),
+		remoteIfIDs:       maps.Clone(d.remoteIfIDs),
+		forwardingMetrics: maps.Clone(d.forwardingMetrics),
+		egressLimiters:    maps.Clone(d.egressLimiters),
+		mtus:              effectiveMTUs(d.mtus, d.pathMTUs),
+	})
+}
+
+// startLink starts the goroutines serving the given underlay link of the given interface if the
+// dataplane is running and the link is new. This must be called with mtx held.
+func (d *DataPlane) startLink(ifID uint16, link Link) {
+	if d.forwardingMetrics != nil {
+		d.forwardingMetrics[ifID] = newInterfaceMetrics(
+			d.Metrics, ifID, d.localIA, link.Scope(), d.neighborIAs)
+	}
+	d.publishTables()
+	if d.runCtx == nil {
+		return
+	}
+	remote := link.Remote()
+	if link.Scope() == External {
+		c, ok := d.underlay.Connections()[remote]
+		if ok {
+			d.growPacketPool(3 * d.RunConfig.BatchSize)
+			d.startConnection(c)
+		}
+	}
+	if _, running := d.bfdStops[remote]; !running {
+		d.startBFD(remote, link)
+	}
+}
+
+// startConnection starts the receiver and the forwarder of the given connection.
+func (d *DataPlane) startConnection(c UnderlayConn) {
+	go func() {
+		defer log.HandlePanic()
+		d.runReceiver(c, d.procQs)
+	}()
+	go func() {
+		defer log.HandlePanic()
+		d.runForwarder(c)
+	}()
+}
+
+// startBFD starts the BFD session of the given link, if it has one. The session stops when the
+// link is removed.
+func (d *DataPlane) startBFD(remote netip.AddrPort, link Link) {
+	s := link.BFDSession()
+	if s == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(d.runCtx)
+	if d.bfdStops == nil {
+		d.bfdStops = make(map[netip.AddrPort]context.CancelFunc)
+	}
+	d.bfdStops[remote] = cancel
+	go func() {
+		defer log.HandlePanic()
+		if err := s.Run(ctx); err != nil && err != bfd.AlreadyRunning {
+			log.Error("BFD session failed to start", "remote address", remote, "err", err)
+		}
+	}()
+}
+
+// growPacketPool allocates n more packets for the packet pool, within the capacity reserved for
+// the interfaces added while running.
+func (d *DataPlane) growPacketPool(n int) {
+	n = min(n, cap(d.packetPool)-d.poolAllocated)
+	if n <= 0 {
+		return
+	}
+	pktBuffers := make([][bufSize]byte, n)
+	pktStructs := make([]Packet, n)
+	for i := 0; i < n; i++ {
+		d.packetPool <- pktStructs[i].init(&pktBuffers[i])
+	}
+	d.poolAllocated += n
+}
+
+// drainQueue returns the packets still queued for a removed connection to the pool. It returns
+// once no packet has been queued for drainGracePeriod.
+func (d *DataPlane) drainQueue(qs [NumPriorities]<-chan *Packet) {
+	timer := time.NewTimer(drainGracePeriod)
+	defer timer.Stop()
+	for {
+		var p *Packet
+		select {
+		case p = <-qs[0]:
+		case p = <-qs[1]:
+		case p = <-qs[2]:
+		case <-timer.C:
+			return
+		}
+		d.returnPacketToPool(p)
+		timer.Reset(drainGracePeriod)
+	}
+}
+
+// RemoveInterface removes the given interface from the dataplane. This can be called while the
+// dataplane is running; the traffic of the remaining interfaces is not affected. If the link of
+// the interface is not used by any other interface, its BFD session is stopped, it is removed
+// from the underlay and, for an external link, its connection is closed. The internal interface
+// cannot be removed.
+func (d *DataPlane) RemoveInterface(ifID uint16) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if ifID == 0 {
+		return modifyExisting
+	}
+	removed := d.interfaces[ifID]
+	delete(d.interfaces, ifID)
+	delete(d.linkTypes, ifID)
+	delete(d.neighborIAs, ifID)
+	delete(d.remoteIfIDs, ifID)
+	delete(d.egressLimiters, ifID)
+	delete(d.mtus, ifID)
+	delete(d.pathMTUs, ifID)
+	// The metrics of the interface are kept, so that the packets still in flight find them.
+	d.publishTables()
+	if removed == nil {
+		return nil
+	}
+	if d.linkInUse(removed) {
+		// A sibling link shared with other interfaces.
+		return nil
+	}
+	links := underlayLinks(removed)
+	if s, ok := removed.(*sharedLink); ok && !d.linkInUse(s.sibling) {
+		links = append(links, s.sibling)
+	}
+	var errs serrors.List
+	for _, link := range links {
+		remote := link.Remote()
+		if stop, ok := d.bfdStops[remote]; ok {
+			stop()
+			delete(d.bfdStops, remote)
+		}
+		c, hasConn := d.underlay.Connections()[remote]
+		d.underlay.RemoveLink(remote)
+		if hasConn && link.Scope() == External {
+			if err := c.Conn().Close(); err != nil {
+				errs = append(errs, serrors.Wrap("closing connection", err,
+					"if_id", ifID, "remote", remote))
+			}
+		}
+	}
+	return errs.ToError()
+}
+
+// linkInUse returns true if the given link is used by any interface, directly or as the sibling
+// link of a shared interface.
+func (d *DataPlane) linkInUse(link Link) bool {
+	for _, l := range d.interfaces {
+		if s, ok := l.(*sharedLink); ok && s.sibling == link || l == link {
+			return true
+		}
+	}
+	return false
+}
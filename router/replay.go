@@ -0,0 +1,102 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"hash/maphash"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+)
+
+// replayBuckets is the number of buckets of a replay filter.
+const replayBuckets = 4096
+
+// replayEntry is a packet remembered by a replay filter.
+type replayEntry struct {
+	digest uint64
+	// seen is the time, relative to rateLimiterEpoch, at which the packet was received. It is 0
+	// for the entries that are not used yet.
+	seen int64
+}
+
+// replayBucket holds the most recent packets of the flows that map to it, in a ring.
+type replayBucket struct {
+	mtx     sync.Mutex
+	next    int
+	entries []replayEntry
+}
+
+// replayFilter detects the packets that the router receives more than once within a time
+// window, as happens on links where an upstream device duplicates traffic, or when packets are
+// replayed.
+//
+// A packet is identified by a digest of all its bytes, which is only the same for copies of the
+// same packet. The digests are kept in a fixed number of buckets, each holding the most recent
+// packets of the flows that map to it. The flow of a packet is its source AS and its path, so a
+// flood of packets only evicts the packets of the flows that share its bucket; duplicates of the
+// evicted packets go undetected. The digests are seeded randomly, so that the buckets and the
+// collisions cannot be predicted from outside.
+type replayFilter struct {
+	window  int64
+	seed    maphash.Seed
+	buckets []replayBucket
+}
+
+// newReplayFilter returns a filter that detects the duplicates received within window of the
+// original packet, among the last packets packets of each bucket.
+func newReplayFilter(window time.Duration, packets int) *replayFilter {
+	f := &replayFilter{
+		window:  int64(window),
+		seed:    maphash.MakeSeed(),
+		buckets: make([]replayBucket, replayBuckets),
+	}
+	entries := make([]replayEntry, replayBuckets*packets)
+	for i := range f.buckets {
+		f.buckets[i].entries = entries[i*packets : (i+1)*packets : (i+1)*packets]
+	}
+	return f
+}
+
+// duplicate returns true if the packet, which comes from the given source AS over the given
+// path, was already received within the window. Otherwise, it remembers the packet and returns
+// false.
+func (f *replayFilter) duplicate(srcIA addr.IA, path, pkt []byte) bool {
+	return f.duplicateAt(srcIA, path, pkt, rateLimiterNow())
+}
+
+func (f *replayFilter) duplicateAt(srcIA addr.IA, path, pkt []byte, now int64) bool {
+	b := f.bucket(srcIA, path)
+	digest := maphash.Bytes(f.seed, pkt)
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	for _, e := range b.entries {
+		if e.digest == digest && e.seen != 0 && now-e.seen <= f.window {
+			return true
+		}
+	}
+	b.entries[b.next] = replayEntry{digest: digest, seen: now}
+	b.next = (b.next + 1) % len(b.entries)
+	return false
+}
+
+// bucket returns the bucket of the flow of the given source AS and path.
+func (f *replayFilter) bucket(srcIA addr.IA, path []byte) *replayBucket {
+	flow := maphash.Bytes(f.seed, path) ^ uint64(srcIA)*0x9e3779b97f4a7c15
+	// Fold the high bits of the multiplication into the index.
+	return &f.buckets[(flow^flow>>32)%replayBuckets]
+}
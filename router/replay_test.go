@@ -0,0 +1,97 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+	"github.com/scionproto/scion/private/topology"
+	"github.com/scionproto/scion/router/mock_router"
+)
+
+func TestReplayFilter(t *testing.T) {
+	srcIA := addr.MustParseIA("1-ff00:0:111")
+	path := []byte("path")
+	now := int64(time.Hour)
+
+	t.Run("duplicates are detected within the window", func(t *testing.T) {
+		f := newReplayFilter(time.Second, 4)
+		assert.False(t, f.duplicateAt(srcIA, path, []byte("packet"), now))
+		assert.True(t, f.duplicateAt(srcIA, path, []byte("packet"), now+int64(time.Second)))
+		assert.False(t, f.duplicateAt(srcIA, path, []byte("other packet"), now))
+		assert.False(t, f.duplicateAt(srcIA, path, []byte("packet"), now+2*int64(time.Second)),
+			"duplicates are only detected within the window")
+	})
+	t.Run("the most recent packets of a flow are remembered", func(t *testing.T) {
+		f := newReplayFilter(time.Second, 4)
+		packets := [][]byte{[]byte("p0"), []byte("p1"), []byte("p2"), []byte("p3"), []byte("p4")}
+		for _, p := range packets {
+			assert.False(t, f.duplicateAt(srcIA, path, p, now))
+		}
+		for _, p := range packets[2:] {
+			assert.True(t, f.duplicateAt(srcIA, path, p, now))
+		}
+		assert.False(t, f.duplicateAt(srcIA, path, packets[0], now), "evicted")
+	})
+	t.Run("a flood only evicts the packets of its own flows", func(t *testing.T) {
+		f := newReplayFilter(time.Second, 4)
+		floodIA := addr.MustParseIA("1-ff00:0:666")
+		// The flows are hashed with a random seed; pick a victim flow in another bucket.
+		victim := []byte("victim path 0")
+		for f.bucket(srcIA, victim) == f.bucket(floodIA, path) {
+			victim[len(victim)-1]++
+		}
+		assert.False(t, f.duplicateAt(srcIA, victim, []byte("packet"), now))
+		for i := 0; i < 1000; i++ {
+			assert.False(t, f.duplicateAt(floodIA, path, []byte{byte(i), byte(i >> 8)}, now))
+		}
+		assert.True(t, f.duplicateAt(srcIA, victim, []byte("packet"), now))
+	})
+}
+
+func TestProcessPktDuplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dp := NewDP([]uint16{1}, map[uint16]topology.LinkType{1: topology.Child},
+		mock_router.NewMockBatchConn(ctrl), nil, map[addr.SVC][]netip.AddrPort{},
+		addr.MustParseIA("1-ff00:0:110"), nil, testKey)
+	dp.replay = newReplayFilter(time.Second, 64)
+	packet := func(flowID uint32) *Packet {
+		s := prepBaseMsg(t, []byte("actualpayloadbytes"), flowID)
+		_ = s.SetDstAddr(addr.HostIP(netip.AddrFrom4([4]byte{10, 0, 200, 200})))
+		_ = s.SetSrcAddr(addr.HostIP(netip.AddrFrom4([4]byte{10, 0, 200, 100})))
+		s.NextHdr = slayers.L4UDP
+		p := new(Packet).init(&[bufSize]byte{})
+		p.reset()
+		p.rawPacket = p.buffer[:copy(p.buffer[:], toMsg(t, s))]
+		p.ingress = 1
+		updateNetAddrFromNetAddr(p.srcAddr, &net.UDPAddr{IP: net.IP{192, 0, 2, 1}, Port: 50000})
+		return p
+	}
+
+	assert.Equal(t, pForward, newPacketProcessor(dp).processPkt(packet(1)))
+	p := packet(1)
+	assert.Equal(t, pDiscard, newPacketProcessor(dp).processPkt(p))
+	assert.Equal(t, dropDuplicate, p.dropReason)
+	assert.Equal(t, pForward, newPacketProcessor(dp).processPkt(packet(2)))
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/binary"
+	"net/netip"
+)
+
+// defaultRSSKey is the default key of the Toeplitz hash of the Microsoft receive side scaling
+// specification, which many network devices use unless they are configured otherwise.
+var defaultRSSKey = []byte{
+	0x6d, 0x5a, 0x56, 0xda, 0x25, 0x5b, 0x0e, 0xc2,
+	0x41, 0x67, 0x25, 0x3d, 0x43, 0xa3, 0x8f, 0xb0,
+	0xd0, 0xca, 0x2b, 0xcb, 0xae, 0x7b, 0x30, 0xb4,
+	0x77, 0xcb, 0x2d, 0xa3, 0x80, 0x30, 0xf2, 0x0c,
+	0x6a, 0x42, 0xb7, 0x3b, 0xbe, 0xac, 0x01, 0xfa,
+}
+
+// rssKeyLen is the length of an RSS key. It is enough for the IPv6 addresses and ports.
+const rssKeyLen = 40
+
+// rssHash returns the Toeplitz hash of the underlay addresses and ports of a datagram, as
+// computed by network devices to pick the receive queue of the datagram. The destination address
+// is left out if it is not of the family of the source address.
+func rssHash(key []byte, src, dst netip.AddrPort) uint32 {
+	var input [36]byte
+	var n int
+	if s := src.Addr().Unmap(); s.Is4() {
+		var d [4]byte
+		if a := dst.Addr().Unmap(); a.Is4() {
+			d = a.As4()
+		}
+		b := s.As4()
+		n += copy(input[n:], b[:])
+		n += copy(input[n:], d[:])
+	} else {
+		var d [16]byte
+		if a := dst.Addr(); a.Is6() {
+			d = a.As16()
+		}
+		b := s.As16()
+		n += copy(input[n:], b[:])
+		n += copy(input[n:], d[:])
+	}
+	binary.BigEndian.PutUint16(input[n:], src.Port())
+	binary.BigEndian.PutUint16(input[n+2:], dst.Port())
+	return toeplitzHash(key, input[:n+4])
+}
+
+// toeplitzHash returns the Toeplitz hash of the input with the given key. The key must be at
+// least 4 bytes longer than the input.
+func toeplitzHash(key, input []byte) uint32 {
+	var hash uint32
+	// window holds the 32 bits of the key that start at the current bit of the input.
+	window := binary.BigEndian.Uint32(key)
+	for i, b := range input {
+		next := key[i+4]
+		for bit := 7; bit >= 0; bit-- {
+			if b&(1<<bit) != 0 {
+				hash ^= window
+			}
+			window = window<<1 | uint32(next>>bit&1)
+		}
+	}
+	return hash
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRSSHash(t *testing.T) {
+	// The verification suite of the Microsoft RSS specification.
+	testCases := []struct {
+		src, dst string
+		hash     uint32
+	}{
+		{src: "66.9.149.187:2794", dst: "161.142.100.80:1766", hash: 0x51ccc178},
+		{src: "199.92.111.2:14230", dst: "65.69.140.83:4739", hash: 0xc626b0ea},
+		{src: "24.19.198.95:12898", dst: "12.22.207.184:38024", hash: 0x5c2b394a},
+		{src: "[3ffe:2501:200:1fff::7]:2794", dst: "[3ffe:2501:200:3::1]:1766",
+			hash: 0x40207d3d},
+		{src: "[3ffe:501:8::260:97ff:fe40:efab]:14230", dst: "[ff02::1]:4739",
+			hash: 0xdde51bbf},
+	}
+	for _, tc := range testCases {
+		src, dst := netip.MustParseAddrPort(tc.src), netip.MustParseAddrPort(tc.dst)
+		assert.Equal(t, tc.hash, rssHash(defaultRSSKey, src, dst), tc.src)
+	}
+}
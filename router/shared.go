@@ -0,0 +1,147 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/netip"
+)
+
+// sharedLink is an external interface that is served by two sibling routers, each with its own
+// underlay to the neighbor. The packets that leave through the interface are shared between the
+// two routers by flow: the flows that this router owns are sent over the local link, the others
+// are handed over to the sibling router over the sibling link, and sent by the sibling router.
+//
+// The owner of a flow is chosen by rendezvous hashing on the internal addresses of the two
+// routers, so that both routers agree on it: the packets of a flow leave through the same
+// router, whichever router they enter the AS through. If the local link is down, all the flows
+// are handed over to the sibling router; if the sibling router cannot be reached, none is.
+// The packets that the sibling router hands over are always sent over the local link, so that
+// packets never bounce between the two routers.
+type sharedLink struct {
+	local   Link
+	sibling Link
+	// localKey and siblingKey are the hashes of the internal addresses of this router and of the
+	// sibling router.
+	localKey   uint32
+	siblingKey uint32
+}
+
+func newSharedLink(local, sibling Link, localAddr, siblingAddr netip.AddrPort) *sharedLink {
+	return &sharedLink{
+		local:      local,
+		sibling:    sibling,
+		localKey:   addrKey(localAddr),
+		siblingKey: addrKey(siblingAddr),
+	}
+}
+
+// addrKey returns the rendezvous hashing key of a router with the given internal address.
+func addrKey(a netip.AddrPort) uint32 {
+	s := fnv1aOffset32
+	for _, c := range a.Addr().Unmap().As16() {
+		s = hashFNV1a(s, c)
+	}
+	s = hashFNV1a(s, byte(a.Port()>>8))
+	return hashFNV1a(s, byte(a.Port()))
+}
+
+// handOver returns true if the packet must be handed over to the sibling router.
+func (l *sharedLink) handOver(p *Packet) bool {
+	if p.ingress == 0 && l.fromSibling(p) {
+		return false
+	}
+	switch {
+	case !l.sibling.IsUp():
+		return false
+	case !l.local.IsUp():
+		return true
+	}
+	h, err := flowHash(p.rawPacket, fnv1aOffset32)
+	if err != nil {
+		return false
+	}
+	return mix32(h^l.siblingKey) > mix32(h^l.localKey)
+}
+
+// fromSibling returns true if the packet was received from the sibling router.
+func (l *sharedLink) fromSibling(p *Packet) bool {
+	src, ok := netip.AddrFromSlice(p.srcAddr.IP)
+	return ok && src.Unmap() == l.sibling.Remote().Addr().Unmap()
+}
+
+// mix32 is the finalizer of MurmurHash3. It spreads the bits of the flow hash, which the
+// rendezvous hashing compares as numbers.
+func mix32(h uint32) uint32 {
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+func (l *sharedLink) Scope() LinkScope {
+	return External
+}
+
+func (l *sharedLink) BFDSession() BFDSession {
+	return l.local.BFDSession()
+}
+
+// IsUp returns true if either router can send over the interface.
+func (l *sharedLink) IsUp() bool {
+	return l.local.IsUp() || l.sibling.IsUp()
+}
+
+func (l *sharedLink) IfID() uint16 {
+	return l.local.IfID()
+}
+
+// Remote returns the remote address of the local link.
+func (l *sharedLink) Remote() netip.AddrPort {
+	return l.local.Remote()
+}
+
+func (l *sharedLink) Send(p *Packet) bool {
+	if p.viaSibling {
+		return l.sibling.Send(p)
+	}
+	return l.local.Send(p)
+}
+
+func (l *sharedLink) SendBlocking(p *Packet) {
+	if p.viaSibling {
+		l.sibling.SendBlocking(p)
+		return
+	}
+	l.local.SendBlocking(p)
+}
+
+// QueueLen returns the number of packets waiting to be sent over the local link.
+func (l *sharedLink) QueueLen() int {
+	return l.local.QueueLen()
+}
+
+func (l *sharedLink) PathMTU() int {
+	return l.local.PathMTU()
+}
+
+// localLink returns the link over which this router itself sends the packets of the given link.
+func localLink(link Link) Link {
+	if s, ok := link.(*sharedLink); ok {
+		return s.local
+	}
+	return link
+}
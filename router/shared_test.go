@@ -0,0 +1,94 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharedLink(t *testing.T) {
+	addrA := netip.MustParseAddrPort("10.0.0.1:30042")
+	addrB := netip.MustParseAddrPort("10.0.0.2:30042")
+	// newPair returns the shared links of the same interface on two sibling routers A and B.
+	newPair := func() (a, b *sharedLink, localA, localB *testLink) {
+		localA = &testLink{remote: netip.MustParseAddrPort("192.0.2.1:50000")}
+		localB = &testLink{remote: netip.MustParseAddrPort("192.0.2.2:50000")}
+		a = newSharedLink(localA, &testLink{remote: addrB}, addrA, addrB)
+		b = newSharedLink(localB, &testLink{remote: addrA}, addrB, addrA)
+		return a, b, localA, localB
+	}
+	packet := func(flowID uint32) *Packet {
+		return &Packet{
+			rawPacket: serializedBaseMsg(t, []byte("payload"), flowID),
+			srcAddr:   &net.UDPAddr{IP: net.ParseIP("10.0.0.3")},
+			ingress:   1,
+		}
+	}
+
+	t.Run("flows are owned by exactly one router", func(t *testing.T) {
+		a, b, _, _ := newPair()
+		handedOver := 0
+		for flowID := uint32(0); flowID < 400; flowID++ {
+			overA, overB := a.handOver(packet(flowID)), b.handOver(packet(flowID))
+			assert.NotEqual(t, overA, overB, flowID)
+			assert.Equal(t, overA, a.handOver(packet(flowID)), flowID)
+			if overA {
+				handedOver++
+			}
+		}
+		assert.Greater(t, handedOver, 150)
+		assert.Less(t, handedOver, 250)
+	})
+	t.Run("all flows are handed over when the local link is down", func(t *testing.T) {
+		a, _, localA, _ := newPair()
+		localA.down = true
+		for flowID := uint32(0); flowID < 100; flowID++ {
+			assert.True(t, a.handOver(packet(flowID)), flowID)
+		}
+		assert.True(t, a.IsUp())
+	})
+	t.Run("no flow is handed over when the sibling is down", func(t *testing.T) {
+		a, _, _, _ := newPair()
+		a.sibling.(*testLink).down = true
+		for flowID := uint32(0); flowID < 100; flowID++ {
+			assert.False(t, a.handOver(packet(flowID)), flowID)
+		}
+	})
+	t.Run("packets from the sibling are sent locally", func(t *testing.T) {
+		a, _, localA, _ := newPair()
+		localA.down = true
+		for flowID := uint32(0); flowID < 100; flowID++ {
+			p := packet(flowID)
+			p.ingress = 0
+			p.srcAddr = net.UDPAddrFromAddrPort(netip.AddrPortFrom(addrB.Addr(), 40000))
+			assert.False(t, a.handOver(p), flowID)
+		}
+	})
+	t.Run("packets are sent over the chosen link", func(t *testing.T) {
+		a, _, localA, _ := newPair()
+		p := packet(1)
+		a.SendBlocking(p)
+		p.viaSibling = true
+		assert.True(t, a.Send(p))
+		assert.Equal(t, 1, localA.sent)
+		assert.Equal(t, 1, a.sibling.(*testLink).sent)
+		assert.Same(t, Link(localA), localLink(a))
+		assert.Equal(t, localA.remote, a.Remote())
+	})
+}
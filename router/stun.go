@@ -0,0 +1,100 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/pkg/private/stun"
+	"github.com/scionproto/scion/private/topology"
+)
+
+const (
+	// natBindingTTL is the time for which the address learned from a STUN
+	// request is used to deliver packets to the end host that sent it.
+	natBindingTTL = 5 * time.Minute
+	// maxNATBindings bounds the number of NAT bindings that are remembered.
+	maxNATBindings = 1 << 16
+)
+
+// natBindings are the addresses from which end hosts behind a NAT sent STUN
+// requests to the router, i.e. the addresses under which the NAT maps their
+// sockets. Packets to these addresses are delivered to the port of the
+// address, even if it is outside the dispatched port range.
+type natBindings struct {
+	mtx sync.RWMutex
+	// expires maps the bindings to the time at which they expire.
+	expires map[netip.AddrPort]time.Time
+}
+
+func (b *natBindings) add(a netip.AddrPort, now time.Time) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.expires == nil {
+		b.expires = make(map[netip.AddrPort]time.Time)
+	}
+	if _, ok := b.expires[a]; !ok && len(b.expires) >= maxNATBindings {
+		for k, exp := range b.expires {
+			if now.After(exp) {
+				delete(b.expires, k)
+			}
+		}
+		if len(b.expires) >= maxNATBindings {
+			return
+		}
+	}
+	b.expires[a] = now.Add(natBindingTTL)
+}
+
+func (b *natBindings) contains(a netip.AddrPort, now time.Time) bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	exp, ok := b.expires[a]
+	return ok && !now.After(exp)
+}
+
+// processSTUN answers a STUN binding request received on the internal
+// interface with the address it was received from, and remembers that address
+// as a NAT binding.
+func (p *scionPacketProcessor) processSTUN() disposition {
+	txID, err := stun.ParseBindingRequest(p.pkt.rawPacket)
+	if err != nil {
+		return p.discard(dropParseError, "error", err)
+	}
+	src := p.pkt.srcAddr.AddrPort()
+	src = netip.AddrPortFrom(src.Addr().Unmap(), src.Port())
+	p.d.natBindings.add(src, time.Now())
+
+	p.pkt.rawPacket = stun.AppendResponse(p.pkt.buffer[:0], txID, src)
+	p.pkt.trafficType = ttOther
+	p.pkt.egress = p.pkt.ingress
+	updateNetAddrFromNetAddr(p.pkt.DstAddr, p.pkt.srcAddr)
+	return pForward
+}
+
+// endhostPort returns the underlay port to which a packet for port at dst is
+// delivered. Ports outside the dispatched port range are delivered to the
+// end-host port, unless dst and port are a NAT binding.
+func (d *DataPlane) endhostPort(dst netip.Addr, port uint16) uint16 {
+	if port >= d.dispatchedPortStart && port <= d.dispatchedPortEnd {
+		return port
+	}
+	if d.RunConfig.STUN && d.natBindings.contains(netip.AddrPortFrom(dst, port), time.Now()) {
+		return port
+	}
+	return topology.EndhostPort
+}
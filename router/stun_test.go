@@ -0,0 +1,89 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/private/stun"
+	"github.com/scionproto/scion/private/topology"
+	"github.com/scionproto/scion/router/mock_router"
+)
+
+func TestProcessSTUN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	host := netip.MustParseAddrPort("192.0.2.7:40000")
+	request := func(txID stun.TxID) *Packet {
+		p := new(Packet).init(&[bufSize]byte{})
+		p.reset()
+		p.rawPacket = p.buffer[:copy(p.buffer[:], stun.Request(txID))]
+		updateNetAddrFromNetAddr(p.srcAddr, net.UDPAddrFromAddrPort(host))
+		return p
+	}
+	newDP := func(enabled bool) *DataPlane {
+		dp := NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+			map[addr.SVC][]netip.AddrPort{}, addr.MustParseIA("1-ff00:0:110"), nil, testKey)
+		dp.RunConfig.STUN = enabled
+		// The mapped port of the NAT is outside the dispatched port range.
+		dp.dispatchedPortStart, dp.dispatchedPortEnd = 31000, 32767
+		return dp
+	}
+
+	t.Run("requests are answered", func(t *testing.T) {
+		dp := newDP(true)
+		txID := stun.NewTxID()
+		p := request(txID)
+		require.Equal(t, pForward, newPacketProcessor(dp).processPkt(p))
+		assert.Equal(t, uint16(0), p.egress)
+		assert.Equal(t, host, p.DstAddr.AddrPort())
+		respID, mapped, err := stun.ParseResponse(p.rawPacket)
+		require.NoError(t, err)
+		assert.Equal(t, txID, respID)
+		assert.Equal(t, host, mapped)
+
+		assert.Equal(t, host.Port(), dp.endhostPort(host.Addr(), host.Port()))
+		assert.Equal(t, uint16(topology.EndhostPort), dp.endhostPort(host.Addr(), 40001))
+		assert.Equal(t, uint16(topology.EndhostPort),
+			dp.endhostPort(netip.MustParseAddr("192.0.2.8"), host.Port()))
+		assert.Equal(t, uint16(31000), dp.endhostPort(netip.MustParseAddr("192.0.2.8"), 31000))
+	})
+	t.Run("requests are dropped if disabled", func(t *testing.T) {
+		dp := newDP(false)
+		p := request(stun.NewTxID())
+		assert.Equal(t, pDiscard, newPacketProcessor(dp).processPkt(p))
+		assert.Equal(t, uint16(topology.EndhostPort), dp.endhostPort(host.Addr(), host.Port()))
+	})
+}
+
+func TestNATBindings(t *testing.T) {
+	var b natBindings
+	now := time.Now()
+	a := netip.MustParseAddrPort("192.0.2.7:40000")
+	b.add(a, now)
+	assert.True(t, b.contains(a, now.Add(natBindingTTL)))
+	assert.False(t, b.contains(a, now.Add(natBindingTTL+time.Second)))
+	b.add(a, now.Add(natBindingTTL))
+	assert.True(t, b.contains(a, now.Add(natBindingTTL+time.Second)))
+	assert.False(t, b.contains(netip.MustParseAddrPort("192.0.2.7:40001"), now))
+}
@@ -67,3 +67,11 @@ func (s *services) Any(svc addr.SVC) (netip.AddrPort, bool) {
 	}
 	return addrs[rand.IntN(len(addrs))], true
 }
+
+// All returns the addresses of all the instances of the given service.
+func (s *services) All(svc addr.SVC) []netip.AddrPort {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return slices.Clone(s.m[svc])
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+// SetTelemetrySampleRate makes the router sample one in every rate of the packets that it
+// processes, and append its telemetry record, in the telemetry hop-by-hop option, to the sampled
+// transit packets. See doc/protocols/telemetry-option.rst. 0 disables telemetry, which is the
+// default. This can only be called before the dataplane is running.
+func (d *DataPlane) SetTelemetrySampleRate(rate uint32) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.IsRunning() {
+		return modifyExisting
+	}
+	d.telemetrySampleRate = rate
+	return nil
+}
+
+// sampleTelemetry returns whether a telemetry record is to be appended to the next packet. Each
+// processor samples the packets that it processes on its own.
+func (p *scionPacketProcessor) sampleTelemetry() bool {
+	rate := p.d.telemetrySampleRate
+	if rate == 0 {
+		return false
+	}
+	p.telemetrySeen++
+	if p.telemetrySeen < rate {
+		return false
+	}
+	p.telemetrySeen = 0
+	return true
+}
+
+// isTransit returns whether the traffic is neither from nor to the local AS.
+func (t trafficType) isTransit() bool {
+	return t == ttInTransit || t == ttOutTransit || t == ttBrTransit
+}
+
+// addTelemetry appends the telemetry record of this router to the packet, which was received at
+// the given time and is about to be sent over the given link. The record is not added if the
+// packet would become larger than the MTU of its egress interface.
+func (t *forwardingTables) addTelemetry(p *Packet, localIA addr.IA, received time.Time, link Link) {
+	maxLen := bufSize
+	if mtu, ok := t.mtus[p.egress]; ok {
+		maxLen = min(mtu, maxLen)
+	}
+	now := time.Now()
+	r := slayers.TelemetryRecord{
+		IA:            localIA,
+		Ingress:       p.ingress,
+		Egress:        p.egress,
+		IngressTime:   uint64(received.UnixNano()),
+		ResidenceTime: uint32(min(now.Sub(received), math.MaxUint32)),
+		QueueDepth:    uint16(min(link.QueueLen(), math.MaxUint16)),
+	}
+	addTelemetryRecord(p, r, maxLen)
+}
+
+// addTelemetryRecord appends the record to the telemetry option of the packet, and returns
+// whether it did. The option, and the hop-by-hop extension, are inserted if the packet does not
+// have them. The packet is left unchanged if it would become longer than maxLen, or if its
+// option is full.
+func addTelemetryRecord(p *Packet, r slayers.TelemetryRecord, maxLen int) bool {
+	raw := p.rawPacket
+	if len(raw) < slayers.CmnHdrLen {
+		return false
+	}
+	hdrLen := int(raw[5]) * slayers.LineLen
+	if hdrLen+2 > len(raw) {
+		return false
+	}
+	hasExtn := slayers.L4ProtocolType(raw[4]) == slayers.HopByHopClass
+	// The bytes are inserted at offset at. A new option starts with its header, where a new
+	// extension has its own header instead; a new option is preceded by a 2-byte PadN option
+	// instead, for its alignment.
+	at, opt, size := hdrLen, -1, 4+slayers.TelemetryRecordLen
+	if hasExtn {
+		end := hdrLen + (int(raw[hdrLen+1])+1)*slayers.LineLen
+		if end > len(raw) {
+			return false
+		}
+		at = end
+		if opt = findTelemetryOption(raw[hdrLen:end]); opt >= 0 {
+			opt += hdrLen
+			if int(raw[opt+1])+slayers.TelemetryRecordLen > math.MaxUint8 {
+				return false
+			}
+			at = opt + 2 + int(raw[opt+1])
+			size = slayers.TelemetryRecordLen
+		}
+		if int(raw[hdrLen+1])+size/slayers.LineLen > math.MaxUint8 {
+			return false
+		}
+	}
+	if len(raw)+size > min(maxLen, cap(raw)) {
+		return false
+	}
+	raw = raw[:len(raw)+size]
+	copy(raw[at+size:], raw[at:len(raw)-size])
+	switch {
+	case !hasExtn:
+		raw[at] = raw[4]
+		raw[at+1] = uint8(size/slayers.LineLen - 1)
+		raw[at+2] = uint8(slayers.OptTypeTelemetry)
+		raw[at+3] = slayers.TelemetryRecordLen
+		raw[4] = uint8(slayers.HopByHopClass)
+	case opt < 0:
+		raw[hdrLen+1] += uint8(size / slayers.LineLen)
+		raw[at], raw[at+1] = uint8(slayers.OptTypePadN), 0
+		raw[at+2] = uint8(slayers.OptTypeTelemetry)
+		raw[at+3] = slayers.TelemetryRecordLen
+	default:
+		raw[hdrLen+1] += uint8(size / slayers.LineLen)
+		raw[opt+1] += slayers.TelemetryRecordLen
+	}
+	r.SerializeTo(raw[at+size-slayers.TelemetryRecordLen:])
+	payloadLen := binary.BigEndian.Uint16(raw[6:8])
+	binary.BigEndian.PutUint16(raw[6:8], payloadLen+uint16(size))
+	p.rawPacket = raw
+	return true
+}
+
+// findTelemetryOption returns the offset of the telemetry option in the given hop-by-hop
+// extension, or -1 if there is none.
+func findTelemetryOption(extn []byte) int {
+	for offset := 2; offset < len(extn); {
+		typ := slayers.OptionType(extn[offset])
+		if typ == slayers.OptTypePad1 {
+			offset++
+			continue
+		}
+		if offset+2 > len(extn) || offset+2+int(extn[offset+1]) > len(extn) {
+			return -1
+		}
+		if typ == slayers.OptTypeTelemetry {
+			return offset
+		}
+		offset += 2 + int(extn[offset+1])
+	}
+	return -1
+}
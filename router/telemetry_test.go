@@ -0,0 +1,119 @@
+// Copyright 2026 SCION Association
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gopacket/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/pkg/addr"
+	"github.com/scionproto/scion/pkg/slayers"
+)
+
+func TestAddTelemetryRecord(t *testing.T) {
+	payload := []byte("actualpayloadbytes")
+	packet := func(hbh *slayers.HopByHopExtn) *Packet {
+		s := prepBaseMsg(t, payload, 1)
+		layers := []gopacket.SerializableLayer{s}
+		if hbh != nil {
+			hbh.NextHdr = s.NextHdr
+			s.NextHdr = slayers.HopByHopClass
+			layers = append(layers, hbh)
+		}
+		buffer := gopacket.NewSerializeBuffer()
+		require.NoError(t, gopacket.SerializeLayers(buffer,
+			gopacket.SerializeOptions{FixLengths: true},
+			append(layers, gopacket.Payload(payload))...))
+		p := new(Packet).init(&[bufSize]byte{})
+		p.reset()
+		p.rawPacket = p.buffer[:copy(p.buffer[:], buffer.Bytes())]
+		return p
+	}
+	record := func(ingress uint16) slayers.TelemetryRecord {
+		return slayers.TelemetryRecord{
+			IA:            addr.MustParseIA("1-ff00:0:110"),
+			Ingress:       ingress,
+			Egress:        2,
+			IngressTime:   1700000000000000000 + uint64(ingress),
+			ResidenceTime: 20000,
+			QueueDepth:    7,
+		}
+	}
+	// decode returns the hop-by-hop extension of the packet, after checking that the payload is
+	// intact.
+	decode := func(t *testing.T, p *Packet) *slayers.HopByHopExtn {
+		var s slayers.SCION
+		require.NoError(t, s.DecodeFromBytes(p.rawPacket, gopacket.NilDecodeFeedback))
+		require.Equal(t, slayers.HopByHopClass, s.NextHdr)
+		hbh := &slayers.HopByHopExtn{}
+		require.NoError(t, hbh.DecodeFromBytes(s.Payload, gopacket.NilDecodeFeedback))
+		assert.Equal(t, slayers.L4UDP, hbh.NextHdr)
+		assert.Equal(t, payload, hbh.Payload)
+		assert.Equal(t, int(s.PayloadLen), len(s.Payload))
+		return hbh
+	}
+	records := func(t *testing.T, hbh *slayers.HopByHopExtn) []slayers.TelemetryRecord {
+		o, err := hbh.FindOption(slayers.OptTypeTelemetry)
+		require.NoError(t, err)
+		opt, err := slayers.ParseTelemetryOption(o)
+		require.NoError(t, err)
+		var rs []slayers.TelemetryRecord
+		for i := 0; i < opt.NumRecords(); i++ {
+			rs = append(rs, opt.Record(i))
+		}
+		return rs
+	}
+
+	t.Run("records are appended", func(t *testing.T) {
+		p := packet(nil)
+		require.True(t, addTelemetryRecord(p, record(1), bufSize))
+		assert.Equal(t, []slayers.TelemetryRecord{record(1)}, records(t, decode(t, p)))
+		require.True(t, addTelemetryRecord(p, record(3), bufSize))
+		assert.Equal(t, []slayers.TelemetryRecord{record(1), record(3)},
+			records(t, decode(t, p)))
+	})
+	t.Run("existing extension", func(t *testing.T) {
+		fabrid := slayers.NewFabridOption(1, 2, make([]slayers.FabridHop, 3))
+		p := packet(&slayers.HopByHopExtn{
+			Options: []*slayers.HopByHopOption{fabrid.HopByHopOption},
+		})
+		require.True(t, addTelemetryRecord(p, record(1), bufSize))
+		hbh := decode(t, p)
+		assert.Equal(t, []slayers.TelemetryRecord{record(1)}, records(t, hbh))
+		o, err := hbh.FindOption(slayers.OptTypeFabrid)
+		require.NoError(t, err)
+		assert.Equal(t, fabrid.OptData, o.OptData)
+	})
+	t.Run("full option", func(t *testing.T) {
+		p := packet(nil)
+		for i := 0; i < slayers.MaxTelemetryRecords; i++ {
+			require.True(t, addTelemetryRecord(p, record(uint16(i)), bufSize))
+		}
+		before := bytes.Clone(p.rawPacket)
+		assert.False(t, addTelemetryRecord(p, record(100), bufSize))
+		assert.Equal(t, before, p.rawPacket)
+		assert.Len(t, records(t, decode(t, p)), slayers.MaxTelemetryRecords)
+	})
+	t.Run("larger than the MTU", func(t *testing.T) {
+		p := packet(nil)
+		before := bytes.Clone(p.rawPacket)
+		assert.False(t, addTelemetryRecord(p, record(1), len(p.rawPacket)+10))
+		assert.Equal(t, before, p.rawPacket)
+	})
+}
@@ -48,6 +48,12 @@ type Link interface {
 	Remote() netip.AddrPort // TODO(multi_underlay): using code will move to underlay.
 	Send(p *Packet) bool
 	SendBlocking(p *Packet)
+	// QueueLen returns the number of packets waiting to be sent over the link.
+	QueueLen() int
+	// PathMTU returns the largest SCION packet that the underlay currently carries to the remote
+	// end of the link without fragmentation, as discovered by the underlay. It returns 0 if the
+	// path MTU is not known.
+	PathMTU() int
 }
 
 // A provider of connectivity over some underlay implementation
@@ -99,6 +105,12 @@ type UnderlayProvider interface {
 	// matched with a link), on ingest by the underlay. That would imply moving a part of the
 	// runReceiver routine to the underlay. We will do that in the next step.
 	Link(netip.AddrPort) Link
+
+	// RemoveLink removes the link that matches the given remote address. The link's connection
+	// is removed too, unless it is shared with other links; in which case it stays. The Done
+	// channel of a removed connection is closed. The connection's BatchConn is not closed; that
+	// is the responsibility of the caller.
+	RemoveLink(netip.AddrPort)
 }
 
 // UnderlayConn defines the minimum interface that the router expects from an underlay
@@ -109,10 +121,13 @@ type UnderlayProvider interface {
 type UnderlayConn interface {
 	// Conn returns the BatchConn associated with the connection.
 	Conn() BatchConn
-	// Queue returns the channel associated with the connection.
-	Queue() <-chan *Packet
+	// Queue returns the channel of the packets of the given priority associated with the
+	// connection.
+	Queue(Priority) <-chan *Packet
 	// Name returns the name (for logging) associated with the connection.
 	Name() string
 	// IfID returns the IfID associated with the connection.
 	IfID() uint16
+	// Done returns a channel that is closed when the connection is removed from the provider.
+	Done() <-chan struct{}
 }
@@ -17,6 +17,7 @@ package underlayproviders
 import (
 	"maps"
 	"net/netip"
+	"sync"
 
 	"github.com/scionproto/scion/router"
 )
@@ -26,7 +27,10 @@ import (
 // This is currently the only implementation. The goal of splitting out this code from the router
 // is to enable other implementations. However, as a first step, we continue assuming that the
 // batchConn is given to us and is a UDP socket and that, in the case of externalLink, it is bound.
+//
+// Links can be added and removed while the router is running, so the maps are protected by mtx.
 type provider struct {
+	mtx            sync.RWMutex
 	allLinks       map[netip.AddrPort]router.Link
 	allConnections map[netip.AddrPort]*udpConnection
 }
@@ -53,6 +57,8 @@ func (u *provider) Connections() map[netip.AddrPort]router.UnderlayConn {
 	// interfaces" rule)... Brilliant, Go.
 	// Since we do not want to store our own things as interfaces, we have to translate.
 	// Good thing it doesn't happen often.
+	u.mtx.RLock()
+	defer u.mtx.RUnlock()
 	m := make(map[netip.AddrPort]router.UnderlayConn)
 	for a, c := range u.allConnections {
 		m[a] = c // Yeah that's exactly as stupid as it looks.
@@ -61,10 +67,14 @@ func (u *provider) Connections() map[netip.AddrPort]router.UnderlayConn {
 }
 
 func (u *provider) Links() map[netip.AddrPort]router.Link {
+	u.mtx.RLock()
+	defer u.mtx.RUnlock()
 	return maps.Clone(u.allLinks)
 }
 
 func (u *provider) Link(addr netip.AddrPort) router.Link {
+	u.mtx.RLock()
+	defer u.mtx.RUnlock()
 	// There is one link for every address. The internal Link catches all.
 	l, found := u.allLinks[addr]
 	if found {
@@ -73,6 +83,21 @@ func (u *provider) Link(addr netip.AddrPort) router.Link {
 	return u.allLinks[netip.AddrPort{}]
 }
 
+func (u *provider) RemoveLink(addr netip.AddrPort) {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	if _, found := u.allLinks[addr]; !found {
+		return
+	}
+	delete(u.allLinks, addr)
+	// Sibling links share the internal connection, which is keyed by the zero address. So,
+	// only external links (and the internal one) have a connection of their own.
+	if c, found := u.allConnections[addr]; found {
+		delete(u.allConnections, addr)
+		close(c.done)
+	}
+}
+
 // udpConnection is simply the combination of a BatchConn and sending queue (plus metadata for
 // logs and such). This allows UDP connections to be shared between links. Bundling link and
 // connection together is possible and simpler for the code here, but leaks more refactoring changes
@@ -82,10 +107,11 @@ func (u *provider) Link(addr netip.AddrPort) router.Link {
 //   - the internal links and sibling links would be the same, which means the router needs to
 //     special case the sibling links: which we want to remove from the main code.
 type udpConnection struct {
-	conn  router.BatchConn
-	queue chan *router.Packet
-	ifID  uint16 // for metrics. All sibling links plus the internal link will be zero, though.
-	name  string // for logs. It's more informative than ifID.
+	conn   router.BatchConn
+	queues queues
+	ifID   uint16 // for metrics. All sibling links plus the internal link will be zero, though.
+	name   string // for logs. It's more informative than ifID.
+	done   chan struct{}
 }
 
 // TODO(multi_underlay): The following implements UnderlayConn so some of the code
@@ -96,8 +122,8 @@ func (u *udpConnection) Conn() router.BatchConn {
 	return u.conn
 }
 
-func (u *udpConnection) Queue() <-chan *router.Packet {
-	return u.queue
+func (u *udpConnection) Queue(prio router.Priority) <-chan *router.Packet {
+	return u.queues[prio]
 }
 
 func (u *udpConnection) Name() string {
@@ -108,20 +134,64 @@ func (u *udpConnection) IfID() uint16 {
 	return u.ifID
 }
 
+func (u *udpConnection) Done() <-chan struct{} {
+	return u.done
+}
+
+// queues holds the sending queues of a connection, one for each packet priority.
+type queues [router.NumPriorities]chan *router.Packet
+
+func newQueues(qSize int) queues {
+	var q queues
+	for i := range q {
+		q[i] = make(chan *router.Packet, qSize)
+	}
+	return q
+}
+
+// send queues the packet according to its priority. It returns false if the queue is full.
+func (q *queues) send(p *router.Packet) bool {
+	select {
+	case q[p.Priority()] <- p:
+	default:
+		return false
+	}
+	return true
+}
+
+func (q *queues) sendBlocking(p *router.Packet) {
+	q[p.Priority()] <- p
+}
+
+// len returns the number of packets waiting in the queues.
+func (q *queues) len() int {
+	n := 0
+	for _, c := range q {
+		n += len(c)
+	}
+	return n
+}
+
 // todo(jiceatscion): use inheritance between implementations?
 
 type externalLink struct {
-	queue      chan<- *router.Packet
+	conn       router.BatchConn
+	queues     queues
 	bfdSession router.BFDSession
 	ifID       uint16
 	remote     netip.AddrPort // We keep this only for Remote()
 }
 
+// pathMTUConn is a connection that discovers the path MTU to its remote address.
+type pathMTUConn interface {
+	PathMTU() (int, error)
+}
+
 // NewExternalLink returns an external link over the UdpIpUnderlay.
 //
 // TODO(multi_underlay): we get the connection ready-made and require it to be bound. So, we
-// don't keep the remote address, but in the future, we will be making the connections, and
-// the conn argument will be gone.
+// only keep the remote address for Remote(), but in the future, we will be making the
+// connections, and the conn argument will be gone.
 func (u *provider) NewExternalLink(
 	conn router.BatchConn,
 	qSize int,
@@ -130,18 +200,23 @@ func (u *provider) NewExternalLink(
 	ifID uint16,
 ) router.Link {
 
-	queue := make(chan *router.Packet, qSize)
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	queues := newQueues(qSize)
 	c := &udpConnection{
-		conn:  conn,
-		queue: queue,
-		ifID:  ifID,
-		name:  remote.String(),
+		conn:   conn,
+		queues: queues,
+		ifID:   ifID,
+		name:   remote.String(),
+		done:   make(chan struct{}),
 	}
 	u.allConnections[remote] = c
 	l := &externalLink{
-		queue:      queue,
+		conn:       conn,
+		queues:     queues,
 		bfdSession: bfd,
 		ifID:       ifID,
+		remote:     remote,
 	}
 	u.allLinks[remote] = l
 	return l
@@ -168,20 +243,31 @@ func (l *externalLink) Remote() netip.AddrPort {
 }
 
 func (l *externalLink) Send(p *router.Packet) bool {
-	select {
-	case l.queue <- p:
-	default:
-		return false
-	}
-	return true
+	return l.queues.send(p)
 }
 
 func (l *externalLink) SendBlocking(p *router.Packet) {
-	l.queue <- p
+	l.queues.sendBlocking(p)
+}
+
+func (l *externalLink) QueueLen() int {
+	return l.queues.len()
+}
+
+func (l *externalLink) PathMTU() int {
+	c, ok := l.conn.(pathMTUConn)
+	if !ok {
+		return 0
+	}
+	mtu, err := c.PathMTU()
+	if err != nil {
+		return 0
+	}
+	return mtu
 }
 
 type siblingLink struct {
-	queue      chan<- *router.Packet
+	queues     queues
 	bfdSession router.BFDSession
 	remote     netip.AddrPort
 }
@@ -202,6 +288,8 @@ type siblingLink struct {
 func (u *provider) NewSiblingLink(
 	qSize int, bfd router.BFDSession, remote netip.AddrPort) router.Link {
 
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
 	// There is exactly one sibling link per sibling router address.
 	l, exists := u.allLinks[remote]
 	if exists {
@@ -219,7 +307,7 @@ func (u *provider) NewSiblingLink(
 	}
 
 	s := &siblingLink{
-		queue:      c.queue,
+		queues:     c.queues,
 		bfdSession: bfd,
 		remote:     remote,
 	}
@@ -251,23 +339,26 @@ func (l *siblingLink) Send(p *router.Packet) bool {
 	// We use an unbound connection but we offer a connection-oriented service. So, we need to
 	// supply the packet's destination address.
 	router.UpdateNetAddrFromAddrPort(p.DstAddr, l.remote)
-	select {
-	case l.queue <- p:
-	default:
-		return false
-	}
-	return true
+	return l.queues.send(p)
 }
 
 func (l *siblingLink) SendBlocking(p *router.Packet) {
 	// We use an unbound connection but we offer a connection-oriented service. So, we need to
 	// supply the packet's destination address.
 	router.UpdateNetAddrFromAddrPort(p.DstAddr, l.remote)
-	l.queue <- p
+	l.queues.sendBlocking(p)
+}
+
+func (l *siblingLink) QueueLen() int {
+	return l.queues.len()
+}
+
+func (l *siblingLink) PathMTU() int {
+	return 0
 }
 
 type internalLink struct {
-	queue chan *router.Packet
+	queues queues
 }
 
 // newSiblingLink returns a sibling link over the UdpIpUnderlay.
@@ -275,16 +366,19 @@ type internalLink struct {
 // TODO(multi_underlay): we get the connection ready made. In the future we will be making it
 // and the conn argument will be gone.
 func (u *provider) NewInternalLink(conn router.BatchConn, qSize int) router.Link {
-	queue := make(chan *router.Packet, qSize)
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	queues := newQueues(qSize)
 	c := &udpConnection{
-		conn:  conn,
-		queue: queue,
-		name:  "internal",
-		ifID:  0,
+		conn:   conn,
+		queues: queues,
+		name:   "internal",
+		ifID:   0,
+		done:   make(chan struct{}),
 	}
 	u.allConnections[netip.AddrPort{}] = c
 	l := &internalLink{
-		queue: queue,
+		queues: queues,
 	}
 	u.allLinks[netip.AddrPort{}] = l
 	return l
@@ -312,15 +406,18 @@ func (l *internalLink) Remote() netip.AddrPort {
 
 // The packet's destination is already in the packet's meta-data.
 func (l *internalLink) Send(p *router.Packet) bool {
-	select {
-	case l.queue <- p:
-	default:
-		return false
-	}
-	return true
+	return l.queues.send(p)
 }
 
 // The packet's destination is already in the packet's meta-data.
 func (l *internalLink) SendBlocking(p *router.Packet) {
-	l.queue <- p
+	l.queues.sendBlocking(p)
+}
+
+func (l *internalLink) QueueLen() int {
+	return l.queues.len()
+}
+
+func (l *internalLink) PathMTU() int {
+	return 0
 }